@@ -39,16 +39,24 @@ func init() {
 // Adds the list of known types to Scheme.
 func addKnownTypes(scheme *runtime.Scheme) error {
 	scheme.AddKnownTypes(SchemeGroupVersion,
+		&Addon{},
+		&AddonList{},
 		&Environment{},
 		&EnvironmentList{},
 		&EnvironmentRoleBinding{},
 		&EnvironmentRoleBindingList{},
 		&GitService{},
 		&GitServiceList{},
+		&HookFailure{},
+		&HookFailureList{},
 		&PipelineActivity{},
 		&PipelineActivityList{},
+		&Promotion{},
+		&PromotionList{},
 		&Release{},
 		&ReleaseList{},
+		&SourceRepository{},
+		&SourceRepositoryList{},
 		&Team{},
 		&TeamList{},
 		&User{},
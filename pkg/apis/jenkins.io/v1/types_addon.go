@@ -0,0 +1,68 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// Addon represents an optional component of the platform installed as a chart along with the state of its lifecycle
+type Addon struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   AddonSpec   `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	Status AddonStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// AddonSpec is the chart, version and values the addon is installed with
+type AddonSpec struct {
+	Chart       string   `json:"chart,omitempty" protobuf:"bytes,1,opt,name=chart"`
+	Version     string   `json:"version,omitempty" protobuf:"bytes,2,opt,name=version"`
+	ReleaseName string   `json:"releaseName,omitempty" protobuf:"bytes,3,opt,name=releaseName"`
+	Namespace   string   `json:"namespace,omitempty" protobuf:"bytes,4,opt,name=namespace"`
+	Values      []string `json:"values,omitempty" protobuf:"bytes,5,rep,name=values"`
+}
+
+// AddonStatus is the lifecycle state of an addon
+type AddonStatus struct {
+	Phase   AddonPhase `json:"phase,omitempty"`
+	Message string     `json:"message,omitempty"`
+	// Version the version of the chart which is currently installed
+	Version string `json:"version,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// AddonList is a list of Addon resources
+type AddonList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Addon `json:"items"`
+}
+
+// AddonPhase is the phase of the lifecycle of an Addon
+type AddonPhase string
+
+const (
+	// AddonPhaseNone the addon has not been installed yet
+	AddonPhaseNone AddonPhase = ""
+
+	// AddonPhaseInstalling the chart of the addon is being installed or upgraded
+	AddonPhaseInstalling AddonPhase = "Installing"
+
+	// AddonPhaseInstalled the addon has been installed and configured
+	AddonPhaseInstalled AddonPhase = "Installed"
+
+	// AddonPhaseFailed a lifecycle hook or the chart of the addon failed
+	AddonPhaseFailed AddonPhase = "Failed"
+
+	// AddonPhaseRemoving the addon is being removed
+	AddonPhaseRemoving AddonPhase = "Removing"
+)
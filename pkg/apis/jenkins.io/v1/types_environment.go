@@ -2,6 +2,7 @@ package v1
 
 import (
 	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -36,6 +37,8 @@ type EnvironmentSpec struct {
 	TeamSettings      TeamSettings          `json:"teamSettings,omitempty" protobuf:"bytes,9,opt,name=teamSettings"`
 	PreviewGitSpec    PreviewGitSpec        `json:"previewGitInfo,omitempty" protobuf:"bytes,10,opt,name=previewGitInfo"`
 	WebHookEngine     WebHookEngineType     `json:"webHookEngine,omitempty" protobuf:"bytes,11,opt,name=webHookEngine"`
+	PromotionGates    *PromotionGates       `json:"promotionGates,omitempty" protobuf:"bytes,12,opt,name=promotionGates"`
+	Lock              *EnvironmentLock      `json:"lock,omitempty" protobuf:"bytes,13,opt,name=lock"`
 }
 
 // EnvironmentStatus is the status for an Environment resource
@@ -81,6 +84,44 @@ const (
 	EnvironmentKindTypeDevelopment EnvironmentKindType = "Development"
 )
 
+// PromotionGates the conditions which must be met before a promotion to an environment is merged
+type PromotionGates struct {
+	// RequiredApprovals the number of approving reviews the promotion Pull Request needs
+	RequiredApprovals int `json:"requiredApprovals,omitempty" protobuf:"bytes,1,opt,name=requiredApprovals"`
+	// Approvers the users whose reviews or ChatOps commands count as approvals. If empty any user other than the author can approve
+	Approvers []string `json:"approvers,omitempty" protobuf:"bytes,2,opt,name=approvers"`
+	// ChatOps requires an approver to comment '/approve promote' on the promotion Pull Request
+	ChatOps bool `json:"chatOps,omitempty" protobuf:"bytes,3,opt,name=chatOps"`
+	// Windows the time windows in which promotions can happen. If empty promotions can happen at any time
+	Windows []PromotionWindow `json:"windows,omitempty" protobuf:"bytes,4,opt,name=windows"`
+}
+
+// PromotionWindow a recurring time window in which promotions are allowed
+type PromotionWindow struct {
+	// Days the abbreviated week days such as Mon or Fri. If empty every day is allowed
+	Days []string `json:"days,omitempty" protobuf:"bytes,1,opt,name=days"`
+	// Start the start time of the window in 24 hour HH:MM format
+	Start string `json:"start,omitempty" protobuf:"bytes,2,opt,name=start"`
+	// End the end time of the window in 24 hour HH:MM format
+	End string `json:"end,omitempty" protobuf:"bytes,3,opt,name=end"`
+	// TimeZone the IANA time zone of the window such as Europe/London. Defaults to UTC
+	TimeZone string `json:"timeZone,omitempty" protobuf:"bytes,4,opt,name=timeZone"`
+}
+
+// EnvironmentLock locks an environment such as during a release freeze so that promotions to it are queued or rejected
+type EnvironmentLock struct {
+	// Reason describes why the environment is locked
+	Reason string `json:"reason,omitempty" protobuf:"bytes,1,opt,name=reason"`
+	// LockedBy the user who locked the environment
+	LockedBy string `json:"lockedBy,omitempty" protobuf:"bytes,2,opt,name=lockedBy"`
+	// LockedTimestamp when the environment was locked
+	LockedTimestamp *metav1.Time `json:"lockedTimestamp,omitempty" protobuf:"bytes,3,opt,name=lockedTimestamp"`
+	// Expires when the lock expires. If empty the environment stays locked until it is unlocked
+	Expires *metav1.Time `json:"expires,omitempty" protobuf:"bytes,4,opt,name=expires"`
+	// Queue makes promotions wait for the lock to be released rather than rejecting them
+	Queue bool `json:"queue,omitempty" protobuf:"bytes,5,opt,name=queue"`
+}
+
 // PromotionEngineType is the type of promotion implementation the team uses
 type PromotionEngineType string
 
@@ -89,6 +130,16 @@ const (
 	PromotionEngineProw    PromotionEngineType = "Prow"
 )
 
+// DeployKindType is how the apps of the team are deployed by their charts
+type DeployKindType string
+
+const (
+	// DeployKindDefault apps are deployed as a Deployment and Service
+	DeployKindDefault DeployKindType = ""
+	// DeployKindKnative apps are deployed as Knative Services which scale to zero when idle
+	DeployKindKnative DeployKindType = "knative"
+)
+
 // WebHookEngineType is the type of webhook processing implementation the team uses
 type WebHookEngineType string
 
@@ -96,6 +147,8 @@ const (
 	WebHookEngineNone    WebHookEngineType = ""
 	WebHookEngineJenkins WebHookEngineType = "Jenkins"
 	WebHookEngineProw    WebHookEngineType = "Prow"
+	// WebHookEngineLighthouse Lighthouse handles the webhooks and ChatOps instead of the full Prow install
+	WebHookEngineLighthouse WebHookEngineType = "Lighthouse"
 )
 
 // IsPermanent returns true if this environment is permanent
@@ -123,26 +176,159 @@ const (
 	EnvironmentRepositoryTypeGit EnvironmentRepositoryType = "Git"
 )
 
+// EnvironmentRepositoryLayoutType is how the releases of an environment are described in its repository
+type EnvironmentRepositoryLayoutType string
+
+const (
+	// EnvironmentRepositoryLayoutChart the releases are the dependencies of a helm chart
+	EnvironmentRepositoryLayoutChart EnvironmentRepositoryLayoutType = ""
+
+	// EnvironmentRepositoryLayoutHelmfile the releases are described by a helmfile.yaml and applied via helmfile
+	EnvironmentRepositoryLayoutHelmfile EnvironmentRepositoryLayoutType = "Helmfile"
+)
+
 // EnvironmentRepository is the repository for an environment using GitOps
 type EnvironmentRepository struct {
-	Kind EnvironmentRepositoryType `json:"kind,omitempty" protobuf:"bytes,1,opt,name=kind"`
-	URL  string                    `json:"url,omitempty" protobuf:"bytes,2,opt,name=url"`
-	Ref  string                    `json:"ref,omitempty" protobuf:"bytes,3,opt,name=ref"`
+	Kind   EnvironmentRepositoryType       `json:"kind,omitempty" protobuf:"bytes,1,opt,name=kind"`
+	URL    string                          `json:"url,omitempty" protobuf:"bytes,2,opt,name=url"`
+	Ref    string                          `json:"ref,omitempty" protobuf:"bytes,3,opt,name=ref"`
+	Layout EnvironmentRepositoryLayoutType `json:"layout,omitempty" protobuf:"bytes,4,opt,name=layout"`
 }
 
 // TeamSettings the default settings for a team
 type TeamSettings struct {
-	UseGitOPs           bool                 `json:"useGitOps,omitempty" protobuf:"bytes,1,opt,name=useGitOps"`
-	AskOnCreate         bool                 `json:"askOnCreate,omitempty" protobuf:"bytes,2,opt,name=askOnCreate"`
-	BranchPatterns      string               `json:"branchPatterns,omitempty" protobuf:"bytes,3,opt,name=branchPatterns"`
-	ForkBranchPatterns  string               `json:"forkBranchPatterns,omitempty" protobuf:"bytes,4,opt,name=forkBranchPatterns"`
-	QuickstartLocations []QuickStartLocation `json:"quickstartLocations,omitempty" protobuf:"bytes,5,opt,name=quickstartLocations"`
-	BuildPackURL        string               `json:"buildPackUrl,omitempty" protobuf:"bytes,6,opt,name=buildPackUrl"`
-	BuildPackRef        string               `json:"buildPackRef,omitempty" protobuf:"bytes,7,opt,name=buildPackRef"`
-	HelmBinary          string               `json:"helmBinary,omitempty" protobuf:"bytes,8,opt,name=helmBinary"`
-	PostPreviewJobs     []batchv1.Job        `json:"postPreviewJobs,omitempty" protobuf:"bytes,9,opt,name=postPreviewJobs"`
-	PromotionEngine     PromotionEngineType  `json:"promotionEngine,omitempty" protobuf:"bytes,10,opt,name=promotionEngine"`
-	NoTiller            bool                 `json:"noTiller,omitempty" protobuf:"bytes,11,opt,name=noTiller"`
+	UseGitOPs           bool                    `json:"useGitOps,omitempty" protobuf:"bytes,1,opt,name=useGitOps"`
+	AskOnCreate         bool                    `json:"askOnCreate,omitempty" protobuf:"bytes,2,opt,name=askOnCreate"`
+	BranchPatterns      string                  `json:"branchPatterns,omitempty" protobuf:"bytes,3,opt,name=branchPatterns"`
+	ForkBranchPatterns  string                  `json:"forkBranchPatterns,omitempty" protobuf:"bytes,4,opt,name=forkBranchPatterns"`
+	QuickstartLocations []QuickStartLocation    `json:"quickstartLocations,omitempty" protobuf:"bytes,5,opt,name=quickstartLocations"`
+	BuildPackURL        string                  `json:"buildPackUrl,omitempty" protobuf:"bytes,6,opt,name=buildPackUrl"`
+	BuildPackRef        string                  `json:"buildPackRef,omitempty" protobuf:"bytes,7,opt,name=buildPackRef"`
+	HelmBinary          string                  `json:"helmBinary,omitempty" protobuf:"bytes,8,opt,name=helmBinary"`
+	PostPreviewJobs     []batchv1.Job           `json:"postPreviewJobs,omitempty" protobuf:"bytes,9,opt,name=postPreviewJobs"`
+	PromotionEngine     PromotionEngineType     `json:"promotionEngine,omitempty" protobuf:"bytes,10,opt,name=promotionEngine"`
+	NoTiller            bool                    `json:"noTiller,omitempty" protobuf:"bytes,11,opt,name=noTiller"`
+	BuildCaches         []BuildCache            `json:"buildCaches,omitempty" protobuf:"bytes,12,opt,name=buildCaches"`
+	SecretScanner       string                  `json:"secretScanner,omitempty" protobuf:"bytes,13,opt,name=secretScanner"`
+	AuditWebhookURL     string                  `json:"auditWebhookUrl,omitempty" protobuf:"bytes,14,opt,name=auditWebhookUrl"`
+	PolicyGitURL        string                  `json:"policyGitUrl,omitempty" protobuf:"bytes,15,opt,name=policyGitUrl"`
+	VulnerabilityPolicy *VulnerabilityPolicy    `json:"vulnerabilityPolicy,omitempty" protobuf:"bytes,16,opt,name=vulnerabilityPolicy"`
+	AutoscalingDefaults *AutoscalingDefaults    `json:"autoscalingDefaults,omitempty" protobuf:"bytes,17,opt,name=autoscalingDefaults"`
+	SpotBuilds          *SpotBuilds             `json:"spotBuilds,omitempty" protobuf:"bytes,18,opt,name=spotBuilds"`
+	BuildPods           []BuildPodCustomization `json:"buildPods,omitempty" protobuf:"bytes,19,rep,name=buildPods"`
+	TillerNamespace     string                  `json:"tillerNamespace,omitempty" protobuf:"bytes,20,opt,name=tillerNamespace"`
+	TillerTLS           bool                    `json:"tillerTls,omitempty" protobuf:"bytes,21,opt,name=tillerTls"`
+	DockerRegistryOrg   string                  `json:"dockerRegistryOrg,omitempty" protobuf:"bytes,22,opt,name=dockerRegistryOrg"`
+	DeployKind          DeployKindType          `json:"deployKind,omitempty" protobuf:"bytes,23,opt,name=deployKind"`
+	ActivityRetention   *ActivityRetention      `json:"activityRetention,omitempty" protobuf:"bytes,24,opt,name=activityRetention"`
+	// Experiments the names of the experimental features enabled for the team
+	Experiments []string `json:"experiments,omitempty" protobuf:"bytes,25,rep,name=experiments"`
+	// Exposure the exposure policies of the platform services of the team such as Deck or Octant
+	Exposure []ServiceExposure `json:"exposure,omitempty" protobuf:"bytes,26,rep,name=exposure"`
+	// CABundle the PEM encoded certificate authorities of the self-hosted git servers, registries and Jenkins of the
+	// team which are trusted by jx, the build pods and helm
+	CABundle string `json:"caBundle,omitempty" protobuf:"bytes,27,opt,name=caBundle"`
+}
+
+// ExposurePolicyType how a platform service is exposed outside of the cluster
+type ExposurePolicyType string
+
+const (
+	// ExposurePolicyPublic the service is exposed publicly via an Ingress
+	ExposurePolicyPublic ExposurePolicyType = "public"
+	// ExposurePolicyOAuth the service is exposed via an Ingress which requires a login via the OAuth2 proxy
+	ExposurePolicyOAuth ExposurePolicyType = "oauth"
+	// ExposurePolicyInternal the service is not exposed and can only be accessed via a port forward
+	ExposurePolicyInternal ExposurePolicyType = "internal"
+)
+
+// ExposurePolicyTypeValues the valid exposure policies
+var ExposurePolicyTypeValues = []string{string(ExposurePolicyPublic), string(ExposurePolicyOAuth), string(ExposurePolicyInternal)}
+
+// ServiceExposure the exposure policy of a platform service
+type ServiceExposure struct {
+	// Service the name of the Service in the dev namespace
+	Service string `json:"service" protobuf:"bytes,1,opt,name=service"`
+	// Policy how the service is exposed
+	Policy ExposurePolicyType `json:"policy" protobuf:"bytes,2,opt,name=policy,casttype=ExposurePolicyType"`
+}
+
+// ActivityRetention the policy used to garbage collect the PipelineActivity resources of a team. Activities referenced
+// by a Release and activities which are still running are never removed
+type ActivityRetention struct {
+	// KeepPerBranch the number of the most recent activities kept for each branch of a repository. All are kept if zero
+	KeepPerBranch int `json:"keepPerBranch,omitempty" protobuf:"varint,1,opt,name=keepPerBranch"`
+	// MaxAgeDays the number of days after which activities are removed. Activities are kept regardless of age if zero
+	MaxAgeDays int `json:"maxAgeDays,omitempty" protobuf:"varint,2,opt,name=maxAgeDays"`
+}
+
+// BuildPodCustomization the customization of the build pod templates of a team
+type BuildPodCustomization struct {
+	// PodTemplate the name of the pod template to customize such as maven. All pod templates are customized if blank
+	PodTemplate string `json:"podTemplate,omitempty" protobuf:"bytes,1,opt,name=podTemplate"`
+	// Image the image of the builder container
+	Image string `json:"image,omitempty" protobuf:"bytes,2,opt,name=image"`
+	// Resources the resource requests and limits of the builder container
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty" protobuf:"bytes,3,opt,name=resources"`
+	// ServiceAccountName the service account the build pod runs as
+	ServiceAccountName string `json:"serviceAccountName,omitempty" protobuf:"bytes,4,opt,name=serviceAccountName"`
+	// Env the environment variables added to the builder container
+	Env []corev1.EnvVar `json:"env,omitempty" protobuf:"bytes,5,rep,name=env"`
+	// Volumes the volumes added to the build pod
+	Volumes []corev1.Volume `json:"volumes,omitempty" protobuf:"bytes,6,rep,name=volumes"`
+	// VolumeMounts the volume mounts added to the builder container
+	VolumeMounts []corev1.VolumeMount `json:"volumeMounts,omitempty" protobuf:"bytes,7,rep,name=volumeMounts"`
+	// Sidecars the containers such as a docker daemon or buildkit added to the build pod
+	Sidecars []corev1.Container `json:"sidecars,omitempty" protobuf:"bytes,8,rep,name=sidecars"`
+}
+
+// SpotBuilds the settings used to run the build pods of a team on spot or preemptible nodes
+type SpotBuilds struct {
+	// NodeSelector the labels of the spot or preemptible nodes which build pods are scheduled onto
+	NodeSelector map[string]string `json:"nodeSelector,omitempty" protobuf:"bytes,1,rep,name=nodeSelector"`
+	// Tolerations the tolerations of the taints of the spot or preemptible nodes
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty" protobuf:"bytes,2,rep,name=tolerations"`
+	// MaxRetries the number of times a build whose pod was evicted by the preemption of its node is retried
+	MaxRetries int `json:"maxRetries,omitempty" protobuf:"varint,3,opt,name=maxRetries"`
+}
+
+// AutoscalingDefaults the HorizontalPodAutoscaler settings added to apps when they are first promoted to an environment
+type AutoscalingDefaults struct {
+	// MinReplicas the minimum number of replicas
+	MinReplicas int32 `json:"minReplicas,omitempty" protobuf:"varint,1,opt,name=minReplicas"`
+	// MaxReplicas the maximum number of replicas
+	MaxReplicas int32 `json:"maxReplicas,omitempty" protobuf:"varint,2,opt,name=maxReplicas"`
+	// TargetCPUUtilization the target average CPU utilization as a percentage of the requested CPU
+	TargetCPUUtilization int32 `json:"targetCPUUtilization,omitempty" protobuf:"varint,3,opt,name=targetCPUUtilization"`
+	// TargetMemoryUtilization the target average memory utilization as a percentage of the requested memory
+	TargetMemoryUtilization int32 `json:"targetMemoryUtilization,omitempty" protobuf:"varint,4,opt,name=targetMemoryUtilization"`
+	// Environments the names of the environments the defaults apply to. Defaults to all permanent environments
+	Environments []string `json:"environments,omitempty" protobuf:"bytes,5,rep,name=environments"`
+}
+
+// VulnerabilityPolicy the policy used by a team to block the promotion of images with known vulnerabilities
+type VulnerabilityPolicy struct {
+	// Scanner the scanner used to find the vulnerabilities of an image. Defaults to trivy
+	Scanner string `json:"scanner,omitempty" protobuf:"bytes,1,opt,name=scanner"`
+	// Thresholds the maximum number of vulnerabilities of each severity such as CRITICAL or HIGH. Severities
+	// without a threshold do not block promotion
+	Thresholds map[string]int `json:"thresholds,omitempty" protobuf:"bytes,2,rep,name=thresholds"`
+	// Environments the names of the environments the policy applies to. Defaults to production
+	Environments []string `json:"environments,omitempty" protobuf:"bytes,3,rep,name=environments"`
+	// IgnoreUnfixed ignores vulnerabilities which have no fixed version available
+	IgnoreUnfixed bool `json:"ignoreUnfixed,omitempty" protobuf:"bytes,4,opt,name=ignoreUnfixed"`
+}
+
+// BuildCache a dependency cache shared by the build pods of a team
+type BuildCache struct {
+	// Kind the kind of dependencies cached such as maven, gradle or npm
+	Kind string `json:"kind,omitempty" protobuf:"bytes,1,opt,name=kind"`
+	// StorageSize the size of the PersistentVolumeClaim used to store the cache
+	StorageSize string `json:"storageSize,omitempty" protobuf:"bytes,2,opt,name=storageSize"`
+	// StorageClass the optional storage class of the PersistentVolumeClaim
+	StorageClass string `json:"storageClass,omitempty" protobuf:"bytes,3,opt,name=storageClass"`
+	// RegistryURL the optional URL of a caching registry or repository manager used to download dependencies
+	RegistryURL string `json:"registryUrl,omitempty" protobuf:"bytes,4,opt,name=registryUrl"`
 }
 
 // QuickStartLocation
@@ -152,6 +338,11 @@ type QuickStartLocation struct {
 	Owner    string   `json:"owner,omitempty" protobuf:"bytes,3,opt,name=owner"`
 	Includes []string `json:"includes,omitempty" protobuf:"bytes,4,opt,name=includes"`
 	Excludes []string `json:"excludes,omitempty" protobuf:"bytes,5,opt,name=excludes"`
+
+	// CatalogURL the optional URL of a quickstart catalog index to use rather than listing the owner's repositories
+	CatalogURL string `json:"catalogUrl,omitempty" protobuf:"bytes,6,opt,name=catalogUrl"`
+	// CatalogPublicKey the optional PEM encoded public key used to verify the signature of the catalog index
+	CatalogPublicKey string `json:"catalogPublicKey,omitempty" protobuf:"bytes,7,opt,name=catalogPublicKey"`
 }
 
 // PreviewGitSpec is the preview git branch/pull request details
@@ -0,0 +1,84 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// HookFailure represents a webhook delivery from a git provider which could not be delivered to the webhook engine
+// of the team so that it can be inspected and replayed once the webhook engine recovers
+type HookFailure struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   HookFailureSpec   `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	Status HookFailureStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// HookFailureSpec is the webhook delivery which failed and why it failed
+type HookFailureSpec struct {
+	// Event the kind of webhook event such as push or pull_request
+	Event string `json:"event,omitempty" protobuf:"bytes,1,opt,name=event"`
+	// DeliveryID the identifier the git provider gave the delivery
+	DeliveryID string `json:"deliveryID,omitempty" protobuf:"bytes,2,opt,name=deliveryID"`
+	// Repository the owner/repo of the git repository the event is about
+	Repository string `json:"repository,omitempty" protobuf:"bytes,3,opt,name=repository"`
+	// Headers the HTTP headers of the delivery
+	Headers map[string]string `json:"headers,omitempty" protobuf:"bytes,4,rep,name=headers"`
+	// Payload the body of the delivery
+	Payload string            `json:"payload,omitempty" protobuf:"bytes,5,opt,name=payload"`
+	Reason  HookFailureReason `json:"reason,omitempty" protobuf:"bytes,6,opt,name=reason"`
+	Message string            `json:"message,omitempty" protobuf:"bytes,7,opt,name=message"`
+	// ReceivedTimestamp when the delivery was received from the git provider
+	ReceivedTimestamp *metav1.Time `json:"receivedTimestamp,omitempty" protobuf:"bytes,8,opt,name=receivedTimestamp"`
+}
+
+// HookFailureStatus is the state of replaying a failed webhook delivery
+type HookFailureStatus struct {
+	Phase    HookFailurePhase `json:"phase,omitempty"`
+	Attempts int              `json:"attempts,omitempty"`
+	Message  string           `json:"message,omitempty"`
+	// LastAttemptTimestamp when the delivery was last replayed
+	LastAttemptTimestamp *metav1.Time `json:"lastAttemptTimestamp,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// HookFailureList is a list of HookFailure resources
+type HookFailureList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []HookFailure `json:"items"`
+}
+
+// HookFailureReason is why a webhook delivery failed
+type HookFailureReason string
+
+const (
+	// HookFailureReasonUnavailable the webhook engine could not be reached or returned a server error
+	HookFailureReasonUnavailable HookFailureReason = "Unavailable"
+
+	// HookFailureReasonHMACMismatch the signature of the delivery did not match the hmac token of the team
+	HookFailureReasonHMACMismatch HookFailureReason = "HMACMismatch"
+
+	// HookFailureReasonRejected the webhook engine rejected the delivery
+	HookFailureReasonRejected HookFailureReason = "Rejected"
+)
+
+// HookFailurePhase is the phase of replaying a failed webhook delivery
+type HookFailurePhase string
+
+const (
+	// HookFailurePhasePending the delivery has not been replayed successfully yet
+	HookFailurePhasePending HookFailurePhase = "Pending"
+
+	// HookFailurePhaseReplayed the delivery was replayed successfully
+	HookFailurePhaseReplayed HookFailurePhase = "Replayed"
+)
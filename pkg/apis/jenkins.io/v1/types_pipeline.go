@@ -87,6 +87,7 @@ type PromoteActivityStep struct {
 	PullRequest    *PromotePullRequestStep `json:"pullRequest,omitempty" protobuf:"bytes,2,opt,name=pullRequest"`
 	Update         *PromoteUpdateStep      `json:"update,omitempty" protobuf:"bytes,3,opt,name=update"`
 	ApplicationURL string                  `json:"applicationURL,omitempty" protobuf:"bytes,4,opt,name=environment"`
+	SmokeTest      *PromoteSmokeTestStep   `json:"smokeTest,omitempty" protobuf:"bytes,5,opt,name=smokeTest"`
 }
 
 // GitStatus the status of a git commit in terms of CI/CD
@@ -111,6 +112,23 @@ type PromoteUpdateStep struct {
 	Statuses []GitStatus `json:"statuses,omitempty" protobuf:"bytes,1,opt,name=statuses"`
 }
 
+// PromoteSmokeTestStep is the step for running the smoke tests against a version after it is promoted to an
+// environment and rolling back the promotion if they fail
+type PromoteSmokeTestStep struct {
+	CoreActivityStep
+
+	Job             string            `json:"job,omitempty" protobuf:"bytes,1,opt,name=job"`
+	Results         []SmokeTestResult `json:"results,omitempty" protobuf:"bytes,2,opt,name=results"`
+	RollbackVersion string            `json:"rollbackVersion,omitempty" protobuf:"bytes,3,opt,name=rollbackVersion"`
+}
+
+// SmokeTestResult the result of a smoke test
+type SmokeTestResult struct {
+	Name    string             `json:"name,omitempty" protobuf:"bytes,1,opt,name=name"`
+	Status  ActivityStatusType `json:"status,omitempty" protobuf:"bytes,2,opt,name=status"`
+	Message string             `json:"message,omitempty" protobuf:"bytes,3,opt,name=message"`
+}
+
 // PipelineActivityStatus is the status for an Environment resource
 type PipelineActivityStatus struct {
 	Version string `json:"version,omitempty"  protobuf:"bytes,1,opt,name=version"`
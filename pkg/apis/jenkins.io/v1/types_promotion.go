@@ -0,0 +1,83 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// Promotion represents a promotion of a version of an app to an environment waiting in the promotion queue of the
+// environment so that promotions to the same environment are serialized
+type Promotion struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   PromotionSpec   `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+	Status PromotionStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// PromotionSpec is the app version being promoted and the pipeline promoting it
+type PromotionSpec struct {
+	Environment       string `json:"environment,omitempty" protobuf:"bytes,1,opt,name=environment"`
+	Application       string `json:"application,omitempty" protobuf:"bytes,2,opt,name=application"`
+	Version           string `json:"version,omitempty" protobuf:"bytes,3,opt,name=version"`
+	Alias             string `json:"alias,omitempty" protobuf:"bytes,4,opt,name=alias"`
+	HelmRepositoryURL string `json:"helmRepositoryURL,omitempty" protobuf:"bytes,5,opt,name=helmRepositoryURL"`
+	Pipeline          string `json:"pipeline,omitempty" protobuf:"bytes,6,opt,name=pipeline"`
+	Build             string `json:"build,omitempty" protobuf:"bytes,7,opt,name=build"`
+	// QueuedTimestamp when the promotion joined the queue which determines its position in the queue
+	QueuedTimestamp *metav1.Time `json:"queuedTimestamp,omitempty" protobuf:"bytes,8,opt,name=queuedTimestamp"`
+	// Expires when the promotion is no longer considered part of the queue unless its lease is renewed such as if its
+	// pipeline was aborted
+	Expires *metav1.Time `json:"expires,omitempty" protobuf:"bytes,9,opt,name=expires"`
+}
+
+// PromotionStatus is the state of a promotion in the queue
+type PromotionStatus struct {
+	Phase   PromotionPhase `json:"phase,omitempty"`
+	Message string         `json:"message,omitempty"`
+	// PullRequestURL the Pull Request of the environment repository which promotes the app
+	PullRequestURL string `json:"pullRequestURL,omitempty"`
+	// BatchedInto the name of the Promotion whose Pull Request also promotes this app
+	BatchedInto string `json:"batchedInto,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// PromotionList is a list of Promotion resources
+type PromotionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Promotion `json:"items"`
+}
+
+// PromotionPhase is the phase of a Promotion in the queue
+type PromotionPhase string
+
+const (
+	// PromotionPhaseQueued the promotion is waiting for the promotions ahead of it in the queue
+	PromotionPhaseQueued PromotionPhase = "Queued"
+
+	// PromotionPhasePromoting the promotion is at the head of the queue and is being promoted
+	PromotionPhasePromoting PromotionPhase = "Promoting"
+
+	// PromotionPhaseBatched the promotion is included in the Pull Request of the promotion at the head of the queue
+	PromotionPhaseBatched PromotionPhase = "Batched"
+
+	// PromotionPhaseSucceeded the promotion completed
+	PromotionPhaseSucceeded PromotionPhase = "Succeeded"
+
+	// PromotionPhaseFailed the promotion failed
+	PromotionPhaseFailed PromotionPhase = "Failed"
+)
+
+// IsTerminated returns true if the promotion has left the queue
+func (p PromotionPhase) IsTerminated() bool {
+	return p == PromotionPhaseSucceeded || p == PromotionPhaseFailed
+}
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +k8s:openapi-gen=true
+
+// SourceRepository is a git repository whose pipelines are triggered by Jenkins X. The state of its pipelines, such as
+// whether their triggers are paused, is recorded as annotations
+type SourceRepository struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard object's metadata.
+	// More info: https://git.k8s.io/community/contributors/devel/api-conventions.md#metadata
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec SourceRepositorySpec `json:"spec,omitempty" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// SourceRepositorySpec is the git server, organisation and name of the repository
+type SourceRepositorySpec struct {
+	Description string `json:"description,omitempty" protobuf:"bytes,1,opt,name=description"`
+	// Provider the URL of the git server
+	Provider string `json:"provider,omitempty" protobuf:"bytes,2,opt,name=provider"`
+	Org      string `json:"org,omitempty" protobuf:"bytes,3,opt,name=org"`
+	Repo     string `json:"repo,omitempty" protobuf:"bytes,4,opt,name=repo"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// SourceRepositoryList is a list of SourceRepository resources
+type SourceRepositoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []SourceRepository `json:"items"`
+}
@@ -23,9 +23,11 @@ type Team struct {
 
 // TeamSpec is the specification of an Team
 type TeamSpec struct {
-	Label   string       `json:"label,omitempty" protobuf:"bytes,1,opt,name=label"`
-	Kind    TeamKindType `json:"kind,omitempty" protobuf:"bytes,2,opt,name=kind"`
-	Members []string     `json:"members,omitempty" protobuf:"bytes,3,opt,name=members"`
+	Label             string       `json:"label,omitempty" protobuf:"bytes,1,opt,name=label"`
+	Kind              TeamKindType `json:"kind,omitempty" protobuf:"bytes,2,opt,name=kind"`
+	Members           []string     `json:"members,omitempty" protobuf:"bytes,3,opt,name=members"`
+	Domain            string       `json:"domain,omitempty" protobuf:"bytes,4,opt,name=domain"`
+	DockerRegistryOrg string       `json:"dockerRegistryOrg,omitempty" protobuf:"bytes,5,opt,name=dockerRegistryOrg"`
 }
 
 // TeamStatus is the status for an Team resource
@@ -0,0 +1,176 @@
+package appconfig
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigDir the directory of an environment chart which contains the configuration files of the apps
+	ConfigDir = "config"
+)
+
+var envVarNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// AppConfig the environment variables of an app in an environment. The values of Secrets are encrypted
+// so that they can be safely stored in the git repository of the environment
+type AppConfig struct {
+	ConfigMap map[string]string `json:"configMap,omitempty"`
+	Secrets   map[string]string `json:"secrets,omitempty"`
+}
+
+// IsEmpty returns true if the app has no environment variables
+func (c *AppConfig) IsEmpty() bool {
+	return len(c.ConfigMap) == 0 && len(c.Secrets) == 0
+}
+
+// Set sets the value of an environment variable. Secret values are encrypted with the given key
+func (c *AppConfig) Set(name string, value string, secret bool, key []byte) error {
+	c.Remove(name)
+	if secret {
+		encrypted, err := Encrypt(key, value)
+		if err != nil {
+			return err
+		}
+		if c.Secrets == nil {
+			c.Secrets = map[string]string{}
+		}
+		c.Secrets[name] = encrypted
+		return nil
+	}
+	if c.ConfigMap == nil {
+		c.ConfigMap = map[string]string{}
+	}
+	c.ConfigMap[name] = value
+	return nil
+}
+
+// Remove removes an environment variable returning true if it was present
+func (c *AppConfig) Remove(name string) bool {
+	_, inConfigMap := c.ConfigMap[name]
+	_, inSecrets := c.Secrets[name]
+	delete(c.ConfigMap, name)
+	delete(c.Secrets, name)
+	return inConfigMap || inSecrets
+}
+
+// Names returns the sorted names of the environment variables
+func (c *AppConfig) Names() []string {
+	names := []string{}
+	for k := range c.ConfigMap {
+		names = append(names, k)
+	}
+	for k := range c.Secrets {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ConfigFileName returns the file name of the configuration of the app in the given environment chart directory
+func ConfigFileName(chartDir string, app string) string {
+	return filepath.Join(chartDir, ConfigDir, app+".yaml")
+}
+
+// LoadAppConfig loads the configuration of the app from the given environment chart directory
+// returning an empty configuration if there is none
+func LoadAppConfig(chartDir string, app string) (*AppConfig, error) {
+	config := &AppConfig{}
+	fileName := ConfigFileName(chartDir, app)
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return config, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return config, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return config, errors.Wrapf(err, "failed to unmarshal YAML file %s", fileName)
+	}
+	return config, nil
+}
+
+// SaveAppConfig saves the configuration of the app into the given environment chart directory.
+// The file is removed if the app has no environment variables
+func SaveAppConfig(chartDir string, app string, config *AppConfig) error {
+	fileName := ConfigFileName(chartDir, app)
+	if config.IsEmpty() {
+		exists, err := util.FileExists(fileName)
+		if err != nil || !exists {
+			return err
+		}
+		return os.Remove(fileName)
+	}
+	err := os.MkdirAll(filepath.Dir(fileName), util.DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the configuration of app %s", app)
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
+
+// LoadAllAppConfigs loads the configuration of all the apps in the given environment chart directory indexed by app name
+func LoadAllAppConfigs(chartDir string) (map[string]*AppConfig, error) {
+	answer := map[string]*AppConfig{}
+	dir := filepath.Join(chartDir, ConfigDir)
+	exists, err := util.FileExists(dir)
+	if err != nil || !exists {
+		return answer, err
+	}
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return answer, err
+	}
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || filepath.Ext(name) != ".yaml" {
+			continue
+		}
+		app := strings.TrimSuffix(name, ".yaml")
+		config, err := LoadAppConfig(chartDir, app)
+		if err != nil {
+			return answer, err
+		}
+		answer[app] = config
+	}
+	return answer, nil
+}
+
+// ValidateEnvVarName returns an error if the name is not a valid environment variable name
+func ValidateEnvVarName(name string) error {
+	if !envVarNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid environment variable name '%s' which must consist of letters, digits and '_' and not start with a digit", name)
+	}
+	return nil
+}
+
+// ParseEnvVars parses environment variables of the form NAME=value
+func ParseEnvVars(args []string) (map[string]string, error) {
+	answer := map[string]string{}
+	for _, arg := range args {
+		parts := strings.SplitN(arg, "=", 2)
+		if len(parts) != 2 {
+			return answer, fmt.Errorf("invalid environment variable '%s' which should be of the form NAME=value", arg)
+		}
+		err := ValidateEnvVarName(parts[0])
+		if err != nil {
+			return answer, err
+		}
+		answer[parts[0]] = parts[1]
+	}
+	return answer, nil
+}
@@ -0,0 +1,162 @@
+package appconfig_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEncryptDecrypt(t *testing.T) {
+	t.Parallel()
+	key, err := appconfig.GenerateKey()
+	require.NoError(t, err)
+
+	encrypted, err := appconfig.Encrypt(key, "s3cr3t")
+	require.NoError(t, err)
+	assert.True(t, appconfig.IsEncrypted(encrypted))
+	assert.NotContains(t, encrypted, "s3cr3t")
+
+	value, err := appconfig.Decrypt(key, encrypted)
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	otherKey, err := appconfig.GenerateKey()
+	require.NoError(t, err)
+	_, err = appconfig.Decrypt(otherKey, encrypted)
+	assert.Error(t, err)
+
+	_, err = appconfig.Decrypt(key, "s3cr3t")
+	assert.Error(t, err)
+	_, err = appconfig.Encrypt([]byte("short"), "s3cr3t")
+	assert.Error(t, err)
+}
+
+func TestParseEnvVars(t *testing.T) {
+	t.Parallel()
+	vars, err := appconfig.ParseEnvVars([]string{"FOO=bar", "URL=http://host?a=b", "EMPTY="})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"FOO": "bar", "URL": "http://host?a=b", "EMPTY": ""}, vars)
+
+	for _, invalid := range []string{"FOO", "1FOO=bar", "FOO-BAR=x", "=x"} {
+		_, err = appconfig.ParseEnvVars([]string{invalid})
+		assert.Error(t, err, "env var %s", invalid)
+	}
+}
+
+func TestSaveAndApplyAppConfig(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-appconfig")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ns := "jx-staging"
+	kubeClient := fake.NewSimpleClientset()
+	key, err := appconfig.LoadOrCreateKey(kubeClient, "jx")
+	require.NoError(t, err)
+	loadedKey, err := appconfig.LoadKey(kubeClient, "jx")
+	require.NoError(t, err)
+	assert.Equal(t, key, loadedKey)
+
+	config, err := appconfig.LoadAppConfig(dir, "myapp")
+	require.NoError(t, err)
+	assert.True(t, config.IsEmpty())
+	require.NoError(t, config.Set("LOG_LEVEL", "debug", false, key))
+	require.NoError(t, config.Set("DB_PASSWORD", "s3cr3t", true, key))
+	require.NoError(t, appconfig.SaveAppConfig(dir, "myapp", config))
+
+	data, err := ioutil.ReadFile(appconfig.ConfigFileName(dir, "myapp"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "LOG_LEVEL: debug")
+	assert.NotContains(t, string(data), "s3cr3t")
+
+	config, err = appconfig.LoadAppConfig(dir, "myapp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"DB_PASSWORD", "LOG_LEVEL"}, config.Names())
+
+	require.NoError(t, appconfig.ApplyEnvironmentConfig(kubeClient, ns, dir, key))
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(appconfig.ConfigMapName("myapp"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug"}, cm.Data)
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(appconfig.SecretName("myapp"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(secret.Data["DB_PASSWORD"]))
+
+	clusterConfig, err := appconfig.LoadAppConfigFromCluster(kubeClient, ns, "myapp", key)
+	require.NoError(t, err)
+	assert.Equal(t, config.Names(), clusterConfig.Names())
+
+	// removing all the variables removes the file and the resources
+	assert.True(t, config.Remove("LOG_LEVEL"))
+	assert.True(t, config.Remove("DB_PASSWORD"))
+	assert.False(t, config.Remove("DB_PASSWORD"))
+	require.NoError(t, appconfig.SaveAppConfig(dir, "myapp", config))
+	_, err = os.Stat(appconfig.ConfigFileName(dir, "myapp"))
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, appconfig.ApplyEnvironmentConfig(kubeClient, ns, dir, key))
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Get(appconfig.ConfigMapName("myapp"), metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = kubeClient.CoreV1().Secrets(ns).Get(appconfig.SecretName("myapp"), metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestUpdateValuesEnvFrom(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-appconfig-values")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	valuesFile := filepath.Join(dir, "values.yaml")
+	initial := `expose:
+  config:
+    domain: example.com
+myapp:
+  replicaCount: 2
+  envFrom:
+  - configMapRef:
+      name: shared-config
+`
+	require.NoError(t, ioutil.WriteFile(valuesFile, []byte(initial), 0644))
+
+	config := &appconfig.AppConfig{
+		ConfigMap: map[string]string{"LOG_LEVEL": "debug"},
+		Secrets:   map[string]string{"DB_PASSWORD": "ENC[AES256_GCM,xyz]"},
+	}
+	require.NoError(t, appconfig.UpdateValuesEnvFrom(valuesFile, "myapp", config))
+	require.NoError(t, appconfig.UpdateValuesEnvFrom(valuesFile, "other", config))
+	data, err := ioutil.ReadFile(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, `expose:
+  config:
+    domain: example.com
+myapp:
+  replicaCount: 2
+  envFrom:
+  - configMapRef:
+      name: shared-config
+  - configMapRef:
+      name: myapp-env
+  - secretRef:
+      name: myapp-secret-env
+other:
+  envFrom:
+  - configMapRef:
+      name: other-env
+  - secretRef:
+      name: other-secret-env
+`, string(data))
+
+	empty := &appconfig.AppConfig{}
+	require.NoError(t, appconfig.UpdateValuesEnvFrom(valuesFile, "myapp", empty))
+	require.NoError(t, appconfig.UpdateValuesEnvFrom(valuesFile, "other", empty))
+	data, err = ioutil.ReadFile(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, initial, string(data))
+}
@@ -0,0 +1,136 @@
+package appconfig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// KeySecretName the name of the Secret in the dev namespace which contains the key used to encrypt secret values
+	KeySecretName = "jx-app-config-key"
+
+	// KeySecretKey the key of the encryption key in the Secret
+	KeySecretKey = "key"
+
+	// KeySize the size in bytes of the AES-256 encryption key
+	KeySize = 32
+
+	encryptedPrefix = "ENC[AES256_GCM,"
+	encryptedSuffix = "]"
+)
+
+// GenerateKey generates a new random encryption key
+func GenerateKey() ([]byte, error) {
+	key := make([]byte, KeySize)
+	_, err := io.ReadFull(rand.Reader, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate an encryption key")
+	}
+	return key, nil
+}
+
+// IsEncrypted returns true if the value has been encrypted via Encrypt
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, encryptedPrefix) && strings.HasSuffix(value, encryptedSuffix)
+}
+
+// Encrypt encrypts the value with AES-256-GCM returning text which is safe to store in git
+func Encrypt(key []byte, value string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to generate a nonce")
+	}
+	data := gcm.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + base64.StdEncoding.EncodeToString(data) + encryptedSuffix, nil
+}
+
+// Decrypt decrypts a value encrypted via Encrypt
+func Decrypt(key []byte, value string) (string, error) {
+	if !IsEncrypted(value) {
+		return "", fmt.Errorf("the value is not encrypted")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(value, encryptedPrefix), encryptedSuffix))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode the encrypted value")
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(data) < gcm.NonceSize() {
+		return "", fmt.Errorf("the encrypted value is too short")
+	}
+	plain, err := gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decrypt the value. Was it encrypted with a different key?")
+	}
+	return string(plain), nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("the encryption key must be %d bytes but was %d bytes", KeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// LoadKey loads the encryption key from the Secret in the given namespace
+func LoadKey(kubeClient kubernetes.Interface, ns string) ([]byte, error) {
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(KeySecretName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the app configuration encryption key from Secret %s in namespace %s", KeySecretName, ns)
+	}
+	key := secret.Data[KeySecretKey]
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("the Secret %s in namespace %s does not contain a valid %s entry", KeySecretName, ns, KeySecretKey)
+	}
+	return key, nil
+}
+
+// LoadOrCreateKey loads the encryption key from the Secret in the given namespace generating it if it does not exist
+func LoadOrCreateKey(kubeClient kubernetes.Interface, ns string) ([]byte, error) {
+	_, err := kubeClient.CoreV1().Secrets(ns).Get(KeySecretName, metav1.GetOptions{})
+	if err == nil {
+		return LoadKey(kubeClient, ns)
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, err
+	}
+	key, err := GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: KeySecretName,
+		},
+		Data: map[string][]byte{
+			KeySecretKey: key,
+		},
+	}
+	_, err = kubeClient.CoreV1().Secrets(ns).Create(secret)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create Secret %s in namespace %s", KeySecretName, ns)
+	}
+	return key, nil
+}
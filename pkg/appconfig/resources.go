@@ -0,0 +1,194 @@
+package appconfig
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LabelValueAppConfig the value of the kind label of the ConfigMaps and Secrets generated for apps
+	LabelValueAppConfig = "app-config"
+
+	// LabelApp the label of the app a generated ConfigMap or Secret belongs to
+	LabelApp = "app"
+)
+
+// ConfigMapName returns the name of the ConfigMap containing the plain environment variables of the app
+func ConfigMapName(app string) string {
+	return app + "-env"
+}
+
+// SecretName returns the name of the Secret containing the secret environment variables of the app
+func SecretName(app string) string {
+	return app + "-secret-env"
+}
+
+func resourceLabels(app string) map[string]string {
+	return map[string]string{
+		kube.LabelKind: LabelValueAppConfig,
+		LabelApp:       app,
+	}
+}
+
+// NewConfigMap creates the ConfigMap for the plain environment variables of the app
+func NewConfigMap(app string, config *AppConfig) *v1.ConfigMap {
+	data := map[string]string{}
+	for k, v := range config.ConfigMap {
+		data[k] = v
+	}
+	return &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   ConfigMapName(app),
+			Labels: resourceLabels(app),
+		},
+		Data: data,
+	}
+}
+
+// NewSecret creates the Secret for the secret environment variables of the app decrypting them with the given key
+func NewSecret(app string, config *AppConfig, key []byte) (*v1.Secret, error) {
+	data := map[string][]byte{}
+	for k, v := range config.Secrets {
+		value, err := Decrypt(key, v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decrypt secret %s of app %s", k, app)
+		}
+		data[k] = []byte(value)
+	}
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   SecretName(app),
+			Labels: resourceLabels(app),
+		},
+		Data: data,
+	}, nil
+}
+
+// ApplyAppConfig creates, updates or deletes the ConfigMap and Secret of the app in the given namespace
+func ApplyAppConfig(kubeClient kubernetes.Interface, ns string, app string, config *AppConfig, key []byte) error {
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	name := ConfigMapName(app)
+	if len(config.ConfigMap) == 0 {
+		err := configMaps.Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete ConfigMap %s in namespace %s", name, ns)
+		}
+	} else {
+		cm := NewConfigMap(app, config)
+		existing, err := configMaps.Get(name, metav1.GetOptions{})
+		if err == nil {
+			existing.Labels = cm.Labels
+			existing.Data = cm.Data
+			_, err = configMaps.Update(existing)
+		} else {
+			_, err = configMaps.Create(cm)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to save ConfigMap %s in namespace %s", name, ns)
+		}
+	}
+
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	name = SecretName(app)
+	if len(config.Secrets) == 0 {
+		err := secrets.Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete Secret %s in namespace %s", name, ns)
+		}
+		return nil
+	}
+	secret, err := NewSecret(app, config, key)
+	if err != nil {
+		return err
+	}
+	existing, err := secrets.Get(name, metav1.GetOptions{})
+	if err == nil {
+		existing.Labels = secret.Labels
+		existing.Data = secret.Data
+		_, err = secrets.Update(existing)
+	} else {
+		_, err = secrets.Create(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save Secret %s in namespace %s", name, ns)
+	}
+	return nil
+}
+
+// ApplyEnvironmentConfig applies the configuration of all the apps in the environment chart directory to the
+// given namespace and removes the ConfigMaps and Secrets of apps which no longer have any configuration
+func ApplyEnvironmentConfig(kubeClient kubernetes.Interface, ns string, chartDir string, key []byte) error {
+	configs, err := LoadAllAppConfigs(chartDir)
+	if err != nil {
+		return err
+	}
+	for app, config := range configs {
+		err = ApplyAppConfig(kubeClient, ns, app, config, key)
+		if err != nil {
+			return err
+		}
+	}
+
+	selector := fmt.Sprintf("%s=%s", kube.LabelKind, LabelValueAppConfig)
+	cms, err := kubeClient.CoreV1().ConfigMaps(ns).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	secrets, err := kubeClient.CoreV1().Secrets(ns).List(metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	removed := map[string]bool{}
+	for _, cm := range cms.Items {
+		app := cm.Labels[LabelApp]
+		if configs[app] == nil {
+			removed[app] = true
+		}
+	}
+	for _, secret := range secrets.Items {
+		app := secret.Labels[LabelApp]
+		if configs[app] == nil {
+			removed[app] = true
+		}
+	}
+	for app := range removed {
+		err = ApplyAppConfig(kubeClient, ns, app, &AppConfig{}, key)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadAppConfigFromCluster loads the configuration of the app from its ConfigMap and Secret in the given namespace
+// encrypting the secret values with the given key
+func LoadAppConfigFromCluster(kubeClient kubernetes.Interface, ns string, app string, key []byte) (*AppConfig, error) {
+	config := &AppConfig{}
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(ConfigMapName(app), metav1.GetOptions{})
+	if err == nil && len(cm.Data) > 0 {
+		config.ConfigMap = map[string]string{}
+		for k, v := range cm.Data {
+			config.ConfigMap[k] = v
+		}
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return config, err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(SecretName(app), metav1.GetOptions{})
+	if err == nil {
+		for k, v := range secret.Data {
+			err = config.Set(k, string(v), true, key)
+			if err != nil {
+				return config, err
+			}
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return config, err
+	}
+	return config, nil
+}
@@ -0,0 +1,69 @@
+package appconfig
+
+import (
+	"github.com/jenkins-x/jx/pkg/helm"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// EnvFromKey the key in the values of an app chart which lists the ConfigMaps and Secrets to use as environment variables
+	EnvFromKey = "envFrom"
+)
+
+// UpdateValuesEnvFrom updates the envFrom values of the app in the values YAML file of the environment chart so that
+// the app references its generated ConfigMap and Secret. Other values in the file are preserved in order
+func UpdateValuesEnvFrom(valuesFile string, app string, config *AppConfig) error {
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return err
+	}
+
+	appValues, _ := helm.GetValue(values, app).(yaml.MapSlice)
+	envFrom, _ := helm.GetValue(appValues, EnvFromKey).([]interface{})
+
+	// lets keep any references which were not generated by us
+	answer := []interface{}{}
+	for _, ref := range envFrom {
+		if !isGeneratedEnvFrom(ref, app) {
+			answer = append(answer, ref)
+		}
+	}
+	if len(config.ConfigMap) > 0 {
+		answer = append(answer, envFromRef("configMapRef", ConfigMapName(app)))
+	}
+	if len(config.Secrets) > 0 {
+		answer = append(answer, envFromRef("secretRef", SecretName(app)))
+	}
+
+	if len(answer) > 0 {
+		appValues = helm.SetValue(appValues, EnvFromKey, answer)
+	} else {
+		appValues = helm.RemoveValue(appValues, EnvFromKey)
+	}
+	if len(appValues) > 0 {
+		values = helm.SetValue(values, app, appValues)
+	} else {
+		values = helm.RemoveValue(values, app)
+	}
+	return helm.SaveValuesFile(valuesFile, values)
+}
+
+func envFromRef(kind string, name string) yaml.MapSlice {
+	return yaml.MapSlice{
+		{Key: kind, Value: yaml.MapSlice{{Key: "name", Value: name}}},
+	}
+}
+
+func isGeneratedEnvFrom(ref interface{}, app string) bool {
+	m, ok := ref.(yaml.MapSlice)
+	if !ok {
+		return false
+	}
+	for kind, name := range map[string]string{"configMapRef": ConfigMapName(app), "secretRef": SecretName(app)} {
+		r, _ := helm.GetValue(m, kind).(yaml.MapSlice)
+		if r != nil && helm.GetValue(r, "name") == name {
+			return true
+		}
+	}
+	return false
+}
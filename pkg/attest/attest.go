@@ -0,0 +1,159 @@
+package attest
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// SBOMFormatSPDX the SPDX JSON software bill of materials format
+	SBOMFormatSPDX = "spdx"
+	// SBOMFormatCycloneDX the CycloneDX JSON software bill of materials format
+	SBOMFormatCycloneDX = "cyclonedx"
+
+	// AttestationFileName the default name of the file which records the attestations of a release
+	AttestationFileName = "attestation.yaml"
+
+	// EnvironmentAttestationsFileName the name of the file in an environment repository which records the
+	// attestations of the promoted apps
+	EnvironmentAttestationsFileName = "attestations.yaml"
+)
+
+// SBOMFormats the supported software bill of materials formats
+var SBOMFormats = []string{SBOMFormatSPDX, SBOMFormatCycloneDX}
+
+// ImageAttestation records the digests of a released image and of its software bill of materials and provenance
+type ImageAttestation struct {
+	App              string    `json:"app,omitempty"`
+	Version          string    `json:"version,omitempty"`
+	Image            string    `json:"image"`
+	Digest           string    `json:"digest"`
+	SBOMFormat       string    `json:"sbomFormat,omitempty"`
+	SBOMDigest       string    `json:"sbomDigest,omitempty"`
+	ProvenanceDigest string    `json:"provenanceDigest,omitempty"`
+	Signed           bool      `json:"signed,omitempty"`
+	Key              string    `json:"key,omitempty"`
+	Created          time.Time `json:"created,omitempty"`
+}
+
+// ImageReference returns the image reference pinned to the digest
+func (a *ImageAttestation) ImageReference() string {
+	return ImageWithDigest(a.Image, a.Digest)
+}
+
+// Attestations the attestations of the apps deployed to an environment
+type Attestations struct {
+	Apps []ImageAttestation `json:"apps,omitempty"`
+}
+
+// SetApp adds or replaces the attestation of the app
+func (a *Attestations) SetApp(attestation *ImageAttestation) {
+	for i, app := range a.Apps {
+		if app.App == attestation.App {
+			a.Apps[i] = *attestation
+			return
+		}
+	}
+	a.Apps = append(a.Apps, *attestation)
+	sort.Slice(a.Apps, func(i, j int) bool {
+		return a.Apps[i].App < a.Apps[j].App
+	})
+}
+
+// GetApp returns the attestation of the app or nil if there is none
+func (a *Attestations) GetApp(app string) *ImageAttestation {
+	for i := range a.Apps {
+		if a.Apps[i].App == app {
+			return &a.Apps[i]
+		}
+	}
+	return nil
+}
+
+// LoadImageAttestation loads the attestation of a release from the file
+func LoadImageAttestation(fileName string) (*ImageAttestation, error) {
+	answer := &ImageAttestation{}
+	err := loadYAML(fileName, answer)
+	if err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// SaveImageAttestation saves the attestation of a release to the file
+func SaveImageAttestation(fileName string, attestation *ImageAttestation) error {
+	return saveYAML(fileName, attestation)
+}
+
+// LoadAttestations loads the attestations of an environment from the file which may not exist yet
+func LoadAttestations(fileName string) (*Attestations, error) {
+	answer := &Attestations{}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return answer, err
+	}
+	err = loadYAML(fileName, answer)
+	if err != nil {
+		return nil, err
+	}
+	return answer, nil
+}
+
+// SaveAttestations saves the attestations of an environment to the file
+func SaveAttestations(fileName string, attestations *Attestations) error {
+	return saveYAML(fileName, attestations)
+}
+
+// ImageWithDigest returns the image name without any tag pinned to the digest
+func ImageWithDigest(image string, digest string) string {
+	if digest == "" {
+		return image
+	}
+	return ImageWithoutTag(image) + "@" + digest
+}
+
+// ImageWithoutTag returns the image name without any tag or digest
+func ImageWithoutTag(image string) string {
+	if idx := strings.Index(image, "@"); idx >= 0 {
+		image = image[:idx]
+	}
+	// a colon after the last slash is a tag rather than a registry port
+	if idx := strings.LastIndex(image, ":"); idx > strings.LastIndex(image, "/") {
+		image = image[:idx]
+	}
+	return image
+}
+
+// ValidateSBOMFormat returns an error if the software bill of materials format is not supported
+func ValidateSBOMFormat(format string) error {
+	if util.StringArrayIndex(SBOMFormats, format) < 0 {
+		return util.InvalidOption("sbom-format", format, append([]string{}, SBOMFormats...))
+	}
+	return nil
+}
+
+func loadYAML(fileName string, value interface{}) error {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to load file %s: %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, value)
+	if err != nil {
+		return fmt.Errorf("failed to unmarshal YAML file %s: %s", fileName, err)
+	}
+	return nil
+}
+
+func saveYAML(fileName string, value interface{}) error {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
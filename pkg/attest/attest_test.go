@@ -0,0 +1,135 @@
+package attest_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/attest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:b9f03c3c4b196d46639bee0ec9cd0f6dbea8cc39d32767c8312f04317c3b18f4"
+
+func TestImageWithDigest(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]string{
+		"myapp":                                  "myapp",
+		"myorg/myapp:1.0.0":                      "myorg/myapp",
+		"10.0.0.1:5000/myorg/myapp:1.0.0":        "10.0.0.1:5000/myorg/myapp",
+		"10.0.0.1:5000/myorg/myapp":              "10.0.0.1:5000/myorg/myapp",
+		"gcr.io/myorg/myapp@" + testDigest:       "gcr.io/myorg/myapp",
+		"gcr.io/myorg/myapp:1.0@" + testDigest:   "gcr.io/myorg/myapp",
+		"localhost:5000/myapp:1.0@" + testDigest: "localhost:5000/myapp",
+	}
+	for image, expected := range testCases {
+		assert.Equal(t, expected, attest.ImageWithoutTag(image), "image %s", image)
+		assert.Equal(t, expected+"@"+testDigest, attest.ImageWithDigest(image, testDigest), "image %s", image)
+	}
+	assert.Equal(t, "myorg/myapp:1.0.0", attest.ImageWithDigest("myorg/myapp:1.0.0", ""))
+}
+
+func TestParseDigest(t *testing.T) {
+	t.Parallel()
+	digest, err := attest.ParseDigest(testDigest + "\n")
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+
+	digest, err = attest.ParseDigest("gcr.io/myorg/myapp@" + testDigest)
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+
+	_, err = attest.ParseDigest("Error: image not found")
+	assert.Error(t, err)
+	_, err = attest.ParseDigest("")
+	assert.Error(t, err)
+}
+
+func TestAttestations(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-attestations")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, attest.EnvironmentAttestationsFileName)
+
+	attestations, err := attest.LoadAttestations(fileName)
+	require.NoError(t, err)
+	assert.Empty(t, attestations.Apps)
+
+	attestations.SetApp(&attest.ImageAttestation{App: "myapp", Version: "1.0.0", Image: "myorg/myapp", Digest: testDigest})
+	attestations.SetApp(&attest.ImageAttestation{App: "another", Version: "2.0.0", Image: "myorg/another", Digest: testDigest})
+	attestations.SetApp(&attest.ImageAttestation{App: "myapp", Version: "1.1.0", Image: "myorg/myapp", Digest: testDigest, Signed: true})
+	err = attest.SaveAttestations(fileName, attestations)
+	require.NoError(t, err)
+
+	loaded, err := attest.LoadAttestations(fileName)
+	require.NoError(t, err)
+	require.Len(t, loaded.Apps, 2)
+	assert.Equal(t, "another", loaded.Apps[0].App)
+	app := loaded.GetApp("myapp")
+	require.NotNil(t, app)
+	assert.Equal(t, "1.1.0", app.Version)
+	assert.True(t, app.Signed)
+	assert.Equal(t, "myorg/myapp@"+testDigest, app.ImageReference())
+	assert.Nil(t, loaded.GetApp("missing"))
+}
+
+func TestNewProvenanceStatement(t *testing.T) {
+	t.Parallel()
+	statement := attest.NewProvenanceStatement("gcr.io/myorg/myapp@"+testDigest, testDigest, &attest.BuildInfo{
+		BuilderID: "https://jenkins.example.com/job/myapp/1",
+		GitURL:    "https://github.com/myorg/myapp.git",
+		GitCommit: "abc123",
+		BuildID:   "1",
+	})
+	assert.Equal(t, attest.InTotoStatementType, statement.Type)
+	assert.Equal(t, attest.SLSAProvenancePredicateType, statement.PredicateType)
+	require.Len(t, statement.Subject, 1)
+	assert.Equal(t, "gcr.io/myorg/myapp", statement.Subject[0].Name)
+	assert.Equal(t, map[string]string{"sha256": strings.TrimPrefix(testDigest, "sha256:")}, statement.Subject[0].Digest)
+
+	data, err := statement.MarshalPredicate()
+	require.NoError(t, err)
+	predicate := attest.Provenance{}
+	err = json.Unmarshal(data, &predicate)
+	require.NoError(t, err)
+	assert.Equal(t, "https://jenkins.example.com/job/myapp/1", predicate.Builder.ID)
+	assert.Equal(t, "git+https://github.com/myorg/myapp.git@abc123", predicate.Invocation.ConfigSource.URI)
+	require.Len(t, predicate.Materials, 1)
+	assert.Equal(t, "abc123", predicate.Materials[0].Digest["sha1"])
+}
+
+func TestTools(t *testing.T) {
+	t.Parallel()
+	commands := []string{}
+	tools := &attest.Tools{
+		Key: "k8s://jx/cosign",
+		Runner: func(dir string, name string, args ...string) (string, error) {
+			commands = append(commands, name+" "+strings.Join(args, " "))
+			return "gcr.io/myorg/myapp@" + testDigest, nil
+		},
+	}
+	digest, err := tools.ImageDigest("gcr.io/myorg/myapp:1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, testDigest, digest)
+
+	ref := "gcr.io/myorg/myapp@" + testDigest
+	require.NoError(t, tools.GenerateSBOM(ref, attest.SBOMFormatCycloneDX, "sbom.json"))
+	require.NoError(t, tools.SignImage(ref))
+	require.NoError(t, tools.AttestImage(ref, attest.PredicateTypeProvenance, "provenance.json"))
+	assert.Error(t, tools.GenerateSBOM(ref, "xml", "sbom.xml"))
+
+	assert.Equal(t, []string{
+		"cosign triangulate --type digest gcr.io/myorg/myapp:1.0.0",
+		"syft " + ref + " --output cyclonedx-json=sbom.json",
+		"cosign sign --key k8s://jx/cosign " + ref,
+		"cosign attest --key k8s://jx/cosign --type slsaprovenance --predicate provenance.json " + ref,
+	}, commands)
+
+	tools.Key = ""
+	assert.Error(t, tools.SignImage(ref))
+}
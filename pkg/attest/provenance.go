@@ -0,0 +1,154 @@
+package attest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+const (
+	// InTotoStatementType the type of an in-toto attestation statement
+	InTotoStatementType = "https://in-toto.io/Statement/v0.1"
+	// SLSAProvenancePredicateType the predicate type of a SLSA provenance attestation
+	SLSAProvenancePredicateType = "https://slsa.dev/provenance/v0.2"
+	// BuildType the type of the builds recorded in the provenance of a release
+	BuildType = "https://jenkins-x.io/release@v1"
+)
+
+// Statement an in-toto attestation statement about the subjects
+// see: https://github.com/in-toto/attestation
+type Statement struct {
+	Type          string     `json:"_type"`
+	PredicateType string     `json:"predicateType"`
+	Subject       []Subject  `json:"subject"`
+	Predicate     Provenance `json:"predicate"`
+}
+
+// Subject an artifact identified by its digests
+type Subject struct {
+	Name   string            `json:"name"`
+	Digest map[string]string `json:"digest"`
+}
+
+// Provenance describes how an artifact was built
+// see: https://slsa.dev/provenance/v0.2
+type Provenance struct {
+	Builder    Builder            `json:"builder"`
+	BuildType  string             `json:"buildType"`
+	Invocation Invocation         `json:"invocation"`
+	Metadata   ProvenanceMetadata `json:"metadata"`
+	Materials  []Material         `json:"materials,omitempty"`
+}
+
+// Builder identifies the platform which ran the build
+type Builder struct {
+	ID string `json:"id"`
+}
+
+// Invocation identifies the source and parameters of the build
+type Invocation struct {
+	ConfigSource ConfigSource      `json:"configSource"`
+	Parameters   map[string]string `json:"parameters,omitempty"`
+}
+
+// ConfigSource the source of the build configuration
+type ConfigSource struct {
+	URI        string            `json:"uri,omitempty"`
+	Digest     map[string]string `json:"digest,omitempty"`
+	EntryPoint string            `json:"entryPoint,omitempty"`
+}
+
+// ProvenanceMetadata the metadata of the build
+type ProvenanceMetadata struct {
+	BuildInvocationID string     `json:"buildInvocationId,omitempty"`
+	BuildStartedOn    *time.Time `json:"buildStartedOn,omitempty"`
+	BuildFinishedOn   *time.Time `json:"buildFinishedOn,omitempty"`
+}
+
+// Material an input of the build
+type Material struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// BuildInfo the details of the build which produced an image
+type BuildInfo struct {
+	BuilderID  string
+	GitURL     string
+	GitCommit  string
+	EntryPoint string
+	BuildID    string
+	Started    *time.Time
+	Finished   *time.Time
+	Parameters map[string]string
+}
+
+// NewProvenanceStatement returns the provenance statement of the image with the given digest
+func NewProvenanceStatement(image string, digest string, build *BuildInfo) *Statement {
+	provenance := Provenance{
+		Builder: Builder{
+			ID: build.BuilderID,
+		},
+		BuildType: BuildType,
+		Invocation: Invocation{
+			ConfigSource: ConfigSource{
+				EntryPoint: build.EntryPoint,
+			},
+			Parameters: build.Parameters,
+		},
+		Metadata: ProvenanceMetadata{
+			BuildInvocationID: build.BuildID,
+			BuildStartedOn:    build.Started,
+			BuildFinishedOn:   build.Finished,
+		},
+	}
+	if build.GitURL != "" {
+		uri := "git+" + build.GitURL
+		var digests map[string]string
+		if build.GitCommit != "" {
+			digests = map[string]string{"sha1": build.GitCommit}
+			provenance.Invocation.ConfigSource.URI = uri + "@" + build.GitCommit
+		} else {
+			provenance.Invocation.ConfigSource.URI = uri
+		}
+		provenance.Invocation.ConfigSource.Digest = digests
+		provenance.Materials = append(provenance.Materials, Material{
+			URI:    uri,
+			Digest: digests,
+		})
+	}
+	return &Statement{
+		Type:          InTotoStatementType,
+		PredicateType: SLSAProvenancePredicateType,
+		Subject: []Subject{
+			{
+				Name:   ImageWithoutTag(image),
+				Digest: DigestMap(digest),
+			},
+		},
+		Predicate: provenance,
+	}
+}
+
+// DigestMap converts a digest of the form 'algorithm:hex' into a map of the algorithm to the hex value
+func DigestMap(digest string) map[string]string {
+	parts := strings.SplitN(digest, ":", 2)
+	if len(parts) != 2 {
+		return map[string]string{"sha256": digest}
+	}
+	return map[string]string{parts[0]: parts[1]}
+}
+
+// SHA256Digest returns the digest of the data of the form 'sha256:hex'
+func SHA256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// MarshalPredicate returns the JSON of the provenance predicate of the statement which is the form signing
+// tools expect when attesting an image
+func (s *Statement) MarshalPredicate() ([]byte, error) {
+	return json.MarshalIndent(s.Predicate, "", "  ")
+}
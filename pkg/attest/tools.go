@@ -0,0 +1,144 @@
+package attest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// SBOMTool the binary used to generate software bill of materials
+	SBOMTool = "syft"
+	// SigningTool the binary used to sign images and attestations
+	SigningTool = "cosign"
+
+	// PredicateTypeProvenance the signing tool predicate type of a SLSA provenance
+	PredicateTypeProvenance = "slsaprovenance"
+)
+
+// Runner runs external commands so that they can be replaced in tests
+type Runner func(dir string, name string, args ...string) (string, error)
+
+// DefaultRunner runs the command returning its output
+func DefaultRunner(dir string, name string, args ...string) (string, error) {
+	cmd := util.Command{
+		Dir:  dir,
+		Name: name,
+		Args: args,
+	}
+	return cmd.RunWithoutRetry()
+}
+
+// Tools generates software bill of materials and signs images using external tools
+type Tools struct {
+	Dir    string
+	Runner Runner
+	// Key the signing key which can be a file, a KMS URI or a kubernetes secret of the form k8s://namespace/name
+	Key string
+}
+
+// SBOMOutputFormat returns the output format of the SBOM tool for the software bill of materials format
+func SBOMOutputFormat(format string) string {
+	switch format {
+	case SBOMFormatCycloneDX:
+		return "cyclonedx-json"
+	default:
+		return "spdx-json"
+	}
+}
+
+// SBOMPredicateType returns the signing tool predicate type of a software bill of materials of the format
+func SBOMPredicateType(format string) string {
+	switch format {
+	case SBOMFormatCycloneDX:
+		return "cyclonedx"
+	default:
+		return "spdxjson"
+	}
+}
+
+// SBOMFileName returns the default file name of a software bill of materials of the format
+func SBOMFileName(format string) string {
+	return "sbom." + format + ".json"
+}
+
+// GenerateSBOM generates the software bill of materials of the image into the file
+func (t *Tools) GenerateSBOM(image string, format string, fileName string) error {
+	err := ValidateSBOMFormat(format)
+	if err != nil {
+		return err
+	}
+	_, err = t.run(SBOMTool, image, "--output", SBOMOutputFormat(format)+"="+fileName)
+	if err != nil {
+		return fmt.Errorf("failed to generate the %s SBOM of image %s: %s", format, image, err)
+	}
+	return nil
+}
+
+// ImageDigest returns the digest of the image in the registry
+func (t *Tools) ImageDigest(image string) (string, error) {
+	out, err := t.run(SigningTool, "triangulate", "--type", "digest", image)
+	if err != nil {
+		return "", fmt.Errorf("failed to find the digest of image %s: %s", image, err)
+	}
+	return ParseDigest(out)
+}
+
+// SignImage signs the image reference which should be pinned to a digest
+func (t *Tools) SignImage(imageRef string) error {
+	if t.Key == "" {
+		return util.MissingOption("key")
+	}
+	_, err := t.run(SigningTool, "sign", "--key", t.Key, imageRef)
+	if err != nil {
+		return fmt.Errorf("failed to sign image %s: %s", imageRef, err)
+	}
+	return nil
+}
+
+// AttestImage signs the predicate file and attaches it to the image reference as an attestation of the type
+func (t *Tools) AttestImage(imageRef string, predicateType string, predicateFile string) error {
+	if t.Key == "" {
+		return util.MissingOption("key")
+	}
+	_, err := t.run(SigningTool, "attest", "--key", t.Key, "--type", predicateType, "--predicate", predicateFile, imageRef)
+	if err != nil {
+		return fmt.Errorf("failed to attest the %s of image %s: %s", predicateType, imageRef, err)
+	}
+	return nil
+}
+
+// VerifyImage verifies the signature of the image reference
+func (t *Tools) VerifyImage(imageRef string) error {
+	if t.Key == "" {
+		return util.MissingOption("key")
+	}
+	_, err := t.run(SigningTool, "verify", "--key", t.Key, imageRef)
+	if err != nil {
+		return fmt.Errorf("failed to verify the signature of image %s: %s", imageRef, err)
+	}
+	return nil
+}
+
+func (t *Tools) run(name string, args ...string) (string, error) {
+	runner := t.Runner
+	if runner == nil {
+		runner = DefaultRunner
+	}
+	return runner(t.Dir, name, args...)
+}
+
+// ParseDigest returns the digest from the output of a command which may be a digest or an image reference pinned
+// to a digest
+func ParseDigest(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	if idx := strings.LastIndex(text, "@"); idx >= 0 {
+		text = text[idx+1:]
+	}
+	parts := strings.SplitN(text, ":", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" || strings.ContainsAny(text, " \n\t") {
+		return "", fmt.Errorf("could not find an image digest in '%s'", text)
+	}
+	return text, nil
+}
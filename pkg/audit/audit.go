@@ -0,0 +1,273 @@
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ConfigMapName the name of the ConfigMap in the team dev namespace which stores the audit log
+	ConfigMapName = "jx-audit"
+
+	// DefaultMaxEntries the maximum number of entries kept in the audit ConfigMap before the oldest are removed
+	DefaultMaxEntries = 1000
+
+	// MaxDataSize the maximum total size in bytes of the entries kept in the audit ConfigMap before the oldest are
+	// removed, which leaves headroom below the 1MiB limit on the size of a ConfigMap
+	MaxDataSize = 800 * 1024
+
+	// SessionStart the entry recorded when an interactive session such as 'jx rsh' starts
+	SessionStart = "start"
+	// SessionStop the entry recorded when an interactive session stops
+	SessionStop = "stop"
+
+	// WebhookTimeout the maximum time spent posting an audit entry to the audit webhook of a team so that a slow
+	// webhook does not hold up the command being audited
+	WebhookTimeout = 3 * time.Second
+
+	maskedValue = "******"
+)
+
+// MutatingCommands the top level jx commands which modify the cluster, the git repositories or the team configuration
+var MutatingCommands = []string{
+	"create", "delete", "edit", "gc", "import", "init", "install", "preview", "promote", "start", "stop", "uninstall", "update", "upgrade",
+}
+
+// sensitiveFlagWords flags and key=value arguments whose names contain any of these words have their values masked
+var sensitiveFlagWords = []string{"password", "secret", "token", "key", "credential"}
+
+// Entry an audit log entry for a jx operation
+type Entry struct {
+	Time      time.Time `json:"time"`
+	User      string    `json:"user,omitempty"`
+	KubeUser  string    `json:"kubeUser,omitempty"`
+	Command   string    `json:"command"`
+	Args      []string  `json:"args,omitempty"`
+	Namespace string    `json:"namespace,omitempty"`
+	Succeeded bool      `json:"succeeded"`
+	Error     string    `json:"error,omitempty"`
+	// Session whether the entry records the start or stop of an interactive session
+	Session string `json:"session,omitempty"`
+}
+
+// Status returns a textual status of the entry
+func (e *Entry) Status() string {
+	if e.Session == SessionStart {
+		return "Started"
+	}
+	if e.Session == SessionStop && e.Succeeded {
+		return "Stopped"
+	}
+	if e.Succeeded {
+		return "Succeeded"
+	}
+	return "Failed"
+}
+
+// CommandLine returns the command along with its arguments
+func (e *Entry) CommandLine() string {
+	return strings.TrimSpace(e.Command + " " + strings.Join(e.Args, " "))
+}
+
+// IsMutatingCommand returns true if the given command path, such as "jx create env", modifies resources
+func IsMutatingCommand(commandPath string) bool {
+	paths := strings.Fields(commandPath)
+	if len(paths) < 2 {
+		return false
+	}
+	for _, c := range MutatingCommands {
+		if paths[1] == c {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskArgs returns a copy of the command line arguments with the values of sensitive flags such as passwords masked.
+// Sensitive key=value arguments and the passwords of URLs are masked too whether they are flag values or positional
+// arguments
+func MaskArgs(args []string) []string {
+	answer := []string{}
+	maskNext := false
+	for _, arg := range args {
+		if maskNext {
+			answer = append(answer, maskedValue)
+			maskNext = false
+			continue
+		}
+		if strings.HasPrefix(arg, "-") {
+			idx := strings.Index(arg, "=")
+			if isSensitiveFlag(arg) {
+				if idx > 0 {
+					answer = append(answer, arg[0:idx+1]+maskedValue)
+				} else {
+					answer = append(answer, arg)
+					maskNext = true
+				}
+			} else if idx > 0 {
+				answer = append(answer, arg[0:idx+1]+maskValue(arg[idx+1:]))
+			} else {
+				answer = append(answer, arg)
+			}
+			continue
+		}
+		answer = append(answer, maskValue(arg))
+	}
+	return answer
+}
+
+// maskValue masks the password of a URL or the value of a key=value argument with a sensitive key
+func maskValue(value string) string {
+	u, err := url.Parse(value)
+	if err == nil && u.User != nil {
+		if _, ok := u.User.Password(); ok {
+			u.User = url.User(u.User.Username())
+			user := u.User.String()
+			return strings.Replace(u.String(), user+"@", user+":"+maskedValue+"@", 1)
+		}
+	}
+	idx := strings.Index(value, "=")
+	if idx > 0 && isSensitiveFlag(value[0:idx]) {
+		return value[0:idx+1] + maskedValue
+	}
+	return value
+}
+
+func isSensitiveFlag(arg string) bool {
+	name := strings.ToLower(strings.TrimLeft(arg, "-"))
+	idx := strings.Index(name, "=")
+	if idx >= 0 {
+		name = name[0:idx]
+	}
+	for _, w := range sensitiveFlagWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+// Record appends the entry to the audit ConfigMap in the given namespace keeping at most maxEntries entries
+func Record(kubeClient kubernetes.Interface, ns string, entry *Entry, maxEntries int) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	key := strconv.FormatInt(entry.Time.UnixNano(), 10)
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+
+	// lets retry in case another jx command updated the audit log concurrently
+	for i := 0; i < 3; i++ {
+		cm, err := configMaps.Get(ConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			cm = &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      ConfigMapName,
+					Namespace: ns,
+				},
+				Data: map[string]string{
+					key: string(data),
+				},
+			}
+			_, err = configMaps.Create(cm)
+		} else {
+			if cm.Data == nil {
+				cm.Data = map[string]string{}
+			}
+			cm.Data[key] = string(data)
+			pruneEntries(cm.Data, maxEntries)
+			_, err = configMaps.Update(cm)
+		}
+		if err == nil {
+			return nil
+		}
+		if !apierrors.IsConflict(err) && !apierrors.IsAlreadyExists(err) {
+			return errors.Wrapf(err, "failed to save the audit log ConfigMap %s in namespace %s", ConfigMapName, ns)
+		}
+	}
+	return fmt.Errorf("failed to save the audit log ConfigMap %s in namespace %s due to concurrent modifications", ConfigMapName, ns)
+}
+
+// pruneEntries removes the oldest entries so that there are at most maxEntries and their total size is at most
+// MaxDataSize. The newest entry is always kept
+func pruneEntries(data map[string]string, maxEntries int) {
+	keys := sortedKeys(data)
+	size := 0
+	for _, key := range keys {
+		size += len(key) + len(data[key])
+	}
+	for len(keys) > 1 && ((maxEntries > 0 && len(keys) > maxEntries) || size > MaxDataSize) {
+		key := keys[0]
+		size -= len(key) + len(data[key])
+		delete(data, key)
+		keys = keys[1:]
+	}
+}
+
+// sortedKeys returns the keys of the audit entries from the oldest to the newest
+func sortedKeys(data map[string]string) []string {
+	keys := []string{}
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// GetEntries returns the audit entries in the given namespace ordered from the oldest to the newest
+func GetEntries(kubeClient kubernetes.Interface, ns string) ([]*Entry, error) {
+	answer := []*Entry{}
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return answer, nil
+		}
+		return answer, err
+	}
+	for _, key := range sortedKeys(cm.Data) {
+		entry := &Entry{}
+		err = json.Unmarshal([]byte(cm.Data[key]), entry)
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to parse audit entry %s in ConfigMap %s", key, ConfigMapName)
+		}
+		answer = append(answer, entry)
+	}
+	return answer, nil
+}
+
+// PostToWebhook sends the entry as JSON to the given webhook URL giving up after the given timeout
+func PostToWebhook(webhookURL string, entry *Entry, timeout time.Duration) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	client := httpclient.NewBaseClient()
+	client.Timeout = timeout
+	resp, err := client.Post(webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrapf(err, "failed to post audit entry to %s", webhookURL)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("failed to post audit entry to %s: status %s", webhookURL, resp.Status)
+	}
+	return nil
+}
@@ -179,6 +179,18 @@ func urlHostName(rawUrl string) string {
 	return strings.TrimSuffix(rawUrl, "/")
 }
 
+// DefaultServerURL returns the URL of the current server, or the URL of the only server if there is just one,
+// otherwise the given default URL
+func (c *AuthConfig) DefaultServerURL(defaultURL string) string {
+	if c.CurrentServer != "" {
+		return c.CurrentServer
+	}
+	if len(c.Servers) == 1 && c.Servers[0].URL != "" {
+		return c.Servers[0].URL
+	}
+	return defaultURL
+}
+
 func (c *AuthConfig) PickServer(message string, batchMode bool) (*AuthServer, error) {
 	if c.Servers == nil || len(c.Servers) == 0 {
 		return nil, fmt.Errorf("No servers available!")
@@ -199,7 +211,7 @@ func (c *AuthConfig) PickServer(message string, batchMode bool) (*AuthServer, er
 				Message: message,
 				Options: urls,
 			}
-			err := survey.AskOne(prompt, &url, survey.Required)
+			err := util.AskOne(prompt, &url, survey.Required)
 			if err != nil {
 				return nil, err
 			}
@@ -227,7 +239,7 @@ func (c *AuthConfig) PickServerUserAuth(server *AuthServer, message string, batc
 			Default: true,
 		}
 		flag := false
-		err := survey.AskOne(confirm, &flag, nil)
+		err := util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return auth, err
 		}
@@ -240,7 +252,7 @@ func (c *AuthConfig) PickServerUserAuth(server *AuthServer, message string, batc
 			Message: message,
 		}
 		username := ""
-		err = survey.AskOne(prompt, &username, nil)
+		err = util.AskOne(prompt, &username, nil)
 		if err != nil {
 			return auth, err
 		}
@@ -270,7 +282,7 @@ func (c *AuthConfig) PickServerUserAuth(server *AuthServer, message string, batc
 			Message: message,
 			Options: usernames,
 		}
-		err := survey.AskOne(prompt, &username, survey.Required)
+		err := util.AskOne(prompt, &username, survey.Required)
 		if err != nil {
 			return &UserAuth{}, err
 		}
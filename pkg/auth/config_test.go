@@ -172,3 +172,18 @@ func TestDeleteServer2(t *testing.T) {
 	assert.Equal(t, url1, c.Servers[0].URL, "Failed to remove the right server from the configuration")
 	assert.Equal(t, url1, c.CurrentServer, "Server 1 should be current server")
 }
+
+func TestDefaultServerURL(t *testing.T) {
+	t.Parallel()
+	config := &auth.AuthConfig{}
+	assert.Equal(t, "https://github.com", config.DefaultServerURL("https://github.com"))
+
+	config.GetOrCreateServerName("https://github.acme.com", "ghe", "github")
+	assert.Equal(t, "https://github.acme.com", config.DefaultServerURL("https://github.com"))
+
+	config.GetOrCreateServerName("https://gitlab.acme.com", "gitlab", "gitlab")
+	assert.Equal(t, "https://github.com", config.DefaultServerURL("https://github.com"))
+
+	config.CurrentServer = "https://gitlab.acme.com"
+	assert.Equal(t, "https://gitlab.acme.com", config.DefaultServerURL("https://github.com"))
+}
@@ -11,6 +11,13 @@ type AuthServer struct {
 	Kind  string
 
 	CurrentUser string
+
+	// ApiURL the base URL of the REST API of the server if it is not at the default location such as /api/v3 for
+	// GitHub Enterprise
+	ApiURL string `yaml:"apiUrl,omitempty"`
+	// CAFile the PEM file of the certificate authorities which issued the TLS certificate of the server if they are
+	// not trusted by the system
+	CAFile string `yaml:"caFile,omitempty"`
 }
 
 type UserAuth struct {
@@ -0,0 +1,168 @@
+package autoscaling
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	yamlv2 "gopkg.in/yaml.v2"
+)
+
+const (
+	// ValuesKey the key in the values of an app in an environment chart which configures its HorizontalPodAutoscaler
+	ValuesKey = "hpa"
+
+	// DefaultMinReplicas the default minimum number of replicas
+	DefaultMinReplicas = 1
+
+	// DefaultMaxReplicas the default maximum number of replicas
+	DefaultMaxReplicas = 5
+
+	// DefaultTargetCPUUtilization the default target average CPU utilization percentage
+	DefaultTargetCPUUtilization = 80
+)
+
+// Values the HorizontalPodAutoscaler values of an app in an environment chart
+type Values struct {
+	Enabled                        bool  `json:"enabled"`
+	MinReplicas                    int32 `json:"minReplicas,omitempty"`
+	MaxReplicas                    int32 `json:"maxReplicas,omitempty"`
+	CPUTargetAverageUtilization    int32 `json:"cpuTargetAverageUtilization,omitempty"`
+	MemoryTargetAverageUtilization int32 `json:"memoryTargetAverageUtilization,omitempty"`
+}
+
+// Validate returns an error if the values cannot be used to create a HorizontalPodAutoscaler
+func (v *Values) Validate() error {
+	if !v.Enabled {
+		return nil
+	}
+	if v.MinReplicas < 1 {
+		return fmt.Errorf("the minimum number of replicas must be at least 1 but was %d", v.MinReplicas)
+	}
+	if v.MaxReplicas < v.MinReplicas {
+		return fmt.Errorf("the maximum number of replicas %d must not be less than the minimum number of replicas %d", v.MaxReplicas, v.MinReplicas)
+	}
+	if v.CPUTargetAverageUtilization == 0 && v.MemoryTargetAverageUtilization == 0 {
+		return fmt.Errorf("a target CPU or memory utilization must be specified")
+	}
+	if v.CPUTargetAverageUtilization < 0 || v.MemoryTargetAverageUtilization < 0 {
+		return fmt.Errorf("the target utilization percentages must be positive")
+	}
+	return nil
+}
+
+// NewValues creates the values for an app from the autoscaling defaults of a team
+func NewValues(defaults *v1.AutoscalingDefaults) *Values {
+	answer := &Values{
+		Enabled:                        true,
+		MinReplicas:                    defaults.MinReplicas,
+		MaxReplicas:                    defaults.MaxReplicas,
+		CPUTargetAverageUtilization:    defaults.TargetCPUUtilization,
+		MemoryTargetAverageUtilization: defaults.TargetMemoryUtilization,
+	}
+	if answer.MinReplicas == 0 {
+		answer.MinReplicas = DefaultMinReplicas
+	}
+	if answer.MaxReplicas == 0 {
+		answer.MaxReplicas = DefaultMaxReplicas
+		if answer.MaxReplicas < answer.MinReplicas {
+			answer.MaxReplicas = answer.MinReplicas
+		}
+	}
+	if answer.CPUTargetAverageUtilization == 0 && answer.MemoryTargetAverageUtilization == 0 {
+		answer.CPUTargetAverageUtilization = DefaultTargetCPUUtilization
+	}
+	return answer
+}
+
+// DefaultsApply returns true if the autoscaling defaults of the team apply to the environment
+func DefaultsApply(defaults *v1.AutoscalingDefaults, env *v1.Environment) bool {
+	if defaults == nil || env == nil || env.Spec.Kind != v1.EnvironmentKindTypePermanent {
+		return false
+	}
+	if len(defaults.Environments) == 0 {
+		return true
+	}
+	return util.StringArrayIndex(defaults.Environments, env.Name) >= 0
+}
+
+// LoadValues loads the autoscaling values of all the apps in the values YAML file of an environment chart
+// indexed by app name
+func LoadValues(valuesFile string) (map[string]*Values, error) {
+	answer := map[string]*Values{}
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return answer, err
+	}
+	for _, item := range values {
+		app, ok := item.Key.(string)
+		if !ok {
+			continue
+		}
+		appValues, ok := item.Value.(yamlv2.MapSlice)
+		if !ok {
+			continue
+		}
+		hpa := helm.GetValue(appValues, ValuesKey)
+		if hpa == nil {
+			continue
+		}
+		data, err := yamlv2.Marshal(hpa)
+		if err != nil {
+			return answer, err
+		}
+		v := &Values{}
+		err = yaml.Unmarshal(data, v)
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to parse the %s values of app %s in %s", ValuesKey, app, valuesFile)
+		}
+		answer[app] = v
+	}
+	return answer, nil
+}
+
+// GetValues returns the autoscaling values of the app in the values YAML file or nil if it has none
+func GetValues(valuesFile string, app string) (*Values, error) {
+	all, err := LoadValues(valuesFile)
+	if err != nil {
+		return nil, err
+	}
+	return all[app], nil
+}
+
+// SetValues sets the autoscaling values of the app in the values YAML file of an environment chart preserving the
+// other values. The autoscaling values are removed if the given values are nil
+func SetValues(valuesFile string, app string, v *Values) error {
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return err
+	}
+	appValues, _ := helm.GetValue(values, app).(yamlv2.MapSlice)
+	if v == nil {
+		appValues = helm.RemoveValue(appValues, ValuesKey)
+	} else {
+		hpa := yamlv2.MapSlice{{Key: "enabled", Value: v.Enabled}}
+		if v.MinReplicas > 0 {
+			hpa = append(hpa, yamlv2.MapItem{Key: "minReplicas", Value: v.MinReplicas})
+		}
+		if v.MaxReplicas > 0 {
+			hpa = append(hpa, yamlv2.MapItem{Key: "maxReplicas", Value: v.MaxReplicas})
+		}
+		if v.CPUTargetAverageUtilization > 0 {
+			hpa = append(hpa, yamlv2.MapItem{Key: "cpuTargetAverageUtilization", Value: v.CPUTargetAverageUtilization})
+		}
+		if v.MemoryTargetAverageUtilization > 0 {
+			hpa = append(hpa, yamlv2.MapItem{Key: "memoryTargetAverageUtilization", Value: v.MemoryTargetAverageUtilization})
+		}
+		appValues = helm.SetValue(appValues, ValuesKey, hpa)
+	}
+	if len(appValues) > 0 {
+		values = helm.SetValue(values, app, appValues)
+	} else {
+		values = helm.RemoveValue(values, app)
+	}
+	return helm.SaveValuesFile(valuesFile, values)
+}
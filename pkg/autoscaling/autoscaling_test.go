@@ -0,0 +1,133 @@
+package autoscaling_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/autoscaling"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/apps/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValuesValidate(t *testing.T) {
+	t.Parallel()
+	valid := &autoscaling.Values{Enabled: true, MinReplicas: 1, MaxReplicas: 3, CPUTargetAverageUtilization: 80}
+	assert.NoError(t, valid.Validate())
+	assert.NoError(t, (&autoscaling.Values{}).Validate(), "disabled values are not validated")
+
+	invalid := []*autoscaling.Values{
+		{Enabled: true, MinReplicas: 0, MaxReplicas: 3, CPUTargetAverageUtilization: 80},
+		{Enabled: true, MinReplicas: 4, MaxReplicas: 3, CPUTargetAverageUtilization: 80},
+		{Enabled: true, MinReplicas: 1, MaxReplicas: 3},
+		{Enabled: true, MinReplicas: 1, MaxReplicas: 3, CPUTargetAverageUtilization: -1, MemoryTargetAverageUtilization: 50},
+	}
+	for _, v := range invalid {
+		assert.Error(t, v.Validate(), "values %#v", v)
+	}
+}
+
+func TestDefaults(t *testing.T) {
+	t.Parallel()
+	values := autoscaling.NewValues(&v1.AutoscalingDefaults{MinReplicas: 2, TargetMemoryUtilization: 70})
+	assert.Equal(t, &autoscaling.Values{Enabled: true, MinReplicas: 2, MaxReplicas: 5, MemoryTargetAverageUtilization: 70}, values)
+	assert.NoError(t, values.Validate())
+
+	staging := &v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "staging"}, Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypePermanent}}
+	production := &v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "production"}, Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypePermanent}}
+	preview := &v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "preview"}, Spec: v1.EnvironmentSpec{Kind: v1.EnvironmentKindTypePreview}}
+
+	assert.False(t, autoscaling.DefaultsApply(nil, staging))
+	all := &v1.AutoscalingDefaults{}
+	assert.True(t, autoscaling.DefaultsApply(all, staging))
+	assert.False(t, autoscaling.DefaultsApply(all, preview))
+	prodOnly := &v1.AutoscalingDefaults{Environments: []string{"production"}}
+	assert.False(t, autoscaling.DefaultsApply(prodOnly, staging))
+	assert.True(t, autoscaling.DefaultsApply(prodOnly, production))
+}
+
+func TestSetValues(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-autoscaling")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	valuesFile := filepath.Join(dir, "values.yaml")
+	initial := `expose:
+  config:
+    domain: example.com
+myapp:
+  replicaCount: 2
+`
+	require.NoError(t, ioutil.WriteFile(valuesFile, []byte(initial), 0644))
+
+	values := &autoscaling.Values{Enabled: true, MinReplicas: 2, MaxReplicas: 10, CPUTargetAverageUtilization: 70}
+	require.NoError(t, autoscaling.SetValues(valuesFile, "myapp", values))
+	require.NoError(t, autoscaling.SetValues(valuesFile, "other", &autoscaling.Values{Enabled: false}))
+	data, err := ioutil.ReadFile(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, initial+`  hpa:
+    enabled: true
+    minReplicas: 2
+    maxReplicas: 10
+    cpuTargetAverageUtilization: 70
+other:
+  hpa:
+    enabled: false
+`, string(data))
+
+	all, err := autoscaling.LoadValues(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]*autoscaling.Values{"myapp": values, "other": {}}, all)
+
+	require.NoError(t, autoscaling.SetValues(valuesFile, "myapp", nil))
+	require.NoError(t, autoscaling.SetValues(valuesFile, "other", nil))
+	data, err = ioutil.ReadFile(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, initial, string(data))
+}
+
+func TestApplyAutoscalers(t *testing.T) {
+	t.Parallel()
+	ns := "jx-staging"
+	client := fake.NewSimpleClientset(
+		&v1beta1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "jx-staging-myapp", Namespace: ns}},
+		&v1beta1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "jx-staging-other", Namespace: ns}},
+	)
+	assert.Error(t, autoscaling.ValidateMetricsServer(client))
+	client.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{
+		{GroupVersion: autoscaling.MetricsGroupVersion},
+	}
+	assert.NoError(t, autoscaling.ValidateMetricsServer(client))
+
+	values := map[string]*autoscaling.Values{
+		"myapp": {Enabled: true, MinReplicas: 2, MaxReplicas: 10, CPUTargetAverageUtilization: 70, MemoryTargetAverageUtilization: 80},
+		"other": {Enabled: true, MinReplicas: 1, MaxReplicas: 3, CPUTargetAverageUtilization: 50},
+	}
+	require.NoError(t, autoscaling.ApplyAutoscalers(client, ns, values))
+
+	hpas := client.AutoscalingV2beta1().HorizontalPodAutoscalers(ns)
+	hpa, err := hpas.Get("myapp", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "jx-staging-myapp", hpa.Spec.ScaleTargetRef.Name)
+	assert.Equal(t, int32(2), *hpa.Spec.MinReplicas)
+	assert.Equal(t, int32(10), hpa.Spec.MaxReplicas)
+	assert.Len(t, hpa.Spec.Metrics, 2)
+
+	// removing the values of an app removes its autoscaler
+	delete(values, "other")
+	require.NoError(t, autoscaling.ApplyAutoscalers(client, ns, values))
+	_, err = hpas.Get("other", metav1.GetOptions{})
+	assert.Error(t, err)
+	_, err = hpas.Get("myapp", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	values["missing"] = &autoscaling.Values{Enabled: true, MinReplicas: 1, MaxReplicas: 3, CPUTargetAverageUtilization: 50}
+	assert.Error(t, autoscaling.ApplyAutoscalers(client, ns, values), "there is no deployment for the app")
+}
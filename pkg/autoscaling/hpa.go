@@ -0,0 +1,165 @@
+package autoscaling
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	autoscalingv2 "k8s.io/api/autoscaling/v2beta1"
+	"k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LabelValueAutoscaler the value of the kind label of the HorizontalPodAutoscalers generated for apps
+	LabelValueAutoscaler = "autoscaler"
+
+	// LabelApp the label of the app a generated HorizontalPodAutoscaler belongs to
+	LabelApp = "app"
+
+	// MetricsGroupVersion the API group version served by the metrics-server which HorizontalPodAutoscalers
+	// need to query the CPU and memory usage of pods
+	MetricsGroupVersion = "metrics.k8s.io/v1beta1"
+)
+
+// ValidateMetricsServer returns an error if the metrics API is not available in the cluster
+func ValidateMetricsServer(client kubernetes.Interface) error {
+	_, err := client.Discovery().ServerResourcesForGroupVersion(MetricsGroupVersion)
+	if err != nil {
+		return fmt.Errorf("the metrics API %s is not available so HorizontalPodAutoscalers cannot scale on CPU or memory. Please install the metrics-server: %s", MetricsGroupVersion, err)
+	}
+	return nil
+}
+
+// FindDeploymentName returns the name of the Deployment of the app in the namespace
+func FindDeploymentName(client kubernetes.Interface, ns string, app string) (string, error) {
+	deployments, err := kube.GetDeployments(client, ns)
+	if err != nil {
+		return "", err
+	}
+	if _, ok := deployments[app]; ok {
+		return app, nil
+	}
+	for name := range deployments {
+		if kube.GetAppName(name, ns) == app {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("no Deployment found for app %s in namespace %s", app, ns)
+}
+
+// NewHorizontalPodAutoscaler creates a HorizontalPodAutoscaler for the Deployment of the app
+func NewHorizontalPodAutoscaler(app string, deployment string, v *Values) *autoscalingv2.HorizontalPodAutoscaler {
+	minReplicas := v.MinReplicas
+	metrics := []autoscalingv2.MetricSpec{}
+	if v.CPUTargetAverageUtilization > 0 {
+		target := v.CPUTargetAverageUtilization
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:                     v1.ResourceCPU,
+				TargetAverageUtilization: &target,
+			},
+		})
+	}
+	if v.MemoryTargetAverageUtilization > 0 {
+		target := v.MemoryTargetAverageUtilization
+		metrics = append(metrics, autoscalingv2.MetricSpec{
+			Type: autoscalingv2.ResourceMetricSourceType,
+			Resource: &autoscalingv2.ResourceMetricSource{
+				Name:                     v1.ResourceMemory,
+				TargetAverageUtilization: &target,
+			},
+		})
+	}
+	return &autoscalingv2.HorizontalPodAutoscaler{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: app,
+			Labels: map[string]string{
+				kube.LabelKind: LabelValueAutoscaler,
+				LabelApp:       app,
+			},
+		},
+		Spec: autoscalingv2.HorizontalPodAutoscalerSpec{
+			ScaleTargetRef: autoscalingv2.CrossVersionObjectReference{
+				APIVersion: "extensions/v1beta1",
+				Kind:       "Deployment",
+				Name:       deployment,
+			},
+			MinReplicas: &minReplicas,
+			MaxReplicas: v.MaxReplicas,
+			Metrics:     metrics,
+		},
+	}
+}
+
+// ApplyAutoscaler creates or updates the HorizontalPodAutoscaler of the app or deletes it if the values are nil
+// or not enabled
+func ApplyAutoscaler(client kubernetes.Interface, ns string, app string, v *Values) error {
+	hpas := client.AutoscalingV2beta1().HorizontalPodAutoscalers(ns)
+	if v == nil || !v.Enabled {
+		// lets only remove autoscalers we generated
+		existing, err := hpas.Get(app, metav1.GetOptions{})
+		if err != nil || existing.Labels[kube.LabelKind] != LabelValueAutoscaler {
+			return nil
+		}
+		err = hpas.Delete(app, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return errors.Wrapf(err, "failed to delete HorizontalPodAutoscaler %s in namespace %s", app, ns)
+		}
+		return nil
+	}
+	err := v.Validate()
+	if err != nil {
+		return errors.Wrapf(err, "invalid autoscaling values for app %s", app)
+	}
+	deployment, err := FindDeploymentName(client, ns, app)
+	if err != nil {
+		return err
+	}
+	hpa := NewHorizontalPodAutoscaler(app, deployment, v)
+	existing, err := hpas.Get(hpa.Name, metav1.GetOptions{})
+	if err == nil {
+		existing.Labels = hpa.Labels
+		existing.Spec = hpa.Spec
+		_, err = hpas.Update(existing)
+	} else {
+		_, err = hpas.Create(hpa)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save HorizontalPodAutoscaler %s in namespace %s", hpa.Name, ns)
+	}
+	return nil
+}
+
+// ApplyAutoscalers creates or updates the HorizontalPodAutoscalers of the apps with enabled autoscaling values and
+// deletes the generated HorizontalPodAutoscalers of any other apps in the namespace
+func ApplyAutoscalers(client kubernetes.Interface, ns string, values map[string]*Values) error {
+	for app, v := range values {
+		if v.Enabled {
+			err := ApplyAutoscaler(client, ns, app, v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	hpas := client.AutoscalingV2beta1().HorizontalPodAutoscalers(ns)
+	list, err := hpas.List(metav1.ListOptions{LabelSelector: fmt.Sprintf("%s=%s", kube.LabelKind, LabelValueAutoscaler)})
+	if err != nil {
+		return err
+	}
+	for _, hpa := range list.Items {
+		app := hpa.Labels[LabelApp]
+		v := values[app]
+		if v == nil || !v.Enabled {
+			err = ApplyAutoscaler(client, ns, app, nil)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,345 @@
+package benchmark
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/kube"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Status the outcome of a check
+type Status string
+
+const (
+	// StatusPass the installation complies with the check
+	StatusPass Status = "PASS"
+	// StatusWarn the installation should be reviewed as it may not comply with the check
+	StatusWarn Status = "WARN"
+	// StatusFail the installation does not comply with the check
+	StatusFail Status = "FAIL"
+
+	// ClusterAdminRole the cluster role which grants full access to the cluster
+	ClusterAdminRole = "cluster-admin"
+
+	dockerSocket = "/var/run/docker.sock"
+)
+
+var (
+	// broadGroups the groups which contain every user or service account of the cluster
+	broadGroups = []string{"system:authenticated", "system:unauthenticated", "system:serviceaccounts"}
+
+	// tillerSelector the labels of the tiller deployment and service created by helm init
+	tillerSelector = "app=helm,name=tiller"
+)
+
+// Options the namespaces the checks audit
+type Options struct {
+	// Namespace the development namespace of the team
+	Namespace string
+	// TillerNamespace the namespace tiller is installed in
+	TillerNamespace string
+}
+
+// Check a CIS inspired check of how jx installed the components of a team
+type Check struct {
+	// ID the identifier of the check along with the section of the CIS Kubernetes benchmark which inspired it
+	ID          string
+	Title       string
+	Remediation string
+	Run         func(client kubernetes.Interface, options Options) (Status, []string, error)
+}
+
+// Result the outcome of running a check
+type Result struct {
+	Check    *Check
+	Status   Status
+	Findings []string
+}
+
+// Checks returns the checks in the order they are run
+func Checks() []*Check {
+	return []*Check{
+		{
+			ID:    "JX-5.1.1",
+			Title: "Ensure that the cluster-admin role is only used where required",
+			Remediation: "Delete the ClusterRoleBindings listed above with 'kubectl delete clusterrolebinding <name>' and grant the " +
+				"service accounts a Role in the namespaces they need instead. Tiller can be removed entirely with 'jx edit helmbin helm3'",
+			Run: checkClusterAdminBindings,
+		},
+		{
+			ID:    "JX-5.1.3",
+			Title: "Minimize wildcard use and broad groups in role bindings",
+			Remediation: "Replace the subjects 'system:authenticated', 'system:unauthenticated' and 'system:serviceaccounts' " +
+				"of the bindings listed above with the users or service accounts which need the access",
+			Run: checkBroadBindings,
+		},
+		{
+			ID:    "JX-5.4.1",
+			Title: "Prefer using secrets as files over secrets as environment variables",
+			Remediation: "Mount the secrets as volumes instead of using 'secretKeyRef' environment variables, for build pods use " +
+				"'jx edit buildpod <podTemplate> --secret <name>=<mountPath>'",
+			Run: checkSecretEnvVars,
+		},
+		{
+			ID:    "JX-5.2.1",
+			Title: "Minimize the admission of privileged build pods",
+			Remediation: "Build images with kaniko rather than the docker daemon of the node, then remove the privileged security " +
+				"context and the " + dockerSocket + " hostPath volume from the pod templates in the ConfigMap " +
+				kube.ConfigMapJenkinsPodTemplates + ". Sidecars added with 'jx edit buildpod --sidecar' can be removed with " +
+				"'jx edit buildpod --reset'",
+			Run: checkPrivilegedBuildPods,
+		},
+		{
+			ID:    "JX-HELM-1",
+			Title: "Ensure that tiller is not exposed outside the cluster and uses TLS",
+			Remediation: "Change the tiller service to type ClusterIP, secure tiller with 'helm init --upgrade --tiller-tls-verify' " +
+				"or stop using tiller with 'jx edit helmbin helm3'",
+			Run: checkTiller,
+		},
+	}
+}
+
+// Run runs all the checks. Checks which cannot be run because the current user is not allowed to read the resources
+// they audit are reported as warnings
+func Run(client kubernetes.Interface, options Options) ([]*Result, error) {
+	if options.TillerNamespace == "" {
+		options.TillerNamespace = kube.DefaultTillerNamespace
+	}
+	answer := []*Result{}
+	for _, check := range Checks() {
+		status, findings, err := check.Run(client, options)
+		if err != nil {
+			if !errors.IsForbidden(err) {
+				return answer, fmt.Errorf("failed to run check %s: %s", check.ID, err)
+			}
+			status = StatusWarn
+			findings = []string{fmt.Sprintf("could not run the check: %s", err)}
+		}
+		answer = append(answer, &Result{Check: check, Status: status, Findings: findings})
+	}
+	return answer, nil
+}
+
+// Failed returns the number of failed results
+func Failed(results []*Result) int {
+	count := 0
+	for _, r := range results {
+		if r.Status == StatusFail {
+			count++
+		}
+	}
+	return count
+}
+
+func checkClusterAdminBindings(client kubernetes.Interface, options Options) (Status, []string, error) {
+	bindings, err := client.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+	if err != nil {
+		return StatusFail, nil, err
+	}
+	findings := []string{}
+	for _, b := range bindings.Items {
+		if b.RoleRef.Kind != "ClusterRole" || b.RoleRef.Name != ClusterAdminRole {
+			continue
+		}
+		for _, s := range b.Subjects {
+			if s.Kind == rbacv1.ServiceAccountKind {
+				findings = append(findings, fmt.Sprintf("ClusterRoleBinding %s grants %s to ServiceAccount %s/%s", b.Name, ClusterAdminRole, s.Namespace, s.Name))
+			}
+		}
+	}
+	return statusOf(findings, StatusFail), sorted(findings), nil
+}
+
+func checkBroadBindings(client kubernetes.Interface, options Options) (Status, []string, error) {
+	findings := []string{}
+	clusterBindings, err := client.RbacV1().ClusterRoleBindings().List(metav1.ListOptions{})
+	if err != nil {
+		return StatusFail, nil, err
+	}
+	for _, b := range clusterBindings.Items {
+		// the default bindings of the cluster such as system:discovery are expected to use broad groups
+		if strings.HasPrefix(b.Name, "system:") {
+			continue
+		}
+		for _, group := range broadGroupSubjects(b.Subjects) {
+			findings = append(findings, fmt.Sprintf("ClusterRoleBinding %s grants %s %s to group %s", b.Name, strings.ToLower(b.RoleRef.Kind), b.RoleRef.Name, group))
+		}
+	}
+	if options.Namespace != "" {
+		bindings, err := client.RbacV1().RoleBindings(options.Namespace).List(metav1.ListOptions{})
+		if err != nil {
+			return StatusFail, nil, err
+		}
+		for _, b := range bindings.Items {
+			for _, group := range broadGroupSubjects(b.Subjects) {
+				findings = append(findings, fmt.Sprintf("RoleBinding %s/%s grants %s %s to group %s", b.Namespace, b.Name, strings.ToLower(b.RoleRef.Kind), b.RoleRef.Name, group))
+			}
+		}
+	}
+	return statusOf(findings, StatusFail), sorted(findings), nil
+}
+
+func checkSecretEnvVars(client kubernetes.Interface, options Options) (Status, []string, error) {
+	findings := []string{}
+	deployments, err := client.AppsV1beta1().Deployments(options.Namespace).List(metav1.ListOptions{})
+	if err != nil {
+		return StatusWarn, nil, err
+	}
+	for _, d := range deployments.Items {
+		for _, name := range secretEnvVars(&d.Spec.Template.Spec) {
+			findings = append(findings, fmt.Sprintf("Deployment %s uses secret %s", d.Name, name))
+		}
+	}
+	templates, err := buildPodTemplates(client, options.Namespace)
+	if err != nil {
+		return StatusWarn, nil, err
+	}
+	for name, pod := range templates {
+		for _, secret := range secretEnvVars(&pod.Spec) {
+			findings = append(findings, fmt.Sprintf("build pod template %s uses secret %s", name, secret))
+		}
+	}
+	return statusOf(findings, StatusWarn), sorted(findings), nil
+}
+
+func checkPrivilegedBuildPods(client kubernetes.Interface, options Options) (Status, []string, error) {
+	findings := []string{}
+	templates, err := buildPodTemplates(client, options.Namespace)
+	if err != nil {
+		return StatusFail, nil, err
+	}
+	for name, pod := range templates {
+		for _, c := range pod.Spec.Containers {
+			if c.SecurityContext != nil && c.SecurityContext.Privileged != nil && *c.SecurityContext.Privileged {
+				findings = append(findings, fmt.Sprintf("build pod template %s runs container %s privileged", name, c.Name))
+			}
+		}
+		for _, v := range pod.Spec.Volumes {
+			if v.HostPath != nil && v.HostPath.Path == dockerSocket {
+				findings = append(findings, fmt.Sprintf("build pod template %s mounts the docker socket of the node", name))
+			}
+		}
+	}
+	return statusOf(findings, StatusFail), sorted(findings), nil
+}
+
+func checkTiller(client kubernetes.Interface, options Options) (Status, []string, error) {
+	ns := options.TillerNamespace
+	findings := []string{}
+	status := StatusPass
+	services, err := client.CoreV1().Services(ns).List(metav1.ListOptions{LabelSelector: tillerSelector})
+	if err != nil {
+		return StatusFail, nil, err
+	}
+	for _, s := range services.Items {
+		if s.Spec.Type == corev1.ServiceTypeLoadBalancer || s.Spec.Type == corev1.ServiceTypeNodePort {
+			findings = append(findings, fmt.Sprintf("Service %s/%s exposes tiller outside the cluster using a %s", ns, s.Name, s.Spec.Type))
+			status = StatusFail
+		}
+	}
+	deployments, err := client.AppsV1beta1().Deployments(ns).List(metav1.ListOptions{LabelSelector: tillerSelector})
+	if err != nil {
+		return StatusFail, nil, err
+	}
+	for _, d := range deployments.Items {
+		for _, c := range d.Spec.Template.Spec.Containers {
+			if c.Name != "tiller" {
+				continue
+			}
+			if !tillerUsesTLS(&c) {
+				findings = append(findings, fmt.Sprintf("Deployment %s/%s runs tiller without TLS so any pod in the cluster can install charts", ns, d.Name))
+				if status == StatusPass {
+					status = StatusWarn
+				}
+			}
+		}
+	}
+	return status, findings, nil
+}
+
+func tillerUsesTLS(c *corev1.Container) bool {
+	for _, e := range c.Env {
+		if e.Name == "TILLER_TLS_VERIFY" && e.Value == "1" {
+			return true
+		}
+	}
+	for _, arg := range append(append([]string{}, c.Command...), c.Args...) {
+		if arg == "--tls-verify" || arg == "--tls-verify=true" {
+			return true
+		}
+	}
+	return false
+}
+
+// buildPodTemplates returns the build pod templates of the team indexed by name. There are no pod templates if the
+// team does not use Jenkins
+func buildPodTemplates(client kubernetes.Interface, ns string) (map[string]*corev1.Pod, error) {
+	answer := map[string]*corev1.Pod{}
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return answer, nil
+		}
+		return answer, err
+	}
+	for name, text := range cm.Data {
+		pod := &corev1.Pod{}
+		err = yaml.Unmarshal([]byte(text), pod)
+		if err != nil {
+			return answer, fmt.Errorf("failed to parse pod template %s: %s", name, err)
+		}
+		answer[name] = pod
+	}
+	return answer, nil
+}
+
+func secretEnvVars(spec *corev1.PodSpec) []string {
+	answer := []string{}
+	for _, c := range spec.Containers {
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.SecretKeyRef != nil {
+				answer = append(answer, fmt.Sprintf("%s as environment variable %s of container %s", e.ValueFrom.SecretKeyRef.Name, e.Name, c.Name))
+			}
+		}
+		for _, from := range c.EnvFrom {
+			if from.SecretRef != nil {
+				answer = append(answer, fmt.Sprintf("%s as environment variables of container %s", from.SecretRef.Name, c.Name))
+			}
+		}
+	}
+	return answer
+}
+
+func broadGroupSubjects(subjects []rbacv1.Subject) []string {
+	answer := []string{}
+	for _, s := range subjects {
+		if s.Kind != rbacv1.GroupKind {
+			continue
+		}
+		for _, group := range broadGroups {
+			if s.Name == group {
+				answer = append(answer, group)
+			}
+		}
+	}
+	return answer
+}
+
+func statusOf(findings []string, status Status) Status {
+	if len(findings) == 0 {
+		return StatusPass
+	}
+	return status
+}
+
+func sorted(values []string) []string {
+	sort.Strings(values)
+	return values
+}
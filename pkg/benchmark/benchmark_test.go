@@ -0,0 +1,120 @@
+package benchmark_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/benchmark"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const ns = "jx"
+
+func findResult(t *testing.T, results []*benchmark.Result, id string) *benchmark.Result {
+	for _, r := range results {
+		if r.Check.ID == id {
+			return r
+		}
+	}
+	require.Fail(t, "no result for check "+id)
+	return nil
+}
+
+func TestRunCompliantInstallation(t *testing.T) {
+	t.Parallel()
+	results, err := benchmark.Run(fake.NewSimpleClientset(), benchmark.Options{Namespace: ns})
+	require.NoError(t, err)
+	assert.Len(t, results, len(benchmark.Checks()))
+	for _, r := range results {
+		assert.Equal(t, benchmark.StatusPass, r.Status, "check %s", r.Check.ID)
+	}
+	assert.Equal(t, 0, benchmark.Failed(results))
+}
+
+func TestRunNonCompliantInstallation(t *testing.T) {
+	t.Parallel()
+	privileged := true
+	maven, err := yaml.Marshal(&corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{
+					Name:            "maven",
+					SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+					Env: []corev1.EnvVar{
+						{Name: "GIT_TOKEN", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "jx-pipeline-git"},
+							Key:                  "password",
+						}}},
+					},
+				},
+			},
+			Volumes: []corev1.Volume{
+				{Name: "docker-sock", VolumeSource: corev1.VolumeSource{HostPath: &corev1.HostPathVolumeSource{Path: "/var/run/docker.sock"}}},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tiller := &appsv1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "tiller-deploy", Namespace: kube.DefaultTillerNamespace, Labels: map[string]string{"app": "helm", "name": "tiller"}},
+	}
+	tiller.Spec.Template.Spec.Containers = []corev1.Container{{Name: "tiller"}}
+
+	client := fake.NewSimpleClientset(
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "tiller"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: benchmark.ClusterAdminRole},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Namespace: "kube-system", Name: "tiller"}},
+		},
+		&rbacv1.ClusterRoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "cluster-admin"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: benchmark.ClusterAdminRole},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:masters"}},
+		},
+		&rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{Name: "everyone", Namespace: ns},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "viewer"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:authenticated"}},
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: kube.ConfigMapJenkinsPodTemplates, Namespace: ns},
+			Data:       map[string]string{"maven": string(maven)},
+		},
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "tiller-deploy", Namespace: kube.DefaultTillerNamespace, Labels: map[string]string{"app": "helm", "name": "tiller"}},
+			Spec:       corev1.ServiceSpec{Type: corev1.ServiceTypeLoadBalancer},
+		},
+		tiller,
+	)
+
+	results, err := benchmark.Run(client, benchmark.Options{Namespace: ns})
+	require.NoError(t, err)
+	assert.Equal(t, 4, benchmark.Failed(results))
+
+	r := findResult(t, results, "JX-5.1.1")
+	assert.Equal(t, benchmark.StatusFail, r.Status)
+	assert.Equal(t, []string{"ClusterRoleBinding tiller grants cluster-admin to ServiceAccount kube-system/tiller"}, r.Findings)
+
+	r = findResult(t, results, "JX-5.1.3")
+	assert.Equal(t, benchmark.StatusFail, r.Status)
+	assert.Equal(t, []string{"RoleBinding jx/everyone grants role viewer to group system:authenticated"}, r.Findings)
+
+	r = findResult(t, results, "JX-5.4.1")
+	assert.Equal(t, benchmark.StatusWarn, r.Status)
+	assert.Len(t, r.Findings, 1)
+
+	r = findResult(t, results, "JX-5.2.1")
+	assert.Equal(t, benchmark.StatusFail, r.Status)
+	assert.Len(t, r.Findings, 2)
+
+	r = findResult(t, results, "JX-HELM-1")
+	assert.Equal(t, benchmark.StatusFail, r.Status)
+	assert.Len(t, r.Findings, 2)
+}
@@ -1,6 +1,8 @@
 package builds
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -30,3 +32,52 @@ func GetBuildPods(kubeClient kubernetes.Interface, ns string) ([]*corev1.Pod, er
 	}
 	return answer, nil
 }
+
+// GetBuildPodSelector returns the label selector of the pods of the build of the given pod
+func GetBuildPodSelector(pod *corev1.Pod) string {
+	for _, label := range []string{LabelBuildName, LabelOldBuildName} {
+		if value := pod.Labels[label]; value != "" {
+			return label + "=" + value
+		}
+	}
+	return ""
+}
+
+// FindLatestBuildPod returns the most recently created build pod of the repository and, if specified, the branch.
+// The repository may be qualified by its owner such as 'myorg/myapp'. Returns nil if there is no matching pod
+func FindLatestBuildPod(pods []*corev1.Pod, repository string, branch string) *corev1.Pod {
+	owner := ""
+	if idx := strings.Index(repository, "/"); idx > 0 {
+		owner = repository[0:idx]
+		repository = repository[idx+1:]
+	}
+	var answer *corev1.Pod
+	for _, pod := range pods {
+		env := buildPodEnv(pod)
+		if env["REPO_NAME"] != repository || (owner != "" && env["REPO_OWNER"] != owner) {
+			continue
+		}
+		if branch != "" && !strings.EqualFold(env["BRANCH_NAME"], branch) {
+			continue
+		}
+		if answer == nil || answer.CreationTimestamp.Before(&pod.CreationTimestamp) {
+			answer = pod
+		}
+	}
+	return answer
+}
+
+// buildPodEnv returns the environment variables describing the build of the pod
+func buildPodEnv(pod *corev1.Pod) map[string]string {
+	answer := map[string]string{}
+	containers := append([]corev1.Container{}, pod.Spec.InitContainers...)
+	containers = append(containers, pod.Spec.Containers...)
+	for _, c := range containers {
+		for _, env := range c.Env {
+			if env.Value != "" && answer[env.Name] == "" {
+				answer[env.Name] = env.Value
+			}
+		}
+	}
+	return answer
+}
@@ -0,0 +1,61 @@
+package builds_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func buildPod(name string, owner string, repo string, branch string, created time.Time) *corev1.Pod {
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels: map[string]string{
+				builds.LabelBuildName: name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			InitContainers: []corev1.Container{
+				{
+					Name: "build-step-git-source",
+					Env: []corev1.EnvVar{
+						{Name: "REPO_OWNER", Value: owner},
+						{Name: "REPO_NAME", Value: repo},
+						{Name: "BRANCH_NAME", Value: branch},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestFindLatestBuildPod(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	pods := []*corev1.Pod{
+		buildPod("myorg-myapp-master-1", "myorg", "myapp", "master", now.Add(-2*time.Hour)),
+		buildPod("myorg-myapp-master-2", "myorg", "myapp", "master", now.Add(-time.Hour)),
+		buildPod("myorg-myapp-pr-3-1", "myorg", "myapp", "PR-3", now),
+		buildPod("other-myapp-master-1", "other", "myapp", "master", now.Add(time.Hour)),
+	}
+
+	pod := builds.FindLatestBuildPod(pods, "myorg/myapp", "master")
+	require.NotNil(t, pod)
+	assert.Equal(t, "myorg-myapp-master-2", pod.Name)
+
+	pod = builds.FindLatestBuildPod(pods, "myorg/myapp", "")
+	require.NotNil(t, pod)
+	assert.Equal(t, "myorg-myapp-pr-3-1", pod.Name)
+
+	pod = builds.FindLatestBuildPod(pods, "myapp", "")
+	require.NotNil(t, pod)
+	assert.Equal(t, "other-myapp-master-1", pod.Name)
+
+	assert.Nil(t, builds.FindLatestBuildPod(pods, "myorg/another", ""))
+}
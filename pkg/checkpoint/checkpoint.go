@@ -0,0 +1,140 @@
+package checkpoint
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// VerifyFunc returns true if the work of a completed step is still in place
+type VerifyFunc func() (bool, error)
+
+// Step a completed step of a checkpoint
+type Step struct {
+	Name      string    `json:"name"`
+	Completed time.Time `json:"completed"`
+}
+
+// Checkpoint records the completed steps of a long running operation such as an install to a file so that the
+// operation can be resumed from the first incomplete step after it is interrupted
+type Checkpoint struct {
+	// Context the kubernetes context the operation runs against
+	Context string `json:"context"`
+	// Namespace the namespace the operation runs against
+	Namespace string `json:"namespace"`
+	Steps     []Step `json:"steps,omitempty"`
+
+	fileName string
+	resuming bool
+	recorded []Step
+}
+
+// Load loads the checkpoint from the file. If resume is false or the file does not exist a new checkpoint is
+// started. An error is returned when resuming a checkpoint of a different context or namespace
+func Load(fileName string, context string, ns string, resume bool) (*Checkpoint, error) {
+	c := &Checkpoint{
+		Context:   context,
+		Namespace: ns,
+		fileName:  fileName,
+	}
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if !resume || !exists {
+		if resume {
+			log.Warnf("No checkpoint found at %s so starting from the beginning\n", fileName)
+		}
+		return c, c.save()
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load file %s due to %s", fileName, err)
+	}
+	loaded := &Checkpoint{}
+	err = yaml.Unmarshal(data, loaded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the checkpoint %s: %s", fileName, err)
+	}
+	if loaded.Context != context || loaded.Namespace != ns {
+		return nil, fmt.Errorf("the checkpoint %s is for context %s and namespace %s rather than context %s and namespace %s",
+			fileName, loaded.Context, loaded.Namespace, context, ns)
+	}
+	c.recorded = loaded.Steps
+	c.resuming = len(c.recorded) > 0
+	return c, nil
+}
+
+// IsResuming returns true while the steps completed before the operation was interrupted are being skipped
+func (c *Checkpoint) IsResuming() bool {
+	return c.resuming
+}
+
+// IsCompleted returns true if the step was recorded as completed before the operation was interrupted
+func (c *Checkpoint) IsCompleted(name string) bool {
+	return c.recordedStep(name) != nil
+}
+
+func (c *Checkpoint) recordedStep(name string) *Step {
+	for i := range c.recorded {
+		if c.recorded[i].Name == name {
+			return &c.recorded[i]
+		}
+	}
+	return nil
+}
+
+// Run runs the step and records it as completed. When resuming, a step which was completed is skipped if the
+// verify function confirms its work is still in place. The first step which is not skipped ends the resume
+// so that every following step runs again
+func (c *Checkpoint) Run(name string, verify VerifyFunc, fn func() error) error {
+	if c.resuming {
+		if step := c.recordedStep(name); step != nil {
+			ok, err := verify()
+			if err == nil && ok {
+				log.Infof("Skipping the completed step %s\n", util.ColorInfo(name))
+				c.Steps = append(c.Steps, *step)
+				return nil
+			}
+			if err != nil {
+				log.Warnf("Failed to verify the completed step %s so running it again: %s\n", name, err)
+			} else {
+				log.Warnf("The completed step %s is no longer in place so running it again\n", name)
+			}
+		}
+		log.Infof("Resuming at step %s\n", util.ColorInfo(name))
+		c.resuming = false
+	}
+	err := fn()
+	if err != nil {
+		return err
+	}
+	c.Steps = append(c.Steps, Step{Name: name, Completed: time.Now()})
+	return c.save()
+}
+
+// Done removes the checkpoint file once the operation has completed
+func (c *Checkpoint) Done() error {
+	err := os.Remove(c.fileName)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func (c *Checkpoint) save() error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(c.fileName, data, util.DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to save the checkpoint %s: %s", c.fileName, err)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package checkpoint_test
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/checkpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpointResume(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-checkpoint-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "checkpoint.yml")
+
+	inPlace := map[string]bool{}
+	ran := []string{}
+	runSteps := func(c *checkpoint.Checkpoint, failAt string) error {
+		for _, name := range []string{"init", "platform", "addons", "environments"} {
+			step := name
+			verify := func() (bool, error) {
+				return inPlace[step], nil
+			}
+			err := c.Run(step, verify, func() error {
+				if step == failAt {
+					return fmt.Errorf("failed to run %s", step)
+				}
+				ran = append(ran, step)
+				inPlace[step] = true
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	c, err := checkpoint.Load(fileName, "gke", "jx", false)
+	require.NoError(t, err)
+	assert.Error(t, runSteps(c, "addons"))
+	assert.Equal(t, []string{"init", "platform"}, ran)
+
+	_, err = checkpoint.Load(fileName, "minikube", "jx", true)
+	assert.Error(t, err, "a checkpoint of another context should not be resumed")
+
+	// the platform chart was removed after the interruption so it must be installed again
+	inPlace["platform"] = false
+	ran = []string{}
+	c, err = checkpoint.Load(fileName, "gke", "jx", true)
+	require.NoError(t, err)
+	assert.True(t, c.IsResuming())
+	assert.True(t, c.IsCompleted("platform"))
+	assert.False(t, c.IsCompleted("addons"))
+	require.NoError(t, runSteps(c, ""))
+	assert.Equal(t, []string{"platform", "addons", "environments"}, ran)
+	assert.False(t, c.IsResuming())
+
+	ran = []string{}
+	c, err = checkpoint.Load(fileName, "gke", "jx", true)
+	require.NoError(t, err)
+	require.NoError(t, runSteps(c, ""))
+	assert.Empty(t, ran, "all the verified steps should be skipped")
+
+	require.NoError(t, c.Done())
+	_, err = os.Stat(fileName)
+	assert.True(t, os.IsNotExist(err))
+}
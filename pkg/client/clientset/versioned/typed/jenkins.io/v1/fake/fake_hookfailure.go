@@ -0,0 +1,124 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	jenkinsiov1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeHookFailures implements HookFailureInterface
+type FakeHookFailures struct {
+	Fake *FakeJenkinsV1
+	ns   string
+}
+
+var hookfailuresResource = schema.GroupVersionResource{Group: "jenkins.io", Version: "v1", Resource: "hookfailures"}
+
+var hookfailuresKind = schema.GroupVersionKind{Group: "jenkins.io", Version: "v1", Kind: "HookFailure"}
+
+// Get takes name of the hookFailure, and returns the corresponding hookFailure object, and an error if there is any.
+func (c *FakeHookFailures) Get(name string, options v1.GetOptions) (result *jenkinsiov1.HookFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(hookfailuresResource, c.ns, name), &jenkinsiov1.HookFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.HookFailure), err
+}
+
+// List takes label and field selectors, and returns the list of HookFailures that match those selectors.
+func (c *FakeHookFailures) List(opts v1.ListOptions) (result *jenkinsiov1.HookFailureList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(hookfailuresResource, hookfailuresKind, c.ns, opts), &jenkinsiov1.HookFailureList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &jenkinsiov1.HookFailureList{ListMeta: obj.(*jenkinsiov1.HookFailureList).ListMeta}
+	for _, item := range obj.(*jenkinsiov1.HookFailureList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested hookFailures.
+func (c *FakeHookFailures) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(hookfailuresResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a hookFailure and creates it.  Returns the server's representation of the hookFailure, and an error, if there is any.
+func (c *FakeHookFailures) Create(hookFailure *jenkinsiov1.HookFailure) (result *jenkinsiov1.HookFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(hookfailuresResource, c.ns, hookFailure), &jenkinsiov1.HookFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.HookFailure), err
+}
+
+// Update takes the representation of a hookFailure and updates it. Returns the server's representation of the hookFailure, and an error, if there is any.
+func (c *FakeHookFailures) Update(hookFailure *jenkinsiov1.HookFailure) (result *jenkinsiov1.HookFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(hookfailuresResource, c.ns, hookFailure), &jenkinsiov1.HookFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.HookFailure), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeHookFailures) UpdateStatus(hookFailure *jenkinsiov1.HookFailure) (*jenkinsiov1.HookFailure, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(hookfailuresResource, "status", c.ns, hookFailure), &jenkinsiov1.HookFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.HookFailure), err
+}
+
+// Delete takes name of the hookFailure and deletes it. Returns an error if one occurs.
+func (c *FakeHookFailures) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(hookfailuresResource, c.ns, name), &jenkinsiov1.HookFailure{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeHookFailures) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(hookfailuresResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &jenkinsiov1.HookFailureList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched hookFailure.
+func (c *FakeHookFailures) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *jenkinsiov1.HookFailure, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(hookfailuresResource, c.ns, name, data, subresources...), &jenkinsiov1.HookFailure{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.HookFailure), err
+}
@@ -12,6 +12,10 @@ type FakeJenkinsV1 struct {
 	*testing.Fake
 }
 
+func (c *FakeJenkinsV1) Addons(namespace string) v1.AddonInterface {
+	return &FakeAddons{c, namespace}
+}
+
 func (c *FakeJenkinsV1) Environments(namespace string) v1.EnvironmentInterface {
 	return &FakeEnvironments{c, namespace}
 }
@@ -24,14 +28,26 @@ func (c *FakeJenkinsV1) GitServices(namespace string) v1.GitServiceInterface {
 	return &FakeGitServices{c, namespace}
 }
 
+func (c *FakeJenkinsV1) HookFailures(namespace string) v1.HookFailureInterface {
+	return &FakeHookFailures{c, namespace}
+}
+
 func (c *FakeJenkinsV1) PipelineActivities(namespace string) v1.PipelineActivityInterface {
 	return &FakePipelineActivities{c, namespace}
 }
 
+func (c *FakeJenkinsV1) Promotions(namespace string) v1.PromotionInterface {
+	return &FakePromotions{c, namespace}
+}
+
 func (c *FakeJenkinsV1) Releases(namespace string) v1.ReleaseInterface {
 	return &FakeReleases{c, namespace}
 }
 
+func (c *FakeJenkinsV1) SourceRepositories(namespace string) v1.SourceRepositoryInterface {
+	return &FakeSourceRepositories{c, namespace}
+}
+
 func (c *FakeJenkinsV1) Teams(namespace string) v1.TeamInterface {
 	return &FakeTeams{c, namespace}
 }
@@ -0,0 +1,124 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	jenkinsiov1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakePromotions implements PromotionInterface
+type FakePromotions struct {
+	Fake *FakeJenkinsV1
+	ns   string
+}
+
+var promotionsResource = schema.GroupVersionResource{Group: "jenkins.io", Version: "v1", Resource: "promotions"}
+
+var promotionsKind = schema.GroupVersionKind{Group: "jenkins.io", Version: "v1", Kind: "Promotion"}
+
+// Get takes name of the promotion, and returns the corresponding promotion object, and an error if there is any.
+func (c *FakePromotions) Get(name string, options v1.GetOptions) (result *jenkinsiov1.Promotion, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(promotionsResource, c.ns, name), &jenkinsiov1.Promotion{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.Promotion), err
+}
+
+// List takes label and field selectors, and returns the list of Promotions that match those selectors.
+func (c *FakePromotions) List(opts v1.ListOptions) (result *jenkinsiov1.PromotionList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(promotionsResource, promotionsKind, c.ns, opts), &jenkinsiov1.PromotionList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &jenkinsiov1.PromotionList{ListMeta: obj.(*jenkinsiov1.PromotionList).ListMeta}
+	for _, item := range obj.(*jenkinsiov1.PromotionList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested promotions.
+func (c *FakePromotions) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(promotionsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a promotion and creates it.  Returns the server's representation of the promotion, and an error, if there is any.
+func (c *FakePromotions) Create(promotion *jenkinsiov1.Promotion) (result *jenkinsiov1.Promotion, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(promotionsResource, c.ns, promotion), &jenkinsiov1.Promotion{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.Promotion), err
+}
+
+// Update takes the representation of a promotion and updates it. Returns the server's representation of the promotion, and an error, if there is any.
+func (c *FakePromotions) Update(promotion *jenkinsiov1.Promotion) (result *jenkinsiov1.Promotion, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(promotionsResource, c.ns, promotion), &jenkinsiov1.Promotion{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.Promotion), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakePromotions) UpdateStatus(promotion *jenkinsiov1.Promotion) (*jenkinsiov1.Promotion, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(promotionsResource, "status", c.ns, promotion), &jenkinsiov1.Promotion{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.Promotion), err
+}
+
+// Delete takes name of the promotion and deletes it. Returns an error if one occurs.
+func (c *FakePromotions) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(promotionsResource, c.ns, name), &jenkinsiov1.Promotion{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakePromotions) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(promotionsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &jenkinsiov1.PromotionList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched promotion.
+func (c *FakePromotions) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *jenkinsiov1.Promotion, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(promotionsResource, c.ns, name, data, subresources...), &jenkinsiov1.Promotion{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*jenkinsiov1.Promotion), err
+}
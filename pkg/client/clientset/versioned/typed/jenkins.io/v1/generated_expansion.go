@@ -2,16 +2,24 @@
 
 package v1
 
+type AddonExpansion interface{}
+
 type EnvironmentExpansion interface{}
 
 type EnvironmentRoleBindingExpansion interface{}
 
 type GitServiceExpansion interface{}
 
+type HookFailureExpansion interface{}
+
 type PipelineActivityExpansion interface{}
 
+type PromotionExpansion interface{}
+
 type ReleaseExpansion interface{}
 
+type SourceRepositoryExpansion interface{}
+
 type TeamExpansion interface{}
 
 type UserExpansion interface{}
@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	scheme "github.com/jenkins-x/jx/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// HookFailuresGetter has a method to return a HookFailureInterface.
+// A group's client should implement this interface.
+type HookFailuresGetter interface {
+	HookFailures(namespace string) HookFailureInterface
+}
+
+// HookFailureInterface has methods to work with HookFailure resources.
+type HookFailureInterface interface {
+	Create(*v1.HookFailure) (*v1.HookFailure, error)
+	Update(*v1.HookFailure) (*v1.HookFailure, error)
+	UpdateStatus(*v1.HookFailure) (*v1.HookFailure, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.HookFailure, error)
+	List(opts metav1.ListOptions) (*v1.HookFailureList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.HookFailure, err error)
+	HookFailureExpansion
+}
+
+// hookFailures implements HookFailureInterface
+type hookFailures struct {
+	client rest.Interface
+	ns     string
+}
+
+// newHookFailures returns a HookFailures
+func newHookFailures(c *JenkinsV1Client, namespace string) *hookFailures {
+	return &hookFailures{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the hookFailure, and returns the corresponding hookFailure object, and an error if there is any.
+func (c *hookFailures) Get(name string, options metav1.GetOptions) (result *v1.HookFailure, err error) {
+	result = &v1.HookFailure{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of HookFailures that match those selectors.
+func (c *hookFailures) List(opts metav1.ListOptions) (result *v1.HookFailureList, err error) {
+	result = &v1.HookFailureList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested hookFailures.
+func (c *hookFailures) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a hookFailure and creates it.  Returns the server's representation of the hookFailure, and an error, if there is any.
+func (c *hookFailures) Create(hookFailure *v1.HookFailure) (result *v1.HookFailure, err error) {
+	result = &v1.HookFailure{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		Body(hookFailure).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a hookFailure and updates it. Returns the server's representation of the hookFailure, and an error, if there is any.
+func (c *hookFailures) Update(hookFailure *v1.HookFailure) (result *v1.HookFailure, err error) {
+	result = &v1.HookFailure{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		Name(hookFailure.Name).
+		Body(hookFailure).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *hookFailures) UpdateStatus(hookFailure *v1.HookFailure) (result *v1.HookFailure, err error) {
+	result = &v1.HookFailure{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		Name(hookFailure.Name).
+		SubResource("status").
+		Body(hookFailure).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the hookFailure and deletes it. Returns an error if one occurs.
+func (c *hookFailures) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *hookFailures) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("hookfailures").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched hookFailure.
+func (c *hookFailures) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.HookFailure, err error) {
+	result = &v1.HookFailure{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("hookfailures").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -11,11 +11,15 @@ import (
 
 type JenkinsV1Interface interface {
 	RESTClient() rest.Interface
+	AddonsGetter
 	EnvironmentsGetter
 	EnvironmentRoleBindingsGetter
 	GitServicesGetter
+	HookFailuresGetter
 	PipelineActivitiesGetter
+	PromotionsGetter
 	ReleasesGetter
+	SourceRepositoriesGetter
 	TeamsGetter
 	UsersGetter
 	WorkflowsGetter
@@ -26,6 +30,10 @@ type JenkinsV1Client struct {
 	restClient rest.Interface
 }
 
+func (c *JenkinsV1Client) Addons(namespace string) AddonInterface {
+	return newAddons(c, namespace)
+}
+
 func (c *JenkinsV1Client) Environments(namespace string) EnvironmentInterface {
 	return newEnvironments(c, namespace)
 }
@@ -38,14 +46,26 @@ func (c *JenkinsV1Client) GitServices(namespace string) GitServiceInterface {
 	return newGitServices(c, namespace)
 }
 
+func (c *JenkinsV1Client) HookFailures(namespace string) HookFailureInterface {
+	return newHookFailures(c, namespace)
+}
+
 func (c *JenkinsV1Client) PipelineActivities(namespace string) PipelineActivityInterface {
 	return newPipelineActivities(c, namespace)
 }
 
+func (c *JenkinsV1Client) Promotions(namespace string) PromotionInterface {
+	return newPromotions(c, namespace)
+}
+
 func (c *JenkinsV1Client) Releases(namespace string) ReleaseInterface {
 	return newReleases(c, namespace)
 }
 
+func (c *JenkinsV1Client) SourceRepositories(namespace string) SourceRepositoryInterface {
+	return newSourceRepositories(c, namespace)
+}
+
 func (c *JenkinsV1Client) Teams(namespace string) TeamInterface {
 	return newTeams(c, namespace)
 }
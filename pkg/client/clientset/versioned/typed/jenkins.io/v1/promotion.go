@@ -0,0 +1,158 @@
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	scheme "github.com/jenkins-x/jx/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// PromotionsGetter has a method to return a PromotionInterface.
+// A group's client should implement this interface.
+type PromotionsGetter interface {
+	Promotions(namespace string) PromotionInterface
+}
+
+// PromotionInterface has methods to work with Promotion resources.
+type PromotionInterface interface {
+	Create(*v1.Promotion) (*v1.Promotion, error)
+	Update(*v1.Promotion) (*v1.Promotion, error)
+	UpdateStatus(*v1.Promotion) (*v1.Promotion, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.Promotion, error)
+	List(opts metav1.ListOptions) (*v1.PromotionList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.Promotion, err error)
+	PromotionExpansion
+}
+
+// promotions implements PromotionInterface
+type promotions struct {
+	client rest.Interface
+	ns     string
+}
+
+// newPromotions returns a Promotions
+func newPromotions(c *JenkinsV1Client, namespace string) *promotions {
+	return &promotions{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the promotion, and returns the corresponding promotion object, and an error if there is any.
+func (c *promotions) Get(name string, options metav1.GetOptions) (result *v1.Promotion, err error) {
+	result = &v1.Promotion{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("promotions").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of Promotions that match those selectors.
+func (c *promotions) List(opts metav1.ListOptions) (result *v1.PromotionList, err error) {
+	result = &v1.PromotionList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("promotions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested promotions.
+func (c *promotions) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("promotions").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Watch()
+}
+
+// Create takes the representation of a promotion and creates it.  Returns the server's representation of the promotion, and an error, if there is any.
+func (c *promotions) Create(promotion *v1.Promotion) (result *v1.Promotion, err error) {
+	result = &v1.Promotion{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("promotions").
+		Body(promotion).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a promotion and updates it. Returns the server's representation of the promotion, and an error, if there is any.
+func (c *promotions) Update(promotion *v1.Promotion) (result *v1.Promotion, err error) {
+	result = &v1.Promotion{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("promotions").
+		Name(promotion.Name).
+		Body(promotion).
+		Do().
+		Into(result)
+	return
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+
+func (c *promotions) UpdateStatus(promotion *v1.Promotion) (result *v1.Promotion, err error) {
+	result = &v1.Promotion{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("promotions").
+		Name(promotion.Name).
+		SubResource("status").
+		Body(promotion).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the promotion and deletes it. Returns an error if one occurs.
+func (c *promotions) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("promotions").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *promotions) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("promotions").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched promotion.
+func (c *promotions) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.Promotion, err error) {
+	result = &v1.Promotion{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("promotions").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -37,16 +37,24 @@ func (f *genericInformer) Lister() cache.GenericLister {
 func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource) (GenericInformer, error) {
 	switch resource {
 	// Group=jenkins.io, Version=v1
+	case v1.SchemeGroupVersion.WithResource("addons"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().Addons().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("environments"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().Environments().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("environmentrolebindings"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().EnvironmentRoleBindings().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("gitservices"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().GitServices().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("hookfailures"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().HookFailures().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("pipelineactivities"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().PipelineActivities().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("promotions"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().Promotions().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("releases"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().Releases().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("sourcerepositories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().SourceRepositories().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("teams"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Jenkins().V1().Teams().Informer()}, nil
 	case v1.SchemeGroupVersion.WithResource("users"):
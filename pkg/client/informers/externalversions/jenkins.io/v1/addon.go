@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	jenkinsiov1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	versioned "github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/jenkins-x/jx/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/jenkins-x/jx/pkg/client/listers/jenkins.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// AddonInformer provides access to a shared informer and lister for
+// Addons.
+type AddonInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.AddonLister
+}
+
+type addonInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewAddonInformer constructs a new informer for Addon type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewAddonInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredAddonInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredAddonInformer constructs a new informer for Addon type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredAddonInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.JenkinsV1().Addons(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.JenkinsV1().Addons(namespace).Watch(options)
+			},
+		},
+		&jenkinsiov1.Addon{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *addonInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredAddonInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *addonInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&jenkinsiov1.Addon{}, f.defaultInformer)
+}
+
+func (f *addonInformer) Lister() v1.AddonLister {
+	return v1.NewAddonLister(f.Informer().GetIndexer())
+}
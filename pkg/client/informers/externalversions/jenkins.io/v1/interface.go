@@ -8,16 +8,24 @@ import (
 
 // Interface provides access to all the informers in this group version.
 type Interface interface {
+	// Addons returns a AddonInformer.
+	Addons() AddonInformer
 	// Environments returns a EnvironmentInformer.
 	Environments() EnvironmentInformer
 	// EnvironmentRoleBindings returns a EnvironmentRoleBindingInformer.
 	EnvironmentRoleBindings() EnvironmentRoleBindingInformer
 	// GitServices returns a GitServiceInformer.
 	GitServices() GitServiceInformer
+	// HookFailures returns a HookFailureInformer.
+	HookFailures() HookFailureInformer
 	// PipelineActivities returns a PipelineActivityInformer.
 	PipelineActivities() PipelineActivityInformer
+	// Promotions returns a PromotionInformer.
+	Promotions() PromotionInformer
 	// Releases returns a ReleaseInformer.
 	Releases() ReleaseInformer
+	// SourceRepositories returns a SourceRepositoryInformer.
+	SourceRepositories() SourceRepositoryInformer
 	// Teams returns a TeamInformer.
 	Teams() TeamInformer
 	// Users returns a UserInformer.
@@ -37,6 +45,11 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 	return &version{factory: f, namespace: namespace, tweakListOptions: tweakListOptions}
 }
 
+// Addons returns a AddonInformer.
+func (v *version) Addons() AddonInformer {
+	return &addonInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Environments returns a EnvironmentInformer.
 func (v *version) Environments() EnvironmentInformer {
 	return &environmentInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -52,16 +65,31 @@ func (v *version) GitServices() GitServiceInformer {
 	return &gitServiceInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// HookFailures returns a HookFailureInformer.
+func (v *version) HookFailures() HookFailureInformer {
+	return &hookFailureInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // PipelineActivities returns a PipelineActivityInformer.
 func (v *version) PipelineActivities() PipelineActivityInformer {
 	return &pipelineActivityInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// Promotions returns a PromotionInformer.
+func (v *version) Promotions() PromotionInformer {
+	return &promotionInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Releases returns a ReleaseInformer.
 func (v *version) Releases() ReleaseInformer {
 	return &releaseInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// SourceRepositories returns a SourceRepositoryInformer.
+func (v *version) SourceRepositories() SourceRepositoryInformer {
+	return &sourceRepositoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // Teams returns a TeamInformer.
 func (v *version) Teams() TeamInformer {
 	return &teamInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -0,0 +1,73 @@
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	jenkinsiov1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	versioned "github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/jenkins-x/jx/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/jenkins-x/jx/pkg/client/listers/jenkins.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// PromotionInformer provides access to a shared informer and lister for
+// Promotions.
+type PromotionInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.PromotionLister
+}
+
+type promotionInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewPromotionInformer constructs a new informer for Promotion type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewPromotionInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredPromotionInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredPromotionInformer constructs a new informer for Promotion type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredPromotionInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.JenkinsV1().Promotions(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.JenkinsV1().Promotions(namespace).Watch(options)
+			},
+		},
+		&jenkinsiov1.Promotion{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *promotionInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredPromotionInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *promotionInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&jenkinsiov1.Promotion{}, f.defaultInformer)
+}
+
+func (f *promotionInformer) Lister() v1.PromotionLister {
+	return v1.NewPromotionLister(f.Informer().GetIndexer())
+}
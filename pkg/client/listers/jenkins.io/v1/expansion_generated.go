@@ -2,6 +2,14 @@
 
 package v1
 
+// AddonListerExpansion allows custom methods to be added to
+// AddonLister.
+type AddonListerExpansion interface{}
+
+// AddonNamespaceListerExpansion allows custom methods to be added to
+// AddonNamespaceLister.
+type AddonNamespaceListerExpansion interface{}
+
 // EnvironmentListerExpansion allows custom methods to be added to
 // EnvironmentLister.
 type EnvironmentListerExpansion interface{}
@@ -26,6 +34,14 @@ type GitServiceListerExpansion interface{}
 // GitServiceNamespaceLister.
 type GitServiceNamespaceListerExpansion interface{}
 
+// HookFailureListerExpansion allows custom methods to be added to
+// HookFailureLister.
+type HookFailureListerExpansion interface{}
+
+// HookFailureNamespaceListerExpansion allows custom methods to be added to
+// HookFailureNamespaceLister.
+type HookFailureNamespaceListerExpansion interface{}
+
 // PipelineActivityListerExpansion allows custom methods to be added to
 // PipelineActivityLister.
 type PipelineActivityListerExpansion interface{}
@@ -34,6 +50,14 @@ type PipelineActivityListerExpansion interface{}
 // PipelineActivityNamespaceLister.
 type PipelineActivityNamespaceListerExpansion interface{}
 
+// PromotionListerExpansion allows custom methods to be added to
+// PromotionLister.
+type PromotionListerExpansion interface{}
+
+// PromotionNamespaceListerExpansion allows custom methods to be added to
+// PromotionNamespaceLister.
+type PromotionNamespaceListerExpansion interface{}
+
 // ReleaseListerExpansion allows custom methods to be added to
 // ReleaseLister.
 type ReleaseListerExpansion interface{}
@@ -42,6 +66,14 @@ type ReleaseListerExpansion interface{}
 // ReleaseNamespaceLister.
 type ReleaseNamespaceListerExpansion interface{}
 
+// SourceRepositoryListerExpansion allows custom methods to be added to
+// SourceRepositoryLister.
+type SourceRepositoryListerExpansion interface{}
+
+// SourceRepositoryNamespaceListerExpansion allows custom methods to be added to
+// SourceRepositoryNamespaceLister.
+type SourceRepositoryNamespaceListerExpansion interface{}
+
 // TeamListerExpansion allows custom methods to be added to
 // TeamLister.
 type TeamListerExpansion interface{}
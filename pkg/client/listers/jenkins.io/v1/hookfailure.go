@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// HookFailureLister helps list HookFailures.
+type HookFailureLister interface {
+	// List lists all HookFailures in the indexer.
+	List(selector labels.Selector) (ret []*v1.HookFailure, err error)
+	// HookFailures returns an object that can list and get HookFailures.
+	HookFailures(namespace string) HookFailureNamespaceLister
+	HookFailureListerExpansion
+}
+
+// hookFailureLister implements the HookFailureLister interface.
+type hookFailureLister struct {
+	indexer cache.Indexer
+}
+
+// NewHookFailureLister returns a new HookFailureLister.
+func NewHookFailureLister(indexer cache.Indexer) HookFailureLister {
+	return &hookFailureLister{indexer: indexer}
+}
+
+// List lists all HookFailures in the indexer.
+func (s *hookFailureLister) List(selector labels.Selector) (ret []*v1.HookFailure, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.HookFailure))
+	})
+	return ret, err
+}
+
+// HookFailures returns an object that can list and get HookFailures.
+func (s *hookFailureLister) HookFailures(namespace string) HookFailureNamespaceLister {
+	return hookFailureNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// HookFailureNamespaceLister helps list and get HookFailures.
+type HookFailureNamespaceLister interface {
+	// List lists all HookFailures in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.HookFailure, err error)
+	// Get retrieves the HookFailure from the indexer for a given namespace and name.
+	Get(name string) (*v1.HookFailure, error)
+	HookFailureNamespaceListerExpansion
+}
+
+// hookFailureNamespaceLister implements the HookFailureNamespaceLister
+// interface.
+type hookFailureNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all HookFailures in the indexer for a given namespace.
+func (s hookFailureNamespaceLister) List(selector labels.Selector) (ret []*v1.HookFailure, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.HookFailure))
+	})
+	return ret, err
+}
+
+// Get retrieves the HookFailure from the indexer for a given namespace and name.
+func (s hookFailureNamespaceLister) Get(name string) (*v1.HookFailure, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("hookfailure"), name)
+	}
+	return obj.(*v1.HookFailure), nil
+}
@@ -0,0 +1,78 @@
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// PromotionLister helps list Promotions.
+type PromotionLister interface {
+	// List lists all Promotions in the indexer.
+	List(selector labels.Selector) (ret []*v1.Promotion, err error)
+	// Promotions returns an object that can list and get Promotions.
+	Promotions(namespace string) PromotionNamespaceLister
+	PromotionListerExpansion
+}
+
+// promotionLister implements the PromotionLister interface.
+type promotionLister struct {
+	indexer cache.Indexer
+}
+
+// NewPromotionLister returns a new PromotionLister.
+func NewPromotionLister(indexer cache.Indexer) PromotionLister {
+	return &promotionLister{indexer: indexer}
+}
+
+// List lists all Promotions in the indexer.
+func (s *promotionLister) List(selector labels.Selector) (ret []*v1.Promotion, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Promotion))
+	})
+	return ret, err
+}
+
+// Promotions returns an object that can list and get Promotions.
+func (s *promotionLister) Promotions(namespace string) PromotionNamespaceLister {
+	return promotionNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// PromotionNamespaceLister helps list and get Promotions.
+type PromotionNamespaceLister interface {
+	// List lists all Promotions in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.Promotion, err error)
+	// Get retrieves the Promotion from the indexer for a given namespace and name.
+	Get(name string) (*v1.Promotion, error)
+	PromotionNamespaceListerExpansion
+}
+
+// promotionNamespaceLister implements the PromotionNamespaceLister
+// interface.
+type promotionNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all Promotions in the indexer for a given namespace.
+func (s promotionNamespaceLister) List(selector labels.Selector) (ret []*v1.Promotion, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.Promotion))
+	})
+	return ret, err
+}
+
+// Get retrieves the Promotion from the indexer for a given namespace and name.
+func (s promotionNamespaceLister) Get(name string) (*v1.Promotion, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("promotion"), name)
+	}
+	return obj.(*v1.Promotion), nil
+}
@@ -0,0 +1,172 @@
+package aks
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// RoleOwner can manage all resources and assign roles
+	RoleOwner = "Owner"
+	// RoleContributor can manage all resources but not assign roles
+	RoleContributor = "Contributor"
+	// RoleUserAccessAdministrator can assign roles
+	RoleUserAccessAdministrator = "User Access Administrator"
+	// RoleAcrPull can pull images from a container registry
+	RoleAcrPull = "AcrPull"
+	// RoleAcrPush can push and pull images to a container registry
+	RoleAcrPush = "AcrPush"
+)
+
+// ServicePrincipal the credentials of an Azure AD service principal
+type ServicePrincipal struct {
+	AppID       string `json:"appId"`
+	DisplayName string `json:"displayName"`
+	Name        string `json:"name"`
+	Password    string `json:"password"`
+	Tenant      string `json:"tenant"`
+}
+
+// Account the signed in Azure account
+type Account struct {
+	SubscriptionID string `json:"id"`
+	TenantID       string `json:"tenantId"`
+	User           struct {
+		Name string `json:"name"`
+		Type string `json:"type"`
+	} `json:"user"`
+}
+
+// SubscriptionScope returns the scope of the subscription
+func SubscriptionScope(subscriptionID string) string {
+	return fmt.Sprintf("/subscriptions/%s", subscriptionID)
+}
+
+// ResourceGroupScope returns the scope of the resource group in the subscription
+func ResourceGroupScope(subscriptionID string, resourceGroup string) string {
+	return fmt.Sprintf("%s/resourceGroups/%s", SubscriptionScope(subscriptionID), resourceGroup)
+}
+
+// ServicePrincipalName returns the identifier URI of the service principal with the given name
+func ServicePrincipalName(name string) string {
+	if strings.HasPrefix(name, "http://") || strings.HasPrefix(name, "https://") {
+		return name
+	}
+	return "http://" + name
+}
+
+// ParseServicePrincipal parses the JSON credentials output by az ad sp create-for-rbac or az ad sp credential reset
+func ParseServicePrincipal(output string) (*ServicePrincipal, error) {
+	sp := &ServicePrincipal{}
+	err := json.Unmarshal([]byte(output), sp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the service principal: %s", err)
+	}
+	if sp.AppID == "" || sp.Password == "" {
+		return nil, fmt.Errorf("the service principal has no appId or password")
+	}
+	return sp, nil
+}
+
+// CheckRoles returns an error if the roles are not enough to create a cluster and, if assignRoles is true, to
+// assign roles to a service principal
+func CheckRoles(roles []string, assignRoles bool) error {
+	has := func(names ...string) bool {
+		for _, name := range names {
+			if util.StringArrayIndex(roles, name) >= 0 {
+				return true
+			}
+		}
+		return false
+	}
+	if !has(RoleOwner, RoleContributor) {
+		return fmt.Errorf("the %s or %s role is needed to create the cluster but the current account has the roles: %s",
+			RoleOwner, RoleContributor, strings.Join(roles, ", "))
+	}
+	if assignRoles && !has(RoleOwner, RoleUserAccessAdministrator) {
+		return fmt.Errorf("the %s or %s role is needed to grant roles to the service principal but the current account has the roles: %s",
+			RoleOwner, RoleUserAccessAdministrator, strings.Join(roles, ", "))
+	}
+	return nil
+}
+
+func runAz(args ...string) (string, error) {
+	cmd := util.Command{
+		Name: "az",
+		Args: args,
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return output, fmt.Errorf("failed to run az %s: %s", strings.Join(args, " "), output)
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// GetAccount returns the signed in account
+func GetAccount() (*Account, error) {
+	output, err := runAz("account", "show", "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	account := &Account{}
+	err = json.Unmarshal([]byte(output), account)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Azure account: %s", err)
+	}
+	return account, nil
+}
+
+// GetRoles returns the names of the roles assigned to the user including those inherited at the scope
+func GetRoles(assignee string, scope string) ([]string, error) {
+	output, err := runAz("role", "assignment", "list", "--assignee", assignee, "--scope", scope, "--include-inherited",
+		"--query", "[].roleDefinitionName", "-o", "tsv")
+	if err != nil {
+		return nil, err
+	}
+	roles := []string{}
+	for _, line := range strings.Split(output, "\n") {
+		role := strings.TrimSpace(line)
+		if role != "" && util.StringArrayIndex(roles, role) < 0 {
+			roles = append(roles, role)
+		}
+	}
+	return roles, nil
+}
+
+// GetOrCreateServicePrincipal creates a service principal with the Contributor role at the scope or, if it already
+// exists, resets its credentials so that the password is known
+func GetOrCreateServicePrincipal(name string, scope string) (*ServicePrincipal, error) {
+	spName := ServicePrincipalName(name)
+	var output string
+	var err error
+	if _, showErr := runAz("ad", "sp", "show", "--id", spName); showErr == nil {
+		log.Infof("Resetting the credentials of the existing service principal %s\n", util.ColorInfo(spName))
+		output, err = runAz("ad", "sp", "credential", "reset", "--name", spName, "-o", "json")
+		if err == nil {
+			err = AssignRole(spName, RoleContributor, scope)
+		}
+	} else {
+		log.Infof("Creating the service principal %s scoped to %s\n", util.ColorInfo(spName), util.ColorInfo(scope))
+		output, err = runAz("ad", "sp", "create-for-rbac", "--name", spName, "--role", RoleContributor, "--scopes", scope, "-o", "json")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ParseServicePrincipal(output)
+}
+
+// AssignRole assigns the role to the assignee at the scope
+func AssignRole(assignee string, role string, scope string) error {
+	log.Infof("Assigning the role %s at %s\n", util.ColorInfo(role), scope)
+	_, err := runAz("role", "assignment", "create", "--assignee", assignee, "--role", role, "--scope", scope)
+	return err
+}
+
+// GetRegistryID returns the resource ID of the Azure container registry
+func GetRegistryID(name string) (string, error) {
+	return runAz("acr", "show", "--name", name, "--query", "id", "-o", "tsv")
+}
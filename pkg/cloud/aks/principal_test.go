@@ -0,0 +1,36 @@
+package aks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseServicePrincipal(t *testing.T) {
+	t.Parallel()
+	sp, err := ParseServicePrincipal(`{"appId": "abc", "displayName": "jx-demo", "name": "http://jx-demo", "password": "secret", "tenant": "t1"}`)
+	require.NoError(t, err)
+	assert.Equal(t, "abc", sp.AppID)
+	assert.Equal(t, "secret", sp.Password)
+	assert.Equal(t, "t1", sp.Tenant)
+
+	_, err = ParseServicePrincipal(`{"appId": "abc"}`)
+	assert.Error(t, err)
+}
+
+func TestScopes(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "/subscriptions/s1/resourceGroups/rg", ResourceGroupScope("s1", "rg"))
+	assert.Equal(t, "http://jx-demo", ServicePrincipalName("jx-demo"))
+	assert.Equal(t, "http://jx-demo", ServicePrincipalName("http://jx-demo"))
+}
+
+func TestCheckRoles(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, CheckRoles([]string{RoleOwner}, true))
+	assert.NoError(t, CheckRoles([]string{RoleContributor}, false))
+	assert.NoError(t, CheckRoles([]string{RoleContributor, RoleUserAccessAdministrator}, true))
+	assert.Error(t, CheckRoles([]string{RoleContributor}, true))
+	assert.Error(t, CheckRoles([]string{"Reader"}, false))
+}
@@ -107,3 +107,55 @@ func LazyCreateRegistry(orgName string, appName string) error {
 	}
 	return nil
 }
+
+// ListImageTags returns the tags of the images in the ECR repository
+func ListImageTags(repoName string) ([]string, error) {
+	sess, _, err := NewAwsSession()
+	if err != nil {
+		return nil, err
+	}
+	svc := ecr.New(sess)
+	answer := []string{}
+	input := &ecr.ListImagesInput{
+		RepositoryName: aws.String(repoName),
+		Filter: &ecr.ListImagesFilter{
+			TagStatus: aws.String(ecr.TagStatusTagged),
+		},
+	}
+	err = svc.ListImagesPages(input, func(page *ecr.ListImagesOutput, lastPage bool) bool {
+		for _, id := range page.ImageIds {
+			if id.ImageTag != nil {
+				answer = append(answer, *id.ImageTag)
+			}
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to list the images of ECR repository %s due to: %s", repoName, err)
+	}
+	return answer, nil
+}
+
+// DeleteImageTag deletes the image tag from the ECR repository. ECR removes untagged image layers itself
+func DeleteImageTag(repoName string, tag string) error {
+	sess, _, err := NewAwsSession()
+	if err != nil {
+		return err
+	}
+	svc := ecr.New(sess)
+	result, err := svc.BatchDeleteImage(&ecr.BatchDeleteImageInput{
+		RepositoryName: aws.String(repoName),
+		ImageIds: []*ecr.ImageIdentifier{
+			{
+				ImageTag: aws.String(tag),
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to delete image %s:%s from ECR due to: %s", repoName, tag, err)
+	}
+	for _, f := range result.Failures {
+		return fmt.Errorf("Failed to delete image %s:%s from ECR due to: %s", repoName, tag, aws.StringValue(f.FailureReason))
+	}
+	return nil
+}
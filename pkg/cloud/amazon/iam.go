@@ -0,0 +1,222 @@
+package amazon
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// PolicyEKS the permissions needed by eksctl to create and delete EKS clusters
+	PolicyEKS = "eks"
+	// PolicyKops the permissions needed by kops to create and delete clusters
+	PolicyKops = "kops"
+	// PolicyRegistry the permissions needed to push and pull images using ECR
+	PolicyRegistry = "registry"
+
+	policyVersion = "2012-10-17"
+)
+
+// PolicyKinds the kinds of installation which have policies
+var PolicyKinds = []string{PolicyEKS, PolicyKops, PolicyRegistry}
+
+var (
+	ec2ClusterActions = []string{
+		"ec2:AllocateAddress", "ec2:AssociateRouteTable", "ec2:AttachInternetGateway",
+		"ec2:AuthorizeSecurityGroupEgress", "ec2:AuthorizeSecurityGroupIngress", "ec2:CreateInternetGateway",
+		"ec2:CreateLaunchTemplate", "ec2:CreateNatGateway", "ec2:CreateRoute", "ec2:CreateRouteTable",
+		"ec2:CreateSecurityGroup", "ec2:CreateSubnet", "ec2:CreateTags", "ec2:CreateVpc",
+		"ec2:DeleteInternetGateway", "ec2:DeleteLaunchTemplate", "ec2:DeleteNatGateway", "ec2:DeleteRoute",
+		"ec2:DeleteRouteTable", "ec2:DeleteSecurityGroup", "ec2:DeleteSubnet", "ec2:DeleteVpc",
+		"ec2:DescribeAddresses", "ec2:DescribeAvailabilityZones", "ec2:DescribeImages",
+		"ec2:DescribeInstances", "ec2:DescribeInternetGateways", "ec2:DescribeKeyPairs",
+		"ec2:DescribeLaunchTemplates", "ec2:DescribeNatGateways", "ec2:DescribeRouteTables",
+		"ec2:DescribeSecurityGroups", "ec2:DescribeSubnets", "ec2:DescribeVpcs", "ec2:DetachInternetGateway",
+		"ec2:DisassociateRouteTable", "ec2:ImportKeyPair", "ec2:ModifyVpcAttribute", "ec2:ReleaseAddress",
+		"ec2:RevokeSecurityGroupEgress", "ec2:RevokeSecurityGroupIngress", "ec2:RunInstances",
+		"ec2:TerminateInstances",
+	}
+	iamClusterActions = []string{
+		"iam:AddRoleToInstanceProfile", "iam:AttachRolePolicy", "iam:CreateInstanceProfile", "iam:CreateRole",
+		"iam:DeleteInstanceProfile", "iam:DeleteRole", "iam:DeleteRolePolicy", "iam:DetachRolePolicy",
+		"iam:GetInstanceProfile", "iam:GetRole", "iam:GetRolePolicy", "iam:PassRole", "iam:PutRolePolicy",
+		"iam:RemoveRoleFromInstanceProfile",
+	}
+	autoscalingClusterActions = []string{
+		"autoscaling:CreateAutoScalingGroup", "autoscaling:CreateLaunchConfiguration",
+		"autoscaling:DeleteAutoScalingGroup", "autoscaling:DeleteLaunchConfiguration",
+		"autoscaling:DescribeAutoScalingGroups", "autoscaling:DescribeLaunchConfigurations",
+		"autoscaling:DescribeScalingActivities", "autoscaling:UpdateAutoScalingGroup",
+	}
+
+	// PolicyActions the actions grouped by statement id needed by each kind of installation
+	PolicyActions = map[string]map[string][]string{
+		PolicyEKS: {
+			"CloudFormation": {
+				"cloudformation:CreateStack", "cloudformation:DeleteStack", "cloudformation:DescribeStackEvents",
+				"cloudformation:DescribeStacks", "cloudformation:ListStacks", "cloudformation:UpdateStack",
+			},
+			"EKS": {
+				"eks:CreateCluster", "eks:DeleteCluster", "eks:DescribeCluster", "eks:ListClusters",
+			},
+			"EC2":         ec2ClusterActions,
+			"IAM":         iamClusterActions,
+			"AutoScaling": autoscalingClusterActions,
+		},
+		PolicyKops: {
+			"S3": {
+				"s3:CreateBucket", "s3:DeleteObject", "s3:GetBucketLocation", "s3:GetObject", "s3:ListBucket",
+				"s3:PutObject",
+			},
+			"Route53": {
+				"route53:ChangeResourceRecordSets", "route53:GetChange", "route53:GetHostedZone",
+				"route53:ListHostedZones", "route53:ListResourceRecordSets",
+			},
+			"ELB": {
+				"elasticloadbalancing:ConfigureHealthCheck", "elasticloadbalancing:CreateLoadBalancer",
+				"elasticloadbalancing:DeleteLoadBalancer", "elasticloadbalancing:DescribeLoadBalancers",
+				"elasticloadbalancing:RegisterInstancesWithLoadBalancer",
+			},
+			"EC2":         ec2ClusterActions,
+			"IAM":         iamClusterActions,
+			"AutoScaling": autoscalingClusterActions,
+		},
+		PolicyRegistry: {
+			"ECR": {
+				"ecr:BatchCheckLayerAvailability", "ecr:BatchGetImage", "ecr:CompleteLayerUpload",
+				"ecr:CreateRepository", "ecr:DescribeRepositories", "ecr:GetAuthorizationToken",
+				"ecr:GetDownloadUrlForLayer", "ecr:InitiateLayerUpload", "ecr:PutImage", "ecr:UploadLayerPart",
+			},
+		},
+	}
+)
+
+// PolicyDocument an IAM policy document
+type PolicyDocument struct {
+	Version   string            `json:"Version"`
+	Statement []PolicyStatement `json:"Statement"`
+}
+
+// PolicyStatement a statement of an IAM policy document
+type PolicyStatement struct {
+	Sid      string   `json:"Sid"`
+	Effect   string   `json:"Effect"`
+	Action   []string `json:"Action"`
+	Resource string   `json:"Resource"`
+}
+
+// Actions returns the sorted actions of all the statements of the policy
+func (p *PolicyDocument) Actions() []string {
+	answer := []string{}
+	for _, s := range p.Statement {
+		for _, action := range s.Action {
+			if util.StringArrayIndex(answer, action) < 0 {
+				answer = append(answer, action)
+			}
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// InstallPolicy returns the minimal policy document needed by the given kinds of installation
+func InstallPolicy(kinds ...string) (*PolicyDocument, error) {
+	actions := map[string][]string{}
+	for _, kind := range kinds {
+		statements, ok := PolicyActions[kind]
+		if !ok {
+			return nil, util.InvalidArg(kind, PolicyKinds)
+		}
+		for sid, sidActions := range statements {
+			for _, action := range sidActions {
+				if util.StringArrayIndex(actions[sid], action) < 0 {
+					actions[sid] = append(actions[sid], action)
+				}
+			}
+		}
+	}
+	sids := []string{}
+	for sid := range actions {
+		sids = append(sids, sid)
+	}
+	sort.Strings(sids)
+	policy := &PolicyDocument{Version: policyVersion}
+	for _, sid := range sids {
+		sort.Strings(actions[sid])
+		policy.Statement = append(policy.Statement, PolicyStatement{
+			Sid:      "JenkinsX" + sid,
+			Effect:   "Allow",
+			Action:   actions[sid],
+			Resource: "*",
+		})
+	}
+	return policy, nil
+}
+
+// PrincipalArn converts the ARN of the caller into the ARN of the IAM user or role which can be simulated. Assumed
+// role sessions are converted into the ARN of their role
+func PrincipalArn(callerArn string) string {
+	parts := strings.SplitN(callerArn, ":", 6)
+	if len(parts) != 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return callerArn
+	}
+	role := strings.Split(strings.TrimPrefix(parts[5], "assumed-role/"), "/")[0]
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], role)
+}
+
+type simulationResults struct {
+	EvaluationResults []struct {
+		EvalActionName string `json:"EvalActionName"`
+		EvalDecision   string `json:"EvalDecision"`
+	} `json:"EvaluationResults"`
+}
+
+// ParseSimulationResults parses the JSON output of aws iam simulate-principal-policy returning the sorted actions
+// which are not allowed
+func ParseSimulationResults(output string) ([]string, error) {
+	results := simulationResults{}
+	err := json.Unmarshal([]byte(output), &results)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the policy simulation results: %s", err)
+	}
+	missing := []string{}
+	for _, r := range results.EvaluationResults {
+		if r.EvalDecision != "allowed" {
+			missing = append(missing, r.EvalActionName)
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+// MissingPermissions simulates the policy document against the current credentials using the aws CLI and returns
+// the actions which are not allowed
+func MissingPermissions(policy *PolicyDocument) ([]string, error) {
+	sess, _, err := NewAwsSession()
+	if err != nil {
+		return nil, err
+	}
+	identity, err := sts.New(sess).GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return nil, err
+	}
+	if identity.Arn == nil {
+		return nil, fmt.Errorf("could not find the ARN of the current AWS credentials")
+	}
+	args := []string{"iam", "simulate-principal-policy", "--output", "json",
+		"--policy-source-arn", PrincipalArn(*identity.Arn), "--action-names"}
+	args = append(args, policy.Actions()...)
+	cmd := util.Command{
+		Name: "aws",
+		Args: args,
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return nil, fmt.Errorf("failed to simulate the policy which needs the iam:SimulatePrincipalPolicy permission: %s", output)
+	}
+	return ParseSimulationResults(output)
+}
@@ -0,0 +1,61 @@
+package amazon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstallPolicy(t *testing.T) {
+	t.Parallel()
+	policy, err := InstallPolicy(PolicyEKS, PolicyRegistry)
+	require.NoError(t, err)
+	assert.Equal(t, "2012-10-17", policy.Version)
+
+	sids := []string{}
+	for _, s := range policy.Statement {
+		sids = append(sids, s.Sid)
+	}
+	assert.Equal(t, []string{"JenkinsXAutoScaling", "JenkinsXCloudFormation", "JenkinsXEC2", "JenkinsXECR", "JenkinsXEKS", "JenkinsXIAM"}, sids)
+	assert.Contains(t, policy.Actions(), "eks:CreateCluster")
+	assert.Contains(t, policy.Actions(), "ecr:PutImage")
+
+	data, err := json.Marshal(policy)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"Effect":"Allow"`)
+
+	// the shared statements are not duplicated
+	policy, err = InstallPolicy(PolicyEKS, PolicyKops)
+	require.NoError(t, err)
+	for _, s := range policy.Statement {
+		if s.Sid == "JenkinsXEC2" {
+			assert.Equal(t, len(ec2ClusterActions), len(s.Action))
+		}
+	}
+
+	_, err = InstallPolicy("cheese")
+	assert.Error(t, err)
+}
+
+func TestPrincipalArn(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "arn:aws:iam::123456789012:role/admin", PrincipalArn("arn:aws:sts::123456789012:assumed-role/admin/james"))
+	assert.Equal(t, "arn:aws:iam::123456789012:user/james", PrincipalArn("arn:aws:iam::123456789012:user/james"))
+}
+
+func TestParseSimulationResults(t *testing.T) {
+	t.Parallel()
+	output := `{"EvaluationResults": [
+		{"EvalActionName": "eks:CreateCluster", "EvalDecision": "allowed"},
+		{"EvalActionName": "iam:PassRole", "EvalDecision": "implicitDeny"},
+		{"EvalActionName": "ec2:CreateVpc", "EvalDecision": "explicitDeny"}
+	]}`
+	missing, err := ParseSimulationResults(output)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ec2:CreateVpc", "iam:PassRole"}, missing)
+
+	_, err = ParseSimulationResults("not json")
+	assert.Error(t, err)
+}
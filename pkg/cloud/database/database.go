@@ -0,0 +1,433 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// KindCloudSQL Google CloudSQL
+	KindCloudSQL = "cloudsql"
+	// KindRDS Amazon Relational Database Service
+	KindRDS = "rds"
+	// KindAzure Azure Database for PostgreSQL or MySQL
+	KindAzure = "azure"
+
+	// EnginePostgres the PostgreSQL database engine
+	EnginePostgres = "postgres"
+	// EngineMySQL the MySQL database engine
+	EngineMySQL = "mysql"
+
+	// DefaultUsername the user the apps connect to their databases as
+	DefaultUsername = "jx"
+
+	// LabelDatabase the label of the connection Secrets of the databases containing the name of the database
+	LabelDatabase = "jenkins.io/database"
+
+	// AnnotationKind the annotation of a connection Secret containing the kind of the database instance
+	AnnotationKind = "jenkins.io/database-kind"
+	// AnnotationInstance the annotation of a connection Secret containing the name of the database instance
+	AnnotationInstance = "jenkins.io/database-instance"
+	// AnnotationEngine the annotation of a connection Secret containing the engine of the database instance
+	AnnotationEngine = "jenkins.io/database-engine"
+	// AnnotationProject the annotation of a connection Secret containing the GCP project of the database instance
+	AnnotationProject = "jenkins.io/database-project"
+	// AnnotationRegion the annotation of a connection Secret containing the region of the database instance
+	AnnotationRegion = "jenkins.io/database-region"
+	// AnnotationResourceGroup the annotation of a connection Secret containing the Azure resource group of the database instance
+	AnnotationResourceGroup = "jenkins.io/database-resource-group"
+
+	maxInstanceNameLength = 63
+)
+
+var (
+	// Kinds the managed database services which can be provisioned
+	Kinds = []string{KindCloudSQL, KindRDS, KindAzure}
+
+	// Engines the database engines which can be provisioned
+	Engines = []string{EnginePostgres, EngineMySQL}
+)
+
+// Runner runs a command line tool returning its output
+type Runner func(name string, args ...string) (string, error)
+
+// DefaultRunner runs the command line tool
+func DefaultRunner(name string, args ...string) (string, error) {
+	cmd := util.Command{
+		Name: name,
+		Args: args,
+	}
+	return cmd.RunWithoutRetry()
+}
+
+// Instance identifies a database instance of a managed database service
+type Instance struct {
+	Kind          string
+	Name          string
+	Engine        string
+	Project       string
+	Region        string
+	ResourceGroup string
+}
+
+// Connection the details the apps use to connect to a database
+type Connection struct {
+	Host     string
+	Port     int
+	Database string
+	Username string
+	Password string
+}
+
+// URL returns the connection URL of the database
+func (c *Connection) URL(engine string) string {
+	scheme := "postgresql"
+	if engine == EngineMySQL {
+		scheme = "mysql"
+	}
+	return fmt.Sprintf("%s://%s:%s@%s:%d/%s", scheme, c.Username, c.Password, c.Host, c.Port, c.Database)
+}
+
+// Validate returns an error if the database cannot be provisioned
+func Validate(db *config.DatabaseConfig) error {
+	if db.Name == "" {
+		return fmt.Errorf("the database has no name")
+	}
+	if util.StringArrayIndex(Kinds, db.Kind) < 0 {
+		return fmt.Errorf("the database %s has an invalid kind '%s', it should be one of: %s", db.Name, db.Kind, strings.Join(Kinds, ", "))
+	}
+	if db.Engine != "" && util.StringArrayIndex(Engines, db.Engine) < 0 {
+		return fmt.Errorf("the database %s has an invalid engine '%s', it should be one of: %s", db.Name, db.Engine, strings.Join(Engines, ", "))
+	}
+	if db.Kind == KindAzure && db.ResourceGroup == "" {
+		return fmt.Errorf("no resource group specified for the Azure database %s", db.Name)
+	}
+	if db.StorageGB < 0 {
+		return fmt.Errorf("the storage of the database %s must not be negative but was %d", db.Name, db.StorageGB)
+	}
+	return nil
+}
+
+// Engine returns the engine of the database defaulting to postgres
+func Engine(db *config.DatabaseConfig) string {
+	if db.Engine == "" {
+		return EnginePostgres
+	}
+	return db.Engine
+}
+
+// DefaultPort returns the default port of the database engine
+func DefaultPort(engine string) int {
+	if engine == EngineMySQL {
+		return 3306
+	}
+	return 5432
+}
+
+// SecretName returns the name of the Secret containing the connection details of the database of the app
+func SecretName(app string, db *config.DatabaseConfig) string {
+	if db.SecretName != "" {
+		return db.SecretName
+	}
+	return kube.ToValidName(app + "-" + db.Name + "-db")
+}
+
+// NewInstance returns the database instance of the app in the namespace of an environment so that each environment,
+// including each preview environment, gets its own instance
+func NewInstance(app string, db *config.DatabaseConfig, ns string) *Instance {
+	name := kube.ToValidName(ns + "-" + app + "-" + db.Name)
+	if len(name) > maxInstanceNameLength {
+		name = strings.TrimRight(name[0:maxInstanceNameLength], "-")
+	}
+	return &Instance{
+		Kind:          db.Kind,
+		Name:          name,
+		Engine:        Engine(db),
+		Project:       db.Project,
+		Region:        db.Region,
+		ResourceGroup: db.ResourceGroup,
+	}
+}
+
+// NewConnectionSecret returns the Secret containing the connection details of the database which is annotated with
+// the instance so that the instance can be deleted along with its environment
+func NewConnectionSecret(name string, dbName string, instance *Instance, conn *Connection) *corev1.Secret {
+	annotations := map[string]string{
+		AnnotationKind:     instance.Kind,
+		AnnotationInstance: instance.Name,
+		AnnotationEngine:   instance.Engine,
+	}
+	if instance.Project != "" {
+		annotations[AnnotationProject] = instance.Project
+	}
+	if instance.Region != "" {
+		annotations[AnnotationRegion] = instance.Region
+	}
+	if instance.ResourceGroup != "" {
+		annotations[AnnotationResourceGroup] = instance.ResourceGroup
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Labels:      map[string]string{LabelDatabase: dbName},
+			Annotations: annotations,
+		},
+		Data: map[string][]byte{
+			"host":     []byte(conn.Host),
+			"port":     []byte(strconv.Itoa(conn.Port)),
+			"database": []byte(conn.Database),
+			"username": []byte(conn.Username),
+			"password": []byte(conn.Password),
+			"url":      []byte(conn.URL(instance.Engine)),
+		},
+	}
+}
+
+// InstanceFromSecret returns the database instance of a connection Secret or nil if it is not a connection Secret
+func InstanceFromSecret(secret *corev1.Secret) *Instance {
+	annotations := secret.Annotations
+	if annotations == nil || annotations[AnnotationInstance] == "" || annotations[AnnotationKind] == "" {
+		return nil
+	}
+	return &Instance{
+		Kind:          annotations[AnnotationKind],
+		Name:          annotations[AnnotationInstance],
+		Engine:        annotations[AnnotationEngine],
+		Project:       annotations[AnnotationProject],
+		Region:        annotations[AnnotationRegion],
+		ResourceGroup: annotations[AnnotationResourceGroup],
+	}
+}
+
+// Manager provisions and deletes database instances using the command line tool of the cloud provider
+type Manager struct {
+	Runner Runner
+}
+
+// NewManager creates a manager of database instances
+func NewManager() *Manager {
+	return &Manager{Runner: DefaultRunner}
+}
+
+// Binary returns the command line tool used to manage the database instance
+func (m *Manager) Binary(instance *Instance) string {
+	switch instance.Kind {
+	case KindCloudSQL:
+		return "gcloud"
+	case KindRDS:
+		return "aws"
+	default:
+		return "az"
+	}
+}
+
+// CreateArgs returns the arguments of the commands which create the database instance, its database and user
+func (m *Manager) CreateArgs(instance *Instance, db *config.DatabaseConfig, password string) [][]string {
+	engine := instance.Engine
+	switch instance.Kind {
+	case KindCloudSQL:
+		version := db.Version
+		if version == "" {
+			if engine == EngineMySQL {
+				version = "5.7"
+			} else {
+				version = "11"
+			}
+		}
+		tier := db.Tier
+		if tier == "" {
+			tier = "db-f1-micro"
+		}
+		create := []string{"sql", "instances", "create", instance.Name,
+			"--database-version", strings.ToUpper(engine) + "_" + strings.Replace(version, ".", "_", -1), "--tier", tier}
+		if db.StorageGB > 0 {
+			create = append(create, "--storage-size", strconv.Itoa(db.StorageGB))
+		}
+		if instance.Region != "" {
+			create = append(create, "--region", instance.Region)
+		}
+		create = append(create, m.projectArgs(instance)...)
+		database := append([]string{"sql", "databases", "create", db.Name, "--instance", instance.Name}, m.projectArgs(instance)...)
+		user := append([]string{"sql", "users", "create", DefaultUsername, "--instance", instance.Name, "--password", password}, m.projectArgs(instance)...)
+		return [][]string{create, database, user}
+	case KindRDS:
+		tier := db.Tier
+		if tier == "" {
+			tier = "db.t3.micro"
+		}
+		storage := db.StorageGB
+		if storage == 0 {
+			storage = 20
+		}
+		create := []string{"rds", "create-db-instance", "--db-instance-identifier", instance.Name, "--engine", engine,
+			"--db-instance-class", tier, "--allocated-storage", strconv.Itoa(storage), "--db-name", rdsDatabaseName(db.Name),
+			"--master-username", DefaultUsername, "--master-user-password", password}
+		if db.Version != "" {
+			create = append(create, "--engine-version", db.Version)
+		}
+		create = append(create, m.regionArgs(instance)...)
+		wait := append([]string{"rds", "wait", "db-instance-available", "--db-instance-identifier", instance.Name}, m.regionArgs(instance)...)
+		return [][]string{create, wait}
+	default:
+		version := db.Version
+		if version == "" {
+			if engine == EngineMySQL {
+				version = "5.7"
+			} else {
+				version = "11"
+			}
+		}
+		tier := db.Tier
+		if tier == "" {
+			tier = "B_Gen5_1"
+		}
+		create := []string{engine, "server", "create", "--name", instance.Name, "--resource-group", instance.ResourceGroup,
+			"--admin-user", DefaultUsername, "--admin-password", password, "--sku-name", tier, "--version", version}
+		if db.StorageGB > 0 {
+			create = append(create, "--storage-size", strconv.Itoa(db.StorageGB*1024))
+		}
+		if instance.Region != "" {
+			create = append(create, "--location", instance.Region)
+		}
+		database := []string{engine, "db", "create", "--name", db.Name, "--server-name", instance.Name, "--resource-group", instance.ResourceGroup}
+		return [][]string{create, database}
+	}
+}
+
+// HostArgs returns the arguments of the command which outputs the host name or IP address of the database instance
+func (m *Manager) HostArgs(instance *Instance) []string {
+	switch instance.Kind {
+	case KindCloudSQL:
+		args := []string{"sql", "instances", "describe", instance.Name, "--format", "value(ipAddresses[0].ipAddress)"}
+		return append(args, m.projectArgs(instance)...)
+	case KindRDS:
+		args := []string{"rds", "describe-db-instances", "--db-instance-identifier", instance.Name,
+			"--query", "DBInstances[0].Endpoint.Address", "--output", "text"}
+		return append(args, m.regionArgs(instance)...)
+	default:
+		return []string{m.azureEngine(instance), "server", "show", "--name", instance.Name, "--resource-group", instance.ResourceGroup,
+			"--query", "fullyQualifiedDomainName", "--output", "tsv"}
+	}
+}
+
+// DeleteArgs returns the arguments of the command which deletes the database instance
+func (m *Manager) DeleteArgs(instance *Instance) []string {
+	switch instance.Kind {
+	case KindCloudSQL:
+		args := []string{"sql", "instances", "delete", instance.Name, "--quiet"}
+		return append(args, m.projectArgs(instance)...)
+	case KindRDS:
+		args := []string{"rds", "delete-db-instance", "--db-instance-identifier", instance.Name, "--skip-final-snapshot"}
+		return append(args, m.regionArgs(instance)...)
+	default:
+		return []string{m.azureEngine(instance), "server", "delete", "--name", instance.Name, "--resource-group", instance.ResourceGroup, "--yes"}
+	}
+}
+
+// Host returns the host name or IP address of the database instance or an error if it does not exist
+func (m *Manager) Host(instance *Instance) (string, error) {
+	output, err := m.run(instance, m.HostArgs(instance))
+	if err != nil {
+		return "", err
+	}
+	host := strings.TrimSpace(output)
+	if host == "" || host == "None" {
+		return "", fmt.Errorf("no host found for database instance %s", instance.Name)
+	}
+	return host, nil
+}
+
+// Provision creates the database instance if it does not exist yet and returns its connection details. The password
+// is only used when the instance is created
+func (m *Manager) Provision(instance *Instance, db *config.DatabaseConfig, password string) (*Connection, error) {
+	err := Validate(db)
+	if err != nil {
+		return nil, err
+	}
+	host, err := m.Host(instance)
+	if err != nil {
+		for _, args := range m.CreateArgs(instance, db, password) {
+			_, err = m.run(instance, args)
+			if err != nil {
+				return nil, err
+			}
+		}
+		host, err = m.Host(instance)
+		if err != nil {
+			return nil, err
+		}
+	}
+	conn := &Connection{
+		Host:     host,
+		Port:     DefaultPort(instance.Engine),
+		Database: db.Name,
+		Username: DefaultUsername,
+		Password: password,
+	}
+	switch instance.Kind {
+	case KindRDS:
+		conn.Database = rdsDatabaseName(db.Name)
+	case KindAzure:
+		// Azure requires the server name in the user name
+		conn.Username = DefaultUsername + "@" + instance.Name
+	}
+	return conn, nil
+}
+
+// Delete deletes the database instance
+func (m *Manager) Delete(instance *Instance) error {
+	_, err := m.run(instance, m.DeleteArgs(instance))
+	return err
+}
+
+func (m *Manager) run(instance *Instance, args []string) (string, error) {
+	runner := m.Runner
+	if runner == nil {
+		runner = DefaultRunner
+	}
+	binary := m.Binary(instance)
+	output, err := runner(binary, args...)
+	if err != nil {
+		// only report the command as its arguments may contain the password
+		command := args
+		if len(command) > 3 {
+			command = command[0:3]
+		}
+		return output, fmt.Errorf("failed to run %s %s: %s %s", binary, strings.Join(command, " "), err, output)
+	}
+	return output, nil
+}
+
+func (m *Manager) projectArgs(instance *Instance) []string {
+	if instance.Project != "" {
+		return []string{"--project", instance.Project}
+	}
+	return []string{}
+}
+
+func (m *Manager) regionArgs(instance *Instance) []string {
+	if instance.Region != "" {
+		return []string{"--region", instance.Region}
+	}
+	return []string{}
+}
+
+// azureEngine returns the az command group of the database instance
+func (m *Manager) azureEngine(instance *Instance) string {
+	if instance.Engine == EngineMySQL {
+		return EngineMySQL
+	}
+	return EnginePostgres
+}
+
+// rdsDatabaseName RDS database names may only contain letters, digits and underscores
+func rdsDatabaseName(name string) string {
+	return strings.Replace(name, "-", "_", -1)
+}
@@ -130,7 +130,7 @@ func GetOrCreateServiceAccount(serviceAccount string, projectId string, clusterC
 				"add-iam-policy-binding",
 				projectId,
 				"--member",
-				fmt.Sprintf("serviceAccount:%s@%s.iam.gserviceaccount.com", serviceAccount, projectId),
+				"serviceAccount:" + ServiceAccountEmail(serviceAccount, projectId),
 				"--role",
 				role,
 				"--project",
@@ -161,7 +161,7 @@ func GetOrCreateServiceAccount(serviceAccount string, projectId string, clusterC
 			"create",
 			keyPath,
 			"--iam-account",
-			fmt.Sprintf("%s@%s.iam.gserviceaccount.com", serviceAccount, projectId),
+			ServiceAccountEmail(serviceAccount, projectId),
 			"--project",
 			projectId}
 
@@ -188,8 +188,6 @@ func GetEnabledApis(projectId string) ([]string, error) {
 		args = append(args, projectId)
 	}
 
-	apis := []string{}
-
 	cmd := util.Command{
 		Name: "gcloud",
 		Args: args,
@@ -199,34 +197,15 @@ func GetEnabledApis(projectId string) ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-
-	lines := strings.Split(string(out), "\n")
-	for _, l := range lines {
-		if strings.Contains(l, "NAME") {
-			continue
-		}
-		fields := strings.Fields(l)
-		apis = append(apis, fields[0])
-	}
-
-	return apis, nil
+	return ParseEnabledApis(out), nil
 }
 
 func EnableApis(projectId string, apis ...string) error {
-	enabledApis, err := GetEnabledApis(projectId)
+	toEnableArray, err := GetMissingApis(projectId, apis...)
 	if err != nil {
 		return err
 	}
 
-	toEnableArray := []string{}
-
-	for _, toEnable := range apis {
-		fullName := fmt.Sprintf("%s.googleapis.com", toEnable)
-		if !util.Contains(enabledApis, fullName) {
-			toEnableArray = append(toEnableArray, toEnable)
-		}
-	}
-
 	if len(toEnableArray) == 0 {
 		log.Infof("No apis to enable\n")
 		return nil
@@ -239,7 +218,7 @@ func EnableApis(projectId string, apis ...string) error {
 		args = append(args, projectId)
 	}
 
-	log.Infof("Lets ensure we have container and compute enabled on your project via: %s\n", util.ColorInfo("gcloud "+strings.Join(args, " ")))
+	log.Infof("Lets ensure we have %s enabled on your project via: %s\n", strings.Join(toEnableArray, ", "), util.ColorInfo("gcloud "+strings.Join(args, " ")))
 
 	cmd := util.Command{
 		Name: "gcloud",
@@ -14,3 +14,16 @@ func TestGetRegionFromZone(t *testing.T) {
 	r = GetRegionFromZone("uswest1-d")
 	assert.Equal(t, r, "uswest1")
 }
+
+func TestParseEnabledApis(t *testing.T) {
+	t.Parallel()
+	output := `NAME                              TITLE
+compute.googleapis.com            Compute Engine API
+container.googleapis.com          Kubernetes Engine API
+
+`
+	enabled := ParseEnabledApis(output)
+	assert.Equal(t, []string{"compute.googleapis.com", "container.googleapis.com"}, enabled)
+	assert.Equal(t, []string{"iam"}, MissingApis(enabled, RequiredApis...))
+	assert.Empty(t, MissingApis(enabled, "compute"))
+}
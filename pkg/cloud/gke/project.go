@@ -0,0 +1,127 @@
+package gke
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	// RequiredApis the Google Cloud APIs which need to be enabled on a project to create a GKE cluster
+	RequiredApis = []string{"container", "compute", "iam"}
+
+	// PlatformServiceAccountRoles the least privilege roles of the service account used by the nodes of the
+	// platform to write logs and metrics and to pull and push images to the container registry
+	PlatformServiceAccountRoles = []string{
+		"roles/logging.logWriter",
+		"roles/monitoring.metricWriter",
+		"roles/monitoring.viewer",
+		"roles/storage.objectAdmin",
+	}
+)
+
+// ParseEnabledApis parses the output of gcloud services list into the names of the APIs
+func ParseEnabledApis(output string) []string {
+	apis := []string{}
+	for _, l := range strings.Split(output, "\n") {
+		fields := strings.Fields(l)
+		if len(fields) == 0 || fields[0] == "NAME" {
+			continue
+		}
+		apis = append(apis, fields[0])
+	}
+	return apis
+}
+
+// MissingApis returns the short names of the APIs which are not in the enabled APIs
+func MissingApis(enabledApis []string, apis ...string) []string {
+	answer := []string{}
+	for _, api := range apis {
+		if !util.Contains(enabledApis, fmt.Sprintf("%s.googleapis.com", api)) {
+			answer = append(answer, api)
+		}
+	}
+	return answer
+}
+
+// GetMissingApis returns the short names of the APIs which are not enabled on the project
+func GetMissingApis(projectId string, apis ...string) ([]string, error) {
+	enabledApis, err := GetEnabledApis(projectId)
+	if err != nil {
+		return nil, err
+	}
+	return MissingApis(enabledApis, apis...), nil
+}
+
+// ValidateProject returns an error if the project does not exist or is not active. Projects without billing are
+// reported as a warning as the billing account may not be visible to the current user
+func ValidateProject(projectId string) error {
+	cmd := util.Command{
+		Name: "gcloud",
+		Args: []string{"projects", "describe", projectId, "--format", "value(lifecycleState)"},
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return fmt.Errorf("could not find the Google Cloud project %s which may not exist or you may not have access to: %s", projectId, output)
+	}
+	state := strings.TrimSpace(output)
+	if state != "ACTIVE" {
+		return fmt.Errorf("the Google Cloud project %s is %s rather than ACTIVE", projectId, state)
+	}
+
+	cmd = util.Command{
+		Name: "gcloud",
+		Args: []string{"beta", "billing", "projects", "describe", projectId, "--format", "value(billingEnabled)"},
+	}
+	output, err = cmd.RunWithoutRetry()
+	if err != nil {
+		log.Warnf("Could not check billing is enabled on the project %s: %s\n", projectId, output)
+	} else if strings.TrimSpace(strings.ToLower(output)) != "true" {
+		return fmt.Errorf("billing is not enabled on the Google Cloud project %s. See https://cloud.google.com/billing/docs/how-to/modify-project", projectId)
+	}
+	return nil
+}
+
+// ServiceAccountEmail returns the email of the service account in the project
+func ServiceAccountEmail(serviceAccount string, projectId string) string {
+	return fmt.Sprintf("%s@%s.iam.gserviceaccount.com", serviceAccount, projectId)
+}
+
+// EnsureServiceAccount creates the service account if it does not exist and binds the roles to it returning the email
+// of the service account
+func EnsureServiceAccount(serviceAccount string, projectId string, roles []string) (string, error) {
+	email := ServiceAccountEmail(serviceAccount, projectId)
+	cmd := util.Command{
+		Name: "gcloud",
+		Args: []string{"iam", "service-accounts", "list", "--filter", "email:" + email, "--format", "value(email)", "--project", projectId},
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(output) == "" {
+		log.Infof("Creating service account %s\n", util.ColorInfo(email))
+		cmd = util.Command{
+			Name: "gcloud",
+			Args: []string{"iam", "service-accounts", "create", serviceAccount, "--display-name", serviceAccount, "--project", projectId},
+		}
+		_, err = cmd.RunWithoutRetry()
+		if err != nil {
+			return "", err
+		}
+	}
+	for _, role := range roles {
+		log.Infof("Assigning role %s to %s\n", util.ColorInfo(role), email)
+		cmd = util.Command{
+			Name: "gcloud",
+			Args: []string{"projects", "add-iam-policy-binding", projectId, "--member", "serviceAccount:" + email, "--role", role},
+		}
+		_, err = cmd.RunWithoutRetry()
+		if err != nil {
+			return "", err
+		}
+	}
+	return email, nil
+}
@@ -0,0 +1,444 @@
+package kms
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/util/buckets"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SchemeGCP the URL scheme of Google Cloud KMS keys such as
+	// gcpkms://projects/myproject/locations/global/keyRings/jx/cryptoKeys/backup
+	SchemeGCP = "gcpkms"
+
+	// SchemeAWS the URL scheme of AWS KMS keys such as awskms://alias/backup?region=us-east-1
+	SchemeAWS = "awskms"
+
+	// SchemeAzure the URL scheme of Azure Key Vault keys such as azurekeyvault://myvault/keys/backup
+	SchemeAzure = "azurekeyvault"
+
+	// DefaultGCPLocation the location of Google Cloud KMS key rings when a key is resolved from its name
+	DefaultGCPLocation = "global"
+
+	// DefaultGCPKeyRing the key ring of Google Cloud KMS keys when a key is resolved from its name
+	DefaultGCPKeyRing = "jx"
+
+	// EncryptedFileExtension the extension of files encrypted via Seal
+	EncryptedFileExtension = ".enc"
+)
+
+// Schemes the supported KMS key URL schemes
+var Schemes = []string{SchemeGCP, SchemeAWS, SchemeAzure}
+
+// providerSchemes the KMS of the cloud provider of a cluster
+var providerSchemes = map[string]string{
+	"gke": SchemeGCP,
+	"eks": SchemeAWS,
+	"aws": SchemeAWS,
+	"aks": SchemeAzure,
+}
+
+// awsKeyIDRegex matches the ID of an AWS KMS key which, unlike a name, is not prefixed with alias/
+var awsKeyIDRegex = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+// Runner runs a command line tool with the additional environment variables returning its output
+type Runner func(env map[string]string, name string, args ...string) (string, error)
+
+// DefaultRunner runs the command line tool
+func DefaultRunner(env map[string]string, name string, args ...string) (string, error) {
+	cmd := util.Command{
+		Name: name,
+		Args: args,
+		Env:  env,
+	}
+	return cmd.RunWithoutRetry()
+}
+
+// Key identifies a key of a cloud KMS
+type Key struct {
+	Scheme string
+	// Name the name of the GCP key, the ID, ARN or alias of the AWS key or the name of the Azure key
+	Name string
+	// Project the GCP project
+	Project string
+	// Location the GCP location of the key ring
+	Location string
+	// KeyRing the GCP key ring
+	KeyRing string
+	// Region the AWS region
+	Region string
+	// Vault the name of the Azure Key Vault
+	Vault string
+}
+
+// ParseKeyURL parses the URL of a KMS key
+func ParseKeyURL(keyURL string) (*Key, error) {
+	i := strings.Index(keyURL, "://")
+	if i <= 0 {
+		return nil, fmt.Errorf("invalid KMS key URL %s", keyURL)
+	}
+	key := &Key{Scheme: strings.ToLower(keyURL[:i])}
+	path := keyURL[i+3:]
+	query := ""
+	if j := strings.Index(path, "?"); j >= 0 {
+		path, query = path[:j], path[j+1:]
+	}
+	path = strings.Trim(path, "/")
+	paths := strings.Split(path, "/")
+	switch key.Scheme {
+	case SchemeGCP:
+		if len(paths) != 8 || paths[0] != "projects" || paths[2] != "locations" || paths[4] != "keyRings" || paths[6] != "cryptoKeys" {
+			return nil, fmt.Errorf("invalid Google Cloud KMS key URL %s. Expected %s://projects/PROJECT/locations/LOCATION/keyRings/KEYRING/cryptoKeys/KEY", keyURL, SchemeGCP)
+		}
+		key.Project = paths[1]
+		key.Location = paths[3]
+		key.KeyRing = paths[5]
+		key.Name = paths[7]
+	case SchemeAWS:
+		if path == "" {
+			return nil, fmt.Errorf("no key in AWS KMS key URL %s", keyURL)
+		}
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid KMS key URL %s: %s", keyURL, err)
+		}
+		// the key ID, ARN or alias
+		key.Name = path
+		key.Region = values.Get("region")
+	case SchemeAzure:
+		if len(paths) != 3 || paths[1] != "keys" {
+			return nil, fmt.Errorf("invalid Azure Key Vault key URL %s. Expected %s://VAULT/keys/KEY", keyURL, SchemeAzure)
+		}
+		key.Vault = paths[0]
+		key.Name = paths[2]
+	default:
+		return nil, fmt.Errorf("unsupported KMS key URL %s. Supported schemes are: %s", keyURL, strings.Join(Schemes, ", "))
+	}
+	return key, nil
+}
+
+// ResolveKey returns the key with the given URL or, if the key is just a name, the key of the KMS of the cluster's
+// cloud provider using the project or region of the provider credentials. Azure keys are named VAULT/KEY
+func ResolveKey(key string, provider string, credentials *buckets.Credentials) (*Key, error) {
+	if key == "" {
+		return nil, fmt.Errorf("no KMS key specified")
+	}
+	if strings.Contains(key, "://") {
+		answer, err := ParseKeyURL(key)
+		if err != nil {
+			return nil, err
+		}
+		if answer.Scheme == SchemeAWS && answer.Region == "" && credentials != nil {
+			answer.Region = credentials.AWSRegion
+		}
+		return answer, nil
+	}
+	if credentials == nil {
+		credentials = &buckets.Credentials{}
+	}
+	scheme := providerSchemes[provider]
+	switch scheme {
+	case SchemeGCP:
+		return &Key{
+			Scheme:   SchemeGCP,
+			Name:     key,
+			Project:  googleProjectID(credentials),
+			Location: DefaultGCPLocation,
+			KeyRing:  DefaultGCPKeyRing,
+		}, nil
+	case SchemeAWS:
+		name := key
+		if !strings.HasPrefix(name, "arn:") && !strings.HasPrefix(name, "alias/") && !awsKeyIDRegex.MatchString(name) {
+			name = "alias/" + name
+		}
+		return &Key{Scheme: SchemeAWS, Name: name, Region: credentials.AWSRegion}, nil
+	case SchemeAzure:
+		paths := strings.Split(key, "/")
+		if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+			return nil, fmt.Errorf("the Azure Key Vault key %s must be specified as VAULT/KEY", key)
+		}
+		return &Key{Scheme: SchemeAzure, Vault: paths[0], Name: paths[1]}, nil
+	default:
+		return nil, fmt.Errorf("cannot resolve the KMS key %s for the cloud provider %s. Please specify the URL of the key using one of the schemes: %s",
+			key, provider, strings.Join(Schemes, ", "))
+	}
+}
+
+// googleProjectID returns the configured project or the project of the service account key
+func googleProjectID(credentials *buckets.Credentials) string {
+	if credentials.GoogleProjectID != "" || len(credentials.GoogleCredentialsJSON) == 0 {
+		return credentials.GoogleProjectID
+	}
+	serviceAccount := struct {
+		ProjectID string `json:"project_id"`
+	}{}
+	err := yaml.Unmarshal(credentials.GoogleCredentialsJSON, &serviceAccount)
+	if err != nil {
+		return ""
+	}
+	return serviceAccount.ProjectID
+}
+
+// URL returns the URL of the key
+func (k *Key) URL() string {
+	switch k.Scheme {
+	case SchemeGCP:
+		project := k.Project
+		if project == "" {
+			project = "-"
+		}
+		return fmt.Sprintf("%s://projects/%s/locations/%s/keyRings/%s/cryptoKeys/%s", SchemeGCP, project, k.Location, k.KeyRing, k.Name)
+	case SchemeAWS:
+		answer := SchemeAWS + "://" + k.Name
+		if k.Region != "" {
+			answer += "?region=" + url.QueryEscape(k.Region)
+		}
+		return answer
+	default:
+		return fmt.Sprintf("%s://%s/keys/%s", SchemeAzure, k.Vault, k.Name)
+	}
+}
+
+// Binary returns the command line tool used to encrypt with the key
+func (k *Key) Binary() string {
+	switch k.Scheme {
+	case SchemeGCP:
+		return "gcloud"
+	case SchemeAWS:
+		return "aws"
+	default:
+		return "az"
+	}
+}
+
+// Manager encrypts data with a data key which is itself encrypted by a cloud KMS key so that only the holders of
+// the cloud credentials can decrypt it
+type Manager struct {
+	Key    Key
+	Runner Runner
+	// Env the environment variables passing the provider credentials to the command line tool
+	Env map[string]string
+}
+
+// NewManager creates a manager for the key using the provider credentials. Blank credentials are resolved by the
+// command line tool of the provider from its own configuration
+func NewManager(key *Key, credentials *buckets.Credentials) (*Manager, error) {
+	if key == nil || key.Name == "" {
+		return nil, fmt.Errorf("no KMS key specified")
+	}
+	env := map[string]string{}
+	if credentials != nil && key.Scheme == SchemeAWS {
+		setEnv(env, "AWS_ACCESS_KEY_ID", credentials.AWSAccessKeyID)
+		setEnv(env, "AWS_SECRET_ACCESS_KEY", credentials.AWSSecretAccessKey)
+		setEnv(env, "AWS_SESSION_TOKEN", credentials.AWSSessionToken)
+	}
+	return &Manager{
+		Key:    *key,
+		Runner: DefaultRunner,
+		Env:    env,
+	}, nil
+}
+
+func setEnv(env map[string]string, name string, value string) {
+	if value != "" {
+		env[name] = value
+	}
+}
+
+// envelope the file format of data encrypted via Seal
+type envelope struct {
+	KMSKey       string `json:"kmsKey"`
+	EncryptedKey string `json:"encryptedKey"`
+	Data         string `json:"data"`
+}
+
+// Seal encrypts the data with a new data key which is encrypted by the KMS key and stored alongside the data
+func (m *Manager) Seal(data []byte) ([]byte, error) {
+	dataKey, err := appconfig.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := m.Encrypt(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := appconfig.Encrypt(dataKey, string(data))
+	if err != nil {
+		return nil, err
+	}
+	return yaml.Marshal(&envelope{
+		KMSKey:       m.Key.URL(),
+		EncryptedKey: base64.StdEncoding.EncodeToString(encryptedKey),
+		Data:         encrypted,
+	})
+}
+
+// Open decrypts data encrypted via Seal
+func (m *Manager) Open(data []byte) ([]byte, error) {
+	e, err := loadEnvelope(data)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := base64.StdEncoding.DecodeString(e.EncryptedKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the encrypted data key")
+	}
+	dataKey, err := m.Decrypt(encryptedKey)
+	if err != nil {
+		return nil, err
+	}
+	plain, err := appconfig.Decrypt(dataKey, e.Data)
+	if err != nil {
+		return nil, err
+	}
+	return []byte(plain), nil
+}
+
+// SealedKeyURL returns the URL of the KMS key which encrypted the data via Seal
+func SealedKeyURL(data []byte) (string, error) {
+	e, err := loadEnvelope(data)
+	if err != nil {
+		return "", err
+	}
+	return e.KMSKey, nil
+}
+
+func loadEnvelope(data []byte) (*envelope, error) {
+	e := &envelope{}
+	err := yaml.Unmarshal(data, e)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse the encrypted data")
+	}
+	if e.KMSKey == "" || e.EncryptedKey == "" || !appconfig.IsEncrypted(e.Data) {
+		return nil, fmt.Errorf("the data was not encrypted with a KMS key")
+	}
+	return e, nil
+}
+
+// Encrypt encrypts a small amount of data, such as a data key, directly with the KMS key
+func (m *Manager) Encrypt(plain []byte) ([]byte, error) {
+	k := m.Key
+	switch k.Scheme {
+	case SchemeGCP:
+		return m.runWithFiles("encrypt", plain)
+	case SchemeAWS:
+		return m.runWithFileBlob([]string{"kms", "encrypt", "--key-id", k.Name, "--plaintext"}, "CiphertextBlob", plain)
+	default:
+		return m.runAzure("encrypt", plain)
+	}
+}
+
+// Decrypt decrypts data encrypted via Encrypt
+func (m *Manager) Decrypt(encrypted []byte) ([]byte, error) {
+	k := m.Key
+	switch k.Scheme {
+	case SchemeGCP:
+		return m.runWithFiles("decrypt", encrypted)
+	case SchemeAWS:
+		return m.runWithFileBlob([]string{"kms", "decrypt", "--key-id", k.Name, "--ciphertext-blob"}, "Plaintext", encrypted)
+	default:
+		return m.runAzure("decrypt", encrypted)
+	}
+}
+
+// runWithFiles runs gcloud which reads and writes binary data via files
+func (m *Manager) runWithFiles(action string, input []byte) ([]byte, error) {
+	k := m.Key
+	dir, err := ioutil.TempDir("", "jx-kms-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	inFile := filepath.Join(dir, "in")
+	outFile := filepath.Join(dir, "out")
+	err = ioutil.WriteFile(inFile, input, 0600)
+	if err != nil {
+		return nil, err
+	}
+	inFlag, outFlag := "--plaintext-file", "--ciphertext-file"
+	if action == "decrypt" {
+		inFlag, outFlag = outFlag, inFlag
+	}
+	args := []string{"kms", action, "--key", k.Name, "--keyring", k.KeyRing, "--location", k.Location, inFlag, inFile, outFlag, outFile}
+	if k.Project != "" && k.Project != "-" {
+		args = append(args, "--project", k.Project)
+	}
+	_, err = m.run(args)
+	if err != nil {
+		return nil, err
+	}
+	return ioutil.ReadFile(outFile)
+}
+
+// runWithFileBlob runs the aws CLI which reads binary data via a fileb:// URL and returns the base64 encoded result
+func (m *Manager) runWithFileBlob(args []string, query string, input []byte) ([]byte, error) {
+	k := m.Key
+	file, err := ioutil.TempFile("", "jx-kms-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(file.Name())
+	_, err = file.Write(input)
+	file.Close()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, "fileb://"+file.Name(), "--output", "text", "--query", query)
+	if k.Region != "" {
+		args = append(args, "--region", k.Region)
+	}
+	output, err := m.run(args)
+	if err != nil {
+		return nil, err
+	}
+	return decodeOutput(output)
+}
+
+// runAzure runs the az CLI which reads and returns base64 encoded data
+func (m *Manager) runAzure(action string, input []byte) ([]byte, error) {
+	k := m.Key
+	args := []string{"keyvault", "key", action, "--vault-name", k.Vault, "--name", k.Name, "--algorithm", "RSA-OAEP-256",
+		"--data-type", "base64", "--value", base64.StdEncoding.EncodeToString(input), "--query", "result", "--output", "tsv"}
+	output, err := m.run(args)
+	if err != nil {
+		return nil, err
+	}
+	return decodeOutput(output)
+}
+
+func decodeOutput(output string) ([]byte, error) {
+	output = strings.TrimSpace(output)
+	answer, err := base64.StdEncoding.DecodeString(output)
+	if err != nil {
+		// Azure Key Vault may return base64url encoded results
+		answer, err = base64.RawURLEncoding.DecodeString(strings.TrimRight(output, "="))
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to decode the output of the KMS")
+	}
+	return answer, nil
+}
+
+func (m *Manager) run(args []string) (string, error) {
+	runner := m.Runner
+	if runner == nil {
+		runner = DefaultRunner
+	}
+	binary := m.Key.Binary()
+	output, err := runner(m.Env, binary, args...)
+	if err != nil {
+		return "", fmt.Errorf("failed to run %s with the KMS key %s: %s %s", binary, m.Key.URL(), err, output)
+	}
+	return output, nil
+}
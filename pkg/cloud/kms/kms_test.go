@@ -0,0 +1,165 @@
+package kms_test
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/cloud/kms"
+	"github.com/jenkins-x/jx/pkg/util/buckets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeyURL(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		url string
+		key kms.Key
+	}{
+		{
+			url: "gcpkms://projects/myproject/locations/global/keyRings/jx/cryptoKeys/backup",
+			key: kms.Key{Scheme: kms.SchemeGCP, Name: "backup", Project: "myproject", Location: "global", KeyRing: "jx"},
+		},
+		{
+			url: "awskms://alias/backup?region=us-east-1",
+			key: kms.Key{Scheme: kms.SchemeAWS, Name: "alias/backup", Region: "us-east-1"},
+		},
+		{
+			url: "awskms://arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab",
+			key: kms.Key{Scheme: kms.SchemeAWS, Name: "arn:aws:kms:eu-west-1:123456789012:key/1234abcd-12ab-34cd-56ef-1234567890ab"},
+		},
+		{
+			url: "azurekeyvault://myvault/keys/backup",
+			key: kms.Key{Scheme: kms.SchemeAzure, Name: "backup", Vault: "myvault"},
+		},
+	}
+	for _, test := range tests {
+		key, err := kms.ParseKeyURL(test.url)
+		require.NoError(t, err, "parsing %s", test.url)
+		assert.Equal(t, test.key, *key, "parsing %s", test.url)
+		assert.Equal(t, test.url, key.URL())
+	}
+
+	invalid := []string{"backup", "gcpkms://projects/myproject/cryptoKeys/backup", "azurekeyvault://myvault/backup", "vault://backup"}
+	for _, u := range invalid {
+		_, err := kms.ParseKeyURL(u)
+		assert.Error(t, err, "parsing %s", u)
+	}
+}
+
+func TestResolveKey(t *testing.T) {
+	t.Parallel()
+	credentials := &buckets.Credentials{
+		GoogleCredentialsJSON: []byte(`{"type": "service_account", "project_id": "myproject"}`),
+		AWSRegion:             "eu-west-1",
+	}
+	tests := []struct {
+		key      string
+		provider string
+		url      string
+	}{
+		{"backup", "gke", "gcpkms://projects/myproject/locations/global/keyRings/jx/cryptoKeys/backup"},
+		{"backup", "eks", "awskms://alias/backup?region=eu-west-1"},
+		{"1234abcd-12ab-34cd-56ef-1234567890ab", "aws", "awskms://1234abcd-12ab-34cd-56ef-1234567890ab?region=eu-west-1"},
+		{"myvault/backup", "aks", "azurekeyvault://myvault/keys/backup"},
+		{"awskms://alias/backup", "gke", "awskms://alias/backup?region=eu-west-1"},
+	}
+	for _, test := range tests {
+		key, err := kms.ResolveKey(test.key, test.provider, credentials)
+		require.NoError(t, err, "resolving %s for %s", test.key, test.provider)
+		assert.Equal(t, test.url, key.URL(), "resolving %s for %s", test.key, test.provider)
+	}
+
+	_, err := kms.ResolveKey("backup", "minikube", credentials)
+	assert.Error(t, err)
+	_, err = kms.ResolveKey("backup", "aks", credentials)
+	assert.Error(t, err, "Azure keys need a vault")
+}
+
+func TestSealAndOpen(t *testing.T) {
+	t.Parallel()
+	keys := []string{
+		"gcpkms://projects/myproject/locations/global/keyRings/jx/cryptoKeys/backup",
+		"awskms://alias/backup?region=us-east-1",
+		"azurekeyvault://myvault/keys/backup",
+	}
+	for _, keyURL := range keys {
+		key, err := kms.ParseKeyURL(keyURL)
+		require.NoError(t, err)
+		m, err := kms.NewManager(key, &buckets.Credentials{AWSAccessKeyID: "myid", AWSSecretAccessKey: "mysecret"})
+		require.NoError(t, err)
+		m.Runner = fakeKMS(t, key.Binary())
+
+		sealed, err := m.Seal([]byte("password: secret\n"))
+		require.NoError(t, err)
+		assert.NotContains(t, string(sealed), "secret\n")
+
+		sealedKey, err := kms.SealedKeyURL(sealed)
+		require.NoError(t, err)
+		assert.Equal(t, keyURL, sealedKey)
+
+		data, err := m.Open(sealed)
+		require.NoError(t, err, "opening data sealed by %s", keyURL)
+		assert.Equal(t, "password: secret\n", string(data))
+	}
+
+	_, err := kms.SealedKeyURL([]byte("password: secret\n"))
+	assert.Error(t, err)
+}
+
+// fakeKMS returns a runner which "encrypts" by reversing the bytes using the arguments of the CLI of the provider
+func fakeKMS(t *testing.T, binary string) kms.Runner {
+	return func(env map[string]string, name string, args ...string) (string, error) {
+		assert.Equal(t, binary, name)
+		if name == "aws" {
+			assert.Equal(t, map[string]string{"AWS_ACCESS_KEY_ID": "myid", "AWS_SECRET_ACCESS_KEY": "mysecret"}, env)
+		}
+		flags := map[string]string{}
+		for i, arg := range args {
+			if strings.HasPrefix(arg, "--") && i+1 < len(args) {
+				flags[arg] = args[i+1]
+			}
+		}
+		switch name {
+		case "gcloud":
+			in := flags["--plaintext-file"]
+			out := flags["--ciphertext-file"]
+			if args[1] == "decrypt" {
+				in, out = out, in
+			}
+			data, err := ioutil.ReadFile(in)
+			if err != nil {
+				return "", err
+			}
+			return "", ioutil.WriteFile(out, reverse(data), 0600)
+		case "aws":
+			file := flags["--plaintext"]
+			if file == "" {
+				file = flags["--ciphertext-blob"]
+			}
+			data, err := ioutil.ReadFile(strings.TrimPrefix(file, "fileb://"))
+			if err != nil {
+				return "", err
+			}
+			return base64.StdEncoding.EncodeToString(reverse(data)) + "\n", nil
+		case "az":
+			data, err := base64.StdEncoding.DecodeString(flags["--value"])
+			if err != nil {
+				return "", err
+			}
+			return base64.StdEncoding.EncodeToString(reverse(data)), nil
+		}
+		return "", fmt.Errorf("unknown binary %s", name)
+	}
+}
+
+func reverse(data []byte) []byte {
+	answer := make([]byte, len(data))
+	for i, b := range data {
+		answer[len(data)-1-i] = b
+	}
+	return answer
+}
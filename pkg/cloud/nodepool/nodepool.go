@@ -0,0 +1,230 @@
+package nodepool
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ProviderGKE Google Kubernetes Engine
+	ProviderGKE = "gke"
+	// ProviderEKS Amazon Elastic Container Service for Kubernetes
+	ProviderEKS = "eks"
+	// ProviderAKS Azure Kubernetes Service
+	ProviderAKS = "aks"
+)
+
+// Providers the cloud providers which support node pools
+var Providers = []string{ProviderGKE, ProviderEKS, ProviderAKS}
+
+// Runner runs a command line tool returning its output
+type Runner func(name string, args ...string) (string, error)
+
+// DefaultRunner runs the command line tool
+func DefaultRunner(name string, args ...string) (string, error) {
+	cmd := util.Command{
+		Name: name,
+		Args: args,
+	}
+	return cmd.RunWithoutRetry()
+}
+
+// Cluster identifies the cluster whose node pools are managed
+type Cluster struct {
+	Provider string
+	Name     string
+	// Project the GKE project
+	Project string
+	// Zone the GKE zone or EKS region
+	Zone string
+	// ResourceGroup the AKS resource group
+	ResourceGroup string
+}
+
+// NodePool the settings of a node pool
+type NodePool struct {
+	Name        string
+	MachineType string
+	Nodes       int
+	// MinNodes and MaxNodes enable the cluster autoscaler for the node pool when MaxNodes is greater than zero
+	MinNodes int
+	MaxNodes int
+	// Spot uses spot or preemptible nodes which are cheaper but can be reclaimed by the cloud provider at any time
+	Spot bool
+}
+
+// Autoscaling returns true if the cluster autoscaler is enabled for the node pool
+func (p *NodePool) Autoscaling() bool {
+	return p.MaxNodes > 0
+}
+
+// Validate returns an error if the node pool settings are invalid
+func (p *NodePool) Validate() error {
+	if p.Name == "" {
+		return fmt.Errorf("the node pool has no name")
+	}
+	if p.Nodes < 0 {
+		return fmt.Errorf("the number of nodes must not be negative but was %d", p.Nodes)
+	}
+	if p.Autoscaling() {
+		if p.MinNodes < 0 || p.MinNodes > p.MaxNodes {
+			return fmt.Errorf("the minimum number of nodes %d must be between 0 and the maximum number of nodes %d", p.MinNodes, p.MaxNodes)
+		}
+		if p.Nodes < p.MinNodes || p.Nodes > p.MaxNodes {
+			return fmt.Errorf("the number of nodes %d must be between the minimum %d and maximum %d number of nodes", p.Nodes, p.MinNodes, p.MaxNodes)
+		}
+	}
+	return nil
+}
+
+// Manager creates and scales the node pools of a cluster using the command line tool of its cloud provider
+type Manager struct {
+	Cluster Cluster
+	Runner  Runner
+}
+
+// NewManager creates a manager for the node pools of the cluster
+func NewManager(cluster Cluster) (*Manager, error) {
+	if util.StringArrayIndex(Providers, cluster.Provider) < 0 {
+		return nil, util.InvalidArg(cluster.Provider, append([]string{}, Providers...))
+	}
+	if cluster.Name == "" {
+		return nil, fmt.Errorf("no cluster name specified")
+	}
+	if cluster.Provider == ProviderAKS && cluster.ResourceGroup == "" {
+		return nil, fmt.Errorf("no resource group specified for the AKS cluster %s", cluster.Name)
+	}
+	return &Manager{
+		Cluster: cluster,
+		Runner:  DefaultRunner,
+	}, nil
+}
+
+// Binary returns the command line tool used to manage the node pools
+func (m *Manager) Binary() string {
+	switch m.Cluster.Provider {
+	case ProviderGKE:
+		return "gcloud"
+	case ProviderEKS:
+		return "eksctl"
+	default:
+		return "az"
+	}
+}
+
+// CreateArgs returns the arguments of the command which creates the node pool
+func (m *Manager) CreateArgs(pool NodePool) []string {
+	c := m.Cluster
+	nodes := strconv.Itoa(pool.Nodes)
+	var args []string
+	switch c.Provider {
+	case ProviderGKE:
+		args = []string{"container", "node-pools", "create", pool.Name, "--cluster", c.Name, "--num-nodes", nodes}
+		if pool.MachineType != "" {
+			args = append(args, "--machine-type", pool.MachineType)
+		}
+		if pool.Autoscaling() {
+			args = append(args, "--enable-autoscaling", "--min-nodes", strconv.Itoa(pool.MinNodes), "--max-nodes", strconv.Itoa(pool.MaxNodes))
+		}
+		if pool.Spot {
+			args = append(args, "--preemptible")
+			taint := kube.SpotNodeTaint(ProviderGKE)
+			args = append(args, "--node-taints", fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+		}
+		args = append(args, m.gkeLocationArgs()...)
+	case ProviderEKS:
+		args = []string{"create", "nodegroup", "--cluster", c.Name, "--name", pool.Name, "--nodes", nodes}
+		if pool.MachineType != "" {
+			args = append(args, "--node-type", pool.MachineType)
+		}
+		if pool.Autoscaling() {
+			args = append(args, "--nodes-min", strconv.Itoa(pool.MinNodes), "--nodes-max", strconv.Itoa(pool.MaxNodes))
+		}
+		if pool.Spot {
+			args = append(args, "--managed", "--spot")
+		}
+		args = append(args, m.eksRegionArgs()...)
+	default:
+		args = []string{"aks", "nodepool", "add", "--resource-group", c.ResourceGroup, "--cluster-name", c.Name, "--name", pool.Name, "--node-count", nodes}
+		if pool.MachineType != "" {
+			args = append(args, "--node-vm-size", pool.MachineType)
+		}
+		if pool.Autoscaling() {
+			args = append(args, "--enable-cluster-autoscaler", "--min-count", strconv.Itoa(pool.MinNodes), "--max-count", strconv.Itoa(pool.MaxNodes))
+		}
+		if pool.Spot {
+			// AKS taints spot nodes itself
+			args = append(args, "--priority", "Spot", "--eviction-policy", "Delete", "--spot-max-price", "-1")
+		}
+	}
+	return args
+}
+
+// ScaleArgs returns the arguments of the command which changes the number of nodes of the node pool
+func (m *Manager) ScaleArgs(name string, nodes int) []string {
+	c := m.Cluster
+	count := strconv.Itoa(nodes)
+	switch c.Provider {
+	case ProviderGKE:
+		args := []string{"container", "clusters", "resize", c.Name, "--node-pool", name, "--num-nodes", count, "--quiet"}
+		return append(args, m.gkeLocationArgs()...)
+	case ProviderEKS:
+		args := []string{"scale", "nodegroup", "--cluster", c.Name, "--name", name, "--nodes", count}
+		return append(args, m.eksRegionArgs()...)
+	default:
+		return []string{"aks", "nodepool", "scale", "--resource-group", c.ResourceGroup, "--cluster-name", c.Name, "--name", name, "--node-count", count}
+	}
+}
+
+// Create creates the node pool
+func (m *Manager) Create(pool NodePool) error {
+	err := pool.Validate()
+	if err != nil {
+		return err
+	}
+	return m.run(m.CreateArgs(pool))
+}
+
+// Scale changes the number of nodes of the node pool
+func (m *Manager) Scale(name string, nodes int) error {
+	if name == "" {
+		return fmt.Errorf("the node pool has no name")
+	}
+	if nodes < 0 {
+		return fmt.Errorf("the number of nodes must not be negative but was %d", nodes)
+	}
+	return m.run(m.ScaleArgs(name, nodes))
+}
+
+func (m *Manager) run(args []string) error {
+	runner := m.Runner
+	if runner == nil {
+		runner = DefaultRunner
+	}
+	output, err := runner(m.Binary(), args...)
+	if err != nil {
+		return fmt.Errorf("failed to run %s: %s %s", m.Binary(), err, output)
+	}
+	return nil
+}
+
+func (m *Manager) gkeLocationArgs() []string {
+	args := []string{}
+	if m.Cluster.Zone != "" {
+		args = append(args, "--zone", m.Cluster.Zone)
+	}
+	if m.Cluster.Project != "" {
+		args = append(args, "--project", m.Cluster.Project)
+	}
+	return args
+}
+
+func (m *Manager) eksRegionArgs() []string {
+	if m.Cluster.Zone != "" {
+		return []string{"--region", m.Cluster.Zone}
+	}
+	return []string{}
+}
@@ -0,0 +1,105 @@
+package nodepool_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/cloud/nodepool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNodePoolValidate(t *testing.T) {
+	t.Parallel()
+	assert.NoError(t, (&nodepool.NodePool{Name: "builds", Nodes: 2}).Validate())
+	assert.NoError(t, (&nodepool.NodePool{Name: "builds", Nodes: 1, MinNodes: 0, MaxNodes: 5}).Validate())
+
+	invalid := []*nodepool.NodePool{
+		{Nodes: 1},
+		{Name: "builds", Nodes: -1},
+		{Name: "builds", Nodes: 1, MinNodes: 3, MaxNodes: 2},
+		{Name: "builds", Nodes: 6, MinNodes: 1, MaxNodes: 5},
+	}
+	for _, p := range invalid {
+		assert.Error(t, p.Validate(), "node pool %#v", p)
+	}
+}
+
+func TestNewManager(t *testing.T) {
+	t.Parallel()
+	_, err := nodepool.NewManager(nodepool.Cluster{Provider: "minikube", Name: "mycluster"})
+	assert.Error(t, err)
+	_, err = nodepool.NewManager(nodepool.Cluster{Provider: nodepool.ProviderGKE})
+	assert.Error(t, err)
+	_, err = nodepool.NewManager(nodepool.Cluster{Provider: nodepool.ProviderAKS, Name: "mycluster"})
+	assert.Error(t, err, "AKS requires a resource group")
+}
+
+func TestCreateAndScale(t *testing.T) {
+	t.Parallel()
+	pool := nodepool.NodePool{Name: "builds", MachineType: "big", Nodes: 1, MinNodes: 1, MaxNodes: 5}
+	tests := []struct {
+		cluster nodepool.Cluster
+		binary  string
+		create  string
+		scale   string
+	}{
+		{
+			cluster: nodepool.Cluster{Provider: nodepool.ProviderGKE, Name: "mycluster", Zone: "europe-west1-b", Project: "myproject"},
+			binary:  "gcloud",
+			create:  "[container node-pools create builds --cluster mycluster --num-nodes 1 --machine-type big --enable-autoscaling --min-nodes 1 --max-nodes 5 --zone europe-west1-b --project myproject]",
+			scale:   "[container clusters resize mycluster --node-pool builds --num-nodes 3 --quiet --zone europe-west1-b --project myproject]",
+		},
+		{
+			cluster: nodepool.Cluster{Provider: nodepool.ProviderEKS, Name: "mycluster", Zone: "us-west-2"},
+			binary:  "eksctl",
+			create:  "[create nodegroup --cluster mycluster --name builds --nodes 1 --node-type big --nodes-min 1 --nodes-max 5 --region us-west-2]",
+			scale:   "[scale nodegroup --cluster mycluster --name builds --nodes 3 --region us-west-2]",
+		},
+		{
+			cluster: nodepool.Cluster{Provider: nodepool.ProviderAKS, Name: "mycluster", ResourceGroup: "mygroup"},
+			binary:  "az",
+			create:  "[aks nodepool add --resource-group mygroup --cluster-name mycluster --name builds --node-count 1 --node-vm-size big --enable-cluster-autoscaler --min-count 1 --max-count 5]",
+			scale:   "[aks nodepool scale --resource-group mygroup --cluster-name mycluster --name builds --node-count 3]",
+		},
+	}
+	for _, tt := range tests {
+		manager, err := nodepool.NewManager(tt.cluster)
+		require.NoError(t, err)
+		commands := []string{}
+		manager.Runner = func(name string, args ...string) (string, error) {
+			commands = append(commands, fmt.Sprintf("%s %v", name, args))
+			return "", nil
+		}
+		require.NoError(t, manager.Create(pool))
+		require.NoError(t, manager.Scale(pool.Name, 3))
+		assert.Equal(t, []string{tt.binary + " " + tt.create, tt.binary + " " + tt.scale}, commands, "provider %s", tt.cluster.Provider)
+	}
+}
+
+func TestCreateSpot(t *testing.T) {
+	t.Parallel()
+	pool := nodepool.NodePool{Name: "spot", Nodes: 0, Spot: true}
+	expected := map[string][]string{
+		nodepool.ProviderGKE: {"container", "node-pools", "create", "spot", "--cluster", "mycluster", "--num-nodes", "0", "--preemptible", "--node-taints", "cloud.google.com/gke-preemptible=true:NoSchedule"},
+		nodepool.ProviderEKS: {"create", "nodegroup", "--cluster", "mycluster", "--name", "spot", "--nodes", "0", "--managed", "--spot"},
+		nodepool.ProviderAKS: {"aks", "nodepool", "add", "--resource-group", "mygroup", "--cluster-name", "mycluster", "--name", "spot", "--node-count", "0", "--priority", "Spot", "--eviction-policy", "Delete", "--spot-max-price", "-1"},
+	}
+	for provider, args := range expected {
+		manager, err := nodepool.NewManager(nodepool.Cluster{Provider: provider, Name: "mycluster", ResourceGroup: "mygroup"})
+		require.NoError(t, err)
+		assert.Equal(t, args, manager.CreateArgs(pool), "provider %s", provider)
+	}
+}
+
+func TestCreateFailure(t *testing.T) {
+	t.Parallel()
+	manager, err := nodepool.NewManager(nodepool.Cluster{Provider: nodepool.ProviderGKE, Name: "mycluster"})
+	require.NoError(t, err)
+	manager.Runner = func(name string, args ...string) (string, error) {
+		return "quota exceeded", fmt.Errorf("exit status 1")
+	}
+	err = manager.Create(nodepool.NodePool{Name: "builds", Nodes: 1})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "quota exceeded")
+}
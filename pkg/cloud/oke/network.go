@@ -0,0 +1,273 @@
+package oke
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// DefaultVcnCidr the CIDR block of the VCNs created for clusters
+	DefaultVcnCidr = "10.0.0.0/16"
+
+	// LoadBalancerShapeFlexible the flexible shape of OCI load balancers
+	LoadBalancerShapeFlexible = "flexible"
+	// AnnotationLoadBalancerShape the annotation of a LoadBalancer Service which selects the OCI load balancer shape
+	AnnotationLoadBalancerShape = "service.beta.kubernetes.io/oci-load-balancer-shape"
+	// AnnotationLoadBalancerShapeFlexMin the minimum bandwidth in Mbps of a flexible load balancer
+	AnnotationLoadBalancerShapeFlexMin = "service.beta.kubernetes.io/oci-load-balancer-shape-flex-min"
+	// AnnotationLoadBalancerShapeFlexMax the maximum bandwidth in Mbps of a flexible load balancer
+	AnnotationLoadBalancerShapeFlexMax = "service.beta.kubernetes.io/oci-load-balancer-shape-flex-max"
+)
+
+// LoadBalancerAnnotations the annotations of the ingress controller Service which create a flexible OCI load
+// balancer so that the external IP of the ingress can be discovered
+var LoadBalancerAnnotations = map[string]string{
+	AnnotationLoadBalancerShape:        LoadBalancerShapeFlexible,
+	AnnotationLoadBalancerShapeFlexMin: "10",
+	AnnotationLoadBalancerShapeFlexMax: "100",
+}
+
+// ClusterNetwork the VCN and subnets created for a cluster
+type ClusterNetwork struct {
+	VcnId           string
+	WorkerSubnetIds []string
+	LBSubnetIds     []string
+}
+
+// SubnetPlan the CIDR blocks and availability domains of the subnets of a cluster network
+type SubnetPlan struct {
+	Name               string
+	Cidr               string
+	AvailabilityDomain string
+}
+
+// PlanSubnets plans a worker subnet in each availability domain and the two load balancer subnets OKE needs which
+// are placed in different availability domains where possible
+func PlanSubnets(availabilityDomains []string) (workers []SubnetPlan, loadBalancers []SubnetPlan, err error) {
+	if len(availabilityDomains) == 0 {
+		return nil, nil, fmt.Errorf("no availability domains found")
+	}
+	for i, ad := range availabilityDomains {
+		workers = append(workers, SubnetPlan{
+			Name:               fmt.Sprintf("workers%d", i+1),
+			Cidr:               fmt.Sprintf("10.0.%d.0/24", 10+i),
+			AvailabilityDomain: ad,
+		})
+	}
+	for i := 0; i < 2; i++ {
+		loadBalancers = append(loadBalancers, SubnetPlan{
+			Name:               fmt.Sprintf("loadbalancers%d", i+1),
+			Cidr:               fmt.Sprintf("10.0.%d.0/24", 20+i),
+			AvailabilityDomain: availabilityDomains[i%len(availabilityDomains)],
+		})
+	}
+	return workers, loadBalancers, nil
+}
+
+type ociResource struct {
+	Data struct {
+		Id                    string `json:"id"`
+		DefaultRouteTableId   string `json:"default-route-table-id"`
+		DefaultSecurityListId string `json:"default-security-list-id"`
+		Resources             []struct {
+			EntityType string `json:"entity-type"`
+			Identifier string `json:"identifier"`
+		} `json:"resources"`
+	} `json:"data"`
+}
+
+// jsonFromOutput returns the JSON document from the output of the oci CLI ignoring any progress messages written to
+// stderr while waiting for a state
+func jsonFromOutput(output string) []byte {
+	start := strings.Index(output, "{")
+	end := strings.LastIndex(output, "}")
+	if start < 0 || end < start {
+		return []byte(output)
+	}
+	return []byte(output[start : end+1])
+}
+
+func parseOCIResource(output string) (*ociResource, error) {
+	resource := &ociResource{}
+	err := json.Unmarshal(jsonFromOutput(output), resource)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the output of the oci CLI: %s", err)
+	}
+	return resource, nil
+}
+
+// ParseResourceID returns the OCID of the resource output by the oci CLI
+func ParseResourceID(output string) (string, error) {
+	resource, err := parseOCIResource(output)
+	if err != nil {
+		return "", err
+	}
+	if resource.Data.Id == "" {
+		return "", fmt.Errorf("no id found in the output of the oci CLI")
+	}
+	return resource.Data.Id, nil
+}
+
+// ParseWorkRequestResourceID returns the OCID of the resource of the given entity type such as cluster or nodepool
+// from the work request output by the oci CLI when waiting for a state
+func ParseWorkRequestResourceID(output string, entityType string) (string, error) {
+	resource, err := parseOCIResource(output)
+	if err != nil {
+		return "", err
+	}
+	for _, r := range resource.Data.Resources {
+		if r.EntityType == entityType && r.Identifier != "" {
+			return r.Identifier, nil
+		}
+	}
+	return "", fmt.Errorf("no %s found in the work request output by the oci CLI", entityType)
+}
+
+// ParseAvailabilityDomains returns the names of the availability domains output by the oci CLI
+func ParseAvailabilityDomains(output string) ([]string, error) {
+	result := struct {
+		Data []struct {
+			Name string `json:"name"`
+		} `json:"data"`
+	}{}
+	err := json.Unmarshal(jsonFromOutput(output), &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the availability domains: %s", err)
+	}
+	answer := []string{}
+	for _, ad := range result.Data {
+		answer = append(answer, ad.Name)
+	}
+	return answer, nil
+}
+
+func runOCI(args ...string) (string, error) {
+	cmd := util.Command{
+		Name: "oci",
+		Args: args,
+	}
+	output, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return output, fmt.Errorf("failed to run oci %s: %s", strings.Join(args, " "), output)
+	}
+	return output, nil
+}
+
+// dnsLabel returns a valid DNS label for a VCN or subnet which must be alphanumeric and start with a letter
+func dnsLabel(name string) string {
+	label := ""
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9' && label != "") {
+			label += string(r)
+		}
+	}
+	if len(label) > 15 {
+		label = label[0:15]
+	}
+	if label == "" {
+		label = "jx"
+	}
+	return label
+}
+
+// CreateCompartment creates a compartment in the parent compartment or tenancy returning its OCID
+func CreateCompartment(parentCompartmentId string, name string) (string, error) {
+	log.Infof("Creating compartment %s\n", util.ColorInfo(name))
+	output, err := runOCI("iam", "compartment", "create", "--compartment-id", parentCompartmentId, "--name", name,
+		"--description", "Jenkins X cluster "+name, "--wait-for-state", "ACTIVE")
+	if err != nil {
+		return "", err
+	}
+	return ParseResourceID(output)
+}
+
+// GetAvailabilityDomains returns the names of the availability domains of the compartment
+func GetAvailabilityDomains(compartmentId string) ([]string, error) {
+	output, err := runOCI("iam", "availability-domain", "list", "--compartment-id", compartmentId)
+	if err != nil {
+		return nil, err
+	}
+	return ParseAvailabilityDomains(output)
+}
+
+// CreateClusterNetwork creates a VCN with an internet gateway, a worker subnet in each availability domain and two
+// load balancer subnets for a cluster
+func CreateClusterNetwork(compartmentId string, name string) (*ClusterNetwork, error) {
+	ads, err := GetAvailabilityDomains(compartmentId)
+	if err != nil {
+		return nil, err
+	}
+	workers, loadBalancers, err := PlanSubnets(ads)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Creating VCN %s with CIDR %s\n", util.ColorInfo(name), DefaultVcnCidr)
+	output, err := runOCI("network", "vcn", "create", "--compartment-id", compartmentId, "--display-name", name,
+		"--cidr-block", DefaultVcnCidr, "--dns-label", dnsLabel(name), "--wait-for-state", "AVAILABLE")
+	if err != nil {
+		return nil, err
+	}
+	vcn, err := parseOCIResource(output)
+	if err != nil {
+		return nil, err
+	}
+	network := &ClusterNetwork{VcnId: vcn.Data.Id}
+
+	output, err = runOCI("network", "internet-gateway", "create", "--compartment-id", compartmentId, "--vcn-id", network.VcnId,
+		"--display-name", name+"-gateway", "--is-enabled", "true", "--wait-for-state", "AVAILABLE")
+	if err != nil {
+		return nil, err
+	}
+	gatewayId, err := ParseResourceID(output)
+	if err != nil {
+		return nil, err
+	}
+	routeRules := fmt.Sprintf(`[{"cidrBlock": "0.0.0.0/0", "networkEntityId": "%s"}]`, gatewayId)
+	_, err = runOCI("network", "route-table", "update", "--rt-id", vcn.Data.DefaultRouteTableId, "--route-rules", routeRules, "--force")
+	if err != nil {
+		return nil, err
+	}
+
+	// allow traffic between the nodes and load balancers in the VCN and HTTP, HTTPS and SSH from anywhere
+	ingressRules := fmt.Sprintf(`[{"source": "%s", "protocol": "all"}`, DefaultVcnCidr)
+	for _, port := range []int{22, 80, 443} {
+		ingressRules += fmt.Sprintf(`, {"source": "0.0.0.0/0", "protocol": "6", "tcpOptions": {"destinationPortRange": {"min": %d, "max": %d}}}`, port, port)
+	}
+	ingressRules += "]"
+	_, err = runOCI("network", "security-list", "update", "--security-list-id", vcn.Data.DefaultSecurityListId,
+		"--ingress-security-rules", ingressRules, "--force")
+	if err != nil {
+		return nil, err
+	}
+
+	create := func(plan SubnetPlan) (string, error) {
+		log.Infof("Creating subnet %s with CIDR %s in %s\n", util.ColorInfo(plan.Name), plan.Cidr, plan.AvailabilityDomain)
+		output, err := runOCI("network", "subnet", "create", "--compartment-id", compartmentId, "--vcn-id", network.VcnId,
+			"--display-name", plan.Name, "--cidr-block", plan.Cidr, "--availability-domain", plan.AvailabilityDomain,
+			"--dns-label", dnsLabel(plan.Name), "--wait-for-state", "AVAILABLE")
+		if err != nil {
+			return "", err
+		}
+		return ParseResourceID(output)
+	}
+	for _, plan := range workers {
+		id, err := create(plan)
+		if err != nil {
+			return nil, err
+		}
+		network.WorkerSubnetIds = append(network.WorkerSubnetIds, id)
+	}
+	for _, plan := range loadBalancers {
+		id, err := create(plan)
+		if err != nil {
+			return nil, err
+		}
+		network.LBSubnetIds = append(network.LBSubnetIds, id)
+	}
+	return network, nil
+}
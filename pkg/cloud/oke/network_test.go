@@ -0,0 +1,59 @@
+package oke
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanSubnets(t *testing.T) {
+	t.Parallel()
+	workers, lbs, err := PlanSubnets([]string{"AD-1", "AD-2", "AD-3"})
+	require.NoError(t, err)
+	require.Len(t, workers, 3)
+	require.Len(t, lbs, 2)
+	assert.Equal(t, "10.0.12.0/24", workers[2].Cidr)
+	assert.Equal(t, "AD-3", workers[2].AvailabilityDomain)
+	assert.Equal(t, "AD-1", lbs[0].AvailabilityDomain)
+	assert.Equal(t, "AD-2", lbs[1].AvailabilityDomain)
+
+	_, lbs, err = PlanSubnets([]string{"AD-1"})
+	require.NoError(t, err)
+	assert.Equal(t, "AD-1", lbs[1].AvailabilityDomain)
+
+	_, _, err = PlanSubnets(nil)
+	assert.Error(t, err)
+}
+
+func TestParseOCIOutput(t *testing.T) {
+	t.Parallel()
+	id, err := ParseResourceID(`{"data": {"id": "ocid1.vcn.oc1..abc", "default-route-table-id": "ocid1.rt"}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.vcn.oc1..abc", id)
+
+	_, err = ParseResourceID(`{"data": {}}`)
+	assert.Error(t, err)
+
+	workRequest := `Action completed. Waiting until the work request has entered state: SUCCEEDED
+{"data": {"resources": [
+		{"entity-type": "nodepool", "identifier": "ocid1.nodepool"},
+		{"entity-type": "cluster", "identifier": "ocid1.cluster"}
+	]}}`
+	id, err = ParseWorkRequestResourceID(workRequest, "cluster")
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.cluster", id)
+	_, err = ParseWorkRequestResourceID(workRequest, "vcn")
+	assert.Error(t, err)
+
+	ads, err := ParseAvailabilityDomains(`{"data": [{"name": "Uocm:PHX-AD-1"}, {"name": "Uocm:PHX-AD-2"}]}`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Uocm:PHX-AD-1", "Uocm:PHX-AD-2"}, ads)
+}
+
+func TestDnsLabel(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "jxdemocluster12", dnsLabel("jx-demo-cluster-1234"))
+	assert.Equal(t, "workers1", dnsLabel("workers1"))
+	assert.Equal(t, "jx", dnsLabel("123"))
+}
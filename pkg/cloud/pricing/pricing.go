@@ -0,0 +1,193 @@
+package pricing
+
+import (
+	"fmt"
+	"sort"
+)
+
+const (
+	// ProviderGKE Google Kubernetes Engine
+	ProviderGKE = "gke"
+	// ProviderEKS Amazon Elastic Container Service for Kubernetes
+	ProviderEKS = "eks"
+	// ProviderAKS Azure Kubernetes Service
+	ProviderAKS = "aks"
+
+	// HoursPerMonth the average number of hours in a month used by the cloud providers to bill
+	HoursPerMonth = 730
+)
+
+// Providers the cloud providers which can be estimated
+var Providers = []string{ProviderGKE, ProviderEKS, ProviderAKS}
+
+// PriceList the approximate on demand prices of a cloud provider in USD per hour
+type PriceList struct {
+	// Region the region the prices are taken from
+	Region string
+	// ControlPlane the price of the managed Kubernetes control plane
+	ControlPlane float64
+	// MachineTypes the price of each node by machine type
+	MachineTypes map[string]float64
+}
+
+// PriceLists the bundled price lists of each provider. The prices are approximate on demand Linux prices which exclude
+// disks, load balancers, network egress and any discounts
+var PriceLists = map[string]PriceList{
+	ProviderGKE: {
+		Region:       "us-central1",
+		ControlPlane: 0.10,
+		MachineTypes: map[string]float64{
+			"g1-small":       0.0257,
+			"n1-standard-1":  0.0475,
+			"n1-standard-2":  0.095,
+			"n1-standard-4":  0.19,
+			"n1-standard-8":  0.38,
+			"n1-standard-16": 0.76,
+			"n1-standard-32": 1.52,
+			"n1-standard-64": 3.04,
+			"n1-standard-96": 4.56,
+			"n1-highmem-2":   0.1184,
+			"n1-highmem-4":   0.2368,
+			"n1-highmem-8":   0.4736,
+			"n1-highmem-16":  0.9472,
+			"n1-highmem-32":  1.8944,
+			"n1-highmem-64":  3.7888,
+			"n1-highmem-96":  5.6832,
+			"n1-highcpu-2":   0.0709,
+			"n1-highcpu-4":   0.1418,
+			"n1-highcpu-8":   0.2836,
+			"n1-highcpu-16":  0.5672,
+			"n1-highcpu-32":  1.1344,
+			"n1-highcpu-64":  2.2688,
+			"n1-highcpu-96":  3.402,
+		},
+	},
+	ProviderEKS: {
+		Region:       "us-west-2",
+		ControlPlane: 0.10,
+		MachineTypes: map[string]float64{
+			"t3.medium":  0.0416,
+			"t3.large":   0.0832,
+			"t3.xlarge":  0.1664,
+			"m4.large":   0.10,
+			"m4.xlarge":  0.20,
+			"m5.large":   0.096,
+			"m5.xlarge":  0.192,
+			"m5.2xlarge": 0.384,
+			"m5.4xlarge": 0.768,
+			"c5.large":   0.085,
+			"c5.xlarge":  0.17,
+			"c5.2xlarge": 0.34,
+			"r5.large":   0.126,
+			"r5.xlarge":  0.252,
+			"r5.2xlarge": 0.504,
+		},
+	},
+	ProviderAKS: {
+		Region:       "eastus",
+		ControlPlane: 0,
+		MachineTypes: map[string]float64{
+			"Standard_D2s_v3":   0.096,
+			"Standard_D4s_v3":   0.192,
+			"Standard_D8s_v3":   0.384,
+			"Standard_D16s_v3":  0.768,
+			"Standard_D32s_v3":  1.536,
+			"Standard_D64s_v3":  3.072,
+			"Standard_F2s_v2":   0.085,
+			"Standard_F4s_v2":   0.169,
+			"Standard_F8s_v2":   0.338,
+			"Standard_F16s_v2":  0.677,
+			"Standard_F32s_v2":  1.353,
+			"Standard_F64s_v2":  2.706,
+			"Standard_F72s_v2":  3.045,
+			"Standard_E2s_v3":   0.126,
+			"Standard_E4s_v3":   0.252,
+			"Standard_E8s_v3":   0.504,
+			"Standard_E16s_v3":  1.008,
+			"Standard_E32s_v3":  2.016,
+			"Standard_E64is_v3": 3.629,
+			"Standard_E64s_v3":  3.629,
+		},
+	},
+}
+
+// Estimate the approximate monthly cost of a cluster
+type Estimate struct {
+	Provider    string
+	Region      string
+	PriceRegion string
+	MachineType string
+	Nodes       int
+	MaxNodes    int
+	// NodeHourly the price of a node per hour
+	NodeHourly float64
+	// ControlPlaneHourly the price of the control plane per hour
+	ControlPlaneHourly float64
+}
+
+// NodeMonthly returns the monthly cost of a single node
+func (e *Estimate) NodeMonthly() float64 {
+	return e.NodeHourly * HoursPerMonth
+}
+
+// ControlPlaneMonthly returns the monthly cost of the control plane
+func (e *Estimate) ControlPlaneMonthly() float64 {
+	return e.ControlPlaneHourly * HoursPerMonth
+}
+
+// Monthly returns the monthly cost of the cluster with the minimum number of nodes
+func (e *Estimate) Monthly() float64 {
+	return e.ControlPlaneMonthly() + e.NodeMonthly()*float64(e.Nodes)
+}
+
+// MaxMonthly returns the monthly cost of the cluster if it scales up to the maximum number of nodes
+func (e *Estimate) MaxMonthly() float64 {
+	if e.MaxNodes <= e.Nodes {
+		return e.Monthly()
+	}
+	return e.ControlPlaneMonthly() + e.NodeMonthly()*float64(e.MaxNodes)
+}
+
+// String returns a summary of the monthly cost
+func (e *Estimate) String() string {
+	if e.MaxMonthly() > e.Monthly() {
+		return fmt.Sprintf("$%.2f - $%.2f a month", e.Monthly(), e.MaxMonthly())
+	}
+	return fmt.Sprintf("$%.2f a month", e.Monthly())
+}
+
+// EstimateCluster estimates the monthly cost of a cluster of the given provider, machine type and number of nodes
+// using the bundled price lists. The maxNodes is the maximum number of nodes the cluster can scale up to or zero
+func EstimateCluster(provider string, machineType string, nodes int, maxNodes int, region string) (*Estimate, error) {
+	prices, ok := PriceLists[provider]
+	if !ok {
+		return nil, fmt.Errorf("no prices available for provider %s. Supported providers: %v", provider, Providers)
+	}
+	hourly, ok := prices.MachineTypes[machineType]
+	if !ok {
+		return nil, fmt.Errorf("no price available for %s machine type %s. Known machine types: %v", provider, machineType, MachineTypes(provider))
+	}
+	if nodes < 0 {
+		return nil, fmt.Errorf("invalid number of nodes %d", nodes)
+	}
+	return &Estimate{
+		Provider:           provider,
+		Region:             region,
+		PriceRegion:        prices.Region,
+		MachineType:        machineType,
+		Nodes:              nodes,
+		MaxNodes:           maxNodes,
+		NodeHourly:         hourly,
+		ControlPlaneHourly: prices.ControlPlane,
+	}, nil
+}
+
+// MachineTypes returns the sorted machine types with prices for the given provider
+func MachineTypes(provider string) []string {
+	answer := []string{}
+	for machineType := range PriceLists[provider].MachineTypes {
+		answer = append(answer, machineType)
+	}
+	sort.Strings(answer)
+	return answer
+}
@@ -0,0 +1,34 @@
+package pricing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateCluster(t *testing.T) {
+	t.Parallel()
+	estimate, err := EstimateCluster(ProviderGKE, "n1-standard-2", 3, 5, "europe-west1")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.095*730, estimate.NodeMonthly(), 0.001)
+	assert.InDelta(t, 0.10*730+3*0.095*730, estimate.Monthly(), 0.001)
+	assert.InDelta(t, 0.10*730+5*0.095*730, estimate.MaxMonthly(), 0.001)
+	assert.Equal(t, "$281.05 - $419.75 a month", estimate.String())
+	assert.Equal(t, "us-central1", estimate.PriceRegion)
+
+	estimate, err = EstimateCluster(ProviderAKS, "Standard_D2s_v3", 3, 0, "eastus")
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), estimate.ControlPlaneMonthly())
+	assert.Equal(t, "$210.24 a month", estimate.String())
+}
+
+func TestEstimateClusterUnknown(t *testing.T) {
+	t.Parallel()
+	_, err := EstimateCluster("minikube", "n1-standard-2", 3, 0, "")
+	assert.Error(t, err)
+	_, err = EstimateCluster(ProviderEKS, "p3.16xlarge", 3, 0, "")
+	assert.Error(t, err)
+	_, err = EstimateCluster(ProviderEKS, "m5.large", -1, 0, "")
+	assert.Error(t, err)
+}
@@ -20,7 +20,25 @@ type ExposeController struct {
 }
 
 type JenkinsValuesConfig struct {
-	Servers JenkinsServersValuesConfig `yaml:"Servers,omitempty"`
+	Servers     JenkinsServersValuesConfig `yaml:"Servers,omitempty"`
+	Persistence *JenkinsPersistenceConfig  `yaml:"Persistence,omitempty"`
+}
+
+// JenkinsPersistenceConfig the persistence values of the Jenkins chart which uses upper case keys
+type JenkinsPersistenceConfig struct {
+	StorageClass string `yaml:"StorageClass,omitempty"`
+	Size         string `yaml:"Size,omitempty"`
+}
+
+// PersistenceConfig the persistence values of charts such as Nexus and ChartMuseum
+type PersistenceConfig struct {
+	StorageClass string `yaml:"storageClass,omitempty"`
+	Size         string `yaml:"size,omitempty"`
+}
+
+// PersistentValuesConfig the values of a chart which only configure its persistence
+type PersistentValuesConfig struct {
+	Persistence *PersistenceConfig `yaml:"persistence,omitempty"`
 }
 
 type ProwValuesConfig struct {
@@ -59,6 +77,8 @@ type HelmValuesConfig struct {
 	Jenkins          JenkinsValuesConfig                `yaml:"jenkins,omitempty"`
 	Prow             ProwValuesConfig                   `yaml:"prow,omitempty"`
 	PipelineSecrets  JenkinsPipelineSecretsValuesConfig `yaml:"PipelineSecrets,omitempty"`
+	Nexus            *PersistentValuesConfig            `yaml:"nexus,omitempty"`
+	ChartMuseum      *PersistentValuesConfig            `yaml:"chartmuseum,omitempty"`
 }
 
 type HelmValuesConfigService struct {
@@ -32,3 +32,25 @@ func TestEnvironmentExposecontrollerHelmValues(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, string(testFile), s, "expected exposecontroller helm values do not match")
 }
+
+func TestStorageClassHelmValues(t *testing.T) {
+	t.Parallel()
+
+	values := config.HelmValuesConfig{
+		Jenkins: config.JenkinsValuesConfig{
+			Persistence: &config.JenkinsPersistenceConfig{StorageClass: "ssd"},
+		},
+		Nexus: &config.PersistentValuesConfig{
+			Persistence: &config.PersistenceConfig{StorageClass: "standard"},
+		},
+	}
+	s, err := values.String()
+	assert.NoError(t, err)
+	assert.Equal(t, `jenkins:
+  Persistence:
+    StorageClass: ssd
+nexus:
+  persistence:
+    storageClass: standard
+`, s)
+}
@@ -0,0 +1,148 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// JSONSchema the subset of JSON Schema used to validate the configuration files of Jenkins X: types, properties,
+// required properties, additional properties, enums, patterns and array items
+type JSONSchema struct {
+	Type                 string                 `json:"type,omitempty"`
+	Description          string                 `json:"description,omitempty"`
+	Properties           map[string]*JSONSchema `json:"properties,omitempty"`
+	Required             []string               `json:"required,omitempty"`
+	AdditionalProperties *bool                  `json:"additionalProperties,omitempty"`
+	Enum                 []interface{}          `json:"enum,omitempty"`
+	Pattern              string                 `json:"pattern,omitempty"`
+	Items                *JSONSchema            `json:"items,omitempty"`
+}
+
+// ParseJSONSchema parses the JSON Schema document
+func ParseJSONSchema(text string) (*JSONSchema, error) {
+	schema := &JSONSchema{}
+	err := json.Unmarshal([]byte(text), schema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse JSON schema: %s", err)
+	}
+	return schema, nil
+}
+
+// Validate validates the JSON document against the schema returning an error describing every violation
+func (s *JSONSchema) Validate(data []byte) error {
+	var doc interface{}
+	err := json.Unmarshal(data, &doc)
+	if err != nil {
+		return fmt.Errorf("failed to parse JSON: %s", err)
+	}
+	violations := s.validate("", doc)
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s", strings.Join(violations, "\n"))
+}
+
+func (s *JSONSchema) validate(path string, value interface{}) []string {
+	if s == nil {
+		return nil
+	}
+	name := path
+	if name == "" {
+		name = "<root>"
+	}
+	if s.Type != "" && !matchesJSONType(s.Type, value) {
+		return []string{fmt.Sprintf("%s: expected %s but was %s", name, s.Type, jsonTypeName(value))}
+	}
+	violations := []string{}
+	if len(s.Enum) > 0 && !containsJSONValue(s.Enum, value) {
+		allowed := []string{}
+		for _, e := range s.Enum {
+			allowed = append(allowed, fmt.Sprintf("%v", e))
+		}
+		violations = append(violations, fmt.Sprintf("%s: %v is not one of %s", name, value, strings.Join(allowed, ", ")))
+	}
+	if text, ok := value.(string); ok && s.Pattern != "" {
+		re, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			violations = append(violations, fmt.Sprintf("%s: invalid pattern %s in schema: %s", name, s.Pattern, err))
+		} else if !re.MatchString(text) {
+			violations = append(violations, fmt.Sprintf("%s: %s does not match pattern %s", name, text, s.Pattern))
+		}
+	}
+	if object, ok := value.(map[string]interface{}); ok {
+		for _, required := range s.Required {
+			if _, found := object[required]; !found {
+				violations = append(violations, fmt.Sprintf("%s: missing required property %s", name, required))
+			}
+		}
+		keys := []string{}
+		for k := range object {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			property := s.Properties[k]
+			if property == nil {
+				if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+					violations = append(violations, fmt.Sprintf("%s: unknown property", childPath))
+				}
+				continue
+			}
+			violations = append(violations, property.validate(childPath, object[k])...)
+		}
+	}
+	if array, ok := value.([]interface{}); ok && s.Items != nil {
+		for i, item := range array {
+			violations = append(violations, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+		}
+	}
+	return violations
+}
+
+func matchesJSONType(schemaType string, value interface{}) bool {
+	switch schemaType {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonTypeName(value) == schemaType
+	}
+}
+
+func jsonTypeName(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return reflect.TypeOf(value).String()
+}
+
+func containsJSONValue(values []interface{}, value interface{}) bool {
+	for _, v := range values {
+		if reflect.DeepEqual(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -30,6 +30,8 @@ type ProjectConfig struct {
 	BuildPackGitURL     string                    `yaml:"buildPackGitURL,omitempty"`
 	BuildPackGitURef    string                    `yaml:"buildPackGitRef,omitempty"`
 	Workflow            string                    `yaml:"workflow,omitempty"`
+	Versioning          *VersioningConfig         `yaml:"versioning,omitempty"`
+	Databases           []*DatabaseConfig         `yaml:"databases,omitempty"`
 }
 
 type PreviewEnvironmentConfig struct {
@@ -56,6 +58,45 @@ type ChatConfig struct {
 	UserChannel      string `yaml:"userChannel,omitempty"`
 }
 
+// VersioningConfig configures how the next semantic version of a project is derived from its conventional commits
+type VersioningConfig struct {
+	// Files the files relative to the project directory which contain the version to update on each release.
+	// If empty the pom.xml, Makefile, package.json and charts/*/Chart.yaml files of the project are updated
+	Files []string `yaml:"files,omitempty"`
+	// MinorTypes the conventional commit types which bump the minor version. Defaults to feat
+	MinorTypes []string `yaml:"minorTypes,omitempty"`
+	// PatchTypes the conventional commit types which bump the patch version. Defaults to fix and perf
+	PatchTypes []string `yaml:"patchTypes,omitempty"`
+	// RequireReleasableCommits if true no new version is released unless a commit bumps the version,
+	// otherwise the patch version is bumped
+	RequireReleasableCommits bool `yaml:"requireReleasableCommits,omitempty"`
+}
+
+// DatabaseConfig a managed database the project depends on which is provisioned for each environment the project
+// is deployed to
+type DatabaseConfig struct {
+	// Name the name of the database
+	Name string `yaml:"name"`
+	// Kind the managed database service: cloudsql, rds or azure
+	Kind string `yaml:"kind"`
+	// Engine the database engine: postgres or mysql. Defaults to postgres
+	Engine string `yaml:"engine,omitempty"`
+	// Version the version of the database engine such as 11 or 5.7
+	Version string `yaml:"version,omitempty"`
+	// Tier the machine tier, instance class or SKU of the database instance
+	Tier string `yaml:"tier,omitempty"`
+	// StorageGB the size of the storage of the database instance in GB
+	StorageGB int `yaml:"storageGB,omitempty"`
+	// Project the GCP project of a CloudSQL instance
+	Project string `yaml:"project,omitempty"`
+	// Region the region of the database instance
+	Region string `yaml:"region,omitempty"`
+	// ResourceGroup the resource group of an Azure database server
+	ResourceGroup string `yaml:"resourceGroup,omitempty"`
+	// SecretName the name of the Secret containing the connection details. Defaults to the project name and database name
+	SecretName string `yaml:"secretName,omitempty"`
+}
+
 type AddonConfig struct {
 	Name    string `yaml:"name,omitempty"`
 	Version string `yaml:"version,omitempty"`
@@ -0,0 +1,216 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// RequirementsConfigFileName the name of the file which declares how the Jenkins X platform is installed
+	RequirementsConfigFileName = "jx-requirements.yml"
+
+	// SecretStorageLocal stores secrets as kubernetes Secrets in the cluster
+	SecretStorageLocal = "local"
+
+	// WebhookEngineJenkins uses Jenkins to process git webhooks
+	WebhookEngineJenkins = "jenkins"
+	// WebhookEngineProw uses Prow to process git webhooks
+	WebhookEngineProw = "prow"
+	// WebhookEngineLighthouse uses Lighthouse, a lighter alternative to Prow, to process git webhooks
+	WebhookEngineLighthouse = "lighthouse"
+)
+
+// RequirementsConfigSchema the JSON schema the requirements file is validated against
+const RequirementsConfigSchema = `{
+  "type": "object",
+  "description": "The requirements of a Jenkins X installation",
+  "required": ["provider"],
+  "additionalProperties": false,
+  "properties": {
+    "provider": {
+      "type": "string",
+      "description": "The kubernetes provider of the cluster",
+      "enum": ["minikube", "gke", "oke", "aks", "aws", "eks", "kubernetes", "ibm", "openshift", "minishift", "jx-infra", "pks"]
+    },
+    "namespace": {
+      "type": "string",
+      "description": "The namespace the platform is installed into",
+      "pattern": "^[a-z0-9]([-a-z0-9]*[a-z0-9])?$"
+    },
+    "domain": {
+      "type": "string",
+      "description": "The domain the ingress of the platform and applications is exposed on",
+      "pattern": "^[a-zA-Z0-9]([-a-zA-Z0-9.]*[a-zA-Z0-9])?$"
+    },
+    "tls": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {
+          "type": "boolean",
+          "description": "Whether ingress is exposed over https using automatically issued certificates"
+        }
+      }
+    },
+    "storage": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "storageClass": {"type": "string", "description": "The storage class of the persistent volumes of the platform"},
+        "jenkins": {"type": "string", "description": "The storage class of the Jenkins home volume"},
+        "nexus": {"type": "string", "description": "The storage class of the Nexus volume"},
+        "chartmuseum": {"type": "string", "description": "The storage class of the ChartMuseum volume"}
+      }
+    },
+    "secretStorage": {
+      "type": "string",
+      "description": "Where the secrets of the platform are stored",
+      "enum": ["local"]
+    },
+    "webhook": {
+      "type": "string",
+      "description": "The engine which processes git webhooks",
+      "enum": ["jenkins", "prow", "lighthouse"]
+    },
+    "version": {
+      "type": "string",
+      "description": "The version of the platform chart"
+    },
+    "helm": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "timeouts": {"type": "object", "description": "The number of seconds helm waits for each component indexed by its release name"},
+        "noWait": {"type": "boolean", "description": "Whether helm returns without waiting for the resources of the charts to be ready"},
+        "atomic": {"type": "boolean", "description": "Whether a release is rolled back if its install or upgrade fails"}
+      }
+    }
+  }
+}`
+
+// RequirementsConfig declares how the Jenkins X platform is installed and upgraded
+type RequirementsConfig struct {
+	Provider      string              `json:"provider"`
+	Namespace     string              `json:"namespace,omitempty"`
+	Domain        string              `json:"domain,omitempty"`
+	TLS           TLSRequirements     `json:"tls,omitempty"`
+	Storage       StorageRequirements `json:"storage,omitempty"`
+	SecretStorage string              `json:"secretStorage,omitempty"`
+	Webhook       string              `json:"webhook,omitempty"`
+	Version       string              `json:"version,omitempty"`
+	Helm          HelmRequirements    `json:"helm,omitempty"`
+}
+
+// TLSRequirements the TLS requirements of the ingress
+type TLSRequirements struct {
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// StorageRequirements the storage classes of the persistent volumes of the platform
+type StorageRequirements struct {
+	StorageClass string `json:"storageClass,omitempty"`
+	Jenkins      string `json:"jenkins,omitempty"`
+	Nexus        string `json:"nexus,omitempty"`
+	ChartMuseum  string `json:"chartmuseum,omitempty"`
+}
+
+// HelmRequirements how helm waits for the charts of the platform and its components to be installed
+type HelmRequirements struct {
+	// Timeouts the number of seconds helm waits for each component indexed by its release name such as jenkins-x
+	Timeouts map[string]int `json:"timeouts,omitempty"`
+	NoWait   bool           `json:"noWait,omitempty"`
+	Atomic   bool           `json:"atomic,omitempty"`
+}
+
+// LoadRequirementsConfig loads and validates the requirements file
+func LoadRequirementsConfig(fileName string) (*RequirementsConfig, error) {
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("requirements file %s does not exist", fileName)
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load file %s due to %s", fileName, err)
+	}
+	config, err := ParseRequirementsConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid requirements file %s:\n%s", fileName, err)
+	}
+	return config, nil
+}
+
+// ParseRequirementsConfig validates the YAML requirements against RequirementsConfigSchema and parses them
+func ParseRequirementsConfig(data []byte) (*RequirementsConfig, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := ParseJSONSchema(RequirementsConfigSchema)
+	if err != nil {
+		return nil, err
+	}
+	err = schema.Validate(jsonData)
+	if err != nil {
+		return nil, err
+	}
+	config := &RequirementsConfig{}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// SaveConfig saves the requirements to the file
+func (c *RequirementsConfig) SaveConfig(fileName string) error {
+	data, err := c.String()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, []byte(data), util.DefaultWritePermissions)
+}
+
+// String returns the requirements as YAML
+func (c *RequirementsConfig) String() (string, error) {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the requirements: %s", err)
+	}
+	return string(data), nil
+}
+
+// HelmValues returns the helm values of the platform chart for the domain, TLS and storage classes of the requirements
+// in the "name=value" form passed to "helm upgrade --set"
+func (c *RequirementsConfig) HelmValues() []string {
+	answer := []string{}
+	if c.Domain != "" {
+		answer = append(answer, "expose.config.domain="+c.Domain)
+	}
+	answer = append(answer, fmt.Sprintf("expose.config.tlsacme=%t", c.TLS.Enabled), fmt.Sprintf("expose.config.http=%t", !c.TLS.Enabled))
+	jenkins := c.storageClass(c.Storage.Jenkins)
+	if jenkins != "" {
+		answer = append(answer, "jenkins.Persistence.StorageClass="+jenkins)
+	}
+	nexus := c.storageClass(c.Storage.Nexus)
+	if nexus != "" {
+		answer = append(answer, "nexus.persistence.storageClass="+nexus)
+	}
+	chartMuseum := c.storageClass(c.Storage.ChartMuseum)
+	if chartMuseum != "" {
+		answer = append(answer, "chartmuseum.persistence.storageClass="+chartMuseum)
+	}
+	return answer
+}
+
+func (c *RequirementsConfig) storageClass(name string) string {
+	if name != "" {
+		return name
+	}
+	return c.Storage.StorageClass
+}
@@ -0,0 +1,93 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRequirementsConfig(t *testing.T) {
+	t.Parallel()
+	requirements, err := config.ParseRequirementsConfig([]byte(`provider: gke
+domain: jx.acme.com
+tls:
+  enabled: true
+storage:
+  storageClass: ssd
+  nexus: standard
+webhook: prow
+helm:
+  atomic: true
+  timeouts:
+    jenkins-x: 3600
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "gke", requirements.Provider)
+	assert.Equal(t, "jx.acme.com", requirements.Domain)
+	assert.True(t, requirements.TLS.Enabled)
+	assert.Equal(t, config.WebhookEngineProw, requirements.Webhook)
+	assert.True(t, requirements.Helm.Atomic)
+	assert.Equal(t, map[string]int{"jenkins-x": 3600}, requirements.Helm.Timeouts)
+	assert.Equal(t, []string{
+		"expose.config.domain=jx.acme.com",
+		"expose.config.tlsacme=true",
+		"expose.config.http=false",
+		"jenkins.Persistence.StorageClass=ssd",
+		"nexus.persistence.storageClass=standard",
+		"chartmuseum.persistence.storageClass=ssd",
+	}, requirements.HelmValues())
+}
+
+func TestParseRequirementsConfigValidation(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		"domain: jx.acme.com\n":                  "missing required property provider",
+		"provider: cheese\n":                     "provider: cheese is not one of",
+		"provider: gke\ntls: true\n":             "tls: expected object but was boolean",
+		"provider: gke\ntls:\n  enabled: yes\n":  "",
+		"provider: gke\nwebhook: travis\n":       "webhook: travis is not one of jenkins, prow, lighthouse",
+		"provider: gke\nstorage:\n  disk: ssd\n": "storage.disk: unknown property",
+		"provider: gke\nnamespace: Not_Valid\n":  "namespace: Not_Valid does not match pattern",
+		"provider: gke\nhelm:\n  wait: false\n":  "helm.wait: unknown property",
+	}
+	for text, expected := range tests {
+		_, err := config.ParseRequirementsConfig([]byte(text))
+		if expected == "" {
+			assert.NoError(t, err, "for requirements %s", text)
+			continue
+		}
+		if assert.Error(t, err, "for requirements %s", text) {
+			assert.Contains(t, err.Error(), expected, "for requirements %s", text)
+		}
+	}
+}
+
+func TestRequirementsConfigSaveAndLoad(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-requirements-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, config.RequirementsConfigFileName)
+	requirements := &config.RequirementsConfig{
+		Provider:      "aks",
+		Namespace:     "jx",
+		Storage:       config.StorageRequirements{Jenkins: "premium"},
+		SecretStorage: config.SecretStorageLocal,
+		Webhook:       config.WebhookEngineJenkins,
+		Version:       "0.0.3000",
+	}
+	require.NoError(t, requirements.SaveConfig(fileName))
+
+	loaded, err := config.LoadRequirementsConfig(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, requirements, loaded)
+
+	_, err = config.LoadRequirementsConfig(filepath.Join(dir, "missing.yml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// SettingsFileName the name of the file in the jx home directory which configures the common options of jx
+const SettingsFileName = "config.yaml"
+
+// SettingSource where the value of a setting came from
+type SettingSource string
+
+const (
+	// SettingSourceFlag the value was set by a command line option
+	SettingSourceFlag SettingSource = "flag"
+	// SettingSourceEnv the value was set by an environment variable
+	SettingSourceEnv SettingSource = "environment"
+	// SettingSourceFile the value was set in the settings file
+	SettingSourceFile SettingSource = "config file"
+	// SettingSourceTeam the value was set in the settings of the team
+	SettingSourceTeam SettingSource = "team settings"
+	// SettingSourceDefault the value is the default
+	SettingSourceDefault SettingSource = "default"
+)
+
+// Setting a common option of jx which can be set, from the highest to the lowest precedence, by a command line
+// option, an environment variable, the settings file or the settings of the team
+type Setting struct {
+	// Name the key of the setting in the settings file and team settings
+	Name string
+	// Flag the name of the command line option which sets the setting
+	Flag string
+	// EnvVar the environment variable which sets the setting
+	EnvVar      string
+	Description string
+	Default     string
+	// Commands the commands, including their sub commands, whose command line option called Flag is bound to the
+	// setting. The option of every command is bound if empty
+	Commands []string
+}
+
+// clusterCommands the commands which install Jenkins X into a cluster
+var clusterCommands = []string{"jx install", "jx init", "jx create cluster"}
+
+var (
+	// SettingBinDir the directory jx installs the binaries it depends on into
+	SettingBinDir = Setting{Name: "binDir", Flag: "bin-dir", EnvVar: "JX_BIN_DIR", Description: "The directory jx installs the binaries it depends on into"}
+	// SettingDomain the domain used to expose services
+	SettingDomain = Setting{Name: "domain", Flag: "domain", EnvVar: "JX_DOMAIN", Description: "The domain used to expose services", Commands: clusterCommands}
+	// SettingProvider the kubernetes provider of the cluster
+	SettingProvider = Setting{Name: "provider", Flag: "provider", EnvVar: "JX_PROVIDER", Description: "The kubernetes provider of the cluster", Commands: clusterCommands}
+	// SettingBatchMode disables prompts in commands which support batch mode
+	SettingBatchMode = Setting{Name: "batchMode", Flag: "batch-mode", EnvVar: "JX_BATCH_MODE", Description: "Whether commands run in batch mode without prompting", Default: "false"}
+	// SettingHelmBinary the helm binary used to install charts
+	SettingHelmBinary = Setting{Name: "helmBinary", EnvVar: "JX_HELM_BINARY", Description: "The helm binary used to install charts", Default: "helm"}
+	// SettingTillerAddress the address a local tiller listens on when the team does not use a remote tiller
+	SettingTillerAddress = Setting{Name: "tillerAddress", EnvVar: "TILLER_ADDR", Description: "The address a local tiller listens on", Default: ":44134"}
+	// SettingTillerArgs the additional arguments of a local tiller
+	SettingTillerArgs = Setting{Name: "tillerArgs", EnvVar: "TILLER_ARGS", Description: "The additional arguments of a local tiller"}
+	// SettingUpdateCheck disables the daily check for a new jx version when commands start if set to false
+	SettingUpdateCheck = Setting{Name: "updateCheck", EnvVar: "JX_UPDATE_CHECK", Description: "Whether commands check once a day for a new jx version", Default: "true"}
+	// SettingCABundle a PEM file of the certificate authorities of self-hosted services trusted in addition to the
+	// system roots
+	SettingCABundle = Setting{Name: "caBundle", EnvVar: "JX_CA_BUNDLE", Description: "A PEM file of the additional certificate authorities trusted by jx"}
+
+	// Settings all the settings in the order they are displayed
+	Settings = []Setting{SettingBinDir, SettingDomain, SettingProvider, SettingBatchMode, SettingHelmBinary, SettingTillerAddress, SettingTillerArgs, SettingUpdateCheck, SettingCABundle}
+)
+
+// IsBoundTo returns true if the command line option of the setting is bound to the command with the given path such
+// as "jx create cluster gke"
+func (s Setting) IsBoundTo(commandPath string) bool {
+	if s.Flag == "" {
+		return false
+	}
+	if len(s.Commands) == 0 {
+		return true
+	}
+	for _, c := range s.Commands {
+		if commandPath == c || strings.HasPrefix(commandPath, c+" ") {
+			return true
+		}
+	}
+	return false
+}
+
+// ResolvedSetting the value of a setting and where it came from
+type ResolvedSetting struct {
+	Setting
+	Value  string
+	Source SettingSource
+}
+
+// SettingsResolver resolves the values of the settings from each of their sources
+type SettingsResolver struct {
+	// Flags the values of the command line options which were set indexed by the name of the option
+	Flags map[string]string
+	// LookupEnv looks up environment variables which defaults to os.LookupEnv
+	LookupEnv func(name string) (string, bool)
+	// File the values of the settings file indexed by the name of the setting
+	File map[string]string
+	// Team the values of the team settings indexed by the name of the setting
+	Team map[string]string
+}
+
+// Resolve returns the value of the setting from the source with the highest precedence which sets it
+func (r *SettingsResolver) Resolve(setting Setting) ResolvedSetting {
+	answer := ResolvedSetting{Setting: setting, Value: setting.Default, Source: SettingSourceDefault}
+	if value, ok := r.Flags[setting.Flag]; ok && setting.Flag != "" {
+		answer.Value, answer.Source = value, SettingSourceFlag
+		return answer
+	}
+	lookupEnv := r.LookupEnv
+	if lookupEnv == nil {
+		lookupEnv = os.LookupEnv
+	}
+	if value, ok := lookupEnv(setting.EnvVar); ok && setting.EnvVar != "" && value != "" {
+		answer.Value, answer.Source = value, SettingSourceEnv
+		return answer
+	}
+	if value := r.File[setting.Name]; value != "" {
+		answer.Value, answer.Source = value, SettingSourceFile
+		return answer
+	}
+	if value := r.Team[setting.Name]; value != "" {
+		answer.Value, answer.Source = value, SettingSourceTeam
+	}
+	return answer
+}
+
+// ResolveAll resolves all the settings
+func (r *SettingsResolver) ResolveAll() []ResolvedSetting {
+	answer := []ResolvedSetting{}
+	for _, setting := range Settings {
+		answer = append(answer, r.Resolve(setting))
+	}
+	return answer
+}
+
+// SettingsFile returns the path of the settings file in the jx home directory
+func SettingsFile() (string, error) {
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, SettingsFileName), nil
+}
+
+// LoadSettingsFile loads the values of the settings file. There are no values if the file does not exist
+func LoadSettingsFile(fileName string) (map[string]string, error) {
+	answer := map[string]string{}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return answer, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return answer, fmt.Errorf("failed to load the settings file %s: %s", fileName, err)
+	}
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &values)
+	if err != nil {
+		return answer, util.InvalidInputError(fmt.Errorf("failed to parse the settings file %s: %s", fileName, err))
+	}
+	for name, value := range values {
+		if !isSettingName(name) {
+			return answer, util.InvalidInputError(fmt.Errorf("unknown setting %s in the settings file %s", name, fileName))
+		}
+		if value != nil {
+			answer[name] = fmt.Sprint(value)
+		}
+	}
+	return answer, nil
+}
+
+func isSettingName(name string) bool {
+	for _, setting := range Settings {
+		if setting.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsResolverPrecedence(t *testing.T) {
+	t.Parallel()
+	env := map[string]string{}
+	resolver := &config.SettingsResolver{
+		Flags: map[string]string{},
+		LookupEnv: func(name string) (string, bool) {
+			value, ok := env[name]
+			return value, ok
+		},
+		File: map[string]string{},
+		Team: map[string]string{},
+	}
+	assertSetting := func(value string, source config.SettingSource) {
+		resolved := resolver.Resolve(config.SettingHelmBinary)
+		assert.Equal(t, value, resolved.Value)
+		assert.Equal(t, source, resolved.Source)
+	}
+	assertSetting("helm", config.SettingSourceDefault)
+
+	resolver.Team["helmBinary"] = "helm3"
+	assertSetting("helm3", config.SettingSourceTeam)
+
+	resolver.File["helmBinary"] = "/opt/helm"
+	assertSetting("/opt/helm", config.SettingSourceFile)
+
+	env["JX_HELM_BINARY"] = "/usr/local/bin/helm"
+	assertSetting("/usr/local/bin/helm", config.SettingSourceEnv)
+
+	env["JX_DOMAIN"] = "env.example.com"
+	resolver.Flags["domain"] = "flag.example.com"
+	resolved := resolver.Resolve(config.SettingDomain)
+	assert.Equal(t, "flag.example.com", resolved.Value)
+	assert.Equal(t, config.SettingSourceFlag, resolved.Source)
+
+	assert.Len(t, resolver.ResolveAll(), len(config.Settings))
+}
+
+func TestSettingIsBoundTo(t *testing.T) {
+	t.Parallel()
+	assert.True(t, config.SettingBatchMode.IsBoundTo("jx create notification"))
+	assert.True(t, config.SettingProvider.IsBoundTo("jx install"))
+	assert.True(t, config.SettingProvider.IsBoundTo("jx create cluster gke"))
+	assert.True(t, config.SettingDomain.IsBoundTo("jx create cluster"))
+	assert.False(t, config.SettingProvider.IsBoundTo("jx create notification"))
+	assert.False(t, config.SettingDomain.IsBoundTo("jx create team"))
+	assert.False(t, config.SettingDomain.IsBoundTo("jx installer"))
+	assert.False(t, config.SettingHelmBinary.IsBoundTo("jx install"), "settings without an option are not bound")
+}
+
+func TestLoadSettingsFile(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-settings-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, config.SettingsFileName)
+	values, err := config.LoadSettingsFile(fileName)
+	require.NoError(t, err)
+	assert.Empty(t, values, "a missing settings file has no values")
+
+	err = ioutil.WriteFile(fileName, []byte("domain: example.com\nbatchMode: true\n"), util.DefaultWritePermissions)
+	require.NoError(t, err)
+	values, err = config.LoadSettingsFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"domain": "example.com", "batchMode": "true"}, values)
+
+	err = ioutil.WriteFile(fileName, []byte("domian: example.com\n"), util.DefaultWritePermissions)
+	require.NoError(t, err)
+	_, err = config.LoadSettingsFile(fileName)
+	assert.Error(t, err)
+	assert.Equal(t, util.ErrorCategoryInvalidInput, util.ErrorCategoryOf(err))
+}
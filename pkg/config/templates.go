@@ -0,0 +1,128 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"text/template"
+)
+
+// templateExpressionRegex matches the expressions which reference the template context. Other expressions such as
+// the ones evaluated by the helm 'tpl' function are left untouched
+var templateExpressionRegex = regexp.MustCompile(`\{\{-?\s*\.(Requirements|Cluster)\b[^}]*\}\}`)
+
+// TemplateContext the values which can be referenced from team settings, helm values files and webhook URLs
+// via expressions such as {{ .Requirements.Domain }} or {{ .Cluster.Provider }} so they are declared once
+type TemplateContext struct {
+	Requirements RequirementsConfig
+	Cluster      ClusterContext
+}
+
+// ClusterContext the cluster the platform is installed on
+type ClusterContext struct {
+	Provider  string
+	Name      string
+	Namespace string
+}
+
+// NewTemplateContext creates the template context for the requirements which may be nil if the platform was not
+// installed with requirements
+func NewTemplateContext(requirements *RequirementsConfig, clusterName string, ns string) *TemplateContext {
+	ctx := &TemplateContext{
+		Cluster: ClusterContext{
+			Name:      clusterName,
+			Namespace: ns,
+		},
+	}
+	if requirements != nil {
+		ctx.Requirements = *requirements
+		ctx.Cluster.Provider = requirements.Provider
+		if requirements.Namespace != "" {
+			ctx.Cluster.Namespace = requirements.Namespace
+		}
+	}
+	return ctx
+}
+
+// HasTemplateExpressions returns true if the text references the template context
+func HasTemplateExpressions(text string) bool {
+	return templateExpressionRegex.MatchString(text)
+}
+
+// Expand resolves the expressions in the text which reference the template context. An error is returned if an
+// expression is invalid or resolves to an empty value
+func (c *TemplateContext) Expand(text string) (string, error) {
+	var answer error
+	expanded := templateExpressionRegex.ReplaceAllStringFunc(text, func(expression string) string {
+		if answer != nil {
+			return expression
+		}
+		value, err := c.evaluate(expression)
+		if err != nil {
+			answer = err
+			return expression
+		}
+		return value
+	})
+	return expanded, answer
+}
+
+func (c *TemplateContext) evaluate(expression string) (string, error) {
+	tmpl, err := template.New("value").Option("missingkey=error").Parse(expression)
+	if err != nil {
+		return "", fmt.Errorf("invalid expression %s: %s", expression, err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, c)
+	if err != nil {
+		return "", fmt.Errorf("failed to evaluate %s: %s", expression, err)
+	}
+	value := buffer.String()
+	if value == "" {
+		return "", fmt.Errorf("%s has no value", expression)
+	}
+	return value, nil
+}
+
+// ExpandValues resolves the expressions in each of the values
+func (c *TemplateContext) ExpandValues(values ...*string) error {
+	for _, value := range values {
+		if value == nil || !HasTemplateExpressions(*value) {
+			continue
+		}
+		expanded, err := c.Expand(*value)
+		if err != nil {
+			return err
+		}
+		*value = expanded
+	}
+	return nil
+}
+
+// ExpandFile returns the file name if the file does not reference the template context. Otherwise the file is
+// expanded into the output directory and the name of the expanded file is returned
+func (c *TemplateContext) ExpandFile(fileName string, outDir string) (string, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return "", fmt.Errorf("failed to load file %s due to %s", fileName, err)
+	}
+	text := string(data)
+	if !HasTemplateExpressions(text) {
+		return fileName, nil
+	}
+	expanded, err := c.Expand(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to expand %s: %s", fileName, err)
+	}
+	file, err := ioutil.TempFile(outDir, "values-")
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	_, err = file.WriteString(expanded)
+	if err != nil {
+		return "", err
+	}
+	return file.Name(), nil
+}
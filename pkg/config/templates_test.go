@@ -0,0 +1,70 @@
+package config_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateContextExpand(t *testing.T) {
+	t.Parallel()
+	ctx := config.NewTemplateContext(&config.RequirementsConfig{
+		Provider: "gke",
+		Domain:   "jx.acme.com",
+	}, "prod-cluster", "jx")
+
+	value, err := ctx.Expand("https://hooks.{{ .Requirements.Domain }}/audit?cluster={{.Cluster.Name}}&provider={{ .Cluster.Provider }}")
+	require.NoError(t, err)
+	assert.Equal(t, "https://hooks.jx.acme.com/audit?cluster=prod-cluster&provider=gke", value)
+
+	text := `host: "{{ tpl .Values.host . }}"`
+	value, err = ctx.Expand(text)
+	require.NoError(t, err)
+	assert.Equal(t, text, value, "expressions which do not reference the context should be left for helm")
+
+	_, err = ctx.Expand("{{ .Requirements.Storage.Nexus }}")
+	assert.Error(t, err, "an empty value should be an error")
+
+	_, err = ctx.Expand("{{ .Cluster.Region }}")
+	assert.Error(t, err)
+}
+
+func TestTemplateContextWithoutRequirements(t *testing.T) {
+	t.Parallel()
+	ctx := config.NewTemplateContext(nil, "", "jx")
+	value, err := ctx.Expand("{{ .Cluster.Namespace }}")
+	require.NoError(t, err)
+	assert.Equal(t, "jx", value)
+
+	url := "{{ .Requirements.Domain }}"
+	assert.Error(t, ctx.ExpandValues(&url))
+}
+
+func TestTemplateContextExpandFile(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-expand-file-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	ctx := config.NewTemplateContext(&config.RequirementsConfig{Provider: "eks", Domain: "apps.acme.com"}, "", "jx")
+
+	plain := filepath.Join(dir, "plain.yaml")
+	require.NoError(t, ioutil.WriteFile(plain, []byte("replicaCount: 2\n"), 0644))
+	fileName, err := ctx.ExpandFile(plain, dir)
+	require.NoError(t, err)
+	assert.Equal(t, plain, fileName)
+
+	values := filepath.Join(dir, "values.yaml")
+	require.NoError(t, ioutil.WriteFile(values, []byte("expose:\n  domain: {{ .Requirements.Domain }}\n"), 0644))
+	fileName, err = ctx.ExpandFile(values, dir)
+	require.NoError(t, err)
+	assert.NotEqual(t, values, fileName)
+	data, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "expose:\n  domain: apps.acme.com\n", string(data))
+}
@@ -0,0 +1,230 @@
+package cve
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+)
+
+const (
+	// AdvisoryFeedEnvVar the environment variable of the file or URL of the advisory feed
+	AdvisoryFeedEnvVar = "JX_CVE_FEED"
+
+	// DefaultAdvisoryFeedURL the advisory feed published for the platform components
+	DefaultAdvisoryFeedURL = "https://raw.githubusercontent.com/jenkins-x/jenkins-x-versions/master/advisories.yml"
+
+	// ComponentKindChart a component installed as a helm chart
+	ComponentKindChart = "chart"
+	// ComponentKindImage a component running as a container image
+	ComponentKindImage = "image"
+)
+
+// AdvisoryFeed the published advisories of the platform components
+type AdvisoryFeed struct {
+	Advisories []Advisory `json:"advisories"`
+}
+
+// Advisory a vulnerability affecting the versions of a chart or image of the platform
+type Advisory struct {
+	ID          string `json:"id"`
+	Component   string `json:"component"`
+	Kind        string `json:"kind,omitempty"`
+	Severity    string `json:"severity"`
+	URL         string `json:"url,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Introduced the first affected version. All versions before Fixed are affected if it is empty
+	Introduced string `json:"introduced,omitempty"`
+	// Fixed the first version which is not affected. All versions from Introduced are affected if it is empty
+	Fixed string `json:"fixed,omitempty"`
+}
+
+// Component a chart or image version of the installed platform
+type Component struct {
+	Name    string
+	Kind    string
+	Version string
+}
+
+// AdvisoryMatch an advisory affecting an installed component
+type AdvisoryMatch struct {
+	Component Component
+	Advisory  Advisory
+}
+
+// LoadAdvisoryFeed loads the advisory feed from a file or an http(s) URL
+func LoadAdvisoryFeed(location string) (*AdvisoryFeed, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		data, err = downloadAdvisoryFeed(location)
+	} else {
+		data, err = ioutil.ReadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the advisory feed %s: %s", location, err)
+	}
+	feed, err := ParseAdvisoryFeed(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the advisory feed %s: %s", location, err)
+	}
+	return feed, nil
+}
+
+func downloadAdvisoryFeed(u string) ([]byte, error) {
+	client := httpclient.NewClient(nil)
+	client.Timeout = 30 * time.Second
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// ParseAdvisoryFeed parses and validates the YAML or JSON advisory feed
+func ParseAdvisoryFeed(data []byte) (*AdvisoryFeed, error) {
+	feed := &AdvisoryFeed{}
+	err := yaml.Unmarshal(data, feed)
+	if err != nil {
+		return nil, err
+	}
+	for i := range feed.Advisories {
+		a := &feed.Advisories[i]
+		if a.ID == "" || a.Component == "" {
+			return nil, fmt.Errorf("advisory %d has no id or component", i+1)
+		}
+		if a.Introduced == "" && a.Fixed == "" {
+			return nil, fmt.Errorf("advisory %s has no introduced or fixed version", a.ID)
+		}
+		for _, version := range []string{a.Introduced, a.Fixed} {
+			if version == "" {
+				continue
+			}
+			_, err = semver.ParseTolerant(version)
+			if err != nil {
+				return nil, fmt.Errorf("advisory %s has an invalid version %s: %s", a.ID, version, err)
+			}
+		}
+		if a.Kind == "" {
+			a.Kind = ComponentKindChart
+		}
+		if a.Kind != ComponentKindChart && a.Kind != ComponentKindImage {
+			return nil, fmt.Errorf("advisory %s has an invalid kind %s. Must be %s or %s", a.ID, a.Kind, ComponentKindChart, ComponentKindImage)
+		}
+		a.Severity = strings.ToUpper(a.Severity)
+		if a.Severity == "" {
+			a.Severity = SeverityUnknown
+		}
+	}
+	return feed, nil
+}
+
+// Affects returns true if the advisory affects the version of the component. Versions which are not semantic
+// versions are reported as affected so that they are checked by hand
+func (a *Advisory) Affects(component Component) bool {
+	if a.Kind != component.Kind || !matchesComponentName(a.Component, component.Name) {
+		return false
+	}
+	v, err := semver.ParseTolerant(component.Version)
+	if err != nil {
+		return true
+	}
+	if a.Introduced != "" {
+		introduced, _ := semver.ParseTolerant(a.Introduced)
+		if v.LT(introduced) {
+			return false
+		}
+	}
+	if a.Fixed != "" {
+		fixed, _ := semver.ParseTolerant(a.Fixed)
+		if v.GE(fixed) {
+			return false
+		}
+	}
+	return true
+}
+
+// matchesComponentName returns true if the names are equal ignoring the repository prefix of a chart
+// such as 'jenkins-x/' or the registry of an image
+func matchesComponentName(advisory string, name string) bool {
+	return advisory == name || strings.HasSuffix(name, "/"+advisory)
+}
+
+// Match returns the advisories affecting the components sorted by severity and component
+func (f *AdvisoryFeed) Match(components []Component) []AdvisoryMatch {
+	answer := []AdvisoryMatch{}
+	for _, component := range components {
+		for _, advisory := range f.Advisories {
+			if advisory.Affects(component) {
+				answer = append(answer, AdvisoryMatch{Component: component, Advisory: advisory})
+			}
+		}
+	}
+	sort.SliceStable(answer, func(i, j int) bool {
+		si := severityRank(answer[i].Advisory.Severity)
+		sj := severityRank(answer[j].Advisory.Severity)
+		if si != sj {
+			return si < sj
+		}
+		if answer[i].Component.Name != answer[j].Component.Name {
+			return answer[i].Component.Name < answer[j].Component.Name
+		}
+		return answer[i].Advisory.ID < answer[j].Advisory.ID
+	})
+	return answer
+}
+
+// ReleaseComponents returns the charts and images of the platform release. The chart of the helm release is
+// used as the component name when known
+func ReleaseComponents(release *versionstream.Release) []Component {
+	answer := []Component{}
+	if release == nil {
+		return answer
+	}
+	for _, chart := range release.Charts {
+		name := chart.Chart
+		if name == "" {
+			name = chart.Name
+		}
+		answer = append(answer, Component{Name: name, Kind: ComponentKindChart, Version: chart.Version})
+	}
+	images := []string{}
+	for image := range release.Images {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	for _, image := range images {
+		answer = append(answer, Component{Name: image, Kind: ComponentKindImage, Version: release.Images[image]})
+	}
+	return answer
+}
+
+// FilterBySeverity returns the matches whose severity is at least the given severity
+func FilterBySeverity(matches []AdvisoryMatch, severity string) ([]AdvisoryMatch, error) {
+	if severity == "" {
+		return matches, nil
+	}
+	rank := severityRank(severity)
+	if rank >= len(Severities) {
+		return nil, util.InvalidOption("severity", severity, Severities)
+	}
+	answer := []AdvisoryMatch{}
+	for _, m := range matches {
+		if severityRank(m.Advisory.Severity) <= rank {
+			answer = append(answer, m)
+		}
+	}
+	return answer, nil
+}
@@ -0,0 +1,57 @@
+package cve_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdvisoryFeedMatch(t *testing.T) {
+	t.Parallel()
+	feed, err := cve.LoadAdvisoryFeed(filepath.Join("test_data", "advisories", "advisories.yml"))
+	require.NoError(t, err)
+	require.Len(t, feed.Advisories, 4)
+	assert.Equal(t, cve.ComponentKindChart, feed.Advisories[0].Kind)
+	assert.Equal(t, cve.SeverityCritical, feed.Advisories[0].Severity)
+
+	release := &versionstream.Release{
+		Version: "1.0.0",
+		Charts: []versionstream.ChartVersion{
+			{Name: "jenkins-x", Chart: "jenkins-x/jenkins-x-platform", Version: "0.0.3100"},
+			{Name: "jenkins", Chart: "stable/jenkins", Version: "0.0.59"},
+			{Name: "nexus", Chart: "jenkins-x/nexus", Version: "0.1.20"},
+		},
+		Images: map[string]string{
+			"gcr.io/jenkinsxio/jx": "2.0.100",
+		},
+	}
+	matches := feed.Match(cve.ReleaseComponents(release))
+	ids := []string{}
+	for _, m := range matches {
+		ids = append(ids, m.Advisory.ID)
+	}
+	assert.Equal(t, []string{"CVE-2019-1003000", "CVE-2019-11253", "JX-2019-0002"}, ids)
+
+	filtered, err := cve.FilterBySeverity(matches, "high")
+	require.NoError(t, err)
+	assert.Len(t, filtered, 2)
+
+	_, err = cve.FilterBySeverity(matches, "severe")
+	assert.Error(t, err)
+}
+
+func TestParseAdvisoryFeedInvalid(t *testing.T) {
+	t.Parallel()
+	_, err := cve.ParseAdvisoryFeed([]byte("advisories:\n- id: CVE-1\n  component: jenkins\n"))
+	assert.Error(t, err, "an advisory without versions should be invalid")
+
+	_, err = cve.ParseAdvisoryFeed([]byte("advisories:\n- id: CVE-1\n  component: jenkins\n  fixed: latest\n"))
+	assert.Error(t, err)
+
+	_, err = cve.ParseAdvisoryFeed([]byte("advisories:\n- id: CVE-1\n  component: jenkins\n  kind: binary\n  fixed: 1.0.0\n"))
+	assert.Error(t, err)
+}
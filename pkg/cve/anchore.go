@@ -9,6 +9,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/auth"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/table"
 	"github.com/jenkins-x/jx/pkg/util"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -65,7 +66,7 @@ func NewAnchoreProvider(server *auth.AuthServer, user *auth.UserAuth) (CVEProvid
 	provider := AnchoreProvider{
 		BaseURL:   server.URL,
 		BasicAuth: basicAuth,
-		Client:    http.DefaultClient,
+		Client:    httpclient.NewBaseClient(),
 	}
 
 	return &provider, nil
@@ -0,0 +1,173 @@
+package cve
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ScannerTrivy scans images using the trivy binary
+	ScannerTrivy = "trivy"
+
+	// SeverityCritical the severity of critical vulnerabilities
+	SeverityCritical = "CRITICAL"
+	// SeverityHigh the severity of high vulnerabilities
+	SeverityHigh = "HIGH"
+	// SeverityMedium the severity of medium vulnerabilities
+	SeverityMedium = "MEDIUM"
+	// SeverityLow the severity of low vulnerabilities
+	SeverityLow = "LOW"
+	// SeverityUnknown the severity of vulnerabilities which have not been classified
+	SeverityUnknown = "UNKNOWN"
+)
+
+// Severities the severities of vulnerabilities from the most to the least severe
+var Severities = []string{SeverityCritical, SeverityHigh, SeverityMedium, SeverityLow, SeverityUnknown}
+
+// ImageScanner scans an image for vulnerabilities
+type ImageScanner interface {
+	ScanImage(image string) ([]Vulnerability, error)
+}
+
+// CommandRunner runs an external command returning its output so that it can be replaced in tests
+type CommandRunner func(name string, args ...string) (string, error)
+
+// TrivyScanner an ImageScanner which uses the trivy binary
+type TrivyScanner struct {
+	IgnoreUnfixed bool
+	Runner        CommandRunner
+}
+
+// NewImageScanner creates the image scanner of the given name
+func NewImageScanner(name string, ignoreUnfixed bool) (ImageScanner, error) {
+	switch name {
+	case "", ScannerTrivy:
+		return &TrivyScanner{IgnoreUnfixed: ignoreUnfixed}, nil
+	default:
+		return nil, util.InvalidOption("scanner", name, []string{ScannerTrivy})
+	}
+}
+
+type trivyReport struct {
+	Results []trivyResult `json:"Results"`
+}
+
+type trivyResult struct {
+	Target          string               `json:"Target"`
+	Vulnerabilities []trivyVulnerability `json:"Vulnerabilities"`
+}
+
+type trivyVulnerability struct {
+	VulnerabilityID  string `json:"VulnerabilityID"`
+	PkgName          string `json:"PkgName"`
+	InstalledVersion string `json:"InstalledVersion"`
+	FixedVersion     string `json:"FixedVersion"`
+	Severity         string `json:"Severity"`
+	PrimaryURL       string `json:"PrimaryURL"`
+}
+
+// ScanImage scans the image using trivy
+func (s *TrivyScanner) ScanImage(image string) ([]Vulnerability, error) {
+	args := []string{"image", "--quiet", "--no-progress", "--format", "json"}
+	if s.IgnoreUnfixed {
+		args = append(args, "--ignore-unfixed")
+	}
+	args = append(args, image)
+	runner := s.Runner
+	if runner == nil {
+		runner = func(name string, args ...string) (string, error) {
+			cmd := util.Command{
+				Name: name,
+				Args: args,
+			}
+			return cmd.RunWithoutRetry()
+		}
+	}
+	out, err := runner(ScannerTrivy, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan image %s with trivy: %s", image, err)
+	}
+	return ParseTrivyReport([]byte(out))
+}
+
+// ParseTrivyReport parses the JSON report generated by trivy
+func ParseTrivyReport(data []byte) ([]Vulnerability, error) {
+	results := []trivyResult{}
+	text := strings.TrimSpace(string(data))
+	if strings.HasPrefix(text, "[") {
+		// older versions of trivy generate an array of results
+		err := json.Unmarshal(data, &results)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the trivy report: %s", err)
+		}
+	} else if text != "" {
+		report := trivyReport{}
+		err := json.Unmarshal(data, &report)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse the trivy report: %s", err)
+		}
+		results = report.Results
+	}
+	answer := []Vulnerability{}
+	for _, r := range results {
+		for _, v := range r.Vulnerabilities {
+			answer = append(answer, Vulnerability{
+				Vuln:     v.VulnerabilityID,
+				Package:  v.PkgName + " " + v.InstalledVersion,
+				Fix:      v.FixedVersion,
+				Severity: strings.ToUpper(v.Severity),
+				URL:      v.PrimaryURL,
+			})
+		}
+	}
+	return answer, nil
+}
+
+// CountBySeverity returns the number of distinct vulnerabilities of each severity
+func CountBySeverity(vulnerabilities []Vulnerability) map[string]int {
+	seen := map[string]bool{}
+	answer := map[string]int{}
+	for _, v := range vulnerabilities {
+		severity := strings.ToUpper(v.Severity)
+		key := severity + "/" + v.Vuln + "/" + v.Package
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		answer[severity]++
+	}
+	return answer
+}
+
+// ExceededThresholds returns a description of each severity whose number of vulnerabilities exceeds the threshold
+// of the severity. Severities without a threshold are not checked
+func ExceededThresholds(counts map[string]int, thresholds map[string]int) []string {
+	severities := []string{}
+	for severity := range thresholds {
+		severities = append(severities, severity)
+	}
+	sort.Slice(severities, func(i, j int) bool {
+		return severityRank(severities[i]) < severityRank(severities[j])
+	})
+	answer := []string{}
+	for _, severity := range severities {
+		max := thresholds[severity]
+		count := counts[strings.ToUpper(severity)]
+		if max >= 0 && count > max {
+			answer = append(answer, fmt.Sprintf("%d %s vulnerabilities exceeds the threshold of %d", count, strings.ToLower(severity), max))
+		}
+	}
+	return answer
+}
+
+func severityRank(severity string) int {
+	idx := util.StringArrayIndex(Severities, strings.ToUpper(severity))
+	if idx < 0 {
+		return len(Severities)
+	}
+	return idx
+}
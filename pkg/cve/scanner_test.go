@@ -0,0 +1,73 @@
+package cve_test
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTrivyReport(t *testing.T) {
+	t.Parallel()
+	data, err := ioutil.ReadFile(filepath.Join("test_data", "trivy", "report.json"))
+	require.NoError(t, err)
+
+	vulnerabilities, err := cve.ParseTrivyReport(data)
+	require.NoError(t, err)
+	require.Len(t, vulnerabilities, 4)
+	assert.Equal(t, "CVE-2020-1967", vulnerabilities[0].Vuln)
+	assert.Equal(t, "libssl1.1 1.1.1d-0+deb10u2", vulnerabilities[0].Package)
+	assert.Equal(t, "1.1.1d-0+deb10u3", vulnerabilities[0].Fix)
+	assert.Equal(t, cve.SeverityHigh, vulnerabilities[0].Severity)
+
+	counts := cve.CountBySeverity(vulnerabilities)
+	assert.Equal(t, map[string]int{cve.SeverityCritical: 1, cve.SeverityHigh: 1, cve.SeverityLow: 1}, counts)
+
+	legacy := `[{"Target": "myapp (alpine 3.7)", "Vulnerabilities": [{"VulnerabilityID": "CVE-2018-0732", "PkgName": "openssl", "Severity": "medium"}]}]`
+	vulnerabilities, err = cve.ParseTrivyReport([]byte(legacy))
+	require.NoError(t, err)
+	require.Len(t, vulnerabilities, 1)
+	assert.Equal(t, cve.SeverityMedium, vulnerabilities[0].Severity)
+
+	vulnerabilities, err = cve.ParseTrivyReport([]byte(""))
+	require.NoError(t, err)
+	assert.Empty(t, vulnerabilities)
+
+	_, err = cve.ParseTrivyReport([]byte("FATAL error"))
+	assert.Error(t, err)
+}
+
+func TestExceededThresholds(t *testing.T) {
+	t.Parallel()
+	counts := map[string]int{cve.SeverityCritical: 2, cve.SeverityHigh: 3, cve.SeverityLow: 10}
+
+	assert.Empty(t, cve.ExceededThresholds(counts, map[string]int{}))
+	assert.Empty(t, cve.ExceededThresholds(counts, map[string]int{"CRITICAL": 2, "HIGH": 5}))
+	assert.Equal(t, []string{
+		"2 critical vulnerabilities exceeds the threshold of 0",
+		"3 high vulnerabilities exceeds the threshold of 1",
+	}, cve.ExceededThresholds(counts, map[string]int{"HIGH": 1, "critical": 0, "MEDIUM": 0}))
+}
+
+func TestTrivyScanner(t *testing.T) {
+	t.Parallel()
+	var command string
+	scanner := &cve.TrivyScanner{
+		IgnoreUnfixed: true,
+		Runner: func(name string, args ...string) (string, error) {
+			command = name + " " + strings.Join(args, " ")
+			return `{"Results": [{"Target": "myapp", "Vulnerabilities": [{"VulnerabilityID": "CVE-1", "PkgName": "foo", "Severity": "CRITICAL"}]}]}`, nil
+		},
+	}
+	vulnerabilities, err := scanner.ScanImage("gcr.io/myorg/myapp:1.0.0")
+	require.NoError(t, err)
+	assert.Len(t, vulnerabilities, 1)
+	assert.Equal(t, "trivy image --quiet --no-progress --format json --ignore-unfixed gcr.io/myorg/myapp:1.0.0", command)
+
+	_, err = cve.NewImageScanner("clair", false)
+	assert.Error(t, err)
+}
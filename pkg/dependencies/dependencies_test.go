@@ -0,0 +1,108 @@
+package dependencies_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/dependencies"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGraph(t *testing.T) {
+	t.Parallel()
+	kubeClient := testclient.NewSimpleClientset()
+	ns := "jx"
+
+	graph, err := dependencies.LoadGraph(kubeClient, ns)
+	require.NoError(t, err)
+	assert.Empty(t, graph.Dependencies)
+
+	assert.True(t, graph.Add(dependencies.Dependency{Kind: dependencies.KindMaven, Library: "io.acme:core", Repository: "https://github.com/acme/orders.git"}))
+	assert.True(t, graph.Add(dependencies.Dependency{Kind: dependencies.KindMaven, Library: "io.acme:core", Repository: "https://github.com/acme/billing.git"}))
+	assert.True(t, graph.Add(dependencies.Dependency{Kind: dependencies.KindNPM, Library: "@acme/ui", Repository: "https://github.com/acme/web.git"}))
+	assert.False(t, graph.Add(dependencies.Dependency{Kind: dependencies.KindMaven, Library: "io.acme:core", Repository: "https://github.com/acme/orders"}))
+	require.NoError(t, dependencies.SaveGraph(kubeClient, ns, graph))
+
+	graph, err = dependencies.LoadGraph(kubeClient, ns)
+	require.NoError(t, err)
+	dependents := graph.Dependents(dependencies.KindMaven, "io.acme:core")
+	require.Len(t, dependents, 2)
+	assert.Equal(t, "https://github.com/acme/billing.git", dependents[0].Repository)
+	assert.Empty(t, graph.Dependents(dependencies.KindGradle, "io.acme:core"))
+
+	assert.True(t, graph.Remove(dependencies.KindMaven, "io.acme:core", "https://github.com/acme/billing"))
+	assert.False(t, graph.Remove(dependencies.KindMaven, "io.acme:core", "https://github.com/acme/billing"))
+	require.NoError(t, dependencies.SaveGraph(kubeClient, ns, graph))
+	graph, err = dependencies.LoadGraph(kubeClient, ns)
+	require.NoError(t, err)
+	assert.Len(t, graph.Dependencies, 2)
+
+	assert.NoError(t, dependencies.ValidateKind("gradle"))
+	assert.Error(t, dependencies.ValidateKind("ant"))
+}
+
+func TestUpdateGradleVersion(t *testing.T) {
+	t.Parallel()
+	text := `dependencies {
+    compile 'io.acme:core:1.0.0'
+    compile "io.acme:core-extras:1.0.0"
+    implementation group: 'io.acme', name: 'core', version: '1.0.0'
+    testCompile 'io.acme:core:1.0.0@jar'
+}`
+	expected := `dependencies {
+    compile 'io.acme:core:1.1.0'
+    compile "io.acme:core-extras:1.0.0"
+    implementation group: 'io.acme', name: 'core', version: '1.1.0'
+    testCompile 'io.acme:core:1.1.0@jar'
+}`
+	assert.Equal(t, expected, dependencies.UpdateGradleVersion(text, "io.acme:core", "1.1.0"))
+}
+
+func TestUpdateNPMVersionKeepsNonSemverDependencies(t *testing.T) {
+	t.Parallel()
+	text := `{"dependencies": {"@acme/ui": "~1.0.0", "@acme/theme": "git+https://github.com/acme/theme.git"}}`
+	assert.Equal(t, `{"dependencies": {"@acme/ui": "~2.0.0", "@acme/theme": "git+https://github.com/acme/theme.git"}}`, dependencies.UpdateNPMVersion(text, "@acme/ui", "2.0.0"))
+	assert.Equal(t, text, dependencies.UpdateNPMVersion(text, "@acme/theme", "2.0.0"))
+}
+
+func TestUpdateVersion(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-update-dependencies-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, util.CopyDir(filepath.Join("test_data", "app"), dir, true))
+
+	files, err := dependencies.UpdateVersion(dir, dependencies.KindMaven, "io.acme:core", "1.2.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "pom.xml")}, files)
+	data, err := ioutil.ReadFile(filepath.Join(dir, "pom.xml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "<core.version>1.2.0</core.version>")
+	assert.Contains(t, string(data), "<version>${core.version}</version>")
+	assert.Contains(t, string(data), "<version>1.0.0</version>", "other dependencies should not be updated")
+
+	files, err = dependencies.UpdateVersion(dir, dependencies.KindNPM, "@acme/ui", "1.3.0")
+	require.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "web", "package.json")}, files)
+	data, err = ioutil.ReadFile(filepath.Join(dir, "web", "package.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), `"@acme/ui": "^1.3.0"`)
+	assert.Contains(t, string(data), `"version": "0.0.1"`)
+
+	files, err = dependencies.UpdateVersion(dir, dependencies.KindGo, "github.com/acme/core", "1.4.0")
+	require.NoError(t, err)
+	assert.Len(t, files, 1)
+	data, err = ioutil.ReadFile(filepath.Join(dir, "go.mod"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "github.com/acme/core v1.4.0\n")
+	assert.Contains(t, string(data), "github.com/acme/core-extras v1.0.0\n")
+
+	files, err = dependencies.UpdateVersion(dir, dependencies.KindGo, "github.com/acme/core", "1.4.0")
+	require.NoError(t, err)
+	assert.Empty(t, files, "nothing should change when the version is already up to date")
+}
@@ -0,0 +1,165 @@
+package dependencies
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Kind the kind of build tool or package manager a dependency is declared with
+type Kind string
+
+const (
+	// KindMaven a dependency declared in a maven pom.xml
+	KindMaven Kind = "maven"
+	// KindGradle a dependency declared in a gradle build file
+	KindGradle Kind = "gradle"
+	// KindNPM a dependency declared in a package.json
+	KindNPM Kind = "npm"
+	// KindGo a module required in a go.mod
+	KindGo Kind = "go"
+	// KindChart a helm chart declared in a requirements.yaml
+	KindChart Kind = "chart"
+
+	// ConfigMapName the name of the ConfigMap in the dev namespace which stores the dependency graph of the team
+	ConfigMapName = "jx-dependency-graph"
+	// ConfigMapKey the key of the dependency graph in the ConfigMap
+	ConfigMapKey = "graph.yaml"
+)
+
+// Kinds returns the names of the kinds of dependencies which can be updated
+func Kinds() []string {
+	return []string{string(KindChart), string(KindGo), string(KindGradle), string(KindMaven), string(KindNPM)}
+}
+
+// Dependency a library which a repository depends on
+type Dependency struct {
+	// Library the name of the library such as 'groupId:artifactId' for maven and gradle, the package name for npm,
+	// the module path for go or the chart name
+	Library string `json:"library"`
+	Kind    Kind   `json:"kind"`
+	// Repository the git URL of the repository which depends on the library
+	Repository string `json:"repository"`
+	// Branch the branch the Pull Requests updating the library are created against. Defaults to master
+	Branch string `json:"branch,omitempty"`
+}
+
+// Graph the dependencies between the repositories of a team. When a library is released a Pull Request bumping its
+// version is created in each dependent repository
+type Graph struct {
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+// Add adds the dependency returning false if it already exists
+func (g *Graph) Add(dependency Dependency) bool {
+	for _, d := range g.Dependencies {
+		if d.matches(dependency.Kind, dependency.Library, dependency.Repository) {
+			return false
+		}
+	}
+	g.Dependencies = append(g.Dependencies, dependency)
+	g.sort()
+	return true
+}
+
+// Remove removes the dependency of the repository on the library returning false if there is no such dependency
+func (g *Graph) Remove(kind Kind, library string, repository string) bool {
+	answer := []Dependency{}
+	for _, d := range g.Dependencies {
+		if !d.matches(kind, library, repository) {
+			answer = append(answer, d)
+		}
+	}
+	removed := len(answer) != len(g.Dependencies)
+	g.Dependencies = answer
+	return removed
+}
+
+// Dependents returns the dependencies of the repositories which depend on the library
+func (g *Graph) Dependents(kind Kind, library string) []Dependency {
+	answer := []Dependency{}
+	for _, d := range g.Dependencies {
+		if d.Kind == kind && d.Library == library {
+			answer = append(answer, d)
+		}
+	}
+	return answer
+}
+
+func (g *Graph) sort() {
+	sort.Slice(g.Dependencies, func(i, j int) bool {
+		a := g.Dependencies[i]
+		b := g.Dependencies[j]
+		if a.Library != b.Library {
+			return a.Library < b.Library
+		}
+		return a.Repository < b.Repository
+	})
+}
+
+func (d *Dependency) matches(kind Kind, library string, repository string) bool {
+	return d.Kind == kind && d.Library == library && strings.TrimSuffix(d.Repository, ".git") == strings.TrimSuffix(repository, ".git")
+}
+
+// ValidateKind returns an error if the kind of dependency is not supported
+func ValidateKind(kind string) error {
+	if util.StringArrayIndex(Kinds(), kind) < 0 {
+		return util.InvalidArg(kind, Kinds())
+	}
+	return nil
+}
+
+// LoadGraph loads the dependency graph of the team from the dev namespace. An empty graph is returned if none
+// has been saved yet
+func LoadGraph(kubeClient kubernetes.Interface, ns string) (*Graph, error) {
+	graph := &Graph{}
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return graph, nil
+		}
+		return nil, fmt.Errorf("failed to load the ConfigMap %s in namespace %s: %s", ConfigMapName, ns, err)
+	}
+	err = yaml.Unmarshal([]byte(cm.Data[ConfigMapKey]), graph)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the dependency graph in the ConfigMap %s: %s", ConfigMapName, err)
+	}
+	return graph, nil
+}
+
+// SaveGraph saves the dependency graph of the team to the dev namespace
+func SaveGraph(kubeClient kubernetes.Interface, ns string, graph *Graph) error {
+	data, err := yaml.Marshal(graph)
+	if err != nil {
+		return err
+	}
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(ConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		_, err = configMaps.Create(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: ConfigMapName,
+			},
+			Data: map[string]string{
+				ConfigMapKey: string(data),
+			},
+		})
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[ConfigMapKey] = string(data)
+	_, err = configMaps.Update(cm)
+	return err
+}
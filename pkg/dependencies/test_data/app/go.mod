@@ -0,0 +1,6 @@
+module github.com/acme/app
+
+require (
+	github.com/acme/core v1.0.0
+	github.com/acme/core-extras v1.0.0
+)
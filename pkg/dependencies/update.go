@@ -0,0 +1,186 @@
+package dependencies
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	mavenBlockRegex   = regexp.MustCompile(`(?s)<(dependency|parent)>.*?</(dependency|parent)>`)
+	mavenVersionRegex = regexp.MustCompile(`<version>\s*([^<]*?)\s*</version>`)
+	mavenPropertyRef  = regexp.MustCompile(`^\$\{(.+)\}$`)
+	npmVersionRegex   = regexp.MustCompile(`^(\^|~|>=)?\d`)
+
+	// skippedDirs the directories which contain generated or downloaded files rather than sources
+	skippedDirs = []string{".git", "node_modules", "target", "build", "vendor", "charts"}
+)
+
+// fileNames returns the names of the files which declare dependencies of the kind
+func fileNames(kind Kind) []string {
+	switch kind {
+	case KindMaven:
+		return []string{"pom.xml"}
+	case KindGradle:
+		return []string{"build.gradle", "build.gradle.kts"}
+	case KindNPM:
+		return []string{"package.json"}
+	case KindGo:
+		return []string{"go.mod"}
+	case KindChart:
+		return []string{"requirements.yaml"}
+	}
+	return nil
+}
+
+// UpdateVersion updates the version of the library in the build files of the kind in the directory and its sub
+// directories returning the files which were modified
+func UpdateVersion(dir string, kind Kind, library string, version string) ([]string, error) {
+	names := fileNames(kind)
+	if len(names) == 0 {
+		return nil, util.InvalidArg(string(kind), Kinds())
+	}
+	modified := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != dir && util.StringArrayIndex(skippedDirs, info.Name()) >= 0 {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if util.StringArrayIndex(names, info.Name()) < 0 {
+			return nil
+		}
+		changed, err := updateFile(path, kind, library, version)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %s", path, err)
+		}
+		if changed {
+			modified = append(modified, path)
+		}
+		return nil
+	})
+	return modified, err
+}
+
+func updateFile(fileName string, kind Kind, library string, version string) (bool, error) {
+	if kind == KindChart {
+		return updateRequirementsFile(fileName, library, version)
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return false, err
+	}
+	text := string(data)
+	var answer string
+	switch kind {
+	case KindMaven:
+		answer = UpdateMavenVersion(text, library, version)
+	case KindGradle:
+		answer = UpdateGradleVersion(text, library, version)
+	case KindNPM:
+		answer = UpdateNPMVersion(text, library, version)
+	case KindGo:
+		answer = UpdateGoModVersion(text, library, version)
+	}
+	if answer == text {
+		return false, nil
+	}
+	return true, ioutil.WriteFile(fileName, []byte(answer), util.DefaultWritePermissions)
+}
+
+func updateRequirementsFile(fileName string, library string, version string) (bool, error) {
+	requirements, err := helm.LoadRequirementsFile(fileName)
+	if err != nil {
+		return false, err
+	}
+	changed := false
+	for _, d := range requirements.Dependencies {
+		if d != nil && d.Name == library && d.Version != version {
+			d.Version = version
+			changed = true
+		}
+	}
+	if !changed {
+		return false, nil
+	}
+	return true, helm.SaveRequirementsFile(fileName, requirements)
+}
+
+// UpdateMavenVersion updates the version of the 'groupId:artifactId' dependency or parent in the text of a pom.xml.
+// If the version is a reference to a property the property is updated instead
+func UpdateMavenVersion(text string, library string, version string) string {
+	parts := strings.SplitN(library, ":", 2)
+	if len(parts) != 2 {
+		return text
+	}
+	groupRegex := regexp.MustCompile(`<groupId>\s*` + regexp.QuoteMeta(parts[0]) + `\s*</groupId>`)
+	artifactRegex := regexp.MustCompile(`<artifactId>\s*` + regexp.QuoteMeta(parts[1]) + `\s*</artifactId>`)
+	properties := []string{}
+	answer := mavenBlockRegex.ReplaceAllStringFunc(text, func(block string) string {
+		if !groupRegex.MatchString(block) || !artifactRegex.MatchString(block) {
+			return block
+		}
+		match := mavenVersionRegex.FindStringSubmatch(block)
+		if match == nil {
+			return block
+		}
+		ref := mavenPropertyRef.FindStringSubmatch(match[1])
+		if ref != nil {
+			properties = append(properties, ref[1])
+			return block
+		}
+		return strings.Replace(block, match[0], "<version>"+version+"</version>", 1)
+	})
+	for _, property := range properties {
+		propertyRegex := regexp.MustCompile(`<` + regexp.QuoteMeta(property) + `>[^<]*</` + regexp.QuoteMeta(property) + `>`)
+		answer = propertyRegex.ReplaceAllString(answer, "<"+property+">"+version+"</"+property+">")
+	}
+	return answer
+}
+
+// UpdateGradleVersion updates the version of the 'group:name' dependency in the text of a gradle build file using
+// either the 'group:name:version' string notation or the map notation
+func UpdateGradleVersion(text string, library string, version string) string {
+	parts := strings.SplitN(library, ":", 2)
+	if len(parts) != 2 {
+		return text
+	}
+	stringRegex := regexp.MustCompile(`(['"])` + regexp.QuoteMeta(library) + `:[^'":@]+(@[^'"]*)?(['"])`)
+	answer := stringRegex.ReplaceAllString(text, "${1}"+library+":"+version+"${2}${3}")
+	mapRegex := regexp.MustCompile(`(group\s*:\s*['"]` + regexp.QuoteMeta(parts[0]) + `['"]\s*,\s*name\s*:\s*['"]` + regexp.QuoteMeta(parts[1]) + `['"]\s*,\s*version\s*:\s*['"])[^'"]+(['"])`)
+	return mapRegex.ReplaceAllString(answer, "${1}"+version+"${2}")
+}
+
+// UpdateNPMVersion updates the version of the package in the text of a package.json keeping any caret, tilde or
+// minimum version prefix. Dependencies on git URLs, tags or local files are left untouched
+func UpdateNPMVersion(text string, library string, version string) string {
+	regex := regexp.MustCompile(`("` + regexp.QuoteMeta(library) + `"\s*:\s*")([^"]*)(")`)
+	return regex.ReplaceAllStringFunc(text, func(entry string) string {
+		match := regex.FindStringSubmatch(entry)
+		current := match[2]
+		prefix := npmVersionRegex.FindStringSubmatch(current)
+		if prefix == nil {
+			return entry
+		}
+		return match[1] + prefix[1] + version + match[3]
+	})
+}
+
+// UpdateGoModVersion updates the required version of the module in the text of a go.mod
+func UpdateGoModVersion(text string, module string, version string) string {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+	regex := regexp.MustCompile(`(?m)^(\s*(?:require\s+)?` + regexp.QuoteMeta(module) + `\s+)v\S+`)
+	return regex.ReplaceAllString(text, "${1}"+version)
+}
@@ -0,0 +1,215 @@
+package dns
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+)
+
+const (
+	// DefaultTTL the time to live in seconds of the answers for the host names of the domain
+	DefaultTTL = 60
+
+	// DefaultUpstream the name server queries are forwarded to if none can be found in /etc/resolv.conf
+	DefaultUpstream = "8.8.8.8:53"
+
+	headerLength    = 12
+	typeA           = 1
+	classIN         = 1
+	maxMessageSize  = 4096
+	upstreamTimeout = 5 * time.Second
+)
+
+// Proxy is a DNS server which resolves the domain and all its sub domains to a single IP address, such as the node
+// of a local cluster, and forwards all other queries to an upstream name server
+type Proxy struct {
+	Domain   string
+	IP       net.IP
+	Upstream string
+	TTL      uint32
+}
+
+// NewProxy creates a DNS proxy resolving the domain to the IP address
+func NewProxy(domain string, ip string, upstream string) (*Proxy, error) {
+	address := net.ParseIP(ip).To4()
+	if address == nil {
+		return nil, fmt.Errorf("invalid IPv4 address %s", ip)
+	}
+	domain = strings.Trim(strings.ToLower(domain), ".")
+	if domain == "" {
+		return nil, fmt.Errorf("no domain specified")
+	}
+	if upstream == "" {
+		upstream = DefaultUpstreamNameServer("/etc/resolv.conf")
+	}
+	return &Proxy{
+		Domain:   domain,
+		IP:       address,
+		Upstream: upstream,
+		TTL:      DefaultTTL,
+	}, nil
+}
+
+// DefaultUpstreamNameServer returns the first name server of the resolv.conf file which is not a loopback address so
+// that the proxy does not forward queries to itself
+func DefaultUpstreamNameServer(resolvConf string) string {
+	f, err := os.Open(resolvConf)
+	if err != nil {
+		return DefaultUpstream
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "nameserver" {
+			continue
+		}
+		ip := net.ParseIP(fields[1])
+		if ip != nil && !ip.IsLoopback() {
+			return net.JoinHostPort(fields[1], "53")
+		}
+	}
+	return DefaultUpstream
+}
+
+// Matches returns true if the host name is the domain or one of its sub domains
+func (p *Proxy) Matches(name string) bool {
+	name = strings.Trim(strings.ToLower(name), ".")
+	return name == p.Domain || strings.HasSuffix(name, "."+p.Domain)
+}
+
+// Resolve returns the response to the query if it asks for a host name of the domain. False is returned if the
+// query should be forwarded to the upstream name server
+func (p *Proxy) Resolve(query []byte) ([]byte, bool, error) {
+	if len(query) < headerLength {
+		return nil, false, fmt.Errorf("the DNS message is too short")
+	}
+	if binary.BigEndian.Uint16(query[4:6]) != 1 {
+		// only queries with a single question are answered locally
+		return nil, false, nil
+	}
+	name, end, err := parseName(query, headerLength)
+	if err != nil {
+		return nil, false, err
+	}
+	if end+4 > len(query) {
+		return nil, false, fmt.Errorf("the DNS question is truncated")
+	}
+	if !p.Matches(name) {
+		return nil, false, nil
+	}
+	qtype := binary.BigEndian.Uint16(query[end : end+2])
+	qclass := binary.BigEndian.Uint16(query[end+2 : end+4])
+
+	response := make([]byte, end+4, end+4+16)
+	copy(response, query[0:end+4])
+	// set the response, authoritative answer and recursion available flags keeping the recursion desired flag
+	response[2] = 0x84 | (query[2] & 0x01)
+	response[3] = 0x80
+	binary.BigEndian.PutUint16(response[8:10], 0)
+	binary.BigEndian.PutUint16(response[10:12], 0)
+	if qtype != typeA || qclass != classIN {
+		// no other records exist for the host names so clients fall back to the A record
+		binary.BigEndian.PutUint16(response[6:8], 0)
+		return response, true, nil
+	}
+	binary.BigEndian.PutUint16(response[6:8], 1)
+	answer := make([]byte, 16)
+	// a pointer to the name of the question
+	binary.BigEndian.PutUint16(answer[0:2], 0xC000|headerLength)
+	binary.BigEndian.PutUint16(answer[2:4], typeA)
+	binary.BigEndian.PutUint16(answer[4:6], classIN)
+	binary.BigEndian.PutUint32(answer[6:10], p.TTL)
+	binary.BigEndian.PutUint16(answer[10:12], 4)
+	copy(answer[12:16], p.IP)
+	return append(response, answer...), true, nil
+}
+
+// ListenAndServe answers the DNS queries received on the UDP address until an error occurs
+func (p *Proxy) ListenAndServe(address string) error {
+	conn, err := net.ListenPacket("udp", address)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %s", address, err)
+	}
+	defer conn.Close()
+	for {
+		buffer := make([]byte, maxMessageSize)
+		n, addr, err := conn.ReadFrom(buffer)
+		if err != nil {
+			return err
+		}
+		go p.handle(conn, addr, buffer[0:n])
+	}
+}
+
+func (p *Proxy) handle(conn net.PacketConn, addr net.Addr, query []byte) {
+	response, ok, err := p.Resolve(query)
+	if err != nil {
+		log.Warnf("Ignoring invalid DNS query from %s: %s\n", addr, err)
+		return
+	}
+	if !ok {
+		response, err = p.forward(query)
+		if err != nil {
+			log.Warnf("%s\n", err)
+			return
+		}
+	}
+	_, err = conn.WriteTo(response, addr)
+	if err != nil {
+		log.Warnf("Failed to reply to %s: %s\n", addr, err)
+	}
+}
+
+// forward sends the query to the upstream name server and returns its response
+func (p *Proxy) forward(query []byte) ([]byte, error) {
+	conn, err := net.DialTimeout("udp", p.Upstream, upstreamTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the upstream name server %s: %s", p.Upstream, err)
+	}
+	defer conn.Close()
+	err = conn.SetDeadline(time.Now().Add(upstreamTimeout))
+	if err != nil {
+		return nil, err
+	}
+	_, err = conn.Write(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to forward the query to %s: %s", p.Upstream, err)
+	}
+	buffer := make([]byte, maxMessageSize)
+	n, err := conn.Read(buffer)
+	if err != nil {
+		return nil, fmt.Errorf("no response from the upstream name server %s: %s", p.Upstream, err)
+	}
+	return buffer[0:n], nil
+}
+
+// parseName parses the uncompressed name of a question returning it and the offset of the end of the name
+func parseName(message []byte, offset int) (string, int, error) {
+	labels := []string{}
+	for {
+		if offset >= len(message) {
+			return "", 0, fmt.Errorf("the DNS name is truncated")
+		}
+		length := int(message[offset])
+		offset++
+		if length == 0 {
+			break
+		}
+		if length&0xC0 != 0 {
+			return "", 0, fmt.Errorf("compressed names are not supported in questions")
+		}
+		if offset+length > len(message) {
+			return "", 0, fmt.Errorf("the DNS name is truncated")
+		}
+		labels = append(labels, string(message[offset:offset+length]))
+		offset += length
+	}
+	return strings.Join(labels, "."), offset, nil
+}
@@ -0,0 +1,73 @@
+package dns_test
+
+import (
+	"encoding/binary"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/dns"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// query returns a DNS query with recursion desired for a single question
+func query(name string, qtype uint16) []byte {
+	message := []byte{0x12, 0x34, 0x01, 0x00, 0, 1, 0, 0, 0, 0, 0, 0}
+	for _, label := range strings.Split(name, ".") {
+		message = append(message, byte(len(label)))
+		message = append(message, label...)
+	}
+	message = append(message, 0, 0, 0, 0, 1)
+	binary.BigEndian.PutUint16(message[len(message)-4:], qtype)
+	return message
+}
+
+func TestProxyResolve(t *testing.T) {
+	t.Parallel()
+	proxy, err := dns.NewProxy("Example.Local.", "192.168.99.100", "10.0.0.53:53")
+	require.NoError(t, err)
+	assert.Equal(t, "example.local", proxy.Domain)
+	assert.True(t, proxy.Matches("jenkins.jx.example.local."))
+	assert.True(t, proxy.Matches("EXAMPLE.local"))
+	assert.False(t, proxy.Matches("notexample.local"))
+
+	q := query("myapp.jx-staging.example.local", 1)
+	response, ok, err := proxy.Resolve(q)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, q[0:2], response[0:2], "the response should have the ID of the query")
+	assert.Equal(t, byte(0x85), response[2], "the response should be authoritative with recursion desired")
+	assert.Equal(t, uint16(1), binary.BigEndian.Uint16(response[6:8]), "answer count")
+	assert.Equal(t, len(q)+16, len(response))
+	assert.Equal(t, []byte{192, 168, 99, 100}, response[len(response)-4:])
+
+	response, ok, err = proxy.Resolve(query("myapp.jx-staging.example.local", 28))
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, uint16(0), binary.BigEndian.Uint16(response[6:8]), "no AAAA records should be returned")
+
+	_, ok, err = proxy.Resolve(query("github.com", 1))
+	require.NoError(t, err)
+	assert.False(t, ok, "other domains should be forwarded")
+
+	_, _, err = proxy.Resolve([]byte{0x12, 0x34})
+	assert.Error(t, err)
+
+	_, err = dns.NewProxy("example.local", "not-an-ip", "")
+	assert.Error(t, err)
+}
+
+func TestDefaultUpstreamNameServer(t *testing.T) {
+	t.Parallel()
+	f, err := ioutil.TempFile("", "resolv-conf-")
+	require.NoError(t, err)
+	defer os.Remove(f.Name())
+	_, err = f.WriteString("# generated\nnameserver 127.0.0.53\nnameserver 10.0.0.2\nsearch example.com\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	assert.Equal(t, "10.0.0.2:53", dns.DefaultUpstreamNameServer(f.Name()))
+	assert.Equal(t, dns.DefaultUpstream, dns.DefaultUpstreamNameServer(f.Name()+"-missing"))
+}
@@ -0,0 +1,92 @@
+package features
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// EnvVarPrefix the prefix of the environment variables which enable or disable an experimental feature
+	// regardless of the settings of the team such as JX_EXPERIMENT_HELM3=true
+	EnvVarPrefix = "JX_EXPERIMENT_"
+)
+
+// Feature an experimental feature which has to be enabled before it can be used
+type Feature struct {
+	Name        string
+	Description string
+}
+
+var registry = map[string]*Feature{}
+
+// Register registers an experimental feature. Features are registered by the package variables of the code paths
+// they gate so that all the experiments are listed by 'jx experiments list'
+func Register(name string, description string) *Feature {
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("the experimental feature %s is registered more than once", name))
+	}
+	f := &Feature{
+		Name:        name,
+		Description: description,
+	}
+	registry[name] = f
+	return f
+}
+
+// All returns the registered experimental features sorted by name
+func All() []*Feature {
+	answer := []*Feature{}
+	for _, f := range registry {
+		answer = append(answer, f)
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Name < answer[j].Name
+	})
+	return answer
+}
+
+// Names returns the names of the registered experimental features
+func Names() []string {
+	answer := []string{}
+	for _, f := range All() {
+		answer = append(answer, f.Name)
+	}
+	return answer
+}
+
+// Lookup returns the registered experimental feature of the given name
+func Lookup(name string) (*Feature, error) {
+	f, ok := registry[name]
+	if !ok {
+		return nil, util.InvalidArg(name, Names())
+	}
+	return f, nil
+}
+
+// EnvVar returns the name of the environment variable which enables or disables the feature
+func (f *Feature) EnvVar() string {
+	return EnvVarPrefix + strings.ToUpper(strings.Replace(f.Name, "-", "_", -1))
+}
+
+// Enabled returns true if the feature is enabled by its environment variable or, if the environment variable is not
+// set, by the experiments enabled for the team
+func (f *Feature) Enabled(teamExperiments []string) bool {
+	value := os.Getenv(f.EnvVar())
+	if value != "" {
+		return value == "true"
+	}
+	return util.StringArrayIndex(teamExperiments, f.Name) >= 0
+}
+
+// RequireEnabled returns an error describing how to enable the feature if it is not enabled
+func (f *Feature) RequireEnabled(teamExperiments []string) error {
+	if f.Enabled(teamExperiments) {
+		return nil
+	}
+	return util.InvalidInputError(fmt.Errorf("%s is experimental. Enable it for your team via 'jx experiments enable %s' or by setting $%s=true",
+		f.Description, f.Name, f.EnvVar()))
+}
@@ -0,0 +1,43 @@
+package features_test
+
+import (
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/features"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testFeature = features.Register("test-feature", "Testing experiments")
+
+func TestFeatureEnabled(t *testing.T) {
+	envVar := testFeature.EnvVar()
+	assert.Equal(t, "JX_EXPERIMENT_TEST_FEATURE", envVar)
+	defer os.Unsetenv(envVar)
+
+	assert.False(t, testFeature.Enabled(nil))
+	assert.True(t, testFeature.Enabled([]string{"other", "test-feature"}))
+	err := testFeature.RequireEnabled(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jx experiments enable test-feature")
+
+	os.Setenv(envVar, "false")
+	assert.False(t, testFeature.Enabled([]string{"test-feature"}), "the environment variable should override the team")
+
+	os.Setenv(envVar, "true")
+	assert.True(t, testFeature.Enabled(nil))
+	assert.NoError(t, testFeature.RequireEnabled(nil))
+}
+
+func TestLookup(t *testing.T) {
+	t.Parallel()
+	f, err := features.Lookup("test-feature")
+	require.NoError(t, err)
+	assert.Equal(t, testFeature, f)
+	assert.Contains(t, features.Names(), "test-feature")
+
+	_, err = features.Lookup("test-featur")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Did you mean: test-feature")
+}
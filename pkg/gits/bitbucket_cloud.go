@@ -12,6 +12,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/util"
 
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/wbrefvem/go-bitbucket"
 )
@@ -52,6 +53,7 @@ func NewBitbucketCloudProvider(server *auth.AuthServer, user *auth.UserAuth, git
 	}
 
 	cfg := bitbucket.NewConfiguration()
+	cfg.HTTPClient = httpclient.NewClient(nil)
 	provider.Client = bitbucket.NewAPIClient(cfg)
 
 	return &provider, nil
@@ -13,6 +13,7 @@ import (
 
 	bitbucket "github.com/gfleury/go-bitbucket-v1"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 )
@@ -80,6 +81,7 @@ func NewBitbucketServerProvider(server *auth.AuthServer, user *auth.UserAuth, gi
 	}
 
 	cfg := bitbucket.NewConfiguration(server.URL + "/rest")
+	cfg.HTTPClient = httpclient.NewClient(nil)
 	provider.Client = bitbucket.NewAPIClient(apiKeyAuthContext, cfg)
 
 	return &provider, nil
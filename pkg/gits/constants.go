@@ -9,6 +9,9 @@ const (
 	KindUnknown         = "unknown"
 
 	BitbucketCloudURL = "https://bitbucket.org"
+
+	// ReviewStateApproved the state of a pull request review which approves the changes
+	ReviewStateApproved = "APPROVED"
 )
 
 var (
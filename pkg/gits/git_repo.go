@@ -172,7 +172,7 @@ func PickNewOrExistingGitRepository(out io.Writer, batchMode bool, authConfigSvc
 			}
 			return provider.ValidateRepositoryName(owner, str)
 		}
-		err = survey.AskOne(prompt, &repoName, validator)
+		err = util.AskOne(prompt, &repoName, validator)
 		if err != nil {
 			return nil, err
 		}
@@ -9,6 +9,7 @@ import (
 	"code.gitea.io/sdk/gitea"
 	"github.com/google/go-github/github"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 )
@@ -24,6 +25,7 @@ type GiteaProvider struct {
 
 func NewGiteaProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter) (GitProvider, error) {
 	client := gitea.NewClient(server.URL, user.ApiToken)
+	client.SetHTTPClient(httpclient.NewClient(nil))
 
 	provider := GiteaProvider{
 		Client:   client,
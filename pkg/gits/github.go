@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/go-github/github"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"golang.org/x/oauth2"
@@ -40,18 +41,24 @@ func NewGitHubProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter)
 		Git:      git,
 	}
 
+	httpClient, err := util.NewHTTPClientWithCAFile(server.CAFile)
+	if err != nil {
+		return nil, err
+	}
+	httpClient.Transport = &gitHubRateLimitTransport{
+		base:    httpclient.NewTransport(httpClient.Transport),
+		maxWait: gitHubRateLimitMaxWait,
+	}
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: user.ApiToken},
 	)
-	tc := oauth2.NewClient(ctx, ts)
+	tc := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, httpClient), ts)
 
-	var err error
-	u := server.URL
-	if IsGitHubServerURL(u) {
+	u := GitHubAPIURL(server)
+	if u == "" {
 		provider.Client = github.NewClient(tc)
 	} else {
-		u = GitHubEnterpriseApiEndpointURL(u)
-		provider.Client, err = github.NewEnterpriseClient(u, u, tc)
+		provider.Client, err = github.NewEnterpriseClient(u, GitHubEnterpriseUploadURL(u), tc)
 	}
 	return &provider, err
 }
@@ -67,14 +74,30 @@ func GitHubEnterpriseApiEndpointURL(u string) string {
 	return u
 }
 
+// GitHubEnterpriseUploadURL returns the URL release assets are uploaded to for the API URL of a GitHub Enterprise server
+func GitHubEnterpriseUploadURL(apiURL string) string {
+	if strings.Contains(apiURL, "/api/v3") {
+		return strings.Replace(apiURL, "/api/v3", "/api/uploads", 1)
+	}
+	return apiURL
+}
+
+// GitHubAPIURL returns the API URL of a GitHub Enterprise server using the configured API URL of the server if
+// there is one. Blank is returned for the https://github.com service
+func GitHubAPIURL(server *auth.AuthServer) string {
+	if server.ApiURL != "" {
+		return server.ApiURL
+	}
+	if IsGitHubServerURL(server.URL) {
+		return ""
+	}
+	return GitHubEnterpriseApiEndpointURL(server.URL)
+}
+
 // GetEnterpriseApiURL returns the github enterprise API URL or blank if this
 // provider is for the https://github.com service
 func (p *GitHubProvider) GetEnterpriseApiURL() string {
-	u := p.Server.URL
-	if IsGitHubServerURL(u) {
-		return ""
-	}
-	return GitHubEnterpriseApiEndpointURL(u)
+	return GitHubAPIURL(&p.Server)
 }
 
 func IsGitHubServerURL(u string) bool {
@@ -532,6 +555,86 @@ func (p *GitHubProvider) AddPRComment(pr *GitPullRequest, comment string) error
 	return nil
 }
 
+// ListWebHooks returns the webhooks of the repository
+func (p *GitHubProvider) ListWebHooks(owner string, repo string) ([]*GitWebHook, error) {
+	answer := []*GitWebHook{}
+	opts := &github.ListOptions{PerPage: pageSize}
+	for {
+		hooks, resp, err := p.Client.Repositories.ListHooks(p.Context, owner, repo, opts)
+		if err != nil {
+			return answer, err
+		}
+		for _, hook := range hooks {
+			u, _ := hook.Config["url"].(string)
+			answer = append(answer, &GitWebHook{ID: hook.GetID(), URL: u})
+		}
+		if resp.NextPage == 0 {
+			return answer, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// DeleteWebHook deletes the webhook from the repository
+func (p *GitHubProvider) DeleteWebHook(owner string, repo string, hook *GitWebHook) error {
+	log.Infof("Deleting github webhook for %s/%s for url %s\n", owner, repo, hook.URL)
+	_, err := p.Client.Repositories.DeleteHook(p.Context, owner, repo, hook.ID)
+	return err
+}
+
+// ListPullRequestReviews returns the reviews of the pull request
+func (p *GitHubProvider) ListPullRequestReviews(pr *GitPullRequest) ([]*GitPullRequestReview, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	answer := []*GitPullRequestReview{}
+	opt := &github.ListOptions{PerPage: pageSize}
+	for {
+		reviews, resp, err := p.Client.PullRequests.ListReviews(p.Context, pr.Owner, pr.Repo, *pr.Number, opt)
+		if err != nil {
+			return answer, err
+		}
+		for _, review := range reviews {
+			answer = append(answer, &GitPullRequestReview{
+				User:        toGitHubUser(review.User),
+				State:       review.GetState(),
+				CommitSha:   review.GetCommitID(),
+				SubmittedAt: review.SubmittedAt,
+			})
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return answer, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// ListPullRequestComments returns the comments of the pull request
+func (p *GitHubProvider) ListPullRequestComments(pr *GitPullRequest) ([]*GitPullRequestComment, error) {
+	if pr.Number == nil {
+		return nil, fmt.Errorf("Missing Number for GitPullRequest %#v", pr)
+	}
+	answer := []*GitPullRequestComment{}
+	opt := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: pageSize}}
+	for {
+		comments, resp, err := p.Client.Issues.ListComments(p.Context, pr.Owner, pr.Repo, *pr.Number, opt)
+		if err != nil {
+			return answer, err
+		}
+		for _, comment := range comments {
+			answer = append(answer, &GitPullRequestComment{
+				User:      toGitHubUser(comment.User),
+				Body:      comment.GetBody(),
+				CreatedAt: comment.CreatedAt,
+			})
+		}
+		if resp == nil || resp.NextPage == 0 {
+			return answer, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
 func (p *GitHubProvider) CreateIssueComment(owner string, repo string, number int, comment string) error {
 	issueComment := &github.IssueComment{
 		Body: &comment,
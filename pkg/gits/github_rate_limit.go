@@ -0,0 +1,72 @@
+package gits
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+)
+
+// gitHubRateLimitMaxWait the longest time a request waits for the API rate limit to reset before failing
+const gitHubRateLimitMaxWait = 5 * time.Minute
+
+// gitHubRateLimitTransport retries requests rejected because the rate limit of the GitHub API is exhausted once the
+// limit resets. GitHub Enterprise servers with rate limiting disabled never reject requests so are unaffected
+type gitHubRateLimitTransport struct {
+	base    http.RoundTripper
+	maxWait time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *gitHubRateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	wait, limited := GitHubRateLimitWait(resp, time.Now())
+	if !limited || wait > t.maxWait {
+		return resp, nil
+	}
+	retry := *req
+	if req.Body != nil {
+		if req.GetBody == nil {
+			return resp, nil
+		}
+		retry.Body, err = req.GetBody()
+		if err != nil {
+			return resp, nil
+		}
+	}
+	resp.Body.Close()
+	log.Warnf("The GitHub API rate limit of %s is exhausted so waiting %s for it to reset\n", req.URL.Host, wait)
+	time.Sleep(wait)
+	return t.base.RoundTrip(&retry)
+}
+
+// GitHubRateLimitWait returns how long to wait before retrying a request which was rejected due to either the
+// primary rate limit or the abuse rate limit of the GitHub API. False is returned if the request was not rate limited
+func GitHubRateLimitWait(resp *http.Response, now time.Time) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter != "" {
+		seconds, err := strconv.Atoi(retryAfter)
+		if err == nil && seconds >= 0 {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	wait := time.Unix(reset, 0).Sub(now) + time.Second
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
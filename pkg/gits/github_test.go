@@ -0,0 +1,60 @@
+package gits_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubAPIURL(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "", gits.GitHubAPIURL(&auth.AuthServer{URL: "https://github.com"}))
+	assert.Equal(t, "https://github.acme.com/api/v3/", gits.GitHubAPIURL(&auth.AuthServer{URL: "https://github.acme.com"}))
+	assert.Equal(t, "https://api.github.acme.com/", gits.GitHubAPIURL(&auth.AuthServer{URL: "https://github.acme.com", ApiURL: "https://api.github.acme.com/"}))
+
+	assert.Equal(t, "https://github.acme.com/api/uploads/", gits.GitHubEnterpriseUploadURL("https://github.acme.com/api/v3/"))
+	assert.Equal(t, "https://api.github.acme.com/", gits.GitHubEnterpriseUploadURL("https://api.github.acme.com/"))
+}
+
+func TestGitHubProviderUsesEnterpriseAPIURL(t *testing.T) {
+	t.Parallel()
+	server := &auth.AuthServer{URL: "https://github.acme.com", Kind: gits.KindGitHub}
+	provider, err := gits.NewGitHubProvider(server, &auth.UserAuth{Username: "bot", ApiToken: "token"}, nil)
+	require.NoError(t, err)
+	github := provider.(*gits.GitHubProvider)
+	assert.Equal(t, "https://github.acme.com/api/v3/", github.Client.BaseURL.String())
+	assert.Equal(t, "https://github.acme.com/api/uploads/", github.Client.UploadURL.String())
+
+	_, err = gits.NewGitHubProvider(&auth.AuthServer{URL: "https://github.acme.com", CAFile: "does-not-exist.pem"}, &auth.UserAuth{}, nil)
+	assert.Error(t, err)
+}
+
+func TestGitHubRateLimitWait(t *testing.T) {
+	t.Parallel()
+	now := time.Unix(1000000, 0)
+
+	resp := &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("X-RateLimit-Remaining", "0")
+	resp.Header.Set("X-RateLimit-Reset", strconv.FormatInt(now.Add(time.Minute).Unix(), 10))
+	wait, limited := gits.GitHubRateLimitWait(resp, now)
+	assert.True(t, limited)
+	assert.Equal(t, time.Minute+time.Second, wait)
+
+	resp = &http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}
+	resp.Header.Set("Retry-After", "30")
+	wait, limited = gits.GitHubRateLimitWait(resp, now)
+	assert.True(t, limited)
+	assert.Equal(t, 30*time.Second, wait)
+
+	_, limited = gits.GitHubRateLimitWait(&http.Response{StatusCode: http.StatusForbidden, Header: http.Header{}}, now)
+	assert.False(t, limited, "a permission error of a GitHub Enterprise server without rate limits should not be retried")
+
+	_, limited = gits.GitHubRateLimitWait(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, now)
+	assert.False(t, limited)
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/google/go-github/github"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/xanzy/go-gitlab"
@@ -26,7 +27,7 @@ type GitlabProvider struct {
 
 func NewGitlabProvider(server *auth.AuthServer, user *auth.UserAuth, git Gitter) (GitProvider, error) {
 	u := server.URL
-	c := gitlab.NewClient(nil, user.ApiToken)
+	c := gitlab.NewClient(httpclient.NewClient(nil), user.ApiToken)
 	if !IsGitLabServerURL(u) {
 		if err := c.SetBaseURL(u); err != nil {
 			return nil, err
@@ -129,6 +129,20 @@ type GitProvider interface {
 	AcceptInvitation(int64) (*github.Response, error)
 }
 
+// PullRequestReviewLister is implemented by git providers which can list the reviews and comments of a pull request
+type PullRequestReviewLister interface {
+	ListPullRequestReviews(pr *GitPullRequest) ([]*GitPullRequestReview, error)
+
+	ListPullRequestComments(pr *GitPullRequest) ([]*GitPullRequestComment, error)
+}
+
+// WebHookManager is implemented by git providers which can list and delete the webhooks of a repository
+type WebHookManager interface {
+	ListWebHooks(owner string, repo string) ([]*GitWebHook, error)
+
+	DeleteWebHook(owner string, repo string, hook *GitWebHook) error
+}
+
 // Gitter defines common git actions used by Jenkins X via git cli
 //go:generate pegomock generate github.com/jenkins-x/jx/pkg/gits Gitter -o mocks/gitter.go
 type Gitter interface {
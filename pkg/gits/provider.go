@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/util"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
 
@@ -47,6 +48,26 @@ type GitPullRequest struct {
 	Body           string
 }
 
+// GitPullRequestReview a review of a pull request
+type GitPullRequestReview struct {
+	User        *GitUser
+	State       string
+	CommitSha   string
+	SubmittedAt *time.Time
+}
+
+// IsApproved returns true if the review approves the pull request
+func (r *GitPullRequestReview) IsApproved() bool {
+	return strings.ToUpper(r.State) == ReviewStateApproved
+}
+
+// GitPullRequestComment a comment on a pull request
+type GitPullRequestComment struct {
+	User      *GitUser
+	Body      string
+	CreatedAt *time.Time
+}
+
 type GitCommit struct {
 	SHA       string
 	Message   string
@@ -124,6 +145,12 @@ type GitPullRequestArguments struct {
 	GitRepositoryInfo *GitRepositoryInfo
 }
 
+// GitWebHook a webhook registered on a git repository
+type GitWebHook struct {
+	ID  int64
+	URL string
+}
+
 type GitWebHookArguments struct {
 	Owner  string
 	Repo   *GitRepositoryInfo
@@ -192,7 +219,7 @@ func PickOrganisation(orgLister OrganisationLister, userName string) (string, er
 	}
 
 	orgName := ""
-	err := survey.AskOne(prompt, &orgName, nil)
+	err := util.AskOne(prompt, &orgName, nil)
 	if err != nil {
 		return "", err
 	}
@@ -246,7 +273,7 @@ func PickRepositories(provider GitProvider, owner string, message string, select
 		prompt.Default = allRepoNames
 	}
 	repoNames := []string{}
-	err = survey.AskOne(prompt, &repoNames, nil)
+	err = util.AskOne(prompt, &repoNames, nil)
 
 	for _, n := range repoNames {
 		repo := repoMap[n]
@@ -44,6 +44,8 @@ type FakePullRequest struct {
 	PullRequest *GitPullRequest
 	Commits     []*FakeCommit
 	Comment     string
+	Reviews     []*GitPullRequestReview
+	Comments    []*GitPullRequestComment
 }
 
 type FakeIssue struct {
@@ -60,6 +62,7 @@ type FakeRepository struct {
 	issueCount         int
 	Releases           map[string]*GitRelease
 	PullRequestCounter int
+	WebHooks           []*GitWebHook
 }
 
 type FakeProvider struct {
@@ -327,9 +330,51 @@ func (f *FakeProvider) MergePullRequest(pr *GitPullRequest, message string) erro
 }
 
 func (f *FakeProvider) CreateWebHook(data *GitWebHookArguments) error {
+	repo, err := f.findRepository(data.Owner, data.Repo.Name)
+	if err != nil {
+		// webhooks are only recorded for repositories the fake knows about
+		return nil
+	}
+	for _, hook := range repo.WebHooks {
+		if hook.URL == data.URL {
+			return nil
+		}
+	}
+	repo.WebHooks = append(repo.WebHooks, &GitWebHook{ID: int64(len(repo.WebHooks) + 1), URL: data.URL})
 	return nil
 }
 
+func (f *FakeProvider) ListWebHooks(owner string, repo string) ([]*GitWebHook, error) {
+	r, err := f.findRepository(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return append([]*GitWebHook{}, r.WebHooks...), nil
+}
+
+func (f *FakeProvider) DeleteWebHook(owner string, repo string, hook *GitWebHook) error {
+	r, err := f.findRepository(owner, repo)
+	if err != nil {
+		return err
+	}
+	for i, h := range r.WebHooks {
+		if h.ID == hook.ID {
+			r.WebHooks = append(r.WebHooks[:i], r.WebHooks[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("webhook %d not found in repository '%s/%s'", hook.ID, owner, repo)
+}
+
+func (f *FakeProvider) findRepository(org string, name string) (*FakeRepository, error) {
+	for _, repo := range f.Repositories[org] {
+		if repo.GitRepo.Name == name {
+			return repo, nil
+		}
+	}
+	return nil, fmt.Errorf("repository '%s' not found within the organization '%s'", name, org)
+}
+
 func (f *FakeProvider) IsGitHub() bool {
 	return f.Type == GitHub
 }
@@ -476,6 +521,42 @@ func (f *FakeProvider) AddPRComment(pr *GitPullRequest, comment string) error {
 	return fmt.Errorf("repository with name '%s' not found", repoName)
 }
 
+func (f *FakeProvider) findPullRequest(pr *GitPullRequest) (*FakePullRequest, error) {
+	repos, ok := f.Repositories[pr.Owner]
+	if !ok {
+		return nil, fmt.Errorf("no repositories found for '%s'", pr.Owner)
+	}
+	if pr.Number == nil {
+		return nil, fmt.Errorf("missing number for pull request %s", pr.URL)
+	}
+	for _, r := range repos {
+		if r.GitRepo.Name == pr.Repo {
+			answer, ok := r.PullRequests[*pr.Number]
+			if !ok {
+				return nil, fmt.Errorf("pull request with id '%d' not found", *pr.Number)
+			}
+			return answer, nil
+		}
+	}
+	return nil, fmt.Errorf("repository with name '%s' not found", pr.Repo)
+}
+
+func (f *FakeProvider) ListPullRequestReviews(pr *GitPullRequest) ([]*GitPullRequestReview, error) {
+	fakePR, err := f.findPullRequest(pr)
+	if err != nil {
+		return nil, err
+	}
+	return fakePR.Reviews, nil
+}
+
+func (f *FakeProvider) ListPullRequestComments(pr *GitPullRequest) ([]*GitPullRequestComment, error) {
+	fakePR, err := f.findPullRequest(pr)
+	if err != nil {
+		return nil, err
+	}
+	return fakePR.Comments, nil
+}
+
 func (f *FakeProvider) CreateIssueComment(owner string, repoName string, number int, comment string) error {
 	repos, ok := f.Repositories[owner]
 	if !ok {
@@ -0,0 +1,119 @@
+package helm
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// ChartVersion a version of a chart stored in ChartMuseum
+type ChartVersion struct {
+	Name    string    `json:"name"`
+	Version string    `json:"version"`
+	Created time.Time `json:"created"`
+}
+
+// ChartRetentionPolicy describes which chart versions are kept in ChartMuseum
+type ChartRetentionPolicy struct {
+	// MaxAge chart versions created longer ago than this are removed. 0 disables removal by age
+	MaxAge time.Duration
+	// MaxVersions the maximum number of versions to keep for each chart. 0 disables removal by count
+	MaxVersions int
+}
+
+// ChartMuseumClient talks to the ChartMuseum REST API
+type ChartMuseumClient struct {
+	URL        string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// NewChartMuseumClient creates a new client for the ChartMuseum at the given URL
+func NewChartMuseumClient(url string, username string, password string) *ChartMuseumClient {
+	return &ChartMuseumClient{
+		URL:        strings.TrimSuffix(url, "/"),
+		Username:   username,
+		Password:   password,
+		HTTPClient: httpclient.NewBaseClient(),
+	}
+}
+
+// ListCharts returns all the versions of all the charts in ChartMuseum indexed by chart name
+func (c *ChartMuseumClient) ListCharts() (map[string][]ChartVersion, error) {
+	u := util.UrlJoin(c.URL, "/api/charts")
+	data, err := c.do(http.MethodGet, u)
+	if err != nil {
+		return nil, err
+	}
+	answer := map[string][]ChartVersion{}
+	err = json.Unmarshal(data, &answer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the charts returned from %s: %s", u, err)
+	}
+	return answer, nil
+}
+
+// DeleteChartVersion removes the chart version from ChartMuseum
+func (c *ChartMuseumClient) DeleteChartVersion(name string, version string) error {
+	_, err := c.do(http.MethodDelete, util.UrlJoin(c.URL, "/api/charts", name, version))
+	return err
+}
+
+func (c *ChartMuseumClient) do(method string, u string) ([]byte, error) {
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if c.Username != "" {
+		req.SetBasicAuth(c.Username, c.Password)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke %s %s: %s", method, u, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to invoke %s %s due to response %d: %s", method, u, resp.StatusCode, string(data))
+	}
+	return data, nil
+}
+
+// FindChartVersionsToDelete returns the chart versions which are not retained by the policy. The latest version of
+// each chart and any versions in the protected map of chart name to versions are always kept
+func FindChartVersionsToDelete(charts map[string][]ChartVersion, policy *ChartRetentionPolicy, protected map[string]map[string]bool, now time.Time) []ChartVersion {
+	names := []string{}
+	for name := range charts {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	answer := []ChartVersion{}
+	for _, name := range names {
+		versions := append([]ChartVersion{}, charts[name]...)
+		sort.SliceStable(versions, func(i, j int) bool {
+			return versions[i].Created.After(versions[j].Created)
+		})
+		for i, v := range versions {
+			if i == 0 || protected[name][v.Version] {
+				continue
+			}
+			tooOld := policy.MaxAge > 0 && now.Sub(v.Created) > policy.MaxAge
+			tooMany := policy.MaxVersions > 0 && i >= policy.MaxVersions
+			if tooOld || tooMany {
+				answer = append(answer, v)
+			}
+		}
+	}
+	return answer
+}
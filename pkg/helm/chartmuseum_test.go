@@ -0,0 +1,41 @@
+package helm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindChartVersionsToDelete(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2018, 10, 1, 0, 0, 0, 0, time.UTC)
+	daysAgo := func(days int) time.Time {
+		return now.Add(-time.Duration(days) * 24 * time.Hour)
+	}
+	charts := map[string][]helm.ChartVersion{
+		"myapp": {
+			{Name: "myapp", Version: "0.0.1", Created: daysAgo(100)},
+			{Name: "myapp", Version: "0.0.4", Created: daysAgo(1)},
+			{Name: "myapp", Version: "0.0.3", Created: daysAgo(5)},
+			{Name: "myapp", Version: "0.0.2", Created: daysAgo(50)},
+		},
+		"old": {
+			{Name: "old", Version: "1.0.0", Created: daysAgo(300)},
+		},
+	}
+	protected := map[string]map[string]bool{
+		"myapp": {"0.0.1": true},
+	}
+
+	actual := helm.FindChartVersionsToDelete(charts, &helm.ChartRetentionPolicy{MaxAge: 30 * 24 * time.Hour}, protected, now)
+	assert.Equal(t, []helm.ChartVersion{charts["myapp"][3]}, actual)
+
+	actual = helm.FindChartVersionsToDelete(charts, &helm.ChartRetentionPolicy{MaxVersions: 2}, nil, now)
+	assert.Equal(t, []helm.ChartVersion{charts["myapp"][3], charts["myapp"][0]}, actual)
+
+	actual = helm.FindChartVersionsToDelete(charts, &helm.ChartRetentionPolicy{}, nil, now)
+	assert.Empty(t, actual)
+}
@@ -17,6 +17,11 @@ type HelmCLI struct {
 	BinVersion Version
 	CWD        string
 	Runner     *util.Command
+	TLS        *TLSFiles
+	// Atomic rolls back a release whose install or upgrade fails
+	Atomic bool
+	// RepoCAFile the PEM file of the certificate authorities used to verify the TLS certificates of added repositories
+	RepoCAFile string
 }
 
 // NewHelmCLI creates a new HelmCLI instance configured to used the provided helm CLI in
@@ -51,6 +56,33 @@ func (h *HelmCLI) SetHost(tillerAddress string) {
 	h.Runner.Env["HELM_HOST"] = tillerAddress
 }
 
+// SetAtomic configures whether failed installs and upgrades are rolled back
+func (h *HelmCLI) SetAtomic(atomic bool) {
+	h.Atomic = atomic
+}
+
+// SetTillerNamespace configures the namespace of the tiller the helm CLI connects to
+func (h *HelmCLI) SetTillerNamespace(ns string) {
+	if h.Runner.Env == nil {
+		h.Runner.Env = map[string]string{}
+	}
+	h.Runner.Env["TILLER_NAMESPACE"] = ns
+}
+
+// SetTLS configures the helm CLI to connect to tiller using mutual TLS. If the files include a tiller certificate
+// then tiller is also configured to verify client certificates when it is installed
+func (h *HelmCLI) SetTLS(files *TLSFiles) {
+	h.TLS = files
+	if h.Runner.Env == nil {
+		h.Runner.Env = map[string]string{}
+	}
+	h.Runner.Env["HELM_TLS_ENABLE"] = "true"
+	h.Runner.Env["HELM_TLS_VERIFY"] = "true"
+	h.Runner.Env["HELM_TLS_CA_CERT"] = files.CACert
+	h.Runner.Env["HELM_TLS_CERT"] = files.ClientCert
+	h.Runner.Env["HELM_TLS_KEY"] = files.ClientKey
+}
+
 // SetCWD configures the common working directory of helm CLI
 func (h *HelmCLI) SetCWD(dir string) {
 	h.CWD = dir
@@ -94,15 +126,28 @@ func (h *HelmCLI) Init(clientOnly bool, serviceAccount string, tillerNamespace s
 	if tillerNamespace != "" {
 		args = append(args, "--tiller-namespace", tillerNamespace)
 	}
+	if !clientOnly && h.TLS != nil && h.TLS.TillerCert != "" {
+		args = append(args, "--tiller-tls", "--tiller-tls-verify", "--tiller-tls-cert", h.TLS.TillerCert,
+			"--tiller-tls-key", h.TLS.TillerKey, "--tls-ca-cert", h.TLS.CACert)
+	}
 	if upgrade {
 		args = append(args, "--upgrade", "--wait", "--force-upgrade")
 	}
 	return h.runHelm(args...)
 }
 
+// SetRepoCAFile configures the PEM file of the certificate authorities which verify the repositories which are added
+func (h *HelmCLI) SetRepoCAFile(caFile string) {
+	h.RepoCAFile = caFile
+}
+
 // AddRepo adds a new helm repo with the given name and URL
 func (h *HelmCLI) AddRepo(repo string, URL string) error {
-	return h.runHelm("repo", "add", repo, URL)
+	args := []string{"repo", "add", repo, URL}
+	if h.RepoCAFile != "" {
+		args = append(args, "--ca-file", h.RepoCAFile)
+	}
+	return h.runHelm(args...)
 }
 
 // RemoveRepo removes the given repo from helm
@@ -217,6 +262,9 @@ func (h *HelmCLI) InstallChart(chart string, releaseName string, ns string, vers
 	values []string, valueFiles []string) error {
 	args := []string{}
 	args = append(args, "install", "--wait", "--name", releaseName, "--namespace", ns, chart)
+	if h.Atomic {
+		args = append(args, "--atomic")
+	}
 	if timeout != nil {
 		args = append(args, "--timeout", strconv.Itoa(*timeout))
 	}
@@ -247,6 +295,9 @@ func (h *HelmCLI) UpgradeChart(chart string, releaseName string, ns string, vers
 	if force {
 		args = append(args, "--force")
 	}
+	if h.Atomic {
+		args = append(args, "--atomic")
+	}
 	if timeout != nil {
 		args = append(args, "--timeout", strconv.Itoa(*timeout))
 	}
@@ -274,6 +325,24 @@ func (h *HelmCLI) DeleteRelease(releaseName string, purge bool) error {
 	return h.runHelm(args...)
 }
 
+// TestRelease executes the helm test command running the test hooks of the release and returns its output
+func (h *HelmCLI) TestRelease(releaseName string, cleanup bool, timeout *int) (string, error) {
+	args := []string{"test", releaseName}
+	if cleanup {
+		args = append(args, "--cleanup")
+	}
+	if timeout != nil {
+		args = append(args, "--timeout", strconv.Itoa(*timeout))
+	}
+	return h.runHelmWithOutput(args...)
+}
+
+// RollbackRelease executes the helm rollback command rolling back the release to the revision or to the previous
+// revision if it is 0
+func (h *HelmCLI) RollbackRelease(releaseName string, revision int) error {
+	return h.runHelm("rollback", releaseName, strconv.Itoa(revision))
+}
+
 // ListCharts execute the helm list command and returns its output
 func (h *HelmCLI) ListCharts() (string, error) {
 	return h.runHelmWithOutput("list")
@@ -357,6 +426,20 @@ func (h *HelmCLI) StatusReleases() (map[string]string, error) {
 	return statusMap, nil
 }
 
+// Template renders the manifests of the given chart into the output directory without installing them
+func (h *HelmCLI) Template(chart string, releaseName string, ns string, outDir string, values []string, valueFiles []string) error {
+	args := []string{}
+	args = append(args, "template", "--name", releaseName, "--namespace", ns, "--output-dir", outDir)
+	for _, value := range values {
+		args = append(args, "--set", value)
+	}
+	for _, valueFile := range valueFiles {
+		args = append(args, "--values", valueFile)
+	}
+	args = append(args, chart)
+	return h.runHelm(args...)
+}
+
 // Lint lints the helm chart from the current working directory and returns the warnings in the output
 func (h *HelmCLI) Lint() (string, error) {
 	return h.runHelmWithOutput("lint")
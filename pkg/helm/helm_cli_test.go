@@ -99,6 +99,32 @@ func TestInit(t *testing.T) {
 	assert.NoError(t, err, "should init helm without any error")
 }
 
+func TestInitWithTLS(t *testing.T) {
+	setup("")
+	cli := helm.NewHelmCLI(binary, helm.V2, cwd)
+	files := &helm.TLSFiles{
+		CACert:     "ca.pem",
+		TillerCert: "tiller.cert.pem",
+		TillerKey:  "tiller.key.pem",
+		ClientCert: "cert.pem",
+		ClientKey:  "key.pem",
+	}
+	cli.SetTLS(files)
+	cli.SetTillerNamespace(namespace)
+
+	err := cli.Init(false, serviceAccount, namespace, false)
+	assert.NoError(t, err, "should init helm without any error")
+	expectedArgs := fmt.Sprintf("init --service-account %s --tiller-namespace %s --tiller-tls --tiller-tls-verify "+
+		"--tiller-tls-cert tiller.cert.pem --tiller-tls-key tiller.key.pem --tls-ca-cert ca.pem", serviceAccount, namespace)
+	assert.NoError(t, checkArgs(cli, cwd, binary, expectedArgs))
+
+	env := cli.Env()
+	assert.Equal(t, "true", env["HELM_TLS_ENABLE"])
+	assert.Equal(t, "cert.pem", env["HELM_TLS_CERT"])
+	assert.Equal(t, "key.pem", env["HELM_TLS_KEY"])
+	assert.Equal(t, namespace, env["TILLER_NAMESPACE"])
+}
+
 func TestAddRepo(t *testing.T) {
 	setup("")
 	expectedArgs := fmt.Sprintf("repo add %s %s", repo, repoURL)
@@ -107,6 +133,16 @@ func TestAddRepo(t *testing.T) {
 	err = helm.AddRepo(repo, repoURL)
 	assert.NoError(t, err, "should add helm repo without any error")
 }
+
+func TestAddRepoWithCAFile(t *testing.T) {
+	setup("")
+	expectedArgs := fmt.Sprintf("repo add %s %s --ca-file /etc/jx/ca-bundle/ca-bundle.crt", repo, repoURL)
+	helm, err := createHelm(expectedArgs)
+	assert.NoError(t, err, "should create helm without any error")
+	helm.SetRepoCAFile("/etc/jx/ca-bundle/ca-bundle.crt")
+	err = helm.AddRepo(repo, repoURL)
+	assert.NoError(t, err, "should add helm repo without any error")
+}
 func TestRemoveRepo(t *testing.T) {
 	setup("")
 	expectedArgs := fmt.Sprintf("repo remove %s", repo)
@@ -299,3 +335,24 @@ func TestPackage(t *testing.T) {
 	err = helm.PackageChart()
 	assert.NoError(t, err, "should package chart without any error")
 }
+
+func TestTestRelease(t *testing.T) {
+	expectedOutput := "PASSED: test-release-test"
+	setup(expectedOutput)
+	expectedArgs := fmt.Sprintf("test %s --cleanup --timeout 300", releaseName)
+	helm, err := createHelm(expectedArgs)
+	assert.NoError(t, err, "should create helm without any error")
+	timeout := 300
+	output, err := helm.TestRelease(releaseName, true, &timeout)
+	assert.NoError(t, err, "should test the helm chart release without any error")
+	assert.Equal(t, expectedOutput, output)
+}
+
+func TestRollbackRelease(t *testing.T) {
+	setup("")
+	expectedArgs := fmt.Sprintf("rollback %s 0", releaseName)
+	helm, err := createHelm(expectedArgs)
+	assert.NoError(t, err, "should create helm without any error")
+	err = helm.RollbackRelease(releaseName, 0)
+	assert.NoError(t, err, "should roll back the helm chart release without any error")
+}
@@ -5,7 +5,9 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"regexp"
 	"sort"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -236,3 +238,59 @@ func AppendMyValues(valueFiles []string) ([]string, error) {
 	}
 	return valueFiles, nil
 }
+
+var chartVersionRegex = regexp.MustCompile(`^(.+?)-(v?[0-9]+\.[0-9]+\.[0-9]+.*)$`)
+
+// ReleaseChartVersions parses the output of "helm list" returning the chart version of each release
+func ReleaseChartVersions(listOutput string) map[string]string {
+	answer := map[string]string{}
+	lines := strings.Split(strings.TrimSpace(listOutput), "\n")
+	if len(lines) < 2 {
+		return answer
+	}
+	for _, line := range lines[1:] {
+		fields := strings.Split(line, "\t")
+		if len(fields) > 4 {
+			release := strings.TrimSpace(fields[0])
+			matches := chartVersionRegex.FindStringSubmatch(strings.TrimSpace(fields[4]))
+			if release != "" && len(matches) == 3 {
+				answer[release] = matches[2]
+			}
+		}
+	}
+	return answer
+}
+
+// ReleaseTestResult the result of a test hook of a release
+type ReleaseTestResult struct {
+	Name    string
+	Passed  bool
+	Message string
+}
+
+// ParseReleaseTestResults parses the output of "helm test" returning the result of each test hook which completed
+func ParseReleaseTestResults(testOutput string) []ReleaseTestResult {
+	answer := []ReleaseTestResult{}
+	for _, line := range strings.Split(testOutput, "\n") {
+		line = strings.TrimSpace(line)
+		var passed bool
+		var text string
+		if strings.HasPrefix(line, "PASSED:") {
+			passed = true
+			text = strings.TrimPrefix(line, "PASSED:")
+		} else if strings.HasPrefix(line, "FAILED:") {
+			text = strings.TrimPrefix(line, "FAILED:")
+		} else {
+			continue
+		}
+		name := strings.TrimSpace(text)
+		message := ""
+		idx := strings.Index(name, ",")
+		if idx > 0 {
+			message = strings.TrimSpace(name[idx+1:])
+			name = name[:idx]
+		}
+		answer = append(answer, ReleaseTestResult{Name: name, Passed: passed, Message: message})
+	}
+	return answer
+}
@@ -0,0 +1,36 @@
+package helm_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReleaseChartVersions(t *testing.T) {
+	t.Parallel()
+	output := "NAME\tREVISION\tUPDATED\tSTATUS\tCHART\tNAMESPACE\n" +
+		"jenkins-x \t3\tMon Oct  1 10:00:00 2018\tDEPLOYED\tjenkins-x-platform-0.0.3300\tjx\n" +
+		"cert-manager\t1\tMon Oct  1 10:00:00 2018\tDEPLOYED\tcert-manager-v0.5.2\tcert-manager\n" +
+		"prow\t1\tMon Oct  1 10:00:00 2018\tDEPLOYED\tprow-1.0.0-rc1\tjx\n"
+	assert.Equal(t, map[string]string{
+		"jenkins-x":    "0.0.3300",
+		"cert-manager": "v0.5.2",
+		"prow":         "1.0.0-rc1",
+	}, helm.ReleaseChartVersions(output))
+	assert.Empty(t, helm.ReleaseChartVersions(""))
+}
+
+func TestParseReleaseTestResults(t *testing.T) {
+	t.Parallel()
+	output := "RUNNING: myapp-test-connection\n" +
+		"PASSED: myapp-test-connection\n" +
+		"RUNNING: myapp-test-api\n" +
+		"FAILED: myapp-test-api, run `kubectl logs myapp-test-api --namespace jx-staging` for more info\n" +
+		"Error: 1 test(s) failed\n"
+	assert.Equal(t, []helm.ReleaseTestResult{
+		{Name: "myapp-test-connection", Passed: true},
+		{Name: "myapp-test-api", Message: "run `kubectl logs myapp-test-api --namespace jx-staging` for more info"},
+	}, helm.ParseReleaseTestResults(output))
+	assert.Empty(t, helm.ParseReleaseTestResults("No Tests Found"))
+}
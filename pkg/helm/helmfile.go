@@ -0,0 +1,307 @@
+package helm
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	// HelmfileFileName the name of the file which describes the releases of an environment using helmfile
+	HelmfileFileName = "helmfile.yaml"
+
+	// HelmfileValuesDir the directory next to the helmfile containing the values files of the releases
+	HelmfileValuesDir = "values"
+)
+
+// Helmfile is a helmfile.yaml whose repositories and releases are modified by jx. Any other configuration in the file
+// is preserved
+type Helmfile struct {
+	values yaml.MapSlice
+}
+
+// HelmfileRelease is a release of a chart in a helmfile
+type HelmfileRelease struct {
+	Name      string
+	Namespace string
+	Chart     string
+	Version   string
+	Values    []string
+}
+
+// FindHelmfileFileName returns the helmfile.yaml of the environment in the given directory or an empty string if the
+// environment does not use helmfile
+func FindHelmfileFileName(dir string) (string, error) {
+	names := []string{
+		filepath.Join(dir, defaultEnvironmentChartDir, HelmfileFileName),
+		filepath.Join(dir, HelmfileFileName),
+	}
+	for _, name := range names {
+		exists, err := util.FileExists(name)
+		if err != nil {
+			return "", err
+		}
+		if exists {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// LoadHelmfile loads the helmfile or creates an empty helmfile if the file does not exist
+func LoadHelmfile(fileName string) (*Helmfile, error) {
+	values, err := LoadValuesFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	return &Helmfile{values: values}, nil
+}
+
+// Save saves the helmfile
+func (h *Helmfile) Save(fileName string) error {
+	return SaveValuesFile(fileName, h.values)
+}
+
+// Releases returns the releases of the helmfile
+func (h *Helmfile) Releases() []HelmfileRelease {
+	answer := []HelmfileRelease{}
+	for _, release := range h.releases() {
+		r := HelmfileRelease{
+			Name:      stringValue(release, "name"),
+			Namespace: stringValue(release, "namespace"),
+			Chart:     stringValue(release, "chart"),
+			Version:   stringValue(release, "version"),
+		}
+		values, _ := GetValue(release, "values").([]interface{})
+		for _, v := range values {
+			if file, ok := v.(string); ok {
+				r.Values = append(r.Values, file)
+			}
+		}
+		answer = append(answer, r)
+	}
+	return answer
+}
+
+// Repositories returns the URLs of the chart repositories of the helmfile indexed by their name
+func (h *Helmfile) Repositories() map[string]string {
+	answer := map[string]string{}
+	repos, _ := GetValue(h.values, "repositories").([]interface{})
+	for _, r := range repos {
+		repo, ok := r.(yaml.MapSlice)
+		if ok {
+			answer[stringValue(repo, "name")] = stringValue(repo, "url")
+		}
+	}
+	return answer
+}
+
+// Requirements returns the releases of the helmfile as the dependencies of an environment chart so that the same
+// functions can modify both layouts of environment
+func (h *Helmfile) Requirements() *Requirements {
+	repos := h.Repositories()
+	answer := &Requirements{
+		Dependencies: []*Dependency{},
+	}
+	for _, release := range h.Releases() {
+		repoName, chart := "", release.Chart
+		i := strings.Index(chart, "/")
+		if i > 0 {
+			repoName, chart = chart[:i], chart[i+1:]
+		}
+		dep := &Dependency{
+			Name:       chart,
+			Version:    release.Version,
+			Repository: repos[repoName],
+		}
+		if dep.Repository == "" {
+			dep.Repository = repoName
+		}
+		if release.Name != chart {
+			dep.Alias = release.Name
+		}
+		answer.Dependencies = append(answer.Dependencies, dep)
+	}
+	return answer
+}
+
+// SetRequirements updates the releases of the helmfile to match the dependencies of an environment chart. New
+// releases are installed into the given namespace
+func (h *Helmfile) SetRequirements(requirements *Requirements, namespace string) {
+	existing := map[string]yaml.MapSlice{}
+	for _, release := range h.releases() {
+		existing[stringValue(release, "name")] = release
+	}
+	releases := []yaml.MapSlice{}
+	for _, dep := range requirements.Dependencies {
+		if dep == nil {
+			continue
+		}
+		name := dep.Alias
+		if name == "" {
+			name = dep.Name
+		}
+		release, ok := existing[name]
+		if !ok {
+			release = yaml.MapSlice{}
+			release = SetValue(release, "name", name)
+			release = SetValue(release, "namespace", namespace)
+		}
+		chart := dep.Name
+		if dep.Repository != "" {
+			chart = h.repositoryName(dep.Repository) + "/" + dep.Name
+		}
+		release = SetValue(release, "chart", chart)
+		if dep.Version != "" {
+			release = SetValue(release, "version", dep.Version)
+		} else {
+			release = RemoveValue(release, "version")
+		}
+		releases = append(releases, release)
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return stringValue(releases[i], "name") < stringValue(releases[j], "name")
+	})
+	h.setReleases(releases)
+}
+
+// SetReleaseValues sets the values files of the named release
+func (h *Helmfile) SetReleaseValues(name string, files []string) {
+	releases := h.releases()
+	for i, release := range releases {
+		if stringValue(release, "name") == name {
+			values := []interface{}{}
+			for _, file := range files {
+				values = append(values, file)
+			}
+			releases[i] = SetValue(release, "values", values)
+		}
+	}
+	h.setReleases(releases)
+}
+
+// repositoryName returns the name of the repository with the URL adding the repository if it is not present
+func (h *Helmfile) repositoryName(repoURL string) string {
+	repos := h.Repositories()
+	names := []string{}
+	for name, u := range repos {
+		if u == repoURL {
+			return name
+		}
+		names = append(names, name)
+	}
+	if !strings.Contains(repoURL, "://") {
+		// already the name of a repository
+		return repoURL
+	}
+	name := repoURL
+	u, err := url.Parse(repoURL)
+	if err == nil && u.Hostname() != "" {
+		name = strings.Replace(u.Hostname(), ".", "-", -1)
+	}
+	unique := name
+	for i := 2; util.StringArrayIndex(names, unique) >= 0; i++ {
+		unique = fmt.Sprintf("%s-%d", name, i)
+	}
+	name = unique
+
+	repoList, _ := GetValue(h.values, "repositories").([]interface{})
+	repo := yaml.MapSlice{
+		{Key: "name", Value: name},
+		{Key: "url", Value: repoURL},
+	}
+	h.values = SetValue(h.values, "repositories", append(repoList, repo))
+	return name
+}
+
+func (h *Helmfile) releases() []yaml.MapSlice {
+	answer := []yaml.MapSlice{}
+	list, _ := GetValue(h.values, "releases").([]interface{})
+	for _, r := range list {
+		release, ok := r.(yaml.MapSlice)
+		if ok {
+			answer = append(answer, release)
+		}
+	}
+	return answer
+}
+
+func (h *Helmfile) setReleases(releases []yaml.MapSlice) {
+	list := []interface{}{}
+	for _, release := range releases {
+		list = append(list, release)
+	}
+	h.values = SetValue(h.values, "releases", list)
+}
+
+func stringValue(values yaml.MapSlice, key string) string {
+	value := GetValue(values, key)
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// ConvertToHelmfile converts the environment chart in the directory into a helmfile.yaml with a release for each
+// of its dependencies. The values of each dependency are moved out of the values.yaml of the chart into a values file
+// of its release and the requirements.yaml is removed. Returns the name of the helmfile
+func ConvertToHelmfile(dir string, namespace string) (string, error) {
+	requirementsFile := filepath.Join(dir, RequirementsFileName)
+	requirements, err := LoadRequirementsFile(requirementsFile)
+	if err != nil {
+		return "", err
+	}
+	valuesFile := filepath.Join(dir, "values.yaml")
+	values, err := LoadValuesFile(valuesFile)
+	if err != nil {
+		return "", err
+	}
+	fileName := filepath.Join(dir, HelmfileFileName)
+	helmfile, err := LoadHelmfile(fileName)
+	if err != nil {
+		return "", err
+	}
+	helmfile.SetRequirements(requirements, namespace)
+
+	for _, release := range helmfile.Releases() {
+		releaseValues, ok := GetValue(values, release.Name).(yaml.MapSlice)
+		if !ok {
+			continue
+		}
+		valuesDir := filepath.Join(dir, HelmfileValuesDir)
+		err = os.MkdirAll(valuesDir, util.DefaultWritePermissions)
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to create directory %s", valuesDir)
+		}
+		err = SaveValuesFile(filepath.Join(valuesDir, release.Name+".yaml"), releaseValues)
+		if err != nil {
+			return "", err
+		}
+		helmfile.SetReleaseValues(release.Name, []string{HelmfileValuesDir + "/" + release.Name + ".yaml"})
+		values = RemoveValue(values, release.Name)
+	}
+	err = helmfile.Save(fileName)
+	if err != nil {
+		return "", err
+	}
+	err = SaveValuesFile(valuesFile, values)
+	if err != nil {
+		return "", err
+	}
+	exists, err := util.FileExists(requirementsFile)
+	if err != nil || !exists {
+		return fileName, err
+	}
+	err = os.Remove(requirementsFile)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to remove %s", requirementsFile)
+	}
+	return fileName, nil
+}
@@ -0,0 +1,111 @@
+package helm_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHelmfileRequirements(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-helmfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	fileName := filepath.Join(dir, helm.HelmfileFileName)
+	err = ioutil.WriteFile(fileName, []byte(`helmDefaults:
+  wait: true
+repositories:
+- name: jenkins-x
+  url: http://jenkins-x-chartmuseum:8080
+releases:
+- name: myapp
+  namespace: jx-staging
+  chart: jenkins-x/myapp
+  version: 1.0.0
+  values:
+  - values/myapp.yaml
+  installed: true
+`), 0644)
+	require.NoError(t, err)
+
+	helmfile, err := helm.LoadHelmfile(fileName)
+	require.NoError(t, err)
+	requirements := helmfile.Requirements()
+	require.Len(t, requirements.Dependencies, 1)
+	assert.Equal(t, helm.Dependency{Name: "myapp", Version: "1.0.0", Repository: helm.DefaultHelmRepositoryURL}, *requirements.Dependencies[0])
+
+	requirements.SetAppVersion("myapp", "1.1.0", helm.DefaultHelmRepositoryURL, "")
+	requirements.SetAppVersion("redis", "3.0.0", "https://kubernetes-charts.storage.googleapis.com", "cache")
+	helmfile.SetRequirements(requirements, "jx-staging")
+	err = helmfile.Save(fileName)
+	require.NoError(t, err)
+
+	helmfile, err = helm.LoadHelmfile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []helm.HelmfileRelease{
+		{Name: "cache", Namespace: "jx-staging", Chart: "kubernetes-charts-storage-googleapis-com/redis", Version: "3.0.0"},
+		{Name: "myapp", Namespace: "jx-staging", Chart: "jenkins-x/myapp", Version: "1.1.0", Values: []string{"values/myapp.yaml"}},
+	}, helmfile.Releases())
+	assert.Equal(t, map[string]string{
+		"jenkins-x": helm.DefaultHelmRepositoryURL,
+		"kubernetes-charts-storage-googleapis-com": "https://kubernetes-charts.storage.googleapis.com",
+	}, helmfile.Repositories())
+
+	requirements = helmfile.Requirements()
+	assert.True(t, requirements.RemoveApp("myapp"))
+	helmfile.SetRequirements(requirements, "jx-staging")
+	require.NoError(t, helmfile.Save(fileName))
+
+	data, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "helmDefaults:\n  wait: true\n")
+	assert.NotContains(t, string(data), "myapp")
+}
+
+func TestConvertToHelmfile(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-convert-helmfile")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, helm.RequirementsFileName), []byte(`dependencies:
+- name: exposecontroller
+  version: 2.3.56
+  repository: https://chartmuseum.build.cd.jenkins-x.io
+  alias: expose
+`), 0644)
+	require.NoError(t, err)
+	err = ioutil.WriteFile(filepath.Join(dir, "values.yaml"), []byte(`expose:
+  config:
+    domain: 1.2.3.4.nip.io
+myapp:
+  hpa:
+    enabled: true
+`), 0644)
+	require.NoError(t, err)
+
+	fileName, err := helm.ConvertToHelmfile(dir, "jx-production")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, helm.HelmfileFileName), fileName)
+	_, err = os.Stat(filepath.Join(dir, helm.RequirementsFileName))
+	assert.True(t, os.IsNotExist(err), "the requirements.yaml should be removed")
+
+	helmfile, err := helm.LoadHelmfile(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []helm.HelmfileRelease{
+		{Name: "expose", Namespace: "jx-production", Chart: "chartmuseum-build-cd-jenkins-x-io/exposecontroller", Version: "2.3.56", Values: []string{"values/expose.yaml"}},
+	}, helmfile.Releases())
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "values", "expose.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "config:\n  domain: 1.2.3.4.nip.io\n", string(data))
+	data, err = ioutil.ReadFile(filepath.Join(dir, "values.yaml"))
+	require.NoError(t, err)
+	assert.Equal(t, "myapp:\n  hpa:\n    enabled: true\n", string(data))
+}
@@ -19,15 +19,22 @@ type Helmer interface {
 	UpgradeChart(chart string, releaseName string, ns string, version *string, install bool,
 		timeout *int, force bool, wait bool, values []string, valueFiles []string) error
 	DeleteRelease(releaseName string, purge bool) error
+	TestRelease(releaseName string, cleanup bool, timeout *int) (string, error)
+	RollbackRelease(releaseName string, revision int) error
 	ListCharts() (string, error)
 	SearchChartVersions(chart string) ([]string, error)
 	FindChart() (string, error)
 	PackageChart() error
 	StatusRelease(releaseName string) error
 	StatusReleases() (map[string]string, error)
+	Template(chart string, releaseName string, ns string, outDir string, values []string, valueFiles []string) error
 	Lint() (string, error)
 	Version(tls bool) (string, error)
 	SearchCharts(filter string) ([]ChartSummary, error)
 	SetHost(host string)
+	SetTillerNamespace(ns string)
+	SetTLS(files *TLSFiles)
+	SetAtomic(atomic bool)
+	SetRepoCAFile(caFile string)
 	Env() map[string]string
 }
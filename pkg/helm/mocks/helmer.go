@@ -262,6 +262,21 @@ func (mock *MockHelmer) RemoveRequirementsLock() error {
 	return ret0
 }
 
+func (mock *MockHelmer) RollbackRelease(_param0 string, _param1 int) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0, _param1}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("RollbackRelease", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
 func (mock *MockHelmer) SearchChartVersions(_param0 string) ([]string, error) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockHelmer().")
@@ -316,6 +331,14 @@ func (mock *MockHelmer) SetHelmBinary(_param0 string) {
 	pegomock.GetGenericMockFrom(mock).Invoke("SetHelmBinary", params, []reflect.Type{})
 }
 
+func (mock *MockHelmer) SetAtomic(_param0 bool) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0}
+	pegomock.GetGenericMockFrom(mock).Invoke("SetAtomic", params, []reflect.Type{})
+}
+
 func (mock *MockHelmer) SetHost(_param0 string) {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockHelmer().")
@@ -324,6 +347,30 @@ func (mock *MockHelmer) SetHost(_param0 string) {
 	pegomock.GetGenericMockFrom(mock).Invoke("SetHost", params, []reflect.Type{})
 }
 
+func (mock *MockHelmer) SetRepoCAFile(_param0 string) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0}
+	pegomock.GetGenericMockFrom(mock).Invoke("SetRepoCAFile", params, []reflect.Type{})
+}
+
+func (mock *MockHelmer) SetTLS(_param0 *helm.TLSFiles) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0}
+	pegomock.GetGenericMockFrom(mock).Invoke("SetTLS", params, []reflect.Type{})
+}
+
+func (mock *MockHelmer) SetTillerNamespace(_param0 string) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0}
+	pegomock.GetGenericMockFrom(mock).Invoke("SetTillerNamespace", params, []reflect.Type{})
+}
+
 func (mock *MockHelmer) StatusRelease(_param0 string) error {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockHelmer().")
@@ -358,6 +405,40 @@ func (mock *MockHelmer) StatusReleases() (map[string]string, error) {
 	return ret0, ret1
 }
 
+func (mock *MockHelmer) Template(_param0 string, _param1 string, _param2 string, _param3 string, _param4 []string, _param5 []string) error {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0, _param1, _param2, _param3, _param4, _param5}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("Template", params, []reflect.Type{reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(error)
+		}
+	}
+	return ret0
+}
+
+func (mock *MockHelmer) TestRelease(_param0 string, _param1 bool, _param2 *int) (string, error) {
+	if mock == nil {
+		panic("mock must not be nil. Use myMock := NewMockHelmer().")
+	}
+	params := []pegomock.Param{_param0, _param1, _param2}
+	result := pegomock.GetGenericMockFrom(mock).Invoke("TestRelease", params, []reflect.Type{reflect.TypeOf((*string)(nil)).Elem(), reflect.TypeOf((*error)(nil)).Elem()})
+	var ret0 string
+	var ret1 error
+	if len(result) != 0 {
+		if result[0] != nil {
+			ret0 = result[0].(string)
+		}
+		if result[1] != nil {
+			ret1 = result[1].(error)
+		}
+	}
+	return ret0, ret1
+}
+
 func (mock *MockHelmer) UpdateRepo() error {
 	if mock == nil {
 		panic("mock must not be nil. Use myMock := NewMockHelmer().")
@@ -784,6 +865,37 @@ func (c *Helmer_RemoveRequirementsLock_OngoingVerification) GetCapturedArguments
 func (c *Helmer_RemoveRequirementsLock_OngoingVerification) GetAllCapturedArguments() {
 }
 
+func (verifier *VerifierHelmer) RollbackRelease(_param0 string, _param1 int) *Helmer_RollbackRelease_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "RollbackRelease", params)
+	return &Helmer_RollbackRelease_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_RollbackRelease_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_RollbackRelease_OngoingVerification) GetCapturedArguments() (string, int) {
+	_param0, _param1 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1]
+}
+
+func (c *Helmer_RollbackRelease_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []int) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]int, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(int)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierHelmer) SearchChartVersions(_param0 string) *Helmer_SearchChartVersions_OngoingVerification {
 	params := []pegomock.Param{_param0}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SearchChartVersions", params)
@@ -892,6 +1004,33 @@ func (c *Helmer_SetHelmBinary_OngoingVerification) GetAllCapturedArguments() (_p
 	return
 }
 
+func (verifier *VerifierHelmer) SetAtomic(_param0 bool) *Helmer_SetAtomic_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetAtomic", params)
+	return &Helmer_SetAtomic_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_SetAtomic_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_SetAtomic_OngoingVerification) GetCapturedArguments() bool {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *Helmer_SetAtomic_OngoingVerification) GetAllCapturedArguments() (_param0 []bool) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]bool, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(bool)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierHelmer) SetHost(_param0 string) *Helmer_SetHost_OngoingVerification {
 	params := []pegomock.Param{_param0}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetHost", params)
@@ -919,6 +1058,87 @@ func (c *Helmer_SetHost_OngoingVerification) GetAllCapturedArguments() (_param0
 	return
 }
 
+func (verifier *VerifierHelmer) SetRepoCAFile(_param0 string) *Helmer_SetRepoCAFile_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetRepoCAFile", params)
+	return &Helmer_SetRepoCAFile_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_SetRepoCAFile_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_SetRepoCAFile_OngoingVerification) GetCapturedArguments() string {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *Helmer_SetRepoCAFile_OngoingVerification) GetAllCapturedArguments() (_param0 []string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+	}
+	return
+}
+
+func (verifier *VerifierHelmer) SetTLS(_param0 *helm.TLSFiles) *Helmer_SetTLS_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetTLS", params)
+	return &Helmer_SetTLS_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_SetTLS_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_SetTLS_OngoingVerification) GetCapturedArguments() *helm.TLSFiles {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *Helmer_SetTLS_OngoingVerification) GetAllCapturedArguments() (_param0 []*helm.TLSFiles) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]*helm.TLSFiles, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(*helm.TLSFiles)
+		}
+	}
+	return
+}
+
+func (verifier *VerifierHelmer) SetTillerNamespace(_param0 string) *Helmer_SetTillerNamespace_OngoingVerification {
+	params := []pegomock.Param{_param0}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "SetTillerNamespace", params)
+	return &Helmer_SetTillerNamespace_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_SetTillerNamespace_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_SetTillerNamespace_OngoingVerification) GetCapturedArguments() string {
+	_param0 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1]
+}
+
+func (c *Helmer_SetTillerNamespace_OngoingVerification) GetAllCapturedArguments() (_param0 []string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierHelmer) StatusRelease(_param0 string) *Helmer_StatusRelease_OngoingVerification {
 	params := []pegomock.Param{_param0}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "StatusRelease", params)
@@ -963,6 +1183,88 @@ func (c *Helmer_StatusReleases_OngoingVerification) GetCapturedArguments() {
 func (c *Helmer_StatusReleases_OngoingVerification) GetAllCapturedArguments() {
 }
 
+func (verifier *VerifierHelmer) Template(_param0 string, _param1 string, _param2 string, _param3 string, _param4 []string, _param5 []string) *Helmer_Template_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1, _param2, _param3, _param4, _param5}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "Template", params)
+	return &Helmer_Template_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_Template_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_Template_OngoingVerification) GetCapturedArguments() (string, string, string, string, []string, []string) {
+	_param0, _param1, _param2, _param3, _param4, _param5 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1], _param2[len(_param2)-1], _param3[len(_param3)-1], _param4[len(_param4)-1], _param5[len(_param5)-1]
+}
+
+func (c *Helmer_Template_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []string, _param2 []string, _param3 []string, _param4 [][]string, _param5 [][]string) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]string, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(string)
+		}
+		_param2 = make([]string, len(params[2]))
+		for u, param := range params[2] {
+			_param2[u] = param.(string)
+		}
+		_param3 = make([]string, len(params[3]))
+		for u, param := range params[3] {
+			_param3[u] = param.(string)
+		}
+		_param4 = make([][]string, len(params[4]))
+		for u, param := range params[4] {
+			_param4[u] = param.([]string)
+		}
+		_param5 = make([][]string, len(params[5]))
+		for u, param := range params[5] {
+			_param5[u] = param.([]string)
+		}
+	}
+	return
+}
+
+func (verifier *VerifierHelmer) TestRelease(_param0 string, _param1 bool, _param2 *int) *Helmer_TestRelease_OngoingVerification {
+	params := []pegomock.Param{_param0, _param1, _param2}
+	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "TestRelease", params)
+	return &Helmer_TestRelease_OngoingVerification{mock: verifier.mock, methodInvocations: methodInvocations}
+}
+
+type Helmer_TestRelease_OngoingVerification struct {
+	mock              *MockHelmer
+	methodInvocations []pegomock.MethodInvocation
+}
+
+func (c *Helmer_TestRelease_OngoingVerification) GetCapturedArguments() (string, bool, *int) {
+	_param0, _param1, _param2 := c.GetAllCapturedArguments()
+	return _param0[len(_param0)-1], _param1[len(_param1)-1], _param2[len(_param2)-1]
+}
+
+func (c *Helmer_TestRelease_OngoingVerification) GetAllCapturedArguments() (_param0 []string, _param1 []bool, _param2 []*int) {
+	params := pegomock.GetGenericMockFrom(c.mock).GetInvocationParams(c.methodInvocations)
+	if len(params) > 0 {
+		_param0 = make([]string, len(params[0]))
+		for u, param := range params[0] {
+			_param0[u] = param.(string)
+		}
+		_param1 = make([]bool, len(params[1]))
+		for u, param := range params[1] {
+			_param1[u] = param.(bool)
+		}
+		_param2 = make([]*int, len(params[2]))
+		for u, param := range params[2] {
+			_param2[u] = param.(*int)
+		}
+	}
+	return
+}
+
 func (verifier *VerifierHelmer) UpdateRepo() *Helmer_UpdateRepo_OngoingVerification {
 	params := []pegomock.Param{}
 	methodInvocations := pegomock.GetGenericMockFrom(verifier.mock).Verify(verifier.inOrderContext, verifier.invocationCountMatcher, "UpdateRepo", params)
@@ -0,0 +1,221 @@
+package helm
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// SecretTillerClientTLS the name of the Secret in the tiller namespace which stores the CA certificate and the
+	// client certificate used to connect to a tiller which verifies client certificates
+	SecretTillerClientTLS = "jx-tiller-client-tls"
+
+	// TLSCACertKey the key of the CA certificate in the Secret
+	TLSCACertKey = "ca.crt"
+	// TLSCertKey the key of the client certificate in the Secret
+	TLSCertKey = "tls.crt"
+	// TLSKeyKey the key of the client private key in the Secret
+	TLSKeyKey = "tls.key"
+
+	// DefaultTLSValidity how long the generated certificates are valid for
+	DefaultTLSValidity = 5 * 365 * 24 * time.Hour
+
+	tillerServiceName = "tiller-deploy"
+	rsaKeySize        = 2048
+)
+
+// TLSCerts the PEM encoded certificates and keys used for mutual TLS between the helm client and tiller
+type TLSCerts struct {
+	CACert     []byte
+	TillerCert []byte
+	TillerKey  []byte
+	ClientCert []byte
+	ClientKey  []byte
+}
+
+// TLSFiles the files containing the certificates and keys used for mutual TLS between the helm client and tiller.
+// The tiller certificate and key are only present when installing tiller
+type TLSFiles struct {
+	CACert     string
+	TillerCert string
+	TillerKey  string
+	ClientCert string
+	ClientKey  string
+}
+
+// GenerateTLSCerts generates a CA along with a certificate for the tiller running in the namespace and a client
+// certificate signed by the CA
+func GenerateTLSCerts(tillerNamespace string, validity time.Duration) (*TLSCerts, error) {
+	notBefore := time.Now().Add(-time.Hour)
+	notAfter := notBefore.Add(validity)
+
+	caKey, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate the CA key")
+	}
+	caTemplate, err := certificateTemplate("tiller-ca-"+tillerNamespace, notBefore, notAfter)
+	if err != nil {
+		return nil, err
+	}
+	caTemplate.IsCA = true
+	caTemplate.BasicConstraintsValid = true
+	caTemplate.KeyUsage = x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the CA certificate")
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		return nil, err
+	}
+
+	tillerTemplate, err := certificateTemplate(tillerServiceName, notBefore, notAfter)
+	if err != nil {
+		return nil, err
+	}
+	tillerTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}
+	tillerTemplate.DNSNames = []string{
+		"localhost",
+		tillerServiceName,
+		fmt.Sprintf("%s.%s", tillerServiceName, tillerNamespace),
+		fmt.Sprintf("%s.%s.svc", tillerServiceName, tillerNamespace),
+	}
+	tillerTemplate.IPAddresses = []net.IP{net.ParseIP("127.0.0.1")}
+	tillerCert, tillerKey, err := signedCertificate(tillerTemplate, ca, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the tiller certificate")
+	}
+
+	clientTemplate, err := certificateTemplate("jx", notBefore, notAfter)
+	if err != nil {
+		return nil, err
+	}
+	clientTemplate.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}
+	clientCert, clientKey, err := signedCertificate(clientTemplate, ca, caKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create the client certificate")
+	}
+
+	return &TLSCerts{
+		CACert:     pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}),
+		TillerCert: tillerCert,
+		TillerKey:  tillerKey,
+		ClientCert: clientCert,
+		ClientKey:  clientKey,
+	}, nil
+}
+
+// ClientSecretData returns the data of the Secret storing the CA and client certificates
+func (c *TLSCerts) ClientSecretData() map[string][]byte {
+	return map[string][]byte{
+		TLSCACertKey: c.CACert,
+		TLSCertKey:   c.ClientCert,
+		TLSKeyKey:    c.ClientKey,
+	}
+}
+
+// TLSCertsFromSecretData returns the CA and client certificates stored in the data of a Secret
+func TLSCertsFromSecretData(data map[string][]byte) (*TLSCerts, error) {
+	for _, key := range []string{TLSCACertKey, TLSCertKey, TLSKeyKey} {
+		if len(data[key]) == 0 {
+			return nil, fmt.Errorf("no %s entry in the tiller client TLS secret", key)
+		}
+	}
+	return &TLSCerts{
+		CACert:     data[TLSCACertKey],
+		ClientCert: data[TLSCertKey],
+		ClientKey:  data[TLSKeyKey],
+	}, nil
+}
+
+// WriteTLSFiles writes the certificates and keys to the directory. The tiller certificate and key are only written
+// if they are present
+func WriteTLSFiles(dir string, certs *TLSCerts) (*TLSFiles, error) {
+	err := os.MkdirAll(dir, util.DefaultWritePermissions)
+	if err != nil {
+		return nil, err
+	}
+	files := &TLSFiles{
+		CACert:     filepath.Join(dir, "ca.pem"),
+		ClientCert: filepath.Join(dir, "cert.pem"),
+		ClientKey:  filepath.Join(dir, "key.pem"),
+	}
+	contents := map[string][]byte{
+		files.CACert:     certs.CACert,
+		files.ClientCert: certs.ClientCert,
+		files.ClientKey:  certs.ClientKey,
+	}
+	if len(certs.TillerCert) > 0 {
+		files.TillerCert = filepath.Join(dir, "tiller.cert.pem")
+		files.TillerKey = filepath.Join(dir, "tiller.key.pem")
+		contents[files.TillerCert] = certs.TillerCert
+		contents[files.TillerKey] = certs.TillerKey
+	}
+	for fileName, data := range contents {
+		err = ioutil.WriteFile(fileName, data, 0600)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to write file %s", fileName)
+		}
+	}
+	return files, nil
+}
+
+// LoadTLSFiles returns the client TLS files in the directory or nil if they do not exist
+func LoadTLSFiles(dir string) (*TLSFiles, error) {
+	files := &TLSFiles{
+		CACert:     filepath.Join(dir, "ca.pem"),
+		ClientCert: filepath.Join(dir, "cert.pem"),
+		ClientKey:  filepath.Join(dir, "key.pem"),
+	}
+	for _, fileName := range []string{files.CACert, files.ClientCert, files.ClientKey} {
+		exists, err := util.FileExists(fileName)
+		if err != nil || !exists {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+func certificateTemplate(commonName string, notBefore time.Time, notAfter time.Time) (*x509.Certificate, error) {
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to generate a certificate serial number")
+	}
+	return &x509.Certificate{
+		SerialNumber: serial,
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: []string{"Jenkins X"},
+		},
+		NotBefore: notBefore,
+		NotAfter:  notAfter,
+		KeyUsage:  x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+	}, nil
+}
+
+func signedCertificate(template *x509.Certificate, ca *x509.Certificate, caKey *rsa.PrivateKey) ([]byte, []byte, error) {
+	key, err := rsa.GenerateKey(rand.Reader, rsaKeySize)
+	if err != nil {
+		return nil, nil, err
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, &key.PublicKey, caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return certPEM, keyPEM, nil
+}
@@ -0,0 +1,81 @@
+package helm_test
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func parseCertificate(t *testing.T, data []byte) *x509.Certificate {
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block, "no PEM data found")
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func TestGenerateTLSCerts(t *testing.T) {
+	t.Parallel()
+	certs, err := helm.GenerateTLSCerts("jx", helm.DefaultTLSValidity)
+	require.NoError(t, err)
+
+	roots := x509.NewCertPool()
+	require.True(t, roots.AppendCertsFromPEM(certs.CACert))
+
+	tiller := parseCertificate(t, certs.TillerCert)
+	_, err = tiller.Verify(x509.VerifyOptions{
+		DNSName:   "tiller-deploy.jx.svc",
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	assert.NoError(t, err)
+
+	client := parseCertificate(t, certs.ClientCert)
+	_, err = client.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	assert.NoError(t, err)
+	_, err = client.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	})
+	assert.Error(t, err, "the client certificate should not be usable by a server")
+}
+
+func TestWriteAndLoadTLSFiles(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-tiller-tls-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	files, err := helm.LoadTLSFiles(dir)
+	require.NoError(t, err)
+	assert.Nil(t, files)
+
+	certs, err := helm.GenerateTLSCerts("jx", helm.DefaultTLSValidity)
+	require.NoError(t, err)
+	written, err := helm.WriteTLSFiles(dir, certs)
+	require.NoError(t, err)
+	assert.NotEmpty(t, written.TillerCert)
+
+	files, err = helm.LoadTLSFiles(dir)
+	require.NoError(t, err)
+	require.NotNil(t, files)
+	assert.Equal(t, written.ClientCert, files.ClientCert)
+	assert.Empty(t, files.TillerCert)
+
+	clientCerts, err := helm.TLSCertsFromSecretData(certs.ClientSecretData())
+	require.NoError(t, err)
+	assert.Equal(t, certs.ClientKey, clientCerts.ClientKey)
+	assert.Empty(t, clientCerts.TillerKey)
+
+	_, err = helm.TLSCertsFromSecretData(map[string][]byte{helm.TLSCACertKey: certs.CACert})
+	assert.Error(t, err)
+}
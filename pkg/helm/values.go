@@ -0,0 +1,69 @@
+package helm
+
+import (
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// LoadValuesFile loads a values YAML file preserving the order of its keys. An empty slice is returned if the file
+// does not exist
+func LoadValuesFile(fileName string) (yaml.MapSlice, error) {
+	values := yaml.MapSlice{}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return values, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return values, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	err = yaml.Unmarshal(data, &values)
+	if err != nil {
+		return values, errors.Wrapf(err, "failed to unmarshal YAML file %s", fileName)
+	}
+	return values, nil
+}
+
+// SaveValuesFile saves the values to a YAML file
+func SaveValuesFile(fileName string, values yaml.MapSlice) error {
+	data, err := yaml.Marshal(values)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal the values of %s", fileName)
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
+
+// GetValue returns the value of the key or nil if there is none
+func GetValue(values yaml.MapSlice, key string) interface{} {
+	for _, item := range values {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// SetValue sets the value of the key appending it if the key is not present
+func SetValue(values yaml.MapSlice, key string, value interface{}) yaml.MapSlice {
+	for i, item := range values {
+		if item.Key == key {
+			values[i].Value = value
+			return values
+		}
+	}
+	return append(values, yaml.MapItem{Key: key, Value: value})
+}
+
+// RemoveValue removes the key from the values
+func RemoveValue(values yaml.MapSlice, key string) yaml.MapSlice {
+	answer := yaml.MapSlice{}
+	for _, item := range values {
+		if item.Key != key {
+			answer = append(answer, item)
+		}
+	}
+	return answer
+}
@@ -0,0 +1,125 @@
+package hooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+const (
+	// HeaderSignature the header of the HMAC signature of the payload
+	HeaderSignature = "X-Hub-Signature"
+	// HeaderEvent the header of the kind of event
+	HeaderEvent = "X-GitHub-Event"
+	// HeaderDelivery the header of the identifier of the delivery
+	HeaderDelivery = "X-GitHub-Delivery"
+
+	// MaxPayloadSize the largest webhook payload which is accepted. GitHub does not deliver larger payloads either
+	MaxPayloadSize = 25 << 20
+
+	signaturePrefix = "sha1="
+)
+
+// DeliveryHeaders the headers of a webhook delivery which are kept so the delivery can be replayed
+var DeliveryHeaders = []string{"Content-Type", "User-Agent", HeaderEvent, HeaderDelivery, HeaderSignature}
+
+// Sign returns the signature of the payload using the HMAC token
+func Sign(payload []byte, hmacToken []byte) string {
+	mac := hmac.New(sha1.New, hmacToken)
+	mac.Write(payload)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidSignature returns true if the signature of the delivery was created with the HMAC token
+func ValidSignature(payload []byte, signature string, hmacToken []byte) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(signature), []byte(Sign(payload, hmacToken)))
+}
+
+// Headers returns the delivery headers of the request
+func Headers(header http.Header) map[string]string {
+	answer := map[string]string{}
+	for _, name := range DeliveryHeaders {
+		value := header.Get(name)
+		if value != "" {
+			answer[name] = value
+		}
+	}
+	return answer
+}
+
+// RepositoryName returns the owner/repo name of the repository the payload is about or an empty string
+func RepositoryName(payload []byte) string {
+	event := struct {
+		Repository struct {
+			FullName string `json:"full_name"`
+		} `json:"repository"`
+	}{}
+	err := json.Unmarshal(payload, &event)
+	if err != nil {
+		return ""
+	}
+	return event.Repository.FullName
+}
+
+// Deliver posts the payload with its headers to the webhook engine returning the status code and body of the response.
+// The error is only returned if the webhook engine could not be reached
+func Deliver(client *http.Client, hookURL string, headers map[string]string, payload []byte) (int, []byte, error) {
+	req, err := http.NewRequest(http.MethodPost, hookURL, bytes.NewReader(payload))
+	if err != nil {
+		return 0, nil, err
+	}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	return resp.StatusCode, body, err
+}
+
+// FailureReason returns why a delivery to the webhook engine failed or an empty reason if it succeeded
+func FailureReason(status int, err error) v1.HookFailureReason {
+	switch {
+	case err != nil || status >= http.StatusInternalServerError:
+		return v1.HookFailureReasonUnavailable
+	case status >= http.StatusBadRequest:
+		return v1.HookFailureReasonRejected
+	default:
+		return ""
+	}
+}
+
+// Replay redelivers the failed delivery to the webhook engine. If a HMAC token is given the payload is signed again
+// with it such as when the delivery failed as it was signed with an old token
+func Replay(client *http.Client, hookURL string, failure *v1.HookFailure, hmacToken []byte) error {
+	headers := map[string]string{}
+	for k, v := range failure.Spec.Headers {
+		headers[k] = v
+	}
+	payload := []byte(failure.Spec.Payload)
+	if len(hmacToken) > 0 {
+		headers[HeaderSignature] = Sign(payload, hmacToken)
+	}
+	status, body, err := Deliver(client, hookURL, headers, payload)
+	if FailureReason(status, err) == "" {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return fmt.Errorf("the webhook engine returned status %d: %s", status, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,55 @@
+package hooks_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/hooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidSignature(t *testing.T) {
+	t.Parallel()
+	payload := []byte(`{"repository":{"full_name":"myorg/myapp"}}`)
+	signature := hooks.Sign(payload, []byte("secret"))
+	assert.True(t, hooks.ValidSignature(payload, signature, []byte("secret")))
+	assert.False(t, hooks.ValidSignature(payload, signature, []byte("other")))
+	assert.False(t, hooks.ValidSignature(payload, "", []byte("secret")))
+	assert.Equal(t, "myorg/myapp", hooks.RepositoryName(payload))
+}
+
+func TestFailureReason(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, v1.HookFailureReason(""), hooks.FailureReason(http.StatusOK, nil))
+	assert.Equal(t, v1.HookFailureReasonRejected, hooks.FailureReason(http.StatusBadRequest, nil))
+	assert.Equal(t, v1.HookFailureReasonUnavailable, hooks.FailureReason(http.StatusBadGateway, nil))
+	assert.Equal(t, v1.HookFailureReasonUnavailable, hooks.FailureReason(0, assert.AnError))
+}
+
+func TestReplayResigns(t *testing.T) {
+	t.Parallel()
+	var received http.Header
+	var body []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r.Header
+		body, _ = ioutil.ReadAll(r.Body)
+	}))
+	defer server.Close()
+
+	failure := &v1.HookFailure{
+		Spec: v1.HookFailureSpec{
+			Headers: map[string]string{hooks.HeaderEvent: "push", hooks.HeaderSignature: "sha1=old"},
+			Payload: `{"ref":"refs/heads/master"}`,
+		},
+	}
+	err := hooks.Replay(server.Client(), server.URL, failure, []byte("secret"))
+	require.NoError(t, err)
+	assert.Equal(t, failure.Spec.Payload, string(body))
+	assert.Equal(t, "push", received.Get(hooks.HeaderEvent))
+	assert.True(t, hooks.ValidSignature(body, received.Get(hooks.HeaderSignature), []byte("secret")))
+	assert.Equal(t, "sha1=old", failure.Spec.Headers[hooks.HeaderSignature], "the stored delivery is not modified")
+}
@@ -0,0 +1,118 @@
+package httpclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+var (
+	caBundle     []byte
+	caPool       *x509.CertPool
+	transport    = newBaseTransport(nil)
+	caBundleLock sync.RWMutex
+)
+
+// AddCABundle trusts the PEM encoded certificate authorities of a self-hosted service such as a git server, docker
+// registry or Jenkins in addition to the system roots. The bundle is used by the clients which make their requests
+// via BaseTransport and by the clients which build their own transport from RootCAs
+func AddCABundle(pem []byte) error {
+	pem = bytes.TrimSpace(pem)
+	if len(pem) == 0 {
+		return nil
+	}
+	caBundleLock.Lock()
+	defer caBundleLock.Unlock()
+	if bytes.Contains(caBundle, pem) {
+		return nil
+	}
+	if !x509.NewCertPool().AppendCertsFromPEM(pem) {
+		return fmt.Errorf("no PEM certificates found in the CA bundle")
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	data := append(append(append([]byte{}, caBundle...), pem...), '\n')
+	pool.AppendCertsFromPEM(data)
+	caBundle = data
+	caPool = pool
+	// the transport is replaced rather than modified as requests may be using it concurrently
+	old := transport
+	transport = newBaseTransport(pool)
+	old.CloseIdleConnections()
+	return nil
+}
+
+// AddCABundleFile trusts the certificate authorities in the PEM file
+func AddCABundleFile(fileName string) error {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to load the CA bundle %s: %s", fileName, err)
+	}
+	err = AddCABundle(data)
+	if err != nil {
+		return fmt.Errorf("failed to load the CA bundle %s: %s", fileName, err)
+	}
+	return nil
+}
+
+// RootCAs returns the system roots together with the added CA bundles or nil, which means the system roots, if no
+// bundle was added
+func RootCAs() *x509.CertPool {
+	caBundleLock.RLock()
+	defer caBundleLock.RUnlock()
+	return caPool
+}
+
+// BaseTransport returns the transport which the HTTP clients of jx make their requests with. It has the settings of
+// http.DefaultTransport and trusts the system roots together with the added CA bundles, including the bundles which
+// are added after the transport is returned
+func BaseTransport() http.RoundTripper {
+	return baseTransport{}
+}
+
+// NewBaseClient creates an HTTP client which makes its requests with BaseTransport
+func NewBaseClient() *http.Client {
+	return &http.Client{Transport: BaseTransport()}
+}
+
+type baseTransport struct{}
+
+// RoundTrip implements http.RoundTripper using the transport which trusts the CA bundles added so far
+func (baseTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	caBundleLock.RLock()
+	t := transport
+	caBundleLock.RUnlock()
+	return t.RoundTrip(req)
+}
+
+// newBaseTransport creates a transport with the settings of http.DefaultTransport which trusts the given roots or the
+// system roots if nil
+func newBaseTransport(roots *x509.CertPool) *http.Transport {
+	return &http.Transport{
+		Proxy: http.ProxyFromEnvironment,
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:          100,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		TLSClientConfig:       &tls.Config{RootCAs: roots},
+	}
+}
+
+// CABundle returns the PEM encoded certificate authorities which were added or nil if none were
+func CABundle() []byte {
+	caBundleLock.RLock()
+	defer caBundleLock.RUnlock()
+	return caBundle
+}
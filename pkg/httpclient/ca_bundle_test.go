@@ -0,0 +1,58 @@
+package httpclient_test
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	// a client created before the bundle is added trusts it once it is added
+	client := httpclient.NewBaseClient()
+	_, err := client.Get(server.URL)
+	require.Error(t, err, "the certificate of the test server is not trusted yet")
+
+	assert.Error(t, httpclient.AddCABundle([]byte("not a certificate")))
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// requests in flight while the bundle is added must not race with it
+		for i := 0; i < 10; i++ {
+			resp, err := client.Get(server.URL)
+			if err == nil {
+				resp.Body.Close()
+			}
+		}
+	}()
+	err = httpclient.AddCABundle(data)
+	require.NoError(t, err)
+	<-done
+	require.NoError(t, httpclient.AddCABundle(data), "adding the same bundle twice is ignored")
+	assert.NotNil(t, httpclient.RootCAs())
+	assert.Equal(t, 1, strings.Count(string(httpclient.CABundle()), "BEGIN CERTIFICATE"), "the bundle is only added once")
+
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	resp, err = httpclient.NewClient(nil).Get(server.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	_, err = http.Get(server.URL)
+	assert.Error(t, err, "http.DefaultTransport is left alone")
+}
@@ -0,0 +1,242 @@
+package httpclient
+
+import (
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// DefaultRequestsPerSecond the default number of requests per second made to a single host
+	DefaultRequestsPerSecond = 10
+	// DefaultMaxRetries the default number of times a failed idempotent request is retried
+	DefaultMaxRetries = 3
+	// DefaultRetryWait the default wait before the first retry which doubles for each following retry
+	DefaultRetryWait = 500 * time.Millisecond
+	// maxRetryAfter the longest Retry-After delay requested by a server which is honoured
+	maxRetryAfter = time.Minute
+)
+
+var (
+	debug     bool
+	debugLock sync.RWMutex
+
+	limiters     = map[string]*rate.Limiter{}
+	limitersLock sync.Mutex
+
+	requests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jx_http_requests_total",
+		Help: "The number of HTTP requests made by jx by host, method and response code",
+	}, []string{"host", "method", "code"})
+	requestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "jx_http_request_duration_seconds",
+		Help:    "The duration of the HTTP requests made by jx by host and method",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"host", "method"})
+	retries = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "jx_http_retries_total",
+		Help: "The number of HTTP requests made by jx which were retried by host",
+	}, []string{"host"})
+)
+
+func init() {
+	for _, c := range []prometheus.Collector{requests, requestDuration, retries} {
+		err := prometheus.Register(c)
+		if err != nil {
+			if _, ok := err.(prometheus.AlreadyRegisteredError); !ok {
+				log.Warnf("Failed to register the HTTP client metrics: %s\n", err)
+			}
+		}
+	}
+}
+
+// SetDebug enables or disables the logging of each HTTP request
+func SetDebug(enabled bool) {
+	debugLock.Lock()
+	defer debugLock.Unlock()
+	debug = enabled
+}
+
+func isDebug() bool {
+	debugLock.RLock()
+	defer debugLock.RUnlock()
+	return debug
+}
+
+// Transport an http.RoundTripper shared by the git providers, version lookups and downloads which rate limits the
+// requests to each host, retries failed idempotent requests with jittered exponential backoff and records metrics
+type Transport struct {
+	// Base the transport which makes the requests. Defaults to BaseTransport
+	Base http.RoundTripper
+	// RequestsPerSecond the limit of the requests per second to each host. Requests are not limited if zero
+	RequestsPerSecond float64
+	// MaxRetries the number of times a failed idempotent request is retried
+	MaxRetries int
+	// RetryWait the wait before the first retry
+	RetryWait time.Duration
+}
+
+// NewTransport creates a transport using the default limits wrapping the base transport which may be nil
+func NewTransport(base http.RoundTripper) *Transport {
+	return &Transport{
+		Base:              base,
+		RequestsPerSecond: DefaultRequestsPerSecond,
+		MaxRetries:        DefaultMaxRetries,
+		RetryWait:         DefaultRetryWait,
+	}
+}
+
+// NewClient creates an HTTP client using the shared transport wrapping the base transport which may be nil
+func NewClient(base http.RoundTripper) *http.Client {
+	return &http.Client{Transport: NewTransport(base)}
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	base := t.Base
+	if base == nil {
+		base = BaseTransport()
+	}
+	host := req.URL.Host
+	retryable := isIdempotent(req) && (req.Body == nil || req.GetBody != nil)
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			retry := *req
+			retry.Body = body
+			req = &retry
+		}
+		limiter := t.limiter(host)
+		if limiter != nil {
+			err := limiter.Wait(req.Context())
+			if err != nil {
+				return nil, err
+			}
+		}
+		start := time.Now()
+		resp, err := base.RoundTrip(req)
+		duration := time.Since(start)
+		requestDuration.WithLabelValues(host, req.Method).Observe(duration.Seconds())
+		code := "error"
+		if resp != nil {
+			code = strconv.Itoa(resp.StatusCode)
+		}
+		requests.WithLabelValues(host, req.Method, code).Inc()
+		if isDebug() {
+			if err != nil {
+				log.Infof("HTTP %s %s failed after %s: %s\n", req.Method, redactURL(req), duration, err)
+			} else {
+				log.Infof("HTTP %s %s returned %s in %s\n", req.Method, redactURL(req), code, duration)
+			}
+		}
+
+		if !retryable || attempt >= t.MaxRetries || !shouldRetry(resp, err) {
+			return resp, err
+		}
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			resp.Body.Close()
+		}
+		retries.WithLabelValues(host).Inc()
+		if isDebug() {
+			log.Infof("Retrying HTTP %s %s in %s\n", req.Method, redactURL(req), wait)
+		}
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// limiter returns the rate limiter shared by all transports for the host
+func (t *Transport) limiter(host string) *rate.Limiter {
+	if t.RequestsPerSecond <= 0 {
+		return nil
+	}
+	limitersLock.Lock()
+	defer limitersLock.Unlock()
+	limiter := limiters[host]
+	if limiter == nil {
+		burst := int(t.RequestsPerSecond)
+		if burst < 1 {
+			burst = 1
+		}
+		limiter = rate.NewLimiter(rate.Limit(t.RequestsPerSecond), burst)
+		limiters[host] = limiter
+	}
+	return limiter
+}
+
+// backoff returns the wait before the retry honouring any Retry-After header of the response
+func (t *Transport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+		if err == nil && seconds >= 0 {
+			wait := time.Duration(seconds) * time.Second
+			if wait > maxRetryAfter {
+				wait = maxRetryAfter
+			}
+			return wait
+		}
+	}
+	return Backoff(t.RetryWait, attempt)
+}
+
+// Backoff returns the exponential backoff of the attempt with up to 50% of random jitter added so that clients
+// which failed at the same time do not retry in lock step
+func Backoff(wait time.Duration, attempt int) time.Duration {
+	answer := wait << uint(attempt)
+	if answer <= 0 {
+		return 0
+	}
+	return answer + time.Duration(rand.Int63n(int64(answer)/2+1))
+}
+
+func shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return !isPermanent(err)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// isPermanent returns true for errors such as an unknown host which retrying does not fix
+func isPermanent(err error) bool {
+	if opErr, ok := err.(*net.OpError); ok {
+		err = opErr.Err
+	}
+	dnsErr, ok := err.(*net.DNSError)
+	return ok && !dnsErr.Temporary() && !dnsErr.Timeout()
+}
+
+func isIdempotent(req *http.Request) bool {
+	switch req.Method {
+	case "", http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// redactURL returns the URL of the request without the query string and credentials which may contain secrets
+func redactURL(req *http.Request) string {
+	u := *req.URL
+	u.User = nil
+	if u.RawQuery != "" {
+		u.RawQuery = "..."
+	}
+	return u.String()
+}
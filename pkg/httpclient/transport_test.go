@@ -0,0 +1,87 @@
+package httpclient_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func flakyServer(failures int32) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	return server, &calls
+}
+
+func testClient() *http.Client {
+	transport := httpclient.NewTransport(nil)
+	transport.RetryWait = time.Millisecond
+	return &http.Client{Transport: transport}
+}
+
+func TestTransportRetriesIdempotentRequests(t *testing.T) {
+	t.Parallel()
+	server, calls := flakyServer(2)
+	defer server.Close()
+
+	resp, err := testClient().Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int32(3), atomic.LoadInt32(calls))
+}
+
+func TestTransportGivesUpAfterMaxRetries(t *testing.T) {
+	t.Parallel()
+	server, calls := flakyServer(10)
+	defer server.Close()
+
+	resp, err := testClient().Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(httpclient.DefaultMaxRetries+1), atomic.LoadInt32(calls))
+}
+
+func TestTransportDoesNotRetryPost(t *testing.T) {
+	t.Parallel()
+	server, calls := flakyServer(1)
+	defer server.Close()
+
+	resp, err := testClient().Post(server.URL, "text/plain", strings.NewReader("hello"))
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+	for attempt := 0; attempt < 4; attempt++ {
+		wait := httpclient.Backoff(100*time.Millisecond, attempt)
+		min := (100 * time.Millisecond) << uint(attempt)
+		assert.True(t, wait >= min && wait <= min+min/2, "attempt %d waited %s", attempt, wait)
+	}
+}
+
+func TestTransportDoesNotRetryUnknownHosts(t *testing.T) {
+	t.Parallel()
+	start := time.Now()
+	transport := httpclient.NewTransport(nil)
+	transport.RetryWait = time.Second
+	_, err := (&http.Client{Transport: transport}).Get("http://does-not-exist.invalid/")
+	assert.Error(t, err)
+	assert.True(t, time.Since(start) < time.Second, "an unknown host should not be retried")
+}
@@ -0,0 +1,365 @@
+package jenkins
+
+import (
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	jenkauth "github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/util"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// OIDCTokenURLEnvVar the environment variable for the OAuth token endpoint used to authenticate with Jenkins via OIDC
+	OIDCTokenURLEnvVar = "JENKINS_OIDC_TOKEN_URL"
+	// OIDCClientIDEnvVar the environment variable for the OIDC client ID
+	OIDCClientIDEnvVar = "JENKINS_OIDC_CLIENT_ID"
+	// OIDCClientSecretEnvVar the environment variable for the OIDC client secret
+	OIDCClientSecretEnvVar = "JENKINS_OIDC_CLIENT_SECRET"
+	// OIDCScopesEnvVar the environment variable for the comma separated OIDC scopes to request
+	OIDCScopesEnvVar = "JENKINS_OIDC_SCOPES"
+
+	crumbIssuerPath = "crumbIssuer/api/json"
+)
+
+// Authenticator adds the credentials of a Jenkins user to a request
+type Authenticator interface {
+	Authenticate(req *http.Request) error
+}
+
+// BasicAuthenticator authenticates using a username with either an API token or a password
+type BasicAuthenticator struct {
+	Username string
+	Token    string
+}
+
+// Authenticate adds the basic auth header
+func (a *BasicAuthenticator) Authenticate(req *http.Request) error {
+	req.SetBasicAuth(a.Username, a.Token)
+	return nil
+}
+
+// BearerAuthenticator authenticates using a static bearer token
+type BearerAuthenticator struct {
+	Token string
+}
+
+// Authenticate adds the bearer token header
+func (a *BearerAuthenticator) Authenticate(req *http.Request) error {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+	return nil
+}
+
+// OIDCAuthenticator authenticates using access tokens from an OAuth2/OIDC token source which are
+// refreshed when they expire
+type OIDCAuthenticator struct {
+	TokenSource oauth2.TokenSource
+}
+
+// NewOIDCAuthenticator creates an authenticator which uses the OAuth2 client credentials grant against
+// the given token endpoint to obtain access tokens
+func NewOIDCAuthenticator(tokenURL string, clientID string, clientSecret string, scopes []string) *OIDCAuthenticator {
+	source := &clientCredentialsTokenSource{
+		TokenURL:     tokenURL,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		Scopes:       scopes,
+	}
+	return &OIDCAuthenticator{
+		TokenSource: oauth2.ReuseTokenSource(nil, source),
+	}
+}
+
+// Authenticate adds the current access token as a bearer token
+func (a *OIDCAuthenticator) Authenticate(req *http.Request) error {
+	token, err := a.TokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("failed to obtain an OIDC token for Jenkins: %s", err)
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
+
+// clientCredentialsTokenSource requests tokens using the OAuth2 client credentials grant
+type clientCredentialsTokenSource struct {
+	TokenURL     string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+func (s *clientCredentialsTokenSource) Token() (*oauth2.Token, error) {
+	form := url.Values{}
+	form.Set("grant_type", "client_credentials")
+	if len(s.Scopes) > 0 {
+		form.Set("scope", strings.Join(s.Scopes, " "))
+	}
+	req, err := http.NewRequest(http.MethodPost, s.TokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.SetBasicAuth(url.QueryEscape(s.ClientID), url.QueryEscape(s.ClientSecret))
+
+	resp, err := httpclient.NewBaseClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("token endpoint %s returned status %s: %s", s.TokenURL, resp.Status, string(data))
+	}
+	result := struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the response of token endpoint %s: %s", s.TokenURL, err)
+	}
+	if result.AccessToken == "" {
+		return nil, fmt.Errorf("token endpoint %s did not return an access_token", s.TokenURL)
+	}
+	token := &oauth2.Token{
+		AccessToken: result.AccessToken,
+		TokenType:   result.TokenType,
+	}
+	if result.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
+
+// CreateAuthenticator returns the authenticator for the given user auth. An OIDC client configured via
+// the $JENKINS_OIDC_* environment variables takes precedence, then a bearer token, then the API token
+// and finally the password of the user
+func CreateAuthenticator(auth jenkauth.UserAuth) Authenticator {
+	tokenURL := os.Getenv(OIDCTokenURLEnvVar)
+	clientID := os.Getenv(OIDCClientIDEnvVar)
+	if tokenURL != "" && clientID != "" {
+		scopes := []string{}
+		for _, scope := range strings.Split(os.Getenv(OIDCScopesEnvVar), ",") {
+			scope = strings.TrimSpace(scope)
+			if scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+		return NewOIDCAuthenticator(tokenURL, clientID, os.Getenv(OIDCClientSecretEnvVar), scopes)
+	}
+	if auth.BearerToken != "" {
+		return &BearerAuthenticator{Token: auth.BearerToken}
+	}
+	token := auth.ApiToken
+	if token == "" {
+		token = auth.Password
+	}
+	return &BasicAuthenticator{Username: auth.Username, Token: token}
+}
+
+// IsOIDCConfigured returns true if the $JENKINS_OIDC_* environment variables configure an OIDC client
+func IsOIDCConfigured() bool {
+	return os.Getenv(OIDCTokenURLEnvVar) != "" && os.Getenv(OIDCClientIDEnvVar) != ""
+}
+
+// crumb the CSRF protection crumb returned by the Jenkins crumb issuer
+type crumb struct {
+	Crumb             string `json:"crumb"`
+	CrumbRequestField string `json:"crumbRequestField"`
+}
+
+// AuthTransport is a http.RoundTripper which authenticates every request to Jenkins and adds a CSRF crumb
+// to any modifying request. Crumbs are cached for the session and refreshed if Jenkins rejects them
+type AuthTransport struct {
+	JenkinsURL string
+	Auth       Authenticator
+	Base       http.RoundTripper
+	Jar        http.CookieJar
+
+	lock    sync.Mutex
+	crumb   *crumb
+	noCrumb bool
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *AuthTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.roundTrip(req, false)
+	if err != nil || !t.isCrumbRejected(req, resp) || (req.Body != nil && req.GetBody == nil) {
+		return resp, err
+	}
+	// the session or crumb has expired so lets get a new crumb and try again
+	resp.Body.Close()
+	t.lock.Lock()
+	t.crumb = nil
+	t.lock.Unlock()
+	return t.roundTrip(req, true)
+}
+
+func (t *AuthTransport) roundTrip(req *http.Request, retry bool) (*http.Response, error) {
+	r := cloneRequest(req)
+	if retry && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	if t.Auth != nil {
+		err := t.Auth.Authenticate(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if requiresCrumb(r) {
+		c, err := t.getCrumb()
+		if err != nil {
+			return nil, err
+		}
+		if c != nil {
+			r.Header.Set(c.CrumbRequestField, c.Crumb)
+			t.addSessionCookies(r)
+		}
+	}
+	return t.base().RoundTrip(r)
+}
+
+// cloneRequest returns a shallow copy of the request with its own headers so that a RoundTripper does not modify the
+// request of its caller
+func cloneRequest(req *http.Request) *http.Request {
+	r := new(http.Request)
+	*r = *req
+	r.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		r.Header[k] = append([]string(nil), v...)
+	}
+	return r
+}
+
+// addSessionCookies replaces the cookies of the request with those of the session the crumb was issued for
+// as the client adds its cookies before the crumb is requested
+func (t *AuthTransport) addSessionCookies(req *http.Request) {
+	if t.Jar == nil {
+		return
+	}
+	sessionCookies := t.Jar.Cookies(req.URL)
+	names := map[string]bool{}
+	for _, cookie := range sessionCookies {
+		names[cookie.Name] = true
+	}
+	cookies := req.Cookies()
+	req.Header.Del("Cookie")
+	for _, cookie := range cookies {
+		if !names[cookie.Name] {
+			req.AddCookie(cookie)
+		}
+	}
+	for _, cookie := range sessionCookies {
+		req.AddCookie(cookie)
+	}
+}
+
+func (t *AuthTransport) base() http.RoundTripper {
+	if t.Base != nil {
+		return t.Base
+	}
+	return httpclient.BaseTransport()
+}
+
+func (t *AuthTransport) isCrumbRejected(req *http.Request, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden || !requiresCrumb(req) {
+		return false
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.crumb != nil
+}
+
+func requiresCrumb(req *http.Request) bool {
+	return req.Method != http.MethodGet && req.Method != http.MethodHead && req.Method != http.MethodOptions
+}
+
+// getCrumb returns the cached crumb or requests a new one from the crumb issuer. Returns nil if CSRF
+// protection is disabled
+func (t *AuthTransport) getCrumb() (*crumb, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.noCrumb {
+		return nil, nil
+	}
+	if t.crumb != nil {
+		return t.crumb, nil
+	}
+
+	// crumbs are bound to the web session so we share the cookies with the caller's client
+	client := &http.Client{
+		Transport: &AuthTransport{Auth: t.Auth, Base: t.base(), noCrumb: true},
+		Jar:       t.Jar,
+	}
+	u := util.UrlJoin(t.JenkinsURL, crumbIssuerPath)
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get a crumb from %s: %s", u, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		t.noCrumb = true
+		return nil, nil
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to get a crumb from %s: status %s: %s", u, resp.Status, string(data))
+	}
+	c := &crumb{}
+	err = json.Unmarshal(data, c)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the crumb from %s: %s", u, err)
+	}
+	if c.Crumb == "" || c.CrumbRequestField == "" {
+		t.noCrumb = true
+		return nil, nil
+	}
+	t.crumb = c
+	return c, nil
+}
+
+// NewHTTPClient creates a HTTP client for the Jenkins server at the given URL which authenticates
+// using the given authenticator and handles CSRF crumbs. Redirects are not followed
+func NewHTTPClient(jenkinsURL string, auth Authenticator, insecureSkipVerify bool) *http.Client {
+	jar, _ := cookiejar.New(nil)
+	base := httpclient.BaseTransport()
+	if insecureSkipVerify {
+		base = &http.Transport{
+			Proxy:           http.ProxyFromEnvironment,
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}
+	}
+	return &http.Client{
+		Transport: &AuthTransport{
+			JenkinsURL: jenkinsURL,
+			Auth:       auth,
+			Base:       base,
+			Jar:        jar,
+		},
+		Jar: jar,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
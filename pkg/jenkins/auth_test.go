@@ -0,0 +1,130 @@
+package jenkins_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	jenkauth "github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHardenedJenkins returns a fake Jenkins server which requires basic auth and a crumb bound to the session
+func newHardenedJenkins(t *testing.T, scriptOutput string) *httptest.Server {
+	var crumbs int32
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, token, ok := r.BasicAuth()
+		if !ok || user != "admin" || token != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/crumbIssuer/api/json":
+			n := atomic.AddInt32(&crumbs, 1)
+			http.SetCookie(w, &http.Cookie{Name: "JSESSIONID", Value: fmt.Sprintf("session-%d", n), Path: "/"})
+			fmt.Fprintf(w, `{"crumb":"crumb-%d","crumbRequestField":"Jenkins-Crumb"}`, n)
+		case "/scriptText":
+			cookie, err := r.Cookie("JSESSIONID")
+			if err != nil || strings.TrimPrefix(cookie.Value, "session-") != strings.TrimPrefix(r.Header.Get("Jenkins-Crumb"), "crumb-") {
+				w.WriteHeader(http.StatusForbidden)
+				fmt.Fprint(w, "No valid crumb was included in the request")
+				return
+			}
+			require.NoError(t, r.ParseForm())
+			assert.NotEmpty(t, r.PostForm.Get("script"))
+			fmt.Fprint(w, scriptOutput)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestRunScriptWithCrumb(t *testing.T) {
+	t.Parallel()
+	server := newHardenedJenkins(t, "hello\n")
+	defer server.Close()
+
+	output, err := jenkins.RunScript(server.URL, "admin", "secret", "println 'hello'")
+	require.NoError(t, err)
+	assert.Equal(t, "hello\n", output)
+
+	_, err = jenkins.RunScript(server.URL, "admin", "wrong", "println 'hello'")
+	assert.Error(t, err)
+}
+
+func TestRunScriptWithoutCrumbIssuer(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/scriptText" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+		fmt.Fprint(w, "done")
+	}))
+	defer server.Close()
+
+	output, err := jenkins.RunScriptWithAuth(server.URL, &jenkins.BearerAuthenticator{Token: "my-token"}, "println 'done'")
+	require.NoError(t, err)
+	assert.Equal(t, "done", output)
+}
+
+func TestRotateAPIToken(t *testing.T) {
+	t.Parallel()
+	server := newHardenedJenkins(t, "token: 11abcdef\n")
+	defer server.Close()
+
+	token, err := jenkins.RotateAPIToken(server.URL, &jenkins.BasicAuthenticator{Username: "admin", Token: "secret"}, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, "11abcdef", token)
+
+	unknown := newHardenedJenkins(t, "unknown user\n")
+	defer unknown.Close()
+	_, err = jenkins.GenerateAPIToken(unknown.URL, &jenkins.BasicAuthenticator{Username: "admin", Token: "secret"}, "admin")
+	assert.Error(t, err)
+}
+
+func TestGenerateAPITokenScript(t *testing.T) {
+	t.Parallel()
+	script := jenkins.GenerateAPITokenScript("o'brien", "jx-1", jenkins.APITokenNamePrefix)
+	assert.Contains(t, script, `User.get('o\'brien', false, [:])`)
+	assert.Contains(t, script, `store.generateNewToken('jx-1')`)
+	assert.Contains(t, script, `def revokePrefix = 'jx-'`)
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "openid jenkins", r.PostForm.Get("scope"))
+		id, secret, _ := r.BasicAuth()
+		assert.Equal(t, "jx", id)
+		assert.Equal(t, "shh", secret)
+		fmt.Fprint(w, `{"access_token":"oidc-token","token_type":"Bearer","expires_in":3600}`)
+	}))
+	defer tokenServer.Close()
+
+	authenticator := jenkins.NewOIDCAuthenticator(tokenServer.URL, "jx", "shh", []string{"openid", "jenkins"})
+	for i := 0; i < 2; i++ {
+		req, err := http.NewRequest(http.MethodGet, "http://jenkins/api/json", nil)
+		require.NoError(t, err)
+		require.NoError(t, authenticator.Authenticate(req))
+		assert.Equal(t, "Bearer oidc-token", req.Header.Get("Authorization"))
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests), "the token should be reused until it expires")
+}
+
+func TestCreateAuthenticator(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, &jenkins.BearerAuthenticator{Token: "bearer"}, jenkins.CreateAuthenticator(jenkauth.UserAuth{BearerToken: "bearer", Username: "admin"}))
+	assert.Equal(t, &jenkins.BasicAuthenticator{Username: "admin", Token: "token"}, jenkins.CreateAuthenticator(jenkauth.UserAuth{Username: "admin", ApiToken: "token", Password: "pwd"}))
+	assert.Equal(t, &jenkins.BasicAuthenticator{Username: "admin", Token: "pwd"}, jenkins.CreateAuthenticator(jenkauth.UserAuth{Username: "admin", Password: "pwd"}))
+}
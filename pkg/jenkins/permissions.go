@@ -0,0 +1,132 @@
+package jenkins
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// permissionsForRole maps the team role names onto the Jenkins matrix authorization permissions
+var permissionsForRole = map[string][]string{
+	kube.TeamRoleAdmin: {
+		"hudson.model.Hudson.Administer",
+	},
+	kube.TeamRoleDeveloper: {
+		"hudson.model.Hudson.Read",
+		"hudson.model.Item.Build",
+		"hudson.model.Item.Cancel",
+		"hudson.model.Item.Configure",
+		"hudson.model.Item.Discover",
+		"hudson.model.Item.Read",
+		"hudson.model.Item.Workspace",
+		"hudson.model.Run.Replay",
+		"hudson.model.Run.Update",
+		"hudson.model.View.Read",
+	},
+	kube.TeamRoleViewer: {
+		"hudson.model.Hudson.Read",
+		"hudson.model.Item.Discover",
+		"hudson.model.Item.Read",
+		"hudson.model.View.Read",
+	},
+}
+
+// PermissionsForRoles returns the sorted Jenkins permission IDs that should be granted for the given team roles.
+// Roles which have no Jenkins equivalent are ignored
+func PermissionsForRoles(roles []string) []string {
+	answer := []string{}
+	for _, role := range roles {
+		for _, p := range permissionsForRole[role] {
+			if util.StringArrayIndex(answer, p) < 0 {
+				answer = append(answer, p)
+			}
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// UserPermissionsScript returns the groovy script which replaces the global matrix authorization
+// permissions of the given user with the given permission IDs
+func UserPermissionsScript(userName string, permissions []string) string {
+	quoted := []string{}
+	for _, p := range permissions {
+		quoted = append(quoted, fmt.Sprintf("'%s'", p))
+	}
+	return fmt.Sprintf(`import hudson.security.*
+import jenkins.model.Jenkins
+
+def jenkins = Jenkins.getInstance()
+def strategy = jenkins.getAuthorizationStrategy()
+if (!(strategy instanceof GlobalMatrixAuthorizationStrategy)) {
+  println "unsupported authorization strategy: " + strategy.getClass().getName()
+  return
+}
+def user = '%s'
+def grants = strategy.getGrantedPermissions()
+grants.each { permission, sids -> sids.remove(user) }
+[%s].each { id ->
+  def permission = Permission.fromId(id)
+  if (permission != null) {
+    strategy.add(permission, user)
+  }
+}
+jenkins.save()
+println "updated permissions for " + user
+`, escapeGroovyString(userName), strings.Join(quoted, ", "))
+}
+
+// UpdateUserPermissions updates the permissions of the user in the Jenkins server at the given URL
+// using the script console authenticated via the given admin user and API token
+func UpdateUserPermissions(jenkinsURL string, adminUser string, apiToken string, userName string, permissions []string) error {
+	output, err := RunScript(jenkinsURL, adminUser, apiToken, UserPermissionsScript(userName, permissions))
+	if err != nil {
+		return err
+	}
+	if strings.HasPrefix(output, "unsupported authorization strategy") {
+		return fmt.Errorf("cannot update the permissions of user %s as Jenkins at %s has an %s; please enable matrix based security", userName, jenkinsURL, strings.TrimSpace(output))
+	}
+	return nil
+}
+
+// RunScript runs the given groovy script in the script console of the Jenkins server and returns its output
+func RunScript(jenkinsURL string, userName string, apiToken string, script string) (string, error) {
+	return RunScriptWithAuth(jenkinsURL, &BasicAuthenticator{Username: userName, Token: apiToken}, script)
+}
+
+// RunScriptWithAuth runs the given groovy script in the script console of the Jenkins server using the given
+// authenticator and returns its output. A CSRF crumb is included if the Jenkins server requires one
+func RunScriptWithAuth(jenkinsURL string, auth Authenticator, script string) (string, error) {
+	u := util.UrlJoin(jenkinsURL, "scriptText")
+	form := url.Values{}
+	form.Set("script", script)
+	req, err := http.NewRequest(http.MethodPost, u, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := NewHTTPClient(jenkinsURL, auth, false).Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to invoke %s: %s", u, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("failed to run script on Jenkins at %s: status %s: %s", jenkinsURL, resp.Status, string(data))
+	}
+	return string(data), nil
+}
+
+func escapeGroovyString(text string) string {
+	return strings.Replace(strings.Replace(text, `\`, `\\`, -1), "'", `\'`, -1)
+}
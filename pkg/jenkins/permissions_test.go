@@ -0,0 +1,30 @@
+package jenkins_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPermissionsForRoles(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"hudson.model.Hudson.Administer"}, jenkins.PermissionsForRoles([]string{kube.TeamRoleAdmin}))
+
+	viewer := jenkins.PermissionsForRoles([]string{kube.TeamRoleViewer})
+	developer := jenkins.PermissionsForRoles([]string{kube.TeamRoleDeveloper, kube.TeamRoleViewer})
+	assert.Contains(t, viewer, "hudson.model.Item.Read")
+	assert.NotContains(t, viewer, "hudson.model.Item.Build")
+	assert.Contains(t, developer, "hudson.model.Item.Build")
+	assert.Equal(t, developer, jenkins.PermissionsForRoles([]string{kube.TeamRoleDeveloper}), "permissions should not be duplicated")
+
+	assert.Empty(t, jenkins.PermissionsForRoles([]string{"some-custom-role"}))
+}
+
+func TestUserPermissionsScript(t *testing.T) {
+	t.Parallel()
+	script := jenkins.UserPermissionsScript("o'brien", []string{"hudson.model.Hudson.Read"})
+	assert.Contains(t, script, `def user = 'o\'brien'`)
+	assert.Contains(t, script, `['hudson.model.Hudson.Read'].each`)
+}
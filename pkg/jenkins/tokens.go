@@ -0,0 +1,81 @@
+package jenkins
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// APITokenNamePrefix the prefix of the names of the API tokens generated by jx so that they can be rotated
+	APITokenNamePrefix = "jx-"
+
+	apiTokenOutputPrefix = "token: "
+)
+
+// GenerateAPITokenScript returns the groovy script which generates a new named API token for the given user
+// and prints it. If revokePrefix is not empty then any other tokens of the user with a name starting with the
+// prefix are revoked
+func GenerateAPITokenScript(userName string, tokenName string, revokePrefix string) string {
+	return fmt.Sprintf(`import hudson.model.User
+import jenkins.security.ApiTokenProperty
+
+def user = User.get('%s', false, [:])
+if (user == null) {
+  println "unknown user"
+  return
+}
+def property = user.getProperty(ApiTokenProperty.class)
+if (property == null) {
+  property = new ApiTokenProperty()
+  user.addProperty(property)
+}
+def store = property.getTokenStore()
+def result = store.generateNewToken('%s')
+def revokePrefix = '%s'
+if (revokePrefix) {
+  store.getTokenListSortedByName().findAll { it.name.startsWith(revokePrefix) && it.uuid != result.tokenUuid }.each {
+    store.revokeToken(it.uuid)
+  }
+}
+user.save()
+println "%s" + result.plainValue
+`, escapeGroovyString(userName), escapeGroovyString(tokenName), escapeGroovyString(revokePrefix), apiTokenOutputPrefix)
+}
+
+// NewAPITokenName returns a unique name for an API token generated by jx
+func NewAPITokenName() string {
+	return APITokenNamePrefix + time.Now().UTC().Format("20060102-150405")
+}
+
+// GenerateAPIToken generates a new API token for the given user via the script console of the Jenkins server,
+// authenticating with the given authenticator such as the admin user and password
+func GenerateAPIToken(jenkinsURL string, auth Authenticator, userName string) (string, error) {
+	return runAPITokenScript(jenkinsURL, auth, userName, GenerateAPITokenScript(userName, NewAPITokenName(), ""))
+}
+
+// RotateAPIToken generates a new API token for the given user via the script console of the Jenkins server
+// then revokes any previous API tokens which were generated by jx
+func RotateAPIToken(jenkinsURL string, auth Authenticator, userName string) (string, error) {
+	return runAPITokenScript(jenkinsURL, auth, userName, GenerateAPITokenScript(userName, NewAPITokenName(), APITokenNamePrefix))
+}
+
+func runAPITokenScript(jenkinsURL string, auth Authenticator, userName string, script string) (string, error) {
+	output, err := RunScriptWithAuth(jenkinsURL, auth, script)
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, apiTokenOutputPrefix) {
+			token := strings.TrimPrefix(line, apiTokenOutputPrefix)
+			if token != "" {
+				return token, nil
+			}
+		}
+	}
+	if strings.HasPrefix(strings.TrimSpace(output), "unknown user") {
+		return "", fmt.Errorf("no user %s exists in Jenkins at %s", userName, jenkinsURL)
+	}
+	return "", fmt.Errorf("failed to generate an API token for user %s in Jenkins at %s: %s", userName, jenkinsURL, strings.TrimSpace(output))
+}
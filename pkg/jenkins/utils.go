@@ -1,11 +1,9 @@
 package jenkins
 
 import (
-	"crypto/tls"
 	"errors"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 
 	"github.com/jenkins-x/golang-jenkins"
@@ -26,7 +24,7 @@ func GetJenkinsClient(url string, batch bool, configService *jenkauth.AuthConfig
 	config := configService.Config()
 
 	showForm := false
-	if auth.IsInvalid() {
+	if auth.IsInvalid() && !IsOIDCConfigured() {
 		// lets try load the current auth
 		config, err = configService.LoadConfig()
 		if err != nil {
@@ -56,7 +54,7 @@ func GetJenkinsClient(url string, batch bool, configService *jenkauth.AuthConfig
 		}
 	}
 
-	if auth.IsInvalid() {
+	if auth.IsInvalid() && !IsOIDCConfigured() {
 		if showForm {
 			return nil, fmt.Errorf("No valid Username and API Token specified for Jenkins server: %s\n", url)
 		} else {
@@ -70,22 +68,11 @@ func GetJenkinsClient(url string, batch bool, configService *jenkauth.AuthConfig
 		}
 	}
 
-	jauth := &gojenkins.Auth{
-		Username:    auth.Username,
-		ApiToken:    auth.ApiToken,
-		BearerToken: auth.BearerToken,
-	}
-	jenkins := gojenkins.NewJenkins(jauth, url)
+	// authentication and CSRF crumbs are handled by the HTTP client
+	jenkins := gojenkins.NewJenkins(nil, url)
 
 	// handle insecure TLS for minishift
-	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
-		CheckRedirect: func(req *http.Request, via []*http.Request) error {
-			return http.ErrUseLastResponse
-		}}
-	jenkins.SetHTTPClient(httpClient)
+	jenkins.SetHTTPClient(NewHTTPClient(url, CreateAuthenticator(auth), true))
 	return jenkins, nil
 }
 
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addonLong = templates.LongDesc(`
+		Manages the lifecycle of the addons of the platform.
+
+		An addon is a chart installed with default values along with hooks which check its prerequisites before
+		it is installed and configure the platform once it is installed, such as registering webhooks. The state
+		of each addon is tracked in an Addon resource in the development namespace of the team.
+`)
+
+	addonExample = templates.Examples(`
+		# List the available and installed addons
+		jx addon list
+
+		# Install the prow addon
+		jx addon install prow
+
+		# Upgrade all the installed addons
+		jx addon upgrade
+
+		# Remove the prow addon
+		jx addon remove prow
+`)
+)
+
+// AddonOptions the options for the "addon" command
+type AddonOptions struct {
+	CommonOptions
+}
+
+// NewCmdAddon creates a command object for the "addon" command
+func NewCmdAddon(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AddonOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "addon",
+		Short:   "Lists, installs, upgrades and removes addons",
+		Long:    addonLong,
+		Example: addonExample,
+		Aliases: []string{"addons"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdAddonList(f, out, errOut))
+	cmd.AddCommand(NewCmdAddonInstall(f, out, errOut))
+	cmd.AddCommand(NewCmdAddonUpgrade(f, out, errOut))
+	cmd.AddCommand(NewCmdAddonRemove(f, out, errOut))
+	return cmd
+}
+
+// Run implements the "addon" command
+func (o *AddonOptions) Run() error {
+	return o.Cmd.Help()
+}
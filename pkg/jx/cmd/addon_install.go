@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addonInstallLong = templates.LongDesc(`
+		Installs one or more addons running their pre-install checks before installing the chart of the addon and
+		their post-install configuration afterwards. Any addons required by an addon are installed first.
+`)
+
+	addonInstallExample = templates.Examples(`
+		# Install the prow addon
+		jx addon install prow
+
+		# Install a specific version of the grafana addon into a namespace with some chart values
+		jx addon install grafana --version 1.13.1 -n monitoring --set persistence.enabled=true
+`)
+)
+
+// AddonInstallOptions the options for the "addon install" command
+type AddonInstallOptions struct {
+	AddonOptions
+
+	Namespace string
+	Version   string
+	SetValues string
+}
+
+// NewCmdAddonInstall creates a command object for the "addon install" command
+func NewCmdAddonInstall(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AddonInstallOptions{
+		AddonOptions: AddonOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "install [name...]",
+		Short:   "Installs one or more addons",
+		Long:    addonInstallLong,
+		Example: addonInstallExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to install the addon into. Defaults to the development namespace of the team")
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The version of the chart of the addon. Defaults to the version declared by the addon or the latest version")
+	cmd.Flags().StringVarP(&options.SetValues, "set", "s", "", "The chart set values (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the "addon install" command
+func (o *AddonInstallOptions) Run() error {
+	if len(o.Args) == 0 {
+		return o.Cmd.Help()
+	}
+	values := []string{}
+	if o.SetValues != "" {
+		values = strings.Split(o.SetValues, ",")
+	}
+	for _, name := range o.Args {
+		definition, err := findAddonDefinition(name)
+		if err != nil {
+			return err
+		}
+		err = o.installAddonDefinition(definition, o.Namespace, o.Version, values)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
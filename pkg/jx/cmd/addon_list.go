@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addonListLong = templates.LongDesc(`
+		Lists the available addons along with the version and lifecycle phase of the installed addons
+`)
+
+	addonListExample = templates.Examples(`
+		# List the available and installed addons
+		jx addon list
+`)
+)
+
+// AddonListOptions the options for the "addon list" command
+type AddonListOptions struct {
+	AddonOptions
+}
+
+// NewCmdAddonList creates a command object for the "addon list" command
+func NewCmdAddonList(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AddonListOptions{
+		AddonOptions: AddonOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the available and installed addons",
+		Long:    addonListLong,
+		Example: addonListExample,
+		Aliases: []string{"ls"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements the "addon list" command
+func (o *AddonListOptions) Run() error {
+	err := o.registerAddonCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	addons, _, err := kube.GetAddons(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	definitions := AddonDefinitions()
+	names := []string{}
+	for name := range definitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "CHART", "VERSION", "NAMESPACE", "PHASE", "MESSAGE")
+	for _, name := range names {
+		definition := definitions[name]
+		addon := addons[name]
+		if addon == nil {
+			table.AddRow(name, definition.Chart, "", "", "", "")
+			continue
+		}
+		table.AddRow(name, addon.Spec.Chart, addon.Status.Version, addon.Spec.Namespace, string(addon.Status.Phase), addon.Status.Message)
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addonRemoveLong = templates.LongDesc(`
+		Removes one or more installed addons running their remove hooks, such as reverting the configuration of
+		the platform, and deleting their chart. An addon which is required by another installed addon can only be
+		removed after that addon, so remove them in the same command or the dependent addon first.
+`)
+
+	addonRemoveExample = templates.Examples(`
+		# Remove the prow addon
+		jx addon remove prow
+
+		# Remove prow along with knative build which it requires
+		jx addon remove prow knative-build
+`)
+)
+
+// AddonRemoveOptions the options for the "addon remove" command
+type AddonRemoveOptions struct {
+	AddonOptions
+
+	Purge bool
+}
+
+// NewCmdAddonRemove creates a command object for the "addon remove" command
+func NewCmdAddonRemove(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AddonRemoveOptions{
+		AddonOptions: AddonOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "remove [name...]",
+		Short:   "Removes one or more installed addons",
+		Long:    addonRemoveLong,
+		Example: addonRemoveExample,
+		Aliases: []string{"rm", "delete"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Purge, "purge", "p", true, "Removes the release name from helm so it can be reused again")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the "addon remove" command
+func (o *AddonRemoveOptions) Run() error {
+	if len(o.Args) == 0 {
+		return o.Cmd.Help()
+	}
+	err := o.registerAddonCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	addons, installed, err := kube.GetAddons(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	definitions := AddonDefinitions()
+	for _, name := range o.Args {
+		definition, err := findAddonDefinition(name)
+		if err != nil {
+			return err
+		}
+		addon := addons[name]
+		if addon == nil {
+			return util.InvalidArg(name, installed)
+		}
+		dependents := AddonDependents(definitions, addons, name)
+		if len(dependents) > 0 {
+			return fmt.Errorf("cannot remove addon %s as it is required by the installed addons %s, remove them first",
+				name, strings.Join(dependents, ", "))
+		}
+		err = o.removeAddon(definition, addon, o.Purge)
+		if err != nil {
+			return err
+		}
+		delete(addons, name)
+		log.Infof("Removed addon %s\n", util.ColorInfo(name))
+	}
+	return nil
+}
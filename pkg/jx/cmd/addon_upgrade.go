@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addonUpgradeLong = templates.LongDesc(`
+		Upgrades the charts of installed addons re-running their install hooks.
+
+		If no addon names are given then all of the installed addons are upgraded.
+`)
+
+	addonUpgradeExample = templates.Examples(`
+		# Upgrade all the installed addons
+		jx addon upgrade
+
+		# Upgrade the prow addon to a specific version
+		jx addon upgrade prow --version 0.0.30
+`)
+)
+
+// AddonUpgradeOptions the options for the "addon upgrade" command
+type AddonUpgradeOptions struct {
+	AddonOptions
+
+	Version   string
+	SetValues string
+}
+
+// NewCmdAddonUpgrade creates a command object for the "addon upgrade" command
+func NewCmdAddonUpgrade(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AddonUpgradeOptions{
+		AddonOptions: AddonOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "upgrade [name...]",
+		Short:   "Upgrades installed addons",
+		Long:    addonUpgradeLong,
+		Example: addonUpgradeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The version of the chart to upgrade a single addon to. Defaults to the version declared by the addon or the latest version")
+	cmd.Flags().StringVarP(&options.SetValues, "set", "s", "", "Additional chart set values (can specify multiple or separate values with commas: key1=val1,key2=val2)")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the "addon upgrade" command
+func (o *AddonUpgradeOptions) Run() error {
+	err := o.registerAddonCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	addons, installed, err := kube.GetAddons(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	names := o.Args
+	if len(names) == 0 {
+		names = installed
+		if len(names) == 0 {
+			log.Infof("There are no installed addons to upgrade\n")
+			return nil
+		}
+	}
+	if o.Version != "" && len(names) > 1 {
+		return fmt.Errorf("the --version option can only be used when upgrading a single addon")
+	}
+	values := []string{}
+	if o.SetValues != "" {
+		values = strings.Split(o.SetValues, ",")
+	}
+	for _, name := range names {
+		definition, err := findAddonDefinition(name)
+		if err != nil {
+			return err
+		}
+		addon := addons[name]
+		if addon == nil {
+			return util.InvalidArg(name, installed)
+		}
+		version := o.Version
+		if version == "" {
+			version = definition.Version
+		}
+		addon.Spec.Chart = definition.Chart
+		addon.Spec.Version = version
+		addon.Spec.Values = append(addon.Spec.Values, values...)
+		log.Infof("Upgrading addon %s\n", util.ColorInfo(name))
+		err = o.applyAddon(definition, addon)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
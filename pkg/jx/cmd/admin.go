@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// Admin contains the command line options
+type Admin struct {
+	CommonOptions
+}
+
+var (
+	adminLong = templates.LongDesc(`
+		Administration tasks such as preparing an offline installation of Jenkins X.
+`)
+
+	adminExample = templates.Examples(`
+		# Export the latest versions of the tools jx installs for an offline install
+		jx admin export versions
+
+		# Export the Secrets of the team encrypted with a cloud KMS key
+		jx admin export secrets --kms-key backup
+
+		# Migrate the team from Jenkins to Prow
+		jx admin migrate prow
+	`)
+)
+
+// NewCmdAdmin creates the command object
+func NewCmdAdmin(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &Admin{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "admin TYPE [flags]",
+		Short:   "Administration tasks such as preparing an offline installation",
+		Long:    adminLong,
+		Example: adminExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdAdminExport(f, out, errOut))
+	cmd.AddCommand(NewCmdAdminImport(f, out, errOut))
+	cmd.AddCommand(NewCmdAdminMigrate(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *Admin) Run() error {
+	return o.Cmd.Help()
+}
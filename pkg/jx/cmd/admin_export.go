@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// AdminExport contains the command line options
+type AdminExport struct {
+	CommonOptions
+}
+
+var (
+	adminExportLong = templates.LongDesc(`
+		Exports metadata on a connected machine which is used by offline installations or the Secrets of the team
+		encrypted with a cloud KMS key.
+`)
+
+	adminExportExample = templates.Examples(`
+		# Export the latest versions of the tools jx installs
+		jx admin export versions
+
+		# Export the Secrets of the team encrypted with a cloud KMS key
+		jx admin export secrets --kms-key backup
+	`)
+)
+
+// NewCmdAdminExport creates the command object
+func NewCmdAdminExport(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminExport{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "export TYPE [flags]",
+		Short:   "Exports metadata used by offline installations or the encrypted Secrets of the team",
+		Long:    adminExportLong,
+		Example: adminExportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdAdminExportSecrets(f, out, errOut))
+	cmd.AddCommand(NewCmdAdminExportVersions(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminExport) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/cloud/kms"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/util/buckets"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	adminExportSecretsLong = templates.LongDesc(`
+		Exports the Secrets of the team to a file encrypted with a cloud KMS key so that the team settings can be
+		restored via 'jx admin import secrets'.
+
+		The Secrets are encrypted with a random data key which is itself encrypted by the KMS key of Google Cloud KMS,
+		AWS KMS or Azure Key Vault, so the file can only be decrypted by someone with access to the KMS key rather than
+		by anyone who knows a passphrase.
+
+		The key can be specified by its URL or just by its name in which case it is resolved via the cloud provider of
+		the cluster and the provider credentials configured for the cluster in the '` + buckets.CredentialsSecretName + `' Secret:
+
+		* GKE: gcpkms://projects/PROJECT/locations/` + kms.DefaultGCPLocation + `/keyRings/` + kms.DefaultGCPKeyRing + `/cryptoKeys/NAME
+		* EKS: awskms://alias/NAME?region=REGION
+		* AKS: azurekeyvault://VAULT/keys/KEY where the name is 'VAULT/KEY'
+`)
+
+	adminExportSecretsExample = templates.Examples(`
+		# Export the Secrets of the team encrypted with a key of the KMS of the cluster's cloud provider
+		jx admin export secrets --kms-key backup
+
+		# Export the Secrets encrypted with an AWS KMS key
+		jx admin export secrets --kms-key awskms://alias/backup?region=us-east-1 -o secrets.yaml.enc
+	`)
+)
+
+// AdminExportSecretsOptions the options for the command
+type AdminExportSecretsOptions struct {
+	CommonOptions
+	KMSFlags
+
+	OutFile   string
+	Namespace string
+	Selector  string
+}
+
+// NewCmdAdminExportSecrets creates the command
+func NewCmdAdminExportSecrets(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminExportSecretsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "secrets",
+		Short:   "Exports the Secrets of the team encrypted with a cloud KMS key",
+		Aliases: []string{"secret"},
+		Long:    adminExportSecretsLong,
+		Example: adminExportSecretsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.OutFile, "output", "o", "jx-secrets.yaml"+kms.EncryptedFileExtension, "The file the encrypted Secrets are written to")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the Secrets. Defaults to the team's development namespace")
+	cmd.Flags().StringVarP(&options.Selector, "selector", "l", "", "The label selector of the Secrets to export")
+	options.KMSFlags.addFlags(cmd)
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminExportSecretsOptions) Run() error {
+	m, err := o.createKMSManager(o.Key, o.Provider)
+	if err != nil {
+		return err
+	}
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Namespace != "" {
+		ns = o.Namespace
+	}
+	list, err := kubeClient.CoreV1().Secrets(ns).List(metav1.ListOptions{LabelSelector: o.Selector})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the Secrets in namespace %s", ns)
+	}
+	export := &corev1.SecretList{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "SecretList",
+			APIVersion: "v1",
+		},
+	}
+	for _, secret := range list.Items {
+		if secret.Type == corev1.SecretTypeServiceAccountToken {
+			// recreated by kubernetes for each service account
+			continue
+		}
+		export.Items = append(export.Items, corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        secret.Name,
+				Labels:      secret.Labels,
+				Annotations: secret.Annotations,
+			},
+			Type: secret.Type,
+			Data: secret.Data,
+		})
+	}
+	data, err := yaml.Marshal(export)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the Secrets")
+	}
+	sealed, err := m.Seal(data)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(o.OutFile, sealed, 0600)
+	if err != nil {
+		return errors.Wrapf(err, "failed to write %s", o.OutFile)
+	}
+	log.Infof("Exported %d Secrets from namespace %s to %s encrypted with %s\n", len(export.Items), util.ColorInfo(ns),
+		util.ColorInfo(o.OutFile), util.ColorInfo(m.Key.URL()))
+	return nil
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// versionManifestRepositories the GitHub repositories whose latest release jx looks up when installing tools
+var versionManifestRepositories = []string{
+	"aquasecurity/trivy",
+	"hashicorp/terraform",
+	"jenkins-x/jx",
+	"kubernetes/helm",
+	"kubernetes/kops",
+	"kubernetes/minikube",
+	"minishift/minishift",
+	"vapor-ware/ksync",
+	"weaveworks/eksctl",
+}
+
+var (
+	adminExportVersionsLong = templates.LongDesc(`
+		Exports the latest release versions of the tools jx installs from GitHub to a JSON version manifest.
+
+		Copy the manifest to an air-gapped network and point jx at it via the $` + util.VersionManifestEnvVar + ` environment
+		variable, which may be a file or an http(s) URL. jx then reads the versions from the manifest instead of
+		contacting the GitHub API.
+`)
+
+	adminExportVersionsExample = templates.Examples(`
+		# Export the versions on a connected machine
+		jx admin export versions -o jx-versions.json
+
+		# Use the versions when installing offline
+		export ` + util.VersionManifestEnvVar + `=/mnt/share/jx-versions.json
+		jx install --provider kubernetes
+
+		# Also export the latest version of another repository
+		jx admin export versions --repo acme/tool
+	`)
+)
+
+// AdminExportVersionsOptions the options for the command
+type AdminExportVersionsOptions struct {
+	CommonOptions
+
+	OutFile      string
+	Repositories []string
+}
+
+// NewCmdAdminExportVersions creates the command
+func NewCmdAdminExportVersions(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminExportVersionsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "versions",
+		Short:   "Exports the latest release versions of the tools jx installs for offline installations",
+		Aliases: []string{"version"},
+		Long:    adminExportVersionsLong,
+		Example: adminExportVersionsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.OutFile, "output", "o", "jx-versions.json", "The file the version manifest is written to")
+	cmd.Flags().StringArrayVarP(&options.Repositories, "repo", "r", []string{}, "Additional 'owner/repo' GitHub repositories to export the latest release of")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminExportVersionsOptions) Run() error {
+	repositories := append([]string{}, versionManifestRepositories...)
+	for _, repo := range o.Repositories {
+		paths := strings.Split(strings.Trim(repo, "/"), "/")
+		if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+			return util.InvalidOptionf("repo", repo, "expected a repository of the form owner/repo")
+		}
+		if util.StringArrayIndex(repositories, paths[0]+"/"+paths[1]) < 0 {
+			repositories = append(repositories, paths[0]+"/"+paths[1])
+		}
+	}
+	manifest := &util.VersionManifest{
+		Generated: time.Now().UTC(),
+	}
+	for _, repo := range repositories {
+		paths := strings.Split(repo, "/")
+		version, err := util.GetLatestReleaseFromGitHubAPI(paths[0], paths[1])
+		if err != nil {
+			return err
+		}
+		manifest.SetVersion(paths[0], paths[1], version)
+		log.Infof("%s %s\n", util.PadRight(repo, " ", 24), util.ColorInfo(version))
+	}
+	err := manifest.SaveVersionManifest(o.OutFile)
+	if err != nil {
+		return err
+	}
+	log.Infof("Saved the versions of %d repositories to %s\n", len(repositories), util.ColorInfo(o.OutFile))
+	return nil
+}
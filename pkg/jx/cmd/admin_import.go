@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// AdminImport contains the command line options
+type AdminImport struct {
+	CommonOptions
+}
+
+var (
+	adminImportLong = templates.LongDesc(`
+		Imports resources previously exported via 'jx admin export'.
+`)
+
+	adminImportExample = templates.Examples(`
+		# Restore the Secrets of the team from an encrypted export
+		jx admin import secrets -f jx-secrets.yaml.enc
+	`)
+)
+
+// NewCmdAdminImport creates the command object
+func NewCmdAdminImport(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminImport{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "import TYPE [flags]",
+		Short:   "Imports resources exported via 'jx admin export'",
+		Long:    adminImportLong,
+		Example: adminImportExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdAdminImportSecrets(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminImport) Run() error {
+	return o.Cmd.Help()
+}
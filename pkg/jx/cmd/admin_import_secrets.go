@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"io"
+	"io/ioutil"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	adminImportSecretsLong = templates.LongDesc(`
+		Imports the Secrets exported via 'jx admin export secrets' creating or updating each Secret.
+
+		The file is decrypted with the cloud KMS key recorded in the file, so the provider credentials configured for
+		the cluster or the local cloud CLI must have access to the key.
+`)
+
+	adminImportSecretsExample = templates.Examples(`
+		# Restore the Secrets of the team
+		jx admin import secrets -f jx-secrets.yaml.enc
+
+		# Restore the Secrets into another namespace
+		jx admin import secrets -f jx-secrets.yaml.enc -n jx-restore
+	`)
+)
+
+// AdminImportSecretsOptions the options for the command
+type AdminImportSecretsOptions struct {
+	CommonOptions
+
+	File      string
+	Namespace string
+}
+
+// NewCmdAdminImportSecrets creates the command
+func NewCmdAdminImportSecrets(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminImportSecretsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "secrets",
+		Short:   "Imports the Secrets of the team from a file encrypted with a cloud KMS key",
+		Aliases: []string{"secret"},
+		Long:    adminImportSecretsLong,
+		Example: adminImportSecretsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.File, "file", "f", "", "The file exported via 'jx admin export secrets'")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to import the Secrets into. Defaults to the team's development namespace")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminImportSecretsOptions) Run() error {
+	if o.File == "" {
+		return util.MissingOption("file")
+	}
+	sealed, err := ioutil.ReadFile(o.File)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", o.File)
+	}
+	data, err := o.openKMSSealed(sealed)
+	if err != nil {
+		return errors.Wrapf(err, "failed to decrypt %s", o.File)
+	}
+	list := &corev1.SecretList{}
+	err = yaml.Unmarshal(data, list)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the Secrets in %s", o.File)
+	}
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Namespace != "" {
+		ns = o.Namespace
+	}
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	for i := range list.Items {
+		secret := &list.Items[i]
+		secret.Namespace = ns
+		existing, err := secrets.Get(secret.Name, metav1.GetOptions{})
+		if err != nil {
+			if !apierrors.IsNotFound(err) {
+				return err
+			}
+			_, err = secrets.Create(secret)
+		} else {
+			existing.Labels = secret.Labels
+			existing.Annotations = secret.Annotations
+			existing.Data = secret.Data
+			_, err = secrets.Update(existing)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to save Secret %s in namespace %s", secret.Name, ns)
+		}
+	}
+	log.Infof("Imported %d Secrets into namespace %s\n", len(list.Items), util.ColorInfo(ns))
+	return nil
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// AdminMigrate contains the command line options
+type AdminMigrate struct {
+	CommonOptions
+}
+
+var (
+	adminMigrateLong = templates.LongDesc(`
+		Migrates the team to a different engine such as from Jenkins to Prow.
+`)
+
+	adminMigrateExample = templates.Examples(`
+		# Migrate the team from Jenkins to Prow
+		jx admin migrate prow
+	`)
+)
+
+// NewCmdAdminMigrate creates the command object
+func NewCmdAdminMigrate(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminMigrate{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "migrate TYPE [flags]",
+		Short:   "Migrates the team to a different engine",
+		Long:    adminMigrateLong,
+		Example: adminMigrateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdAdminMigrateProw(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminMigrate) Run() error {
+	return o.Cmd.Help()
+}
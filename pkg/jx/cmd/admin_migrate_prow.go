@@ -0,0 +1,383 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	adminMigrateProwLong = templates.LongDesc(`
+		Migrates the team from Jenkins to Prow as its webhook and promotion engine.
+
+		Prow must already be installed via 'jx create addon prow'. The migration then:
+
+		* registers the git repositories of the apps and environments in the Prow configuration, using the build image
+		  of the pod template of the Jenkinsfile of each app
+		* creates the Prow webhook on each repository
+		* removes the obsolete Jenkins webhooks from each repository
+		* switches the team settings to use Prow
+
+		The apps are found from the multi branch projects in Jenkins, the SourceRepository resources of the team and
+		any '--repo' options.
+
+		Use '--rollback' to switch the team back to Jenkins, recreating the Jenkins webhooks and removing the Prow
+		webhooks. The Prow configuration of the repositories is kept so the migration can be run again.
+`)
+
+	adminMigrateProwExample = templates.Examples(`
+		# Show what the migration would change
+		jx admin migrate prow --dry-run
+
+		# Migrate the team to Prow
+		jx admin migrate prow
+
+		# Switch the team back to Jenkins
+		jx admin migrate prow --rollback
+	`)
+)
+
+// AdminMigrateProwOptions the options for the command
+type AdminMigrateProwOptions struct {
+	CommonOptions
+
+	Repositories []string
+	DefaultPack  string
+	Rollback     bool
+	DryRun       bool
+}
+
+// migrationRepository a git repository whose pipelines are migrated
+type migrationRepository struct {
+	GitURL string
+	// Environment the environment of the repository or nil if the repository is an app
+	Environment *v1.Environment
+}
+
+// NewCmdAdminMigrateProw creates the command
+func NewCmdAdminMigrateProw(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &AdminMigrateProwOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "prow",
+		Short:   "Migrates the team from Jenkins to Prow as its webhook and promotion engine",
+		Long:    adminMigrateProwLong,
+		Example: adminMigrateProwExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Repositories, "repo", "r", []string{}, "Additional 'owner/repo' git repositories of apps to migrate")
+	cmd.Flags().StringVarP(&options.DefaultPack, "pack", "", "maven", "The draft pack whose build image is used for apps whose Jenkinsfile does not use a Jenkins X pod template")
+	cmd.Flags().BoolVarP(&options.Rollback, "rollback", "", false, "Switches the team back to Jenkins")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only shows the repositories which would be migrated")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *AdminMigrateProwOptions) Run() error {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.CoreV1().Secrets(devNs).Get("hmac-token", metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "Prow is not installed in namespace %s. Please install it via 'jx create addon prow'", devNs)
+	}
+	repos, err := o.migrationRepositories()
+	if err != nil {
+		return err
+	}
+	if o.DryRun {
+		action := "migrate to Prow"
+		if o.Rollback {
+			action = "roll back to Jenkins"
+		}
+		log.Infof("Would %s the %d repositories:\n", action, len(repos))
+		for _, repo := range repos {
+			log.Infof("  %s\n", util.ColorInfo(repo.String()))
+		}
+		return nil
+	}
+	if o.Rollback {
+		return o.rollbackProw(repos)
+	}
+	return o.migrateProw(repos)
+}
+
+func (o *AdminMigrateProwOptions) migrateProw(repos []*migrationRepository) error {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		gitInfo, err := gits.ParseGitURL(repo.GitURL)
+		if err != nil {
+			return err
+		}
+		fullName := gitInfo.Organisation + "/" + gitInfo.Name
+		gitProvider, err := o.gitProviderForURL(repo.GitURL, "repository "+fullName)
+		if err != nil {
+			return err
+		}
+		if repo.Environment != nil {
+			err = prow.AddEnvironment(kubeClient, []string{fullName}, devNs, repo.Environment.Spec.Namespace)
+		} else {
+			pack := o.draftPack(repo.GitURL)
+			log.Infof("Using the build image of the %s pack for %s\n", util.ColorInfo(pack), util.ColorInfo(fullName))
+			err = prow.AddApplication(kubeClient, []string{fullName}, devNs, pack)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to add %s to the Prow configuration", fullName)
+		}
+		err = o.createWebhookProw(repo.GitURL, gitProvider)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the Prow webhook for %s", fullName)
+		}
+		jenkinsSuffix := gitProvider.JenkinsWebHookPath(repo.GitURL, "")
+		err = deleteWebHooks(gitProvider, gitInfo, func(hookURL string) bool {
+			return isJenkinsWebHook(hookURL, jenkinsSuffix)
+		})
+		if err != nil {
+			return err
+		}
+		log.Infof("Migrated %s to Prow\n", util.ColorInfo(fullName))
+	}
+	err = o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.WebHookEngine = v1.WebHookEngineProw
+		env.Spec.TeamSettings.PromotionEngine = v1.PromotionEngineProw
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("The team now uses %s. Switch back via %s\n", util.ColorInfo("Prow"), util.ColorInfo("jx admin migrate prow --rollback"))
+	return nil
+}
+
+func (o *AdminMigrateProwOptions) rollbackProw(repos []*migrationRepository) error {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	jenk, err := o.JenkinsClient()
+	if err != nil {
+		return errors.Wrap(err, "failed to connect to Jenkins")
+	}
+	prowHookURLs := []string{}
+	for _, name := range []string{"hook", hookRelayServiceName} {
+		baseURL, err := kube.GetServiceURLFromName(kubeClient, name, devNs)
+		if err == nil {
+			prowHookURLs = append(prowHookURLs, util.UrlJoin(baseURL, "hook"))
+		}
+	}
+	for _, repo := range repos {
+		gitInfo, err := gits.ParseGitURL(repo.GitURL)
+		if err != nil {
+			return err
+		}
+		fullName := gitInfo.Organisation + "/" + gitInfo.Name
+		gitProvider, err := o.gitProviderForURL(repo.GitURL, "repository "+fullName)
+		if err != nil {
+			return err
+		}
+		err = gitProvider.CreateWebHook(&gits.GitWebHookArguments{
+			Owner: gitInfo.Organisation,
+			Repo:  gitInfo,
+			URL:   util.UrlJoin(jenk.BaseURL(), gitProvider.JenkinsWebHookPath(repo.GitURL, "")),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the Jenkins webhook for %s", fullName)
+		}
+		err = deleteWebHooks(gitProvider, gitInfo, func(hookURL string) bool {
+			return util.StringArrayIndex(prowHookURLs, hookURL) >= 0
+		})
+		if err != nil {
+			return err
+		}
+		log.Infof("Rolled back %s to Jenkins\n", util.ColorInfo(fullName))
+	}
+	err = o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.WebHookEngine = v1.WebHookEngineJenkins
+		env.Spec.TeamSettings.PromotionEngine = v1.PromotionEngineJenkins
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("The team now uses %s again\n", util.ColorInfo("Jenkins"))
+	return nil
+}
+
+// migrationRepositories returns the git repositories of the permanent environments and apps of the team
+func (o *AdminMigrateProwOptions) migrationRepositories() ([]*migrationRepository, error) {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	answer := []*migrationRepository{}
+	found := map[string]bool{}
+	add := func(gitURL string, env *v1.Environment) {
+		gitInfo, err := gits.ParseGitURL(gitURL)
+		if err != nil {
+			log.Warnf("Ignoring invalid git URL %s: %s\n", gitURL, err)
+			return
+		}
+		key := strings.ToLower(gitInfo.Host + "/" + gitInfo.Organisation + "/" + gitInfo.Name)
+		if found[key] {
+			return
+		}
+		found[key] = true
+		answer = append(answer, &migrationRepository{GitURL: gitInfo.HttpsURL(), Environment: env})
+	}
+
+	envs, names, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range names {
+		env := envs[name]
+		gitURL := env.Spec.Source.URL
+		if env.Spec.Kind != v1.EnvironmentKindTypePermanent || gitURL == "" || strings.HasPrefix(gitURL, "file:") {
+			continue
+		}
+		add(gitURL, env)
+	}
+
+	authConfigSvc, err := o.CreateGitAuthConfigService()
+	if err != nil {
+		return nil, err
+	}
+	defaultServerURL := authConfigSvc.Config().DefaultServerURL(gits.GitHubURL)
+	fullNames := append([]string{}, o.Repositories...)
+	jenk, err := o.JenkinsClient()
+	if err != nil {
+		log.Warnf("Not migrating the projects in Jenkins as it is not available: %s\n", err)
+	} else {
+		jobs, err := jenkins.LoadAllJenkinsJobs(jenk)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load the Jenkins jobs")
+		}
+		for _, job := range jobs {
+			if jenkins.IsMultiBranchProject(job) {
+				fullNames = append(fullNames, job.FullName)
+			}
+		}
+	}
+	sort.Strings(fullNames)
+	for _, fullName := range fullNames {
+		paths := strings.Split(strings.Trim(fullName, "/"), "/")
+		if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+			return nil, util.InvalidOptionf("repo", fullName, "expected a repository of the form owner/repo")
+		}
+		add(util.UrlJoin(defaultServerURL, paths[0], paths[1]), nil)
+	}
+
+	err = o.registerSourceRepositoryCRD()
+	if err != nil {
+		return nil, err
+	}
+	list, err := jxClient.JenkinsV1().SourceRepositories(devNs).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, sr := range list.Items {
+		server := sr.Spec.Provider
+		if server == "" {
+			server = defaultServerURL
+		}
+		add(util.UrlJoin(server, sr.Spec.Org, sr.Spec.Repo), nil)
+	}
+	return answer, nil
+}
+
+// draftPack returns the draft pack of the Jenkinsfile of the app
+func (o *AdminMigrateProwOptions) draftPack(gitURL string) string {
+	dir, err := ioutil.TempDir("", "jx-migrate-prow-")
+	if err != nil {
+		return o.DefaultPack
+	}
+	defer os.RemoveAll(dir)
+	err = o.Git().Clone(gitURL, dir)
+	if err != nil {
+		log.Warnf("Failed to clone %s so using the %s pack: %s\n", gitURL, o.DefaultPack, err)
+		return o.DefaultPack
+	}
+	data, err := ioutil.ReadFile(filepath.Join(dir, "Jenkinsfile"))
+	if err != nil {
+		return o.DefaultPack
+	}
+	pack := prow.DraftPackFromJenkinsfile(string(data))
+	if pack == "" {
+		return o.DefaultPack
+	}
+	return pack
+}
+
+// isJenkinsWebHook returns true if the webhook URL triggers Jenkins via the webhook path of the git provider
+func isJenkinsWebHook(hookURL string, jenkinsSuffix string) bool {
+	suffix := strings.Trim(jenkinsSuffix, "/")
+	u := hookURL
+	if i := strings.Index(u, "?"); i >= 0 && !strings.Contains(suffix, "?") {
+		u = u[:i]
+	}
+	return suffix != "" && strings.HasSuffix(strings.TrimSuffix(u, "/"), suffix)
+}
+
+// deleteWebHooks deletes the webhooks of the repository whose URL matches
+func deleteWebHooks(gitProvider gits.GitProvider, gitInfo *gits.GitRepositoryInfo, matches func(hookURL string) bool) error {
+	manager, ok := gitProvider.(gits.WebHookManager)
+	if !ok {
+		log.Warnf("Please remove the obsolete webhooks of %s manually as the %s git provider does not support deleting webhooks\n",
+			gitInfo.HttpsURL(), gitProvider.Kind())
+		return nil
+	}
+	hooks, err := manager.ListWebHooks(gitInfo.Organisation, gitInfo.Name)
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the webhooks of %s", gitInfo.HttpsURL())
+	}
+	for _, hook := range hooks {
+		if !matches(hook.URL) {
+			continue
+		}
+		err = manager.DeleteWebHook(gitInfo.Organisation, gitInfo.Name, hook)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete the webhook %s of %s", hook.URL, gitInfo.HttpsURL())
+		}
+	}
+	return nil
+}
+
+// String returns the git URL of the repository
+func (r *migrationRepository) String() string {
+	if r.Environment != nil {
+		return fmt.Sprintf("%s (environment %s)", r.GitURL, r.Environment.Name)
+	}
+	return r.GitURL
+}
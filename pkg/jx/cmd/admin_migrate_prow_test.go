@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsJenkinsWebHook(t *testing.T) {
+	t.Parallel()
+	assert.True(t, isJenkinsWebHook("http://jenkins.jx.example.com/github-webhook/", "/github-webhook/"))
+	assert.True(t, isJenkinsWebHook("http://jenkins.jx.example.com/github-webhook", "/github-webhook/"))
+	assert.True(t, isJenkinsWebHook("http://jenkins.jx.example.com/gitlab/notify_commit?token=abc", "/gitlab/notify_commit"))
+	assert.False(t, isJenkinsWebHook("http://hook.jx.example.com/hook", "/github-webhook/"))
+	assert.False(t, isJenkinsWebHook("http://hook.jx.example.com/hook", ""))
+}
+
+func TestDeleteWebHooks(t *testing.T) {
+	t.Parallel()
+	gitProvider := gits.NewFakeProvider(gits.NewFakeRepository("myorg", "myapp"))
+	gitInfo, err := gits.ParseGitURL("https://github.com/myorg/myapp")
+	require.NoError(t, err)
+	for _, u := range []string{"http://jenkins/github-webhook/", "http://hook/hook", "http://jenkins/github-webhook/?old"} {
+		require.NoError(t, gitProvider.CreateWebHook(&gits.GitWebHookArguments{Owner: "myorg", Repo: gitInfo, URL: u}))
+	}
+
+	err = deleteWebHooks(gitProvider, gitInfo, func(hookURL string) bool {
+		return isJenkinsWebHook(hookURL, "/github-webhook/")
+	})
+	require.NoError(t, err)
+
+	hooks, err := gitProvider.ListWebHooks("myorg", "myapp")
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+	assert.Equal(t, "http://hook/hook", hooks[0].URL)
+}
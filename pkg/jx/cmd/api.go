@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	apiextensionsclientset "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// EmbeddedClients the clients a Go program which embeds jx injects rather than jx creating them from the kube config.
+// Any client which is nil is created from the kube config as it would be by the jx binary
+type EmbeddedClients struct {
+	KubeClient          kubernetes.Interface
+	JXClient            versioned.Interface
+	APIExtensionsClient apiextensionsclientset.Interface
+	Git                 gits.Gitter
+	Helm                helm.Helmer
+
+	// Namespace the namespace of the team which defaults to the current namespace of the kube config or, when jx runs
+	// in a pod, the namespace of the pod
+	Namespace string
+	// Out where the output of the operations is written which defaults to being discarded
+	Out io.Writer
+	// Err where the errors of the operations are written which defaults to being discarded
+	Err io.Writer
+}
+
+// NewEmbeddedOptions creates the CommonOptions for a Go program which embeds jx using the given clients. The options
+// are in batch mode so that the operations never prompt for input
+func NewEmbeddedOptions(clients EmbeddedClients) *CommonOptions {
+	factory := NewFactory()
+	factory.SetBatch(true)
+	ns := clients.Namespace
+	if ns == "" && clients.KubeClient != nil {
+		// the namespace is only resolved when creating the kube client so lets resolve it for an injected client
+		config, _, _ := kube.LoadConfig()
+		ns = kube.CurrentNamespace(config)
+	}
+	o := &CommonOptions{
+		Factory:          factory,
+		Out:              clients.Out,
+		Err:              clients.Err,
+		BatchMode:        true,
+		KubeClientCached: clients.KubeClient,
+		jxClient:         clients.JXClient,
+		GitClient:        clients.Git,
+		helm:             clients.Helm,
+		currentNamespace: ns,
+	}
+	if clients.APIExtensionsClient != nil {
+		o.apiExtensionsClient = clients.APIExtensionsClient
+	}
+	if o.Out == nil {
+		o.Out = ioutil.Discard
+	}
+	if o.Err == nil {
+		o.Err = ioutil.Discard
+	}
+	return o
+}
+
+// APIError is returned by the operations of the API so that callers can tell which operation failed and get the
+// underlying error via errors.Cause
+type APIError struct {
+	Operation string
+	Err       error
+}
+
+// Error returns the description of the error
+func (e *APIError) Error() string {
+	return fmt.Sprintf("jx %s failed: %s", e.Operation, e.Err)
+}
+
+// Cause returns the underlying error
+func (e *APIError) Cause() error {
+	return e.Err
+}
+
+func apiError(operation string, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &APIError{Operation: operation, Err: err}
+}
+
+// API the operations of jx which are supported for Go programs which embed jx. Unlike the commands the signatures
+// of these operations are kept stable between releases
+type API struct {
+	options *CommonOptions
+}
+
+// NewAPI creates the API for a Go program which embeds jx using the given clients
+func NewAPI(clients EmbeddedClients) *API {
+	return &API{options: NewEmbeddedOptions(clients)}
+}
+
+// CommonOptions returns the options the operations are run with
+func (a *API) CommonOptions() *CommonOptions {
+	return a.options
+}
+
+// PromoteRequest the version of an application to promote and where to promote it
+type PromoteRequest struct {
+	Application string
+	Version     string
+	// Environment the name of the Environment to promote to
+	Environment string
+	// AllAutomatic promotes to all the Environments with an automatic promotion strategy in order
+	AllAutomatic bool
+	ReleaseName  string
+	// HelmRepositoryURL the helm repository which contains the chart of the application
+	HelmRepositoryURL string
+	// NoPoll disables waiting for the Pull Request of the promotion to merge and the promotion to complete
+	NoPoll bool
+}
+
+// Promote promotes a version of an application to an Environment
+func (a *API) Promote(request PromoteRequest) error {
+	options := &PromoteOptions{
+		CommonOptions: *a.options,
+	}
+	options.addPromoteOptions(&cobra.Command{})
+	options.Application = request.Application
+	options.Version = request.Version
+	options.Environment = request.Environment
+	options.AllAutomatic = request.AllAutomatic
+	options.ReleaseName = request.ReleaseName
+	options.NoPoll = request.NoPoll
+	if request.HelmRepositoryURL != "" {
+		options.HelmRepositoryURL = request.HelmRepositoryURL
+	}
+	if options.Application == "" {
+		return apiError("promote", fmt.Errorf("no application specified"))
+	}
+	if options.Environment == "" && !options.AllAutomatic {
+		return apiError("promote", fmt.Errorf("no environment specified"))
+	}
+	return apiError("promote", options.Run())
+}
+
+// PreviewRequest the Pull Request of an application to create or update the Preview Environment of
+type PreviewRequest struct {
+	// Dir the directory of the source code of the application
+	Dir            string
+	Application    string
+	Version        string
+	PullRequest    string
+	PullRequestURL string
+	SourceURL      string
+	SourceRef      string
+	// Namespace the namespace of the Preview Environment which defaults to one derived from the Pull Request
+	Namespace string
+}
+
+// CreatePreview creates or updates the Preview Environment of a Pull Request
+func (a *API) CreatePreview(request PreviewRequest) error {
+	options := &PreviewOptions{
+		HelmValuesConfig: config.HelmValuesConfig{
+			ExposeController: &config.ExposeController{},
+		},
+		PromoteOptions: PromoteOptions{
+			CommonOptions: *a.options,
+		},
+	}
+	cmd := &cobra.Command{}
+	options.addPreviewOptions(cmd)
+	options.PromoteOptions.addPromoteOptions(cmd)
+	options.Dir = request.Dir
+	options.Application = request.Application
+	options.Version = request.Version
+	options.PullRequest = request.PullRequest
+	options.PullRequestURL = request.PullRequestURL
+	options.SourceURL = request.SourceURL
+	options.SourceRef = request.SourceRef
+	options.Namespace = request.Namespace
+	return apiError("preview", options.Run())
+}
+
+// URLs returns the URLs of the services exposed in the namespace which defaults to the namespace of the team
+func (a *API) URLs(ns string) ([]kube.ServiceURL, error) {
+	client, currentNs, err := a.options.KubeClient()
+	if err != nil {
+		return nil, apiError("get urls", err)
+	}
+	if ns == "" {
+		ns = currentNs
+	}
+	urls, err := kube.FindServiceURLs(client, ns)
+	if err != nil {
+		return nil, apiError("get urls", err)
+	}
+	return urls, nil
+}
+
+// WatchURLs invokes the callback with the URLs of the services exposed in the namespace, which defaults to the
+// namespace of the team, each time they change until the stop channel is closed
+func (a *API) WatchURLs(ns string, stop <-chan struct{}, callback func([]kube.ServiceURL)) error {
+	client, currentNs, err := a.options.KubeClient()
+	if err != nil {
+		return apiError("get urls", err)
+	}
+	if ns == "" {
+		ns = currentNs
+	}
+	kube.WatchServiceURLs(client, ns, metav1.ListOptions{}, stop, callback)
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	jxfake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/gits"
+	helm_test "github.com/jenkins-x/jx/pkg/helm/mocks"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/petergtz/pegomock"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsfake "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset/fake"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestAPIURLs(t *testing.T) {
+	t.Parallel()
+	kubeClient := fake.NewSimpleClientset(&corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "jenkins",
+			Namespace:   "jx",
+			Annotations: map[string]string{kube.ExposeURLAnnotation: "http://jenkins.jx.acme.com"},
+		},
+	})
+	api := cmd.NewAPI(cmd.EmbeddedClients{
+		KubeClient: kubeClient,
+		Namespace:  "jx",
+	})
+	assert.True(t, api.CommonOptions().BatchMode, "embedded operations should never prompt")
+
+	urls, err := api.URLs("")
+	require.NoError(t, err)
+	assert.Equal(t, []kube.ServiceURL{{Name: "jenkins", URL: "http://jenkins.jx.acme.com", Namespace: "jx"}}, urls)
+}
+
+func TestAPIPromoteValidation(t *testing.T) {
+	t.Parallel()
+	api := cmd.NewAPI(cmd.EmbeddedClients{
+		KubeClient: fake.NewSimpleClientset(),
+		Namespace:  "jx",
+	})
+	err := api.Promote(cmd.PromoteRequest{Application: "myapp", Version: "1.0.0"})
+	require.Error(t, err)
+	apiErr, ok := err.(*cmd.APIError)
+	require.True(t, ok, "expected an APIError but got %#v", err)
+	assert.Equal(t, "promote", apiErr.Operation)
+	assert.EqualError(t, errors.Cause(err), "no environment specified")
+}
+
+func TestAPIPromoteWithInjectedClients(t *testing.T) {
+	pegomock.RegisterMockTestingT(t)
+	devEnv := kube.NewPermanentEnvironment("dev")
+	devEnv.Spec.Namespace = "jx"
+	devEnv.Spec.Kind = v1.EnvironmentKindTypeDevelopment
+	staging := kube.NewPermanentEnvironment("staging")
+	helmer := helm_test.NewMockHelmer()
+
+	api := cmd.NewAPI(cmd.EmbeddedClients{
+		KubeClient: fake.NewSimpleClientset(
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "jx"}},
+			&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "jx-staging"}},
+			&corev1.Service{ObjectMeta: metav1.ObjectMeta{
+				Name:        kube.ServiceChartMuseum,
+				Namespace:   "jx",
+				Annotations: map[string]string{kube.ExposeURLAnnotation: "http://chartmuseum.jx.example.com"},
+			}},
+		),
+		JXClient:            jxfake.NewSimpleClientset(devEnv, staging),
+		APIExtensionsClient: apiextensionsfake.NewSimpleClientset(),
+		Git:                 &gits.GitFake{},
+		Helm:                helmer,
+		Namespace:           "jx",
+	})
+	err := api.Promote(cmd.PromoteRequest{Application: "myapp", Version: "1.0.0", Environment: "staging", NoPoll: true})
+	require.NoError(t, err)
+	chart, releaseName, ns, _, _, _, _, _, _, _ := helmer.VerifyWasCalledOnce().UpgradeChart(pegomock.AnyString(), pegomock.AnyString(), pegomock.AnyString(),
+		anyStringPtr(), pegomock.AnyBool(), anyIntPtr(), pegomock.AnyBool(), pegomock.AnyBool(), pegomock.AnyStringSlice(),
+		pegomock.AnyStringSlice()).GetCapturedArguments()
+	assert.Equal(t, "releases/myapp", chart)
+	assert.Equal(t, "jx-staging-myapp", releaseName)
+	assert.Equal(t, "jx-staging", ns)
+}
+
+func anyStringPtr() *string {
+	pegomock.RegisterMatcher(pegomock.NewAnyMatcher(reflect.TypeOf((*string)(nil))))
+	return nil
+}
+
+func anyIntPtr() *int {
+	pegomock.RegisterMatcher(pegomock.NewAnyMatcher(reflect.TypeOf((*int)(nil))))
+	return nil
+}
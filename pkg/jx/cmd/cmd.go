@@ -27,11 +27,22 @@ func NewJXCommand(f Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 		Use:   "jx",
 		Short: "jx is a command line tool for working with Jenkins X",
 		Long: `
+jx is a command line tool for working with Jenkins X
+
+When a command fails its exit code tells scripts what kind of failure it was:
+
+  1 an unknown error
+  2 an invalid argument, option or input file
+  3 a missing binary or other dependency
+  4 the user is not authenticated or allowed to perform the operation
+  5 a resource or file could not be found
+  6 a server could not be reached
+  7 an operation timed out
+
+Commands run with '--output json' write the error to stderr as JSON with its category, exit code and message.
  `,
-		Run: runHelp,
-		/*
-			BashCompletionFunction: bash_completion_func,
-		*/
+		Run:                    runHelp,
+		BashCompletionFunction: bash_completion_func,
 	}
 
 	createCommands := NewCmdCreate(f, out, err)
@@ -44,6 +55,8 @@ func NewJXCommand(f Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 		NewCmdInstall(f, out, err),
 		NewCmdUninstall(f, out, err),
 		NewCmdUpgrade(f, out, err),
+		NewCmdAdmin(f, out, err),
+		NewCmdVerify(f, out, err),
 	}
 	installCommands = append(installCommands, findCommands("cluster", createCommands, deleteCommands)...)
 	installCommands = append(installCommands, findCommands("cluster", updateCommands)...)
@@ -64,7 +77,9 @@ func NewJXCommand(f Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 	gitCommands = append(gitCommands, findCommands("git token", createCommands, deleteCommands)...)
 	gitCommands = append(gitCommands, NewCmdRepo(f, out, err))
 
-	addonCommands := []*cobra.Command{}
+	addonCommands := []*cobra.Command{
+		NewCmdAddon(f, out, err),
+	}
 	addonCommands = append(addonCommands, findCommands("addon", createCommands, deleteCommands)...)
 
 	environmentsCommands := []*cobra.Command{
@@ -109,6 +124,8 @@ func NewJXCommand(f Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 			Message: "Working with Applications:",
 			Commands: []*cobra.Command{
 				NewCmdConsole(f, out, err),
+				NewCmdCp(f, out, err),
+				NewCmdDNSProxy(f, out, err),
 				NewCmdLogs(f, out, err),
 				NewCmdOpen(f, out, err),
 				NewCmdRsh(f, out, err),
@@ -134,8 +151,14 @@ func NewJXCommand(f Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 				createCommands,
 				updateCommands,
 				deleteCommands,
+				NewCmdExperiments(f, out, err),
+				NewCmdConfig(f, out, err),
 				NewCmdStart(f, out, err),
 				NewCmdStop(f, out, err),
+				NewCmdPause(f, out, err),
+				NewCmdResume(f, out, err),
+				NewCmdRerun(f, out, err),
+				NewCmdScale(f, out, err),
 			},
 		},
 		{
@@ -163,6 +186,13 @@ func NewJXCommand(f Factory, in io.Reader, out, err io.Writer) *cobra.Command {
 	cmds.SetVersionTemplate("{{printf .Version}}\n")
 	cmds.AddCommand(NewCmdOptions(out))
 
+	addAuditHooks(f, cmds)
+	addHTTPDebugHook(cmds)
+	addErrorOutputHook(cmds)
+	addPromptFlags(cmds)
+	addUpdateCheckHook(cmds)
+	addSettingsHook(cmds)
+
 	return cmds
 }
 
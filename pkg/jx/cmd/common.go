@@ -63,12 +63,14 @@ type CommonOptions struct {
 	apiExtensionsClient apiextensionsclientset.Interface
 	currentNamespace    string
 	devNamespace        string
+	envNamespaces       map[string]string
 	jxClient            versioned.Interface
 	jenkinsClient       *gojenkins.Jenkins
 	GitClient           gits.Gitter
 	helm                helm.Helmer
 
 	Prow
+	ChartInstall ChartInstallSettings
 }
 
 // NewCommonOptions a helper method to create a new CommonOptions instance
@@ -113,8 +115,9 @@ func (c *CommonOptions) Debugf(format string, a ...interface{}) {
 func (options *CommonOptions) addCommonFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&options.BatchMode, "batch-mode", "b", false, "In batch mode the command never prompts for user input")
 	cmd.Flags().BoolVarP(&options.Verbose, "verbose", "", false, "Enable verbose logging")
-	cmd.Flags().BoolVarP(&options.Headless, "headless", "", false, "Enable headless operation if using browser automation")
+	cmd.Flags().BoolVarP(&options.Headless, "headless", "", false, "Enable headless operation: browser automation runs without a window and logins use flows which do not open a browser. Defaults to $"+util.HeadlessEnvVar)
 	cmd.Flags().BoolVarP(&options.NoBrew, "no-brew", "", false, "Disables the use of brew on MacOS to install or upgrade command line dependencies")
+	cmd.Flags().String(config.SettingBinDir.Flag, "", "The directory jx installs itself and the binaries it depends on into, which disables the use of brew. Defaults to $"+config.SettingBinDir.EnvVar+" or the bin directory of the jx home directory")
 	cmd.Flags().BoolVarP(&options.InstallDependencies, "install-dependencies", "", false, "Should any required dependencies be installed automatically")
 	cmd.Flags().BoolVarP(&options.SkipAuthSecretsMerge, "skip-auth-secrets-merge", "", false, "Skips merging a local git auth yaml file with any pipeline secrets that are found")
 	options.Cmd = cmd
@@ -146,14 +149,12 @@ func (o *CommonOptions) KubeClient() (kubernetes.Interface, string, error) {
 
 // KubeClientAndDevNamespace returns a kube client and the development namespace
 func (o *CommonOptions) KubeClientAndDevNamespace() (kubernetes.Interface, string, error) {
-	kubeClient, curNs, err := o.KubeClient()
+	kubeClient, _, err := o.KubeClient()
 	if err != nil {
 		return nil, "", err
 	}
-	if o.devNamespace == "" {
-		o.devNamespace, _, err = kube.GetDevNamespace(kubeClient, curNs)
-	}
-	return kubeClient, o.devNamespace, err
+	devNs, err := o.Namespaces().DevNamespace()
+	return kubeClient, devNs, err
 }
 
 func (o *CommonOptions) JXClient() (versioned.Interface, string, error) {
@@ -188,28 +189,15 @@ func (o *CommonOptions) JXClientAndAdminNamespace() (versioned.Interface, string
 }
 
 func (o *CommonOptions) JXClientAndDevNamespace() (versioned.Interface, string, error) {
-	if o.jxClient == nil {
-		jxClient, ns, err := o.JXClient()
-		if err != nil {
-			return nil, ns, err
-		}
-		o.jxClient = jxClient
-		if o.currentNamespace == "" {
-			o.currentNamespace = ns
-		}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return nil, "", err
 	}
-	if o.devNamespace == "" {
-		client, ns, err := o.KubeClient()
-		if err != nil {
-			return nil, "", err
-		}
-		devNs, _, err := kube.GetDevNamespace(client, ns)
-		if err != nil {
-			return nil, "", err
-		}
-		o.devNamespace = devNs
+	devNs, err := o.Namespaces().DevNamespace()
+	if err != nil {
+		return nil, "", err
 	}
-	return o.jxClient, o.devNamespace, nil
+	return jxClient, devNs, nil
 }
 
 func (o *CommonOptions) JenkinsClient() (*gojenkins.Jenkins, error) {
@@ -248,12 +236,20 @@ func (o *CommonOptions) Helm() helm.Helmer {
 	if o.helm == nil {
 		helmBinary, noTiller, err := o.TeamHelmBin()
 		if err != nil {
-			helmBinary = defaultHelmBin
+			helmBinary = o.localSetting(config.SettingHelmBinary).Value
 		}
 		o.helm = helm.NewHelmCLI(helmBinary, helm.V2, "")
+		caFile, caErr := caBundleFile()
+		if caErr != nil {
+			log.Warnf("Failed to save the CA bundle used by helm repositories: %s\n", caErr)
+		} else if caFile != "" {
+			o.helm.SetRepoCAFile(caFile)
+		}
 		if noTiller {
 			o.helm.SetHost(o.tillerAddress())
 			o.startLocalTillerIfNotRunning()
+		} else if err == nil {
+			o.configureTillerClient(o.helm)
 		}
 	}
 	return o.helm
@@ -391,33 +387,7 @@ func (o *CommonOptions) findService(name string) (string, error) {
 }
 
 func (o *CommonOptions) findEnvironmentNamespace(envName string) (string, error) {
-	client, ns, err := o.KubeClient()
-	if err != nil {
-		return "", err
-	}
-	jxClient, _, err := o.JXClient()
-	if err != nil {
-		return "", err
-	}
-
-	devNs, _, err := kube.GetDevNamespace(client, ns)
-	if err != nil {
-		return "", err
-	}
-
-	envMap, envNames, err := kube.GetEnvironments(jxClient, devNs)
-	if err != nil {
-		return "", err
-	}
-	env := envMap[envName]
-	if env == nil {
-		return "", util.InvalidOption(optionEnvironment, envName, envNames)
-	}
-	answer := env.Spec.Namespace
-	if answer == "" {
-		return "", fmt.Errorf("Environment %s does not have a Namespace!", envName)
-	}
-	return answer, nil
+	return o.Namespaces().EnvironmentNamespace(envName)
 }
 
 func (o *CommonOptions) findServiceInNamespace(name string, ns string) (string, error) {
@@ -632,7 +602,7 @@ func (o *CommonOptions) pickRemoteURL(config *gitcfg.Config) (string, error) {
 			Message: "Choose a remote git URL:",
 			Options: urls,
 		}
-		err := survey.AskOne(prompt, &url, nil)
+		err := util.AskOne(prompt, &url, nil)
 		if err != nil {
 			return "", err
 		}
@@ -676,6 +646,14 @@ func (o *CommonOptions) expose(devNamespace, targetNamespace, password string) e
 		return fmt.Errorf("cannot get existing team exposecontroller config from namespace %s: %v", devNamespace, err)
 	}
 
+	if ic.Exposer == kube.ExposerIstio {
+		return o.exposeIstio(devNamespace, targetNamespace, ic)
+	}
+	if ic.Exposer == kube.ExposerRoute {
+		// Routes are secured by the OpenShift router rather than cert-manager
+		return o.runExposecontroller(devNamespace, targetNamespace, ic)
+	}
+
 	err = kube.AnnotateNamespaceServicesWithCertManager(o.KubeClientCached, targetNamespace, ic.Issuer)
 	if err != nil {
 		return err
@@ -717,6 +695,34 @@ func (o *CommonOptions) runExposecontroller(devNamespace, targetNamespace string
 
 }
 
+// waitForExposedIngresses waits for the ingresses exposecontroller generated in the namespaces to be assigned an
+// address and, when TLS is enabled, for their certificates to be issued so that the external URLs work before they
+// are used by webhooks or Jenkins
+func (o *CommonOptions) waitForExposedIngresses(namespaces []string, tls bool, timeout time.Duration) error {
+	for _, ns := range namespaces {
+		ingresses, err := o.KubeClientCached.ExtensionsV1beta1().Ingresses(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("cannot list the ingresses in namespace %s: %v", ns, err)
+		}
+		for _, ing := range ingresses.Items {
+			if ing.Annotations[kube.ExposeGeneratedByAnnotation] != Exposecontroller {
+				continue
+			}
+			if tls {
+				log.Infof("Waiting for the TLS certificates of ingress %s in namespace %s\n", util.ColorInfo(ing.Name), util.ColorInfo(ns))
+				err = kube.WaitForIngressCertificates(o.KubeClientCached, ns, ing.Name, timeout)
+			} else {
+				log.Infof("Waiting for ingress %s in namespace %s to be assigned an address\n", util.ColorInfo(ing.Name), util.ColorInfo(ns))
+				err = kube.WaitForIngress(o.KubeClientCached, ns, ing.Name, timeout)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // CleanExposecontrollerReources cleans expose controller resources
 func (o *CommonOptions) CleanExposecontrollerReources(ns string) {
 
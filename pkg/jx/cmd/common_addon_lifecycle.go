@@ -0,0 +1,488 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// AddonHook is a lifecycle hook of an addon which is passed the Addon resource tracking its state
+type AddonHook func(o *CommonOptions, addon *v1.Addon) error
+
+// AddonValuesFn returns chart values which are computed whenever the addon is installed or upgraded, such as
+// credentials, so that they are never stored in the Addon resource
+type AddonValuesFn func(o *CommonOptions, addon *v1.Addon) ([]string, error)
+
+// AddonDefinition declares an addon as a chart with its default values and lifecycle hooks.
+// The install hooks run on both install and upgrade so they must be idempotent
+type AddonDefinition struct {
+	Name        string
+	Description string
+	Chart       string
+	Version     string
+	ReleaseName string
+	// Namespace the namespace the addon is installed into by default instead of the dev namespace
+	Namespace string
+	Values    []string
+	// Requires the addons which are installed before this addon
+	Requires     []string
+	SecretValues AddonValuesFn
+	PreInstall   []AddonHook
+	PostInstall  []AddonHook
+	PreRemove    []AddonHook
+	PostRemove   []AddonHook
+}
+
+// AddonDefinitions returns the definitions of the available addons indexed by name
+func AddonDefinitions() map[string]*AddonDefinition {
+	answer := map[string]*AddonDefinition{}
+	for name, chart := range kube.AddonCharts {
+		definition := &AddonDefinition{
+			Name:        name,
+			Description: "The " + chart + " chart",
+			Chart:       chart,
+		}
+		if _, ok := kube.AddonServices[name]; ok {
+			definition.PostInstall = []AddonHook{exposeAddonServiceHook}
+		}
+		answer[name] = definition
+	}
+	answer["knative-build"] = &AddonDefinition{
+		Name:        "knative-build",
+		Description: "Knative Build for running the build pipelines triggered by Prow",
+		Chart:       prow.ChartKnativeBuild,
+		Version:     prow.KnativeBuildVersion,
+		ReleaseName: prow.DefaultKnativeBuildReleaseName,
+		PreInstall:  []AddonHook{requireDevEnvironmentHook},
+	}
+	answer["prow"] = &AddonDefinition{
+		Name:         "prow",
+		Description:  "Prow for handling webhook events and ChatOps on pull requests",
+		Chart:        prow.ChartProw,
+		Version:      prow.ProwVersion,
+		ReleaseName:  prow.DefaultProwReleaseName,
+		Requires:     []string{"knative-build"},
+		SecretValues: prowSecretValues,
+		PreInstall:   []AddonHook{requireDevEnvironmentHook},
+		PostInstall:  []AddonHook{exposeAddonHook, configureProwTeamSettingsHook, createProwEnvironmentWebhooksHook},
+		PostRemove:   []AddonHook{removeProwTeamSettingsHook},
+	}
+	answer["lighthouse"] = &AddonDefinition{
+		Name:         "lighthouse",
+		Description:  "Lighthouse, a lighter alternative to Prow, for handling webhook events and ChatOps on pull requests",
+		Chart:        prow.ChartLighthouse,
+		Version:      prow.LighthouseVersion,
+		ReleaseName:  prow.DefaultLighthouseReleaseName,
+		Requires:     []string{"knative-build"},
+		SecretValues: prowSecretValues,
+		PreInstall:   []AddonHook{requireDevEnvironmentHook},
+		PostInstall:  []AddonHook{exposeAddonHook, configureLighthouseHook, createProwEnvironmentWebhooksHook},
+		PostRemove:   []AddonHook{removeProwTeamSettingsHook},
+	}
+	answer["istio-gateway"] = &AddonDefinition{
+		Name:        "istio-gateway",
+		Description: "The Istio ingress gateway for exposing the services of the team instead of an ingress controller",
+		Chart:       istioGatewayChart,
+		Version:     istioGatewayVersion,
+		ReleaseName: defaultIstioReleaseName,
+		Namespace:   defaultIstioNamespace,
+		Values:      istioGatewayValues,
+		PreInstall:  []AddonHook{requireDevEnvironmentHook, addIstioHelmRepoHook},
+		PostInstall: []AddonHook{configureIstioGatewayHook},
+		PreRemove:   []AddonHook{removeIstioGatewayHook},
+		PostRemove:  []AddonHook{exposeTeamHook},
+	}
+	answer["knative-serving"] = &AddonDefinition{
+		Name:        "knative-serving",
+		Description: "Knative Serving for deploying apps as Knative Services which scale to zero when idle",
+		Chart:       knativeServingChart,
+		Namespace:   knativeServingNamespace,
+		PreInstall:  []AddonHook{requireDevEnvironmentHook, requireIstioHook},
+		PostInstall: []AddonHook{configureKnativeDomainHook, configureKnativeTeamSettingsHook},
+		PreRemove:   []AddonHook{removeKnativeTeamSettingsHook},
+	}
+	answer["crossplane"] = &AddonDefinition{
+		Name:        "crossplane",
+		Description: "Crossplane for provisioning the cloud resources claimed by apps such as buckets, queues and caches",
+		Chart:       crossplaneChart,
+		Namespace:   kube.CrossplaneNamespace,
+		PreInstall:  []AddonHook{addCrossplaneHelmRepoHook},
+	}
+	return answer
+}
+
+// newAddon returns the Addon resource for installing the addon
+func (d *AddonDefinition) newAddon(ns string, version string, values []string) *v1.Addon {
+	if version == "" {
+		version = d.Version
+	}
+	releaseName := d.ReleaseName
+	if releaseName == "" {
+		releaseName = d.Name
+	}
+	return &v1.Addon{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: d.Name,
+		},
+		Spec: v1.AddonSpec{
+			Chart:       d.Chart,
+			Version:     version,
+			ReleaseName: releaseName,
+			Namespace:   ns,
+			Values:      append(append([]string{}, d.Values...), values...),
+		},
+	}
+}
+
+// findAddonDefinition returns the addon definition of the given name or an error listing the available addons
+func findAddonDefinition(name string) (*AddonDefinition, error) {
+	definitions := AddonDefinitions()
+	definition := definitions[name]
+	if definition == nil {
+		names := []string{}
+		for k := range definitions {
+			names = append(names, k)
+		}
+		sort.Strings(names)
+		return nil, util.InvalidArg(name, names)
+	}
+	return definition, nil
+}
+
+// AddonInstallOrder returns the addons which the named addon requires, directly or through other addons, in the
+// order they have to be installed before it
+func AddonInstallOrder(definitions map[string]*AddonDefinition, name string) ([]string, error) {
+	order := []string{}
+	visited := map[string]bool{}
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		for _, p := range path {
+			if p == name {
+				return fmt.Errorf("addon %s requires itself through %s", name, strings.Join(append(path, name), " -> "))
+			}
+		}
+		if visited[name] {
+			return nil
+		}
+		definition := definitions[name]
+		if definition == nil {
+			return fmt.Errorf("addon %s requires the unknown addon %s", path[len(path)-1], name)
+		}
+		path = append(path, name)
+		for _, required := range definition.Requires {
+			err := visit(required, path)
+			if err != nil {
+				return err
+			}
+		}
+		visited[name] = true
+		order = append(order, name)
+		return nil
+	}
+	if definitions[name] == nil {
+		return nil, fmt.Errorf("unknown addon %s", name)
+	}
+	err := visit(name, nil)
+	if err != nil {
+		return nil, err
+	}
+	return order[:len(order)-1], nil
+}
+
+// AddonDependents returns the sorted names of the installed addons which require the named addon
+func AddonDependents(definitions map[string]*AddonDefinition, addons map[string]*v1.Addon, name string) []string {
+	answer := []string{}
+	for installed := range addons {
+		definition := definitions[installed]
+		if definition == nil {
+			continue
+		}
+		for _, required := range definition.Requires {
+			if required == name {
+				answer = append(answer, installed)
+				break
+			}
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+func (o *CommonOptions) registerAddonCRD() error {
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterAddonCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the Addon CRD")
+	}
+	return nil
+}
+
+// installAddonDefinition installs the addon, along with any addons it requires which are not installed yet,
+// into the given namespace tracking its state in an Addon resource in the dev namespace
+func (o *CommonOptions) installAddonDefinition(definition *AddonDefinition, ns string, version string, values []string) error {
+	err := o.registerAddonCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if ns == "" {
+		ns = definition.Namespace
+	}
+	if ns == "" {
+		ns = devNs
+	}
+	definitions := AddonDefinitions()
+	definitions[definition.Name] = definition
+	requires, err := AddonInstallOrder(definitions, definition.Name)
+	if err != nil {
+		return err
+	}
+	for _, name := range requires {
+		existing, err := kube.GetAddon(jxClient, devNs, name)
+		if err != nil {
+			return err
+		}
+		if existing != nil && existing.Status.Phase == v1.AddonPhaseInstalled {
+			continue
+		}
+		log.Infof("Installing addon %s which is required by %s\n", util.ColorInfo(name), util.ColorInfo(definition.Name))
+		required := definitions[name]
+		err = o.applyAddon(required, required.newAddon(ns, "", nil))
+		if err != nil {
+			return err
+		}
+	}
+	return o.applyAddon(definition, definition.newAddon(ns, version, values))
+}
+
+// applyAddon installs or upgrades the chart of the addon running its install hooks
+func (o *CommonOptions) applyAddon(definition *AddonDefinition, addon *v1.Addon) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	name := addon.Name
+	addon, err = kube.UpdateAddonPhase(jxClient, devNs, addon, v1.AddonPhaseInstalling, "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the state of addon %s", name)
+	}
+	err = o.runAddonLifecycle(definition, addon)
+	if err != nil {
+		_, saveErr := kube.UpdateAddonPhase(jxClient, devNs, addon, v1.AddonPhaseFailed, err.Error())
+		if saveErr != nil {
+			log.Warnf("Failed to save the state of addon %s: %s\n", addon.Name, saveErr)
+		}
+		return err
+	}
+	addon.Status.Version = addon.Spec.Version
+	_, err = kube.UpdateAddonPhase(jxClient, devNs, addon, v1.AddonPhaseInstalled, "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the state of addon %s", addon.Name)
+	}
+	log.Infof("Addon %s is installed in namespace %s\n", util.ColorInfo(addon.Name), util.ColorInfo(addon.Spec.Namespace))
+	return nil
+}
+
+func (o *CommonOptions) runAddonLifecycle(definition *AddonDefinition, addon *v1.Addon) error {
+	err := o.runAddonHooks(addon, "pre-install", definition.PreInstall)
+	if err != nil {
+		return err
+	}
+	values := append([]string{}, addon.Spec.Values...)
+	if definition.SecretValues != nil {
+		secretValues, err := definition.SecretValues(o, addon)
+		if err != nil {
+			return errors.Wrapf(err, "failed to compute the values of addon %s", addon.Name)
+		}
+		values = append(values, secretValues...)
+	}
+	err = o.installChart(addon.Spec.ReleaseName, addon.Spec.Chart, addon.Spec.Version, addon.Spec.Namespace, true, values)
+	if err != nil {
+		return errors.Wrapf(err, "failed to install chart %s of addon %s", addon.Spec.Chart, addon.Name)
+	}
+	return o.runAddonHooks(addon, "post-install", definition.PostInstall)
+}
+
+// removeAddon deletes the chart of the addon running its remove hooks and then deletes its Addon resource
+func (o *CommonOptions) removeAddon(definition *AddonDefinition, addon *v1.Addon, purge bool) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	name := addon.Name
+	addon, err = kube.UpdateAddonPhase(jxClient, devNs, addon, v1.AddonPhaseRemoving, "")
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the state of addon %s", name)
+	}
+	err = o.runAddonHooks(addon, "pre-remove", definition.PreRemove)
+	if err == nil {
+		err = o.deleteChart(addon.Spec.ReleaseName, purge)
+		if err != nil {
+			err = errors.Wrapf(err, "failed to delete chart %s of addon %s", addon.Spec.Chart, addon.Name)
+		}
+	}
+	if err == nil {
+		err = o.runAddonHooks(addon, "post-remove", definition.PostRemove)
+	}
+	if err != nil {
+		_, saveErr := kube.UpdateAddonPhase(jxClient, devNs, addon, v1.AddonPhaseFailed, err.Error())
+		if saveErr != nil {
+			log.Warnf("Failed to save the state of addon %s: %s\n", addon.Name, saveErr)
+		}
+		return err
+	}
+	return jxClient.JenkinsV1().Addons(devNs).Delete(addon.Name, &metav1.DeleteOptions{})
+}
+
+func (o *CommonOptions) runAddonHooks(addon *v1.Addon, phase string, hooks []AddonHook) error {
+	for _, hook := range hooks {
+		err := hook(o, addon)
+		if err != nil {
+			return errors.Wrapf(err, "%s hook of addon %s failed", phase, addon.Name)
+		}
+	}
+	return nil
+}
+
+// requireDevEnvironmentHook checks the team has a dev environment
+func requireDevEnvironmentHook(o *CommonOptions, addon *v1.Addon) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	env, err := kube.GetEnvironment(jxClient, devNs, kube.LabelValueDevEnvironment)
+	if err != nil || env == nil {
+		return fmt.Errorf("addon %s requires Jenkins X to be installed in namespace %s, try running 'jx install'", addon.Name, devNs)
+	}
+	return nil
+}
+
+// exposeAddonHook exposes the services of the namespace the addon is installed into
+func exposeAddonHook(o *CommonOptions, addon *v1.Addon) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(client, addon.Spec.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "retrieving the dev namespace")
+	}
+	return o.expose(devNs, addon.Spec.Namespace, "")
+}
+
+// exposeAddonServiceHook annotates the well known service of the addon so it gets exposed and then exposes it
+func exposeAddonServiceHook(o *CommonOptions, addon *v1.Addon) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	name := kube.AddonServices[addon.Name]
+	services := client.CoreV1().Services(addon.Spec.Namespace)
+	svc, err := services.Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "getting the addon service: %s", name)
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	if svc.Annotations[kube.AnnotationExpose] == "" {
+		svc.Annotations[kube.AnnotationExpose] = "true"
+		_, err = services.Update(svc)
+		if err != nil {
+			return errors.Wrap(err, "updating the service annotations")
+		}
+	}
+	return exposeAddonHook(o, addon)
+}
+
+// prowSecretValues returns the chart values of Prow and Lighthouse the same way as 'jx install' does
+func prowSecretValues(o *CommonOptions, addon *v1.Addon) ([]string, error) {
+	return o.prowSecretValues(addon.Spec.Namespace)
+}
+
+// configureProwTeamSettingsHook imports the configuration of the repositories added to Lighthouse and switches the
+// team to use Prow as its webhook and promotion engine
+func configureProwTeamSettingsHook(o *CommonOptions, addon *v1.Addon) error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = prow.ImportLighthouseConfig(client, devNs)
+	if err != nil {
+		return errors.Wrap(err, "translating the Lighthouse configuration for Prow")
+	}
+	return o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.WebHookEngine = v1.WebHookEngineProw
+		env.Spec.TeamSettings.PromotionEngine = v1.PromotionEngineProw
+		return nil
+	})
+}
+
+// configureLighthouseHook translates the Prow configuration of the team into the Lighthouse configuration and
+// switches the team to use Lighthouse as its webhook engine. Promotion works the same way as with Prow
+func configureLighthouseHook(o *CommonOptions, addon *v1.Addon) error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = prow.SyncLighthouseConfig(client, devNs)
+	if err != nil {
+		return errors.Wrap(err, "translating the Prow configuration for Lighthouse")
+	}
+	return o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.WebHookEngine = v1.WebHookEngineLighthouse
+		env.Spec.TeamSettings.PromotionEngine = v1.PromotionEngineProw
+		return nil
+	})
+}
+
+// removeProwTeamSettingsHook switches the team back to Jenkins as its webhook and promotion engine
+func removeProwTeamSettingsHook(o *CommonOptions, addon *v1.Addon) error {
+	return o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.WebHookEngine = v1.WebHookEngineJenkins
+		env.Spec.TeamSettings.PromotionEngine = v1.PromotionEngineJenkins
+		return nil
+	})
+}
+
+// createProwEnvironmentWebhooksHook registers the Prow webhook on the git repositories of the environments
+func createProwEnvironmentWebhooksHook(o *CommonOptions, addon *v1.Addon) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	envs, names, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		gitURL := envs[name].Spec.Source.URL
+		if gitURL == "" || strings.HasPrefix(gitURL, "file:") {
+			continue
+		}
+		gitProvider, err := o.gitProviderForURL(gitURL, "environment "+name)
+		if err != nil {
+			return err
+		}
+		err = o.createWebhookProw(gitURL, gitProvider)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the Prow webhook for environment %s", name)
+		}
+		log.Infof("Created the Prow webhook for environment %s\n", util.ColorInfo(name))
+	}
+	return nil
+}
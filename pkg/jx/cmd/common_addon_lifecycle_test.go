@@ -0,0 +1,153 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	helm_test "github.com/jenkins-x/jx/pkg/helm/mocks"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/petergtz/pegomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestAddonDefinitions(t *testing.T) {
+	t.Parallel()
+	definitions := cmd.AddonDefinitions()
+	for name := range kube.AddonCharts {
+		assert.Contains(t, definitions, name)
+	}
+	for name, definition := range definitions {
+		assert.Equal(t, name, definition.Name)
+		assert.NotEmpty(t, definition.Chart, "addon %s has no chart", name)
+		for _, required := range definition.Requires {
+			assert.Contains(t, definitions, required, "addon %s requires an unknown addon", name)
+		}
+	}
+	assert.Equal(t, []string{"knative-build"}, definitions["prow"].Requires)
+	assert.NotNil(t, definitions["prow"].SecretValues)
+	assert.NotEmpty(t, definitions["prow"].PostInstall)
+	assert.Equal(t, []string{"knative-build"}, definitions["lighthouse"].Requires)
+	assert.NotNil(t, definitions["lighthouse"].SecretValues)
+	assert.Equal(t, "istio-system", definitions["istio-gateway"].Namespace)
+	assert.NotEmpty(t, definitions["istio-gateway"].PostInstall)
+	assert.Equal(t, "knative-serving", definitions["knative-serving"].Namespace)
+	assert.Equal(t, "crossplane-system", definitions["crossplane"].Namespace)
+}
+
+func testAddonDefinitions(requires map[string][]string) map[string]*cmd.AddonDefinition {
+	answer := map[string]*cmd.AddonDefinition{}
+	for name, required := range requires {
+		answer[name] = &cmd.AddonDefinition{
+			Name:     name,
+			Chart:    "jenkins-x/" + name,
+			Requires: required,
+		}
+	}
+	return answer
+}
+
+func TestAddonInstallOrder(t *testing.T) {
+	t.Parallel()
+	definitions := testAddonDefinitions(map[string][]string{
+		"app":      {"database", "cache"},
+		"database": {"storage"},
+		"cache":    {"storage"},
+		"storage":  nil,
+	})
+
+	order, err := cmd.AddonInstallOrder(definitions, "storage")
+	require.NoError(t, err)
+	assert.Empty(t, order)
+
+	order, err = cmd.AddonInstallOrder(definitions, "database")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"storage"}, order)
+
+	order, err = cmd.AddonInstallOrder(definitions, "app")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"storage", "database", "cache"}, order)
+
+	order, err = cmd.AddonInstallOrder(cmd.AddonDefinitions(), "prow")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"knative-build"}, order)
+
+	_, err = cmd.AddonInstallOrder(definitions, "missing")
+	assert.Error(t, err)
+}
+
+func TestAddonInstallOrderFailures(t *testing.T) {
+	t.Parallel()
+	definitions := testAddonDefinitions(map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+		"d": {"unknown"},
+	})
+
+	_, err := cmd.AddonInstallOrder(definitions, "a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "a -> b -> c -> a")
+
+	_, err = cmd.AddonInstallOrder(definitions, "d")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown addon unknown")
+}
+
+func TestAddonDependents(t *testing.T) {
+	t.Parallel()
+	definitions := cmd.AddonDefinitions()
+	addons := map[string]*v1.Addon{}
+	for _, name := range []string{"knative-build", "prow", "lighthouse", "grafana", "custom"} {
+		addons[name] = &v1.Addon{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	}
+
+	assert.Equal(t, []string{"lighthouse", "prow"}, cmd.AddonDependents(definitions, addons, "knative-build"))
+	assert.Empty(t, cmd.AddonDependents(definitions, addons, "prow"))
+	assert.Empty(t, cmd.AddonDependents(definitions, addons, "grafana"))
+
+	delete(addons, "prow")
+	assert.Equal(t, []string{"lighthouse"}, cmd.AddonDependents(definitions, addons, "knative-build"))
+
+	delete(addons, "lighthouse")
+	assert.Empty(t, cmd.AddonDependents(definitions, addons, "knative-build"))
+}
+
+func TestAddonRemoveRequiredAddon(t *testing.T) {
+	pegomock.RegisterMockTestingT(t)
+	helmer := helm_test.NewMockHelmer()
+	addons := []runtime.Object{}
+	for _, name := range []string{"knative-build", "prow"} {
+		addons = append(addons, &v1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "jx"},
+			Spec:       v1.AddonSpec{Chart: "jenkins-x/" + name, ReleaseName: "jx-" + name, Namespace: "jx"},
+			Status:     v1.AddonStatus{Phase: v1.AddonPhaseInstalled},
+		})
+	}
+	o := &cmd.AddonRemoveOptions{Purge: true}
+	cmd.ConfigureTestOptionsWithResources(&o.CommonOptions, nil, addons, gits.NewGitCLI(), helmer)
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	require.NoError(t, err)
+
+	o.Args = []string{"knative-build"}
+	err = o.Run()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "required by the installed addons prow")
+	helmer.VerifyWasCalled(pegomock.Never()).DeleteRelease(pegomock.AnyString(), pegomock.AnyBool())
+	_, names, err := kube.GetAddons(jxClient, ns)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"knative-build", "prow"}, names)
+
+	o.Args = []string{"prow", "knative-build"}
+	err = o.Run()
+	require.NoError(t, err)
+	helmer.VerifyWasCalledOnce().DeleteRelease("jx-prow", true)
+	helmer.VerifyWasCalledOnce().DeleteRelease("jx-knative-build", true)
+	_, names, err = kube.GetAddons(jxClient, ns)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
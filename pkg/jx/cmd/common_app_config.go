@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ModifyAppConfigFn callback for modifying the environment variables of an app
+type ModifyAppConfigFn func(config *appconfig.AppConfig, key []byte) error
+
+// modifyAppConfig modifies the environment variables of the app in the given environment. For GitOps environments
+// a Pull Request is created which changes the app configuration file and the chart values, otherwise the
+// ConfigMap and Secret of the app are updated in the namespace of the environment
+func (o *CommonOptions) modifyAppConfig(envName string, app string, branchName string, title string, message string, fn ModifyAppConfigFn) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(devNs).Get(envName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("no environment found called %s, try running `jx get env`: %s", envName, err)
+	}
+	key, err := appconfig.LoadOrCreateKey(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+
+	if env.Spec.Source.URL == "" {
+		ns := env.Spec.Namespace
+		config, err := appconfig.LoadAppConfigFromCluster(kubeClient, ns, app, key)
+		if err != nil {
+			return err
+		}
+		err = fn(config, key)
+		if err != nil {
+			return err
+		}
+		err = appconfig.ApplyAppConfig(kubeClient, ns, app, config, key)
+		if err != nil {
+			return err
+		}
+		log.Infof("Updated the environment variables of app %s in namespace %s\n", util.ColorInfo(app), util.ColorInfo(ns))
+		log.Infof("The chart of the app must reference ConfigMap %s and Secret %s via envFrom\n", util.ColorInfo(appconfig.ConfigMapName(app)), util.ColorInfo(appconfig.SecretName(app)))
+		return nil
+	}
+
+	modifyRequirementsFn := func(requirements *helm.Requirements) error {
+		return nil
+	}
+	modifyDirFn := func(dir string) error {
+		config, err := appconfig.LoadAppConfig(dir, app)
+		if err != nil {
+			return err
+		}
+		err = fn(config, key)
+		if err != nil {
+			return err
+		}
+		err = appconfig.SaveAppConfig(dir, app, config)
+		if err != nil {
+			return err
+		}
+		return appconfig.UpdateValuesEnvFrom(filepath.Join(dir, "values.yaml"), app, config)
+	}
+	info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, modifyDirFn, branchName, title, message, nil, nil)
+	if err != nil {
+		return err
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Created Pull Request %s to update the environment variables of app %s in environment %s\n", util.ColorInfo(info.PullRequest.URL), util.ColorInfo(app), util.ColorInfo(envName))
+	}
+	return nil
+}
+
+// applyAppConfigs applies the ConfigMaps and Secrets of the environment variables of the apps in the environment
+// chart in the given directory to the namespace so that they exist before the apps are upgraded
+func (o *CommonOptions) applyAppConfigs(dir string, ns string) error {
+	configs, err := appconfig.LoadAllAppConfigs(dir)
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	var key []byte
+	if len(configs) > 0 {
+		_, devNs, err := o.JXClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		key, err = appconfig.LoadKey(kubeClient, devNs)
+		if err != nil {
+			return err
+		}
+		log.Infof("Applying the environment variables of %d apps to namespace %s\n", len(configs), util.ColorInfo(ns))
+	}
+	return appconfig.ApplyEnvironmentConfig(kubeClient, ns, dir, key)
+}
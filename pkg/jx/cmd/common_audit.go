@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/user"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/audit"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+const (
+	// auditDisabledEnvVar disables the recording of mutating jx commands in the team audit log when set to true
+	auditDisabledEnvVar = "JX_DISABLE_AUDIT"
+)
+
+// auditOptions the options used to record the mutating command which is currently running in the team audit log
+type auditOptions struct {
+	Factory Factory
+
+	lock  sync.Mutex
+	entry *audit.Entry
+}
+
+// addAuditHooks registers hooks on the root command so that mutating commands are recorded in the team audit log.
+// Failed commands are recorded before the fatal error handler exits the process
+func addAuditHooks(f Factory, cmd *cobra.Command) {
+	o := &auditOptions{
+		Factory: f,
+	}
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		o.start(c, args)
+	}
+	cmd.PersistentPostRun = func(c *cobra.Command, args []string) {
+		o.finish("")
+	}
+	handler := fatalErrHandler
+	BehaviorOnFatal(func(msg string, code int) {
+		if msg == "" {
+			msg = fmt.Sprintf("exit code %d", code)
+		}
+		o.finish(msg)
+		handler(msg, code)
+	})
+}
+
+// start creates the audit entry for the given command if it is a mutating command
+func (o *auditOptions) start(c *cobra.Command, args []string) {
+	path := c.CommandPath()
+	if os.Getenv(auditDisabledEnvVar) == "true" || !audit.IsMutatingCommand(path) {
+		return
+	}
+	auditArgs := append([]string{}, args...)
+	c.Flags().Visit(func(flag *pflag.Flag) {
+		auditArgs = append(auditArgs, fmt.Sprintf("--%s=%s", flag.Name, flag.Value.String()))
+	})
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.entry = &audit.Entry{
+		Time:    time.Now(),
+		User:    currentUserName(),
+		Command: path,
+		Args:    audit.MaskArgs(auditArgs),
+	}
+}
+
+// finish records the outcome of the current mutating command in the audit log of the team and posts it to the
+// audit webhook of the team if there is one. The command succeeded if there is no error message
+func (o *auditOptions) finish(errorMessage string) {
+	o.lock.Lock()
+	entry := o.entry
+	o.entry = nil
+	o.lock.Unlock()
+	if entry == nil {
+		return
+	}
+
+	entry.Succeeded = errorMessage == ""
+	entry.Error = errorMessage
+	recordAuditEntry(o.Factory, entry)
+}
+
+// recordAuditEntry records the entry in the audit log of the team and posts it to the audit webhook of the team
+// if there is one
+func recordAuditEntry(factory Factory, entry *audit.Entry) {
+	if os.Getenv(auditDisabledEnvVar) == "true" {
+		return
+	}
+	// lets not fail commands such as creating a cluster when there is no team to record them in yet
+	kubeClient, ns, err := factory.CreateClient()
+	if err != nil {
+		return
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return
+	}
+	if entry.Namespace == "" {
+		entry.Namespace = ns
+	}
+	config, _, err := kube.LoadConfig()
+	if err == nil {
+		context := kube.CurrentContext(config)
+		if context != nil {
+			entry.KubeUser = context.AuthInfo
+		}
+	}
+
+	err = audit.Record(kubeClient, devNs, entry, audit.DefaultMaxEntries)
+	if err != nil {
+		log.Warnf("Failed to record the command in the audit log: %s\n", err)
+	}
+
+	jxClient, _, err := factory.CreateJXClient()
+	if err != nil {
+		return
+	}
+	env, err := kube.GetEnvironment(jxClient, devNs, kube.LabelValueDevEnvironment)
+	if err != nil || env == nil || env.Spec.TeamSettings.AuditWebhookURL == "" {
+		return
+	}
+	webhookURL, err := expandTemplateValue(kubeClient, devNs, env.Spec.TeamSettings.AuditWebhookURL)
+	if err != nil {
+		log.Warnf("Failed to expand the audit webhook URL: %s\n", err)
+		return
+	}
+	err = audit.PostToWebhook(webhookURL, entry, audit.WebhookTimeout)
+	if err != nil {
+		log.Warnf("%s\n", err)
+	}
+}
+
+func currentUserName() string {
+	u, err := user.Current()
+	if err == nil && u.Username != "" {
+		return u.Username
+	}
+	return os.Getenv("USER")
+}
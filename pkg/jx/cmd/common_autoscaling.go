@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/autoscaling"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// applyAutoscalers generates the HorizontalPodAutoscalers of the apps from the autoscaling values of the environment
+// chart in the given directory once the apps have been upgraded in the namespace
+func (o *CommonOptions) applyAutoscalers(dir string, ns string) error {
+	values, err := autoscaling.LoadValues(filepath.Join(dir, "values.yaml"))
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	enabled := 0
+	for _, v := range values {
+		if v.Enabled {
+			enabled++
+		}
+	}
+	if enabled > 0 {
+		err = autoscaling.ValidateMetricsServer(kubeClient)
+		if err != nil {
+			log.Warnf("%s\n", err)
+		}
+		log.Infof("Applying the HorizontalPodAutoscalers of %d apps to namespace %s\n", enabled, util.ColorInfo(ns))
+	}
+	return autoscaling.ApplyAutoscalers(kubeClient, ns, values)
+}
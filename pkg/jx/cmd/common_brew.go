@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// jenkinsXBrewTap the Homebrew tap which provides the jx formula
+	jenkinsXBrewTap = "jenkins-x/jx"
+)
+
+// brewFormula a Homebrew formula and the GitHub repository whose releases it packages
+type brewFormula struct {
+	// Name the name of the formula
+	Name string
+	// Tap the tap which provides the formula or empty if it is a core formula
+	Tap string
+	// Binary the binary the formula links into the Homebrew bin directory
+	Binary string
+	// Owner the owner of the GitHub repository whose release versions are recorded in the version manifest or empty
+	// if the version of the formula is never pinned
+	Owner string
+	// Repo the GitHub repository whose release versions are recorded in the version manifest
+	Repo string
+}
+
+var (
+	jxBrewFormula        = brewFormula{Name: "jx", Tap: jenkinsXBrewTap, Binary: "jx", Owner: "jenkins-x", Repo: "jx"}
+	kubectlBrewFormula   = brewFormula{Name: "kubectl", Binary: "kubectl"}
+	terraformBrewFormula = brewFormula{Name: "terraform", Binary: "terraform", Owner: "hashicorp", Repo: "terraform"}
+	trivyBrewFormula     = brewFormula{Name: "trivy", Tap: "aquasecurity/trivy", Binary: "trivy", Owner: "aquasecurity", Repo: "trivy"}
+	helmfileBrewFormula  = brewFormula{Name: "helmfile", Binary: "helmfile", Owner: "roboll", Repo: "helmfile"}
+)
+
+// brewInstall installs the formula, or upgrades it if upgrade is true, via brew. If the version manifest pins the
+// version of the formula brew has to provide that exact version, which is then pinned so that 'brew upgrade' keeps
+// it. It returns false if brew could not provide a working binary of the right version so that the caller downloads
+// the binary directly instead
+func (o *CommonOptions) brewInstall(formula brewFormula, upgrade bool) bool {
+	pinned := ""
+	var err error
+	if formula.Owner != "" {
+		pinned, err = util.PinnedVersion(formula.Owner, formula.Repo)
+		if err != nil {
+			log.Warnf("Not using brew to install %s: %s\n", formula.Name, err)
+			return false
+		}
+	}
+	if formula.Tap != "" {
+		err = o.ensureBrewTap(formula.Tap)
+		if err != nil {
+			log.Warnf("Not using brew to install %s as the %s tap could not be added: %s\n", formula.Name, formula.Tap, err)
+			return false
+		}
+	}
+
+	args := brewInstallArgs(formula.Name, o.brewInstalledVersions(formula.Name), upgrade, pinned)
+	if len(args) > 0 {
+		if args[0] == "upgrade" {
+			// a formula pinned by an earlier install has to be unpinned before it can be upgraded
+			o.runCommandQuietly("brew", "unpin", formula.Name)
+		}
+		err = o.RunCommand("brew", args...)
+		if err != nil {
+			log.Warnf("Failed to install %s via brew so downloading it instead: %s\n", formula.Name, err)
+			return false
+		}
+	}
+
+	if pinned != "" {
+		versions := o.brewInstalledVersions(formula.Name)
+		if !brewProvidesVersion(versions, pinned) {
+			log.Warnf("brew provides %s %s rather than version %s of the version manifest so downloading it instead\n",
+				formula.Name, strings.Join(versions, ", "), pinned)
+			// the brew binary would otherwise be found on the PATH before the downloaded binary
+			o.runCommandQuietly("brew", "unlink", formula.Name)
+			return false
+		}
+		err = o.RunCommand("brew", "pin", formula.Name)
+		if err != nil {
+			log.Warnf("Failed to pin %s to version %s: %s\n", formula.Name, pinned, err)
+		}
+	}
+	return o.repairBrewLinks(formula)
+}
+
+// brewInstallArgs returns the arguments of the brew command which installs the formula if none of its versions are
+// installed or upgrades it if upgrade is true and its version is not pinned by the version manifest. It returns nothing
+// if the installed formula should be kept
+func brewInstallArgs(name string, installed []string, upgrade bool, pinned string) []string {
+	if len(installed) == 0 {
+		return []string{"install", name}
+	}
+	if upgrade && pinned == "" {
+		return []string{"upgrade", name}
+	}
+	return nil
+}
+
+// brewProvidesVersion returns true if the pinned version is one of the installed versions of a formula
+func brewProvidesVersion(installed []string, pinned string) bool {
+	return util.StringArrayIndex(installed, pinned) >= 0
+}
+
+// repairBrewLinks relinks the formula if its binary is not on the PATH, which happens when the links of an upgraded
+// formula are broken or conflict with another formula. It returns false if the binary is still not available
+func (o *CommonOptions) repairBrewLinks(formula brewFormula) bool {
+	_, err := exec.LookPath(formula.Binary)
+	if err == nil {
+		return true
+	}
+	log.Warnf("Repairing the brew links of %s as %s is not on the PATH\n", formula.Name, formula.Binary)
+	o.runCommandQuietly("brew", "unlink", formula.Name)
+	err = o.RunCommand("brew", "link", "--overwrite", formula.Name)
+	if err == nil {
+		_, err = exec.LookPath(formula.Binary)
+	}
+	if err != nil {
+		log.Warnf("Failed to repair the brew links of %s so downloading it instead: %s\n", formula.Name, err)
+		return false
+	}
+	return true
+}
+
+// brewInstalledVersions returns the versions of the formula installed via brew or nothing if it is not installed
+func (o *CommonOptions) brewInstalledVersions(name string) []string {
+	out, err := o.getCommandOutput("", "brew", "list", "--versions", name)
+	if err != nil {
+		return nil
+	}
+	return parseBrewVersions(out, name)
+}
+
+// brewTaps returns the taps which have been added to brew
+func (o *CommonOptions) brewTaps() ([]string, error) {
+	out, err := o.getCommandOutput("", "brew", "tap")
+	if err != nil {
+		return nil, err
+	}
+	return strings.Fields(out), nil
+}
+
+// ensureBrewTap adds the tap to brew if it has not been added yet
+func (o *CommonOptions) ensureBrewTap(tap string) error {
+	taps, err := o.brewTaps()
+	if err != nil {
+		return err
+	}
+	if util.StringArrayIndex(taps, tap) >= 0 {
+		return nil
+	}
+	return o.RunCommand("brew", "tap", tap)
+}
+
+// isVersionPinned returns true if the version manifest pins the version of the repository. Casks can not be pinned
+// so the binaries of pinned repositories are downloaded directly
+func isVersionPinned(owner string, repo string) bool {
+	version, err := util.PinnedVersion(owner, repo)
+	return err != nil || version != ""
+}
+
+// parseBrewVersions parses the versions of the formula from the output of 'brew list --versions' which lists the
+// name of the formula followed by its installed versions
+func parseBrewVersions(out string, name string) []string {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 1 && fields[0] == name {
+			return fields[1:]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBrewVersions(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"1.3.400", "1.3.399"}, parseBrewVersions("jx 1.3.400 1.3.399\n", "jx"))
+	assert.Equal(t, []string{"0.11.10"}, parseBrewVersions("terraform 0.11.10", "terraform"))
+	assert.Empty(t, parseBrewVersions("", "jx"))
+	assert.Empty(t, parseBrewVersions("jx-cli 1.0.0\n", "jx"))
+}
+
+func TestBrewInstallArgs(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"install", "jx"}, brewInstallArgs("jx", nil, false, ""))
+	assert.Equal(t, []string{"install", "jx"}, brewInstallArgs("jx", nil, true, ""))
+	assert.Equal(t, []string{"install", "jx"}, brewInstallArgs("jx", nil, true, "1.3.400"), "a missing pinned formula is installed")
+	assert.Equal(t, []string{"upgrade", "jx"}, brewInstallArgs("jx", []string{"1.3.399"}, true, ""))
+	assert.Empty(t, brewInstallArgs("jx", []string{"1.3.399"}, false, ""), "an installed formula is kept unless upgrading")
+	assert.Empty(t, brewInstallArgs("jx", []string{"1.3.399"}, true, "1.3.400"), "a pinned formula is never upgraded to the latest version")
+}
+
+func TestBrewProvidesVersion(t *testing.T) {
+	t.Parallel()
+	assert.True(t, brewProvidesVersion([]string{"1.3.400", "1.3.399"}, "1.3.399"))
+	assert.False(t, brewProvidesVersion([]string{"1.3.400"}, "1.3.399"))
+	assert.False(t, brewProvidesVersion(nil, "1.3.399"))
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// caBundleFileName the name of the file in the jx home directory which contains the CA bundles trusted by jx so that
+// they can be passed to helm
+const caBundleFileName = "ca-bundle.crt"
+
+// addTeamCABundle trusts the CA bundle of the team in the HTTP clients of jx once the team settings are loaded
+func addTeamCABundle(settings *v1.TeamSettings) {
+	if settings.CABundle == "" {
+		return
+	}
+	err := httpclient.AddCABundle([]byte(settings.CABundle))
+	if err != nil {
+		log.Warnf("Ignoring the CA bundle of the team settings: %s\n", err)
+	}
+}
+
+// caBundleFile returns the file of the CA bundles trusted by jx which is blank if no bundle is trusted. The file is
+// kept in the jx home directory as helm stores its path in the configuration of the repositories
+func caBundleFile() (string, error) {
+	data := httpclient.CABundle()
+	if len(data) == 0 {
+		return "", nil
+	}
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	fileName := filepath.Join(configDir, caBundleFileName)
+	existing, err := ioutil.ReadFile(fileName)
+	if err == nil && bytes.Equal(existing, data) {
+		return fileName, nil
+	}
+	err = ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+	if err != nil {
+		return "", err
+	}
+	return fileName, nil
+}
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
@@ -126,16 +125,25 @@ func (o *CommonOptions) runCommandInteractiveInDir(interactive bool, dir string,
 
 // getCommandOutput evaluates the given command and returns the trimmed output
 func (o *CommonOptions) getCommandOutput(dir string, name string, args ...string) (string, error) {
-	os.Setenv("PATH", util.PathWithBinary())
-	e := exec.Command(name, args...)
-	if dir != "" {
-		e.Dir = dir
+	return o.getCommandOutputWith(&util.Command{
+		Dir:  dir,
+		Name: name,
+		Args: args,
+	})
+}
+
+// getCommandOutputWith evaluates the command without retrying it and returns the trimmed output. The command is only
+// killed after a timeout or fails on a large output if it sets its ExecTimeout or OutputLimit. The output is streamed
+// to the log in verbose mode
+func (o *CommonOptions) getCommandOutputWith(cmd *util.Command) (string, error) {
+	if cmd.OnOutput == nil && o.Verbose {
+		cmd.OnOutput = func(line string) {
+			log.Infof("%s\n", line)
+		}
 	}
-	data, err := e.CombinedOutput()
-	text := string(data)
-	text = strings.TrimSpace(text)
+	text, err := cmd.RunWithoutRetry()
 	if err != nil {
-		return "", fmt.Errorf("Command failed '%s %s': %s %s\n", name, strings.Join(args, " "), text, err)
+		return "", err
 	}
-	return text, err
+	return text, nil
 }
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	crossplaneRepoName = "crossplane-alpha"
+	crossplaneRepoURL  = "https://charts.crossplane.io/alpha"
+
+	// crossplaneChart the published chart which installs Crossplane and its cloud provider stacks
+	crossplaneChart = "crossplane-alpha/crossplane"
+)
+
+// addCrossplaneHelmRepoHook adds the helm repository of the published Crossplane charts
+func addCrossplaneHelmRepoHook(o *CommonOptions, addon *v1.Addon) error {
+	return o.addHelmRepoIfMissing(crossplaneRepoURL, crossplaneRepoName)
+}
+
+// modifyCloudResourceClaims modifies the Crossplane resource claims of an environment. For GitOps environments a
+// Pull Request is created which changes the claims in the templates of the environment chart, otherwise the claims
+// are changed directly in the namespace of the environment
+func (o *CommonOptions) modifyCloudResourceClaims(envName string, branchName string, title string, message string,
+	modifyDirFn ModifyEnvironmentDirFn, applyFn func(client kubernetes.Interface, ns string) error) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(devNs).Get(envName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("no environment found called %s, try running `jx get env`: %s", envName, err)
+	}
+
+	if env.Spec.Source.URL == "" {
+		kubeClient, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		return applyFn(kubeClient, env.Spec.Namespace)
+	}
+
+	modifyRequirementsFn := func(requirements *helm.Requirements) error {
+		return nil
+	}
+	info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, modifyDirFn, branchName, title, message, nil, nil)
+	if err != nil {
+		return err
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Created Pull Request %s to change the cloud resources of environment %s\n", util.ColorInfo(info.PullRequest.URL), util.ColorInfo(envName))
+	}
+	return nil
+}
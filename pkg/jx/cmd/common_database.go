@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/cloud/database"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// provisionDatabases provisions the databases declared in the project configuration of the directory for the
+// environment namespace and creates their connection Secrets in that namespace
+func (o *CommonOptions) provisionDatabases(dir string, app string, ns string) error {
+	projectConfig, _, err := config.LoadProjectConfig(dir)
+	if err != nil {
+		return err
+	}
+	if len(projectConfig.Databases) == 0 {
+		return nil
+	}
+	for _, db := range projectConfig.Databases {
+		err = database.Validate(db)
+		if err != nil {
+			return err
+		}
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	manager := database.NewManager()
+	secrets := client.CoreV1().Secrets(ns)
+	for _, db := range projectConfig.Databases {
+		secretName := database.SecretName(app, db)
+		instance := database.NewInstance(app, db, ns)
+
+		// lets reuse the password of an existing instance
+		password := ""
+		existing, err := secrets.Get(secretName, v1.GetOptions{})
+		found := err == nil
+		if found {
+			password = string(existing.Data["password"])
+		}
+		if password == "" {
+			password, err = util.RandStringBytesMaskImprSrc(20)
+			if err != nil {
+				return fmt.Errorf("cannot create a random password for database %s", db.Name)
+			}
+		}
+
+		log.Infof("Provisioning %s database %s as instance %s\n", util.ColorInfo(db.Kind), util.ColorInfo(db.Name), util.ColorInfo(instance.Name))
+		conn, err := manager.Provision(instance, db, password)
+		if err != nil {
+			return errors.Wrapf(err, "failed to provision database %s", db.Name)
+		}
+		secret := database.NewConnectionSecret(secretName, db.Name, instance, conn)
+		if found {
+			existing.Labels = secret.Labels
+			existing.Annotations = secret.Annotations
+			existing.Data = secret.Data
+			_, err = secrets.Update(existing)
+		} else {
+			_, err = secrets.Create(secret)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to save the connection Secret %s of database %s in namespace %s", secretName, db.Name, ns)
+		}
+		log.Infof("The connection details of database %s are in Secret %s in namespace %s\n", util.ColorInfo(db.Name), util.ColorInfo(secretName), util.ColorInfo(ns))
+	}
+	return nil
+}
+
+// deleteEnvironmentDatabases deletes the database instances whose connection Secrets are in the namespace of an environment
+func (o *CommonOptions) deleteEnvironmentDatabases(ns string) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	list, err := client.CoreV1().Secrets(ns).List(v1.ListOptions{LabelSelector: database.LabelDatabase})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the database Secrets in namespace %s", ns)
+	}
+	manager := database.NewManager()
+	for i := range list.Items {
+		instance := database.InstanceFromSecret(&list.Items[i])
+		if instance == nil {
+			continue
+		}
+		log.Infof("Deleting %s database instance %s\n", util.ColorInfo(instance.Kind), util.ColorInfo(instance.Name))
+		err = manager.Delete(instance)
+		if err != nil {
+			return errors.Wrapf(err, "failed to delete the database instance %s of namespace %s", instance.Name, ns)
+		}
+	}
+	return nil
+}
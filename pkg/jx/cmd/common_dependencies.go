@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/dependencies"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// modifyDependencyGraph applies the changes to the dependency graph of the team and saves it
+func (o *CommonOptions) modifyDependencyGraph(fn func(graph *dependencies.Graph) error) error {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	graph, err := dependencies.LoadGraph(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	err = fn(graph)
+	if err != nil {
+		return err
+	}
+	return dependencies.SaveGraph(kubeClient, devNs, graph)
+}
+
+// dependencyRepositoryURL returns the git URL of the repository or the git URL of the current directory if blank
+func (o *CommonOptions) dependencyRepositoryURL(repository string) (string, error) {
+	if repository != "" {
+		return repository, nil
+	}
+	gitInfo, err := o.FindGitInfo("")
+	if err != nil {
+		return "", fmt.Errorf("no repository specified and %s", err)
+	}
+	return gitInfo.HttpsURL(), nil
+}
+
+// createDependencyUpdatePullRequest creates a Pull Request in the dependent repository which bumps the version of
+// the library. Nil is returned if the repository already uses the version
+func (o *CommonOptions) createDependencyUpdatePullRequest(dependency dependencies.Dependency, version string, releaseURL string) (*gits.GitPullRequest, error) {
+	gitInfo, err := gits.ParseGitURL(dependency.Repository)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := ioutil.TempDir("", "jx-update-dependencies-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	err = o.Git().Clone(dependency.Repository, dir)
+	if err != nil {
+		return nil, err
+	}
+	base := dependency.Branch
+	if base == "" {
+		base = "master"
+	}
+	if base != "master" {
+		err = o.Git().Checkout(dir, base)
+		if err != nil {
+			return nil, err
+		}
+	}
+	branchName := o.Git().ConvertToValidBranchName("update-" + strings.Replace(dependency.Library, "/", "-", -1) + "-" + version)
+	branchNames, err := o.Git().RemoteBranchNames(dir, "remotes/origin/")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load remote branch names: %s", err)
+	}
+	if util.StringArrayIndex(branchNames, branchName) >= 0 {
+		log.Infof("The branch %s already exists in %s so the Pull Request has already been created\n", util.ColorInfo(branchName), util.ColorInfo(dependency.Repository))
+		return nil, nil
+	}
+	err = o.Git().CreateBranch(dir, branchName)
+	if err != nil {
+		return nil, err
+	}
+	err = o.Git().Checkout(dir, branchName)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := dependencies.UpdateVersion(dir, dependency.Kind, dependency.Library, version)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		log.Infof("%s is already up to date or does not declare %s\n", util.ColorInfo(dependency.Repository), util.ColorInfo(dependency.Library))
+		return nil, nil
+	}
+	title := fmt.Sprintf("chore(deps): bump %s to %s", dependency.Library, version)
+	message := fmt.Sprintf("Updates the %s dependency %s to version %s", dependency.Kind, dependency.Library, version)
+	if releaseURL != "" {
+		message += "\n\nRelease: " + releaseURL
+	}
+	err = o.Git().Add(dir, "-A")
+	if err != nil {
+		return nil, err
+	}
+	err = o.Git().CommitDir(dir, title)
+	if err != nil {
+		return nil, err
+	}
+	err = o.Git().Push(dir)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := o.gitProviderForURL(dependency.Repository, "user name to submit the Pull Request")
+	if err != nil {
+		return nil, err
+	}
+	return provider.CreatePullRequest(&gits.GitPullRequestArguments{
+		GitRepositoryInfo: gitInfo,
+		Title:             title,
+		Body:              message,
+		Base:              base,
+		Head:              branchName,
+	})
+}
@@ -19,12 +19,54 @@ import (
 // ModifyRequirementsFn callback for modifying requirements
 type ModifyRequirementsFn func(requirements *helm.Requirements) error
 
+// ModifyEnvironmentDirFn callback for modifying other files in the directory of the environment chart
+type ModifyEnvironmentDirFn func(dir string) error
+
+// chainModifyEnvironmentDirFns returns a callback which invokes each of the non nil callbacks in order or nil if
+// there are none
+func chainModifyEnvironmentDirFns(fns ...ModifyEnvironmentDirFn) ModifyEnvironmentDirFn {
+	answer := []ModifyEnvironmentDirFn{}
+	for _, fn := range fns {
+		if fn != nil {
+			answer = append(answer, fn)
+		}
+	}
+	if len(answer) == 0 {
+		return nil
+	}
+	return func(dir string) error {
+		for _, fn := range answer {
+			err := fn(dir)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
 // ConfigureGitFolderFn callback to optionally configure git before its used for creating commits and PRs
 type ConfigureGitFolderFn func(dir string, gitInfo *gits.GitRepositoryInfo, gitAdapter gits.Gitter) error
 
 type CreateEnvPullRequestFn func(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn, branchNameText string, title string, message string, pullRequestInfo *ReleasePullRequestInfo) (*ReleasePullRequestInfo, error)
 
-func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn, branchNameText string, title string, message string, pullRequestInfo *ReleasePullRequestInfo, configGitFn ConfigureGitFolderFn) (*ReleasePullRequestInfo, error) {
+// modifyHelmfile modifies the releases of an environment which uses helmfile as if they were the dependencies of
+// the environment chart
+func modifyHelmfile(fileName string, env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn) error {
+	helmfile, err := helm.LoadHelmfile(fileName)
+	if err != nil {
+		return err
+	}
+	requirements := helmfile.Requirements()
+	err = modifyRequirementsFn(requirements)
+	if err != nil {
+		return err
+	}
+	helmfile.SetRequirements(requirements, env.Spec.Namespace)
+	return helmfile.Save(fileName)
+}
+
+func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modifyRequirementsFn ModifyRequirementsFn, modifyDirFn ModifyEnvironmentDirFn, branchNameText string, title string, message string, pullRequestInfo *ReleasePullRequestInfo, configGitFn ConfigureGitFolderFn) (*ReleasePullRequestInfo, error) {
 	var answer *ReleasePullRequestInfo
 	source := &env.Spec.Source
 	gitURL := source.URL
@@ -120,18 +162,39 @@ func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modify
 		return answer, err
 	}
 
-	requirementsFile, err := helm.FindRequirementsFileName(dir)
-	if err != nil {
-		return answer, err
-	}
-	requirements, err := helm.LoadRequirementsFile(requirementsFile)
+	helmfileName, err := helm.FindHelmfileFileName(dir)
 	if err != nil {
 		return answer, err
 	}
+	chartDir := ""
+	if helmfileName != "" {
+		err = modifyHelmfile(helmfileName, env, modifyRequirementsFn)
+		if err != nil {
+			return answer, err
+		}
+		chartDir = filepath.Dir(helmfileName)
+	} else {
+		requirementsFile, err := helm.FindRequirementsFileName(dir)
+		if err != nil {
+			return answer, err
+		}
+		requirements, err := helm.LoadRequirementsFile(requirementsFile)
+		if err != nil {
+			return answer, err
+		}
 
-	err = modifyRequirementsFn(requirements)
+		err = modifyRequirementsFn(requirements)
+
+		err = helm.SaveRequirementsFile(requirementsFile, requirements)
+		chartDir = filepath.Dir(requirementsFile)
+	}
 
-	err = helm.SaveRequirementsFile(requirementsFile, requirements)
+	if modifyDirFn != nil {
+		err = modifyDirFn(chartDir)
+		if err != nil {
+			return answer, err
+		}
+	}
 
 	err = o.Git().Add(dir, "*", "*/*")
 	if err != nil {
@@ -197,7 +260,7 @@ func (o *CommonOptions) createEnvironmentPullRequest(env *v1.Environment, modify
 }
 
 func (o *CommonOptions) registerEnvironmentCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// errorOutputFormat the format errors are written to stderr in which is set from the --output flag of the command
+var errorOutputFormat = ""
+
+// errorOutput the machine readable form of the error a command failed with
+type errorOutput struct {
+	Category util.ErrorCategory `json:"category"`
+	ExitCode int                `json:"exitCode"`
+	Message  string             `json:"message"`
+}
+
+// addErrorOutputHook writes the errors of commands run with --output json as JSON so that scripts can handle them
+func addErrorOutputHook(cmd *cobra.Command) {
+	preRun := cmd.PersistentPreRun
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		errorOutputFormat = ""
+		flag := c.Flags().Lookup("output")
+		if flag != nil {
+			errorOutputFormat = flag.Value.String()
+		}
+		if preRun != nil {
+			preRun(c, args)
+		}
+	}
+}
+
+// errorCategory returns the category of the error a command failed with including the errors of the Kubernetes API
+func errorCategory(err error) util.ErrorCategory {
+	category := util.ErrorCategoryOf(err)
+	if category != util.ErrorCategoryUnknown {
+		return category
+	}
+	for err != nil {
+		switch {
+		case kerrors.IsForbidden(err), kerrors.IsUnauthorized(err):
+			return util.ErrorCategoryPermissionDenied
+		case kerrors.IsNotFound(err):
+			return util.ErrorCategoryNotFound
+		case kerrors.IsTimeout(err), kerrors.IsServerTimeout(err), err == wait.ErrWaitTimeout:
+			return util.ErrorCategoryTimeout
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return util.ErrorCategoryUnknown
+}
+
+// formatError returns the message and exit code of the error in the format of the --output flag of the command
+func formatError(msg string, err error) (string, int) {
+	category := errorCategory(err)
+	code := category.ExitCode()
+	if errorOutputFormat != "json" {
+		return msg, code
+	}
+	data, jsonErr := json.Marshal(&errorOutput{
+		Category: category,
+		ExitCode: code,
+		Message:  err.Error(),
+	})
+	if jsonErr != nil {
+		return msg, code
+	}
+	return string(data), code
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestErrorCategory(t *testing.T) {
+	t.Parallel()
+	resource := schema.GroupResource{Resource: "secrets"}
+	assert.Equal(t, util.ErrorCategoryPermissionDenied, errorCategory(errors.Wrap(kerrors.NewForbidden(resource, "foo", fmt.Errorf("nope")), "failed")))
+	assert.Equal(t, util.ErrorCategoryNotFound, errorCategory(kerrors.NewNotFound(resource, "foo")))
+	assert.Equal(t, util.ErrorCategoryInvalidInput, errorCategory(util.MissingOption("name")))
+	assert.Equal(t, util.ErrorCategoryUnknown, errorCategory(fmt.Errorf("boom")))
+}
+
+func TestFormatErrorJSON(t *testing.T) {
+	defer func() {
+		errorOutputFormat = ""
+	}()
+	err := util.MissingOption("name")
+
+	msg, code := formatError("error: Missing option: --name", err)
+	assert.Equal(t, "error: Missing option: --name", msg)
+	assert.Equal(t, 2, code)
+
+	errorOutputFormat = "json"
+	msg, code = formatError("error: Missing option: --name", err)
+	assert.Equal(t, `{"category":"InvalidInput","exitCode":2,"message":"Missing option: --name"}`, msg)
+	assert.Equal(t, 2, code)
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/features"
+	"github.com/jenkins-x/jx/pkg/kube"
+)
+
+var (
+	experimentHelm3      = features.Register("helm3", "Using helm 3, which does not need Tiller, to install Jenkins X and apps")
+	experimentLighthouse = features.Register("lighthouse", "Using Lighthouse as the webhook and ChatOps engine of the team")
+)
+
+// teamExperiments returns the experiments enabled for the team or none if there is no team yet such as before
+// Jenkins X is installed
+func (o *CommonOptions) teamExperiments() []string {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil
+	}
+	env, err := kube.GetEnvironment(jxClient, ns, kube.LabelValueDevEnvironment)
+	if err != nil {
+		return nil
+	}
+	return env.Spec.TeamSettings.Experiments
+}
+
+// requireExperiment returns an error describing how to enable the experimental feature if it is not enabled
+func (o *CommonOptions) requireExperiment(feature *features.Feature) error {
+	if feature.Enabled(nil) {
+		return nil
+	}
+	return feature.RequireEnabled(o.teamExperiments())
+}
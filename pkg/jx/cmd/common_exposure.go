@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// oauth2ProxyChart the chart of the OAuth2 proxy which protects exposed services
+	oauth2ProxyChart = "stable/oauth2-proxy"
+)
+
+// configureServiceExposure saves the exposure policy of the service in the dev namespace and applies it, installing
+// the OAuth2 proxy first if the policy needs it and it is not installed yet
+func (o *CommonOptions) configureServiceExposure(name string, policy v1.ExposurePolicyType, proxySettings *kube.OAuth2ProxySettings) error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	_, err = client.CoreV1().Services(devNs).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to find service %s in namespace %s", name, devNs)
+	}
+
+	proxyURL := ""
+	if policy == v1.ExposurePolicyOAuth {
+		proxyURL, err = o.ensureOAuth2Proxy(devNs, proxySettings)
+		if err != nil {
+			return err
+		}
+	}
+
+	callback := func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		answer := []v1.ServiceExposure{}
+		for _, e := range settings.Exposure {
+			if e.Service != name {
+				answer = append(answer, e)
+			}
+		}
+		settings.Exposure = append(answer, v1.ServiceExposure{Service: name, Policy: policy})
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+
+	needsExpose, err := kube.ApplyServiceExposure(client, devNs, name, policy, proxyURL)
+	if err != nil {
+		return err
+	}
+	if needsExpose {
+		err = o.expose(devNs, devNs, "")
+		if err != nil {
+			return err
+		}
+	}
+	switch policy {
+	case v1.ExposurePolicyInternal:
+		log.Infof("Service %s is no longer exposed. Access it via %s\n", util.ColorInfo(name), util.ColorInfo(portForwardCommand(devNs, name)))
+	case v1.ExposurePolicyOAuth:
+		log.Infof("Service %s now requires a login via %s\n", util.ColorInfo(name), util.ColorInfo(proxyURL))
+	default:
+		log.Infof("Service %s is exposed publicly\n", util.ColorInfo(name))
+	}
+	return nil
+}
+
+// ensureOAuth2Proxy installs the OAuth2 proxy in the namespace if it is not installed and returns its external URL
+func (o *CommonOptions) ensureOAuth2Proxy(ns string, proxySettings *kube.OAuth2ProxySettings) (string, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	_, err = client.CoreV1().Services(ns).Get(kube.OAuth2ProxyName, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return "", err
+		}
+		err = o.installOAuth2Proxy(ns, proxySettings, false)
+		if err != nil {
+			return "", err
+		}
+	}
+	url, err := kube.FindServiceURL(client, ns, kube.OAuth2ProxyName)
+	if err != nil {
+		return "", err
+	}
+	if url == "" {
+		return "", fmt.Errorf("the %s service in namespace %s is not exposed", kube.OAuth2ProxyName, ns)
+	}
+	return url, nil
+}
+
+// installOAuth2Proxy installs or upgrades the OAuth2 proxy which logs users in via the provider and sets a cookie
+// valid for all the hosts of the domain of the team. The client ID and secret are kept in a Secret so that the proxy
+// can be upgraded without them
+func (o *CommonOptions) installOAuth2Proxy(ns string, settings *kube.OAuth2ProxySettings, rotateCookieSecret bool) error {
+	if settings == nil {
+		settings = &kube.OAuth2ProxySettings{}
+	}
+	err := settings.Validate()
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ic, err := kube.GetIngressConfig(client, ns)
+	if err != nil {
+		return errors.Wrapf(err, "cannot get the ingress config of namespace %s", ns)
+	}
+	if ic.Domain == "" {
+		return fmt.Errorf("no domain configured in ConfigMap %s in namespace %s", kube.IngressConfigConfigmap, ns)
+	}
+	err = kube.EnsureOAuth2ProxySecret(client, ns, settings, rotateCookieSecret)
+	if err != nil {
+		return errors.Wrap(err, "specify the client ID and secret of the OAuth application of the OAuth2 proxy")
+	}
+
+	values := []string{
+		"config.existingSecret=" + kube.OAuth2ProxyName,
+		"service.annotations.fabric8\\.io/expose=true",
+	}
+	args := kube.OAuth2ProxyArgs(settings, ns, ic)
+	keys := []string{}
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		values = append(values, "extraArgs."+k+"="+args[k])
+	}
+	log.Infof("Installing the OAuth2 proxy in namespace %s using the %s provider\n", util.ColorInfo(ns), util.ColorInfo(settings.Provider))
+	err = o.installChart(kube.OAuth2ProxyName, oauth2ProxyChart, "", ns, true, values)
+	if err != nil {
+		return errors.Wrap(err, "failed to install the OAuth2 proxy")
+	}
+	err = o.expose(ns, ns, "")
+	if err != nil {
+		return err
+	}
+	log.Infof("Register %s as the callback URL of the OAuth application\n", util.ColorInfo(kube.OAuth2ProxyCallbackURL(ns, ic)))
+	return nil
+}
+
+func portForwardCommand(ns string, name string) string {
+	return fmt.Sprintf("kubectl port-forward -n %s svc/%s 8080:80", ns, name)
+}
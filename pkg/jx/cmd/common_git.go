@@ -179,7 +179,7 @@ func (o *CommonOptions) ensureGitServiceCRD(server *auth.AuthServer) error {
 	if kind == "" || kind == "github" || server.URL == "" {
 		return nil
 	}
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const optionTokenFile = "token-file"
+
+// addTokenFileFlag adds the option to read an API token from a file or stdin rather than prompting for it
+func addTokenFileFlag(cmd *cobra.Command, tokenFile *string) {
+	cmd.Flags().StringVarP(tokenFile, optionTokenFile, "", "", "A file containing the API token or '-' to read it from stdin so that the token is not prompted for or visible in the arguments of the process")
+}
+
+// readTokenFile returns the token of the file given by the --token-file option or an empty token if there is no file
+func (o *CommonOptions) readTokenFile(tokenFile string) (string, error) {
+	if tokenFile == "" {
+		return "", nil
+	}
+	token, err := util.ReadToken(tokenFile, os.Stdin)
+	if err != nil {
+		return "", util.InvalidOptionError(optionTokenFile, tokenFile, err)
+	}
+	return token, nil
+}
+
+// isHeadless returns true if logins should use flows which do not open a browser on this machine
+func (o *CommonOptions) isHeadless() bool {
+	return o.Headless || util.IsHeadlessEnvironment()
+}
+
+// gcloudLoginArgs returns the arguments of 'gcloud auth login' which, when headless, prints a URL to open on any
+// machine and reads the verification code rather than opening a browser
+func (o *CommonOptions) gcloudLoginArgs() []string {
+	args := []string{"auth", "login", "--brief"}
+	if o.isHeadless() {
+		args = append(args, "--no-launch-browser")
+	}
+	return args
+}
+
+// azureLoginArgs returns the arguments of 'az login' which, when headless, uses the device code flow rather than
+// opening a browser
+func (o *CommonOptions) azureLoginArgs() []string {
+	args := []string{"login"}
+	if o.isHeadless() {
+		args = append(args, "--use-device-code")
+	}
+	return args
+}
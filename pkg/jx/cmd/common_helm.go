@@ -14,6 +14,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func (o *CommonOptions) registerLocalHelmRepo(repoName, ns string) error {
@@ -99,6 +100,15 @@ func (o *CommonOptions) installChart(releaseName string, chart string, version s
 
 // installChartAt installs the given chart
 func (o *CommonOptions) installChartAt(dir string, releaseName string, chart string, version string, ns string, helmUpdate bool, setValues []string) error {
+	return o.installChartAtWithValueFiles(dir, releaseName, chart, version, ns, helmUpdate, setValues, nil)
+}
+
+// installChartWithValueFiles installs the given chart using the given values files
+func (o *CommonOptions) installChartWithValueFiles(releaseName string, chart string, version string, ns string, helmUpdate bool, setValues []string, valueFiles []string) error {
+	return o.installChartAtWithValueFiles("", releaseName, chart, version, ns, helmUpdate, setValues, valueFiles)
+}
+
+func (o *CommonOptions) installChartAtWithValueFiles(dir string, releaseName string, chart string, version string, ns string, helmUpdate bool, setValues []string, valueFiles []string) error {
 	if helmUpdate {
 		log.Infoln("Updating Helm repository...")
 		err := o.Helm().UpdateRepo()
@@ -115,18 +125,65 @@ func (o *CommonOptions) installChartAt(dir string, releaseName string, chart str
 		annotations := map[string]string{"jenkins-x.io/created-by": "Jenkins X"}
 		kube.EnsureNamespaceCreated(kubeClient, ns, nil, annotations)
 	}
-	timeout, err := strconv.Atoi(defaultInstallTimeout)
+	timeout, err := o.ChartInstall.Timeout(releaseName, chart)
+	if err != nil {
+		return err
+	}
+	valueFiles, cleanup, err := o.expandValueFiles(valueFiles, nil)
 	if err != nil {
-		return errors.Wrap(err, "failed to convert the timeout to an int")
+		return err
 	}
+	defer cleanup()
 	o.Helm().SetCWD(dir)
-	return o.Helm().UpgradeChart(chart, releaseName, ns, &version, true,
-		&timeout, true, false, setValues, nil)
+	o.Helm().SetAtomic(o.ChartInstall.Atomic)
+	wait := !o.ChartInstall.NoWait
+	if wait && ns != "" {
+		stop := o.reportNotReadyResources(ns)
+		defer close(stop)
+	}
+	return o.withHelmLock(releaseName, "upgrade", func() error {
+		return o.Helm().UpgradeChart(chart, releaseName, ns, &version, true,
+			&timeout, true, wait, setValues, valueFiles)
+	})
+}
+
+// reportNotReadyResources logs the resources in the namespace which are not ready yet while helm waits for a chart
+// until the returned channel is closed
+func (o *CommonOptions) reportNotReadyResources(ns string) chan struct{} {
+	stop := make(chan struct{})
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return stop
+	}
+	go func() {
+		ticker := time.NewTicker(chartProgressInterval)
+		defer ticker.Stop()
+		last := ""
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				names, err := kube.NotReadyResources(client, ns)
+				if err != nil || len(names) == 0 {
+					continue
+				}
+				text := strings.Join(names, ", ")
+				if text != last {
+					log.Infof("Waiting for %s in namespace %s\n", text, ns)
+					last = text
+				}
+			}
+		}
+	}()
+	return stop
 }
 
 // deleteChart deletes the given chart
 func (o *CommonOptions) deleteChart(releaseName string, purge bool) error {
-	return o.Helm().DeleteRelease(releaseName, purge)
+	return o.withHelmLock(releaseName, "delete", func() error {
+		return o.Helm().DeleteRelease(releaseName, purge)
+	})
 }
 
 func (o *CommonOptions) FindHelmChart() (string, error) {
@@ -392,3 +449,31 @@ func (o *CommonOptions) ensureHelm() error {
 	}
 	return initOpts.initHelm()
 }
+
+// getChartMuseumCredentials returns the ChartMuseum user and password from the $CHARTMUSEUM_CREDS_USR and
+// $CHARTMUSEUM_CREDS_PSW environment variables or the ChartMuseum secret in the dev namespace of the team
+func (o *CommonOptions) getChartMuseumCredentials() (string, string, error) {
+	userName := os.Getenv("CHARTMUSEUM_CREDS_USR")
+	password := os.Getenv("CHARTMUSEUM_CREDS_PSW")
+	if userName == "" || password == "" {
+		// lets try load them from the secret directly
+		client, ns, err := o.KubeClientAndDevNamespace()
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to create the kube client")
+		}
+		secret, err := client.CoreV1().Secrets(ns).Get(kube.SecretJenkinsChartMuseum, metav1.GetOptions{})
+		if err != nil {
+			log.Warnf("Could not load Secret %s in namespace %s: %s\n", kube.SecretJenkinsChartMuseum, ns, err)
+		} else {
+			if secret != nil && secret.Data != nil {
+				if userName == "" {
+					userName = string(secret.Data["BASIC_AUTH_USER"])
+				}
+				if password == "" {
+					password = string(secret.Data["BASIC_AUTH_PASS"])
+				}
+			}
+		}
+	}
+	return userName, password, nil
+}
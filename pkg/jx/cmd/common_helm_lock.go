@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/alexflint/go-filemutex"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// helmLockDuration the time after which the lock of a release expires unless it is renewed
+	helmLockDuration = 2 * time.Minute
+
+	// helmLockPollInterval how often a queued helm operation checks if the lock of the release has been released
+	helmLockPollInterval = 5 * time.Second
+
+	// helmLockWaitTimeout the maximum time a helm operation is queued waiting for the lock of the release
+	helmLockWaitTimeout = 30 * time.Minute
+)
+
+// withHelmLock runs the helm operation on the release while holding the lock of the release so that concurrent jx
+// commands do not fail because tiller is already operating on the release. Operations started while the lock is
+// held are queued until it is released. The lock is a lease in the dev namespace or, when tiller runs locally, a
+// file lock in the jx home directory
+func (o *CommonOptions) withHelmLock(releaseName string, operation string, fn func() error) error {
+	_, noTiller, _ := o.TeamHelmBin()
+	if noTiller {
+		unlock, err := o.acquireLocalHelmLock(releaseName)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		return fn()
+	}
+
+	client, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		log.Warnf("Running without the helm lock of release %s as the dev namespace could not be found: %s\n", releaseName, err)
+		return fn()
+	}
+	lock := &kube.HelmLock{
+		Release:   releaseName,
+		Holder:    helmLockHolder(),
+		Operation: operation,
+		Duration:  helmLockDuration,
+	}
+	acquired, err := o.acquireHelmLock(client, ns, lock)
+	if err != nil {
+		return err
+	}
+	if acquired {
+		stop := o.renewHelmLock(client, ns, lock)
+		defer func() {
+			close(stop)
+			err := kube.ReleaseHelmLock(client, ns, lock)
+			if err != nil {
+				log.Warnf("Failed to release the helm lock of release %s: %s\n", releaseName, err)
+			}
+		}()
+	}
+	return fn()
+}
+
+// acquireHelmLock waits until the lock of the release is acquired. It returns false if the lock could not be stored
+// in which case the operation runs without it
+func (o *CommonOptions) acquireHelmLock(client kubernetes.Interface, ns string, lock *kube.HelmLock) (bool, error) {
+	deadline := time.Now().Add(helmLockWaitTimeout)
+	last := ""
+	for {
+		current, err := kube.TryAcquireHelmLock(client, ns, lock, time.Now())
+		if err != nil {
+			log.Warnf("Running without the helm lock of release %s as it could not be acquired: %s\n", lock.Release, err)
+			return false, nil
+		}
+		if current == nil {
+			return true, nil
+		}
+		message := current.Message()
+		if time.Now().After(deadline) {
+			return false, fmt.Errorf("timed out after %s waiting as %s", helmLockWaitTimeout.String(), message)
+		}
+		if message != last {
+			log.Warnf("Waiting as %s\n", util.ColorWarning(message))
+			last = message
+		}
+		time.Sleep(helmLockPollInterval)
+	}
+}
+
+// renewHelmLock renews the lease of the lock until the returned channel is closed
+func (o *CommonOptions) renewHelmLock(client kubernetes.Interface, ns string, lock *kube.HelmLock) chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(lock.Duration / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := kube.RenewHelmLock(client, ns, lock, time.Now())
+				if err != nil {
+					log.Warnf("Failed to renew the helm lock of release %s: %s\n", lock.Release, err)
+				}
+			}
+		}
+	}()
+	return stop
+}
+
+// acquireLocalHelmLock waits until the file lock of the release is acquired and returns the function which releases it.
+// The operating system releases the lock if jx crashes
+func (o *CommonOptions) acquireLocalHelmLock(releaseName string) (func(), error) {
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Join(configDir, "helm-locks")
+	err = os.MkdirAll(dir, util.DefaultWritePermissions)
+	if err != nil {
+		return nil, err
+	}
+	m, err := filemutex.New(filepath.Join(dir, kube.ToValidName(releaseName)+".lock"))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the helm lock of release %s", releaseName)
+	}
+	locked := make(chan error, 1)
+	go func() {
+		locked <- m.Lock()
+	}()
+	select {
+	case err = <-locked:
+	case <-time.After(time.Second):
+		log.Warnf("Waiting as another helm operation on release %s is in progress\n", util.ColorWarning(releaseName))
+		err = <-locked
+	}
+	if err != nil {
+		m.Close()
+		return nil, errors.Wrapf(err, "failed to acquire the helm lock of release %s", releaseName)
+	}
+	return func() {
+		m.Unlock()
+		m.Close()
+	}, nil
+}
+
+// helmLockHolder identifies this jx process as the holder of a helm lock
+func helmLockHolder() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s@%s/%d", currentUserName(), host, os.Getpid())
+}
@@ -0,0 +1,19 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/spf13/cobra"
+)
+
+// addHTTPDebugHook logs the HTTP requests made by git providers, version lookups and downloads when a command is run
+// with --verbose
+func addHTTPDebugHook(cmd *cobra.Command) {
+	preRun := cmd.PersistentPreRun
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		flag := c.Flags().Lookup("verbose")
+		httpclient.SetDebug(flag != nil && flag.Value.String() == "true")
+		if preRun != nil {
+			preRun(c, args)
+		}
+	}
+}
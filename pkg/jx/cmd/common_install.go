@@ -7,8 +7,10 @@ import (
 	"net/url"
 	"os"
 	"os/exec"
+	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 	"time"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/alexflint/go-filemutex"
 	"github.com/blang/semver"
 	jenkinsv1 "github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -61,6 +64,54 @@ type Prow struct {
 	ReleaseName string
 	HMACToken   string
 	OAUTHToken  string
+	// Lighthouse installs Lighthouse instead of Prow to handle the webhooks and ChatOps
+	Lighthouse bool
+}
+
+// chartProgressInterval how often the resources which are not ready yet are reported while helm waits for a chart
+const chartProgressInterval = 20 * time.Second
+
+// ChartInstallSettings how long helm waits for the charts of components to be installed and whether failed
+// releases are rolled back
+type ChartInstallSettings struct {
+	// Timeouts the number of seconds helm waits for each component indexed by its release or chart name
+	Timeouts map[string]int
+	NoWait   bool
+	Atomic   bool
+}
+
+// Timeout returns the number of seconds helm waits for the release of the chart
+func (s *ChartInstallSettings) Timeout(releaseName string, chart string) (int, error) {
+	for _, name := range []string{releaseName, chart, path.Base(chart)} {
+		timeout, ok := s.Timeouts[name]
+		if ok && timeout > 0 {
+			return timeout, nil
+		}
+	}
+	timeout, err := strconv.Atoi(defaultInstallTimeout)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to convert the timeout to an int")
+	}
+	return timeout, nil
+}
+
+// AddTimeouts adds the timeouts of the form NAME=SECONDS
+func (s *ChartInstallSettings) AddTimeouts(values []string) error {
+	for _, value := range values {
+		paths := strings.SplitN(value, "=", 2)
+		if len(paths) != 2 || paths[0] == "" {
+			return util.InvalidOptionf(optionComponentTimeout, value, "expected NAME=SECONDS")
+		}
+		timeout, err := strconv.Atoi(paths[1])
+		if err != nil || timeout <= 0 {
+			return util.InvalidOptionf(optionComponentTimeout, value, "the timeout must be a positive number of seconds")
+		}
+		if s.Timeouts == nil {
+			s.Timeouts = map[string]int{}
+		}
+		s.Timeouts[paths[0]] = timeout
+	}
+	return nil
 }
 
 func (o *CommonOptions) doInstallMissingDependencies(install []string) error {
@@ -119,6 +170,8 @@ func (o *CommonOptions) doInstallMissingDependencies(install []string) error {
 			err = o.installEksCtl()
 		case "heptio-authenticator-aws":
 			err = o.installHeptioAuthenticatorAws()
+		case "trivy":
+			err = o.installTrivy()
 		default:
 			return fmt.Errorf("unknown dependency to install %s\n", i)
 		}
@@ -164,12 +217,15 @@ func (o *CommonOptions) installBrew() error {
 func (o *CommonOptions) shouldInstallBinary(binDir string, name string) (fileName string, download bool, err error) {
 	fileName = name
 	download = false
-	if runtime.GOOS == "windows" {
+	if util.BinaryGOOS(name) == "windows" {
 		fileName += ".exe"
 	}
 	pgmPath, err := exec.LookPath(fileName)
 	if err == nil {
 		log.Warnf("%s is already available on your PATH at %s\n", util.ColorInfo(fileName), util.ColorInfo(pgmPath))
+		if fileName != name && util.IsWSL() {
+			err = util.LinkWindowsBinary(binDir, name, pgmPath)
+		}
 		return
 	}
 
@@ -179,7 +235,7 @@ func (o *CommonOptions) shouldInstallBinary(binDir string, name string) (fileNam
 		return
 	}
 	if exists {
-		log.Warnf("Please add %s to your PATH\n", util.ColorInfo(binDir))
+		logPathInstructions(binDir)
 		return
 	}
 	download = true
@@ -196,8 +252,14 @@ func (o *CommonOptions) downloadFile(clientURL string, fullPath string) error {
 	return nil
 }
 
+// useBrew returns true if brew should be used to install binaries which is only the case on MacOS when no bin
+// directory has been configured
+func (o *CommonOptions) useBrew() bool {
+	return runtime.GOOS == "darwin" && !o.NoBrew && !util.IsJXBinLocationConfigured()
+}
+
 func (o *CommonOptions) installBrewIfRequired() error {
-	if runtime.GOOS != "darwin" || o.NoBrew {
+	if !o.useBrew() {
 		return nil
 	}
 
@@ -213,8 +275,8 @@ func (o *CommonOptions) installBrewIfRequired() error {
 }
 
 func (o *CommonOptions) installKubectl() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.RunCommand("brew", "install", "kubectl")
+	if o.useBrew() && o.brewInstall(kubectlBrewFormula, false) {
+		return nil
 	}
 	binDir, err := util.JXBinLocation()
 	if err != nil {
@@ -430,7 +492,11 @@ func (o *CommonOptions) installXhyve() error {
 }
 
 func (o *CommonOptions) installhyperv() error {
-	info, err := o.getCommandOutput("", "powershell", "Get-WindowsOptionalFeature", "-FeatureName", "Microsoft-Hyper-V-All", "-Online")
+	powershell := "powershell"
+	if util.IsWSL() {
+		powershell = "powershell.exe"
+	}
+	info, err := o.getCommandOutput("", powershell, "Get-WindowsOptionalFeature", "-FeatureName", "Microsoft-Hyper-V-All", "-Online")
 
 	if err != nil {
 		return err
@@ -443,11 +509,11 @@ func (o *CommonOptions) installhyperv() error {
 
 		if util.Confirm(message, true, "Please indicate if you would like to restart your computer.") {
 
-			err = o.RunCommand("powershell", "Enable-WindowsOptionalFeature", "-Online", "-FeatureName", "Microsoft-Hyper-V", "-All", "-NoRestart")
+			err = o.RunCommand(powershell, "Enable-WindowsOptionalFeature", "-Online", "-FeatureName", "Microsoft-Hyper-V", "-All", "-NoRestart")
 			if err != nil {
 				return err
 			}
-			err = o.RunCommand("powershell", "Restart-Computer")
+			err = o.RunCommand(powershell, "Restart-Computer")
 			if err != nil {
 				return err
 			}
@@ -466,7 +532,7 @@ func (o *CommonOptions) installhyperv() error {
 func (o *CommonOptions) installHelm() error {
 	// TODO temporary hack while we are on the 2.10-rc version:
 	/*
-		if runtime.GOOS == "darwin" && !o.NoBrew {
+		if o.useBrew() {
 			return o.runCommand("brew", "install", "kubernetes-helm")
 		}
 	*/
@@ -563,7 +629,7 @@ func (o *CommonOptions) restartLocalTiller() error {
 
 func (o *CommonOptions) startLocalTiller(lazy bool) error {
 	tillerAddress := o.tillerAddress()
-	tillerArgs := os.Getenv("TILLER_ARGS")
+	tillerArgs := o.localSetting(config.SettingTillerArgs).Value
 	args := []string{"-listen", tillerAddress, "-alsologtostderr"}
 	if tillerArgs != "" {
 		args = append(args, tillerArgs)
@@ -634,11 +700,7 @@ func (o *CommonOptions) killProcessesTree(binary string, processes []*process.Pr
 
 // tillerAddress returns the address that tiller is listening on
 func (o *CommonOptions) tillerAddress() string {
-	tillerAddress := os.Getenv("TILLER_ADDR")
-	if tillerAddress == "" {
-		tillerAddress = ":44134"
-	}
-	return tillerAddress
+	return o.localSetting(config.SettingTillerAddress).Value
 }
 
 func (o *CommonOptions) installHelm3() error {
@@ -706,19 +768,11 @@ func (o *CommonOptions) installHelmSecretsPlugin(helmBinary string, clientOnly b
 		errors.Wrap(err, "failed to initialize helm")
 	}
 	// remove the plugin just in case is already installed
-	cmd := util.Command{
-		Name: helmBinary,
-		Args: []string{"plugin", "remove", "secrets"},
-	}
-	_, err = cmd.RunWithoutRetry()
+	_, err = o.getCommandOutput("", helmBinary, "plugin", "remove", "secrets")
 	if err != nil {
 		errors.Wrap(err, "failed to remove helm secrets")
 	}
-	cmd = util.Command{
-		Name: helmBinary,
-		Args: []string{"plugin", "install", "https://github.com/futuresimple/helm-secrets"},
-	}
-	_, err = cmd.RunWithoutRetry()
+	_, err = o.getCommandOutput("", helmBinary, "plugin", "install", "https://github.com/futuresimple/helm-secrets")
 	return err
 }
 
@@ -733,11 +787,7 @@ func (o *CommonOptions) installMavenIfRequired() error {
 	}
 	m.Lock()
 
-	cmd := util.Command{
-		Name: "mvn",
-		Args: []string{"-v"},
-	}
-	_, err = cmd.RunWithoutRetry()
+	_, err = o.getCommandOutput("", "mvn", "-v")
 	if err == nil {
 		m.Unlock()
 		return nil
@@ -809,8 +859,8 @@ func (o *CommonOptions) installMavenIfRequired() error {
 }
 
 func (o *CommonOptions) installTerraform() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.RunCommand("brew", "install", "terraform")
+	if o.useBrew() && o.brewInstall(terraformBrewFormula, false) {
+		return nil
 	}
 
 	binDir, err := util.JXBinLocation()
@@ -877,6 +927,50 @@ func (o *CommonOptions) installKops() error {
 	return os.Chmod(fullPath, 0755)
 }
 
+func (o *CommonOptions) installTrivy() error {
+	if o.useBrew() && o.brewInstall(trivyBrewFormula, false) {
+		return nil
+	}
+
+	binDir, err := util.JXBinLocation()
+	if err != nil {
+		return err
+	}
+	binary := "trivy"
+	fileName, flag, err := o.shouldInstallBinary(binDir, binary)
+	if err != nil || !flag {
+		return err
+	}
+	latestVersion, err := util.GetLatestVersionFromGitHub("aquasecurity", "trivy")
+	if err != nil {
+		return err
+	}
+	platform := "Linux"
+	if runtime.GOOS == "darwin" {
+		platform = "macOS"
+	}
+	arch := "64bit"
+	if runtime.GOARCH == "arm64" {
+		arch = "ARM64"
+	}
+	clientURL := fmt.Sprintf("https://github.com/aquasecurity/trivy/releases/download/v%s/trivy_%s_%s-%s.tar.gz", latestVersion, latestVersion, platform, arch)
+	fullPath := filepath.Join(binDir, fileName)
+	tarFile := fullPath + ".tgz"
+	err = o.downloadFile(clientURL, tarFile)
+	if err != nil {
+		return err
+	}
+	err = util.UnTargz(tarFile, binDir, []string{binary, fileName})
+	if err != nil {
+		return err
+	}
+	err = os.Remove(tarFile)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(fullPath, 0755)
+}
+
 func (o *CommonOptions) installKSync() (bool, error) {
 	binDir, err := util.JXBinLocation()
 	if err != nil {
@@ -908,25 +1002,67 @@ func (o *CommonOptions) installKSync() (bool, error) {
 	return true, os.Chmod(fullPath, 0755)
 }
 
-func (o *CommonOptions) installJx(upgrade bool, version string) error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		if upgrade {
-			return o.RunCommand("brew", "upgrade", "jx")
-		} else {
-			return o.RunCommand("brew", "install", "jx")
-		}
+func (o *CommonOptions) installHelmfile() error {
+	if o.useBrew() && o.brewInstall(helmfileBrewFormula, false) {
+		return nil
 	}
+
 	binDir, err := util.JXBinLocation()
 	if err != nil {
 		return err
 	}
-	// Check for jx binary in non standard path and install there instead if found...
-	nonStandardBinDir, err := util.JXBinaryLocation(&util.Command{})
-	if err == nil && binDir != nonStandardBinDir {
-		binDir = nonStandardBinDir
+	binary := "helmfile"
+	fileName, flag, err := o.shouldInstallBinary(binDir, binary)
+	if err != nil || !flag {
+		return err
+	}
+	latestVersion, err := util.GetLatestVersionFromGitHub("roboll", "helmfile")
+	if err != nil {
+		return err
+	}
+	clientURL := fmt.Sprintf("https://github.com/roboll/helmfile/releases/download/v%s/helmfile_%s_%s", latestVersion, runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		clientURL += ".exe"
+	}
+	fullPath := filepath.Join(binDir, fileName)
+	tmpFile := fullPath + ".tmp"
+	err = o.downloadFile(clientURL, tmpFile)
+	if err != nil {
+		return err
+	}
+	err = util.RenameFile(tmpFile, fullPath)
+	if err != nil {
+		return err
+	}
+	return os.Chmod(fullPath, 0755)
+}
+
+// jxInstallDir returns the directory jx is installed into. This is the configured bin directory, otherwise the
+// directory of the jx binary on the PATH so that the binary which is run gets replaced, otherwise the bin directory
+// of the jx home directory
+func (o *CommonOptions) jxInstallDir() (string, error) {
+	if !util.IsJXBinLocationConfigured() {
+		dir, err := util.JXBinaryLocation(&util.Command{})
+		if err == nil && dir != "" {
+			return dir, nil
+		}
+	}
+	return util.JXBinLocation()
+}
+
+func (o *CommonOptions) installJx(upgrade bool, version string) error {
+	if o.useBrew() && o.brewInstall(jxBrewFormula, upgrade) {
+		return nil
+	}
+	binDir, err := o.jxInstallDir()
+	if err != nil {
+		return err
 	}
 	binary := "jx"
 	fileName := binary
+	if runtime.GOOS == "windows" {
+		fileName += ".exe"
+	}
 	if !upgrade {
 		f, flag, err := o.shouldInstallBinary(binDir, binary)
 		if err != nil || !flag {
@@ -944,30 +1080,44 @@ func (o *CommonOptions) installJx(upgrade bool, version string) error {
 		version = fmt.Sprintf("%s", latestVersion)
 	}
 	clientURL := fmt.Sprintf("https://github.com/"+org+"/"+repo+"/releases/download/v%s/"+binary+"-%s-%s.tar.gz", version, runtime.GOOS, runtime.GOARCH)
-	fullPath := filepath.Join(binDir, fileName)
-	tarFile := fullPath + ".tgz"
-	err = o.downloadFile(clientURL, tarFile)
+	// download into the bin directory so that the new binary can be renamed over the existing one
+	tmpDir, err := ioutil.TempDir(binDir, ".jx-install-")
 	if err != nil {
 		return err
 	}
-	err = os.Remove(binDir + "/jx")
-	if err != nil && o.Verbose {
-		log.Infof("Skipping removal of old jx binary: %s\n", err)
+	defer os.RemoveAll(tmpDir)
+	tarFile := filepath.Join(tmpDir, binary+".tgz")
+	err = o.downloadFile(clientURL, tarFile)
+	if err != nil {
+		return err
 	}
-	err = util.UnTargz(tarFile, binDir, []string{binary, fileName})
+	err = util.UnTargz(tarFile, tmpDir, []string{binary, fileName})
 	if err != nil {
 		return err
 	}
-	log.Infof("Jenkins X client has been installed into %s\n", util.ColorInfo(binDir+"/jx"))
-	err = os.Remove(tarFile)
+	fullPath := filepath.Join(binDir, fileName)
+	err = util.ReplaceBinary(filepath.Join(tmpDir, fileName), fullPath)
 	if err != nil {
 		return err
 	}
-	return os.Chmod(fullPath, 0755)
+	log.Infof("Jenkins X client has been installed into %s\n", util.ColorInfo(fullPath))
+	logPathInstructions(binDir)
+	return nil
+}
+
+// logPathInstructions explains how to add the directory to the PATH if it is not on the PATH yet
+func logPathInstructions(dir string) {
+	if util.IsDirOnPath(dir, os.Getenv("PATH")) {
+		return
+	}
+	log.Warnf("%s is not on your PATH. Add it via:\n", dir)
+	for _, line := range util.PathInstructions(dir, runtime.GOOS, os.Getenv("SHELL")) {
+		log.Infof("  %s\n", util.ColorInfo(line))
+	}
 }
 
 func (o *CommonOptions) installMinikube() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
+	if o.useBrew() && !isVersionPinned("kubernetes", "minikube") {
 		return o.RunCommand("brew", "cask", "install", "minikube")
 	}
 
@@ -983,7 +1133,13 @@ func (o *CommonOptions) installMinikube() error {
 	if err != nil {
 		return err
 	}
-	clientURL := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/minikube-%s-%s", latestVersion, runtime.GOOS, runtime.GOARCH)
+	// inside WSL the Windows binary is installed as minikube has to drive the Windows hypervisors
+	goos := util.BinaryGOOS("minikube")
+	extension := ""
+	if goos == "windows" {
+		extension = ".exe"
+	}
+	clientURL := fmt.Sprintf("https://github.com/kubernetes/minikube/releases/download/v%s/minikube-%s-%s%s", latestVersion, goos, runtime.GOARCH, extension)
 	fullPath := filepath.Join(binDir, fileName)
 	tmpFile := fullPath + ".tmp"
 	err = o.downloadFile(clientURL, tmpFile)
@@ -994,11 +1150,18 @@ func (o *CommonOptions) installMinikube() error {
 	if err != nil {
 		return err
 	}
-	return os.Chmod(fullPath, 0755)
+	err = os.Chmod(fullPath, 0755)
+	if err != nil {
+		return err
+	}
+	if fileName != "minikube" && util.IsWSL() {
+		return util.LinkWindowsBinary(binDir, "minikube", fullPath)
+	}
+	return nil
 }
 
 func (o *CommonOptions) installMinishift() error {
-	if runtime.GOOS == "darwin" && !o.NoBrew {
+	if o.useBrew() && !isVersionPinned("minishift", "minishift") {
 		return o.RunCommand("brew", "cask", "install", "minishift")
 	}
 
@@ -1033,7 +1196,7 @@ func (o *CommonOptions) installGcloud() error {
 	if runtime.GOOS != "darwin" || o.NoBrew {
 		return errors.New("please install missing gloud sdk - see https://cloud.google.com/sdk/downloads#interactive")
 	}
-	err := o.RunCommand("brew", "tap", "caskroom/cask")
+	err := o.ensureBrewTap("caskroom/cask")
 	if err != nil {
 		return err
 	}
@@ -1152,7 +1315,7 @@ func (o *CommonOptions) installHeptioAuthenticatorAws() error {
 func (o *CommonOptions) GetCloudProvider(p string) (string, error) {
 	if p == "" {
 		// lets detect minikube
-		currentContext, err := o.getCommandOutput("", "kubectl", "config", "current-context")
+		currentContext, err := kube.CurrentContextName()
 		if err == nil && currentContext == "minikube" {
 			p = MINIKUBE
 		}
@@ -1171,7 +1334,7 @@ func (o *CommonOptions) GetCloudProvider(p string) (string, error) {
 			Help:    "Cloud service providing the kubernetes cluster, local VM (minikube), Google (GKE), Oracle (OKE), Azure (AKS)",
 		}
 
-		survey.AskOne(prompt, &p, nil)
+		util.AskOne(prompt, &p, nil)
 	}
 	return p, nil
 }
@@ -1189,7 +1352,7 @@ func (o *CommonOptions) getClusterDependencies(deps []string) []string {
 
 	// Platform specific deps
 	if runtime.GOOS == "darwin" {
-		if !o.NoBrew {
+		if o.useBrew() {
 			d = binaryShouldBeInstalled("brew")
 			if d != "" && util.StringArrayIndex(deps, d) < 0 {
 				deps = append(deps, d)
@@ -1221,7 +1384,7 @@ func (o *CommonOptions) installMissingDependencies(providerSpecificDeps []string
 			Options: deps,
 			Default: deps,
 		}
-		survey.AskOne(prompt, &install, nil)
+		util.AskOne(prompt, &install, nil)
 	}
 
 	return o.doInstallMissingDependencies(install)
@@ -1338,7 +1501,11 @@ func (o *CommonOptions) updateJenkinsURL(namespaces []string) error {
 		data := url.Values{}
 		data.Add("script", fmt.Sprintf(groovy, externalURL))
 
+		// the Jenkins client adds the CSRF crumb required by hardened Jenkins servers
 		err = jenkins.Post("/scriptText", data, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update the external URL of Jenkins in namespace %s", n)
+		}
 	}
 
 	return nil
@@ -1346,7 +1513,13 @@ func (o *CommonOptions) updateJenkinsURL(namespaces []string) error {
 
 func (o *CommonOptions) GetClusterUserName() (string, error) {
 
-	username, _ := o.getCommandOutput("", "gcloud", "config", "get-value", "core/account")
+	// gcloud must not wait for a login when it is not authenticated
+	username, _ := o.getCommandOutputWith(&util.Command{
+		Name:        "gcloud",
+		Args:        []string{"config", "get-value", "core/account"},
+		Env:         map[string]string{"CLOUDSDK_CORE_DISABLE_PROMPTS": "1"},
+		ExecTimeout: 30 * time.Second,
+	})
 
 	if username != "" {
 		return GetSafeUsername(username), nil
@@ -1380,6 +1553,19 @@ func GetSafeUsername(username string) string {
 }
 
 func (o *CommonOptions) installProw() error {
+	engine := "prow"
+	if o.Lighthouse {
+		engine = "lighthouse"
+		if o.ReleaseName == "" {
+			o.ReleaseName = prow.DefaultLighthouseReleaseName
+		}
+		if o.Chart == "" {
+			o.Chart = prow.ChartLighthouse
+		}
+		if o.Version == "" {
+			o.Version = prow.LighthouseVersion
+		}
+	}
 
 	if o.ReleaseName == "" {
 		o.ReleaseName = prow.DefaultProwReleaseName
@@ -1393,47 +1579,15 @@ func (o *CommonOptions) installProw() error {
 		o.Version = prow.ProwVersion
 	}
 
-	var err error
-	if o.HMACToken == "" {
-		// why 41?  seems all examples so far have a random token of 41 chars
-		o.HMACToken, err = util.RandStringBytesMaskImprSrc(41)
-		if err != nil {
-			return fmt.Errorf("cannot create a random hmac token for Prow")
-		}
-	}
-
-	if o.OAUTHToken == "" {
-		authConfigSvc, err := o.CreateGitAuthConfigService()
-		if err != nil {
-			return err
-		}
-
-		config := authConfigSvc.Config()
-		if "" == config.CurrentServer {
-			config.CurrentServer = "https://github.com"
-		}
-
-		server := config.GetOrCreateServer(config.CurrentServer)
-		userAuth, err := config.PickServerUserAuth(server, "Git account to be used to send webhook events", o.BatchMode, "")
-		if err != nil {
-			return err
-		}
-		o.OAUTHToken = userAuth.ApiToken
-	}
-
-	if o.Username == "" {
-		o.Username, err = o.GetClusterUserName()
-		if err != nil {
-			return err
-		}
-	}
-
 	devNamespace, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
 	if err != nil {
 		return fmt.Errorf("cannot find a dev team namespace to get existing exposecontroller config from. %v", err)
 	}
 
-	values := []string{"user=" + o.Username, "oauthToken=" + o.OAUTHToken, "hmacToken=" + o.HMACToken}
+	values, err := o.prowSecretValues(devNamespace)
+	if err != nil {
+		return err
+	}
 	setValues := strings.Split(o.SetValues, ",")
 	values = append(values, setValues...)
 
@@ -1443,10 +1597,10 @@ func (o *CommonOptions) installProw() error {
 	})
 
 	if err != nil {
-		return fmt.Errorf("failed to install prow: %v", err)
+		return fmt.Errorf("failed to install %s: %v", engine, err)
 	}
 
-	log.Infof("Installing prow into namespace %s\n", util.ColorInfo(devNamespace))
+	log.Infof("Installing %s into namespace %s\n", engine, util.ColorInfo(devNamespace))
 
 	err = o.retry(2, time.Second, func() (err error) {
 		err = o.installChart(prow.DefaultKnativeBuildReleaseName, prow.ChartKnativeBuild, "", devNamespace, true, values)
@@ -1457,9 +1611,67 @@ func (o *CommonOptions) installProw() error {
 		return fmt.Errorf("failed to install knative build: %v", err)
 	}
 
+	// Lighthouse and Prow share the hook service and hmac-token Secret so webhooks are registered the same way
+	// and the configuration of the repositories already added to the other engine is carried across
+	if o.Lighthouse {
+		err = prow.SyncLighthouseConfig(o.KubeClientCached, devNamespace)
+	} else {
+		err = prow.ImportLighthouseConfig(o.KubeClientCached, devNamespace)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to translate the %s configuration", engine)
+	}
 	return nil
 }
 
+// prowSecretValues returns the git user, OAuth token and HMAC token chart values of Prow or Lighthouse. Unless the
+// HMAC token is given the token of an existing installation in the namespace is reused so that the webhooks which
+// are already registered keep working
+func (o *CommonOptions) prowSecretValues(ns string) ([]string, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	if o.HMACToken == "" {
+		secret, err := client.CoreV1().Secrets(ns).Get("hmac-token", metav1.GetOptions{})
+		if err == nil {
+			o.HMACToken = string(secret.Data["hmac"])
+		}
+	}
+	if o.HMACToken == "" {
+		// why 41?  seems all examples so far have a random token of 41 chars
+		o.HMACToken, err = util.RandStringBytesMaskImprSrc(41)
+		if err != nil {
+			return nil, fmt.Errorf("cannot create a random hmac token for Prow")
+		}
+	}
+
+	if o.OAUTHToken == "" {
+		authConfigSvc, err := o.CreateGitAuthConfigService()
+		if err != nil {
+			return nil, err
+		}
+
+		config := authConfigSvc.Config()
+		config.CurrentServer = config.DefaultServerURL(gits.GitHubURL)
+
+		server := config.GetOrCreateServer(config.CurrentServer)
+		userAuth, err := config.PickServerUserAuth(server, "Git account to be used to send webhook events", o.BatchMode, "")
+		if err != nil {
+			return nil, err
+		}
+		o.OAUTHToken = userAuth.ApiToken
+	}
+
+	if o.Username == "" {
+		o.Username, err = o.GetClusterUserName()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return []string{"user=" + o.Username, "oauthToken=" + o.OAUTHToken, "hmacToken=" + o.HMACToken}, nil
+}
+
 func (o *CommonOptions) createWebhookProw(gitURL string, gitProvider gits.GitProvider) error {
 	ns, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
 	if err != nil {
@@ -1469,9 +1681,13 @@ func (o *CommonOptions) createWebhookProw(gitURL string, gitProvider gits.GitPro
 	if err != nil {
 		return err
 	}
-	baseURL, err := kube.GetServiceURLFromName(o.KubeClientCached, "hook", ns)
+	// send the webhooks via the hook relay when it is installed so that failed deliveries are replayed
+	baseURL, err := kube.GetServiceURLFromName(o.KubeClientCached, hookRelayServiceName, ns)
 	if err != nil {
-		return err
+		baseURL, err = kube.GetServiceURLFromName(o.KubeClientCached, "hook", ns)
+		if err != nil {
+			return err
+		}
 	}
 	webhookUrl := util.UrlJoin(baseURL, "hook")
 
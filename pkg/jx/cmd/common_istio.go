@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	istioRepoName = "istio.io"
+	istioRepoURL  = "https://storage.googleapis.com/istio-release/releases/1.0.6/charts"
+
+	// istioGatewayChart the published Istio chart which installs the ingress gateway
+	istioGatewayChart   = "istio.io/istio"
+	istioGatewayVersion = "1.0.6"
+)
+
+// istioGatewayValues enables the ingress gateway of the Istio chart
+var istioGatewayValues = []string{
+	"gateways.istio-ingressgateway.enabled=true",
+	"global.k8sIngress.enabled=false",
+}
+
+// exposeIstio routes the exposed services of the target namespace through the Istio gateway of the team by
+// creating a VirtualService for each of them
+func (o *CommonOptions) exposeIstio(devNamespace, targetNamespace string, ic kube.IngressConfig) error {
+	if ic.Domain == "" {
+		return fmt.Errorf("no domain configured in ConfigMap %s in namespace %s", kube.IngressConfigConfigmap, devNamespace)
+	}
+	services, err := o.KubeClientCached.CoreV1().Services(targetNamespace).List(metav1.ListOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to list the services in namespace %s", targetNamespace)
+	}
+	gateway := devNamespace + "/" + kube.IstioGatewayName
+	for _, svc := range services.Items {
+		if svc.Annotations[kube.AnnotationExpose] != "true" {
+			continue
+		}
+		host := svc.Name + "." + targetNamespace + "." + ic.Domain
+		vs := kube.NewIstioVirtualService(svc.Name, host, gateway, istioServicePort(&svc))
+		err = kube.ApplyIstioVirtualService(o.KubeClientCached, targetNamespace, vs)
+		if err != nil {
+			return err
+		}
+		log.Infof("Exposed service %s at %s\n", util.ColorInfo(svc.Name), util.ColorInfo(host))
+	}
+	return nil
+}
+
+func istioServicePort(svc *corev1.Service) int {
+	if len(svc.Spec.Ports) > 0 {
+		return int(svc.Spec.Ports[0].Port)
+	}
+	return 0
+}
+
+// addIstioHelmRepoHook adds the helm repository of the published Istio charts
+func addIstioHelmRepoHook(o *CommonOptions, addon *v1.Addon) error {
+	return o.addHelmRepoIfMissing(istioRepoURL, istioRepoName)
+}
+
+// configureIstioGatewayHook creates the Gateway of the team and switches the team to expose its services through it
+func configureIstioGatewayHook(o *CommonOptions, addon *v1.Addon) error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	ic, err := kube.GetIngressConfig(client, devNs)
+	if err != nil {
+		return errors.Wrapf(err, "cannot get the ingress config of namespace %s", devNs)
+	}
+	if ic.Domain == "" {
+		return fmt.Errorf("no domain configured in ConfigMap %s in namespace %s", kube.IngressConfigConfigmap, devNs)
+	}
+	err = kube.ApplyIstioGateway(client, devNs, kube.NewIstioGateway(kube.IstioGatewayName, ic.Domain, ic.TLS))
+	if err != nil {
+		return err
+	}
+	err = o.setTeamExposer(devNs, kube.ExposerIstio)
+	if err != nil {
+		return err
+	}
+	return exposeTeamHook(o, addon)
+}
+
+// removeIstioGatewayHook switches the team back to exposing its services via Ingress and deletes its Gateway
+func removeIstioGatewayHook(o *CommonOptions, addon *v1.Addon) error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = o.setTeamExposer(devNs, "Ingress")
+	if err != nil {
+		return err
+	}
+	return kube.DeleteIstioGateway(client, devNs, kube.IstioGatewayName)
+}
+
+func (o *CommonOptions) setTeamExposer(devNs string, exposer string) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	configMaps := client.CoreV1().ConfigMaps(devNs)
+	cm, err := configMaps.Get(kube.IngressConfigConfigmap, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "cannot get ConfigMap %s in namespace %s", kube.IngressConfigConfigmap, devNs)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	if cm.Data[kube.Exposer] == exposer {
+		return nil
+	}
+	cm.Data[kube.Exposer] = exposer
+	_, err = configMaps.Update(cm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ConfigMap %s in namespace %s", kube.IngressConfigConfigmap, devNs)
+	}
+	log.Infof("Services of the team are now exposed using %s\n", util.ColorInfo(exposer))
+	return nil
+}
+
+// exposeTeamHook exposes the services of the dev namespace using the current exposer of the team
+func exposeTeamHook(o *CommonOptions, addon *v1.Addon) error {
+	_, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	return o.expose(devNs, devNs, "")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/cloud/kms"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/util/buckets"
+	"github.com/spf13/cobra"
+)
+
+const optionKMSKey = "kms-key"
+
+// KMSFlags the flags of the cloud KMS key used to encrypt exported secrets and backups
+type KMSFlags struct {
+	Key      string
+	Provider string
+}
+
+func (f *KMSFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.Key, optionKMSKey, "", "", "The cloud KMS key to encrypt with. Either the URL of the key, using one of the schemes "+
+		strings.Join(kms.Schemes, ", ")+", or the name of a key of the KMS of the cluster's cloud provider")
+	cmd.Flags().StringVarP(&f.Provider, "provider", "", "", "The cloud provider of the cluster used to resolve the KMS key from its name")
+}
+
+// createKMSManager creates the manager of the KMS key resolving a key name via the credentials of the cluster's
+// cloud provider
+func (o *CommonOptions) createKMSManager(key string, provider string) (*kms.Manager, error) {
+	if key == "" {
+		return nil, util.MissingOption(optionKMSKey)
+	}
+	credentials, err := o.kmsCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if !strings.Contains(key, "://") {
+		if provider == "" && o.BatchMode {
+			return nil, util.InvalidOptionf(optionKMSKey, key, "specify the URL of the key or the --provider of the cluster")
+		}
+		provider, err = o.GetCloudProvider(provider)
+		if err != nil {
+			return nil, err
+		}
+	}
+	k, err := kms.ResolveKey(key, provider, credentials)
+	if err != nil {
+		return nil, err
+	}
+	return kms.NewManager(k, credentials)
+}
+
+// openKMSSealed decrypts data encrypted by a KMS key using the key recorded alongside the data
+func (o *CommonOptions) openKMSSealed(data []byte) ([]byte, error) {
+	keyURL, err := kms.SealedKeyURL(data)
+	if err != nil {
+		return nil, err
+	}
+	m, err := o.createKMSManager(keyURL, "")
+	if err != nil {
+		return nil, err
+	}
+	return m.Open(data)
+}
+
+// kmsCredentials returns the provider credentials configured for the cluster, which are the same credentials used
+// to access its storage buckets
+func (o *CommonOptions) kmsCredentials() (*buckets.Credentials, error) {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return buckets.CredentialsFromEnvironment()
+	}
+	return buckets.ResolveCredentials(kubeClient, devNs)
+}
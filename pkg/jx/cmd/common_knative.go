@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	knativeServingChart     = "jenkins-x/knative-serving"
+	knativeServingNamespace = "knative-serving"
+
+	// knativeDomainConfigMap the ConfigMap of Knative Serving which configures the domain of the Routes
+	knativeDomainConfigMap = "config-domain"
+
+	istioVirtualServiceCRD = "virtualservices.networking.istio.io"
+)
+
+// requireIstioHook checks Istio is installed as Knative Serving routes requests through it
+func requireIstioHook(o *CommonOptions, addon *v1.Addon) error {
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	_, err = apisClient.ApiextensionsV1beta1().CustomResourceDefinitions().Get(istioVirtualServiceCRD, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("addon %s requires Istio to be installed, try running 'jx addon install istio-gateway'", addon.Name)
+	}
+	return nil
+}
+
+// configureKnativeDomainHook configures Knative Serving to create the Routes of the apps on the domain of the team
+func configureKnativeDomainHook(o *CommonOptions, addon *v1.Addon) error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	ic, err := kube.GetIngressConfig(client, devNs)
+	if err != nil {
+		return errors.Wrapf(err, "cannot get the ingress config of namespace %s", devNs)
+	}
+	if ic.Domain == "" {
+		return nil
+	}
+	configMaps := client.CoreV1().ConfigMaps(addon.Spec.Namespace)
+	cm, err := configMaps.Get(knativeDomainConfigMap, metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "cannot get ConfigMap %s in namespace %s", knativeDomainConfigMap, addon.Spec.Namespace)
+	}
+	cm.Data = map[string]string{ic.Domain: ""}
+	_, err = configMaps.Update(cm)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update ConfigMap %s in namespace %s", knativeDomainConfigMap, addon.Spec.Namespace)
+	}
+	log.Infof("Knative Services are available on the domain %s\n", util.ColorInfo(ic.Domain))
+	return nil
+}
+
+// configureKnativeTeamSettingsHook makes Knative Services the default deploy kind of the apps imported by the team
+func configureKnativeTeamSettingsHook(o *CommonOptions, addon *v1.Addon) error {
+	return o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.TeamSettings.DeployKind = v1.DeployKindKnative
+		return nil
+	})
+}
+
+// removeKnativeTeamSettingsHook switches the team back to deploying new apps as a Deployment and Service
+func removeKnativeTeamSettingsHook(o *CommonOptions, addon *v1.Addon) error {
+	return o.ModifyDevEnvironment(func(env *v1.Environment) error {
+		env.Spec.TeamSettings.DeployKind = v1.DeployKindDefault
+		return nil
+	})
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// localClusterIP returns the IP address of the node of a local cluster such as minikube or kind which the exposed
+// services of the cluster are reachable on from the host
+func (o *CommonOptions) localClusterIP() (string, error) {
+	context, err := kube.CurrentContextName()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get the current context")
+	}
+	if context == "minikube" {
+		return o.getCommandOutput("", "minikube", "ip")
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to list the nodes")
+	}
+	for _, node := range nodes.Items {
+		for _, address := range node.Status.Addresses {
+			if address.Type == corev1.NodeInternalIP && address.Address != "" {
+				return address.Address, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("could not discover the IP address of a node of the cluster, try specifying it via the --ip flag")
+}
+
+// localServiceURLs returns the URLs of the exposed services of the development namespace and of all the
+// environments of the team, including preview environments
+func (o *CommonOptions) localServiceURLs() ([]string, error) {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return nil, err
+	}
+	namespaces := []string{devNs}
+	envs, err := jxClient.JenkinsV1().Environments(devNs).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to list the environments in namespace %s", devNs)
+	}
+	for _, env := range envs.Items {
+		ns := env.Spec.Namespace
+		if ns != "" && ns != devNs {
+			namespaces = append(namespaces, ns)
+		}
+	}
+	answer := []string{}
+	for _, ns := range namespaces {
+		urls, err := kube.FindServiceURLs(client, ns)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to find the service URLs in namespace %s", ns)
+		}
+		for _, u := range urls {
+			answer = append(answer, u.URL)
+		}
+	}
+	return answer, nil
+}
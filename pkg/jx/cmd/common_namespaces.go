@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	namespaceCompletionFunc   = "__jx_get_namespaces"
+	environmentCompletionFunc = "__jx_get_environments"
+)
+
+// bash_completion_func the functions which complete the --namespace and --env options via the hidden
+// 'jx completion namespaces' command
+const bash_completion_func = `
+__jx_get_namespaces()
+{
+    local jx_out
+    if jx_out=$(jx completion namespaces 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${jx_out[*]}" -- "$cur" ) )
+    fi
+}
+
+__jx_get_environments()
+{
+    local jx_out
+    if jx_out=$(jx completion namespaces --environments 2>/dev/null); then
+        COMPREPLY=( $( compgen -W "${jx_out[*]}" -- "$cur" ) )
+    fi
+}
+`
+
+// NamespaceResolver resolves the current, development and Environment namespaces of a command. The namespaces are
+// cached on the CommonOptions so that they are only looked up once however many helpers need them
+type NamespaceResolver struct {
+	options *CommonOptions
+}
+
+// Namespaces returns the resolver of the namespaces of the command
+func (o *CommonOptions) Namespaces() *NamespaceResolver {
+	return &NamespaceResolver{options: o}
+}
+
+// CurrentNamespace returns the current namespace of the kube config
+func (r *NamespaceResolver) CurrentNamespace() (string, error) {
+	_, ns, err := r.options.KubeClient()
+	return ns, err
+}
+
+// DevNamespace returns the development namespace of the team of the current namespace
+func (r *NamespaceResolver) DevNamespace() (string, error) {
+	o := r.options
+	if o.devNamespace != "" {
+		return o.devNamespace, nil
+	}
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	devNs, _, err := kube.GetDevNamespace(client, ns)
+	if err != nil {
+		return "", err
+	}
+	o.devNamespace = devNs
+	return devNs, nil
+}
+
+// EnvironmentNamespaces returns the namespaces of the Environments of the team indexed by the name of the Environment
+func (r *NamespaceResolver) EnvironmentNamespaces() (map[string]string, error) {
+	o := r.options
+	if o.envNamespaces != nil {
+		return o.envNamespaces, nil
+	}
+	devNs, err := r.DevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return nil, err
+	}
+	envMap, _, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return nil, err
+	}
+	answer := map[string]string{}
+	for name, env := range envMap {
+		answer[name] = env.Spec.Namespace
+	}
+	o.envNamespaces = answer
+	return answer, nil
+}
+
+// EnvironmentNames returns the sorted names of the Environments of the team
+func (r *NamespaceResolver) EnvironmentNames() ([]string, error) {
+	envNamespaces, err := r.EnvironmentNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	return util.SortedMapKeys(envNamespaces), nil
+}
+
+// EnvironmentNamespace returns the namespace of the Environment of the given name
+func (r *NamespaceResolver) EnvironmentNamespace(envName string) (string, error) {
+	envNamespaces, err := r.EnvironmentNamespaces()
+	if err != nil {
+		return "", err
+	}
+	answer, ok := envNamespaces[envName]
+	if !ok {
+		return "", util.InvalidOption(optionEnvironment, envName, util.SortedMapKeys(envNamespaces))
+	}
+	if answer == "" {
+		return "", fmt.Errorf("Environment %s does not have a Namespace!", envName)
+	}
+	return answer, nil
+}
+
+// Resolve returns the namespace of the --namespace and --env options of a command. The namespace takes precedence
+// over the Environment and the current namespace is used if neither is specified
+func (r *NamespaceResolver) Resolve(namespace string, envName string) (string, error) {
+	if namespace != "" {
+		return namespace, nil
+	}
+	if envName != "" {
+		return r.EnvironmentNamespace(envName)
+	}
+	return r.CurrentNamespace()
+}
+
+// CompletionNamespaces returns the sorted namespaces which are completed for the --namespace option: the development
+// namespace and the namespaces of the Environments of the team
+func (r *NamespaceResolver) CompletionNamespaces() ([]string, error) {
+	devNs, err := r.DevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	envNamespaces, err := r.EnvironmentNamespaces()
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{devNs}
+	for _, ns := range envNamespaces {
+		if ns != "" && util.StringArrayIndex(answer, ns) < 0 {
+			answer = append(answer, ns)
+		}
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+// addNamespaceFlags adds the --namespace and --env options whose values are completed with the namespaces and
+// Environments of the team. Use NamespaceResolver.Resolve to get the namespace they select
+func addNamespaceFlags(cmd *cobra.Command, namespace *string, envName *string) {
+	cmd.Flags().StringVarP(namespace, optionNamespace, "n", "", "Specifies the namespace name to look inside")
+	cmd.Flags().StringVarP(envName, optionEnvironment, "e", "", "Specifies the Environment name to look inside")
+	cmd.Flags().SetAnnotation(optionNamespace, cobra.BashCompCustom, []string{namespaceCompletionFunc})
+	cmd.Flags().SetAnnotation(optionEnvironment, cobra.BashCompCustom, []string{environmentCompletionFunc})
+}
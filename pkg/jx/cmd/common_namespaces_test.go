@@ -0,0 +1,68 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	jxfake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNamespaceResolver(t *testing.T) {
+	t.Parallel()
+	kubeClient := fake.NewSimpleClientset(&corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   "jx-staging",
+			Labels: map[string]string{kube.LabelTeam: "jx", kube.LabelEnvironment: "staging"},
+		},
+	})
+	jxClient := jxfake.NewSimpleClientset(
+		&v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "dev", Namespace: "jx"}, Spec: v1.EnvironmentSpec{Namespace: "jx"}},
+		&v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "staging", Namespace: "jx"}, Spec: v1.EnvironmentSpec{Namespace: "jx-staging"}},
+		&v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: "jx"}, Spec: v1.EnvironmentSpec{Namespace: "jx-production"}},
+	)
+	o := cmd.NewEmbeddedOptions(cmd.EmbeddedClients{
+		KubeClient: kubeClient,
+		JXClient:   jxClient,
+		Namespace:  "jx-staging",
+	})
+	resolver := o.Namespaces()
+
+	devNs, err := resolver.DevNamespace()
+	require.NoError(t, err)
+	assert.Equal(t, "jx", devNs)
+
+	ns, err := resolver.Resolve("", "")
+	require.NoError(t, err)
+	assert.Equal(t, "jx-staging", ns, "the current namespace is used by default")
+	ns, err = resolver.Resolve("", "production")
+	require.NoError(t, err)
+	assert.Equal(t, "jx-production", ns)
+	ns, err = resolver.Resolve("other", "production")
+	require.NoError(t, err)
+	assert.Equal(t, "other", ns, "the namespace takes precedence over the environment")
+	_, err = resolver.Resolve("", "missing")
+	assert.Error(t, err)
+
+	names, err := resolver.EnvironmentNames()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"dev", "production", "staging"}, names)
+	namespaces, err := resolver.CompletionNamespaces()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jx", "jx-production", "jx-staging"}, namespaces)
+
+	// the namespaces are cached on the options
+	_, _, err = o.JXClientAndDevNamespace()
+	require.NoError(t, err)
+	err = kubeClient.CoreV1().Namespaces().Delete("jx-staging", &metav1.DeleteOptions{})
+	require.NoError(t, err)
+	devNs, err = o.Namespaces().DevNamespace()
+	require.NoError(t, err)
+	assert.Equal(t, "jx", devNs)
+}
@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/nexus"
+	"gopkg.in/yaml.v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// createNexusClient creates a client for the Nexus in the dev namespace. If no password is specified the default
+// admin password from the install configuration is used
+func (o *CommonOptions) createNexusClient(username string, password string) (*nexus.Client, error) {
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	devNs, _, err := kube.GetDevNamespace(client, ns)
+	if err != nil {
+		return nil, err
+	}
+	url, err := kube.FindServiceURL(client, devNs, kube.ServiceNexus)
+	if err != nil || url == "" {
+		return nil, fmt.Errorf("could not find the URL of the %s service in namespace %s. Is Nexus installed?", kube.ServiceNexus, devNs)
+	}
+	if password == "" {
+		password, err = o.getNexusAdminPassword(devNs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nexus.NewClient(url, username, password), nil
+}
+
+// getNexusAdminPassword returns the default Nexus admin password from the install configuration
+func (o *CommonOptions) getNexusAdminPassword(devNamespace string) (string, error) {
+	secret, err := o.KubeClientCached.CoreV1().Secrets(devNamespace).Get(JXInstallConfig, metav1.GetOptions{})
+	if err != nil {
+		return "", fmt.Errorf("cannot find secret %s in namespace %s: %v", JXInstallConfig, devNamespace, err)
+	}
+	adminConfig := config.AdminSecretsConfig{}
+	err = yaml.Unmarshal(secret.Data[AdminSecretsFile], &adminConfig)
+	if err != nil {
+		return "", err
+	}
+	if adminConfig.Nexus == nil || adminConfig.Nexus.DefaultAdminPassword == "" {
+		return "", fmt.Errorf("no Nexus admin password found in secret %s. Please specify one via --password", JXInstallConfig)
+	}
+	return adminConfig.Nexus.DefaultAdminPassword, nil
+}
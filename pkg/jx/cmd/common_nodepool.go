@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/cloud/nodepool"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionNodePoolCluster = "cluster"
+	optionResourceGroup   = "resource-group"
+)
+
+// NodePoolFlags the flags identifying the cluster whose node pools are managed
+type NodePoolFlags struct {
+	Provider      string
+	Cluster       string
+	Project       string
+	Zone          string
+	ResourceGroup string
+}
+
+func (f *NodePoolFlags) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&f.Provider, "provider", "", "", "The cloud provider of the cluster: "+strings.Join(nodepool.Providers, ", "))
+	cmd.Flags().StringVarP(&f.Cluster, optionNodePoolCluster, "c", "", "The name of the cluster")
+	cmd.Flags().StringVarP(&f.Project, "project-id", "p", "", "The GKE project of the cluster")
+	cmd.Flags().StringVarP(&f.Zone, "zone", "z", "", "The GKE zone or EKS region of the cluster")
+	cmd.Flags().StringVarP(&f.ResourceGroup, optionResourceGroup, "", "", "The AKS resource group of the cluster")
+}
+
+// createNodePoolManager creates the manager of the node pools of the cluster using the cloud provider CLI
+func (o *CommonOptions) createNodePoolManager(flags *NodePoolFlags) (*nodepool.Manager, error) {
+	provider, err := o.GetCloudProvider(flags.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if util.StringArrayIndex(nodepool.Providers, provider) < 0 {
+		return nil, util.InvalidOptionf("provider", provider, "node pools can only be managed for the providers: %s", strings.Join(nodepool.Providers, ", "))
+	}
+	if flags.Cluster == "" {
+		return nil, util.MissingOption(optionNodePoolCluster)
+	}
+	if provider == AKS && flags.ResourceGroup == "" {
+		return nil, util.MissingOption(optionResourceGroup)
+	}
+	return nodepool.NewManager(nodepool.Cluster{
+		Provider:      provider,
+		Name:          flags.Cluster,
+		Project:       flags.Project,
+		Zone:          flags.Zone,
+		ResourceGroup: flags.ResourceGroup,
+	})
+}
@@ -0,0 +1,22 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// openShiftBuildPodSCCs the security context constraints build pods need to run docker builds as root
+var openShiftBuildPodSCCs = []string{"anyuid", "hostaccess", "privileged"}
+
+// enableOpenShiftBuildPodSCC lets build pods running as the service account in the namespace use the security
+// context constraints they need
+func (o *CommonOptions) enableOpenShiftBuildPodSCC(ns string, serviceAccount string) error {
+	log.Infof("Enabling the build pod security context constraints for service account %s in namespace %s\n", util.ColorInfo(serviceAccount), util.ColorInfo(ns))
+	for _, scc := range openShiftBuildPodSCCs {
+		err := o.RunCommand("oc", "adm", "policy", "add-scc-to-user", scc, "system:serviceaccount:"+ns+":"+serviceAccount)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
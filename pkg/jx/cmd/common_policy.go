@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/policy"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// validatePolicies renders the helm chart in the given directory and validates the generated resources against the
+// policies in the policy git repository of the team. Nothing is validated if the team has no policy repository
+func (o *CommonOptions) validatePolicies(dir string, releaseName string, ns string) error {
+	return o.validateRenderedPolicies(dir, func(outDir string) error {
+		o.Helm().SetCWD(dir)
+		err := o.Helm().Template(".", releaseName, ns, outDir, nil, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to render the helm chart in %s", dir)
+		}
+		return nil
+	})
+}
+
+// validateHelmfilePolicies renders the releases of the helmfile and validates the generated resources against the
+// policies of the team in the same way as validatePolicies does for a chart
+func (o *CommonOptions) validateHelmfilePolicies(fileName string, ns string) error {
+	return o.validateRenderedPolicies(fileName, func(outDir string) error {
+		err := o.installHelmfile()
+		if err != nil {
+			return errors.Wrap(err, "failed to install helmfile")
+		}
+		args := []string{"--file", fileName, "--namespace", ns, "--helm-binary", o.Helm().HelmBinary(), "template",
+			"--args", "--output-dir " + outDir}
+		_, err = o.getCommandOutput(filepath.Dir(fileName), "helmfile", args...)
+		if err != nil {
+			return errors.Wrapf(err, "failed to render the releases of helmfile %s", fileName)
+		}
+		return nil
+	})
+}
+
+// validateRenderedPolicies validates the resources which the render function generates into the output directory
+// against the policies of the team. The source is the chart or helmfile the resources are generated from
+func (o *CommonOptions) validateRenderedPolicies(source string, render func(outDir string) error) error {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	gitURL := settings.PolicyGitURL
+	if gitURL == "" {
+		return nil
+	}
+
+	tmpDir, err := ioutil.TempDir("", "jx-policy-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	policyDir := filepath.Join(tmpDir, "policies")
+	err = o.Git().Clone(gitURL, policyDir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clone the policy repository %s", gitURL)
+	}
+	config, err := policy.LoadConfig(policyDir)
+	if err != nil {
+		return err
+	}
+
+	outDir := filepath.Join(tmpDir, "manifests")
+	err = os.MkdirAll(outDir, util.DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	err = render(outDir)
+	if err != nil {
+		return err
+	}
+	manifests, err := policy.LoadManifests(outDir)
+	if err != nil {
+		return err
+	}
+
+	violations := policy.Validate(manifests, config)
+	hasRego, err := policy.HasRegoPolicies(policyDir)
+	if err != nil {
+		return err
+	}
+	if hasRego {
+		_, err = exec.LookPath("opa")
+		if err != nil {
+			return fmt.Errorf("the policy repository %s contains rego policies but the opa binary could not be found on the PATH", gitURL)
+		}
+		regoViolations, err := policy.EvaluateRego(policyDir, manifests)
+		if err != nil {
+			return err
+		}
+		violations = append(violations, regoViolations...)
+	}
+
+	if len(violations) == 0 {
+		log.Infof("The %d resources generated from %s comply with the policies in %s\n", len(manifests), util.ColorInfo(source), util.ColorInfo(gitURL))
+		return nil
+	}
+	policy.SortViolations(violations)
+	log.Warnf("The resources generated from %s violate the policies in %s:\n", source, gitURL)
+	for _, v := range violations {
+		log.Warnf("  %s %s/%s in %s: %s\n", util.ColorError(v.Policy), v.Kind, v.Name, v.File, v.Message)
+	}
+	return fmt.Errorf("found %d policy violations", len(violations))
+}
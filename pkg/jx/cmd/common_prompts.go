@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// promptFlags the global flags of how prompts behave
+type promptFlags struct {
+	NoInput     bool
+	NoColor     bool
+	Accessible  bool
+	AnswersFile string
+}
+
+// addPromptFlags adds the global flags of how prompts behave which override the environment variables so that even
+// deeply nested prompts honour them
+func addPromptFlags(cmd *cobra.Command) {
+	flags := &promptFlags{}
+	cmd.PersistentFlags().BoolVarP(&flags.NoInput, "no-input", "", false, "Never prompts for input. The answers file or the defaults of prompts are used instead or the command fails. Defaults to $"+util.NoInputEnvVar)
+	cmd.PersistentFlags().BoolVarP(&flags.NoColor, "no-color", "", false, "Disables colors. Defaults to true if $"+util.NoColorEnvVar+" is set")
+	cmd.PersistentFlags().BoolVarP(&flags.Accessible, "accessible", "", false, "Uses plain line based prompts which work with screen readers. Defaults to $"+util.AccessibleEnvVar)
+	cmd.PersistentFlags().StringVarP(&flags.AnswersFile, "answers-file", "", "", "A YAML file of the answers to prompts indexed by the message of the prompt. Defaults to $"+util.AnswersFileEnvVar)
+
+	preRun := cmd.PersistentPreRun
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		err := configurePrompts(c, flags)
+		if err != nil {
+			CheckErr(err)
+		}
+		if preRun != nil {
+			preRun(c, args)
+		}
+	}
+}
+
+func configurePrompts(c *cobra.Command, flags *promptFlags) error {
+	settings, err := util.PromptSettingsFromEnv()
+	if err != nil {
+		return err
+	}
+	settings.NoInput = settings.NoInput || flags.NoInput
+	settings.NoColor = settings.NoColor || flags.NoColor
+	settings.Accessible = settings.Accessible || flags.Accessible
+	if flags.AnswersFile != "" {
+		settings.Answers, err = util.LoadPromptAnswers(flags.AnswersFile)
+		if err != nil {
+			return err
+		}
+	}
+	if settings.NoInput {
+		// commands which check for batch mode rather than prompting should not prompt either
+		batchMode := c.Flags().Lookup("batch-mode")
+		if batchMode != nil {
+			batchMode.Value.Set("true")
+		}
+	}
+	util.ConfigurePrompts(settings)
+	return nil
+}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// loadInstalledRequirements loads the requirements the platform was last installed or upgraded with
+// returning nil if there are none
+func (o *CommonOptions) loadInstalledRequirements(ns string) (*config.RequirementsConfig, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	return readInstalledRequirements(client, ns)
+}
+
+func readInstalledRequirements(client kubernetes.Interface, ns string) (*config.RequirementsConfig, error) {
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapNameJXRequirements, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil
+	}
+	data := cm.Data[config.RequirementsConfigFileName]
+	if data == "" {
+		return nil, nil
+	}
+	requirements, err := config.ParseRequirementsConfig([]byte(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid requirements in ConfigMap %s in namespace %s", kube.ConfigMapNameJXRequirements, ns)
+	}
+	return requirements, nil
+}
+
+// saveInstalledRequirements stores the requirements in the dev namespace and, if the dev environment is
+// managed via GitOps, creates a Pull Request to store them in the git repository of the dev environment
+func (o *CommonOptions) saveInstalledRequirements(requirements *config.RequirementsConfig, ns string) error {
+	text, err := requirements.String()
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(kube.ConfigMapNameJXRequirements, metav1.GetOptions{})
+	if err != nil {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kube.ConfigMapNameJXRequirements,
+			},
+			Data: map[string]string{config.RequirementsConfigFileName: text},
+		}
+		_, err = configMaps.Create(cm)
+	} else {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[config.RequirementsConfigFileName] = text
+		_, err = configMaps.Update(cm)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the requirements to ConfigMap %s in namespace %s", kube.ConfigMapNameJXRequirements, ns)
+	}
+
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	devEnv, err := kube.GetEnvironment(jxClient, ns, kube.LabelValueDevEnvironment)
+	if err != nil || devEnv == nil || devEnv.Spec.Source.URL == "" {
+		return nil
+	}
+	return o.createRequirementsPullRequest(devEnv, requirements)
+}
+
+func (o *CommonOptions) createRequirementsPullRequest(env *v1.Environment, requirements *config.RequirementsConfig) error {
+	modifyRequirementsFn := func(r *helm.Requirements) error {
+		return nil
+	}
+	modifyDirFn := func(dir string) error {
+		return requirements.SaveConfig(filepath.Join(dir, config.RequirementsConfigFileName))
+	}
+	branchName := "jx-requirements"
+	title := "Update the platform requirements"
+	message := fmt.Sprintf("Update %s with the requirements of the platform", config.RequirementsConfigFileName)
+	info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, modifyDirFn, branchName, title, message, nil, nil)
+	if err != nil {
+		return errors.Wrapf(err, "failed to create a Pull Request with the requirements on environment %s", env.Name)
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Created Pull Request %s to update the platform requirements\n", util.ColorInfo(info.PullRequest.URL))
+	}
+	return nil
+}
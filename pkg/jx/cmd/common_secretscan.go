@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/secretscan"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionSecretScanner = "secret-scanner"
+	optionAllowSecrets  = "allow-secrets"
+)
+
+// SecretScanOptions the options for scanning source code for committed secrets
+type SecretScanOptions struct {
+	SecretScanner string
+	AllowSecrets  bool
+}
+
+func (o *SecretScanOptions) addSecretScanFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&o.SecretScanner, optionSecretScanner, "", "", "The scanner used to detect committed secrets: 'builtin', 'gitleaks', 'trufflehog', 'none' or the command line of an external scanner which is passed the directory to scan and exits with 1 if it finds secrets. Defaults to the team setting")
+	cmd.Flags().BoolVarP(&o.AllowSecrets, optionAllowSecrets, "", false, "Continue even if committed secrets are detected")
+}
+
+// scanForSecrets scans the directory for committed secrets using the scanner from the options or the team settings
+func (o *CommonOptions) scanForSecrets(scanOptions *SecretScanOptions, dir string, description string) error {
+	scannerName := scanOptions.SecretScanner
+	fromTeam := scannerName == ""
+	if fromTeam {
+		settings, err := o.TeamSettings()
+		if err != nil {
+			log.Warnf("Unable to load the team settings to find the secret scanner: %s\n", err)
+			return nil
+		}
+		scannerName = settings.SecretScanner
+	}
+	// only the command line of a scanner passed by the user is run as the team settings are editable by others
+	scanner, err := secretscan.NewScanner(scannerName, !fromTeam)
+	if err != nil {
+		if fromTeam {
+			return errors.Wrap(err, "invalid secret scanner in the team settings")
+		}
+		return util.InvalidOptionError(optionSecretScanner, scannerName, err)
+	}
+	if scanner == nil {
+		return nil
+	}
+	findings, err := scanner.Scan(dir)
+	if err != nil {
+		return err
+	}
+	if len(findings) == 0 {
+		return nil
+	}
+	log.Warnf("Found %d possible secrets committed to %s:\n", len(findings), description)
+	for _, f := range findings {
+		log.Warnf("  %s\n", f.String())
+	}
+	if scanOptions.AllowSecrets {
+		log.Warnf("Continuing as --%s was specified\n", optionAllowSecrets)
+		return nil
+	}
+	return fmt.Errorf("found %d possible secrets committed to %s. Remove them, add the comment %s to the line or use --%s to continue", len(findings), description, util.ColorInfo(secretscan.IgnoreMarker), optionAllowSecrets)
+}
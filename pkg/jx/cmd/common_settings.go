@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// addSettingsHook applies the settings of the environment variables and the settings file to the options of the
+// command which were not set on the command line so that every command honours them with the same precedence
+func addSettingsHook(cmd *cobra.Command) {
+	preRun := cmd.PersistentPreRun
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		err := applySettings(c)
+		if err != nil {
+			CheckErr(err)
+		}
+		if preRun != nil {
+			preRun(c, args)
+		}
+	}
+}
+
+func applySettings(c *cobra.Command) error {
+	resolver, err := localSettingsResolver(c)
+	if err != nil {
+		return err
+	}
+	for _, setting := range config.Settings {
+		resolved := resolver.Resolve(setting)
+		if resolved.Source != config.SettingSourceEnv && resolved.Source != config.SettingSourceFile {
+			continue
+		}
+		if !setting.IsBoundTo(c.CommandPath()) {
+			continue
+		}
+		flag := c.Flags().Lookup(setting.Flag)
+		if flag != nil {
+			// the value is set without marking the flag as changed so that the flag still has a lower precedence
+			err = flag.Value.Set(resolved.Value)
+			if err != nil {
+				return util.InvalidInputError(err)
+			}
+		}
+	}
+	util.SetJXBinLocation(resolver.Resolve(config.SettingBinDir).Value)
+	caBundle := resolver.Resolve(config.SettingCABundle).Value
+	if caBundle != "" {
+		err = httpclient.AddCABundleFile(caBundle)
+		if err != nil {
+			return util.InvalidInputError(err)
+		}
+	}
+	return nil
+}
+
+// localSettingsResolver returns the resolver of the settings of the command line options of the command, the
+// environment variables and the settings file
+func localSettingsResolver(c *cobra.Command) (*config.SettingsResolver, error) {
+	fileName, err := config.SettingsFile()
+	if err != nil {
+		return nil, err
+	}
+	file, err := config.LoadSettingsFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	flags := map[string]string{}
+	if c != nil {
+		for _, setting := range config.Settings {
+			if !setting.IsBoundTo(c.CommandPath()) {
+				continue
+			}
+			flag := c.Flags().Lookup(setting.Flag)
+			if flag != nil && flag.Changed {
+				flags[setting.Flag] = flag.Value.String()
+			}
+		}
+	}
+	return &config.SettingsResolver{
+		Flags: flags,
+		File:  file,
+	}, nil
+}
+
+// SettingsResolver returns the resolver of the settings of the command. The settings of the team are only
+// included if includeTeam is true as they are loaded from the cluster
+func (o *CommonOptions) SettingsResolver(includeTeam bool) (*config.SettingsResolver, error) {
+	resolver, err := localSettingsResolver(o.Cmd)
+	if err != nil {
+		return nil, err
+	}
+	if includeTeam {
+		teamSettings, err := o.TeamSettings()
+		if err != nil {
+			return nil, err
+		}
+		resolver.Team = map[string]string{
+			config.SettingHelmBinary.Name: teamSettings.HelmBinary,
+		}
+	}
+	return resolver, nil
+}
+
+// localSetting returns the value of the setting ignoring the settings of the team
+func (o *CommonOptions) localSetting(setting config.Setting) config.ResolvedSetting {
+	resolver, err := o.SettingsResolver(false)
+	if err != nil {
+		log.Warnf("%s\n", err)
+		resolver = &config.SettingsResolver{}
+	}
+	return resolver.Resolve(setting)
+}
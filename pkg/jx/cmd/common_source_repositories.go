@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+func (o *CommonOptions) registerSourceRepositoryCRD() error {
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterSourceRepositoryCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the SourceRepository CRD")
+	}
+	return nil
+}
+
+// pipelineRepository returns the git repository of the 'owner/repo' argument on the git server or the git repository
+// of the current directory if no argument is given
+func (o *CommonOptions) pipelineRepository(args []string, gitServerURL string) (*gits.GitRepositoryInfo, error) {
+	if len(args) == 0 {
+		return o.FindGitInfo("")
+	}
+	paths := strings.Split(strings.Trim(args[0], "/"), "/")
+	if len(paths) != 2 || paths[0] == "" || paths[1] == "" {
+		return nil, util.InvalidArgf(args[0], "expected a repository of the form owner/repo")
+	}
+	if gitServerURL == "" {
+		authConfigSvc, err := o.CreateGitAuthConfigService()
+		if err != nil {
+			return nil, err
+		}
+		gitServerURL = authConfigSvc.Config().DefaultServerURL(gits.GitHubURL)
+	}
+	return gits.ParseGitURL(util.UrlJoin(gitServerURL, paths[0], paths[1]))
+}
+
+// modifySourceRepository applies the changes to the SourceRepository resource of the git repository in the dev
+// namespace creating the resource if it does not exist yet
+func (o *CommonOptions) modifySourceRepository(gitInfo *gits.GitRepositoryInfo, fn func(sr *v1.SourceRepository)) error {
+	err := o.registerSourceRepositoryCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	sr, err := kube.GetOrCreateSourceRepository(jxClient, devNs, gitInfo.Organisation, gitInfo.Name, gitInfo.HostURLWithoutUser())
+	if err != nil {
+		return errors.Wrapf(err, "failed to get the SourceRepository of %s/%s", gitInfo.Organisation, gitInfo.Name)
+	}
+	fn(sr)
+	_, err = jxClient.JenkinsV1().SourceRepositories(devNs).Update(sr)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the SourceRepository %s", sr.Name)
+	}
+	return nil
+}
+
+// getSourceRepository returns the SourceRepository resource of the git repository in the dev namespace
+func (o *CommonOptions) getSourceRepository(gitInfo *gits.GitRepositoryInfo) (*v1.SourceRepository, error) {
+	err := o.registerSourceRepositoryCRD()
+	if err != nil {
+		return nil, err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return kube.GetOrCreateSourceRepository(jxClient, devNs, gitInfo.Organisation, gitInfo.Name, gitInfo.HostURLWithoutUser())
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+// updateTeamMemberRoles binds the user to the given team roles in the team dev namespace and then
+// updates the permissions of the user in the CI system of the team to match
+func (o *CommonOptions) updateTeamMemberRoles(ns string, user *v1.User, roles []string) error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureDefaultTeamRoles(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	teamRoles, roleNames, err := kube.GetTeamRoles(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	for _, role := range roles {
+		if util.StringArrayIndex(roleNames, role) < 0 {
+			return util.InvalidOption(optionRole, role, roleNames)
+		}
+	}
+
+	name := user.Name
+	userKind := user.SubjectKind()
+	err = kube.UpdateUserRoles(kubeClient, jxClient, ns, userKind, name, roles, teamRoles)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the roles of user %s kind %s", name, userKind)
+	}
+
+	login := user.Spec.Login
+	if login == "" {
+		login = name
+	}
+
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	if settings.PromotionEngine == v1.PromotionEngineProw {
+		log.Infof("Prow uses the git provider permissions and the OWNERS files of each repository so please grant %s access to the team repositories\n", util.ColorInfo(login))
+		return nil
+	}
+	err = o.updateJenkinsUserPermissions(login, roles)
+	if err != nil {
+		log.Warnf("Failed to update the Jenkins permissions of user %s: %s\n", login, err)
+	}
+	return nil
+}
+
+// updateJenkinsUserPermissions updates the permissions of the given Jenkins user to match the given team roles
+func (o *CommonOptions) updateJenkinsUserPermissions(login string, roles []string) error {
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	jenkinsURL, err := o.Factory.GetJenkinsURL(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	authConfigSvc, err := o.Factory.CreateJenkinsAuthConfigService(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	for _, auth := range authConfigSvc.Config().FindUserAuths(jenkinsURL) {
+		if !auth.IsInvalid() {
+			err = jenkins.UpdateUserPermissions(jenkinsURL, auth.Username, auth.ApiToken, login, jenkins.PermissionsForRoles(roles))
+			if err != nil {
+				return err
+			}
+			log.Infof("Updated the Jenkins permissions of user %s\n", util.ColorInfo(login))
+			return nil
+		}
+	}
+	return fmt.Errorf("no Jenkins API token found for %s", jenkinsURL)
+}
@@ -6,6 +6,7 @@ import (
 	"reflect"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -48,6 +49,11 @@ func (o *CommonOptions) TeamSettings() (*v1.TeamSettings, error) {
 	if teamSettings.BuildPackRef == "" {
 		teamSettings.BuildPackRef = defaultBuildPackRef
 	}
+	err = o.expandTeamSettings(teamSettings)
+	if err != nil {
+		return nil, err
+	}
+	addTeamCABundle(teamSettings)
 	return teamSettings, nil
 }
 
@@ -86,6 +92,11 @@ func (o *CommonOptions) TeamHelmBin() (string, bool, error) {
 	if helmBin == "" {
 		helmBin = defaultHelmBin
 	}
+	// a helm binary set by an option, environment variable or the settings file overrides the team
+	resolved := o.localSetting(config.SettingHelmBinary)
+	if resolved.Source != config.SettingSourceDefault {
+		helmBin = resolved.Value
+	}
 	return helmBin, teamSettings.NoTiller, nil
 }
 
@@ -117,7 +128,7 @@ func (o *CommonOptions) ModifyDevEnvironment(callback func(env *v1.Environment)
 }
 
 func (o *CommonOptions) registerReleaseCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -129,7 +140,7 @@ func (o *CommonOptions) registerReleaseCRD() error {
 }
 
 func (o *CommonOptions) registerTeamCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -141,7 +152,7 @@ func (o *CommonOptions) registerTeamCRD() error {
 }
 
 func (o *CommonOptions) registerUserCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -153,7 +164,7 @@ func (o *CommonOptions) registerUserCRD() error {
 }
 
 func (o *CommonOptions) registerEnvironmentRoleBindingCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -164,8 +175,20 @@ func (o *CommonOptions) registerEnvironmentRoleBindingCRD() error {
 	return nil
 }
 
+func (o *CommonOptions) registerHookFailureCRD() error {
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterHookFailureCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the HookFailure CRD")
+	}
+	return nil
+}
+
 func (o *CommonOptions) registerPipelineActivityCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -177,7 +200,7 @@ func (o *CommonOptions) registerPipelineActivityCRD() error {
 }
 
 func (o *CommonOptions) registerWorkflowCRD() error {
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
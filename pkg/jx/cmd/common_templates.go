@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// loadTemplateContext loads the template context of the team in the dev namespace from the installed requirements
+// and the current cluster
+func loadTemplateContext(kubeClient kubernetes.Interface, devNs string, requirements *config.RequirementsConfig) (*config.TemplateContext, error) {
+	if requirements == nil {
+		var err error
+		requirements, err = readInstalledRequirements(kubeClient, devNs)
+		if err != nil {
+			return nil, err
+		}
+	}
+	clusterName := ""
+	kubeConfig, _, err := kube.LoadConfig()
+	if err == nil && kubeConfig != nil {
+		clusterName, _ = kube.CurrentCluster(kubeConfig)
+	}
+	return config.NewTemplateContext(requirements, clusterName, devNs), nil
+}
+
+// expandTemplateValue resolves the expressions in a value such as a webhook URL which reference the template context
+// of the team in the dev namespace
+func expandTemplateValue(kubeClient kubernetes.Interface, devNs string, value string) (string, error) {
+	if !config.HasTemplateExpressions(value) {
+		return value, nil
+	}
+	ctx, err := loadTemplateContext(kubeClient, devNs, nil)
+	if err != nil {
+		return value, err
+	}
+	return ctx.Expand(value)
+}
+
+// templateContext returns the template context of the current team. The requirements being applied are used if
+// specified, otherwise the requirements the platform was installed with
+func (o *CommonOptions) templateContext(requirements *config.RequirementsConfig) (*config.TemplateContext, error) {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	return loadTemplateContext(kubeClient, devNs, requirements)
+}
+
+// expandTeamSettings resolves the expressions in the team settings which reference the template context
+func (o *CommonOptions) expandTeamSettings(settings *v1.TeamSettings) error {
+	values := []*string{
+		&settings.BuildPackURL,
+		&settings.BuildPackRef,
+		&settings.HelmBinary,
+		&settings.AuditWebhookURL,
+		&settings.PolicyGitURL,
+		&settings.DockerRegistryOrg,
+		&settings.TillerNamespace,
+	}
+	for i := range settings.QuickstartLocations {
+		location := &settings.QuickstartLocations[i]
+		values = append(values, &location.GitURL, &location.Owner, &location.CatalogURL)
+	}
+	found := false
+	for _, value := range values {
+		if config.HasTemplateExpressions(*value) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil
+	}
+	ctx, err := o.templateContext(nil)
+	if err != nil {
+		return err
+	}
+	return errors.Wrap(ctx.ExpandValues(values...), "failed to expand the team settings")
+}
+
+// expandValueFiles expands the helm values files which reference the template context into a temporary directory.
+// The returned function removes the expanded files
+func (o *CommonOptions) expandValueFiles(valueFiles []string, requirements *config.RequirementsConfig) ([]string, func(), error) {
+	cleanup := func() {}
+	found := false
+	for _, fileName := range valueFiles {
+		data, err := ioutil.ReadFile(fileName)
+		if err == nil && config.HasTemplateExpressions(string(data)) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return valueFiles, cleanup, nil
+	}
+	ctx, err := o.templateContext(requirements)
+	if err != nil {
+		return nil, cleanup, err
+	}
+	dir, err := ioutil.TempDir("", "jx-values-")
+	if err != nil {
+		return nil, cleanup, err
+	}
+	cleanup = func() {
+		os.RemoveAll(dir)
+	}
+	answer := []string{}
+	for _, fileName := range valueFiles {
+		expanded, err := ctx.ExpandFile(fileName, dir)
+		if err != nil {
+			cleanup()
+			return nil, func() {}, err
+		}
+		answer = append(answer, expanded)
+	}
+	return answer, cleanup, nil
+}
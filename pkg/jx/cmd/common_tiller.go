@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// tillerTLSDir returns the directory the TLS certificates used to connect to the tiller in the namespace are stored in
+func tillerTLSDir(tillerNamespace string) (string, error) {
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "tiller", tillerNamespace), nil
+}
+
+// createTillerTLS generates the certificates used for mutual TLS between the helm client and the tiller in the
+// namespace, storing the client certificate in a Secret so that other members of the team can use the tiller
+func (o *CommonOptions) createTillerTLS(tillerNamespace string) (*helm.TLSFiles, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	certs, err := helm.GenerateTLSCerts(tillerNamespace, helm.DefaultTLSValidity)
+	if err != nil {
+		return nil, err
+	}
+	dir, err := tillerTLSDir(tillerNamespace)
+	if err != nil {
+		return nil, err
+	}
+	files, err := helm.WriteTLSFiles(dir, certs)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := client.CoreV1().Secrets(tillerNamespace)
+	secret, err := secrets.Get(helm.SecretTillerClientTLS, metav1.GetOptions{})
+	if err == nil {
+		secret.Data = certs.ClientSecretData()
+		_, err = secrets.Update(secret)
+	} else {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      helm.SecretTillerClientTLS,
+				Namespace: tillerNamespace,
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: certs.ClientSecretData(),
+		}
+		_, err = secrets.Create(secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to save Secret %s in namespace %s: %s", helm.SecretTillerClientTLS, tillerNamespace, err)
+	}
+	log.Infof("Generated the tiller TLS certificates in %s\n", util.ColorInfo(dir))
+	return files, nil
+}
+
+// loadTillerTLS returns the client certificates used to connect to the tiller in the namespace, downloading them
+// from the tiller namespace if they are not available locally
+func (o *CommonOptions) loadTillerTLS(tillerNamespace string) (*helm.TLSFiles, error) {
+	dir, err := tillerTLSDir(tillerNamespace)
+	if err != nil {
+		return nil, err
+	}
+	files, err := helm.LoadTLSFiles(dir)
+	if err != nil || files != nil {
+		return files, err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	secret, err := client.CoreV1().Secrets(tillerNamespace).Get(helm.SecretTillerClientTLS, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find Secret %s in namespace %s: %s", helm.SecretTillerClientTLS, tillerNamespace, err)
+	}
+	certs, err := helm.TLSCertsFromSecretData(secret.Data)
+	if err != nil {
+		return nil, err
+	}
+	return helm.WriteTLSFiles(dir, certs)
+}
+
+// configureTillerClient points the helm client at the tiller of the team using TLS if the tiller requires it
+func (o *CommonOptions) configureTillerClient(h helm.Helmer) {
+	settings, err := o.TeamSettings()
+	if err != nil || settings.TillerNamespace == "" {
+		return
+	}
+	h.SetTillerNamespace(settings.TillerNamespace)
+	if settings.TillerTLS {
+		files, err := o.loadTillerTLS(settings.TillerNamespace)
+		if err != nil {
+			log.Warnf("Failed to load the TLS certificates of tiller in namespace %s: %s\n", settings.TillerNamespace, err)
+			return
+		}
+		h.SetTLS(files)
+	}
+}
+
+// ensureTillerCanManageNamespace lets the tiller of the team manage the namespace if the team runs its own tiller in
+// its development namespace rather than using a global tiller
+func (o *CommonOptions) ensureTillerCanManageNamespace(ns string) error {
+	kubeClient, currentNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, currentNs)
+	if err != nil {
+		return err
+	}
+	devEnv, err := jxClient.JenkinsV1().Environments(devNs).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if !isNamespacedTiller(&devEnv.Spec.TeamSettings, devNs) || ns == devNs {
+		return nil
+	}
+	return kube.EnsureTillerRole(kubeClient, devNs, ns)
+}
+
+func isNamespacedTiller(settings *v1.TeamSettings, devNs string) bool {
+	return !settings.NoTiller && settings.TillerNamespace == devNs
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/version"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh/terminal"
+)
+
+const (
+	// updateCheckFileName the name of the file in the jx home directory which caches the last update check
+	updateCheckFileName = "update-check.yml"
+
+	// updateCheckInterval how often commands check for a new jx version
+	updateCheckInterval = 24 * time.Hour
+
+	// updateCheckTimeout how long a command waits for the latest jx version before it carries on without it
+	updateCheckTimeout = 2 * time.Second
+)
+
+// updateCheckSkippedCommands the commands which do not check for a new jx version as they check themselves or their
+// output is consumed by other tools
+var updateCheckSkippedCommands = []string{"jx version", "jx upgrade cli", "jx completion", "jx shell init", "jx step", "jx options"}
+
+// addUpdateCheckHook checks once a day whether a new jx version is available when a command starts and prints a one
+// line upgrade hint to stderr if it is. The check is disabled via the updateCheck setting and skipped in batch mode
+// or when stderr is not a terminal
+func addUpdateCheckHook(cmd *cobra.Command) {
+	preRun := cmd.PersistentPreRun
+	cmd.PersistentPreRun = func(c *cobra.Command, args []string) {
+		if preRun != nil {
+			preRun(c, args)
+		}
+		if isUpdateCheckEnabled(c) {
+			checkForUpdate()
+		}
+	}
+}
+
+func isUpdateCheckEnabled(c *cobra.Command) bool {
+	path := c.CommandPath()
+	for _, skipped := range updateCheckSkippedCommands {
+		if path == skipped || strings.HasPrefix(path, skipped+" ") {
+			return false
+		}
+	}
+	flag := c.Flags().Lookup("batch-mode")
+	if flag != nil && flag.Value.String() == "true" {
+		return false
+	}
+	resolver, err := localSettingsResolver(c)
+	if err != nil || resolver.Resolve(config.SettingUpdateCheck).Value == "false" {
+		return false
+	}
+	return terminal.IsTerminal(int(os.Stderr.Fd()))
+}
+
+// checkForUpdate prints the upgrade hint if the cached or, once the cache is stale, the latest released version is
+// newer than the running version. Failures are ignored as the check must never break a command but the time of the
+// attempt is cached so that commands do not wait for an unreachable GitHub every time they start
+func checkForUpdate() {
+	current, err := version.GetSemverVersion()
+	if err != nil || version.Channel(current) == version.ChannelDev {
+		return
+	}
+	configDir, err := util.ConfigDir()
+	if err != nil {
+		return
+	}
+	fileName := filepath.Join(configDir, updateCheckFileName)
+	check, err := version.LoadUpdateCheck(fileName)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	if check.IsStale(now, updateCheckInterval) {
+		check.CheckedAt = now
+		latest, err := latestJXVersionWithin(updateCheckTimeout)
+		if err == nil {
+			check.LatestVersion = latest.String()
+		}
+		version.SaveUpdateCheck(fileName, check)
+		if err != nil {
+			return
+		}
+	}
+	latest, err := semver.Make(check.LatestVersion)
+	if err != nil {
+		return
+	}
+	status := version.NewUpdateStatus(current, latest)
+	if status.UpdateAvailable {
+		fmt.Fprintln(os.Stderr, util.ColorWarning(status.Hint()))
+	}
+}
+
+// latestJXVersionWithin returns the latest released jx version unless it can not be found within the timeout
+func latestJXVersionWithin(timeout time.Duration) (semver.Version, error) {
+	type result struct {
+		version semver.Version
+		err     error
+	}
+	results := make(chan result, 1)
+	go func() {
+		v, err := util.GetLatestVersionFromGitHub("jenkins-x", "jx")
+		results <- result{v, err}
+	}()
+	select {
+	case r := <-results:
+		return r.version, r.err
+	case <-time.After(timeout):
+		return semver.Version{}, fmt.Errorf("timed out after %s finding the latest jx version", timeout.String())
+	}
+}
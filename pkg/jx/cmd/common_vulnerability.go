@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// checkVulnerabilityPolicy scans the image being promoted to the environment and returns an error if it exceeds the
+// vulnerability thresholds of the policy unless the environment has been annotated to override the policy
+func (o *CommonOptions) checkVulnerabilityPolicy(policy *v1.VulnerabilityPolicy, env *v1.Environment, app string, version string, image string, scanner cve.ImageScanner) error {
+	if !kube.VulnerabilityPolicyApplies(policy, env) {
+		return nil
+	}
+	if kube.IsVulnerabilityOverridden(env, app, version) {
+		log.Warnf("Skipping the vulnerability scan of %s as environment %s has the annotation %s\n", app, env.Name, kube.AnnotationVulnerabilityOverride)
+		return nil
+	}
+	if image == "" {
+		return fmt.Errorf("cannot determine the image of %s to scan for vulnerabilities before promoting to environment %s. Use the --image option", app, env.Name)
+	}
+	if scanner == nil {
+		var err error
+		scanner, err = cve.NewImageScanner(policy.Scanner, policy.IgnoreUnfixed)
+		if err != nil {
+			return err
+		}
+		if _, ok := scanner.(*cve.TrivyScanner); ok && binaryShouldBeInstalled(cve.ScannerTrivy) != "" {
+			err = o.installTrivy()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	log.Infof("Scanning image %s for vulnerabilities before promoting to environment %s\n", util.ColorInfo(image), util.ColorInfo(env.Name))
+	vulnerabilities, err := scanner.ScanImage(image)
+	if err != nil {
+		return err
+	}
+	counts := cve.CountBySeverity(vulnerabilities)
+	summary := []string{}
+	for _, severity := range cve.Severities {
+		if counts[severity] > 0 {
+			summary = append(summary, fmt.Sprintf("%d %s", counts[severity], strings.ToLower(severity)))
+		}
+	}
+	if len(summary) == 0 {
+		log.Infof("No vulnerabilities found in image %s\n", util.ColorInfo(image))
+	} else {
+		log.Infof("Found %s vulnerabilities in image %s\n", strings.Join(summary, ", "), util.ColorInfo(image))
+	}
+
+	reasons := cve.ExceededThresholds(counts, kube.VulnerabilityThresholds(policy))
+	if len(reasons) == 0 {
+		return nil
+	}
+	blocking := []string{}
+	for _, v := range vulnerabilities {
+		if _, ok := kube.VulnerabilityThresholds(policy)[strings.ToUpper(v.Severity)]; ok && util.StringArrayIndex(blocking, v.Vuln) < 0 {
+			blocking = append(blocking, v.Vuln)
+		}
+	}
+	sort.Strings(blocking)
+	for _, id := range blocking {
+		log.Warnf("  %s\n", id)
+	}
+	return fmt.Errorf("cannot promote %s to environment %s as image %s has %s. To promote anyway annotate the environment with %s=%s", app, env.Name, image, strings.Join(reasons, ", "), kube.AnnotationVulnerabilityOverride, app+":"+version)
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const (
+	// dockerDesktopContext the name of the kubernetes context of the cluster provided by Docker Desktop
+	dockerDesktopContext = "docker-desktop"
+
+	// dockerDesktopHost the address at which Docker Desktop exposes its daemon without TLS when enabled in its settings
+	dockerDesktopHost = "tcp://localhost:2375"
+)
+
+// mergeWindowsKubeConfig merges the named contexts of the kube config on the Windows host into the kube config used
+// inside WSL as Windows tools such as Docker Desktop and minikube only write to the Windows kube config
+func (o *CommonOptions) mergeWindowsKubeConfig(names []string, setCurrent bool) error {
+	profile, err := util.WindowsUserProfile()
+	if err != nil {
+		return errors.Wrap(err, "failed to find the profile directory of the Windows user")
+	}
+	fileName := filepath.Join(profile, ".kube", "config")
+	windowsConfig, err := clientcmd.LoadFromFile(fileName)
+	if err != nil {
+		return errors.Wrapf(err, "failed to load the Windows kube config file %s", fileName)
+	}
+	newConfig, err := kube.WindowsKubeConfig(windowsConfig, names)
+	if err != nil {
+		return err
+	}
+	if setCurrent && len(names) > 0 {
+		newConfig.CurrentContext = names[0]
+	}
+	contexts, err := kube.MergeIntoKubeConfig(newConfig, setCurrent)
+	if err != nil {
+		return err
+	}
+	log.Infof("Merged the kubernetes contexts %s of the Windows kube config %s into the kube config\n",
+		util.ColorInfo(strings.Join(contexts, ", ")), util.ColorInfo(fileName))
+	return nil
+}
+
+// checkWSLDocker explains how to connect the docker CLI inside WSL to Docker Desktop if it cannot reach a daemon
+func (o *CommonOptions) checkWSLDocker() {
+	_, err := o.getCommandOutput("", "docker", "version", "--format", "{{.Server.Version}}")
+	if err == nil {
+		return
+	}
+	log.Warnf("The docker CLI inside WSL cannot connect to a docker daemon. To use the daemon of Docker Desktop either:\n")
+	log.Infof("  * enable the WSL integration of your distribution in the Resources settings of Docker Desktop\n")
+	log.Infof("  * or enable %s in the General settings of Docker Desktop and run:\n",
+		util.ColorInfo(fmt.Sprintf("Expose daemon on %s without TLS", dockerDesktopHost)))
+	log.Infof("      %s\n", util.ColorInfo(fmt.Sprintf("echo 'export DOCKER_HOST=%s' >> ~/.bashrc", dockerDesktopHost)))
+}
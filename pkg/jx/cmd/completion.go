@@ -2,9 +2,11 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
@@ -71,10 +73,43 @@ func NewCmdCompletion(f Factory, out io.Writer) *cobra.Command {
 		},
 		ValidArgs: shells,
 	}
+	cmd.AddCommand(newCmdCompletionNamespaces(f, out))
 
 	return cmd
 }
 
+// newCmdCompletionNamespaces creates the hidden command the shell completion functions use to complete the values
+// of the --namespace and --env options
+func newCmdCompletionNamespaces(f Factory, out io.Writer) *cobra.Command {
+	options := &CommonOptions{
+		Factory: f,
+		Out:     out,
+		Err:     out,
+	}
+	environments := false
+	cmd := &cobra.Command{
+		Use:    "namespaces",
+		Short:  "Output the namespaces or Environments of the team which are used to complete options",
+		Hidden: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			resolver := options.Namespaces()
+			var names []string
+			var err error
+			if environments {
+				names, err = resolver.EnvironmentNames()
+			} else {
+				names, err = resolver.CompletionNamespaces()
+			}
+			CheckErr(err)
+			for _, name := range names {
+				fmt.Fprintln(out, name)
+			}
+		},
+	}
+	cmd.Flags().BoolVarP(&environments, "environments", "", false, "Output the names of the Environments rather than the namespaces")
+	return cmd
+}
+
 func (o *CommonOptions) Run() error {
 	shells := []string{}
 	for s := range completion_shells {
@@ -90,7 +125,7 @@ func (o *CommonOptions) Run() error {
 			PageSize: len(shells),
 			Help:     "The name of the shell",
 		}
-		err := survey.AskOne(prompts, &ShellName, nil)
+		err := util.AskOne(prompts, &ShellName, nil)
 		if err != nil {
 			return err
 		}
@@ -44,6 +44,7 @@ func NewCompliance(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 		},
 	}
 
+	cmd.AddCommand(NewCmdComplianceCheck(f, out, errOut))
 	cmd.AddCommand(NewCmdComplianceStatus(f, out, errOut))
 	cmd.AddCommand(NewCmdComplianceResults(f, out, errOut))
 	cmd.AddCommand(NewCmdComplianceRun(f, out, errOut))
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/benchmark"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	complianceCheckLong = templates.LongDesc(`
+		Audits how Jenkins X was installed against a checklist inspired by the CIS Kubernetes benchmark
+
+		The checks cover the breadth of the RBAC bindings such as service accounts bound to cluster-admin, how
+		secrets are passed to the platform and build pods, privileged build pods and whether tiller is exposed.
+		Remediation steps are shown for each check which does not pass.
+	`)
+
+	complianceCheckExample = templates.Examples(`
+		# Audit the installation of the current team
+		jx compliance check
+
+		# Audit the installation failing if any check fails so it can be used in a pipeline
+		jx compliance check --fail
+	`)
+)
+
+// ComplianceCheckOptions options for "compliance check" command
+type ComplianceCheckOptions struct {
+	CommonOptions
+
+	TillerNamespace string
+	Fail            bool
+}
+
+// NewCmdComplianceCheck creates a command object for the "compliance check" action, which
+// audits the installation against CIS inspired checks
+func NewCmdComplianceCheck(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ComplianceCheckOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "check",
+		Short:   "Audits the installation against CIS benchmark inspired checks",
+		Long:    complianceCheckLong,
+		Example: complianceCheckExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.TillerNamespace, optionTillerNamespace, "", kube.DefaultTillerNamespace, "The namespace tiller is installed in")
+	cmd.Flags().BoolVarP(&options.Fail, "fail", "", false, "Returns an error if any check fails")
+
+	return cmd
+}
+
+// Run implements the "compliance check" command
+func (o *ComplianceCheckOptions) Run() error {
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	results, err := benchmark.Run(kubeClient, benchmark.Options{
+		Namespace:       devNs,
+		TillerNamespace: o.TillerNamespace,
+	})
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("STATUS", "ID", "CHECK")
+	for _, r := range results {
+		table.AddRow(complianceStatusColor(r.Status), r.Check.ID, r.Check.Title)
+	}
+	table.Render()
+
+	for _, r := range results {
+		if r.Status == benchmark.StatusPass {
+			continue
+		}
+		log.Infof("\n%s %s\n", complianceStatusColor(r.Status), util.ColorInfo(r.Check.ID+" "+r.Check.Title))
+		for _, f := range r.Findings {
+			log.Infof("  * %s\n", f)
+		}
+		log.Infof("Remediation: %s\n", r.Check.Remediation)
+	}
+
+	failed := benchmark.Failed(results)
+	if failed > 0 && o.Fail {
+		return fmt.Errorf("%d of %d compliance checks failed", failed, len(results))
+	}
+	return nil
+}
+
+func complianceStatusColor(status benchmark.Status) string {
+	switch status {
+	case benchmark.StatusPass:
+		return util.ColorInfo(status)
+	case benchmark.StatusWarn:
+		return util.ColorWarning(status)
+	default:
+		return util.ColorError(status)
+	}
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+)
+
+// ConfigOptions the options for the config command
+type ConfigOptions struct {
+	CommonOptions
+}
+
+var (
+	configLong = templates.LongDesc(`
+		Views the settings of the common options of jx.
+
+		Each setting is taken from the first of these which sets it:
+
+		* the command line option
+		* the environment variable
+		* the settings file $JX_HOME/config.yaml
+		* the settings of the team
+`)
+)
+
+// NewCmdConfig creates the command object for the "config" command
+func NewCmdConfig(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Views the settings of the common options of jx",
+		Long:  configLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdConfigView(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/spf13/cobra"
+)
+
+// ConfigViewOptions the options for the config view command
+type ConfigViewOptions struct {
+	CommonOptions
+
+	Resolved bool
+	NoTeam   bool
+}
+
+var (
+	configViewLong = templates.LongDesc(`
+		Displays the settings of the common options of jx along with the environment variables which set them.
+
+		With --resolved the value each setting resolves to and where the value came from is displayed.
+`)
+
+	configViewExample = templates.Examples(`
+		# Display the settings and their environment variables
+		jx config view
+
+		# Display the value of each setting and where it came from
+		jx config view --resolved
+	`)
+)
+
+// NewCmdConfigView creates the command object for the "config view" command
+func NewCmdConfigView(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ConfigViewOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "view",
+		Short:   "Displays the settings of the common options of jx",
+		Long:    configViewLong,
+		Example: configViewExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Resolved, "resolved", "r", false, "Displays the value each setting resolves to and where it came from")
+	cmd.Flags().BoolVarP(&options.NoTeam, "no-team", "", false, "Does not load the settings of the team from the cluster")
+	return cmd
+}
+
+// Run implements this command
+func (o *ConfigViewOptions) Run() error {
+	resolver, err := o.SettingsResolver(false)
+	if err != nil {
+		return err
+	}
+	if o.Resolved && !o.NoTeam {
+		teamResolver, err := o.SettingsResolver(true)
+		if err != nil {
+			log.Warnf("Ignoring the settings of the team: %s\n", err)
+		} else {
+			resolver = teamResolver
+		}
+	}
+
+	table := o.CreateTable()
+	if o.Resolved {
+		table.AddRow("NAME", "VALUE", "SOURCE")
+		for _, setting := range resolver.ResolveAll() {
+			table.AddRow(setting.Name, setting.Value, string(setting.Source))
+		}
+	} else {
+		table.AddRow("NAME", "ENVIRONMENT VARIABLE", "OPTION", "DEFAULT", "DESCRIPTION")
+		for _, setting := range config.Settings {
+			option := ""
+			if setting.Flag != "" {
+				option = "--" + setting.Flag
+			}
+			table.AddRow(setting.Name, setting.EnvVar, option, setting.Default, setting.Description)
+		}
+	}
+	table.Render()
+	return nil
+}
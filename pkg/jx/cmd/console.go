@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/spf13/cobra"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/browser"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 type ConsoleOptions struct {
@@ -90,7 +92,7 @@ func (o *ConsoleOptions) Open(name string, label string) error {
 	if ns != "" {
 		url, err = o.findServiceInNamespace(name, ns)
 	} else {
-		url, err = o.findService(name)
+		url, err = o.resolveServiceURL(name)
 	}
 	if err != nil && name != "" {
 		log.Infof("If the app %s is running in a different environment you could try: %s\n", util.ColorInfo(name), util.ColorInfo("jx get applications"))
@@ -116,3 +118,76 @@ func (o *ConsoleOptions) urlForMode(url string) string {
 		return util.UrlJoin(url, BlueOceanPath)
 	}
 }
+
+// resolveServiceURL returns the URL of the service of the given name or short name of a well known service in the
+// current or development namespace falling back to the services of all environments which match the name
+func (o *ConsoleOptions) resolveServiceURL(name string) (string, error) {
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return "", err
+	}
+	devNs, _, err := kube.GetDevNamespace(client, ns)
+	if err != nil {
+		return "", err
+	}
+	for _, candidate := range kube.ServiceNameCandidates(name) {
+		for _, n := range []string{ns, devNs} {
+			url, _ := kube.FindServiceURL(client, n, candidate)
+			if url != "" {
+				return url, nil
+			}
+		}
+	}
+
+	namespaces := []string{ns}
+	if devNs != ns {
+		namespaces = append(namespaces, devNs)
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return "", err
+	}
+	envs, err := jxClient.JenkinsV1().Environments(devNs).List(metav1.ListOptions{})
+	if err != nil {
+		return "", err
+	}
+	for _, env := range envs.Items {
+		envNs := env.Spec.Namespace
+		if envNs != "" && util.StringArrayIndex(namespaces, envNs) < 0 {
+			namespaces = append(namespaces, envNs)
+		}
+	}
+	urls := []kube.ServiceURL{}
+	for _, n := range namespaces {
+		nsURLs, err := kube.FindServiceURLs(client, n)
+		if err != nil {
+			return "", err
+		}
+		urls = append(urls, nsURLs...)
+	}
+
+	matches := kube.MatchServiceURLs(urls, name)
+	if len(matches) == 1 {
+		return matches[0].URL, nil
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("Could not find URL for service %s in namespaces %s", name, strings.Join(namespaces, ", "))
+	}
+	labels := []string{}
+	for _, m := range matches {
+		labels = append(labels, m.Name+" ("+m.Namespace+")")
+	}
+	if o.BatchMode {
+		return "", fmt.Errorf("More than one service matches %s: %s", name, strings.Join(labels, ", "))
+	}
+	label, err := util.PickName(labels, "Pick service to open: ")
+	if err != nil {
+		return "", err
+	}
+	for i, l := range labels {
+		if l == label {
+			return matches[i].URL, nil
+		}
+	}
+	return "", fmt.Errorf("No service picked")
+}
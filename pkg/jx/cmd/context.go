@@ -3,27 +3,31 @@ package cmd
 import (
 	"fmt"
 	"io"
-	"sort"
-	"strings"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
 )
 
 type ContextOptions struct {
 	CommonOptions
 
-	Filter string
+	Filter  string
+	Refresh bool
 }
 
 var (
 	context_long = templates.LongDesc(`
-		Displays or changes the current kubernetes context (cluster).`)
+		Displays or changes the current kubernetes context (cluster).
+
+		The contexts you used most recently are shown first along with the provider, cluster, namespace and
+		server of each context. Type to narrow down the list or use --filter to fuzzy match the context names.`)
 	context_example = templates.Examples(`
 		# to select the context to switch to
 		jx context
@@ -35,7 +39,13 @@ var (
 		jx ctx -b
 
 		# Change the current namespace to 'minikube'
-		jx ctx minikube`)
+		jx ctx minikube
+
+		# pick from the contexts which fuzzy match 'prod' such as 'gke_acme_europe-west1_production'
+		jx ctx -f prod
+
+		# refresh the credentials of the current context if the cloud provider token has expired
+		jx ctx -b --refresh`)
 )
 
 func NewCmdContext(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
@@ -59,7 +69,8 @@ func NewCmdContext(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 			CheckErr(err)
 		},
 	}
-	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the list of contexts to switch between using the given text")
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the list of contexts to switch between using a fuzzy match of the given text")
+	cmd.Flags().BoolVarP(&options.Refresh, "refresh", "r", false, "Refreshes the cloud provider credentials of the context")
 	options.addCommonFlags(cmd)
 	return cmd
 }
@@ -74,15 +85,14 @@ func (o *ContextOptions) Run() error {
 		return fmt.Errorf("No kubernetes contexts available! Try create or connect to cluster?")
 	}
 
+	summaries := kube.ContextSummaries(config)
 	contextNames := []string{}
-	for k, v := range config.Contexts {
-		if k != "" && v != nil {
-			if o.Filter == "" || strings.Index(k, o.Filter) >= 0 {
-				contextNames = append(contextNames, k)
-			}
-		}
+	for _, s := range summaries {
+		contextNames = append(contextNames, s.Name)
+	}
+	if o.Filter != "" {
+		contextNames = kube.FuzzyFilter(o.Filter, contextNames)
 	}
-	sort.Strings(contextNames)
 
 	ctxName := ""
 	args := o.Args
@@ -94,8 +104,10 @@ func (o *ContextOptions) Run() error {
 	}
 
 	if ctxName == "" && !o.BatchMode {
-		defaultCtxName := config.CurrentContext
-		pick, err := o.PickContext(contextNames, defaultCtxName)
+		if o.Filter == "" {
+			contextNames = kube.OrderByRecent(contextNames, loadContextHistory().RecentContexts)
+		}
+		pick, err := o.PickContext(contextNames, config.CurrentContext, summaries)
 		if err != nil {
 			return err
 		}
@@ -103,38 +115,103 @@ func (o *ContextOptions) Run() error {
 	}
 	info := util.ColorInfo
 	if ctxName != "" && ctxName != config.CurrentContext {
-		ctx := config.Contexts[ctxName]
-		if ctx == nil {
-			return fmt.Errorf("Could not find kubernetes context %s", ctxName)
-		}
-		newConfig := *config
-		newConfig.CurrentContext = ctxName
-		err = clientcmd.ModifyConfig(po, newConfig, false)
+		err = kube.SwitchContext(config, po, ctxName)
 		if err != nil {
-			return fmt.Errorf("Failed to update the kube config %s", err)
+			return err
 		}
-		fmt.Fprintf(o.Out, "Now using namespace '%s' from context named '%s' on server '%s'.\n", info(ctx.Namespace), info(newConfig.CurrentContext), info(kube.Server(config, ctx)))
+		updateContextHistory(func(h *kube.ContextHistory) {
+			h.AddRecentContext(ctxName)
+		})
+		ctx := config.Contexts[ctxName]
+		fmt.Fprintf(o.Out, "Now using namespace '%s' from context named '%s' on server '%s'.\n", info(ctx.Namespace), info(ctxName), info(kube.Server(config, ctx)))
 	} else {
 		ns := kube.CurrentNamespace(config)
 		server := kube.CurrentServer(config)
 		fmt.Fprintf(o.Out, "Using namespace '%s' from context named '%s' on server '%s'.\n", info(ns), info(config.CurrentContext), info(server))
 	}
+	if o.Refresh {
+		return o.refreshCredentials(config, po)
+	}
 	return nil
 }
 
-func (o *ContextOptions) PickContext(names []string, defaultValue string) (string, error) {
+func (o *ContextOptions) refreshCredentials(config *api.Config, po *clientcmd.PathOptions) error {
+	ctx := kube.CurrentContext(config)
+	if ctx == nil || ctx.AuthInfo == "" {
+		return nil
+	}
+	err := kube.RefreshCredentials(config, ctx.AuthInfo)
+	if err != nil {
+		return err
+	}
+	err = clientcmd.ModifyConfig(po, *config, false)
+	if err != nil {
+		return fmt.Errorf("Failed to update the kube config %s", err)
+	}
+	fmt.Fprintf(o.Out, "Refreshed the credentials of user '%s'.\n", util.ColorInfo(ctx.AuthInfo))
+	return nil
+}
+
+// PickContext prompts the user to pick one of the contexts showing the cluster, provider and namespace of each
+// context. The options can be narrowed down by typing part of the name
+func (o *ContextOptions) PickContext(names []string, defaultValue string, summaries []kube.ContextSummary) (string, error) {
 	if len(names) == 0 {
 		return "", nil
 	}
 	if len(names) == 1 {
 		return names[0], nil
 	}
-	name := ""
+	descriptions := map[string]string{}
+	for _, s := range summaries {
+		descriptions[s.Name] = s.Describe()
+	}
+	labels := []string{}
+	defaultLabel := ""
+	for _, name := range names {
+		label := descriptions[name]
+		if label == "" {
+			label = name
+		}
+		if name == defaultValue {
+			defaultLabel = label
+		}
+		labels = append(labels, label)
+	}
+	label := ""
 	prompt := &survey.Select{
 		Message: "Change kubernetes context:",
-		Options: names,
-		Default: defaultValue,
+		Options: labels,
+		Default: defaultLabel,
+	}
+	err := util.AskOne(prompt, &label, nil)
+	if err != nil {
+		return "", err
+	}
+	return names[util.StringArrayIndex(labels, label)], nil
+}
+
+// loadContextHistory loads the recently used contexts and namespaces, returning an empty history if it cannot be loaded
+func loadContextHistory() *kube.ContextHistory {
+	fileName, err := kube.DefaultContextHistoryFile()
+	if err != nil {
+		return &kube.ContextHistory{}
+	}
+	history, err := kube.LoadContextHistory(fileName)
+	if err != nil {
+		log.Warnf("Failed to load the context history: %s\n", err)
+	}
+	return history
+}
+
+// updateContextHistory modifies and saves the context history warning if it could not be saved
+func updateContextHistory(fn func(h *kube.ContextHistory)) {
+	fileName, err := kube.DefaultContextHistoryFile()
+	if err == nil {
+		history := loadContextHistory()
+		fn(history)
+		err = history.Save(fileName)
+	}
+	if err != nil {
+		log.Warnf("Failed to save the context history: %s\n", err)
 	}
-	err := survey.AskOne(prompt, &name, nil)
-	return name, err
 }
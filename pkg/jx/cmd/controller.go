@@ -48,6 +48,9 @@ func NewCmdController(f Factory, out io.Writer, errOut io.Writer) *cobra.Command
 
 	cmd.AddCommand(NewCmdControllerBackup(f, out, errOut))
 	cmd.AddCommand(NewCmdControllerBuild(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerHookRelay(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerMetrics(f, out, errOut))
+	cmd.AddCommand(NewCmdControllerNotifications(f, out, errOut))
 	cmd.AddCommand(NewCmdControllerRole(f, out, errOut))
 	cmd.AddCommand(NewCmdControllerTeam(f, out, errOut))
 	cmd.AddCommand(NewCmdControllerWorkflow(f, out, errOut))
@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"github.com/ghodss/yaml"
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cloud/kms"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -24,9 +26,14 @@ type ControllerBackupOptions struct {
 	ControllerOptions
 
 	GitRepositoryOptions gits.GitRepositoryOptions
+	KMSFlags
 
 	Namespace    string
 	Organisation string
+
+	kmsManager *kms.Manager
+	// backups the plain text of the encrypted backup files so unchanged resources are not encrypted and committed again
+	backups map[string][]byte
 }
 
 // NewCmdControllerBackup creates a command object for the generic "get" action, which
@@ -56,6 +63,7 @@ func NewCmdControllerBackup(f Factory, out io.Writer, errOut io.Writer) *cobra.C
 
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to watch or defaults to the current namespace")
 	cmd.Flags().StringVarP(&options.Organisation, "organisation", "o", "", "The organisation to backup")
+	options.KMSFlags.addFlags(cmd)
 
 	options.addCommonFlags(cmd)
 
@@ -85,6 +93,15 @@ func (o *ControllerBackupOptions) Run() error {
 		return err
 	}
 
+	if o.Key != "" {
+		o.kmsManager, err = o.createKMSManager(o.Key, o.Provider)
+		if err != nil {
+			return err
+		}
+		o.backups = map[string][]byte{}
+		log.Infof("Encrypting backups with the KMS key %s\n", util.ColorInfo(o.kmsManager.Key.URL()))
+	}
+
 	ns := o.Namespace
 	if ns == "" {
 		ns = devNs
@@ -222,6 +239,19 @@ func (o *ControllerBackupOptions) writeResourceToBackupFile(obj interface{}, res
 	}
 
 	envFile := path.Join(nsDir, fmt.Sprintf("%s.yaml", key))
+	if o.kmsManager != nil {
+		envFile += kms.EncryptedFileExtension
+		if bytes.Equal(o.backups[envFile], out) {
+			return
+		}
+		plain := out
+		out, err = o.kmsManager.Seal(plain)
+		if err != nil {
+			log.Errorf("Unable to encrypt %s %s\n", resource, err)
+			return
+		}
+		o.backups[envFile] = plain
+	}
 	err = ioutil.WriteFile(envFile, out, 0644)
 	if err != nil {
 		log.Errorf("Unable to write file %s\n", err)
@@ -26,7 +26,8 @@ import (
 type ControllerBuildOptions struct {
 	ControllerOptions
 
-	Namespace string
+	Namespace       string
+	ActivityGCEvery time.Duration
 }
 
 // NewCmdControllerBuild creates a command object for the generic "get" action, which
@@ -55,6 +56,7 @@ func NewCmdControllerBuild(f Factory, out io.Writer, errOut io.Writer) *cobra.Co
 	}
 
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to watch or defaults to the current namespace")
+	cmd.Flags().DurationVarP(&options.ActivityGCEvery, "activity-gc-every", "", time.Hour, "How often the PipelineActivities are pruned using the retention policy of the team. Use 0 to disable pruning")
 	return cmd
 }
 
@@ -106,6 +108,10 @@ func (o *ControllerBuildOptions) Run() error {
 	stop := make(chan struct{})
 	go controller.Run(stop)
 
+	if o.ActivityGCEvery > 0 {
+		go o.pruneActivitiesPeriodically(jxClient, ns)
+	}
+
 	// Wait forever
 	select {}
 }
@@ -123,6 +129,10 @@ func (o *ControllerBuildOptions) onPod(obj interface{}, jxClient versioned.Inter
 			if buildName != "" {
 				log.Infof("Found build pod %s\n", pod.Name)
 
+				if kube.IsPodPreempted(pod) {
+					o.retryPreemptedBuildPod(pod)
+				}
+
 				activities := jxClient.JenkinsV1().PipelineActivities(ns)
 				key := o.createPromoteStepActivityKey(buildName, pod)
 				if key != nil {
@@ -147,6 +157,48 @@ func (o *ControllerBuildOptions) onPod(obj interface{}, jxClient versioned.Inter
 	}
 }
 
+// pruneActivitiesPeriodically removes the PipelineActivities which are not kept by the retention policy of the team
+func (o *ControllerBuildOptions) pruneActivitiesPeriodically(jxClient versioned.Interface, ns string) {
+	for {
+		settings, err := o.TeamSettings()
+		if err != nil {
+			log.Warnf("Failed to load the team settings: %s\n", err)
+		} else {
+			err = o.pruneActivities(jxClient, ns, kube.ActivityRetentionOrDefault(settings), false)
+			if err != nil {
+				log.Warnf("Failed to prune the PipelineActivities in namespace %s: %s\n", ns, err)
+			}
+		}
+		time.Sleep(o.ActivityGCEvery)
+	}
+}
+
+// retryPreemptedBuildPod recreates a build pod whose spot or preemptible node was preempted if the spot build
+// settings of the team allow it to be retried
+func (o *ControllerBuildOptions) retryPreemptedBuildPod(pod *corev1.Pod) {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		log.Warnf("Failed to load the team settings: %s\n", err)
+		return
+	}
+	if settings.SpotBuilds == nil || settings.SpotBuilds.MaxRetries <= 0 {
+		return
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		log.Warnf("Failed to create the kubernetes client: %s\n", err)
+		return
+	}
+	retry, err := kube.RetryPreemptedBuildPod(client, pod, settings.SpotBuilds.MaxRetries)
+	if err != nil {
+		log.Warnf("%s\n", err)
+		return
+	}
+	if retry != nil {
+		log.Infof("Build pod %s was preempted so retrying it as pod %s (attempt %s of %s)\n", util.ColorInfo(pod.Name), util.ColorInfo(retry.Name), util.ColorInfo(kube.GetBuildRetries(retry)), util.ColorInfo(settings.SpotBuilds.MaxRetries))
+	}
+}
+
 // createPromoteStepActivityKey deduces the pipeline metadata from the knative build pod
 func (o *ControllerBuildOptions) createPromoteStepActivityKey(buildName string, pod *corev1.Pod) *kube.PromoteStepActivityKey {
 	branch := ""
@@ -0,0 +1,258 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/hooks"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"golang.org/x/time/rate"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// hookRelayServiceName the name of the service of the hook relay which git providers send webhooks to when present
+	hookRelayServiceName = "hook-relay"
+
+	// hookRelayMaxMismatches the maximum number of pending deliveries whose HMAC signature did not match which are
+	// stored so that unauthenticated callers can not create an unlimited number of HookFailures
+	hookRelayMaxMismatches = 100
+)
+
+// ControllerHookRelayOptions are the flags for the commands
+type ControllerHookRelayOptions struct {
+	ControllerOptions
+
+	Namespace      string
+	Port           int
+	HookURL        string
+	ReplayInterval time.Duration
+	MaxAttempts    int
+
+	jxClient   versioned.Interface
+	ns         string
+	hmacToken  []byte
+	httpClient *http.Client
+	// mismatches limits how often deliveries whose HMAC signature did not match are stored
+	mismatches *rate.Limiter
+}
+
+var (
+	controllerHookRelayLong = templates.LongDesc(`
+		Runs the hook relay which receives the webhooks of the git providers and forwards them to the webhook engine of
+		the team such as Prow or Lighthouse.
+
+		Deliveries which cannot be forwarded, such as when the hook pod is down, or whose HMAC signature does not match
+		are stored as HookFailure resources so that builds are not silently lost. At most 100 deliveries whose HMAC
+		signature does not match are kept pending and they are stored at a limited rate. Deliveries which failed as the
+		webhook engine was unavailable are replayed automatically once it recovers.
+
+		Once the 'hook-relay' service exists the webhooks of new repositories are registered against the relay.
+		Use 'jx get hook-failures' to inspect the failed deliveries and 'jx rerun hook-failures' to replay them.
+`)
+
+	controllerHookRelayExample = templates.Examples(`
+		# Run the hook relay in front of the Prow hook service
+		jx controller hook-relay
+
+		# Replay failed deliveries every 30 seconds
+		jx controller hook-relay --replay-interval 30s
+	`)
+)
+
+// NewCmdControllerHookRelay creates a command object for the "controller hook-relay" command
+func NewCmdControllerHookRelay(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerHookRelayOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "hook-relay",
+		Short:   "Runs the hook relay which stores and replays the webhook deliveries the webhook engine failed to process",
+		Long:    controllerHookRelayLong,
+		Example: controllerHookRelayExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the webhook engine or defaults to the dev namespace")
+	cmd.Flags().IntVarP(&options.Port, "port", "p", 8080, "The port to receive webhooks on")
+	cmd.Flags().StringVarP(&options.HookURL, "hook-url", "", "http://hook/hook", "The URL of the webhook engine to forward deliveries to")
+	cmd.Flags().DurationVarP(&options.ReplayInterval, "replay-interval", "", time.Minute, "How often failed deliveries are replayed")
+	cmd.Flags().IntVarP(&options.MaxAttempts, "max-attempts", "", 20, "The number of times a failed delivery is replayed automatically")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerHookRelayOptions) Run() error {
+	err := o.registerHookFailureCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	o.jxClient = jxClient
+	o.ns = o.Namespace
+	if o.ns == "" {
+		o.ns = devNs
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	secret, err := kubeClient.CoreV1().Secrets(o.ns).Get("hmac-token", metav1.GetOptions{})
+	if err != nil {
+		return errors.Wrapf(err, "failed to load the hmac-token Secret in namespace %s", o.ns)
+	}
+	o.hmacToken = secret.Data["hmac"]
+	o.httpClient = &http.Client{Transport: httpclient.BaseTransport(), Timeout: 30 * time.Second}
+	o.mismatches = rate.NewLimiter(rate.Every(time.Minute), 10)
+
+	go o.replayLoop()
+
+	http.HandleFunc("/hook", o.handleWebHook)
+	http.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	address := fmt.Sprintf(":%d", o.Port)
+	log.Infof("Relaying webhooks received on %s to %s\n", util.ColorInfo(address+"/hook"), util.ColorInfo(o.HookURL))
+	return http.ListenAndServe(address, nil)
+}
+
+func (o *ControllerHookRelayOptions) handleWebHook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "only POST is supported", http.StatusMethodNotAllowed)
+		return
+	}
+	payload, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, hooks.MaxPayloadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+	headers := hooks.Headers(r.Header)
+	spec := v1.HookFailureSpec{
+		Event:      headers[hooks.HeaderEvent],
+		DeliveryID: headers[hooks.HeaderDelivery],
+		Repository: hooks.RepositoryName(payload),
+		Headers:    headers,
+		Payload:    string(payload),
+	}
+
+	if !hooks.ValidSignature(payload, headers[hooks.HeaderSignature], o.hmacToken) {
+		spec.Reason = v1.HookFailureReasonHMACMismatch
+		spec.Message = "the signature of the delivery does not match the hmac-token of the team"
+		o.recordMismatch(spec)
+		http.Error(w, spec.Message, http.StatusForbidden)
+		return
+	}
+
+	status, body, err := hooks.Deliver(o.httpClient, o.HookURL, headers, payload)
+	spec.Reason = hooks.FailureReason(status, err)
+	switch spec.Reason {
+	case "":
+		w.WriteHeader(status)
+		w.Write(body)
+	case v1.HookFailureReasonUnavailable:
+		spec.Message = fmt.Sprintf("the webhook engine returned status %d: %s", status, string(body))
+		if err != nil {
+			spec.Message = err.Error()
+		}
+		o.recordFailure(spec)
+		// the git provider does not need to redeliver as the relay replays the delivery
+		w.WriteHeader(http.StatusAccepted)
+	default:
+		spec.Message = fmt.Sprintf("the webhook engine returned status %d: %s", status, string(body))
+		o.recordFailure(spec)
+		w.WriteHeader(status)
+		w.Write(body)
+	}
+}
+
+func (o *ControllerHookRelayOptions) recordFailure(spec v1.HookFailureSpec) {
+	failure := kube.NewHookFailure(spec, time.Now())
+	_, err := o.jxClient.JenkinsV1().HookFailures(o.ns).Create(failure)
+	if err != nil && !kerrors.IsAlreadyExists(err) {
+		log.Warnf("Failed to store the failed %s delivery %s of %s: %s\n", spec.Event, spec.DeliveryID, spec.Repository, err)
+		return
+	}
+	log.Warnf("Stored the failed %s delivery of %s as %s: %s\n", spec.Event, spec.Repository, failure.Name, spec.Message)
+}
+
+// recordMismatch stores the delivery whose HMAC signature did not match, so that it can be replayed if the hmac-token
+// was rotated, unless too many mismatches have been received recently or are already pending
+func (o *ControllerHookRelayOptions) recordMismatch(spec v1.HookFailureSpec) {
+	if !o.mismatches.Allow() {
+		log.Warnf("Not storing the %s delivery of %s as too many deliveries with an invalid signature were received\n", spec.Event, spec.Repository)
+		return
+	}
+	failures, err := kube.HookFailures(o.jxClient, o.ns, false)
+	if err != nil {
+		log.Warnf("Failed to list the HookFailures in namespace %s: %s\n", o.ns, err)
+		return
+	}
+	count := 0
+	for _, failure := range failures {
+		if failure.Spec.Reason == v1.HookFailureReasonHMACMismatch {
+			count++
+		}
+	}
+	if count >= hookRelayMaxMismatches {
+		log.Warnf("Not storing the %s delivery of %s as %d deliveries with an invalid signature are already pending\n", spec.Event, spec.Repository, count)
+		return
+	}
+	o.recordFailure(spec)
+}
+
+// replayLoop periodically replays the deliveries which failed as the webhook engine was unavailable
+func (o *ControllerHookRelayOptions) replayLoop() {
+	for {
+		time.Sleep(o.ReplayInterval)
+		failures, err := kube.HookFailures(o.jxClient, o.ns, false)
+		if err != nil {
+			log.Warnf("Failed to list the HookFailures in namespace %s: %s\n", o.ns, err)
+			continue
+		}
+		for _, failure := range failures {
+			if failure.Spec.Reason != v1.HookFailureReasonUnavailable || failure.Status.Attempts >= o.MaxAttempts {
+				continue
+			}
+			err = hooks.Replay(o.httpClient, o.HookURL, failure, nil)
+			kube.RecordHookReplay(failure, err, time.Now())
+			_, updateErr := o.jxClient.JenkinsV1().HookFailures(o.ns).Update(failure)
+			if updateErr != nil {
+				log.Warnf("Failed to update HookFailure %s: %s\n", failure.Name, updateErr)
+			}
+			if err != nil {
+				// the webhook engine is still unavailable so try again later keeping the deliveries in order
+				break
+			}
+			log.Infof("Replayed the %s delivery of %s\n", failure.Spec.Event, util.ColorInfo(failure.Spec.Repository))
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/hooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestHookRelayLimitsMismatches(t *testing.T) {
+	t.Parallel()
+	o := &ControllerHookRelayOptions{
+		jxClient:   fake.NewSimpleClientset(),
+		ns:         "jx",
+		hmacToken:  []byte("secret"),
+		mismatches: rate.NewLimiter(rate.Every(time.Hour), 3),
+	}
+	for i := 0; i < 10; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(`{"repository":{"full_name":"foo/bar"}}`))
+		req.Header.Set(hooks.HeaderDelivery, fmt.Sprintf("delivery-%d", i))
+		req.Header.Set(hooks.HeaderSignature, "sha1=invalid")
+		w := httptest.NewRecorder()
+		o.handleWebHook(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	}
+	list, err := o.jxClient.JenkinsV1().HookFailures(o.ns).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 3, "only a limited number of deliveries with an invalid signature should be stored")
+
+	req := httptest.NewRequest(http.MethodPost, "/hook", strings.NewReader(strings.Repeat("x", hooks.MaxPayloadSize+1)))
+	w := httptest.NewRecorder()
+	o.handleWebHook(w, req)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+}
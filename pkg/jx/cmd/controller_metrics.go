@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/observability"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/spf13/cobra"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ControllerMetricsOptions are the flags for the commands
+type ControllerMetricsOptions struct {
+	ControllerOptions
+
+	Namespace string
+	Port      int
+
+	metrics *observability.PipelineMetrics
+}
+
+var (
+	controllerMetricsLong = templates.LongDesc(`
+		Runs the pipeline metrics exporter which watches PipelineActivity resources and exports the durations, results and
+		queue times of pipelines and promotions as Prometheus metrics on the /metrics path.
+
+		The recorded durations are also added as annotations to each PipelineActivity.
+`)
+
+	controllerMetricsExample = templates.Examples(`
+		# Export the pipeline metrics of the current team
+		jx controller metrics
+	`)
+)
+
+// NewCmdControllerMetrics creates a command object for the "controller metrics" command
+func NewCmdControllerMetrics(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerMetricsOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "metrics",
+		Short:   "Runs the pipeline metrics exporter",
+		Long:    controllerMetricsLong,
+		Example: controllerMetricsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+		Aliases: []string{"metric"},
+	}
+
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to watch or defaults to the dev namespace of the current team. When specified the PipelineActivity CRD must already be registered")
+	cmd.Flags().IntVarP(&options.Port, "port", "p", observability.DefaultExporterPort, "The port to serve the metrics on")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerMetricsOptions) Run() error {
+	// the exporter deployed via 'jx create addon pipeline-metrics' is only allowed to access PipelineActivity resources
+	// in its namespace so it can not look up the dev namespace or register the CRD
+	ns := o.Namespace
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	if ns == "" {
+		err = o.registerPipelineActivityCRD()
+		if err != nil {
+			return err
+		}
+		jxClient, ns, err = o.JXClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+	}
+
+	o.metrics, err = observability.NewPipelineMetrics(prometheus.DefaultRegisterer)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Watching for PipelineActivity resources in namespace %s\n", util.ColorInfo(ns))
+	_, controller := cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(lo meta_v1.ListOptions) (runtime.Object, error) {
+				return jxClient.JenkinsV1().PipelineActivities(ns).List(lo)
+			},
+			WatchFunc: func(lo meta_v1.ListOptions) (watch.Interface, error) {
+				return jxClient.JenkinsV1().PipelineActivities(ns).Watch(lo)
+			},
+		},
+		&v1.PipelineActivity{},
+		time.Minute*10,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				o.onActivity(obj, jxClient, ns)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				o.onActivity(newObj, jxClient, ns)
+			},
+			DeleteFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	stop := make(chan struct{})
+	go controller.Run(stop)
+
+	http.Handle("/metrics", prometheus.UninstrumentedHandler())
+	address := fmt.Sprintf(":%d", o.Port)
+	log.Infof("Serving the pipeline metrics on %s\n", util.ColorInfo(address+"/metrics"))
+	return http.ListenAndServe(address, nil)
+}
+
+func (o *ControllerMetricsOptions) onActivity(obj interface{}, jxClient versioned.Interface, ns string) {
+	activity, ok := obj.(*v1.PipelineActivity)
+	if !ok {
+		log.Infof("Object is not a PipelineActivity %#v\n", obj)
+		return
+	}
+	metrics := observability.ComputeActivityMetrics(activity)
+	if metrics.IsEmpty() {
+		return
+	}
+
+	// lets annotate the latest version of the activity first so that the metrics are only recorded once
+	activities := jxClient.JenkinsV1().PipelineActivities(ns)
+	latest, err := activities.Get(activity.Name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Warnf("Failed to get PipelineActivity %s: %s\n", activity.Name, err)
+		return
+	}
+	metrics = observability.ComputeActivityMetrics(latest)
+	if metrics.IsEmpty() {
+		return
+	}
+	observability.AnnotateActivity(latest, metrics)
+	_, err = activities.Update(latest)
+	if err != nil {
+		log.Warnf("Failed to annotate PipelineActivity %s with its metrics: %s\n", activity.Name, err)
+		return
+	}
+	o.metrics.Record(metrics)
+}
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ControllerNotificationsOptions are the flags for the commands
+type ControllerNotificationsOptions struct {
+	ControllerOptions
+
+	Namespace string
+
+	started time.Time
+}
+
+var (
+	controllerNotificationsLong = templates.LongDesc(`
+		Runs the notifications controller which watches PipelineActivity resources and posts pipeline failures,
+		successful promotions and new preview environments to the chat channels of the team.
+
+		Channels are added via 'jx create notification' and the messages customised via 'jx edit notificationtemplate'.
+`)
+
+	controllerNotificationsExample = templates.Examples(`
+		# Post the pipeline and promotion events of the current team to its channels
+		jx controller notifications
+	`)
+)
+
+// NewCmdControllerNotifications creates a command object for the "controller notifications" command
+func NewCmdControllerNotifications(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ControllerNotificationsOptions{
+		ControllerOptions: ControllerOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "notifications",
+		Short:   "Runs the controller which posts pipeline and promotion events to chat channels",
+		Long:    controllerNotificationsLong,
+		Example: controllerNotificationsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+		Aliases: []string{"notification", "notify"},
+	}
+
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to watch or defaults to the current namespace")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ControllerNotificationsOptions) Run() error {
+	err := o.registerPipelineActivityCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		ns = devNs
+	}
+	o.started = time.Now()
+
+	log.Infof("Watching for PipelineActivity resources in namespace %s\n", util.ColorInfo(ns))
+	_, controller := cache.NewInformer(
+		&cache.ListWatch{
+			ListFunc: func(lo meta_v1.ListOptions) (runtime.Object, error) {
+				return jxClient.JenkinsV1().PipelineActivities(ns).List(lo)
+			},
+			WatchFunc: func(lo meta_v1.ListOptions) (watch.Interface, error) {
+				return jxClient.JenkinsV1().PipelineActivities(ns).Watch(lo)
+			},
+		},
+		&v1.PipelineActivity{},
+		time.Minute*10,
+		cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				o.onActivity(obj, jxClient, kubeClient, ns)
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				o.onActivity(newObj, jxClient, kubeClient, ns)
+			},
+			DeleteFunc: func(obj interface{}) {
+			},
+		},
+	)
+
+	stop := make(chan struct{})
+	go controller.Run(stop)
+
+	// Wait forever
+	select {}
+}
+
+func (o *ControllerNotificationsOptions) onActivity(obj interface{}, jxClient versioned.Interface, kubeClient kubernetes.Interface, ns string) {
+	activity, ok := obj.(*v1.PipelineActivity)
+	if !ok {
+		log.Infof("Object is not a PipelineActivity %#v\n", obj)
+		return
+	}
+	if len(notify.ActivityEvents(activity)) == 0 {
+		return
+	}
+
+	// lets mark the events as notified on the latest version of the activity first so they are only posted once
+	activities := jxClient.JenkinsV1().PipelineActivities(ns)
+	latest, err := activities.Get(activity.Name, meta_v1.GetOptions{})
+	if err != nil {
+		log.Warnf("Failed to get PipelineActivity %s: %s\n", activity.Name, err)
+		return
+	}
+	events := notify.ActivityEvents(latest)
+	if len(events) == 0 {
+		return
+	}
+	notify.MarkNotified(latest, events)
+	_, err = activities.Update(latest)
+	if err != nil {
+		log.Warnf("Failed to mark the events of PipelineActivity %s as notified: %s\n", activity.Name, err)
+		return
+	}
+
+	// activities which completed before the controller started are marked without posting their old events
+	completed := latest.Spec.CompletedTimestamp
+	if latest.Spec.Status.IsTerminated() && completed != nil && completed.Time.Before(o.started) {
+		return
+	}
+
+	channels, err := notify.GetChannels(kubeClient, ns)
+	if err != nil {
+		log.Warnf("Failed to load the notification channels: %s\n", err)
+		return
+	}
+	for _, channel := range channels {
+		channel.WebhookURL, err = expandTemplateValue(kubeClient, ns, channel.WebhookURL)
+		if err != nil {
+			log.Warnf("Failed to expand the webhook URL of channel %s: %s\n", channel.Name, err)
+		}
+	}
+	messageTemplates, err := notify.GetTemplates(kubeClient, ns)
+	if err != nil {
+		log.Warnf("Failed to load the notification templates: %s\n", err)
+		return
+	}
+	for _, event := range events {
+		message, err := notify.RenderMessage(event, messageTemplates[event.Kind])
+		if err != nil {
+			log.Warnf("%s\n", err)
+			continue
+		}
+		for _, channel := range channels {
+			if !channel.Accepts(event.Kind) {
+				continue
+			}
+			err = notify.Send(channel, message)
+			if err != nil {
+				log.Warnf("Failed to notify channel %s of %s: %s\n", channel.Name, event.Kind, err)
+			}
+		}
+	}
+}
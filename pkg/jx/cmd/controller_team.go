@@ -23,6 +23,8 @@ type ControllerTeamOptions struct {
 	InstallOptions
 
 	GitRepositoryOptions gits.GitRepositoryOptions
+
+	clusterDomain string
 }
 
 // NewCmdControllerTeam creates a command object for the generic "get" action, which
@@ -74,6 +76,8 @@ func (o *ControllerTeamOptions) Run() error {
 		return err
 	}
 
+	o.clusterDomain = o.InstallOptions.Flags.Domain
+
 	log.Infof("Watching for teams in all namespaces\n")
 
 	stop := make(chan struct{})
@@ -142,6 +146,8 @@ func (o *ControllerTeamOptions) onTeamChange(obj interface{}, kubeClient kuberne
 		o.InstallOptions.Flags.Prow = true
 		o.InstallOptions.Flags.Namespace = team.Name
 		o.InstallOptions.Flags.DefaultEnvironmentPrefix = team.Name
+		o.InstallOptions.Flags.Domain = kube.TeamDomain(team, o.clusterDomain)
+		o.InstallOptions.InitOptions.Flags.Domain = o.InstallOptions.Flags.Domain
 		o.InstallOptions.InitOptions.Flags.Helm3 = true
 		o.InstallOptions.CommonOptions.InstallDependencies = true
 
@@ -163,6 +169,20 @@ func (o *ControllerTeamOptions) onTeamChange(obj interface{}, kubeClient kuberne
 			return
 		}
 
+		err = o.isolateTeam(team, kubeClient, jxClient)
+		if err != nil {
+			log.Errorf("Unable to isolate team %s: %s", util.ColorInfo(team.Name), err)
+			err = o.ControllerOptions.ModifyTeam(team.Name, func(team *v1.Team) error {
+				team.Status.ProvisionStatus = v1.TeamProvisionStatusError
+				team.Status.Message = err.Error()
+				return nil
+			})
+			if err != nil {
+				log.Errorf("Unable to update team %s to %s - %s", util.ColorInfo(team.Name), v1.TeamProvisionStatusError, err)
+			}
+			return
+		}
+
 		err = o.ControllerOptions.ModifyTeam(team.Name, func(team *v1.Team) error {
 			team.Status.ProvisionStatus = v1.TeamProvisionStatusComplete
 			team.Status.Message = "Installation complete"
@@ -174,3 +194,28 @@ func (o *ControllerTeamOptions) onTeamChange(obj interface{}, kubeClient kuberne
 		}
 	}
 }
+
+// isolateTeam stops other teams connecting to the namespaces of the team, gives the members of the team access to
+// its environments and records the docker registry organisation the team pushes its images to
+func (o *ControllerTeamOptions) isolateTeam(team *v1.Team, kubeClient kubernetes.Interface, jxClient versioned.Interface) error {
+	err := kube.EnsureTeamIsolation(kubeClient, team.Name)
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureTeamMembers(kubeClient, jxClient, team.Name, team.Spec.Members)
+	if err != nil {
+		return err
+	}
+	environments := jxClient.JenkinsV1().Environments(team.Name)
+	devEnv, err := environments.Get(kube.LabelValueDevEnvironment, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	org := kube.TeamDockerRegistryOrg(team)
+	if devEnv.Spec.TeamSettings.DockerRegistryOrg == org {
+		return nil
+	}
+	devEnv.Spec.TeamSettings.DockerRegistryOrg = org
+	_, err = environments.Update(devEnv)
+	return err
+}
@@ -0,0 +1,217 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// CpOptions the options for the copy command
+type CpOptions struct {
+	CommonOptions
+
+	Container   string
+	Namespace   string
+	Environment string
+	Build       string
+	Branch      string
+	Quiet       bool
+}
+
+var (
+	cp_long = templates.LongDesc(`
+		Copies files and directories between the local machine and a pod of an application or build.
+
+		The remote side is specified as 'name:path' where the name is a pod or deployment name. When using --build the
+		remote side is specified as ':path' and the latest build pod of the repository is used.
+
+		Local sources may be glob patterns. The last element of a remote source may be a glob pattern too.
+`)
+
+	cp_example = templates.Examples(`
+		# Copy the local reports into the /tmp/reports directory of the latest pod of the foo deployment
+		jx cp 'reports/*.xml' foo:/tmp/reports
+
+		# Copy the log files of the foo deployment in the staging environment into the current directory
+		jx cp -e staging 'foo:/var/log/*.log' .
+
+		# Copy the test results out of the maven container of the latest build pod of the master branch
+		jx cp --build myorg/myapp --branch master -c maven :/workspace/target/surefire-reports results
+`)
+)
+
+// NewCmdCp creates the command
+func NewCmdCp(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CpOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "cp SOURCE... DEST",
+		Short:   "Copies files and directories between the local machine and a pod of an application or build",
+		Long:    cp_long,
+		Example: cp_example,
+		Aliases: []string{"copy"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Container, "container", "c", "", "The name of the container to copy the files to or from. Defaults to the first container of the pod")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the pod. Defaults to the current namespace")
+	cmd.Flags().StringVarP(&options.Environment, "environment", "e", "", "The environment of the pod. Used to find the namespace if --namespace is not specified")
+	cmd.Flags().StringVarP(&options.Build, "build", "b", "", "Copy to or from the latest build pod of the application repository such as 'myorg/myapp'")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "", "", "The branch of the build pod when using --build")
+	cmd.Flags().BoolVarP(&options.Quiet, "quiet", "q", false, "Disables reporting each file as it is copied")
+	return cmd
+}
+
+// Run implements this command
+func (o *CpOptions) Run() error {
+	args := o.Args
+	if len(args) < 2 {
+		return fmt.Errorf("missing arguments. Usage: jx cp SOURCE... DEST")
+	}
+	sources := args[:len(args)-1]
+	dest := args[len(args)-1]
+
+	target, destPath, toPod := splitPodPath(dest)
+	if toPod {
+		for _, source := range sources {
+			if _, _, remote := splitPodPath(source); remote {
+				return fmt.Errorf("cannot copy from one pod to another: %s", source)
+			}
+		}
+	} else {
+		if len(sources) > 1 {
+			return fmt.Errorf("only a single pod source can be copied to the local directory %s", dest)
+		}
+		var remote bool
+		target, destPath, remote = splitPodPath(sources[0])
+		if !remote {
+			return fmt.Errorf("either the sources or the destination must be a pod path such as 'name:path'")
+		}
+	}
+
+	client, ns, err := o.cpNamespace()
+	if err != nil {
+		return err
+	}
+	pod, err := o.cpPod(client, ns, target)
+	if err != nil {
+		return err
+	}
+	container := o.Container
+	if container == "" && len(pod.Spec.Containers) > 0 {
+		container = pod.Spec.Containers[0].Name
+	}
+
+	config, err := o.Factory.CreateKubeConfig()
+	if err != nil {
+		return err
+	}
+	exec := kube.NewPodExec(config, client)
+	var progress io.Writer
+	if !o.Quiet {
+		progress = o.Out
+	}
+	var stats *kube.CopyStats
+	if toPod {
+		log.Infof("Copying to %s in container %s of pod %s\n", util.ColorInfo(destPath), util.ColorInfo(container), util.ColorInfo(pod.Name))
+		stats, err = kube.CopyToPod(exec, ns, pod.Name, container, sources, destPath, progress)
+	} else {
+		log.Infof("Copying %s from container %s of pod %s to %s\n", util.ColorInfo(destPath), util.ColorInfo(container), util.ColorInfo(pod.Name), util.ColorInfo(dest))
+		stats, err = kube.CopyFromPod(exec, ns, pod.Name, container, destPath, dest, progress)
+	}
+	if err != nil {
+		return err
+	}
+	log.Infof("Copied %s files (%s bytes)\n", util.ColorInfo(stats.Files), util.ColorInfo(stats.Bytes))
+	return nil
+}
+
+// cpNamespace returns the namespace of the pod from the options defaulting to the current namespace
+func (o *CpOptions) cpNamespace() (kubernetes.Interface, string, error) {
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return nil, "", err
+	}
+	if o.Namespace != "" {
+		return client, o.Namespace, nil
+	}
+	if o.Environment != "" {
+		jxClient, devNs, err := o.JXClientAndDevNamespace()
+		if err != nil {
+			return nil, "", err
+		}
+		ns, err = kube.GetEnvironmentNamespace(jxClient, devNs, o.Environment)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+	return client, ns, nil
+}
+
+// cpPod returns the latest build pod if --build is specified, otherwise the pod of the name or a running pod of the
+// deployment of the name
+func (o *CpOptions) cpPod(client kubernetes.Interface, ns string, name string) (*corev1.Pod, error) {
+	if o.Build != "" {
+		buildPods, err := builds.GetBuildPods(client, ns)
+		if err != nil {
+			return nil, err
+		}
+		pod := builds.FindLatestBuildPod(buildPods, o.Build, o.Branch)
+		if pod == nil {
+			return nil, fmt.Errorf("There are no build pods for %s in namespace %s", o.Build, ns)
+		}
+		return pod, nil
+	}
+	if name == "" {
+		return nil, fmt.Errorf("no pod or deployment name specified. Use 'name:path' or --build")
+	}
+	pod, err := client.CoreV1().Pods(ns).Get(name, metav1.GetOptions{})
+	if err == nil {
+		return pod, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	pods, err := kube.GetDeploymentPods(client, name, ns)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("no pod or deployment called %s in namespace %s", name, ns)
+		}
+		return nil, err
+	}
+	for i := range pods {
+		if kube.IsPodReady(&pods[i]) {
+			return &pods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no ready pods for deployment %s in namespace %s", name, ns)
+}
+
+// splitPodPath splits a 'name:path' argument into the pod name and path returning false if it is a local path
+func splitPodPath(arg string) (string, string, bool) {
+	idx := strings.Index(arg, ":")
+	if idx < 0 || strings.Contains(arg[:idx], "/") {
+		return "", arg, false
+	}
+	return arg[:idx], arg[idx+1:], true
+}
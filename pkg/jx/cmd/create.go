@@ -66,14 +66,19 @@ func NewCmdCreate(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdCreateAddon(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateArchetype(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateBranchPattern(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateBrewTap(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateBuildCache(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateCamel(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateChat(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateCodeship(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateCluster(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateDependency(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateDevPod(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateDockerAuth(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateDocs(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateEnvVar(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateCloudResource(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateEtcHosts(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateGkeServiceAccount(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateGit(f, out, errOut))
@@ -82,6 +87,8 @@ func NewCmdCreate(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdCreateJHipster(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateLile(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateMicro(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateNodePool(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateNotification(f, out, errOut))
 	cmd.AddCommand(NewCmdCreatePostPreviewJob(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateQuickstart(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateQuickstartLocation(f, out, errOut))
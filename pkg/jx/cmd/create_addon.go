@@ -54,8 +54,11 @@ func NewCmdCreateAddon(f Factory, out io.Writer, errOut io.Writer) *cobra.Comman
 	cmd.AddCommand(NewCmdCreateAddonIstio(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonKnativeBuild(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonKubeless(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonOAuth2Proxy(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonObservability(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonOwasp(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonPipelineEvents(f, out, errOut))
+	cmd.AddCommand(NewCmdCreateAddonPipelineMetrics(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonProw(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateAddonSSO(f, out, errOut))
 
@@ -98,7 +98,7 @@ func (o *CreateAddonCloudBeesOptions) Run() error {
 			Default: false,
 			Help:    "a cluster admin role provides full privileges and therefore this action should not be run on anything other than a demo cluster that can be recreated",
 		}
-		survey.AskOne(prompt, &ok, nil)
+		util.AskOne(prompt, &ok, nil)
 
 		if !ok {
 			log.Info("aborting the cdx addon\n")
@@ -147,14 +147,14 @@ To register to get your username/password to to: %s
 			Message: "CloudBees Preview username",
 			Help:    "CloudBees is in private preview which requires a username / password for installation",
 		}
-		survey.AskOne(prompt, &username, nil)
+		util.AskOne(prompt, &username, nil)
 
 		password := ""
 		passPrompt := &survey.Password{
 			Message: "CloudBees Preview password",
 			Help:    "CloudBees is in private preview which requires a username / password for installation",
 		}
-		survey.AskOne(passPrompt, &password, nil)
+		util.AskOne(passPrompt, &password, nil)
 
 		err := o.addHelmRepoIfMissing(fmt.Sprintf(cdxRepoUrl, username, password), cdxRepoName)
 		if err != nil {
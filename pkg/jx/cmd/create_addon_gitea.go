@@ -116,7 +116,7 @@ func (o *CreateAddonGiteaOptions) Run() error {
 				prompt := &survey.Input{
 					Message: "Enter the user name to create in gitea: ",
 				}
-				err = survey.AskOne(prompt, &o.Username, nil)
+				err = util.AskOne(prompt, &o.Username, nil)
 				if err != nil {
 					return err
 				}
@@ -126,7 +126,7 @@ func (o *CreateAddonGiteaOptions) Run() error {
 					prompt := &survey.Password{
 						Message: "Enter the password for the new user in gitea: ",
 					}
-					err = survey.AskOne(prompt, &o.Password, nil)
+					err = util.AskOne(prompt, &o.Password, nil)
 					if err != nil {
 						return err
 					}
@@ -136,7 +136,7 @@ func (o *CreateAddonGiteaOptions) Run() error {
 						prompt := &survey.Input{
 							Message: "Enter the email address of the user to create in gitea: ",
 						}
-						err = survey.AskOne(prompt, &o.Email, nil)
+						err = util.AskOne(prompt, &o.Email, nil)
 						if err != nil {
 							return err
 						}
@@ -60,8 +60,11 @@ func NewCmdCreateAddonKnativeBuild(f Factory, out io.Writer, errOut io.Writer) *
 // Create the addon
 func (o *CreateAddonKnativeBuildOptions) Run() error {
 	log.Info("Installing Knative Build addon\n\n")
-	err := o.runCommandVerbose("kubectl", "apply", "-f", "https://storage.googleapis.com/knative-releases/build/latest/release.yaml")
-
+	definition, err := findAddonDefinition("knative-build")
+	if err != nil {
+		return err
+	}
+	err = o.installAddonDefinition(definition, o.Namespace, "", nil)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createAddonOAuth2ProxyLong = templates.LongDesc(`
+		Creates the OAuth2 proxy addon which protects exposed services with a login via GitHub, Google or an
+		OpenID Connect issuer
+
+		The client ID and secret of the OAuth application are stored in a Secret along with a generated cookie secret.
+		The callback URL to register with the OAuth application is displayed once the proxy is installed.
+
+		Services are protected with --protect or later via 'jx edit exposure SERVICE --policy oauth'.
+`)
+
+	createAddonOAuth2ProxyExample = templates.Examples(`
+		# Protect Nexus, ChartMuseum and Deck with a login by members of a GitHub organisation
+		jx create addon oauth2-proxy --oauth-client-id 123 --oauth-client-secret 456 --github-org myorg --protect nexus,jenkins-x-chartmuseum,deck
+
+		# Protect Deck with a Google login restricted to the users of a domain
+		jx create addon oauth2-proxy --oauth-provider google --email-domain example.com --oauth-client-id 123 --oauth-client-secret 456 --protect deck
+
+		# Upgrade the proxy reusing the stored client ID and secret and rotate its cookie secret
+		jx create addon oauth2-proxy --rotate-cookie-secret
+	`)
+)
+
+// CreateAddonOAuth2ProxyOptions the options for the create addon oauth2-proxy command
+type CreateAddonOAuth2ProxyOptions struct {
+	CreateAddonOptions
+
+	ProxySettings      kube.OAuth2ProxySettings
+	Protect            []string
+	RotateCookieSecret bool
+}
+
+// NewCmdCreateAddonOAuth2Proxy creates a command object for the "create addon oauth2-proxy" command
+func NewCmdCreateAddonOAuth2Proxy(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonOAuth2ProxyOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "oauth2-proxy",
+		Short:   "Create the OAuth2 proxy addon which protects exposed services with a login",
+		Aliases: []string{"oauth2proxy", "oauth"},
+		Long:    createAddonOAuth2ProxyLong,
+		Example: createAddonOAuth2ProxyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	addOAuth2ProxyFlags(cmd, &options.ProxySettings)
+	cmd.Flags().StringSliceVarP(&options.Protect, "protect", "", []string{}, "The names of the services in the development namespace to protect with the login")
+	cmd.Flags().BoolVarP(&options.RotateCookieSecret, "rotate-cookie-secret", "", false, "Generates a new cookie secret which logs out all users")
+	return cmd
+}
+
+func addOAuth2ProxyFlags(cmd *cobra.Command, settings *kube.OAuth2ProxySettings) {
+	cmd.Flags().StringVarP(&settings.Provider, "oauth-provider", "", kube.OAuth2ProxyProviderGitHub, "The login provider of the OAuth2 proxy. One of: "+strings.Join(kube.OAuth2ProxyProviders, ", "))
+	cmd.Flags().StringVarP(&settings.ClientID, "oauth-client-id", "", "", "The client ID of the OAuth application of the OAuth2 proxy. Defaults to the stored client ID")
+	cmd.Flags().StringVarP(&settings.ClientSecret, "oauth-client-secret", "", "", "The client secret of the OAuth application of the OAuth2 proxy. Defaults to the stored client secret")
+	cmd.Flags().StringVarP(&settings.OIDCIssuerURL, "oidc-issuer-url", "", "", "The URL of the OpenID Connect issuer used by the oidc provider")
+	cmd.Flags().StringVarP(&settings.EmailDomain, "email-domain", "", "", "Only allows users with an email address of the domain to log in. Defaults to any domain")
+	cmd.Flags().StringVarP(&settings.GitHubOrg, "github-org", "", "", "Only allows members of the GitHub organisation to log in")
+}
+
+// Run implements the command
+func (o *CreateAddonOAuth2ProxyOptions) Run() error {
+	_, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = o.installOAuth2Proxy(devNs, &o.ProxySettings, o.RotateCookieSecret)
+	if err != nil {
+		return err
+	}
+	for _, name := range o.Protect {
+		err = o.configureServiceExposure(name, v1.ExposurePolicyOAuth, &o.ProxySettings)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
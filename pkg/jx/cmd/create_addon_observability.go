@@ -0,0 +1,248 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/observability"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	defaultObservabilityNamespace   = "monitoring"
+	defaultObservabilityReleaseName = "jx-observability"
+	observabilityAuthSecretName     = "jx-observability-auth"
+)
+
+var (
+	createAddonObservabilityLong = templates.LongDesc(`
+		Creates the observability addon which installs Prometheus, Alertmanager and Grafana via the kube-prometheus-stack chart
+
+		ServiceMonitors are created for the Jenkins, Nexus and Prow services of the current team if they exist. Grafana is
+		exposed using the ingress and TLS configuration of the team and is provisioned with dashboards for the pipeline
+		and promotion metrics of Jenkins X.
+
+		Note that Jenkins only exposes metrics if the 'prometheus' plugin is installed.
+`)
+
+	createAddonObservabilityExample = templates.Examples(`
+		# Create the observability addon
+		jx create addon observability
+
+		# Create the observability addon in a custom namespace
+		jx create addon observability -n mynamespace
+	`)
+)
+
+// CreateAddonObservabilityOptions the options for the create addon observability command
+type CreateAddonObservabilityOptions struct {
+	CreateAddonOptions
+
+	Password string
+}
+
+// NewCmdCreateAddonObservability creates a command object for the "create addon observability" command
+func NewCmdCreateAddonObservability(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonObservabilityOptions{
+		CreateAddonOptions: CreateAddonOptions{
+			CreateOptions: CreateOptions{
+				CommonOptions: CommonOptions{
+					Factory: f,
+					Out:     out,
+					Err:     errOut,
+				},
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "observability",
+		Short:   "Create the observability addon which installs Prometheus and Grafana",
+		Aliases: []string{"monitoring", "prometheus"},
+		Long:    createAddonObservabilityLong,
+		Example: createAddonObservabilityExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	options.addFlags(cmd, defaultObservabilityNamespace, defaultObservabilityReleaseName)
+
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The version of the kube-prometheus-stack chart to use")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The Grafana admin password. Defaults to the admin password of Jenkins X")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonObservabilityOptions) Run() error {
+	if o.ReleaseName == "" {
+		return util.MissingOption(optionRelease)
+	}
+	err := o.ensureHelm()
+	if err != nil {
+		return errors.Wrap(err, "failed to ensure that helm is present")
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNamespace, _, err := kube.GetDevNamespace(kubeClient, o.currentNamespace)
+	if err != nil {
+		return fmt.Errorf("cannot find a dev team namespace to get existing exposecontroller config from. %v", err)
+	}
+	if o.Password == "" {
+		o.Password, err = o.getDefaultAdminPassword(devNamespace)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.addHelmRepoIfMissing(observability.ChartRepositoryURL, observability.ChartRepositoryName)
+	if err != nil {
+		return err
+	}
+	err = kube.EnsureNamespaceCreated(kubeClient, o.Namespace, nil, nil)
+	if err != nil {
+		return err
+	}
+	err = o.ensureObservabilityAuthSecret()
+	if err != nil {
+		return err
+	}
+
+	serviceMonitors := []map[string]interface{}{}
+	for _, target := range observability.DefaultServiceMonitorTargets {
+		svc, err := kubeClient.CoreV1().Services(devNamespace).Get(target.Service, meta_v1.GetOptions{})
+		if err != nil {
+			continue
+		}
+		log.Infof("Adding ServiceMonitor %s for service %s\n", util.ColorInfo(target.Name), util.ColorInfo(target.Service))
+		serviceMonitors = append(serviceMonitors, observability.ServiceMonitor(target, svc, observabilityAuthSecretName))
+	}
+	data, err := observability.ChartValues(serviceMonitors, o.Password)
+	if err != nil {
+		return err
+	}
+	tmpDir, err := ioutil.TempDir("", "jx-observability-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+	valuesFile := filepath.Join(tmpDir, "values.yaml")
+	err = ioutil.WriteFile(valuesFile, data, DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+
+	setValues := []string{}
+	if o.SetValues != "" {
+		setValues = strings.Split(o.SetValues, ",")
+	}
+	err = o.installChartWithValueFiles(o.ReleaseName, observability.ChartKubePrometheusStack, o.Version, o.Namespace, o.HelmUpdate, setValues, []string{valuesFile})
+	if err != nil {
+		return fmt.Errorf("kube-prometheus-stack deployment failed: %v", err)
+	}
+
+	err = o.ensureDashboards()
+	if err != nil {
+		return err
+	}
+
+	grafanaService := o.ReleaseName + "-grafana"
+	err = o.exposeGrafana(grafanaService)
+	if err != nil {
+		return err
+	}
+	err = o.expose(devNamespace, o.Namespace, o.Password)
+	if err != nil {
+		return err
+	}
+	url, err := kube.GetServiceURLFromName(kubeClient, grafanaService, o.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get external URL for service %s: %v", grafanaService, err)
+	}
+	log.Successf("Grafana is available at %s\n", url)
+	return nil
+}
+
+// ensureObservabilityAuthSecret creates the secret used by the ServiceMonitors of services which require authentication
+func (o *CreateAddonObservabilityOptions) ensureObservabilityAuthSecret() error {
+	secrets := o.KubeClientCached.CoreV1().Secrets(o.Namespace)
+	data := map[string][]byte{
+		observability.AuthUsernameKey: []byte("admin"),
+		observability.AuthPasswordKey: []byte(o.Password),
+	}
+	secret, err := secrets.Get(observabilityAuthSecretName, meta_v1.GetOptions{})
+	if err != nil {
+		secret = &corev1.Secret{
+			ObjectMeta: meta_v1.ObjectMeta{
+				Name:      observabilityAuthSecretName,
+				Namespace: o.Namespace,
+			},
+			Data: data,
+		}
+		_, err = secrets.Create(secret)
+		return errors.Wrapf(err, "failed to create Secret %s", observabilityAuthSecretName)
+	}
+	secret.Data = data
+	_, err = secrets.Update(secret)
+	return errors.Wrapf(err, "failed to update Secret %s", observabilityAuthSecretName)
+}
+
+// ensureDashboards creates or updates the ConfigMaps containing the Jenkins X Grafana dashboards
+func (o *CreateAddonObservabilityOptions) ensureDashboards() error {
+	configMaps, err := observability.DashboardConfigMaps(o.Namespace)
+	if err != nil {
+		return err
+	}
+	configMapInterface := o.KubeClientCached.CoreV1().ConfigMaps(o.Namespace)
+	for _, cm := range configMaps {
+		current, err := configMapInterface.Get(cm.Name, meta_v1.GetOptions{})
+		if err != nil {
+			_, err = configMapInterface.Create(cm)
+		} else {
+			current.Labels = cm.Labels
+			current.Data = cm.Data
+			_, err = configMapInterface.Update(current)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to save the dashboard ConfigMap %s", cm.Name)
+		}
+	}
+	return nil
+}
+
+// exposeGrafana annotates the Grafana service so that exposecontroller creates an ingress for it
+func (o *CreateAddonObservabilityOptions) exposeGrafana(serviceName string) error {
+	services := o.KubeClientCached.CoreV1().Services(o.Namespace)
+	svc, err := services.Get(serviceName, meta_v1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get Service %s: %v", serviceName, err)
+	}
+	if svc.Annotations == nil {
+		svc.Annotations = map[string]string{}
+	}
+	if svc.Annotations[kube.AnnotationExpose] == "" {
+		svc.Annotations[kube.AnnotationExpose] = "true"
+		_, err = services.Update(svc)
+		if err != nil {
+			return fmt.Errorf("failed to update service %s/%s", o.Namespace, serviceName)
+		}
+	}
+	return nil
+}
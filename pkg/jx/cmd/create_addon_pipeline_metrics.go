@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/observability"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createAddonPipelineMetricsLong = templates.LongDesc(`
+		Creates the pipeline metrics exporter addon which exports the durations, success rates and queue times of the
+		pipelines and promotions of the current team as Prometheus metrics
+
+		If the observability addon is installed it scrapes the exporter and displays the metrics on the Jenkins X dashboards.
+		Run 'jx create addon observability' again after installing this addon so that a ServiceMonitor is created for it.
+`)
+
+	createAddonPipelineMetricsExample = templates.Examples(`
+		# Create the pipeline metrics addon
+		jx create addon pipeline-metrics
+	`)
+)
+
+// CreateAddonPipelineMetricsOptions the options for the create addon pipeline-metrics command
+type CreateAddonPipelineMetricsOptions struct {
+	CreateOptions
+
+	Namespace string
+	Image     string
+	Port      int
+}
+
+// NewCmdCreateAddonPipelineMetrics creates a command object for the "create addon pipeline-metrics" command
+func NewCmdCreateAddonPipelineMetrics(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateAddonPipelineMetricsOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipeline-metrics",
+		Short:   "Create the pipeline metrics exporter addon",
+		Aliases: []string{"metrics"},
+		Long:    createAddonPipelineMetricsLong,
+		Example: createAddonPipelineMetricsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The team namespace to install into. Defaults to the current team")
+	cmd.Flags().StringVarP(&options.Image, "image", "i", observability.DefaultExporterImage, "The image containing the jx binary used to run the exporter")
+	cmd.Flags().IntVarP(&options.Port, "port", "p", observability.DefaultExporterPort, "The port the exporter serves the metrics on")
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateAddonPipelineMetricsOptions) Run() error {
+	if o.Image == "" {
+		return util.MissingOption("image")
+	}
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	if o.Namespace == "" {
+		o.Namespace, _, err = kube.GetDevNamespace(kubeClient, ns)
+		if err != nil {
+			return err
+		}
+	}
+	// the exporter is not allowed to register the PipelineActivity CRD itself
+	err = o.registerPipelineActivityCRD()
+	if err != nil {
+		return err
+	}
+	err = observability.EnsureExporter(kubeClient, o.Namespace, o.Image, o.Port)
+	if err != nil {
+		return err
+	}
+	log.Infof("Installed the pipeline metrics exporter %s in namespace %s\n", util.ColorInfo(observability.ExporterName), util.ColorInfo(o.Namespace))
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"fmt"
+	"strings"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -17,6 +18,9 @@ import (
 var (
 	createAddonProwLong = templates.LongDesc(`
 		Creates the prow addon for handling webhook events
+
+		This is the same as 'jx addon install prow' so the addon can be upgraded and removed with the 'jx addon'
+		commands. Knative Build is installed as well as Prow requires it.
 `)
 
 	createAddonProwExample = templates.Examples(`
@@ -88,24 +92,32 @@ func (o *CreateAddonProwOptions) Run() error {
 		return err
 	}
 
-	o.Prow.Chart = o.Chart
-	o.Prow.Version = o.Version
-	o.Prow.SetValues = o.SetValues
-	err = o.installProw()
+	definition, err := findAddonDefinition("prow")
 	if err != nil {
-		return fmt.Errorf("failed to install prow: %v", err)
+		return err
 	}
-
-	devNamespace, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
-	if err != nil {
-		return fmt.Errorf("cannot find a dev team namespace to get existing exposecontroller config from. %v", err)
+	prowDefinition := *definition
+	prowDefinition.Chart = o.Prow.Chart
+	prowDefinition.ReleaseName = o.ReleaseName
+	values := []string{}
+	if o.SetValues != "" {
+		values = strings.Split(o.SetValues, ",")
 	}
-
-	// create the ingress rule
-	err = o.expose(devNamespace, devNamespace, o.Password)
+	err = o.installAddonDefinition(&prowDefinition, o.Namespace, o.Version, values)
 	if err != nil {
-		return err
+		return fmt.Errorf("failed to install prow: %v", err)
 	}
 
+	if o.Password != "" {
+		// expose the services again so the ingress rules use the admin password
+		devNamespace, _, err := kube.GetDevNamespace(o.KubeClientCached, o.currentNamespace)
+		if err != nil {
+			return fmt.Errorf("cannot find a dev team namespace to get existing exposecontroller config from. %v", err)
+		}
+		err = o.expose(devNamespace, devNamespace, o.Password)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -194,7 +194,7 @@ func (o *CreateAddonSSOOptions) getAuthorizedOrgs() ([]string, error) {
 	}
 
 	authorizedOrgs := []string{}
-	err = survey.AskOne(promt, &authorizedOrgs, nil)
+	err = util.AskOne(promt, &authorizedOrgs, nil)
 	return authorizedOrgs, err
 }
 
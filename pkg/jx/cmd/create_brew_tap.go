@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createBrewTapLong = templates.LongDesc(`
+		Adds a Homebrew tap so that jx can install the formulae it provides, such as the jx formula of the Jenkins X tap.
+
+		If $JX_VERSION_MANIFEST is set the formulae jx installs via brew are pinned to the versions of the manifest.
+`)
+
+	createBrewTapExample = templates.Examples(`
+		# Adds the Jenkins X tap
+		jx create brewtap
+
+		# Adds another tap
+		jx create brewtap aquasecurity/trivy
+	`)
+)
+
+// CreateBrewTapOptions the options for the create brewtap command
+type CreateBrewTapOptions struct {
+	CreateOptions
+}
+
+// NewCmdCreateBrewTap creates a command object for the "create brewtap" command
+func NewCmdCreateBrewTap(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateBrewTapOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "brewtap [TAP]",
+		Short:   "Adds a Homebrew tap which defaults to the Jenkins X tap",
+		Aliases: []string{"brew-tap", "tap"},
+		Long:    createBrewTapLong,
+		Example: createBrewTapExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateBrewTapOptions) Run() error {
+	tap := jenkinsXBrewTap
+	if len(o.Args) > 0 {
+		tap = o.Args[0]
+	}
+	taps, err := o.brewTaps()
+	if err != nil {
+		return err
+	}
+	if util.StringArrayIndex(taps, tap) >= 0 {
+		log.Infof("The brew tap %s has already been added\n", util.ColorInfo(tap))
+		return nil
+	}
+	err = o.RunCommand("brew", "tap", tap)
+	if err != nil {
+		return err
+	}
+	log.Infof("Added the brew tap %s\n", util.ColorInfo(tap))
+	return nil
+}
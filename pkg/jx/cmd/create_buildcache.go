@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	buildCache  = "buildcache"
+	buildCaches = buildCache + "s"
+
+	optionBuildCacheKind = "kind"
+)
+
+var (
+	buildCacheAliases = []string{
+		buildCaches, "bc",
+	}
+
+	createBuildCacheLong = templates.LongDesc(`
+		Creates a dependency cache for the builds of your team.
+
+		A PersistentVolumeClaim is created for the cache which is mounted into all of the build pod templates
+		so that maven, gradle or npm dependencies are only downloaded once. As build pods can run on any node
+		the storage class should support the ReadWriteMany access mode.
+
+		You can also specify a caching registry or repository manager to download dependencies from which is
+		configured in the maven settings.xml or npm configuration of the build pods.
+`)
+
+	createBuildCacheExample = templates.Examples(`
+		# Create a maven dependency cache for your team
+		jx create buildcache --kind maven
+
+		# Create a 20Gi npm cache using a caching npm registry
+		jx create buildcache --kind npm --size 20Gi --registry http://nexus/repository/npm-group/
+	`)
+)
+
+// CreateBuildCacheOptions the options for the create buildcache command
+type CreateBuildCacheOptions struct {
+	CreateOptions
+
+	Cache v1.BuildCache
+}
+
+// NewCmdCreateBuildCache creates a command object for the "create buildcache" command
+func NewCmdCreateBuildCache(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateBuildCacheOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     buildCache,
+		Short:   "Creates a dependency cache for the builds of your team",
+		Aliases: buildCacheAliases,
+		Long:    createBuildCacheLong,
+		Example: createBuildCacheExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Cache.Kind, optionBuildCacheKind, "k", "", fmt.Sprintf("The kind of dependencies to cache. Possible values: %s", kube.BuildCacheKinds))
+	cmd.Flags().StringVarP(&options.Cache.StorageSize, "size", "s", kube.DefaultBuildCacheStorageSize, "The size of the PersistentVolumeClaim used to store the cache")
+	cmd.Flags().StringVarP(&options.Cache.StorageClass, "storage-class", "", "", "The storage class of the PersistentVolumeClaim used to store the cache")
+	cmd.Flags().StringVarP(&options.Cache.RegistryURL, "registry", "r", "", "The URL of a caching registry or repository manager to download dependencies from")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateBuildCacheOptions) Run() error {
+	if o.Cache.Kind == "" {
+		if o.BatchMode {
+			return util.MissingOption(optionBuildCacheKind)
+		}
+		kind, err := util.PickName(kube.BuildCacheKinds, "Pick the kind of dependencies to cache: ")
+		if err != nil {
+			return err
+		}
+		o.Cache.Kind = kind
+	}
+	err := kube.ValidateBuildCache(&o.Cache)
+	if err != nil {
+		return err
+	}
+
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	var caches []v1.BuildCache
+	callback := func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		found := false
+		for i, c := range settings.BuildCaches {
+			if c.Kind == o.Cache.Kind {
+				settings.BuildCaches[i] = o.Cache
+				found = true
+			}
+		}
+		if !found {
+			settings.BuildCaches = append(settings.BuildCaches, o.Cache)
+		}
+		caches = settings.BuildCaches
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+
+	err = kube.EnsureBuildCachePVC(kubeClient, ns, &o.Cache)
+	if err != nil {
+		return err
+	}
+	err = kube.UpdatePodTemplatesWithBuildCaches(kubeClient, ns, caches)
+	if err != nil {
+		return err
+	}
+	if o.Cache.Kind == kube.BuildCacheMaven && o.Cache.RegistryURL != "" {
+		err = kube.UpdateMavenSettingsSecretMirror(kubeClient, ns, o.Cache.RegistryURL)
+		if err != nil {
+			return err
+		}
+	}
+	log.Infof("Created the %s build cache %s for the team\n", util.ColorInfo(o.Cache.Kind), util.ColorInfo(kube.BuildCacheName(o.Cache.Kind)))
+	return nil
+}
@@ -37,6 +37,7 @@ type CreateChatTokenOptions struct {
 	Username    string
 	Password    string
 	ApiToken    string
+	TokenFile   string
 	Timeout     string
 }
 
@@ -68,6 +69,7 @@ func NewCmdCreateChatToken(f Factory, out io.Writer, errOut io.Writer) *cobra.Co
 	options.addCommonFlags(cmd)
 	options.ServerFlags.addGitServerFlags(cmd)
 	cmd.Flags().StringVarP(&options.ApiToken, "api-token", "t", "", "The API Token for the user")
+	addTokenFileFlag(cmd, &options.TokenFile)
 	cmd.Flags().StringVarP(&options.Timeout, "timeout", "", "", "The timeout if using browser automation to generate the API token (by passing username and password)")
 
 	return cmd
@@ -82,6 +84,13 @@ func (o *CreateChatTokenOptions) Run() error {
 	if len(args) > 1 {
 		o.ApiToken = args[1]
 	}
+	if o.ApiToken == "" {
+		token, err := o.readTokenFile(o.TokenFile)
+		if err != nil {
+			return err
+		}
+		o.ApiToken = token
+	}
 	authConfigSvc, err := o.CreateChatAuthConfigService()
 	if err != nil {
 		return err
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	createCloudResourceLong = templates.LongDesc(`
+		Requests a cloud resource such as a bucket, queue or cache for an app in an environment.
+
+		A Crossplane resource claim is added to the templates of the environment chart and Crossplane provisions the
+		resource from the given resource class of the cloud provider. The connection details are written to a Secret
+		in the namespace of the environment with the same name as the claim. For GitOps environments a Pull Request
+		is created. Crossplane must be installed first via 'jx addon install crossplane'.
+
+		Use 'jx get apps' to see the status of the resources of the apps.
+`)
+
+	createCloudResourceExample = templates.Examples(`
+		# request a bucket for uploads of an app in staging
+		jx create cloud-resource bucket uploads --app myapp --env staging
+
+		# request a redis cache of a specific version from the 'fast' resource class in production
+		jx create cloud-resource cache sessions --app myapp --env production --class fast --param engineVersion=3.2
+	`)
+)
+
+// CreateCloudResourceOptions the options for the create cloud-resource command
+type CreateCloudResourceOptions struct {
+	CreateOptions
+
+	App         string
+	Environment string
+	Class       string
+	Parameters  []string
+}
+
+// NewCmdCreateCloudResource creates a command object for the "create cloud-resource" command
+func NewCmdCreateCloudResource(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateCloudResourceOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "cloud-resource KIND NAME",
+		Short:   "Requests a cloud resource such as a bucket, queue or cache for an app in an environment",
+		Aliases: []string{"cloud-resources", "claim"},
+		Long:    createCloudResourceLong,
+		Example: createCloudResourceExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The name of the app")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The name of the environment")
+	cmd.Flags().StringVarP(&options.Class, "class", "c", "standard", "The name of the Crossplane resource class the resource is provisioned from")
+	cmd.Flags().StringArrayVarP(&options.Parameters, "param", "p", []string{}, "Additional fields of the claim of the form name=value")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateCloudResourceOptions) Run() error {
+	if len(o.Args) < 2 {
+		return fmt.Errorf("Missing arguments for the kind and name of the cloud resource, the kind should be one of: %s", strings.Join(kube.CrossplaneClaimKindNames(), ", "))
+	}
+	kindName := o.Args[0]
+	name := o.Args[1]
+	if o.App == "" {
+		return util.MissingOption("app")
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+	parameters := map[string]string{}
+	for _, p := range o.Parameters {
+		values := strings.SplitN(p, "=", 2)
+		if len(values) != 2 || values[0] == "" {
+			return util.InvalidOptionf("param", p, "parameters should be of the form name=value")
+		}
+		parameters[values[0]] = values[1]
+	}
+	claim, err := kube.NewCrossplaneClaim(kindName, o.App, name, o.Class, parameters)
+	if err != nil {
+		return err
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	branchName := "cloud-resource-" + claim.Name
+	title := fmt.Sprintf("Request %s %s for app %s", kindName, name, o.App)
+	message := "The command `jx create cloud-resource` was run by " + u.Username + " and it generated this Pull Request"
+
+	modifyDirFn := func(dir string) error {
+		return kube.SaveCrossplaneClaim(dir, claim)
+	}
+	applyFn := func(client kubernetes.Interface, ns string) error {
+		return kube.ApplyCrossplaneClaim(client, ns, claim)
+	}
+	err = o.modifyCloudResourceClaims(o.Environment, branchName, title, message, modifyDirFn, applyFn)
+	if err != nil {
+		return err
+	}
+	log.Infof("Requested %s %s for app %s in environment %s\n", kindName, util.ColorInfo(name), util.ColorInfo(o.App), util.ColorInfo(o.Environment))
+	log.Infof("Its connection details will be in Secret %s\n", util.ColorInfo(claim.Name))
+	return nil
+}
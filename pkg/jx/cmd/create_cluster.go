@@ -6,6 +6,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/spf13/cobra"
@@ -20,6 +21,11 @@ type CreateClusterOptions struct {
 	Flags            InitFlags
 	Provider         string
 	SkipInstallation bool
+	Phases           []string
+	SkipCostEstimate bool
+	TTL              time.Duration
+
+	phases *clusterPhases
 }
 
 const (
@@ -149,8 +155,10 @@ func createCreateClusterOptions(f Factory, out io.Writer, errOut io.Writer, clou
 }
 
 func (o *CreateClusterOptions) initAndInstall(provider string) error {
-	if o.SkipInstallation {
-		log.Infof("%s cluster created. Skipping Jenkins X installation.\n", o.Provider)
+	if !o.startPlatformPhase() {
+		if o.SkipInstallation {
+			log.Infof("%s cluster created. Skipping Jenkins X installation.\n", o.Provider)
+		}
 		return nil
 	}
 	// call jx init
@@ -161,10 +169,10 @@ func (o *CreateClusterOptions) initAndInstall(provider string) error {
 	installOpts := &o.InstallOptions
 
 	err := installOpts.Run()
-	if err != nil {
-		return err
+	if o.phases != nil {
+		o.phases.end(err)
 	}
-	return nil
+	return err
 }
 
 func (o *CreateClusterOptions) Run() error {
@@ -174,4 +182,7 @@ func (o *CreateClusterOptions) Run() error {
 func (o *CreateClusterOptions) addCreateClusterFlags(cmd *cobra.Command) {
 	o.InstallOptions.addInstallFlags(cmd, true)
 	cmd.Flags().BoolVarP(&o.SkipInstallation, "skip-installation", "", false, "Provision cluster only, don't install Jenkins X into it")
+	o.addClusterPhaseFlags(cmd)
+	o.addCostEstimateFlags(cmd)
+	o.addClusterTeardownFlags(cmd)
 }
@@ -1,14 +1,20 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/Pallinder/go-randomdata"
 	"github.com/jenkins-x/jx/pkg/cloud/aks"
+	"github.com/jenkins-x/jx/pkg/cloud/pricing"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
@@ -37,6 +43,10 @@ type CreateClusterAKSFlags struct {
 	SkipProviderRegistration  bool
 	SkipResourceGroupCreation bool
 	Tags                      string
+	CreateServicePrincipal    bool
+	ManagedIdentity           bool
+	RegistryName              string
+	SkipPermissionsCheck      bool
 }
 
 var (
@@ -101,6 +111,10 @@ func NewCmdCreateClusterAKS(f Factory, out io.Writer, errOut io.Writer) *cobra.C
 	cmd.Flags().BoolVarP(&options.Flags.SkipProviderRegistration, "skip-provider-registration", "", false, "Skip provider registration")
 	cmd.Flags().BoolVarP(&options.Flags.SkipResourceGroupCreation, "skip-resource-group-creation", "", false, "Skip resource group creation")
 	cmd.Flags().StringVarP(&options.Flags.Tags, "tags", "", "", "Space-separated tags in 'key[=value]' format. Use '' to clear existing tags.")
+	cmd.Flags().BoolVarP(&options.Flags.CreateServicePrincipal, "create-service-principal", "", false, "Creates or reuses a service principal for the cluster scoped to its resource group")
+	cmd.Flags().BoolVarP(&options.Flags.ManagedIdentity, "managed-identity", "", false, "Uses a managed identity for the cluster rather than a service principal")
+	cmd.Flags().StringVarP(&options.Flags.RegistryName, "acr-name", "", "", "The name of an Azure Container Registry the cluster is granted pull and push access to")
+	cmd.Flags().BoolVarP(&options.Flags.SkipPermissionsCheck, "skip-permissions-check", "", false, "Skip checking the current account has the roles needed to create the cluster")
 	return cmd
 }
 
@@ -111,19 +125,9 @@ func (o *CreateClusterAKSOptions) Run() error {
 	if d != "" {
 		deps = append(deps, d)
 	}
-	err := o.installMissingDependencies(deps)
-	if err != nil {
-		log.Errorf("%v\nPlease fix the error or install manually then try again", err)
-		os.Exit(-1)
-	}
-
-	err = o.createClusterAKS()
-	if err != nil {
-		log.Errorf("error creating cluster %v", err)
-		os.Exit(-1)
-	}
-
-	return nil
+	return o.runClusterPhases(func() error {
+		return o.installClusterDependencies(deps)
+	}, o.createClusterAKS)
 }
 
 func (o *CreateClusterAKSOptions) createClusterAKS() error {
@@ -149,7 +153,7 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 			PageSize: 10,
 			Help:     "location to run cluster",
 		}
-		err := survey.AskOne(prompt, &location, nil)
+		err := util.AskOne(prompt, &location, nil)
 		if err != nil {
 			return err
 		}
@@ -165,7 +169,7 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 			Default:  "Standard_D2s_v3",
 		}
 
-		err := survey.AskOne(prompts, &nodeVMSize, nil)
+		err := util.AskOne(prompts, &nodeVMSize, nil)
 		if err != nil {
 			return err
 		}
@@ -178,7 +182,12 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 			Default: "3",
 			Help:    "We recommend a minimum of 3 nodes for Jenkins X",
 		}
-		survey.AskOne(prompt, &nodeCount, nil)
+		util.AskOne(prompt, &nodeCount, nil)
+	}
+
+	err := o.confirmClusterCost(pricing.ProviderAKS, nodeVMSize, parseNodeCount(nodeCount), 0, location)
+	if err != nil {
+		return err
 	}
 
 	pathToPublicKey := o.Flags.PathToPublicKey
@@ -188,8 +197,13 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 
 	clientSecret := o.Flags.ClientSecret
 	servicePrincipal := o.Flags.ServicePrincipal
+	if o.Flags.ManagedIdentity && (o.Flags.CreateServicePrincipal || servicePrincipal != "") {
+		return util.InvalidOptionf("managed-identity", "true", "a managed identity cannot be used with a service principal")
+	}
+	if o.TTL > 0 && !o.Flags.CreateServicePrincipal && (clientSecret == "" || servicePrincipal == "") {
+		return util.InvalidOptionf(optionTTL, o.TTL.String(), "the teardown job needs --service-principal and --client-secret or --create-service-principal to delete the cluster")
+	}
 
-	var err error
 	if !o.Flags.SkipLogin {
 		//First login
 
@@ -201,7 +215,7 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 			}
 		} else {
 			log.Info("Logging in to Azure interactively...\n")
-			err = o.runCommandVerbose("az", "login")
+			err = o.runCommandVerbose("az", o.azureLoginArgs()...)
 			if err != nil {
 				return err
 			}
@@ -222,6 +236,18 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 		}
 	}
 
+	account, err := aks.GetAccount()
+	if err != nil {
+		return err
+	}
+	groupScope := aks.ResourceGroupScope(account.SubscriptionID, resourceName)
+	if !o.Flags.SkipPermissionsCheck {
+		err = o.checkAzurePermissions(account, groupScope)
+		if err != nil {
+			return err
+		}
+	}
+
 	if !o.Flags.SkipResourceGroupCreation {
 		//create a resource group
 
@@ -234,8 +260,31 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 		}
 	}
 
+	if o.Flags.CreateServicePrincipal && servicePrincipal == "" {
+		sp, err := aks.GetOrCreateServicePrincipal("jx-"+clusterName, groupScope)
+		if err != nil {
+			return err
+		}
+		servicePrincipal = sp.AppID
+		clientSecret = sp.Password
+	}
+	if o.Flags.RegistryName != "" && servicePrincipal != "" {
+		err = o.grantRegistryAccess(servicePrincipal)
+		if err != nil {
+			return err
+		}
+	}
+
 	createCluster := []string{"aks", "create", "-g", resourceName, "-n", clusterName}
 
+	if o.Flags.ManagedIdentity {
+		createCluster = append(createCluster, "--enable-managed-identity")
+		if o.Flags.RegistryName != "" {
+			// the identity only exists once the cluster is created so let az grant the pull access
+			createCluster = append(createCluster, "--attach-acr", o.Flags.RegistryName)
+		}
+	}
+
 	if o.Flags.KubeVersion != "" {
 		createCluster = append(createCluster, "--kubernetes-version", o.Flags.KubeVersion)
 	}
@@ -278,13 +327,89 @@ func (o *CreateClusterAKSOptions) createClusterAKS() error {
 
 	//setup the kube context
 
-	getCredentials := []string{"aks", "get-credentials", "--resource-group", resourceName, "--name", clusterName}
+	kubeConfigDir, err := ioutil.TempDir("", "jx-aks-kubeconfig")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(kubeConfigDir)
+	kubeConfigFile := filepath.Join(kubeConfigDir, "config")
+
+	getCredentials := []string{"aks", "get-credentials", "--resource-group", resourceName, "--name", clusterName, "--file", kubeConfigFile}
 
 	err = o.RunCommand("az", getCredentials...)
 	if err != nil {
 		return err
 	}
+	contexts, err := kube.MergeKubeConfigFile(kubeConfigFile, true)
+	if err != nil {
+		return err
+	}
+	log.Infof("Merged the kubernetes contexts %s into the kube config\n", util.ColorInfo(strings.Join(contexts, ", ")))
+
+	if o.TTL > 0 {
+		err = o.scheduleAKSTeardown(resourceName, clusterName, servicePrincipal, clientSecret)
+		if err != nil {
+			return err
+		}
+	}
 
 	log.Info("Initialising cluster ...\n")
 	return o.initAndInstall(AKS)
 }
+
+// scheduleAKSTeardown registers the job which logs in as the service principal to delete the cluster. If jx created
+// the resource group then the whole group is deleted so that the other resources of the cluster are removed too
+func (o *CreateClusterAKSOptions) scheduleAKSTeardown(resourceGroup string, clusterName string, servicePrincipal string, clientSecret string) error {
+	tenantID, err := o.getCommandOutput("", "az", "account", "show", "--query", "tenantId", "-o", "tsv")
+	if err != nil {
+		return err
+	}
+	deleteCommand := fmt.Sprintf("az group delete --name %s --yes --no-wait", resourceGroup)
+	if o.Flags.SkipResourceGroupCreation {
+		deleteCommand = fmt.Sprintf("az aks delete --resource-group %s --name %s --yes --no-wait", resourceGroup, clusterName)
+	}
+	return o.scheduleClusterTeardown(&kube.ClusterTeardown{
+		Image:   "microsoft/azure-cli",
+		Command: `az login --service-principal -u "$AZURE_CLIENT_ID" -p "$AZURE_CLIENT_SECRET" --tenant "$AZURE_TENANT_ID" && ` + deleteCommand,
+		Secrets: map[string]string{
+			"AZURE_CLIENT_ID":     servicePrincipal,
+			"AZURE_CLIENT_SECRET": clientSecret,
+			"AZURE_TENANT_ID":     strings.TrimSpace(tenantID),
+		},
+	})
+}
+
+// checkAzurePermissions verifies the current account can create the cluster in the resource group and grant roles if
+// a service principal is created or given access to a registry
+func (o *CreateClusterAKSOptions) checkAzurePermissions(account *aks.Account, groupScope string) error {
+	scope := aks.SubscriptionScope(account.SubscriptionID)
+	if o.Flags.SkipResourceGroupCreation {
+		scope = groupScope
+	}
+	roles, err := aks.GetRoles(account.User.Name, scope)
+	if err != nil {
+		log.Warnf("Could not check the roles of %s: %s\n", account.User.Name, err)
+		return nil
+	}
+	assignRoles := o.Flags.CreateServicePrincipal || (o.Flags.RegistryName != "" && !o.Flags.ManagedIdentity)
+	err = aks.CheckRoles(roles, assignRoles)
+	if err != nil {
+		return fmt.Errorf("%s at scope %s. You can skip this check via --skip-permissions-check", err, scope)
+	}
+	return nil
+}
+
+// grantRegistryAccess lets the service principal pull and push images to the Azure container registry
+func (o *CreateClusterAKSOptions) grantRegistryAccess(servicePrincipal string) error {
+	registryID, err := aks.GetRegistryID(o.Flags.RegistryName)
+	if err != nil {
+		return err
+	}
+	for _, role := range []string{aks.RoleAcrPull, aks.RoleAcrPush} {
+		err = aks.AssignRole(servicePrincipal, role, registryID)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -108,12 +108,12 @@ func (o *CreateClusterAWSOptions) Run() error {
 	if d != "" {
 		deps = append(deps, d)
 	}
-	err := o.installMissingDependencies(deps)
-	if err != nil {
-		log.Errorf("%v\nPlease fix the error or install manually then try again", err)
-		os.Exit(-1)
-	}
+	return o.runClusterPhases(func() error {
+		return o.installClusterDependencies(deps)
+	}, o.createClusterAWS)
+}
 
+func (o *CreateClusterAWSOptions) createClusterAWS() error {
 	flags := &o.Flags
 
 	if flags.NodeCount == "" {
@@ -122,7 +122,7 @@ func (o *CreateClusterAWSOptions) Run() error {
 			Default: "3",
 			Help:    "number of nodes",
 		}
-		survey.AskOne(prompt, &flags.NodeCount, nil)
+		util.AskOne(prompt, &flags.NodeCount, nil)
 	}
 
 	/*
@@ -133,7 +133,7 @@ func (o *CreateClusterAWSOptions) Run() error {
 				Default: kubeVersion,
 				Help:    "The release version of kubernetes to install in the cluster",
 			}
-			survey.AskOne(prompt, &kubeVersion, nil)
+			util.AskOne(prompt, &kubeVersion, nil)
 		}
 	*/
 
@@ -161,7 +161,7 @@ func (o *CreateClusterAWSOptions) Run() error {
 				Default: "",
 				Help:    "The AWS Availability Zones to use for the Kubernetes cluster",
 			}
-			err = survey.AskOne(prompt, &zones, survey.Required)
+			err := util.AskOne(prompt, &zones, survey.Required)
 			if err != nil {
 				return err
 			}
@@ -174,6 +174,8 @@ func (o *CreateClusterAWSOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	o.warnMissingAWSPermissions(amazon.PolicyKops)
+
 	state := flags.State
 	if state == "" {
 		kopsState := os.Getenv("KOPS_STATE_STORE")
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/jenkins-x/jx/pkg/cloud/pricing"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"gopkg.in/AlecAivazis/survey.v1"
+)
+
+const optionSkipCostEstimate = "skip-cost-estimate"
+
+func (o *CreateClusterOptions) addCostEstimateFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.SkipCostEstimate, optionSkipCostEstimate, "", false, "Don't estimate the monthly cost of the cluster before creating it")
+}
+
+// confirmClusterCost displays the approximate monthly cost of the cluster and asks the user to confirm the creation
+// of the cluster when not in batch mode
+func (o *CreateClusterOptions) confirmClusterCost(provider string, machineType string, nodes int, maxNodes int, region string) error {
+	if o.SkipCostEstimate {
+		return nil
+	}
+	estimate, err := pricing.EstimateCluster(provider, machineType, nodes, maxNodes, region)
+	if err != nil {
+		log.Warnf("Could not estimate the cost of the cluster: %s\n", err)
+		return nil
+	}
+	o.renderClusterCost(estimate)
+	if o.BatchMode {
+		return nil
+	}
+	confirm := true
+	prompt := &survey.Confirm{
+		Message: fmt.Sprintf("Create the cluster at an estimated cost of %s?", estimate),
+		Default: true,
+		Help:    "The estimate uses approximate on demand prices and excludes disks, load balancers and network traffic",
+	}
+	err = util.AskOne(prompt, &confirm, nil)
+	if err != nil {
+		return err
+	}
+	if !confirm {
+		return fmt.Errorf("cluster creation cancelled")
+	}
+	return nil
+}
+
+// parseNodeCount parses the number of nodes returning -1 if it is not valid
+func parseNodeCount(text string) int {
+	answer, err := strconv.Atoi(text)
+	if err != nil {
+		return -1
+	}
+	return answer
+}
+
+func (o *CreateClusterOptions) renderClusterCost(estimate *pricing.Estimate) {
+	log.Infof("\nEstimated cost of the %s cluster using on demand prices in %s:\n\n", util.ColorInfo(estimate.Provider), estimate.PriceRegion)
+	table := o.CreateTable()
+	table.AddRow("ITEM", "COUNT", "MONTHLY")
+	table.AddRow("control plane", "1", fmt.Sprintf("$%.2f", estimate.ControlPlaneMonthly()))
+	count := fmt.Sprintf("%d", estimate.Nodes)
+	if estimate.MaxNodes > estimate.Nodes {
+		count = fmt.Sprintf("%d - %d", estimate.Nodes, estimate.MaxNodes)
+	}
+	table.AddRow(estimate.MachineType+" node", count, fmt.Sprintf("$%.2f each", estimate.NodeMonthly()))
+	table.AddRow("total", "", estimate.String())
+	table.Render()
+	log.Infof("\nThe estimate excludes disks, load balancers, network traffic and any discounts\n\n")
+}
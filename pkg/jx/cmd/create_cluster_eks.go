@@ -1,13 +1,18 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 	"os"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/Pallinder/go-randomdata"
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/cloud/pricing"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
@@ -23,6 +28,7 @@ type CreateClusterEKSOptions struct {
 type CreateClusterEKSFlags struct {
 	ClusterName         string
 	NodeCount           int
+	NodeType            string
 	NodesMin            int
 	NodesMax            int
 	Region              string
@@ -74,6 +80,7 @@ func NewCmdCreateClusterEKS(f Factory, out io.Writer, errOut io.Writer) *cobra.C
 
 	cmd.Flags().StringVarP(&options.Flags.ClusterName, optionClusterName, "n", "", "The name of this cluster.")
 	cmd.Flags().IntVarP(&options.Flags.NodeCount, optionNodes, "o", -1, "number of nodes")
+	cmd.Flags().StringVarP(&options.Flags.NodeType, "node-type", "", "m5.large", "The EC2 instance type of the nodes")
 	cmd.Flags().IntVarP(&options.Flags.NodesMin, "nodes-min", "", -1, "minimum number of nodes")
 	cmd.Flags().IntVarP(&options.Flags.NodesMax, "nodes-max", "", -1, "maximum number of nodes")
 	cmd.Flags().IntVarP(&options.Flags.Verbose, "log-level", "", -1, "set log level, use 0 to silence, 4 for debugging and 5 for debugging with AWS debug logging (default 3)")
@@ -102,12 +109,12 @@ func (o *CreateClusterEKSOptions) Run() error {
 	if d != "" {
 		deps = append(deps, d)
 	}
-	err := o.installMissingDependencies(deps)
-	if err != nil {
-		log.Errorf("%v\nPlease fix the error or install manually then try again", err)
-		os.Exit(-1)
-	}
+	return o.runClusterPhases(func() error {
+		return o.installClusterDependencies(deps)
+	}, o.createClusterEKS)
+}
 
+func (o *CreateClusterEKSOptions) createClusterEKS() error {
 	flags := &o.Flags
 
 	zones := flags.Zones
@@ -115,6 +122,22 @@ func (o *CreateClusterEKSOptions) Run() error {
 		zones = os.Getenv("EKS_AVAILABILITY_ZONES")
 	}
 
+	nodes := flags.NodeCount
+	if nodes < 0 {
+		// the default number of nodes created by eksctl
+		nodes = 2
+	}
+	err := o.confirmClusterCost(pricing.ProviderEKS, flags.NodeType, nodes, flags.NodesMax, flags.Region)
+	if err != nil {
+		return err
+	}
+
+	if o.TTL > 0 && flags.ClusterName == "" {
+		// the teardown job needs to know the name of the cluster to delete
+		flags.ClusterName = strings.ToLower(randomdata.SillyName())
+		log.Infof("No cluster name provided so using a generated one: %s\n", flags.ClusterName)
+	}
+
 	args := []string{"create", "cluster", "--full-ecr-access"}
 	if flags.ClusterName != "" {
 		args = append(args, "--name", flags.ClusterName)
@@ -131,6 +154,9 @@ func (o *CreateClusterEKSOptions) Run() error {
 	if flags.SshPublicKey != "" {
 		args = append(args, "--ssh-public-key", flags.SshPublicKey)
 	}
+	if flags.NodeType != "" {
+		args = append(args, "--node-type", flags.NodeType)
+	}
 	if flags.NodeCount >= 0 {
 		args = append(args, "--nodes", strconv.Itoa(flags.NodeCount))
 	}
@@ -145,6 +171,8 @@ func (o *CreateClusterEKSOptions) Run() error {
 	}
 	args = append(args, "--aws-api-timeout", flags.AWSOperationTimeout.String())
 
+	o.warnMissingAWSPermissions(amazon.PolicyEKS)
+
 	log.Info("Creating EKS cluster - this can take a while so please be patient...\n")
 	log.Infof("You can watch progress in the CloudFormation console: %s\n\n", util.ColorInfo("https://console.aws.amazon.com/cloudformation/"))
 
@@ -153,6 +181,21 @@ func (o *CreateClusterEKSOptions) Run() error {
 	if err != nil {
 		return err
 	}
+
+	if o.TTL > 0 {
+		secrets := awsTeardownSecrets(flags.Region)
+		if secrets["AWS_ACCESS_KEY_ID"] == "" {
+			log.Warnf("No $AWS_ACCESS_KEY_ID so the teardown job uses the IAM role of the nodes which needs permission to delete the cluster\n")
+		}
+		err = o.scheduleClusterTeardown(&kube.ClusterTeardown{
+			Image:   "weaveworks/eksctl",
+			Command: fmt.Sprintf("eksctl delete cluster --name %s --region %s", flags.ClusterName, flags.Region),
+			Secrets: secrets,
+		})
+		if err != nil {
+			return err
+		}
+	}
 	log.Blank()
 
 	log.Info("Initialising cluster ...\n")
@@ -15,7 +15,9 @@ import (
 
 	"github.com/Pallinder/go-randomdata"
 	"github.com/jenkins-x/jx/pkg/cloud/gke"
+	"github.com/jenkins-x/jx/pkg/cloud/pricing"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
@@ -46,6 +48,9 @@ type CreateClusterGKEFlags struct {
 	Zone            string
 	Namespace       string
 	Labels          string
+
+	SkipEnableApis     bool
+	NodeServiceAccount string
 }
 
 const CLUSTER_LIST_HEADER = "PROJECT_ID"
@@ -111,30 +116,24 @@ func NewCmdCreateClusterGKE(f Factory, out io.Writer, errOut io.Writer) *cobra.C
 	cmd.Flags().BoolVarP(&options.Flags.SkipLogin, "skip-login", "", false, "Skip Google auth if already logged in via gloud auth")
 	cmd.Flags().StringVarP(&options.Flags.Labels, "labels", "", "", "The labels to add to the cluster being created such as 'foo=bar,whatnot=123'. Label names must begin with a lowercase character ([a-z]), end with a lowercase alphanumeric ([a-z0-9]) with dashes (-), and lowercase alphanumeric ([a-z0-9]) between.")
 
+	cmd.Flags().BoolVarP(&options.Flags.SkipEnableApis, "skip-enable-apis", "", false, "Don't enable the Google Cloud APIs needed to create the cluster")
+	cmd.Flags().StringVarP(&options.Flags.NodeServiceAccount, "node-service-account", "", "", "The name of the service account for the nodes which is created if it does not exist with the least privilege roles needed by the platform. Defaults to the Compute Engine default service account")
+
 	cmd.AddCommand(NewCmdCreateClusterGKETerraform(f, out, errOut))
 
 	return cmd
 }
 
 func (o *CreateClusterGKEOptions) Run() error {
-	err := o.installRequirements(GKE)
-	if err != nil {
-		return err
-	}
-
-	err = o.createClusterGKE()
-	if err != nil {
-		log.Errorf("error creating cluster %v", err)
-		return err
-	}
-
-	return nil
+	return o.runClusterPhases(func() error {
+		return o.installRequirements(GKE)
+	}, o.createClusterGKE)
 }
 
 func (o *CreateClusterGKEOptions) createClusterGKE() error {
 	var err error
 	if !o.Flags.SkipLogin {
-		err := o.runCommandVerbose("gcloud", "auth", "login", "--brief")
+		err := o.runCommandVerbose("gcloud", o.gcloudLoginArgs()...)
 		if err != nil {
 			return err
 		}
@@ -148,6 +147,11 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		}
 	}
 
+	err = o.prepareGoogleProject(projectId)
+	if err != nil {
+		return err
+	}
+
 	err = o.runCommandVerbose("gcloud", "config", "set", "project", projectId)
 	if err != nil {
 		return err
@@ -180,7 +184,7 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 			Help:     "The compute zone (e.g. us-central1-a) for the cluster",
 		}
 
-		err = survey.AskOne(prompts, &zone, nil)
+		err = util.AskOne(prompts, &zone, nil)
 		if err != nil {
 			return err
 		}
@@ -196,7 +200,7 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 			Default:  "n1-standard-2",
 		}
 
-		err := survey.AskOne(prompts, &machineType, nil)
+		err := util.AskOne(prompts, &machineType, nil)
 		if err != nil {
 			return err
 		}
@@ -210,7 +214,7 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 			Help:    "We recommend a minimum of 3 for Jenkins X,  the minimum number of nodes to be created in each of the cluster's zones",
 		}
 
-		survey.AskOne(prompt, &minNumOfNodes, nil)
+		util.AskOne(prompt, &minNumOfNodes, nil)
 	}
 
 	maxNumOfNodes := o.Flags.MaxNumOfNodes
@@ -221,7 +225,12 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 			Help:    "We recommend at least 5 for Jenkins X,  the maximum number of nodes to be created in each of the cluster's zones",
 		}
 
-		survey.AskOne(prompt, &maxNumOfNodes, nil)
+		util.AskOne(prompt, &maxNumOfNodes, nil)
+	}
+
+	err = o.confirmClusterCost(pricing.ProviderGKE, machineType, parseNodeCount(minNumOfNodes), parseNodeCount(maxNumOfNodes), zone)
+	if err != nil {
+		return err
 	}
 
 	// mandatory flags are machine type, num-nodes, zone,
@@ -261,6 +270,24 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		args = append(args, "--subnetwork", o.Flags.SubNetwork)
 	}
 
+	if o.Flags.NodeServiceAccount != "" {
+		roles := append([]string{}, gke.PlatformServiceAccountRoles...)
+		if o.TTL > 0 {
+			roles = append(roles, "roles/container.clusterAdmin")
+		}
+		email, err := gke.EnsureServiceAccount(o.Flags.NodeServiceAccount, projectId, roles)
+		if err != nil {
+			return fmt.Errorf("failed to create the node service account %s: %s", o.Flags.NodeServiceAccount, err)
+		}
+		args = append(args, "--service-account", email)
+	}
+
+	if o.TTL > 0 || o.Flags.NodeServiceAccount != "" {
+		// the access of the nodes is limited by the roles of their service account rather than the scopes. The
+		// teardown job uses the credentials of the nodes to delete the cluster
+		args = append(args, "--scopes", "cloud-platform")
+	}
+
 	labels := o.Flags.Labels
 	user, err := os_user.Current()
 	if err == nil && user != nil {
@@ -283,6 +310,14 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		return err
 	}
 
+	err = o.scheduleClusterTeardown(&kube.ClusterTeardown{
+		Image:   "google/cloud-sdk:slim",
+		Command: fmt.Sprintf("gcloud container clusters delete %s --zone %s --project %s --quiet", o.Flags.ClusterName, zone, projectId),
+	})
+	if err != nil {
+		return err
+	}
+
 	log.Info("Initialising cluster ...\n")
 	o.InstallOptions.Flags.DefaultEnvironmentPrefix = o.Flags.ClusterName
 	err = o.initAndInstall(GKE)
@@ -295,7 +330,7 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 		return err
 	}
 
-	context, err := o.getCommandOutput("", "kubectl", "config", "current-context")
+	context, err := kube.CurrentContextName()
 	if err != nil {
 		return err
 	}
@@ -320,6 +355,40 @@ func (o *CreateClusterGKEOptions) createClusterGKE() error {
 	return nil
 }
 
+// prepareGoogleProject validates the project and enables the APIs needed to create the cluster
+func (o *CreateClusterGKEOptions) prepareGoogleProject(projectId string) error {
+	err := gke.ValidateProject(projectId)
+	if err != nil {
+		return err
+	}
+	if o.Flags.SkipEnableApis {
+		return nil
+	}
+	missing, err := gke.GetMissingApis(projectId, gke.RequiredApis...)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if !o.BatchMode {
+		confirm := true
+		prompt := &survey.Confirm{
+			Message: fmt.Sprintf("The %s APIs need to be enabled on project %s. Enable them?", strings.Join(missing, ", "), projectId),
+			Default: true,
+		}
+		err = util.AskOne(prompt, &confirm, nil)
+		if err != nil {
+			return err
+		}
+		if !confirm {
+			return fmt.Errorf("cannot create the cluster without the APIs. You can enable them via: gcloud services enable %s --project %s",
+				strings.Join(missing, " "), projectId)
+		}
+	}
+	return gke.EnableApis(projectId, missing...)
+}
+
 func sanitizeLabel(username string) string {
 	sanitized := strings.ToLower(username)
 	return disallowedLabelCharacters.ReplaceAllString(sanitized, "-")
@@ -349,7 +418,7 @@ func (o *CreateClusterGKEOptions) getGoogleProjectId() (string, error) {
 			Default: true,
 		}
 		flag := true
-		err = survey.AskOne(confirm, &flag, nil)
+		err = util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return "", err
 		}
@@ -370,7 +439,7 @@ func (o *CreateClusterGKEOptions) getGoogleProjectId() (string, error) {
 			Help:    "Select a Google Project to create the cluster in",
 		}
 
-		err := survey.AskOne(prompts, &projectId, nil)
+		err := util.AskOne(prompts, &projectId, nil)
 		if err != nil {
 			return "", err
 		}
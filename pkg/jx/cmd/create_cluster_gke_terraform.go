@@ -20,6 +20,7 @@ import (
 	"github.com/Pallinder/go-randomdata"
 	"github.com/jenkins-x/jx/pkg/cloud/gke"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
@@ -121,18 +122,9 @@ func (o *CreateClusterGKETerraformOptions) addAuthFlags(cmd *cobra.Command) {
 }
 
 func (o *CreateClusterGKETerraformOptions) Run() error {
-	err := o.installRequirements(GKE, "terraform", o.InstallOptions.InitOptions.HelmBinary())
-	if err != nil {
-		return err
-	}
-
-	err = o.createClusterGKETerraform()
-	if err != nil {
-		log.Errorf("error creating cluster %v", err)
-		return err
-	}
-
-	return nil
+	return o.runClusterPhases(func() error {
+		return o.installRequirements(GKE, "terraform", o.InstallOptions.InitOptions.HelmBinary())
+	}, o.createClusterGKETerraform)
 }
 
 func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
@@ -141,7 +133,7 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 		prompt := &survey.Confirm{
 			Message: "Creating a GKE cluster with terraform is an experimental feature in jx.  Would you like to continue?",
 		}
-		survey.AskOne(prompt, &confirm, nil)
+		util.AskOne(prompt, &confirm, nil)
 
 		if !confirm {
 			// exit at this point
@@ -185,7 +177,7 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 			Help:     "The compute zone (e.g. us-central1-a) for the cluster",
 		}
 
-		err = survey.AskOne(prompts, &zone, nil)
+		err = util.AskOne(prompts, &zone, nil)
 		if err != nil {
 			return err
 		}
@@ -201,7 +193,7 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 			Default:  "n1-standard-2",
 		}
 
-		err := survey.AskOne(prompts, &machineType, nil)
+		err := util.AskOne(prompts, &machineType, nil)
 		if err != nil {
 			return err
 		}
@@ -215,7 +207,7 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 			Help:    "We recommend a minimum of 3 for Jenkins X,  the minimum number of nodes to be created in each of the cluster's zones",
 		}
 
-		survey.AskOne(prompt, &minNumOfNodes, nil)
+		util.AskOne(prompt, &minNumOfNodes, nil)
 	}
 
 	maxNumOfNodes := o.Flags.MaxNumOfNodes
@@ -226,7 +218,7 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 			Help:    "We recommend at least 5 for Jenkins X,  the maximum number of nodes to be created in each of the cluster's zones",
 		}
 
-		survey.AskOne(prompt, &maxNumOfNodes, nil)
+		util.AskOne(prompt, &maxNumOfNodes, nil)
 	}
 
 	jxHome, err := util.ConfigDir()
@@ -371,7 +363,7 @@ func (o *CreateClusterGKETerraformOptions) createClusterGKETerraform() error {
 		return err
 	}
 
-	context, err := o.getCommandOutput("", "kubectl", "config", "current-context")
+	context, err := kube.CurrentContextName()
 	if err != nil {
 		return err
 	}
@@ -411,7 +403,7 @@ func (o *CreateClusterGKETerraformOptions) getGoogleProjectId() (string, error)
 			Default: true,
 		}
 		flag := true
-		err = survey.AskOne(confirm, &flag, nil)
+		err = util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return "", err
 		}
@@ -432,7 +424,7 @@ func (o *CreateClusterGKETerraformOptions) getGoogleProjectId() (string, error)
 			Help:    "Select a Google Project to create the cluster in",
 		}
 
-		err := survey.AskOne(prompts, &projectId, nil)
+		err := util.AskOne(prompts, &projectId, nil)
 		if err != nil {
 			return "", err
 		}
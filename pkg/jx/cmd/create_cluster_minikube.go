@@ -5,7 +5,9 @@ import (
 	"io"
 	"os"
 	"os/exec"
+	"regexp"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"time"
@@ -13,8 +15,10 @@ import (
 	"fmt"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/shirou/gopsutil/mem"
 	"github.com/spf13/cobra"
 	"gopkg.in/AlecAivazis/survey.v1"
 )
@@ -35,8 +39,25 @@ type CreateClusterMinikubeFlags struct {
 	HyperVVirtualSwitch string
 	Namespace           string
 	ClusterVersion      string
+	Profile             string
+	Addons              []string
+	Reuse               bool
+	DeleteExisting      bool
 }
 
+const (
+	minikubeProfileMissing = ""
+	minikubeProfileRunning = "Running"
+	minikubeProfileStopped = "Stopped"
+
+	// minikubeMinimumMemory the minimum memory in MB the platform needs
+	minikubeMinimumMemory = 4096
+	// minikubeHostReservedMemory the memory in MB left for the host operating system
+	minikubeHostReservedMemory = 1024
+)
+
+var minikubeDiskSizeRegex = regexp.MustCompile(`^[0-9]+([kKmMgG][bB]?)?$`)
+
 var (
 	createClusterMinikubeLong = templates.LongDesc(`
 		This command creates a new kubernetes cluster, installing required local dependencies and provisions the
@@ -51,6 +72,12 @@ var (
 
 		jx create cluster minikube
 
+		# Creates a cluster with more resources and the registry addon in a profile called demo
+		jx create cluster minikube --profile demo --cpu 4 --memory 8192 --addon registry
+
+		# Installs Jenkins X into the existing jx profile, starting it if it is stopped
+		jx create cluster minikube --reuse
+
 `)
 )
 
@@ -82,6 +109,10 @@ func NewCmdCreateClusterMinikube(f Factory, out io.Writer, errOut io.Writer) *co
 	cmd.Flags().StringVarP(&options.Flags.Driver, "vm-driver", "d", "", "VM driver is one of: [hyperkit hyperv kvm kvm2 virtualbox vmwarefusion xhyve]")
 	cmd.Flags().StringVarP(&options.Flags.HyperVVirtualSwitch, "hyperv-virtual-switch", "v", "", "Additional options for using HyperV with minikube")
 	cmd.Flags().StringVarP(&options.Flags.ClusterVersion, optionKubernetesVersion, "", "", "kubernetes version")
+	cmd.Flags().StringVarP(&options.Flags.Profile, "profile", "p", "jx", "The name of the minikube profile for the cluster")
+	cmd.Flags().StringArrayVarP(&options.Flags.Addons, "addon", "", []string{}, "The minikube addons to enable such as registry")
+	cmd.Flags().BoolVarP(&options.Flags.Reuse, "reuse", "", false, "Reuses an existing profile, starting it if it is stopped, rather than failing")
+	cmd.Flags().BoolVarP(&options.Flags.DeleteExisting, "delete-existing", "", false, "Deletes an existing profile and creates it again")
 
 	return cmd
 }
@@ -93,24 +124,9 @@ func (o *CreateClusterMinikubeOptions) Run() error {
 		deps = append(deps, d)
 	}
 
-	err := o.installMissingDependencies(deps)
-	if err != nil {
-		log.Errorf("error installing missing dependencies %v, please fix or install manually then try again", err)
-		os.Exit(-1)
-	}
-
-	if o.isExistingMinikubeRunning() {
-		log.Error("an existing minikube cluster is already running, perhaps use `jx install`.\nNote existing minikube must have RBAC enabled, running `minikube delete` and `jx create cluster minikube` creates a new VM with RBAC enabled")
-		os.Exit(-1)
-	}
-
-	err = o.createClusterMinikube()
-	if err != nil {
-		log.Errorf("error creating cluster %v", err)
-		os.Exit(-1)
-	}
-
-	return nil
+	return o.runClusterPhases(func() error {
+		return o.installClusterDependencies(deps)
+	}, o.createClusterMinikube)
 }
 
 func (o *CreateClusterMinikubeOptions) defaultMacVMDriver() string {
@@ -122,43 +138,175 @@ func (o *CreateClusterMinikubeOptions) defaultMacVMDriver() string {
 	return "hyperkit"
 }
 
-func (o *CreateClusterMinikubeOptions) isExistingMinikubeRunning() bool {
-
+// minikubeProfileStatus returns whether the profile is running, stopped or missing
+func (o *CreateClusterMinikubeOptions) minikubeProfileStatus(profile string) string {
 	var cmd_out bytes.Buffer
 
-	e := exec.Command("minikube", "status")
+	e := exec.Command("minikube", "status", "--profile", profile)
 	e.Stdout = &cmd_out
-	e.Stderr = o.Err
-	err := e.Run()
+	e.Stderr = &cmd_out
+	// minikube status fails for stopped profiles so the output is checked regardless
+	e.Run()
 
-	if err != nil {
-		return false
+	output := cmd_out.String()
+	if strings.Contains(output, minikubeProfileRunning) {
+		return minikubeProfileRunning
+	}
+	if strings.Contains(output, minikubeProfileStopped) {
+		return minikubeProfileStopped
 	}
+	return minikubeProfileMissing
+}
 
-	if strings.Contains(cmd_out.String(), "Running") {
-		return true
+// prepareMinikubeProfile deletes or reuses an existing profile returning true if the existing profile is reused
+func (o *CreateClusterMinikubeOptions) prepareMinikubeProfile(profile string) (bool, error) {
+	status := o.minikubeProfileStatus(profile)
+	if status == minikubeProfileMissing {
+		return false, nil
+	}
+	if o.Flags.DeleteExisting {
+		log.Infof("Deleting the existing minikube profile %s\n", util.ColorInfo(profile))
+		return false, o.RunCommand("minikube", "delete", "--profile", profile)
+	}
+	if !o.Flags.Reuse {
+		return false, fmt.Errorf("the minikube profile %s already exists and is %s. Use --reuse to install into it, --delete-existing to create it again or --profile to use another profile",
+			profile, strings.ToLower(status))
 	}
+	if status == minikubeProfileStopped {
+		log.Infof("Starting the existing minikube profile %s\n", util.ColorInfo(profile))
+		err := o.RunCommand("minikube", "start", "--profile", profile)
+		if err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
 
-	return false
+// validateMinikubeResources returns an error if the resources are invalid or more than the host has and warns if
+// they are less than the platform needs
+func validateMinikubeResources(memory string, cpus string, diskSize string, hostMemoryMB uint64, hostCPUs int) error {
+	memoryMB, err := strconv.Atoi(memory)
+	if err != nil || memoryMB <= 0 {
+		return util.InvalidOptionf("memory", memory, "the memory must be a positive number of MB")
+	}
+	if hostMemoryMB > 0 && uint64(memoryMB)+minikubeHostReservedMemory > hostMemoryMB {
+		return util.InvalidOptionf("memory", memory, "the host only has %d MB of memory and needs %d MB for itself", hostMemoryMB, minikubeHostReservedMemory)
+	}
+	if memoryMB < minikubeMinimumMemory {
+		log.Warnf("Jenkins X needs at least %d MB of memory so %s MB may not be enough\n", minikubeMinimumMemory, memory)
+	}
+	cpuCount, err := strconv.Atoi(cpus)
+	if err != nil || cpuCount <= 0 {
+		return util.InvalidOptionf("cpu", cpus, "the number of CPUs must be a positive number")
+	}
+	if hostCPUs > 0 && cpuCount > hostCPUs {
+		return util.InvalidOptionf("cpu", cpus, "the host only has %d CPUs", hostCPUs)
+	}
+	if !minikubeDiskSizeRegex.MatchString(diskSize) {
+		return util.InvalidOptionf("disk-size", diskSize, "the disk size must be a number with an optional unit such as 150GB or 20000MB")
+	}
+	return nil
 }
 
 func (o *CreateClusterMinikubeOptions) createClusterMinikube() error {
+	profile := o.Flags.Profile
+	reuse, err := o.prepareMinikubeProfile(profile)
+	if err != nil {
+		return err
+	}
+	if !reuse {
+		err = o.startMinikube(profile)
+		if err != nil {
+			return err
+		}
+	}
+
+	// make the profile the active one so that later minikube commands use it
+	err = o.RunCommand("minikube", "profile", profile)
+	if err != nil {
+		return err
+	}
+	if util.IsWSL() {
+		// the Windows minikube binary only configures the Windows kube config
+		err = o.mergeWindowsKubeConfig([]string{profile}, true)
+		if err != nil {
+			return err
+		}
+	}
+	for _, addon := range o.Flags.Addons {
+		err = o.RunCommand("minikube", "addons", "enable", addon, "--profile", profile)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.retry(3, 10*time.Second, func() (err error) {
+		err = o.RunCommand("kubectl", "create", "clusterrolebinding", "add-on-cluster-admin", "--clusterrole", "cluster-admin", "--serviceaccount", "kube-system:default")
+		if err != nil && reuse {
+			// the binding already exists in a reused profile
+			return nil
+		}
+		return
+	})
+	if err != nil {
+		return err
+	}
+
+	ip, err := o.getCommandOutput("", "minikube", "ip", "--profile", profile)
+	if err != nil {
+		return err
+	}
+	o.InstallOptions.Flags.Domain = ip + ".nip.io"
+
+	log.Info("Initialising cluster ...\n")
+	err = o.initAndInstall(MINIKUBE)
+	if err != nil {
+		return err
+	}
 
-	mem := o.Flags.Memory
+	context, err := kube.CurrentContextName()
+	if err != nil {
+		return err
+	}
+
+	ns := o.Flags.Namespace
+	if ns == "" {
+		_, ns, _ = o.KubeClient()
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.RunCommand("kubectl", "config", "set-context", context, "--namespace", ns)
+	if err != nil {
+		return err
+	}
+
+	err = o.RunCommand("kubectl", "get", "ingress")
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// startMinikube creates the minikube VM for the profile
+func (o *CreateClusterMinikubeOptions) startMinikube(profile string) error {
+	memory := o.Flags.Memory
 	prompt := &survey.Input{
 		Message: "memory (MB)",
 		Default: MinikubeDefaultMemory,
 		Help:    "Amount of RAM allocated to the minikube VM in MB",
 	}
-	showPromptIfOptionNotSet(&mem, prompt)
+	showPromptIfOptionNotSet(&memory, prompt)
 
-	cpu := o.Flags.CPU
+	cpus := o.Flags.CPU
 	prompt = &survey.Input{
 		Message: "cpu (cores)",
 		Default: MinikubeDefaultCpu,
 		Help:    "Number of CPUs allocated to the minikube VM",
 	}
-	showPromptIfOptionNotSet(&cpu, prompt)
+	showPromptIfOptionNotSet(&cpus, prompt)
 
 	disksize := o.Flags.DiskSize
 	prompt = &survey.Input{
@@ -168,11 +316,23 @@ func (o *CreateClusterMinikubeOptions) createClusterMinikube() error {
 	}
 	showPromptIfOptionNotSet(&disksize, prompt)
 
+	hostMemoryMB := uint64(0)
+	vm, err := mem.VirtualMemory()
+	if err == nil {
+		hostMemoryMB = vm.Total / (1024 * 1024)
+	}
+	err = validateMinikubeResources(memory, cpus, disksize, hostMemoryMB, runtime.NumCPU())
+	if err != nil {
+		return err
+	}
+
 	vmDriverValue := o.Flags.Driver
 
+	// inside WSL minikube is a Windows binary which uses the Windows hypervisors
+	goos := util.BinaryGOOS("minikube")
 	defaultDriver := ""
 	if len(vmDriverValue) == 0 {
-		switch runtime.GOOS {
+		switch goos {
 		case "darwin":
 			defaultDriver = o.defaultMacVMDriver()
 		case "windows":
@@ -192,12 +352,12 @@ func (o *CreateClusterMinikubeOptions) createClusterMinikube() error {
 	if defaultDriver != "virtualbox" {
 		drivers = append(drivers, "virtualbox")
 	}
-	if runtime.GOOS == "darwin" {
+	if goos == "darwin" {
 		if util.StringArrayIndex(drivers, "xhyve") < 0 {
 			drivers = append(drivers, "xhyve")
 		}
 	}
-	if runtime.GOOS == "linux" {
+	if goos == "linux" {
 		drivers = append(drivers, "none")
 	}
 
@@ -211,14 +371,14 @@ func (o *CreateClusterMinikubeOptions) createClusterMinikube() error {
 	showPromptIfOptionNotSet(&vmDriverValue, prompts)
 
 	if vmDriverValue != "none" {
-		err := o.doInstallMissingDependencies([]string{vmDriverValue})
+		err = o.doInstallMissingDependencies([]string{vmDriverValue})
 		if err != nil {
 			log.Errorf("error installing missing dependencies %v, please fix or install manually then try again", err)
 			os.Exit(-1)
 		}
 	}
 
-	args := []string{"start", "--memory", mem, "--cpus", cpu, "--disk-size", disksize, "--vm-driver", vmDriverValue, "--bootstrapper=kubeadm"}
+	args := []string{"start", "--profile", profile, "--memory", memory, "--cpus", cpus, "--disk-size", disksize, "--vm-driver", vmDriverValue, "--bootstrapper=kubeadm"}
 	hyperVVirtualSwitch := o.Flags.HyperVVirtualSwitch
 	if hyperVVirtualSwitch != "" {
 		args = append(args, "--hyperv-virtual-switch", hyperVVirtualSwitch)
@@ -228,62 +388,18 @@ func (o *CreateClusterMinikubeOptions) createClusterMinikube() error {
 		args = append(args, "--kubernetes-version", kubernetesVersion)
 	}
 	o.Out.Write([]byte("Creating Minikube cluster...\n"))
-	err := o.RunCommand("minikube", args...)
-	if err != nil {
-		return err
-	} else {
-		o.Out.Write([]byte("Minikube cluster created.\n"))
-	}
-
-	err = o.retry(3, 10*time.Second, func() (err error) {
-		err = o.RunCommand("kubectl", "create", "clusterrolebinding", "add-on-cluster-admin", "--clusterrole", "cluster-admin", "--serviceaccount", "kube-system:default")
-		return
-	})
-	if err != nil {
-		return err
-	}
-
-	ip, err := o.getCommandOutput("", "minikube", "ip")
-	if err != nil {
-		return err
-	}
-	o.InstallOptions.Flags.Domain = ip + ".nip.io"
-
-	log.Info("Initialising cluster ...\n")
-	err = o.initAndInstall(MINIKUBE)
-	if err != nil {
-		return err
-	}
-
-	context, err := o.getCommandOutput("", "kubectl", "config", "current-context")
-	if err != nil {
-		return err
-	}
-
-	ns := o.Flags.Namespace
-	if ns == "" {
-		_, ns, _ = o.KubeClient()
-		if err != nil {
-			return err
-		}
-	}
-
-	err = o.RunCommand("kubectl", "config", "set-context", context, "--namespace", ns)
-	if err != nil {
-		return err
-	}
-
-	err = o.RunCommand("kubectl", "get", "ingress")
+	err = o.RunCommand("minikube", args...)
 	if err != nil {
 		return err
 	}
+	o.Out.Write([]byte("Minikube cluster created.\n"))
 
 	return nil
 }
 
 func showPromptIfOptionNotSet(option *string, p survey.Prompt) error {
 	if *option == "" {
-		err := survey.AskOne(p, option, nil)
+		err := util.AskOne(p, option, nil)
 		if err != nil {
 			return err
 		}
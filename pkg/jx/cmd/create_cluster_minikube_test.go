@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateMinikubeResources(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, validateMinikubeResources("4096", "3", "150GB", 16384, 8))
+	assert.NoError(t, validateMinikubeResources("2048", "2", "20000", 16384, 8), "low memory only warns")
+	assert.NoError(t, validateMinikubeResources("8192", "4", "40g", 0, 0), "unknown host resources are not checked")
+
+	assert.Error(t, validateMinikubeResources("lots", "3", "150GB", 16384, 8))
+	assert.Error(t, validateMinikubeResources("16384", "3", "150GB", 16384, 8), "memory must leave room for the host")
+	assert.Error(t, validateMinikubeResources("4096", "0", "150GB", 16384, 8))
+	assert.Error(t, validateMinikubeResources("4096", "16", "150GB", 16384, 8))
+	assert.Error(t, validateMinikubeResources("4096", "3", "150 TB", 16384, 8))
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
@@ -89,24 +90,9 @@ func (o *CreateClusterMinishiftOptions) Run() error {
 		deps = append(deps, d)
 	}
 
-	err := o.installMissingDependencies(deps)
-	if err != nil {
-		log.Errorf("error installing missing dependencies %v, please fix or install manually then try again", err)
-		os.Exit(-1)
-	}
-
-	if o.isExistingMinishiftRunning() {
-		log.Error("an existing minishift cluster is already running, perhaps use `jx install`.\nNote existing minishift musty have RBAC enabled, running `minishift delete` and `jx create cluster minishift` creates a new VM with RBAC enabled")
-		os.Exit(-1)
-	}
-
-	err = o.createClusterMinishift()
-	if err != nil {
-		log.Errorf("error creating cluster %v", err)
-		os.Exit(-1)
-	}
-
-	return nil
+	return o.runClusterPhases(func() error {
+		return o.installClusterDependencies(deps)
+	}, o.createClusterMinishift)
 }
 
 func (o *CreateClusterMinishiftOptions) defaultMacVMDriver() string {
@@ -133,13 +119,17 @@ func (o *CreateClusterMinishiftOptions) isExistingMinishiftRunning() bool {
 }
 
 func (o *CreateClusterMinishiftOptions) createClusterMinishift() error {
+	if o.isExistingMinishiftRunning() {
+		return fmt.Errorf("an existing minishift cluster is already running, perhaps use `jx install`.\nNote existing minishift must have RBAC enabled, running `minishift delete` and `jx create cluster minishift` creates a new VM with RBAC enabled")
+	}
+
 	mem := o.Flags.Memory
 	prompt := &survey.Input{
 		Message: "memory (MB)",
 		Default: mem,
 		Help:    "Amount of RAM allocated to the minishift VM in MB",
 	}
-	survey.AskOne(prompt, &mem, nil)
+	util.AskOne(prompt, &mem, nil)
 
 	cpu := o.Flags.CPU
 	prompt = &survey.Input{
@@ -147,7 +137,7 @@ func (o *CreateClusterMinishiftOptions) createClusterMinishift() error {
 		Default: cpu,
 		Help:    "Number of CPUs allocated to the minishift VM",
 	}
-	survey.AskOne(prompt, &cpu, nil)
+	util.AskOne(prompt, &cpu, nil)
 
 	vmDriverValue := o.Flags.Driver
 
@@ -186,7 +176,7 @@ func (o *CreateClusterMinishiftOptions) createClusterMinishift() error {
 		Help:    "VM driver, defaults to recommended native virtualisation",
 	}
 
-	err := survey.AskOne(prompts, &driver, nil)
+	err := util.AskOne(prompts, &driver, nil)
 	if err != nil {
 		return err
 	}
@@ -14,6 +14,7 @@ import (
 	"github.com/Pallinder/go-randomdata"
 	"github.com/jenkins-x/jx/pkg/cloud/oke"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
@@ -49,6 +50,9 @@ type CreateClusterOKEFlags struct {
 	InitialNodeLabels            string
 	PoolMaxWaitSeconds           string
 	PoolWaitIntervalSeconds      string
+	CompartmentName              string
+	ParentCompartmentId          string
+	CreateVcn                    bool
 }
 
 var (
@@ -71,6 +75,9 @@ var (
 
 		jx create cluster oke
 
+		# Creates the cluster in a new compartment of the tenancy with a new VCN and subnets
+		jx create cluster oke --compartmentName jx --parentCompartmentId ocid1.tenancy.oc1..abc --createVcn
+
 `)
 )
 
@@ -117,24 +124,18 @@ func NewCmdCreateClusterOKE(f Factory, out io.Writer, errOut io.Writer) *cobra.C
 	cmd.Flags().StringVarP(&options.Flags.ClusterWaitIntervalSeconds, "clusterWaitIntervalSeconds", "", "", "Check every --wait-interval-seconds to see whether the work request to see if it has reached the state defined by --wait-for-state.")
 	cmd.Flags().StringVarP(&options.Flags.InitialNodeLabels, "initialNodeLabels", "", "", "A list of key/value pairs to add to nodes after they join the Kubernetes cluster.")
 	cmd.Flags().StringVarP(&options.Flags.PoolMaxWaitSeconds, "poolMaxWaitSeconds", "", "", "The maximum time to wait for the work request to reach the state defined by --wait-for-state. Defaults to 1200 seconds.")
+	cmd.Flags().StringVarP(&options.Flags.CompartmentName, "compartmentName", "", "", "The name of a compartment to create for the cluster if no --compartmentId is specified.")
+	cmd.Flags().StringVarP(&options.Flags.ParentCompartmentId, "parentCompartmentId", "", "", "The OCID of the compartment or tenancy in which to create the --compartmentName compartment.")
+	cmd.Flags().BoolVarP(&options.Flags.CreateVcn, "createVcn", "", false, "Creates a VCN with an internet gateway, worker subnets and load balancer subnets for the cluster rather than using --vcnId.")
 	cmd.Flags().StringVarP(&options.Flags.PoolWaitIntervalSeconds, "poolWaitIntervalSeconds", "", "", "Check every --wait-interval-seconds to see whether the work request to see if it has reached the state defined by --wait-for-state.")
 
 	return cmd
 }
 
 func (o *CreateClusterOKEOptions) Run() error {
-	err := o.installRequirements(OKE)
-	if err != nil {
-		return err
-	}
-
-	err = o.createClusterOKE()
-	if err != nil {
-		log.Errorf("error creating cluster %v", err)
-		return err
-	}
-
-	return nil
+	return o.runClusterPhases(func() error {
+		return o.installRequirements(OKE)
+	}, o.createClusterOKE)
 }
 
 func (o *CreateClusterOKEOptions) createClusterOKE() error {
@@ -152,7 +153,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is required environment variable",
 		}
 
-		survey.AskOne(prompt, &endpoint, nil)
+		util.AskOne(prompt, &endpoint, nil)
 	}
 	fmt.Printf("Endpoint is %s\n", endpoint)
 	os.Setenv("ENDPOINT", endpoint)
@@ -163,6 +164,15 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 	}
 
 	compartmentId := o.Flags.CompartmentId
+	if compartmentId == "" && o.Flags.CompartmentName != "" {
+		if o.Flags.ParentCompartmentId == "" {
+			return util.MissingOption("parentCompartmentId")
+		}
+		compartmentId, err = oke.CreateCompartment(o.Flags.ParentCompartmentId, o.Flags.CompartmentName)
+		if err != nil {
+			return err
+		}
+	}
 	if compartmentId == "" {
 		prompt := &survey.Input{
 			Message: "The OCID of the compartment in which to create the cluster:",
@@ -170,7 +180,21 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is required parameter",
 		}
 
-		survey.AskOne(prompt, &compartmentId, nil)
+		util.AskOne(prompt, &compartmentId, nil)
+	}
+
+	if o.Flags.CreateVcn && o.Flags.VcnId == "" {
+		network, err := oke.CreateClusterNetwork(compartmentId, "jx-"+o.Flags.ClusterName)
+		if err != nil {
+			return err
+		}
+		o.Flags.VcnId = network.VcnId
+		if o.Flags.NodePoolSubnetIds == "" {
+			o.Flags.NodePoolSubnetIds = strings.Join(network.WorkerSubnetIds, ",")
+		}
+		if o.Flags.ServiceLbSubnetIds == "" {
+			o.Flags.ServiceLbSubnetIds = strings.Join(network.LBSubnetIds, ",")
+		}
 	}
 
 	vcnId := o.Flags.VcnId
@@ -181,7 +205,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is required parameter",
 		}
 
-		survey.AskOne(prompt, &vcnId, nil)
+		util.AskOne(prompt, &vcnId, nil)
 	}
 
 	kubernetesVersion := o.Flags.KubernetesVersion
@@ -193,7 +217,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is required parameter",
 		}
 
-		survey.AskOne(prompt, &kubernetesVersion, nil)
+		util.AskOne(prompt, &kubernetesVersion, nil)
 	}
 
 	//Get node pool settings
@@ -212,7 +236,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			PageSize: 10,
 		}
 
-		survey.AskOne(prompt, &nodeImageName, nil)
+		util.AskOne(prompt, &nodeImageName, nil)
 	}
 
 	nodeShape := o.Flags.NodeShape
@@ -225,7 +249,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			PageSize: 10,
 		}
 
-		survey.AskOne(prompt, &nodeShape, nil)
+		util.AskOne(prompt, &nodeShape, nil)
 	}
 
 	nodePoolSubnetIds := o.Flags.NodePoolSubnetIds
@@ -236,7 +260,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is required parameter",
 		}
 
-		survey.AskOne(prompt, &nodePoolSubnetIds, nil)
+		util.AskOne(prompt, &nodePoolSubnetIds, nil)
 	}
 	nodePoolSubnetIdsArray := strings.Split(nodePoolSubnetIds, ",")
 	for i := range nodePoolSubnetIdsArray {
@@ -266,7 +290,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is optional parameter and nice to have it as Jenkins X will create ingress controller based on it",
 		}
 
-		survey.AskOne(prompt, &serviceLbSubnetIds, nil)
+		util.AskOne(prompt, &serviceLbSubnetIds, nil)
 	}
 
 	if serviceLbSubnetIds != "" {
@@ -293,7 +317,7 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 			Help:    "This is optional parameter and nice to have it as user can access work nodes with it",
 		}
 
-		survey.AskOne(prompt, &sshPublicKeyValue, nil)
+		util.AskOne(prompt, &sshPublicKeyValue, nil)
 	}
 
 	isKubernetesDashboardEnabled := o.Flags.IsKubernetesDashboardEnabled
@@ -335,120 +359,127 @@ func (o *CreateClusterOKEOptions) createClusterOKE() error {
 
 	fmt.Printf("Create cluster output: %s\n", output)
 
-	if strings.Contains(output, "identifier") {
-		subClusterInfo := strings.Split(output, "identifier")
-		clusterIdRaw := strings.Split(subClusterInfo[1], "}")
-		clusterId := strings.TrimSpace(strings.Replace(clusterIdRaw[0][4:], "\"", "", -1))
-		fmt.Printf("Cluster id: %s\n", clusterId)
-
-		//setup the kube context
-		log.Info("Setup kube context ...\n")
-		var kubeconfigFile = ""
-		if home := util.HomeDir(); home != "" {
-			kubeconfigFile = filepath.Join(util.HomeDir(), "kubeconfig")
-		} else {
-			kubeconfigFile = filepath.Join("/tmp", "kubeconfig")
-		}
+	clusterId, err := oke.ParseWorkRequestResourceID(output, "cluster")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Cluster id: %s\n", clusterId)
+
+	//setup the kube context
+	log.Info("Setup kube context ...\n")
+	err = o.mergeOKEKubeConfig(clusterId)
+	if err != nil {
+		return err
+	}
+
+	//create node pool
+	log.Info("Creating node pool ...\n")
 
-		kubeContextArgs := []string{"ce", "cluster", "create-kubeconfig",
-			"--cluster-id", clusterId,
-			"--file", kubeconfigFile}
+	poolArgs := "ce node-pool create --name=" + o.Flags.NodePoolName + " --compartment-id=" + compartmentId + " --cluster-id=" + clusterId + " --kubernetes-version=" + kubernetesVersion + " --node-image-name=" + nodeImageName + " --node-shape=" + nodeShape + " --subnet-ids=file:///tmp/oke_pool_config.json" + " --wait-for-state=SUCCEEDED"
 
-		err = o.runCommandVerbose("oci", kubeContextArgs...)
+	quantityPerSubnet := o.Flags.QuantityPerSubnet
+	quantityPerSubnet = (map[bool]string{true: quantityPerSubnet, false: "1"})[quantityPerSubnet != ""]
+	log.Info("Will create " + quantityPerSubnet + " node per subnet ...\n")
+	poolArgs = poolArgs + " --quantity-per-subnet=" + quantityPerSubnet
+
+	initialNodeLabels := o.Flags.InitialNodeLabels
+	if initialNodeLabels != "" {
+		initialNodeLabelsJson := "[" + initialNodeLabels + "]"
+		err := ioutil.WriteFile("/tmp/oke_pool_labels_config.json", []byte(initialNodeLabelsJson), 0644)
 		if err != nil {
-			return err
-		}
-		os.Setenv("KUBECONFIG", kubeconfigFile)
-
-		//create node pool
-		log.Info("Creating node pool ...\n")
-
-		poolArgs := "ce node-pool create --name=" + o.Flags.NodePoolName + " --compartment-id=" + compartmentId + " --cluster-id=" + clusterId + " --kubernetes-version=" + kubernetesVersion + " --node-image-name=" + nodeImageName + " --node-shape=" + nodeShape + " --subnet-ids=file:///tmp/oke_pool_config.json" + " --wait-for-state=SUCCEEDED"
-
-		quantityPerSubnet := o.Flags.QuantityPerSubnet
-		quantityPerSubnet = (map[bool]string{true: quantityPerSubnet, false: "1"})[quantityPerSubnet != ""]
-		log.Info("Will create " + quantityPerSubnet + " node per subnet ...\n")
-		poolArgs = poolArgs + " --quantity-per-subnet=" + quantityPerSubnet
-
-		initialNodeLabels := o.Flags.InitialNodeLabels
-		if initialNodeLabels != "" {
-			initialNodeLabelsJson := "[" + initialNodeLabels + "]"
-			err := ioutil.WriteFile("/tmp/oke_pool_labels_config.json", []byte(initialNodeLabelsJson), 0644)
-			if err != nil {
-				fmt.Printf("error write file to /tmp file %v", err)
-			}
-			poolArgs = poolArgs + " --initial-node-labels=file:///tmp/oke_pool_labels_config.json"
+			fmt.Printf("error write file to /tmp file %v", err)
 		}
+		poolArgs = poolArgs + " --initial-node-labels=file:///tmp/oke_pool_labels_config.json"
+	}
 
-		poolMaxWaitSeconds := o.Flags.PoolMaxWaitSeconds
-		if poolMaxWaitSeconds != "" {
+	poolMaxWaitSeconds := o.Flags.PoolMaxWaitSeconds
+	if poolMaxWaitSeconds != "" {
 
-			poolArgs = poolArgs + " --max-wait-seconds=" + poolMaxWaitSeconds
-		}
+		poolArgs = poolArgs + " --max-wait-seconds=" + poolMaxWaitSeconds
+	}
 
-		poolWaitIntervalSeconds := o.Flags.PoolWaitIntervalSeconds
-		if poolWaitIntervalSeconds != "" {
-			poolArgs = poolArgs + " --wait-interval-seconds=" + poolWaitIntervalSeconds
-		}
+	poolWaitIntervalSeconds := o.Flags.PoolWaitIntervalSeconds
+	if poolWaitIntervalSeconds != "" {
+		poolArgs = poolArgs + " --wait-interval-seconds=" + poolWaitIntervalSeconds
+	}
 
-		log.Info("Creating Node Pool...\n")
-		poolArgsArray := strings.Split(poolArgs, " ")
+	log.Info("Creating Node Pool...\n")
+	poolArgsArray := strings.Split(poolArgs, " ")
 
-		if sshPublicKeyValue != "" {
-			sshPubKey := "--ssh-public-key=" + sshPublicKeyValue
-			poolArgsArray = append(poolArgsArray, sshPubKey)
-		}
+	if sshPublicKeyValue != "" {
+		sshPubKey := "--ssh-public-key=" + sshPublicKeyValue
+		poolArgsArray = append(poolArgsArray, sshPubKey)
+	}
+
+	fmt.Printf("Pool creation args are: %s\n", poolArgsArray)
+	poolCreationOutput, err := o.getCommandOutput("", "oci", poolArgsArray...)
+	if err != nil {
+		return err
+	}
+
+	//wait for node pool active
+	poolId, err := oke.ParseWorkRequestResourceID(poolCreationOutput, "nodepool")
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Node Pool id: %s\n", poolId)
+
+	//get node pool status until they are active
+	nodeQuantity, err := strconv.Atoi(quantityPerSubnet)
+	if err != nil {
+		return err
+	}
+
+	err = o.waitForNodeToComeUp(nodeQuantity*len(nodePoolSubnetIdsArray), poolId)
+	if err != nil {
+		return fmt.Errorf("Failed to wait for Kubernetes cluster node to be ready: %s\n", err)
+	}
 
-		fmt.Printf("Pool creation args are: %s\n", poolArgsArray)
-		poolCreationOutput, err := o.getCommandOutput("", "oci", poolArgsArray...)
+	if isTillerEnabled {
+		//need to wait for tiller pod is running
+		fmt.Printf("Wait for tiller pod is running\n")
+		err = o.waitForTillerComeUp()
 		if err != nil {
-			return err
+			return fmt.Errorf("Failed to wait for Tiller to be ready: %s\n", err)
 		}
+	}
 
-		//wait for node pool active
-		if strings.Contains(poolCreationOutput, "identifier") {
-			subPoolInfo := strings.Split(poolCreationOutput, "identifier")
-			poolIdRaw := strings.Split(subPoolInfo[1], "}")
-			poolId := strings.TrimSpace(strings.Replace(poolIdRaw[0][4:], "\"", "", -1))
-			fmt.Printf("Node Pool id: %s\n", poolId)
-
-			//get node pool status until they are active
-			nodeQuantity, err := strconv.Atoi(quantityPerSubnet)
-			if err != nil {
-				return err
-			}
-
-			err = o.waitForNodeToComeUp(nodeQuantity*len(nodePoolSubnetIdsArray), poolId)
-			if err != nil {
-				return fmt.Errorf("Failed to wait for Kubernetes cluster node to be ready: %s\n", err)
-			}
-
-			if isTillerEnabled {
-				//need to wait for tiller pod is running
-				fmt.Printf("Wait for tiller pod is running\n")
-				err = o.waitForTillerComeUp()
-				if err != nil {
-					return fmt.Errorf("Failed to wait for Tiller to be ready: %s\n", err)
-				}
-			}
-
-			err = util.DeleteFile("/tmp/oke_cluster_config.json")
-			if err != nil {
-				return err
-			}
-			err = util.DeleteFile("/tmp/oke_pool_config.json")
-			if err != nil {
-				return err
-			}
-			err = util.DeleteFile("/tmp/oke_pool_labels_config.json")
-			if err != nil {
-				return err
-			}
-			log.Info("Initialising cluster ...\n")
-
-			return o.initAndInstall(OKE)
-		}
+	err = util.DeleteFile("/tmp/oke_cluster_config.json")
+	if err != nil {
+		return err
+	}
+	err = util.DeleteFile("/tmp/oke_pool_config.json")
+	if err != nil {
+		return err
+	}
+	err = util.DeleteFile("/tmp/oke_pool_labels_config.json")
+	if err != nil {
+		return err
+	}
+	log.Info("Initialising cluster ...\n")
+
+	return o.initAndInstall(OKE)
+}
+
+// mergeOKEKubeConfig generates the kube config of the cluster via the oci CLI and merges it into the kube config
+// making it the current context
+func (o *CreateClusterOKEOptions) mergeOKEKubeConfig(clusterId string) error {
+	kubeConfigDir, err := ioutil.TempDir("", "jx-oke-kubeconfig")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(kubeConfigDir)
+	kubeConfigFile := filepath.Join(kubeConfigDir, "config")
+
+	err = o.runCommandVerbose("oci", "ce", "cluster", "create-kubeconfig", "--cluster-id", clusterId, "--file", kubeConfigFile)
+	if err != nil {
+		return err
+	}
+	contexts, err := kube.MergeKubeConfigFile(kubeConfigFile, true)
+	if err != nil {
+		return err
 	}
+	log.Infof("Merged the kubernetes contexts %s into the kube config\n", util.ColorInfo(strings.Join(contexts, ", ")))
 	return nil
 }
 
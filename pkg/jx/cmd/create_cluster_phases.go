@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// ClusterPhaseDependencies installs the binaries needed to create the cluster
+	ClusterPhaseDependencies = "install-dependencies"
+	// ClusterPhaseProvision provisions the infrastructure of the cluster
+	ClusterPhaseProvision = "provision"
+	// ClusterPhasePlatform installs the Jenkins X platform into the cluster
+	ClusterPhasePlatform = "install-platform"
+	// ClusterPhaseVerify verifies the cluster and platform are ready
+	ClusterPhaseVerify = "verify"
+
+	optionPhase = "phase"
+
+	clusterPhaseSucceeded = "Succeeded"
+	clusterPhaseFailed    = "Failed"
+	clusterPhaseSkipped   = "Skipped"
+)
+
+// ClusterPhases the phases of creating a cluster in the order they run
+var ClusterPhases = []string{ClusterPhaseDependencies, ClusterPhaseProvision, ClusterPhasePlatform, ClusterPhaseVerify}
+
+// clusterPhaseResult the outcome and duration of a phase of creating a cluster
+type clusterPhaseResult struct {
+	Name     string
+	Status   string
+	Started  time.Time
+	Duration time.Duration
+}
+
+// clusterPhases tracks the phases of creating a cluster
+type clusterPhases struct {
+	results []*clusterPhaseResult
+	current *clusterPhaseResult
+}
+
+func (p *clusterPhases) start(name string) {
+	p.end(nil)
+	log.Infof("\n%s %s\n", util.ColorInfo("Phase"), util.ColorInfo(name))
+	p.current = &clusterPhaseResult{Name: name, Started: time.Now()}
+	p.results = append(p.results, p.current)
+}
+
+func (p *clusterPhases) end(err error) {
+	if p.current == nil {
+		return
+	}
+	p.current.Duration = time.Since(p.current.Started)
+	p.current.Status = clusterPhaseSucceeded
+	if err != nil {
+		p.current.Status = clusterPhaseFailed
+	}
+	p.current = nil
+}
+
+func (p *clusterPhases) skip(name string) {
+	p.end(nil)
+	p.results = append(p.results, &clusterPhaseResult{Name: name, Status: clusterPhaseSkipped})
+}
+
+// fail marks the last run of the phase as failed
+func (p *clusterPhases) fail(name string) {
+	for i := len(p.results) - 1; i >= 0; i-- {
+		if p.results[i].Name == name {
+			p.results[i].Status = clusterPhaseFailed
+			return
+		}
+	}
+}
+
+// failed returns the name of the phase which failed if any
+func (p *clusterPhases) failed() string {
+	for _, r := range p.results {
+		if r.Status == clusterPhaseFailed {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+func (o *CreateClusterOptions) addClusterPhaseFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVarP(&o.Phases, optionPhase, "", []string{}, "Only runs the given phases of creating the cluster such as the phase which failed. Phases: "+strings.Join(ClusterPhases, ", "))
+}
+
+// phaseEnabled returns true if the phase should run
+func (o *CreateClusterOptions) phaseEnabled(name string) bool {
+	if name == ClusterPhasePlatform && o.SkipInstallation {
+		return false
+	}
+	return len(o.Phases) == 0 || util.StringArrayIndex(o.Phases, name) >= 0
+}
+
+// runClusterPhases creates a cluster in phases then displays the status and duration of each phase. The provision
+// function calls initAndInstall once the cluster exists which runs the install-platform phase
+func (o *CreateClusterOptions) runClusterPhases(installDependencies func() error, provision func() error) error {
+	for _, phase := range o.Phases {
+		if util.StringArrayIndex(ClusterPhases, phase) < 0 {
+			return util.InvalidOption(optionPhase, phase, ClusterPhases)
+		}
+	}
+	err := o.validateClusterTTL()
+	if err != nil {
+		return err
+	}
+	o.phases = &clusterPhases{}
+	err = o.runClusterPhase(ClusterPhaseDependencies, installDependencies)
+	if err == nil {
+		if o.phaseEnabled(ClusterPhaseProvision) {
+			err = o.runClusterPhase(ClusterPhaseProvision, provision)
+		} else {
+			o.phases.skip(ClusterPhaseProvision)
+			err = o.initAndInstall(o.Provider)
+		}
+	}
+	if err == nil {
+		if o.SkipInstallation {
+			o.phases.skip(ClusterPhaseVerify)
+		} else {
+			err = o.runClusterPhase(ClusterPhaseVerify, o.verifyCluster)
+		}
+	}
+	o.phases.end(err)
+	o.renderClusterPhases()
+	failed := o.phases.failed()
+	if failed != "" {
+		log.Infof("Once the problem is fixed you can re-run the failed phase via: %s\n",
+			util.ColorInfo(fmt.Sprintf("%s --%s %s", o.Cmd.CommandPath(), optionPhase, failed)))
+		return errors.Wrapf(err, "phase %s failed", failed)
+	}
+	return err
+}
+
+func (o *CreateClusterOptions) runClusterPhase(name string, fn func() error) error {
+	if !o.phaseEnabled(name) {
+		o.phases.skip(name)
+		return nil
+	}
+	o.phases.start(name)
+	err := fn()
+	if o.phases.current != nil {
+		o.phases.end(err)
+	} else if err != nil && o.phases.failed() == "" {
+		// a step after installing the platform failed
+		o.phases.fail(name)
+	}
+	return err
+}
+
+// startPlatformPhase starts the install-platform phase returning false if the phase should be skipped
+func (o *CreateClusterOptions) startPlatformPhase() bool {
+	if o.phases == nil {
+		return !o.SkipInstallation
+	}
+	if !o.phaseEnabled(ClusterPhasePlatform) {
+		o.phases.skip(ClusterPhasePlatform)
+		return false
+	}
+	o.phases.start(ClusterPhasePlatform)
+	return true
+}
+
+// verifyCluster reports any resources of the platform which are not ready
+func (o *CreateClusterOptions) verifyCluster() error {
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	if o.InstallOptions.Flags.Namespace != "" {
+		ns = o.InstallOptions.Flags.Namespace
+	}
+	notReady, err := kube.NotReadyResources(client, ns)
+	if err != nil {
+		return err
+	}
+	if len(notReady) > 0 {
+		return fmt.Errorf("the following resources in namespace %s are not ready: %s", ns, strings.Join(notReady, ", "))
+	}
+	log.Infof("All the resources in namespace %s are ready\n", util.ColorInfo(ns))
+	return nil
+}
+
+func (o *CreateClusterOptions) renderClusterPhases() {
+	log.Info("\n")
+	table := o.CreateTable()
+	table.AddRow("PHASE", "STATUS", "DURATION")
+	for _, r := range o.phases.results {
+		duration := ""
+		if r.Status != clusterPhaseSkipped {
+			duration = r.Duration.Round(time.Second).String()
+		}
+		table.AddRow(r.Name, r.Status, duration)
+	}
+	table.Render()
+}
+
+// installClusterDependencies installs the missing binaries needed to create the cluster
+func (o *CreateClusterOptions) installClusterDependencies(deps []string) error {
+	err := o.installMissingDependencies(deps)
+	if err != nil {
+		return errors.Wrap(err, "please fix the error or install manually then try again")
+	}
+	return nil
+}
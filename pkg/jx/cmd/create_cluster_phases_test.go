@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCreateClusterOptions(phases ...string) *CreateClusterOptions {
+	o := &CreateClusterOptions{Phases: phases, SkipInstallation: true}
+	o.Out = &bytes.Buffer{}
+	o.Factory = NewFactory()
+	o.Cmd = &cobra.Command{Use: "minikube"}
+	return o
+}
+
+func clusterPhaseStatuses(o *CreateClusterOptions) map[string]string {
+	answer := map[string]string{}
+	for _, r := range o.phases.results {
+		answer[r.Name] = r.Status
+	}
+	return answer
+}
+
+func TestRunClusterPhases(t *testing.T) {
+	t.Parallel()
+	o := newTestCreateClusterOptions()
+	var ran []string
+	err := o.runClusterPhases(func() error {
+		ran = append(ran, ClusterPhaseDependencies)
+		return nil
+	}, func() error {
+		ran = append(ran, ClusterPhaseProvision)
+		return o.initAndInstall(MINIKUBE)
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{ClusterPhaseDependencies, ClusterPhaseProvision}, ran)
+	assert.Equal(t, map[string]string{
+		ClusterPhaseDependencies: clusterPhaseSucceeded,
+		ClusterPhaseProvision:    clusterPhaseSucceeded,
+		ClusterPhasePlatform:     clusterPhaseSkipped,
+		ClusterPhaseVerify:       clusterPhaseSkipped,
+	}, clusterPhaseStatuses(o))
+}
+
+func TestRunClusterPhasesFailedPhase(t *testing.T) {
+	t.Parallel()
+	o := newTestCreateClusterOptions()
+	err := o.runClusterPhases(func() error {
+		return nil
+	}, func() error {
+		return errors.New("quota exceeded")
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "phase provision failed")
+	assert.Equal(t, ClusterPhaseProvision, o.phases.failed())
+}
+
+func TestRunClusterPhasesSelected(t *testing.T) {
+	t.Parallel()
+	o := newTestCreateClusterOptions(ClusterPhaseProvision)
+	provisioned := false
+	err := o.runClusterPhases(func() error {
+		return errors.New("should not install dependencies")
+	}, func() error {
+		provisioned = true
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, provisioned)
+	assert.Equal(t, clusterPhaseSkipped, clusterPhaseStatuses(o)[ClusterPhaseDependencies])
+
+	o = newTestCreateClusterOptions("cheese")
+	err = o.runClusterPhases(nil, nil)
+	assert.Error(t, err)
+}
+
+func TestRunClusterPhasesTTLUnsupported(t *testing.T) {
+	t.Parallel()
+	o := newTestCreateClusterOptions()
+	o.Provider = MINIKUBE
+	o.TTL = time.Hour
+	err := o.runClusterPhases(func() error {
+		return errors.New("should not install dependencies")
+	}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "ttl")
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionTTL = "ttl"
+
+	// clusterTeardownNamespace the namespace of the teardown job which is not removed by jx uninstall
+	clusterTeardownNamespace = "kube-system"
+)
+
+// clusterTeardownProviders the providers which support tearing down a cluster after its time to live
+var clusterTeardownProviders = []string{GKE, EKS, AKS}
+
+func (o *CreateClusterOptions) addClusterTeardownFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVarP(&o.TTL, optionTTL, "", 0, "The time to live of the cluster such as 8h after which a job in the cluster deletes the cluster and its cloud resources. Supported on: gke, eks, aks")
+}
+
+// validateClusterTTL returns an error if the provider cannot tear down the cluster after its time to live
+func (o *CreateClusterOptions) validateClusterTTL() error {
+	if o.TTL < 0 {
+		return util.InvalidOptionf(optionTTL, o.TTL.String(), "the time to live must be positive")
+	}
+	if o.TTL > 0 && util.StringArrayIndex(clusterTeardownProviders, o.Provider) < 0 {
+		return util.InvalidOptionf(optionTTL, o.TTL.String(), "tearing down %s clusters is not supported. Supported providers: %v", o.Provider, clusterTeardownProviders)
+	}
+	return nil
+}
+
+// scheduleClusterTeardown registers the job in the new cluster which deletes it once the time to live has passed.
+// The job is registered as soon as the cluster exists so that the cluster is still removed if installing the
+// platform fails
+func (o *CreateClusterOptions) scheduleClusterTeardown(teardown *kube.ClusterTeardown) error {
+	if o.TTL <= 0 {
+		return nil
+	}
+	teardown.Expires = time.Now().Add(o.TTL)
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, err = kube.CreateClusterTeardown(client, clusterTeardownNamespace, teardown)
+	if err != nil {
+		return err
+	}
+	log.Infof("The cluster will be deleted after %s at %s by the CronJob %s in namespace %s\n", util.ColorInfo(o.TTL.String()),
+		util.ColorInfo(teardown.Expires.Format(time.RFC1123)), util.ColorInfo(kube.ClusterTeardownName), clusterTeardownNamespace)
+	log.Infof("To keep the cluster run: %s\n", util.ColorInfo("kubectl delete cronjob -n "+clusterTeardownNamespace+" "+kube.ClusterTeardownName))
+	return nil
+}
+
+// awsTeardownSecrets returns the AWS credentials from the environment for the teardown job
+func awsTeardownSecrets(region string) map[string]string {
+	answer := map[string]string{}
+	for _, name := range []string{"AWS_ACCESS_KEY_ID", "AWS_SECRET_ACCESS_KEY", "AWS_SESSION_TOKEN"} {
+		value := os.Getenv(name)
+		if value != "" {
+			answer[name] = value
+		}
+	}
+	if region != "" {
+		answer["AWS_DEFAULT_REGION"] = region
+	}
+	return answer
+}
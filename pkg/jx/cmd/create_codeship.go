@@ -164,7 +164,7 @@ func (o *CreateCodeshipOptions) Run() error {
 			Help:    "This will not be stored anywhere",
 		}
 
-		err := survey.AskOne(prompt, &o.Flags.CodeshipUsername, nil)
+		err := util.AskOne(prompt, &o.Flags.CodeshipUsername, nil)
 		if err != nil {
 			return err
 		}
@@ -176,7 +176,7 @@ func (o *CreateCodeshipOptions) Run() error {
 			Help:    "This will not be stored anywhere",
 		}
 
-		err := survey.AskOne(prompt, &o.Flags.CodeshipPassword, nil)
+		err := util.AskOne(prompt, &o.Flags.CodeshipPassword, nil)
 		if err != nil {
 			return err
 		}
@@ -188,7 +188,7 @@ func (o *CreateCodeshipOptions) Run() error {
 			Help:    "This will not be stored anywhere",
 		}
 
-		err := survey.AskOne(prompt, &o.Flags.CodeshipOrganisation, nil)
+		err := util.AskOne(prompt, &o.Flags.CodeshipOrganisation, nil)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/dependencies"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	dependencyAliases = []string{"dependencies", "deps"}
+
+	createDependencyLong = templates.LongDesc(`
+		Registers that a repository depends on a library in the dependency graph of your team.
+
+		When the library is released 'jx step update dependencies' creates a Pull Request in each dependent repository
+		bumping the library to the released version.
+`)
+
+	createDependencyExample = templates.Examples(`
+		# Register that the repository in the current directory depends on a maven library
+		jx create dependency --kind maven --library io.acme:core
+
+		# Register that a repository depends on an npm package
+		jx create dependency --kind npm --library @acme/ui --repo https://github.com/acme/web.git
+	`)
+)
+
+// CreateDependencyOptions the options for the create dependency command
+type CreateDependencyOptions struct {
+	CreateOptions
+
+	Library    string
+	Kind       string
+	Repository string
+	Branch     string
+}
+
+// NewCmdCreateDependency creates a command object for the "create dependency" command
+func NewCmdCreateDependency(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateDependencyOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "dependency",
+		Short:   "Registers that a repository depends on a library",
+		Aliases: dependencyAliases,
+		Long:    createDependencyLong,
+		Example: createDependencyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Library, "library", "l", "", "The name of the library such as 'groupId:artifactId' for maven and gradle, the npm package, the go module or the chart")
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", fmt.Sprintf("The kind of the library. One of: %s", strings.Join(dependencies.Kinds(), ", ")))
+	cmd.Flags().StringVarP(&options.Repository, "repo", "r", "", "The git URL of the repository which depends on the library. Defaults to the repository in the current directory")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "", "", "The branch the Pull Requests updating the library are created against. Defaults to master")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateDependencyOptions) Run() error {
+	if o.Library == "" {
+		return util.MissingOption("library")
+	}
+	if o.Kind == "" {
+		return util.MissingOption("kind")
+	}
+	err := dependencies.ValidateKind(o.Kind)
+	if err != nil {
+		return err
+	}
+	repository, err := o.dependencyRepositoryURL(o.Repository)
+	if err != nil {
+		return err
+	}
+	dependency := dependencies.Dependency{
+		Library:    o.Library,
+		Kind:       dependencies.Kind(o.Kind),
+		Repository: repository,
+		Branch:     o.Branch,
+	}
+	return o.modifyDependencyGraph(func(graph *dependencies.Graph) error {
+		if !graph.Add(dependency) {
+			log.Infof("%s already depends on %s\n", util.ColorInfo(repository), util.ColorInfo(o.Library))
+			return nil
+		}
+		log.Infof("Registered that %s depends on %s\n", util.ColorInfo(repository), util.ColorInfo(o.Library))
+		return nil
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"io/ioutil"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"regexp"
 	"strconv"
@@ -32,6 +33,8 @@ const (
 	optionLabel      = "label"
 	optionRequestCpu = "request-cpu"
 	devPodGoPath     = "/workspace"
+
+	defaultDevPodTTL = 8 * time.Hour
 )
 
 var (
@@ -48,6 +51,12 @@ var (
 
 		# creates a new Maven DevPod 
 		jx create devpod -l maven
+
+		# creates a DevPod synchronising the current directory and forwarding its ports to localhost
+		jx create devpod --sync --port-forward -p 8080
+
+		# creates a DevPod which is garbage collected via 'jx gc devpods' after being idle for 2 hours
+		jx create devpod --ttl 2h
 	`)
 )
 
@@ -62,20 +71,22 @@ type CreateDevPodResults struct {
 type CreateDevPodOptions struct {
 	CreateOptions
 
-	Label      string
-	Suffix     string
-	WorkingDir string
-	RequestCpu string
-	Dir        string
-	Reuse      bool
-	Sync       bool
-	Ports      []int
-	AutoExpose bool
-	Persist    bool
-	ImportUrl  string
-	Import     bool
-	ShellCmd   string
-	Username   string
+	Label       string
+	Suffix      string
+	WorkingDir  string
+	RequestCpu  string
+	Dir         string
+	Reuse       bool
+	Sync        bool
+	Ports       []int
+	AutoExpose  bool
+	Persist     bool
+	ImportUrl   string
+	Import      bool
+	ShellCmd    string
+	Username    string
+	PortForward bool
+	TTL         time.Duration
 
 	Results CreateDevPodResults
 }
@@ -119,6 +130,8 @@ func NewCmdCreateDevPod(f Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 	cmd.Flags().BoolVarP(&options.Import, "import", "", true, "Detect if there is a Git repository in the current directory and attempt to clone it into the DevPod. Ignored if used with --sync")
 	cmd.Flags().StringVarP(&options.ShellCmd, "shell", "", "", "The name of the shell to invoke in the DevPod. If nothing is specified it will use 'bash'")
 	cmd.Flags().StringVarP(&options.Username, "username", "", "", "The username to create the DevPod. If not specified defaults to the current operating system user or $USER'")
+	cmd.Flags().BoolVarP(&options.PortForward, "port-forward", "", false, "Forwards the container ports of the DevPod to the same ports on localhost while the shell is open")
+	cmd.Flags().DurationVarP(&options.TTL, "ttl", "", defaultDevPodTTL, "The duration the DevPod can be idle before it is deleted by 'jx gc devpods'. Use 0 to keep the DevPod until it is deleted")
 	options.addCommonFlags(cmd)
 	return cmd
 }
@@ -331,6 +344,9 @@ func (o *CreateDevPodOptions) Run() error {
 		}
 	}
 	pod.Annotations[kube.AnnotationWorkingDir] = workingDir
+	if o.TTL > 0 {
+		pod.Annotations[kube.AnnotationDevPodTTL] = o.TTL.String()
+	}
 	if o.Sync {
 		pod.Annotations[kube.AnnotationLocalDir] = dir
 	}
@@ -549,6 +565,7 @@ func (o *CreateDevPodOptions) Run() error {
 	}
 
 	log.Infof("Pod %s is now ready!\n", util.ColorInfo(pod.Name))
+	o.markDevPodActive(client, ns, pod.Name)
 	log.Infof("You can open other shells into this DevPod via %s\n", util.ColorInfo("jx create devpod"))
 
 	if !o.Sync {
@@ -644,7 +661,58 @@ func (o *CreateDevPodOptions) Run() error {
 		Username:      userName,
 	}
 	options.Args = []string{}
-	return options.Run()
+
+	if o.PortForward {
+		stop, err := o.portForwardDevPod(ns, pod)
+		if err != nil {
+			return err
+		}
+		defer stop()
+	}
+	err = options.Run()
+	o.markDevPodActive(client, ns, pod.Name)
+	return err
+}
+
+// markDevPodActive records the DevPod is in use so that it is not garbage collected before its TTL expires
+func (o *CreateDevPodOptions) markDevPodActive(client kubernetes.Interface, ns string, name string) {
+	err := kube.MarkDevPodActive(client, ns, name, time.Now())
+	if err != nil {
+		log.Warnf("Failed to mark DevPod %s as active: %s\n", name, err)
+	}
+}
+
+// portForwardDevPod forwards the container ports of the DevPod to localhost. The returned function stops forwarding
+func (o *CreateDevPodOptions) portForwardDevPod(ns string, pod *corev1.Pod) (func(), error) {
+	stop := func() {}
+	args := []string{"port-forward", "--namespace", ns, "pod/" + pod.Name}
+	ports := []string{}
+	if len(pod.Spec.Containers) > 0 {
+		for _, port := range pod.Spec.Containers[0].Ports {
+			ports = append(ports, strconv.Itoa(int(port.ContainerPort)))
+		}
+	}
+	if len(ports) == 0 {
+		log.Warnf("DevPod %s has no container ports to forward. Specify them via --ports\n", pod.Name)
+		return stop, nil
+	}
+	cmd := exec.Command("kubectl", append(args, ports...)...)
+	if o.Verbose {
+		cmd.Stdout = o.Out
+		cmd.Stderr = o.Err
+	}
+	err := cmd.Start()
+	if err != nil {
+		return stop, errors.Wrapf(err, "failed to forward the ports of DevPod %s", pod.Name)
+	}
+	log.Infof("Forwarding ports %s of DevPod %s to localhost\n", util.ColorInfo(strings.Join(ports, ", ")), util.ColorInfo(pod.Name))
+	stop = func() {
+		if cmd.Process != nil {
+			cmd.Process.Kill()
+			cmd.Wait()
+		}
+	}
+	return stop, nil
 }
 
 func (o *CreateDevPodOptions) getOrCreateEditEnvironment() (*v1.Environment, error) {
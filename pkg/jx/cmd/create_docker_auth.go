@@ -92,14 +92,14 @@ func (o *CreateDockerAuthOptions) Run() error {
 		prompt := &survey.Password{
 			Message: "Please provide secret for the host: " + o.Host + "  and user: " + o.User,
 		}
-		survey.AskOne(prompt, &secret, nil)
+		util.AskOne(prompt, &secret, nil)
 	}
 	email := o.Email
 	if email == "" {
 		prompt := &survey.Input{
 			Message: "Please provide email ID for the host: " + o.Host + "  and user: " + o.User,
 		}
-		survey.AskOne(prompt, &secret, nil)
+		util.AskOne(prompt, &secret, nil)
 	}
 	kubeClient, currentNs, err := o.KubeClient()
 	if err != nil {
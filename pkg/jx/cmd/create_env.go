@@ -36,6 +36,9 @@ var (
 
 		# Creates a new Environment passing in the required data on the command line
 		jx create env -n prod -l Production --no-gitops --namespace my-prod
+
+		# Creates a new Environment whose git repository describes its releases in a helmfile.yaml
+		jx create env -n prod -l Production --namespace my-prod --helmfile
 	`)
 )
 
@@ -53,6 +56,7 @@ type CreateEnvOptions struct {
 	GitRepositoryOptions   gits.GitRepositoryOptions
 	Prefix                 string
 	BranchPattern          string
+	Helmfile               bool
 }
 
 // NewCmdCreateEnv creates a command object for the "create" command
@@ -102,6 +106,8 @@ func NewCmdCreateEnv(f Factory, out io.Writer, errOut io.Writer) *cobra.Command
 	cmd.Flags().BoolVarP(&options.NoGitOps, "no-gitops", "x", false, "Disables the use of GitOps on the environment so that promotion is implemented by directly modifying the resources via helm instead of using a git repository")
 	cmd.Flags().BoolVarP(&options.Prow, "prow", "", false, "Install and use Prow for environment promotion")
 
+	cmd.Flags().BoolVarP(&options.Helmfile, "helmfile", "", false, "Describes the releases of the Environment in a 'helmfile.yaml' applied via helmfile rather than as the dependencies of a chart when creating its git repository")
+
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
 	options.HelmValuesConfig.AddExposeControllerValues(cmd, false)
 
@@ -155,6 +161,9 @@ func (o *CreateEnvOptions) Run() error {
 
 	env := v1.Environment{}
 	o.Options.Spec.PromotionStrategy = v1.PromotionStrategyType(o.PromotionStrategy)
+	if o.Helmfile {
+		o.Options.Spec.Source.Layout = v1.EnvironmentRepositoryLayoutHelmfile
+	}
 	gitProvider, err := kube.CreateEnvironmentSurvey(o.Out, o.BatchMode, authConfigSvc, devEnv, &env, &o.Options, o.ForkEnvironmentGitRepo, ns,
 		jxClient, kubeClient, envDir, &o.GitRepositoryOptions, o.HelmValuesConfig, o.Prefix, o.Git())
 	if err != nil {
@@ -171,6 +180,10 @@ func (o *CreateEnvOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	err = o.ensureTillerCanManageNamespace(env.Spec.Namespace)
+	if err != nil {
+		return err
+	}
 	gitURL := env.Spec.Source.URL
 	gitInfo, err := gits.ParseGitURL(gitURL)
 	if err != nil {
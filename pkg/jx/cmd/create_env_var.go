@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/user"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createEnvVarLong = templates.LongDesc(`
+		Creates or updates environment variables of an app in an environment.
+
+		Plain values are stored in a ConfigMap and secret values in a Secret which are referenced by the 'envFrom'
+		values of the app chart. For GitOps environments a Pull Request is created which changes the
+		'config/<app>.yaml' file and the values of the environment chart. Secret values are encrypted with a key
+		stored in the development namespace so that they are never stored in plain text in git.
+`)
+
+	createEnvVarExample = templates.Examples(`
+		# set an environment variable of an app in staging
+		jx create env-var LOG_LEVEL=debug --app myapp --env staging
+
+		# set a secret environment variable of an app in production prompting for its value
+		jx create env-var DB_PASSWORD --secret --app myapp --env production
+	`)
+)
+
+// CreateEnvVarOptions the options for the create env-var command
+type CreateEnvVarOptions struct {
+	CreateOptions
+
+	App         string
+	Environment string
+	Secret      bool
+}
+
+// NewCmdCreateEnvVar creates a command object for the "create env-var" command
+func NewCmdCreateEnvVar(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateEnvVarOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "env-var NAME=value...",
+		Short:   "Creates or updates environment variables of an app in an environment",
+		Aliases: []string{"env-vars", "envvar", "envvars"},
+		Long:    createEnvVarLong,
+		Example: createEnvVarExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The name of the app")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The name of the environment")
+	cmd.Flags().BoolVarP(&options.Secret, "secret", "s", false, "Stores the values encrypted in a Secret rather than a ConfigMap")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateEnvVarOptions) Run() error {
+	if o.App == "" {
+		return util.MissingOption("app")
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+	vars, err := o.parseEnvVars()
+	if err != nil {
+		return err
+	}
+	names := []string{}
+	for k := range vars {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	branchName := "env-var-" + o.App
+	title := fmt.Sprintf("Set environment variables %s of app %s", strings.Join(names, ", "), o.App)
+	message := "The command `jx create env-var` was run by " + u.Username + " and it generated this Pull Request"
+
+	fn := func(config *appconfig.AppConfig, key []byte) error {
+		for _, name := range names {
+			err := config.Set(name, vars[name], o.Secret, key)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	err = o.modifyAppConfig(o.Environment, o.App, branchName, title, message, fn)
+	if err != nil {
+		return err
+	}
+	log.Infof("Set environment variables %s of app %s in environment %s\n", util.ColorInfo(strings.Join(names, ", ")), util.ColorInfo(o.App), util.ColorInfo(o.Environment))
+	return nil
+}
+
+func (o *CreateEnvVarOptions) parseEnvVars() (map[string]string, error) {
+	if len(o.Args) == 0 {
+		return nil, fmt.Errorf("Missing argument for the environment variables of the form NAME=value")
+	}
+	args := []string{}
+	for _, arg := range o.Args {
+		// lets prompt for secret values so they don't end up in the shell history
+		if !strings.Contains(arg, "=") && o.Secret && !o.BatchMode {
+			err := appconfig.ValidateEnvVarName(arg)
+			if err != nil {
+				return nil, err
+			}
+			value, err := util.PickPassword("Value of " + arg + ":")
+			if err != nil {
+				return nil, err
+			}
+			arg = arg + "=" + value
+		}
+		args = append(args, arg)
+	}
+	return appconfig.ParseEnvVars(args)
+}
@@ -0,0 +1,61 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCreateAndDeleteEnvVar(t *testing.T) {
+	t.Parallel()
+	staging := kube.NewPermanentEnvironment("staging")
+	staging.Spec.Namespace = "jx-staging"
+
+	createOptions := &cmd.CreateEnvVarOptions{
+		App:         "myapp",
+		Environment: "staging",
+	}
+	cmd.ConfigureTestOptionsWithResources(&createOptions.CommonOptions, nil, []runtime.Object{staging}, gits.NewGitCLI(), helm.NewHelmCLI("helm", helm.V2, ""))
+	kubeClient := createOptions.KubeClientCached
+
+	createOptions.Args = []string{"LOG_LEVEL=debug", "FEATURE_X=true"}
+	require.NoError(t, createOptions.Run())
+	createOptions.Secret = true
+	createOptions.Args = []string{"DB_PASSWORD=s3cr3t"}
+	require.NoError(t, createOptions.Run())
+
+	cm, err := kubeClient.CoreV1().ConfigMaps("jx-staging").Get(appconfig.ConfigMapName("myapp"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"LOG_LEVEL": "debug", "FEATURE_X": "true"}, cm.Data)
+	secret, err := kubeClient.CoreV1().Secrets("jx-staging").Get(appconfig.SecretName("myapp"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", string(secret.Data["DB_PASSWORD"]))
+
+	createOptions.Args = []string{"DB_PASSWORD"}
+	assert.Error(t, createOptions.Run(), "secret values cannot be prompted for in batch mode")
+
+	deleteOptions := &cmd.DeleteEnvVarOptions{
+		CommonOptions: createOptions.CommonOptions,
+		App:           "myapp",
+		Environment:   "staging",
+	}
+	deleteOptions.Args = []string{"DB_PASSWORD", "LOG_LEVEL"}
+	require.NoError(t, deleteOptions.Run())
+
+	cm, err = kubeClient.CoreV1().ConfigMaps("jx-staging").Get(appconfig.ConfigMapName("myapp"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"FEATURE_X": "true"}, cm.Data)
+	_, err = kubeClient.CoreV1().Secrets("jx-staging").Get(appconfig.SecretName("myapp"), metav1.GetOptions{})
+	assert.Error(t, err)
+
+	deleteOptions.Args = []string{"UNKNOWN"}
+	assert.Error(t, deleteOptions.Run())
+}
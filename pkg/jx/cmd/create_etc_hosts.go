@@ -5,8 +5,6 @@ import (
 
 	"fmt"
 	"io/ioutil"
-	"net/url"
-	"strings"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -21,21 +19,36 @@ const (
 
 var (
 	create_etc_hosts_long = templates.LongDesc(`
-		Creates /etc/hosts entries for all current exposed services
+		Creates /etc/hosts entries for the exposed services of the team so that their URLs resolve on a local cluster
+		such as minikube or kind.
+
+		The entries are added to a section of the file managed by jx which is replaced each time this command runs,
+		so new preview environments can be added by running it again. You are asked to confirm the changes before
+		the file is modified unless batch mode is enabled. Editing /etc/hosts usually requires sudo.
+
+		If you would rather not edit /etc/hosts, see 'jx dns-proxy' which resolves all host names of the domain.
 `)
 
 	create_etc_hosts_example = templates.Examples(`
-		# Creates /etc/hosts entries for all current exposed services
+		# Creates /etc/hosts entries for all the exposed services of the team including previews
 		sudo jx create etc-hosts
+
+		# Creates /etc/hosts entries for the exposed services of the current namespace only
+		sudo jx create etc-hosts --current-namespace
+
+		# Removes the entries added by jx
+		sudo jx create etc-hosts --remove
 	`)
 )
 
-// CreateEtcHostsOptions the options for the create spring command
+// CreateEtcHostsOptions the options for the create etc-hosts command
 type CreateEtcHostsOptions struct {
 	CreateOptions
 
-	Name string
-	IP   string
+	Name             string
+	IP               string
+	CurrentNamespace bool
+	Remove           bool
 }
 
 // NewCmdCreateEtcHosts creates a command object for the "create" command
@@ -51,8 +64,8 @@ func NewCmdCreateEtcHosts(f Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	}
 
 	cmd := &cobra.Command{
-		Use:     "etc-hosts kind [url]",
-		Short:   "Creates a new git server URL",
+		Use:     "etc-hosts",
+		Short:   "Creates /etc/hosts entries for the exposed services of the team",
 		Aliases: []string{"etchosts", "etc_hosts"},
 		Long:    create_etc_hosts_long,
 		Example: create_etc_hosts_example,
@@ -65,7 +78,11 @@ func NewCmdCreateEtcHosts(f Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	}
 
 	cmd.Flags().StringVarP(&options.Name, optionName, "n", "/etc/hosts", "The etc hosts file to edit")
-	cmd.Flags().StringVarP(&options.IP, "ip", "i", "", "The IP address of the node to point the host entries to")
+	cmd.Flags().StringVarP(&options.IP, "ip", "i", "", "The IP address of the node to point the host entries to. Defaults to the IP of minikube or of the first node")
+	cmd.Flags().BoolVarP(&options.CurrentNamespace, "current-namespace", "c", false, "Only adds the exposed services of the current namespace rather than of all environments")
+	cmd.Flags().BoolVarP(&options.Remove, "remove", "r", false, "Removes the entries added by jx")
+
+	options.addCommonFlags(cmd)
 	return cmd
 }
 
@@ -73,26 +90,7 @@ func NewCmdCreateEtcHosts(f Factory, out io.Writer, errOut io.Writer) *cobra.Com
 func (o *CreateEtcHostsOptions) Run() error {
 	name := o.Name
 	if name == "" {
-		return util.MissingOption(name)
-	}
-	if o.IP == "" {
-		// lets find a node ip
-		ip, err := o.getCommandOutput("", "minikube", "ip")
-		if err != nil {
-			return err
-		}
-		o.IP = ip
-	}
-	if o.IP == "" {
-		return fmt.Errorf("Could not discover a node IP address")
-	}
-	client, ns, err := o.KubeClient()
-	if err != nil {
-		return err
-	}
-	urls, err := kube.FindServiceURLs(client, ns)
-	if err != nil {
-		return err
+		return util.MissingOption(optionName)
 	}
 	exists, err := util.FileExists(name)
 	if err != nil {
@@ -106,53 +104,66 @@ func (o *CreateEtcHostsOptions) Run() error {
 		return err
 	}
 	text := string(data)
-	lines := strings.Split(text, "\n")
-	idx, ipLine := o.findIPLine(&lines)
-	for _, u := range urls {
-		ipLine = o.addUrl(u, ipLine)
-	}
-	lines[idx] = ipLine
-	newText := strings.Join(lines, "\n")
-	if newText != text {
-		err = ioutil.WriteFile(name, []byte(newText), util.DefaultWritePermissions)
+
+	newText := util.RemoveHostsEntries(text)
+	message := fmt.Sprintf("Remove the entries added by jx from %s?", name)
+	if !o.Remove {
+		hosts, err := o.hosts()
 		if err != nil {
 			return err
 		}
-		log.Infof("Updated file %s\n", util.ColorInfo(name))
+		if len(hosts) == 0 {
+			log.Infof("No exposed services found which need an entry in %s\n", name)
+			return nil
+		}
+		if o.IP == "" {
+			o.IP, err = o.localClusterIP()
+			if err != nil {
+				return err
+			}
+		}
+		newText = util.UpdateHostsEntries(text, o.IP, hosts)
+		log.Infof("The following host names will resolve to %s:\n", util.ColorInfo(o.IP))
+		for _, host := range hosts {
+			log.Infof("  %s\n", host)
+		}
+		message = fmt.Sprintf("Update the entries added by jx in %s?", name)
 	}
-	return nil
-}
-
-func (o *CreateEtcHostsOptions) addUrl(serviceUrl kube.ServiceURL, ipLine string) string {
-	text := serviceUrl.URL
-	u, err := url.Parse(text)
-	if err != nil {
-		log.Warnf("Ignored invalid URL %s %s", text, err)
-		return ipLine
+	if newText == text {
+		log.Infof("File %s is already up to date\n", util.ColorInfo(name))
+		return nil
 	}
-	host := u.Host
-	fields := strings.Fields(ipLine)
-	for i := 1; i < len(fields); i++ {
-		if fields[i] == host {
-			return ipLine
-		}
+	if !o.BatchMode && !util.Confirm(message, true, "jx only changes the section of the file between its BEGIN and END comments") {
+		return nil
 	}
-	if !strings.HasSuffix(ipLine, " ") {
-		ipLine += " "
+	err = ioutil.WriteFile(name, []byte(newText), util.DefaultWritePermissions)
+	if err != nil {
+		return err
 	}
-	return ipLine + host
+	log.Infof("Updated file %s\n", util.ColorInfo(name))
+	return nil
 }
 
-func (o *CreateEtcHostsOptions) findIPLine(lines *[]string) (int, string) {
-	prefix := o.IP + " "
-	for i, line := range *lines {
-		if strings.HasPrefix(line, prefix) {
-			return i, line
+// hosts returns the host names of the exposed services which need an entry in the hosts file
+func (o *CreateEtcHostsOptions) hosts() ([]string, error) {
+	if !o.CurrentNamespace {
+		urls, err := o.localServiceURLs()
+		if err != nil {
+			return nil, err
 		}
+		return util.HostsFromURLs(urls), nil
 	}
-
-	idx := len(*lines) + 2
-	*lines = append(*lines, "", "# jx added service entries", prefix)
-	return idx, prefix
-
+	client, ns, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	serviceURLs, err := kube.FindServiceURLs(client, ns)
+	if err != nil {
+		return nil, err
+	}
+	urls := []string{}
+	for _, u := range serviceURLs {
+		urls = append(urls, u.URL)
+	}
+	return util.HostsFromURLs(urls), nil
 }
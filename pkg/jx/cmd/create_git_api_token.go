@@ -40,6 +40,9 @@ var (
  		# using browser automation to login to the git server
 		# with the username an password to find the API Token
 		jx create git token -n local -p somePassword someUserName	
+
+		# Add a new API Token for a user reading the token from stdin such as on a headless CI machine
+		echo $GIT_TOKEN | jx create git token -n local --token-file - someUserName
 	`)
 )
 
@@ -51,6 +54,7 @@ type CreateGitTokenOptions struct {
 	Username    string
 	Password    string
 	ApiToken    string
+	TokenFile   string
 	Timeout     string
 }
 
@@ -82,6 +86,7 @@ func NewCmdCreateGitToken(f Factory, out io.Writer, errOut io.Writer) *cobra.Com
 	options.addCommonFlags(cmd)
 	options.ServerFlags.addGitServerFlags(cmd)
 	cmd.Flags().StringVarP(&options.ApiToken, "api-token", "t", "", "The API Token for the user")
+	addTokenFileFlag(cmd, &options.TokenFile)
 	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The User password to try automatically create a new API Token")
 	cmd.Flags().StringVarP(&options.Timeout, "timeout", "", "", "The timeout if using browser automation to generate the API token (by passing username and password)")
 
@@ -97,6 +102,13 @@ func (o *CreateGitTokenOptions) Run() error {
 	if len(args) > 1 {
 		o.ApiToken = args[1]
 	}
+	if o.ApiToken == "" {
+		token, err := o.readTokenFile(o.TokenFile)
+		if err != nil {
+			return err
+		}
+		o.ApiToken = token
+	}
 	authConfigSvc, err := o.CreateGitAuthConfigService()
 	if err != nil {
 		return err
@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"path/filepath"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -22,6 +23,12 @@ var (
 		# Add a new git server with a name
 		jx create git server bitbucket http://bitbucket.org -n MyBitBucket 
 
+		# Add a GitHub Enterprise server whose certificate is issued by a private certificate authority
+		jx create git server github https://github.acme.com --ca-file acme-ca.pem
+
+		# Add a GitHub Enterprise server whose API is served from a separate host
+		jx create git server github https://github.acme.com --api-url https://api.github.acme.com/
+
 		For more documentation see: [https://jenkins-x.io/developing/git/](https://jenkins-x.io/developing/git/)
 
 	`)
@@ -35,7 +42,9 @@ var (
 type CreateGitServerOptions struct {
 	CreateOptions
 
-	Name string
+	Name   string
+	ApiURL string
+	CAFile string
 }
 
 // NewCmdCreateGitServer creates a command object for the "create" command
@@ -65,6 +74,8 @@ func NewCmdCreateGitServer(f Factory, out io.Writer, errOut io.Writer) *cobra.Co
 	}
 
 	cmd.Flags().StringVarP(&options.Name, "name", "n", "", "The name for the git server being created")
+	cmd.Flags().StringVarP(&options.ApiURL, "api-url", "", "", "The base URL of the REST API if it is not at the default location such as /api/v3 for GitHub Enterprise")
+	cmd.Flags().StringVarP(&options.CAFile, "ca-file", "", "", "The PEM file of the certificate authorities which issued the TLS certificate of the git server")
 	return cmd
 }
 
@@ -101,8 +112,24 @@ func (o *CreateGitServerOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	if o.CAFile != "" {
+		o.CAFile, err = filepath.Abs(o.CAFile)
+		if err != nil {
+			return err
+		}
+		_, err = util.LoadCertPool(o.CAFile)
+		if err != nil {
+			return err
+		}
+	}
 	config := authConfigSvc.Config()
-	config.GetOrCreateServerName(gitUrl, name, kind)
+	server := config.GetOrCreateServerName(gitUrl, name, kind)
+	if o.ApiURL != "" {
+		server.ApiURL = o.ApiURL
+	}
+	if o.CAFile != "" {
+		server.CAFile = o.CAFile
+	}
 	config.CurrentServer = gitUrl
 	err = authConfigSvc.SaveConfig()
 	if err != nil {
@@ -73,7 +73,7 @@ func (options *CreateGkeServiceAccountOptions) addFlags(cmd *cobra.Command) {
 // Run implements this command
 func (o *CreateGkeServiceAccountOptions) Run() error {
 	if !o.Flags.SkipLogin {
-		err := o.runCommandVerbose("gcloud", "auth", "login", "--brief")
+		err := o.runCommandVerbose("gcloud", o.gcloudLoginArgs()...)
 		if err != nil {
 			return err
 		}
@@ -84,7 +84,7 @@ func (o *CreateGkeServiceAccountOptions) Run() error {
 			Message: "Name for the service account",
 		}
 
-		err := survey.AskOne(prompt, &o.Flags.Name, func(val interface{}) error {
+		err := util.AskOne(prompt, &o.Flags.Name, func(val interface{}) error {
 			// since we are validating an Input, the assertion will always succeed
 			if str, ok := val.(string); !ok || len(str) < 6 {
 				return errors.New("Service Account name must be longer than 5 characters")
@@ -130,7 +130,7 @@ func (o *CreateGkeServiceAccountOptions) getGoogleProjectId() (string, error) {
 			Default: true,
 		}
 		flag := true
-		err = survey.AskOne(confirm, &flag, nil)
+		err = util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return "", err
 		}
@@ -151,7 +151,7 @@ func (o *CreateGkeServiceAccountOptions) getGoogleProjectId() (string, error) {
 			Help:    "Select a Google Project to create the cluster in",
 		}
 
-		err := survey.AskOne(prompts, &projectId, nil)
+		err := util.AskOne(prompts, &projectId, nil)
 		if err != nil {
 			return "", err
 		}
@@ -35,6 +35,12 @@ var (
  		# using browser automation to login to the git server
 		# with the username an password to find the API Token
 		jx create jenkins token -p somePassword someUserName	
+
+		# Add a new API Token for the admin user generated via the Jenkins script console
+		jx create jenkins token -p somePassword admin
+
+		# Rotate the API Token of the admin user revoking any previous tokens generated by jx
+		jx create jenkins token --rotate admin
 	`)
 )
 
@@ -48,6 +54,7 @@ type CreateJenkinsUserOptions struct {
 	ApiToken    string
 	Timeout     string
 	UseBrowser  bool
+	Rotate      bool
 }
 
 // NewCmdCreateJenkinsUser creates a command
@@ -81,6 +88,7 @@ func NewCmdCreateJenkinsUser(f Factory, out io.Writer, errOut io.Writer) *cobra.
 	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The User password to try automatically create a new API Token")
 	cmd.Flags().StringVarP(&options.Timeout, "timeout", "", "", "The timeout if using browser automation to generate the API token (by passing username and password)")
 	cmd.Flags().BoolVarP(&options.UseBrowser, "browser", "", false, "Use a Chrome browser to automatically find the API token if the user and password are known")
+	cmd.Flags().BoolVarP(&options.Rotate, "rotate", "", false, "Generate a new API token via the Jenkins script console and revoke any previous API tokens generated by jx")
 
 	return cmd
 }
@@ -138,6 +146,15 @@ func (o *CreateJenkinsUserOptions) Run() error {
 	if o.Verbose {
 		log.Infof("using url %s\n", tokenUrl)
 	}
+	if o.Rotate || (userAuth.IsInvalid() && o.Password != "" && !o.UseBrowser) {
+		err = o.generateAPIToken(server.URL, userAuth)
+		if err != nil {
+			if o.Rotate {
+				return err
+			}
+			log.Warnf("unable to generate an API token via the script console of %s: %s\n", server.URL, err)
+		}
+	}
 	if userAuth.IsInvalid() && o.Password != "" && o.UseBrowser {
 		err := o.tryFindAPITokenFromBrowser(tokenUrl, userAuth)
 		if err != nil {
@@ -184,6 +201,31 @@ func (o *CreateJenkinsUserOptions) Run() error {
 	return nil
 }
 
+// generateAPIToken generates a new API token for the user via the Jenkins script console, authenticating with
+// the password if one is specified otherwise the current credentials of the user
+func (o *CreateJenkinsUserOptions) generateAPIToken(jenkinsURL string, userAuth *auth.UserAuth) error {
+	var authenticator jenkins.Authenticator
+	if o.Password != "" {
+		authenticator = &jenkins.BasicAuthenticator{Username: userAuth.Username, Token: o.Password}
+	} else if !userAuth.IsInvalid() || jenkins.IsOIDCConfigured() {
+		authenticator = jenkins.CreateAuthenticator(*userAuth)
+	} else {
+		return fmt.Errorf("no password or API token for user %s to authenticate with Jenkins", userAuth.Username)
+	}
+	var token string
+	var err error
+	if o.Rotate {
+		token, err = jenkins.RotateAPIToken(jenkinsURL, authenticator, userAuth.Username)
+	} else {
+		token, err = jenkins.GenerateAPIToken(jenkinsURL, authenticator, userAuth.Username)
+	}
+	if err != nil {
+		return err
+	}
+	userAuth.ApiToken = token
+	return nil
+}
+
 // lets try use the users browser to find the API token
 func (o *CreateJenkinsUserOptions) tryFindAPITokenFromBrowser(tokenUrl string, userAuth *auth.UserAuth) error {
 	var ctxt context.Context
@@ -6,7 +6,6 @@ import (
 	"io"
 	"os"
 	"os/exec"
-	"runtime"
 
 	"github.com/spf13/cobra"
 
@@ -87,7 +86,7 @@ func (o CreateLileOptions) checkLileInstalled() error {
 		if err != nil {
 			return err
 		}
-		if runtime.GOOS == "darwin" && !o.NoBrew {
+		if o.useBrew() {
 			err = o.RunCommand("brew", "install", "protobuf")
 			if err != nil {
 				return err
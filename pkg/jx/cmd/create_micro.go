@@ -5,7 +5,6 @@ import (
 	"io"
 	"os"
 	"path/filepath"
-	"runtime"
 
 	"github.com/spf13/cobra"
 
@@ -79,7 +78,7 @@ func (o CreateMicroOptions) checkMicroInstalled() error {
 		if err != nil {
 			return err
 		}
-		if runtime.GOOS == "darwin" && !o.NoBrew {
+		if o.useBrew() {
 			err = o.RunCommand("brew", "install", "protobuf")
 			if err != nil {
 				return err
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/cloud/nodepool"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createNodePoolLong = templates.LongDesc(`
+		Creates a node pool in a GKE, EKS or AKS cluster using the command line tool of the cloud provider.
+
+		If a maximum number of nodes is specified the cluster autoscaler is enabled for the node pool so that
+		pending build pods trigger new nodes to be added.
+`)
+
+	createNodePoolExample = templates.Examples(`
+		# Create an autoscaled node pool for builds in a GKE cluster
+		jx create nodepool builds --provider gke --cluster mycluster --zone europe-west1-b --machine-type n1-standard-4 --nodes 1 --min-nodes 1 --max-nodes 5
+
+		# Create a preemptible node pool for builds in a GKE cluster
+		jx create nodepool spot-builds --provider gke --cluster mycluster --spot --nodes 0 --min-nodes 0 --max-nodes 10
+
+		# Create a node group in an EKS cluster
+		jx create nodepool builds --provider eks --cluster mycluster --machine-type m5.xlarge --nodes 2
+	`)
+)
+
+// CreateNodePoolOptions the options for the create nodepool command
+type CreateNodePoolOptions struct {
+	CreateOptions
+	NodePoolFlags
+
+	MachineType string
+	Nodes       int
+	MinNodes    int
+	MaxNodes    int
+	Spot        bool
+}
+
+// NewCmdCreateNodePool creates a command object for the "create nodepool" command
+func NewCmdCreateNodePool(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateNodePoolOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "nodepool NAME",
+		Short:   "Creates a node pool in the cluster",
+		Aliases: []string{"nodepools", "node-pool", "nodegroup"},
+		Long:    createNodePoolLong,
+		Example: createNodePoolExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.NodePoolFlags.addFlags(cmd)
+	cmd.Flags().StringVarP(&options.MachineType, "machine-type", "m", "", "The machine type, node type or VM size of the nodes")
+	cmd.Flags().IntVarP(&options.Nodes, "nodes", "n", 1, "The number of nodes")
+	cmd.Flags().IntVarP(&options.MinNodes, "min-nodes", "", 0, "The minimum number of nodes when autoscaling")
+	cmd.Flags().IntVarP(&options.MaxNodes, "max-nodes", "", 0, "The maximum number of nodes. Enables the cluster autoscaler for the node pool")
+	cmd.Flags().BoolVarP(&options.Spot, "spot", "", false, "Uses spot or preemptible nodes. Use 'jx edit spot-builds' to run build pods on them")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateNodePoolOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("missing node pool name argument")
+	}
+	pool := nodepool.NodePool{
+		Name:        o.Args[0],
+		MachineType: o.MachineType,
+		Nodes:       o.Nodes,
+		MinNodes:    o.MinNodes,
+		MaxNodes:    o.MaxNodes,
+		Spot:        o.Spot,
+	}
+	err := pool.Validate()
+	if err != nil {
+		return err
+	}
+	manager, err := o.createNodePoolManager(&o.NodePoolFlags)
+	if err != nil {
+		return err
+	}
+	log.Infof("Creating node pool %s in cluster %s\n", util.ColorInfo(pool.Name), util.ColorInfo(manager.Cluster.Name))
+	err = manager.Create(pool)
+	if err != nil {
+		return err
+	}
+	log.Infof("Created node pool %s with %s nodes\n", util.ColorInfo(pool.Name), util.ColorInfo(pool.Nodes))
+	if pool.Autoscaling() {
+		log.Infof("The cluster autoscaler will scale the node pool between %s and %s nodes\n", util.ColorInfo(pool.MinNodes), util.ColorInfo(pool.MaxNodes))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	createNotificationLong = templates.LongDesc(`
+		Creates a chat channel which is notified of pipeline failures, successful promotions and new preview environments
+
+		The webhook URL of the channel is stored as a Secret in the development namespace of the team.
+		The notifications are sent by the 'jx controller notifications' controller and the messages can be customised
+		via 'jx edit notificationtemplate'.
+`)
+
+	createNotificationExample = templates.Examples(`
+		# Post all events to a Slack channel
+		jx create notification builds --provider slack --url https://hooks.slack.com/services/T000/B000/XXXX
+
+		# Post only the successful promotions to a Microsoft Teams channel
+		jx create notification releases --provider teams --url https://outlook.office.com/webhook/xxx --events promotion-succeeded
+
+		# Post pipeline failures to a Discord channel
+		jx create notification failures --provider discord --url https://discordapp.com/api/webhooks/xxx --events pipeline-failed
+	`)
+)
+
+// CreateNotificationOptions the options for the create notification command
+type CreateNotificationOptions struct {
+	CreateOptions
+
+	Provider   string
+	WebhookURL string
+	Events     []string
+}
+
+// NewCmdCreateNotification creates a command object for the "create notification" command
+func NewCmdCreateNotification(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &CreateNotificationOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "notification name",
+		Short:   "Creates a chat channel which is notified of pipeline and promotion events",
+		Aliases: []string{"notifications", "notify"},
+		Long:    createNotificationLong,
+		Example: createNotificationExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.Provider, "provider", "p", notify.ProviderSlack, "The chat provider of the channel: "+strings.Join(notify.Providers, ", "))
+	cmd.Flags().StringVarP(&options.WebhookURL, "url", "u", "", "The incoming webhook URL of the channel")
+	cmd.Flags().StringArrayVarP(&options.Events, "events", "e", notify.EventKinds, "The events to post to the channel: "+strings.Join(notify.EventKinds, ", "))
+	return cmd
+}
+
+// Run implements the command
+func (o *CreateNotificationOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the name of the notification channel")
+	}
+	name := o.Args[0]
+	var err error
+	if o.WebhookURL == "" && !o.BatchMode {
+		o.WebhookURL, err = util.PickValue("Webhook URL of the channel:", "", true)
+		if err != nil {
+			return err
+		}
+	}
+	events := []string{}
+	for _, e := range o.Events {
+		events = append(events, strings.Split(e, ",")...)
+	}
+	channel := &notify.Channel{
+		Name:       name,
+		Provider:   o.Provider,
+		WebhookURL: o.WebhookURL,
+		Events:     events,
+	}
+
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = notify.SaveChannel(kubeClient, devNs, channel)
+	if err != nil {
+		return err
+	}
+	log.Infof("Created the %s notification channel %s for the events: %s\n", channel.Provider, util.ColorInfo(name), util.ColorInfo(strings.Join(events, ", ")))
+	return nil
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -11,6 +12,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/quickstarts"
@@ -58,6 +60,7 @@ type CreateQuickstartOptions struct {
 	GitProvider         gits.GitProvider
 	GitHost             string
 	IgnoreTeam          bool
+	RefreshCache        bool
 }
 
 // NewCmdCreateQuickstart creates a command object for the "create" command
@@ -97,6 +100,7 @@ func NewCmdCreateQuickstart(f Factory, out io.Writer, errOut io.Writer) *cobra.C
 	cmd.Flags().StringVarP(&options.GitHost, "git-host", "", "", "The Git server host if not using GitHub when pushing created project")
 	cmd.Flags().StringVarP(&options.Filter.Text, "filter", "f", "", "The text filter")
 	cmd.Flags().StringVarP(&options.Filter.ProjectName, "project-name", "p", "", "The project name (for use with -b batch mode)")
+	cmd.Flags().BoolVarP(&options.RefreshCache, "refresh", "", false, "Refreshes the cached quickstart catalogs from the git servers")
 	return cmd
 }
 
@@ -227,19 +231,17 @@ func (o *CreateQuickstartOptions) createQuickstart(f *quickstarts.QuickstartForm
 	if u == "" {
 		return answer, fmt.Errorf("quickstart %s does not have a download zip URL", q.ID)
 	}
-	client := http.Client{}
+	client := httpclient.NewBaseClient()
 
 	req, err := http.NewRequest(http.MethodGet, u, strings.NewReader(""))
 	if err != nil {
 		return answer, err
 	}
 	userAuth := q.GitProvider.UserAuth()
-	token := userAuth.ApiToken
-	username := userAuth.Username
-	if token != "" && username != "" {
-		o.Debugf("Downloading Quickstart source zip from %s with basic auth for user: %s\n", u, username)
-		req.SetBasicAuth(username, token)
+	if userAuth.ApiToken != "" {
+		o.Debugf("Downloading Quickstart source zip from %s as user: %s\n", u, userAuth.Username)
 	}
+	quickstarts.AddAuthHeaders(req, q.GitProvider)
 	res, err := client.Do(req)
 	if err != nil {
 		return answer, err
@@ -305,9 +307,48 @@ func (o *CreateQuickstartOptions) LoadQuickstartsFromMap(config *auth.AuthConfig
 			if err != nil {
 				return model, err
 			}
-			o.Debugf("Searching for repositories in git server %s owner %s includes %s excludes %s as user %s \n", gitProvider.ServerURL(), location.Owner, strings.Join(location.Includes, ", "), strings.Join(location.Excludes, ", "), gitProvider.CurrentUsername())
-			model.LoadGithubQuickstarts(gitProvider, location.Owner, location.Includes, location.Excludes)
+			catalog, err := o.loadQuickstartCatalog(gitProvider, location)
+			if err != nil {
+				log.Warnf("Failed to load quickstarts from git server %s owner %s: %s\n", gitProvider.ServerURL(), location.Owner, err)
+				continue
+			}
+			model.LoadCatalog(gitProvider, location.Owner, catalog)
 		}
 	}
 	return model, nil
 }
+
+// loadQuickstartCatalog loads the catalog of quickstarts for the location either from its catalog index or by
+// searching the repositories of the owner. The catalog is cached locally unless a refresh is requested
+func (o *CreateQuickstartOptions) loadQuickstartCatalog(gitProvider gits.GitProvider, location v1.QuickStartLocation) (*quickstarts.QuickstartCatalog, error) {
+	loader := func() ([]byte, error) {
+		if location.CatalogURL != "" {
+			o.Debugf("Downloading quickstart catalog %s as user %s \n", location.CatalogURL, gitProvider.CurrentUsername())
+			return quickstarts.DownloadCatalogIndex(gitProvider, location.CatalogURL, location.CatalogPublicKey)
+		}
+		o.Debugf("Searching for repositories in git server %s owner %s includes %s excludes %s as user %s \n", gitProvider.ServerURL(), location.Owner, strings.Join(location.Includes, ", "), strings.Join(location.Excludes, ", "), gitProvider.CurrentUsername())
+		catalog, err := quickstarts.RepositoryCatalog(gitProvider, location.Owner, location.Includes, location.Excludes)
+		if err != nil {
+			return nil, err
+		}
+		return catalog.ToYAML()
+	}
+
+	cacheDir, err := util.CacheDir()
+	if err != nil {
+		return nil, err
+	}
+	key := strings.Join([]string{location.GitURL, location.Owner, location.CatalogURL, strings.Join(location.Includes, ","), strings.Join(location.Excludes, ",")}, "|")
+	cacheFileName := filepath.Join(cacheDir, fmt.Sprintf("quickstarts-%x.yml", sha256.Sum256([]byte(key))))
+	if o.RefreshCache {
+		err = os.RemoveAll(cacheFileName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	data, err := util.LoadCacheData(cacheFileName, loader)
+	if err != nil {
+		return nil, err
+	}
+	return quickstarts.ParseCatalog(data)
+}
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"io/ioutil"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -37,6 +39,9 @@ var (
 		# Create a quickstart location for your git repo and organisation 
 		jx create quickstartlocation --url https://mygit.server.com --owner my-quickstarts
 
+		# Create a quickstart location for a private catalog index which is signed by your team
+		jx create quickstartlocation --url https://gitlab.acme.com --kind gitlab --owner my-quickstarts --catalog-url https://gitlab.acme.com/my-quickstarts/catalog/raw/master/quickstarts.yml --catalog-public-key catalog.pub
+
 	`)
 )
 
@@ -49,6 +54,9 @@ type CreateQuickstartLocationOptions struct {
 	Owner    string
 	Includes []string
 	Excludes []string
+
+	CatalogURL           string
+	CatalogPublicKeyFile string
 }
 
 // NewCmdCreateQuickstartLocation creates a command object for the "create" command
@@ -82,6 +90,8 @@ func NewCmdCreateQuickstartLocation(f Factory, out io.Writer, errOut io.Writer)
 	cmd.Flags().StringVarP(&options.Owner, optionOwner, "o", "", "The owner is the user or organisation of the git provider used to find repositories")
 	cmd.Flags().StringArrayVarP(&options.Includes, "includes", "i", []string{"*"}, "The patterns to include repositories")
 	cmd.Flags().StringArrayVarP(&options.Excludes, "excludes", "x", []string{"WIP-*"}, "The patterns to exclude repositories")
+	cmd.Flags().StringVarP(&options.CatalogURL, "catalog-url", "", "", "The URL of a quickstart catalog index to use instead of searching the owner's repositories")
+	cmd.Flags().StringVarP(&options.CatalogPublicKeyFile, "catalog-public-key", "", "", "The file containing the PEM encoded public key used to verify the signature of the catalog index")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -118,6 +128,17 @@ func (o *CreateQuickstartLocationOptions) Run() error {
 	if o.GitKind == "" {
 		return util.MissingOption(optionGitKind)
 	}
+	publicKey := ""
+	if o.CatalogPublicKeyFile != "" {
+		if o.CatalogURL == "" {
+			return util.MissingOption("catalog-url")
+		}
+		data, err := ioutil.ReadFile(o.CatalogPublicKeyFile)
+		if err != nil {
+			return fmt.Errorf("failed to load catalog public key %s: %s", o.CatalogPublicKeyFile, err)
+		}
+		publicKey = string(data)
+	}
 	locations, err := kube.GetQuickstartLocations(jxClient, ns)
 	if err != nil {
 		return err
@@ -139,6 +160,8 @@ func (o *CreateQuickstartLocationOptions) Run() error {
 	location = &locations[len(locations)-1]
 	location.Includes = o.Includes
 	location.Excludes = o.Excludes
+	location.CatalogURL = o.CatalogURL
+	location.CatalogPublicKey = publicKey
 
 	callback := func(env *v1.Environment) error {
 		env.Spec.TeamSettings.QuickstartLocations = locations
@@ -15,12 +15,18 @@ import (
 var (
 	createTeamLong = templates.LongDesc(`
 		Creates a Team
+
+		When the Team is provisioned it gets its own namespaces which other teams cannot connect to, an ingress
+		domain which defaults to a sub domain of the cluster domain and an organisation in the docker registry
+		which defaults to the name of the Team. The members of the Team are given the developer role.
 `)
 
 	createTeamExample = templates.Examples(`
 		# Create a new pending Team which can then be provisioned
 		jx create team myname
-"
+
+		# Create a new Team with its own ingress domain and docker registry organisation
+		jx create team myname --domain myname.acme.com --docker-registry-org acme-myname
 	`)
 )
 
@@ -28,8 +34,10 @@ var (
 type CreateTeamOptions struct {
 	CreateOptions
 
-	Name    string
-	Members []string
+	Name              string
+	Members           []string
+	Domain            string
+	DockerRegistryOrg string
 }
 
 // NewCmdCreateTeam creates a command object for the "create" command
@@ -60,6 +68,8 @@ func NewCmdCreateTeam(f Factory, out io.Writer, errOut io.Writer) *cobra.Command
 
 	cmd.Flags().StringVarP(&options.Name, optionName, "n", "", "The name of the new Team. Should be all lower case and no special characters other than '-'")
 	cmd.Flags().StringArrayVarP(&options.Members, "member", "m", []string{}, "The usernames of the members to add to the Team")
+	cmd.Flags().StringVarP(&options.Domain, "domain", "d", "", "The ingress domain of the Team. Defaults to a sub domain of the cluster domain named after the Team")
+	cmd.Flags().StringVarP(&options.DockerRegistryOrg, "docker-registry-org", "", "", "The organisation in the docker registry the images of the Team are pushed to. Defaults to the name of the Team")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -106,8 +116,9 @@ func (o *CreateTeamOptions) Run() error {
 		return fmt.Errorf("The Team %s already exists!", name)
 	}
 
-	// TODO configure other properties?
 	team := kube.CreateTeam(ns, name, o.Members)
+	team.Spec.Domain = o.Domain
+	team.Spec.DockerRegistryOrg = o.DockerRegistryOrg
 	_, err = jxClient.JenkinsV1().Teams(ns).Create(team)
 	if err != nil {
 		return fmt.Errorf("Failed to create Team %s: %s", name, err)
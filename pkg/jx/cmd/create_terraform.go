@@ -364,7 +364,7 @@ func (options *CreateTerraformOptions) ClusterDetailsWizard() error {
 		Default: numOfClustersStr,
 	}
 
-	err := survey.AskOne(prompts, &numOfClustersStr, nil)
+	err := util.AskOne(prompts, &numOfClustersStr, nil)
 	if err != nil {
 		return err
 	}
@@ -388,7 +388,7 @@ func (options *CreateTerraformOptions) ClusterDetailsWizard() error {
 			Default: defaultOption,
 		}
 		validator := survey.Required
-		err := survey.AskOne(prompts, &name, validator)
+		err := util.AskOne(prompts, &name, validator)
 		if err != nil {
 			return err
 		}
@@ -405,7 +405,7 @@ func (options *CreateTerraformOptions) ClusterDetailsWizard() error {
 				return nil
 			},
 		)
-		err = survey.AskOne(prompts, &provider, validator)
+		err = util.AskOne(prompts, &provider, validator)
 		if err != nil {
 			return err
 		}
@@ -416,7 +416,7 @@ func (options *CreateTerraformOptions) ClusterDetailsWizard() error {
 				Message: fmt.Sprintf("Would you like to install Jenkins X in cluster %v", name),
 				Default: true,
 			}
-			survey.AskOne(prompt, &confirm, nil)
+			util.AskOne(prompt, &confirm, nil)
 
 			if confirm {
 				jxEnvironment = name
@@ -796,7 +796,7 @@ func (options *CreateTerraformOptions) configureGKECluster(g *GKECluster, path s
 			Help:     "The compute zone (e.g. us-central1-a) for the cluster",
 		}
 
-		err = survey.AskOne(prompts, &g.Zone, nil)
+		err = util.AskOne(prompts, &g.Zone, nil)
 		if err != nil {
 			return err
 		}
@@ -811,7 +811,7 @@ func (options *CreateTerraformOptions) configureGKECluster(g *GKECluster, path s
 			Default:  "n1-standard-2",
 		}
 
-		err := survey.AskOne(prompts, &g.MachineType, nil)
+		err := util.AskOne(prompts, &g.MachineType, nil)
 		if err != nil {
 			return err
 		}
@@ -824,7 +824,7 @@ func (options *CreateTerraformOptions) configureGKECluster(g *GKECluster, path s
 			Help:    "We recommend a minimum of 3 for Jenkins X,  the minimum number of nodes to be created in each of the cluster's zones",
 		}
 
-		err := survey.AskOne(prompt, &g.MinNumOfNodes, nil)
+		err := util.AskOne(prompt, &g.MinNumOfNodes, nil)
 		if err != nil {
 			return err
 		}
@@ -837,7 +837,7 @@ func (options *CreateTerraformOptions) configureGKECluster(g *GKECluster, path s
 			Help:    "We recommend at least 5 for Jenkins X,  the maximum number of nodes to be created in each of the cluster's zones",
 		}
 
-		err := survey.AskOne(prompt, &g.MaxNumOfNodes, nil)
+		err := util.AskOne(prompt, &g.MaxNumOfNodes, nil)
 		if err != nil {
 			return err
 		}
@@ -945,7 +945,7 @@ func (options *CreateTerraformOptions) applyTerraformGKE(g *GKECluster, path str
 		prompt := &survey.Confirm{
 			Message: "Would you like to apply this plan?",
 		}
-		survey.AskOne(prompt, &confirm, nil)
+		util.AskOne(prompt, &confirm, nil)
 
 		if !confirm {
 			// exit at this point
@@ -994,7 +994,7 @@ func (options *CreateTerraformOptions) getGoogleProjectID() (string, error) {
 			Default: true,
 		}
 		flag := true
-		err = survey.AskOne(confirm, &flag, nil)
+		err = util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return "", err
 		}
@@ -1015,7 +1015,7 @@ func (options *CreateTerraformOptions) getGoogleProjectID() (string, error) {
 			Help:    "Select a Google Project to create the cluster in",
 		}
 
-		err := survey.AskOne(prompts, &projectID, nil)
+		err := util.AskOne(prompts, &projectID, nil)
 		if err != nil {
 			return "", err
 		}
@@ -1047,7 +1047,7 @@ func (options *CreateTerraformOptions) installJx(c Cluster, clusters []Cluster)
 			return err
 		}
 
-		context, err := options.getCommandOutput("", "kubectl", "config", "current-context")
+		context, err := kube.CurrentContextName()
 		if err != nil {
 			return err
 		}
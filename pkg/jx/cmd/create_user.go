@@ -15,26 +15,24 @@ import (
 
 const (
 	optionLogin = "login"
+	optionRole  = "role"
 )
 
 var (
 	createUserLong = templates.LongDesc(`
-		Creates a user
+		Creates a user and optionally binds it to team roles.
+
+		The default team roles are admin, developer and viewer. Binding a user to a role grants the matching
+		Kubernetes RBAC permissions in the team namespace and the matching Jenkins permissions (when using
+		matrix based security) so that team members do not need to share cluster-admin.
 `)
 
 	createUserExample = templates.Examples(`
-		# Create an issue in the current project
-		jx create issue -t "something we should do"
-
-
-		# Create an issue with a title and a body
-		jx create issue -t "something we should do" --body "	
-		some more
-		text
-		goes
-		here
-		""
-"
+		# Create a user
+		jx create user -l jstrachan -n "James Strachan" -e james@example.com
+
+		# Create a user who is a developer in the current team
+		jx create user -l jstrachan -r developer
 	`)
 )
 
@@ -42,6 +40,7 @@ var (
 type CreateUserOptions struct {
 	CreateOptions
 	UserSpec v1.UserDetails
+	Roles    []string
 }
 
 // NewCmdCreateUser creates a command object for the "create" command
@@ -73,6 +72,7 @@ func NewCmdCreateUser(f Factory, out io.Writer, errOut io.Writer) *cobra.Command
 	cmd.Flags().StringVarP(&options.UserSpec.Login, optionLogin, "l", "", "The user login name")
 	cmd.Flags().StringVarP(&options.UserSpec.Name, "name", "n", "", "The textual full name of the user")
 	cmd.Flags().StringVarP(&options.UserSpec.Email, "email", "e", "", "The users email address")
+	cmd.Flags().StringArrayVarP(&options.Roles, optionRole, "r", []string{}, "The team roles of the user such as admin, developer or viewer")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -129,11 +129,19 @@ func (o *CreateUserOptions) Run() error {
 		name = strings.Title(login)
 	}
 	user := kube.CreateUser(ns, login, name, spec.Email)
-	_, err = jxClient.JenkinsV1().Users(ns).Create(user)
+	user, err = jxClient.JenkinsV1().Users(ns).Create(user)
 	if err != nil {
 		return fmt.Errorf("Failed to create User %s: %s", login, err)
 	}
 	log.Infof("Created User: %s\n", util.ColorInfo(login))
+	if len(o.Roles) > 0 {
+		err = o.updateTeamMemberRoles(devNs, user, o.Roles)
+		if err != nil {
+			return err
+		}
+		log.Infof("Added user %s to the team roles: %s\n", util.ColorInfo(login), util.ColorInfo(strings.Join(o.Roles, ", ")))
+		return nil
+	}
 	log.Infof("You can configure the roles for the user via: %s\n", util.ColorInfo(fmt.Sprintf("jx edit userrole %s", login)))
 	return nil
 
@@ -52,12 +52,18 @@ func NewCmdDelete(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 
 	cmd.AddCommand(NewCmdDeleteAddon(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteApp(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteBrewTap(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteBuildCache(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteChat(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteContext(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteDependency(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteDevPod(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteEnvVar(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteCloudResource(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteGit(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteJenkins(f, out, errOut))
+	cmd.AddCommand(NewCmdDeleteNotification(f, out, errOut))
 	cmd.AddCommand(NewCmdDeletePostPreviewJob(f, out, errOut))
 	cmd.AddCommand(NewCmdDeletePreview(f, out, errOut))
 	cmd.AddCommand(NewCmdDeleteQuickstartLocation(f, out, errOut))
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteBrewTapLong = templates.LongDesc(`
+		Removes a Homebrew tap. The formulae installed from the tap have to be uninstalled first.
+`)
+
+	deleteBrewTapExample = templates.Examples(`
+		# Removes the Jenkins X tap
+		jx delete brewtap
+	`)
+)
+
+// DeleteBrewTapOptions the options for the delete brewtap command
+type DeleteBrewTapOptions struct {
+	CommonOptions
+}
+
+// NewCmdDeleteBrewTap creates a command object for the "delete brewtap" command
+func NewCmdDeleteBrewTap(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteBrewTapOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "brewtap [TAP]",
+		Short:   "Removes a Homebrew tap which defaults to the Jenkins X tap",
+		Aliases: []string{"brew-tap", "tap"},
+		Long:    deleteBrewTapLong,
+		Example: deleteBrewTapExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteBrewTapOptions) Run() error {
+	tap := jenkinsXBrewTap
+	if len(o.Args) > 0 {
+		tap = o.Args[0]
+	}
+	taps, err := o.brewTaps()
+	if err != nil {
+		return err
+	}
+	if util.StringArrayIndex(taps, tap) < 0 {
+		return util.InvalidArg(tap, taps)
+	}
+	err = o.RunCommand("brew", "untap", tap)
+	if err != nil {
+		return fmt.Errorf("failed to remove the brew tap %s. Uninstall the formulae installed from it first: %s", tap, err)
+	}
+	log.Infof("Removed the brew tap %s\n", util.ColorInfo(tap))
+	return nil
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	deleteBuildCacheLong = templates.LongDesc(`
+		Deletes a dependency cache for the builds of your team
+
+		The cache is removed from the build pod templates. Use --delete-pvc to also remove the cached dependencies.
+`)
+
+	deleteBuildCacheExample = templates.Examples(`
+		# Pick a build cache to delete
+		jx delete buildcache
+
+		# Delete the maven cache along with its storage
+		jx delete buildcache --kind maven --delete-pvc
+	`)
+)
+
+// DeleteBuildCacheOptions the options for the delete buildcache command
+type DeleteBuildCacheOptions struct {
+	CommonOptions
+
+	Kind      string
+	DeletePVC bool
+}
+
+// NewCmdDeleteBuildCache defines the command
+func NewCmdDeleteBuildCache(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteBuildCacheOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     buildCache,
+		Short:   "Deletes a dependency cache for the builds of your team",
+		Aliases: buildCacheAliases,
+		Long:    deleteBuildCacheLong,
+		Example: deleteBuildCacheExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Kind, optionBuildCacheKind, "k", "", "The kind of build cache to delete")
+	cmd.Flags().BoolVarP(&options.DeletePVC, "delete-pvc", "", false, "Also deletes the PersistentVolumeClaim containing the cached dependencies")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteBuildCacheOptions) Run() error {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	if o.Kind == "" {
+		if o.BatchMode {
+			return util.MissingOption(optionBuildCacheKind)
+		}
+		kinds := []string{}
+		for _, c := range settings.BuildCaches {
+			kinds = append(kinds, c.Kind)
+		}
+		o.Kind, err = util.PickName(kinds, "Pick the build cache to delete: ")
+		if err != nil {
+			return err
+		}
+		if o.Kind == "" {
+			return fmt.Errorf("No build cache chosen")
+		}
+	}
+
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	var caches []v1.BuildCache
+	callback := func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		for i, c := range settings.BuildCaches {
+			if c.Kind == o.Kind {
+				settings.BuildCaches = append(settings.BuildCaches[0:i], settings.BuildCaches[i+1:]...)
+				caches = settings.BuildCaches
+				return nil
+			}
+		}
+		return fmt.Errorf("No build cache found for kind: %s", o.Kind)
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	err = kube.UpdatePodTemplatesWithBuildCaches(kubeClient, ns, caches)
+	if err != nil {
+		return err
+	}
+	log.Infof("Deleted the %s build cache\n", util.ColorInfo(o.Kind))
+
+	if o.DeletePVC {
+		name := kube.BuildCacheName(o.Kind)
+		err = kubeClient.CoreV1().PersistentVolumeClaims(ns).Delete(name, &metav1.DeleteOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to delete PersistentVolumeClaim %s in namespace %s: %s", name, ns, err)
+		}
+		log.Infof("Deleted PersistentVolumeClaim %s\n", util.ColorInfo(name))
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/kubernetes"
+)
+
+var (
+	deleteCloudResourceLong = templates.LongDesc(`
+		Deletes the claim of a cloud resource of an app in an environment.
+
+		Crossplane deletes or retains the cloud resource depending on the reclaim policy of its resource class. For
+		GitOps environments a Pull Request is created which removes the claim from the environment chart.
+`)
+
+	deleteCloudResourceExample = templates.Examples(`
+		# delete the uploads bucket of an app in staging
+		jx delete cloud-resource bucket uploads --app myapp --env staging
+	`)
+)
+
+// DeleteCloudResourceOptions the options for the delete cloud-resource command
+type DeleteCloudResourceOptions struct {
+	CommonOptions
+
+	App         string
+	Environment string
+}
+
+// NewCmdDeleteCloudResource creates a command object for the "delete cloud-resource" command
+func NewCmdDeleteCloudResource(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteCloudResourceOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "cloud-resource KIND NAME",
+		Short:   "Deletes the claim of a cloud resource of an app in an environment",
+		Aliases: []string{"cloud-resources", "claim"},
+		Long:    deleteCloudResourceLong,
+		Example: deleteCloudResourceExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The name of the app")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The name of the environment")
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteCloudResourceOptions) Run() error {
+	if len(o.Args) < 2 {
+		return fmt.Errorf("Missing arguments for the kind and name of the cloud resource, the kind should be one of: %s", strings.Join(kube.CrossplaneClaimKindNames(), ", "))
+	}
+	kindName := o.Args[0]
+	name := o.Args[1]
+	if _, ok := kube.CrossplaneClaimKinds[kindName]; !ok {
+		return util.InvalidArg(kindName, kube.CrossplaneClaimKindNames())
+	}
+	if o.App == "" {
+		return util.MissingOption("app")
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	claimName := kube.CrossplaneClaimName(o.App, name)
+	branchName := "delete-cloud-resource-" + claimName
+	title := fmt.Sprintf("Delete %s %s of app %s", kindName, name, o.App)
+	message := "The command `jx delete cloud-resource` was run by " + u.Username + " and it generated this Pull Request"
+
+	modifyDirFn := func(dir string) error {
+		removed, err := kube.RemoveCrossplaneClaim(dir, o.App, name)
+		if err != nil {
+			return err
+		}
+		if !removed {
+			return fmt.Errorf("app %s has no cloud resource %s in environment %s", o.App, name, o.Environment)
+		}
+		return nil
+	}
+	applyFn := func(client kubernetes.Interface, ns string) error {
+		return kube.DeleteCrossplaneClaim(client, ns, kindName, claimName)
+	}
+	err = o.modifyCloudResourceClaims(o.Environment, branchName, title, message, modifyDirFn, applyFn)
+	if err != nil {
+		return err
+	}
+	log.Infof("Deleted %s %s of app %s in environment %s\n", kindName, util.ColorInfo(name), util.ColorInfo(o.App), util.ColorInfo(o.Environment))
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -18,6 +19,10 @@ import (
 var (
 	delete_context_long = templates.LongDesc(`
 		Deletes one or more kubernetes contexts.
+
+		Use --prune to select the contexts of clusters which no longer exist, i.e. contexts which refer to a missing
+		cluster or user or whose API server cannot be reached. Pruning also removes the clusters and users which are
+		no longer used by any context. A backup of the kube config is taken before it is modified.
 `)
 
 	delete_context_example = templates.Examples(`
@@ -27,6 +32,9 @@ var (
 		# Deletes all contexts containing the word cheese
 		# selecting them all by default
 		jx delete ctx -a cheese
+
+		# Removes the contexts of clusters which have been deleted
+		jx delete ctx --prune
 	`)
 )
 
@@ -36,6 +44,8 @@ type DeleteContextOptions struct {
 
 	SelectAll    bool
 	SelectFilter string
+	Prune        bool
+	Timeout      time.Duration
 }
 
 // NewCmdDeleteContext creates a command object for the "delete repo" command
@@ -67,6 +77,8 @@ func NewCmdDeleteContext(f Factory, out io.Writer, errOut io.Writer) *cobra.Comm
 
 	cmd.Flags().BoolVarP(&options.SelectAll, "all", "a", false, "Selects all the matched contexts")
 	cmd.Flags().StringVarP(&options.SelectFilter, "filter", "f", "", "Filter the list of contexts to those containing this text")
+	cmd.Flags().BoolVarP(&options.Prune, "prune", "p", false, "Selects the contexts of clusters which are missing or cannot be reached and removes unused clusters and users")
+	cmd.Flags().DurationVarP(&options.Timeout, "timeout", "", 5*time.Second, "How long to wait when connecting to the API server of a cluster when pruning")
 	return cmd
 }
 
@@ -84,15 +96,30 @@ func (o *DeleteContextOptions) Run() error {
 	names := []string{}
 	allNames := []string{}
 
+	candidates := map[string]bool{}
+	if o.Prune {
+		for _, name := range kube.BrokenContexts(config) {
+			candidates[name] = true
+		}
+		for _, name := range kube.UnreachableContexts(config, o.Timeout) {
+			candidates[name] = true
+		}
+	}
+
 	args := o.Args
 	for k, _ := range config.Contexts {
 		allNames = append(allNames, k)
-		if matchesFilter(k, args) {
+		if matchesFilter(k, args) && (!o.Prune || candidates[k]) {
 			names = append(names, k)
 		}
 	}
 	sort.Strings(allNames)
+	sort.Strings(names)
 
+	if len(names) == 0 && o.Prune {
+		log.Infof("All the kubernetes contexts refer to clusters which can be reached\n")
+		return nil
+	}
 	if len(names) == 0 {
 		if len(args) == 0 {
 			return fmt.Errorf("Failed to find a context!")
@@ -113,7 +140,7 @@ func (o *DeleteContextOptions) Run() error {
 		Message: "Are you sure you want to delete these these Kubernetes Contexts?",
 		Default: false,
 	}
-	err = survey.AskOne(prompt, &flag, nil)
+	err = util.AskOne(prompt, &flag, nil)
 	if err != nil {
 		return err
 	}
@@ -121,9 +148,21 @@ func (o *DeleteContextOptions) Run() error {
 		return nil
 	}
 
+	backup, err := kube.BackupKubeConfig(po)
+	if err != nil {
+		return err
+	}
+	if backup != "" {
+		log.Infof("Saved a backup of the kube config to %s\n", util.ColorInfo(backup))
+	}
+
 	newConfig := *config
-	for _, name := range selected {
-		delete(newConfig.Contexts, name)
+	if o.Prune {
+		kube.PruneContexts(&newConfig, selected)
+	} else {
+		for _, name := range selected {
+			delete(newConfig.Contexts, name)
+		}
 	}
 	err = clientcmd.ModifyConfig(po, newConfig, false)
 	if err != nil {
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/dependencies"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteDependencyLong = templates.LongDesc(`
+		Removes the dependency of a repository on a library from the dependency graph of your team.
+`)
+
+	deleteDependencyExample = templates.Examples(`
+		# Stop updating the maven library in the repository in the current directory
+		jx delete dependency --kind maven --library io.acme:core
+
+		# Stop updating the npm package in a repository
+		jx delete dependency --kind npm --library @acme/ui --repo https://github.com/acme/web.git
+	`)
+)
+
+// DeleteDependencyOptions the options for the delete dependency command
+type DeleteDependencyOptions struct {
+	CommonOptions
+
+	Library    string
+	Kind       string
+	Repository string
+}
+
+// NewCmdDeleteDependency defines the command
+func NewCmdDeleteDependency(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteDependencyOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "dependency",
+		Short:   "Removes the dependency of a repository on a library",
+		Aliases: dependencyAliases,
+		Long:    deleteDependencyLong,
+		Example: deleteDependencyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Library, "library", "l", "", "The name of the library")
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", fmt.Sprintf("The kind of the library. One of: %s", strings.Join(dependencies.Kinds(), ", ")))
+	cmd.Flags().StringVarP(&options.Repository, "repo", "r", "", "The git URL of the repository which depends on the library. Defaults to the repository in the current directory")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteDependencyOptions) Run() error {
+	if o.Library == "" {
+		return util.MissingOption("library")
+	}
+	if o.Kind == "" {
+		return util.MissingOption("kind")
+	}
+	err := dependencies.ValidateKind(o.Kind)
+	if err != nil {
+		return err
+	}
+	repository, err := o.dependencyRepositoryURL(o.Repository)
+	if err != nil {
+		return err
+	}
+	return o.modifyDependencyGraph(func(graph *dependencies.Graph) error {
+		if !graph.Remove(dependencies.Kind(o.Kind), o.Library, repository) {
+			return fmt.Errorf("%s does not depend on the %s library %s", repository, o.Kind, o.Library)
+		}
+		log.Infof("Removed the dependency of %s on %s\n", util.ColorInfo(repository), util.ColorInfo(o.Library))
+		return nil
+	})
+}
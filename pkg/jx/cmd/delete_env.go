@@ -114,13 +114,21 @@ func (o *DeleteEnvOptions) Run() error {
 }
 
 func (o *DeleteEnvOptions) deleteEnviroment(jxClient versioned.Interface, ns string, name string, envMap map[string]*v1.Environment) error {
+	// lets delete the database instances of the environment before the namespace containing their Secrets
+	env := envMap[name]
+	if env != nil && env.Spec.Namespace != "" && (o.DeleteNamespace || !env.Spec.Kind.IsPermanent()) {
+		err := o.deleteEnvironmentDatabases(env.Spec.Namespace)
+		if err != nil {
+			return err
+		}
+	}
+
 	err := jxClient.JenkinsV1().Environments(ns).Delete(name, &metav1.DeleteOptions{})
 	if err != nil {
 		return err
 	}
 	log.Infof("Deleted environment %s\n", util.ColorInfo(name))
 
-	env := envMap[name]
 	envNs := env.Spec.Namespace
 	if envNs == "" {
 		return fmt.Errorf("No namespace for environment %s", name)
@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/user"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteEnvVarLong = templates.LongDesc(`
+		Deletes environment variables of an app in an environment.
+
+		For GitOps environments a Pull Request is created which removes the variables from the app configuration.
+`)
+
+	deleteEnvVarExample = templates.Examples(`
+		# delete an environment variable of an app in staging
+		jx delete env-var LOG_LEVEL --app myapp --env staging
+	`)
+)
+
+// DeleteEnvVarOptions the options for the delete env-var command
+type DeleteEnvVarOptions struct {
+	CommonOptions
+
+	App         string
+	Environment string
+}
+
+// NewCmdDeleteEnvVar creates a command object for the "delete env-var" command
+func NewCmdDeleteEnvVar(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteEnvVarOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "env-var NAME...",
+		Short:   "Deletes environment variables of an app in an environment",
+		Aliases: []string{"env-vars", "envvar", "envvars"},
+		Long:    deleteEnvVarLong,
+		Example: deleteEnvVarExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The name of the app")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The name of the environment")
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteEnvVarOptions) Run() error {
+	if o.App == "" {
+		return util.MissingOption("app")
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+	names := o.Args
+	if len(names) == 0 {
+		return fmt.Errorf("Missing argument for the names of the environment variables to delete")
+	}
+	for _, name := range names {
+		err := appconfig.ValidateEnvVarName(name)
+		if err != nil {
+			return err
+		}
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	branchName := "delete-env-var-" + o.App
+	title := fmt.Sprintf("Delete environment variables %s of app %s", strings.Join(names, ", "), o.App)
+	message := "The command `jx delete env-var` was run by " + u.Username + " and it generated this Pull Request"
+
+	fn := func(config *appconfig.AppConfig, key []byte) error {
+		for _, name := range names {
+			if !config.Remove(name) {
+				return fmt.Errorf("app %s has no environment variable %s in environment %s", o.App, name, o.Environment)
+			}
+		}
+		return nil
+	}
+	err = o.modifyAppConfig(o.Environment, o.App, branchName, title, message, fn)
+	if err != nil {
+		return err
+	}
+	log.Infof("Deleted environment variables %s of app %s in environment %s\n", util.ColorInfo(strings.Join(names, ", ")), util.ColorInfo(o.App), util.ColorInfo(o.Environment))
+	return nil
+}
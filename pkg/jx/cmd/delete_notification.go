@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	deleteNotificationLong = templates.LongDesc(`
+		Deletes one or more chat channels which are notified of pipeline and promotion events
+`)
+
+	deleteNotificationExample = templates.Examples(`
+		# Delete a notification channel
+		jx delete notification builds
+	`)
+)
+
+// DeleteNotificationOptions the options for the delete notification command
+type DeleteNotificationOptions struct {
+	CommonOptions
+}
+
+// NewCmdDeleteNotification defines the command
+func NewCmdDeleteNotification(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DeleteNotificationOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "notification name",
+		Short:   "Deletes one or more notification channels",
+		Aliases: []string{"notifications", "notify"},
+		Long:    deleteNotificationLong,
+		Example: deleteNotificationExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *DeleteNotificationOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the name of the notification channel")
+	}
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	for _, name := range o.Args {
+		err = notify.DeleteChannel(kubeClient, devNs, name)
+		if err != nil {
+			return err
+		}
+		log.Infof("Deleted the notification channel %s\n", util.ColorInfo(name))
+	}
+	return nil
+}
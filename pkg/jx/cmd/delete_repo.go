@@ -147,7 +147,7 @@ func (o *DeleteRepoOptions) Run() error {
 			Message: "Are you sure you want to delete these all these repositories?",
 			Default: false,
 		}
-		err = survey.AskOne(prompt, &flag, nil)
+		err = util.AskOne(prompt, &flag, nil)
 		if err != nil {
 			return err
 		}
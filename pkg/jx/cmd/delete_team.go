@@ -110,7 +110,7 @@ func (o *DeleteTeamOptions) Run() error {
 			Message: "Are you sure you want to delete these all these teams?",
 			Default: false,
 		}
-		err = survey.AskOne(prompt, &flag, nil)
+		err = util.AskOne(prompt, &flag, nil)
 		if err != nil {
 			return err
 		}
@@ -107,7 +107,7 @@ func (o *DeleteUserOptions) Run() error {
 			Message: "Are you sure you want to delete these all these users?",
 			Default: false,
 		}
-		err = survey.AskOne(prompt, &flag, nil)
+		err = util.AskOne(prompt, &flag, nil)
 		if err != nil {
 			return err
 		}
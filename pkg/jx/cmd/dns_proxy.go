@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"io"
+	"net"
+	"runtime"
+	"strconv"
+
+	"github.com/jenkins-x/jx/pkg/dns"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// DNSProxyOptions the options for the dns-proxy command
+type DNSProxyOptions struct {
+	CommonOptions
+
+	Domain   string
+	IP       string
+	Address  string
+	Port     int
+	Upstream string
+}
+
+var (
+	dnsProxyLong = templates.LongDesc(`
+		Runs a local DNS proxy which resolves the domain of the team and all its sub domains to the IP address of
+		the node of a local cluster such as minikube or kind. All other queries are forwarded to the upstream name
+		server so the proxy can be used as the name server of the host.
+
+		This makes the URLs of the Jenkins, preview and environment services work locally without adding an
+		/etc/hosts entry for each of them. Configure the resolver of your operating system to send the queries of
+		the domain to the proxy, for example on macOS by creating the file /etc/resolver/<domain>.
+
+		Domains of wildcard DNS services such as nip.io already resolve so they do not need the proxy.
+`)
+
+	dnsProxyExample = templates.Examples(`
+		# resolve the domain of the team to the IP address of minikube
+		jx dns-proxy
+
+		# resolve a custom domain to the IP address of a kind node on the standard DNS port
+		sudo jx dns-proxy --domain jx.local --port 53
+	`)
+)
+
+// NewCmdDNSProxy creates the command
+func NewCmdDNSProxy(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &DNSProxyOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "dns-proxy",
+		Short:   "Runs a local DNS proxy resolving the domain of the team to a local cluster",
+		Aliases: []string{"dns"},
+		Long:    dnsProxyLong,
+		Example: dnsProxyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Domain, "domain", "d", "", "The domain to resolve. Defaults to the domain of the team")
+	cmd.Flags().StringVarP(&options.IP, "ip", "i", "", "The IP address the domain resolves to. Defaults to the IP of minikube or of the first node")
+	cmd.Flags().StringVarP(&options.Address, "address", "a", "127.0.0.1", "The address the proxy listens on")
+	cmd.Flags().IntVarP(&options.Port, "port", "p", 5353, "The UDP port the proxy listens on")
+	cmd.Flags().StringVarP(&options.Upstream, "upstream", "u", "", "The host:port of the name server other queries are forwarded to. Defaults to the first name server in /etc/resolv.conf")
+	return cmd
+}
+
+// Run implements this command
+func (o *DNSProxyOptions) Run() error {
+	var err error
+	if o.Domain == "" {
+		client, devNs, err := o.KubeClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		ic, err := kube.GetIngressConfig(client, devNs)
+		if err != nil {
+			return errors.Wrapf(err, "cannot get the ingress config of namespace %s", devNs)
+		}
+		o.Domain = ic.Domain
+		if o.Domain == "" {
+			return util.MissingOption("domain")
+		}
+	}
+	if o.IP == "" {
+		o.IP, err = o.localClusterIP()
+		if err != nil {
+			return err
+		}
+	}
+	proxy, err := dns.NewProxy(o.Domain, o.IP, o.Upstream)
+	if err != nil {
+		return err
+	}
+	address := net.JoinHostPort(o.Address, strconv.Itoa(o.Port))
+	log.Infof("Resolving %s and its sub domains to %s on %s and forwarding other queries to %s\n",
+		util.ColorInfo(proxy.Domain), util.ColorInfo(o.IP), util.ColorInfo(address), util.ColorInfo(proxy.Upstream))
+	if runtime.GOOS == "darwin" {
+		log.Infof("To use the proxy for the domain run:\n")
+		log.Infof("  sudo mkdir -p /etc/resolver && printf 'nameserver %s\\nport %d\\n' | sudo tee /etc/resolver/%s\n", o.Address, o.Port, proxy.Domain)
+	} else {
+		log.Infof("To use the proxy configure your resolver, such as dnsmasq or systemd-resolved, to send the queries of %s to %s\n", proxy.Domain, address)
+	}
+	return proxy.ListenAndServe(address)
+}
@@ -50,12 +50,29 @@ func NewCmdEdit(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCmdCreateBranchPattern(f, out, errOut))
+	cmd.AddCommand(NewCmdEditActivityRetention(f, out, errOut))
 	cmd.AddCommand(NewCmdEditAddon(f, out, errOut))
+	cmd.AddCommand(NewCmdEditAuditWebhook(f, out, errOut))
+	cmd.AddCommand(NewCmdEditAutoscaling(f, out, errOut))
 	cmd.AddCommand(NewCmdEditBuildpack(f, out, errOut))
+	cmd.AddCommand(NewCmdEditBuildPod(f, out, errOut))
+	cmd.AddCommand(NewCmdEditCABundle(f, out, errOut))
 	cmd.AddCommand(NewCmdEditConfig(f, out, errOut))
+	cmd.AddCommand(NewCmdEditDomain(f, out, errOut))
 	cmd.AddCommand(NewCmdEditEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdEditExposure(f, out, errOut))
 	cmd.AddCommand(NewCmdEditHelmBin(f, out, errOut))
+	cmd.AddCommand(NewCmdEditIngress(f, out, errOut))
+	cmd.AddCommand(NewCmdEditNexusRetention(f, out, errOut))
+	cmd.AddCommand(NewCmdEditNotificationTemplate(f, out, errOut))
+	cmd.AddCommand(NewCmdEditPolicyRepo(f, out, errOut))
+	cmd.AddCommand(NewCmdEditPromotionGates(f, out, errOut))
+	cmd.AddCommand(NewCmdEditSecretScanner(f, out, errOut))
+	cmd.AddCommand(NewCmdEditSpotBuilds(f, out, errOut))
+	cmd.AddCommand(NewCmdEditStorage(f, out, errOut))
 	cmd.AddCommand(NewCmdEditUserRole(f, out, errOut))
+	cmd.AddCommand(NewCmdEditVulnerabilityPolicy(f, out, errOut))
+	cmd.AddCommand(NewCmdEditWSL(f, out, errOut))
 	return cmd
 }
 
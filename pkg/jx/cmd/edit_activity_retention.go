@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editActivityRetentionLong = templates.LongDesc(`
+		Configures the retention policy of the pipeline activities of your team
+
+		The build controller and 'jx gc activities' remove the PipelineActivity resources which are not kept by the
+		policy. The most recent activities of each branch are kept along with any younger than the maximum age.
+		Activities whose version has been released and those which are still running are never removed.
+`)
+
+	editActivityRetentionExample = templates.Examples(`
+		# Keep the last 10 activities of each branch
+		jx edit activity-retention --keep 10
+
+		# Keep the last 10 activities of each branch and any from the last 30 days
+		jx edit activity-retention --keep 10 --max-age-days 30
+	`)
+)
+
+// EditActivityRetentionOptions the options for the edit activity-retention command
+type EditActivityRetentionOptions struct {
+	EditOptions
+
+	Retention v1.ActivityRetention
+}
+
+// NewCmdEditActivityRetention creates a command object for the "edit activity-retention" command
+func NewCmdEditActivityRetention(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditActivityRetentionOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "activity-retention",
+		Short:   "Configures the retention policy of the pipeline activities of your team",
+		Aliases: []string{"activityretention", "activities"},
+		Long:    editActivityRetentionLong,
+		Example: editActivityRetentionExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.Retention.KeepPerBranch, "keep", "k", kube.DefaultActivityKeepPerBranch, "The number of the most recent activities to keep for each branch. Use 0 to keep all of them")
+	cmd.Flags().IntVarP(&options.Retention.MaxAgeDays, "max-age-days", "", 0, "The number of days after which activities are removed. Use 0 to keep activities regardless of age")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditActivityRetentionOptions) Run() error {
+	if o.Retention.KeepPerBranch < 0 {
+		return util.InvalidOptionf("keep", fmt.Sprintf("%d", o.Retention.KeepPerBranch), "the number of activities must not be negative")
+	}
+	if o.Retention.MaxAgeDays < 0 {
+		return util.InvalidOptionf("max-age-days", fmt.Sprintf("%d", o.Retention.MaxAgeDays), "the number of days must not be negative")
+	}
+	retention := o.Retention
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.ActivityRetention = &retention
+		return nil
+	}
+	err := o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	if retention.KeepPerBranch > 0 {
+		log.Infof("The last %s activities of each branch will be kept\n", util.ColorInfo(retention.KeepPerBranch))
+	}
+	if retention.MaxAgeDays > 0 {
+		log.Infof("Activities older than %s days will be removed\n", util.ColorInfo(retention.MaxAgeDays))
+	}
+	if retention.KeepPerBranch == 0 && retention.MaxAgeDays == 0 {
+		log.Infof("All activities will be kept\n")
+	}
+	return nil
+}
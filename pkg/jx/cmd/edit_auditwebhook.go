@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const auditWebhookNone = "none"
+
+var (
+	editAuditWebhookLong = templates.LongDesc(`
+		Configures an external webhook which is sent every entry of the audit log of your team
+
+		Each mutating jx command is posted to the webhook as JSON after it completes in addition to being stored in the
+		audit log of the team which can be viewed via 'jx get audit'.
+`)
+
+	editAuditWebhookExample = templates.Examples(`
+		# To post the audit log entries to a webhook:
+		jx edit auditwebhook https://audit.example.com/jx
+
+		# To post the audit log entries to a webhook on the domain the platform was installed with:
+		jx edit auditwebhook "https://audit.{{ .Requirements.Domain }}/jx"
+
+		# To stop posting the audit log entries:
+		jx edit auditwebhook none
+	`)
+)
+
+// EditAuditWebhookOptions the options for the edit auditwebhook command
+type EditAuditWebhookOptions struct {
+	CreateOptions
+}
+
+// NewCmdEditAuditWebhook creates a command object for the "edit auditwebhook" command
+func NewCmdEditAuditWebhook(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditAuditWebhookOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "auditwebhook",
+		Short:   "Configures the webhook which is sent the audit log entries of your team",
+		Aliases: []string{"audit"},
+		Long:    editAuditWebhookLong,
+		Example: editAuditWebhookExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditAuditWebhookOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the audit webhook URL")
+	}
+	arg := strings.TrimSpace(o.Args[0])
+	if arg != auditWebhookNone {
+		expanded := arg
+		if config.HasTemplateExpressions(arg) {
+			ctx, err := o.templateContext(nil)
+			if err != nil {
+				return err
+			}
+			expanded, err = ctx.Expand(arg)
+			if err != nil {
+				return util.InvalidArgError(arg, err)
+			}
+		}
+		u, err := url.Parse(expanded)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return util.InvalidArgError(arg, fmt.Errorf("the audit webhook must be an absolute URL"))
+		}
+	}
+
+	callback := func(env *v1.Environment) error {
+		if arg == auditWebhookNone {
+			env.Spec.TeamSettings.AuditWebhookURL = ""
+			log.Infof("Disabled the audit webhook\n")
+			return nil
+		}
+		env.Spec.TeamSettings.AuditWebhookURL = arg
+		log.Infof("Setting the audit webhook to: %s\n", util.ColorInfo(arg))
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
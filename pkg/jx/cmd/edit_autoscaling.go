@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os/user"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/autoscaling"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	editAutoscalingLong = templates.LongDesc(`
+		Configures the HorizontalPodAutoscaler of an app in one or more environments
+
+		The autoscaling settings are stored in the 'hpa' values of the app in the environment chart and the
+		HorizontalPodAutoscaler is generated from them when the environment is applied. For GitOps environments a
+		Pull Request is created. The metrics-server must be installed in the cluster for the autoscaler to work.
+
+		If no app is specified the autoscaling defaults of the team are configured instead. These are added to the
+		values of apps when they are first promoted to a permanent environment.
+`)
+
+	editAutoscalingExample = templates.Examples(`
+		# Scale an app between 2 and 10 replicas targeting 70% CPU utilization in production
+		jx edit autoscaling myapp --env production --min 2 --max 10 --cpu 70
+
+		# Disable the autoscaling of an app in staging
+		jx edit autoscaling myapp --env staging --disable
+
+		# Add autoscaling to all apps promoted to production
+		jx edit autoscaling --env production --min 2 --max 5 --cpu 80
+	`)
+)
+
+// EditAutoscalingOptions the options for the edit autoscaling command
+type EditAutoscalingOptions struct {
+	EditOptions
+
+	Environments      []string
+	MinReplicas       int32
+	MaxReplicas       int32
+	CPUUtilization    int32
+	MemoryUtilization int32
+	Disable           bool
+}
+
+// NewCmdEditAutoscaling creates a command object for the "edit autoscaling" command
+func NewCmdEditAutoscaling(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditAutoscalingOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "autoscaling [app]",
+		Short:   "Configures the HorizontalPodAutoscaler of an app or the autoscaling defaults of the team",
+		Aliases: []string{"hpa", "autoscaler"},
+		Long:    editAutoscalingLong,
+		Example: editAutoscalingExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Environments, optionEnvironment, "e", []string{}, "The environments to configure. For team defaults no environments means all permanent environments")
+	cmd.Flags().Int32VarP(&options.MinReplicas, "min", "", autoscaling.DefaultMinReplicas, "The minimum number of replicas")
+	cmd.Flags().Int32VarP(&options.MaxReplicas, "max", "", autoscaling.DefaultMaxReplicas, "The maximum number of replicas")
+	cmd.Flags().Int32VarP(&options.CPUUtilization, "cpu", "", autoscaling.DefaultTargetCPUUtilization, "The target average CPU utilization as a percentage of the requested CPU. Use 0 to only scale on memory")
+	cmd.Flags().Int32VarP(&options.MemoryUtilization, "memory", "", 0, "The target average memory utilization as a percentage of the requested memory")
+	cmd.Flags().BoolVarP(&options.Disable, "disable", "", false, "Removes the autoscaling settings")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditAutoscalingOptions) Run() error {
+	values := &autoscaling.Values{
+		Enabled:                        true,
+		MinReplicas:                    o.MinReplicas,
+		MaxReplicas:                    o.MaxReplicas,
+		CPUTargetAverageUtilization:    o.CPUUtilization,
+		MemoryTargetAverageUtilization: o.MemoryUtilization,
+	}
+	if o.Disable {
+		values = nil
+	} else {
+		err := values.Validate()
+		if err != nil {
+			return err
+		}
+		kubeClient, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		err = autoscaling.ValidateMetricsServer(kubeClient)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(o.Args) == 0 {
+		return o.editTeamDefaults(values)
+	}
+	app := o.Args[0]
+	if len(o.Environments) == 0 {
+		return util.MissingOption(optionEnvironment)
+	}
+	for _, envName := range o.Environments {
+		err := o.editAppAutoscaling(envName, app, values)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *EditAutoscalingOptions) editTeamDefaults(values *autoscaling.Values) error {
+	callback := func(env *v1.Environment) error {
+		if values == nil {
+			env.Spec.TeamSettings.AutoscalingDefaults = nil
+			log.Infof("Removed the autoscaling defaults of the team\n")
+			return nil
+		}
+		env.Spec.TeamSettings.AutoscalingDefaults = &v1.AutoscalingDefaults{
+			MinReplicas:             values.MinReplicas,
+			MaxReplicas:             values.MaxReplicas,
+			TargetCPUUtilization:    values.CPUTargetAverageUtilization,
+			TargetMemoryUtilization: values.MemoryTargetAverageUtilization,
+			Environments:            o.Environments,
+		}
+		envs := "all permanent environments"
+		if len(o.Environments) > 0 {
+			envs = strings.Join(o.Environments, ", ")
+		}
+		log.Infof("Apps promoted to %s will scale between %s and %s replicas\n", util.ColorInfo(envs), util.ColorInfo(values.MinReplicas), util.ColorInfo(values.MaxReplicas))
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
+
+func (o *EditAutoscalingOptions) editAppAutoscaling(envName string, app string, values *autoscaling.Values) error {
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(devNs).Get(envName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("no environment found called %s, try running `jx get env`: %s", envName, err)
+	}
+
+	if env.Spec.Source.URL == "" {
+		kubeClient, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		err = autoscaling.ApplyAutoscaler(kubeClient, env.Spec.Namespace, app, values)
+		if err != nil {
+			return err
+		}
+		log.Infof("Updated the HorizontalPodAutoscaler of app %s in namespace %s\n", util.ColorInfo(app), util.ColorInfo(env.Spec.Namespace))
+		return nil
+	}
+
+	u, err := user.Current()
+	if err != nil {
+		return err
+	}
+	branchName := "autoscaling-" + app
+	title := "Configure the autoscaling of app " + app
+	if values == nil {
+		title = "Disable the autoscaling of app " + app
+	}
+	message := "The command `jx edit autoscaling` was run by " + u.Username + " and it generated this Pull Request"
+
+	modifyRequirementsFn := func(requirements *helm.Requirements) error {
+		return nil
+	}
+	modifyDirFn := func(dir string) error {
+		return autoscaling.SetValues(filepath.Join(dir, "values.yaml"), app, values)
+	}
+	info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, modifyDirFn, branchName, title, message, nil, nil)
+	if err != nil {
+		return err
+	}
+	if info != nil && info.PullRequest != nil {
+		log.Infof("Created Pull Request %s to configure the autoscaling of app %s in environment %s\n", util.ColorInfo(info.PullRequest.URL), util.ColorInfo(app), util.ColorInfo(envName))
+	}
+	return nil
+}
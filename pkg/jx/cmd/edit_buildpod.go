@@ -0,0 +1,286 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	editBuildPodLong = templates.LongDesc(`
+		Customizes the pod templates used by the builds of your team
+
+		The customizations are stored in the team settings and applied to the build pod templates used by Jenkins
+		and by the knative builds created for Prow. If no pod template is specified all pod templates are
+		customized. Successive edits of the same pod template are merged; use --reset to remove the customization.
+
+		The image, resources, environment variables and volume mounts apply to the builder container.
+		Sidecars such as a docker daemon or buildkit are only added to Jenkins agent pods as knative builds do not
+		support sidecars.
+`)
+
+	editBuildPodExample = templates.Examples(`
+		# Use a custom maven builder image with more memory
+		jx edit buildpod maven --image myorg/builder-maven:1.0.0 --memory-request 1Gi --memory-limit 2Gi
+
+		# Add a docker daemon sidecar to all build pods
+		jx edit buildpod --sidecar docker
+
+		# Mount a secret into the builder container of the nodejs pod template
+		jx edit buildpod nodejs --secret npm-token=/home/jenkins/.npm-token
+
+		# Remove the customization of the maven pod template
+		jx edit buildpod maven --reset
+	`)
+)
+
+// EditBuildPodOptions the options for the edit buildpod command
+type EditBuildPodOptions struct {
+	EditOptions
+
+	Image          string
+	CPURequest     string
+	MemoryRequest  string
+	CPULimit       string
+	MemoryLimit    string
+	ServiceAccount string
+	Env            []string
+	Secrets        []string
+	ConfigMaps     []string
+	PVCs           []string
+	Sidecars       []string
+	Reset          bool
+}
+
+// NewCmdEditBuildPod creates a command object for the "edit buildpod" command
+func NewCmdEditBuildPod(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditBuildPodOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "buildpod [podTemplate]",
+		Short:   "Customizes the pod templates used by the builds of your team",
+		Aliases: []string{"buildpods", "build-pod", "podtemplate"},
+		Long:    editBuildPodLong,
+		Example: editBuildPodExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Image, "image", "i", "", "The image of the builder container")
+	cmd.Flags().StringVarP(&options.CPURequest, "cpu-request", "", "", "The CPU request of the builder container such as 500m")
+	cmd.Flags().StringVarP(&options.MemoryRequest, "memory-request", "", "", "The memory request of the builder container such as 512Mi")
+	cmd.Flags().StringVarP(&options.CPULimit, "cpu-limit", "", "", "The CPU limit of the builder container such as 2")
+	cmd.Flags().StringVarP(&options.MemoryLimit, "memory-limit", "", "", "The memory limit of the builder container such as 2Gi")
+	cmd.Flags().StringVarP(&options.ServiceAccount, "service-account", "", "", "The service account the build pods run as")
+	cmd.Flags().StringArrayVarP(&options.Env, "env", "e", []string{}, "The environment variables of the form NAME=value added to the builder container")
+	cmd.Flags().StringArrayVarP(&options.Secrets, "secret", "", []string{}, "The Secrets of the form name=mountPath mounted into the builder container")
+	cmd.Flags().StringArrayVarP(&options.ConfigMaps, "configmap", "", []string{}, "The ConfigMaps of the form name=mountPath mounted into the builder container")
+	cmd.Flags().StringArrayVarP(&options.PVCs, "pvc", "", []string{}, "The PersistentVolumeClaims of the form name=mountPath mounted into the builder container")
+	cmd.Flags().StringArrayVarP(&options.Sidecars, "sidecar", "", []string{}, fmt.Sprintf("The sidecars added to the build pods. Possible values: %s", strings.Join(kube.BuildPodSidecars, ", ")))
+	cmd.Flags().BoolVarP(&options.Reset, "reset", "", false, "Removes the customization of the pod template")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditBuildPodOptions) Run() error {
+	podTemplate := ""
+	if len(o.Args) > 0 {
+		podTemplate = o.Args[0]
+	}
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	var customizations []v1.BuildPodCustomization
+	callback := func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		answer := []v1.BuildPodCustomization{}
+		var existing *v1.BuildPodCustomization
+		for i := range settings.BuildPods {
+			c := settings.BuildPods[i]
+			if c.PodTemplate == podTemplate {
+				existing = &c
+			} else {
+				answer = append(answer, c)
+			}
+		}
+		if !o.Reset {
+			if existing == nil {
+				existing = &v1.BuildPodCustomization{PodTemplate: podTemplate}
+			}
+			err := o.modifyCustomization(existing)
+			if err != nil {
+				return err
+			}
+			answer = append(answer, *existing)
+		}
+		settings.BuildPods = answer
+		customizations = kube.BuildPodCustomizations(settings)
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	err = kube.UpdatePodTemplatesWithBuildPodCustomizations(kubeClient, ns, customizations)
+	if err != nil {
+		return err
+	}
+	if o.ServiceAccount != "" && !o.Reset && kube.IsOpenShiftCluster(kubeClient) {
+		err = o.enableOpenShiftBuildPodSCC(ns, o.ServiceAccount)
+		if err != nil {
+			return err
+		}
+	}
+
+	name := podTemplate
+	if name == "" {
+		name = "all pod templates"
+	}
+	if o.Reset {
+		log.Infof("Removed the customization of %s\n", util.ColorInfo(name))
+	} else {
+		log.Infof("Customized %s\n", util.ColorInfo(name))
+	}
+	return nil
+}
+
+// modifyCustomization merges the command line options into the customization
+func (o *EditBuildPodOptions) modifyCustomization(c *v1.BuildPodCustomization) error {
+	if o.Image != "" {
+		c.Image = o.Image
+	}
+	if o.ServiceAccount != "" {
+		c.ServiceAccountName = o.ServiceAccount
+	}
+	quantities := []struct {
+		option string
+		value  string
+		limit  bool
+		name   corev1.ResourceName
+	}{
+		{"cpu-request", o.CPURequest, false, corev1.ResourceCPU},
+		{"memory-request", o.MemoryRequest, false, corev1.ResourceMemory},
+		{"cpu-limit", o.CPULimit, true, corev1.ResourceCPU},
+		{"memory-limit", o.MemoryLimit, true, corev1.ResourceMemory},
+	}
+	for _, q := range quantities {
+		if q.value == "" {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(q.value)
+		if err != nil {
+			return util.InvalidOptionError(q.option, q.value, err)
+		}
+		if c.Resources == nil {
+			c.Resources = &corev1.ResourceRequirements{}
+		}
+		list := &c.Resources.Requests
+		if q.limit {
+			list = &c.Resources.Limits
+		}
+		if *list == nil {
+			*list = corev1.ResourceList{}
+		}
+		(*list)[q.name] = quantity
+	}
+	for _, text := range o.Env {
+		parts := strings.SplitN(text, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return util.InvalidOptionf("env", text, "the environment variable must be of the form NAME=value")
+		}
+		c.Env = addBuildPodEnvVar(c.Env, corev1.EnvVar{Name: parts[0], Value: parts[1]})
+	}
+
+	volumeOptions := []struct {
+		option string
+		values []string
+		source func(name string) corev1.VolumeSource
+	}{
+		{"secret", o.Secrets, func(name string) corev1.VolumeSource {
+			return corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: name}}
+		}},
+		{"configmap", o.ConfigMaps, func(name string) corev1.VolumeSource {
+			return corev1.VolumeSource{ConfigMap: &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: name}}}
+		}},
+		{"pvc", o.PVCs, func(name string) corev1.VolumeSource {
+			return corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name}}
+		}},
+	}
+	for _, vo := range volumeOptions {
+		for _, text := range vo.values {
+			parts := strings.SplitN(text, "=", 2)
+			if len(parts) != 2 || parts[0] == "" || !strings.HasPrefix(parts[1], "/") {
+				return util.InvalidOptionf(vo.option, text, "the value must be of the form name=/mount/path")
+			}
+			volumeName := kube.ToValidName("custom-" + vo.option + "-" + parts[0])
+			if kube.GetVolume(&c.Volumes, volumeName) == nil {
+				c.Volumes = append(c.Volumes, corev1.Volume{Name: volumeName, VolumeSource: vo.source(parts[0])})
+			}
+			mount := kube.GetVolumeMount(&c.VolumeMounts, volumeName)
+			if mount != nil {
+				mount.MountPath = parts[1]
+			} else {
+				c.VolumeMounts = append(c.VolumeMounts, corev1.VolumeMount{Name: volumeName, MountPath: parts[1]})
+			}
+		}
+	}
+
+	for _, name := range o.Sidecars {
+		sidecar, env, err := kube.NewSidecar(name)
+		if err != nil {
+			return util.InvalidOptionError("sidecar", name, err)
+		}
+		found := false
+		for i := range c.Sidecars {
+			if c.Sidecars[i].Name == sidecar.Name {
+				c.Sidecars[i] = *sidecar
+				found = true
+			}
+		}
+		if !found {
+			c.Sidecars = append(c.Sidecars, *sidecar)
+		}
+		for _, e := range env {
+			c.Env = addBuildPodEnvVar(c.Env, e)
+		}
+	}
+	return nil
+}
+
+func addBuildPodEnvVar(env []corev1.EnvVar, envVar corev1.EnvVar) []corev1.EnvVar {
+	for i := range env {
+		if env[i].Name == envVar.Name {
+			env[i] = envVar
+			return env
+		}
+	}
+	return append(env, envVar)
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	editCABundleLong = templates.LongDesc(`
+		Configures the certificate authorities of the self-hosted git servers, docker registries and Jenkins of your team
+
+		The PEM encoded certificate authorities are stored in the team settings and trusted in addition to the system
+		roots by jx when it connects to these services and when it adds helm repositories. The bundle is also mounted
+		into the build pods of the team at ` + kube.CABundleMountPath + ` and the JX_CA_BUNDLE and NODE_EXTRA_CA_CERTS
+		environment variables point at it.
+
+		To trust a CA bundle on your machine only set the caBundle setting of the jx settings file or the
+		JX_CA_BUNDLE environment variable to the PEM file instead.
+`)
+
+	editCABundleExample = templates.Examples(`
+		# Trust the certificate authority of the self-hosted GitLab and Harbor of your team
+		jx edit cabundle company-ca.pem
+
+		# Remove the CA bundle of your team
+		jx edit cabundle --reset
+	`)
+)
+
+// EditCABundleOptions the options for the edit cabundle command
+type EditCABundleOptions struct {
+	EditOptions
+
+	Reset bool
+}
+
+// NewCmdEditCABundle creates a command object for the "edit cabundle" command
+func NewCmdEditCABundle(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditCABundleOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "cabundle [file]",
+		Short:   "Configures the certificate authorities of the self-hosted services of your team",
+		Aliases: []string{"ca-bundle", "ca"},
+		Long:    editCABundleLong,
+		Example: editCABundleExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Reset, "reset", "", false, "Removes the CA bundle of the team")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditCABundleOptions) Run() error {
+	caBundle := ""
+	if !o.Reset {
+		if len(o.Args) == 0 {
+			return fmt.Errorf("missing the PEM file of the CA bundle")
+		}
+		fileName := o.Args[0]
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return fmt.Errorf("failed to load the CA bundle %s: %s", fileName, err)
+		}
+		if !x509.NewCertPool().AppendCertsFromPEM(data) {
+			return util.InvalidArgError(fileName, fmt.Errorf("no PEM certificates found in the CA bundle"))
+		}
+		caBundle = string(data)
+	}
+
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = kube.UpdateCABundleConfigMap(kubeClient, ns, caBundle)
+	if err != nil {
+		return fmt.Errorf("failed to update ConfigMap %s in namespace %s: %s", kube.ConfigMapNameJXCABundle, ns, err)
+	}
+
+	var customizations []v1.BuildPodCustomization
+	callback := func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		settings.CABundle = caBundle
+		customizations = kube.BuildPodCustomizations(settings)
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	if err == nil {
+		err = kube.UpdatePodTemplatesWithBuildPodCustomizations(kubeClient, ns, customizations)
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+
+	if o.Reset {
+		log.Infof("Removed the CA bundle of the team\n")
+	} else {
+		log.Infof("The team now trusts the CA bundle %s\n", util.ColorInfo(o.Args[0]))
+	}
+	return nil
+}
@@ -0,0 +1,381 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	editDomainLong = templates.LongDesc(`
+		Changes the ingress domain of the team.
+
+		The change is made in the following steps:
+
+		* updates the domain in the exposecontroller config of the team
+		* re-exposes the services of the development and permanent environment namespaces on the new domain
+		* re-issues the TLS certificates when TLS is enabled
+		* updates the external URL of Jenkins
+		* re-registers the webhooks of the git repositories of the team and removes the webhooks on the old domain
+		* creates Pull Requests to change the domain in the environment repositories which configure one
+
+		If the services cannot be exposed on the new domain the team is switched back to the old domain. If the
+		webhooks or environment repositories cannot be updated the command can be run again with '--previous-domain'
+		to retry those steps.
+`)
+
+	editDomainExample = templates.Examples(`
+		# Moves the team to a new domain
+		jx edit domain jx.example.com
+
+		# Retries updating the webhooks and environments after the services were moved to the new domain
+		jx edit domain jx.example.com --previous-domain 1.2.3.4.nip.io
+	`)
+)
+
+// EditDomainOptions the options for the edit domain command
+type EditDomainOptions struct {
+	EditOptions
+
+	PreviousDomain   string
+	SkipWebhooks     bool
+	SkipEnvironments bool
+	WaitTimeout      time.Duration
+}
+
+// NewCmdEditDomain creates a command object for the "edit domain" command
+func NewCmdEditDomain(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditDomainOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "domain DOMAIN",
+		Short:   "Changes the ingress domain of the team updating its services, certificates, webhooks and environments",
+		Long:    editDomainLong,
+		Example: editDomainExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.PreviousDomain, "previous-domain", "", "", "The domain the team used before if the services were already moved to the new domain by an earlier run")
+	cmd.Flags().BoolVarP(&options.SkipWebhooks, "skip-webhooks", "", false, "Does not re-register the webhooks of the git repositories")
+	cmd.Flags().BoolVarP(&options.SkipEnvironments, "skip-environments", "", false, "Does not create Pull Requests to change the domain in the environment repositories")
+	cmd.Flags().DurationVarP(&options.WaitTimeout, "wait-timeout", "", 10*time.Minute, "The time to wait for the recreated ingress rules to be assigned an address and their TLS certificates to be issued. Use 0 to not wait")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditDomainOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the new domain")
+	}
+	domain := strings.ToLower(strings.TrimSuffix(o.Args[0], "."))
+	err := kube.ValidateSubDomain(domain)
+	if err != nil {
+		return util.InvalidArgError(domain, err)
+	}
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	ic, err := kube.GetIngressConfig(client, devNs)
+	if err != nil {
+		return errors.Wrapf(err, "cannot get the ingress config of namespace %s", devNs)
+	}
+	oldDomain := ic.Domain
+	moved := false
+	if oldDomain == domain {
+		if o.PreviousDomain == "" {
+			return fmt.Errorf("the team already uses the domain %s", domain)
+		}
+		oldDomain = o.PreviousDomain
+		moved = true
+	}
+	namespaces, err := o.teamNamespaces(devNs)
+	if err != nil {
+		return err
+	}
+
+	if !o.BatchMode && !util.Confirm(fmt.Sprintf("Change the domain of the team from %s to %s?", oldDomain, domain), true,
+		fmt.Sprintf("The services in the namespaces %s will be exposed on the new domain", strings.Join(namespaces, ", "))) {
+		return nil
+	}
+
+	if !moved {
+		newConfig := ic
+		newConfig.Domain = domain
+		err = o.exposeOnDomain(devNs, namespaces, newConfig)
+		if err != nil {
+			log.Warnf("Failed to move the team to domain %s so restoring domain %s: %s\n", domain, oldDomain, err)
+			rollbackErr := o.exposeOnDomain(devNs, namespaces, ic)
+			if rollbackErr != nil {
+				return errors.Wrapf(err, "failed to restore domain %s: %s", oldDomain, rollbackErr)
+			}
+			return err
+		}
+		log.Infof("The services of the team are now exposed on the domain %s\n", util.ColorInfo(domain))
+	}
+
+	retry := fmt.Sprintf("jx edit domain %s --previous-domain %s", domain, oldDomain)
+	if !o.SkipWebhooks {
+		err = o.updateWebhookDomains(devNs, oldDomain, domain)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update the webhooks, retry via '%s'", retry)
+		}
+	}
+	if !o.SkipEnvironments {
+		err = o.updateEnvironmentDomains(devNs, domain)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update the environments, retry via '%s'", retry)
+		}
+	}
+	log.Successf("The team now uses the domain %s\n", domain)
+	return nil
+}
+
+// teamNamespaces returns the dev namespace and the namespaces of the permanent environments of the team
+func (o *EditDomainOptions) teamNamespaces(devNs string) ([]string, error) {
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return nil, err
+	}
+	envs, names, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{devNs}
+	for _, name := range names {
+		env := envs[name]
+		ns := env.Spec.Namespace
+		if env.Spec.Kind == v1.EnvironmentKindTypePermanent && ns != "" && util.StringArrayIndex(answer, ns) < 0 {
+			answer = append(answer, ns)
+		}
+	}
+	return answer, nil
+}
+
+// exposeOnDomain saves the ingress config and recreates the ingress rules and certificates of the namespaces so that
+// the services are exposed on the domain of the config, then updates the URLs which depend on them
+func (o *EditDomainOptions) exposeOnDomain(devNs string, namespaces []string, ic kube.IngressConfig) error {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	_, err = kube.SaveAsConfigMap(client, kube.ConfigMapIngressConfig, devNs, ic)
+	if err != nil {
+		return err
+	}
+	upgrade := &UpgradeIngressOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: o.CommonOptions,
+		},
+		IngressConfig:    ic,
+		TargetNamespaces: namespaces,
+	}
+	err = upgrade.CleanServiceAnnotations()
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		ingresses, err := client.ExtensionsV1beta1().Ingresses(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("cannot list the ingresses in namespace %s: %v", ns, err)
+		}
+		for _, ing := range ingresses.Items {
+			if ing.Annotations[kube.ExposeGeneratedByAnnotation] != Exposecontroller {
+				continue
+			}
+			err = client.ExtensionsV1beta1().Ingresses(ns).Delete(ing.Name, &metav1.DeleteOptions{})
+			if err != nil {
+				return fmt.Errorf("cannot delete ingress rule %s in namespace %s: %v", ing.Name, ns, err)
+			}
+		}
+		if ic.TLS {
+			// the certificates are only valid for the hosts of the old domain
+			err = upgrade.cleanTLSSecrets(ns)
+			if err != nil {
+				return err
+			}
+			err = kube.CleanCertmanagerResources(client, ns, ic)
+			if err != nil {
+				return err
+			}
+		}
+		err = o.expose(devNs, ns, "")
+		if err != nil {
+			return err
+		}
+	}
+	if o.WaitTimeout > 0 {
+		err = o.waitForExposedIngresses(namespaces, ic.TLS, o.WaitTimeout)
+		if err != nil {
+			return err
+		}
+	}
+	err = o.updateOAuth2ProxyExposure(devNs)
+	if err != nil {
+		return err
+	}
+	return o.updateJenkinsURL(namespaces)
+}
+
+// updateOAuth2ProxyExposure points the services which require a login at the OAuth2 proxy on its new URL
+func (o *EditDomainOptions) updateOAuth2ProxyExposure(devNs string) error {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	proxyURL := ""
+	for _, e := range settings.Exposure {
+		if e.Policy != v1.ExposurePolicyOAuth {
+			continue
+		}
+		if proxyURL == "" {
+			proxyURL, err = kube.FindServiceURL(client, devNs, kube.OAuth2ProxyName)
+			if err != nil {
+				return err
+			}
+			if proxyURL == "" {
+				return fmt.Errorf("the %s service in namespace %s is not exposed", kube.OAuth2ProxyName, devNs)
+			}
+		}
+		_, err = kube.ApplyServiceExposure(client, devNs, e.Service, e.Policy, proxyURL)
+		if err != nil {
+			return err
+		}
+	}
+	if proxyURL != "" {
+		log.Warnf("Please update the OAuth2 proxy via 'jx create addon oauth2-proxy' and register %s as the callback URL of the OAuth application\n",
+			util.UrlJoin(proxyURL, "oauth2", "callback"))
+	}
+	return nil
+}
+
+// updateWebhookDomains registers the webhooks of the git repositories of the team on the new domain and removes the
+// webhooks on the old domain
+func (o *EditDomainOptions) updateWebhookDomains(devNs string, oldDomain string, domain string) error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	devEnv, err := kube.GetEnrichedDevEnvironment(kubeClient, jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	jenkinsURL := ""
+	webhookEngine := devEnv.Spec.WebHookEngine
+	if webhookEngine != v1.WebHookEngineProw && webhookEngine != v1.WebHookEngineLighthouse {
+		jenk, err := o.JenkinsClient()
+		if err != nil {
+			return errors.Wrap(err, "failed to connect to Jenkins")
+		}
+		jenkinsURL = jenk.BaseURL()
+	}
+	migrate := &AdminMigrateProwOptions{
+		CommonOptions: o.CommonOptions,
+	}
+	repos, err := migrate.migrationRepositories()
+	if err != nil {
+		return err
+	}
+	for _, repo := range repos {
+		gitInfo, err := gits.ParseGitURL(repo.GitURL)
+		if err != nil {
+			return err
+		}
+		fullName := gitInfo.Organisation + "/" + gitInfo.Name
+		gitProvider, err := o.gitProviderForURL(repo.GitURL, "repository "+fullName)
+		if err != nil {
+			return err
+		}
+		if jenkinsURL == "" {
+			err = o.createWebhookProw(repo.GitURL, gitProvider)
+		} else {
+			err = gitProvider.CreateWebHook(&gits.GitWebHookArguments{
+				Owner: gitInfo.Organisation,
+				Repo:  gitInfo,
+				URL:   util.UrlJoin(jenkinsURL, gitProvider.JenkinsWebHookPath(repo.GitURL, "")),
+			})
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the webhook for %s", fullName)
+		}
+		err = deleteWebHooks(gitProvider, gitInfo, func(hookURL string) bool {
+			return kube.IsURLInDomain(hookURL, oldDomain) && !kube.IsURLInDomain(hookURL, domain)
+		})
+		if err != nil {
+			return err
+		}
+		log.Infof("Updated the webhooks of %s\n", util.ColorInfo(fullName))
+	}
+	return nil
+}
+
+// updateEnvironmentDomains creates Pull Requests which change the domain of the environment repositories which
+// configure one
+func (o *EditDomainOptions) updateEnvironmentDomains(devNs string, domain string) error {
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	envs, names, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	modifyRequirementsFn := func(requirements *helm.Requirements) error {
+		return nil
+	}
+	modifyDirFn := func(dir string) error {
+		_, err := kube.SetEnvironmentValuesDomain(filepath.Join(dir, "values.yaml"), domain)
+		return err
+	}
+	for _, name := range names {
+		env := envs[name]
+		gitURL := env.Spec.Source.URL
+		if (env.Spec.Kind != v1.EnvironmentKindTypePermanent && env.Spec.Kind != v1.EnvironmentKindTypeDevelopment) || gitURL == "" || strings.HasPrefix(gitURL, "file:") {
+			continue
+		}
+		info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, modifyDirFn, "change-domain-"+domain,
+			"Change the domain to "+domain, fmt.Sprintf("Changes the domain of environment %s to %s", name, domain), nil, nil)
+		if err != nil {
+			return errors.Wrapf(err, "failed to create the Pull Request for environment %s", name)
+		}
+		if info != nil && info.PullRequest != nil {
+			log.Infof("Created Pull Request %s to change the domain of environment %s\n", util.ColorInfo(info.PullRequest.URL), util.ColorInfo(name))
+		}
+	}
+	return nil
+}
@@ -1,11 +1,14 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jenkins"
@@ -28,9 +31,22 @@ var (
 
 		# Edit the prod Environment in batch mode (so not interactive)
 		jx edit env -b -n prod -l Production --no-gitops --namespace my-prod
+
+		# Lock the production Environment for a release freeze of a week rejecting promotions to it
+		jx edit env production --lock --lock-reason "release freeze" --lock-expires 168h
+
+		# Lock the staging Environment queueing promotions to it until it is unlocked
+		jx edit env staging --lock --lock-queue
+
+		# Unlock the production Environment
+		jx edit env production --unlock
 	`)
 )
 
+const (
+	optionLockExpires = "lock-expires"
+)
+
 // EditEnvOptions the options for the create spring command
 type EditEnvOptions struct {
 	CreateOptions
@@ -44,6 +60,11 @@ type EditEnvOptions struct {
 	GitRepositoryOptions   gits.GitRepositoryOptions
 	Prefix                 string
 	BranchPattern          string
+	Lock                   bool
+	Unlock                 bool
+	LockReason             string
+	LockExpires            string
+	LockQueue              bool
 }
 
 // NewCmdEditEnv creates a command object for the "create" command
@@ -92,6 +113,12 @@ func NewCmdEditEnv(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 
 	cmd.Flags().BoolVarP(&options.NoGitOps, "no-gitops", "x", false, "Disables the use of GitOps on the environment so that promotion is implemented by directly modifying the resources via helm instead of using a git repository")
 
+	cmd.Flags().BoolVarP(&options.Lock, "lock", "", false, "Locks the Environment such as during a release freeze so that promotions to it are rejected or queued")
+	cmd.Flags().BoolVarP(&options.Unlock, "unlock", "", false, "Unlocks the Environment allowing promotions to it again")
+	cmd.Flags().StringVarP(&options.LockReason, "lock-reason", "", "", "The reason the Environment is locked which is reported to anyone promoting to it")
+	cmd.Flags().StringVarP(&options.LockExpires, optionLockExpires, "", "", "The duration after which the lock expires such as '48h'. If not specified the Environment stays locked until it is unlocked")
+	cmd.Flags().BoolVarP(&options.LockQueue, "lock-queue", "", false, "Queues promotions to the locked Environment until it is unlocked rather than rejecting them")
+
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
 	options.HelmValuesConfig.AddExposeControllerValues(cmd, false)
 	return cmd
@@ -148,6 +175,9 @@ func (o *EditEnvOptions) Run() error {
 	if err != nil {
 		return util.InvalidArg(name, envNames)
 	}
+	if o.Lock || o.Unlock {
+		return o.updateLock(jxClient, ns, env)
+	}
 
 	devEnv, err := kube.EnsureDevEnvironmentSetup(jxClient, ns)
 	if err != nil {
@@ -169,6 +199,10 @@ func (o *EditEnvOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	err = o.ensureTillerCanManageNamespace(env.Spec.Namespace)
+	if err != nil {
+		return err
+	}
 	gitURL := env.Spec.Source.URL
 	if gitURL != "" {
 		if gitProvider == nil {
@@ -182,3 +216,41 @@ func (o *EditEnvOptions) Run() error {
 	}
 	return nil
 }
+
+// updateLock locks or unlocks the environment
+func (o *EditEnvOptions) updateLock(jxClient versioned.Interface, ns string, env *v1.Environment) error {
+	if o.Lock && o.Unlock {
+		return fmt.Errorf("cannot use --lock and --unlock together")
+	}
+	if o.Unlock {
+		if env.Spec.Lock == nil {
+			log.Infof("Environment %s is not locked\n", util.ColorInfo(env.Name))
+			return nil
+		}
+		env.Spec.Lock = nil
+	} else {
+		now := time.Now()
+		expires := time.Time{}
+		if o.LockExpires != "" {
+			duration, err := time.ParseDuration(o.LockExpires)
+			if err != nil {
+				return util.InvalidOptionf(optionLockExpires, o.LockExpires, "must be a duration such as 48h: %s", err)
+			}
+			if duration <= 0 {
+				return util.InvalidOptionf(optionLockExpires, o.LockExpires, "must be positive")
+			}
+			expires = now.Add(duration)
+		}
+		kube.LockEnvironment(env, o.LockReason, currentUserName(), o.LockQueue, now, expires)
+	}
+	_, err := jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("failed to update environment %s: %s", env.Name, err)
+	}
+	if env.Spec.Lock == nil {
+		log.Infof("Unlocked environment %s\n", util.ColorInfo(env.Name))
+	} else {
+		log.Infof("Locked %s\n", util.ColorInfo(kube.EnvironmentLockMessage(env)))
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editExposureLong = templates.LongDesc(`
+		Configures how a platform service of your team, such as Deck, Octant or a custom console, is exposed
+
+		The policies are:
+
+		* public exposes the service via an Ingress
+		* oauth exposes the service via an Ingress which requires a login via the OAuth2 proxy. The proxy is installed if it is missing
+		* internal removes the Ingress so the service can only be accessed via a port forward
+`)
+
+	editExposureExample = templates.Examples(`
+		# Require a GitHub login to access Deck
+		jx edit exposure deck --policy oauth --oauth-client-id 123 --oauth-client-secret 456
+
+		# Require a login via an OpenID Connect issuer to access Octant
+		jx edit exposure octant --policy oauth --oauth-provider oidc --oidc-issuer-url https://dex.example.com --oauth-client-id 123 --oauth-client-secret 456
+
+		# Only allow access to Octant via a port forward
+		jx edit exposure octant --policy internal
+	`)
+)
+
+// EditExposureOptions the options for the edit exposure command
+type EditExposureOptions struct {
+	EditOptions
+
+	Policy        string
+	ProxySettings kube.OAuth2ProxySettings
+}
+
+// NewCmdEditExposure creates a command object for the "edit exposure" command
+func NewCmdEditExposure(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditExposureOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "exposure SERVICE",
+		Short:   "Configures whether a platform service is exposed publicly, behind an OAuth2 login or only internally",
+		Aliases: []string{"expose"},
+		Long:    editExposureLong,
+		Example: editExposureExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Policy, "policy", "p", "", "The exposure policy. One of: public, oauth, internal")
+	addOAuth2ProxyFlags(cmd, &options.ProxySettings)
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditExposureOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the name of the service")
+	}
+	if util.StringArrayIndex(v1.ExposurePolicyTypeValues, o.Policy) < 0 {
+		return util.InvalidOption("policy", o.Policy, v1.ExposurePolicyTypeValues)
+	}
+	return o.configureServiceExposure(o.Args[0], v1.ExposurePolicyType(o.Policy), &o.ProxySettings)
+}
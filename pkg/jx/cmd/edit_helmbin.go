@@ -75,6 +75,12 @@ func (o *EditHelmBinOptions) Run() error {
 	if !strings.HasPrefix(arg, "helm") {
 		return util.InvalidArgError(arg, fmt.Errorf("Helm binary name should start with 'helm'"))
 	}
+	if arg == "helm3" {
+		err := o.requireExperiment(experimentHelm3)
+		if err != nil {
+			return err
+		}
+	}
 
 	callback := func(env *v1.Environment) error {
 		env.Spec.TeamSettings.HelmBinary = arg
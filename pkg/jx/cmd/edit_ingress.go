@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	editIngressLong = templates.LongDesc(`
+		Restricts who can access an exposed service with a basic authentication login or an IP allowlist
+
+		The settings are stored as ingress annotations of the service so that they are kept when exposecontroller
+		generates the Ingress again. For single sign on see 'jx create addon oauth2-proxy'.
+`)
+
+	editIngressExample = templates.Examples(`
+		# Require a login to access Nexus generating the password
+		jx edit ingress nexus --basic-auth
+
+		# Only allow access to ChartMuseum from the office network and a single address
+		jx edit ingress jenkins-x-chartmuseum --allow-cidr 203.0.113.0/24 --allow-cidr 198.51.100.7
+
+		# Allow access to ChartMuseum from anywhere again
+		jx edit ingress jenkins-x-chartmuseum --clear-allow-cidrs
+
+		# Remove the login of an app in the staging environment
+		jx edit ingress myapp -n jx-staging --disable-basic-auth
+	`)
+)
+
+// EditIngressOptions the options for the edit ingress command
+type EditIngressOptions struct {
+	EditOptions
+
+	Namespace        string
+	BasicAuth        bool
+	DisableBasicAuth bool
+	Username         string
+	Password         string
+	AllowCIDRs       []string
+	RemoveCIDRs      []string
+	ClearAllowCIDRs  bool
+}
+
+// NewCmdEditIngress creates a command object for the "edit ingress" command
+func NewCmdEditIngress(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditIngressOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "ingress SERVICE",
+		Short:   "Restricts access to an exposed service with basic authentication or an IP allowlist",
+		Aliases: []string{"ing"},
+		Long:    editIngressLong,
+		Example: editIngressExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the service. Defaults to the development namespace")
+	cmd.Flags().BoolVarP(&options.BasicAuth, "basic-auth", "", false, "Requires a basic authentication login")
+	cmd.Flags().BoolVarP(&options.DisableBasicAuth, "disable-basic-auth", "", false, "Removes the basic authentication login")
+	cmd.Flags().StringVarP(&options.Username, "username", "u", "admin", "The user name of the basic authentication login")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The password of the basic authentication login. Generated if not specified")
+	cmd.Flags().StringArrayVarP(&options.AllowCIDRs, "allow-cidr", "", []string{}, "Adds a CIDR or IP address which is allowed to access the service")
+	cmd.Flags().StringArrayVarP(&options.RemoveCIDRs, "remove-cidr", "", []string{}, "Removes a CIDR or IP address which is allowed to access the service")
+	cmd.Flags().BoolVarP(&options.ClearAllowCIDRs, "clear-allow-cidrs", "", false, "Allows access to the service from any address")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditIngressOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the name of the service")
+	}
+	name := o.Args[0]
+	if o.BasicAuth && o.DisableBasicAuth {
+		return fmt.Errorf("cannot use --basic-auth and --disable-basic-auth together")
+	}
+	allow, err := validateCIDRs("allow-cidr", o.AllowCIDRs)
+	if err != nil {
+		return err
+	}
+	remove, err := validateCIDRs("remove-cidr", o.RemoveCIDRs)
+	if err != nil {
+		return err
+	}
+	if !o.BasicAuth && !o.DisableBasicAuth && len(allow) == 0 && len(remove) == 0 && !o.ClearAllowCIDRs {
+		return fmt.Errorf("no changes specified. Use --basic-auth, --disable-basic-auth, --allow-cidr, --remove-cidr or --clear-allow-cidrs")
+	}
+
+	client, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if o.Namespace != "" {
+		ns = o.Namespace
+	}
+	svc, err := client.CoreV1().Services(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find service %s in namespace %s: %s", name, ns, err)
+	}
+	existing := kube.ParseIngressAnnotations(svc.Annotations[kube.ExposeIngressAnnotation])
+
+	secretName := ""
+	password := o.Password
+	if o.BasicAuth {
+		if existing[kube.IngressAuthURLAnnotation] != "" {
+			return fmt.Errorf("service %s is protected by the OAuth2 proxy. Use 'jx edit exposure %s --policy public' first", name, name)
+		}
+		if password == "" {
+			password, err = util.RandStringBytesMaskImprSrc(20)
+			if err != nil {
+				return err
+			}
+		}
+		secretName, err = kube.CreateBasicAuthSecret(client, ns, name, o.Username, password)
+		if err != nil {
+			return err
+		}
+	}
+
+	var cidrs []string
+	needsExpose, err := kube.UpdateExposedServiceIngressAnnotations(client, ns, name, func(annotations map[string]string) {
+		if o.BasicAuth {
+			for k, v := range kube.BasicAuthAnnotations(secretName) {
+				annotations[k] = v
+			}
+		}
+		if o.DisableBasicAuth {
+			kube.RemoveBasicAuthAnnotations(annotations)
+		}
+		cidrs = mergeCIDRs(kube.ParseAllowedCIDRs(annotations), allow, remove, o.ClearAllowCIDRs)
+		kube.SetAllowedCIDRs(annotations, cidrs)
+	})
+	if err != nil {
+		return err
+	}
+	if needsExpose {
+		_, devNs, err := o.KubeClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		err = o.expose(devNs, ns, "")
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.BasicAuth {
+		log.Infof("Service %s now requires a login as user %s", util.ColorInfo(name), util.ColorInfo(o.Username))
+		if o.Password == "" {
+			log.Infof(" with the generated password %s", util.ColorInfo(password))
+		}
+		log.Infof("\n")
+	}
+	if o.DisableBasicAuth {
+		log.Infof("Service %s no longer requires a login\n", util.ColorInfo(name))
+		err = client.CoreV1().Secrets(ns).Delete(kube.BasicAuthSecretName(name), &metav1.DeleteOptions{})
+		if err != nil {
+			log.Warnf("Failed to delete the Secret %s: %s\n", kube.BasicAuthSecretName(name), err)
+		}
+	}
+	if len(cidrs) == 0 {
+		log.Infof("Service %s can be accessed from any address\n", util.ColorInfo(name))
+	} else {
+		log.Infof("Service %s can only be accessed from %s\n", util.ColorInfo(name), util.ColorInfo(strings.Join(cidrs, ", ")))
+	}
+	return nil
+}
+
+func validateCIDRs(option string, values []string) ([]string, error) {
+	answer := []string{}
+	for _, value := range values {
+		cidr, err := kube.ValidateCIDR(value)
+		if err != nil {
+			return nil, util.InvalidOptionError(option, value, err)
+		}
+		answer = append(answer, cidr)
+	}
+	return answer, nil
+}
+
+// mergeCIDRs returns the existing CIDRs with the CIDRs to allow added and the CIDRs to remove removed
+func mergeCIDRs(existing []string, allow []string, remove []string, clear bool) []string {
+	answer := []string{}
+	if !clear {
+		for _, cidr := range existing {
+			if util.StringArrayIndex(remove, cidr) < 0 {
+				answer = append(answer, cidr)
+			}
+		}
+	}
+	for _, cidr := range allow {
+		if util.StringArrayIndex(answer, cidr) < 0 {
+			answer = append(answer, cidr)
+		}
+	}
+	return answer
+}
@@ -0,0 +1,16 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeCIDRs(t *testing.T) {
+	t.Parallel()
+	existing := []string{"203.0.113.0/24", "198.51.100.7/32"}
+	assert.Equal(t, []string{"203.0.113.0/24", "198.51.100.7/32", "192.0.2.0/24"}, mergeCIDRs(existing, []string{"192.0.2.0/24", "203.0.113.0/24"}, nil, false))
+	assert.Equal(t, []string{"203.0.113.0/24"}, mergeCIDRs(existing, nil, []string{"198.51.100.7/32"}, false))
+	assert.Equal(t, []string{"192.0.2.0/24"}, mergeCIDRs(existing, []string{"192.0.2.0/24"}, nil, true))
+	assert.Empty(t, mergeCIDRs(existing, nil, nil, true))
+}
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/nexus"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editNexusRetentionLong = templates.LongDesc(`
+		Configures the retention policies of the Nexus repository manager installed with Jenkins X
+
+		Nexus tasks are scheduled which remove old maven snapshots, prune docker images which have not been updated
+		recently and then compact the blob store so that the storage of Nexus does not fill up.
+`)
+
+	editNexusRetentionExample = templates.Examples(`
+		# Keep maven snapshots for 30 days and docker images for 14 days
+		jx edit nexusretention --snapshot-days 30 --docker-days 14
+
+		# Disable pruning of docker images and run the cleanup every Sunday
+		jx edit nexusretention --docker-days 0 --schedule "0 0 1 ? * SUN"
+	`)
+)
+
+// EditNexusRetentionOptions the options for the edit nexusretention command
+type EditNexusRetentionOptions struct {
+	EditOptions
+
+	Username string
+	Password string
+	Policy   nexus.RetentionPolicy
+}
+
+// NewCmdEditNexusRetention creates a command object for the "edit nexusretention" command
+func NewCmdEditNexusRetention(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditNexusRetentionOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "nexusretention",
+		Short:   "Configures the retention policies of Nexus",
+		Aliases: []string{"nexus"},
+		Long:    editNexusRetentionLong,
+		Example: editNexusRetentionExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.Policy.SnapshotRetentionDays, "snapshot-days", "", 30, "The number of days to keep maven snapshots for. Use 0 to disable the removal of snapshots")
+	cmd.Flags().IntVarP(&options.Policy.MinimumSnapshots, "min-snapshots", "", 1, "The minimum number of snapshots to keep for each artifact")
+	cmd.Flags().IntVarP(&options.Policy.DockerRetentionDays, "docker-days", "", 30, "The number of days since a docker image was last updated before it is deleted. Use 0 to disable the pruning of docker images")
+	cmd.Flags().StringVarP(&options.Policy.Schedule, "schedule", "", nexus.DefaultRetentionSchedule, "The cron expression of when the cleanup tasks run")
+	cmd.Flags().StringVarP(&options.Username, "username", "", nexus.DefaultUsername, "The Nexus admin user")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The password of the Nexus admin user. Defaults to the admin password specified when installing Jenkins X")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditNexusRetentionOptions) Run() error {
+	err := o.Policy.Validate()
+	if err != nil {
+		return err
+	}
+	client, err := o.createNexusClient(o.Username, o.Password)
+	if err != nil {
+		return err
+	}
+	err = client.ConfigureRetention(&o.Policy)
+	if err != nil {
+		return fmt.Errorf("failed to configure the retention policies of Nexus at %s: %s", client.BaseURL, err)
+	}
+	log.Infof("Configured the retention policies of Nexus at %s\n", util.ColorInfo(client.BaseURL))
+	if o.Policy.SnapshotRetentionDays > 0 {
+		log.Infof("Maven snapshots older than %s days will be removed keeping at least %d per artifact\n", util.ColorInfo(o.Policy.SnapshotRetentionDays), o.Policy.MinimumSnapshots)
+	}
+	if o.Policy.DockerRetentionDays > 0 {
+		log.Infof("Docker images not updated for %s days will be removed\n", util.ColorInfo(o.Policy.DockerRetentionDays))
+	}
+	return nil
+}
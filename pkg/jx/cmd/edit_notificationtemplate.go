@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const notificationTemplateNone = "none"
+
+var (
+	editNotificationTemplateLong = templates.LongDesc(`
+		Customises the message posted to the notification channels of your team for a kind of event
+
+		The template uses the go template syntax and can refer to the fields of the event:
+		.Pipeline, .Build, .Owner, .Repository, .Version, .Status, .Environment, .PullRequestURL and .URL
+
+		The kinds of events are: ` + strings.Join(notify.EventKinds, ", ") + `
+`)
+
+	editNotificationTemplateExample = templates.Examples(`
+		# Customise the message of successful promotions
+		jx edit notificationtemplate promotion-succeeded ":rocket: {{.Repository}} {{.Version}} is now in {{.Environment}}"
+
+		# Load the template from a file
+		jx edit notificationtemplate pipeline-failed --file failed.tmpl
+
+		# Revert to the default message
+		jx edit notificationtemplate pipeline-failed none
+	`)
+)
+
+// EditNotificationTemplateOptions the options for the edit notificationtemplate command
+type EditNotificationTemplateOptions struct {
+	CreateOptions
+
+	File string
+}
+
+// NewCmdEditNotificationTemplate creates a command object for the "edit notificationtemplate" command
+func NewCmdEditNotificationTemplate(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditNotificationTemplateOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "notificationtemplate event [template]",
+		Short:   "Customises the message posted to the notification channels for a kind of event",
+		Aliases: []string{"notifytemplate"},
+		Long:    editNotificationTemplateLong,
+		Example: editNotificationTemplateExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	cmd.Flags().StringVarP(&options.File, "file", "f", "", "The file containing the template")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditNotificationTemplateOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the kind of event: %s", strings.Join(notify.EventKinds, ", "))
+	}
+	kind := o.Args[0]
+	text := ""
+	if o.File != "" {
+		data, err := ioutil.ReadFile(o.File)
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	} else if len(o.Args) > 1 {
+		text = o.Args[1]
+	} else {
+		return fmt.Errorf("Missing argument for the template or the --file option")
+	}
+	if strings.TrimSpace(text) == notificationTemplateNone {
+		text = ""
+	}
+
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	err = notify.SaveTemplate(kubeClient, devNs, kind, text)
+	if err != nil {
+		return err
+	}
+	if text == "" {
+		log.Infof("Using the default template for %s events\n", util.ColorInfo(kind))
+	} else {
+		log.Infof("Updated the template for %s events\n", util.ColorInfo(kind))
+	}
+	return nil
+}
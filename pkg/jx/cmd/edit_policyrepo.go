@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/policy"
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const policyRepoNone = "none"
+
+var (
+	editPolicyRepoLong = templates.LongDesc(`
+		Configures the git repository containing the policies which the resources generated for your team must comply with
+
+		Before a helm chart is applied to an Environment or an Environment Pull Request is built the rendered resources
+		are validated against the policies. The built in policies reject images using the latest tag, containers without
+		resource limits and images from registries which are not allowed. They are configured via a '` + policy.ConfigFileName + `'
+		file in the root of the repository such as:
+
+		    noLatestTag: true
+		    requireResourceLimits: true
+		    allowedRegistries:
+		    - gcr.io/myproject
+
+		Any '.rego' files in the repository are also evaluated with the 'opa' binary. They should be in package 'jx' and add a
+		message to the 'deny' set for each violation of a resource which is passed as the input.
+`)
+
+	editPolicyRepoExample = templates.Examples(`
+		# To validate the resources of your team against the policies in a git repository:
+		jx edit policyrepo https://github.com/myorg/policies.git
+
+		# To disable the policy validation:
+		jx edit policyrepo none
+	`)
+)
+
+// EditPolicyRepoOptions the options for the edit policyrepo command
+type EditPolicyRepoOptions struct {
+	CreateOptions
+}
+
+// NewCmdEditPolicyRepo creates a command object for the "edit policyrepo" command
+func NewCmdEditPolicyRepo(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditPolicyRepoOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "policyrepo",
+		Short:   "Configures the git repository containing the policies for the resources generated for your team",
+		Aliases: []string{"policy", "policies"},
+		Long:    editPolicyRepoLong,
+		Example: editPolicyRepoExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditPolicyRepoOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the policy git repository URL")
+	}
+	arg := strings.TrimSpace(o.Args[0])
+	if arg == "" {
+		return util.InvalidArgError(arg, fmt.Errorf("the policy git repository URL cannot be blank"))
+	}
+
+	callback := func(env *v1.Environment) error {
+		if arg == policyRepoNone {
+			env.Spec.TeamSettings.PolicyGitURL = ""
+			log.Infof("Disabled the policy validation\n")
+			return nil
+		}
+		env.Spec.TeamSettings.PolicyGitURL = arg
+		log.Infof("Setting the policy repository to: %s\n", util.ColorInfo(arg))
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
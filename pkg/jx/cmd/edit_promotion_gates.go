@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	editPromotionGatesLong = templates.LongDesc(`
+		Configures the gates which must be open before an application can be promoted to an Environment.
+
+		Gates can require a number of approving reviews of the promotion Pull Request, an approver to comment
+		'/approve promote' on the Pull Request or restrict promotions to time windows. 'jx promote' only merges
+		the promotion Pull Request once all of the gates are open. If the team uses Prow then the environment
+		repository is removed from the Tide merge pool so that Pull Requests are not merged before the gates are open.
+
+		The specified gates replace any gates currently configured on the Environment.
+`)
+
+	editPromotionGatesExample = templates.Examples(`
+		# Require 2 approving reviews before promoting to production
+		jx edit promotiongates production --approvals 2
+
+		# Require one of the given users to comment '/approve promote' on the promotion Pull Request
+		jx edit promotiongates production --chatops --approvers alice,bob
+
+		# Only allow promotions during office hours
+		jx edit promotiongates production --window "Mon-Fri 09:00-17:00 Europe/London"
+
+		# Remove all of the promotion gates
+		jx edit promotiongates production --remove
+	`)
+)
+
+// EditPromotionGatesOptions the options for the edit promotiongates command
+type EditPromotionGatesOptions struct {
+	EditOptions
+
+	Environment string
+	Approvals   int
+	Approvers   []string
+	ChatOps     bool
+	Windows     []string
+	Remove      bool
+}
+
+// NewCmdEditPromotionGates creates a command object for the "edit promotiongates" command
+func NewCmdEditPromotionGates(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditPromotionGatesOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "promotiongates [environment]",
+		Short:   "Configures the gates which must be open before promoting to an Environment",
+		Aliases: []string{"promotiongate", "gates"},
+		Long:    editPromotionGatesLong,
+		Example: editPromotionGatesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The Environment to configure")
+	cmd.Flags().IntVarP(&options.Approvals, "approvals", "", 0, "The number of approving reviews the promotion Pull Request requires")
+	cmd.Flags().StringArrayVarP(&options.Approvers, "approvers", "", []string{}, "The users who can approve promotions. If not specified any user other than the Pull Request author can approve")
+	cmd.Flags().BoolVarP(&options.ChatOps, "chatops", "", false, fmt.Sprintf("Requires an approver to comment '%s' on the promotion Pull Request", kube.ChatOpsPromoteCommand))
+	cmd.Flags().StringArrayVarP(&options.Windows, "window", "w", []string{}, "A time window when promotions are allowed of the form '[Mon-Fri] 09:00-17:00 [Europe/London]'")
+	cmd.Flags().BoolVarP(&options.Remove, "remove", "", false, "Removes all of the promotion gates of the Environment")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditPromotionGatesOptions) Run() error {
+	if len(o.Args) > 0 {
+		o.Environment = o.Args[0]
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+	if o.Approvals < 0 {
+		return util.InvalidOptionf("approvals", strconv.Itoa(o.Approvals), "must not be negative")
+	}
+	gates := &v1.PromotionGates{
+		RequiredApprovals: o.Approvals,
+		ChatOps:           o.ChatOps,
+	}
+	for _, a := range o.Approvers {
+		for _, approver := range strings.Split(a, ",") {
+			approver = strings.TrimSpace(approver)
+			if approver != "" {
+				gates.Approvers = append(gates.Approvers, approver)
+			}
+		}
+	}
+	for _, text := range o.Windows {
+		w, err := kube.ParsePromotionWindow(text)
+		if err != nil {
+			return err
+		}
+		gates.Windows = append(gates.Windows, *w)
+	}
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	env, err := jxClient.JenkinsV1().Environments(ns).Get(o.Environment, metav1.GetOptions{})
+	if err != nil {
+		envNames, err2 := kube.GetEnvironmentNames(jxClient, ns)
+		if err2 != nil {
+			return err
+		}
+		return util.InvalidOption(optionEnvironment, o.Environment, envNames)
+	}
+	if o.Remove {
+		env.Spec.PromotionGates = nil
+	} else {
+		env.Spec.PromotionGates = gates
+		if !kube.HasPromotionGates(env) {
+			return fmt.Errorf("no promotion gates specified. Use --remove to remove the promotion gates of environment %s", env.Name)
+		}
+		if kube.RequiresPromotionApproval(gates) && env.Spec.Source.URL == "" {
+			return fmt.Errorf("environment %s does not use GitOps so promotions cannot be approved via Pull Requests", env.Name)
+		}
+	}
+	_, err = jxClient.JenkinsV1().Environments(ns).Update(env)
+	if err != nil {
+		return fmt.Errorf("failed to update environment %s: %s", env.Name, err)
+	}
+
+	err = o.updateProwPromotionGates(env, ns)
+	if err != nil {
+		return err
+	}
+
+	if env.Spec.PromotionGates == nil {
+		log.Infof("Removed the promotion gates of environment %s\n", util.ColorInfo(env.Name))
+		return nil
+	}
+	log.Infof("Updated the promotion gates of environment %s\n", util.ColorInfo(env.Name))
+	if gates.RequiredApprovals > 0 {
+		log.Infof("Promotions require %s approving reviews\n", util.ColorInfo(gates.RequiredApprovals))
+	}
+	if gates.ChatOps {
+		log.Infof("Promotions require an approver to comment %s\n", util.ColorInfo(kube.ChatOpsPromoteCommand))
+	}
+	if len(gates.Approvers) > 0 {
+		log.Infof("Promotions can be approved by %s\n", util.ColorInfo(strings.Join(gates.Approvers, ", ")))
+	}
+	if len(gates.Windows) > 0 {
+		log.Infof("Promotions are allowed during %s\n", util.ColorInfo(strings.Join(kube.PromotionWindowsToStrings(gates.Windows), ", ")))
+	}
+	return nil
+}
+
+// updateProwPromotionGates stops Tide merging the promotion Pull Requests of a gated environment if the team uses Prow
+func (o *EditPromotionGatesOptions) updateProwPromotionGates(env *v1.Environment, ns string) error {
+	if env.Spec.Source.URL == "" {
+		return nil
+	}
+	isProw, err := o.isProw()
+	if err != nil || !isProw {
+		return err
+	}
+	gitInfo, err := gits.ParseGitURL(env.Spec.Source.URL)
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	repo := gitInfo.Organisation + "/" + gitInfo.Name
+	err = prow.SetPromotionGated(kubeClient, repo, ns, kube.HasPromotionGates(env))
+	if err != nil {
+		return fmt.Errorf("failed to update the prow configuration of repository %s: %s", repo, err)
+	}
+	return nil
+}
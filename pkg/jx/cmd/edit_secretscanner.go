@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/secretscan"
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	editSecretScannerLong = templates.LongDesc(`
+		Configures the scanner used by your team to detect secrets committed to imported projects and Environment repositories
+
+		When a scanner is configured 'jx import' and 'jx promote' fail if secrets such as AWS keys or API tokens are found
+		unless the --allow-secrets flag is used. Lines containing the comment '` + secretscan.IgnoreMarker + `' are ignored by the built in scanner.
+
+		As the team settings are used on the machine of every developer of the team only the built in scanner and
+		the external scanners gitleaks and trufflehog, which must be on the PATH, can be configured. The command line
+		of any other scanner can be passed to 'jx import' and 'jx promote' via --secret-scanner.
+`)
+
+	editSecretScannerExample = templates.Examples(`
+		# To use the built in secret detector:
+		jx edit secretscanner builtin
+
+		# To use gitleaks:
+		jx edit secretscanner gitleaks
+
+		# To disable secret scanning:
+		jx edit secretscanner none
+	`)
+)
+
+// EditSecretScannerOptions the options for the edit secretscanner command
+type EditSecretScannerOptions struct {
+	CreateOptions
+}
+
+// NewCmdEditSecretScanner creates a command object for the "edit secretscanner" command
+func NewCmdEditSecretScanner(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditSecretScannerOptions{
+		CreateOptions: CreateOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "secretscanner",
+		Short:   "Configures the scanner used by your team to detect committed secrets",
+		Aliases: []string{"secretscan"},
+		Long:    editSecretScannerLong,
+		Example: editSecretScannerExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditSecretScannerOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the secret scanner")
+	}
+	arg := strings.TrimSpace(strings.Join(o.Args, " "))
+	if arg == "" {
+		return util.InvalidArgError(arg, fmt.Errorf("the secret scanner cannot be blank"))
+	}
+
+	_, err := secretscan.NewScanner(arg, false)
+	if err != nil {
+		return util.InvalidArgError(arg, err)
+	}
+
+	callback := func(env *v1.Environment) error {
+		if arg == secretscan.ScannerNone {
+			env.Spec.TeamSettings.SecretScanner = ""
+			log.Infof("Disabled secret scanning\n")
+			return nil
+		}
+		env.Spec.TeamSettings.SecretScanner = arg
+		log.Infof("Setting the secret scanner to: %s\n", util.ColorInfo(arg))
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var (
+	editSpotBuildsLong = templates.LongDesc(`
+		Configures the build pods of your team to run on spot or preemptible nodes
+
+		The node selector and tolerations are added to all of the build pod templates. If none are specified the
+		labels and taints of the spot or preemptible nodes of the cloud provider are used. Create a node pool of
+		spot or preemptible nodes via 'jx create nodepool --spot'.
+
+		Build pods which fail because their node was preempted are retried by the build controller up to the
+		maximum number of retries.
+`)
+
+	editSpotBuildsExample = templates.Examples(`
+		# Run builds on the preemptible nodes of a GKE cluster
+		jx edit spot-builds --provider gke
+
+		# Run builds on nodes with a custom label and taint retrying preempted builds 3 times
+		jx edit spot-builds --node-selector pool=spot --toleration spot=true:NoSchedule --max-retries 3
+
+		# Run builds on any node again
+		jx edit spot-builds --disable
+	`)
+)
+
+// EditSpotBuildsOptions the options for the edit spot-builds command
+type EditSpotBuildsOptions struct {
+	EditOptions
+
+	Provider     string
+	NodeSelector []string
+	Tolerations  []string
+	MaxRetries   int
+	Disable      bool
+}
+
+// NewCmdEditSpotBuilds creates a command object for the "edit spot-builds" command
+func NewCmdEditSpotBuilds(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditSpotBuildsOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "spot-builds",
+		Short:   "Configures the build pods of your team to run on spot or preemptible nodes",
+		Aliases: []string{"spot-build", "spot", "preemptible-builds"},
+		Long:    editSpotBuildsLong,
+		Example: editSpotBuildsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Provider, "provider", "", "", "The cloud provider whose spot or preemptible node labels and taints are used when no node selector or tolerations are specified")
+	cmd.Flags().StringArrayVarP(&options.NodeSelector, "node-selector", "", []string{}, "The node labels of the form key=value of the nodes to run build pods on")
+	cmd.Flags().StringArrayVarP(&options.Tolerations, "toleration", "", []string{}, "The node taints of the form key=value:Effect tolerated by build pods")
+	cmd.Flags().IntVarP(&options.MaxRetries, "max-retries", "", kube.DefaultSpotBuildMaxRetries, "The number of times a build whose node was preempted is retried")
+	cmd.Flags().BoolVarP(&options.Disable, "disable", "", false, "Runs build pods on any node again")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditSpotBuildsOptions) Run() error {
+	if o.Disable {
+		return o.configureSpotBuilds(nil)
+	}
+	if o.MaxRetries < 0 {
+		return util.InvalidOptionf("max-retries", fmt.Sprintf("%d", o.MaxRetries), "the number of retries must not be negative")
+	}
+	var settings *v1.SpotBuilds
+	if len(o.NodeSelector) == 0 && len(o.Tolerations) == 0 {
+		provider, err := o.GetCloudProvider(o.Provider)
+		if err != nil {
+			return err
+		}
+		settings, err = kube.DefaultSpotBuilds(provider)
+		if err != nil {
+			return err
+		}
+	} else {
+		settings = &v1.SpotBuilds{}
+		if len(o.NodeSelector) > 0 {
+			settings.NodeSelector = map[string]string{}
+		}
+		for _, text := range o.NodeSelector {
+			parts := strings.SplitN(text, "=", 2)
+			if len(parts) != 2 || parts[0] == "" {
+				return util.InvalidOptionf("node-selector", text, "the node selector must be of the form key=value")
+			}
+			settings.NodeSelector[parts[0]] = parts[1]
+		}
+		for _, text := range o.Tolerations {
+			toleration, err := kube.ParseToleration(text)
+			if err != nil {
+				return util.InvalidOptionError("toleration", text, err)
+			}
+			settings.Tolerations = append(settings.Tolerations, toleration)
+		}
+	}
+	settings.MaxRetries = o.MaxRetries
+	return o.configureSpotBuilds(settings)
+}
+
+// configureSpotBuilds saves the spot build settings of the team and applies them to the build pod templates.
+// Passing nil settings lets build pods run on any node again
+func (o *CommonOptions) configureSpotBuilds(settings *v1.SpotBuilds) error {
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.SpotBuilds = settings
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	err = kube.UpdatePodTemplatesWithSpotBuilds(kubeClient, ns, settings)
+	if err != nil {
+		return err
+	}
+	if settings == nil {
+		log.Infof("Build pods of the team can run on any node\n")
+		return nil
+	}
+	log.Infof("Build pods of the team will run on nodes matching %s tolerating %s\n", util.ColorInfo(formatNodeSelector(settings.NodeSelector)), util.ColorInfo(formatTolerations(settings.Tolerations)))
+	if settings.MaxRetries > 0 {
+		log.Infof("Builds whose node is preempted will be retried up to %s times\n", util.ColorInfo(settings.MaxRetries))
+	}
+	return nil
+}
+
+func formatNodeSelector(selector map[string]string) string {
+	answer := []string{}
+	for k, v := range selector {
+		answer = append(answer, k+"="+v)
+	}
+	if len(answer) == 0 {
+		return "any labels"
+	}
+	sort.Strings(answer)
+	return strings.Join(answer, ", ")
+}
+
+func formatTolerations(tolerations []corev1.Toleration) string {
+	answer := []string{}
+	for _, t := range tolerations {
+		text := t.Key
+		if t.Value != "" {
+			text += "=" + t.Value
+		}
+		if t.Effect != "" {
+			text += ":" + string(t.Effect)
+		}
+		answer = append(answer, text)
+	}
+	if len(answer) == 0 {
+		return "no taints"
+	}
+	return strings.Join(answer, ", ")
+}
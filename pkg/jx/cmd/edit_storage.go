@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+var (
+	editStorageLong = templates.LongDesc(`
+		Resizes the persistent volume of a platform component such as Jenkins, Nexus or ChartMuseum
+
+		The storage class of the PersistentVolumeClaim must allow volume expansion. Depending on the provisioner
+		the pod using the volume may need to be restarted for the file system to be resized.
+`)
+
+	editStorageExample = templates.Examples(`
+		# Resize the Jenkins home volume to 50Gi
+		jx edit storage jenkins --size 50Gi
+
+		# Resize a PersistentVolumeClaim by name
+		jx edit storage jenkins-x-nexus --size 100Gi
+	`)
+)
+
+// EditStorageOptions the options for the edit storage command
+type EditStorageOptions struct {
+	EditOptions
+
+	Namespace string
+	Size      string
+}
+
+// NewCmdEditStorage creates a command object for the "edit storage" command
+func NewCmdEditStorage(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditStorageOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "storage [pvc or component]",
+		Short:   "Resizes the persistent volume of a platform component",
+		Aliases: []string{"pvc"},
+		Long:    editStorageLong,
+		Example: editStorageExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Size, "size", "s", "", "The new size of the volume such as 50Gi")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the PersistentVolumeClaim. Defaults to the development namespace")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditStorageOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("Missing argument for the name of the PersistentVolumeClaim or platform component")
+	}
+	if o.Size == "" {
+		return util.MissingOption("size")
+	}
+	size, err := resource.ParseQuantity(o.Size)
+	if err != nil {
+		return util.InvalidOptionError("size", o.Size, err)
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		_, ns, err = o.JXClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+	}
+	pvc, err := kube.FindPersistentVolumeClaim(kubeClient, ns, o.Args[0])
+	if err != nil {
+		return err
+	}
+	_, err = kube.ResizePersistentVolumeClaim(kubeClient, pvc, size)
+	if err != nil {
+		return err
+	}
+	log.Infof("Resized PersistentVolumeClaim %s in namespace %s to %s\n", util.ColorInfo(pvc.Name), util.ColorInfo(ns), util.ColorInfo(size.String()))
+	return nil
+}
@@ -16,6 +16,9 @@ import (
 var (
 	editUserRoleLong = templates.LongDesc(`
 		Edits the Roles associated with a User
+
+		The default team roles admin, developer and viewer are created if they do not exist yet. The Jenkins
+		permissions of the user are updated to match the roles when Jenkins uses matrix based security.
 `)
 
 	editUserRoleExample = templates.Examples(`
@@ -64,7 +67,7 @@ func NewCmdEditUserRole(f Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 	}
 
 	cmd.Flags().StringVarP(&options.Login, optionLogin, "l", "", "The user login name")
-	cmd.Flags().StringArrayVarP(&options.Roles, "role", "r", []string{}, "The roles to set on a user")
+	cmd.Flags().StringArrayVarP(&options.Roles, optionRole, "r", []string{}, "The roles to set on a user")
 
 	options.addCommonFlags(cmd)
 	return cmd
@@ -121,7 +124,11 @@ func (o *EditUserRoleOptions) Run() error {
 	}
 	userKind := user.SubjectKind()
 
-	roles, roleNames, err := kube.GetTeamRoles(kubeClient, ns)
+	err = kube.EnsureDefaultTeamRoles(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	_, roleNames, err := kube.GetTeamRoles(kubeClient, ns)
 	if err != nil {
 		return err
 	}
@@ -142,7 +149,7 @@ func (o *EditUserRoleOptions) Run() error {
 	rolesText := strings.Join(userRoles, ", ")
 	log.Infof("updating user %s for roles %s\n", name, rolesText)
 
-	err = kube.UpdateUserRoles(kubeClient, jxClient, ns, userKind, name, userRoles, roles)
+	err = o.updateTeamMemberRoles(ns, user, userRoles)
 	if err != nil {
 		return errors.Wrapf(err, "Failed to update user roles for user %s kind %s and roles %s", name, userKind, rolesText)
 	}
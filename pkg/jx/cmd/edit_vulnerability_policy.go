@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editVulnerabilityPolicyLong = templates.LongDesc(`
+		Configures the policy used by your team to block promotions of images with known vulnerabilities.
+
+		Before promoting to an environment covered by the policy 'jx promote' scans the image of the app using trivy and
+		fails if the number of vulnerabilities of a severity exceeds its threshold. Severities without a threshold are
+		not checked. By default the policy applies to the production environment and blocks any critical vulnerabilities.
+
+		To promote an app regardless of its vulnerabilities annotate the environment with '` + kube.AnnotationVulnerabilityOverride + `'
+		using a comma separated list of app names or app:version pairs.
+`)
+
+	editVulnerabilityPolicyExample = templates.Examples(`
+		# Block promotions to production of images with any critical vulnerabilities
+		jx edit vulnerabilitypolicy
+
+		# Allow up to 5 high vulnerabilities but no critical ones in staging and production
+		jx edit vulnerabilitypolicy --threshold CRITICAL=0 --threshold HIGH=5 --env staging --env production
+
+		# Allow a single release with known vulnerabilities to be promoted
+		kubectl annotate env production ` + kube.AnnotationVulnerabilityOverride + `=myapp:1.2.3
+
+		# Remove the vulnerability policy
+		jx edit vulnerabilitypolicy --remove
+	`)
+)
+
+// EditVulnerabilityPolicyOptions the options for the edit vulnerabilitypolicy command
+type EditVulnerabilityPolicyOptions struct {
+	EditOptions
+
+	Scanner       string
+	Thresholds    []string
+	Environments  []string
+	IgnoreUnfixed bool
+	Remove        bool
+}
+
+// NewCmdEditVulnerabilityPolicy creates a command object for the "edit vulnerabilitypolicy" command
+func NewCmdEditVulnerabilityPolicy(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditVulnerabilityPolicyOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "vulnerabilitypolicy",
+		Short:   "Configures the policy used by your team to block promotions of images with known vulnerabilities",
+		Aliases: []string{"vulnpolicy", "cvepolicy"},
+		Long:    editVulnerabilityPolicyLong,
+		Example: editVulnerabilityPolicyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Scanner, "scanner", "s", cve.ScannerTrivy, "The scanner used to find the vulnerabilities of images")
+	cmd.Flags().StringArrayVarP(&options.Thresholds, "threshold", "t", []string{}, "The maximum number of vulnerabilities of a severity of the form SEVERITY=COUNT. Defaults to CRITICAL=0")
+	cmd.Flags().StringArrayVarP(&options.Environments, optionEnvironment, "e", []string{}, "The environments the policy applies to or '*' for all permanent environments. Defaults to "+kube.DefaultVulnerabilityEnvironment)
+	cmd.Flags().BoolVarP(&options.IgnoreUnfixed, "ignore-unfixed", "", false, "Ignores vulnerabilities which have no fixed version available")
+	cmd.Flags().BoolVarP(&options.Remove, "remove", "", false, "Removes the vulnerability policy of the team")
+	return cmd
+}
+
+// Run implements the command
+func (o *EditVulnerabilityPolicyOptions) Run() error {
+	var policy *v1.VulnerabilityPolicy
+	if !o.Remove {
+		_, err := cve.NewImageScanner(o.Scanner, o.IgnoreUnfixed)
+		if err != nil {
+			return err
+		}
+		thresholds, err := ParseVulnerabilityThresholds(o.Thresholds)
+		if err != nil {
+			return err
+		}
+		policy = &v1.VulnerabilityPolicy{
+			Scanner:       o.Scanner,
+			Thresholds:    thresholds,
+			IgnoreUnfixed: o.IgnoreUnfixed,
+		}
+		for _, e := range o.Environments {
+			for _, name := range strings.Split(e, ",") {
+				name = strings.TrimSpace(name)
+				if name != "" {
+					policy.Environments = append(policy.Environments, name)
+				}
+			}
+		}
+	}
+
+	callback := func(env *v1.Environment) error {
+		env.Spec.TeamSettings.VulnerabilityPolicy = policy
+		if policy == nil {
+			log.Infof("Removed the vulnerability policy\n")
+			return nil
+		}
+		envs := policy.Environments
+		if len(envs) == 0 {
+			envs = []string{kube.DefaultVulnerabilityEnvironment}
+		}
+		log.Infof("Promotions to %s are blocked when the vulnerabilities of an image exceed %s\n", util.ColorInfo(strings.Join(envs, ", ")), util.ColorInfo(VulnerabilityThresholdsToString(kube.VulnerabilityThresholds(policy))))
+		return nil
+	}
+	return o.ModifyDevEnvironment(callback)
+}
+
+// ParseVulnerabilityThresholds parses thresholds of the form SEVERITY=COUNT
+func ParseVulnerabilityThresholds(values []string) (map[string]int, error) {
+	answer := map[string]int{}
+	for _, value := range values {
+		for _, text := range strings.Split(value, ",") {
+			text = strings.TrimSpace(text)
+			if text == "" {
+				continue
+			}
+			parts := strings.SplitN(text, "=", 2)
+			if len(parts) != 2 {
+				return nil, util.InvalidOptionf("threshold", text, "should be of the form SEVERITY=COUNT")
+			}
+			severity := strings.ToUpper(strings.TrimSpace(parts[0]))
+			if util.StringArrayIndex(cve.Severities, severity) < 0 {
+				return nil, util.InvalidOption("threshold", severity, append([]string{}, cve.Severities...))
+			}
+			count, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+			if err != nil || count < 0 {
+				return nil, util.InvalidOptionf("threshold", text, "the count should be a number which is not negative")
+			}
+			answer[severity] = count
+		}
+	}
+	if len(answer) == 0 {
+		return nil, nil
+	}
+	return answer, nil
+}
+
+// VulnerabilityThresholdsToString returns the text form of the thresholds
+func VulnerabilityThresholdsToString(thresholds map[string]int) string {
+	answer := []string{}
+	for severity, count := range thresholds {
+		answer = append(answer, fmt.Sprintf("%s=%d", severity, count))
+	}
+	sort.Strings(answer)
+	return strings.Join(answer, ", ")
+}
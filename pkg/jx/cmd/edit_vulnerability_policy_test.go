@@ -0,0 +1,26 @@
+package cmd_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseVulnerabilityThresholds(t *testing.T) {
+	t.Parallel()
+	thresholds, err := cmd.ParseVulnerabilityThresholds([]string{"critical=0", "HIGH=5,medium=20"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"CRITICAL": 0, "HIGH": 5, "MEDIUM": 20}, thresholds)
+	assert.Equal(t, "CRITICAL=0, HIGH=5, MEDIUM=20", cmd.VulnerabilityThresholdsToString(thresholds))
+
+	thresholds, err = cmd.ParseVulnerabilityThresholds(nil)
+	require.NoError(t, err)
+	assert.Nil(t, thresholds)
+
+	for _, invalid := range []string{"CRITICAL", "SEVERE=1", "HIGH=-1", "HIGH=many"} {
+		_, err = cmd.ParseVulnerabilityThresholds([]string{invalid})
+		assert.Error(t, err, "threshold %s", invalid)
+	}
+}
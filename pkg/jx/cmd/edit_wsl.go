@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	editWSLLong = templates.LongDesc(`
+		Connects kubectl and docker inside the Windows Subsystem for Linux (WSL) to the Kubernetes cluster and the docker daemon of Docker Desktop
+
+		Docker Desktop only configures the kube config of the Windows user so its context is merged into the kube config used inside WSL.
+`)
+
+	editWSLExample = templates.Examples(`
+		# Use the Kubernetes cluster of Docker Desktop from inside WSL
+		jx edit wsl
+
+		# Use another context of the Windows kube config from inside WSL
+		jx edit wsl --context minikube
+	`)
+)
+
+// EditWSLOptions the options for the edit wsl command
+type EditWSLOptions struct {
+	EditOptions
+
+	Context    string
+	SkipDocker bool
+}
+
+// NewCmdEditWSL creates a command object for the "edit wsl" command
+func NewCmdEditWSL(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &EditWSLOptions{
+		EditOptions: EditOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "wsl",
+		Short:   "Connects kubectl and docker inside WSL to Docker Desktop",
+		Long:    editWSLLong,
+		Example: editWSLExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Context, "context", "c", dockerDesktopContext, "The context of the Windows kube config to use inside WSL")
+	cmd.Flags().BoolVarP(&options.SkipDocker, "skip-docker", "", false, "Skips checking that the docker CLI can connect to the docker daemon of Docker Desktop")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *EditWSLOptions) Run() error {
+	if !util.IsWSL() {
+		return fmt.Errorf("jx is not running inside the Windows Subsystem for Linux")
+	}
+	err := o.mergeWindowsKubeConfig([]string{o.Context}, true)
+	if err != nil {
+		return err
+	}
+	log.Infof("kubectl now uses the context %s\n", util.ColorInfo(o.Context))
+	if !o.SkipDocker {
+		o.checkWSLDocker()
+	}
+	return nil
+}
@@ -168,7 +168,7 @@ func (o *EnvironmentOptions) PickNamespace(client kubernetes.Interface, defaultN
 	answers := struct {
 		Namespace string
 	}{}
-	err = survey.Ask(qs, &answers)
+	err = util.Ask(qs, &answers)
 	if err != nil {
 		return "", err
 	}
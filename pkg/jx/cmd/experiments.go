@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+)
+
+// ExperimentsOptions the options for the experiments command
+type ExperimentsOptions struct {
+	CommonOptions
+}
+
+var (
+	experimentsLong = templates.LongDesc(`
+		Lists, enables and disables the experimental features of the team.
+
+		Experimental features have to be enabled before they can be used. An experiment can also be enabled or
+		disabled for a single user regardless of the team by setting the $JX_EXPERIMENT_<NAME> environment variable
+		to true or false such as $JX_EXPERIMENT_HELM3=true
+`)
+)
+
+// NewCmdExperiments creates the command object for the "experiments" command
+func NewCmdExperiments(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ExperimentsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "experiments",
+		Short:   "Lists, enables and disables the experimental features of the team",
+		Aliases: []string{"experiment"},
+		Long:    experimentsLong,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdExperimentsList(f, out, errOut))
+	cmd.AddCommand(NewCmdExperimentsEnable(f, out, errOut))
+	cmd.AddCommand(NewCmdExperimentsDisable(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *ExperimentsOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/features"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ExperimentsEnableOptions the options for the experiments enable and disable commands
+type ExperimentsEnableOptions struct {
+	CommonOptions
+
+	Disable bool
+}
+
+var (
+	experimentsEnableExample = templates.Examples(`
+		# Enable Lighthouse for the team
+		jx experiments enable lighthouse
+	`)
+
+	experimentsDisableExample = templates.Examples(`
+		# Disable helm 3 for the team
+		jx experiments disable helm3
+	`)
+)
+
+// NewCmdExperimentsEnable creates the command object for the "experiments enable" command
+func NewCmdExperimentsEnable(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	return newCmdExperimentsEnable(f, out, errOut, false)
+}
+
+// NewCmdExperimentsDisable creates the command object for the "experiments disable" command
+func NewCmdExperimentsDisable(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	return newCmdExperimentsEnable(f, out, errOut, true)
+}
+
+func newCmdExperimentsEnable(f Factory, out io.Writer, errOut io.Writer, disable bool) *cobra.Command {
+	options := &ExperimentsEnableOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+		Disable: disable,
+	}
+
+	cmd := &cobra.Command{
+		Use:     "enable [name]",
+		Short:   "Enables an experimental feature for the team",
+		Example: experimentsEnableExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	if disable {
+		cmd.Use = "disable [name]"
+		cmd.Short = "Disables an experimental feature for the team"
+		cmd.Example = experimentsDisableExample
+	}
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ExperimentsEnableOptions) Run() error {
+	if len(o.Args) == 0 {
+		return util.InvalidInputError(fmt.Errorf("Missing argument for the experiment name. Possible values: %s", features.Names()))
+	}
+	feature, err := features.Lookup(o.Args[0])
+	if err != nil {
+		return err
+	}
+	callback := func(env *v1.Environment) error {
+		settings := &env.Spec.TeamSettings
+		settings.Experiments = setExperiment(settings.Experiments, feature.Name, !o.Disable)
+		return nil
+	}
+	err = o.ModifyDevEnvironment(callback)
+	if err != nil {
+		return err
+	}
+	if o.Disable {
+		log.Infof("Disabled the experiment %s for the team\n", util.ColorInfo(feature.Name))
+	} else {
+		log.Infof("Enabled the experiment %s for the team\n", util.ColorInfo(feature.Name))
+	}
+	return nil
+}
+
+// setExperiment adds or removes the experiment from the experiments of the team
+func setExperiment(experiments []string, name string, enabled bool) []string {
+	answer := []string{}
+	for _, e := range experiments {
+		if e != name {
+			answer = append(answer, e)
+		}
+	}
+	if enabled {
+		answer = append(answer, name)
+	}
+	return answer
+}
@@ -0,0 +1,14 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetExperiment(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"helm3", "lighthouse"}, setExperiment([]string{"helm3"}, "lighthouse", true))
+	assert.Equal(t, []string{"helm3", "lighthouse"}, setExperiment([]string{"lighthouse", "helm3"}, "lighthouse", true))
+	assert.Equal(t, []string{"helm3"}, setExperiment([]string{"lighthouse", "helm3"}, "lighthouse", false))
+}
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/features"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+)
+
+// ExperimentsListOptions the options for the experiments list command
+type ExperimentsListOptions struct {
+	CommonOptions
+}
+
+var (
+	experimentsListLong = templates.LongDesc(`
+		Lists the experimental features and whether they are enabled for the team or via their environment variables
+`)
+
+	experimentsListExample = templates.Examples(`
+		# List the experimental features
+		jx experiments list
+	`)
+)
+
+// NewCmdExperimentsList creates the command object for the "experiments list" command
+func NewCmdExperimentsList(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ExperimentsListOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lists the experimental features",
+		Aliases: []string{"ls"},
+		Long:    experimentsListLong,
+		Example: experimentsListExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ExperimentsListOptions) Run() error {
+	teamExperiments := o.teamExperiments()
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "ENABLED", "ENV VAR", "DESCRIPTION")
+	for _, f := range features.All() {
+		enabled := "false"
+		if f.Enabled(teamExperiments) {
+			enabled = "true"
+		}
+		table.AddRow(f.Name, enabled, f.EnvVar(), f.Description)
+	}
+	table.Render()
+	return nil
+}
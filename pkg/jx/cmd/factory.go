@@ -92,9 +92,13 @@ func (f *factory) CreateJenkinsClient(kubeClient kubernetes.Interface, ns string
 
 func (f *factory) GetJenkinsURL(kubeClient kubernetes.Interface, ns string) (string, error) {
 	// lets find the kubernetes service
-	client, ns, err := f.CreateClient()
-	if err != nil {
-		return "", errors.Wrap(err, "failed to create the kube client")
+	client := kubeClient
+	if client == nil {
+		var err error
+		client, ns, err = f.CreateClient()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to create the kube client")
+		}
 	}
 	url, err := kube.FindServiceURL(client, ns, kube.ServiceJenkins)
 	if err != nil {
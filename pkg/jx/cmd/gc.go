@@ -41,6 +41,7 @@ var (
 		jx gc gke
 		jx gc previews
 		jx gc releases
+		jx gc devpods
 
 	`)
 )
@@ -70,9 +71,13 @@ func NewCmdGC(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	}
 
 	cmd.AddCommand(NewCmdGCActivities(f, out, errOut))
+	cmd.AddCommand(NewCmdGCBuildCaches(f, out, errOut))
+	cmd.AddCommand(NewCmdGCCharts(f, out, errOut))
+	cmd.AddCommand(NewCmdGCDevPods(f, out, errOut))
 	cmd.AddCommand(NewCmdGCPreviews(f, out, errOut))
 	cmd.AddCommand(NewCmdGCGKE(f, out, errOut))
 	cmd.AddCommand(NewCmdGCHelm(f, out, errOut))
+	cmd.AddCommand(NewCmdGCImages(f, out, errOut))
 	cmd.AddCommand(NewCmdGCReleases(f, out, errOut))
 
 	return cmd
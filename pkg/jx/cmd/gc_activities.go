@@ -3,12 +3,14 @@ package cmd
 import (
 	"fmt"
 	"io"
-	"sort"
-	"strconv"
-	"strings"
+	"time"
 
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/jenkins-x/golang-jenkins"
@@ -22,6 +24,8 @@ type GCActivitiesOptions struct {
 	CommonOptions
 
 	RevisionHistoryLimit int
+	MaxAgeDays           int
+	DryRun               bool
 	jclient              *gojenkins.Jenkins
 }
 
@@ -29,11 +33,21 @@ var (
 	GCActivitiesLong = templates.LongDesc(`
 		Garbage collect the Jenkins X Activity Custom Resource Definitions
 
+		Activities are removed using the retention policy of the team which keeps the most recent activities of each
+		branch and removes those older than a maximum age. Activities whose version has been released and those
+		which are still running are always kept. Configure the retention policy via 'jx edit activity-retention'.
+
 `)
 
 	GCActivitiesExample = templates.Examples(`
 		jx garbage collect activities
 		jx gc activities
+
+		# Only keep the last 10 activities of each branch which are younger than 30 days
+		jx gc activities --revision-history-limit 10 --max-age-days 30
+
+		# Show which activities would be deleted
+		jx gc activities --dry-run
 `)
 )
 
@@ -59,7 +73,9 @@ func NewCmdGCActivities(f Factory, out io.Writer, errOut io.Writer) *cobra.Comma
 			CheckErr(err)
 		},
 	}
-	cmd.Flags().IntVarP(&options.RevisionHistoryLimit, "revision-history-limit", "l", 5, "Minimum number of Activities per application to keep")
+	cmd.Flags().IntVarP(&options.RevisionHistoryLimit, "revision-history-limit", "l", 5, "Number of the most recent Activities of each branch to keep. Defaults to the retention policy of the team")
+	cmd.Flags().IntVarP(&options.MaxAgeDays, "max-age-days", "", 0, "Number of days after which Activities are deleted. Defaults to the retention policy of the team")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only shows which Activities would be deleted")
 	return cmd
 }
 
@@ -119,12 +135,8 @@ func (o *GCActivitiesOptions) Run() error {
 				return err
 			}
 		}
-	}
-
-	activityBuilds := make(map[string][]int)
 
-	for _, a := range activities.Items {
-		if !prowEnabled {
+		for _, a := range activities.Items {
 			// if activity has no job in jenkins delete it
 			matched := false
 			for _, j := range jobNames {
@@ -133,42 +145,72 @@ func (o *GCActivitiesOptions) Run() error {
 					break
 				}
 			}
-			if !matched {
-				err = client.JenkinsV1().PipelineActivities(currentNs).Delete(a.Name, metav1.NewDeleteOptions(0))
-				if err != nil {
-					return err
-				}
+			if matched {
+				continue
+			}
+			if o.DryRun {
+				log.Infof("Would delete activity %s\n", util.ColorInfo(a.Name))
+				continue
+			}
+			err = client.JenkinsV1().PipelineActivities(currentNs).Delete(a.Name, metav1.NewDeleteOptions(0))
+			if err != nil {
+				return err
 			}
 		}
-
-		buildNumber, err := strconv.Atoi(a.Spec.Build)
-		if err != nil {
-			return err
-		}
-		// collect all activities for a pipeline
-		activityBuilds[a.Spec.Pipeline] = append(activityBuilds[a.Spec.Pipeline], buildNumber)
 	}
 
-	for pipeline, builds := range activityBuilds {
-
-		sort.Ints(builds)
-
-		// iterate over the build numbers and delete any while the activity is under the RevisionHistoryLimit
-		i := 0
-		for i < len(builds)-o.RevisionHistoryLimit {
-			activityName := fmt.Sprintf("%s-%v", pipeline, builds[i])
-			activityName = strings.Replace(activityName, "/", "-", -1)
-			activityName = strings.Replace(activityName, "_", "-", -1)
-			activityName = strings.ToLower(activityName)
-
-			err = client.JenkinsV1().PipelineActivities(currentNs).Delete(activityName, metav1.NewDeleteOptions(0))
-			if err != nil {
-				return fmt.Errorf("failed to delete activity %s: %v\n", activityName, err)
-			}
+	retention, err := o.activityRetention()
+	if err != nil {
+		return err
+	}
+	return o.pruneActivities(client, currentNs, retention, o.DryRun)
+}
 
-			i++
+// activityRetention returns the retention policy of the team overridden by any command line flags
+func (o *GCActivitiesOptions) activityRetention() (*v1.ActivityRetention, error) {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return nil, err
+	}
+	retention := *kube.ActivityRetentionOrDefault(settings)
+	if o.Cmd != nil {
+		flags := o.Cmd.Flags()
+		if flags.Changed("revision-history-limit") {
+			retention.KeepPerBranch = o.RevisionHistoryLimit
+		}
+		if flags.Changed("max-age-days") {
+			retention.MaxAgeDays = o.MaxAgeDays
 		}
 	}
+	return &retention, nil
+}
 
+// pruneActivities deletes the activities in the namespace which are removed by the retention policy
+func (o *CommonOptions) pruneActivities(jxClient versioned.Interface, ns string, retention *v1.ActivityRetention, dryRun bool) error {
+	activities, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	releases, err := jxClient.JenkinsV1().Releases(ns).List(metav1.ListOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		releases = &v1.ReleaseList{}
+	}
+	names := kube.ActivitiesToPrune(activities.Items, releases.Items, retention, time.Now())
+	for _, name := range names {
+		if dryRun {
+			log.Infof("Would delete activity %s\n", util.ColorInfo(name))
+			continue
+		}
+		err = jxClient.JenkinsV1().PipelineActivities(ns).Delete(name, metav1.NewDeleteOptions(0))
+		if err != nil && !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete activity %s: %v\n", name, err)
+		}
+	}
+	if len(names) > 0 && !dryRun {
+		log.Infof("Deleted %s activities in namespace %s\n", util.ColorInfo(len(names)), util.ColorInfo(ns))
+	}
 	return nil
 }
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GCBuildCachesOptions the options for the gc buildcaches command
+type GCBuildCachesOptions struct {
+	CommonOptions
+
+	Kinds   []string
+	Timeout string
+}
+
+var (
+	GCBuildCachesLong = templates.LongDesc(`
+		Purges the contents of the dependency caches used by the builds of the current team
+
+		Use this command if a cache has become corrupt or has grown too large. Builds will download their
+		dependencies again the next time they run.
+`)
+
+	GCBuildCachesExample = templates.Examples(`
+		# Purge all the build caches
+		jx gc buildcaches
+
+		# Purge only the npm cache
+		jx gc buildcaches --kind npm
+`)
+)
+
+// NewCmdGCBuildCaches creates the command object for "gc buildcaches"
+func NewCmdGCBuildCaches(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GCBuildCachesOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     buildCaches,
+		Short:   "Purges the contents of the build caches",
+		Aliases: buildCacheAliases,
+		Long:    GCBuildCachesLong,
+		Example: GCBuildCachesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringArrayVarP(&options.Kinds, optionBuildCacheKind, "k", []string{}, "The kinds of build cache to purge. Defaults to all of them")
+	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "10m", "The timeout to wait for each cache to be purged")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GCBuildCachesOptions) Run() error {
+	timeout, err := time.ParseDuration(o.Timeout)
+	if err != nil {
+		return util.InvalidOptionError(optionTimeout, o.Timeout, err)
+	}
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	for _, kind := range o.Kinds {
+		found := false
+		for _, c := range settings.BuildCaches {
+			if c.Kind == kind {
+				found = true
+			}
+		}
+		if !found {
+			return fmt.Errorf("No build cache found for kind: %s", kind)
+		}
+	}
+	for _, c := range settings.BuildCaches {
+		if len(o.Kinds) > 0 && util.StringArrayIndex(o.Kinds, c.Kind) < 0 {
+			continue
+		}
+		log.Infof("Purging the %s build cache\n", util.ColorInfo(c.Kind))
+		err = kube.PurgeBuildCache(kubeClient, ns, c.Kind, timeout)
+		if err != nil {
+			return fmt.Errorf("failed to purge the %s build cache: %s", c.Kind, err)
+		}
+	}
+	return nil
+}
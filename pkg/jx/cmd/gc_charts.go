@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GCChartsOptions contains the CLI options for this command
+type GCChartsOptions struct {
+	CommonOptions
+
+	Days        int
+	MaxVersions int
+	DryRun      bool
+}
+
+var (
+	GCChartsLong = templates.LongDesc(`
+		Garbage collect the chart versions stored in the ChartMuseum installed with Jenkins X
+
+		Chart versions older than the given number of days or beyond the maximum number of versions per chart
+		are deleted. The latest version of each chart and any version referenced by the requirements.yaml of an
+		Environment git repository are always kept.
+`)
+
+	GCChartsExample = templates.Examples(`
+		# Delete chart versions older than 90 days keeping at most 20 versions of each chart
+		jx gc charts
+
+		# Show which chart versions would be deleted
+		jx gc charts --days 30 --max-versions 5 --dry-run
+`)
+)
+
+// NewCmdGCCharts creates the command object for "gc charts"
+func NewCmdGCCharts(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GCChartsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "charts",
+		Short:   "garbage collection for chart versions in ChartMuseum",
+		Aliases: []string{"chart"},
+		Long:    GCChartsLong,
+		Example: GCChartsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().IntVarP(&options.Days, "days", "d", 90, "Delete chart versions created more than this number of days ago. Use 0 to disable")
+	cmd.Flags().IntVarP(&options.MaxVersions, "max-versions", "m", 20, "The maximum number of versions of each chart to keep. Use 0 to disable")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only display the chart versions which would be deleted")
+	return cmd
+}
+
+// Run implements this command
+func (o *GCChartsOptions) Run() error {
+	if o.Days < 0 {
+		return util.InvalidOptionf("days", fmt.Sprintf("%d", o.Days), "must not be negative")
+	}
+	if o.MaxVersions < 0 {
+		return util.InvalidOptionf("max-versions", fmt.Sprintf("%d", o.MaxVersions), "must not be negative")
+	}
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	chartRepo, err := kube.FindServiceURL(kubeClient, devNs, kube.ServiceChartMuseum)
+	if err != nil || chartRepo == "" {
+		chartRepo = o.releaseChartMuseumUrl()
+	}
+	userName, password, err := o.getChartMuseumCredentials()
+	if err != nil {
+		return err
+	}
+	client := helm.NewChartMuseumClient(chartRepo, userName, password)
+
+	charts, err := client.ListCharts()
+	if err != nil {
+		return err
+	}
+	protected, err := o.environmentChartVersions(devNs)
+	if err != nil {
+		return err
+	}
+	policy := &helm.ChartRetentionPolicy{
+		MaxAge:      time.Duration(o.Days) * 24 * time.Hour,
+		MaxVersions: o.MaxVersions,
+	}
+	versions := helm.FindChartVersionsToDelete(charts, policy, protected, time.Now())
+	if len(versions) == 0 {
+		log.Infof("No chart versions to delete in %s\n", util.ColorInfo(chartRepo))
+		return nil
+	}
+	for _, v := range versions {
+		if o.DryRun {
+			log.Infof("Would delete chart %s version %s\n", util.ColorInfo(v.Name), util.ColorInfo(v.Version))
+			continue
+		}
+		err = client.DeleteChartVersion(v.Name, v.Version)
+		if err != nil {
+			return err
+		}
+		log.Infof("Deleted chart %s version %s\n", util.ColorInfo(v.Name), util.ColorInfo(v.Version))
+	}
+	return nil
+}
+
+// environmentChartVersions returns the chart versions referenced by the requirements.yaml of each Environment
+// git repository indexed by chart name
+func (o *GCChartsOptions) environmentChartVersions(devNs string) (map[string]map[string]bool, error) {
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return nil, err
+	}
+	envMap, names, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return nil, err
+	}
+	tmpDir, err := ioutil.TempDir("", "jx-gc-charts-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	answer := map[string]map[string]bool{}
+	for _, name := range names {
+		env := envMap[name]
+		if env == nil || env.Spec.Kind != v1.EnvironmentKindTypePermanent || env.Spec.Source.URL == "" {
+			continue
+		}
+		dir := filepath.Join(tmpDir, name)
+		err = o.Git().Clone(env.Spec.Source.URL, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to clone the git repository %s of Environment %s: %s", env.Spec.Source.URL, name, err)
+		}
+		if env.Spec.Source.Ref != "" && env.Spec.Source.Ref != "master" {
+			err = o.Git().Checkout(dir, env.Spec.Source.Ref)
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = addRequirementsChartVersions(dir, answer)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the charts of Environment %s: %s", name, err)
+		}
+	}
+	return answer, nil
+}
+
+// addRequirementsChartVersions adds the chart versions of the requirements.yaml of the environment in the directory.
+// An environment without a requirements.yaml file references no charts
+func addRequirementsChartVersions(dir string, versions map[string]map[string]bool) error {
+	fileName, err := helm.FindRequirementsFileName(dir)
+	if err != nil {
+		return err
+	}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	requirements, err := helm.LoadRequirementsFile(fileName)
+	if err != nil {
+		return err
+	}
+	for _, dep := range requirements.Dependencies {
+		if dep == nil || dep.Version == "" {
+			continue
+		}
+		if versions[dep.Name] == nil {
+			versions[dep.Name] = map[string]bool{}
+		}
+		versions[dep.Name][dep.Version] = true
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddRequirementsChartVersions(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-gc-charts-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	versions := map[string]map[string]bool{}
+	staging := filepath.Join(dir, "staging")
+	require.NoError(t, os.MkdirAll(filepath.Join(staging, "env"), 0755))
+	err = addRequirementsChartVersions(staging, versions)
+	require.NoError(t, err)
+	assert.Empty(t, versions, "an environment without a requirements.yaml references no charts")
+
+	production := filepath.Join(dir, "production")
+	require.NoError(t, os.MkdirAll(filepath.Join(production, "env"), 0755))
+	requirements := `dependencies:
+- name: myapp
+  version: 1.0.3
+  repository: http://jenkins-x-chartmuseum:8080
+- name: exposecontroller
+  version: 2.3.82
+  repository: https://chartmuseum.build.cd.jenkins-x.io
+`
+	require.NoError(t, ioutil.WriteFile(filepath.Join(production, "env", "requirements.yaml"), []byte(requirements), 0644))
+	err = addRequirementsChartVersions(production, versions)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]bool{
+		"myapp":            {"1.0.3": true},
+		"exposecontroller": {"2.3.82": true},
+	}, versions)
+}
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"io"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GCDevPodsOptions the command line options
+type GCDevPodsOptions struct {
+	CommonOptions
+
+	DryRun bool
+}
+
+var (
+	gcDevPodsLong = templates.LongDesc(`
+		Garbage collect the DevPods of all users which have been idle for longer than their TTL.
+
+		The TTL of a DevPod is specified when it is created via 'jx create devpod --ttl'. A DevPod is active while a shell is open in it.
+
+`)
+
+	gcDevPodsExample = templates.Examples(`
+		# Deletes the idle DevPods
+		jx gc devpods
+
+		# Lists the DevPods which would be deleted
+		jx gc devpods --dry-run
+`)
+)
+
+// NewCmdGCDevPods creates the command
+func NewCmdGCDevPods(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GCDevPodsOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "devpods",
+		Short:   "garbage collection for idle DevPods",
+		Long:    gcDevPodsLong,
+		Example: gcDevPodsExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only lists the DevPods which would be deleted")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GCDevPodsOptions) Run() error {
+	client, curNs, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err := kube.GetDevNamespace(client, curNs)
+	if err != nil {
+		return err
+	}
+	pods, err := kube.GetExpiredDevPods(client, ns, time.Now())
+	if err != nil {
+		return err
+	}
+	if len(pods) == 0 {
+		if o.Verbose {
+			log.Infof("No idle DevPods found in namespace %s\n", ns)
+		}
+		return nil
+	}
+	for _, pod := range pods {
+		name := pod.Name
+		owner := pod.Labels[kube.LabelDevPodUsername]
+		if o.DryRun {
+			log.Infof("Would delete idle DevPod %s of user %s\n", util.ColorInfo(name), util.ColorInfo(owner))
+			continue
+		}
+		err = client.CoreV1().Pods(ns).Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		// the services exposing the ports and Theia IDE of the DevPod
+		for _, service := range []string{name, name + "-theia"} {
+			err = client.CoreV1().Services(ns).Delete(service, &metav1.DeleteOptions{})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+		log.Infof("Deleted idle DevPod %s of user %s\n", util.ColorInfo(name), util.ColorInfo(owner))
+	}
+	return nil
+}
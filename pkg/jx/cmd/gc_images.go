@@ -0,0 +1,260 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/registry"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GCImagesOptions contains the CLI options for this command
+type GCImagesOptions struct {
+	CommonOptions
+
+	Registry          string
+	Kind              string
+	Username          string
+	Password          string
+	Insecure          bool
+	DockerRegistryOrg string
+	RegistryGC        bool
+	DryRun            bool
+}
+
+var (
+	GCImagesLong = templates.LongDesc(`
+		Garbage collect the docker images of Preview Environments
+
+		The image tags built for pull requests which have been merged or closed are deleted from the docker registry.
+		Supported registries are the Docker Registry installed with Jenkins X, ECR, GCR, ACR and Harbor.
+`)
+
+	GCImagesExample = templates.Examples(`
+		# Delete the preview images of closed pull requests from the team's docker registry
+		jx gc images
+
+		# Show which preview images would be deleted from Harbor
+		jx gc images --registry harbor.acme.com --kind harbor --dry-run
+`)
+)
+
+// NewCmdGCImages creates the command object for "gc images"
+func NewCmdGCImages(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GCImagesOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "images",
+		Short:   "garbage collection for the docker images of Preview Environments",
+		Aliases: []string{"image"},
+		Long:    GCImagesLong,
+		Example: GCImagesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Registry, "registry", "r", "", "The docker registry host. Defaults to the docker registry of the team")
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", fmt.Sprintf("The kind of docker registry. Possible values: %s. Defaults to the kind of the registry host", strings.Join(registry.Kinds, ", ")))
+	cmd.Flags().StringVarP(&options.Username, "username", "", "", "The user to access the docker registry")
+	cmd.Flags().StringVarP(&options.Password, "password", "p", "", "The password to access the docker registry")
+	cmd.Flags().BoolVarP(&options.Insecure, "insecure", "", false, "Access the docker registry via http rather than https. Not supported by the ECR, GCR and ACR registries")
+	cmd.Flags().StringVarP(&options.DockerRegistryOrg, "docker-registry-org", "", "", "The docker registry organisation of the images. Defaults to the git organisation")
+	cmd.Flags().BoolVarP(&options.RegistryGC, "registry-gc", "", false, "Triggers the garbage collection of the docker registry after deleting the images where supported")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only display the images which would be deleted")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GCImagesOptions) Run() error {
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	devNs, _, err := kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	host := o.Registry
+	if host == "" {
+		cm, err := kubeClient.CoreV1().ConfigMaps(devNs).Get(kube.ConfigMapJenkinsDockerRegistry, metav1.GetOptions{})
+		if err == nil && cm.Data != nil {
+			host = cm.Data["docker.registry"]
+		}
+		if host == "" {
+			return util.MissingOption("registry")
+		}
+	}
+	kind := o.Kind
+	if kind == "" {
+		kind = registry.KindForHost(host)
+	}
+	if o.Insecure {
+		if registry.IsHTTPSOnly(kind) {
+			return util.InvalidOptionf("insecure", "true", "the %s registry %s can only be accessed via https", kind, host)
+		}
+		host = "http://" + host
+	}
+	reg, err := registry.NewRegistry(kind, host, o.Username, o.Password)
+	if err != nil {
+		return err
+	}
+
+	repos, err := o.previewGitRepositories(devNs)
+	if err != nil {
+		return err
+	}
+	deleted := 0
+	for _, gitURL := range repos {
+		count, err := o.gcPreviewImages(reg, gitURL)
+		if err != nil {
+			return err
+		}
+		deleted += count
+	}
+	if deleted == 0 {
+		log.Infof("No preview images to delete\n")
+		return nil
+	}
+	if o.RegistryGC && !o.DryRun {
+		return o.garbageCollectRegistry(reg, kind, devNs)
+	}
+	return nil
+}
+
+// previewGitRepositories returns the git URLs of the repositories which have built pull requests
+func (o *GCImagesOptions) previewGitRepositories(devNs string) ([]string, error) {
+	err := o.registerPipelineActivityCRD()
+	if err != nil {
+		return nil, err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return nil, err
+	}
+	activities, err := jxClient.JenkinsV1().PipelineActivities(devNs).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	urls := map[string]bool{}
+	for _, a := range activities.Items {
+		if a.Spec.GitURL != "" && strings.Contains(strings.ToUpper(a.Spec.Pipeline), "/PR-") {
+			urls[a.Spec.GitURL] = true
+		}
+	}
+	answer := []string{}
+	for u := range urls {
+		answer = append(answer, u)
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+// gcPreviewImages deletes the preview image tags of the closed pull requests of the git repository
+func (o *GCImagesOptions) gcPreviewImages(reg registry.Registry, gitURL string) (int, error) {
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return 0, err
+	}
+	org := o.DockerRegistryOrg
+	if org == "" {
+		org = gitInfo.Organisation
+	}
+	image := strings.ToLower(org + "/" + gitInfo.Name)
+	tags, err := reg.ListTags(image)
+	if err != nil {
+		return 0, err
+	}
+
+	var gitProvider gits.GitProvider
+	closed := map[int]bool{}
+	count := 0
+	for _, tag := range tags {
+		prNumber, ok := registry.PreviewPullRequestNumber(tag)
+		if !ok {
+			continue
+		}
+		isClosed, checked := closed[prNumber]
+		if !checked {
+			if gitProvider == nil {
+				gitProvider, err = o.gitProviderForURL(gitURL, "git repository of preview images")
+				if err != nil {
+					return count, err
+				}
+			}
+			pr, err := gitProvider.GetPullRequest(gitInfo.Organisation, gitInfo, prNumber)
+			if err != nil {
+				log.Warnf("Unable to find pull request %d of %s: %s\n", prNumber, gitURL, err)
+				continue
+			}
+			isClosed = pr.State != nil && isPullRequestClosed(*pr.State)
+			closed[prNumber] = isClosed
+		}
+		if !isClosed {
+			continue
+		}
+		count++
+		if o.DryRun {
+			log.Infof("Would delete image %s:%s\n", util.ColorInfo(image), util.ColorInfo(tag))
+			continue
+		}
+		err = reg.DeleteTag(image, tag)
+		if err != nil {
+			return count, err
+		}
+		log.Infof("Deleted image %s:%s\n", util.ColorInfo(image), util.ColorInfo(tag))
+	}
+	return count, nil
+}
+
+// garbageCollectRegistry frees the storage of the deleted images
+func (o *GCImagesOptions) garbageCollectRegistry(reg registry.Registry, kind string, devNs string) error {
+	triggered, err := reg.GarbageCollect()
+	if err != nil {
+		return err
+	}
+	if triggered {
+		log.Infof("Triggered the garbage collection of the %s registry\n", kind)
+		return nil
+	}
+	if kind != registry.KindDocker {
+		log.Infof("The %s registry removes the storage of deleted images automatically\n", kind)
+		return nil
+	}
+	pods, err := o.KubeClientCached.CoreV1().Pods(devNs).List(metav1.ListOptions{
+		LabelSelector: "app=docker-registry",
+	})
+	if err != nil {
+		return err
+	}
+	if len(pods.Items) == 0 {
+		log.Warnf("Could not find the docker registry pod in namespace %s to run the garbage collection\n", devNs)
+		return nil
+	}
+	pod := pods.Items[0].Name
+	log.Infof("Running the garbage collection of the docker registry in pod %s\n", util.ColorInfo(pod))
+	return o.RunCommand("kubectl", "exec", "-n", devNs, pod, "--", "registry", "garbage-collect", "/etc/docker/registry/config.yml")
+}
+
+// isPullRequestClosed returns true if the state of the pull request indicates it is no longer open
+func isPullRequestClosed(state string) bool {
+	lowerState := strings.ToLower(state)
+	return strings.HasPrefix(lowerState, "clos") || strings.HasPrefix(lowerState, "merged") || strings.HasPrefix(lowerState, "superseded") || strings.HasPrefix(lowerState, "declined")
+}
@@ -9,8 +9,6 @@ import (
 
 	"strconv"
 
-	"strings"
-
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
@@ -116,9 +114,7 @@ func (o *GCPreviewsOptions) Run() error {
 				return err
 			}
 
-			lowerState := strings.ToLower(*pullRequest.State)
-
-			if strings.HasPrefix(lowerState, "clos") || strings.HasPrefix(lowerState, "merged") || strings.HasPrefix(lowerState, "superseded") || strings.HasPrefix(lowerState, "declined") {
+			if isPullRequestClosed(*pullRequest.State) {
 				// lets delete the preview environment
 				deleteOpts := DeleteEnvOptions{
 					DeleteNamespace: true,
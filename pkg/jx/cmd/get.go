@@ -65,24 +65,34 @@ func NewCmdGet(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdGetActivity(f, out, errOut))
 	cmd.AddCommand(NewCmdGetAddon(f, out, errOut))
 	cmd.AddCommand(NewCmdGetApplications(f, out, errOut))
+	cmd.AddCommand(NewCmdGetAudit(f, out, errOut))
 	cmd.AddCommand(NewCmdGetAWSInfo(f, out, errOut))
+	cmd.AddCommand(NewCmdGetAWSPolicy(f, out, errOut))
 	cmd.AddCommand(NewCmdGetBranchPattern(f, out, errOut))
 	cmd.AddCommand(NewCmdGetBuild(f, out, errOut))
 	cmd.AddCommand(NewCmdGetBuildPack(f, out, errOut))
+	cmd.AddCommand(NewCmdGetBuildCache(f, out, errOut))
 	cmd.AddCommand(NewCmdGetChat(f, out, errOut))
 	cmd.AddCommand(NewCmdGetConfig(f, out, errOut))
 	cmd.AddCommand(NewCmdGetCVE(f, out, errOut))
+	cmd.AddCommand(NewCmdGetDependencies(f, out, errOut))
 	cmd.AddCommand(NewCmdGetDevPod(f, out, errOut))
 	cmd.AddCommand(NewCmdGetEnv(f, out, errOut))
+	cmd.AddCommand(NewCmdGetEnvVar(f, out, errOut))
+	cmd.AddCommand(NewCmdGetEvents(f, out, errOut))
+	cmd.AddCommand(NewCmdGetExposure(f, out, errOut))
 	cmd.AddCommand(NewCmdGetGit(f, out, errOut))
 	cmd.AddCommand(NewCmdGetHelmBin(f, out, errOut))
+	cmd.AddCommand(NewCmdGetHookFailures(f, out, errOut))
 	cmd.AddCommand(NewCmdGetIssue(f, out, errOut))
 	cmd.AddCommand(NewCmdGetIssues(f, out, errOut))
+	cmd.AddCommand(NewCmdGetNotification(f, out, errOut))
 	cmd.AddCommand(NewCmdGetPipeline(f, out, errOut))
 	cmd.AddCommand(NewCmdGetPostPreviewJob(f, out, errOut))
 	cmd.AddCommand(NewCmdGetPreview(f, out, errOut))
 	cmd.AddCommand(NewCmdGetQuickstartLocation(f, out, errOut))
 	cmd.AddCommand(NewCmdGetRelease(f, out, errOut))
+	cmd.AddCommand(NewCmdGetStorage(f, out, errOut))
 	cmd.AddCommand(NewCmdGetTeam(f, out, errOut))
 	cmd.AddCommand(NewCmdGetTeamRole(f, out, errOut))
 	cmd.AddCommand(NewCmdGetToken(f, out, errOut))
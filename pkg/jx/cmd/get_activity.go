@@ -260,6 +260,10 @@ func addPromoteRow(table *tbl.Table, parent *v1.PromoteActivityStep, indent stri
 	if update != nil {
 		addStepRowItem(table, &update.CoreActivityStep, indent, "Update", describePromoteUpdate(update))
 	}
+	smokeTest := parent.SmokeTest
+	if smokeTest != nil {
+		addStepRowItem(table, &smokeTest.CoreActivityStep, indent, "SmokeTest", describePromoteSmokeTest(smokeTest))
+	}
 	appURL := parent.ApplicationURL
 	if appURL != "" {
 		addStepRowItem(table, &update.CoreActivityStep, indent, "Promoted", " Application is at: "+util.ColorInfo(appURL))
@@ -326,6 +330,17 @@ func describePromoteUpdate(promote *v1.PromoteUpdateStep) string {
 	return description
 }
 
+func describePromoteSmokeTest(smokeTest *v1.PromoteSmokeTestStep) string {
+	description := ""
+	for _, result := range smokeTest.Results {
+		description += " " + result.Name + ": " + statusString(result.Status)
+	}
+	if smokeTest.RollbackVersion != "" {
+		description += " Rolled back to: " + util.ColorInfo(smokeTest.RollbackVersion)
+	}
+	return description
+}
+
 func pullRequestStatusString(text string) string {
 	title := strings.Title(text)
 	switch text {
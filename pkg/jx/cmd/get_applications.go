@@ -51,6 +51,9 @@ var (
 
 		# List applications just showing the versions (hiding urls and pod counts)
 		jx get apps -u -p
+
+		# The status of the cloud resources claimed by the apps is shown if any are claimed
+		# see: jx create cloud-resource --help
 	`)
 )
 
@@ -87,6 +90,8 @@ func NewCmdGetApplications(f Factory, out io.Writer, errOut io.Writer) *cobra.Co
 type EnvApps struct {
 	Environment v1.Environment
 	Apps        map[string]v1beta1.Deployment
+	// Resources the status of the cloud resources claimed by the apps
+	Resources map[string]string
 }
 
 // Run implements this command
@@ -116,6 +121,7 @@ func (o *GetApplicationsOptions) Run() error {
 
 	namespaces := []string{}
 	envApps := []EnvApps{}
+	hasResources := false
 	envNames := []string{}
 	apps := []string{}
 	for _, env := range envList.Items {
@@ -136,7 +142,13 @@ func (o *GetApplicationsOptions) Run() error {
 					envApp := EnvApps{
 						Environment: env,
 						Apps:        map[string]v1beta1.Deployment{},
+						Resources:   map[string]string{},
+					}
+					claims, err := kube.GetCrossplaneClaims(kubeClient, ens)
+					if err != nil {
+						log.Warnf("Failed to get the cloud resources in namespace %s: %s\n", ens, err)
 					}
+					appResources := kube.CrossplaneClaimsByApp(claims)
 					envApps = append(envApps, envApp)
 					for k, d := range m {
 						appName := kube.GetAppName(k, ens)
@@ -148,6 +160,11 @@ func (o *GetApplicationsOptions) Run() error {
 						} else if env.Spec.Kind == v1.EnvironmentKindTypePreview {
 							appName = env.Spec.PullRequestURL
 						}
+						resources := appResources[kube.GetAppName(k, ens)]
+						if resources != "" {
+							envApp.Resources[appName] = resources
+							hasResources = true
+						}
 						envApp.Apps[appName] = d
 						if util.StringArrayIndex(apps, appName) < 0 {
 							apps = append(apps, appName)
@@ -184,6 +201,9 @@ func (o *GetApplicationsOptions) Run() error {
 		if !o.HideUrl {
 			titles = append(titles, "URL")
 		}
+		if hasResources {
+			titles = append(titles, "RESOURCES")
+		}
 	}
 	table.AddRow(titles...)
 
@@ -226,6 +246,9 @@ func (o *GetApplicationsOptions) Run() error {
 				}
 				row = append(row, url)
 			}
+			if hasResources {
+				row = append(row, ea.Resources[appName])
+			}
 		}
 		table.AddRow(row...)
 	}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/audit"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+)
+
+// GetAuditOptions containers the CLI options
+type GetAuditOptions struct {
+	GetOptions
+
+	User    string
+	Command string
+	Since   time.Duration
+	Failed  bool
+}
+
+var (
+	getAuditLong = templates.LongDesc(`
+		Display the audit log of the mutating jx commands run against the current Team
+
+		Every jx command which creates, edits or deletes resources is recorded along with the user who ran it, when it ran,
+		its arguments (with any passwords or tokens masked) and whether it succeeded.
+`)
+
+	getAuditExample = templates.Examples(`
+		# List the audit log of the current team
+		jx get audit
+
+		# List the commands run by a user in the last day
+		jx get audit --user jstrachan --since 24h
+
+		# List the failed promotions
+		jx get audit --command promote --failed
+	`)
+)
+
+// NewCmdGetAudit creates the new command for: jx get audit
+func NewCmdGetAudit(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetAuditOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "audit",
+		Short:   "Display the audit log of the mutating jx commands run against the current Team",
+		Aliases: []string{"audits"},
+		Long:    getAuditLong,
+		Example: getAuditExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.User, "user", "u", "", "Only display the commands run by the given user")
+	cmd.Flags().StringVarP(&options.Command, "command", "c", "", "Only display the commands which contain the given text such as 'delete'")
+	cmd.Flags().DurationVarP(&options.Since, "since", "s", 0, "Only display the commands run within the given duration such as 24h")
+	cmd.Flags().BoolVarP(&options.Failed, "failed", "", false, "Only display the commands which failed")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetAuditOptions) Run() error {
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	entries, err := audit.GetEntries(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("TIME", "USER", "KUBE USER", "NAMESPACE", "STATUS", "COMMAND")
+	for _, entry := range entries {
+		if !o.matches(entry) {
+			continue
+		}
+		table.AddRow(entry.Time.Format(time.RFC3339), entry.User, entry.KubeUser, entry.Namespace, entry.Status(), entry.CommandLine())
+	}
+	table.Render()
+	return nil
+}
+
+func (o *GetAuditOptions) matches(entry *audit.Entry) bool {
+	if o.User != "" && o.User != entry.User && o.User != entry.KubeUser {
+		return false
+	}
+	if o.Command != "" && !strings.Contains(entry.Command, o.Command) {
+		return false
+	}
+	if o.Since > 0 && entry.Time.Before(time.Now().Add(-o.Since)) {
+		return false
+	}
+	if o.Failed && entry.Succeeded {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GetAWSPolicyOptions containers the CLI options
+type GetAWSPolicyOptions struct {
+	GetOptions
+
+	Kinds      []string
+	Validate   bool
+	OutputFile string
+}
+
+var (
+	getAWSPolicyLong = templates.LongDesc(`
+		Displays the minimal AWS IAM policy document needed to install Jenkins X using eksctl or kops and to use ECR
+		as the container registry.
+
+		The current AWS credentials can be validated against the policy which reports the permissions which are missing.
+`)
+
+	getAWSPolicyExample = templates.Examples(`
+		# Display the policy needed to create an EKS cluster and use ECR
+		jx get aws-policy
+
+		# Save the policy needed by kops to a file
+		jx get aws-policy --kind kops --output-file policy.json
+
+		# Check the current credentials have the permissions needed to create an EKS cluster
+		jx get aws-policy --kind eks --validate
+	`)
+)
+
+// NewCmdGetAWSPolicy creates the new command for: jx get aws-policy
+func NewCmdGetAWSPolicy(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetAWSPolicyOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "aws-policy",
+		Short:   "Displays and validates the AWS IAM policy needed to install Jenkins X",
+		Aliases: []string{"awspolicy"},
+		Long:    getAWSPolicyLong,
+		Example: getAWSPolicyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&options.Kinds, "kind", "k", []string{amazon.PolicyEKS, amazon.PolicyRegistry}, "The kinds of installation to include in the policy: "+strings.Join(amazon.PolicyKinds, ", "))
+	cmd.Flags().BoolVarP(&options.Validate, "validate", "", false, "Validates the current AWS credentials have the permissions of the policy")
+	cmd.Flags().StringVarP(&options.OutputFile, "output-file", "f", "", "The file to save the policy document to")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetAWSPolicyOptions) Run() error {
+	policy, err := amazon.InstallPolicy(o.Kinds...)
+	if err != nil {
+		return err
+	}
+	if o.Validate {
+		return o.validatePolicy(policy)
+	}
+	data, err := json.MarshalIndent(policy, "", "  ")
+	if err != nil {
+		return err
+	}
+	if o.OutputFile != "" {
+		err = ioutil.WriteFile(o.OutputFile, data, util.DefaultWritePermissions)
+		if err != nil {
+			return err
+		}
+		log.Infof("Saved the policy to %s\n", util.ColorInfo(o.OutputFile))
+		return nil
+	}
+	_, err = fmt.Fprintln(o.Out, string(data))
+	return err
+}
+
+func (o *GetAWSPolicyOptions) validatePolicy(policy *amazon.PolicyDocument) error {
+	missing, err := amazon.MissingPermissions(policy)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		log.Infof("The current AWS credentials have all the %d permissions needed\n", len(policy.Actions()))
+		return nil
+	}
+	table := o.CreateTable()
+	table.AddRow("MISSING PERMISSION")
+	for _, action := range missing {
+		table.AddRow(action)
+	}
+	table.Render()
+	return fmt.Errorf("the current AWS credentials are missing %d of the %d permissions needed", len(missing), len(policy.Actions()))
+}
+
+// warnMissingAWSPermissions warns about any permissions of the policy which the current credentials are missing.
+// The check is skipped if the aws CLI is not installed or the credentials cannot simulate policies
+func (o *CommonOptions) warnMissingAWSPermissions(kinds ...string) {
+	if _, err := o.getCommandOutput("", "aws", "--version"); err != nil {
+		return
+	}
+	policy, err := amazon.InstallPolicy(kinds...)
+	if err != nil {
+		o.Debugf("failed to create the AWS policy: %s\n", err)
+		return
+	}
+	missing, err := amazon.MissingPermissions(policy)
+	if err != nil {
+		o.Debugf("failed to validate the AWS permissions: %s\n", err)
+		return
+	}
+	if len(missing) > 0 {
+		log.Warnf("The current AWS credentials are missing the permissions: %s\n", strings.Join(missing, ", "))
+		log.Warnf("You can display the policy needed via: %s\n", util.ColorInfo("jx get aws-policy --kind "+strings.Join(kinds, " --kind ")))
+	}
+}
@@ -89,7 +89,7 @@ func (o *GetBuildLogsOptions) Run() error {
 
 	devEnv, err := kube.GetEnrichedDevEnvironment(kubeClient, jxClient, ns)
 	webhookEngine := devEnv.Spec.WebHookEngine
-	if webhookEngine == v1.WebHookEngineProw {
+	if webhookEngine == v1.WebHookEngineProw || webhookEngine == v1.WebHookEngineLighthouse {
 		return o.getProwBuildLog(kubeClient, jxClient, ns)
 	}
 	jobMap, err := o.getJobMap(o.Filter)
@@ -238,7 +238,7 @@ func (o *GetBuildLogsOptions) getProwBuildLog(kubeClient kubernetes.Interface, j
 			params.DefaultValuesFromEnvVars(lastInitC.Env)
 
 			if params.MatchesPipeline(build) {
-				return o.getPodLog(ns, pod, lastInitC)
+				return o.getPodLog(kubeClient, ns, pod)
 			}
 		}
 	}
@@ -246,9 +246,19 @@ func (o *GetBuildLogsOptions) getProwBuildLog(kubeClient kubernetes.Interface, j
 	return nil
 }
 
-func (o *GetBuildLogsOptions) getPodLog(ns string, pod *corev1.Pod, container corev1.Container) error {
-	log.Infof("Getting the pod log for pod %s and init container %s\n", pod.Name, container.Name)
-	return o.tailLogs(ns, pod.Name, container.Name)
+func (o *GetBuildLogsOptions) getPodLog(kubeClient kubernetes.Interface, ns string, pod *corev1.Pod) error {
+	selector := builds.GetBuildPodSelector(pod)
+	if selector == "" {
+		return fmt.Errorf("No build name label on pod %s", pod.Name)
+	}
+	hint, err := kube.UnschedulablePodHint(kubeClient, pod)
+	if err != nil {
+		log.Warnf("Failed to check if build pod %s can be scheduled: %s\n", pod.Name, err)
+	} else if hint != "" {
+		log.Warnf("%s\n", hint)
+	}
+	log.Infof("Getting the logs of the steps of build pod %s\n", pod.Name)
+	return o.streamLogs(kubeClient, ns, selector, "", true)
 }
 
 type BuildParams struct {
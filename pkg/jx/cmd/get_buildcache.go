@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetBuildCacheOptions containers the CLI options
+type GetBuildCacheOptions struct {
+	GetOptions
+}
+
+var (
+	getBuildCacheLong = templates.LongDesc(`
+		Display the dependency caches for the builds of the current Team along with the status of their storage
+`)
+
+	getBuildCacheExample = templates.Examples(`
+		# List the build caches for the current team
+		jx get buildcaches
+	`)
+)
+
+// NewCmdGetBuildCache creates the new command for: jx get buildcaches
+func NewCmdGetBuildCache(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetBuildCacheOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     buildCaches,
+		Short:   "Display the dependency caches for the builds of the current Team",
+		Aliases: buildCacheAliases,
+		Long:    getBuildCacheLong,
+		Example: getBuildCacheExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetBuildCacheOptions) Run() error {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	kubeClient, ns, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, _, err = kube.GetDevNamespace(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("KIND", "CLAIM", "STATUS", "CAPACITY", "STORAGE CLASS", "REGISTRY")
+	for _, cache := range settings.BuildCaches {
+		name := kube.BuildCacheName(cache.Kind)
+		status := "Missing"
+		capacity := cache.StorageSize
+		storageClass := cache.StorageClass
+		pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(ns).Get(name, metav1.GetOptions{})
+		if err == nil {
+			status = string(pvc.Status.Phase)
+			if q, ok := pvc.Status.Capacity[corev1.ResourceStorage]; ok {
+				capacity = q.String()
+			}
+			if pvc.Spec.StorageClassName != nil {
+				storageClass = *pvc.Spec.StorageClassName
+			}
+		}
+		table.AddRow(cache.Kind, name, status, capacity, storageClass, cache.RegistryURL)
+	}
+	table.Render()
+	return nil
+}
@@ -70,6 +70,7 @@ func NewCmdGetCVE(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	options.addCommonFlags(cmd)
 	options.addGetCVEFlags(cmd)
 
+	cmd.AddCommand(NewCmdGetCVEReport(f, out, errOut))
 	return cmd
 }
 
@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/spf13/cobra"
+)
+
+// GetCVEReportOptions the command line options
+type GetCVEReportOptions struct {
+	GetOptions
+
+	Feed      string
+	Namespace string
+	Severity  string
+	Fail      bool
+}
+
+var (
+	getCVEReportLong = templates.LongDesc(`
+		Reports the known security advisories affecting the chart and image versions of the installed platform.
+
+		The advisories are loaded from a published feed which can be overridden via the --feed option or the $` + cve.AdvisoryFeedEnvVar + ` environment variable. Upgrade the affected components via 'jx upgrade platform'.
+`)
+
+	getCVEReportExample = templates.Examples(`
+		# Reports the advisories affecting the installed platform
+		jx get cve report
+
+		# Reports the high and critical advisories using a local feed and fails if there are any
+		jx get cve report --severity high --feed advisories.yml --fail
+	`)
+)
+
+// NewCmdGetCVEReport creates the command
+func NewCmdGetCVEReport(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetCVEReportOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "report",
+		Short:   "Reports the security advisories affecting the installed platform components",
+		Long:    getCVEReportLong,
+		Example: getCVEReportExample,
+		Aliases: []string{"platform"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Feed, "feed", "", "", "The file or URL of the advisory feed. Defaults to $"+cve.AdvisoryFeedEnvVar+" or "+cve.DefaultAdvisoryFeedURL)
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace the platform is installed in. Defaults to the dev namespace")
+	cmd.Flags().StringVarP(&options.Severity, "severity", "s", "", fmt.Sprintf("The minimum severity of the advisories to report. One of: %s", strings.Join(cve.Severities, ", ")))
+	cmd.Flags().BoolVarP(&options.Fail, "fail", "", false, "Fails if any advisory affects the platform so that it can be used as a pipeline gate")
+
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetCVEReportOptions) Run() error {
+	ns := o.Namespace
+	if ns == "" {
+		_, devNs, err := o.KubeClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		ns = devNs
+	}
+	feed, err := cve.LoadAdvisoryFeed(advisoryFeedLocation(o.Feed))
+	if err != nil {
+		return err
+	}
+	release, err := o.installedPlatformRelease(ns)
+	if err != nil {
+		return err
+	}
+	matches, err := cve.FilterBySeverity(feed.Match(cve.ReleaseComponents(release)), o.Severity)
+	if err != nil {
+		return err
+	}
+	if o.Output != "" {
+		err = o.renderResult(matches, o.Output)
+		if err == nil && o.Fail && len(matches) > 0 {
+			err = fmt.Errorf("%d advisories affect the platform installed in namespace %s", len(matches), ns)
+		}
+		return err
+	}
+	if len(matches) == 0 {
+		log.Successf("No advisories affect the platform installed in namespace %s", ns)
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow("COMPONENT", "KIND", "VERSION", "ADVISORY", "SEVERITY", "FIXED IN", "URL")
+	for _, m := range matches {
+		fixed := m.Advisory.Fixed
+		if fixed == "" {
+			fixed = "none"
+		}
+		table.AddRow(m.Component.Name, m.Component.Kind, m.Component.Version, m.Advisory.ID, m.Advisory.Severity, fixed, m.Advisory.URL)
+	}
+	table.Render()
+
+	if o.Fail {
+		return fmt.Errorf("%d advisories affect the platform installed in namespace %s. Upgrade it via: jx upgrade platform", len(matches), ns)
+	}
+	return nil
+}
+
+// advisoryFeedLocation returns the file or URL of the advisory feed from the option, the environment or the default
+func advisoryFeedLocation(feed string) string {
+	if feed == "" {
+		feed = os.Getenv(cve.AdvisoryFeedEnvVar)
+	}
+	if feed == "" {
+		feed = cve.DefaultAdvisoryFeedURL
+	}
+	return feed
+}
+
+// logReleaseAdvisories logs the advisories fixed by upgrading the platform and the ones which still affect the
+// target release
+func logReleaseAdvisories(feed *cve.AdvisoryFeed, installed *versionstream.Release, target *versionstream.Release) {
+	remaining := feed.Match(cve.ReleaseComponents(target))
+	affected := map[string]bool{}
+	for _, m := range remaining {
+		affected[m.Advisory.ID] = true
+	}
+	for _, m := range feed.Match(cve.ReleaseComponents(installed)) {
+		if !affected[m.Advisory.ID] {
+			log.Infof("  fixes %s advisory %s in %s %s\n", strings.ToLower(m.Advisory.Severity), util.ColorInfo(m.Advisory.ID), m.Component.Name, m.Component.Version)
+		}
+	}
+	for _, m := range remaining {
+		log.Warnf("  %s advisory %s still affects %s %s of release %s\n", strings.ToLower(m.Advisory.Severity), m.Advisory.ID, m.Component.Name, m.Component.Version, target.Version)
+	}
+}
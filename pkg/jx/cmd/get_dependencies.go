@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/dependencies"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/spf13/cobra"
+)
+
+// GetDependenciesOptions containers the CLI options
+type GetDependenciesOptions struct {
+	GetOptions
+
+	Library string
+	Kind    string
+}
+
+var (
+	getDependenciesLong = templates.LongDesc(`
+		Display the dependency graph of the current Team which 'jx step update dependencies' uses to
+		create Pull Requests when a library is released.
+`)
+
+	getDependenciesExample = templates.Examples(`
+		# List all the dependencies
+		jx get dependencies
+
+		# List the repositories which depend on a library
+		jx get deps --library io.acme:core
+	`)
+)
+
+// NewCmdGetDependencies creates the new command for: jx get dependencies
+func NewCmdGetDependencies(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetDependenciesOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "dependencies",
+		Short:   "Display the repositories which depend on libraries of the team",
+		Aliases: []string{"dependency", "deps"},
+		Long:    getDependenciesLong,
+		Example: getDependenciesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Library, "library", "l", "", "Only display the repositories which depend on this library")
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", "Only display the dependencies of this kind")
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetDependenciesOptions) Run() error {
+	kubeClient, ns, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	graph, err := dependencies.LoadGraph(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("LIBRARY", "KIND", "REPOSITORY", "BRANCH")
+	for _, d := range graph.Dependencies {
+		if o.Library != "" && o.Library != d.Library {
+			continue
+		}
+		if o.Kind != "" && o.Kind != string(d.Kind) {
+			continue
+		}
+		branch := d.Branch
+		if branch == "" {
+			branch = "master"
+		}
+		table.AddRow(d.Library, string(d.Kind), d.Repository, branch)
+	}
+	table.Render()
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
@@ -107,6 +108,9 @@ func (o *GetEnvOptions) Run() error {
 		table.AddRow(e, spec.Label, spec.Namespace, kindString(spec), spec.Source.URL, spec.Source.Ref, spec.PullRequestURL)
 		table.Render()
 		log.Blank()
+		if kube.IsEnvironmentLocked(env, time.Now()) {
+			log.Warnf("The %s\n\n", kube.EnvironmentLockMessage(env))
+		}
 
 		ens := env.Spec.Namespace
 		if ens != "" {
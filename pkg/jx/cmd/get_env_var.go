@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/appconfig"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GetEnvVarOptions the command line options
+type GetEnvVarOptions struct {
+	GetOptions
+
+	App         string
+	Environment string
+}
+
+var (
+	getEnvVarLong = templates.LongDesc(`
+		Display the environment variables of an app which are deployed in an environment.
+
+		The values of secret environment variables are not displayed.
+`)
+
+	getEnvVarExample = templates.Examples(`
+		# List the environment variables of an app in staging
+		jx get env-vars --app myapp --env staging
+	`)
+)
+
+// NewCmdGetEnvVar creates the command
+func NewCmdGetEnvVar(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetEnvVarOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "env-vars",
+		Short:   "Display the environment variables of an app in an environment",
+		Long:    getEnvVarLong,
+		Example: getEnvVarExample,
+		Aliases: []string{"env-var", "envvar", "envvars"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The name of the app")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The name of the environment")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetEnvVarOptions) Run() error {
+	if o.App == "" {
+		return util.MissingOption("app")
+	}
+	if o.Environment == "" {
+		return util.MissingOption(optionEnvironment)
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, err := kube.GetEnvironmentNamespace(jxClient, devNs, o.Environment)
+	if err != nil {
+		return err
+	}
+	key, err := appconfig.LoadOrCreateKey(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	config, err := appconfig.LoadAppConfigFromCluster(kubeClient, ns, o.App, key)
+	if err != nil {
+		return err
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "VALUE", "SOURCE")
+	for _, name := range config.Names() {
+		if value, ok := config.ConfigMap[name]; ok {
+			table.AddRow(name, value, "ConfigMap "+appconfig.ConfigMapName(o.App))
+		} else {
+			table.AddRow(name, "********", "Secret "+appconfig.SecretName(o.App))
+		}
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,158 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/builds"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GetEventsOptions the command line options
+type GetEventsOptions struct {
+	GetOptions
+
+	Environment     string
+	Build           string
+	Since           time.Duration
+	TillerNamespace string
+	Warnings        bool
+}
+
+var (
+	getEventsLong = templates.LongDesc(`
+		Display a chronological troubleshooting timeline of an application or build
+
+		The Kubernetes events of the Deployments, ReplicaSets and Pods of the application, the current status of their
+		containers and the helm release history are correlated across the namespaces of all the environments of the team.
+`)
+
+	getEventsExample = templates.Examples(`
+		# Display the timeline of an application in all environments
+		jx get events myapp
+
+		# Display the timeline of an application in the staging environment over the last hour
+		jx get events myapp --env staging --since 1h
+
+		# Display only the warnings of a build
+		jx get events --build myorg-myapp-master-3 --warnings
+	`)
+)
+
+// NewCmdGetEvents creates the command
+func NewCmdGetEvents(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetEventsOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "events [app] [flags]",
+		Short:   "Display a chronological troubleshooting timeline of an application or build",
+		Long:    getEventsLong,
+		Example: getEventsExample,
+		Aliases: []string{"event", "timeline"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Environment, "env", "e", "", "Only display the timeline in the given environment")
+	cmd.Flags().StringVarP(&options.Build, "build", "b", "", "The name of a build whose pods are included")
+	cmd.Flags().DurationVarP(&options.Since, "since", "s", 0, "Only display the entries within the given duration such as 1h")
+	cmd.Flags().StringVarP(&options.TillerNamespace, optionTillerNamespace, "", kube.DefaultTillerNamespace, "The namespace in which tiller stores the helm releases")
+	cmd.Flags().BoolVarP(&options.Warnings, "warnings", "w", false, "Only display the warnings")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetEventsOptions) Run() error {
+	query := &kube.TimelineQuery{
+		TillerNamespace: o.TillerNamespace,
+	}
+	if len(o.Args) > 0 {
+		query.App = o.Args[0]
+	}
+	if o.Build != "" {
+		query.PodSelector = builds.LabelBuildName + "=" + o.Build
+	}
+	if query.App == "" && query.PodSelector == "" {
+		return fmt.Errorf("Missing argument for the application name or the --build option")
+	}
+	if o.Since > 0 {
+		query.Since = time.Now().Add(-o.Since)
+	}
+
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	envMap, envNames, err := kube.GetEnvironments(jxClient, devNs)
+	if err != nil {
+		return err
+	}
+	if o.Environment != "" {
+		env := envMap[o.Environment]
+		if env == nil {
+			return util.InvalidOption("env", o.Environment, envNames)
+		}
+		query.Namespaces = []string{env.Spec.Namespace}
+	} else {
+		namespaces := map[string]bool{devNs: true}
+		for _, env := range envMap {
+			if env.Spec.Namespace != "" {
+				namespaces[env.Spec.Namespace] = true
+			}
+		}
+		for ns := range namespaces {
+			query.Namespaces = append(query.Namespaces, ns)
+		}
+		sort.Strings(query.Namespaces)
+	}
+
+	entries, err := kube.GetTimeline(kubeClient, query)
+	if err != nil {
+		return err
+	}
+	if len(entries) == 0 {
+		log.Infof("No events found in namespaces %s\n", util.ColorInfo(strings.Join(query.Namespaces, ", ")))
+		return nil
+	}
+
+	table := o.CreateTable()
+	table.AddRow("TIME", "NAMESPACE", "KIND", "NAME", "REASON", "COUNT", "MESSAGE")
+	for _, e := range entries {
+		if o.Warnings && !e.IsWarning() {
+			continue
+		}
+		reason := e.Reason
+		if e.IsWarning() {
+			reason = util.ColorError(reason)
+		}
+		count := ""
+		if e.Count > 1 {
+			count = fmt.Sprintf("%d", e.Count)
+		}
+		table.AddRow(e.Time.Format(time.RFC3339), e.Namespace, e.Kind, e.Name, reason, count, e.Message)
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"io"
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	getExposureLong = templates.LongDesc(`
+		Display the exposure policies of the platform services of your team
+
+		Services without a policy are public if they are exposed and internal otherwise. See 'jx edit exposure'.
+`)
+
+	getExposureExample = templates.Examples(`
+		# List the exposure policies of the services in the development namespace
+		jx get exposure
+	`)
+)
+
+// GetExposureOptions the options for the get exposure command
+type GetExposureOptions struct {
+	GetOptions
+}
+
+// NewCmdGetExposure creates a command object for the "get exposure" command
+func NewCmdGetExposure(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetExposureOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "exposure",
+		Short:   "Display the exposure policies of the platform services",
+		Long:    getExposureLong,
+		Example: getExposureExample,
+		Aliases: []string{"exposures"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *GetExposureOptions) Run() error {
+	client, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return err
+	}
+	services, err := client.CoreV1().Services(devNs).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	configured := map[string]bool{}
+	for _, e := range settings.Exposure {
+		configured[e.Service] = true
+	}
+	sort.Slice(services.Items, func(i, j int) bool {
+		return services.Items[i].Name < services.Items[j].Name
+	})
+
+	table := o.CreateTable()
+	table.AddRow("SERVICE", "POLICY", "ACCESS")
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if !configured[svc.Name] && svc.Annotations[kube.ExposeAnnotation] != "true" {
+			continue
+		}
+		policy := kube.GetServiceExposurePolicy(settings.Exposure, svc)
+		access := kube.GetServiceURL(svc)
+		if policy == v1.ExposurePolicyInternal {
+			access = portForwardCommand(devNs, svc.Name)
+		}
+		table.AddRow(svc.Name, string(policy), access)
+	}
+	table.Render()
+	return nil
+}
@@ -0,0 +1,119 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GetHookFailuresOptions containers the CLI options
+type GetHookFailuresOptions struct {
+	GetOptions
+
+	Namespace string
+	All       bool
+}
+
+var (
+	getHookFailuresLong = templates.LongDesc(`
+		Display the webhook deliveries from the git providers which the webhook engine of the team failed to process.
+
+		The deliveries are stored by the hook relay which is run via 'jx controller hook-relay'.
+`)
+
+	getHookFailuresExample = templates.Examples(`
+		# List the failed webhook deliveries which have not been replayed yet
+		jx get hook-failures
+
+		# List all the failed webhook deliveries including those which have been replayed
+		jx get hook-failures --all
+	`)
+)
+
+// NewCmdGetHookFailures creates the new command for: jx get hook-failures
+func NewCmdGetHookFailures(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetHookFailuresOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "hook-failures",
+		Short:   "Display the webhook deliveries which the webhook engine failed to process",
+		Aliases: []string{"hook-failure", "hookfailures"},
+		Long:    getHookFailuresLong,
+		Example: getHookFailuresExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace to view or defaults to the dev namespace")
+	cmd.Flags().BoolVarP(&options.All, "all", "a", false, "Include the deliveries which have been replayed")
+
+	options.addGetFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *GetHookFailuresOptions) Run() error {
+	err := o.registerHookFailureCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		ns = devNs
+	}
+	failures, err := kube.HookFailures(jxClient, ns, o.All)
+	if err != nil {
+		return err
+	}
+	if len(failures) == 0 {
+		log.Infof("No failed webhook deliveries found in namespace %s\n", util.ColorInfo(ns))
+		return nil
+	}
+	table := o.CreateTable()
+	table.AddRow("NAME", "EVENT", "REPOSITORY", "REASON", "STATUS", "ATTEMPTS", "AGE", "MESSAGE")
+	now := time.Now()
+	for _, failure := range failures {
+		age := ""
+		if failure.Spec.ReceivedTimestamp != nil {
+			age = now.Sub(failure.Spec.ReceivedTimestamp.Time).Round(time.Second).String()
+		}
+		message := failure.Status.Message
+		if message == "" {
+			message = failure.Spec.Message
+		}
+		table.AddRow(failure.Name, failure.Spec.Event, failure.Spec.Repository, hookFailureReason(failure),
+			string(failure.Status.Phase), strconv.Itoa(failure.Status.Attempts), age, message)
+	}
+	table.Render()
+	return nil
+}
+
+func hookFailureReason(failure *v1.HookFailure) string {
+	reason := string(failure.Spec.Reason)
+	if failure.Spec.Reason == v1.HookFailureReasonHMACMismatch {
+		return fmt.Sprintf("%s (replay with --resign)", reason)
+	}
+	return reason
+}
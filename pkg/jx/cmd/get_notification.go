@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"io"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// GetNotificationOptions the command line options
+type GetNotificationOptions struct {
+	GetOptions
+}
+
+var (
+	getNotificationLong = templates.LongDesc(`
+		Display the chat channels which are notified of the pipeline and promotion events of the current team
+
+`)
+
+	getNotificationExample = templates.Examples(`
+		# List the notification channels
+		jx get notifications
+	`)
+)
+
+// NewCmdGetNotification creates the command
+func NewCmdGetNotification(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetNotificationOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "notifications [flags]",
+		Short:   "Display the chat channels which are notified of pipeline and promotion events",
+		Long:    getNotificationLong,
+		Example: getNotificationExample,
+		Aliases: []string{"notification", "notify"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	return cmd
+}
+
+// Run implements this command
+func (o *GetNotificationOptions) Run() error {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	channels, err := notify.GetChannels(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	if len(channels) == 0 {
+		log.Infof("No notification channels. To add one use: %s\n", util.ColorInfo("jx create notification"))
+		return nil
+	}
+	table := o.CreateTable()
+	table.AddRow("NAME", "PROVIDER", "EVENTS")
+	for _, c := range channels {
+		table.AddRow(c.Name, c.Provider, strings.Join(c.Events, ", "))
+	}
+	table.Render()
+	return nil
+}
@@ -88,14 +88,18 @@ func (o *GetQuickstartLocationOptions) Run() error {
 	}
 
 	table := o.CreateTable()
-	table.AddRow("GIT SERVER", "KIND", "OWNER", "INCLUDES", "EXCLUDES")
+	table.AddRow("GIT SERVER", "KIND", "OWNER", "INCLUDES", "EXCLUDES", "CATALOG")
 
 	for _, location := range locations {
 		kind := location.GitKind
 		if kind == "" {
 			kind = gits.KindGitHub
 		}
-		table.AddRow(location.GitURL, kind, location.Owner, strings.Join(location.Includes, ", "), strings.Join(location.Excludes, ", "))
+		catalog := location.CatalogURL
+		if catalog != "" && location.CatalogPublicKey != "" {
+			catalog += " (signed)"
+		}
+		table.AddRow(location.GitURL, kind, location.Owner, strings.Join(location.Includes, ", "), strings.Join(location.Excludes, ", "), catalog)
 	}
 	table.Render()
 	return nil
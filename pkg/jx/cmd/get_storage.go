@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/spf13/cobra"
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetStorageOptions the command line options
+type GetStorageOptions struct {
+	GetOptions
+
+	Namespace string
+	NoUsage   bool
+}
+
+var (
+	getStorageLong = templates.LongDesc(`
+		Display the persistent volumes of the platform components with their storage class and disk usage.
+
+		The disk usage is reported by the kubelet of the nodes running the pods which mount the volumes.
+`)
+
+	getStorageExample = templates.Examples(`
+		# List the persistent volumes of the platform components
+		jx get storage
+	`)
+)
+
+// NewCmdGetStorage creates the command
+func NewCmdGetStorage(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &GetStorageOptions{
+		GetOptions: GetOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "storage",
+		Short:   "Display the persistent volumes of the platform components and their disk usage",
+		Long:    getStorageLong,
+		Example: getStorageExample,
+		Aliases: []string{"pvc", "pvcs"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the PersistentVolumeClaims. Defaults to the development namespace")
+	cmd.Flags().BoolVarP(&options.NoUsage, "no-usage", "", false, "Disables querying the kubelets for the disk usage")
+	return cmd
+}
+
+// Run implements this command
+func (o *GetStorageOptions) Run() error {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		_, ns, err = o.JXClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+	}
+	pvcs, err := kubeClient.CoreV1().PersistentVolumeClaims(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	if len(pvcs.Items) == 0 {
+		log.Infof("No PersistentVolumeClaims found in namespace %s\n", ns)
+		return nil
+	}
+	usages := map[string]kube.PVCUsage{}
+	if !o.NoUsage {
+		usages, err = kube.GetPVCUsage(kubeClient, ns)
+		if err != nil {
+			log.Warnf("Could not get the disk usage of the volumes: %s\n", err)
+		}
+	}
+
+	table := o.CreateTable()
+	table.AddRow("NAME", "COMPONENT", "STATUS", "CAPACITY", "USED", "STORAGE CLASS", "EXPANDABLE")
+	for i := range pvcs.Items {
+		pvc := &pvcs.Items[i]
+		capacity := pvc.Status.Capacity[v1.ResourceStorage]
+		if capacity.IsZero() {
+			capacity = pvc.Spec.Resources.Requests[v1.ResourceStorage]
+		}
+		used := ""
+		if usage, ok := usages[pvc.Name]; ok {
+			used = resource.NewQuantity(usage.UsedBytes, resource.BinarySI).String()
+			if usage.CapacityBytes > 0 {
+				used += fmt.Sprintf(" (%d%%)", usage.UsedBytes*100/usage.CapacityBytes)
+			}
+		}
+		scName, err := kube.PVCStorageClassName(kubeClient, pvc)
+		if err != nil {
+			return err
+		}
+		expandable := ""
+		if scName != "" {
+			sc, err := kubeClient.StorageV1().StorageClasses().Get(scName, metav1.GetOptions{})
+			if err == nil {
+				expandable = fmt.Sprintf("%v", kube.StorageClassAllowsExpansion(sc))
+			}
+		}
+		table.AddRow(pvc.Name, pvc.Labels["app"], string(pvc.Status.Phase), capacity.String(), used, scName, expandable)
+	}
+	table.Render()
+	return nil
+}
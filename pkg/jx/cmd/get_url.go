@@ -1,20 +1,25 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GetURLOptions the command line options
 type GetURLOptions struct {
 	GetOptions
 
-	Namespace   string
-	Environment string
+	Namespace     string
+	Environment   string
+	Selector      string
+	FieldSelector string
+	Watch         bool
 }
 
 var (
@@ -26,6 +31,12 @@ var (
 	get_url_example = templates.Examples(`
 		# List all URLs in this namespace
 		jx get url
+
+		# List the URLs of the services with a label
+		jx get url -l app=jenkins
+
+		# Watch the URLs while they are exposed during an install
+		jx get url -w
 	`)
 )
 
@@ -59,28 +70,43 @@ func NewCmdGetURL(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 }
 
 func (o *GetURLOptions) addGetUrlFlags(cmd *cobra.Command) {
-	cmd.Flags().StringVarP(&o.Namespace, "namespace", "n", "", "Specifies the namespace name to look inside")
-	cmd.Flags().StringVarP(&o.Environment, "env", "e", "", "Specifies the Environment name to look inside")
+	addNamespaceFlags(cmd, &o.Namespace, &o.Environment)
+	cmd.Flags().StringVarP(&o.Selector, "selector", "l", "", "The label selector of the services to display the URLs of")
+	cmd.Flags().StringVarP(&o.FieldSelector, "field-selector", "", "", "The field selector of the services to display the URLs of")
+	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", false, "Whether to watch the services and display the URLs again whenever they change")
 }
 
 // Run implements this command
 func (o *GetURLOptions) Run() error {
-	client, ns, err := o.KubeClient()
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns, err := o.Namespaces().Resolve(o.Namespace, o.Environment)
 	if err != nil {
 		return err
 	}
-	if o.Namespace != "" {
-		ns = o.Namespace
-	} else if o.Environment != "" {
-		ns, err = o.findEnvironmentNamespace(o.Environment)
-		if err != nil {
-			return err
-		}
+	listOptions := metav1.ListOptions{
+		LabelSelector: o.Selector,
+		FieldSelector: o.FieldSelector,
 	}
-	urls, err := kube.FindServiceURLs(client, ns)
+	if o.Watch {
+		stop := make(chan struct{})
+		kube.WatchServiceURLs(client, ns, listOptions, stop, func(urls []kube.ServiceURL) {
+			fmt.Fprintln(o.Out)
+			o.renderURLs(urls)
+		})
+		return nil
+	}
+	urls, err := kube.FindServiceURLsWithOptions(client, ns, listOptions)
 	if err != nil {
 		return err
 	}
+	o.renderURLs(urls)
+	return nil
+}
+
+func (o *GetURLOptions) renderURLs(urls []kube.ServiceURL) {
 	table := o.CreateTable()
 	table.AddRow("Name", "URL")
 
@@ -88,5 +114,4 @@ func (o *GetURLOptions) Run() error {
 		table.AddRow(url.Name, url.URL)
 	}
 	table.Render()
-	return nil
 }
@@ -72,7 +72,7 @@ func checkErrWithPrefix(prefix string, err error) {
 }
 
 // checkErr formats a given error as a string and calls the passed handleErr
-// func with that string and an kubectl exit code.
+// func with that string and the exit code of the category of the error.
 func checkErr(prefix string, err error, handleErr func(string, int)) {
 	// unwrap aggregates of 1
 	/*
@@ -128,7 +128,7 @@ func checkErr(prefix string, err error, handleErr func(string, int)) {
 					msg = fmt.Sprintf("error: %s", msg)
 				}
 			}
-			handleErr(msg, DefaultErrorExitCode)
+			handleErr(formatError(msg, err))
 		}
 	}
 }
@@ -80,6 +80,7 @@ type ImportOptions struct {
 	AppName                 string
 	GitHub                  bool
 	DryRun                  bool
+	Preview                 bool
 	SelectAll               bool
 	DisableDraft            bool
 	DisableJenkinsfileCheck bool
@@ -92,6 +93,10 @@ type ImportOptions struct {
 	DraftPack               string
 	DefaultOwner            string
 	DockerRegistryOrg       string
+	Windows                 bool
+	RuntimeClass            string
+	DeployKind              string
+	KnativeScaling          kube.KnativeScaling
 
 	DisableDotGitSearch   bool
 	InitialisedGit        bool
@@ -102,6 +107,10 @@ type ImportOptions struct {
 	GitProvider           gits.GitProvider
 	PostDraftPackCallback CallbackFn
 	DisableMaven          bool
+	Monorepo              bool
+	MonorepoAppDirs       []string
+	MonorepoApps          []prow.MonorepoApp
+	SecretScan            SecretScanOptions
 }
 
 var (
@@ -112,6 +121,10 @@ var (
 	    Or you can use '--dir' to specify a directory to import.
 
 	    You can specify the git URL as an argument.
+
+		When importing a monorepo via '--monorepo' the pull request pipeline of an application only runs when its
+		directory changes. Prow postsubmit jobs can not be filtered on the changed files so the release pipeline of
+		every application runs on each merge to the master branch.
 	    
 		For more documentation see: [https://jenkins-x.io/developing/import/](https://jenkins-x.io/developing/import/)
 	    
@@ -127,6 +140,9 @@ var (
 		# Import a git repository from a URL
 		jx import --url https://github.com/jenkins-x/spring-boot-web-example.git
 
+		# Report the build pack and the files which would be generated without changing the current folder
+		jx import --preview
+
         # Select a number of repositories from a github organisation
 		jx import --github --org myname 
 
@@ -135,6 +151,15 @@ var (
 
         # Import all repositories from a github organisation which contain the text foo
 		jx import --github --org myname --all --filter foo 
+
+		# Import a monorepo with an application in each of the 'frontend' and 'backend' folders
+		jx import --monorepo --app-dir frontend --app-dir backend
+
+		# Import a Windows container application which runs with a specific RuntimeClass
+		jx import --windows --runtime-class windows-2019
+
+		# Import an application deployed as a Knative Service which runs at most 10 pods
+		jx import --deploy-kind knative --max-scale 10
 		`)
 )
 
@@ -163,6 +188,8 @@ func NewCmdImport(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.Flags().BoolVarP(&options.GitHub, "github", "", false, "If you wish to pick the repositories from GitHub to import")
 	cmd.Flags().BoolVarP(&options.SelectAll, "all", "", false, "If selecting projects to import from a git provider this defaults to selecting them all")
 	cmd.Flags().StringVarP(&options.SelectFilter, "filter", "", "", "If selecting projects to import from a git provider this filters the list of repositories")
+	cmd.Flags().BoolVarP(&options.Monorepo, "monorepo", "", false, "Imports a monorepo where each application lives in its own sub directory")
+	cmd.Flags().StringArrayVarP(&options.MonorepoAppDirs, "app-dir", "", []string{}, "The sub directories of the monorepo containing applications. If not specified they are detected from the top level directories")
 
 	options.addImportFlags(cmd, false)
 
@@ -181,6 +208,9 @@ func (options *ImportOptions) addImportFlags(cmd *cobra.Command, createProject b
 	cmd.Flags().StringVarP(&options.Credentials, "credentials", notCreateProject("c"), "", "The Jenkins credentials name used by the job")
 	cmd.Flags().StringVarP(&options.Jenkinsfile, "jenkinsfile", notCreateProject("j"), "", "The name of the Jenkinsfile to use. If not specified then 'Jenkinsfile' will be used")
 	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Performs local changes to the repo but skips the import into Jenkins X")
+	if !createProject {
+		cmd.Flags().BoolVarP(&options.Preview, "preview", "", false, "Reports the detected build pack, the files which would be generated and the remote changes which would be made without modifying the repo")
+	}
 	cmd.Flags().BoolVarP(&options.DisableDraft, "no-draft", "", false, "Disable Draft from trying to default a Dockerfile and Helm Chart")
 	cmd.Flags().BoolVarP(&options.DisableJenkinsfileCheck, "no-jenkinsfile", "", false, "Disable defaulting a Jenkinsfile if its missing")
 	cmd.Flags().StringVarP(&options.ImportGitCommitMessage, "import-commit-message", "", "", "Should we override the Jenkinsfile in the project?")
@@ -188,7 +218,15 @@ func (options *ImportOptions) addImportFlags(cmd *cobra.Command, createProject b
 	cmd.Flags().BoolVarP(&options.ListDraftPacks, "list-packs", "", false, "list available draft packs")
 	cmd.Flags().StringVarP(&options.DraftPack, "pack", "", "", "The name of the pack to use")
 	cmd.Flags().StringVarP(&options.DefaultOwner, "default-owner", "", "someone", "The default user/organisation used if no user is found for the current git repository being imported")
-	cmd.Flags().StringVarP(&options.DockerRegistryOrg, "docker-registry-org", "", "", "The name of the docker registry organisation to use. If not specified then the git provider organisation will be used")
+	cmd.Flags().StringVarP(&options.DockerRegistryOrg, "docker-registry-org", "", "", "The name of the docker registry organisation to use. If not specified then the organisation of the team or the git provider organisation will be used")
+	cmd.Flags().BoolVarP(&options.Windows, "windows", "", false, "Builds and deploys the application on Windows nodes. Enabled automatically if the Dockerfile uses a Windows base image")
+	cmd.Flags().StringVarP(&options.RuntimeClass, "runtime-class", "", "", "The name of the RuntimeClass the pods of the application use")
+	cmd.Flags().StringVarP(&options.DeployKind, "deploy-kind", "", "", "How the application is deployed: 'default' for a Deployment and Service or 'knative' for a Knative Service. If not specified then the deploy kind of the team is used")
+	cmd.Flags().IntVarP(&options.KnativeScaling.MinScale, "min-scale", "", 0, "The minimum number of pods of a Knative Service. The application scales to zero when idle if 0")
+	cmd.Flags().IntVarP(&options.KnativeScaling.MaxScale, "max-scale", "", 0, "The maximum number of pods of a Knative Service. Unlimited if 0")
+	cmd.Flags().IntVarP(&options.KnativeScaling.ContainerConcurrency, "concurrency", "", 0, "The maximum number of concurrent requests each pod of a Knative Service handles. Unlimited if 0")
+
+	options.SecretScan.addSecretScanFlags(cmd)
 
 	options.addCommonFlags(cmd)
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
@@ -211,6 +249,10 @@ func (options *ImportOptions) Run() error {
 
 	options.Factory.SetBatch(options.BatchMode)
 
+	if options.Preview {
+		options.DryRun = true
+	}
+
 	var err error
 	isProw := false
 	if !options.DryRun {
@@ -237,6 +279,9 @@ func (options *ImportOptions) Run() error {
 		if err != nil {
 			return err
 		}
+		if options.Monorepo && !isProw {
+			return fmt.Errorf("importing a monorepo requires the Prow promotion engine so that pipelines can be triggered per application directory")
+		}
 
 		if !isProw {
 			options.Jenkins, err = options.JenkinsClient()
@@ -330,6 +375,10 @@ func (options *ImportOptions) Run() error {
 	}
 	options.AppName = kube.ToValidName(strings.ToLower(options.AppName))
 
+	if options.Preview {
+		return options.PreviewImport()
+	}
+
 	checkForJenkinsfile := options.Jenkinsfile == "" && !options.DisableJenkinsfileCheck
 	shouldClone := checkForJenkinsfile || !options.DisableDraft
 
@@ -355,7 +404,12 @@ func (options *ImportOptions) Run() error {
 		}
 	}
 
-	if !options.DisableDraft {
+	if options.Monorepo {
+		err = options.ImportMonorepoApps()
+		if err != nil {
+			return err
+		}
+	} else if !options.DisableDraft {
 		err = options.DraftCreate()
 		if err != nil {
 			return err
@@ -372,6 +426,11 @@ func (options *ImportOptions) Run() error {
 		return err
 	}
 
+	err = options.scanForSecrets(&options.SecretScan, options.Dir, "the imported project "+options.Dir)
+	if err != nil {
+		return err
+	}
+
 	if options.RepoURL == "" {
 		if !options.DryRun {
 			err = options.CreateNewRemoteRepository()
@@ -422,6 +481,8 @@ func (options *ImportOptions) ImportProjectsFromGitHub() error {
 			GitProvider:             options.GitProvider,
 			DisableJenkinsfileCheck: options.DisableJenkinsfileCheck,
 			DisableDraft:            options.DisableDraft,
+			DryRun:                  options.DryRun,
+			Preview:                 options.Preview,
 		}
 		log.Infof("Importing repository %s\n", util.ColorInfo(r.Name))
 		err = o2.Run()
@@ -626,6 +687,16 @@ func (options *ImportOptions) DraftCreate() error {
 		return err
 	}
 
+	err = options.configureWindowsApp()
+	if err != nil {
+		return err
+	}
+
+	err = options.configureKnativeApp()
+	if err != nil {
+		return err
+	}
+
 	// Create prow owners file
 	err = options.CreateProwOwnersFile()
 	if err != nil {
@@ -645,6 +716,12 @@ func (options *ImportOptions) DraftCreate() error {
 
 func (options *ImportOptions) getDockerRegistryOrg() string {
 	dockerRegistryOrg := options.DockerRegistryOrg
+	if dockerRegistryOrg == "" {
+		settings, err := options.TeamSettings()
+		if err == nil {
+			dockerRegistryOrg = settings.DockerRegistryOrg
+		}
+	}
 	if dockerRegistryOrg == "" {
 		dockerRegistryOrg = options.getOrganisationOrCurrentUser()
 	}
@@ -836,7 +913,7 @@ func (options *ImportOptions) DiscoverGit() error {
 			Message: "Would you like to initialise git now?",
 			Default: true,
 		}
-		err := survey.AskOne(prompt, &flag, nil)
+		err := util.AskOne(prompt, &flag, nil)
 		if err != nil {
 			return err
 		}
@@ -877,7 +954,7 @@ func (options *ImportOptions) DiscoverGit() error {
 				Message: "Commit message: ",
 				Default: "Initial import",
 			}
-			err = survey.AskOne(messagePrompt, &message, nil)
+			err = util.AskOne(messagePrompt, &message, nil)
 			if err != nil {
 				return err
 			}
@@ -965,9 +1042,11 @@ func (options *ImportOptions) doImport() error {
 		jenkinsfile = jenkins.DefaultJenkinsfile
 	}
 
-	err = options.ensureDockerRepositoryExists()
-	if err != nil {
-		return err
+	if !options.Monorepo {
+		err = options.ensureDockerRepositoryExists()
+		if err != nil {
+			return err
+		}
 	}
 
 	isProw, err := options.isProw()
@@ -980,6 +1059,9 @@ func (options *ImportOptions) doImport() error {
 		if err != nil {
 			return err
 		}
+		if options.Monorepo {
+			return options.addMonorepoProwConfig(gitURL)
+		}
 		return options.addProwConfig(gitURL)
 	}
 
@@ -1032,6 +1114,9 @@ func (options *ImportOptions) ensureDockerRepositoryExists() error {
 	if cm.Data != nil {
 		dockerRegistry := cm.Data["docker.registry"]
 		if dockerRegistry != "" {
+			if options.Windows && kube.IsInClusterDockerRegistry(dockerRegistry) {
+				log.Warnf("Windows nodes cannot pull images from the in-cluster docker registry %s. Install with %s to use a registry the Windows nodes can reach\n", dockerRegistry, util.ColorInfo("--docker-registry"))
+			}
 			if strings.HasSuffix(dockerRegistry, ".amazonaws.com") && strings.Index(dockerRegistry, ".ecr.") > 0 {
 				return amazon.LazyCreateRegistry(orgName, appName)
 			}
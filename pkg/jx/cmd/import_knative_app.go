@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+const deployKindDefault = "default"
+
+// knativeDeploy returns true if the application should be deployed as a Knative Service either because of the
+// --deploy-kind flag or the deploy kind of the team
+func (options *ImportOptions) knativeDeploy() (bool, error) {
+	switch options.DeployKind {
+	case string(v1.DeployKindKnative):
+		return true, nil
+	case deployKindDefault:
+		return false, nil
+	case "":
+		settings, err := options.TeamSettings()
+		if err != nil {
+			log.Warnf("Failed to load the team settings so using the default deploy kind: %s\n", err)
+			return false, nil
+		}
+		return settings.DeployKind == v1.DeployKindKnative, nil
+	default:
+		return false, util.InvalidOption("deploy-kind", options.DeployKind, []string{deployKindDefault, string(v1.DeployKindKnative)})
+	}
+}
+
+// configureKnativeApp modifies the generated chart to deploy the application as a Knative Service instead of a
+// Deployment and Service, including in its preview environments
+func (options *ImportOptions) configureKnativeApp() error {
+	knative, err := options.knativeDeploy()
+	if err != nil || !knative {
+		return err
+	}
+	chartDir := filepath.Join(options.Dir, "charts", options.AppName)
+	exists, err := util.FileExists(chartDir)
+	if err != nil || !exists {
+		return err
+	}
+	log.Infof("Deploying the application as a %s\n", util.ColorInfo("Knative Service"))
+
+	scaling := options.KnativeScaling
+	knativeValues := yaml.MapSlice{{Key: "minScale", Value: scaling.MinScale}}
+	if scaling.MaxScale > 0 {
+		knativeValues = append(knativeValues, yaml.MapItem{Key: "maxScale", Value: scaling.MaxScale})
+	}
+	if scaling.ContainerConcurrency > 0 {
+		knativeValues = append(knativeValues, yaml.MapItem{Key: "containerConcurrency", Value: scaling.ContainerConcurrency})
+	}
+	valuesFile := filepath.Join(chartDir, "values.yaml")
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return err
+	}
+	values = helm.SetValue(values, kube.ValuesKnativeDeploy, true)
+	values = helm.SetValue(values, kube.ValuesKnative, knativeValues)
+	err = helm.SaveValuesFile(valuesFile, values)
+	if err != nil {
+		return err
+	}
+
+	templatesDir := filepath.Join(chartDir, "templates")
+	for _, name := range []string{"deployment.yaml", "service.yaml"} {
+		err = disableChartTemplateForKnative(filepath.Join(templatesDir, name))
+		if err != nil {
+			return err
+		}
+	}
+	err = ioutil.WriteFile(filepath.Join(templatesDir, kube.KnativeServiceTemplateFile), []byte(kube.KnativeServiceTemplate), util.DefaultWritePermissions)
+	if err != nil {
+		return fmt.Errorf("failed to write the Knative Service template: %s", err)
+	}
+	return options.configureKnativePreview()
+}
+
+// configureKnativePreview deploys the application as a Knative Service in its preview environments
+func (options *ImportOptions) configureKnativePreview() error {
+	valuesFile := filepath.Join(options.Dir, "charts", "preview", "values.yaml")
+	exists, err := util.FileExists(valuesFile)
+	if err != nil || !exists {
+		return err
+	}
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return err
+	}
+	previewValues, _ := helm.GetValue(values, "preview").(yaml.MapSlice)
+	previewValues = helm.SetValue(previewValues, kube.ValuesKnativeDeploy, true)
+	values = helm.SetValue(values, "preview", previewValues)
+	return helm.SaveValuesFile(valuesFile, values)
+}
+
+func disableChartTemplateForKnative(fileName string) error {
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", fileName, err)
+	}
+	text := kube.DisableTemplateForKnative(string(data))
+	return ioutil.WriteFile(fileName, []byte(text), util.DefaultWritePermissions)
+}
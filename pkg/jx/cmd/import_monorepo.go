@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+var (
+	// monorepoAppMarkerFiles the files which indicate a directory of a monorepo contains an application
+	monorepoAppMarkerFiles = []string{
+		"pom.xml", "build.gradle", "package.json", "Gopkg.toml", "go.mod", "main.go", "requirements.txt",
+		"setup.py", "Gemfile", "Cargo.toml", "build.sbt", "Package.swift", "Dockerfile", "Jenkinsfile",
+	}
+
+	// monorepoIgnoredDirs the directories of a monorepo which are never applications
+	monorepoIgnoredDirs = []string{"charts", "docs", "vendor", "node_modules", "target", "build"}
+)
+
+// ImportMonorepoApps generates the Jenkinsfile, Dockerfile and chart for each application directory of a monorepo
+func (options *ImportOptions) ImportMonorepoApps() error {
+	dirs, err := options.monorepoAppDirs()
+	if err != nil {
+		return err
+	}
+	if len(dirs) == 0 {
+		return fmt.Errorf("no application directories found in the monorepo %s. Please specify them via --app-dir", options.Dir)
+	}
+	options.MonorepoApps = nil
+	appDirs := map[string]string{}
+	for _, dir := range dirs {
+		appName := monorepoAppName(dir)
+		if appName == "" {
+			return fmt.Errorf("cannot derive an application name from the monorepo directory %s", dir)
+		}
+		if existing, ok := appDirs[appName]; ok {
+			return fmt.Errorf("the monorepo directories %s and %s would both be imported as application %s", existing, dir, appName)
+		}
+		appDirs[appName] = dir
+		log.Infof("Importing monorepo application %s from directory %s\n", util.ColorInfo(appName), util.ColorInfo(dir))
+
+		appOptions := *options
+		appOptions.Dir = filepath.Join(options.Dir, dir)
+		appOptions.AppName = appName
+		appOptions.DraftPack = ""
+		appOptions.PostDraftPackCallback = nil
+		if !options.DisableDraft {
+			err = appOptions.DraftCreate()
+			if err != nil {
+				return fmt.Errorf("failed to create the build pack files for monorepo application %s: %s", appName, err)
+			}
+		}
+		err = appOptions.fixDockerIgnoreFile()
+		if err != nil {
+			return err
+		}
+		err = appOptions.fixMaven()
+		if err != nil {
+			return err
+		}
+		options.MonorepoApps = append(options.MonorepoApps, prow.MonorepoApp{
+			Name:      appName,
+			Dir:       filepath.ToSlash(dir),
+			DraftPack: appOptions.DraftPack,
+		})
+	}
+	return nil
+}
+
+// monorepoAppName returns the application name of a monorepo directory which is derived from its whole relative
+// path, e.g. services/api becomes services-api, so that directories of the same name do not clash
+func monorepoAppName(dir string) string {
+	return kube.ToValidName(strings.Trim(filepath.ToSlash(filepath.Clean(dir)), "/"))
+}
+
+// monorepoAppDirs returns the application directories relative to the root of the monorepo. If none are
+// specified then the top level directories which contain a recognised build file are used
+func (options *ImportOptions) monorepoAppDirs() ([]string, error) {
+	if len(options.MonorepoAppDirs) > 0 {
+		for _, dir := range options.MonorepoAppDirs {
+			exists, err := util.FileExists(filepath.Join(options.Dir, dir))
+			if err != nil {
+				return nil, err
+			}
+			if !exists {
+				return nil, fmt.Errorf("monorepo application directory %s does not exist in %s", dir, options.Dir)
+			}
+		}
+		return options.MonorepoAppDirs, nil
+	}
+	files, err := ioutil.ReadDir(options.Dir)
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	for _, f := range files {
+		name := f.Name()
+		if !f.IsDir() || strings.HasPrefix(name, ".") || util.StringArrayIndex(monorepoIgnoredDirs, name) >= 0 {
+			continue
+		}
+		for _, marker := range monorepoAppMarkerFiles {
+			exists, err := util.FileExists(filepath.Join(options.Dir, name, marker))
+			if err != nil {
+				return nil, err
+			}
+			if exists {
+				answer = append(answer, name)
+				break
+			}
+		}
+	}
+	return answer, nil
+}
+
+// addMonorepoProwConfig adds the path filtered Prow jobs for each application in the monorepo
+func (options *ImportOptions) addMonorepoProwConfig(gitURL string) error {
+	gitInfo, err := gits.ParseGitURL(gitURL)
+	if err != nil {
+		return err
+	}
+	for _, app := range options.MonorepoApps {
+		appOptions := *options
+		appOptions.AppName = app.Name
+		err = appOptions.ensureDockerRepositoryExists()
+		if err != nil {
+			return err
+		}
+	}
+	repo := gitInfo.Organisation + "/" + gitInfo.Name
+	err = prow.AddMonorepoApplications(options.KubeClientCached, repo, options.currentNamespace, options.MonorepoApps)
+	if err != nil {
+		return err
+	}
+	options.logImportedProject(false, gitInfo)
+	return nil
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMonorepoAppName(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "frontend", monorepoAppName("frontend"))
+	assert.Equal(t, "services-api", monorepoAppName("services/api"))
+	assert.Equal(t, "services-api", monorepoAppName("./services/api/"))
+	assert.Equal(t, "tools-api", monorepoAppName("tools/API"))
+}
+
+func TestImportMonorepoApps(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-import-monorepo-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeImportTestFiles(t, dir, map[string]string{
+		"services/api/main.go": "package main",
+		"tools/api/main.go":    "package main",
+		"services-api/go.mod":  "module services-api",
+	})
+
+	options := &ImportOptions{
+		Dir:             dir,
+		DisableDraft:    true,
+		DisableMaven:    true,
+		MonorepoAppDirs: []string{"services/api", "tools/api"},
+	}
+	err = options.ImportMonorepoApps()
+	require.NoError(t, err)
+	require.Len(t, options.MonorepoApps, 2)
+	assert.Equal(t, "services-api", options.MonorepoApps[0].Name)
+	assert.Equal(t, "services/api", options.MonorepoApps[0].Dir)
+	assert.Equal(t, "tools-api", options.MonorepoApps[1].Name)
+
+	options.MonorepoAppDirs = []string{"services/api", "services-api"}
+	err = options.ImportMonorepoApps()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "services/api and services-api")
+}
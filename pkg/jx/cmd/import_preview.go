@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pmezard/go-difflib/difflib"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// importFileChange describes a file which would be added, modified or removed by an import
+type importFileChange struct {
+	Path   string
+	Change string
+	Diff   string
+}
+
+// PreviewImport performs the local changes of the import on a temporary copy of the project then reports
+// the detected build pack, the generated files and the remote changes which would be made by the import
+// without modifying the project
+func (options *ImportOptions) PreviewImport() error {
+	tmpDir, err := ioutil.TempDir("", "jx-import-preview-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	sourceDir := options.Dir
+	if options.RepoURL != "" {
+		options.Dir = tmpDir
+		err = options.CloneRepository()
+		if err != nil {
+			return err
+		}
+		sourceDir = options.Dir
+	} else {
+		root, gitConf, err := options.Git().FindGitConfigDir(options.Dir)
+		if err != nil {
+			return err
+		}
+		if root != "" {
+			sourceDir = root
+			options.GitConfDir = gitConf
+			err = options.DiscoverRemoteGitURL()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	previewDir := filepath.Join(tmpDir, "preview")
+	err = util.CopyDir(sourceDir, previewDir, true)
+	if err != nil {
+		return fmt.Errorf("failed to copy %s to %s: %s", sourceDir, previewDir, err)
+	}
+	options.Dir = previewDir
+	options.GitConfDir = filepath.Join(previewDir, ".git", "config")
+
+	exists, err := util.FileExists(filepath.Join(previewDir, ".git"))
+	if err != nil {
+		return err
+	}
+	if !exists {
+		log.Infof("The directory %s is not yet using git so it would be initialised\n", util.ColorInfo(sourceDir))
+		options.InitialisedGit = true
+		err = options.Git().Init(previewDir)
+		if err != nil {
+			return err
+		}
+		err = options.DefaultGitIgnore()
+		if err != nil {
+			return err
+		}
+		err = options.Git().Add(previewDir, "*")
+		if err != nil {
+			return err
+		}
+		err = options.Git().CommitIfChanges(previewDir, "Initial import")
+		if err != nil {
+			return err
+		}
+	}
+
+	if options.Monorepo {
+		err = options.ImportMonorepoApps()
+		if err != nil {
+			return err
+		}
+	} else if !options.DisableDraft {
+		err = options.DraftCreate()
+		if err != nil {
+			return err
+		}
+	}
+	err = options.fixDockerIgnoreFile()
+	if err != nil {
+		return err
+	}
+	err = options.fixMaven()
+	if err != nil {
+		return err
+	}
+
+	changes, err := diffImportDirs(sourceDir, previewDir)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("\nDry run of importing %s\n\n", util.ColorInfo(sourceDir))
+	if options.DraftPack != "" {
+		log.Infof("Detected build pack: %s\n", util.ColorInfo(options.DraftPack))
+	}
+	for _, app := range options.MonorepoApps {
+		log.Infof("Detected build pack for monorepo application %s in %s: %s\n", util.ColorInfo(app.Name), app.Dir, util.ColorInfo(app.DraftPack))
+	}
+	if len(changes) == 0 {
+		log.Infof("No files would be generated or modified\n")
+	} else {
+		log.Infof("The following files would be generated or modified:\n")
+		for _, c := range changes {
+			log.Infof("  %s %s\n", util.ColorInfo(c.Change), c.Path)
+		}
+		for _, c := range changes {
+			if c.Diff != "" {
+				log.Infof("\n%s", c.Diff)
+			}
+		}
+	}
+	log.Infoln("")
+	options.logPreviewRemoteChanges()
+	return nil
+}
+
+// logPreviewRemoteChanges logs the git repository, webhook and docker registry changes which would be made
+func (options *ImportOptions) logPreviewRemoteChanges() {
+	org := options.getOrganisationOrCurrentUser()
+	appName := options.AppName
+	gitURL := options.RepoURL
+	if gitURL == "" {
+		serverURL := ""
+		if options.GitProvider != nil {
+			serverURL = options.GitProvider.ServerURL()
+		}
+		log.Infof("Would create git repository %s on %s\n", util.ColorInfo(org+"/"+appName), serverURL)
+	} else {
+		log.Infof("Would push the generated files to %s\n", util.ColorInfo(gitURL))
+	}
+
+	kubeClient, curNs, err := options.KubeClient()
+	if err != nil {
+		log.Infof("Would register a webhook for the git repository and create the docker image %s\n", util.ColorInfo(options.getDockerRegistryOrg()+"/"+appName))
+		return
+	}
+	_, _, err = options.JXClient()
+	if err == nil {
+		isProw, err := options.isProw()
+		if err == nil {
+			if isProw {
+				log.Infof("Would register a webhook for the Prow hook endpoint and add the repository to the Prow configuration\n")
+			} else {
+				log.Infof("Would register a webhook for Jenkins and create a multi branch project\n")
+			}
+		}
+	}
+
+	dockerRegistry := ""
+	ns, _, err := kube.GetDevNamespace(kubeClient, curNs)
+	if err == nil {
+		cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsDockerRegistry, metav1.GetOptions{})
+		if err == nil && cm.Data != nil {
+			dockerRegistry = cm.Data["docker.registry"]
+		}
+	}
+	image := options.getDockerRegistryOrg() + "/" + appName
+	if dockerRegistry != "" {
+		image = dockerRegistry + "/" + image
+	}
+	log.Infof("Would push docker images to %s\n", util.ColorInfo(image))
+	if strings.HasSuffix(dockerRegistry, ".amazonaws.com") && strings.Index(dockerRegistry, ".ecr.") > 0 {
+		log.Infof("Would create the ECR repository %s\n", util.ColorInfo(org+"/"+appName))
+	}
+}
+
+// diffImportDirs returns the changes between the source directory and the directory the import was previewed in
+func diffImportDirs(sourceDir string, previewDir string) ([]importFileChange, error) {
+	sourceFiles, err := importFiles(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+	previewFiles, err := importFiles(previewDir)
+	if err != nil {
+		return nil, err
+	}
+	answer := []importFileChange{}
+	for _, name := range util.SortedMapKeys(previewFiles) {
+		_, existing := sourceFiles[name]
+		before := ""
+		if existing {
+			data, err := ioutil.ReadFile(filepath.Join(sourceDir, name))
+			if err != nil {
+				return nil, err
+			}
+			before = string(data)
+		}
+		data, err := ioutil.ReadFile(filepath.Join(previewDir, name))
+		if err != nil {
+			return nil, err
+		}
+		after := string(data)
+		if existing && before == after {
+			continue
+		}
+		change := "modified"
+		if !existing {
+			change = "added"
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(before),
+			B:        difflib.SplitLines(after),
+			FromFile: "a/" + name,
+			ToFile:   "b/" + name,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, err
+		}
+		answer = append(answer, importFileChange{Path: name, Change: change, Diff: diff})
+	}
+	removed := []string{}
+	for name := range sourceFiles {
+		if _, ok := previewFiles[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		answer = append(answer, importFileChange{Path: name, Change: "removed"})
+	}
+	return answer, nil
+}
+
+// importFiles returns the relative paths of all the files in the directory excluding the .git folder
+func importFiles(dir string) (map[string]string, error) {
+	answer := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		answer[filepath.ToSlash(rel)] = path
+		return nil
+	})
+	return answer, err
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeImportTestFiles(t *testing.T, dir string, files map[string]string) {
+	for name, content := range files {
+		fileName := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(fileName), 0755))
+		require.NoError(t, ioutil.WriteFile(fileName, []byte(content), 0644))
+	}
+}
+
+func TestImportFiles(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-import-files-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	writeImportTestFiles(t, dir, map[string]string{
+		"pom.xml":                "<project/>",
+		"src/main/App.java":      "class App {}",
+		".git/config":            "[core]",
+		".git/refs/heads/master": "abc",
+	})
+
+	files, err := importFiles(dir)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"pom.xml":           filepath.Join(dir, "pom.xml"),
+		"src/main/App.java": filepath.Join(dir, "src", "main", "App.java"),
+	}, files)
+}
+
+func TestDiffImportDirs(t *testing.T) {
+	t.Parallel()
+	sourceDir, err := ioutil.TempDir("", "test-import-source-")
+	require.NoError(t, err)
+	defer os.RemoveAll(sourceDir)
+	previewDir, err := ioutil.TempDir("", "test-import-preview-")
+	require.NoError(t, err)
+	defer os.RemoveAll(previewDir)
+
+	writeImportTestFiles(t, sourceDir, map[string]string{
+		"pom.xml":    "<project/>\n",
+		"README.md":  "hello\n",
+		"Dockerfile": "FROM scratch\n",
+		".git/HEAD":  "ref: refs/heads/master\n",
+	})
+	writeImportTestFiles(t, previewDir, map[string]string{
+		"pom.xml":               "<project/>\n",
+		"README.md":             "hello\nworld\n",
+		"Jenkinsfile":           "pipeline {}\n",
+		"charts/app/Chart.yaml": "name: app\n",
+		".git/HEAD":             "ref: refs/heads/other\n",
+	})
+
+	changes, err := diffImportDirs(sourceDir, previewDir)
+	require.NoError(t, err)
+	actual := map[string]string{}
+	paths := []string{}
+	for _, c := range changes {
+		actual[c.Path] = c.Change
+		paths = append(paths, c.Path)
+	}
+	assert.Equal(t, map[string]string{
+		"Jenkinsfile":           "added",
+		"README.md":             "modified",
+		"charts/app/Chart.yaml": "added",
+		"Dockerfile":            "removed",
+	}, actual)
+	assert.Equal(t, []string{"Jenkinsfile", "README.md", "charts/app/Chart.yaml", "Dockerfile"}, paths, "added and modified files are sorted before removed files")
+
+	for _, c := range changes {
+		switch c.Path {
+		case "README.md":
+			assert.Contains(t, c.Diff, "--- a/README.md")
+			assert.Contains(t, c.Diff, "+world")
+		case "Dockerfile":
+			assert.Empty(t, c.Diff)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/jenkins"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+var (
+	jenkinsfileAgentLabelRegex     = regexp.MustCompile(`label\s+"jenkins-[^"]*"`)
+	jenkinsfileContainerRegex      = regexp.MustCompile(`container\s*\(\s*'[^']*'\s*\)`)
+	deploymentTemplateContainersRe = regexp.MustCompile(`(?m)^([ \t]*)containers:[ \t]*$`)
+)
+
+// detectWindows enables Windows container support if the Dockerfile of the application uses a Windows base image
+func (options *ImportOptions) detectWindows() error {
+	if options.Windows {
+		return nil
+	}
+	fileName := filepath.Join(options.Dir, "Dockerfile")
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", fileName, err)
+	}
+	if kube.IsWindowsDockerfile(string(data)) {
+		log.Infof("The Dockerfile uses a %s base image so building and deploying the application on Windows nodes\n", util.ColorInfo("Windows"))
+		options.Windows = true
+	}
+	return nil
+}
+
+// configureWindowsApp configures the generated pipeline to build on the Windows build pod and the generated chart
+// to deploy onto Windows nodes
+func (options *ImportOptions) configureWindowsApp() error {
+	err := options.detectWindows()
+	if err != nil {
+		return err
+	}
+	if !options.Windows {
+		if options.RuntimeClass != "" {
+			return options.modifyChartForWindows()
+		}
+		return nil
+	}
+	jenkinsfile := options.Jenkinsfile
+	if jenkinsfile == "" {
+		jenkinsfile = jenkins.DefaultJenkinsfile
+	}
+	err = useWindowsBuildPod(filepath.Join(options.Dir, jenkinsfile))
+	if err != nil {
+		return err
+	}
+	return options.modifyChartForWindows()
+}
+
+// modifyChartForWindows adds the Windows node selector, toleration and runtime class to the values of the chart and
+// makes sure the deployment template uses them
+func (options *ImportOptions) modifyChartForWindows() error {
+	chartDir := filepath.Join(options.Dir, "charts", options.AppName)
+	exists, err := util.FileExists(chartDir)
+	if err != nil || !exists {
+		return err
+	}
+	valuesFile := filepath.Join(chartDir, "values.yaml")
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return err
+	}
+	if options.Windows {
+		values = helm.SetValue(values, "nodeSelector", yaml.MapSlice{{Key: kube.LabelOS, Value: kube.OSWindows}})
+		t := kube.WindowsToleration
+		values = helm.SetValue(values, "tolerations", []yaml.MapSlice{{
+			{Key: "key", Value: t.Key},
+			{Key: "operator", Value: string(t.Operator)},
+			{Key: "value", Value: t.Value},
+			{Key: "effect", Value: string(t.Effect)},
+		}})
+	}
+	if options.RuntimeClass != "" {
+		values = helm.SetValue(values, "runtimeClassName", options.RuntimeClass)
+	}
+	err = helm.SaveValuesFile(valuesFile, values)
+	if err != nil {
+		return err
+	}
+
+	deploymentFile := filepath.Join(chartDir, "templates", "deployment.yaml")
+	exists, err = util.FileExists(deploymentFile)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(deploymentFile)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", deploymentFile, err)
+	}
+	text, err := addPodSchedulingToDeploymentTemplate(string(data))
+	if err != nil {
+		return fmt.Errorf("failed to modify %s: %s", deploymentFile, err)
+	}
+	return ioutil.WriteFile(deploymentFile, []byte(text), util.DefaultWritePermissions)
+}
+
+// useWindowsBuildPod changes the agent label and containers of the Jenkinsfile to the Windows build pod template
+func useWindowsBuildPod(fileName string) error {
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return fmt.Errorf("failed to load %s: %s", fileName, err)
+	}
+	text := jenkinsfileAgentLabelRegex.ReplaceAllString(string(data), fmt.Sprintf(`label "jenkins-%s"`, kube.WindowsPodTemplateName))
+	text = jenkinsfileContainerRegex.ReplaceAllString(text, fmt.Sprintf("container('%s')", kube.WindowsPodTemplateName))
+	return ioutil.WriteFile(fileName, []byte(text), util.DefaultWritePermissions)
+}
+
+// addPodSchedulingToDeploymentTemplate adds the nodeSelector, tolerations and runtimeClassName values to the pod spec
+// of the deployment template if it does not already use them
+func addPodSchedulingToDeploymentTemplate(text string) (string, error) {
+	loc := deploymentTemplateContainersRe.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return text, fmt.Errorf("no containers found in the deployment template")
+	}
+	indent := text[loc[2]:loc[3]]
+	blocks := []struct {
+		value    string
+		template string
+	}{
+		{".Values.nodeSelector", "{{- with .Values.nodeSelector }}\n%[1]snodeSelector:\n{{ toYaml . | indent %[2]d }}\n{{- end }}\n"},
+		{".Values.tolerations", "{{- with .Values.tolerations }}\n%[1]stolerations:\n{{ toYaml . | indent %[2]d }}\n{{- end }}\n"},
+		{".Values.runtimeClassName", "{{- if .Values.runtimeClassName }}\n%[1]sruntimeClassName: {{ .Values.runtimeClassName }}\n{{- end }}\n"},
+	}
+	insert := ""
+	for _, b := range blocks {
+		if !strings.Contains(text, b.value) {
+			insert += fmt.Sprintf(b.template, indent, len(indent)+2)
+		}
+	}
+	return text[0:loc[0]] + insert + text[loc[0]:], nil
+}
@@ -8,10 +8,12 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/cloud/oke"
 	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -53,10 +55,12 @@ type InitFlags struct {
 	RecreateExistingDraftRepos bool
 	Tiller                     bool
 	GlobalTiller               bool
+	TillerTLS                  bool
 	SkipIngress                bool
 	SkipTiller                 bool
 	OnPremise                  bool
 	Http                       bool
+	IngressNode                string
 }
 
 const (
@@ -127,10 +131,12 @@ func (options *InitOptions) addInitFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&options.Flags.HelmClient, "helm-client-only", "", false, "Only install helm client")
 	cmd.Flags().BoolVarP(&options.Flags.RecreateExistingDraftRepos, "recreate-existing-draft-repos", "", false, "Delete existing helm repos used by Jenkins X under ~/draft/packs")
 	cmd.Flags().BoolVarP(&options.Flags.GlobalTiller, "global-tiller", "", true, "Whether or not to use a cluster global tiller")
+	cmd.Flags().BoolVarP(&options.Flags.TillerTLS, "tiller-tls", "", false, "Secures tiller with mutual TLS using generated certificates so that only clients with the certificate stored in the tiller namespace can use it")
 	cmd.Flags().BoolVarP(&options.Flags.Tiller, "tiller", "", true, "Whether or not to use tiller at all. If no tiller is enabled then its ran as a local process instead")
 	cmd.Flags().BoolVarP(&options.Flags.SkipIngress, "skip-ingress", "", false, "Dont install an ingress controller")
 	cmd.Flags().BoolVarP(&options.Flags.SkipTiller, "skip-tiller", "", false, "Don't install a Helms Tiller service")
 	cmd.Flags().BoolVarP(&options.Flags.Helm3, "helm3", "", false, "Use helm3 to install Jenkins X which does not use Tiller")
+	cmd.Flags().StringVarP(&options.Flags.IngressNode, "ingress-node", "", "", "Runs the ingress controller on the host network of the node for clusters without load balancers. Use the address of the node as the --external-ip")
 	cmd.Flags().BoolVarP(&options.Flags.OnPremise, "on-premise", "", false, "If installing on an on premise cluster then lets default the 'external-ip' to be the kubernetes master IP address")
 }
 
@@ -256,7 +262,7 @@ func (o *InitOptions) initHelm() error {
 			return err
 		}
 
-		serviceAccountName := "tiller"
+		serviceAccountName := kube.TillerServiceAccount
 		tillerNamespace := o.Flags.TillerNamespace
 
 		if o.Flags.GlobalTiller {
@@ -288,64 +294,25 @@ func (o *InitOptions) initHelm() error {
 				return err
 			}
 		} else {
-			// lets create a tiller service account
-			roleName := "tiller-manager"
-			roleBindingName := "tiller-binding"
-
-			_, err = client.RbacV1().Roles(tillerNamespace).Get(roleName, metav1.GetOptions{})
-			if err != nil {
-				// lets create a Role for tiller
-				role := &rbacv1.Role{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      roleName,
-						Namespace: tillerNamespace,
-					},
-					Rules: []rbacv1.PolicyRule{
-						{
-							APIGroups: []string{"", "extensions", "apps"},
-							Resources: []string{"*"},
-							Verbs:     []string{"*"},
-						},
-					},
-				}
-				_, err = client.RbacV1().Roles(tillerNamespace).Create(role)
-				if err != nil {
-					return fmt.Errorf("Failed to create Role %s in namespace %s: %s", roleName, tillerNamespace, err)
-				}
-				log.Infof("Created Role %s in namespace %s\n", util.ColorInfo(roleName), util.ColorInfo(tillerNamespace))
-			}
-			_, err = client.RbacV1().RoleBindings(tillerNamespace).Get(roleBindingName, metav1.GetOptions{})
+			// lets only allow tiller to manage the namespace it runs in
+			err = kube.EnsureTillerRole(client, tillerNamespace, tillerNamespace)
 			if err != nil {
-				// lets create a RoleBinding for tiller
-				roleBinding := &rbacv1.RoleBinding{
-					ObjectMeta: metav1.ObjectMeta{
-						Name:      roleBindingName,
-						Namespace: tillerNamespace,
-					},
-					Subjects: []rbacv1.Subject{
-						{
-							Kind:      "ServiceAccount",
-							Name:      serviceAccountName,
-							Namespace: tillerNamespace,
-						},
-					},
-					RoleRef: rbacv1.RoleRef{
-						Kind:     "Role",
-						Name:     roleName,
-						APIGroup: "rbac.authorization.k8s.io",
-					},
-				}
-				_, err = client.RbacV1().RoleBindings(tillerNamespace).Create(roleBinding)
-				if err != nil {
-					return fmt.Errorf("Failed to create RoleBinding %s in namespace %s: %s", roleName, tillerNamespace, err)
-				}
-				log.Infof("Created RoleBinding %s in namespace %s\n", util.ColorInfo(roleName), util.ColorInfo(tillerNamespace))
+				return err
 			}
+			log.Infof("Tiller can only manage the resources of namespace %s\n", util.ColorInfo(tillerNamespace))
 		}
 
+		o.Helm().SetTillerNamespace(tillerNamespace)
 		running, err := kube.IsDeploymentRunning(client, "tiller-deploy", tillerNamespace)
 		if running {
 			log.Infof("Tiller Deployment is running in namespace %s\n", util.ColorInfo(tillerNamespace))
+			if o.Flags.TillerTLS {
+				files, err := o.loadTillerTLS(tillerNamespace)
+				if err != nil {
+					return errors.Wrap(err, "failed to load the tiller TLS certificates")
+				}
+				o.Helm().SetTLS(files)
+			}
 			return nil
 		}
 		if err == nil && !running {
@@ -355,6 +322,14 @@ func (o *InitOptions) initHelm() error {
 		if !running {
 			log.Infof("Initialising helm using ServiceAccount %s in namespace %s\n", util.ColorInfo(serviceAccountName), util.ColorInfo(tillerNamespace))
 
+			if o.Flags.TillerTLS {
+				files, err := o.createTillerTLS(tillerNamespace)
+				if err != nil {
+					return errors.Wrap(err, "failed to create the tiller TLS certificates")
+				}
+				o.Helm().SetTLS(files)
+			}
+
 			err = o.Helm().Init(false, serviceAccountName, tillerNamespace, false)
 			if err != nil {
 				return err
@@ -456,7 +431,7 @@ func (o *InitOptions) initIngress() error {
 		}
 	*/
 
-	currentContext, err := o.getCommandOutput("", "kubectl", "config", "current-context")
+	currentContext, err := kube.CurrentContextName()
 	if err != nil {
 		return err
 	}
@@ -496,7 +471,7 @@ func (o *InitOptions) initIngress() error {
 				Default: true,
 				Help:    "An ingress controller works with an external loadbalancer so you can access Jenkins X and your applications",
 			}
-			survey.AskOne(prompt, &installIngressController, nil)
+			util.AskOne(prompt, &installIngressController, nil)
 		}
 
 		if !installIngressController {
@@ -504,11 +479,17 @@ func (o *InitOptions) initIngress() error {
 		}
 
 		values := []string{"rbac.create=true" /*,"rbac.serviceAccountName="+ingressServiceAccount*/}
+		if o.Flags.IngressNode != "" {
+			// without load balancers the ingress controller listens on the ports of the node
+			values = append(values, "controller.hostNetwork=true", "controller.dnsPolicy=ClusterFirstWithHostNet",
+				"controller.service.type=NodePort", "controller.nodeSelector.kubernetes\\.io/hostname="+o.Flags.IngressNode)
+		}
 		valuesFiles := []string{}
 		valuesFiles, err = helm.AppendMyValues(valuesFiles)
 		if err != nil {
 			return errors.Wrap(err, "failed to append the myvalues file")
 		}
+		yamlText := ""
 		if o.Flags.Provider == AWS || o.Flags.Provider == EKS {
 			// we can only enable one port for NLBs right now
 			enableHttp := "false"
@@ -517,7 +498,7 @@ func (o *InitOptions) initIngress() error {
 				enableHttp = "true"
 				enableHttps = "false"
 			}
-			yamlText := `---
+			yamlText = `---
 rbac:
  create: true
 
@@ -528,7 +509,27 @@ controller:
    enableHttp: ` + enableHttp + `
    enableHttps: ` + enableHttps + `
 `
+		}
+		if o.Flags.Provider == OKE {
+			// use a flexible load balancer so that the external IP of the ingress controller can be discovered
+			yamlText = `---
+rbac:
+ create: true
 
+controller:
+ service:
+   annotations:
+`
+			keys := []string{}
+			for k := range oke.LoadBalancerAnnotations {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				yamlText += fmt.Sprintf("     %s: %q\n", k, oke.LoadBalancerAnnotations[k])
+			}
+		}
+		if yamlText != "" {
 			f, err := ioutil.TempFile("", "ing-values-")
 			if err != nil {
 				return err
@@ -731,7 +732,7 @@ func (o *CommonOptions) GetDomain(client kubernetes.Interface, domain string, pr
 					Message: "Your custom DNS name: ",
 					Help:    "Enter your custom domain that we can use to setup a Route 53 ALIAS record to point at the ELB host: " + address,
 				}
-				survey.AskOne(prompt, &customDomain, nil)
+				util.AskOne(prompt, &customDomain, nil)
 				if customDomain != "" {
 					err := amazon.RegisterAwsCustomDomain(customDomain, address)
 					return customDomain, err
@@ -798,7 +799,7 @@ func (o *CommonOptions) GetDomain(client kubernetes.Interface, domain string, pr
 				Default: defaultDomain,
 				Help:    "Enter your custom domain that is used to generate Ingress rules, defaults to the magic dns nip.io",
 			}
-			survey.AskOne(prompt, &domain, survey.Required)
+			util.AskOne(prompt, &domain, survey.Required)
 		}
 		if domain == "" {
 			domain = defaultDomain
@@ -66,6 +66,18 @@ type InstallFlags struct {
 	EnvironmentGitOwner      string
 	Version                  string
 	Prow                     bool
+	Lighthouse               bool
+	StorageClass             string
+	JenkinsStorageClass      string
+	NexusStorageClass        string
+	ChartMuseumStorageClass  string
+	SpotBuilds               bool
+	Requirements             string
+	Resume                   bool
+	ComponentTimeouts        []string
+	NoWait                   bool
+	Atomic                   bool
+	Existing                 bool
 }
 
 // Secrets struct for secrets
@@ -86,6 +98,8 @@ const (
 	CloudEnvValuesFile    = "myvalues.yaml"
 	CloudEnvSecretsFile   = "secrets.yaml"
 	defaultInstallTimeout = "6000"
+
+	optionComponentTimeout = "component-timeout"
 )
 
 var (
@@ -114,6 +128,18 @@ var (
 
 		# If you know the cloud provider you can pass this as a CLI argument. E.g. for AWS
 		jx install --provider=aws
+
+		# Install the platform declared in a requirements file
+		jx install --requirements jx-requirements.yml
+
+		# Resume an install which was interrupted skipping the steps which are still in place
+		jx install --resume
+
+		# Give the platform chart an hour to install and roll back any chart which fails
+		jx install --component-timeout jenkins-x=3600 --atomic
+
+		# Install into an existing cluster reusing its ingress controller and adapting to its capabilities
+		jx install --existing
 `)
 )
 
@@ -212,6 +238,18 @@ func (options *InstallOptions) addInstallFlags(cmd *cobra.Command, includesInit
 	cmd.Flags().StringVarP(&flags.ExposeControllerPathMode, "exposecontroller-pathmode", "", "", "The ExposeController path mode for how services should be exposed as URLs. Defaults to using subnets. Use a value of `path` to use relative paths within the domain host such as when using AWS ELB host names")
 	cmd.Flags().StringVarP(&flags.Version, "version", "", "", "The specific platform version to install")
 	cmd.Flags().BoolVarP(&flags.Prow, "prow", "", false, "Enable prow")
+	cmd.Flags().BoolVarP(&flags.Lighthouse, "lighthouse", "", false, "Enable Lighthouse, a lighter alternative to prow, for handling webhooks and ChatOps")
+	cmd.Flags().StringArrayVarP(&flags.ComponentTimeouts, optionComponentTimeout, "", nil, "The number of seconds to wait for a component to install of the form NAME=SECONDS where NAME is the release or chart name such as jenkins-x or prow. Defaults to --timeout")
+	cmd.Flags().BoolVarP(&flags.NoWait, "no-wait", "", false, "Does not wait for the resources of the charts to be ready")
+	cmd.Flags().BoolVarP(&flags.Atomic, "atomic", "", false, "Rolls back the release of a chart which fails to install")
+	cmd.Flags().StringVarP(&flags.StorageClass, "storage-class", "", "", "The storage class of the persistent volumes of the platform components. Defaults to the default storage class of the cluster")
+	cmd.Flags().StringVarP(&flags.JenkinsStorageClass, "jenkins-storage-class", "", "", "The storage class of the Jenkins home persistent volume. Defaults to --storage-class")
+	cmd.Flags().StringVarP(&flags.NexusStorageClass, "nexus-storage-class", "", "", "The storage class of the Nexus persistent volume. Defaults to --storage-class")
+	cmd.Flags().StringVarP(&flags.ChartMuseumStorageClass, "chartmuseum-storage-class", "", "", "The storage class of the ChartMuseum persistent volume. Defaults to --storage-class")
+	cmd.Flags().BoolVarP(&flags.SpotBuilds, "spot-builds", "", false, "Runs build pods on the spot or preemptible nodes of the cluster. See 'jx edit spot-builds'")
+	cmd.Flags().BoolVarP(&flags.Existing, "existing", "", false, "Installs into an existing cluster of any provider by detecting its ingress controller, storage classes, RBAC and load balancers and adapting the install to them")
+	cmd.Flags().BoolVarP(&flags.Resume, "resume", "", false, "Resumes an interrupted install into the same context and namespace by skipping the steps which were completed and are still in place")
+	cmd.Flags().StringVarP(&flags.Requirements, "requirements", "", "", "The "+config.RequirementsConfigFileName+" file declaring the provider, domain, TLS, storage, secret storage and webhook engine of the platform. Its values override the equivalent flags")
 
 	addGitRepoOptionsArguments(cmd, &options.GitRepositoryOptions)
 	options.HelmValuesConfig.AddExposeControllerValues(cmd, true)
@@ -226,6 +264,31 @@ func (flags *InstallFlags) addCloudEnvOptions(cmd *cobra.Command) {
 
 // Run implements this command
 func (options *InstallOptions) Run() error {
+	err := options.applyRequirements()
+	if err != nil {
+		return err
+	}
+	// the team controller installs teams with helm 3 so only users asking for helm 3 need the experiment enabled
+	if options.InitOptions.Flags.Helm3 && options.Cmd != nil && options.Cmd.Flags().Changed("helm3") {
+		err = options.requireExperiment(experimentHelm3)
+		if err != nil {
+			return err
+		}
+	}
+	if options.Flags.Lighthouse {
+		err = options.requireExperiment(experimentLighthouse)
+		if err != nil {
+			return err
+		}
+		// Lighthouse is installed via the same flow as prow
+		options.Flags.Prow = true
+		options.CommonOptions.Lighthouse = true
+	}
+	err = options.applyChartInstallFlags()
+	if err != nil {
+		return err
+	}
+
 	client, originalNs, err := options.KubeClient()
 	if err != nil {
 		return errors.Wrap(err, "failed to create the kube client")
@@ -249,7 +312,7 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "failed to install the platform requirements")
 	}
 
-	context, err := options.getCommandOutput("", "kubectl", "config", "current-context")
+	context, err := kube.CurrentContextName()
 	if err != nil {
 		return errors.Wrap(err, "failed to retrieve the current context from kube configuration")
 	}
@@ -265,15 +328,30 @@ func (options *InstallOptions) Run() error {
 		return fmt.Errorf("Failed to ensure the namespace %s is created: %s\nIs this an RBAC issue on your cluster?", ns, err)
 	}
 
+	installSteps, err := options.loadInstallCheckpoint(context, ns)
+	if err != nil {
+		return errors.Wrap(err, "failed to load the install checkpoint")
+	}
+
 	err = options.RunCommand("kubectl", "config", "set-context", context, "--namespace", ns)
 	if err != nil {
 		return errors.Wrapf(err, "failed to set the context '%s' in kube configuration", context)
 	}
 
+	if options.Flags.Existing && options.Flags.Provider == "" {
+		options.Flags.Provider = KUBERNETES
+	}
 	options.Flags.Provider, err = options.GetCloudProvider(options.Flags.Provider)
 	if err != nil {
 		return errors.Wrapf(err, "failed to get the cloud provider '%s'", options.Flags.Provider)
 	}
+	var spotBuilds *v1.SpotBuilds
+	if options.Flags.SpotBuilds {
+		spotBuilds, err = kube.DefaultSpotBuilds(options.Flags.Provider)
+		if err != nil {
+			return err
+		}
+	}
 
 	initOpts.Flags.Provider = options.Flags.Provider
 	initOpts.Flags.Namespace = options.Flags.Namespace
@@ -295,7 +373,7 @@ func (options *InstallOptions) Run() error {
 		log.Success("created role cluster-admin")
 	}
 
-	currentContext, err := options.getCommandOutput("", "kubectl", "config", "current-context")
+	currentContext, err := kube.CurrentContextName()
 	if err != nil {
 		return errors.Wrap(err, "failed to get the current context")
 	}
@@ -307,6 +385,13 @@ func (options *InstallOptions) Run() error {
 		}
 	}
 
+	if options.Flags.Existing {
+		err = options.configureExistingCluster(client)
+		if err != nil {
+			return err
+		}
+	}
+
 	if currentContext == "minikube" {
 		if options.Flags.Provider == "" {
 			options.Flags.Provider = MINIKUBE
@@ -338,7 +423,7 @@ func (options *InstallOptions) Run() error {
 			log.Success("set exposeController Config Domain " + ecConfig.Domain + "\n")
 		}
 		if isOpenShiftProvider(options.Flags.Provider) {
-			ecConfig.Exposer = "Route"
+			ecConfig.Exposer = kube.ExposerRoute
 		}
 	}
 
@@ -347,8 +432,9 @@ func (options *InstallOptions) Run() error {
 		if err != nil {
 			return err
 		}
-		initOpts.helm = options.helm
 	}
+	// lets share the helm client so that it uses the tiller namespace and TLS certificates configured by init
+	initOpts.helm = options.helm
 
 	err = initOpts.Run()
 	if err != nil {
@@ -373,6 +459,13 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "failed to read the git secrets from configuration")
 	}
 
+	if installSteps.IsResuming() {
+		err = options.restoreAdminPassword(ns)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = options.AdminSecretsService.NewAdminSecretsConfig()
 	if err != nil {
 		return errors.Wrap(err, "failed to create the admin secret config service")
@@ -415,6 +508,11 @@ func (options *InstallOptions) Run() error {
 		return errors.Wrap(err, "failed to add the git servers to Jenkins config")
 	}
 
+	err = options.configureStorageClasses(client, helmConfig)
+	if err != nil {
+		return errors.Wrap(err, "failed to configure the storage classes")
+	}
+
 	config, err := helmConfig.String()
 	if err != nil {
 		return errors.Wrap(err, "failed to get the helm config")
@@ -521,6 +619,12 @@ func (options *InstallOptions) Run() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to append the myvalues.yaml file")
 	}
+	tlsEnabled, _ := strconv.ParseBool(helmConfig.ExposeController.Config.TLSAcme)
+	valueFiles, cleanup, err := options.expandValueFiles(valueFiles, options.installedRequirements(ns, domain, tlsEnabled, version))
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
 	options.currentNamespace = ns
 	if options.Flags.Prow {
@@ -535,17 +639,23 @@ func (options *InstallOptions) Run() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to convert the helm install timeout value")
 	}
+	jxRelName := "jenkins-x"
+	chartInstall := &options.CommonOptions.ChartInstall
+	if t, ok := chartInstall.Timeouts[jxRelName]; ok {
+		timeoutInt = t
+	}
 	options.Helm().SetCWD(makefileDir)
+	options.Helm().SetAtomic(chartInstall.Atomic)
 	jxChart := "jenkins-x/jenkins-x-platform"
-	jxRelName := "jenkins-x"
 
 	log.Infof("Installing jx into namespace %s\n", util.ColorInfo(ns))
 
-	if !options.Flags.InstallOnly {
-		err = options.Helm().UpgradeChart(jxChart, jxRelName, ns, &version, true, &timeoutInt, false, false, nil, valueFiles)
-	} else {
-		err = options.Helm().InstallChart(jxChart, jxRelName, ns, &version, &timeoutInt, nil, valueFiles)
-	}
+	err = installSteps.Run(installStepPlatform, options.verifyChartRelease(jxRelName, version), func() error {
+		if !options.Flags.InstallOnly {
+			return options.Helm().UpgradeChart(jxChart, jxRelName, ns, &version, true, &timeoutInt, false, false, nil, valueFiles)
+		}
+		return options.Helm().InstallChart(jxChart, jxRelName, ns, &version, &timeoutInt, nil, valueFiles)
+	})
 	if err != nil {
 		return errors.Wrap(err, "failed to install/upgrade the jenkins-x platform chart")
 	}
@@ -601,15 +711,25 @@ func (options *InstallOptions) Run() error {
 		return err
 	}
 
-	err = options.waitForInstallToBeReady(ns)
+	err = options.configureMixedOSCluster(ns)
 	if err != nil {
-		return errors.Wrap(err, "failed to wait for jenkinx-x chart installation to be ready")
+		return errors.Wrap(err, "failed to configure the platform for Windows nodes")
+	}
+
+	if !chartInstall.NoWait {
+		err = options.waitForInstallToBeReady(ns, time.Duration(timeoutInt)*time.Second)
+		if err != nil {
+			return errors.Wrap(err, "failed to wait for jenkinx-x chart installation to be ready")
+		}
+		log.Infof("Jenkins X deployments ready in namespace %s\n", ns)
 	}
-	log.Infof("Jenkins X deployments ready in namespace %s\n", ns)
 
 	if options.Flags.Prow {
 		callback := func(env *v1.Environment) error {
 			env.Spec.WebHookEngine = v1.WebHookEngineProw
+			if options.Flags.Lighthouse {
+				env.Spec.WebHookEngine = v1.WebHookEngineLighthouse
+			}
 			settings := &env.Spec.TeamSettings
 			settings.PromotionEngine = v1.PromotionEngineProw
 			if settings.BuildPackURL == "" {
@@ -637,6 +757,27 @@ func (options *InstallOptions) Run() error {
 			return err
 		}
 	}
+	if initOpts.Flags.Tiller && !initOpts.Flags.SkipTiller && !initOpts.Flags.Helm3 {
+		tillerNamespace := ns
+		if initOpts.Flags.GlobalTiller {
+			tillerNamespace = initOpts.Flags.TillerNamespace
+		}
+		callback := func(env *v1.Environment) error {
+			env.Spec.TeamSettings.TillerNamespace = tillerNamespace
+			env.Spec.TeamSettings.TillerTLS = initOpts.Flags.TillerTLS
+			return nil
+		}
+		err = options.ModifyDevEnvironment(callback)
+		if err != nil {
+			return err
+		}
+	}
+	if spotBuilds != nil {
+		err = options.configureSpotBuilds(spotBuilds)
+		if err != nil {
+			return errors.Wrap(err, "failed to configure builds to run on spot nodes")
+		}
+	}
 	if helmBinary != "helm" {
 		// default apps to use helm3 too
 		helmOptions := EditHelmBinOptions{}
@@ -658,7 +799,14 @@ func (options *InstallOptions) Run() error {
 
 	for _, ac := range addonConfig.Addons {
 		if ac.Enabled {
-			err = options.installAddon(ac.Name)
+			name := ac.Name
+			releaseName := name
+			if name == "gitea" {
+				releaseName = defaultGiteaReleaseName
+			}
+			err = installSteps.Run(installStepAddonPrefix+name, options.verifyChartRelease(releaseName, ""), func() error {
+				return options.installAddon(name)
+			})
 			if err != nil {
 				return fmt.Errorf("failed to install addon %s: %s", ac.Name, err)
 			}
@@ -667,18 +815,20 @@ func (options *InstallOptions) Run() error {
 
 	options.logAdminPassword()
 
-	log.Info("Getting Jenkins API Token\n")
-	err = options.retry(3, 2*time.Second, func() (err error) {
-		options.CreateJenkinsUserOptions.CommonOptions = options.CommonOptions
-		options.CreateJenkinsUserOptions.Password = options.AdminSecretsService.Flags.DefaultAdminPassword
-		options.CreateJenkinsUserOptions.UseBrowser = true
-		if options.BatchMode {
-			options.CreateJenkinsUserOptions.BatchMode = true
-			options.CreateJenkinsUserOptions.Headless = true
-			log.Info("Attempting to find the Jenkins API Token with the browser in headless mode...")
-		}
-		err = options.CreateJenkinsUserOptions.Run()
-		return
+	err = installSteps.Run(installStepJenkinsToken, options.verifyJenkinsToken, func() error {
+		log.Info("Getting Jenkins API Token\n")
+		return options.retry(3, 2*time.Second, func() (err error) {
+			options.CreateJenkinsUserOptions.CommonOptions = options.CommonOptions
+			options.CreateJenkinsUserOptions.Password = options.AdminSecretsService.Flags.DefaultAdminPassword
+			options.CreateJenkinsUserOptions.UseBrowser = true
+			if options.BatchMode {
+				options.CreateJenkinsUserOptions.BatchMode = true
+				options.CreateJenkinsUserOptions.Headless = true
+				log.Info("Attempting to find the Jenkins API Token with the browser in headless mode...")
+			}
+			err = options.CreateJenkinsUserOptions.Run()
+			return
+		})
 	})
 	if err != nil {
 		return errors.Wrap(err, "failed to get the Jenkins API token")
@@ -694,6 +844,11 @@ func (options *InstallOptions) Run() error {
 		log.Warnf("failed to update the Jenkins external URL")
 	}
 
+	err = kube.EnsureDefaultTeamRoles(client, ns)
+	if err != nil {
+		return errors.Wrap(err, "failed to create the default team roles")
+	}
+
 	if !options.Flags.NoDefaultEnvironments {
 		// lets only recreate the environments if its the first time we run this
 		_, envNames, err := kube.GetEnvironments(jxClient, ns)
@@ -748,6 +903,17 @@ func (options *InstallOptions) Run() error {
 		}
 	}
 
+	requirements := options.installedRequirements(ns, domain, tls, version)
+	err = options.saveInstalledRequirements(requirements, ns)
+	if err != nil {
+		log.Warnf("failed to save the platform requirements: %s\n", err)
+	}
+
+	err = installSteps.Done()
+	if err != nil {
+		log.Warnf("failed to remove the install checkpoint: %s\n", err)
+	}
+
 	log.Success("\nJenkins X installation completed successfully\n")
 
 	options.logAdminPassword()
@@ -762,6 +928,115 @@ func (options *InstallOptions) Run() error {
 	return nil
 }
 
+// applyRequirements loads the requirements file and applies it to the install flags
+func (options *InstallOptions) applyRequirements() error {
+	if options.Flags.Requirements == "" {
+		return nil
+	}
+	requirements, err := config.LoadRequirementsConfig(options.Flags.Requirements)
+	if err != nil {
+		return err
+	}
+	log.Infof("Installing the platform with the requirements from %s\n", util.ColorInfo(options.Flags.Requirements))
+	flags := &options.Flags
+	flags.Provider = requirements.Provider
+	if requirements.Namespace != "" {
+		flags.Namespace = requirements.Namespace
+	}
+	if requirements.Domain != "" {
+		flags.Domain = requirements.Domain
+		options.InitOptions.Flags.Domain = requirements.Domain
+	}
+	if requirements.Version != "" {
+		flags.Version = requirements.Version
+	}
+	if requirements.Webhook != "" {
+		flags.Lighthouse = requirements.Webhook == config.WebhookEngineLighthouse
+		flags.Prow = requirements.Webhook == config.WebhookEngineProw || flags.Lighthouse
+	}
+	storage := requirements.Storage
+	if storage.StorageClass != "" {
+		flags.StorageClass = storage.StorageClass
+	}
+	if storage.Jenkins != "" {
+		flags.JenkinsStorageClass = storage.Jenkins
+	}
+	if storage.Nexus != "" {
+		flags.NexusStorageClass = storage.Nexus
+	}
+	if storage.ChartMuseum != "" {
+		flags.ChartMuseumStorageClass = storage.ChartMuseum
+	}
+	exposeController := options.CreateEnvOptions.HelmValuesConfig.ExposeController
+	if exposeController != nil {
+		exposeController.Config.TLSAcme = strconv.FormatBool(requirements.TLS.Enabled)
+		exposeController.Config.HTTP = strconv.FormatBool(!requirements.TLS.Enabled)
+	}
+	chartInstall := &options.CommonOptions.ChartInstall
+	for name, timeout := range requirements.Helm.Timeouts {
+		if chartInstall.Timeouts == nil {
+			chartInstall.Timeouts = map[string]int{}
+		}
+		chartInstall.Timeouts[name] = timeout
+	}
+	chartInstall.NoWait = requirements.Helm.NoWait
+	chartInstall.Atomic = requirements.Helm.Atomic
+	return nil
+}
+
+// applyChartInstallFlags applies the flags of how helm waits for the charts over those of the requirements
+func (options *InstallOptions) applyChartInstallFlags() error {
+	flags := options.Flags
+	chartInstall := &options.CommonOptions.ChartInstall
+	err := chartInstall.AddTimeouts(flags.ComponentTimeouts)
+	if err != nil {
+		return err
+	}
+	if flags.NoWait {
+		chartInstall.NoWait = true
+	}
+	if flags.Atomic {
+		chartInstall.Atomic = true
+	}
+	if chartInstall.NoWait && chartInstall.Atomic {
+		return fmt.Errorf("the --atomic option requires helm to wait for the charts so it cannot be used with --no-wait")
+	}
+	return nil
+}
+
+// installedRequirements returns the requirements the platform has been installed with
+func (options *InstallOptions) installedRequirements(ns string, domain string, tls bool, version string) *config.RequirementsConfig {
+	flags := options.Flags
+	webhook := config.WebhookEngineJenkins
+	if flags.Lighthouse {
+		webhook = config.WebhookEngineLighthouse
+	} else if flags.Prow {
+		webhook = config.WebhookEngineProw
+	}
+	return &config.RequirementsConfig{
+		Provider:  flags.Provider,
+		Namespace: ns,
+		Domain:    domain,
+		TLS: config.TLSRequirements{
+			Enabled: tls,
+		},
+		Storage: config.StorageRequirements{
+			StorageClass: flags.StorageClass,
+			Jenkins:      flags.JenkinsStorageClass,
+			Nexus:        flags.NexusStorageClass,
+			ChartMuseum:  flags.ChartMuseumStorageClass,
+		},
+		SecretStorage: config.SecretStorageLocal,
+		Webhook:       webhook,
+		Version:       version,
+		Helm: config.HelmRequirements{
+			Timeouts: options.CommonOptions.ChartInstall.Timeouts,
+			NoWait:   options.CommonOptions.ChartInstall.NoWait,
+			Atomic:   options.CommonOptions.ChartInstall.Atomic,
+		},
+	}
+}
+
 func isOpenShiftProvider(provider string) bool {
 	switch provider {
 	case OPENSHIFT, MINISHIFT:
@@ -772,21 +1047,14 @@ func isOpenShiftProvider(provider string) bool {
 }
 
 func (options *InstallOptions) enableOpenShiftSCC(ns string) error {
-	log.Infof("Enabling anyuid for the Jenkins service account in namespace %s\n", ns)
-	err := options.RunCommand("oc", "adm", "policy", "add-scc-to-user", "anyuid", "system:serviceaccount:"+ns+":jenkins")
-	if err != nil {
-		return err
-	}
-	err = options.RunCommand("oc", "adm", "policy", "add-scc-to-user", "hostaccess", "system:serviceaccount:"+ns+":jenkins")
-	if err != nil {
-		return err
-	}
-	err = options.RunCommand("oc", "adm", "policy", "add-scc-to-user", "privileged", "system:serviceaccount:"+ns+":jenkins")
-	if err != nil {
-		return err
+	// the jenkins agents and the knative build pods, which run as the default service account, run docker builds
+	for _, serviceAccount := range []string{"jenkins", "default"} {
+		err := options.enableOpenShiftBuildPodSCC(ns, serviceAccount)
+		if err != nil {
+			return err
+		}
 	}
-	// try fix monocular
-	return options.RunCommand("oc", "adm", "policy", "add-scc-to-user", "anyuid", "system:serviceaccount:"+ns+":default")
+	return nil
 }
 
 func (options *InstallOptions) enableOpenShiftRegistryPermissions(ns string, helmConfig *config.HelmValuesConfig, dockerRegistry string) error {
@@ -893,7 +1161,7 @@ func (options *InstallOptions) cloneJXCloudEnvironmentsRepo() (string, error) {
 					Message: "A local Jenkins X cloud environments repository already exists, recreate with latest?",
 					Default: true,
 				}
-				err := survey.AskOne(confirm, &flag, nil)
+				err := util.AskOne(confirm, &flag, nil)
 				if err != nil {
 					return wrkDir, err
 				}
@@ -977,15 +1245,47 @@ func (options *InstallOptions) getGitToken() (string, string, error) {
 	return userAuth.Username, userAuth.ApiToken, nil
 }
 
-func (options *InstallOptions) waitForInstallToBeReady(ns string) error {
+// configureMixedOSCluster schedules the platform components and the linux build pods onto linux nodes and adds the
+// Windows build pod template if the cluster has both linux and Windows nodes
+func (options *InstallOptions) configureMixedOSCluster(ns string) error {
+	client := options.KubeClientCached
+	mixed, err := kube.IsMixedOSCluster(client)
+	if err != nil {
+		return errors.Wrap(err, "failed to find the operating systems of the nodes")
+	}
+	if !mixed {
+		return nil
+	}
+	log.Infof("The cluster has %s nodes so scheduling the Jenkins X platform onto %s nodes\n", util.ColorInfo("Windows"), util.ColorInfo("linux"))
+	names, err := kube.EnsureLinuxNodeSelectors(client, ns)
+	if err != nil {
+		return err
+	}
+	if len(names) > 0 {
+		log.Infof("Added a linux node selector to %s\n", util.ColorInfo(strings.Join(names, ", ")))
+	}
+	if options.Flags.Prow {
+		return nil
+	}
+	err = kube.UpdatePodTemplatesForMixedOSCluster(client, ns)
+	if err != nil {
+		return err
+	}
+	log.Infof("Added the %s build pod template. Use %s to import Windows container applications\n", util.ColorInfo(kube.WindowsPodTemplateName), util.ColorInfo("jx import --windows"))
+	return nil
+}
+
+func (options *InstallOptions) waitForInstallToBeReady(ns string, timeout time.Duration) error {
 	client, _, err := options.KubeClient()
 	if err != nil {
 		return err
 	}
 
 	log.Warnf("waiting for install to be ready, if this is the first time then it will take a while to download images")
+	stop := options.reportNotReadyResources(ns)
+	defer close(stop)
 
-	return kube.WaitForAllDeploymentsToBeReady(client, ns, 30*time.Minute)
+	return kube.WaitForAllDeploymentsToBeReady(client, ns, timeout)
 
 }
 
@@ -1106,7 +1406,7 @@ func (options *InstallOptions) addGitServersToJenkinsConfig(helmConfig *config.H
 			if !gits.IsGitHubServerURL(u) {
 				sc := config.JenkinsGithubServersValuesConfig{
 					Name: server.Name,
-					Url:  gits.GitHubEnterpriseApiEndpointURL(u),
+					Url:  gits.GitHubAPIURL(server),
 				}
 				helmConfig.Jenkins.Servers.GHE = append(helmConfig.Jenkins.Servers.GHE, sc)
 			}
@@ -1166,6 +1466,64 @@ func (options *InstallOptions) ensureDefaultStorageClass(client kubernetes.Inter
 	return err
 }
 
+// configureStorageClasses validates the storage classes of the persistent volumes of the platform components
+// and adds them to the helm values
+func (options *InstallOptions) configureStorageClasses(client kubernetes.Interface, helmConfig *config.HelmValuesConfig) error {
+	flags := &options.Flags
+	jenkinsClass := flags.JenkinsStorageClass
+	if jenkinsClass == "" {
+		jenkinsClass = flags.StorageClass
+	}
+	nexusClass := flags.NexusStorageClass
+	if nexusClass == "" {
+		nexusClass = flags.StorageClass
+	}
+	chartMuseumClass := flags.ChartMuseumStorageClass
+	if chartMuseumClass == "" {
+		chartMuseumClass = flags.StorageClass
+	}
+
+	if jenkinsClass == "" && nexusClass == "" && chartMuseumClass == "" {
+		sc, err := kube.GetDefaultStorageClass(client)
+		if err != nil {
+			return err
+		}
+		if sc == nil {
+			log.Warnf("There is no default storage class in the cluster so the persistent volumes of the platform components may not be provisioned. Use --storage-class to specify one\n")
+		}
+		return nil
+	}
+
+	for _, name := range []string{jenkinsClass, nexusClass, chartMuseumClass} {
+		if name != "" {
+			err := kube.ValidateStorageClass(client, name)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if jenkinsClass != "" {
+		if helmConfig.Jenkins.Persistence == nil {
+			helmConfig.Jenkins.Persistence = &config.JenkinsPersistenceConfig{}
+		}
+		helmConfig.Jenkins.Persistence.StorageClass = jenkinsClass
+		log.Infof("Using storage class %s for Jenkins\n", util.ColorInfo(jenkinsClass))
+	}
+	if nexusClass != "" {
+		helmConfig.Nexus = &config.PersistentValuesConfig{
+			Persistence: &config.PersistenceConfig{StorageClass: nexusClass},
+		}
+		log.Infof("Using storage class %s for Nexus\n", util.ColorInfo(nexusClass))
+	}
+	if chartMuseumClass != "" {
+		helmConfig.ChartMuseum = &config.PersistentValuesConfig{
+			Persistence: &config.PersistenceConfig{StorageClass: chartMuseumClass},
+		}
+		log.Infof("Using storage class %s for ChartMuseum\n", util.ColorInfo(chartMuseumClass))
+	}
+	return nil
+}
+
 // returns the docker registry string for the given provider
 func (options *InstallOptions) dockerRegistryValue() (string, error) {
 	if options.Flags.DockerRegistry != "" {
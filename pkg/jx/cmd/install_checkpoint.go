@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/checkpoint"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// InstallCheckpointFile the file in the jx config dir recording the completed steps of an install
+	InstallCheckpointFile = "install-checkpoint.yml"
+
+	installStepPlatform     = "platform"
+	installStepJenkinsToken = "jenkins-token"
+	installStepAddonPrefix  = "addon-"
+)
+
+// loadInstallCheckpoint loads the checkpoint of the install into the namespace of the context. The completed steps
+// are only skipped if --resume is specified
+func (options *InstallOptions) loadInstallCheckpoint(context string, ns string) (*checkpoint.Checkpoint, error) {
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return nil, err
+	}
+	return checkpoint.Load(filepath.Join(dir, InstallCheckpointFile), context, ns, options.Flags.Resume)
+}
+
+// restoreAdminPassword reuses the admin password of the interrupted install so that the values of the resumed
+// install match the components which are already installed
+func (options *InstallOptions) restoreAdminPassword(ns string) error {
+	if options.Cmd != nil && options.Cmd.Flags().Changed("default-admin-password") {
+		return nil
+	}
+	secret, err := options.KubeClientCached.CoreV1().Secrets(ns).Get(JXInstallConfig, metav1.GetOptions{})
+	if err != nil || len(secret.Data[AdminSecretsFile]) == 0 {
+		return nil
+	}
+	adminSecrets := &config.AdminSecretsConfig{}
+	err = yaml.Unmarshal(secret.Data[AdminSecretsFile], adminSecrets)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse the admin secrets of Secret %s in namespace %s", JXInstallConfig, ns)
+	}
+	if adminSecrets.Jenkins != nil && adminSecrets.Jenkins.JenkinsSecret.Password != "" {
+		options.AdminSecretsService.Flags.DefaultAdminPassword = adminSecrets.Jenkins.JenkinsSecret.Password
+		log.Info("Reusing the admin password of the interrupted install\n")
+	}
+	return nil
+}
+
+// verifyChartRelease returns a function verifying the helm release is deployed at the version if specified
+func (options *InstallOptions) verifyChartRelease(releaseName string, version string) checkpoint.VerifyFunc {
+	return func() (bool, error) {
+		statusMap, err := options.Helm().StatusReleases()
+		if err != nil {
+			return false, err
+		}
+		if statusMap[releaseName] != "DEPLOYED" {
+			return false, nil
+		}
+		if version == "" {
+			return true, nil
+		}
+		output, err := options.Helm().ListCharts()
+		if err != nil {
+			return false, err
+		}
+		return helm.ReleaseChartVersions(output)[releaseName] == version, nil
+	}
+}
+
+// verifyJenkinsToken verifies the API token of the Jenkins server is in the auth config
+func (options *InstallOptions) verifyJenkinsToken() (bool, error) {
+	kubeClient, ns, err := options.KubeClient()
+	if err != nil {
+		return false, err
+	}
+	authConfigSvc, err := options.Factory.CreateJenkinsAuthConfigService(kubeClient, ns)
+	if err != nil {
+		return false, err
+	}
+	url, err := options.findService(kube.ServiceJenkins)
+	if err != nil {
+		return false, err
+	}
+	for _, userAuth := range authConfigSvc.Config().FindUserAuths(url) {
+		if !userAuth.IsInvalid() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
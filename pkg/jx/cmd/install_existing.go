@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/kubernetes"
+)
+
+// configureExistingCluster detects what the existing cluster provides and adapts the install to it, reusing its
+// ingress controller and exposing the ingress controller on a node when the cluster has no load balancers
+func (options *InstallOptions) configureExistingCluster(client kubernetes.Interface) error {
+	caps, err := kube.DetectClusterCapabilities(client)
+	if err != nil {
+		return errors.Wrap(err, "failed to detect the capabilities of the cluster")
+	}
+	options.renderClusterCapabilities(caps)
+	return options.adaptToClusterCapabilities(caps)
+}
+
+func (options *InstallOptions) adaptToClusterCapabilities(caps *kube.ClusterCapabilities) error {
+	if !caps.RBAC {
+		return fmt.Errorf("RBAC is not enabled on the cluster. Jenkins X requires RBAC")
+	}
+	if caps.DefaultStorageClass == "" && options.Flags.StorageClass == "" {
+		return fmt.Errorf("the cluster has no default storage class so the persistent volumes of the platform cannot be provisioned. Use --storage-class to specify one")
+	}
+
+	initFlags := &options.InitOptions.Flags
+	if caps.HasIngressController() {
+		log.Infof("Using the existing ingress controller %s in namespace %s\n", util.ColorInfo(caps.IngressDeployment), util.ColorInfo(caps.IngressNamespace))
+		initFlags.IngressNamespace = caps.IngressNamespace
+		initFlags.IngressDeployment = caps.IngressDeployment
+		if caps.IngressService != "" {
+			initFlags.IngressService = caps.IngressService
+		}
+	}
+
+	if caps.LoadBalancer || initFlags.ExternalIP != "" {
+		return nil
+	}
+	if caps.NodeAddress == "" {
+		return fmt.Errorf("the cluster has no load balancers and no ready node with an address. Use --external-ip to specify the address of the ingress controller")
+	}
+	if caps.HasIngressController() {
+		log.Warnf("The cluster has no load balancers so the ingress controller %s must listen on port 80 of node %s\n", caps.IngressDeployment, caps.NodeName)
+	} else {
+		log.Infof("The cluster has no load balancers so the ingress controller will use the host network of node %s\n", util.ColorInfo(caps.NodeName))
+		initFlags.IngressNode = caps.NodeName
+	}
+	initFlags.ExternalIP = caps.NodeAddress
+	return nil
+}
+
+func (options *InstallOptions) renderClusterCapabilities(caps *kube.ClusterCapabilities) {
+	yesNo := func(value bool) string {
+		if value {
+			return "yes"
+		}
+		return "no"
+	}
+	ingress := "none"
+	if caps.HasIngressController() {
+		ingress = caps.IngressNamespace + "/" + caps.IngressDeployment
+	}
+	storageClass := caps.DefaultStorageClass
+	if storageClass == "" {
+		storageClass = "none"
+	}
+	table := options.CreateTable()
+	table.AddRow("CAPABILITY", "DETECTED")
+	table.AddRow("RBAC", yesNo(caps.RBAC))
+	table.AddRow("Default storage class", storageClass)
+	table.AddRow("Ingress controller", ingress)
+	table.AddRow("LoadBalancer services", yesNo(caps.LoadBalancer))
+	table.Render()
+}
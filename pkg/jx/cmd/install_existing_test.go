@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdaptToClusterCapabilities(t *testing.T) {
+	t.Parallel()
+	caps := &kube.ClusterCapabilities{
+		DefaultStorageClass: "standard",
+		NodeName:            "node1",
+		NodeAddress:         "10.0.0.5",
+	}
+
+	o := &InstallOptions{}
+	assert.Error(t, o.adaptToClusterCapabilities(caps), "RBAC is required")
+
+	caps.RBAC = true
+	require.NoError(t, o.adaptToClusterCapabilities(caps))
+	assert.Equal(t, "node1", o.InitOptions.Flags.IngressNode)
+	assert.Equal(t, "10.0.0.5", o.InitOptions.Flags.ExternalIP)
+
+	o = &InstallOptions{}
+	caps.IngressNamespace = "ingress"
+	caps.IngressDeployment = "nginx"
+	caps.IngressService = "nginx"
+	caps.LoadBalancer = true
+	require.NoError(t, o.adaptToClusterCapabilities(caps))
+	assert.Equal(t, "ingress", o.InitOptions.Flags.IngressNamespace)
+	assert.Equal(t, "nginx", o.InitOptions.Flags.IngressDeployment)
+	assert.Equal(t, "nginx", o.InitOptions.Flags.IngressService)
+	assert.Equal(t, "", o.InitOptions.Flags.IngressNode)
+	assert.Equal(t, "", o.InitOptions.Flags.ExternalIP)
+
+	o = &InstallOptions{}
+	caps.DefaultStorageClass = ""
+	assert.Error(t, o.adaptToClusterCapabilities(caps), "a storage class is required")
+	o.Flags.StorageClass = "fast"
+	assert.NoError(t, o.adaptToClusterCapabilities(caps))
+}
@@ -37,3 +37,26 @@ tutorial@bamboo-depth-206411.iam.gserviceaccount.com`
 	username = `tutorial@bamboo-depth-206411.iam.gserviceaccount.com`
 	assert.Equal(t, cmd.GetSafeUsername(username), "tutorial@bamboo-depth-206411.iam.gserviceaccount.com")
 }
+
+func TestChartInstallSettingsTimeout(t *testing.T) {
+	t.Parallel()
+	settings := &cmd.ChartInstallSettings{}
+	err := settings.AddTimeouts([]string{"jenkins-x=3600", "prow=120"})
+	assert.NoError(t, err)
+
+	timeout, err := settings.Timeout("jenkins-x", "jenkins-x/jenkins-x-platform")
+	assert.NoError(t, err)
+	assert.Equal(t, 3600, timeout)
+
+	timeout, err = settings.Timeout("jx-prow", "jenkins-x/prow")
+	assert.NoError(t, err)
+	assert.Equal(t, 120, timeout, "the chart name without the repository should match")
+
+	timeout, err = settings.Timeout("jx-nexus", "jenkins-x/nexus")
+	assert.NoError(t, err)
+	assert.Equal(t, 6000, timeout, "the default timeout should be used")
+
+	for _, value := range []string{"prow", "prow=soon", "prow=-1", "=60"} {
+		assert.Error(t, settings.AddTimeouts([]string{value}), "for %s", value)
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/chromedp/chromedp/runner"
 	"github.com/hpcloud/tail"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	jxlog "github.com/jenkins-x/jx/pkg/log"
@@ -184,7 +185,7 @@ func (o *LoginOptions) Login() (*UserLoginInfo, error) {
 }
 
 func (o *LoginOptions) OnboardUser(cookie string) (*UserLoginInfo, error) {
-	client := http.Client{}
+	client := httpclient.NewBaseClient()
 	req, err := http.NewRequest("POST", o.onboardingURL(), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "building onboarding request")
@@ -32,18 +32,24 @@ type LogsOptions struct {
 
 var (
 	logs_long = templates.LongDesc(`
-		Tails the logs of the newest pod for a Deployment.
+		Streams the logs of all the containers of the pods of a Deployment, a label selector or the latest knative build.
+
+		Pods which restart or are created while streaming are followed too. Each line is prefixed with the pod and
+		container name unless a single container is chosen.
 
 `)
 
 	logs_example = templates.Examples(`
-		# Tails the log of the latest pod in deployment myapp
+		# Streams the logs of the pods in deployment myapp
 		jx logs myapp
 
-		# Tails the log of the container foo in the latest pod in deployment myapp
+		# Streams the log of the container foo in the pods of deployment myapp
 		jx logs myapp -c foo
 
-		# Tails the log of the latest knative build pod
+		# Streams the logs of the pods matching a label selector
+		jx logs -l app=jenkins
+
+		# Streams the logs of all the steps of the latest knative build
 		jx logs -k
 `)
 )
@@ -58,7 +64,7 @@ func NewCmdLogs(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	}
 	cmd := &cobra.Command{
 		Use:     "logs [deployment]",
-		Short:   "Tails the log of the latest pod for a deployment",
+		Short:   "Streams the logs of the pods of a deployment",
 		Long:    logs_long,
 		Example: logs_example,
 		Aliases: []string{"log"},
@@ -137,47 +143,70 @@ func (o *LogsOptions) Run() error {
 		}
 	}
 
-	for {
-		pod := ""
-		if o.KNativeBuild {
-			pod, err = o.waitForReadyKnativeBuildPod(client, ns, false)
-			if pod == "" {
-				return fmt.Errorf("No knative build pod found for namespace %s", ns)
-			}
-		} else if o.Label != "" {
-			selector, err := parseSelector(o.Label)
-			if err != nil {
-				return err
-			}
-			pod, err = o.waitForReadyPodForSelectorLabels(client, ns, selector, false)
-			if err != nil {
-				return err
-			}
-			if pod == "" {
-				return fmt.Errorf("No pod found for namespace %s with selector %s", ns, o.Label)
-			}
-		} else {
-			pod, err = o.waitForReadyPodForDeployment(client, ns, name, names, false)
-			if err != nil {
-				return err
-			}
-			if pod == "" {
-				return fmt.Errorf("No pod found for namespace %s with name %s", ns, name)
-			}
+	selector := ""
+	untilComplete := false
+	if o.KNativeBuild {
+		selector, err = o.latestKnativeBuildSelector(client, ns)
+		if err != nil {
+			return err
 		}
-		err = o.tailLogs(ns, pod, o.Container)
+		untilComplete = true
+	} else if o.Label != "" {
+		_, err = labels.Parse(o.Label)
 		if err != nil {
-			return nil
+			return util.InvalidOptionError("label", o.Label, err)
 		}
+		selector = o.Label
+	} else {
+		deployment, err := client.AppsV1beta1().Deployments(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return util.InvalidArg(name, names)
+		}
+		if deployment.Spec.Selector == nil || len(deployment.Spec.Selector.MatchLabels) == 0 {
+			return fmt.Errorf("No MatchLabels defined on the Selector of Deployment %s in namespace %s", name, ns)
+		}
+		selector = labels.SelectorFromSet(deployment.Spec.Selector.MatchLabels).String()
 	}
+	return o.streamLogs(client, ns, selector, o.Container, untilComplete)
 }
 
-func parseSelector(selectorText string) (map[string]string, error) {
-	selector, err := metav1.ParseToLabelSelector(selectorText)
+// latestKnativeBuildSelector returns the selector of the pods of the newest knative build in the given namespace
+func (o *LogsOptions) latestKnativeBuildSelector(c kubernetes.Interface, ns string) (string, error) {
+	pods, err := builds.GetBuildPods(c, ns)
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	var latest *corev1.Pod
+	for _, pod := range pods {
+		if latest == nil || pod.CreationTimestamp.After(latest.CreationTimestamp.Time) {
+			latest = pod
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("No knative build pod found for namespace %s", ns)
+	}
+	selector := builds.GetBuildPodSelector(latest)
+	if selector == "" {
+		return "", fmt.Errorf("No build name label on pod %s", latest.Name)
+	}
+	log.Infof("Streaming the logs of build pod %s\n", util.ColorInfo(latest.Name))
+	return selector, nil
+}
+
+// streamLogs follows the logs of all the containers, or only the given container, of the pods matching the selector
+// as they restart and new pods appear. If untilComplete is true it returns once all the pods have completed
+func (o *CommonOptions) streamLogs(kubeClient kubernetes.Interface, ns string, selector string, container string, untilComplete bool) error {
+	streamer := &kube.LogStreamer{
+		KubeClient:    kubeClient,
+		Namespace:     ns,
+		Selector:      selector,
+		Container:     container,
+		Out:           o.Out,
+		Prefix:        container == "",
+		Follow:        true,
+		UntilComplete: untilComplete,
 	}
-	return selector.MatchLabels, nil
+	return streamer.Run(make(chan struct{}))
 }
 
 func (o *CommonOptions) tailLogs(ns string, pod string, containerName string) error {
@@ -216,59 +245,6 @@ func (o *CommonOptions) waitForReadyPodForSelectorLabels(c kubernetes.Interface,
 	return o.waitForReadyPodForSelector(c, ns, selector, readyOnly)
 }
 
-func (o *CommonOptions) waitForReadyKnativeBuildPod(c kubernetes.Interface, ns string, readyOnly bool) (string, error) {
-	log.Warnf("Waiting for a running knative build pod in namespace %s\n", ns)
-	lastPod := ""
-	for {
-		pods, err := builds.GetBuildPods(c, ns)
-		if err != nil {
-			return "", err
-		}
-		name := ""
-		loggedInitContainerIdx := -1
-		var latestPod *corev1.Pod
-		lastTime := time.Time{}
-		for _, pod := range pods {
-			phase := pod.Status.Phase
-			if phase == corev1.PodRunning || phase == corev1.PodPending {
-				if !readyOnly {
-					created := pod.CreationTimestamp
-					if name == "" || created.After(lastTime) {
-						lastTime = created.Time
-						name = pod.Name
-						latestPod = pod
-					}
-				}
-			}
-		}
-		if latestPod != nil && name != "" {
-			if name != lastPod {
-				lastPod = name
-				loggedInitContainerIdx = -1
-				log.Warnf("Found newest pod: %s\n", name)
-			}
-			if kube.IsPodReady(latestPod) {
-				return name, nil
-			}
-
-			initContainers := latestPod.Status.InitContainerStatuses
-			for idx, ic := range initContainers {
-				if isContainerStarted(&ic.State) && idx > loggedInitContainerIdx {
-					loggedInitContainerIdx = idx
-					containerName := ic.Name
-					log.Warnf("Init container on pod: %s is: %s\n", name, containerName)
-					err = o.tailLogs(ns, name, containerName)
-					if err != nil {
-						break
-					}
-				}
-			}
-		}
-		// TODO replace with a watch flavour
-		time.Sleep(time.Second)
-	}
-}
-
 func (o *CommonOptions) waitForReadyPodForSelector(c kubernetes.Interface, ns string, selector labels.Selector, readyOnly bool) (string, error) {
 	log.Warnf("Waiting for a running pod in namespace %s with labels %v\n", ns, selector.String())
 	lastPod := ""
@@ -3,13 +3,13 @@ package cmd
 import (
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/spf13/cobra"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/client-go/tools/clientcmd"
 
 	"sort"
 
@@ -20,6 +20,9 @@ import (
 
 type NamespaceOptions struct {
 	CommonOptions
+
+	Filter     string
+	DirDefault bool
 }
 
 const (
@@ -28,7 +31,11 @@ const (
 
 var (
 	namespace_long = templates.LongDesc(`
-		Displays or changes the current namespace.`)
+		Displays or changes the current namespace.
+
+		The namespaces you used most recently are shown first. Type to narrow down the list or use --filter to
+		fuzzy match the namespace names. A namespace can be remembered as the default of the current directory
+		so that running 'jx ns' in the directory or any of its sub directories offers it as the default choice.`)
 	namespace_example = templates.Examples(`
 		# view the current namespace
 		jx ns -b
@@ -37,7 +44,13 @@ var (
 		jx ns
 
 		# Change the current namespace to 'cheese'
-		jx ns cheese`)
+		jx ns cheese
+
+		# Change the current namespace to 'cheese' and use it by default when running 'jx ns' in the current directory
+		jx ns cheese --dir-default
+
+		# pick from the namespaces which fuzzy match 'stg' such as 'jx-staging'
+		jx ns -f stg`)
 )
 
 func NewCmdNamespace(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
@@ -61,6 +74,8 @@ func NewCmdNamespace(f Factory, out io.Writer, errOut io.Writer) *cobra.Command
 			CheckErr(err)
 		},
 	}
+	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the list of namespaces to switch between using a fuzzy match of the given text")
+	cmd.Flags().BoolVarP(&options.DirDefault, "dir-default", "", false, "Remember the namespace as the default namespace of the current directory")
 	options.addCommonFlags(cmd)
 	return cmd
 }
@@ -84,13 +99,29 @@ func (o *NamespaceOptions) Run() error {
 		return err
 	}
 	currentNS := kube.CurrentNamespace(config)
+	if o.Filter != "" {
+		names = kube.FuzzyFilter(o.Filter, names)
+	}
+
+	history := loadContextHistory()
+	dirDefault := ""
+	dir, err := os.Getwd()
+	if err == nil {
+		d := history.FindDirectoryDefault(dir)
+		if d != nil && (d.Context == "" || d.Context == config.CurrentContext) {
+			dirDefault = d.Namespace
+		}
+	}
 
 	if ns == "" && !o.BatchMode {
-		defaultNamespace := ""
+		defaultNamespace := dirDefault
 		ctx := kube.CurrentContext(config)
-		if ctx != nil {
+		if ctx != nil && defaultNamespace == "" {
 			defaultNamespace = currentNS
 		}
+		if o.Filter == "" {
+			names = kube.OrderByRecent(names, history.RecentNamespaces)
+		}
 		pick, err := o.PickNamespace(names, defaultNamespace)
 		if err != nil {
 			return err
@@ -98,30 +129,34 @@ func (o *NamespaceOptions) Run() error {
 		ns = pick
 	}
 	info := util.ColorInfo
-	if ns != "" && ns != currentNS {
+	switched := ns != "" && ns != currentNS
+	if switched {
 		_, err = client.CoreV1().Namespaces().Get(ns, meta_v1.GetOptions{})
 		if err != nil {
 			return util.InvalidArg(ns, names)
 		}
-		newConfig := *config
-		ctx := kube.CurrentContext(config)
-		if ctx == nil {
+		if kube.CurrentContext(config) == nil {
 			return fmt.Errorf(noContextDefinedError)
 		}
-		if ctx.Namespace == ns {
-			return nil
-		}
-		ctx.Namespace = ns
-		err = clientcmd.ModifyConfig(po, newConfig, false)
+		err = kube.SwitchNamespace(config, po, ns)
 		if err != nil {
-			return fmt.Errorf("Failed to update the kube config %s", err)
+			return err
 		}
-		fmt.Fprintf(o.Out, "Now using namespace '%s' on server '%s'.\n", info(ctx.Namespace), info(kube.Server(config, ctx)))
+		fmt.Fprintf(o.Out, "Now using namespace '%s' on server '%s'.\n", info(ns), info(kube.CurrentServer(config)))
 	} else {
-		ns := kube.CurrentNamespace(config)
+		ns = kube.CurrentNamespace(config)
 		server := kube.CurrentServer(config)
 		fmt.Fprintf(o.Out, "Using namespace '%s' from context named '%s' on server '%s'.\n", info(ns), info(config.CurrentContext), info(server))
 	}
+	if switched || o.DirDefault {
+		updateContextHistory(func(h *kube.ContextHistory) {
+			h.AddRecentNamespace(ns)
+			if o.DirDefault && dir != "" {
+				h.SetDirectoryDefault(dir, config.CurrentContext, ns)
+				fmt.Fprintf(o.Out, "Namespace '%s' is now the default of directory %s\n", info(ns), info(dir))
+			}
+		})
+	}
 	return nil
 }
 
@@ -152,6 +187,6 @@ func (o *NamespaceOptions) PickNamespace(names []string, defaultNamespace string
 		Options: names,
 		Default: defaultNamespace,
 	}
-	err := survey.AskOne(prompt, &name, nil)
+	err := util.AskOne(prompt, &name, nil)
 	return name, err
 }
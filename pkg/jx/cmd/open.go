@@ -16,6 +16,11 @@ var (
 	open_long = templates.LongDesc(`
 		Opens a named service in the browser.
 
+		The well known services can be opened by their short names: jenkins, deck, nexus, monocular, chartmuseum,
+		dashboard and cloudbees. Services are looked up in the current and development namespaces first and then in
+		the namespaces of all environments. If no service has the exact name then the services whose name contains
+		the name or is similar to it are opened, prompting you to pick one if there are several.
+
 		You can use the '--url' argument to just display the URL without opening it`)
 
 	open_example = templates.Examples(`
@@ -23,7 +28,13 @@ var (
 		jx open jenkins-x-sonatype-nexus
 
 		# Print the Nexus console URL but do not open a browser
-		jx open jenkins-x-sonatype-nexus -u
+		jx open nexus -u
+
+		# Open an app deployed to any environment
+		jx open myapp
+
+		# Open an app in the staging environment
+		jx open myapp -e staging
 
 		# List all the service URLs
 		jx open`)
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// Pause contains the command line options
+type Pause struct {
+	CommonOptions
+}
+
+var (
+	pauseLong = templates.LongDesc(`
+		Pauses a process such as the pipeline triggers of a repository.
+`)
+
+	pauseExample = templates.Examples(`
+		# Pause the pipelines of a repository during an incident freeze
+		jx pause pipeline myorg/myapp --reason "incident freeze"
+	`)
+)
+
+// NewCmdPause creates the command object
+func NewCmdPause(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &Pause{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pause TYPE [flags]",
+		Short:   "Pauses a process such as the pipelines of a repository",
+		Long:    pauseLong,
+		Example: pauseExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdPausePipeline(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *Pause) Run() error {
+	return o.Cmd.Help()
+}
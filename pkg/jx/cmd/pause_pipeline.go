@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/jenkins-x/golang-jenkins"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// PausePipelineOptions the options for the pause pipeline command
+type PausePipelineOptions struct {
+	CommonOptions
+
+	GitServerURL string
+	Reason       string
+}
+
+var (
+	pausePipelineLong = templates.LongDesc(`
+		Pauses the triggers of the pipelines of a repository, for example during an incident freeze.
+
+		While paused, pushes and Pull Request comments no longer start pipelines. If the team uses Prow the trigger
+		plugin is removed for the repository, otherwise the Jenkins job of the repository is disabled.
+		Who paused the pipelines and why is recorded as annotations on the SourceRepository resource.
+
+		Use 'jx resume pipeline' to resume the triggers.
+`)
+
+	pausePipelineExample = templates.Examples(`
+		# Pause the pipelines of the repository in the current directory
+		jx pause pipeline --reason "incident freeze"
+
+		# Pause the pipelines of a repository
+		jx pause pipeline myorg/myapp --reason "incident freeze"
+	`)
+)
+
+// NewCmdPausePipeline creates the command
+func NewCmdPausePipeline(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &PausePipelineOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipeline [owner/repo] [flags]",
+		Short:   "Pauses the triggers of the pipelines of a repository",
+		Long:    pausePipelineLong,
+		Example: pausePipelineExample,
+		Aliases: []string{"pipe", "pipelines", "build", "run"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.GitServerURL, "git-provider-url", "", "", "The git server URL of the repository. Defaults to the current git server")
+	cmd.Flags().StringVarP(&options.Reason, "reason", "r", "", "Why the pipelines are paused")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *PausePipelineOptions) Run() error {
+	gitInfo, err := o.pipelineRepository(o.Args, o.GitServerURL)
+	if err != nil {
+		return err
+	}
+	err = o.setPipelineTriggersPaused(gitInfo, true)
+	if err != nil {
+		return err
+	}
+	err = o.modifySourceRepository(gitInfo, func(sr *v1.SourceRepository) {
+		kube.PausePipelines(sr, currentUserName(), o.Reason, time.Now())
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Paused the pipelines of %s\n", util.ColorInfo(gitInfo.Organisation+"/"+gitInfo.Name))
+	return nil
+}
+
+// setPipelineTriggersPaused pauses or resumes the triggers of the pipelines of the repository in Prow or Jenkins
+func (o *CommonOptions) setPipelineTriggersPaused(gitInfo *gits.GitRepositoryInfo, paused bool) error {
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	_, _, err = o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	isProw, err := o.isProw()
+	if err != nil {
+		return err
+	}
+	repo := gitInfo.Organisation + "/" + gitInfo.Name
+	if isProw {
+		err = prow.SetTriggersPaused(kubeClient, repo, devNs, paused)
+		if err != nil {
+			return fmt.Errorf("failed to update the prow configuration of repository %s: %s", repo, err)
+		}
+		return nil
+	}
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	action := "/enable"
+	if paused {
+		action = "/disable"
+	}
+	err = jenkinsClient.Post(gojenkins.FullJobPath(gitInfo.Organisation, gitInfo.Name)+action, nil, nil)
+	if err != nil {
+		return fmt.Errorf("failed to %s the Jenkins job of repository %s: %s", action[1:], repo, err)
+	}
+	return nil
+}
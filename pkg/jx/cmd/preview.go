@@ -67,6 +67,7 @@ type PreviewOptions struct {
 	Dir                    string
 	PostPreviewJobTimeout  string
 	PostPreviewJobPollTime string
+	MonorepoAppDir         string
 
 	PullRequestName string
 	GitConfDir      string
@@ -128,6 +129,7 @@ func (options *PreviewOptions) addPreviewOptions(cmd *cobra.Command) {
 	cmd.Flags().StringVarP(&options.PullRequestURL, "pr-url", "", "", "The Pull Request URL")
 	cmd.Flags().StringVarP(&options.SourceURL, "source-url", "s", "", "The source code git URL")
 	cmd.Flags().StringVarP(&options.SourceRef, "source-ref", "", "", "The source code git ref (branch/sha)")
+	cmd.Flags().StringVarP(&options.MonorepoAppDir, "app-dir", "", "", "The sub directory of the application if the source is a monorepo. Defaults to $APP_DIR or the current directory relative to the git root")
 	cmd.Flags().StringVarP(&options.PostPreviewJobTimeout, optionPostPreviewJobTimeout, "", "2h", "The duration before we consider the post preview Jobs failed")
 	cmd.Flags().StringVarP(&options.PostPreviewJobPollTime, optionPostPreviewJobPollTime, "", "10s", "The amount of time between polls for the post preview Job status")
 }
@@ -409,6 +411,14 @@ func (o *PreviewOptions) Run() error {
 	if err != nil {
 		return err
 	}
+	err = o.ensureTillerCanManageNamespace(env.Spec.Namespace)
+	if err != nil {
+		return err
+	}
+	err = o.provisionDatabases(o.projectDir(), o.Application, ns)
+	if err != nil {
+		return err
+	}
 
 	if o.ReleaseName == "" {
 		o.ReleaseName = o.Namespace
@@ -679,6 +689,10 @@ func (o *PreviewOptions) defaultValues(ns string, warnMissingName bool) error {
 		}
 	}
 
+	if o.MonorepoAppDir == "" {
+		o.MonorepoAppDir = os.Getenv("APP_DIR")
+	}
+
 	// fill in default values
 	if o.SourceURL == "" {
 		o.SourceURL = os.Getenv("SOURCE_URL")
@@ -696,6 +710,9 @@ func (o *PreviewOptions) defaultValues(ns string, warnMissingName bool) error {
 				log.Warnf("Could not find a .git directory: %s\n", err)
 			} else {
 				if root != "" {
+					if o.MonorepoAppDir == "" {
+						o.MonorepoAppDir = monorepoAppDir(root, o.Dir)
+					}
 					o.Dir = root
 					o.SourceURL, err = o.discoverGitURL(gitConf)
 					if err != nil {
@@ -740,10 +757,18 @@ func (o *PreviewOptions) defaultValues(ns string, warnMissingName bool) error {
 				}
 			}
 			if o.Name == "" && o.PullRequestName != "" {
-				o.Name = o.GitInfo.Organisation + "-" + o.GitInfo.Name + "-pr-" + o.PullRequestName
+				if o.MonorepoAppDir != "" {
+					o.Name = o.GitInfo.Organisation + "-" + o.GitInfo.Name + "-" + o.Application + "-pr-" + o.PullRequestName
+				} else {
+					o.Name = o.GitInfo.Organisation + "-" + o.GitInfo.Name + "-pr-" + o.PullRequestName
+				}
 			}
 			if o.Label == "" {
-				o.Label = o.GitInfo.Organisation + "/" + o.GitInfo.Name + " PR-" + o.PullRequestName
+				if o.MonorepoAppDir != "" {
+					o.Label = o.GitInfo.Organisation + "/" + o.GitInfo.Name + "/" + o.MonorepoAppDir + " PR-" + o.PullRequestName
+				} else {
+					o.Label = o.GitInfo.Organisation + "/" + o.GitInfo.Name + " PR-" + o.PullRequestName
+				}
 			}
 		}
 	}
@@ -764,6 +789,16 @@ func (o *PreviewOptions) defaultValues(ns string, warnMissingName bool) error {
 	return nil
 }
 
+// monorepoAppDir returns the relative directory of the application if the dir is inside a sub directory
+// of the git repository root. Preview environments are then created per application of a monorepo
+func monorepoAppDir(root string, dir string) string {
+	rel, err := filepath.Rel(root, dir)
+	if err != nil || rel == "." || strings.HasPrefix(rel, "..") {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
 func writePreviewURL(o *PreviewOptions, url string) {
 	previewFileName := filepath.Join(o.Dir, ".previewUrl")
 	err := ioutil.WriteFile(previewFileName, []byte(url), 0644)
@@ -823,3 +858,15 @@ func getImageTag() (string, error) {
 
 	return tag, nil
 }
+
+// projectDir returns the directory of the application being previewed which contains its jenkins-x.yml file
+func (o *PreviewOptions) projectDir() string {
+	dir := o.Dir
+	if dir == "" {
+		dir, _ = os.Getwd()
+	}
+	if o.MonorepoAppDir != "" {
+		return filepath.Join(dir, o.MonorepoAppDir)
+	}
+	return dir
+}
@@ -11,7 +11,10 @@ import (
 
 	"github.com/blang/semver"
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/attest"
+	"github.com/jenkins-x/jx/pkg/autoscaling"
 	typev1 "github.com/jenkins-x/jx/pkg/client/clientset/versioned/typed/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/cve"
 	"github.com/jenkins-x/jx/pkg/gits"
 	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
@@ -59,12 +62,21 @@ type PromoteOptions struct {
 	PullRequestPollTime string
 	Filter              string
 	Alias               string
+	AttestationFile     string
+	Image               string
+	SecretScan          SecretScanOptions
+	NoRollback          bool
+	NoQueue             bool
+	NoBatch             bool
+	SmokeTest           SmokeTestOptions
+	HealthCheck         HealthCheckOptions
 
 	// allow git to be configured externally before a PR is created
 	ConfigureGitCallback ConfigureGitFolderFn
 
 	// for testing
 	FakePullRequests CreateEnvPullRequestFn
+	ImageScanner     cve.ImageScanner
 
 	// calculated fields
 	TimeoutDuration         *time.Duration
@@ -74,12 +86,16 @@ type PromoteOptions struct {
 	jenkinsURL              string
 	releaseResource         *v1.Release
 	ReleaseInfo             *ReleaseInfo
+	queuedPromotion         *v1.Promotion
+	batchedPromotions       []*v1.Promotion
+	stopPromotionLease      chan struct{}
 }
 
 type ReleaseInfo struct {
 	ReleaseName     string
 	FullAppName     string
 	Version         string
+	PreviousVersion string
 	PullRequestInfo *ReleasePullRequestInfo
 }
 
@@ -112,6 +128,18 @@ var (
 		# To promote a postgres chart using an alias
 		jx promote -f postgres --alias mydb
 
+		# Promote a version to staging running the helm test hooks of the chart and rolling back if they fail
+		jx promote myapp --version 1.2.3 --env staging --smoke-test-helm
+
+		# Promote a version to production running a smoke test image against it
+		jx promote myapp --version 1.2.3 --env production --smoke-test-image myorg/myapp-smoke-tests:1.0
+
+		# Promote a version to production rolling back if it does not roll out or its health URL fails
+		jx promote myapp --version 1.2.3 --env production --health-check --health-url http://myapp.example.com/health
+
+		# Promote a version to production without waiting for other promotions to production in the queue
+		jx promote myapp --version 1.2.3 --env production --no-queue
+
 		# To create or update a Preview Environment please see the 'jx preview' command
 		jx preview
 	`)
@@ -146,6 +174,12 @@ func NewCmdPromote(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.Flags().BoolVarP(&options.AllAutomatic, "all-auto", "", false, "Promote to all automatic environments in order")
 
 	options.addPromoteOptions(cmd)
+	options.SecretScan.addSecretScanFlags(cmd)
+	options.SmokeTest.addSmokeTestFlags(cmd)
+	options.HealthCheck.addHealthCheckFlags(cmd)
+	cmd.Flags().BoolVarP(&options.NoRollback, "no-rollback", "", false, "Disables rolling back to the previous version if the health checks or smoke tests fail")
+	cmd.Flags().BoolVarP(&options.NoQueue, "no-queue", "", false, "Disables waiting for other promotions to the Environment to complete before creating the Pull Request")
+	cmd.Flags().BoolVarP(&options.NoBatch, "no-batch", "", false, "Disables including the promotions of other apps waiting in the queue of the Environment in the Pull Request")
 	return cmd
 }
 
@@ -166,6 +200,8 @@ func (options *PromoteOptions) addPromoteOptions(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&options.NoPoll, "no-poll", "", false, "Disables polling for Pull Request or Pipeline status")
 	cmd.Flags().BoolVarP(&options.NoWaitAfterMerge, "no-wait", "", false, "Disables waiting for completing promotion after the Pull request is merged")
 	cmd.Flags().BoolVarP(&options.IgnoreLocalFiles, "ignore-local-file", "", false, "Ignores the local file system when deducing the git repository")
+	cmd.Flags().StringVarP(&options.Image, "image", "", "", "The image of the app which is scanned for vulnerabilities if the team has a vulnerability policy. Defaults to the image in the attestation file or $DOCKER_REGISTRY/$ORG/$APP_NAME:$VERSION")
+	cmd.Flags().StringVarP(&options.AttestationFile, "attestation", "", attest.AttestationFileName, "The file generated by 'jx step attest' whose image digests are recorded in the environment repository if it exists")
 }
 
 // Run implements this command
@@ -232,7 +268,7 @@ func (o *PromoteOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	apisClient, err := o.Factory.CreateApiExtensionsClient()
+	apisClient, err := o.CreateApiExtensionsClient()
 	if err != nil {
 		return err
 	}
@@ -276,17 +312,26 @@ func (o *PromoteOptions) Run() error {
 			return fmt.Errorf("Could not find an Environment called %s", o.Environment)
 		}
 	}
+	err = o.enqueuePromotion(env)
+	if err != nil {
+		return err
+	}
 	releaseInfo, err := o.Promote(targetNS, env, true)
 	if err != nil {
+		o.completeQueuedPromotion(releaseInfo, err)
 		return err
 	}
 	o.ReleaseInfo = releaseInfo
 	if !o.NoPoll {
 		err = o.WaitForPromotion(targetNS, env, releaseInfo)
-		if err != nil {
-			return err
+		if err == nil {
+			err = o.runHealthChecks(targetNS, env, releaseInfo)
+		}
+		if err == nil {
+			err = o.runSmokeTests(targetNS, env, releaseInfo)
 		}
 	}
+	o.completeQueuedPromotion(releaseInfo, err)
 	return err
 }
 
@@ -322,12 +367,22 @@ func (o *PromoteOptions) PromoteAllAutomatic() error {
 			if ns == "" {
 				return fmt.Errorf("No namespace for environment %s", env.Name)
 			}
-			releaseInfo, err := o.Promote(ns, &env, false)
+			err := o.enqueuePromotion(&env)
 			if err != nil {
 				return err
 			}
+			releaseInfo, err := o.Promote(ns, &env, false)
 			o.ReleaseInfo = releaseInfo
-			err = o.WaitForPromotion(ns, &env, releaseInfo)
+			if err == nil {
+				err = o.WaitForPromotion(ns, &env, releaseInfo)
+			}
+			if err == nil {
+				err = o.runHealthChecks(ns, &env, releaseInfo)
+			}
+			if err == nil {
+				err = o.runSmokeTests(ns, &env, releaseInfo)
+			}
+			o.completeQueuedPromotion(releaseInfo, err)
 			if err != nil {
 				return err
 			}
@@ -358,11 +413,18 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		releaseName = targetNS + "-" + app
 		o.ReleaseName = releaseName
 	}
+	err := o.waitForEnvironmentUnlock(env)
+	if err != nil {
+		return nil, err
+	}
 	releaseInfo := &ReleaseInfo{
 		ReleaseName: releaseName,
 		FullAppName: fullAppName,
 		Version:     version,
 	}
+	if o.SmokeTest.Enabled() || o.HealthCheck.Enabled() {
+		releaseInfo.PreviousVersion = o.deployedAppVersion(targetNS, app)
+	}
 
 	if warnIfAuto && env != nil && env.Spec.PromotionStrategy == v1.PromotionStrategyTypeAutomatic && !o.BatchMode {
 		log.Infof("%s", util.ColorWarning(fmt.Sprintf("WARNING: The Environment %s is setup to promote automatically as part of the CI/CD Pipelines.\n\n", env.Name)))
@@ -372,7 +434,7 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 			Default: false,
 		}
 		flag := false
-		err := survey.AskOne(confirm, &flag, nil)
+		err := util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return releaseInfo, err
 		}
@@ -381,11 +443,20 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 		}
 	}
 
+	err = o.checkPromotionVulnerabilities(env, app, version)
+	if err != nil {
+		return releaseInfo, err
+	}
+
 	promoteKey := o.createPromoteKey(env)
 	if env != nil {
 		source := &env.Spec.Source
 		if source.URL != "" && env.Spec.Kind.IsPermanent() {
-			err := o.PromoteViaPullRequest(env, releaseInfo)
+			completed, err := o.waitForPromotionQueue(env)
+			if err != nil || completed {
+				return releaseInfo, err
+			}
+			err = o.PromoteViaPullRequest(env, releaseInfo)
 			if err == nil {
 				startPromotePR := func(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromotePullRequestStep) error {
 					kube.StartPromotionPullRequest(a, s, ps, p)
@@ -408,7 +479,16 @@ func (o *PromoteOptions) Promote(targetNS string, env *v1.Environment, warnIfAut
 			return releaseInfo, err
 		}
 	}
-	err := o.verifyHelmConfigured()
+	if kube.HasPromotionGates(env) {
+		gateStatus, err := kube.CheckPromotionGates(env, nil, nil, time.Now())
+		if err != nil {
+			return releaseInfo, err
+		}
+		if !gateStatus.Open {
+			return releaseInfo, fmt.Errorf("cannot promote to environment %s: %s", env.Name, strings.Join(gateStatus.Reasons, ", "))
+		}
+	}
+	err = o.verifyHelmConfigured()
 	if err != nil {
 		return releaseInfo, err
 	}
@@ -456,6 +536,12 @@ func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo
 
 	title := app + " to " + versionName
 	message := fmt.Sprintf("Promote %s to version %s", app, versionName)
+	if len(o.batchedPromotions) > 0 {
+		title += fmt.Sprintf(" and %d other apps", len(o.batchedPromotions))
+		for _, p := range o.batchedPromotions {
+			message += fmt.Sprintf("\nPromote %s to version %s", p.Spec.Application, p.Spec.Version)
+		}
+	}
 
 	modifyRequirementsFn := func(requirements *helm.Requirements) error {
 		var err error
@@ -466,6 +552,9 @@ func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo
 			}
 		}
 		requirements.SetAppVersion(app, version, o.HelmRepositoryURL, o.Alias)
+		for _, p := range o.batchedPromotions {
+			requirements.SetAppVersion(p.Spec.Application, p.Spec.Version, p.Spec.HelmRepositoryURL, p.Spec.Alias)
+		}
 		return nil
 	}
 	if o.FakePullRequests != nil {
@@ -473,12 +562,135 @@ func (o *PromoteOptions) PromoteViaPullRequest(env *v1.Environment, releaseInfo
 		releaseInfo.PullRequestInfo = info
 		return err
 	} else {
-		info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, branchNameText, title, message, releaseInfo.PullRequestInfo, o.ConfigureGitCallback)
+		configureGitFn := func(dir string, gitInfo *gits.GitRepositoryInfo, gitAdapter gits.Gitter) error {
+			if o.ConfigureGitCallback != nil {
+				err := o.ConfigureGitCallback(dir, gitInfo, gitAdapter)
+				if err != nil {
+					return err
+				}
+			}
+			return o.scanForSecrets(&o.SecretScan, dir, "the "+env.Name+" environment repository "+env.Spec.Source.URL)
+		}
+		attestationFn, err := o.recordAttestationFn(app, versionName)
+		if err != nil {
+			return err
+		}
+		autoscalingFn, err := o.autoscalingDefaultsFn(env, app)
+		if err != nil {
+			return err
+		}
+		modifyDirFns := []ModifyEnvironmentDirFn{attestationFn, autoscalingFn}
+		for _, p := range o.batchedPromotions {
+			fn, err := o.autoscalingDefaultsFn(env, p.Spec.Application)
+			if err != nil {
+				return err
+			}
+			modifyDirFns = append(modifyDirFns, fn)
+		}
+		modifyDirFn := chainModifyEnvironmentDirFns(modifyDirFns...)
+		info, err := o.createEnvironmentPullRequest(env, modifyRequirementsFn, modifyDirFn, branchNameText, title, message, releaseInfo.PullRequestInfo, configureGitFn)
 		releaseInfo.PullRequestInfo = info
 		return err
 	}
 }
 
+// checkPromotionVulnerabilities scans the image of the app if the team has a vulnerability policy for the environment
+func (o *PromoteOptions) checkPromotionVulnerabilities(env *v1.Environment, app string, version string) error {
+	if env == nil {
+		return nil
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	devEnv, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err != nil {
+		// without a dev environment there are no team settings
+		return nil
+	}
+	policy := devEnv.Spec.TeamSettings.VulnerabilityPolicy
+	if !kube.VulnerabilityPolicyApplies(policy, env) {
+		return nil
+	}
+	return o.checkVulnerabilityPolicy(policy, env, app, version, o.promotedImage(version), o.ImageScanner)
+}
+
+// promotedImage returns the image of the version being promoted
+func (o *PromoteOptions) promotedImage(version string) string {
+	if o.Image != "" {
+		return o.Image
+	}
+	if o.AttestationFile != "" {
+		exists, err := util.FileExists(o.AttestationFile)
+		if err == nil && exists {
+			attestation, err := attest.LoadImageAttestation(o.AttestationFile)
+			if err == nil && (version == "" || attestation.Version == "" || attestation.Version == version) {
+				return attestation.ImageReference()
+			}
+		}
+	}
+	if version == "" {
+		return ""
+	}
+	repository := defaultImageRepository()
+	if repository == "" {
+		return ""
+	}
+	return repository + ":" + version
+}
+
+// recordAttestationFn returns a function which records the attestation of the release in the environment repository
+// or nil if the release has not been attested
+func (o *PromoteOptions) recordAttestationFn(app string, version string) (ModifyEnvironmentDirFn, error) {
+	if o.AttestationFile == "" {
+		return nil, nil
+	}
+	exists, err := util.FileExists(o.AttestationFile)
+	if err != nil || !exists {
+		return nil, err
+	}
+	attestation, err := attest.LoadImageAttestation(o.AttestationFile)
+	if err != nil {
+		return nil, err
+	}
+	if attestation.Version != "" && version != "latest" && attestation.Version != version {
+		log.Warnf("Ignoring the attestation %s as it is for version %s rather than %s\n", o.AttestationFile, attestation.Version, version)
+		return nil, nil
+	}
+	attestation.App = app
+	return func(dir string) error {
+		fileName := filepath.Join(dir, attest.EnvironmentAttestationsFileName)
+		attestations, err := attest.LoadAttestations(fileName)
+		if err != nil {
+			return err
+		}
+		attestations.SetApp(attestation)
+		return attest.SaveAttestations(fileName, attestations)
+	}, nil
+}
+
+// autoscalingDefaultsFn returns a function which adds the autoscaling defaults of the team to the values of the app
+// in the environment if it has no autoscaling values yet or nil if the team has no defaults for the environment
+func (o *PromoteOptions) autoscalingDefaultsFn(env *v1.Environment, app string) (ModifyEnvironmentDirFn, error) {
+	settings, err := o.TeamSettings()
+	if err != nil {
+		return nil, err
+	}
+	defaults := settings.AutoscalingDefaults
+	if !autoscaling.DefaultsApply(defaults, env) {
+		return nil, nil
+	}
+	return func(dir string) error {
+		valuesFile := filepath.Join(dir, "values.yaml")
+		values, err := autoscaling.GetValues(valuesFile, app)
+		if err != nil || values != nil {
+			return err
+		}
+		log.Infof("Adding the default autoscaling values of the team for app %s in environment %s\n", util.ColorInfo(app), util.ColorInfo(env.Name))
+		return autoscaling.SetValues(valuesFile, app, autoscaling.NewValues(defaults))
+	}, nil
+}
+
 func (o *PromoteOptions) GetTargetNamespace(ns string, env string) (string, *v1.Environment, error) {
 	kubeClient, currentNs, err := o.KubeClient()
 	if err != nil {
@@ -560,6 +772,7 @@ func (o *PromoteOptions) waitForGitOpsPullRequest(ns string, env *v1.Environment
 	logHasMergeSha := false
 	logMergeStatusError := false
 	logNoMergeStatuses := false
+	logGateStatus := ""
 	urlStatusMap := map[string]string{}
 	urlStatusTargetURLMap := map[string]string{}
 
@@ -681,11 +894,17 @@ func (o *PromoteOptions) waitForGitOpsPullRequest(ns string, env *v1.Environment
 					} else {
 						if status == "success" {
 							if !o.NoMergePullRequest {
-								err = gitProvider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
+								gatesOpen, err := o.checkPromotionGates(env, pr, gitProvider, &logGateStatus)
 								if err != nil {
-									if !logMergeFailure {
-										logMergeFailure = true
-										log.Warnf("Failed to merge the Pull Request %s due to %s maybe I don't have karma?\n", pr.URL, err)
+									return err
+								}
+								if gatesOpen {
+									err = gitProvider.MergePullRequest(pr, "jx promote automatically merged promotion PR")
+									if err != nil {
+										if !logMergeFailure {
+											logMergeFailure = true
+											log.Warnf("Failed to merge the Pull Request %s due to %s maybe I don't have karma?\n", pr.URL, err)
+										}
 									}
 								}
 							}
@@ -710,6 +929,70 @@ func (o *PromoteOptions) waitForGitOpsPullRequest(ns string, env *v1.Environment
 	return nil
 }
 
+// waitForEnvironmentUnlock rejects promotions to a locked environment unless the lock queues promotions in which
+// case it waits for the environment to be unlocked or for the lock to expire
+func (o *PromoteOptions) waitForEnvironmentUnlock(env *v1.Environment) error {
+	if !kube.IsEnvironmentLocked(env, time.Now()) {
+		return nil
+	}
+	message := kube.EnvironmentLockMessage(env)
+	if !env.Spec.Lock.Queue {
+		return fmt.Errorf("cannot promote to %s", message)
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	if env.Namespace != "" {
+		ns = env.Namespace
+	}
+	duration := time.Hour
+	if o.TimeoutDuration != nil {
+		duration = *o.TimeoutDuration
+	}
+	log.Infof("Waiting up to %s to promote as %s\n", duration.String(), util.ColorWarning(message))
+	unlocked := func() (bool, error) {
+		current, err := jxClient.JenkinsV1().Environments(ns).Get(env.Name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		env.Spec.Lock = current.Spec.Lock
+		return !kube.IsEnvironmentLocked(env, time.Now()), nil
+	}
+	err = o.retryUntilTrueOrTimeout(duration, 10*time.Second, unlocked)
+	if err != nil {
+		return fmt.Errorf("timed out waiting to promote as %s", kube.EnvironmentLockMessage(env))
+	}
+	log.Infof("Environment %s is unlocked\n", util.ColorInfo(env.Name))
+	return nil
+}
+
+// checkPromotionGates returns true if the promotion gates of the environment allow the Pull Request to be merged.
+// The reasons the promotion is blocked are logged whenever they change
+func (o *PromoteOptions) checkPromotionGates(env *v1.Environment, pr *gits.GitPullRequest, gitProvider gits.GitProvider, logStatus *string) (bool, error) {
+	if !kube.HasPromotionGates(env) {
+		return true, nil
+	}
+	status, err := kube.CheckPromotionGates(env, pr, gitProvider, time.Now())
+	if err != nil {
+		return false, err
+	}
+	message := ""
+	if status.Open {
+		message = fmt.Sprintf("Promotion gates of environment %s are open", env.Name)
+		if len(status.Approvers) > 0 {
+			message += fmt.Sprintf(" approved by %s", strings.Join(status.Approvers, ", "))
+		}
+	} else {
+		message = fmt.Sprintf("Waiting to merge Pull Request %s as the promotion to environment %s is %s", pr.URL, env.Name, strings.Join(status.Reasons, ", "))
+	}
+	if message != *logStatus {
+		*logStatus = message
+		log.Infof("%s\n", message)
+	}
+	return status.Open, nil
+}
+
 func (o *PromoteOptions) findLatestVersion(app string) (string, error) {
 	versions, err := o.Helm().SearchChartVersions(app)
 	if err != nil {
@@ -947,7 +1230,7 @@ func (o *CommonOptions) getLatestPipelineBuild(pipeline string) (string, string,
 	}
 	devEnv, err := kube.GetEnrichedDevEnvironment(kubeClient, jxClient, ns)
 	webhookEngine := devEnv.Spec.WebHookEngine
-	if webhookEngine == v1.WebHookEngineProw {
+	if webhookEngine == v1.WebHookEngineProw || webhookEngine == v1.WebHookEngineLighthouse {
 		return pipeline, build, nil
 	}
 
@@ -0,0 +1,198 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+const (
+	optionHealthTimeout = "health-timeout"
+)
+
+// HealthCheckOptions the options for checking the health of an app after it is promoted
+type HealthCheckOptions struct {
+	Rollout     bool
+	URL         string
+	Timeout     string
+	MaxRestarts int32
+}
+
+func (o *HealthCheckOptions) addHealthCheckFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.Rollout, "health-check", "", false, "Checks the deployment of the app rolls out without crash looping or restarting containers after the promotion completes")
+	cmd.Flags().StringVarP(&o.URL, "health-url", "", "", "A URL of the app which must respond successfully after the promotion completes")
+	cmd.Flags().StringVarP(&o.Timeout, optionHealthTimeout, "", "5m", "The timeout of the health checks")
+	cmd.Flags().Int32VarP(&o.MaxRestarts, "max-restarts", "", 3, "The number of times a container of the app can restart before the app is considered unhealthy")
+}
+
+// Enabled returns true if any health checks are configured
+func (o *HealthCheckOptions) Enabled() bool {
+	return o.Rollout || o.URL != ""
+}
+
+// runHealthChecks checks the health of the promoted app. If it is unhealthy the promotion is failed and rolled back
+// to the previously deployed version
+func (o *PromoteOptions) runHealthChecks(ns string, env *v1.Environment, releaseInfo *ReleaseInfo) error {
+	if !o.HealthCheck.Enabled() || releaseInfo == nil {
+		return nil
+	}
+	if o.NoWaitAfterMerge && releaseInfo.PullRequestInfo != nil {
+		log.Warnf("Not running the health checks as the promotion was not awaited\n")
+		return nil
+	}
+	timeout, err := time.ParseDuration(o.HealthCheck.Timeout)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.HealthCheck.Timeout, optionHealthTimeout, err)
+	}
+	app := o.Application
+	failures := []string{}
+	if o.HealthCheck.Rollout {
+		log.Infof("Checking the health of app %s in namespace %s\n", util.ColorInfo(app), util.ColorInfo(ns))
+		kubeClient, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		health, err := kube.WaitForAppHealth(kubeClient, ns, app, o.HealthCheck.MaxRestarts, timeout, 5*time.Second)
+		if err != nil {
+			failures = append(failures, err.Error())
+		} else {
+			failures = append(failures, health.Failures...)
+		}
+	}
+	if o.HealthCheck.URL != "" && len(failures) == 0 {
+		err = o.waitForHealthURL(o.HealthCheck.URL, timeout)
+		if err != nil {
+			failures = append(failures, err.Error())
+		}
+	}
+	if len(failures) == 0 {
+		log.Infof("App %s is healthy in namespace %s\n", util.ColorInfo(app), util.ColorInfo(ns))
+		return nil
+	}
+	for _, failure := range failures {
+		log.Warnf("%s\n", failure)
+	}
+	if env != nil {
+		err = o.createPromoteKey(env).OnPromoteUpdate(o.Activities, kube.FailedPromotionUpdate)
+		if err != nil {
+			log.Warnf("Failed to update PipelineActivity: %s\n", err)
+		}
+	}
+	_, err = o.failPromotion(ns, env, releaseInfo, "health checks", failures)
+	return err
+}
+
+// waitForHealthURL waits for the URL to respond with a successful status
+func (o *PromoteOptions) waitForHealthURL(u string, timeout time.Duration) error {
+	log.Infof("Waiting for %s to respond successfully\n", util.ColorInfo(u))
+	client := httpclient.NewClient(nil)
+	client.Timeout = 30 * time.Second
+	var lastErr error
+	fn := func() (bool, error) {
+		resp, err := client.Get(u)
+		if err != nil {
+			lastErr = err
+			return false, nil
+		}
+		resp.Body.Close()
+		if resp.StatusCode >= 400 {
+			lastErr = fmt.Errorf("status %s", resp.Status)
+			return false, nil
+		}
+		return true, nil
+	}
+	err := o.retryUntilTrueOrTimeout(timeout, 5*time.Second, fn)
+	if err != nil && lastErr != nil {
+		return fmt.Errorf("the health URL %s did not respond successfully within %s: %s", u, timeout, lastErr)
+	}
+	return err
+}
+
+// failPromotion fails the promotion which did not pass the checks. Unless rollback is disabled the app is rolled
+// back to its previous version. The failures are explained on the promotion Pull Request if there is one. It returns
+// the version rolled back to, if any, and the error failing the promotion
+func (o *PromoteOptions) failPromotion(ns string, env *v1.Environment, releaseInfo *ReleaseInfo, checks string, failures []string) (string, error) {
+	version := releaseInfo.Version
+	if version == "" {
+		version = "latest"
+	}
+	err := fmt.Errorf("the %s of app %s version %s failed in namespace %s", checks, o.Application, version, ns)
+	rollbackVersion := ""
+	outcome := ""
+	if o.NoRollback {
+		outcome = "The app was not rolled back as rollback is disabled."
+	} else {
+		rollbackErr := o.rollbackPromotion(ns, env, releaseInfo)
+		if rollbackErr != nil {
+			outcome = fmt.Sprintf("Failed to roll back the app: %s", rollbackErr)
+			err = fmt.Errorf("%s and failed to roll back: %s", err, rollbackErr)
+		} else {
+			rollbackVersion = releaseInfo.PreviousVersion
+			description := "the previous revision"
+			if rollbackVersion != "" {
+				description = "version " + rollbackVersion
+			}
+			outcome = fmt.Sprintf("The app was rolled back to %s.", description)
+			err = fmt.Errorf("%s so rolled back to %s", err, description)
+		}
+	}
+
+	envName := ns
+	if env != nil {
+		envName = env.Name
+	}
+	comment := fmt.Sprintf(":warning: The promotion of **%s** version **%s** to the **%s** environment failed the %s:\n\n", o.Application, version, envName, checks)
+	for _, failure := range failures {
+		comment += "* " + failure + "\n"
+	}
+	comment += "\n" + outcome
+	o.commentOnPromotionPullRequest(releaseInfo, comment)
+	return rollbackVersion, err
+}
+
+// rollbackPromotion rolls back the app to the previously deployed version. A GitOps environment is rolled back via
+// a Pull Request promoting the previous version otherwise the helm release is rolled back to its previous revision
+func (o *PromoteOptions) rollbackPromotion(ns string, env *v1.Environment, releaseInfo *ReleaseInfo) error {
+	previous := releaseInfo.PreviousVersion
+	if env != nil && env.Spec.Source.URL != "" && env.Spec.Kind.IsPermanent() {
+		if previous == "" {
+			return fmt.Errorf("no previous version of app %s was deployed in namespace %s", o.Application, ns)
+		}
+		log.Infof("Rolling back app %s in namespace %s to version %s\n", util.ColorInfo(o.Application), util.ColorInfo(ns), util.ColorInfo(previous))
+		version := o.Version
+		o.Version = previous
+		defer func() {
+			o.Version = version
+		}()
+		rollbackInfo := &ReleaseInfo{
+			ReleaseName: releaseInfo.ReleaseName,
+			FullAppName: releaseInfo.FullAppName,
+			Version:     previous,
+		}
+		err := o.PromoteViaPullRequest(env, rollbackInfo)
+		if err != nil {
+			return err
+		}
+		return o.WaitForPromotion(ns, env, rollbackInfo)
+	}
+	log.Infof("Rolling back release %s in namespace %s to its previous revision\n", util.ColorInfo(releaseInfo.ReleaseName), util.ColorInfo(ns))
+	return o.Helm().RollbackRelease(releaseInfo.ReleaseName, 0)
+}
+
+// commentOnPromotionPullRequest adds the comment to the Pull Request of the promotion if there is one
+func (o *PromoteOptions) commentOnPromotionPullRequest(releaseInfo *ReleaseInfo, comment string) {
+	prInfo := releaseInfo.PullRequestInfo
+	if prInfo == nil || prInfo.PullRequest == nil || prInfo.GitProvider == nil {
+		return
+	}
+	err := prInfo.GitProvider.AddPRComment(prInfo.PullRequest, comment)
+	if err != nil {
+		log.Warnf("Failed to comment on Pull Request %s: %s\n", prInfo.PullRequest.URL, err)
+	}
+}
@@ -0,0 +1,224 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// promotionLeaseDuration the time after which a queued promotion leaves the queue unless its lease is renewed
+	promotionLeaseDuration = 2 * time.Minute
+)
+
+// promotionQueueTimeout returns how long a promotion waits in the queue of an environment
+func (o *PromoteOptions) promotionQueueTimeout() time.Duration {
+	if o.TimeoutDuration != nil {
+		return *o.TimeoutDuration
+	}
+	return time.Hour
+}
+
+// enqueuePromotion adds the promotion to the queue of the environment if the environment is promoted via Pull
+// Requests on its git repository so that concurrent promotions do not race on the repository and helm release
+func (o *PromoteOptions) enqueuePromotion(env *v1.Environment) error {
+	o.queuedPromotion = nil
+	o.batchedPromotions = nil
+	if o.NoQueue || env == nil || env.Spec.Source.URL == "" || !env.Spec.Kind.IsPermanent() {
+		return nil
+	}
+	apisClient, err := o.CreateApiExtensionsClient()
+	if err != nil {
+		return err
+	}
+	err = kube.RegisterPromotionCRD(apisClient)
+	if err != nil {
+		return errors.Wrap(err, "failed to register the Promotion CRD")
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	spec := v1.PromotionSpec{
+		Environment:       env.Name,
+		Application:       o.Application,
+		Version:           o.Version,
+		Alias:             o.Alias,
+		HelmRepositoryURL: o.HelmRepositoryURL,
+		Pipeline:          o.Pipeline,
+		Build:             o.Build,
+	}
+	// the lease of the promotion is renewed until it completes so that a killed promotion soon leaves the queue
+	now := time.Now()
+	promotion := kube.NewPromotion(spec, now, promotionLeaseDuration)
+	o.queuedPromotion, err = kube.EnqueuePromotion(jxClient, ns, promotion, now)
+	if err != nil {
+		return err
+	}
+	o.stopPromotionLease = o.renewPromotionLease(jxClient, ns, o.queuedPromotion.Name)
+	return nil
+}
+
+// renewPromotionLease renews the lease of the promotion until the returned channel is closed
+func (o *PromoteOptions) renewPromotionLease(jxClient versioned.Interface, ns string, name string) chan struct{} {
+	stop := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(promotionLeaseDuration / 4)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				err := kube.RenewPromotion(jxClient, ns, name, time.Now(), promotionLeaseDuration)
+				if err != nil {
+					log.Warnf("Failed to renew the lease of promotion %s: %s\n", name, err)
+				}
+			}
+		}
+	}()
+	return stop
+}
+
+// waitForPromotionQueue waits for the promotion to reach the head of the queue of the environment and then batches
+// the compatible promotions queued behind it into its Pull Request. Returns true if the promotion has instead
+// been completed by the Pull Request of another promotion it was batched into
+func (o *PromoteOptions) waitForPromotionQueue(env *v1.Environment) (bool, error) {
+	if o.queuedPromotion == nil {
+		return false, nil
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return false, err
+	}
+	name := o.queuedPromotion.Name
+	completed := false
+	lastPosition := -1
+	lastBatchedInto := ""
+	var queue []*v1.Promotion
+	atHead := func() (bool, error) {
+		list, err := jxClient.JenkinsV1().Promotions(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		var promotion *v1.Promotion
+		for i := range list.Items {
+			if list.Items[i].Name == name {
+				promotion = &list.Items[i]
+			}
+		}
+		if promotion == nil {
+			return false, fmt.Errorf("promotion %s was removed from the queue", name)
+		}
+		o.queuedPromotion = promotion
+		switch promotion.Status.Phase {
+		case v1.PromotionPhaseSucceeded:
+			completed = true
+			return true, nil
+		case v1.PromotionPhaseFailed:
+			return false, fmt.Errorf("promotion %s failed: %s", promotion.Status.BatchedInto, promotion.Status.Message)
+		case v1.PromotionPhaseBatched:
+			if promotion.Status.BatchedInto != lastBatchedInto {
+				lastBatchedInto = promotion.Status.BatchedInto
+				log.Infof("Promoting as part of the Pull Request of promotion %s\n", util.ColorInfo(lastBatchedInto))
+			}
+			return false, nil
+		}
+		queue = kube.PromotionQueue(list.Items, env.Name, time.Now())
+		position := kube.PromotionQueuePosition(queue, name)
+		if position < 0 {
+			return false, fmt.Errorf("promotion %s expired", name)
+		}
+		if position > 0 && position != lastPosition {
+			lastPosition = position
+			head := queue[0].Spec
+			log.Infof("Promotion %s is at position %s in the queue of environment %s behind the promotion of %s version %s\n",
+				util.ColorInfo(name), util.ColorInfo(position), util.ColorInfo(env.Name), util.ColorInfo(head.Application), util.ColorInfo(head.Version))
+		}
+		return position == 0, nil
+	}
+	err = o.retryUntilTrueOrTimeout(o.promotionQueueTimeout(), 10*time.Second, atHead)
+	if err != nil {
+		return false, errors.Wrapf(err, "waiting in the promotion queue of environment %s", env.Name)
+	}
+	if completed {
+		log.Infof("Promoted by the Pull Request %s\n", util.ColorInfo(o.queuedPromotion.Status.PullRequestURL))
+		return true, nil
+	}
+	o.queuedPromotion, err = kube.UpdatePromotionPhase(jxClient, ns, o.queuedPromotion, v1.PromotionPhasePromoting, "")
+	if err != nil {
+		return false, err
+	}
+	if o.NoBatch {
+		return false, nil
+	}
+	for _, promotion := range kube.BatchPromotions(queue) {
+		promotion.Status.BatchedInto = name
+		batched, err := kube.UpdatePromotionPhase(jxClient, ns, promotion, v1.PromotionPhaseBatched, "")
+		if err != nil {
+			// the promotion may have left the queue in the meantime so lets leave it to promote itself
+			log.Warnf("Failed to batch promotion %s: %s\n", promotion.Name, err)
+			continue
+		}
+		log.Infof("Including app %s version %s in the Pull Request\n", util.ColorInfo(batched.Spec.Application), util.ColorInfo(batched.Spec.Version))
+		o.batchedPromotions = append(o.batchedPromotions, batched)
+	}
+	return false, nil
+}
+
+// completeQueuedPromotion marks the promotion and any promotions batched into its Pull Request as succeeded or
+// failed so that the next promotion in the queue of the environment can start. A promotion which is still queued
+// failed or was cancelled before it reached the head of the queue
+func (o *PromoteOptions) completeQueuedPromotion(releaseInfo *ReleaseInfo, promoteErr error) {
+	promotion := o.queuedPromotion
+	batched := o.batchedPromotions
+	o.queuedPromotion = nil
+	o.batchedPromotions = nil
+	if o.stopPromotionLease != nil {
+		close(o.stopPromotionLease)
+		o.stopPromotionLease = nil
+	}
+	if promotion == nil {
+		return
+	}
+	phase := v1.PromotionPhaseSucceeded
+	message := ""
+	if promoteErr != nil {
+		phase = v1.PromotionPhaseFailed
+		message = promoteErr.Error()
+	}
+	switch promotion.Status.Phase {
+	case v1.PromotionPhasePromoting:
+	case v1.PromotionPhaseQueued:
+		phase = v1.PromotionPhaseFailed
+		if promoteErr == nil {
+			message = "the promotion was cancelled before it started"
+		}
+		batched = nil
+	default:
+		return
+	}
+	prURL := ""
+	if releaseInfo != nil && releaseInfo.PullRequestInfo != nil && releaseInfo.PullRequestInfo.PullRequest != nil {
+		prURL = releaseInfo.PullRequestInfo.PullRequest.URL
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		log.Warnf("Failed to complete promotion %s: %s\n", promotion.Name, err)
+		return
+	}
+	for _, p := range append([]*v1.Promotion{promotion}, batched...) {
+		p.Status.PullRequestURL = prURL
+		_, err = kube.UpdatePromotionPhase(jxClient, ns, p, phase, message)
+		if err != nil {
+			log.Warnf("Failed to complete promotion %s: %s\n", p.Name, err)
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestCompleteQueuedPromotionFailsQueuedPromotion(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	promotion := kube.NewPromotion(v1.PromotionSpec{Environment: "production", Application: "cheese", Version: "1.0.0"}, now, promotionLeaseDuration)
+	promotion.Namespace = "jx"
+	o := &PromoteOptions{}
+	ConfigureTestOptionsWithResources(&o.CommonOptions, nil, []runtime.Object{promotion}, gits.NewGitCLI(), nil)
+	o.queuedPromotion = promotion.DeepCopy()
+	o.stopPromotionLease = make(chan struct{})
+
+	o.completeQueuedPromotion(nil, fmt.Errorf("app cheese has critical vulnerabilities"))
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	require.NoError(t, err)
+	updated, err := jxClient.JenkinsV1().Promotions(ns).Get(promotion.Name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, v1.PromotionPhaseFailed, updated.Status.Phase, "a promotion which fails while queued should leave the queue")
+	assert.Equal(t, "app cheese has critical vulnerabilities", updated.Status.Message)
+	assert.Nil(t, o.stopPromotionLease, "the lease should no longer be renewed")
+}
@@ -0,0 +1,249 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	optionSmokeTestTimeout = "smoke-test-timeout"
+)
+
+// SmokeTestOptions the options for running smoke tests against a version after it is promoted
+type SmokeTestOptions struct {
+	HelmTest bool
+	Image    string
+	Command  string
+	Timeout  string
+}
+
+func (o *SmokeTestOptions) addSmokeTestFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&o.HelmTest, "smoke-test-helm", "", false, "Runs the test hooks of the chart via 'helm test' after the promotion completes")
+	cmd.Flags().StringVarP(&o.Image, "smoke-test-image", "", "", "The image of a Job which is run in the namespace of the environment after the promotion completes")
+	cmd.Flags().StringVarP(&o.Command, "smoke-test-command", "", "", "The shell command run in the smoke test image. Defaults to the entrypoint of the image")
+	cmd.Flags().StringVarP(&o.Timeout, optionSmokeTestTimeout, "", "10m", "The timeout of the smoke tests")
+}
+
+// Enabled returns true if any smoke tests are configured
+func (o *SmokeTestOptions) Enabled() bool {
+	return o.HelmTest || o.Image != ""
+}
+
+// deployedAppVersion returns the version of the app currently deployed in the namespace so that a failed promotion
+// can be rolled back to it
+func (o *PromoteOptions) deployedAppVersion(ns string, app string) string {
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		log.Warnf("Failed to find the deployed version of app %s in namespace %s: %s\n", app, ns, err)
+		return ""
+	}
+	version, err := kube.DeployedAppVersion(kubeClient, ns, app)
+	if err != nil {
+		log.Warnf("Failed to find the deployed version of app %s in namespace %s: %s\n", app, ns, err)
+	}
+	return version
+}
+
+// runSmokeTests runs the smoke tests against the promoted version recording the results in the PipelineActivity.
+// If the tests fail the promotion is rolled back to the previously deployed version
+func (o *PromoteOptions) runSmokeTests(ns string, env *v1.Environment, releaseInfo *ReleaseInfo) error {
+	if !o.SmokeTest.Enabled() || releaseInfo == nil {
+		return nil
+	}
+	if o.NoWaitAfterMerge && releaseInfo.PullRequestInfo != nil {
+		log.Warnf("Not running the smoke tests as the promotion was not awaited\n")
+		return nil
+	}
+	timeout, err := time.ParseDuration(o.SmokeTest.Timeout)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.SmokeTest.Timeout, optionSmokeTestTimeout, err)
+	}
+	version := releaseInfo.Version
+	if version == "" {
+		version = "latest"
+	}
+	var promoteKey *kube.PromoteStepActivityKey
+	if env != nil {
+		promoteKey = o.createPromoteKey(env)
+	}
+	o.onPromoteSmokeTest(promoteKey, kube.StartPromotionSmokeTest)
+
+	results := []v1.SmokeTestResult{}
+	jobName := ""
+	if o.SmokeTest.HelmTest {
+		releaseName := releaseInfo.ReleaseName
+		if env != nil && env.Spec.Source.URL != "" && env.Spec.Kind.IsPermanent() {
+			// the apps of a GitOps environment are installed by the release of the environment chart
+			releaseName = ns
+		}
+		results = append(results, o.runHelmTests(releaseName, timeout)...)
+	}
+	if o.SmokeTest.Image != "" {
+		var result v1.SmokeTestResult
+		jobName, result = o.runSmokeTestJob(ns, env, version, timeout)
+		results = append(results, result)
+	}
+	setResults := func(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteSmokeTestStep) error {
+		p.Job = jobName
+		p.Results = results
+		return nil
+	}
+	o.onPromoteSmokeTest(promoteKey, setResults)
+
+	failed := []string{}
+	for _, result := range results {
+		if result.Status != v1.ActivityStatusTypeSucceeded {
+			failure := "test " + result.Name + " failed"
+			if result.Message != "" {
+				failure += ": " + result.Message
+			}
+			failed = append(failed, failure)
+		}
+	}
+	if len(failed) == 0 {
+		log.Infof("Smoke tests of app %s version %s passed\n", util.ColorInfo(o.Application), util.ColorInfo(version))
+		o.onPromoteSmokeTest(promoteKey, kube.CompletePromotionSmokeTest)
+		return nil
+	}
+	rollbackVersion, err := o.failPromotion(ns, env, releaseInfo, "smoke tests", failed)
+	rolledBack := func(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteSmokeTestStep) error {
+		p.RollbackVersion = rollbackVersion
+		return kube.FailedPromotionSmokeTest(a, s, ps, p)
+	}
+	o.onPromoteSmokeTest(promoteKey, rolledBack)
+	return err
+}
+
+// runHelmTests runs the test hooks of the release returning the result of each test
+func (o *PromoteOptions) runHelmTests(releaseName string, timeout time.Duration) []v1.SmokeTestResult {
+	log.Infof("Running the test hooks of release %s\n", util.ColorInfo(releaseName))
+	seconds := int(timeout.Seconds())
+	output, err := o.Helm().TestRelease(releaseName, true, &seconds)
+	results := []v1.SmokeTestResult{}
+	failed := false
+	for _, r := range helm.ParseReleaseTestResults(output) {
+		result := v1.SmokeTestResult{
+			Name:    r.Name,
+			Status:  v1.ActivityStatusTypeSucceeded,
+			Message: r.Message,
+		}
+		if !r.Passed {
+			result.Status = v1.ActivityStatusTypeFailed
+			failed = true
+		}
+		results = append(results, result)
+	}
+	if err != nil && !failed {
+		// the tests could not be run at all
+		results = append(results, v1.SmokeTestResult{
+			Name:    "helm test " + releaseName,
+			Status:  v1.ActivityStatusTypeError,
+			Message: err.Error(),
+		})
+	}
+	for _, result := range results {
+		if result.Status == v1.ActivityStatusTypeSucceeded {
+			log.Infof("Test %s passed\n", util.ColorInfo(result.Name))
+		} else {
+			log.Warnf("Test %s failed: %s\n", result.Name, result.Message)
+		}
+	}
+	return results
+}
+
+// runSmokeTestJob runs the smoke test image as a Job in the namespace of the environment and waits for it to finish
+func (o *PromoteOptions) runSmokeTestJob(ns string, env *v1.Environment, version string, timeout time.Duration) (string, v1.SmokeTestResult) {
+	envName := ""
+	if env != nil {
+		envName = env.Name
+	}
+	envVars := map[string]string{
+		"JX_APP":         o.Application,
+		"JX_VERSION":     version,
+		"JX_NAMESPACE":   ns,
+		"JX_ENVIRONMENT": envName,
+		"JX_PIPELINE":    o.Pipeline,
+		"JX_BUILD":       o.Build,
+	}
+	job := kube.SmokeTestJob("smoke-test-"+o.Application+"-"+version, o.SmokeTest.Image, o.SmokeTest.Command, envVars, timeout)
+	name := job.Name
+	result := v1.SmokeTestResult{
+		Name:   name,
+		Status: v1.ActivityStatusTypeError,
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		result.Message = err.Error()
+		return name, result
+	}
+	jobResources := kubeClient.BatchV1().Jobs(ns)
+
+	// lets remove the job of a previous promotion of the same version
+	gracePeriod := int64(0)
+	propagationPolicy := metav1.DeletePropagationForeground
+	err = jobResources.Delete(name, &metav1.DeleteOptions{
+		GracePeriodSeconds: &gracePeriod,
+		PropagationPolicy:  &propagationPolicy,
+	})
+	if err == nil {
+		jobGone := func() (bool, error) {
+			_, err := jobResources.Get(name, metav1.GetOptions{})
+			return err != nil, nil
+		}
+		o.retryUntilTrueOrTimeout(time.Minute, time.Second, jobGone)
+	}
+
+	log.Infof("Running the smoke test Job %s in namespace %s\n", util.ColorInfo(name), util.ColorInfo(ns))
+	_, err = jobResources.Create(job)
+	if err != nil {
+		result.Message = err.Error()
+		log.Warnf("Failed to create the smoke test Job %s in namespace %s: %s\n", name, ns, err)
+		return name, result
+	}
+	finished := func() (bool, error) {
+		current, err := jobResources.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return true, err
+		}
+		if !kube.IsJobFinished(current) {
+			return false, nil
+		}
+		if kube.IsJobSucceeded(current) {
+			result.Status = v1.ActivityStatusTypeSucceeded
+		} else {
+			result.Status = v1.ActivityStatusTypeFailed
+			result.Message = fmt.Sprintf("run `kubectl logs job/%s --namespace %s` for more info", name, ns)
+		}
+		return true, nil
+	}
+	err = o.retryUntilTrueOrTimeout(timeout, 2*time.Second, finished)
+	if err != nil {
+		result.Status = v1.ActivityStatusTypeFailed
+		result.Message = err.Error()
+	}
+	if result.Status == v1.ActivityStatusTypeSucceeded {
+		log.Infof("Smoke test Job %s passed\n", util.ColorInfo(name))
+	} else {
+		log.Warnf("Smoke test Job %s failed: %s\n", name, result.Message)
+	}
+	return name, result
+}
+
+// onPromoteSmokeTest updates the smoke test step of the PipelineActivity of the promotion if there is one
+func (o *PromoteOptions) onPromoteSmokeTest(promoteKey *kube.PromoteStepActivityKey, fn kube.PromoteSmokeTestFn) {
+	if promoteKey == nil {
+		return
+	}
+	err := promoteKey.OnPromoteSmokeTest(o.Activities, fn)
+	if err != nil {
+		log.Warnf("Failed to update PipelineActivity: %s\n", err)
+	}
+}
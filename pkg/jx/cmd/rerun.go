@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// Rerun contains the command line options
+type Rerun struct {
+	CommonOptions
+}
+
+var (
+	rerunLong = templates.LongDesc(`
+		Re-runs a process such as the pipeline of a Pull Request or commit.
+`)
+
+	rerunExample = templates.Examples(`
+		# Re-run the pipeline of a Pull Request
+		jx rerun pipeline myorg/myapp --pr 12
+
+		# Replay the webhook deliveries which the webhook engine failed to process
+		jx rerun hook-failures --all
+	`)
+)
+
+// NewCmdRerun creates the command object
+func NewCmdRerun(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &Rerun{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "rerun TYPE [flags]",
+		Short:   "Re-runs a process such as a pipeline",
+		Long:    rerunLong,
+		Example: rerunExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdRerunHookFailures(f, out, errOut))
+	cmd.AddCommand(NewCmdRerunPipeline(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *Rerun) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/hooks"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RerunHookFailuresOptions the options for the command
+type RerunHookFailuresOptions struct {
+	CommonOptions
+
+	Namespace string
+	HookURL   string
+	All       bool
+	Resign    bool
+}
+
+var (
+	rerunHookFailuresLong = templates.LongDesc(`
+		Replays the webhook deliveries which the webhook engine of the team failed to process.
+
+		Deliveries which failed as their HMAC signature did not match, such as after the hmac-token was rotated, are
+		only replayed with '--resign' which signs them again with the current hmac-token of the team. Only use it
+		once you have checked the deliveries came from your git provider.
+`)
+
+	rerunHookFailuresExample = templates.Examples(`
+		# Replay a failed delivery
+		jx rerun hook-failures hook-72d5a4c0
+
+		# Replay all the failed deliveries
+		jx rerun hook-failures --all
+
+		# Replay all the failed deliveries signing them with the current hmac-token
+		jx rerun hook-failures --all --resign
+	`)
+)
+
+// NewCmdRerunHookFailures creates the command
+func NewCmdRerunHookFailures(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &RerunHookFailuresOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "hook-failures [NAME...]",
+		Short:   "Replays the webhook deliveries which the webhook engine failed to process",
+		Aliases: []string{"hook-failure"},
+		Long:    rerunHookFailuresLong,
+		Example: rerunHookFailuresExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the failed deliveries or defaults to the dev namespace")
+	cmd.Flags().StringVarP(&options.HookURL, "hook-url", "", "", "The URL of the webhook engine. Defaults to the URL of the hook service")
+	cmd.Flags().BoolVarP(&options.All, "all", "a", false, "Replay all the pending deliveries")
+	cmd.Flags().BoolVarP(&options.Resign, "resign", "", false, "Sign the deliveries again with the current hmac-token")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *RerunHookFailuresOptions) Run() error {
+	if len(o.Args) == 0 && !o.All {
+		return fmt.Errorf("Missing argument for the name of the HookFailure or use --all")
+	}
+	err := o.registerHookFailureCRD()
+	if err != nil {
+		return err
+	}
+	jxClient, devNs, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	kubeClient, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	ns := o.Namespace
+	if ns == "" {
+		ns = devNs
+	}
+	hookURL := o.HookURL
+	if hookURL == "" {
+		baseURL, err := kube.GetServiceURLFromName(kubeClient, "hook", ns)
+		if err != nil {
+			return errors.Wrapf(err, "failed to find the URL of the hook service in namespace %s", ns)
+		}
+		hookURL = util.UrlJoin(baseURL, "hook")
+	}
+	var hmacToken []byte
+	if o.Resign {
+		secret, err := kubeClient.CoreV1().Secrets(ns).Get("hmac-token", metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to load the hmac-token Secret in namespace %s", ns)
+		}
+		hmacToken = secret.Data["hmac"]
+	}
+
+	failures := []*v1.HookFailure{}
+	if o.All {
+		failures, err = kube.HookFailures(jxClient, ns, false)
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range o.Args {
+		failure, err := jxClient.JenkinsV1().HookFailures(ns).Get(name, metav1.GetOptions{})
+		if err != nil {
+			return errors.Wrapf(err, "failed to find HookFailure %s in namespace %s", name, ns)
+		}
+		failures = append(failures, failure)
+	}
+
+	client := &http.Client{Transport: httpclient.BaseTransport(), Timeout: 30 * time.Second}
+	failed := 0
+	for _, failure := range failures {
+		if failure.Spec.Reason == v1.HookFailureReasonHMACMismatch && !o.Resign {
+			log.Warnf("Skipping %s as its signature did not match. Use --resign to sign it with the current hmac-token\n", failure.Name)
+			continue
+		}
+		err = hooks.Replay(client, hookURL, failure, hmacToken)
+		kube.RecordHookReplay(failure, err, time.Now())
+		_, updateErr := jxClient.JenkinsV1().HookFailures(ns).Update(failure)
+		if updateErr != nil {
+			return errors.Wrapf(updateErr, "failed to update HookFailure %s", failure.Name)
+		}
+		if err != nil {
+			failed++
+			log.Warnf("Failed to replay %s: %s\n", failure.Name, err)
+			continue
+		}
+		log.Infof("Replayed the %s delivery of %s\n", failure.Spec.Event, util.ColorInfo(failure.Spec.Repository))
+	}
+	if failed > 0 {
+		return errors.Errorf("failed to replay %d of %d deliveries", failed, len(failures))
+	}
+	return nil
+}
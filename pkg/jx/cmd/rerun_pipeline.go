@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// RerunPipelineOptions the options for the rerun pipeline command
+type RerunPipelineOptions struct {
+	CommonOptions
+
+	GitServerURL string
+	PullRequest  int
+	Commit       string
+	Branch       string
+	Force        bool
+}
+
+var (
+	rerunPipelineLong = templates.LongDesc(`
+		Re-runs the pipeline of a Pull Request or of a commit of a branch, for example after a flaky failure.
+
+		If the team uses Prow the failed jobs of a Pull Request are re-run by commenting '/retest' on it and the
+		postsubmit jobs of a commit are re-run by creating new ProwJobs. Otherwise the Jenkins job of the Pull Request
+		or branch is built again.
+
+		Pipelines of a repository whose triggers are paused are only re-run with the --force flag. The last
+		re-run is recorded as annotations on the SourceRepository resource.
+`)
+
+	rerunPipelineExample = templates.Examples(`
+		# Re-run the pipeline of a Pull Request of the repository in the current directory
+		jx rerun pipeline --pr 12
+
+		# Re-run the release pipeline of a commit on master
+		jx rerun pipeline myorg/myapp --commit 3c9a1b2
+
+		# Re-run a pipeline even though the pipelines of the repository are paused
+		jx rerun pipeline myorg/myapp --pr 12 --force
+	`)
+)
+
+// NewCmdRerunPipeline creates the command
+func NewCmdRerunPipeline(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &RerunPipelineOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipeline [owner/repo] [flags]",
+		Short:   "Re-runs the pipeline of a Pull Request or commit",
+		Long:    rerunPipelineLong,
+		Example: rerunPipelineExample,
+		Aliases: []string{"pipe", "pipelines", "build", "run"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.GitServerURL, "git-provider-url", "", "", "The git server URL of the repository. Defaults to the current git server")
+	cmd.Flags().IntVarP(&options.PullRequest, "pr", "", 0, "The number of the Pull Request whose pipeline is re-run")
+	cmd.Flags().StringVarP(&options.Commit, "commit", "c", "", "The SHA of the commit whose pipeline is re-run")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "", "master", "The branch of the commit")
+	cmd.Flags().BoolVarP(&options.Force, "force", "", false, "Re-runs the pipeline even if the pipelines of the repository are paused")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *RerunPipelineOptions) Run() error {
+	if o.PullRequest <= 0 && o.Commit == "" {
+		return fmt.Errorf("either the --pr or the --commit option must be specified")
+	}
+	if o.PullRequest > 0 && o.Commit != "" {
+		return util.InvalidOptionf("commit", o.Commit, "cannot be specified along with the --pr option")
+	}
+	gitInfo, err := o.pipelineRepository(o.Args, o.GitServerURL)
+	if err != nil {
+		return err
+	}
+	repo := gitInfo.Organisation + "/" + gitInfo.Name
+	sr, err := o.getSourceRepository(gitInfo)
+	if err != nil {
+		return err
+	}
+	if kube.IsPipelinePaused(sr) && !o.Force {
+		return fmt.Errorf("the pipelines of %s were paused by %s: %s. Use --force to re-run the pipeline anyway",
+			repo, sr.Annotations[kube.AnnotationPipelinesPausedBy], sr.Annotations[kube.AnnotationPipelinesPausedReason])
+	}
+
+	_, _, err = o.JXClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	isProw, err := o.isProw()
+	if err != nil {
+		return err
+	}
+	ref := o.Commit
+	if o.PullRequest > 0 {
+		ref = "PR-" + strconv.Itoa(o.PullRequest)
+	}
+	if isProw {
+		err = o.rerunProwPipeline(gitInfo)
+	} else {
+		err = o.rerunJenkinsPipeline(gitInfo)
+	}
+	if err != nil {
+		return err
+	}
+	err = o.modifySourceRepository(gitInfo, func(sr *v1.SourceRepository) {
+		kube.RecordRerun(sr, ref, currentUserName(), time.Now())
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Re-running the pipeline of %s for %s\n", util.ColorInfo(repo), util.ColorInfo(ref))
+	return nil
+}
+
+func (o *RerunPipelineOptions) rerunProwPipeline(gitInfo *gits.GitRepositoryInfo) error {
+	if o.PullRequest > 0 {
+		provider, err := o.gitProviderForURL(gitInfo.HttpsURL(), "git provider")
+		if err != nil {
+			return err
+		}
+		err = provider.CreateIssueComment(gitInfo.Organisation, gitInfo.Name, o.PullRequest, "/retest")
+		if err != nil {
+			return fmt.Errorf("failed to comment on Pull Request %d of %s/%s: %s", o.PullRequest, gitInfo.Organisation, gitInfo.Name, err)
+		}
+		return nil
+	}
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	jobs, err := prow.PostsubmitJobs(kubeClient, devNs, gitInfo.Organisation, gitInfo.Name, o.Branch, o.Commit)
+	if err != nil {
+		return err
+	}
+	for i := range jobs {
+		err = prow.CreateProwJob(kubeClient, devNs, &jobs[i])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *RerunPipelineOptions) rerunJenkinsPipeline(gitInfo *gits.GitRepositoryInfo) error {
+	jenkinsClient, err := o.JenkinsClient()
+	if err != nil {
+		return err
+	}
+	name := o.Branch
+	if o.PullRequest > 0 {
+		name = "PR-" + strconv.Itoa(o.PullRequest)
+	} else {
+		log.Warnf("Jenkins builds the latest commit of the branch %s which may be newer than %s\n", o.Branch, o.Commit)
+	}
+	job, err := jenkinsClient.GetJobByPath(gitInfo.Organisation, gitInfo.Name, name)
+	if err != nil {
+		return fmt.Errorf("failed to find the Jenkins job %s/%s/%s: %s", gitInfo.Organisation, gitInfo.Name, name, err)
+	}
+	return jenkinsClient.Build(job, nil)
+}
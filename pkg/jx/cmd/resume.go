@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// Resume contains the command line options
+type Resume struct {
+	CommonOptions
+}
+
+var (
+	resumeLong = templates.LongDesc(`
+		Resumes a paused process such as the pipeline triggers of a repository.
+`)
+
+	resumeExample = templates.Examples(`
+		# Resume the paused pipelines of a repository
+		jx resume pipeline myorg/myapp
+	`)
+)
+
+// NewCmdResume creates the command object
+func NewCmdResume(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &Resume{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "resume TYPE [flags]",
+		Short:   "Resumes a paused process such as the pipelines of a repository",
+		Long:    resumeLong,
+		Example: resumeExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdResumePipeline(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *Resume) Run() error {
+	return o.Cmd.Help()
+}
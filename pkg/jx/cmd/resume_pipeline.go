@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// ResumePipelineOptions the options for the resume pipeline command
+type ResumePipelineOptions struct {
+	CommonOptions
+
+	GitServerURL string
+}
+
+var (
+	resumePipelineLong = templates.LongDesc(`
+		Resumes the triggers of the pipelines of a repository which were paused via 'jx pause pipeline'.
+
+		Pushes and Pull Request comments made while the pipelines were paused are not replayed. Use
+		'jx rerun pipeline' to run the pipelines of those Pull Requests or commits.
+`)
+
+	resumePipelineExample = templates.Examples(`
+		# Resume the pipelines of the repository in the current directory
+		jx resume pipeline
+
+		# Resume the pipelines of a repository
+		jx resume pipeline myorg/myapp
+	`)
+)
+
+// NewCmdResumePipeline creates the command
+func NewCmdResumePipeline(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ResumePipelineOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "pipeline [owner/repo] [flags]",
+		Short:   "Resumes the paused triggers of the pipelines of a repository",
+		Long:    resumePipelineLong,
+		Example: resumePipelineExample,
+		Aliases: []string{"pipe", "pipelines", "build", "run"},
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.GitServerURL, "git-provider-url", "", "", "The git server URL of the repository. Defaults to the current git server")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *ResumePipelineOptions) Run() error {
+	gitInfo, err := o.pipelineRepository(o.Args, o.GitServerURL)
+	if err != nil {
+		return err
+	}
+	err = o.setPipelineTriggersPaused(gitInfo, false)
+	if err != nil {
+		return err
+	}
+	err = o.modifySourceRepository(gitInfo, func(sr *v1.SourceRepository) {
+		kube.ResumePipelines(sr)
+	})
+	if err != nil {
+		return err
+	}
+	log.Infof("Resumed the pipelines of %s\n", util.ColorInfo(gitInfo.Organisation+"/"+gitInfo.Name))
+	return nil
+}
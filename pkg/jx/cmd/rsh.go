@@ -6,7 +6,10 @@ import (
 	"io/ioutil"
 	"os"
 	"strings"
+	"time"
 
+	"github.com/jenkins-x/jx/pkg/audit"
+	"github.com/jenkins-x/jx/pkg/builds"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
@@ -14,7 +17,9 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/util"
+	"golang.org/x/crypto/ssh/terminal"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -33,6 +38,8 @@ type RshOptions struct {
 	ExecCmd    string
 	DevPod     bool
 	Username   string
+	Build      string
+	Branch     string
 
 	stopCh chan struct{}
 }
@@ -55,6 +62,9 @@ var (
 
 		# To execute something in the remote shell (like classic rsh or ssh commands)
 		jx rsh -e 'do something'
+
+		# Open a terminal in the latest build pod of the master branch of an application
+		jx rsh --build myorg/myapp --branch master
 `)
 )
 
@@ -79,13 +89,15 @@ func NewCmdRsh(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 			CheckErr(err)
 		},
 	}
-	cmd.Flags().StringVarP(&options.Container, "container", "c", "", "The name of the container to log")
+	cmd.Flags().StringVarP(&options.Container, "container", "c", "", "The name of the container to open the terminal in. If not specified you are asked to pick one when the pod has several")
 	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "the namespace to look for the Deployment. Defaults to the current namespace")
-	cmd.Flags().StringVarP(&options.Namespace, "pod", "p", "", "the pod name to use")
+	cmd.Flags().StringVarP(&options.Pod, "pod", "p", "", "the pod name to use")
 	cmd.Flags().StringVarP(&options.Executable, "shell", "s", "", "Path to the shell command")
 	cmd.Flags().BoolVarP(&options.DevPod, "devpod", "d", false, "Connect to a DevPod")
 	cmd.Flags().StringVarP(&options.ExecCmd, "execute", "e", defaultRshCommand, "Execute this command on the remote container")
 	cmd.Flags().StringVarP(&options.Username, "username", "", "", "The username to create the DevPod. If not specified defaults to the current operating system user or $USER'")
+	cmd.Flags().StringVarP(&options.Build, "build", "b", "", "Connect to the latest build pod of the application repository such as 'myorg/myapp'")
+	cmd.Flags().StringVarP(&options.Branch, "branch", "", "", "The branch of the build pod to connect to when using --build")
 
 	return cmd
 }
@@ -110,7 +122,20 @@ func (o *RshOptions) Run() error {
 	names := []string{}
 	podsName := "Pods"
 	pods := map[string]*corev1.Pod{}
-	if o.DevPod {
+	if o.Build != "" {
+		buildPods, err := builds.GetBuildPods(client, ns)
+		if err != nil {
+			return err
+		}
+		pod := builds.FindLatestBuildPod(buildPods, o.Build, o.Branch)
+		if pod == nil {
+			return fmt.Errorf("There are no build pods for %s in namespace %s", o.Build, ns)
+		}
+		o.Pod = pod.Name
+		names = []string{pod.Name}
+		pods[pod.Name] = pod
+		args = nil
+	} else if o.DevPod {
 		podsName = "DevPods"
 		userName, err := o.getUsername(o.Username)
 		if err != nil {
@@ -164,6 +189,26 @@ func (o *RshOptions) Run() error {
 		return fmt.Errorf("No pod found for namespace %s with name %s", ns, name)
 	}
 
+	if o.Container == "" && !o.DevPod && !o.BatchMode {
+		pod := pods[name]
+		if pod == nil {
+			pod, err = client.CoreV1().Pods(ns).Get(name, metav1.GetOptions{})
+			if err != nil {
+				return err
+			}
+		}
+		if len(pod.Spec.Containers) > 1 {
+			containers := []string{}
+			for _, c := range pod.Spec.Containers {
+				containers = append(containers, c.Name)
+			}
+			o.Container, err = util.PickName(containers, "Pick Container:")
+			if err != nil {
+				return err
+			}
+		}
+	}
+
 	commandArguments := []string{}
 	if o.Executable == "" {
 		if o.DevPod {
@@ -207,7 +252,11 @@ func (o *RshOptions) Run() error {
 		commandArguments = []string{o.Executable}
 	}
 
-	a := []string{"exec", "-it", "-n", ns}
+	// only allocate a TTY when there is a terminal so that commands can be piped
+	a := []string{"exec", "-i", "-n", ns}
+	if terminal.IsTerminal(int(os.Stdin.Fd())) {
+		a[1] = "-it"
+	}
 	if o.Container != "" {
 		a = append(a, "-c", o.Container)
 	}
@@ -220,7 +269,34 @@ func (o *RshOptions) Run() error {
 	if o.Verbose {
 		log.Infof("Running command: kubectl %s\n", strings.Join(a, " "))
 	}
-	return o.runCommandInteractive(true, "kubectl", a...)
+	o.recordSession(audit.SessionStart, ns, name, nil)
+	err = o.runCommandInteractive(true, "kubectl", a...)
+	o.recordSession(audit.SessionStop, ns, name, err)
+	return err
+}
+
+// recordSession records the start or stop of the session in the audit log of the team
+func (o *RshOptions) recordSession(session string, ns string, pod string, err error) {
+	args := []string{pod}
+	if o.Container != "" {
+		args = append(args, "--container="+o.Container)
+	}
+	if o.ExecCmd != "" && o.ExecCmd != defaultRshCommand {
+		args = append(args, "--execute="+o.ExecCmd)
+	}
+	entry := &audit.Entry{
+		Time:      time.Now(),
+		User:      currentUserName(),
+		Command:   "jx rsh",
+		Args:      audit.MaskArgs(args),
+		Namespace: ns,
+		Session:   session,
+		Succeeded: err == nil,
+	}
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	recordAuditEntry(o.Factory, entry)
 }
 
 func (o *RshOptions) detectBash(ns string, podName string, container string) (string, error) {
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// Scale contains the command line options
+type Scale struct {
+	CommonOptions
+}
+
+var (
+	scaleLong = templates.LongDesc(`
+		Scales a resource such as a node pool of the cluster.
+`)
+
+	scaleExample = templates.Examples(`
+		# Scale a node pool to 3 nodes
+		jx scale nodepool builds --nodes 3 --provider gke --cluster mycluster
+	`)
+)
+
+// NewCmdScale creates the command object
+func NewCmdScale(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &Scale{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "scale TYPE [flags]",
+		Short:   "Scales a resource such as a node pool",
+		Long:    scaleLong,
+		Example: scaleExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdScaleNodePool(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *Scale) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	scaleNodePoolLong = templates.LongDesc(`
+		Changes the number of nodes of a node pool in a GKE, EKS or AKS cluster using the command line tool of
+		the cloud provider.
+
+		Use this when build pods cannot be scheduled because the cluster has no spare capacity and the cluster
+		autoscaler is not enabled.
+`)
+
+	scaleNodePoolExample = templates.Examples(`
+		# Scale a node pool of a GKE cluster to 3 nodes
+		jx scale nodepool builds --nodes 3 --provider gke --cluster mycluster --zone europe-west1-b
+
+		# Scale a node pool of an AKS cluster to 5 nodes
+		jx scale nodepool builds --nodes 5 --provider aks --cluster mycluster --resource-group mygroup
+	`)
+)
+
+// ScaleNodePoolOptions the options for the scale nodepool command
+type ScaleNodePoolOptions struct {
+	CommonOptions
+	NodePoolFlags
+
+	Nodes int
+}
+
+// NewCmdScaleNodePool creates a command object for the "scale nodepool" command
+func NewCmdScaleNodePool(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ScaleNodePoolOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "nodepool NAME",
+		Short:   "Changes the number of nodes of a node pool",
+		Aliases: []string{"nodepools", "node-pool", "nodegroup"},
+		Long:    scaleNodePoolLong,
+		Example: scaleNodePoolExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.NodePoolFlags.addFlags(cmd)
+	cmd.Flags().IntVarP(&options.Nodes, "nodes", "n", -1, "The number of nodes")
+
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *ScaleNodePoolOptions) Run() error {
+	if len(o.Args) == 0 {
+		return fmt.Errorf("missing node pool name argument")
+	}
+	name := o.Args[0]
+	if o.Nodes < 0 {
+		return util.MissingOption("nodes")
+	}
+	manager, err := o.createNodePoolManager(&o.NodePoolFlags)
+	if err != nil {
+		return err
+	}
+	err = manager.Scale(name, o.Nodes)
+	if err != nil {
+		return err
+	}
+	log.Infof("Scaled node pool %s of cluster %s to %s nodes\n", util.ColorInfo(name), util.ColorInfo(manager.Cluster.Name), util.ColorInfo(o.Nodes))
+	return nil
+}
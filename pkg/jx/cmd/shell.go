@@ -78,6 +78,8 @@ func NewCmdShell(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	}
 	cmd.Flags().StringVarP(&options.Filter, "filter", "f", "", "Filter the list of contexts to switch between using the given text")
 	options.addCommonFlags(cmd)
+
+	cmd.AddCommand(NewCmdShellInit(f, out, errOut))
 	return cmd
 }
 
@@ -176,7 +178,7 @@ func (o *ShellOptions) PickContext(names []string, defaultValue string) (string,
 		Options: names,
 		Default: defaultValue,
 	}
-	err := survey.AskOne(prompt, &name, nil)
+	err := util.AskOne(prompt, &name, nil)
 	return name, err
 }
 
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+var (
+	shellInitLong = templates.LongDesc(`
+		Outputs the shell snippet which adds the directory jx installs binaries into to the PATH, sets KUBECONFIG and defines aliases for the commands used most often with jx.
+
+		Supported shells are bash, zsh, fish and powershell. Use --install to add the snippet to the profile file of the shell. Installing it again replaces the snippet added before.
+
+		The aliases are:
+
+		  * k   - kubectl
+		  * jxc - jx context
+		  * jxe - jx environment
+		  * jxg - jx get
+		  * jxn - jx namespace
+`)
+
+	shellInitExample = templates.Examples(`
+		# Initialise the current bash or zsh shell
+		eval "$(jx shell init bash)"
+
+		# Initialise the current fish shell
+		jx shell init fish | source
+
+		# Initialise the current PowerShell
+		jx shell init powershell | Out-String | Invoke-Expression
+
+		# Add the snippet to the profile file of your shell
+		jx shell init --install
+	`)
+)
+
+// ShellInitOptions the options for the shell init command
+type ShellInitOptions struct {
+	CommonOptions
+
+	Install     bool
+	ProfileFile string
+	KubeConfig  string
+	NoAliases   bool
+}
+
+// NewCmdShellInit creates a command object for the "shell init" command
+func NewCmdShellInit(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &ShellInitOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "init [bash|zsh|fish|powershell]",
+		Short:   "Outputs or installs the shell snippet which sets up the environment to use jx",
+		Long:    shellInitLong,
+		Example: shellInitExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().BoolVarP(&options.Install, "install", "i", false, "Adds the snippet to the profile file of the shell")
+	cmd.Flags().StringVarP(&options.ProfileFile, "profile-file", "", "", "The profile file to add the snippet to. Defaults to the profile file the shell runs on startup")
+	cmd.Flags().StringVarP(&options.KubeConfig, "kubeconfig", "", os.Getenv("KUBECONFIG"), "The kube config file to set KUBECONFIG to. Defaults to the current value of KUBECONFIG")
+	cmd.Flags().BoolVarP(&options.NoAliases, "no-aliases", "", false, "Does not define the aliases")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the command
+func (o *ShellInitOptions) Run() error {
+	shell, err := o.shell()
+	if err != nil {
+		return err
+	}
+	binDir, err := util.JXBinLocation()
+	if err != nil {
+		return err
+	}
+	shellInit := &util.ShellInit{
+		BinDir:     binDir,
+		KubeConfig: o.KubeConfig,
+	}
+	if !o.NoAliases {
+		shellInit.Aliases = util.DefaultShellAliases
+	}
+	script, err := shellInit.Script(shell)
+	if err != nil {
+		return err
+	}
+	if !o.Install {
+		_, err = fmt.Fprint(o.Out, script)
+		return err
+	}
+	return o.installScript(shell, script)
+}
+
+// shell returns the shell given as argument, picked by the user when installing or detected from the environment
+func (o *ShellInitOptions) shell() (string, error) {
+	if len(o.Args) > 0 {
+		shell := o.Args[0]
+		if util.StringArrayIndex(util.Shells, shell) < 0 {
+			return "", util.InvalidArg(shell, util.Shells)
+		}
+		return shell, nil
+	}
+	detected := util.DetectShell(os.Getenv("SHELL"), runtime.GOOS)
+	if !o.Install || o.BatchMode {
+		return detected, nil
+	}
+	return util.PickNameWithDefault(util.Shells, "Shell to initialise:", detected)
+}
+
+// installScript adds the script to the profile file of the shell replacing the script added by an earlier install
+func (o *ShellInitOptions) installScript(shell string, script string) error {
+	profileFile := o.ProfileFile
+	if profileFile == "" {
+		var err error
+		profileFile, err = util.ShellProfileFile(shell, util.HomeDir(), runtime.GOOS)
+		if err != nil {
+			return err
+		}
+	}
+	text := ""
+	exists, err := util.FileExists(profileFile)
+	if err != nil {
+		return err
+	}
+	if exists {
+		data, err := ioutil.ReadFile(profileFile)
+		if err != nil {
+			return err
+		}
+		text = string(data)
+	}
+	newText := util.ReplaceShellInitBlock(text, script)
+	if newText == text {
+		log.Infof("The jx environment is already set up in %s\n", util.ColorInfo(profileFile))
+		return nil
+	}
+	if !o.BatchMode && !util.Confirm(fmt.Sprintf("Set up the jx environment in %s?", profileFile), true,
+		"Adds the jx snippet to the profile file of your shell") {
+		return nil
+	}
+	err = os.MkdirAll(filepath.Dir(profileFile), DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	err = ioutil.WriteFile(profileFile, []byte(newText), 0644)
+	if err != nil {
+		return err
+	}
+	source := "source " + profileFile
+	if shell == "powershell" {
+		source = ". " + profileFile
+	}
+	log.Infof("Set up the jx environment in %s. Start a new shell or run: %s\n", util.ColorInfo(profileFile), util.ColorInfo(source))
+	return nil
+}
@@ -8,10 +8,15 @@ import (
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/nexus"
+	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// nexusStorageWarningPercent the percentage of the Nexus volume used after which jx status warns
+const nexusStorageWarningPercent = 80
+
 type StatusOptions struct {
 	CommonOptions
 	node string
@@ -112,6 +117,53 @@ func (o *StatusOptions) Run() error {
 	} else {
 		log.Successf("Jenkins X checks passed for %s. Jenkins is running at %s\n", clusterStatus.Info(), jenkinsURL)
 	}
+	o.logNexusStorage(namespace)
 
 	return nil
 }
+
+// logNexusStorage reports the storage used by Nexus, warning if its volume is nearly full
+func (o *StatusOptions) logNexusStorage(ns string) {
+	url, err := kube.FindServiceURL(o.KubeClientCached, ns, kube.ServiceNexus)
+	if err != nil || url == "" {
+		return
+	}
+	password, err := o.getNexusAdminPassword(ns)
+	if err != nil {
+		log.Warnf("Unable to query the Nexus storage: %s\n", err)
+		return
+	}
+	stores, err := nexus.NewClient(url, "", password).GetStorage()
+	if err == nexus.ErrStorageNotSupported {
+		return
+	}
+	if err != nil {
+		log.Warnf("Unable to query the Nexus storage: %s\n", err)
+		return
+	}
+	for _, s := range stores {
+		used := s.UsedPercent()
+		if s.Unlimited {
+			log.Infof("Nexus blob store %s is using %s\n", util.ColorInfo(s.Name), util.ColorInfo(formatStorageSize(s.TotalSize)))
+		} else if used >= nexusStorageWarningPercent {
+			log.Warnf("Nexus blob store %s is using %s with only %s available (%.0f%%). Try: jx edit nexusretention\n", s.Name, formatStorageSize(s.TotalSize), formatStorageSize(s.AvailableSpace), used)
+		} else {
+			log.Infof("Nexus blob store %s is using %s with %s available (%.0f%%)\n", util.ColorInfo(s.Name), util.ColorInfo(formatStorageSize(s.TotalSize)), formatStorageSize(s.AvailableSpace), used)
+		}
+	}
+}
+
+// formatStorageSize formats the number of bytes using binary units
+func formatStorageSize(bytes int64) string {
+	units := []string{"B", "KiB", "MiB", "GiB", "TiB"}
+	size := float64(bytes)
+	i := 0
+	for size >= 1024 && i < len(units)-1 {
+		size /= 1024
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%d%s", bytes, units[0])
+	}
+	return fmt.Sprintf("%.1f%s", size, units[i])
+}
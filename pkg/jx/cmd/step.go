@@ -39,6 +39,7 @@ func NewCmdStep(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 		},
 	}
 
+	cmd.AddCommand(NewCmdStepAttest(f, out, errOut))
 	cmd.AddCommand(NewCmdStepBlog(f, out, errOut))
 	cmd.AddCommand(NewCmdStepChangelog(f, out, errOut))
 	cmd.AddCommand(NewCmdCreateBuild(f, out, errOut))
@@ -52,13 +53,16 @@ func NewCmdStep(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.AddCommand(NewCmdStepPre(f, out, errOut))
 	cmd.AddCommand(NewCmdStepPR(f, out, errOut))
 	cmd.AddCommand(NewCmdStepPost(f, out, errOut))
+	cmd.AddCommand(NewCmdStepProvisionDatabases(f, out, errOut))
 	cmd.AddCommand(NewCmdStepReport(f, out, errOut))
 	cmd.AddCommand(NewCmdStepRelease(f, out, errOut))
 	cmd.AddCommand(NewCmdStepSplitMonorepo(f, out, errOut))
 	cmd.AddCommand(NewCmdStepTag(f, out, errOut))
+	cmd.AddCommand(NewCmdStepUpdate(f, out, errOut))
 	cmd.AddCommand(NewCmdStepValidate(f, out, errOut))
 	cmd.AddCommand(NewCmdStepVerify(f, out, errOut))
 	cmd.AddCommand(NewCmdStepWaitForArtifact(f, out, errOut))
+	cmd.AddCommand(NewCmdStepWaitForURL(f, out, errOut))
 
 	return cmd
 }
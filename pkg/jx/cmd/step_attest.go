@@ -0,0 +1,270 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/attest"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// StepAttestOptions contains the command line flags
+type StepAttestOptions struct {
+	StepOptions
+
+	Dir        string
+	Image      string
+	Digest     string
+	Version    string
+	SBOMFormat string
+	Sign       bool
+	Key        string
+	OutputFile string
+	BuilderID  string
+
+	Runner attest.Runner
+}
+
+var (
+	stepAttestLong = templates.LongDesc(`
+		This pipeline step generates a software bill of materials (SBOM) and a SLSA provenance for a released image.
+
+		When signing is enabled the image is signed and the SBOM and provenance are attached to it as signed
+		attestations using cosign. The SBOM is generated using syft so both tools need to be on the PATH.
+
+		The digests of the image, SBOM and provenance are written to an attestation file which 'jx promote' records
+		in the environment git repository so that the images deployed to an environment can be verified.
+`)
+
+	stepAttestExample = templates.Examples(`
+		# generate an SPDX SBOM and provenance for the image of the current release
+		jx step attest
+
+		# generate a CycloneDX SBOM then sign the image and its attestations
+		jx step attest --sbom-format cyclonedx --sign --key k8s://jx/cosign
+`)
+)
+
+// NewCmdStepAttest creates the command
+func NewCmdStepAttest(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := StepAttestOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "attest",
+		Short:   "Generates a software bill of materials and provenance for a released image and optionally signs them",
+		Long:    stepAttestLong,
+		Example: stepAttestExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The directory of the project. Defaults to the current directory")
+	cmd.Flags().StringVarP(&options.Image, "image", "i", "", "The full image name including the registry prefix and tag. Defaults to $DOCKER_REGISTRY/$ORG/$APP_NAME:$VERSION")
+	cmd.Flags().StringVarP(&options.Digest, "digest", "", "", "The digest of the image. If not specified it is looked up in the registry")
+	cmd.Flags().StringVarP(&options.Version, VERSION, "v", "", "The version of the release. Defaults to the contents of the VERSION file")
+	cmd.Flags().StringVarP(&options.SBOMFormat, "sbom-format", "", attest.SBOMFormatSPDX, fmt.Sprintf("The format of the SBOM. One of: %s", strings.Join(attest.SBOMFormats, ", ")))
+	cmd.Flags().BoolVarP(&options.Sign, "sign", "", false, "Signs the image and attaches the SBOM and provenance as signed attestations")
+	cmd.Flags().StringVarP(&options.Key, "key", "k", "", "The signing key which can be a file, a KMS URI or a kubernetes secret such as k8s://jx/cosign. Defaults to $COSIGN_KEY")
+	cmd.Flags().StringVarP(&options.OutputFile, "output", "o", attest.AttestationFileName, "The file the digests of the image and its attestations are written to")
+	cmd.Flags().StringVarP(&options.BuilderID, "builder-id", "", "", "The ID of the builder recorded in the provenance. Defaults to $BUILD_URL")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepAttestOptions) Run() error {
+	err := attest.ValidateSBOMFormat(o.SBOMFormat)
+	if err != nil {
+		return err
+	}
+	dir := o.Dir
+	if dir == "" {
+		dir, err = os.Getwd()
+		if err != nil {
+			return err
+		}
+	}
+	if o.Version == "" {
+		o.Version, err = o.loadVersion(dir)
+		if err != nil {
+			return err
+		}
+	}
+	if o.Image == "" {
+		o.Image = defaultReleaseImage(o.Version)
+		if o.Image == "" {
+			return util.MissingOption("image")
+		}
+	}
+	if o.Key == "" {
+		o.Key = os.Getenv("COSIGN_KEY")
+	}
+	if o.Sign && o.Key == "" {
+		return util.MissingOption("key")
+	}
+	tools := &attest.Tools{
+		Dir:    dir,
+		Runner: o.Runner,
+		Key:    o.Key,
+	}
+
+	digest := o.Digest
+	if digest == "" {
+		digest, err = tools.ImageDigest(o.Image)
+		if err != nil {
+			return err
+		}
+	}
+	attestation := &attest.ImageAttestation{
+		App:        os.Getenv("APP_NAME"),
+		Version:    o.Version,
+		Image:      attest.ImageWithoutTag(o.Image),
+		Digest:     digest,
+		SBOMFormat: o.SBOMFormat,
+		Created:    time.Now().UTC(),
+	}
+	if attestation.App == "" {
+		attestation.App = filepath.Base(attestation.Image)
+	}
+	imageRef := attestation.ImageReference()
+
+	sbomFile := filepath.Join(dir, attest.SBOMFileName(o.SBOMFormat))
+	err = tools.GenerateSBOM(imageRef, o.SBOMFormat, sbomFile)
+	if err != nil {
+		return err
+	}
+	attestation.SBOMDigest, err = fileDigest(sbomFile)
+	if err != nil {
+		return err
+	}
+	log.Infof("Generated %s SBOM %s for image %s\n", o.SBOMFormat, util.ColorInfo(sbomFile), util.ColorInfo(imageRef))
+
+	provenanceFile := filepath.Join(dir, "provenance.json")
+	err = o.writeProvenance(dir, imageRef, digest, provenanceFile)
+	if err != nil {
+		return err
+	}
+	attestation.ProvenanceDigest, err = fileDigest(provenanceFile)
+	if err != nil {
+		return err
+	}
+	log.Infof("Generated provenance %s\n", util.ColorInfo(provenanceFile))
+
+	if o.Sign {
+		err = tools.SignImage(imageRef)
+		if err != nil {
+			return err
+		}
+		err = tools.AttestImage(imageRef, attest.SBOMPredicateType(o.SBOMFormat), sbomFile)
+		if err != nil {
+			return err
+		}
+		err = tools.AttestImage(imageRef, attest.PredicateTypeProvenance, provenanceFile)
+		if err != nil {
+			return err
+		}
+		attestation.Signed = true
+		attestation.Key = o.Key
+		log.Infof("Signed image %s and its attestations\n", util.ColorInfo(imageRef))
+	}
+
+	outputFile := o.OutputFile
+	if !filepath.IsAbs(outputFile) {
+		outputFile = filepath.Join(dir, outputFile)
+	}
+	err = attest.SaveImageAttestation(outputFile, attestation)
+	if err != nil {
+		return err
+	}
+	log.Infof("Wrote the attestation digests to %s\n", util.ColorInfo(outputFile))
+	return nil
+}
+
+func (o *StepAttestOptions) writeProvenance(dir string, imageRef string, digest string, fileName string) error {
+	build := &attest.BuildInfo{
+		BuilderID:  o.BuilderID,
+		EntryPoint: "Jenkinsfile",
+		BuildID:    o.getBuildNumber(),
+		Parameters: map[string]string{},
+	}
+	if build.BuilderID == "" {
+		build.BuilderID = os.Getenv("BUILD_URL")
+	}
+	if build.BuilderID == "" {
+		build.BuilderID = "https://jenkins-x.io"
+	}
+	if job := o.getJobName(); job != "" {
+		build.Parameters["job"] = job
+	}
+	if o.Version != "" {
+		build.Parameters["version"] = o.Version
+	}
+	finished := time.Now().UTC()
+	build.Finished = &finished
+
+	gitInfo, err := o.FindGitInfo(dir)
+	if err == nil && gitInfo != nil {
+		build.GitURL = gitInfo.HttpCloneURL()
+		sha, err := o.getCommandOutput(dir, "git", "rev-parse", "HEAD")
+		if err == nil {
+			build.GitCommit = strings.TrimSpace(sha)
+		}
+	} else {
+		log.Warnf("Could not find the git repository of %s so the provenance will not include its source: %s\n", dir, err)
+	}
+
+	statement := attest.NewProvenanceStatement(imageRef, digest, build)
+	data, err := statement.MarshalPredicate()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, DefaultWritePermissions)
+}
+
+func (o *StepAttestOptions) loadVersion(dir string) (string, error) {
+	path := filepath.Join(dir, defaultVersionFile)
+	exists, err := util.FileExists(path)
+	if err != nil || !exists {
+		return os.Getenv("VERSION"), err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// defaultReleaseImage returns the image of the release from the pipeline environment variables
+func defaultReleaseImage(version string) string {
+	repository := defaultImageRepository()
+	if repository == "" || version == "" {
+		return ""
+	}
+	return repository + ":" + version
+}
+
+func fileDigest(fileName string) (string, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return "", err
+	}
+	return attest.SHA256Digest(data), nil
+}
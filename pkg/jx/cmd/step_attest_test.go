@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/attest"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/jenkins-x/jx/pkg/tests"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStepAttest(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-step-attest")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	digest := "sha256:b9f03c3c4b196d46639bee0ec9cd0f6dbea8cc39d32767c8312f04317c3b18f4"
+	commands := []string{}
+	o := cmd.StepAttestOptions{
+		Dir:        dir,
+		Image:      "gcr.io/myorg/myapp:1.2.3",
+		Digest:     digest,
+		Version:    "1.2.3",
+		SBOMFormat: attest.SBOMFormatCycloneDX,
+		Sign:       true,
+		Key:        "cosign.key",
+		OutputFile: attest.AttestationFileName,
+		BuilderID:  "https://jenkins.example.com/job/myapp/1",
+		Runner: func(dir string, name string, args ...string) (string, error) {
+			commands = append(commands, name+" "+args[0])
+			if name == attest.SBOMTool {
+				fileName := strings.TrimPrefix(args[2], "cyclonedx-json=")
+				return "", ioutil.WriteFile(fileName, []byte(`{"bomFormat": "CycloneDX"}`), 0644)
+			}
+			return "", nil
+		},
+	}
+	o.Out = tests.Output()
+	o.GitClient = &gits.GitFake{
+		Remotes: []gits.GitRemote{{Name: "origin", URL: "https://github.com/myorg/myapp.git"}},
+	}
+	err = o.Run()
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"syft gcr.io/myorg/myapp@" + digest, "cosign sign", "cosign attest", "cosign attest"}, commands)
+	assert.FileExists(t, filepath.Join(dir, "sbom.cyclonedx.json"))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "provenance.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "git+https://github.com/myorg/myapp.git")
+	assert.Contains(t, string(data), "https://jenkins.example.com/job/myapp/1")
+
+	attestation, err := attest.LoadImageAttestation(filepath.Join(dir, attest.AttestationFileName))
+	require.NoError(t, err)
+	assert.Equal(t, "gcr.io/myorg/myapp", attestation.Image)
+	assert.Equal(t, digest, attestation.Digest)
+	assert.Equal(t, "1.2.3", attestation.Version)
+	assert.Equal(t, attest.SHA256Digest([]byte(`{"bomFormat": "CycloneDX"}`)), attestation.SBOMDigest)
+	assert.NotEmpty(t, attestation.ProvenanceDigest)
+	assert.True(t, attestation.Signed)
+}
+
+func TestStepAttestRequiresKeyToSign(t *testing.T) {
+	t.Parallel()
+	o := cmd.StepAttestOptions{
+		Image:      "gcr.io/myorg/myapp:1.2.3",
+		Version:    "1.2.3",
+		SBOMFormat: attest.SBOMFormatSPDX,
+		Sign:       true,
+		Key:        "",
+	}
+	o.Out = tests.Output()
+	if os.Getenv("COSIGN_KEY") == "" {
+		assert.Error(t, o.Run())
+	}
+
+	o.Sign = false
+	o.SBOMFormat = "xml"
+	assert.Error(t, o.Run())
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 
 	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/pkg/errors"
 
@@ -189,9 +190,36 @@ func (o *StepCreateBuildOptions) generateBuild(projectConfig *config.ProjectConf
 		steps = append(steps, step2)
 	}
 	answer.Spec.Steps = steps
+
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return answer, err
+	}
+	devEnv, err := jxClient.JenkinsV1().Environments(ns).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err == nil {
+		applyBuildPodCustomizations(answer, kube.MatchingBuildPodCustomizations(kube.BuildPodCustomizations(&devEnv.Spec.TeamSettings), projectConfig.BuildPack))
+	}
 	return answer, nil
 }
 
+// applyBuildPodCustomizations applies the customizations of the team which are not already part of the pod template
+// to the knative build
+func applyBuildPodCustomizations(build *Build, customizations []v1.BuildPodCustomization) {
+	for _, c := range customizations {
+		if c.ServiceAccountName != "" {
+			build.Spec.ServiceAccountName = c.ServiceAccountName
+		}
+		if c.Resources != nil {
+			for i := range build.Spec.Steps {
+				build.Spec.Steps[i].Resources = *c.Resources.DeepCopy()
+			}
+		}
+		for _, sidecar := range c.Sidecars {
+			log.Warnf("Ignoring the sidecar %s of the build pod as knative builds do not support sidecars\n", sidecar.Name)
+		}
+	}
+}
+
 func (o *StepCreateBuildOptions) loadPodTemplate(buildPack string) (*corev1.Pod, error) {
 	if buildPack == "" {
 		return nil, nil
@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 
+	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
@@ -26,6 +29,17 @@ var (
 		Applies the helm chart in a given directory.
 
 		This step is usually used to apply any GitOps promotion changes into a Staging or Production cluster.
+
+		If the team has a policy repository configured via 'jx edit policyrepo' the resources generated by the chart,
+		or by the releases of the helmfile of the environment, are validated against its policies first.
+
+		The environment variables of apps in the 'config' folder of the chart, which are managed via 'jx create env-var',
+		are applied as ConfigMaps and decrypted Secrets before the chart is upgraded.
+
+		HorizontalPodAutoscalers are generated for the apps whose 'hpa' values are enabled once the chart is upgraded.
+
+		If the directory contains a 'helmfile.yaml' the releases it describes are applied via 'helmfile sync' instead
+		of upgrading a chart. The team policies are not validated for environments which use helmfile.
 `)
 
 	StepHelmApplyExample = templates.Examples(`
@@ -88,11 +102,6 @@ func (o *StepHelmApplyOptions) Run() error {
 		}
 	}
 
-	helmBinary, err := o.helmInitDependencyBuild(dir, o.defaultReleaseCharts())
-	if err != nil {
-		return err
-	}
-
 	ns := o.Namespace
 	if ns == "" {
 		ns = os.Getenv("DEPLOY_NAMESPACE")
@@ -101,6 +110,19 @@ func (o *StepHelmApplyOptions) Run() error {
 		return fmt.Errorf("No --namespace option specified or $DEPLOY_NAMESPACE environment variable available")
 	}
 
+	helmfileName, err := helm.FindHelmfileFileName(dir)
+	if err != nil {
+		return err
+	}
+	if helmfileName != "" {
+		return o.applyHelmfile(helmfileName, ns)
+	}
+
+	helmBinary, err := o.helmInitDependencyBuild(dir, o.defaultReleaseCharts())
+	if err != nil {
+		return err
+	}
+
 	releaseName := o.ReleaseName
 	if releaseName == "" {
 		if helmBinary == "helm" {
@@ -113,6 +135,16 @@ func (o *StepHelmApplyOptions) Run() error {
 	info := util.ColorInfo
 	log.Infof("Applying helm chart at %s as release name %s to namespace %s\n", info(dir), info(releaseName), info(ns))
 
+	err = o.validatePolicies(dir, releaseName, ns)
+	if err != nil {
+		return err
+	}
+
+	err = o.applyAppConfigs(dir, ns)
+	if err != nil {
+		return err
+	}
+
 	o.Helm().SetCWD(dir)
 
 	if o.Wait {
@@ -124,5 +156,34 @@ func (o *StepHelmApplyOptions) Run() error {
 	if err != nil {
 		return err
 	}
-	return nil
+	return o.applyAutoscalers(dir, ns)
+}
+
+// applyHelmfile applies the releases of an environment which uses helmfile rather than an environment chart
+func (o *StepHelmApplyOptions) applyHelmfile(fileName string, ns string) error {
+	dir := filepath.Dir(fileName)
+	info := util.ColorInfo
+	log.Infof("Applying helmfile %s to namespace %s\n", info(fileName), info(ns))
+
+	err := o.validateHelmfilePolicies(fileName, ns)
+	if err != nil {
+		return err
+	}
+	err = o.applyAppConfigs(dir, ns)
+	if err != nil {
+		return err
+	}
+	err = o.installHelmfile()
+	if err != nil {
+		return errors.Wrap(err, "failed to install helmfile")
+	}
+	args := []string{"--file", fileName, "--namespace", ns, "--helm-binary", o.Helm().HelmBinary(), "sync"}
+	if o.Wait {
+		args = append(args, "--args", "--wait")
+	}
+	err = o.runCommandVerboseAt(dir, "helmfile", args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to apply helmfile %s", fileName)
+	}
+	return o.applyAutoscalers(dir, ns)
 }
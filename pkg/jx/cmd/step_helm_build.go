@@ -22,6 +22,9 @@ var (
 		Builds the helm chart in a given directory.
 
 		This step is usually used to validate any GitOps Pull Requests.
+
+		If the team has a policy repository configured via 'jx edit policyrepo' the resources generated by the chart
+		are validated against its policies first.
 `)
 
 	StepHelmBuildExample = templates.Examples(`
@@ -64,7 +67,7 @@ func NewCmdStepHelmBuild(f Factory, out io.Writer, errOut io.Writer) *cobra.Comm
 }
 
 func (o *StepHelmBuildOptions) Run() error {
-	_, _, err := o.KubeClient()
+	_, ns, err := o.KubeClient()
 	if err != nil {
 		return err
 	}
@@ -85,8 +88,16 @@ func (o *StepHelmBuildOptions) Run() error {
 		}
 	}
 	if o.recursive {
-		return o.helmInitRecursiveDependencyBuild(dir, o.defaultReleaseCharts())
+		err = o.helmInitRecursiveDependencyBuild(dir, o.defaultReleaseCharts())
+	} else {
+		_, err = o.helmInitDependencyBuild(dir, o.defaultReleaseCharts())
+	}
+	if err != nil {
+		return err
+	}
+	deployNamespace := os.Getenv("DEPLOY_NAMESPACE")
+	if deployNamespace == "" {
+		deployNamespace = ns
 	}
-	_, err = o.helmInitDependencyBuild(dir, o.defaultReleaseCharts())
-	return err
+	return o.validatePolicies(dir, deployNamespace, deployNamespace)
 }
@@ -10,13 +10,12 @@ import (
 	"path/filepath"
 
 	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
-	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const (
@@ -105,27 +104,9 @@ func (o *StepHelmReleaseOptions) Run() error {
 
 	chartRepo := o.releaseChartMuseumUrl()
 
-	userName := os.Getenv("CHARTMUSEUM_CREDS_USR")
-	password := os.Getenv("CHARTMUSEUM_CREDS_PSW")
-	if userName == "" || password == "" {
-		// lets try load them from the secret directly
-		client, ns, err := o.KubeClient()
-		if err != nil {
-			return errors.Wrap(err, "failed to create the kube client")
-		}
-		secret, err := client.CoreV1().Secrets(ns).Get(kube.SecretJenkinsChartMuseum, metav1.GetOptions{})
-		if err != nil {
-			log.Warnf("Could not load Secret %s in namespace %s: %s\n", kube.SecretJenkinsChartMuseum, ns, err)
-		} else {
-			if secret != nil && secret.Data != nil {
-				if userName == "" {
-					userName = string(secret.Data["BASIC_AUTH_USER"])
-				}
-				if password == "" {
-					password = string(secret.Data["BASIC_AUTH_PASS"])
-				}
-			}
-		}
+	userName, password, err := o.getChartMuseumCredentials()
+	if err != nil {
+		return err
 	}
 	if userName == "" {
 		return fmt.Errorf("No enviroment variable $CHARTMUSEUM_CREDS_USR defined")
@@ -135,7 +116,7 @@ func (o *StepHelmReleaseOptions) Run() error {
 	}
 
 	// post the tarball to the chart repository
-	client := http.Client{}
+	client := httpclient.NewBaseClient()
 
 	u := util.UrlJoin(chartRepo, "/api/charts")
 
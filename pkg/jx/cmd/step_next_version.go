@@ -15,8 +15,11 @@ import (
 
 	"github.com/blang/semver"
 	version "github.com/hashicorp/go-version"
+	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versioning"
 	"github.com/spf13/cobra"
 )
 
@@ -33,6 +36,7 @@ type StepNextVersionOptions struct {
 	Dir           string
 	Tag           bool
 	UseGitTagOnly bool
+	Semantic      bool
 	NewVersion    string
 	StepOptions
 }
@@ -48,6 +52,14 @@ type PackageJSON struct {
 var (
 	StepNextVersionLong = templates.LongDesc(`
 		This pipeline step command works out a semantic version, writes a file ./VERSION and optionally updates a file
+
+		With the --semantic flag, or if the project's jenkins-x.yml has a 'versioning' section, the next version is
+		derived from the conventional commit messages since the latest version tag: 'fix' commits bump the patch
+		version, 'feat' commits bump the minor version and breaking changes ('feat!:' or a 'BREAKING CHANGE:' footer)
+		bump the major version. The version of the pom.xml, Makefile, package.json and charts/*/Chart.yaml files of the
+		project, or the files configured in jenkins-x.yml, are then updated. With the --tag flag the changes are
+		committed, tagged and pushed. If no commit requires a new version the ./VERSION file contains the current
+		version and nothing else is changed.
 `)
 
 	StepNextVersionExample = templates.Examples(`
@@ -55,6 +67,9 @@ var (
 		jx step next-version --filename package.json
 		jx step next-version --filename package.json --tag
 		jx step next-version --filename package.json --tag --version 1.2.3
+
+		# derive the version from conventional commits, update the version files and tag the release
+		jx step next-version --semantic --tag
 `)
 )
 
@@ -77,14 +92,21 @@ func NewCmdStepNextVersion(f Factory, out io.Writer, errOut io.Writer) *cobra.Co
 	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "the directory to look for files that contain a pom.xml or Makefile with the project version to bump")
 	cmd.Flags().BoolVarP(&options.Tag, "tag", "t", false, "tag and push new version")
 	cmd.Flags().BoolVarP(&options.UseGitTagOnly, "use-git-tag-only", "", false, "only use a git tag so work out new semantic version, else specify filename [pom.xml,package.json,Makefile,Chart.yaml]")
+	cmd.Flags().BoolVarP(&options.Semantic, "semantic", "s", false, "derive the version from the conventional commit messages since the latest version tag and update the version files of the project")
 
 	options.addCommonFlags(cmd)
 	return cmd
 }
 
 func (o *StepNextVersionOptions) Run() error {
+	projectConfig, _, err := config.LoadProjectConfig(o.Dir)
+	if err != nil {
+		return err
+	}
+	if o.Semantic || projectConfig.Versioning != nil {
+		return o.runSemanticVersion(projectConfig.Versioning)
+	}
 
-	var err error
 	if o.NewVersion == "" {
 		o.NewVersion, err = o.getNewVersionFromTag()
 		if err != nil {
@@ -122,6 +144,81 @@ func (o *StepNextVersionOptions) Run() error {
 	return nil
 }
 
+// runSemanticVersion derives the next version from the conventional commits since the latest version tag then
+// updates the version files of the project
+func (o *StepNextVersionOptions) runSemanticVersion(cfg *config.VersioningConfig) error {
+	if cfg == nil {
+		cfg = &config.VersioningConfig{}
+	}
+	if o.NewVersion == "" {
+		err := o.Git().FetchTags(o.Dir)
+		if err != nil {
+			log.Warnf("Failed to fetch the git tags: %s\n", err)
+		}
+		tag, err := versioning.LatestVersionTag(o.Dir)
+		if err != nil {
+			return err
+		}
+		current := semver.Version{}
+		from := "the first commit"
+		if tag != nil {
+			current = tag.Version
+			from = tag.Name
+		}
+		messages, err := versioning.CommitMessagesSince(o.Dir, tag)
+		if err != nil {
+			return err
+		}
+		next, bump := versioning.NextVersion(current, messages, cfg)
+		if bump == versioning.BumpNone {
+			log.Infof("None of the %d commits since %s require a new version\n", len(messages), util.ColorInfo(from))
+			// later steps of the pipeline read the current version from the file
+			return ioutil.WriteFile("VERSION", []byte(current.String()), 0755)
+		}
+		o.NewVersion = next.String()
+		log.Infof("Bumping the %s version to %s based on %d commits since %s\n", bump, util.ColorInfo(o.NewVersion), len(messages), util.ColorInfo(from))
+	}
+
+	err := ioutil.WriteFile("VERSION", []byte(o.NewVersion), 0755)
+	if err != nil {
+		return err
+	}
+
+	files := cfg.Files
+	if o.Filename != "" {
+		files = []string{o.Filename}
+	}
+	if len(files) == 0 {
+		files, err = versioning.DetectVersionFiles(o.Dir)
+		if err != nil {
+			return err
+		}
+	}
+	if len(files) > 0 {
+		err = versioning.UpdateVersionFiles(o.Dir, files, o.NewVersion)
+		if err != nil {
+			return err
+		}
+		log.Infof("Updated the version of %s\n", util.ColorInfo(strings.Join(files, ", ")))
+		args := append([]string{"--"}, files...)
+		err = o.Git().Add(o.Dir, args...)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.Tag {
+		tagOptions := StepTagOptions{
+			Flags: StepTagFlags{
+				Version: o.NewVersion,
+			},
+			StepOptions: o.StepOptions,
+		}
+		return tagOptions.Run()
+	}
+	return nil
+}
+
 // GetVersion gets the version from a source file
 func (o *StepNextVersionOptions) GetVersion() (string, error) {
 	if o.UseGitTagOnly {
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"io"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// StepProvisionDatabasesOptions contains the command line flags
+type StepProvisionDatabasesOptions struct {
+	StepOptions
+
+	Dir         string
+	App         string
+	Namespace   string
+	Environment string
+}
+
+var (
+	stepProvisionDatabasesLong = templates.LongDesc(`
+		Provisions the managed databases declared in the 'databases' section of the jenkins-x.yml file of the project
+		for an environment and creates a Secret in the namespace of the environment with the host, port, database,
+		username, password and url of each database.
+
+		Each environment gets its own database instance which is created using the gcloud, aws or az command line
+		tool. The database instances of a preview environment are deleted when the preview environment is deleted.
+`)
+
+	stepProvisionDatabasesExample = templates.Examples(`
+		# provision the databases of the app of the current pipeline in staging
+		jx step provision-databases --env staging
+
+		# provision the databases of an app in a namespace
+		jx step provision-databases --app myapp --namespace jx-production
+`)
+)
+
+// NewCmdStepProvisionDatabases creates the command
+func NewCmdStepProvisionDatabases(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := StepProvisionDatabasesOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "provision-databases",
+		Short:   "Provisions the managed databases of the project for an environment",
+		Aliases: []string{"provision-database"},
+		Long:    stepProvisionDatabasesLong,
+		Example: stepProvisionDatabasesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Dir, "dir", "d", "", "The root project directory containing the jenkins-x.yml file")
+	cmd.Flags().StringVarP(&options.App, "app", "a", "", "The name of the app. Defaults to $APP_NAME")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace the app is deployed to. Defaults to the namespace of the environment or the current namespace")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The environment the app is deployed to")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepProvisionDatabasesOptions) Run() error {
+	if o.App == "" {
+		o.App = os.Getenv("APP_NAME")
+	}
+	if o.App == "" {
+		return util.MissingOption("app")
+	}
+	ns := o.Namespace
+	if ns == "" {
+		jxClient, devNs, err := o.JXClientAndDevNamespace()
+		if err != nil {
+			return err
+		}
+		if o.Environment != "" {
+			ns, err = kube.GetEnvironmentNamespace(jxClient, devNs, o.Environment)
+		} else {
+			_, ns, err = o.KubeClient()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return o.provisionDatabases(o.Dir, o.App, ns)
+}
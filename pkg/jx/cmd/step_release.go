@@ -7,6 +7,7 @@ import (
 	"os/user"
 	"path/filepath"
 
+	"github.com/jenkins-x/jx/pkg/attest"
 	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/kube"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -28,6 +29,8 @@ type StepReleaseOptions struct {
 	Dir            string
 	XdgConfigHome  string
 	NoBatch        bool
+	Attest         bool
+	Sign           bool
 
 	// promote flags
 	Build               string
@@ -67,6 +70,8 @@ func NewCmdStepRelease(f Factory, out io.Writer, errOut io.Writer) *cobra.Comman
 	cmd.Flags().StringVarP(&options.GitEmail, "git-email", "e", "", "The git email address to configure if there is none already setup")
 	cmd.Flags().StringVarP(&options.XdgConfigHome, "xdg-config-home", "", "/home/jenkins", "The home directory where git config is setup")
 	cmd.Flags().BoolVarP(&options.NoBatch, "no-batch", "", false, "Whether to disable batch mode")
+	cmd.Flags().BoolVarP(&options.Attest, "attest", "", false, "Generates a software bill of materials and provenance for the released image which are recorded in the environment repositories")
+	cmd.Flags().BoolVarP(&options.Sign, "sign", "", false, "Signs the released image and its attestations using the key in $COSIGN_KEY. Implies --attest")
 	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "1h", "The timeout to wait for the promotion to succeed in the underlying Environment. The command fails if the timeout is exceeded or the promotion does not complete")
 	cmd.Flags().StringVarP(&options.PullRequestPollTime, optionPullRequestPollTime, "", "20s", "Poll time when waiting for a Pull Request to merge")
 	cmd.Flags().StringVarP(&options.LocalHelmRepoName, "helm-repo-name", "", kube.LocalHelmRepoName, "The name of the helm repository that contains the app")
@@ -311,8 +316,24 @@ func (o *StepReleaseOptions) releaseAndPromoteChart(dir string) error {
 		return fmt.Errorf("Failed to release helm chart: %s", err)
 	}
 
+	if o.Attest || o.Sign {
+		stepAttestOptions := &StepAttestOptions{
+			StepOptions: o.StepOptions,
+			Dir:         o.Dir,
+			Version:     o.Version,
+			SBOMFormat:  attest.SBOMFormatSPDX,
+			Sign:        o.Sign,
+			OutputFile:  attest.AttestationFileName,
+		}
+		err = stepAttestOptions.Run()
+		if err != nil {
+			return fmt.Errorf("Failed to attest the release: %s", err)
+		}
+	}
+
 	promoteOptions := PromoteOptions{
 		CommonOptions:       o.CommonOptions,
+		AttestationFile:     attest.AttestationFileName,
 		AllAutomatic:        true,
 		Timeout:             o.Timeout,
 		PullRequestPollTime: o.PullRequestPollTime,
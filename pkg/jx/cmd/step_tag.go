@@ -204,6 +204,12 @@ func (o *StepTagOptions) updateChartValues(version string, chartsDir string) err
 }
 
 func (o *StepTagOptions) defaultChartValueRepository() string {
+	return defaultImageRepository()
+}
+
+// defaultImageRepository returns the image name of the app without the version tag from the pipeline environment
+// variables
+func defaultImageRepository() string {
 	dockerRegistry := os.Getenv("DOCKER_REGISTRY")
 	dockerRegistryOrg := os.Getenv("DOCKER_REGISTRY_ORG")
 	if dockerRegistryOrg == "" {
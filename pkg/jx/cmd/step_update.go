@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+// StepUpdateOptions contains the command line flags
+type StepUpdateOptions struct {
+	StepOptions
+}
+
+// NewCmdStepUpdate creates a command object for the "step update" command
+func NewCmdStepUpdate(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &StepUpdateOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:   "update",
+		Short: "update [command]",
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.AddCommand(NewCmdStepUpdateDependencies(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *StepUpdateOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/dependencies"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// StepUpdateDependenciesOptions contains the command line flags
+type StepUpdateDependenciesOptions struct {
+	StepOptions
+
+	Library    string
+	Kind       string
+	Version    string
+	ReleaseURL string
+	DryRun     bool
+}
+
+var (
+	stepUpdateDependenciesLong = templates.LongDesc(`
+		Creates a Pull Request in each repository which depends on a library bumping the library to the released version.
+
+		The repositories depending on a library are looked up in the dependency graph of the team which is managed
+		via 'jx create dependency' and 'jx delete dependency'. Just like a release of an app is promoted to the
+		environments, a release of a library is promoted to its dependent repositories.
+
+		Dependencies are updated in maven pom.xml files, gradle build files, npm package.json files, go.mod files and
+		helm requirements.yaml files.
+`)
+
+	stepUpdateDependenciesExample = templates.Examples(`
+		# bump the maven library in all dependent repositories to the version being released
+		jx step update dependencies --kind maven --library io.acme:core
+
+		# bump the npm package in all dependent repositories to a specific version
+		jx step update dependencies --kind npm --library @acme/ui --version 1.2.3
+`)
+)
+
+// NewCmdStepUpdateDependencies creates the command
+func NewCmdStepUpdateDependencies(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := StepUpdateDependenciesOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "dependencies",
+		Short:   "Creates Pull Requests bumping a released library in the repositories which depend on it",
+		Aliases: []string{"dependency", "deps"},
+		Long:    stepUpdateDependenciesLong,
+		Example: stepUpdateDependenciesExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Library, "library", "l", "", "The name of the released library such as 'groupId:artifactId' for maven and gradle, the npm package, the go module or the chart")
+	cmd.Flags().StringVarP(&options.Kind, "kind", "k", "", fmt.Sprintf("The kind of the library. One of: %s", strings.Join(dependencies.Kinds(), ", ")))
+	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The released version of the library. Defaults to $VERSION")
+	cmd.Flags().StringVarP(&options.ReleaseURL, "release-url", "", "", "The URL of the release notes of the library which is linked from the Pull Requests")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only shows which repositories would be updated")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepUpdateDependenciesOptions) Run() error {
+	if o.Library == "" {
+		return util.MissingOption("library")
+	}
+	if o.Kind == "" {
+		return util.MissingOption("kind")
+	}
+	err := dependencies.ValidateKind(o.Kind)
+	if err != nil {
+		return err
+	}
+	if o.Version == "" {
+		o.Version = os.Getenv("VERSION")
+	}
+	if o.Version == "" {
+		return util.MissingOption("version")
+	}
+	kubeClient, devNs, err := o.KubeClientAndDevNamespace()
+	if err != nil {
+		return err
+	}
+	graph, err := dependencies.LoadGraph(kubeClient, devNs)
+	if err != nil {
+		return err
+	}
+	dependents := graph.Dependents(dependencies.Kind(o.Kind), o.Library)
+	if len(dependents) == 0 {
+		log.Infof("No repositories depend on %s\n", util.ColorInfo(o.Library))
+		return nil
+	}
+	failed := []string{}
+	for _, dependency := range dependents {
+		if o.DryRun {
+			log.Infof("Would update %s to %s in %s\n", util.ColorInfo(o.Library), util.ColorInfo(o.Version), util.ColorInfo(dependency.Repository))
+			continue
+		}
+		pr, err := o.createDependencyUpdatePullRequest(dependency, o.Version, o.ReleaseURL)
+		if err != nil {
+			log.Warnf("Failed to update %s in %s: %s\n", o.Library, dependency.Repository, err)
+			failed = append(failed, dependency.Repository)
+			continue
+		}
+		if pr != nil {
+			log.Infof("Created Pull Request %s to update %s to %s\n", util.ColorInfo(pr.URL), util.ColorInfo(o.Library), util.ColorInfo(o.Version))
+		}
+	}
+	if len(failed) > 0 {
+		return fmt.Errorf("failed to update %s in %s", o.Library, strings.Join(failed, ", "))
+	}
+	return nil
+}
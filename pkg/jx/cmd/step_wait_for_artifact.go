@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -90,7 +91,7 @@ func NewCmdStepWaitForArtifact(f Factory, out io.Writer, errOut io.Writer) *cobr
 }
 
 func (o *StepWaitForArtifactOptions) getUrlStatusOK(u string) error {
-	client := http.Client{}
+	client := httpclient.NewBaseClient()
 	req, err := http.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
 		return err
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// StepWaitForURLOptions contains the command line flags
+type StepWaitForURLOptions struct {
+	StepOptions
+
+	URL             string
+	Service         string
+	Namespace       string
+	Environment     string
+	Path            string
+	Method          string
+	Headers         []string
+	StatusCodes     []int
+	BodyContains    []string
+	BodyRegex       string
+	Insecure        bool
+	CAFile          string
+	MinCertValidity string
+	Timeout         string
+	PollTime        string
+
+	// calculated fields
+	TimeoutDuration time.Duration
+	PollDuration    time.Duration
+}
+
+var (
+	stepWaitForURLLong = templates.LongDesc(`
+		Waits for the URL of a deployed application to return an expected response so that it can be used as a
+		smoke test after a deployment.
+
+		If no URL is specified the URL of the service is found in the namespace of the environment using its
+		Ingress or LoadBalancer. The TLS certificate chain of https URLs is verified unless --insecure is used.
+`)
+
+	stepWaitForURLExample = templates.Examples(`
+		# wait for the app of the current pipeline to be available in staging
+		jx step wait-for-url --env staging
+
+		# wait for a health endpoint to return 200 and report it is up
+		jx step wait-for-url --service myapp --env production --path /health --status 200 --body-contains UP
+
+		# wait for a URL whose certificate must be valid for at least 7 days
+		jx step wait-for-url --url https://myapp.example.com --min-cert-validity 168h
+`)
+)
+
+// NewCmdStepWaitForURL creates the command
+func NewCmdStepWaitForURL(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := StepWaitForURLOptions{
+		StepOptions: StepOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "wait-for-url",
+		Short:   "Waits for the URL of a deployed application to return an expected response",
+		Long:    stepWaitForURLLong,
+		Example: stepWaitForURLExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.URL, "url", "u", "", "The URL to wait for. If not specified the URL of the service is used")
+	cmd.Flags().StringVarP(&options.Service, "service", "s", "", "The name of the service whose URL is used. Defaults to $APP_NAME")
+	cmd.Flags().StringVarP(&options.Namespace, "namespace", "n", "", "The namespace of the service. Defaults to the namespace of the environment or the current namespace")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "", "The environment the service is deployed to")
+	cmd.Flags().StringVarP(&options.Path, "path", "p", "", "The path appended to the URL such as /health")
+	cmd.Flags().StringVarP(&options.Method, "method", "m", "GET", "The HTTP method of the requests")
+	cmd.Flags().StringArrayVarP(&options.Headers, "header", "", []string{}, "A request header of the form 'Name: value'")
+	cmd.Flags().IntSliceVarP(&options.StatusCodes, "status", "", []int{}, "The expected status codes. Defaults to any 2xx status code")
+	cmd.Flags().StringArrayVarP(&options.BodyContains, "body-contains", "c", []string{}, "Text the response body must contain")
+	cmd.Flags().StringVarP(&options.BodyRegex, "body-regex", "r", "", "A regular expression the response body must match")
+	cmd.Flags().BoolVarP(&options.Insecure, "insecure", "k", false, "Disables the verification of the TLS certificate chain")
+	cmd.Flags().StringVarP(&options.CAFile, "ca-file", "", "", "A PEM file of the certificate authorities used to verify the TLS certificate chain. Defaults to the system certificate authorities")
+	cmd.Flags().StringVarP(&options.MinCertValidity, "min-cert-validity", "", "", "The minimum duration the TLS certificate must be valid for such as 168h")
+	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "5m", "The duration before we consider this operation failed")
+	cmd.Flags().StringVarP(&options.PollTime, optionPollTime, "", "5s", "The amount of time between requests")
+	return cmd
+}
+
+// Run implements this command
+func (o *StepWaitForURLOptions) Run() error {
+	var err error
+	if o.PollTime != "" {
+		o.PollDuration, err = time.ParseDuration(o.PollTime)
+		if err != nil {
+			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.PollTime, optionPollTime, err)
+		}
+	}
+	if o.Timeout != "" {
+		o.TimeoutDuration, err = time.ParseDuration(o.Timeout)
+		if err != nil {
+			return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.Timeout, optionTimeout, err)
+		}
+	}
+	check, err := o.createURLCheck()
+	if err != nil {
+		return err
+	}
+
+	if o.URL == "" {
+		if o.Service == "" {
+			o.Service = os.Getenv("APP_NAME")
+		}
+		if o.Service == "" {
+			return util.MissingOption("url")
+		}
+		ns, err := o.serviceNamespace()
+		if err != nil {
+			return err
+		}
+		kubeClient, _, err := o.KubeClient()
+		if err != nil {
+			return err
+		}
+		log.Infof("Waiting for the URL of service %s in namespace %s\n", util.ColorInfo(o.Service), util.ColorInfo(ns))
+		fn := func() error {
+			url, err := kube.FindServiceURL(kubeClient, ns, o.Service)
+			if err != nil {
+				return err
+			}
+			if url == "" {
+				return fmt.Errorf("no URL found for service %s in namespace %s", o.Service, ns)
+			}
+			o.URL = url
+			return nil
+		}
+		err = o.retryQuietlyUntilTimeout(o.TimeoutDuration, o.PollDuration, fn)
+		if err != nil {
+			return err
+		}
+	}
+	check.URL = o.URL
+	if o.Path != "" {
+		check.URL = util.UrlJoin(o.URL, o.Path)
+	}
+
+	log.Infof("Waiting for %s\n", util.ColorInfo(check.URL))
+	var result *util.URLCheckResult
+	fn := func() error {
+		var err error
+		result, err = check.Check()
+		return err
+	}
+	err = o.retryQuietlyUntilTimeout(o.TimeoutDuration, o.PollDuration, fn)
+	if err != nil {
+		log.Warnf("Failed waiting for %s due to %s\n", check.URL, err)
+		return err
+	}
+	log.Infof("Got status %s from %s\n", util.ColorInfo(result.StatusCode), util.ColorInfo(check.URL))
+	if result.CertExpiry != nil {
+		log.Infof("The TLS certificate is valid until %s\n", util.ColorInfo(result.CertExpiry.Format(time.RFC3339)))
+	}
+	return nil
+}
+
+func (o *StepWaitForURLOptions) createURLCheck() (*util.URLCheck, error) {
+	check := &util.URLCheck{
+		Method:       strings.ToUpper(o.Method),
+		StatusCodes:  o.StatusCodes,
+		BodyContains: o.BodyContains,
+		Insecure:     o.Insecure,
+		Headers:      map[string]string{},
+	}
+	for _, header := range o.Headers {
+		parts := strings.SplitN(header, ":", 2)
+		if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" {
+			return nil, util.InvalidOptionf("header", header, "should be of the form 'Name: value'")
+		}
+		check.Headers[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+	}
+	if o.BodyRegex != "" {
+		regex, err := regexp.Compile(o.BodyRegex)
+		if err != nil {
+			return nil, util.InvalidOptionError("body-regex", o.BodyRegex, err)
+		}
+		check.BodyRegex = regex
+	}
+	if o.CAFile != "" {
+		pool, err := util.LoadCertPool(o.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		check.RootCAs = pool
+	}
+	if o.MinCertValidity != "" {
+		d, err := time.ParseDuration(o.MinCertValidity)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid duration format %s for option --%s: %s", o.MinCertValidity, "min-cert-validity", err)
+		}
+		check.MinCertValidity = d
+	}
+	return check, nil
+}
+
+func (o *StepWaitForURLOptions) serviceNamespace() (string, error) {
+	if o.Namespace != "" {
+		return o.Namespace, nil
+	}
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return "", err
+	}
+	if o.Environment != "" {
+		return kube.GetEnvironmentNamespace(jxClient, ns, o.Environment)
+	}
+	_, currentNs, err := o.KubeClient()
+	return currentNs, err
+}
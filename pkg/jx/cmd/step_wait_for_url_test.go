@@ -0,0 +1,54 @@
+package cmd_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStepWaitForURL(t *testing.T) {
+	t.Parallel()
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the app only becomes ready after a few requests
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"status": "UP"}`)
+	}))
+	defer server.Close()
+
+	options := &cmd.StepWaitForURLOptions{
+		URL:          server.URL,
+		Path:         "health",
+		StatusCodes:  []int{http.StatusOK},
+		BodyContains: []string{"UP"},
+		Timeout:      "5s",
+		PollTime:     "10ms",
+	}
+	err := options.Run()
+	assert.NoError(t, err)
+	assert.True(t, atomic.LoadInt32(&requests) >= 3)
+
+	options = &cmd.StepWaitForURLOptions{
+		URL:          server.URL,
+		BodyContains: []string{"DOWN"},
+		Timeout:      "100ms",
+		PollTime:     "10ms",
+	}
+	err = options.Run()
+	assert.Error(t, err)
+
+	options = &cmd.StepWaitForURLOptions{
+		URL:     server.URL,
+		Headers: []string{"no-colon"},
+	}
+	err = options.Run()
+	assert.Error(t, err)
+}
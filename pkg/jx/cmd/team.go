@@ -35,6 +35,9 @@ var (
 
 		# Change the current team to 'cheese'
 		jx team cheese
+
+		# Change the current team to 'cheese' failing if there is no such team
+		jx team switch cheese
 `)
 )
 
@@ -60,6 +63,8 @@ func NewCmdTeam(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 		},
 	}
 	options.addCommonFlags(cmd)
+
+	cmd.AddCommand(NewCmdTeamSwitch(f, out, errOut))
 	return cmd
 }
 
@@ -78,10 +83,6 @@ func (o *TeamOptions) Run() error {
 		return err
 	}
 
-	config, po, err := kube.LoadConfig()
-	if err != nil {
-		return err
-	}
 	team := ""
 	args := o.Args
 	if len(args) > 0 {
@@ -94,6 +95,16 @@ func (o *TeamOptions) Run() error {
 		}
 		team = pick
 	}
+	return o.switchTeam(team, currentTeam)
+}
+
+// switchTeam changes the current namespace to the development namespace of the team so that it is used by
+// subsequent commands
+func (o *TeamOptions) switchTeam(team string, currentTeam string) error {
+	config, po, err := kube.LoadConfig()
+	if err != nil {
+		return err
+	}
 	info := util.ColorInfo
 	if team != "" && team != currentTeam {
 		newConfig := *config
@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	teamSwitchLong = templates.LongDesc(`
+		Switches to a team so that subsequent commands use the namespaces, ingress domain and docker registry
+		organisation of the team.
+`)
+
+	teamSwitchExample = templates.Examples(`
+		# pick which team to switch to
+		jx team switch
+
+		# switch to the team 'cheese'
+		jx team switch cheese
+`)
+)
+
+// TeamSwitchOptions the options for the "team switch" command
+type TeamSwitchOptions struct {
+	TeamOptions
+}
+
+// NewCmdTeamSwitch creates a command object for the "team switch" command
+func NewCmdTeamSwitch(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &TeamSwitchOptions{
+		TeamOptions: TeamOptions{
+			CommonOptions: CommonOptions{
+				Factory: f,
+				Out:     out,
+				Err:     errOut,
+			},
+		},
+	}
+	cmd := &cobra.Command{
+		Use:     "switch [name]",
+		Short:   "Switches the active team used by subsequent commands",
+		Long:    teamSwitchLong,
+		Example: teamSwitchExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements the "team switch" command
+func (o *TeamSwitchOptions) Run() error {
+	kubeClient, currentTeam, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	jxClient, _, err := o.JXClient()
+	if err != nil {
+		return err
+	}
+	_, teamNames, err := kube.GetTeams(kubeClient)
+	if err != nil {
+		return err
+	}
+
+	team := ""
+	if len(o.Args) > 0 {
+		team = o.Args[0]
+	}
+	if team == "" {
+		if o.BatchMode {
+			return fmt.Errorf("missing team name argument")
+		}
+		team, err = util.PickName(teamNames, "Pick Team: ")
+		if err != nil {
+			return err
+		}
+	}
+	if util.StringArrayIndex(teamNames, team) < 0 {
+		return util.InvalidArg(team, teamNames)
+	}
+
+	err = o.switchTeam(team, currentTeam)
+	if err != nil {
+		return err
+	}
+
+	domain, err := kube.GetCurrentDomain(kubeClient, team)
+	if err == nil && domain != "" {
+		log.Infof("Applications of the team are exposed on domain %s\n", util.ColorInfo(domain))
+	}
+	devEnv, err := jxClient.JenkinsV1().Environments(team).Get(kube.LabelValueDevEnvironment, metav1.GetOptions{})
+	if err == nil && devEnv.Spec.TeamSettings.DockerRegistryOrg != "" {
+		log.Infof("Images of the team are pushed to the docker registry organisation %s\n", util.ColorInfo(devEnv.Spec.TeamSettings.DockerRegistryOrg))
+	}
+	return nil
+}
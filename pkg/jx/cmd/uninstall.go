@@ -81,7 +81,7 @@ func (o *UninstallOptions) Run() error {
 			Default: false,
 		}
 		flag := false
-		err = survey.AskOne(confirm, &flag, nil)
+		err = util.AskOne(confirm, &flag, nil)
 		if err != nil {
 			return err
 		}
@@ -108,7 +108,7 @@ func (o *UpdateClusterGKETerraformOptions) updateClusterGKETerraform() error {
 		prompt := &survey.Confirm{
 			Message: "Updating a GKE cluster with terraform is an experimental feature in jx.  Would you like to continue?",
 		}
-		survey.AskOne(prompt, &confirm, nil)
+		util.AskOne(prompt, &confirm, nil)
 
 		if !confirm {
 			// exit at this point
@@ -181,7 +181,7 @@ func (o *UpdateClusterGKETerraformOptions) updateClusterGKETerraform() error {
 		prompt := &survey.Confirm{
 			Message: "Would you like to apply this plan",
 		}
-		survey.AskOne(prompt, &confirm, nil)
+		util.AskOne(prompt, &confirm, nil)
 
 		if !confirm {
 			// exit at this point
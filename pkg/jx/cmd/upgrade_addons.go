@@ -115,6 +115,10 @@ func (o *UpgradeAddonsOptions) Run() error {
 			if err != nil {
 				return errors.Wrap(err, "failed to append the myvalues.yaml file")
 			}
+			valueFiles, cleanup, err := o.expandValueFiles(valueFiles, nil)
+			if err != nil {
+				return err
+			}
 
 			values := []string{}
 			if o.Set != "" {
@@ -122,6 +126,7 @@ func (o *UpgradeAddonsOptions) Run() error {
 			}
 
 			err = o.Helm().UpgradeChart(chart, k, ns, nil, false, nil, false, false, values, valueFiles)
+			cleanup()
 			if err != nil {
 				return errors.Wrapf(err, "Failed to upgrade %s chart %s\n", name, chart)
 			}
@@ -2,7 +2,6 @@ package cmd
 
 import (
 	"io"
-	"runtime"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -80,9 +79,5 @@ func (o *UpgradeCLIOptions) Run() error {
 		return nil
 	}
 
-	if runtime.GOOS == "darwin" && !o.NoBrew {
-		return o.RunCommand("brew", "upgrade", "jx")
-	} else {
-		return o.installJx(true, newVersion.String())
-	}
+	return o.installJx(true, newVersion.String())
 }
@@ -67,7 +67,7 @@ func (o *UpgradeClusterOptions) Run() error {
 	prompt := &survey.Confirm{
 		Message: "Upgrading a GKE cluster is an experimental feature in jx.  Would you like to continue?",
 	}
-	survey.AskOne(prompt, &confirm, nil)
+	util.AskOne(prompt, &confirm, nil)
 
 	if !confirm {
 		// exit at this point
@@ -138,7 +138,7 @@ func (o *UpgradeClusterOptions) getClusterName() (string, error) {
 			Help:    "Select a GKE cluster to upgrade",
 		}
 
-		err := survey.AskOne(prompts, &selectedClusterName, nil)
+		err := util.AskOne(prompts, &selectedClusterName, nil)
 		if err != nil {
 			return "", err
 		}
@@ -179,7 +179,7 @@ func (o *UpgradeClusterOptions) getVersion() (string, error) {
 		Help:    "Select a GKE cluster version to upgrade to",
 	}
 
-	err = survey.AskOne(prompts, &selectedVersion, nil)
+	err = util.AskOne(prompts, &selectedVersion, nil)
 	if err != nil {
 		return "", err
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/kube"
@@ -41,6 +42,7 @@ type UpgradeIngressOptions struct {
 	Namespaces       []string
 	Version          string
 	TargetNamespaces []string
+	WaitTimeout      time.Duration
 
 	IngressConfig kube.IngressConfig
 }
@@ -79,6 +81,7 @@ func (o *UpgradeIngressOptions) addFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&o.Cluster, "cluster", "", false, "Enable cluster wide Ingress upgrade")
 	cmd.Flags().StringArrayVarP(&o.Namespaces, "namespaces", "", []string{}, "Namespaces to upgrade")
 	cmd.Flags().BoolVarP(&o.SkipCertManager, "skip-certmanager", "", false, "Skips certmanager installation")
+	cmd.Flags().DurationVarP(&o.WaitTimeout, "wait-timeout", "", 10*time.Minute, "The time to wait for the recreated ingress rules to be assigned an address and their TLS certificates to be issued. Use 0 to not wait")
 }
 
 // Run implements the command
@@ -147,11 +150,17 @@ func (o *UpgradeIngressOptions) Run() error {
 		return err
 	}
 
+	if o.WaitTimeout > 0 {
+		err = o.waitForExposedIngresses(o.TargetNamespaces, o.IngressConfig.TLS, o.WaitTimeout)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = o.updateJenkinsURL(o.TargetNamespaces)
 	if err != nil {
 		return err
 	}
-	// todo wait for certs secrets to update ingress rules?
 
 	log.Success("Ingress rules recreated\n")
 
@@ -224,7 +233,7 @@ func (o *UpgradeIngressOptions) getExistingIngressRules() (map[string]string, er
 		Default: true,
 	}
 	flag := true
-	err := survey.AskOne(confirm, &flag, nil)
+	err := util.AskOne(confirm, &flag, nil)
 	if err != nil {
 		return existingIngressNames, err
 	}
@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
 	"io"
 
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/cve"
 	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -19,6 +22,15 @@ var (
 	upgrade_platform_example = templates.Examples(`
 		# Upgrades the Jenkins X platform 
 		jx upgrade platform
+
+		# Upgrades the Jenkins X platform to the requirements declared in a file
+		jx upgrade platform --requirements jx-requirements.yml
+
+		# Upgrades the charts of the Jenkins X platform to the latest release of a versions repository
+		jx upgrade platform --versions-repo https://github.com/jenkins-x/jenkins-x-versions.git
+
+		# Shows what would change when upgrading to a specific release of a versions repository
+		jx upgrade platform --versions-repo https://github.com/jenkins-x/jenkins-x-versions.git --release 1.2.0 --dry-run
 	`)
 )
 
@@ -26,11 +38,16 @@ var (
 type UpgradePlatformOptions struct {
 	CreateOptions
 
-	Version     string
-	ReleaseName string
-	Chart       string
-	Namespace   string
-	Set         string
+	Version      string
+	ReleaseName  string
+	Chart        string
+	Namespace    string
+	Set          string
+	Requirements string
+	VersionsRepo string
+	Release      string
+	DryRun       bool
+	CVEFeed      string
 
 	InstallFlags InstallFlags
 }
@@ -65,6 +82,12 @@ func NewCmdUpgradePlatform(f Factory, out io.Writer, errOut io.Writer) *cobra.Co
 	cmd.Flags().StringVarP(&options.Chart, "chart", "c", "jenkins-x/jenkins-x-platform", "The Chart to upgrade")
 	cmd.Flags().StringVarP(&options.Version, "version", "v", "", "The specific platform version to upgrade to")
 	cmd.Flags().StringVarP(&options.Set, "set", "s", "", "The helm parameters to pass in while upgrading")
+	cmd.Flags().StringVarP(&options.Requirements, "requirements", "", "", "The "+config.RequirementsConfigFileName+" file declaring the platform. Defaults to the requirements the platform was installed with")
+
+	cmd.Flags().StringVarP(&options.VersionsRepo, "versions-repo", "", "", "The git repository of the version stream pinning the chart and image versions of each platform release. If specified every chart of the platform release is upgraded")
+	cmd.Flags().StringVarP(&options.Release, "release", "", "", "The platform release of the versions repository to upgrade to. Defaults to the latest release")
+	cmd.Flags().BoolVarP(&options.DryRun, "dry-run", "", false, "Only reports what would change when upgrading from the versions repository")
+	cmd.Flags().StringVarP(&options.CVEFeed, "cve-feed", "", "", "The file or URL of the advisory feed used to report the advisories fixed by upgrading from the versions repository. Defaults to $"+cve.AdvisoryFeedEnvVar)
 
 	options.addCommonFlags(cmd)
 	options.InstallFlags.addCloudEnvOptions(cmd)
@@ -86,6 +109,16 @@ func (o *UpgradePlatformOptions) Run() error {
 			return err
 		}
 	}
+	requirements, err := o.upgradeRequirements(ns)
+	if err != nil {
+		return err
+	}
+	if o.VersionsRepo != "" {
+		return o.upgradeFromVersionStream(ns, requirements)
+	}
+	if version == "" && requirements != nil {
+		version = requirements.Version
+	}
 	if version == "" {
 		io := &InstallOptions{}
 		io.CommonOptions = o.CommonOptions
@@ -108,10 +141,44 @@ func (o *UpgradePlatformOptions) Run() error {
 	if err != nil {
 		return errors.Wrap(err, "failed to append the myvalues.yaml file")
 	}
+	valueFiles, cleanup, err := o.expandValueFiles(valueFiles, requirements)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
 
 	values := []string{}
+	if requirements != nil {
+		values = append(values, requirements.HelmValues()...)
+	}
 	if o.Set != "" {
 		values = append(values, o.Set)
 	}
-	return o.Helm().UpgradeChart(o.Chart, o.ReleaseName, ns, nil, false, nil, false, false, values, valueFiles)
+	err = o.Helm().UpgradeChart(o.Chart, o.ReleaseName, ns, nil, false, nil, false, false, values, valueFiles)
+	if err != nil {
+		return err
+	}
+	if o.Requirements != "" {
+		return o.saveInstalledRequirements(requirements, ns)
+	}
+	return nil
+}
+
+// upgradeRequirements returns the requirements to upgrade the platform to
+func (o *UpgradePlatformOptions) upgradeRequirements(ns string) (*config.RequirementsConfig, error) {
+	installed, err := o.loadInstalledRequirements(ns)
+	if err != nil {
+		return nil, err
+	}
+	if o.Requirements == "" {
+		return installed, nil
+	}
+	requirements, err := config.LoadRequirementsConfig(o.Requirements)
+	if err != nil {
+		return nil, err
+	}
+	if installed != nil && installed.Provider != requirements.Provider {
+		return nil, fmt.Errorf("the platform was installed on provider %s so cannot be upgraded with provider %s", installed.Provider, requirements.Provider)
+	}
+	return requirements, nil
 }
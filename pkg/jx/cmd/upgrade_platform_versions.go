@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/cve"
+	"github.com/jenkins-x/jx/pkg/helm"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const platformReleaseKey = "release.yml"
+
+// upgradeFromVersionStream upgrades the charts of the platform to the versions pinned by a release of the versions repository
+func (o *UpgradePlatformOptions) upgradeFromVersionStream(ns string, requirements *config.RequirementsConfig) error {
+	dir, err := ioutil.TempDir("", "jx-versions-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	log.Infof("Cloning the versions repository %s\n", util.ColorInfo(o.VersionsRepo))
+	err = o.Git().Clone(o.VersionsRepo, dir)
+	if err != nil {
+		return errors.Wrapf(err, "failed to clone the versions repository %s", o.VersionsRepo)
+	}
+	version := o.Release
+	if version == "" {
+		version, err = versionstream.LatestReleaseVersion(dir)
+		if err != nil {
+			return err
+		}
+	}
+	target, err := versionstream.LoadRelease(versionstream.ReleaseFileName(dir, version))
+	if err != nil {
+		return err
+	}
+	if target.Version == "" {
+		target.Version = version
+	}
+	installed, err := o.installedPlatformRelease(ns)
+	if err != nil {
+		return err
+	}
+	diff, err := versionstream.Diff(installed, target)
+	if err != nil {
+		return err
+	}
+	o.logReleaseDiff(diff)
+	o.logAdvisories(installed, target)
+	if o.DryRun || diff.IsEmpty() {
+		return nil
+	}
+
+	valueFiles, err := helm.AppendMyValues([]string{})
+	if err != nil {
+		return errors.Wrap(err, "failed to append the myvalues.yaml file")
+	}
+	for _, change := range diff.Charts {
+		chart := change.ChartVersion
+		chartNs := chart.Namespace
+		if chartNs == "" {
+			chartNs = ns
+		}
+		err = o.runUpgradeHooks(dir, chart.Name, "pre-upgrade", chart.PreUpgrade, ns, target.Version)
+		if err != nil {
+			return err
+		}
+		values := []string{}
+		files := []string{}
+		if chart.Name == o.ReleaseName {
+			if requirements != nil {
+				values = append(values, requirements.HelmValues()...)
+			}
+			if o.Set != "" {
+				values = append(values, o.Set)
+			}
+			files = valueFiles
+		}
+		log.Infof("Upgrading chart %s to version %s in namespace %s\n", util.ColorInfo(chart.Name), util.ColorInfo(chart.Version), util.ColorInfo(chartNs))
+		chartVersion := chart.Version
+		err = o.Helm().UpgradeChart(chart.Chart, chart.Name, chartNs, &chartVersion, true, nil, false, true, values, files)
+		if err != nil {
+			return errors.Wrapf(err, "failed to upgrade chart %s to version %s", chart.Name, chart.Version)
+		}
+		err = o.runUpgradeHooks(dir, chart.Name, "post-upgrade", chart.PostUpgrade, ns, target.Version)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = o.saveInstalledPlatformRelease(ns, target)
+	if err != nil {
+		return err
+	}
+	if o.Requirements != "" && requirements != nil {
+		err = o.saveInstalledRequirements(requirements, ns)
+		if err != nil {
+			return err
+		}
+	}
+	log.Successf("Upgraded the Jenkins X platform to release %s", target.Version)
+	return nil
+}
+
+// installedPlatformRelease returns the platform release which was last applied or, if there is none, the chart
+// versions of the installed helm releases
+func (o *CommonOptions) installedPlatformRelease(ns string) (*versionstream.Release, error) {
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return nil, err
+	}
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapNameJXPlatformVersions, metav1.GetOptions{})
+	if err == nil && cm.Data[platformReleaseKey] != "" {
+		release, err := versionstream.ParseRelease([]byte(cm.Data[platformReleaseKey]))
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid platform release in ConfigMap %s in namespace %s", kube.ConfigMapNameJXPlatformVersions, ns)
+		}
+		return release, nil
+	}
+	output, err := o.Helm().ListCharts()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list the installed chart releases")
+	}
+	release := &versionstream.Release{}
+	for name, version := range helm.ReleaseChartVersions(output) {
+		release.Charts = append(release.Charts, versionstream.ChartVersion{Name: name, Version: version})
+	}
+	return release, nil
+}
+
+func (o *UpgradePlatformOptions) saveInstalledPlatformRelease(ns string, release *versionstream.Release) error {
+	text, err := release.String()
+	if err != nil {
+		return err
+	}
+	client, _, err := o.KubeClient()
+	if err != nil {
+		return err
+	}
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(kube.ConfigMapNameJXPlatformVersions, metav1.GetOptions{})
+	if err != nil {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: kube.ConfigMapNameJXPlatformVersions,
+			},
+			Data: map[string]string{platformReleaseKey: text},
+		}
+		_, err = configMaps.Create(cm)
+	} else {
+		if cm.Data == nil {
+			cm.Data = map[string]string{}
+		}
+		cm.Data[platformReleaseKey] = text
+		_, err = configMaps.Update(cm)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save the platform release to ConfigMap %s in namespace %s", kube.ConfigMapNameJXPlatformVersions, ns)
+	}
+	return nil
+}
+
+func (o *UpgradePlatformOptions) runUpgradeHooks(dir string, chart string, phase string, hooks []versionstream.Hook, ns string, version string) error {
+	for _, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = hook.String()
+		}
+		log.Infof("Running %s hook %s of chart %s\n", phase, util.ColorInfo(name), util.ColorInfo(chart))
+		cmd := util.Command{
+			Dir:  dir,
+			Name: hook.Command,
+			Args: hook.Args,
+			Out:  o.Out,
+			Err:  o.Err,
+			Env: map[string]string{
+				"JX_NAMESPACE":        ns,
+				"JX_PLATFORM_RELEASE": version,
+				"JX_CHART":            chart,
+			},
+		}
+		_, err := cmd.RunWithoutRetry()
+		if err != nil {
+			return errors.Wrapf(err, "%s hook %s of chart %s failed", phase, name, chart)
+		}
+	}
+	return nil
+}
+
+// logAdvisories reports the advisories fixed by the upgrade if an advisory feed is configured. The upgrade is not
+// blocked if the feed cannot be loaded
+func (o *UpgradePlatformOptions) logAdvisories(installed *versionstream.Release, target *versionstream.Release) {
+	location := o.CVEFeed
+	if location == "" {
+		location = os.Getenv(cve.AdvisoryFeedEnvVar)
+	}
+	if location == "" {
+		return
+	}
+	feed, err := cve.LoadAdvisoryFeed(location)
+	if err != nil {
+		log.Warnf("Failed to check the advisories of release %s: %s\n", target.Version, err)
+		return
+	}
+	logReleaseAdvisories(feed, installed, target)
+}
+
+func (o *UpgradePlatformOptions) logReleaseDiff(diff *versionstream.ReleaseDiff) {
+	from := diff.From
+	if from == "" {
+		from = "the installed versions"
+	}
+	if diff.IsEmpty() {
+		log.Infof("The platform is already at release %s\n", util.ColorInfo(diff.To))
+		return
+	}
+	log.Infof("Changes from %s to release %s:\n", util.ColorInfo(from), util.ColorInfo(diff.To))
+	for _, change := range diff.Charts {
+		if change.From == "" {
+			log.Infof("  chart %s: install %s\n", util.ColorInfo(change.Name), util.ColorInfo(change.Version))
+		} else {
+			log.Infof("  chart %s: %s -> %s\n", util.ColorInfo(change.Name), change.From, util.ColorInfo(change.Version))
+		}
+	}
+	for _, change := range diff.Images {
+		if change.From == "" {
+			log.Infof("  image %s: %s\n", util.ColorInfo(change.Image), util.ColorInfo(change.To))
+		} else {
+			log.Infof("  image %s: %s -> %s\n", util.ColorInfo(change.Image), change.From, util.ColorInfo(change.To))
+		}
+	}
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+)
+
+// VerifyOptions contains the command line options
+type VerifyOptions struct {
+	CommonOptions
+}
+
+var (
+	verifyLong = templates.LongDesc(`
+		Verifies that Jenkins X works as expected.
+`)
+
+	verifyExample = templates.Examples(`
+		# Verify the installation by creating, building, previewing and promoting a quickstart
+		jx verify install
+	`)
+)
+
+// NewCmdVerify creates the command object
+func NewCmdVerify(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &VerifyOptions{
+		CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "verify TYPE [flags]",
+		Short:   "Verifies that Jenkins X works as expected",
+		Long:    verifyLong,
+		Example: verifyExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+
+	cmd.AddCommand(NewCmdVerifyInstall(f, out, errOut))
+	return cmd
+}
+
+// Run implements this command
+func (o *VerifyOptions) Run() error {
+	return o.Cmd.Help()
+}
@@ -0,0 +1,390 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/jx/cmd/templates"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/log"
+	"github.com/jenkins-x/jx/pkg/quickstarts"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	verifyCheckPassed  = "PASSED"
+	verifyCheckFailed  = "FAILED"
+	verifyCheckSkipped = "SKIPPED"
+)
+
+var (
+	verifyInstallLong = templates.LongDesc(`
+		Verifies the installation of Jenkins X by running an end to end conformance check:
+
+		* creates a throwaway app from a quickstart and imports it
+		* waits for the pipeline of the master branch to build the app
+		* raises a Pull Request on the app and waits for its Preview Environment
+		* requests the URL of the preview
+		* waits for the app to be promoted to the Staging Environment and requests its URL
+
+		The result of each check is reported in a pass/fail matrix. Once a check fails the remaining checks are skipped.
+		The git repository of the app is deleted afterwards unless '--no-cleanup' is specified.
+`)
+
+	verifyInstallExample = templates.Examples(`
+		# Verify the installation
+		jx verify install
+
+		# Verify the installation using a specific quickstart and git organisation
+		jx verify install --quickstart golang-http --org myorg
+	`)
+)
+
+// VerifyInstallOptions the options for the command
+type VerifyInstallOptions struct {
+	CommonOptions
+
+	Quickstart   string
+	Organisation string
+	Environment  string
+	NoCleanup    bool
+	Timeout      string
+	PollTime     string
+
+	timeout  time.Duration
+	pollTime time.Duration
+}
+
+// verifyCheck a check of the installation returning a description of what it verified
+type verifyCheck struct {
+	Name string
+	Run  func() (string, error)
+}
+
+// verifyCheckResult the result of a check of the installation
+type verifyCheckResult struct {
+	Name    string
+	Status  string
+	Details string
+}
+
+// verifyApp the app created to verify the installation
+type verifyApp struct {
+	Dir         string
+	GitInfo     *gits.GitRepositoryInfo
+	GitProvider gits.GitProvider
+	PullRequest *gits.GitPullRequest
+}
+
+// NewCmdVerifyInstall creates the command
+func NewCmdVerifyInstall(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
+	options := &VerifyInstallOptions{
+		CommonOptions: CommonOptions{
+			Factory: f,
+			Out:     out,
+			Err:     errOut,
+		},
+	}
+
+	cmd := &cobra.Command{
+		Use:     "install",
+		Short:   "Verifies the installation by creating, building, previewing and promoting a throwaway quickstart",
+		Long:    verifyInstallLong,
+		Example: verifyInstallExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			options.Cmd = cmd
+			options.Args = args
+			err := options.Run()
+			CheckErr(err)
+		},
+	}
+	cmd.Flags().StringVarP(&options.Quickstart, "quickstart", "q", "node-http", "The name of the quickstart to create")
+	cmd.Flags().StringVarP(&options.Organisation, "org", "", "", "The git organisation to create the app in. Defaults to the git user")
+	cmd.Flags().StringVarP(&options.Environment, optionEnvironment, "e", "staging", "The environment the app should be promoted to")
+	cmd.Flags().BoolVarP(&options.NoCleanup, "no-cleanup", "", false, "Keeps the git repository of the app once the installation is verified")
+	cmd.Flags().StringVarP(&options.Timeout, optionTimeout, "t", "30m", "The time to wait for each pipeline, preview and promotion")
+	cmd.Flags().StringVarP(&options.PollTime, optionPollTime, "", "10s", "The time between checks of the pipelines and URLs")
+	options.addCommonFlags(cmd)
+	return cmd
+}
+
+// Run implements this command
+func (o *VerifyInstallOptions) Run() error {
+	var err error
+	o.timeout, err = time.ParseDuration(o.Timeout)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.Timeout, optionTimeout, err)
+	}
+	o.pollTime, err = time.ParseDuration(o.PollTime)
+	if err != nil {
+		return fmt.Errorf("Invalid duration format %s for option --%s: %s", o.PollTime, optionPollTime, err)
+	}
+	err = o.registerPipelineActivityCRD()
+	if err != nil {
+		return err
+	}
+	dir, err := ioutil.TempDir("", "jx-verify-install-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	appName := kube.ToValidName("jx-verify-" + time.Now().Format("20060102-150405"))
+	app := &verifyApp{
+		Dir: filepath.Join(dir, appName),
+	}
+	var master *v1.PipelineActivity
+	checks := []verifyCheck{
+		{
+			Name: "Create quickstart",
+			Run: func() (string, error) {
+				return o.createVerifyApp(app, dir, appName)
+			},
+		},
+		{
+			Name: "Build master",
+			Run: func() (string, error) {
+				master, err = o.waitForVerifyPipeline(app, "master")
+				if err != nil {
+					return "", err
+				}
+				return fmt.Sprintf("built version %s", master.Spec.Version), nil
+			},
+		},
+		{
+			Name: "Create Pull Request",
+			Run: func() (string, error) {
+				return o.createVerifyPullRequest(app)
+			},
+		},
+		{
+			Name: "Preview Environment",
+			Run: func() (string, error) {
+				preview, err := o.waitForVerifyPipeline(app, "PR-"+strconv.Itoa(*app.PullRequest.Number))
+				if err != nil {
+					return "", err
+				}
+				step := kube.PreviewStep(preview)
+				if step == nil || step.ApplicationURL == "" {
+					return "", fmt.Errorf("the pipeline %s did not create a preview", preview.Spec.Pipeline)
+				}
+				return o.waitForVerifyURL(step.ApplicationURL)
+			},
+		},
+		{
+			Name: "Promote to " + o.Environment,
+			Run: func() (string, error) {
+				step := kube.PromoteStep(master, o.Environment)
+				if step == nil {
+					return "", fmt.Errorf("the pipeline %s did not promote to the %s environment", master.Spec.Pipeline, o.Environment)
+				}
+				if step.ApplicationURL == "" {
+					return "", fmt.Errorf("no URL of the app in the %s environment", o.Environment)
+				}
+				return o.waitForVerifyURL(step.ApplicationURL)
+			},
+		},
+	}
+
+	results := runVerifyChecks(checks)
+	o.cleanupVerifyApp(app)
+
+	table := o.CreateTable()
+	table.AddRow("CHECK", "RESULT", "DETAILS")
+	failed := 0
+	for _, r := range results {
+		status := r.Status
+		switch status {
+		case verifyCheckPassed:
+			status = util.ColorInfo(status)
+		case verifyCheckFailed:
+			status = util.ColorError(status)
+			failed++
+		}
+		table.AddRow(r.Name, status, r.Details)
+	}
+	table.Render()
+	if failed > 0 {
+		return fmt.Errorf("the installation failed %d of %d checks", failed, len(results))
+	}
+	log.Infof("\nThe installation of Jenkins X passed all %d checks\n", len(results))
+	return nil
+}
+
+// runVerifyChecks runs the checks in order skipping the remaining checks once a check fails
+func runVerifyChecks(checks []verifyCheck) []verifyCheckResult {
+	results := []verifyCheckResult{}
+	failed := false
+	for _, check := range checks {
+		result := verifyCheckResult{
+			Name:   check.Name,
+			Status: verifyCheckSkipped,
+		}
+		if !failed {
+			log.Infof("Verifying %s\n", util.ColorInfo(check.Name))
+			details, err := check.Run()
+			result.Status = verifyCheckPassed
+			result.Details = details
+			if err != nil {
+				failed = true
+				result.Status = verifyCheckFailed
+				result.Details = err.Error()
+			}
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// createVerifyApp creates and imports the quickstart
+func (o *VerifyInstallOptions) createVerifyApp(app *verifyApp, dir string, appName string) (string, error) {
+	co := &CreateQuickstartOptions{
+		CreateProjectOptions: CreateProjectOptions{
+			ImportOptions: ImportOptions{
+				CommonOptions: o.CommonOptions,
+				Organisation:  o.Organisation,
+				Repository:    appName,
+			},
+			OutDir: dir,
+		},
+		Filter: quickstarts.QuickstartFilter{
+			Text:        o.Quickstart,
+			ProjectName: appName,
+		},
+	}
+	co.BatchMode = true
+	co.DisableMaven = true
+	err := co.Run()
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to create the quickstart %s", o.Quickstart)
+	}
+	app.GitProvider = co.ImportOptions.GitProvider
+	app.GitInfo, err = gits.ParseGitURL(co.RepoURL)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("created %s from %s", app.GitInfo.HttpsURL(), o.Quickstart), nil
+}
+
+// createVerifyPullRequest raises a Pull Request changing the app which should create a preview of the app
+func (o *VerifyInstallOptions) createVerifyPullRequest(app *verifyApp) (string, error) {
+	if app.GitProvider == nil {
+		return "", fmt.Errorf("no git provider for %s", app.GitInfo.HttpsURL())
+	}
+	gitter := o.Git()
+	branch := "verify-preview"
+	err := gitter.CreateBranch(app.Dir, branch)
+	if err != nil {
+		return "", err
+	}
+	err = gitter.Checkout(app.Dir, branch)
+	if err != nil {
+		return "", err
+	}
+	err = ioutil.WriteFile(filepath.Join(app.Dir, "VERIFY.md"), []byte("Verifying the installation of Jenkins X\n"), util.DefaultWritePermissions)
+	if err != nil {
+		return "", err
+	}
+	err = gitter.Add(app.Dir, "VERIFY.md")
+	if err != nil {
+		return "", err
+	}
+	err = gitter.CommitDir(app.Dir, "Verify the preview of a Pull Request")
+	if err != nil {
+		return "", err
+	}
+	err = gitter.ForcePushBranch(app.Dir, branch, branch)
+	if err != nil {
+		return "", err
+	}
+	app.PullRequest, err = app.GitProvider.CreatePullRequest(&gits.GitPullRequestArguments{
+		Title:             "Verify the preview of a Pull Request",
+		Body:              "Created by 'jx verify install'",
+		Head:              branch,
+		Base:              "master",
+		GitRepositoryInfo: app.GitInfo,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to create the Pull Request")
+	}
+	if app.PullRequest.Number == nil {
+		return "", fmt.Errorf("no number for the Pull Request %s", app.PullRequest.URL)
+	}
+	return app.PullRequest.URL, nil
+}
+
+// waitForVerifyPipeline waits for the latest build of the branch of the app to complete returning its activity
+func (o *VerifyInstallOptions) waitForVerifyPipeline(app *verifyApp, branch string) (*v1.PipelineActivity, error) {
+	jxClient, ns, err := o.JXClientAndDevNamespace()
+	if err != nil {
+		return nil, err
+	}
+	pipeline := app.GitInfo.Organisation + "/" + app.GitInfo.Name + "/" + branch
+	var answer *v1.PipelineActivity
+	err = o.retryUntilTrueOrTimeout(o.timeout, o.pollTime, func() (bool, error) {
+		list, err := jxClient.JenkinsV1().PipelineActivities(ns).List(metav1.ListOptions{})
+		if err != nil {
+			return false, err
+		}
+		answer = kube.LatestPipelineActivity(list.Items, pipeline)
+		if answer == nil || !answer.Spec.Status.IsTerminated() {
+			return false, nil
+		}
+		if answer.Spec.Status != v1.ActivityStatusTypeSucceeded {
+			return false, fmt.Errorf("build %s of pipeline %s %s", answer.Spec.Build, pipeline, answer.Spec.Status)
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "waiting for the pipeline %s", pipeline)
+	}
+	return answer, nil
+}
+
+// waitForVerifyURL waits for the URL to respond successfully
+func (o *VerifyInstallOptions) waitForVerifyURL(u string) (string, error) {
+	check := &util.URLCheck{
+		URL:            u,
+		RequestTimeout: 30 * time.Second,
+	}
+	var lastErr error
+	err := o.retryUntilTrueOrTimeout(o.timeout, o.pollTime, func() (bool, error) {
+		_, lastErr = check.Check()
+		return lastErr == nil, nil
+	})
+	if err != nil {
+		if lastErr != nil {
+			err = lastErr
+		}
+		return "", errors.Wrapf(err, "requesting %s", u)
+	}
+	return u, nil
+}
+
+// cleanupVerifyApp deletes the git repository of the app
+func (o *VerifyInstallOptions) cleanupVerifyApp(app *verifyApp) {
+	if app.GitInfo == nil || app.GitProvider == nil {
+		return
+	}
+	if o.NoCleanup {
+		log.Infof("Keeping the git repository %s\n", util.ColorInfo(app.GitInfo.HttpsURL()))
+		return
+	}
+	err := app.GitProvider.DeleteRepository(app.GitInfo.Organisation, app.GitInfo.Name)
+	if err != nil {
+		log.Warnf("Failed to delete the git repository %s: %s\n", app.GitInfo.HttpsURL(), err)
+		return
+	}
+	log.Infof("Deleted the git repository %s. The app remains in the %s environment until it is removed via 'jx delete app'\n",
+		util.ColorInfo(app.GitInfo.HttpsURL()), o.Environment)
+}
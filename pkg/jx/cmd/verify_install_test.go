@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunVerifyChecks(t *testing.T) {
+	t.Parallel()
+	ran := []string{}
+	check := func(name string, err error) verifyCheck {
+		return verifyCheck{
+			Name: name,
+			Run: func() (string, error) {
+				ran = append(ran, name)
+				return name + " ok", err
+			},
+		}
+	}
+	results := runVerifyChecks([]verifyCheck{
+		check("create", nil),
+		check("build", fmt.Errorf("build failed")),
+		check("preview", nil),
+	})
+	assert.Equal(t, []string{"create", "build"}, ran)
+	assert.Equal(t, []verifyCheckResult{
+		{Name: "create", Status: verifyCheckPassed, Details: "create ok"},
+		{Name: "build", Status: verifyCheckFailed, Details: "build failed"},
+		{Name: "preview", Status: verifyCheckSkipped},
+	}, results)
+}
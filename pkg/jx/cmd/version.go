@@ -1,10 +1,13 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
+	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
@@ -23,6 +26,7 @@ type VersionOptions struct {
 	Namespace      string
 	HelmTLS        bool
 	NoVersionCheck bool
+	Check          bool
 }
 
 func NewCmdVersion(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
@@ -53,14 +57,22 @@ func NewCmdVersion(f Factory, out io.Writer, errOut io.Writer) *cobra.Command {
 	cmd.Flags().MarkShorthandDeprecated("client", "please use --client instead.")
 	cmd.Flags().BoolVarP(&options.HelmTLS, "helm-tls", "", false, "Whether to use TLS with helm")
 	cmd.Flags().BoolVarP(&options.NoVersionCheck, "no-version-check", "n", false, "Disable checking of version upgrade checks")
+	cmd.Flags().BoolVarP(&options.Check, "check", "", false, "Only checks whether a new jx version is available and outputs the result as JSON")
 	return cmd
 }
 
 func (o *VersionOptions) Run() error {
+	if o.Check {
+		return o.checkUpdateStatus()
+	}
 	info := util.ColorInfo
 	table := o.CreateTable()
 	table.AddRow("NAME", "VERSION")
 	table.AddRow("jx", info(version.GetVersion()))
+	current, err := version.GetSemverVersion()
+	if err == nil {
+		table.AddRow("jx release channel", info(version.Channel(current)))
+	}
 
 	// Jenkins X version
 	output, err := o.Helm().ListCharts()
@@ -190,6 +202,30 @@ func (o *VersionOptions) VersionCheck() error {
 	return nil
 }
 
+// checkUpdateStatus outputs whether a new jx version is available as JSON and caches the latest version for the
+// update check of other commands
+func (o *VersionOptions) checkUpdateStatus() error {
+	current, err := version.GetSemverVersion()
+	if err != nil {
+		return err
+	}
+	latest, err := o.GetLatestJXVersion()
+	if err != nil {
+		return err
+	}
+	configDir, err := util.ConfigDir()
+	if err == nil {
+		check := &version.UpdateCheck{CheckedAt: time.Now(), LatestVersion: latest.String()}
+		version.SaveUpdateCheck(filepath.Join(configDir, updateCheckFileName), check)
+	}
+	data, err := json.MarshalIndent(version.NewUpdateStatus(current, latest), "", "  ")
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintln(o.Out, string(data))
+	return err
+}
+
 func (o *VersionOptions) UpgradeCli() error {
 	options := &UpgradeCLIOptions{
 		CreateOptions: CreateOptions{
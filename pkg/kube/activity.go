@@ -42,6 +42,7 @@ type PromoteStepActivityKey struct {
 
 type PromotePullRequestFn func(*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromotePullRequestStep) error
 type PromoteUpdateFn func(*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromoteUpdateStep) error
+type PromoteSmokeTestFn func(*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromoteSmokeTestStep) error
 
 type PipelineDetails struct {
 	GitOwner      string
@@ -383,6 +384,25 @@ func (k *PromoteStepActivityKey) GetOrCreatePromoteUpdate(activities typev1.Pipe
 	return a, s, p, p.Update, created, err
 }
 
+// GetOrCreatePromoteSmokeTest gets or creates the PromoteSmokeTest for the key
+func (k *PromoteStepActivityKey) GetOrCreatePromoteSmokeTest(activities typev1.PipelineActivityInterface) (*v1.PipelineActivity, *v1.PipelineActivityStep, *v1.PromoteActivityStep, *v1.PromoteSmokeTestStep, bool, error) {
+	a, s, p, created, err := k.GetOrCreatePromote(activities)
+	if err != nil {
+		return nil, nil, nil, nil, created, err
+	}
+	if p.SmokeTest == nil {
+		created = true
+		p.SmokeTest = &v1.PromoteSmokeTestStep{
+			CoreActivityStep: v1.CoreActivityStep{
+				StartedTimestamp: &metav1.Time{
+					Time: time.Now(),
+				},
+			},
+		}
+	}
+	return a, s, p, p.SmokeTest, created, err
+}
+
 func (k *PromoteStepActivityKey) OnPromotePullRequest(activities typev1.PipelineActivityInterface, fn PromotePullRequestFn) error {
 	if !k.IsValid() {
 		return nil
@@ -439,6 +459,32 @@ func (k *PromoteStepActivityKey) OnPromoteUpdate(activities typev1.PipelineActiv
 	return err
 }
 
+// OnPromoteSmokeTest updates the smoke test step of the promotion via the function
+func (k *PromoteStepActivityKey) OnPromoteSmokeTest(activities typev1.PipelineActivityInterface, fn PromoteSmokeTestFn) error {
+	if !k.IsValid() {
+		return nil
+	}
+	if activities == nil {
+		log.Warn("Warning: no PipelineActivities client available!")
+		return nil
+	}
+	a, s, ps, p, added, err := k.GetOrCreatePromoteSmokeTest(activities)
+	if err != nil {
+		return err
+	}
+	p1 := asYaml(a)
+	err = fn(a, s, ps, p)
+	if err != nil {
+		return err
+	}
+	p2 := asYaml(a)
+
+	if added || p1 == "" || p1 != p2 {
+		_, err = activities.Update(a)
+	}
+	return err
+}
+
 func asYaml(activity *v1.PipelineActivity) string {
 	data, err := yaml.Marshal(activity)
 	if err == nil {
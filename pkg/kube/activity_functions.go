@@ -152,3 +152,39 @@ func FailedPromotionUpdate(a *v1.PipelineActivity, s *v1.PipelineActivityStep, p
 	p.Status = v1.ActivityStatusTypeFailed
 	return nil
 }
+
+func StartPromotionSmokeTest(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteSmokeTestStep) error {
+	if p.StartedTimestamp == nil {
+		p.StartedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	if p.Status != v1.ActivityStatusTypeRunning {
+		p.Status = v1.ActivityStatusTypeRunning
+	}
+	return nil
+}
+
+func CompletePromotionSmokeTest(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteSmokeTestStep) error {
+	StartPromotionSmokeTest(a, s, ps, p)
+	if p.CompletedTimestamp == nil {
+		p.CompletedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	p.Status = v1.ActivityStatusTypeSucceeded
+	return nil
+}
+
+// FailedPromotionSmokeTest marks the smoke tests and the promotion as failed
+func FailedPromotionSmokeTest(a *v1.PipelineActivity, s *v1.PipelineActivityStep, ps *v1.PromoteActivityStep, p *v1.PromoteSmokeTestStep) error {
+	StartPromotionSmokeTest(a, s, ps, p)
+	if p.CompletedTimestamp == nil {
+		p.CompletedTimestamp = &metav1.Time{
+			Time: time.Now(),
+		}
+	}
+	p.Status = v1.ActivityStatusTypeFailed
+	ps.Status = v1.ActivityStatusTypeFailed
+	return nil
+}
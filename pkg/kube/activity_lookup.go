@@ -0,0 +1,49 @@
+package kube
+
+import (
+	"strconv"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+// LatestPipelineActivity returns the activity of the latest build of the pipeline, named owner/repository/branch, or
+// nil if the pipeline has not been built
+func LatestPipelineActivity(activities []v1.PipelineActivity, pipeline string) *v1.PipelineActivity {
+	var answer *v1.PipelineActivity
+	latest := -1
+	for i := range activities {
+		activity := &activities[i]
+		if activity.Spec.Pipeline != pipeline {
+			continue
+		}
+		build, err := strconv.Atoi(activity.Spec.Build)
+		if err != nil {
+			build = 0
+		}
+		if build > latest {
+			latest = build
+			answer = activity
+		}
+	}
+	return answer
+}
+
+// PreviewStep returns the step of the activity which created a preview environment or nil if there is none
+func PreviewStep(activity *v1.PipelineActivity) *v1.PreviewActivityStep {
+	for _, step := range activity.Spec.Steps {
+		if step.Preview != nil {
+			return step.Preview
+		}
+	}
+	return nil
+}
+
+// PromoteStep returns the step of the activity which promoted to the environment or nil if there is none
+func PromoteStep(activity *v1.PipelineActivity, environment string) *v1.PromoteActivityStep {
+	for _, step := range activity.Spec.Steps {
+		if step.Promote != nil && step.Promote.Environment == environment {
+			return step.Promote
+		}
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestLatestPipelineActivity(t *testing.T) {
+	t.Parallel()
+	activity := func(pipeline string, build string, steps ...v1.PipelineActivityStep) v1.PipelineActivity {
+		return v1.PipelineActivity{
+			ObjectMeta: metav1.ObjectMeta{Name: kube.ToValidName(pipeline + "-" + build)},
+			Spec:       v1.PipelineActivitySpec{Pipeline: pipeline, Build: build, Steps: steps},
+		}
+	}
+	activities := []v1.PipelineActivity{
+		activity("myorg/myapp/master", "2",
+			v1.PipelineActivityStep{Kind: v1.ActivityStepKindTypePromote, Promote: &v1.PromoteActivityStep{Environment: "staging", ApplicationURL: "http://myapp.jx-staging"}}),
+		activity("myorg/myapp/master", "10"),
+		activity("myorg/myapp/PR-1", "1",
+			v1.PipelineActivityStep{Kind: v1.ActivityStepKindTypePreview, Preview: &v1.PreviewActivityStep{ApplicationURL: "http://myapp.jx-myorg-myapp-pr-1"}}),
+		activity("myorg/other/master", "11"),
+	}
+
+	latest := kube.LatestPipelineActivity(activities, "myorg/myapp/master")
+	require.NotNil(t, latest)
+	assert.Equal(t, "10", latest.Spec.Build)
+	assert.Nil(t, kube.LatestPipelineActivity(activities, "myorg/myapp/PR-2"))
+
+	assert.Nil(t, kube.PromoteStep(latest, "staging"))
+	promote := kube.PromoteStep(&activities[0], "staging")
+	require.NotNil(t, promote)
+	assert.Equal(t, "http://myapp.jx-staging", promote.ApplicationURL)
+	assert.Nil(t, kube.PromoteStep(&activities[0], "production"))
+
+	preview := kube.PreviewStep(kube.LatestPipelineActivity(activities, "myorg/myapp/PR-1"))
+	require.NotNil(t, preview)
+	assert.Equal(t, "http://myapp.jx-myorg-myapp-pr-1", preview.ApplicationURL)
+}
@@ -0,0 +1,104 @@
+package kube
+
+import (
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+const (
+	// DefaultActivityKeepPerBranch the number of activities kept for each branch if the team has no retention policy
+	DefaultActivityKeepPerBranch = 5
+)
+
+// DefaultActivityRetention returns the retention policy used if the team has not configured one
+func DefaultActivityRetention() *v1.ActivityRetention {
+	return &v1.ActivityRetention{
+		KeepPerBranch: DefaultActivityKeepPerBranch,
+	}
+}
+
+// ActivityRetentionOrDefault returns the retention policy of the team settings or the default policy
+func ActivityRetentionOrDefault(settings *v1.TeamSettings) *v1.ActivityRetention {
+	if settings == nil || settings.ActivityRetention == nil {
+		return DefaultActivityRetention()
+	}
+	return settings.ActivityRetention
+}
+
+// ActivitiesToPrune returns the names of the activities which are removed by the retention policy. For each pipeline,
+// which is a branch of a repository, only the most recent activities are kept along with any younger than the
+// maximum age. Activities which are still running or whose version has been released are always kept
+func ActivitiesToPrune(activities []v1.PipelineActivity, releases []v1.Release, retention *v1.ActivityRetention, now time.Time) []string {
+	answer := []string{}
+	if retention == nil || (retention.KeepPerBranch <= 0 && retention.MaxAgeDays <= 0) {
+		return answer
+	}
+	released := map[string]bool{}
+	for _, r := range releases {
+		if r.Spec.Version != "" {
+			released[releaseKey(r.Spec.GitOwner, r.Spec.GitRepository, r.Spec.Version)] = true
+		}
+	}
+	pipelines := map[string][]*v1.PipelineActivity{}
+	for i := range activities {
+		a := &activities[i]
+		pipelines[a.Spec.Pipeline] = append(pipelines[a.Spec.Pipeline], a)
+	}
+	maxAge := time.Duration(retention.MaxAgeDays) * 24 * time.Hour
+	for _, pipelineActivities := range pipelines {
+		sort.Slice(pipelineActivities, func(i, j int) bool {
+			return isNewerActivity(pipelineActivities[i], pipelineActivities[j])
+		})
+		for i, a := range pipelineActivities {
+			expired := retention.MaxAgeDays > 0 && now.Sub(activityTime(a)) > maxAge
+			if !expired && (retention.KeepPerBranch <= 0 || i < retention.KeepPerBranch) {
+				continue
+			}
+			if isActivityRunning(a) {
+				continue
+			}
+			if a.Spec.Version != "" && released[releaseKey(a.Spec.GitOwner, a.Spec.GitRepository, a.Spec.Version)] {
+				continue
+			}
+			answer = append(answer, a.Name)
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+func releaseKey(owner string, repo string, version string) string {
+	return owner + "/" + repo + "/" + version
+}
+
+func isActivityRunning(a *v1.PipelineActivity) bool {
+	switch a.Spec.Status {
+	case v1.ActivityStatusTypePending, v1.ActivityStatusTypeRunning, v1.ActivityStatusTypeWaitingForApproval:
+		return true
+	}
+	return false
+}
+
+// isNewerActivity returns true if the first activity is a later build than the second
+func isNewerActivity(a *v1.PipelineActivity, b *v1.PipelineActivity) bool {
+	buildA, errA := strconv.Atoi(a.Spec.Build)
+	buildB, errB := strconv.Atoi(b.Spec.Build)
+	if errA == nil && errB == nil && buildA != buildB {
+		return buildA > buildB
+	}
+	return activityTime(a).After(activityTime(b))
+}
+
+// activityTime returns when the activity completed or started falling back to when the resource was created
+func activityTime(a *v1.PipelineActivity) time.Time {
+	if a.Spec.CompletedTimestamp != nil {
+		return a.Spec.CompletedTimestamp.Time
+	}
+	if a.Spec.StartedTimestamp != nil {
+		return a.Spec.StartedTimestamp.Time
+	}
+	return a.CreationTimestamp.Time
+}
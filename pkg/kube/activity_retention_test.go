@@ -0,0 +1,56 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestActivitiesToPrune(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	activity := func(name string, pipeline string, build string, version string, daysAgo int, status v1.ActivityStatusType) v1.PipelineActivity {
+		started := metav1.NewTime(now.Add(-time.Duration(daysAgo) * 24 * time.Hour))
+		return v1.PipelineActivity{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.PipelineActivitySpec{
+				Pipeline:         pipeline,
+				Build:            build,
+				Version:          version,
+				GitOwner:         "myorg",
+				GitRepository:    "myapp",
+				Status:           status,
+				StartedTimestamp: &started,
+			},
+		}
+	}
+	activities := []v1.PipelineActivity{
+		activity("myorg-myapp-master-1", "myorg/myapp/master", "1", "0.0.1", 40, v1.ActivityStatusTypeSucceeded),
+		activity("myorg-myapp-master-2", "myorg/myapp/master", "2", "0.0.2", 30, v1.ActivityStatusTypeSucceeded),
+		activity("myorg-myapp-master-3", "myorg/myapp/master", "3", "0.0.3", 20, v1.ActivityStatusTypeFailed),
+		activity("myorg-myapp-master-10", "myorg/myapp/master", "10", "0.0.10", 1, v1.ActivityStatusTypeSucceeded),
+		activity("myorg-myapp-pr-1-1", "myorg/myapp/PR-1", "1", "", 50, v1.ActivityStatusTypeRunning),
+		activity("myorg-myapp-pr-1-2", "myorg/myapp/PR-1", "2", "", 45, v1.ActivityStatusTypeFailed),
+	}
+	releases := []v1.Release{
+		{Spec: v1.ReleaseSpec{GitOwner: "myorg", GitRepository: "myapp", Version: "0.0.1"}},
+	}
+
+	names := kube.ActivitiesToPrune(activities, releases, &v1.ActivityRetention{KeepPerBranch: 2}, now)
+	assert.Equal(t, []string{"myorg-myapp-master-2"}, names, "released builds should be kept")
+
+	names = kube.ActivitiesToPrune(activities, releases, &v1.ActivityRetention{MaxAgeDays: 25}, now)
+	assert.Equal(t, []string{"myorg-myapp-master-2", "myorg-myapp-pr-1-2"}, names, "running builds should be kept")
+
+	names = kube.ActivitiesToPrune(activities, nil, &v1.ActivityRetention{KeepPerBranch: 3, MaxAgeDays: 35}, now)
+	assert.Equal(t, []string{"myorg-myapp-master-1", "myorg-myapp-pr-1-2"}, names)
+
+	names = kube.ActivitiesToPrune(activities, nil, &v1.ActivityRetention{}, now)
+	assert.Empty(t, names)
+
+	assert.Equal(t, kube.DefaultActivityKeepPerBranch, kube.ActivityRetentionOrDefault(&v1.TeamSettings{}).KeepPerBranch)
+}
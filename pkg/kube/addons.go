@@ -0,0 +1,61 @@
+package kube
+
+import (
+	"sort"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GetAddons returns the Addon resources tracking the state of the addons of the team along with their sorted names
+func GetAddons(jxClient versioned.Interface, ns string) (map[string]*v1.Addon, []string, error) {
+	m := map[string]*v1.Addon{}
+	names := []string{}
+	list, err := jxClient.JenkinsV1().Addons(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return m, names, err
+	}
+	for _, addon := range list.Items {
+		copy := addon
+		m[addon.Name] = &copy
+		names = append(names, addon.Name)
+	}
+	sort.Strings(names)
+	return m, names, nil
+}
+
+// GetAddon returns the Addon resource of the given name or nil if the addon has never been installed
+func GetAddon(jxClient versioned.Interface, ns string, name string) (*v1.Addon, error) {
+	addon, err := jxClient.JenkinsV1().Addons(ns).Get(name, metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return addon, nil
+}
+
+// SaveAddon creates or updates the Addon resource
+func SaveAddon(jxClient versioned.Interface, ns string, addon *v1.Addon) (*v1.Addon, error) {
+	addons := jxClient.JenkinsV1().Addons(ns)
+	existing, err := addons.Get(addon.Name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		return addons.Create(addon)
+	}
+	existing.Spec = addon.Spec
+	existing.Status = addon.Status
+	return addons.Update(existing)
+}
+
+// UpdateAddonPhase updates the lifecycle phase of the Addon resource
+func UpdateAddonPhase(jxClient versioned.Interface, ns string, addon *v1.Addon, phase v1.AddonPhase, message string) (*v1.Addon, error) {
+	addon.Status.Phase = phase
+	addon.Status.Message = message
+	return SaveAddon(jxClient, ns, addon)
+}
@@ -0,0 +1,45 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	jxfake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestAddonState(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	jxClient := jxfake.NewSimpleClientset()
+
+	addon, err := kube.GetAddon(jxClient, ns, "prow")
+	require.NoError(t, err)
+	assert.Nil(t, addon)
+
+	for _, name := range []string{"prow", "grafana"} {
+		_, err = kube.SaveAddon(jxClient, ns, &v1.Addon{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       v1.AddonSpec{Chart: "jenkins-x/" + name, Namespace: ns},
+		})
+		require.NoError(t, err)
+	}
+
+	addon, err = kube.GetAddon(jxClient, ns, "prow")
+	require.NoError(t, err)
+	require.NotNil(t, addon)
+	addon.Status.Version = "0.0.26"
+	_, err = kube.UpdateAddonPhase(jxClient, ns, addon, v1.AddonPhaseFailed, "chart not found")
+	require.NoError(t, err)
+
+	addons, names, err := kube.GetAddons(jxClient, ns)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grafana", "prow"}, names)
+	assert.Equal(t, v1.AddonPhaseFailed, addons["prow"].Status.Phase)
+	assert.Equal(t, "chart not found", addons["prow"].Status.Message)
+	assert.Equal(t, "0.0.26", addons["prow"].Status.Version)
+	assert.Equal(t, v1.AddonPhaseNone, addons["grafana"].Status.Phase)
+}
@@ -0,0 +1,113 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/apps/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// failingContainerReasons the reasons a container is waiting which mean it will not start without a fix
+var failingContainerReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+}
+
+// AppHealth the health of the deployment of an app after it is upgraded
+type AppHealth struct {
+	// RolledOut is true once all the replicas of the latest revision of the deployment are available
+	RolledOut bool
+	// Failures the reasons the app is unhealthy such as crash looping containers
+	Failures []string
+}
+
+// Healthy returns true if the deployment is rolled out without any failures
+func (h *AppHealth) Healthy() bool {
+	return h.RolledOut && len(h.Failures) == 0
+}
+
+// FindAppDeployment returns the deployment of the app in the namespace or nil if there is none
+func FindAppDeployment(client kubernetes.Interface, ns string, app string) (*v1beta1.Deployment, error) {
+	deployments, err := GetDeployments(client, ns)
+	if err != nil {
+		return nil, err
+	}
+	for name, d := range deployments {
+		if GetAppName(name, ns) == app {
+			return &d, nil
+		}
+	}
+	return nil, nil
+}
+
+// CheckAppHealth checks the rollout of the deployment of the app and the containers of its pods. Containers which
+// restarted more than maxRestarts times or which are waiting due to a crash or a bad image are reported as failures
+func CheckAppHealth(client kubernetes.Interface, ns string, app string, maxRestarts int32) (*AppHealth, error) {
+	health := &AppHealth{}
+	d, err := FindAppDeployment(client, ns, app)
+	if err != nil {
+		return health, err
+	}
+	if d == nil {
+		health.Failures = append(health.Failures, fmt.Sprintf("no deployment found for app %s in namespace %s", app, ns))
+		return health, nil
+	}
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+	status := d.Status
+	health.RolledOut = status.ObservedGeneration >= d.Generation && status.UpdatedReplicas >= replicas &&
+		status.AvailableReplicas >= replicas && status.Replicas == status.UpdatedReplicas
+
+	selector, err := metav1.LabelSelectorAsSelector(d.Spec.Selector)
+	if err != nil {
+		return health, err
+	}
+	pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return health, err
+	}
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		for _, c := range pod.Status.ContainerStatuses {
+			if c.RestartCount > maxRestarts {
+				health.Failures = append(health.Failures, fmt.Sprintf("container %s of pod %s restarted %d times", c.Name, pod.Name, c.RestartCount))
+				continue
+			}
+			waiting := c.State.Waiting
+			if waiting != nil && failingContainerReasons[waiting.Reason] {
+				failure := fmt.Sprintf("container %s of pod %s is %s", c.Name, pod.Name, waiting.Reason)
+				if waiting.Message != "" {
+					failure += ": " + waiting.Message
+				}
+				health.Failures = append(health.Failures, failure)
+			}
+		}
+	}
+	return health, nil
+}
+
+// WaitForAppHealth waits for the deployment of the app to roll out. It returns as soon as a failure is detected
+// or adds a failure if the deployment is not rolled out before the timeout
+func WaitForAppHealth(client kubernetes.Interface, ns string, app string, maxRestarts int32, timeout time.Duration, poll time.Duration) (*AppHealth, error) {
+	end := time.Now().Add(timeout)
+	for {
+		health, err := CheckAppHealth(client, ns, app, maxRestarts)
+		if err != nil || len(health.Failures) > 0 || health.RolledOut {
+			return health, err
+		}
+		if time.Now().After(end) {
+			health.Failures = append(health.Failures, fmt.Sprintf("the deployment of app %s in namespace %s did not roll out within %s", app, ns, timeout))
+			return health, nil
+		}
+		time.Sleep(poll)
+	}
+}
@@ -0,0 +1,108 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/apps/v1beta1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func appDeployment(ns string, replicas int32, available int32) *v1beta1.Deployment {
+	labels := map[string]string{"app": "jx-staging-myapp"}
+	return &v1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jx-staging-myapp",
+			Namespace: ns,
+		},
+		Spec: v1beta1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{MatchLabels: labels},
+		},
+		Status: v1beta1.DeploymentStatus{
+			Replicas:          replicas,
+			UpdatedReplicas:   replicas,
+			AvailableReplicas: available,
+		},
+	}
+}
+
+func appPod(ns string, name string, status v1.ContainerStatus) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels:    map[string]string{"app": "jx-staging-myapp"},
+		},
+		Status: v1.PodStatus{
+			ContainerStatuses: []v1.ContainerStatus{status},
+		},
+	}
+}
+
+func TestCheckAppHealth(t *testing.T) {
+	t.Parallel()
+	ns := "jx-staging"
+	running := v1.ContainerStatus{Name: "myapp", Ready: true, RestartCount: 1}
+	crashing := v1.ContainerStatus{
+		Name:         "myapp",
+		RestartCount: 2,
+		State: v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off restarting failed container"},
+		},
+	}
+	restarting := v1.ContainerStatus{Name: "myapp", RestartCount: 5}
+
+	testCases := []struct {
+		name      string
+		objects   []runtime.Object
+		rolledOut bool
+		failures  []string
+	}{
+		{
+			name:      "healthy",
+			objects:   []runtime.Object{appDeployment(ns, 1, 1), appPod(ns, "myapp-1", running)},
+			rolledOut: true,
+		},
+		{
+			name:     "crash looping",
+			objects:  []runtime.Object{appDeployment(ns, 1, 0), appPod(ns, "myapp-1", crashing)},
+			failures: []string{"container myapp of pod myapp-1 is CrashLoopBackOff: back-off restarting failed container"},
+		},
+		{
+			name:      "restarting",
+			objects:   []runtime.Object{appDeployment(ns, 1, 1), appPod(ns, "myapp-1", restarting)},
+			rolledOut: true,
+			failures:  []string{"container myapp of pod myapp-1 restarted 5 times"},
+		},
+		{
+			name:     "missing",
+			failures: []string{"no deployment found for app myapp in namespace jx-staging"},
+		},
+	}
+	for _, tc := range testCases {
+		client := fake.NewSimpleClientset(tc.objects...)
+		health, err := kube.CheckAppHealth(client, ns, "myapp", 3)
+		require.NoError(t, err, tc.name)
+		assert.Equal(t, tc.rolledOut, health.RolledOut, tc.name)
+		assert.Equal(t, tc.failures, health.Failures, tc.name)
+		assert.Equal(t, tc.rolledOut && len(tc.failures) == 0, health.Healthy(), tc.name)
+	}
+}
+
+func TestWaitForAppHealthTimesOut(t *testing.T) {
+	t.Parallel()
+	ns := "jx-staging"
+	client := fake.NewSimpleClientset(appDeployment(ns, 2, 1))
+
+	health, err := kube.WaitForAppHealth(client, ns, "myapp", 3, 10*time.Millisecond, time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, health.Healthy())
+	assert.Equal(t, []string{"the deployment of app myapp in namespace jx-staging did not roll out within 10ms"}, health.Failures)
+}
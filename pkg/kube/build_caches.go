@@ -0,0 +1,364 @@
+package kube
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// BuildCacheMaven the kind of cache for maven repositories
+	BuildCacheMaven = "maven"
+	// BuildCacheGradle the kind of cache for gradle dependencies
+	BuildCacheGradle = "gradle"
+	// BuildCacheNpm the kind of cache for npm packages
+	BuildCacheNpm = "npm"
+
+	// BuildCacheMountPath the path build caches are mounted into build containers
+	BuildCacheMountPath = "/cache"
+
+	// DefaultBuildCacheStorageSize the default size of the PVC of a build cache
+	DefaultBuildCacheStorageSize = "10Gi"
+
+	// LabelBuildCache the label added to build cache PVCs with the kind of cache
+	LabelBuildCache = "jenkins.io/build-cache"
+
+	// SecretJenkinsMavenSettings the Secret containing the maven settings.xml used by builds
+	SecretJenkinsMavenSettings = "jenkins-maven-settings"
+
+	buildCacheVolumePrefix = "jx-build-cache-"
+	mavenRepoLocalOption   = "-Dmaven.repo.local="
+)
+
+var (
+	// BuildCacheKinds the kinds of build cache which are supported
+	BuildCacheKinds = []string{BuildCacheMaven, BuildCacheGradle, BuildCacheNpm}
+
+	mavenMirrorURLRegex = regexp.MustCompile(`(?s)(<mirror>.*?<url>)(.*?)(</url>)`)
+)
+
+// BuildCacheName returns the name of the PVC and volume used for the kind of build cache
+func BuildCacheName(kind string) string {
+	return buildCacheVolumePrefix + kind
+}
+
+// BuildCacheEnvVars returns the environment variables used to point the build tools at the cache.
+// For maven the local repository is passed via MAVEN_OPTS so it is appended to any existing value
+func BuildCacheEnvVars(cache *v1.BuildCache) []corev1.EnvVar {
+	dir := BuildCacheMountPath + "/" + cache.Kind
+	switch cache.Kind {
+	case BuildCacheMaven:
+		return []corev1.EnvVar{{Name: "MAVEN_OPTS", Value: mavenRepoLocalOption + dir}}
+	case BuildCacheGradle:
+		return []corev1.EnvVar{{Name: "GRADLE_USER_HOME", Value: dir}}
+	case BuildCacheNpm:
+		answer := []corev1.EnvVar{{Name: "npm_config_cache", Value: dir}}
+		if cache.RegistryURL != "" {
+			answer = append(answer, corev1.EnvVar{Name: "npm_config_registry", Value: cache.RegistryURL})
+		}
+		return answer
+	default:
+		return nil
+	}
+}
+
+// ValidateBuildCache validates the build cache configuration
+func ValidateBuildCache(cache *v1.BuildCache) error {
+	found := false
+	for _, k := range BuildCacheKinds {
+		if k == cache.Kind {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return fmt.Errorf("unknown build cache kind %s. Supported kinds are: %s", cache.Kind, strings.Join(BuildCacheKinds, ", "))
+	}
+	if cache.StorageSize != "" {
+		_, err := resource.ParseQuantity(cache.StorageSize)
+		if err != nil {
+			return fmt.Errorf("invalid storage size %s for build cache %s: %s", cache.StorageSize, cache.Kind, err)
+		}
+	}
+	return nil
+}
+
+// EnsureBuildCachePVC lazily creates the PersistentVolumeClaim for the build cache
+func EnsureBuildCachePVC(kubeClient kubernetes.Interface, ns string, cache *v1.BuildCache) error {
+	name := BuildCacheName(cache.Kind)
+	_, err := kubeClient.CoreV1().PersistentVolumeClaims(ns).Get(name, metav1.GetOptions{})
+	if err == nil {
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to get PersistentVolumeClaim %s in namespace %s: %s", name, ns, err)
+	}
+	size := cache.StorageSize
+	if size == "" {
+		size = DefaultBuildCacheStorageSize
+	}
+	storageRequest, err := resource.ParseQuantity(size)
+	if err != nil {
+		return err
+	}
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				LabelBuildCache: cache.Kind,
+			},
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteMany,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: storageRequest,
+				},
+			},
+		},
+	}
+	if cache.StorageClass != "" {
+		storageClass := cache.StorageClass
+		pvc.Spec.StorageClassName = &storageClass
+	}
+	_, err = kubeClient.CoreV1().PersistentVolumeClaims(ns).Create(pvc)
+	if err != nil {
+		return fmt.Errorf("failed to create PersistentVolumeClaim %s in namespace %s: %s", name, ns, err)
+	}
+	return nil
+}
+
+// ApplyBuildCaches adds the volumes, volume mounts and environment variables of the build caches to the pod,
+// removing any build caches which are no longer configured
+func ApplyBuildCaches(pod *corev1.Pod, caches []v1.BuildCache) {
+	spec := &pod.Spec
+	volumes := []corev1.Volume{}
+	for _, v := range spec.Volumes {
+		if !strings.HasPrefix(v.Name, buildCacheVolumePrefix) {
+			volumes = append(volumes, v)
+		}
+	}
+	for _, cache := range caches {
+		name := BuildCacheName(cache.Kind)
+		volumes = append(volumes, corev1.Volume{
+			Name: name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: name,
+				},
+			},
+		})
+	}
+	spec.Volumes = volumes
+
+	for i := range spec.Containers {
+		container := &spec.Containers[i]
+		hadNpmCache := false
+		mounts := []corev1.VolumeMount{}
+		for _, m := range container.VolumeMounts {
+			if m.Name == BuildCacheName(BuildCacheNpm) {
+				hadNpmCache = true
+			}
+			if !strings.HasPrefix(m.Name, buildCacheVolumePrefix) {
+				mounts = append(mounts, m)
+			}
+		}
+		for _, cache := range caches {
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      BuildCacheName(cache.Kind),
+				MountPath: BuildCacheMountPath + "/" + cache.Kind,
+			})
+		}
+		container.VolumeMounts = mounts
+
+		removeBuildCacheEnvVars(container, hadNpmCache)
+		for k := range caches {
+			for _, e := range BuildCacheEnvVars(&caches[k]) {
+				addBuildCacheEnvVar(container, e)
+			}
+		}
+	}
+}
+
+// UpdatePodTemplatesWithBuildCaches updates all the pod templates used by builds with the build caches
+func UpdatePodTemplatesWithBuildCaches(kubeClient kubernetes.Interface, ns string, caches []v1.BuildCache) error {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find ConfigMap %s in namespace %s: %s", ConfigMapJenkinsPodTemplates, ns, err)
+	}
+	names := []string{}
+	for name := range cm.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pod := &corev1.Pod{}
+		err = yaml.Unmarshal([]byte(cm.Data[name]), pod)
+		if err != nil {
+			return fmt.Errorf("failed to parse pod template %s: %s", name, err)
+		}
+		ApplyBuildCaches(pod, caches)
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return err
+		}
+		cm.Data[name] = string(data)
+	}
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Update(cm)
+	return err
+}
+
+// UpdateMavenSettingsMirror changes the URL of the first mirror in the maven settings.xml or adds a mirror of all
+// repositories if there is none
+func UpdateMavenSettingsMirror(settingsXML string, url string) string {
+	loc := mavenMirrorURLRegex.FindStringSubmatchIndex(settingsXML)
+	if loc != nil {
+		return settingsXML[0:loc[4]] + url + settingsXML[loc[5]:]
+	}
+	mirror := `  <mirrors>
+    <mirror>
+      <id>jx-build-cache</id>
+      <mirrorOf>external:*</mirrorOf>
+      <url>` + url + `</url>
+    </mirror>
+  </mirrors>
+`
+	idx := strings.LastIndex(settingsXML, "</settings>")
+	if idx < 0 {
+		return settingsXML
+	}
+	return settingsXML[0:idx] + mirror + settingsXML[idx:]
+}
+
+// UpdateMavenSettingsSecretMirror updates the maven settings.xml used by builds to download via the given URL
+func UpdateMavenSettingsSecretMirror(kubeClient kubernetes.Interface, ns string, url string) error {
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(SecretJenkinsMavenSettings, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find Secret %s in namespace %s: %s", SecretJenkinsMavenSettings, ns, err)
+	}
+	key := "settings.xml"
+	settings := string(secret.Data[key])
+	if settings == "" {
+		return fmt.Errorf("no %s found in Secret %s in namespace %s", key, SecretJenkinsMavenSettings, ns)
+	}
+	secret.Data[key] = []byte(UpdateMavenSettingsMirror(settings, url))
+	_, err = kubeClient.CoreV1().Secrets(ns).Update(secret)
+	return err
+}
+
+// PurgeBuildCache removes the contents of the build cache by running a Job which mounts its PersistentVolumeClaim.
+// The Job gets a generated name so that a Job left behind by an earlier purge which failed does not block it
+func PurgeBuildCache(kubeClient kubernetes.Interface, ns string, kind string, timeout time.Duration) error {
+	name := BuildCacheName(kind)
+	backoffLimit := int32(1)
+	dir := BuildCacheMountPath + "/" + kind
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "purge-" + name + "-",
+			Labels: map[string]string{
+				LabelBuildCache: kind,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:    "purge",
+							Image:   "busybox",
+							Command: []string{"sh", "-c", "rm -rf " + dir + "/* " + dir + "/.[!.]*"},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      name,
+									MountPath: dir,
+								},
+							},
+						},
+					},
+					Volumes: []corev1.Volume{
+						{
+							Name: name,
+							VolumeSource: corev1.VolumeSource{
+								PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+									ClaimName: name,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	job, err := kubeClient.BatchV1().Jobs(ns).Create(job)
+	if err != nil {
+		return fmt.Errorf("failed to create the Job purging build cache %s in namespace %s: %s", kind, ns, err)
+	}
+	jobName := job.Name
+	err = WaitForJobToSucceeded(kubeClient, ns, jobName, timeout)
+	if err != nil {
+		return err
+	}
+	return DeleteJob(kubeClient, ns, jobName)
+}
+
+// removeBuildCacheEnvVars removes any environment variables previously added for build caches
+func removeBuildCacheEnvVars(container *corev1.Container, hadNpmCache bool) {
+	env := []corev1.EnvVar{}
+	for _, e := range container.Env {
+		switch e.Name {
+		case "MAVEN_OPTS":
+			options := []string{}
+			for _, o := range strings.Fields(e.Value) {
+				if !strings.HasPrefix(o, mavenRepoLocalOption+BuildCacheMountPath) {
+					options = append(options, o)
+				}
+			}
+			if len(options) == 0 {
+				continue
+			}
+			e.Value = strings.Join(options, " ")
+		case "GRADLE_USER_HOME", "npm_config_cache":
+			if strings.HasPrefix(e.Value, BuildCacheMountPath) {
+				continue
+			}
+		case "npm_config_registry":
+			if hadNpmCache {
+				continue
+			}
+		}
+		env = append(env, e)
+	}
+	container.Env = env
+}
+
+// addBuildCacheEnvVar adds the environment variable appending to MAVEN_OPTS if its already defined
+func addBuildCacheEnvVar(container *corev1.Container, envVar corev1.EnvVar) {
+	for i := range container.Env {
+		e := &container.Env[i]
+		if e.Name == envVar.Name {
+			if e.Name == "MAVEN_OPTS" && e.Value != "" {
+				e.Value = e.Value + " " + envVar.Value
+			} else {
+				e.Value = envVar.Value
+				e.ValueFrom = nil
+			}
+			return
+		}
+	}
+	container.Env = append(container.Env, envVar)
+}
@@ -0,0 +1,111 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestApplyBuildCaches(t *testing.T) {
+	t.Parallel()
+
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{Name: "workspace"}},
+			Containers: []corev1.Container{
+				{
+					Name:         "maven",
+					Env:          []corev1.EnvVar{{Name: "MAVEN_OPTS", Value: "-Xmx192m"}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "workspace", MountPath: "/workspace"}},
+				},
+			},
+		},
+	}
+	caches := []v1.BuildCache{
+		{Kind: kube.BuildCacheMaven},
+		{Kind: kube.BuildCacheNpm, RegistryURL: "http://nexus/repository/npm-group/"},
+	}
+
+	kube.ApplyBuildCaches(pod, caches)
+	kube.ApplyBuildCaches(pod, caches)
+
+	container := pod.Spec.Containers[0]
+	assert.Equal(t, 3, len(pod.Spec.Volumes))
+	assert.Equal(t, 3, len(container.VolumeMounts))
+	assert.Equal(t, "-Xmx192m -Dmaven.repo.local=/cache/maven", kube.GetEnvVar(&container, "MAVEN_OPTS").Value)
+	assert.Equal(t, "/cache/npm", kube.GetEnvVar(&container, "npm_config_cache").Value)
+	assert.Equal(t, "http://nexus/repository/npm-group/", kube.GetEnvVar(&container, "npm_config_registry").Value)
+
+	kube.ApplyBuildCaches(pod, nil)
+
+	container = pod.Spec.Containers[0]
+	assert.Equal(t, 1, len(pod.Spec.Volumes))
+	assert.Equal(t, 1, len(container.VolumeMounts))
+	assert.Equal(t, "-Xmx192m", kube.GetEnvVar(&container, "MAVEN_OPTS").Value)
+	assert.Nil(t, kube.GetEnvVar(&container, "npm_config_cache"))
+	assert.Nil(t, kube.GetEnvVar(&container, "npm_config_registry"))
+}
+
+func TestUpdateMavenSettingsMirror(t *testing.T) {
+	t.Parallel()
+
+	settings := `<settings>
+  <mirrors>
+    <mirror>
+      <id>nexus</id>
+      <mirrorOf>external:*</mirrorOf>
+      <url>http://nexus/repository/maven-group/</url>
+    </mirror>
+  </mirrors>
+</settings>
+`
+	actual := kube.UpdateMavenSettingsMirror(settings, "http://cache.acme.com/maven/")
+	assert.Contains(t, actual, "<url>http://cache.acme.com/maven/</url>")
+	assert.NotContains(t, actual, "maven-group")
+
+	actual = kube.UpdateMavenSettingsMirror("<settings>\n</settings>\n", "http://cache.acme.com/maven/")
+	assert.Contains(t, actual, "<mirrorOf>external:*</mirrorOf>")
+	assert.Contains(t, actual, "<url>http://cache.acme.com/maven/</url>")
+}
+
+func TestEnsureBuildCachePVC(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	kubeClient := fake.NewSimpleClientset()
+	cache := &v1.BuildCache{Kind: kube.BuildCacheMaven, StorageSize: "5Gi"}
+
+	err := kube.EnsureBuildCachePVC(kubeClient, ns, cache)
+	require.NoError(t, err)
+	pvc, err := kubeClient.CoreV1().PersistentVolumeClaims(ns).Get(kube.BuildCacheName(cache.Kind), metav1.GetOptions{})
+	require.NoError(t, err)
+	size := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "5Gi", size.String())
+
+	cache.StorageSize = "20Gi"
+	err = kube.EnsureBuildCachePVC(kubeClient, ns, cache)
+	require.NoError(t, err)
+	pvc, err = kubeClient.CoreV1().PersistentVolumeClaims(ns).Get(kube.BuildCacheName(cache.Kind), metav1.GetOptions{})
+	require.NoError(t, err)
+	size = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	assert.Equal(t, "5Gi", size.String(), "an existing PVC is left alone")
+
+	forbidden := fake.NewSimpleClientset()
+	forbidden.PrependReactor("get", "persistentvolumeclaims", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(schema.GroupResource{Resource: "persistentvolumeclaims"}, "", nil)
+	})
+	err = kube.EnsureBuildCachePVC(forbidden, ns, cache)
+	assert.Error(t, err)
+	list, err := forbidden.CoreV1().PersistentVolumeClaims(ns).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, list.Items, "no PVC is created when the lookup fails")
+}
@@ -0,0 +1,301 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/util"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// AnnotationBuildPodCustomization the annotation on pod templates recording the original values replaced and the
+	// resources added by build pod customizations so that they can be reverted when the customizations change
+	AnnotationBuildPodCustomization = "jenkins.io/build-pod-customization"
+
+	// SidecarDocker the name of the docker daemon sidecar
+	SidecarDocker = "docker"
+	// SidecarBuildKit the name of the buildkit daemon sidecar
+	SidecarBuildKit = "buildkit"
+)
+
+var (
+	// BuildPodSidecars the names of the sidecars which can be added to build pods
+	BuildPodSidecars = []string{SidecarDocker, SidecarBuildKit}
+)
+
+// appliedBuildPodCustomization records the original values of the pod template which were replaced and the names of
+// the resources which were added by customizations
+type appliedBuildPodCustomization struct {
+	Image              *string                      `json:"image,omitempty"`
+	Resources          *corev1.ResourceRequirements `json:"resources,omitempty"`
+	ServiceAccountName *string                      `json:"serviceAccountName,omitempty"`
+	ServiceAccount     *string                      `json:"serviceAccount,omitempty"`
+	Env                []corev1.EnvVar              `json:"env,omitempty"`
+	EnvNames           []string                     `json:"envNames,omitempty"`
+	Volumes            []string                     `json:"volumes,omitempty"`
+	VolumeMounts       []string                     `json:"volumeMounts,omitempty"`
+	Sidecars           []string                     `json:"sidecars,omitempty"`
+}
+
+// NewSidecar returns the sidecar container and the environment variables the builder container needs to use it
+func NewSidecar(name string) (*corev1.Container, []corev1.EnvVar, error) {
+	privileged := true
+	switch name {
+	case SidecarDocker:
+		return &corev1.Container{
+			Name:            SidecarDocker,
+			Image:           "docker:18.09-dind",
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+			Env:             []corev1.EnvVar{{Name: "DOCKER_TLS_CERTDIR", Value: ""}},
+		}, []corev1.EnvVar{
+			{Name: "DOCKER_HOST", Value: "tcp://localhost:2375"},
+		}, nil
+	case SidecarBuildKit:
+		return &corev1.Container{
+			Name:            SidecarBuildKit,
+			Image:           "moby/buildkit:v0.3.3",
+			Args:            []string{"--addr", "tcp://0.0.0.0:1234"},
+			SecurityContext: &corev1.SecurityContext{Privileged: &privileged},
+		}, []corev1.EnvVar{
+			{Name: "BUILDKIT_HOST", Value: "tcp://localhost:1234"},
+		}, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown sidecar %s. Supported sidecars are: %v", name, BuildPodSidecars)
+	}
+}
+
+// FindBuildPodCustomization returns the customization of the pod template or nil if there is none. Use a blank
+// pod template name for the customization of all pod templates
+func FindBuildPodCustomization(customizations []v1.BuildPodCustomization, podTemplate string) *v1.BuildPodCustomization {
+	for i := range customizations {
+		if customizations[i].PodTemplate == podTemplate {
+			return &customizations[i]
+		}
+	}
+	return nil
+}
+
+// MatchingBuildPodCustomizations returns the customizations which apply to the pod template with those of all pod
+// templates first so that the customizations of the pod template take precedence
+func MatchingBuildPodCustomizations(customizations []v1.BuildPodCustomization, podTemplate string) []v1.BuildPodCustomization {
+	answer := []v1.BuildPodCustomization{}
+	if c := FindBuildPodCustomization(customizations, ""); c != nil {
+		answer = append(answer, *c)
+	}
+	if podTemplate != "" {
+		if c := FindBuildPodCustomization(customizations, podTemplate); c != nil {
+			answer = append(answer, *c)
+		}
+	}
+	return answer
+}
+
+// ApplyBuildPodCustomizations reverts any previously applied customizations of the pod template then applies the
+// customizations which match the name of the pod template. The first container of the pod is the builder container
+func ApplyBuildPodCustomizations(pod *corev1.Pod, name string, customizations []v1.BuildPodCustomization) error {
+	err := revertBuildPodCustomizations(pod)
+	if err != nil {
+		return err
+	}
+	matching := MatchingBuildPodCustomizations(customizations, name)
+	if len(matching) == 0 {
+		return nil
+	}
+	if len(pod.Spec.Containers) == 0 {
+		return fmt.Errorf("pod template %s has no containers", name)
+	}
+	applied := &appliedBuildPodCustomization{}
+	spec := &pod.Spec
+	for _, c := range matching {
+		builder := &spec.Containers[0]
+		if c.Image != "" {
+			if applied.Image == nil {
+				original := builder.Image
+				applied.Image = &original
+			}
+			builder.Image = c.Image
+		}
+		if c.Resources != nil {
+			if applied.Resources == nil {
+				applied.Resources = builder.Resources.DeepCopy()
+			}
+			builder.Resources = *c.Resources.DeepCopy()
+		}
+		if c.ServiceAccountName != "" {
+			if applied.ServiceAccountName == nil {
+				originalName := spec.ServiceAccountName
+				original := spec.DeprecatedServiceAccount
+				applied.ServiceAccountName = &originalName
+				applied.ServiceAccount = &original
+			}
+			spec.ServiceAccountName = c.ServiceAccountName
+			spec.DeprecatedServiceAccount = c.ServiceAccountName
+		}
+		for _, e := range c.Env {
+			env := []corev1.EnvVar{}
+			for _, existing := range builder.Env {
+				if existing.Name != e.Name {
+					env = append(env, existing)
+				} else if util.StringArrayIndex(applied.EnvNames, e.Name) < 0 {
+					applied.Env = append(applied.Env, existing)
+				}
+			}
+			builder.Env = append(env, e)
+			if util.StringArrayIndex(applied.EnvNames, e.Name) < 0 {
+				applied.EnvNames = append(applied.EnvNames, e.Name)
+			}
+		}
+		for _, v := range c.Volumes {
+			if GetVolume(&spec.Volumes, v.Name) != nil {
+				if util.StringArrayIndex(applied.Volumes, v.Name) < 0 {
+					return fmt.Errorf("pod template %s already has a volume called %s", name, v.Name)
+				}
+				continue
+			}
+			spec.Volumes = append(spec.Volumes, v)
+			applied.Volumes = append(applied.Volumes, v.Name)
+		}
+		for _, m := range c.VolumeMounts {
+			if GetVolumeMount(&builder.VolumeMounts, m.Name) != nil {
+				if util.StringArrayIndex(applied.VolumeMounts, m.Name) < 0 {
+					return fmt.Errorf("pod template %s already mounts the volume %s", name, m.Name)
+				}
+				continue
+			}
+			builder.VolumeMounts = append(builder.VolumeMounts, m)
+			applied.VolumeMounts = append(applied.VolumeMounts, m.Name)
+		}
+		for _, sidecar := range c.Sidecars {
+			if findContainer(spec.Containers, sidecar.Name) >= 0 {
+				if util.StringArrayIndex(applied.Sidecars, sidecar.Name) < 0 {
+					return fmt.Errorf("pod template %s already has a container called %s", name, sidecar.Name)
+				}
+				continue
+			}
+			spec.Containers = append(spec.Containers, sidecar)
+			applied.Sidecars = append(applied.Sidecars, sidecar.Name)
+		}
+	}
+	data, err := json.Marshal(applied)
+	if err != nil {
+		return err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationBuildPodCustomization] = string(data)
+	return nil
+}
+
+// UpdatePodTemplatesWithBuildPodCustomizations updates all the pod templates used by builds with the customizations
+func UpdatePodTemplatesWithBuildPodCustomizations(kubeClient kubernetes.Interface, ns string, customizations []v1.BuildPodCustomization) error {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find ConfigMap %s in namespace %s: %s", ConfigMapJenkinsPodTemplates, ns, err)
+	}
+	names := []string{}
+	for name := range cm.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, c := range customizations {
+		if c.PodTemplate != "" && cm.Data[c.PodTemplate] == "" {
+			return fmt.Errorf("no pod template called %s in ConfigMap %s. Available pod templates are: %v", c.PodTemplate, ConfigMapJenkinsPodTemplates, names)
+		}
+	}
+	for _, name := range names {
+		pod := &corev1.Pod{}
+		err = yaml.Unmarshal([]byte(cm.Data[name]), pod)
+		if err != nil {
+			return fmt.Errorf("failed to parse pod template %s: %s", name, err)
+		}
+		err = ApplyBuildPodCustomizations(pod, name, customizations)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return err
+		}
+		cm.Data[name] = string(data)
+	}
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Update(cm)
+	return err
+}
+
+// revertBuildPodCustomizations restores the original values of the pod template and removes any resources added by
+// previously applied customizations
+func revertBuildPodCustomizations(pod *corev1.Pod) error {
+	text := pod.Annotations[AnnotationBuildPodCustomization]
+	if text == "" {
+		return nil
+	}
+	applied := &appliedBuildPodCustomization{}
+	err := json.Unmarshal([]byte(text), applied)
+	if err != nil {
+		return fmt.Errorf("failed to parse annotation %s on pod %s: %s", AnnotationBuildPodCustomization, pod.Name, err)
+	}
+	delete(pod.Annotations, AnnotationBuildPodCustomization)
+
+	spec := &pod.Spec
+	containers := []corev1.Container{}
+	for _, c := range spec.Containers {
+		if util.StringArrayIndex(applied.Sidecars, c.Name) < 0 {
+			containers = append(containers, c)
+		}
+	}
+	spec.Containers = containers
+	volumes := []corev1.Volume{}
+	for _, v := range spec.Volumes {
+		if util.StringArrayIndex(applied.Volumes, v.Name) < 0 {
+			volumes = append(volumes, v)
+		}
+	}
+	spec.Volumes = volumes
+	if applied.ServiceAccountName != nil {
+		spec.ServiceAccountName = *applied.ServiceAccountName
+	}
+	if applied.ServiceAccount != nil {
+		spec.DeprecatedServiceAccount = *applied.ServiceAccount
+	}
+	if len(spec.Containers) == 0 {
+		return nil
+	}
+	builder := &spec.Containers[0]
+	if applied.Image != nil {
+		builder.Image = *applied.Image
+	}
+	if applied.Resources != nil {
+		builder.Resources = *applied.Resources
+	}
+	env := []corev1.EnvVar{}
+	for _, e := range builder.Env {
+		if util.StringArrayIndex(applied.EnvNames, e.Name) < 0 {
+			env = append(env, e)
+		}
+	}
+	builder.Env = append(env, applied.Env...)
+	mounts := []corev1.VolumeMount{}
+	for _, m := range builder.VolumeMounts {
+		if util.StringArrayIndex(applied.VolumeMounts, m.Name) < 0 {
+			mounts = append(mounts, m)
+		}
+	}
+	builder.VolumeMounts = mounts
+	return nil
+}
+
+func findContainer(containers []corev1.Container, name string) int {
+	for i, c := range containers {
+		if c.Name == name {
+			return i
+		}
+	}
+	return -1
+}
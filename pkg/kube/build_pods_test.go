@@ -0,0 +1,83 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func mavenPodTemplate() *corev1.Pod {
+	return &corev1.Pod{
+		Spec: corev1.PodSpec{
+			ServiceAccountName: "jenkins",
+			Volumes:            []corev1.Volume{{Name: "workspace-volume"}},
+			Containers: []corev1.Container{
+				{
+					Name:         "maven",
+					Image:        "jenkinsxio/builder-maven:0.0.408",
+					Env:          []corev1.EnvVar{{Name: "XDG_CONFIG_HOME", Value: "/home/jenkins"}},
+					VolumeMounts: []corev1.VolumeMount{{Name: "workspace-volume", MountPath: "/home/jenkins"}},
+				},
+			},
+		},
+	}
+}
+
+func TestApplyBuildPodCustomizations(t *testing.T) {
+	t.Parallel()
+	docker, dockerEnv, err := kube.NewSidecar(kube.SidecarDocker)
+	require.NoError(t, err)
+	_, _, err = kube.NewSidecar("podman")
+	assert.Error(t, err)
+
+	customizations := []v1.BuildPodCustomization{
+		{
+			PodTemplate: "maven",
+			Image:       "myorg/builder-maven:1.0.0",
+			Resources: &corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+			},
+			Env: []corev1.EnvVar{{Name: "XDG_CONFIG_HOME", Value: "/tmp"}},
+		},
+		{
+			ServiceAccountName: "builder",
+			Env:                dockerEnv,
+			Volumes:            []corev1.Volume{{Name: "custom-secret-npm"}},
+			VolumeMounts:       []corev1.VolumeMount{{Name: "custom-secret-npm", MountPath: "/npm"}},
+			Sidecars:           []corev1.Container{*docker},
+		},
+		{
+			PodTemplate: "nodejs",
+			Image:       "myorg/builder-nodejs:1.0.0",
+		},
+	}
+
+	pod := mavenPodTemplate()
+	require.NoError(t, kube.ApplyBuildPodCustomizations(pod, "maven", customizations))
+	require.NoError(t, kube.ApplyBuildPodCustomizations(pod, "maven", customizations))
+
+	builder := pod.Spec.Containers[0]
+	assert.Equal(t, "myorg/builder-maven:1.0.0", builder.Image)
+	assert.Equal(t, "2Gi", builder.Resources.Limits.Memory().String())
+	assert.Equal(t, "builder", pod.Spec.ServiceAccountName)
+	assert.Equal(t, "/tmp", kube.GetEnvVar(&builder, "XDG_CONFIG_HOME").Value)
+	assert.Equal(t, "tcp://localhost:2375", kube.GetEnvVar(&builder, "DOCKER_HOST").Value)
+	assert.Len(t, pod.Spec.Volumes, 2)
+	assert.Len(t, builder.VolumeMounts, 2)
+	require.Len(t, pod.Spec.Containers, 2)
+	assert.Equal(t, kube.SidecarDocker, pod.Spec.Containers[1].Name)
+
+	// removing the customizations restores the pod template
+	require.NoError(t, kube.ApplyBuildPodCustomizations(pod, "maven", nil))
+	expected := mavenPodTemplate()
+	expected.Annotations = map[string]string{}
+	assert.Equal(t, expected, pod)
+
+	conflict := []v1.BuildPodCustomization{{Volumes: []corev1.Volume{{Name: "workspace-volume"}}}}
+	assert.Error(t, kube.ApplyBuildPodCustomizations(mavenPodTemplate(), "maven", conflict))
+}
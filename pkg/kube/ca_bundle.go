@@ -0,0 +1,83 @@
+package kube
+
+import (
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// CABundleKey the key of the CA bundle in the ConfigMap of the CA bundle
+	CABundleKey = "ca-bundle.crt"
+
+	// CABundleMountPath the directory the CA bundle is mounted into in the builder container
+	CABundleMountPath = "/etc/jx/ca-bundle"
+
+	caBundleVolumeName = "jx-ca-bundle"
+)
+
+// CABundleBuildPodCustomization returns the customization of all pod templates which mounts the CA bundle of the
+// team into the builder container and points jx and node at it
+func CABundleBuildPodCustomization() v1.BuildPodCustomization {
+	fileName := filepath.Join(CABundleMountPath, CABundleKey)
+	return v1.BuildPodCustomization{
+		Env: []corev1.EnvVar{
+			{Name: "JX_CA_BUNDLE", Value: fileName},
+			{Name: "NODE_EXTRA_CA_CERTS", Value: fileName},
+		},
+		Volumes: []corev1.Volume{
+			{
+				Name: caBundleVolumeName,
+				VolumeSource: corev1.VolumeSource{
+					ConfigMap: &corev1.ConfigMapVolumeSource{
+						LocalObjectReference: corev1.LocalObjectReference{Name: ConfigMapNameJXCABundle},
+					},
+				},
+			},
+		},
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: caBundleVolumeName, MountPath: CABundleMountPath, ReadOnly: true},
+		},
+	}
+}
+
+// BuildPodCustomizations returns the customizations of the build pods of the team including the mount of the CA
+// bundle if the team has one
+func BuildPodCustomizations(settings *v1.TeamSettings) []v1.BuildPodCustomization {
+	answer := append([]v1.BuildPodCustomization{}, settings.BuildPods...)
+	if settings.CABundle != "" {
+		answer = append(answer, CABundleBuildPodCustomization())
+	}
+	return answer
+}
+
+// UpdateCABundleConfigMap stores the CA bundle in the ConfigMap mounted into the build pods or deletes the ConfigMap
+// if the bundle is blank
+func UpdateCABundleConfigMap(kubeClient kubernetes.Interface, ns string, caBundle string) error {
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(ConfigMapNameJXCABundle, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		if caBundle == "" {
+			return nil
+		}
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ConfigMapNameJXCABundle},
+			Data:       map[string]string{CABundleKey: caBundle},
+		}
+		_, err = configMaps.Create(cm)
+		return err
+	}
+	if caBundle == "" {
+		return configMaps.Delete(ConfigMapNameJXCABundle, nil)
+	}
+	cm.Data = map[string]string{CABundleKey: caBundle}
+	_, err = configMaps.Update(cm)
+	return err
+}
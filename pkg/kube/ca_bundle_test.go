@@ -0,0 +1,50 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCABundleBuildPodCustomization(t *testing.T) {
+	t.Parallel()
+	settings := &v1.TeamSettings{BuildPods: []v1.BuildPodCustomization{{PodTemplate: "maven", Image: "myorg/builder-maven:1.0.0"}}}
+	assert.Len(t, kube.BuildPodCustomizations(settings), 1)
+
+	settings.CABundle = "-----BEGIN CERTIFICATE-----"
+	customizations := kube.BuildPodCustomizations(settings)
+	require.Len(t, customizations, 2)
+	assert.Len(t, settings.BuildPods, 1, "the team settings are not modified")
+
+	pod := mavenPodTemplate()
+	require.NoError(t, kube.ApplyBuildPodCustomizations(pod, "maven", customizations))
+	builder := pod.Spec.Containers[0]
+	assert.Equal(t, "/etc/jx/ca-bundle/ca-bundle.crt", kube.GetEnvVar(&builder, "JX_CA_BUNDLE").Value)
+	assert.Equal(t, "/etc/jx/ca-bundle/ca-bundle.crt", kube.GetEnvVar(&builder, "NODE_EXTRA_CA_CERTS").Value)
+	require.Len(t, pod.Spec.Volumes, 2)
+	assert.Equal(t, kube.ConfigMapNameJXCABundle, pod.Spec.Volumes[1].ConfigMap.Name)
+	require.Len(t, builder.VolumeMounts, 2)
+	assert.Equal(t, kube.CABundleMountPath, builder.VolumeMounts[1].MountPath)
+}
+
+func TestUpdateCABundleConfigMap(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	ns := "jx"
+
+	require.NoError(t, kube.UpdateCABundleConfigMap(client, ns, ""))
+	require.NoError(t, kube.UpdateCABundleConfigMap(client, ns, "first"))
+	require.NoError(t, kube.UpdateCABundleConfigMap(client, ns, "second"))
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapNameJXCABundle, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "second", cm.Data[kube.CABundleKey])
+
+	require.NoError(t, kube.UpdateCABundleConfigMap(client, ns, ""))
+	_, err = client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapNameJXCABundle, metav1.GetOptions{})
+	assert.Error(t, err)
+}
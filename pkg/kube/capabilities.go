@@ -0,0 +1,166 @@
+package kube
+
+import (
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// rbacAPIGroup the API group which is served when RBAC is enabled
+const rbacAPIGroup = "rbac.authorization.k8s.io"
+
+// ClusterCapabilities what an existing cluster provides for the platform
+type ClusterCapabilities struct {
+	// RBAC true if the cluster has RBAC enabled
+	RBAC bool
+	// DefaultStorageClass the name of the default storage class or empty if there is none
+	DefaultStorageClass string
+	// IngressNamespace the namespace of the ingress controller or empty if there is none
+	IngressNamespace string
+	// IngressDeployment the name of the Deployment of the ingress controller
+	IngressDeployment string
+	// IngressService the name of the Service of the ingress controller or empty if it has none
+	IngressService string
+	// LoadBalancer true if Services of type LoadBalancer are assigned an address
+	LoadBalancer bool
+	// NodeName the name of a ready node which can be used to expose services when there are no load balancers
+	NodeName string
+	// NodeAddress the external, or if there is none the internal, address of the node
+	NodeAddress string
+}
+
+// HasIngressController returns true if the cluster already runs an ingress controller
+func (c *ClusterCapabilities) HasIngressController() bool {
+	return c.IngressDeployment != ""
+}
+
+// DetectClusterCapabilities detects whether the cluster has RBAC, a default storage class, an ingress controller
+// and load balancers
+func DetectClusterCapabilities(client kubernetes.Interface) (*ClusterCapabilities, error) {
+	answer := &ClusterCapabilities{}
+	groups, err := client.Discovery().ServerGroups()
+	if err != nil {
+		return answer, err
+	}
+	for _, g := range groups.Groups {
+		if g.Name == rbacAPIGroup {
+			answer.RBAC = true
+		}
+	}
+
+	sc, err := GetDefaultStorageClass(client)
+	if err != nil {
+		return answer, err
+	}
+	if sc != nil {
+		answer.DefaultStorageClass = sc.Name
+	}
+
+	deployments, err := client.AppsV1().Deployments("").List(metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		if IsIngressControllerDeployment(d) {
+			answer.IngressNamespace = d.Namespace
+			answer.IngressDeployment = d.Name
+			break
+		}
+	}
+
+	services, err := client.CoreV1().Services("").List(metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for i := range services.Items {
+		svc := &services.Items[i]
+		if svc.Spec.Type == v1.ServiceTypeLoadBalancer && HasExternalAddress(svc) {
+			answer.LoadBalancer = true
+		}
+		if answer.IngressService == "" && svc.Namespace == answer.IngressNamespace && svc.Spec.Type != v1.ServiceTypeClusterIP {
+			for j := range deployments.Items {
+				d := &deployments.Items[j]
+				if d.Namespace == answer.IngressNamespace && d.Name == answer.IngressDeployment && selectorMatches(svc.Spec.Selector, d.Spec.Template.Labels) {
+					answer.IngressService = svc.Name
+				}
+			}
+		}
+	}
+
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		// nodes of cloud providers have a provider ID and can have load balancers provisioned for them
+		if node.Spec.ProviderID != "" {
+			answer.LoadBalancer = true
+		}
+		if answer.NodeName == "" && IsNodeReady(node) && !node.Spec.Unschedulable {
+			address := NodeAddress(node)
+			if address != "" {
+				answer.NodeName = node.Name
+				answer.NodeAddress = address
+			}
+		}
+	}
+	return answer, nil
+}
+
+// IsIngressControllerDeployment returns true if the Deployment runs a well known ingress controller
+func IsIngressControllerDeployment(d *appsv1.Deployment) bool {
+	name := strings.ToLower(d.Name)
+	if strings.Contains(name, "ingress") && strings.Contains(name, "controller") {
+		return true
+	}
+	for _, c := range d.Spec.Template.Spec.Containers {
+		image := strings.ToLower(c.Image)
+		for _, known := range []string{"ingress-controller", "traefik", "contour", "haproxy-ingress"} {
+			if strings.Contains(image, known) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsNodeReady returns true if the node has a true Ready condition
+func IsNodeReady(node *v1.Node) bool {
+	for _, c := range node.Status.Conditions {
+		if c.Type == v1.NodeReady {
+			return c.Status == v1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// NodeAddress returns the external IP of the node or its internal IP if it has no external IP
+func NodeAddress(node *v1.Node) string {
+	internal := ""
+	for _, a := range node.Status.Addresses {
+		if a.Type == v1.NodeExternalIP && a.Address != "" {
+			return a.Address
+		}
+		if a.Type == v1.NodeInternalIP && internal == "" {
+			internal = a.Address
+		}
+	}
+	return internal
+}
+
+func selectorMatches(selector map[string]string, labels map[string]string) bool {
+	if len(selector) == 0 {
+		return false
+	}
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+	return true
+}
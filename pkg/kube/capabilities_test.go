@@ -0,0 +1,88 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDetectClusterCapabilitiesOnPremise(t *testing.T) {
+	t.Parallel()
+	labels := map[string]string{"app": "nginx-ingress", "component": "controller"}
+	client := fake.NewSimpleClientset(
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "local",
+				Annotations: map[string]string{kube.AnnotationIsDefaultStorageClass: "true"},
+			},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "ingress"},
+			Spec: appsv1.DeploymentSpec{
+				Template: v1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: labels},
+					Spec: v1.PodSpec{
+						Containers: []v1.Container{{Image: "quay.io/kubernetes-ingress-controller/nginx-ingress-controller:0.22.0"}},
+					},
+				},
+			},
+		},
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "nginx", Namespace: "ingress"},
+			Spec:       v1.ServiceSpec{Type: v1.ServiceTypeNodePort, Selector: labels},
+		},
+		&v1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+			Status: v1.NodeStatus{
+				Conditions: []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+				Addresses:  []v1.NodeAddress{{Type: v1.NodeInternalIP, Address: "10.0.0.5"}},
+			},
+		},
+	)
+	client.Resources = []*metav1.APIResourceList{{GroupVersion: "rbac.authorization.k8s.io/v1"}}
+
+	caps, err := kube.DetectClusterCapabilities(client)
+	require.NoError(t, err)
+	assert.True(t, caps.RBAC)
+	assert.Equal(t, "local", caps.DefaultStorageClass)
+	assert.True(t, caps.HasIngressController())
+	assert.Equal(t, "ingress", caps.IngressNamespace)
+	assert.Equal(t, "nginx", caps.IngressDeployment)
+	assert.Equal(t, "nginx", caps.IngressService)
+	assert.False(t, caps.LoadBalancer)
+	assert.Equal(t, "node1", caps.NodeName)
+	assert.Equal(t, "10.0.0.5", caps.NodeAddress)
+}
+
+func TestDetectClusterCapabilitiesCloud(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(&v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node1"},
+		Spec:       v1.NodeSpec{ProviderID: "gce://my-project/europe-west1-b/node1"},
+		Status: v1.NodeStatus{
+			Addresses: []v1.NodeAddress{
+				{Type: v1.NodeInternalIP, Address: "10.0.0.5"},
+				{Type: v1.NodeExternalIP, Address: "35.1.2.3"},
+			},
+		},
+	})
+
+	caps, err := kube.DetectClusterCapabilities(client)
+	require.NoError(t, err)
+	assert.False(t, caps.RBAC)
+	assert.Equal(t, "", caps.DefaultStorageClass)
+	assert.False(t, caps.HasIngressController())
+	assert.True(t, caps.LoadBalancer)
+	assert.Equal(t, "", caps.NodeName, "the node is not ready")
+	assert.Equal(t, "35.1.2.3", kube.NodeAddress(&v1.Node{Status: v1.NodeStatus{Addresses: []v1.NodeAddress{
+		{Type: v1.NodeInternalIP, Address: "10.0.0.5"},
+		{Type: v1.NodeExternalIP, Address: "35.1.2.3"},
+	}}}))
+}
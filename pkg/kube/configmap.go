@@ -54,27 +54,36 @@ func ExtractDomainValue(data map[string]string) (string, error) {
 func SaveAsConfigMap(c kubernetes.Interface, configMapName string, ns string, obj interface{}) (*v1.ConfigMap, error) {
 	config := util.ToStringMapStringFromStruct(obj)
 
-	cm, err := c.CoreV1().ConfigMaps(ns).Get(configMapName, meta_v1.GetOptions{})
+	var answer *v1.ConfigMap
+	err := RetryOnAPIError(func() error {
+		cm, err := c.CoreV1().ConfigMaps(ns).Get(configMapName, meta_v1.GetOptions{})
 
-	if err != nil {
-		cm := &v1.ConfigMap{
-			Data: config,
-			ObjectMeta: meta_v1.ObjectMeta{
-				Name: configMapName,
-			},
-		}
-		_, err := c.CoreV1().ConfigMaps(ns).Create(cm)
 		if err != nil {
-			return &v1.ConfigMap{}, err
+			cm := &v1.ConfigMap{
+				Data: config,
+				ObjectMeta: meta_v1.ObjectMeta{
+					Name: configMapName,
+				},
+			}
+			_, err := c.CoreV1().ConfigMaps(ns).Create(cm)
+			if err != nil {
+				return err
+			}
+			answer = cm
+			return nil
 		}
-		return cm, nil
-	}
 
-	// replace configmap values if it already exists
-	cm.Data = config
-	_, err = c.CoreV1().ConfigMaps(ns).Update(cm)
+		// replace configmap values if it already exists
+		cm.Data = config
+		_, err = c.CoreV1().ConfigMaps(ns).Update(cm)
+		if err != nil {
+			return err
+		}
+		answer = cm
+		return nil
+	})
 	if err != nil {
 		return &v1.ConfigMap{}, err
 	}
-	return cm, nil
+	return answer, nil
 }
@@ -37,6 +37,9 @@ const (
 	// ServiceChartMuseum the service name of the Helm Chart Museum service
 	ServiceChartMuseum = "jenkins-x-chartmuseum"
 
+	// ServiceNexus the service name of the Nexus repository manager
+	ServiceNexus = "nexus"
+
 	// ServiceKubernetesDashboard the kubernetes dashboard
 	ServiceKubernetesDashboard = "jenkins-x-kubernetes-dashboard"
 
@@ -79,6 +82,15 @@ const (
 	// ConfigMapNameJXInstallConfig is the ConfigMap containing the jx installation's CA and server url. Used by jx login
 	ConfigMapNameJXInstallConfig = "jx-install-config"
 
+	// ConfigMapNameJXRequirements is the ConfigMap containing the requirements the platform was installed or upgraded with
+	ConfigMapNameJXRequirements = "jx-requirements"
+
+	// ConfigMapNameJXPlatformVersions is the ConfigMap containing the platform release of the version stream which was last applied
+	ConfigMapNameJXPlatformVersions = "jx-platform-versions"
+
+	// ConfigMapNameJXCABundle is the ConfigMap containing the CA bundle of the team which is mounted into the build pods
+	ConfigMapNameJXCABundle = "jx-ca-bundle"
+
 	// LocalHelmRepoName is the default name of the local chart repository where CI/CD releases go to
 	LocalHelmRepoName = "releases"
 
@@ -128,6 +140,9 @@ const (
 	// ValueKindEditNamespace for edit namespace
 	ValueKindEditNamespace = "editspace"
 
+	// ValueKindNotification a notification channel secret
+	ValueKindNotification = "notification"
+
 	// LabelServiceKind the label to indicate the auto Server's Kind
 	LabelServiceKind = "jenkins.io/service-kind"
 
@@ -170,6 +185,9 @@ const (
 	// ValueJobKindPostPreview
 	ValueJobKindPostPreview = "post-preview-step"
 
+	// ValueJobKindSmokeTest the kind of the jobs running the smoke tests of a promotion
+	ValueJobKindSmokeTest = "smoke-test"
+
 	// AnnotationURL indicates a service/server's URL
 	AnnotationURL = "jenkins.io/url"
 
@@ -189,6 +207,10 @@ const (
 	AnnotationWorkingDir = "jenkins.io/working-dir"
 	// AnnotationLocalDir the local directory that is sync'd to the DevPod
 	AnnotationLocalDir = "jenkins.io/local-dir"
+	// AnnotationDevPodTTL the duration a DevPod can be idle before it is garbage collected
+	AnnotationDevPodTTL = "jenkins.io/devpod-ttl"
+	// AnnotationDevPodLastActive the time a DevPod was last used
+	AnnotationDevPodLastActive = "jenkins.io/devpod-last-active"
 
 	// AnnotationIsDefaultStorageClass used to indicate a storageclass is default
 	AnnotationIsDefaultStorageClass = "storageclass.kubernetes.io/is-default-class"
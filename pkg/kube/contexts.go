@@ -0,0 +1,335 @@
+package kube
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// ContextHistoryFileName the name of the file in the jx config directory which stores the recently used
+	// contexts and namespaces along with the default namespaces of directories
+	ContextHistoryFileName = "kube-history.yml"
+
+	// MaxRecentEntries the maximum number of recent contexts and namespaces which are remembered
+	MaxRecentEntries = 5
+)
+
+// ContextSummary describes a kubernetes context so that it can be previewed before switching to it
+type ContextSummary struct {
+	Name      string
+	Cluster   string
+	Server    string
+	Namespace string
+	User      string
+	Provider  string
+}
+
+// DirectoryDefault the context and namespace to use when working in a directory
+type DirectoryDefault struct {
+	Context   string `json:"context,omitempty"`
+	Namespace string `json:"namespace"`
+}
+
+// ContextHistory the recently used contexts and namespaces along with the default namespaces of directories
+type ContextHistory struct {
+	RecentContexts   []string                    `json:"recentContexts,omitempty"`
+	RecentNamespaces []string                    `json:"recentNamespaces,omitempty"`
+	Directories      map[string]DirectoryDefault `json:"directories,omitempty"`
+}
+
+// CurrentContextName returns the name of the current context in the kube config without invoking kubectl
+func CurrentContextName() (string, error) {
+	config, _, err := LoadConfig()
+	if err != nil {
+		return "", err
+	}
+	if config == nil || config.CurrentContext == "" {
+		return "", fmt.Errorf("no current context is defined in the kube config")
+	}
+	return config.CurrentContext, nil
+}
+
+// SwitchContext changes the current context of the kube config
+func SwitchContext(config *api.Config, po *clientcmd.PathOptions, name string) error {
+	if config.Contexts[name] == nil {
+		return fmt.Errorf("could not find kubernetes context %s", name)
+	}
+	newConfig := *config
+	newConfig.CurrentContext = name
+	err := clientcmd.ModifyConfig(po, newConfig, false)
+	if err != nil {
+		return fmt.Errorf("failed to update the kube config %s", err)
+	}
+	config.CurrentContext = name
+	return nil
+}
+
+// SwitchNamespace changes the namespace of the current context of the kube config
+func SwitchNamespace(config *api.Config, po *clientcmd.PathOptions, ns string) error {
+	ctx := CurrentContext(config)
+	if ctx == nil {
+		return fmt.Errorf("there is no context defined in your kubernetes configuration")
+	}
+	if ctx.Namespace == ns {
+		return nil
+	}
+	ctx.Namespace = ns
+	err := clientcmd.ModifyConfig(po, *config, false)
+	if err != nil {
+		return fmt.Errorf("failed to update the kube config %s", err)
+	}
+	return nil
+}
+
+// ContextSummaries returns the summaries of all the contexts in the kube config sorted by name
+func ContextSummaries(config *api.Config) []ContextSummary {
+	answer := []ContextSummary{}
+	if config == nil {
+		return answer
+	}
+	for name, ctx := range config.Contexts {
+		if name == "" || ctx == nil {
+			continue
+		}
+		server := Server(config, ctx)
+		answer = append(answer, ContextSummary{
+			Name:      name,
+			Cluster:   ctx.Cluster,
+			Server:    server,
+			Namespace: ctx.Namespace,
+			User:      ctx.AuthInfo,
+			Provider:  DetectProvider(ctx.Cluster, server),
+		})
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Name < answer[j].Name
+	})
+	return answer
+}
+
+// Describe returns a one line description of the context showing its cluster, provider and namespace
+func (s *ContextSummary) Describe() string {
+	details := []string{}
+	if s.Provider != "" {
+		details = append(details, s.Provider)
+	}
+	if s.Cluster != "" && s.Cluster != s.Name {
+		details = append(details, "cluster: "+s.Cluster)
+	}
+	if s.Namespace != "" {
+		details = append(details, "ns: "+s.Namespace)
+	}
+	if s.Server != "" {
+		details = append(details, s.Server)
+	}
+	if len(details) == 0 {
+		return s.Name
+	}
+	return fmt.Sprintf("%s (%s)", s.Name, strings.Join(details, ", "))
+}
+
+// DetectProvider guesses the kubernetes provider of a cluster from its name and API server URL
+func DetectProvider(cluster string, server string) string {
+	switch {
+	case strings.HasPrefix(cluster, "gke_"):
+		return "gke"
+	case strings.Contains(server, ".eks.amazonaws.com"):
+		return "eks"
+	case strings.Contains(server, ".azmk8s.io"):
+		return "aks"
+	case strings.Contains(server, ".oraclecloud.com"):
+		return "oke"
+	case strings.Contains(server, ".containers.cloud.ibm.com"), strings.Contains(server, ".bluemix.net"):
+		return "iks"
+	case strings.Contains(server, ".k8s.local"), strings.Contains(cluster, ".k8s.local"):
+		return "aws"
+	case cluster == "minikube":
+		return "minikube"
+	case strings.Contains(cluster, "minishift"):
+		return "minishift"
+	case strings.HasPrefix(cluster, "docker-for-desktop"), cluster == "docker-desktop":
+		return "docker"
+	}
+	return ""
+}
+
+// FuzzyMatch returns true if all the characters of the pattern appear in order in the text ignoring case along with
+// a score which is higher the closer together the matching characters are
+func FuzzyMatch(pattern string, text string) (bool, int) {
+	if pattern == "" {
+		return true, 0
+	}
+	p := []rune(strings.ToLower(pattern))
+	score := 0
+	pi := 0
+	last := -1
+	lower := strings.ToLower(text)
+	for i, r := range lower {
+		if pi >= len(p) {
+			break
+		}
+		if r != p[pi] {
+			continue
+		}
+		switch {
+		case last >= 0 && i == last+1:
+			score += 3
+		case i == 0 || isWordBoundary(lower, i):
+			score += 2
+		default:
+			score++
+		}
+		last = i
+		pi++
+	}
+	if pi < len(p) {
+		return false, 0
+	}
+	if strings.Contains(lower, string(p)) {
+		score += len(p)
+	}
+	return true, score
+}
+
+// FuzzyFilter returns the names which fuzzy match the pattern with the best matches first
+func FuzzyFilter(pattern string, names []string) []string {
+	type match struct {
+		name  string
+		score int
+	}
+	matches := []match{}
+	for _, name := range names {
+		ok, score := FuzzyMatch(pattern, name)
+		if ok {
+			matches = append(matches, match{name, score})
+		}
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].score > matches[j].score
+	})
+	answer := []string{}
+	for _, m := range matches {
+		answer = append(answer, m.name)
+	}
+	return answer
+}
+
+// OrderByRecent moves the recently used names to the front of the names keeping the rest in their current order
+func OrderByRecent(names []string, recent []string) []string {
+	answer := []string{}
+	for _, r := range recent {
+		if util.StringArrayIndex(names, r) >= 0 && util.StringArrayIndex(answer, r) < 0 {
+			answer = append(answer, r)
+		}
+	}
+	for _, name := range names {
+		if util.StringArrayIndex(answer, name) < 0 {
+			answer = append(answer, name)
+		}
+	}
+	return answer
+}
+
+// DefaultContextHistoryFile returns the file the context history is stored in
+func DefaultContextHistoryFile() (string, error) {
+	dir, err := util.ConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, ContextHistoryFileName), nil
+}
+
+// LoadContextHistory loads the context history from the file returning an empty history if it does not exist
+func LoadContextHistory(fileName string) (*ContextHistory, error) {
+	history := &ContextHistory{}
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return history, err
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return history, fmt.Errorf("failed to load file %s due to %s", fileName, err)
+	}
+	err = yaml.Unmarshal(data, history)
+	if err != nil {
+		return history, fmt.Errorf("failed to unmarshal YAML file %s due to %s", fileName, err)
+	}
+	return history, nil
+}
+
+// Save saves the context history to the file
+func (h *ContextHistory) Save(fileName string) error {
+	data, err := yaml.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
+
+// AddRecentContext records that the context was used
+func (h *ContextHistory) AddRecentContext(name string) {
+	h.RecentContexts = addRecent(h.RecentContexts, name)
+}
+
+// AddRecentNamespace records that the namespace was used
+func (h *ContextHistory) AddRecentNamespace(ns string) {
+	h.RecentNamespaces = addRecent(h.RecentNamespaces, ns)
+}
+
+// SetDirectoryDefault sets the context and namespace to use when working in the directory
+func (h *ContextHistory) SetDirectoryDefault(dir string, context string, ns string) {
+	if h.Directories == nil {
+		h.Directories = map[string]DirectoryDefault{}
+	}
+	h.Directories[filepath.Clean(dir)] = DirectoryDefault{
+		Context:   context,
+		Namespace: ns,
+	}
+}
+
+// FindDirectoryDefault returns the default context and namespace of the directory or of its closest parent
+// directory which has a default or nil if there is none
+func (h *ContextHistory) FindDirectoryDefault(dir string) *DirectoryDefault {
+	if len(h.Directories) == 0 {
+		return nil
+	}
+	dir = filepath.Clean(dir)
+	for {
+		d, ok := h.Directories[dir]
+		if ok {
+			return &d
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil
+		}
+		dir = parent
+	}
+}
+
+func addRecent(recent []string, name string) []string {
+	if name == "" {
+		return recent
+	}
+	answer := []string{name}
+	for _, r := range recent {
+		if r != name && len(answer) < MaxRecentEntries {
+			answer = append(answer, r)
+		}
+	}
+	return answer
+}
+
+func isWordBoundary(text string, i int) bool {
+	prev := rune(text[i-1])
+	return !unicode.IsLetter(prev) && !unicode.IsDigit(prev)
+}
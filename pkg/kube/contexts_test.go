@@ -0,0 +1,82 @@
+package kube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestFuzzyFilter(t *testing.T) {
+	t.Parallel()
+	names := []string{"default", "jx", "jx-production", "jx-staging", "kube-system"}
+
+	assert.Equal(t, []string{"jx-staging"}, kube.FuzzyFilter("stg", names))
+	assert.Equal(t, []string{"jx-production"}, kube.FuzzyFilter("JXPROD", names))
+	assert.Equal(t, names, kube.FuzzyFilter("", names))
+	assert.Empty(t, kube.FuzzyFilter("xyz", names))
+
+	matches := kube.FuzzyFilter("jx", names)
+	require.Len(t, matches, 3)
+	assert.Equal(t, "jx", matches[0])
+}
+
+func TestOrderByRecent(t *testing.T) {
+	t.Parallel()
+	names := []string{"a", "b", "c", "d"}
+	assert.Equal(t, []string{"c", "a", "b", "d"}, kube.OrderByRecent(names, []string{"c", "missing", "a"}))
+}
+
+func TestContextSummaries(t *testing.T) {
+	t.Parallel()
+	config := api.NewConfig()
+	config.Clusters["gke_acme_europe-west1-b_prod"] = &api.Cluster{Server: "https://35.1.2.3"}
+	config.Clusters["eks"] = &api.Cluster{Server: "https://ABC.yl4.eu-west-1.eks.amazonaws.com"}
+	config.Contexts["prod"] = &api.Context{Cluster: "gke_acme_europe-west1-b_prod", Namespace: "jx"}
+	config.Contexts["eks"] = &api.Context{Cluster: "eks"}
+
+	summaries := kube.ContextSummaries(config)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, "eks", summaries[0].Name)
+	assert.Equal(t, "eks", summaries[0].Provider)
+	assert.Equal(t, "eks (eks, https://ABC.yl4.eu-west-1.eks.amazonaws.com)", summaries[0].Describe())
+	assert.Equal(t, "gke", summaries[1].Provider)
+	assert.Equal(t, "prod (gke, cluster: gke_acme_europe-west1-b_prod, ns: jx, https://35.1.2.3)", summaries[1].Describe())
+
+	assert.Equal(t, "minikube", kube.DetectProvider("minikube", "https://192.168.99.100:8443"))
+	assert.Equal(t, "aks", kube.DetectProvider("jx", "https://jx-1234.hcp.westeurope.azmk8s.io:443"))
+	assert.Equal(t, "", kube.DetectProvider("kubernetes", "https://10.0.0.1"))
+}
+
+func TestContextHistory(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-context-history")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, kube.ContextHistoryFileName)
+
+	history, err := kube.LoadContextHistory(fileName)
+	require.NoError(t, err)
+	for _, ns := range []string{"a", "b", "c", "d", "e", "f", "b"} {
+		history.AddRecentNamespace(ns)
+	}
+	history.AddRecentContext("minikube")
+	history.SetDirectoryDefault("/work/cheese", "minikube", "jx-staging")
+	require.NoError(t, history.Save(fileName))
+
+	history, err = kube.LoadContextHistory(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "f", "e", "d", "c"}, history.RecentNamespaces)
+	assert.Equal(t, []string{"minikube"}, history.RecentContexts)
+
+	d := history.FindDirectoryDefault("/work/cheese/charts/cheese")
+	require.NotNil(t, d)
+	assert.Equal(t, "jx-staging", d.Namespace)
+	assert.Equal(t, "minikube", d.Context)
+	assert.Nil(t, history.FindDirectoryDefault("/work/wine"))
+}
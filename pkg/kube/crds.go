@@ -20,6 +20,39 @@ const (
 	CertmanagerIssuerStaging      = "letsencrypt-staging"
 )
 
+// RegisterAddonCRD ensures that the CRD is registered for Addon
+func RegisterAddonCRD(apiClient apiextensionsclientset.Interface) error {
+	name := "addons." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "Addon",
+		ListKind:   "AddonList",
+		Plural:     "addons",
+		Singular:   "addon",
+		ShortNames: []string{"addon"},
+	}
+	columns := []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "Chart",
+			Type:        "string",
+			Description: "The chart of the addon",
+			JSONPath:    ".spec.chart",
+		},
+		{
+			Name:        "Version",
+			Type:        "string",
+			Description: "The installed version of the chart",
+			JSONPath:    ".status.version",
+		},
+		{
+			Name:        "Phase",
+			Type:        "string",
+			Description: "The phase of the lifecycle of the addon",
+			JSONPath:    ".status.phase",
+		},
+	}
+	return registerCRD(apiClient, name, names, columns)
+}
+
 // RegisterEnvironmentCRD ensures that the CRD is registered for Environments
 func RegisterEnvironmentCRD(apiClient apiextensionsclientset.Interface) error {
 	name := "environments." + jenkinsio.GroupName
@@ -99,6 +132,45 @@ func RegisterGitServiceCRD(apiClient apiextensionsclientset.Interface) error {
 	return registerCRD(apiClient, name, names, columns)
 }
 
+// RegisterHookFailureCRD ensures that the CRD is registered for HookFailure
+func RegisterHookFailureCRD(apiClient apiextensionsclientset.Interface) error {
+	name := "hookfailures." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "HookFailure",
+		ListKind:   "HookFailureList",
+		Plural:     "hookfailures",
+		Singular:   "hookfailure",
+		ShortNames: []string{"hookfail"},
+	}
+	columns := []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "Event",
+			Type:        "string",
+			Description: "The kind of webhook event",
+			JSONPath:    ".spec.event",
+		},
+		{
+			Name:        "Repository",
+			Type:        "string",
+			Description: "The git repository the event is about",
+			JSONPath:    ".spec.repository",
+		},
+		{
+			Name:        "Reason",
+			Type:        "string",
+			Description: "Why the delivery failed",
+			JSONPath:    ".spec.reason",
+		},
+		{
+			Name:        "Phase",
+			Type:        "string",
+			Description: "Whether the delivery has been replayed",
+			JSONPath:    ".status.phase",
+		},
+	}
+	return registerCRD(apiClient, name, names, columns)
+}
+
 // RegisterPipelineActivityCRD ensures that the CRD is registered for PipelineActivity
 func RegisterPipelineActivityCRD(apiClient apiextensionsclientset.Interface) error {
 	name := "pipelineactivities." + jenkinsio.GroupName
@@ -126,6 +198,45 @@ func RegisterPipelineActivityCRD(apiClient apiextensionsclientset.Interface) err
 	return registerCRD(apiClient, name, names, columns)
 }
 
+// RegisterPromotionCRD ensures that the CRD is registered for Promotion
+func RegisterPromotionCRD(apiClient apiextensionsclientset.Interface) error {
+	name := "promotions." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "Promotion",
+		ListKind:   "PromotionList",
+		Plural:     "promotions",
+		Singular:   "promotion",
+		ShortNames: []string{"promote"},
+	}
+	columns := []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "Environment",
+			Type:        "string",
+			Description: "The environment the app is promoted to",
+			JSONPath:    ".spec.environment",
+		},
+		{
+			Name:        "Application",
+			Type:        "string",
+			Description: "The app being promoted",
+			JSONPath:    ".spec.application",
+		},
+		{
+			Name:        "Version",
+			Type:        "string",
+			Description: "The version of the app being promoted",
+			JSONPath:    ".spec.version",
+		},
+		{
+			Name:        "Phase",
+			Type:        "string",
+			Description: "The phase of the promotion in the queue",
+			JSONPath:    ".status.phase",
+		},
+	}
+	return registerCRD(apiClient, name, names, columns)
+}
+
 // RegisterReleaseCRD ensures that the CRD is registered for Release
 func RegisterReleaseCRD(apiClient apiextensionsclientset.Interface) error {
 	name := "releases." + jenkinsio.GroupName
@@ -159,6 +270,39 @@ func RegisterReleaseCRD(apiClient apiextensionsclientset.Interface) error {
 	return registerCRD(apiClient, name, names, columns)
 }
 
+// RegisterSourceRepositoryCRD ensures that the CRD is registered for SourceRepository
+func RegisterSourceRepositoryCRD(apiClient apiextensionsclientset.Interface) error {
+	name := "sourcerepositories." + jenkinsio.GroupName
+	names := &v1beta1.CustomResourceDefinitionNames{
+		Kind:       "SourceRepository",
+		ListKind:   "SourceRepositoryList",
+		Plural:     "sourcerepositories",
+		Singular:   "sourcerepository",
+		ShortNames: []string{"sourcerepo", "srcrepo", "sr"},
+	}
+	columns := []v1beta1.CustomResourceColumnDefinition{
+		{
+			Name:        "Org",
+			Type:        "string",
+			Description: "The git organisation of the repository",
+			JSONPath:    ".spec.org",
+		},
+		{
+			Name:        "Repo",
+			Type:        "string",
+			Description: "The name of the git repository",
+			JSONPath:    ".spec.repo",
+		},
+		{
+			Name:        "Paused",
+			Type:        "string",
+			Description: "Whether the pipeline triggers of the repository are paused",
+			JSONPath:    ".metadata.annotations.jenkins\\.io/pipelines-paused",
+		},
+	}
+	return registerCRD(apiClient, name, names, columns)
+}
+
 // RegisterUserCRD ensures that the CRD is registered for User
 func RegisterUserCRD(apiClient apiextensionsclientset.Interface) error {
 	name := "users." + jenkinsio.GroupName
@@ -0,0 +1,282 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// CrossplaneNamespace the namespace Crossplane and its resource classes are installed into
+	CrossplaneNamespace = "crossplane-system"
+
+	// LabelCrossplaneApp the label of a resource claim containing the name of the app which uses the resource
+	LabelCrossplaneApp = "app"
+
+	crossplaneClaimFileSuffix = "-claim.yaml"
+)
+
+// CrossplaneClaimKind the kind of cloud resource which can be claimed via Crossplane
+type CrossplaneClaimKind struct {
+	APIVersion string
+	Kind       string
+	// Resource the plural name of the claim resource in the Kubernetes API
+	Resource string
+}
+
+// CrossplaneClaimKinds the kinds of cloud resources apps can claim indexed by the name used on the command line
+var CrossplaneClaimKinds = map[string]CrossplaneClaimKind{
+	"bucket": {APIVersion: "storage.crossplane.io/v1alpha1", Kind: "Bucket", Resource: "buckets"},
+	"cache":  {APIVersion: "cache.crossplane.io/v1alpha1", Kind: "RedisCluster", Resource: "redisclusters"},
+	"queue":  {APIVersion: "queue.crossplane.io/v1alpha1", Kind: "Queue", Resource: "queues"},
+}
+
+// CrossplaneClaimKindNames returns the sorted names of the kinds of cloud resources which can be claimed
+func CrossplaneClaimKindNames() []string {
+	answer := []string{}
+	for name := range CrossplaneClaimKinds {
+		answer = append(answer, name)
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// CrossplaneClaim a Crossplane resource claim requesting a cloud resource for an app. The spec is kept generic as
+// the fields vary by the kind of resource
+type CrossplaneClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              map[string]interface{} `json:"spec,omitempty"`
+	Status            *CrossplaneClaimStatus `json:"status,omitempty"`
+}
+
+// CrossplaneClaimStatus the binding phase and conditions of a resource claim
+type CrossplaneClaimStatus struct {
+	BindingPhase string                `json:"bindingPhase,omitempty"`
+	Conditions   []CrossplaneCondition `json:"conditions,omitempty"`
+}
+
+// CrossplaneCondition a condition of a resource claim
+type CrossplaneCondition struct {
+	Type    string `json:"type"`
+	Status  string `json:"status"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+// NewCrossplaneClaim returns a claim of a cloud resource for an app which is provisioned from the given resource
+// class and whose connection details are written to a Secret of the same name as the claim
+func NewCrossplaneClaim(kindName string, app string, name string, class string, parameters map[string]string) (*CrossplaneClaim, error) {
+	kind, ok := CrossplaneClaimKinds[kindName]
+	if !ok {
+		return nil, util.InvalidArg(kindName, CrossplaneClaimKindNames())
+	}
+	claimName := CrossplaneClaimName(app, name)
+	spec := map[string]interface{}{
+		"classReference": map[string]interface{}{
+			"name":      class,
+			"namespace": CrossplaneNamespace,
+		},
+		"writeConnectionSecretToRef": map[string]interface{}{
+			"name": claimName,
+		},
+	}
+	for k, v := range parameters {
+		spec[k] = v
+	}
+	return &CrossplaneClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: kind.APIVersion,
+			Kind:       kind.Kind,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   claimName,
+			Labels: map[string]string{LabelCrossplaneApp: app},
+		},
+		Spec: spec,
+	}, nil
+}
+
+// CrossplaneClaimName returns the name of the claim and connection Secret of a cloud resource of an app
+func CrossplaneClaimName(app string, name string) string {
+	return ToValidName(app + "-" + name)
+}
+
+// CrossplaneClaimFile returns the file in the environment chart directory which contains the claim so that the
+// claim is applied along with the rest of the environment
+func CrossplaneClaimFile(dir string, app string, name string) string {
+	return filepath.Join(dir, "templates", CrossplaneClaimName(app, name)+crossplaneClaimFileSuffix)
+}
+
+// SaveCrossplaneClaim writes the claim to the templates of the environment chart directory
+func SaveCrossplaneClaim(dir string, claim *CrossplaneClaim) error {
+	data, err := yaml.Marshal(claim)
+	if err != nil {
+		return fmt.Errorf("failed to marshal the claim %s: %s", claim.Name, err)
+	}
+	fileName := filepath.Join(dir, "templates", claim.Name+crossplaneClaimFileSuffix)
+	err = os.MkdirAll(filepath.Dir(fileName), util.DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, util.DefaultWritePermissions)
+}
+
+// RemoveCrossplaneClaim removes the claim of the cloud resource of the app from the environment chart directory
+// returning false if there is no such claim
+func RemoveCrossplaneClaim(dir string, app string, name string) (bool, error) {
+	fileName := CrossplaneClaimFile(dir, app, name)
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return false, err
+	}
+	return true, os.Remove(fileName)
+}
+
+// CrossplaneClaimPhase returns the binding phase of the claim or the reason of its failing condition
+func CrossplaneClaimPhase(claim *CrossplaneClaim) string {
+	if claim.Status == nil {
+		return "Pending"
+	}
+	for _, c := range claim.Status.Conditions {
+		if c.Status == "True" && c.Type == "Failed" {
+			if c.Reason != "" {
+				return c.Reason
+			}
+			return c.Type
+		}
+	}
+	if claim.Status.BindingPhase != "" {
+		return claim.Status.BindingPhase
+	}
+	return "Pending"
+}
+
+// GetCrossplaneClaims returns the resource claims of all kinds in the namespace. No claims are returned if
+// Crossplane is not installed
+func GetCrossplaneClaims(client kubernetes.Interface, ns string) ([]CrossplaneClaim, error) {
+	answer := []CrossplaneClaim{}
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return answer, nil
+	}
+	for _, kindName := range CrossplaneClaimKindNames() {
+		kind := CrossplaneClaimKinds[kindName]
+		data, err := restClient.Get().AbsPath("/apis/" + kind.APIVersion + "/namespaces/" + ns + "/" + kind.Resource).DoRaw()
+		if err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return answer, fmt.Errorf("failed to list the %s claims in namespace %s: %s", kind.Kind, ns, err)
+		}
+		list := struct {
+			Items []CrossplaneClaim `json:"items"`
+		}{}
+		err = json.Unmarshal(data, &list)
+		if err != nil {
+			return answer, fmt.Errorf("failed to parse the %s claims in namespace %s: %s", kind.Kind, ns, err)
+		}
+		answer = append(answer, list.Items...)
+	}
+	return answer, nil
+}
+
+// CrossplaneClaimsByApp returns the status of the claims indexed by the app which uses the resources
+func CrossplaneClaimsByApp(claims []CrossplaneClaim) map[string]string {
+	apps := map[string][]string{}
+	for i := range claims {
+		claim := &claims[i]
+		app := claim.Labels[LabelCrossplaneApp]
+		if app == "" {
+			continue
+		}
+		name := strings.TrimPrefix(claim.Name, app+"-")
+		apps[app] = append(apps[app], name+":"+CrossplaneClaimPhase(claim))
+	}
+	answer := map[string]string{}
+	for app, statuses := range apps {
+		sort.Strings(statuses)
+		answer[app] = strings.Join(statuses, " ")
+	}
+	return answer
+}
+
+// ApplyCrossplaneClaim creates or updates the claim in the namespace
+func ApplyCrossplaneClaim(client kubernetes.Interface, ns string, claim *CrossplaneClaim) error {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return fmt.Errorf("no REST client available to apply the claim %s", claim.Name)
+	}
+	path, err := crossplaneClaimPath(claim.APIVersion, claim.Kind, ns)
+	if err != nil {
+		return err
+	}
+	existing := &CrossplaneClaim{}
+	data, err := restClient.Get().AbsPath(path + "/" + claim.Name).DoRaw()
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the claim %s in namespace %s: %s", claim.Name, ns, err)
+		}
+		body, err := json.Marshal(claim)
+		if err != nil {
+			return err
+		}
+		err = restClient.Post().AbsPath(path).Body(body).Do().Error()
+		if err != nil {
+			return fmt.Errorf("failed to create the claim %s in namespace %s: %s", claim.Name, ns, err)
+		}
+		return nil
+	}
+	err = json.Unmarshal(data, existing)
+	if err != nil {
+		return err
+	}
+	claim.ResourceVersion = existing.ResourceVersion
+	body, err := json.Marshal(claim)
+	if err != nil {
+		return err
+	}
+	err = restClient.Put().AbsPath(path + "/" + claim.Name).Body(body).Do().Error()
+	if err != nil {
+		return fmt.Errorf("failed to update the claim %s in namespace %s: %s", claim.Name, ns, err)
+	}
+	return nil
+}
+
+// DeleteCrossplaneClaim deletes the claim of the given kind and name if it exists. Crossplane then deletes the
+// cloud resource depending on the reclaim policy of its resource class
+func DeleteCrossplaneClaim(client kubernetes.Interface, ns string, kindName string, name string) error {
+	kind, ok := CrossplaneClaimKinds[kindName]
+	if !ok {
+		return util.InvalidArg(kindName, CrossplaneClaimKindNames())
+	}
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return fmt.Errorf("no REST client available to delete the claim %s", name)
+	}
+	path := "/apis/" + kind.APIVersion + "/namespaces/" + ns + "/" + kind.Resource + "/" + name
+	err := restClient.Delete().AbsPath(path).Do().Error()
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the claim %s in namespace %s: %s", name, ns, err)
+	}
+	return nil
+}
+
+func crossplaneClaimPath(apiVersion string, kindText string, ns string) (string, error) {
+	for _, kind := range CrossplaneClaimKinds {
+		if kind.APIVersion == apiVersion && kind.Kind == kindText {
+			return "/apis/" + kind.APIVersion + "/namespaces/" + ns + "/" + kind.Resource, nil
+		}
+	}
+	return "", fmt.Errorf("unknown claim kind %s of API version %s", kindText, apiVersion)
+}
@@ -0,0 +1,73 @@
+package kube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCrossplaneClaim(t *testing.T) {
+	t.Parallel()
+	claim, err := kube.NewCrossplaneClaim("bucket", "myapp", "uploads", "standard", map[string]string{"predefinedACL": "private"})
+	require.NoError(t, err)
+	assert.Equal(t, "storage.crossplane.io/v1alpha1", claim.APIVersion)
+	assert.Equal(t, "Bucket", claim.Kind)
+	assert.Equal(t, "myapp-uploads", claim.Name)
+	assert.Equal(t, "myapp", claim.Labels[kube.LabelCrossplaneApp])
+	assert.Equal(t, "private", claim.Spec["predefinedACL"])
+	assert.Equal(t, map[string]interface{}{"name": "myapp-uploads"}, claim.Spec["writeConnectionSecretToRef"])
+
+	_, err = kube.NewCrossplaneClaim("database", "myapp", "orders", "standard", nil)
+	assert.Error(t, err)
+}
+
+func TestSaveAndRemoveCrossplaneClaim(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-crossplane-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	claim, err := kube.NewCrossplaneClaim("cache", "myapp", "sessions", "fast", nil)
+	require.NoError(t, err)
+	require.NoError(t, kube.SaveCrossplaneClaim(dir, claim))
+
+	fileName := kube.CrossplaneClaimFile(dir, "myapp", "sessions")
+	assert.Equal(t, filepath.Join(dir, "templates", "myapp-sessions-claim.yaml"), fileName)
+	data, err := ioutil.ReadFile(fileName)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "kind: RedisCluster")
+	assert.NotContains(t, string(data), "status")
+
+	removed, err := kube.RemoveCrossplaneClaim(dir, "myapp", "sessions")
+	require.NoError(t, err)
+	assert.True(t, removed)
+	removed, err = kube.RemoveCrossplaneClaim(dir, "myapp", "sessions")
+	require.NoError(t, err)
+	assert.False(t, removed)
+}
+
+func TestCrossplaneClaimsByApp(t *testing.T) {
+	t.Parallel()
+	bucket, err := kube.NewCrossplaneClaim("bucket", "myapp", "uploads", "standard", nil)
+	require.NoError(t, err)
+	bucket.Status = &kube.CrossplaneClaimStatus{BindingPhase: "Bound"}
+	queue, err := kube.NewCrossplaneClaim("queue", "myapp", "jobs", "standard", nil)
+	require.NoError(t, err)
+	cache, err := kube.NewCrossplaneClaim("cache", "other", "sessions", "standard", nil)
+	require.NoError(t, err)
+	cache.Status = &kube.CrossplaneClaimStatus{
+		BindingPhase: "Unbound",
+		Conditions:   []kube.CrossplaneCondition{{Type: "Failed", Status: "True", Reason: "QuotaExceeded"}},
+	}
+
+	apps := kube.CrossplaneClaimsByApp([]kube.CrossplaneClaim{*bucket, *queue, *cache})
+	assert.Equal(t, map[string]string{
+		"myapp": "jobs:Pending uploads:Bound",
+		"other": "sessions:QuotaExceeded",
+	}, apps)
+}
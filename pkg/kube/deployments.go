@@ -17,28 +17,37 @@ import (
 
 func GetDeployments(kubeClient kubernetes.Interface, ns string) (map[string]v1beta1.Deployment, error) {
 	answer := map[string]v1beta1.Deployment{}
-	deps, err := kubeClient.AppsV1beta1().Deployments(ns).List(metav1.ListOptions{})
-	if err != nil {
-		return answer, err
-	}
-	for _, d := range deps.Items {
-		answer[d.Name] = d
-	}
-	return answer, nil
+	err := ListPages(metav1.ListOptions{}, func(options metav1.ListOptions) (string, error) {
+		deps, err := kubeClient.AppsV1beta1().Deployments(ns).List(options)
+		if err != nil {
+			return "", err
+		}
+		for _, d := range deps.Items {
+			answer[d.Name] = d
+		}
+		return deps.Continue, nil
+	})
+	return answer, err
 }
 
 func GetDeploymentNames(client kubernetes.Interface, ns string, filter string) ([]string, error) {
 	names := []string{}
-	list, err := client.AppsV1beta1().Deployments(ns).List(metav1.ListOptions{})
+	err := ListPages(metav1.ListOptions{}, func(options metav1.ListOptions) (string, error) {
+		list, err := client.AppsV1beta1().Deployments(ns).List(options)
+		if err != nil {
+			return "", err
+		}
+		for _, d := range list.Items {
+			name := d.Name
+			if filter == "" || strings.Contains(name, filter) {
+				names = append(names, name)
+			}
+		}
+		return list.Continue, nil
+	})
 	if err != nil {
 		return names, fmt.Errorf("Failed to load Deployments %s", err)
 	}
-	for _, d := range list.Items {
-		name := d.Name
-		if filter == "" || strings.Contains(name, filter) {
-			names = append(names, name)
-		}
-	}
 	sort.Strings(names)
 	return names, nil
 }
@@ -71,15 +80,15 @@ func IsDeploymentRunning(client kubernetes.Interface, name, namespace string) (b
 }
 
 func WaitForAllDeploymentsToBeReady(client kubernetes.Interface, namespace string, timeoutPerDeploy time.Duration) error {
-	deployList, err := client.AppsV1().Deployments(namespace).List(metav1.ListOptions{})
+	deployList, err := ListDeployments(client, namespace, metav1.ListOptions{})
 	if err != nil {
 		return err
 	}
-	if deployList == nil || len(deployList.Items) == 0 {
+	if len(deployList) == 0 {
 		return fmt.Errorf("no deployments found in namespace %s", namespace)
 	}
 
-	for _, d := range deployList.Items {
+	for _, d := range deployList {
 		err = WaitForDeploymentToBeReady(client, d.Name, namespace, timeoutPerDeploy)
 		if err != nil {
 			log.Warnf("deployment %s failed to become ready in namespace %s", d.Name, namespace)
@@ -149,3 +158,48 @@ func GetDeploymentPods(client kubernetes.Interface, name, namespace string) ([]v
 
 	return pods.Items, err
 }
+
+// NotReadyResources returns the deployments and statefulsets in the namespace which do not have all their replicas
+// ready yet along with the pods whose containers are waiting such as when their image cannot be pulled
+func NotReadyResources(client kubernetes.Interface, ns string) ([]string, error) {
+	answer := []string{}
+	deployments, err := ListDeployments(client, ns, metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for _, d := range deployments {
+		replicas := int32(1)
+		if d.Spec.Replicas != nil {
+			replicas = *d.Spec.Replicas
+		}
+		if d.Status.ReadyReplicas < replicas {
+			answer = append(answer, fmt.Sprintf("deployment/%s %d/%d", d.Name, d.Status.ReadyReplicas, replicas))
+		}
+	}
+	statefulSets, err := ListStatefulSets(client, ns, metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for _, s := range statefulSets {
+		replicas := int32(1)
+		if s.Spec.Replicas != nil {
+			replicas = *s.Spec.Replicas
+		}
+		if s.Status.ReadyReplicas < replicas {
+			answer = append(answer, fmt.Sprintf("statefulset/%s %d/%d", s.Name, s.Status.ReadyReplicas, replicas))
+		}
+	}
+	pods, err := ListPods(client, ns, metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Waiting != nil && status.State.Waiting.Reason != "" && status.State.Waiting.Reason != "ContainerCreating" {
+				answer = append(answer, fmt.Sprintf("pod/%s %s", pod.Name, status.State.Waiting.Reason))
+				break
+			}
+		}
+	}
+	return answer, nil
+}
@@ -86,3 +86,41 @@ func TestWaitForDeploymentToBeReady(t *testing.T) {
 	assert.NoError(t, err, "Should not error")
 
 }
+
+func TestNotReadyResources(t *testing.T) {
+	t.Parallel()
+
+	ns := "jx"
+	replicas := int32(2)
+	client := kube_mocks.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "ready", Namespace: ns},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "jenkins", Namespace: ns},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ReadyReplicas: 1},
+		},
+		&appsv1.StatefulSet{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "nexus", Namespace: ns},
+		},
+		&v1.Pod{
+			ObjectMeta: meta_v1.ObjectMeta{Name: "jenkins-abc", Namespace: ns},
+			Status: v1.PodStatus{
+				ContainerStatuses: []v1.ContainerStatus{
+					{
+						State: v1.ContainerState{
+							Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+						},
+					},
+				},
+			},
+		},
+	)
+
+	names, err := kube.NotReadyResources(client, ns)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deployment/jenkins 1/2", "statefulset/nexus 0/1", "pod/jenkins-abc ImagePullBackOff"}, names)
+}
@@ -0,0 +1,73 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// MarkDevPodActive records the DevPod is being used so that it is not garbage collected while its TTL has not expired
+func MarkDevPodActive(client kubernetes.Interface, ns string, name string, now time.Time) error {
+	pods := client.CoreV1().Pods(ns)
+	pod, err := pods.Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationDevPodLastActive] = now.UTC().Format(time.RFC3339)
+	_, err = pods.Update(pod)
+	return err
+}
+
+// IsDevPodExpired returns true if the DevPod has a TTL and has been idle for longer than it. DevPods which have not
+// been marked active are idle since they were created
+func IsDevPodExpired(pod *v1.Pod, now time.Time) (bool, error) {
+	text := pod.Annotations[AnnotationDevPodTTL]
+	if text == "" {
+		return false, nil
+	}
+	ttl, err := time.ParseDuration(text)
+	if err != nil {
+		return false, fmt.Errorf("invalid annotation %s on DevPod %s: %s", AnnotationDevPodTTL, pod.Name, err)
+	}
+	if ttl <= 0 {
+		return false, nil
+	}
+	lastActive := pod.CreationTimestamp.Time
+	if text := pod.Annotations[AnnotationDevPodLastActive]; text != "" {
+		lastActive, err = time.Parse(time.RFC3339, text)
+		if err != nil {
+			return false, fmt.Errorf("invalid annotation %s on DevPod %s: %s", AnnotationDevPodLastActive, pod.Name, err)
+		}
+	}
+	return now.Sub(lastActive) > ttl, nil
+}
+
+// GetExpiredDevPods returns the DevPods of all users in the namespace which have been idle for longer than their TTL
+func GetExpiredDevPods(client kubernetes.Interface, ns string, now time.Time) ([]v1.Pod, error) {
+	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{
+		LabelSelector: LabelDevPodName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to load Pods %s", err)
+	}
+	answer := []v1.Pod{}
+	for _, pod := range list.Items {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+		expired, err := IsDevPodExpired(&pod, now)
+		if err != nil {
+			return nil, err
+		}
+		if expired {
+			answer = append(answer, pod)
+		}
+	}
+	return answer, nil
+}
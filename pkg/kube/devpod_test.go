@@ -0,0 +1,62 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func devPod(name string, created time.Time, annotations map[string]string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{
+			Name:              name,
+			Namespace:         "jx",
+			CreationTimestamp: meta_v1.NewTime(created),
+			Labels: map[string]string{
+				kube.LabelDevPodName:     name,
+				kube.LabelDevPodUsername: "james",
+			},
+			Annotations: annotations,
+		},
+	}
+}
+
+func TestGetExpiredDevPods(t *testing.T) {
+	t.Parallel()
+	now := time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC)
+	client := fake.NewSimpleClientset(
+		devPod("james-maven", now.Add(-3*time.Hour), map[string]string{kube.AnnotationDevPodTTL: "2h"}),
+		devPod("james-go", now.Add(-3*time.Hour), map[string]string{
+			kube.AnnotationDevPodTTL:        "2h",
+			kube.AnnotationDevPodLastActive: now.Add(-time.Hour).Format(time.RFC3339),
+		}),
+		devPod("james-nodejs", now.Add(-72*time.Hour), nil),
+		&v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "jenkins", Namespace: "jx"}},
+	)
+
+	pods, err := kube.GetExpiredDevPods(client, "jx", now)
+	require.NoError(t, err)
+	require.Len(t, pods, 1)
+	assert.Equal(t, "james-maven", pods[0].Name)
+
+	require.NoError(t, kube.MarkDevPodActive(client, "jx", "james-maven", now))
+	pods, err = kube.GetExpiredDevPods(client, "jx", now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, pods)
+
+	pods, err = kube.GetExpiredDevPods(client, "jx", now.Add(3*time.Hour))
+	require.NoError(t, err)
+	assert.Len(t, pods, 2)
+}
+
+func TestIsDevPodExpiredInvalidTTL(t *testing.T) {
+	t.Parallel()
+	_, err := kube.IsDevPodExpired(devPod("james-maven", time.Now(), map[string]string{kube.AnnotationDevPodTTL: "forever"}), time.Now())
+	assert.Error(t, err)
+}
@@ -0,0 +1,48 @@
+package kube
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/helm"
+	"gopkg.in/yaml.v2"
+)
+
+// IsHostInDomain returns true if the host is the domain or one of its sub domains
+func IsHostInDomain(host string, domain string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if domain == "" {
+		return false
+	}
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
+
+// IsURLInDomain returns true if the host of the URL is the domain or one of its sub domains
+func IsURLInDomain(rawURL string, domain string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return false
+	}
+	return IsHostInDomain(u.Hostname(), domain)
+}
+
+// SetEnvironmentValuesDomain sets the domain exposecontroller uses in the values YAML file of an environment chart
+// preserving the other values. It returns false if the values do not configure a domain so that the environment
+// uses the domain of the team
+func SetEnvironmentValuesDomain(valuesFile string, domain string) (bool, error) {
+	values, err := helm.LoadValuesFile(valuesFile)
+	if err != nil {
+		return false, err
+	}
+	expose, _ := helm.GetValue(values, "expose").(yaml.MapSlice)
+	config, _ := helm.GetValue(expose, "config").(yaml.MapSlice)
+	current, _ := helm.GetValue(config, Domain).(string)
+	if current == "" || current == domain {
+		return false, nil
+	}
+	config = helm.SetValue(config, Domain, domain)
+	expose = helm.SetValue(expose, "config", config)
+	values = helm.SetValue(values, "expose", expose)
+	return true, helm.SaveValuesFile(valuesFile, values)
+}
@@ -0,0 +1,50 @@
+package kube_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsURLInDomain(t *testing.T) {
+	t.Parallel()
+	assert.True(t, kube.IsURLInDomain("http://jenkins.jx.1.2.3.4.nip.io/github-webhook/", "1.2.3.4.nip.io"))
+	assert.True(t, kube.IsURLInDomain("https://hook.jx.Old.io:8443/hook?x=1", "old.io"))
+	assert.True(t, kube.IsURLInDomain("https://old.io/hook", "old.io."))
+	assert.False(t, kube.IsURLInDomain("https://jenkins.jx.cheese.io/github-webhook/", "old.io"))
+	assert.False(t, kube.IsURLInDomain("https://jenkins.jx.gold.io/", "old.io"), "a domain which only ends with the same characters is not a sub domain")
+	assert.False(t, kube.IsURLInDomain("https://jenkins.jx.old.io/", ""))
+}
+
+func TestSetEnvironmentValuesDomain(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-env-domain-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	valuesFile := filepath.Join(dir, "values.yaml")
+	err = ioutil.WriteFile(valuesFile, []byte("expose:\n  config:\n    domain: 1.2.3.4.nip.io\n    exposer: Ingress\ncleanup:\n  Args: []\n"), 0644)
+	require.NoError(t, err)
+
+	changed, err := kube.SetEnvironmentValuesDomain(valuesFile, "acme.com")
+	require.NoError(t, err)
+	assert.True(t, changed)
+	data, err := ioutil.ReadFile(valuesFile)
+	require.NoError(t, err)
+	assert.Equal(t, "expose:\n  config:\n    domain: acme.com\n    exposer: Ingress\ncleanup:\n  Args: []\n", string(data))
+
+	changed, err = kube.SetEnvironmentValuesDomain(valuesFile, "acme.com")
+	require.NoError(t, err)
+	assert.False(t, changed)
+
+	err = ioutil.WriteFile(valuesFile, []byte("expose:\n  config:\n    exposer: Ingress\n"), 0644)
+	require.NoError(t, err)
+	changed, err = kube.SetEnvironmentValuesDomain(valuesFile, "acme.com")
+	require.NoError(t, err)
+	assert.False(t, changed, "environments without a domain use the domain of the team")
+}
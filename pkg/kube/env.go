@@ -16,8 +16,10 @@ import (
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
 	"github.com/jenkins-x/jx/pkg/config"
 	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/helm"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
 	"gopkg.in/AlecAivazis/survey.v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -62,7 +64,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 				Message: "Name:",
 				Help:    "The Environment name must be unique, lower case and a valid DNS name",
 			}
-			err := survey.AskOne(q, &data.Name, validator)
+			err := util.AskOne(q, &data.Name, validator)
 			if err != nil {
 				return nil, err
 			}
@@ -87,7 +89,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 			Default: defaultValue,
 			Help:    "The Environment label is a person friendly descriptive text like 'Staging' or 'Production'",
 		}
-		err := survey.AskOne(q, &data.Spec.Label, survey.Required)
+		err := util.AskOne(q, &data.Spec.Label, survey.Required)
 		if err != nil {
 			return nil, err
 		}
@@ -122,7 +124,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 				Default: defaultValue,
 				Help:    "The kubernetes namespace name to use for this Environment",
 			}
-			err := survey.AskOne(q, &data.Spec.Namespace, ValidateName)
+			err := util.AskOne(q, &data.Spec.Namespace, ValidateName)
 			if err != nil {
 				return nil, err
 			}
@@ -145,7 +147,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 				Default: expose["domain"],
 				Help:    "Domain to expose ingress endpoints.  Example: jenkinsx.io, leave blank if no appplications are to be exposed via ingress rules",
 			}
-			err := survey.AskOne(q, &helmValues.ExposeController.Config.Domain, nil)
+			err := util.AskOne(q, &helmValues.ExposeController.Config.Domain, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -167,7 +169,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 					Help:    "The kubernetes cluster URL to use to host this Environment",
 				}
 				// TODO validate/transform to match valid kubnernetes cluster syntax
-				err := survey.AskOne(q, &data.Spec.Cluster, nil)
+				err := util.AskOne(q, &data.Spec.Cluster, nil)
 				if err != nil {
 					return nil, err
 				}
@@ -193,7 +195,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 			Help:    "Whether we promote to this Environment automatically, manually or never",
 		}
 		textValue := ""
-		err := survey.AskOne(q, &textValue, survey.Required)
+		err := util.AskOne(q, &textValue, survey.Required)
 		if err != nil {
 			return nil, err
 		}
@@ -219,7 +221,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 			Help:    "This number is used to sort Environments in sequential order, lowest first",
 		}
 		textValue := ""
-		err := survey.AskOne(q, &textValue, survey.Required)
+		err := util.AskOne(q, &textValue, survey.Required)
 		if err != nil {
 			return nil, err
 		}
@@ -231,6 +233,9 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 			data.Spec.Order = i
 		}
 	}
+	if config.Spec.Source.Layout != "" {
+		data.Spec.Source.Layout = config.Spec.Source.Layout
+	}
 	createRepo := false
 	if config.Spec.Source.URL != "" {
 		data.Spec.Source.URL = config.Spec.Source.URL
@@ -242,7 +247,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 					Message: "Would you like to use GitOps to manage this environment? :",
 					Default: false,
 				}
-				err := survey.AskOne(confirm, &showUrlEdit, nil)
+				err := util.AskOne(confirm, &showUrlEdit, nil)
 				if err != nil {
 					return nil, err
 				}
@@ -259,7 +264,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 						Message: fmt.Sprintf("We will now create a Git repository to store your %s environment, ok? :", data.Name),
 						Default: true,
 					}
-					err := survey.AskOne(confirm, &createRepo, nil)
+					err := util.AskOne(confirm, &createRepo, nil)
 					if err != nil {
 						return nil, err
 					}
@@ -283,7 +288,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 					Default: data.Spec.Source.URL,
 					Help:    "The git clone URL for the Environment's Helm charts source code and custom configuration",
 				}
-				err := survey.AskOne(q, &data.Spec.Source.URL, survey.Required)
+				err := util.AskOne(q, &data.Spec.Source.URL, survey.Required)
 				if err != nil {
 					return nil, err
 				}
@@ -306,7 +311,7 @@ func CreateEnvironmentSurvey(out io.Writer, batchMode bool, authConfigSvc auth.A
 					Default: defaultBranch,
 					Help:    "The git release branch in the Environments git repository used to store Helm charts source code and custom configuration",
 				}
-				err := survey.AskOne(q, &data.Spec.Source.Ref, nil)
+				err := util.AskOne(q, &data.Spec.Source.Ref, nil)
 				if err != nil {
 					return nil, err
 				}
@@ -374,6 +379,10 @@ func createEnvironmentGitRepo(out io.Writer, batchMode bool, authConfigSvc auth.
 		if err != nil {
 			return "", nil, err
 		}
+		err = modifyLayout(out, dir, env, git)
+		if err != nil {
+			return "", nil, err
+		}
 		err = git.PushMaster(dir)
 		if err != nil {
 			return "", nil, err
@@ -427,6 +436,10 @@ func createEnvironmentGitRepo(out io.Writer, batchMode bool, authConfigSvc auth.
 				if err != nil {
 					return "", nil, err
 				}
+				err = modifyLayout(out, dir, env, git)
+				if err != nil {
+					return "", nil, err
+				}
 				err = git.Push(dir)
 				if err != nil {
 					return "", nil, err
@@ -471,6 +484,10 @@ func createEnvironmentGitRepo(out io.Writer, batchMode bool, authConfigSvc auth.
 			if err != nil {
 				return "", nil, err
 			}
+			err = modifyLayout(out, dir, env, git)
+			if err != nil {
+				return "", nil, err
+			}
 			err = git.PushMaster(dir)
 			if err != nil {
 				return "", nil, err
@@ -593,6 +610,36 @@ func addValues(out io.Writer, dir string, values config.HelmValuesConfig, git gi
 	return nil
 }
 
+// modifyLayout converts the environment chart of a new environment repository into a helmfile.yaml if the
+// environment uses helmfile
+func modifyLayout(out io.Writer, dir string, env *v1.Environment, git gits.Gitter) error {
+	if env.Spec.Source.Layout != v1.EnvironmentRepositoryLayoutHelmfile {
+		return nil
+	}
+	helmfileName, err := helm.FindHelmfileFileName(dir)
+	if err != nil || helmfileName != "" {
+		return err
+	}
+	helmfileName, err = helm.ConvertToHelmfile(filepath.Join(dir, "env"), env.Spec.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "failed to convert the environment chart to a helmfile")
+	}
+	fmt.Fprintf(out, "Converted the environment chart to %s\n", util.ColorInfo(helmfileName))
+
+	err = git.Add(dir, "*")
+	if err != nil {
+		return err
+	}
+	changes, err := git.HasChanges(dir)
+	if err != nil {
+		return err
+	}
+	if changes {
+		return git.CommitDir(dir, "Use helmfile for environment")
+	}
+	return nil
+}
+
 // ReplaceMakeVariable needs a description
 func ReplaceMakeVariable(lines []string, name string, value string) error {
 	re, err := regexp.Compile(name + "\\s*:?=\\s*(.*)")
@@ -786,15 +833,15 @@ func GetDevNamespace(kubeClient kubernetes.Interface, ns string) (string, string
 func GetTeams(kubeClient kubernetes.Interface) ([]*corev1.Namespace, []string, error) {
 	names := []string{}
 	answer := []*corev1.Namespace{}
-	namespaceList, err := kubeClient.CoreV1().Namespaces().List(metav1.ListOptions{})
-	if err != err {
+	namespaceList, err := ListNamespaces(kubeClient, metav1.ListOptions{
+		LabelSelector: LabelEnvironment + "=" + LabelValueDevEnvironment,
+	})
+	if err != nil {
 		return answer, names, err
 	}
-	for idx, namespace := range namespaceList.Items {
-		if namespace.Labels[LabelEnvironment] == LabelValueDevEnvironment {
-			answer = append(answer, &namespaceList.Items[idx])
-			names = append(names, namespace.Name)
-		}
+	for idx, namespace := range namespaceList {
+		answer = append(answer, &namespaceList[idx])
+		names = append(names, namespace.Name)
 	}
 	sort.Strings(names)
 	return answer, names, nil
@@ -812,7 +859,7 @@ func PickEnvironment(envNames []string, defaultEnv string) (string, error) {
 			Options: envNames,
 			Default: defaultEnv,
 		}
-		err := survey.AskOne(prompt, &name, nil)
+		err := util.AskOne(prompt, &name, nil)
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,52 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// LockEnvironment locks the environment with the given reason until the expiry time. A zero expiry locks the
+// environment until it is unlocked
+func LockEnvironment(env *v1.Environment, reason string, user string, queue bool, now time.Time, expires time.Time) {
+	lock := &v1.EnvironmentLock{
+		Reason:          reason,
+		LockedBy:        user,
+		LockedTimestamp: &metav1.Time{Time: now},
+		Queue:           queue,
+	}
+	if !expires.IsZero() {
+		lock.Expires = &metav1.Time{Time: expires}
+	}
+	env.Spec.Lock = lock
+}
+
+// IsEnvironmentLocked returns true if the environment has a lock which has not expired at the given time
+func IsEnvironmentLocked(env *v1.Environment, now time.Time) bool {
+	if env == nil || env.Spec.Lock == nil {
+		return false
+	}
+	expires := env.Spec.Lock.Expires
+	return expires == nil || now.Before(expires.Time)
+}
+
+// EnvironmentLockMessage describes the lock of the environment
+func EnvironmentLockMessage(env *v1.Environment) string {
+	lock := env.Spec.Lock
+	if lock == nil {
+		return fmt.Sprintf("environment %s is not locked", env.Name)
+	}
+	message := fmt.Sprintf("environment %s is locked", env.Name)
+	if lock.LockedBy != "" {
+		message += " by " + lock.LockedBy
+	}
+	if lock.Expires != nil {
+		message += " until " + lock.Expires.Time.UTC().Format(time.RFC3339)
+	}
+	if lock.Reason != "" {
+		message += ": " + lock.Reason
+	}
+	return message
+}
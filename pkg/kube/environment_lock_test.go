@@ -0,0 +1,32 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestEnvironmentLock(t *testing.T) {
+	t.Parallel()
+	env := &v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+	now := time.Date(2018, 12, 20, 9, 0, 0, 0, time.UTC)
+
+	assert.False(t, kube.IsEnvironmentLocked(env, now))
+	assert.Equal(t, "environment production is not locked", kube.EnvironmentLockMessage(env))
+
+	kube.LockEnvironment(env, "release freeze", "jstrachan", true, now, now.Add(24*time.Hour))
+	assert.True(t, env.Spec.Lock.Queue)
+	assert.True(t, kube.IsEnvironmentLocked(env, now))
+	assert.True(t, kube.IsEnvironmentLocked(env, now.Add(23*time.Hour)))
+	assert.False(t, kube.IsEnvironmentLocked(env, now.Add(24*time.Hour)))
+	assert.Equal(t, "environment production is locked by jstrachan until 2018-12-21T09:00:00Z: release freeze", kube.EnvironmentLockMessage(env))
+
+	kube.LockEnvironment(env, "", "", false, now, time.Time{})
+	assert.Nil(t, env.Spec.Lock.Expires)
+	assert.True(t, kube.IsEnvironmentLocked(env, now.AddDate(1, 0, 0)))
+	assert.Equal(t, "environment production is locked", kube.EnvironmentLockMessage(env))
+}
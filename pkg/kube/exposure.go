@@ -0,0 +1,160 @@
+package kube
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OAuth2ProxyName the name of the release and Service of the OAuth2 proxy which protects exposed services
+	OAuth2ProxyName = "oauth2-proxy"
+
+	// IngressAuthURLAnnotation the nginx annotation of the URL which authenticates the requests of an Ingress
+	IngressAuthURLAnnotation = "nginx.ingress.kubernetes.io/auth-url"
+	// IngressAuthSigninAnnotation the nginx annotation of the URL unauthenticated requests of an Ingress are sent to
+	IngressAuthSigninAnnotation = "nginx.ingress.kubernetes.io/auth-signin"
+)
+
+// ParseIngressAnnotations parses the ingress annotations of an exposed service which are of the form key: value
+// on separate lines
+func ParseIngressAnnotations(text string) map[string]string {
+	answer := map[string]string{}
+	for _, line := range strings.Split(text, "\n") {
+		parts := strings.SplitN(line, ":", 2)
+		key := strings.TrimSpace(parts[0])
+		if key == "" {
+			continue
+		}
+		value := ""
+		if len(parts) > 1 {
+			value = strings.TrimSpace(parts[1])
+		}
+		answer[key] = value
+	}
+	return answer
+}
+
+// FormatIngressAnnotations formats the ingress annotations of an exposed service sorted by key
+func FormatIngressAnnotations(annotations map[string]string) string {
+	keys := []string{}
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	lines := []string{}
+	for _, k := range keys {
+		lines = append(lines, k+": "+annotations[k])
+	}
+	return strings.Join(lines, "\n")
+}
+
+// OAuth2ProxyAnnotations returns the nginx annotations which make an Ingress authenticate its requests via the OAuth2
+// proxy in the namespace which is exposed at the given URL
+func OAuth2ProxyAnnotations(ns string, proxyURL string) map[string]string {
+	return map[string]string{
+		IngressAuthURLAnnotation:    "http://" + OAuth2ProxyName + "." + ns + ".svc.cluster.local/oauth2/auth",
+		IngressAuthSigninAnnotation: strings.TrimSuffix(proxyURL, "/") + "/oauth2/start?rd=$scheme://$host$escaped_request_uri",
+	}
+}
+
+// GetServiceExposurePolicy returns the exposure policy of the service. Services without a policy are public if they
+// are exposed and internal otherwise
+func GetServiceExposurePolicy(settings []v1.ServiceExposure, svc *corev1.Service) v1.ExposurePolicyType {
+	for _, e := range settings {
+		if e.Service == svc.Name && e.Policy != "" {
+			return e.Policy
+		}
+	}
+	if svc.Annotations[ExposeAnnotation] == "true" {
+		return v1.ExposurePolicyPublic
+	}
+	return v1.ExposurePolicyInternal
+}
+
+// ApplyServiceExposure updates the expose annotations of the service and its existing Ingress to match the policy.
+// The proxy URL is the external URL of the OAuth2 proxy which is only used by the oauth policy. It returns true if
+// the service is exposed but has no Ingress yet so that exposecontroller needs to run
+func ApplyServiceExposure(client kubernetes.Interface, ns string, name string, policy v1.ExposurePolicyType, proxyURL string) (bool, error) {
+	if policy == v1.ExposurePolicyInternal {
+		err := UpdateService(client, ns, name, func(svc *corev1.Service) bool {
+			delete(svc.Annotations, ExposeAnnotation)
+			delete(svc.Annotations, ExposeURLAnnotation)
+			return true
+		})
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to update the expose annotations of service %s in namespace %s", name, ns)
+		}
+		ingresses := client.ExtensionsV1beta1().Ingresses(ns)
+		ing, err := ingresses.Get(name, metav1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if ing.Annotations[ExposeGeneratedByAnnotation] == "" {
+			return false, nil
+		}
+		err = ingresses.Delete(name, &metav1.DeleteOptions{})
+		if err != nil && !kerrors.IsNotFound(err) {
+			return false, errors.Wrapf(err, "failed to delete ingress %s in namespace %s", name, ns)
+		}
+		return false, nil
+	}
+	return UpdateExposedServiceIngressAnnotations(client, ns, name, func(annotations map[string]string) {
+		delete(annotations, IngressAuthURLAnnotation)
+		delete(annotations, IngressAuthSigninAnnotation)
+		if policy == v1.ExposurePolicyOAuth {
+			// the login via the OAuth2 proxy replaces any basic authentication
+			RemoveBasicAuthAnnotations(annotations)
+			for k, v := range OAuth2ProxyAnnotations(ns, proxyURL) {
+				annotations[k] = v
+			}
+		}
+	})
+}
+
+// UpdateExposedServiceIngressAnnotations exposes the service and modifies the annotations exposecontroller adds to
+// its Ingress along with the annotations of its existing Ingress. It returns true if the service has no Ingress yet
+// so that exposecontroller needs to run
+func UpdateExposedServiceIngressAnnotations(client kubernetes.Interface, ns string, name string, modify func(annotations map[string]string)) (bool, error) {
+	err := UpdateService(client, ns, name, func(svc *corev1.Service) bool {
+		svc.Annotations[ExposeAnnotation] = "true"
+		annotations := ParseIngressAnnotations(svc.Annotations[ExposeIngressAnnotation])
+		modify(annotations)
+		if len(annotations) == 0 {
+			delete(svc.Annotations, ExposeIngressAnnotation)
+		} else {
+			svc.Annotations[ExposeIngressAnnotation] = FormatIngressAnnotations(annotations)
+		}
+		return true
+	})
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update the expose annotations of service %s in namespace %s", name, ns)
+	}
+
+	ingresses := client.ExtensionsV1beta1().Ingresses(ns)
+	ing, err := ingresses.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	if ing.Annotations == nil {
+		ing.Annotations = map[string]string{}
+	}
+	modify(ing.Annotations)
+	_, err = ingresses.Update(ing)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to update ingress %s in namespace %s", name, ns)
+	}
+	return false, nil
+}
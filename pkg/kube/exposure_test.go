@@ -0,0 +1,89 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseAndFormatIngressAnnotations(t *testing.T) {
+	t.Parallel()
+	annotations := kube.ParseIngressAnnotations("kubernetes.io/ingress.class: nginx\n\ncertmanager.k8s.io/issuer: letsencrypt-prod")
+	assert.Equal(t, map[string]string{
+		"kubernetes.io/ingress.class": "nginx",
+		"certmanager.k8s.io/issuer":   "letsencrypt-prod",
+	}, annotations)
+	assert.Equal(t, "certmanager.k8s.io/issuer: letsencrypt-prod\nkubernetes.io/ingress.class: nginx", kube.FormatIngressAnnotations(annotations))
+}
+
+func TestGetServiceExposurePolicy(t *testing.T) {
+	t.Parallel()
+	svc := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "deck"}}
+	assert.Equal(t, v1.ExposurePolicyInternal, kube.GetServiceExposurePolicy(nil, svc))
+
+	svc.Annotations = map[string]string{kube.ExposeAnnotation: "true"}
+	assert.Equal(t, v1.ExposurePolicyPublic, kube.GetServiceExposurePolicy(nil, svc))
+
+	settings := []v1.ServiceExposure{{Service: "deck", Policy: v1.ExposurePolicyOAuth}}
+	assert.Equal(t, v1.ExposurePolicyOAuth, kube.GetServiceExposurePolicy(settings, svc))
+}
+
+func TestApplyServiceExposure(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(
+		&corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "deck",
+				Namespace: "jx",
+				Annotations: map[string]string{
+					kube.ExposeAnnotation:        "true",
+					kube.ExposeIngressAnnotation: "kubernetes.io/ingress.class: nginx",
+				},
+			},
+		},
+		&v1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "deck",
+				Namespace:   "jx",
+				Annotations: map[string]string{kube.ExposeGeneratedByAnnotation: "exposecontroller"},
+			},
+		},
+	)
+
+	needsExpose, err := kube.ApplyServiceExposure(client, "jx", "deck", v1.ExposurePolicyOAuth, "https://oauth2-proxy.jx.example.com")
+	require.NoError(t, err)
+	assert.False(t, needsExpose)
+	svc, err := client.CoreV1().Services("jx").Get("deck", metav1.GetOptions{})
+	require.NoError(t, err)
+	annotations := kube.ParseIngressAnnotations(svc.Annotations[kube.ExposeIngressAnnotation])
+	assert.Equal(t, "nginx", annotations["kubernetes.io/ingress.class"])
+	assert.Equal(t, "http://oauth2-proxy.jx.svc.cluster.local/oauth2/auth", annotations[kube.IngressAuthURLAnnotation])
+	ing, err := client.ExtensionsV1beta1().Ingresses("jx").Get("deck", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "https://oauth2-proxy.jx.example.com/oauth2/start?rd=$scheme://$host$escaped_request_uri", ing.Annotations[kube.IngressAuthSigninAnnotation])
+
+	_, err = kube.ApplyServiceExposure(client, "jx", "deck", v1.ExposurePolicyPublic, "")
+	require.NoError(t, err)
+	ing, err = client.ExtensionsV1beta1().Ingresses("jx").Get("deck", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, ing.Annotations[kube.IngressAuthURLAnnotation])
+
+	_, err = kube.ApplyServiceExposure(client, "jx", "deck", v1.ExposurePolicyInternal, "")
+	require.NoError(t, err)
+	svc, err = client.CoreV1().Services("jx").Get("deck", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, svc.Annotations[kube.ExposeAnnotation])
+	_, err = client.ExtensionsV1beta1().Ingresses("jx").Get("deck", metav1.GetOptions{})
+	assert.Error(t, err, "the generated ingress should be deleted")
+
+	needsExpose, err = kube.ApplyServiceExposure(client, "jx", "deck", v1.ExposurePolicyPublic, "")
+	require.NoError(t, err)
+	assert.True(t, needsExpose)
+}
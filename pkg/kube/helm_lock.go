@@ -0,0 +1,179 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ValueKindHelmLock the value of the kind label of the ConfigMaps which lock helm releases
+	ValueKindHelmLock = "helm-lock"
+
+	helmLockPrefix = "jx-helm-lock-"
+
+	helmLockRelease   = "release"
+	helmLockHolder    = "holder"
+	helmLockOperation = "operation"
+	helmLockAcquired  = "acquired"
+	helmLockRenewed   = "renewed"
+	helmLockDuration  = "duration"
+)
+
+// HelmLock a lease on a helm release which stops other jx commands from installing, upgrading or deleting the
+// release at the same time. The lease expires unless it is renewed so that a crashed command does not block the
+// release forever
+type HelmLock struct {
+	Release   string
+	Holder    string
+	Operation string
+	Acquired  time.Time
+	Renewed   time.Time
+	Duration  time.Duration
+}
+
+// HelmLockName returns the name of the ConfigMap which locks the release
+func HelmLockName(release string) string {
+	return ToValidName(helmLockPrefix + release)
+}
+
+// IsExpired returns true if the lease has not been renewed in time
+func (l *HelmLock) IsExpired(now time.Time) bool {
+	return !now.Before(l.Renewed.Add(l.Duration))
+}
+
+// Message describes the operation which holds the lock
+func (l *HelmLock) Message() string {
+	return fmt.Sprintf("another helm operation (%s) on release %s is in progress by %s since %s", l.Operation, l.Release,
+		l.Holder, l.Acquired.UTC().Format(time.RFC3339))
+}
+
+// ToConfigMap returns the ConfigMap which stores the lock
+func (l *HelmLock) ToConfigMap() *v1.ConfigMap {
+	cm := &v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: HelmLockName(l.Release),
+			Labels: map[string]string{
+				LabelKind: ValueKindHelmLock,
+			},
+		},
+	}
+	l.writeTo(cm)
+	return cm
+}
+
+func (l *HelmLock) writeTo(cm *v1.ConfigMap) {
+	cm.Data = map[string]string{
+		helmLockRelease:   l.Release,
+		helmLockHolder:    l.Holder,
+		helmLockOperation: l.Operation,
+		helmLockAcquired:  l.Acquired.UTC().Format(time.RFC3339),
+		helmLockRenewed:   l.Renewed.UTC().Format(time.RFC3339),
+		helmLockDuration:  l.Duration.String(),
+	}
+}
+
+// HelmLockFromConfigMap returns the lock stored in the ConfigMap
+func HelmLockFromConfigMap(cm *v1.ConfigMap) (*HelmLock, error) {
+	data := cm.Data
+	acquired, err := time.Parse(time.RFC3339, data[helmLockAcquired])
+	if err != nil {
+		return nil, fmt.Errorf("invalid acquired time in helm lock %s: %s", cm.Name, err)
+	}
+	renewed, err := time.Parse(time.RFC3339, data[helmLockRenewed])
+	if err != nil {
+		return nil, fmt.Errorf("invalid renewed time in helm lock %s: %s", cm.Name, err)
+	}
+	duration, err := time.ParseDuration(data[helmLockDuration])
+	if err != nil {
+		return nil, fmt.Errorf("invalid duration in helm lock %s: %s", cm.Name, err)
+	}
+	return &HelmLock{
+		Release:   data[helmLockRelease],
+		Holder:    data[helmLockHolder],
+		Operation: data[helmLockOperation],
+		Acquired:  acquired,
+		Renewed:   renewed,
+		Duration:  duration,
+	}, nil
+}
+
+// TryAcquireHelmLock tries to acquire the lock in the namespace taking over a lock which has expired. It returns the
+// current lock if it is held by someone else
+func TryAcquireHelmLock(client kubernetes.Interface, ns string, lock *HelmLock, now time.Time) (*HelmLock, error) {
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	lock.Acquired = now
+	lock.Renewed = now
+	_, err := configMaps.Create(lock.ToConfigMap())
+	if err == nil || !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	cm, err := configMaps.Get(HelmLockName(lock.Release), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			// released in the meantime so try again
+			return TryAcquireHelmLock(client, ns, lock, now)
+		}
+		return nil, err
+	}
+	current, err := HelmLockFromConfigMap(cm)
+	if err == nil && current.Holder != lock.Holder && !current.IsExpired(now) {
+		return current, nil
+	}
+	// the update fails with a conflict if another command takes over the lock at the same time
+	lock.writeTo(cm)
+	_, err = configMaps.Update(cm)
+	if errors.IsConflict(err) {
+		cm, err = configMaps.Get(HelmLockName(lock.Release), metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return HelmLockFromConfigMap(cm)
+	}
+	return nil, err
+}
+
+// RenewHelmLock renews the lease of the lock so that it does not expire. It fails if the lock has been taken over
+func RenewHelmLock(client kubernetes.Interface, ns string, lock *HelmLock, now time.Time) error {
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(HelmLockName(lock.Release), metav1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	current, err := HelmLockFromConfigMap(cm)
+	if err != nil {
+		return err
+	}
+	if current.Holder != lock.Holder {
+		return fmt.Errorf("the helm lock of release %s has been taken over by %s", lock.Release, current.Holder)
+	}
+	lock.Renewed = now
+	lock.writeTo(cm)
+	_, err = configMaps.Update(cm)
+	return err
+}
+
+// ReleaseHelmLock deletes the lock unless it has been taken over by someone else
+func ReleaseHelmLock(client kubernetes.Interface, ns string, lock *HelmLock) error {
+	configMaps := client.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(HelmLockName(lock.Release), metav1.GetOptions{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if cm.Data[helmLockHolder] != lock.Holder {
+		return nil
+	}
+	uid := cm.UID
+	err = configMaps.Delete(cm.Name, &metav1.DeleteOptions{Preconditions: &metav1.Preconditions{UID: &uid}})
+	if errors.IsNotFound(err) || errors.IsConflict(err) {
+		return nil
+	}
+	return err
+}
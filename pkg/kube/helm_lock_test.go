@@ -0,0 +1,52 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestHelmLock(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	ns := "jx"
+	now := time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	first := &kube.HelmLock{Release: "jenkins-x", Holder: "alice@laptop/1", Operation: "upgrade", Duration: 2 * time.Minute}
+	current, err := kube.TryAcquireHelmLock(client, ns, first, now)
+	require.NoError(t, err)
+	assert.Nil(t, current, "the lock is acquired")
+
+	second := &kube.HelmLock{Release: "jenkins-x", Holder: "bob@pipeline/7", Operation: "delete", Duration: 2 * time.Minute}
+	current, err = kube.TryAcquireHelmLock(client, ns, second, now.Add(time.Minute))
+	require.NoError(t, err)
+	require.NotNil(t, current, "the lock is held by the first holder")
+	assert.Equal(t, "alice@laptop/1", current.Holder)
+	assert.Equal(t, "another helm operation (upgrade) on release jenkins-x is in progress by alice@laptop/1 since 2019-03-01T09:00:00Z", current.Message())
+
+	err = kube.RenewHelmLock(client, ns, first, now.Add(90*time.Second))
+	require.NoError(t, err)
+	current, err = kube.TryAcquireHelmLock(client, ns, second, now.Add(3*time.Minute))
+	require.NoError(t, err)
+	assert.NotNil(t, current, "the renewed lock has not expired")
+
+	current, err = kube.TryAcquireHelmLock(client, ns, second, now.Add(4*time.Minute))
+	require.NoError(t, err)
+	assert.Nil(t, current, "the expired lock is taken over")
+	assert.Error(t, kube.RenewHelmLock(client, ns, first, now.Add(4*time.Minute)), "the lock has been taken over")
+
+	err = kube.ReleaseHelmLock(client, ns, first)
+	require.NoError(t, err)
+	_, err = client.CoreV1().ConfigMaps(ns).Get(kube.HelmLockName("jenkins-x"), metav1.GetOptions{})
+	assert.NoError(t, err, "a lock taken over is not released by its previous holder")
+
+	err = kube.ReleaseHelmLock(client, ns, second)
+	require.NoError(t, err)
+	_, err = client.CoreV1().ConfigMaps(ns).Get(kube.HelmLockName("jenkins-x"), metav1.GetOptions{})
+	assert.Error(t, err)
+}
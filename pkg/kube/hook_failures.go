@@ -0,0 +1,73 @@
+package kube
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewHookFailure creates a HookFailure for the webhook delivery which failed. The name is derived from the delivery
+// so that the same delivery is only recorded once if the git provider redelivers it
+func NewHookFailure(spec v1.HookFailureSpec, now time.Time) *v1.HookFailure {
+	id := spec.DeliveryID
+	if id == "" {
+		sum := sha256.Sum256([]byte(spec.Payload))
+		id = hex.EncodeToString(sum[:])[:16]
+	}
+	spec.ReceivedTimestamp = &metav1.Time{Time: now}
+	return &v1.HookFailure{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ToValidName("hook-" + id),
+		},
+		Spec: spec,
+		Status: v1.HookFailureStatus{
+			Phase: v1.HookFailurePhasePending,
+		},
+	}
+}
+
+// HookFailures returns the HookFailures in the namespace in the order they were received, only returning those
+// which are still pending unless all is true
+func HookFailures(jxClient versioned.Interface, ns string, all bool) ([]*v1.HookFailure, error) {
+	list, err := jxClient.JenkinsV1().HookFailures(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	answer := []*v1.HookFailure{}
+	for i := range list.Items {
+		failure := &list.Items[i]
+		if all || failure.Status.Phase != v1.HookFailurePhaseReplayed {
+			answer = append(answer, failure)
+		}
+	}
+	sort.SliceStable(answer, func(i, j int) bool {
+		return receivedTime(answer[i]).Before(receivedTime(answer[j]))
+	})
+	return answer, nil
+}
+
+// RecordHookReplay updates the status of the HookFailure with the result of replaying it
+func RecordHookReplay(failure *v1.HookFailure, err error, now time.Time) {
+	status := &failure.Status
+	status.Attempts++
+	status.LastAttemptTimestamp = &metav1.Time{Time: now}
+	if err != nil {
+		status.Phase = v1.HookFailurePhasePending
+		status.Message = err.Error()
+		return
+	}
+	status.Phase = v1.HookFailurePhaseReplayed
+	status.Message = ""
+}
+
+func receivedTime(failure *v1.HookFailure) time.Time {
+	if failure.Spec.ReceivedTimestamp != nil {
+		return failure.Spec.ReceivedTimestamp.Time
+	}
+	return failure.CreationTimestamp.Time
+}
@@ -0,0 +1,46 @@
+package kube_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookFailures(t *testing.T) {
+	t.Parallel()
+	jxClient := fake.NewSimpleClientset()
+	now := time.Now()
+	for i, id := range []string{"b", "a", ""} {
+		failure := kube.NewHookFailure(v1.HookFailureSpec{DeliveryID: id, Payload: fmt.Sprintf("payload %d", i)}, now.Add(time.Duration(i)*time.Minute))
+		_, err := jxClient.JenkinsV1().HookFailures("jx").Create(failure)
+		require.NoError(t, err)
+	}
+
+	failures, err := kube.HookFailures(jxClient, "jx", false)
+	require.NoError(t, err)
+	require.Len(t, failures, 3)
+	assert.Equal(t, "hook-b", failures[0].Name)
+	assert.Equal(t, "hook-a", failures[1].Name)
+
+	kube.RecordHookReplay(failures[0], fmt.Errorf("connection refused"), now)
+	assert.Equal(t, v1.HookFailurePhasePending, failures[0].Status.Phase)
+	assert.Equal(t, "connection refused", failures[0].Status.Message)
+	kube.RecordHookReplay(failures[0], nil, now)
+	assert.Equal(t, v1.HookFailurePhaseReplayed, failures[0].Status.Phase)
+	assert.Equal(t, 2, failures[0].Status.Attempts)
+	_, err = jxClient.JenkinsV1().HookFailures("jx").Update(failures[0])
+	require.NoError(t, err)
+
+	failures, err = kube.HookFailures(jxClient, "jx", false)
+	require.NoError(t, err)
+	assert.Len(t, failures, 2)
+	failures, err = kube.HookFailures(jxClient, "jx", true)
+	require.NoError(t, err)
+	assert.Len(t, failures, 3)
+}
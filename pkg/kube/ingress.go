@@ -1,11 +1,18 @@
 package kube
 
 import (
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
-
 	"strconv"
+	"time"
 
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -18,6 +25,9 @@ const (
 	Exposer                = "exposer"
 )
 
+// IngressPollInterval how often ingresses and their TLS secrets are checked while waiting for them to become ready
+var IngressPollInterval = 2 * time.Second
+
 type IngressConfig struct {
 	Email   string `structs:"email" yaml:"email" json:"email"`
 	Domain  string `structs:"domain" yaml:"domain" json:"domain"`
@@ -68,3 +78,106 @@ func GetIngressConfig(c kubernetes.Interface, ns string) (IngressConfig, error)
 	}
 	return ic, nil
 }
+
+// IngressHasAddress returns true if the ingress controller has assigned an IP address or hostname to the ingress
+func IngressHasAddress(ing *v1beta1.Ingress) bool {
+	for _, v := range ing.Status.LoadBalancer.Ingress {
+		if v.IP != "" || v.Hostname != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitForIngress waits for the ingress controller to assign an address to the ingress so that the hosts of its rules
+// can be reached
+func WaitForIngress(client kubernetes.Interface, ns, name string, timeout time.Duration) error {
+	err := wait.PollImmediate(IngressPollInterval, timeout, func() (bool, error) {
+		ing, err := client.ExtensionsV1beta1().Ingresses(ns).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return IngressHasAddress(ing), nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return errors.Wrapf(err, "ingress %s in namespace %s was not assigned an address within %s", name, ns, timeout)
+	}
+	return err
+}
+
+// WaitForCertificate waits for the TLS secret to be populated with a certificate, such as by cert-manager, which is
+// currently valid and, if any hosts are given, valid for all of the hosts. It returns the certificate
+func WaitForCertificate(client kubernetes.Interface, ns, secretName string, hosts []string, timeout time.Duration) (*x509.Certificate, error) {
+	var cert *x509.Certificate
+	var invalid error
+	err := wait.PollImmediate(IngressPollInterval, timeout, func() (bool, error) {
+		secret, err := client.CoreV1().Secrets(ns).Get(secretName, meta_v1.GetOptions{})
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				invalid = fmt.Errorf("the secret does not exist")
+				return false, nil
+			}
+			return false, err
+		}
+		cert, invalid = ValidCertificate(secret, hosts, time.Now())
+		return invalid == nil, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		return nil, errors.Wrapf(err, "TLS secret %s in namespace %s was not populated with a valid certificate within %s: %s",
+			secretName, ns, timeout, invalid)
+	}
+	return cert, err
+}
+
+// ValidCertificate returns the certificate of the TLS secret or an error if the secret has no certificate and key, or
+// the certificate is not valid at the given time or for all of the hosts
+func ValidCertificate(secret *v1.Secret, hosts []string, now time.Time) (*x509.Certificate, error) {
+	if len(secret.Data[v1.TLSCertKey]) == 0 || len(secret.Data[v1.TLSPrivateKeyKey]) == 0 {
+		return nil, fmt.Errorf("the secret has no %s and %s", v1.TLSCertKey, v1.TLSPrivateKeyKey)
+	}
+	block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+	if block == nil {
+		return nil, fmt.Errorf("the %s of the secret is not PEM encoded", v1.TLSCertKey)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse the %s of the secret", v1.TLSCertKey)
+	}
+	if now.Before(cert.NotBefore) || now.After(cert.NotAfter) {
+		return nil, fmt.Errorf("the certificate is only valid from %s to %s", cert.NotBefore, cert.NotAfter)
+	}
+	for _, host := range hosts {
+		err = cert.VerifyHostname(host)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cert, nil
+}
+
+// WaitForIngressCertificates waits for the ingress to be assigned an address and then for the TLS secrets of the
+// ingress to be populated with valid certificates for their hosts
+func WaitForIngressCertificates(client kubernetes.Interface, ns, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	err := WaitForIngress(client, ns, name, timeout)
+	if err != nil {
+		return err
+	}
+	ing, err := client.ExtensionsV1beta1().Ingresses(ns).Get(name, meta_v1.GetOptions{})
+	if err != nil {
+		return err
+	}
+	for _, tls := range ing.Spec.TLS {
+		if tls.SecretName == "" {
+			continue
+		}
+		_, err = WaitForCertificate(client, ns, tls.SecretName, tls.Hosts, time.Until(deadline))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package kube
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// IngressAuthTypeAnnotation the nginx annotation of the kind of authentication an Ingress requires
+	IngressAuthTypeAnnotation = "nginx.ingress.kubernetes.io/auth-type"
+	// IngressAuthSecretAnnotation the nginx annotation of the Secret containing the htpasswd file of an Ingress
+	IngressAuthSecretAnnotation = "nginx.ingress.kubernetes.io/auth-secret"
+	// IngressAuthRealmAnnotation the nginx annotation of the realm displayed when logging in to an Ingress
+	IngressAuthRealmAnnotation = "nginx.ingress.kubernetes.io/auth-realm"
+	// IngressWhitelistSourceRangeAnnotation the nginx annotation of the comma separated CIDRs allowed to access an Ingress
+	IngressWhitelistSourceRangeAnnotation = "nginx.ingress.kubernetes.io/whitelist-source-range"
+)
+
+// BasicAuthSecretName returns the name of the Secret containing the htpasswd file of the exposed service
+func BasicAuthSecretName(service string) string {
+	return service + "-basic-auth"
+}
+
+// HtpasswdEntry returns the htpasswd line of the user using the SHA password format supported by nginx
+func HtpasswdEntry(username string, password string) string {
+	return fmt.Sprintf("%s:{SHA}%s", username, config.HashSha(password))
+}
+
+// BasicAuthAnnotations returns the nginx annotations which make an Ingress require a login checked against the
+// htpasswd file in the Secret
+func BasicAuthAnnotations(secretName string) map[string]string {
+	return map[string]string{
+		IngressAuthTypeAnnotation:   "basic",
+		IngressAuthSecretAnnotation: secretName,
+		IngressAuthRealmAnnotation:  "Authentication Required",
+	}
+}
+
+// RemoveBasicAuthAnnotations removes the basic authentication annotations
+func RemoveBasicAuthAnnotations(annotations map[string]string) {
+	for k := range BasicAuthAnnotations("") {
+		delete(annotations, k)
+	}
+}
+
+// CreateBasicAuthSecret creates or updates the Secret containing the htpasswd file of the exposed service
+func CreateBasicAuthSecret(client kubernetes.Interface, ns string, service string, username string, password string) (string, error) {
+	name := BasicAuthSecretName(service)
+	data := map[string][]byte{AUTH: []byte(HtpasswdEntry(username, password))}
+	secrets := client.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return "", err
+		}
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+			Data: data,
+		}
+		_, err = secrets.Create(secret)
+	} else {
+		secret.Data = data
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to save Secret %s in namespace %s", name, ns)
+	}
+	return name, nil
+}
+
+// ParseAllowedCIDRs returns the CIDRs of the whitelist source range annotation
+func ParseAllowedCIDRs(annotations map[string]string) []string {
+	answer := []string{}
+	for _, cidr := range strings.Split(annotations[IngressWhitelistSourceRangeAnnotation], ",") {
+		cidr = strings.TrimSpace(cidr)
+		if cidr != "" {
+			answer = append(answer, cidr)
+		}
+	}
+	return answer
+}
+
+// SetAllowedCIDRs sets the whitelist source range annotation to the CIDRs or removes it if there are none
+func SetAllowedCIDRs(annotations map[string]string, cidrs []string) {
+	if len(cidrs) == 0 {
+		delete(annotations, IngressWhitelistSourceRangeAnnotation)
+		return
+	}
+	annotations[IngressWhitelistSourceRangeAnnotation] = strings.Join(cidrs, ",")
+}
+
+// ValidateCIDR returns the CIDR, adding a /32 or /128 suffix to a single IP address, or an error if it is invalid
+func ValidateCIDR(cidr string) (string, error) {
+	if !strings.Contains(cidr, "/") {
+		ip := net.ParseIP(cidr)
+		if ip == nil {
+			return "", fmt.Errorf("%s is not an IP address or CIDR", cidr)
+		}
+		if ip.To4() != nil {
+			return cidr + "/32", nil
+		}
+		return cidr + "/128", nil
+	}
+	_, _, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return "", err
+	}
+	return cidr, nil
+}
@@ -0,0 +1,58 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestValidateCIDR(t *testing.T) {
+	t.Parallel()
+	for value, expected := range map[string]string{
+		"203.0.113.0/24": "203.0.113.0/24",
+		"198.51.100.7":   "198.51.100.7/32",
+		"2001:db8::1":    "2001:db8::1/128",
+	} {
+		actual, err := kube.ValidateCIDR(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, actual)
+	}
+	for _, value := range []string{"", "example.com", "10.0.0.0/33"} {
+		_, err := kube.ValidateCIDR(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestAllowedCIDRs(t *testing.T) {
+	t.Parallel()
+	annotations := map[string]string{}
+	assert.Empty(t, kube.ParseAllowedCIDRs(annotations))
+
+	kube.SetAllowedCIDRs(annotations, []string{"203.0.113.0/24", "198.51.100.7/32"})
+	assert.Equal(t, "203.0.113.0/24,198.51.100.7/32", annotations[kube.IngressWhitelistSourceRangeAnnotation])
+	assert.Equal(t, []string{"203.0.113.0/24", "198.51.100.7/32"}, kube.ParseAllowedCIDRs(annotations))
+
+	kube.SetAllowedCIDRs(annotations, nil)
+	assert.Empty(t, annotations)
+}
+
+func TestCreateBasicAuthSecret(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	name, err := kube.CreateBasicAuthSecret(client, "jx", "nexus", "admin", "secret")
+	require.NoError(t, err)
+	assert.Equal(t, "nexus-basic-auth", name)
+
+	secret, err := client.CoreV1().Secrets("jx").Get(name, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "admin:{SHA}5en6G6MezRroT3XKqkdPOmY/BfQ=", string(secret.Data[kube.AUTH]))
+
+	annotations := kube.BasicAuthAnnotations(name)
+	assert.Equal(t, "basic", annotations[kube.IngressAuthTypeAnnotation])
+	kube.RemoveBasicAuthAnnotations(annotations)
+	assert.Empty(t, annotations)
+}
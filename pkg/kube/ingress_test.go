@@ -0,0 +1,101 @@
+package kube_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	"k8s.io/api/extensions/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWaitForIngress(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	client := fake.NewSimpleClientset(
+		&v1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: ns},
+			Status:     v1beta1.IngressStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{IP: "1.2.3.4"}}}},
+		},
+		&v1beta1.Ingress{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: ns}},
+	)
+	err := kube.WaitForIngress(client, ns, "jenkins", time.Second)
+	assert.NoError(t, err)
+
+	err = kube.WaitForIngress(client, ns, "nexus", 10*time.Millisecond)
+	assert.Error(t, err)
+	err = kube.WaitForIngress(client, ns, "missing", 10*time.Millisecond)
+	assert.Error(t, err)
+}
+
+func TestValidCertificate(t *testing.T) {
+	t.Parallel()
+	now := time.Now()
+	secret := createTLSSecret(t, "tls-jenkins", "jenkins.jx.example.com", now.Add(-time.Hour), now.Add(time.Hour))
+
+	cert, err := kube.ValidCertificate(secret, []string{"jenkins.jx.example.com"}, now)
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins.jx.example.com", cert.Subject.CommonName)
+
+	_, err = kube.ValidCertificate(secret, []string{"nexus.jx.example.com"}, now)
+	assert.Error(t, err)
+	_, err = kube.ValidCertificate(secret, nil, now.Add(2*time.Hour))
+	assert.Error(t, err)
+	_, err = kube.ValidCertificate(&v1.Secret{}, nil, now)
+	assert.Error(t, err)
+}
+
+func TestWaitForIngressCertificates(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	now := time.Now()
+	host := "jenkins.jx.example.com"
+	secret := createTLSSecret(t, "tls-jenkins", host, now.Add(-time.Hour), now.Add(time.Hour))
+	secret.Namespace = ns
+	client := fake.NewSimpleClientset(
+		&v1beta1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: ns},
+			Spec:       v1beta1.IngressSpec{TLS: []v1beta1.IngressTLS{{Hosts: []string{host}, SecretName: "tls-jenkins"}}},
+			Status:     v1beta1.IngressStatus{LoadBalancer: v1.LoadBalancerStatus{Ingress: []v1.LoadBalancerIngress{{Hostname: "lb.example.com"}}}},
+		},
+	)
+	err := kube.WaitForIngressCertificates(client, ns, "jenkins", 10*time.Millisecond)
+	assert.Error(t, err, "the TLS secret has not been created yet")
+
+	_, err = client.CoreV1().Secrets(ns).Create(secret)
+	require.NoError(t, err)
+	err = kube.WaitForIngressCertificates(client, ns, "jenkins", time.Second)
+	assert.NoError(t, err)
+}
+
+func createTLSSecret(t *testing.T, name string, host string, notBefore time.Time, notAfter time.Time) *v1.Secret {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: host},
+		DNSNames:     []string{host},
+		NotBefore:    notBefore,
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Type:       v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+			v1.TLSPrivateKeyKey: pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}),
+		},
+	}
+}
@@ -0,0 +1,426 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	// ExposerIstio the exposer which routes the exposed services through the Istio ingress gateway
+	ExposerIstio = "Istio"
+
+	// IstioGatewayName the name of the Gateway in the dev namespace the exposed services are routed through
+	IstioGatewayName = "jx-gateway"
+
+	// IstioNetworkingAPIVersion the API version of the Istio Gateway and VirtualService resources
+	IstioNetworkingAPIVersion = "networking.istio.io/v1alpha3"
+
+	istioNetworkingAPIPath = "/apis/" + IstioNetworkingAPIVersion
+)
+
+// IstioGateway is the subset of an Istio Gateway resource which describes the hosts it accepts
+type IstioGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IstioGatewaySpec `json:"spec"`
+}
+
+// IstioGatewaySpec the selector of the ingress gateway pods and the servers they expose
+type IstioGatewaySpec struct {
+	Selector map[string]string `json:"selector,omitempty"`
+	Servers  []IstioServer     `json:"servers,omitempty"`
+}
+
+// IstioServer a port of the ingress gateway and the hosts it accepts
+type IstioServer struct {
+	Port  IstioPort         `json:"port"`
+	Hosts []string          `json:"hosts,omitempty"`
+	TLS   map[string]string `json:"tls,omitempty"`
+}
+
+// IstioPort a port of an Istio server
+type IstioPort struct {
+	Number   int    `json:"number"`
+	Name     string `json:"name,omitempty"`
+	Protocol string `json:"protocol"`
+}
+
+// IstioVirtualService is the subset of an Istio VirtualService resource which routes hosts to services
+type IstioVirtualService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              IstioVirtualServiceSpec `json:"spec"`
+}
+
+// IstioVirtualServiceSpec the hosts and gateways of a VirtualService and the services it routes to
+type IstioVirtualServiceSpec struct {
+	Hosts    []string         `json:"hosts,omitempty"`
+	Gateways []string         `json:"gateways,omitempty"`
+	HTTP     []IstioHTTPRoute `json:"http,omitempty"`
+}
+
+// IstioHTTPRoute a HTTP route of a VirtualService
+type IstioHTTPRoute struct {
+	Route []IstioDestinationWeight `json:"route,omitempty"`
+}
+
+// IstioDestinationWeight a destination of a HTTP route
+type IstioDestinationWeight struct {
+	Destination IstioDestination `json:"destination"`
+}
+
+// IstioDestination the service a route sends traffic to
+type IstioDestination struct {
+	Host string         `json:"host"`
+	Port map[string]int `json:"port,omitempty"`
+}
+
+type istioVirtualServiceList struct {
+	Items []IstioVirtualService `json:"items"`
+}
+
+// istioRESTClient returns the REST client used to access the Istio resources or nil if the client does not
+// talk to a real cluster, such as in tests
+func crdRESTClient(client kubernetes.Interface) rest.Interface {
+	discovery := client.Discovery()
+	if discovery == nil {
+		return nil
+	}
+	return discovery.RESTClient()
+}
+
+func istioResourcePath(ns string, resource string, name string) string {
+	path := istioNetworkingAPIPath + "/namespaces/" + ns + "/" + resource
+	if name != "" {
+		path += "/" + name
+	}
+	return path
+}
+
+// GetIstioVirtualServices returns the VirtualServices in the given namespace. If Istio is not installed
+// no VirtualServices are returned
+func GetIstioVirtualServices(client kubernetes.Interface, ns string) ([]IstioVirtualService, error) {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return nil, nil
+	}
+	data, err := restClient.Get().AbsPath(istioResourcePath(ns, "virtualservices", "")).DoRaw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list the VirtualServices in namespace %s: %s", ns, err)
+	}
+	list := istioVirtualServiceList{}
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the VirtualServices in namespace %s: %s", ns, err)
+	}
+	return list.Items, nil
+}
+
+// GetIstioGateway returns the Gateway of the given name or nil if it does not exist
+func GetIstioGateway(client kubernetes.Interface, ns string, name string) (*IstioGateway, error) {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return nil, nil
+	}
+	data, err := restClient.Get().AbsPath(istioResourcePath(ns, "gateways", name)).DoRaw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the Gateway %s in namespace %s: %s", name, ns, err)
+	}
+	gateway := &IstioGateway{}
+	err = json.Unmarshal(data, gateway)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Gateway %s in namespace %s: %s", name, ns, err)
+	}
+	return gateway, nil
+}
+
+// FindIstioServiceURL returns the URL of the service from the hosts of the VirtualService routing to it
+// or an empty string if the service is not routed through an Istio gateway
+func FindIstioServiceURL(client kubernetes.Interface, ns string, name string) (string, error) {
+	virtualServices, err := GetIstioVirtualServices(client, ns)
+	if err != nil || len(virtualServices) == 0 {
+		return "", err
+	}
+	for _, vs := range virtualServices {
+		if VirtualServiceRoutesTo(&vs, ns, name) {
+			gateways, err := getIstioVirtualServiceGateways(client, &vs)
+			if err != nil {
+				return "", err
+			}
+			return VirtualServiceURL(&vs, gateways), nil
+		}
+	}
+	return "", nil
+}
+
+// FindIstioServiceURLs returns the URLs of the services in the namespace indexed by service name
+func FindIstioServiceURLs(client kubernetes.Interface, ns string) (map[string]string, error) {
+	answer := map[string]string{}
+	virtualServices, err := GetIstioVirtualServices(client, ns)
+	if err != nil {
+		return answer, err
+	}
+	for _, vs := range virtualServices {
+		gateways, err := getIstioVirtualServiceGateways(client, &vs)
+		if err != nil {
+			return answer, err
+		}
+		url := VirtualServiceURL(&vs, gateways)
+		if url == "" {
+			continue
+		}
+		for _, name := range VirtualServiceDestinations(&vs, ns) {
+			if answer[name] == "" {
+				answer[name] = url
+			}
+		}
+	}
+	return answer, nil
+}
+
+func getIstioVirtualServiceGateways(client kubernetes.Interface, vs *IstioVirtualService) ([]*IstioGateway, error) {
+	gateways := []*IstioGateway{}
+	for _, ref := range vs.Spec.Gateways {
+		ns := vs.Namespace
+		name := ref
+		paths := strings.SplitN(ref, "/", 2)
+		if len(paths) == 2 {
+			ns = paths[0]
+			name = paths[1]
+		}
+		gateway, err := GetIstioGateway(client, ns, name)
+		if err != nil {
+			return nil, err
+		}
+		if gateway != nil {
+			gateways = append(gateways, gateway)
+		}
+	}
+	return gateways, nil
+}
+
+// VirtualServiceDestinations returns the sorted names of the services in the namespace the VirtualService routes to
+func VirtualServiceDestinations(vs *IstioVirtualService, ns string) []string {
+	names := []string{}
+	found := map[string]bool{}
+	for _, route := range vs.Spec.HTTP {
+		for _, dest := range route.Route {
+			name := istioDestinationServiceName(dest.Destination.Host, ns)
+			if name != "" && !found[name] {
+				found[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// VirtualServiceRoutesTo returns true if the VirtualService routes to the service of the given name and namespace
+func VirtualServiceRoutesTo(vs *IstioVirtualService, ns string, name string) bool {
+	for _, n := range VirtualServiceDestinations(vs, ns) {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
+
+// istioDestinationServiceName returns the name of the service of a destination host if it is in the given namespace
+func istioDestinationServiceName(host string, ns string) string {
+	host = strings.TrimSuffix(host, ".svc.cluster.local")
+	paths := strings.Split(host, ".")
+	switch len(paths) {
+	case 1:
+		return paths[0]
+	case 2, 3:
+		if paths[1] == ns {
+			return paths[0]
+		}
+	}
+	return ""
+}
+
+// VirtualServiceURL returns the URL of the first external host of the VirtualService using https if one of its
+// gateways terminates TLS for that host
+func VirtualServiceURL(vs *IstioVirtualService, gateways []*IstioGateway) string {
+	for _, host := range vs.Spec.Hosts {
+		if host == "" || host == "*" || !strings.Contains(host, ".") || strings.HasSuffix(host, ".svc.cluster.local") {
+			continue
+		}
+		scheme := "http://"
+		for _, gateway := range gateways {
+			if gatewayTerminatesTLS(gateway, host) {
+				scheme = "https://"
+			}
+		}
+		return scheme + host
+	}
+	return ""
+}
+
+func gatewayTerminatesTLS(gateway *IstioGateway, host string) bool {
+	for _, server := range gateway.Spec.Servers {
+		if strings.ToUpper(server.Port.Protocol) != "HTTPS" {
+			continue
+		}
+		for _, h := range server.Hosts {
+			if istioHostMatches(h, host) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// istioHostMatches returns true if the gateway host, which may be a wildcard such as *.example.com, matches the host
+func istioHostMatches(pattern string, host string) bool {
+	if pattern == "*" || pattern == host {
+		return true
+	}
+	if strings.HasPrefix(pattern, "*.") {
+		return strings.HasSuffix(host, pattern[1:])
+	}
+	return false
+}
+
+// NewIstioGateway returns the Gateway which accepts the hosts of the domain on the Istio ingress gateway
+func NewIstioGateway(name string, domain string, tls bool) *IstioGateway {
+	hosts := []string{"*." + domain}
+	gateway := &IstioGateway{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: IstioNetworkingAPIVersion,
+			Kind:       "Gateway",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: IstioGatewaySpec{
+			Selector: map[string]string{"istio": "ingressgateway"},
+			Servers: []IstioServer{
+				{
+					Port:  IstioPort{Number: 80, Name: "http", Protocol: "HTTP"},
+					Hosts: hosts,
+				},
+			},
+		},
+	}
+	if tls {
+		gateway.Spec.Servers = append(gateway.Spec.Servers, IstioServer{
+			Port:  IstioPort{Number: 443, Name: "https", Protocol: "HTTPS"},
+			Hosts: hosts,
+			TLS: map[string]string{
+				"mode":              "SIMPLE",
+				"serverCertificate": "/etc/istio/ingressgateway-certs/tls.crt",
+				"privateKey":        "/etc/istio/ingressgateway-certs/tls.key",
+			},
+		})
+	}
+	return gateway
+}
+
+// NewIstioVirtualService returns the VirtualService routing the host through the gateway to the port of the service
+func NewIstioVirtualService(name string, host string, gateway string, port int) *IstioVirtualService {
+	destination := IstioDestination{Host: name}
+	if port > 0 {
+		destination.Port = map[string]int{"number": port}
+	}
+	return &IstioVirtualService{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: IstioNetworkingAPIVersion,
+			Kind:       "VirtualService",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: IstioVirtualServiceSpec{
+			Hosts:    []string{host},
+			Gateways: []string{gateway},
+			HTTP: []IstioHTTPRoute{
+				{
+					Route: []IstioDestinationWeight{{Destination: destination}},
+				},
+			},
+		},
+	}
+}
+
+// ApplyIstioGateway creates or updates the Gateway
+func ApplyIstioGateway(client kubernetes.Interface, ns string, gateway *IstioGateway) error {
+	return applyIstioResource(client, ns, "gateways", gateway.Name, gateway)
+}
+
+// ApplyIstioVirtualService creates or updates the VirtualService
+func ApplyIstioVirtualService(client kubernetes.Interface, ns string, vs *IstioVirtualService) error {
+	return applyIstioResource(client, ns, "virtualservices", vs.Name, vs)
+}
+
+// DeleteIstioGateway deletes the Gateway if it exists
+func DeleteIstioGateway(client kubernetes.Interface, ns string, name string) error {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return fmt.Errorf("no REST client available to delete the Gateway %s", name)
+	}
+	err := restClient.Delete().AbsPath(istioResourcePath(ns, "gateways", name)).Do().Error()
+	if err != nil && !errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete the Gateway %s in namespace %s: %s", name, ns, err)
+	}
+	return nil
+}
+
+func applyIstioResource(client kubernetes.Interface, ns string, resource string, name string, obj interface{}) error {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return fmt.Errorf("no REST client available to apply the %s %s", resource, name)
+	}
+	existing := &struct {
+		metav1.ObjectMeta `json:"metadata"`
+	}{}
+	data, err := restClient.Get().AbsPath(istioResourcePath(ns, resource, name)).DoRaw()
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return fmt.Errorf("failed to get the %s %s in namespace %s: %s", resource, name, ns, err)
+		}
+		body, err := json.Marshal(obj)
+		if err != nil {
+			return err
+		}
+		err = restClient.Post().AbsPath(istioResourcePath(ns, resource, "")).Body(body).Do().Error()
+		if err != nil {
+			return fmt.Errorf("failed to create the %s %s in namespace %s: %s", resource, name, ns, err)
+		}
+		return nil
+	}
+	err = json.Unmarshal(data, existing)
+	if err != nil {
+		return err
+	}
+	switch r := obj.(type) {
+	case *IstioGateway:
+		r.ResourceVersion = existing.ResourceVersion
+	case *IstioVirtualService:
+		r.ResourceVersion = existing.ResourceVersion
+	}
+	body, err := json.Marshal(obj)
+	if err != nil {
+		return err
+	}
+	err = restClient.Put().AbsPath(istioResourcePath(ns, resource, name)).Body(body).Do().Error()
+	if err != nil {
+		return fmt.Errorf("failed to update the %s %s in namespace %s: %s", resource, name, ns, err)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestVirtualServiceDestinations(t *testing.T) {
+	t.Parallel()
+	vs := kube.NewIstioVirtualService("jenkins", "jenkins.jx.example.com", "jx/jx-gateway", 8080)
+	vs.Spec.HTTP = append(vs.Spec.HTTP, kube.IstioHTTPRoute{
+		Route: []kube.IstioDestinationWeight{
+			{Destination: kube.IstioDestination{Host: "nexus.jx.svc.cluster.local"}},
+			{Destination: kube.IstioDestination{Host: "other.staging.svc.cluster.local"}},
+		},
+	})
+	assert.Equal(t, []string{"jenkins", "nexus"}, kube.VirtualServiceDestinations(vs, "jx"))
+	assert.True(t, kube.VirtualServiceRoutesTo(vs, "jx", "nexus"))
+	assert.False(t, kube.VirtualServiceRoutesTo(vs, "jx", "other"))
+}
+
+func TestVirtualServiceURL(t *testing.T) {
+	t.Parallel()
+	vs := kube.NewIstioVirtualService("jenkins", "jenkins.jx.example.com", "jx/jx-gateway", 8080)
+	vs.Spec.Hosts = append([]string{"jenkins"}, vs.Spec.Hosts...)
+
+	assert.Equal(t, "http://jenkins.jx.example.com", kube.VirtualServiceURL(vs, nil))
+
+	httpGateway := kube.NewIstioGateway("jx-gateway", "example.com", false)
+	assert.Equal(t, "http://jenkins.jx.example.com", kube.VirtualServiceURL(vs, []*kube.IstioGateway{httpGateway}))
+
+	tlsGateway := kube.NewIstioGateway("jx-gateway", "example.com", true)
+	assert.Equal(t, "https://jenkins.jx.example.com", kube.VirtualServiceURL(vs, []*kube.IstioGateway{tlsGateway}))
+
+	otherGateway := kube.NewIstioGateway("jx-gateway", "other.com", true)
+	assert.Equal(t, "http://jenkins.jx.example.com", kube.VirtualServiceURL(vs, []*kube.IstioGateway{otherGateway}))
+
+	vs.Spec.Hosts = []string{"jenkins", "jenkins.jx.svc.cluster.local"}
+	assert.Equal(t, "", kube.VirtualServiceURL(vs, nil))
+}
+
+func TestFindServiceURLWithoutIstio(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "jx",
+		},
+	})
+	url, err := kube.FindServiceURL(client, "jx", "jenkins")
+	assert.NoError(t, err)
+	assert.Equal(t, "", url)
+
+	urls, err := kube.FindServiceURLs(client, "jx")
+	assert.NoError(t, err)
+	assert.Empty(t, urls)
+}
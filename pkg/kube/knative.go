@@ -0,0 +1,178 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// KnativeServingAPIVersion the API version of the Knative Serving resources
+	KnativeServingAPIVersion = "serving.knative.dev/v1alpha1"
+
+	// KnativeServiceTemplateFile the name of the chart template which deploys the app as a Knative Service
+	KnativeServiceTemplateFile = "ksvc.yaml"
+
+	// ValuesKnativeDeploy the chart value which deploys the app as a Knative Service instead of a Deployment and Service
+	ValuesKnativeDeploy = "knativeDeploy"
+
+	// ValuesKnative the chart value containing the scaling settings of the Knative Service
+	ValuesKnative = "knative"
+
+	knativeServingAPIPath = "/apis/" + KnativeServingAPIVersion
+
+	knativeDeployCondition = ".Values." + ValuesKnativeDeploy
+)
+
+// KnativeServiceTemplate the chart template which deploys the app as a Knative Service when knativeDeploy is enabled.
+// The minScale annotation defaults to 0 so that idle apps scale to zero
+const KnativeServiceTemplate = `{{- if .Values.knativeDeploy }}
+apiVersion: serving.knative.dev/v1alpha1
+kind: Service
+metadata:
+  name: {{ .Values.service.name }}
+  labels:
+    chart: "{{ .Chart.Name }}-{{ .Chart.Version | replace "+" "_" }}"
+spec:
+  runLatest:
+    configuration:
+      revisionTemplate:
+        metadata:
+          annotations:
+{{- with .Values.knative }}
+            autoscaling.knative.dev/minScale: "{{ .minScale | default 0 }}"
+{{- if .maxScale }}
+            autoscaling.knative.dev/maxScale: "{{ .maxScale }}"
+{{- end }}
+{{- end }}
+        spec:
+{{- with .Values.knative }}
+{{- if .containerConcurrency }}
+          containerConcurrency: {{ .containerConcurrency }}
+{{- end }}
+{{- end }}
+          container:
+            image: "{{ .Values.image.repository }}:{{ .Values.image.tag }}"
+            imagePullPolicy: {{ .Values.image.pullPolicy }}
+{{- with .Values.resources }}
+            resources:
+{{ toYaml . | indent 14 }}
+{{- end }}
+{{- end }}
+`
+
+// KnativeScaling the scaling settings of an app deployed as a Knative Service
+type KnativeScaling struct {
+	// MinScale the minimum number of pods. Apps scale to zero when idle if 0
+	MinScale int `json:"minScale"`
+	// MaxScale the maximum number of pods. Unlimited if 0
+	MaxScale int `json:"maxScale,omitempty"`
+	// ContainerConcurrency the maximum number of concurrent requests handled by a pod. Unlimited if 0
+	ContainerConcurrency int `json:"containerConcurrency,omitempty"`
+}
+
+// KnativeRoute is the subset of a Knative Route resource which describes where the app is available
+type KnativeRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            KnativeRouteStatus `json:"status,omitempty"`
+}
+
+// KnativeRouteStatus the external URL or domain of a Knative Route
+type KnativeRouteStatus struct {
+	URL    string `json:"url,omitempty"`
+	Domain string `json:"domain,omitempty"`
+}
+
+// GetKnativeRoute returns the Knative Route of the given name or nil if it does not exist or Knative is not installed
+func GetKnativeRoute(client kubernetes.Interface, ns string, name string) (*KnativeRoute, error) {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return nil, nil
+	}
+	path := knativeServingAPIPath + "/namespaces/" + ns + "/routes/" + name
+	data, err := restClient.Get().AbsPath(path).DoRaw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get the Knative Route %s in namespace %s: %s", name, ns, err)
+	}
+	route := &KnativeRoute{}
+	err = json.Unmarshal(data, route)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Knative Route %s in namespace %s: %s", name, ns, err)
+	}
+	return route, nil
+}
+
+// KnativeRouteURL returns the external URL of the Knative Route or an empty string if it has not been assigned a domain yet
+func KnativeRouteURL(route *KnativeRoute) string {
+	if route == nil {
+		return ""
+	}
+	if route.Status.URL != "" {
+		return route.Status.URL
+	}
+	if route.Status.Domain != "" {
+		return "http://" + route.Status.Domain
+	}
+	return ""
+}
+
+// FindKnativeServiceURL returns the URL of the app deployed as a Knative Service of the given name or an empty string
+// if there is no such Knative Service
+func FindKnativeServiceURL(client kubernetes.Interface, ns string, name string) (string, error) {
+	route, err := GetKnativeRoute(client, ns, name)
+	if err != nil {
+		return "", err
+	}
+	return KnativeRouteURL(route), nil
+}
+
+// FindKnativeServiceURLs returns the URLs of the apps deployed as Knative Services in the namespace indexed by name
+func FindKnativeServiceURLs(client kubernetes.Interface, ns string) (map[string]string, error) {
+	answer := map[string]string{}
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return answer, nil
+	}
+	data, err := restClient.Get().AbsPath(knativeServingAPIPath + "/namespaces/" + ns + "/routes").DoRaw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return answer, nil
+		}
+		return answer, fmt.Errorf("failed to list the Knative Routes in namespace %s: %s", ns, err)
+	}
+	list := struct {
+		Items []KnativeRoute `json:"items"`
+	}{}
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return answer, fmt.Errorf("failed to parse the Knative Routes in namespace %s: %s", ns, err)
+	}
+	for i := range list.Items {
+		route := &list.Items[i]
+		url := KnativeRouteURL(route)
+		if url != "" {
+			answer[route.Name] = url
+		}
+	}
+	return answer, nil
+}
+
+// DisableTemplateForKnative wraps the text of a chart template, such as the Deployment or Service of the app, so that
+// it is not rendered when the app is deployed as a Knative Service
+func DisableTemplateForKnative(text string) string {
+	if strings.Contains(text, knativeDeployCondition) {
+		return text
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return "{{- if not " + knativeDeployCondition + " }}\n" + text + "{{- end }}\n"
+}
@@ -0,0 +1,30 @@
+package kube_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnativeRouteURL(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "", kube.KnativeRouteURL(nil))
+	assert.Equal(t, "", kube.KnativeRouteURL(&kube.KnativeRoute{}))
+
+	route := &kube.KnativeRoute{}
+	route.Status.Domain = "myapp.jx-staging.example.com"
+	assert.Equal(t, "http://myapp.jx-staging.example.com", kube.KnativeRouteURL(route))
+
+	route.Status.URL = "https://myapp.jx-staging.example.com"
+	assert.Equal(t, "https://myapp.jx-staging.example.com", kube.KnativeRouteURL(route))
+}
+
+func TestDisableTemplateForKnative(t *testing.T) {
+	t.Parallel()
+	text := kube.DisableTemplateForKnative("apiVersion: v1\nkind: Service")
+	assert.Equal(t, "{{- if not .Values.knativeDeploy }}\napiVersion: v1\nkind: Service\n{{- end }}\n", text)
+	assert.Equal(t, text, kube.DisableTemplateForKnative(text), "the template should only be wrapped once")
+	assert.True(t, strings.HasPrefix(kube.KnativeServiceTemplate, "{{- if .Values.knativeDeploy }}"))
+}
@@ -0,0 +1,315 @@
+package kube
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// KubeConfigBackupSuffix the suffix of the copy of the kube config file taken before it is modified
+	KubeConfigBackupSuffix = ".jx-backup"
+
+	authProviderGCP   = "gcp"
+	authProviderAzure = "azure"
+)
+
+// MergeKubeConfig merges the clusters, users and contexts of the new config into the config. Entries with the same
+// name are replaced as the provider has just issued them, all the other entries are kept. The names of the merged
+// contexts are returned
+func MergeKubeConfig(config *api.Config, newConfig *api.Config, setCurrent bool) []string {
+	if config.Clusters == nil {
+		config.Clusters = map[string]*api.Cluster{}
+	}
+	if config.AuthInfos == nil {
+		config.AuthInfos = map[string]*api.AuthInfo{}
+	}
+	if config.Contexts == nil {
+		config.Contexts = map[string]*api.Context{}
+	}
+	for name, cluster := range newConfig.Clusters {
+		config.Clusters[name] = cluster
+	}
+	for name, authInfo := range newConfig.AuthInfos {
+		config.AuthInfos[name] = authInfo
+	}
+	names := []string{}
+	for name, ctx := range newConfig.Contexts {
+		config.Contexts[name] = ctx
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if setCurrent && newConfig.CurrentContext != "" {
+		config.CurrentContext = newConfig.CurrentContext
+	}
+	return names
+}
+
+// MergeKubeConfigFile merges the kube config file written by a provider into the kube config of the user taking a
+// backup of the kube config first. The names of the merged contexts are returned
+func MergeKubeConfigFile(fileName string, setCurrent bool) ([]string, error) {
+	newConfig, err := clientcmd.LoadFromFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load the kube config file %s", fileName)
+	}
+	return MergeIntoKubeConfig(newConfig, setCurrent)
+}
+
+// MergeIntoKubeConfig merges the config into the kube config of the user taking a backup of the kube config first.
+// The names of the merged contexts are returned
+func MergeIntoKubeConfig(newConfig *api.Config, setCurrent bool) ([]string, error) {
+	config, po, err := LoadConfig()
+	if err != nil {
+		return nil, err
+	}
+	_, err = BackupKubeConfig(po)
+	if err != nil {
+		return nil, err
+	}
+	names := MergeKubeConfig(config, newConfig, setCurrent)
+	err = clientcmd.ModifyConfig(po, *config, false)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to update the kube config %s", err)
+	}
+	return names, nil
+}
+
+// BackupKubeConfig copies the kube config file so that it can be restored if a change breaks it. The name of the
+// backup is returned or an empty string if there is no kube config file yet
+func BackupKubeConfig(po *clientcmd.PathOptions) (string, error) {
+	fileName := po.GetDefaultFilename()
+	exists, err := util.FileExists(fileName)
+	if err != nil || !exists {
+		return "", err
+	}
+	backup := fileName + KubeConfigBackupSuffix
+	err = util.CopyFile(fileName, backup)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to backup the kube config file %s", fileName)
+	}
+	return backup, nil
+}
+
+// BrokenContexts returns the sorted names of the contexts which refer to a cluster or user which does not exist
+func BrokenContexts(config *api.Config) []string {
+	names := []string{}
+	for name, ctx := range config.Contexts {
+		if ctx == nil {
+			names = append(names, name)
+			continue
+		}
+		cluster := config.Clusters[ctx.Cluster]
+		if cluster == nil || cluster.Server == "" || (ctx.AuthInfo != "" && config.AuthInfos[ctx.AuthInfo] == nil) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// UnreachableContexts returns the sorted names of the contexts whose API server cannot be connected to within the
+// timeout, which is usually because the cluster has been deleted
+func UnreachableContexts(config *api.Config, timeout time.Duration) []string {
+	reachable := map[string]bool{}
+	names := []string{}
+	for name, ctx := range config.Contexts {
+		if ctx == nil {
+			continue
+		}
+		cluster := config.Clusters[ctx.Cluster]
+		if cluster == nil || cluster.Server == "" {
+			continue
+		}
+		ok, checked := reachable[cluster.Server]
+		if !checked {
+			ok = isServerReachable(cluster.Server, timeout)
+			reachable[cluster.Server] = ok
+		}
+		if !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PruneContexts removes the contexts from the config along with any clusters and users which are no longer referred
+// to by the remaining contexts. The current context is cleared if it is removed
+func PruneContexts(config *api.Config, names []string) {
+	for _, name := range names {
+		delete(config.Contexts, name)
+		if config.CurrentContext == name {
+			config.CurrentContext = ""
+		}
+	}
+	clusters := map[string]bool{}
+	users := map[string]bool{}
+	for _, ctx := range config.Contexts {
+		if ctx != nil {
+			clusters[ctx.Cluster] = true
+			users[ctx.AuthInfo] = true
+		}
+	}
+	for name := range config.Clusters {
+		if !clusters[name] {
+			delete(config.Clusters, name)
+		}
+	}
+	for name := range config.AuthInfos {
+		if !users[name] {
+			delete(config.AuthInfos, name)
+		}
+	}
+}
+
+// CredentialExpiry returns when the access token cached in the kube config by the GKE or AKS auth provider expires
+func CredentialExpiry(authInfo *api.AuthInfo) (time.Time, bool) {
+	if authInfo == nil || authInfo.AuthProvider == nil || authInfo.AuthProvider.Config == nil {
+		return time.Time{}, false
+	}
+	cfg := authInfo.AuthProvider.Config
+	switch authInfo.AuthProvider.Name {
+	case authProviderGCP:
+		expiry, err := time.Parse(time.RFC3339Nano, cfg["expiry"])
+		if err == nil {
+			return expiry, true
+		}
+	case authProviderAzure:
+		seconds, err := strconv.ParseInt(cfg["expires-on"], 10, 64)
+		if err == nil {
+			return time.Unix(seconds, 0), true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ExpiringCredentials returns the sorted names of the users whose cached access token expires within the duration
+func ExpiringCredentials(config *api.Config, within time.Duration) []string {
+	deadline := time.Now().Add(within)
+	names := []string{}
+	for name, authInfo := range config.AuthInfos {
+		expiry, ok := CredentialExpiry(authInfo)
+		if ok && expiry.Before(deadline) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RefreshCredentials refreshes the credentials of the user. The access token cached by the GKE or AKS auth provider
+// is removed so that a new one is requested on the next use, while the exec plugin used by EKS and other providers
+// is run to verify it can still issue a token, e.g. the cloud CLI session has not expired
+func RefreshCredentials(config *api.Config, user string) error {
+	authInfo := config.AuthInfos[user]
+	if authInfo == nil {
+		return fmt.Errorf("no user %s in the kube config", user)
+	}
+	if authInfo.AuthProvider != nil && authInfo.AuthProvider.Config != nil {
+		cfg := authInfo.AuthProvider.Config
+		switch authInfo.AuthProvider.Name {
+		case authProviderGCP:
+			delete(cfg, "access-token")
+			delete(cfg, "expiry")
+		case authProviderAzure:
+			if cfg["refresh-token"] == "" {
+				return fmt.Errorf("user %s has no refresh token, please run 'az aks get-credentials' again", user)
+			}
+			delete(cfg, "access-token")
+			delete(cfg, "expires-on")
+		}
+	}
+	if authInfo.Exec != nil {
+		env := map[string]string{
+			"KUBERNETES_EXEC_INFO": fmt.Sprintf(`{"apiVersion":"%s","kind":"ExecCredential","spec":{}}`, authInfo.Exec.APIVersion),
+		}
+		for _, e := range authInfo.Exec.Env {
+			env[e.Name] = e.Value
+		}
+		cmd := util.Command{
+			Name: authInfo.Exec.Command,
+			Args: authInfo.Exec.Args,
+			Env:  env,
+		}
+		_, err := cmd.RunWithoutRetry()
+		if err != nil {
+			return errors.Wrapf(err, "failed to get a token for user %s", user)
+		}
+	}
+	return nil
+}
+
+func isServerReachable(server string, timeout time.Duration) bool {
+	u, err := url.Parse(server)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	host := u.Host
+	if u.Port() == "" {
+		port := "443"
+		if u.Scheme == "http" {
+			port = "80"
+		}
+		host = net.JoinHostPort(u.Hostname(), port)
+	}
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return false
+	}
+	conn.Close()
+	return true
+}
+
+// WindowsKubeConfig returns the named contexts of the kube config of a Windows tool, such as Docker Desktop or
+// minikube, so that they can be merged into the kube config used inside WSL. The paths of the certificate and key
+// files the tool wrote on the Windows host are translated into the paths WSL mounts them at
+func WindowsKubeConfig(config *api.Config, names []string) (*api.Config, error) {
+	answer := api.NewConfig()
+	for _, name := range names {
+		ctx := config.Contexts[name]
+		if ctx == nil {
+			return nil, fmt.Errorf("could not find the context %s in the Windows kube config", name)
+		}
+		answer.Contexts[name] = ctx
+		if cluster := config.Clusters[ctx.Cluster]; cluster != nil {
+			err := toWSLPath(&cluster.CertificateAuthority)
+			if err != nil {
+				return nil, err
+			}
+			answer.Clusters[ctx.Cluster] = cluster
+		}
+		if authInfo := config.AuthInfos[ctx.AuthInfo]; authInfo != nil {
+			for _, path := range []*string{&authInfo.ClientCertificate, &authInfo.ClientKey, &authInfo.TokenFile} {
+				err := toWSLPath(path)
+				if err != nil {
+					return nil, err
+				}
+			}
+			answer.AuthInfos[ctx.AuthInfo] = authInfo
+		}
+	}
+	if util.StringArrayIndex(names, config.CurrentContext) >= 0 {
+		answer.CurrentContext = config.CurrentContext
+	}
+	return answer, nil
+}
+
+func toWSLPath(path *string) error {
+	if *path == "" {
+		return nil
+	}
+	answer, err := util.WindowsToWSLPath(*path)
+	if err != nil {
+		return errors.Wrap(err, "failed to translate the Windows kube config")
+	}
+	*path = answer
+	return nil
+}
@@ -0,0 +1,134 @@
+package kube_test
+
+import (
+	"net"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/tools/clientcmd/api"
+)
+
+func TestMergeKubeConfig(t *testing.T) {
+	t.Parallel()
+	config := api.NewConfig()
+	config.Clusters["old"] = &api.Cluster{Server: "https://old"}
+	config.Clusters["aks"] = &api.Cluster{Server: "https://stale"}
+	config.AuthInfos["old"] = &api.AuthInfo{Token: "old"}
+	config.Contexts["old"] = &api.Context{Cluster: "old", AuthInfo: "old"}
+	config.CurrentContext = "old"
+
+	newConfig := api.NewConfig()
+	newConfig.Clusters["aks"] = &api.Cluster{Server: "https://new"}
+	newConfig.AuthInfos["aks-user"] = &api.AuthInfo{Token: "new"}
+	newConfig.Contexts["aks"] = &api.Context{Cluster: "aks", AuthInfo: "aks-user"}
+	newConfig.CurrentContext = "aks"
+
+	names := kube.MergeKubeConfig(config, newConfig, false)
+	assert.Equal(t, []string{"aks"}, names)
+	assert.Equal(t, "old", config.CurrentContext)
+	assert.Equal(t, "https://new", config.Clusters["aks"].Server)
+	assert.NotNil(t, config.Contexts["old"])
+	assert.Len(t, config.AuthInfos, 2)
+
+	kube.MergeKubeConfig(config, newConfig, true)
+	assert.Equal(t, "aks", config.CurrentContext)
+}
+
+func TestPruneContexts(t *testing.T) {
+	t.Parallel()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	closed, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	closedAddr := closed.Addr().String()
+	closed.Close()
+
+	config := api.NewConfig()
+	config.Clusters["live"] = &api.Cluster{Server: "https://" + listener.Addr().String()}
+	config.Clusters["deleted"] = &api.Cluster{Server: "https://" + closedAddr}
+	config.AuthInfos["live"] = &api.AuthInfo{}
+	config.AuthInfos["deleted"] = &api.AuthInfo{}
+	config.Contexts["live"] = &api.Context{Cluster: "live", AuthInfo: "live"}
+	config.Contexts["deleted"] = &api.Context{Cluster: "deleted", AuthInfo: "deleted"}
+	config.Contexts["no-cluster"] = &api.Context{Cluster: "missing", AuthInfo: "live"}
+	config.Contexts["no-user"] = &api.Context{Cluster: "live", AuthInfo: "missing"}
+	config.CurrentContext = "deleted"
+
+	assert.Equal(t, []string{"no-cluster", "no-user"}, kube.BrokenContexts(config))
+	assert.Equal(t, []string{"deleted"}, kube.UnreachableContexts(config, time.Second))
+
+	kube.PruneContexts(config, []string{"deleted", "no-cluster", "no-user"})
+	assert.Equal(t, "", config.CurrentContext)
+	assert.Len(t, config.Contexts, 1)
+	assert.NotNil(t, config.Clusters["live"])
+	assert.Nil(t, config.Clusters["deleted"])
+	assert.NotNil(t, config.AuthInfos["live"])
+	assert.Nil(t, config.AuthInfos["deleted"])
+}
+
+func TestRefreshCredentials(t *testing.T) {
+	t.Parallel()
+	soon := time.Now().Add(time.Minute)
+	later := time.Now().Add(time.Hour)
+	config := api.NewConfig()
+	config.AuthInfos["gke"] = &api.AuthInfo{AuthProvider: &api.AuthProviderConfig{
+		Name: "gcp",
+		Config: map[string]string{
+			"access-token": "abc",
+			"cmd-path":     "gcloud",
+			"expiry":       soon.Format(time.RFC3339),
+		},
+	}}
+	config.AuthInfos["aks"] = &api.AuthInfo{AuthProvider: &api.AuthProviderConfig{
+		Name: "azure",
+		Config: map[string]string{
+			"access-token": "abc",
+			"expires-on":   strconv.FormatInt(later.Unix(), 10),
+		},
+	}}
+	config.AuthInfos["token"] = &api.AuthInfo{Token: "abc"}
+
+	assert.Equal(t, []string{"gke"}, kube.ExpiringCredentials(config, 5*time.Minute))
+	assert.Equal(t, []string{"aks", "gke"}, kube.ExpiringCredentials(config, 2*time.Hour))
+
+	require.NoError(t, kube.RefreshCredentials(config, "gke"))
+	assert.Equal(t, map[string]string{"cmd-path": "gcloud"}, config.AuthInfos["gke"].AuthProvider.Config)
+
+	assert.Error(t, kube.RefreshCredentials(config, "aks"), "should fail without a refresh token")
+	assert.Error(t, kube.RefreshCredentials(config, "missing"))
+	assert.NoError(t, kube.RefreshCredentials(config, "token"))
+}
+
+func TestWindowsKubeConfig(t *testing.T) {
+	t.Parallel()
+	config := api.NewConfig()
+	config.Clusters["minikube"] = &api.Cluster{Server: "https://172.17.8.10:8443", CertificateAuthority: `C:\Users\jx\.minikube\ca.crt`}
+	config.AuthInfos["minikube"] = &api.AuthInfo{ClientCertificate: `C:\Users\jx\.minikube\client.crt`, ClientKey: `C:\Users\jx\.minikube\client.key`}
+	config.Contexts["minikube"] = &api.Context{Cluster: "minikube", AuthInfo: "minikube"}
+	config.Clusters["docker-desktop"] = &api.Cluster{Server: "https://kubernetes.docker.internal:6443", CertificateAuthorityData: []byte("ca")}
+	config.AuthInfos["docker-desktop"] = &api.AuthInfo{ClientCertificateData: []byte("cert")}
+	config.Contexts["docker-desktop"] = &api.Context{Cluster: "docker-desktop", AuthInfo: "docker-desktop"}
+	config.Contexts["gke"] = &api.Context{Cluster: "gke", AuthInfo: "gke"}
+	config.CurrentContext = "minikube"
+
+	answer, err := kube.WindowsKubeConfig(config, []string{"minikube"})
+	require.NoError(t, err)
+	assert.Len(t, answer.Contexts, 1)
+	assert.Equal(t, "minikube", answer.CurrentContext)
+	assert.Equal(t, "/mnt/c/Users/jx/.minikube/ca.crt", answer.Clusters["minikube"].CertificateAuthority)
+	assert.Equal(t, "/mnt/c/Users/jx/.minikube/client.crt", answer.AuthInfos["minikube"].ClientCertificate)
+	assert.Equal(t, "/mnt/c/Users/jx/.minikube/client.key", answer.AuthInfos["minikube"].ClientKey)
+
+	answer, err = kube.WindowsKubeConfig(config, []string{"docker-desktop"})
+	require.NoError(t, err)
+	assert.Equal(t, "", answer.CurrentContext)
+	assert.Equal(t, []byte("ca"), answer.Clusters["docker-desktop"].CertificateAuthorityData)
+
+	_, err = kube.WindowsKubeConfig(config, []string{"missing"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,106 @@
+package kube
+
+import (
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// DefaultListPageSize the number of resources fetched per request when resources are listed page by page so that
+// listing the resources of large clusters does not load them all in a single response
+const DefaultListPageSize = 500
+
+// ListPages invokes the list function with the options of each page of resources until there are no more pages. The
+// list function returns the continue token of the page it fetched. The page size defaults to DefaultListPageSize if
+// the options have no limit
+func ListPages(options metav1.ListOptions, list func(options metav1.ListOptions) (string, error)) error {
+	if options.Limit == 0 {
+		options.Limit = DefaultListPageSize
+	}
+	for {
+		next, err := list(options)
+		if err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		options.Continue = next
+	}
+}
+
+// ListServices returns the services in the namespace matching the label and field selectors of the options
+// fetching them page by page
+func ListServices(client kubernetes.Interface, ns string, options metav1.ListOptions) ([]v1.Service, error) {
+	answer := []v1.Service{}
+	err := ListPages(options, func(options metav1.ListOptions) (string, error) {
+		list, err := client.CoreV1().Services(ns).List(options)
+		if err != nil {
+			return "", err
+		}
+		answer = append(answer, list.Items...)
+		return list.Continue, nil
+	})
+	return answer, err
+}
+
+// ListPods returns the pods in the namespace matching the label and field selectors of the options fetching them
+// page by page
+func ListPods(client kubernetes.Interface, ns string, options metav1.ListOptions) ([]v1.Pod, error) {
+	answer := []v1.Pod{}
+	err := ListPages(options, func(options metav1.ListOptions) (string, error) {
+		list, err := client.CoreV1().Pods(ns).List(options)
+		if err != nil {
+			return "", err
+		}
+		answer = append(answer, list.Items...)
+		return list.Continue, nil
+	})
+	return answer, err
+}
+
+// ListDeployments returns the deployments in the namespace matching the label and field selectors of the options
+// fetching them page by page
+func ListDeployments(client kubernetes.Interface, ns string, options metav1.ListOptions) ([]appsv1.Deployment, error) {
+	answer := []appsv1.Deployment{}
+	err := ListPages(options, func(options metav1.ListOptions) (string, error) {
+		list, err := client.AppsV1().Deployments(ns).List(options)
+		if err != nil {
+			return "", err
+		}
+		answer = append(answer, list.Items...)
+		return list.Continue, nil
+	})
+	return answer, err
+}
+
+// ListStatefulSets returns the statefulsets in the namespace matching the label and field selectors of the options
+// fetching them page by page
+func ListStatefulSets(client kubernetes.Interface, ns string, options metav1.ListOptions) ([]appsv1.StatefulSet, error) {
+	answer := []appsv1.StatefulSet{}
+	err := ListPages(options, func(options metav1.ListOptions) (string, error) {
+		list, err := client.AppsV1().StatefulSets(ns).List(options)
+		if err != nil {
+			return "", err
+		}
+		answer = append(answer, list.Items...)
+		return list.Continue, nil
+	})
+	return answer, err
+}
+
+// ListNamespaces returns the namespaces matching the label and field selectors of the options fetching them page by
+// page
+func ListNamespaces(client kubernetes.Interface, options metav1.ListOptions) ([]v1.Namespace, error) {
+	answer := []v1.Namespace{}
+	err := ListPages(options, func(options metav1.ListOptions) (string, error) {
+		list, err := client.CoreV1().Namespaces().List(options)
+		if err != nil {
+			return "", err
+		}
+		answer = append(answer, list.Items...)
+		return list.Continue, nil
+	})
+	return answer, err
+}
@@ -0,0 +1,48 @@
+package kube_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestListPages(t *testing.T) {
+	t.Parallel()
+	requests := []metav1.ListOptions{}
+	err := kube.ListPages(metav1.ListOptions{LabelSelector: "app=jenkins"}, func(options metav1.ListOptions) (string, error) {
+		requests = append(requests, options)
+		if len(requests) < 3 {
+			return fmt.Sprintf("page-%d", len(requests)+1), nil
+		}
+		return "", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []metav1.ListOptions{
+		{LabelSelector: "app=jenkins", Limit: kube.DefaultListPageSize},
+		{LabelSelector: "app=jenkins", Limit: kube.DefaultListPageSize, Continue: "page-2"},
+		{LabelSelector: "app=jenkins", Limit: kube.DefaultListPageSize, Continue: "page-3"},
+	}, requests)
+
+	err = kube.ListPages(metav1.ListOptions{Limit: 10}, func(options metav1.ListOptions) (string, error) {
+		assert.Equal(t, int64(10), options.Limit)
+		return "", fmt.Errorf("forbidden")
+	})
+	assert.EqualError(t, err, "forbidden")
+}
+
+func TestGetTeams(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "jx", Labels: map[string]string{kube.LabelEnvironment: kube.LabelValueDevEnvironment}}},
+		&v1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "jx-staging", Labels: map[string]string{kube.LabelEnvironment: "staging"}}},
+	)
+	_, names, err := kube.GetTeams(client)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jx"}, names)
+}
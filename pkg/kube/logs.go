@@ -0,0 +1,178 @@
+package kube
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jenkins-x/jx/pkg/log"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// maxLogLineLength the longest log line which is streamed before it is split
+const maxLogLineLength = 1024 * 1024
+
+var logColors = []color.Attribute{
+	color.FgCyan, color.FgGreen, color.FgMagenta, color.FgYellow, color.FgBlue,
+	color.FgHiCyan, color.FgHiGreen, color.FgHiMagenta, color.FgHiYellow, color.FgHiBlue,
+}
+
+// LogSource opens the log of a container of a pod
+type LogSource func(ns string, pod string, opts *v1.PodLogOptions) (io.ReadCloser, error)
+
+// PodLogSource returns the LogSource which reads the logs from the Kubernetes API server
+func PodLogSource(kubeClient kubernetes.Interface) LogSource {
+	return func(ns string, pod string, opts *v1.PodLogOptions) (io.ReadCloser, error) {
+		return kubeClient.CoreV1().Pods(ns).GetLogs(pod, opts).Stream()
+	}
+}
+
+// LogStreamer streams the logs of all the containers of the pods matching a label selector such as the pods of a
+// knative build, a Jenkins agent or a Deployment
+type LogStreamer struct {
+	KubeClient kubernetes.Interface
+	Namespace  string
+	Selector   string
+	// Container if specified only the logs of this container are streamed
+	Container string
+	Out       io.Writer
+	// Prefix whether each line is prefixed with the pod and container name in a color per container
+	Prefix bool
+	// Follow whether to keep streaming as containers restart and new pods appear
+	Follow bool
+	// UntilComplete when following stops once all the pods matching the selector have completed
+	UntilComplete bool
+	PollInterval  time.Duration
+	// Source opens the container logs; defaults to the Kubernetes API server
+	Source LogSource
+
+	lock     sync.Mutex
+	wg       sync.WaitGroup
+	streamed map[string]bool
+	colors   map[string]func(a ...interface{}) string
+}
+
+// Run streams the logs until the stop channel is closed. If not following it returns once the current logs have been
+// written
+func (s *LogStreamer) Run(stop <-chan struct{}) error {
+	if s.Source == nil {
+		s.Source = PodLogSource(s.KubeClient)
+	}
+	interval := s.PollInterval
+	if interval == 0 {
+		interval = time.Second
+	}
+	s.streamed = map[string]bool{}
+	s.colors = map[string]func(a ...interface{}) string{}
+
+	for {
+		list, err := s.KubeClient.CoreV1().Pods(s.Namespace).List(metav1.ListOptions{
+			LabelSelector: s.Selector,
+		})
+		if err != nil {
+			return fmt.Errorf("Failed to load Pods in namespace %s with selector %s: %s", s.Namespace, s.Selector, err)
+		}
+		pods := list.Items
+		sort.Slice(pods, func(i, j int) bool {
+			return pods[i].CreationTimestamp.Before(&pods[j].CreationTimestamp)
+		})
+		complete := len(pods) > 0
+		for i := range pods {
+			pod := &pods[i]
+			s.streamPod(pod)
+			if !isPodCompleted(pod) {
+				complete = false
+			}
+		}
+		if !s.Follow || (s.UntilComplete && complete) {
+			s.wg.Wait()
+			return nil
+		}
+		select {
+		case <-stop:
+			return nil
+		case <-time.After(interval):
+		}
+	}
+}
+
+// streamPod starts streaming the containers of the pod which have started since the last poll. Restarted containers
+// are streamed again
+func (s *LogStreamer) streamPod(pod *v1.Pod) {
+	statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		if s.Container != "" && status.Name != s.Container {
+			continue
+		}
+		if status.State.Running == nil && status.State.Terminated == nil {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s/%d", pod.Name, status.Name, status.RestartCount)
+		s.lock.Lock()
+		streamed := s.streamed[key]
+		s.streamed[key] = true
+		s.lock.Unlock()
+		if streamed {
+			continue
+		}
+
+		podName := pod.Name
+		container := status.Name
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			err := s.streamContainer(podName, container)
+			if err != nil {
+				log.Warnf("Failed to stream the log of container %s of pod %s: %s\n", container, podName, err)
+			}
+		}()
+	}
+}
+
+func (s *LogStreamer) streamContainer(pod string, container string) error {
+	reader, err := s.Source(s.Namespace, pod, &v1.PodLogOptions{
+		Container: container,
+		Follow:    s.Follow,
+	})
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	prefix := ""
+	if s.Prefix {
+		prefix = s.colorFor(container)(pod+" "+container) + " "
+	}
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLogLineLength)
+	for scanner.Scan() {
+		s.lock.Lock()
+		fmt.Fprintf(s.Out, "%s%s\n", prefix, scanner.Text())
+		s.lock.Unlock()
+	}
+	return scanner.Err()
+}
+
+// colorFor returns the color of the given container so that the lines of each container can be told apart
+func (s *LogStreamer) colorFor(container string) func(a ...interface{}) string {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	fn := s.colors[container]
+	if fn == nil {
+		fn = color.New(logColors[len(s.colors)%len(logColors)]).SprintFunc()
+		s.colors[container] = fn
+	}
+	return fn
+}
+
+func isPodCompleted(pod *v1.Pod) bool {
+	phase := pod.Status.Phase
+	return phase == v1.PodSucceeded || phase == v1.PodFailed
+}
@@ -0,0 +1,110 @@
+package kube_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestLogStreamerStreamsAllStartedContainers(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	running := corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}
+	terminated := corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{}}
+	waiting := corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}}
+	kubeClient := fake.NewSimpleClientset(
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myapp-build-1",
+				Namespace: ns,
+				Labels:    map[string]string{"build": "myapp-1"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodSucceeded,
+				InitContainerStatuses: []corev1.ContainerStatus{
+					{Name: "build-step-checkout", State: terminated},
+					{Name: "build-step-build", State: terminated},
+				},
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nop", State: terminated},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myapp-build-2",
+				Namespace: ns,
+				Labels:    map[string]string{"build": "myapp-2"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nop", State: running},
+				},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "myapp-build-3",
+				Namespace: ns,
+				Labels:    map[string]string{"build": "myapp-1"},
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodPending,
+				ContainerStatuses: []corev1.ContainerStatus{
+					{Name: "nop", State: waiting},
+				},
+			},
+		},
+	)
+
+	var lock sync.Mutex
+	opened := []string{}
+	var out bytes.Buffer
+	streamer := &kube.LogStreamer{
+		KubeClient: kubeClient,
+		Namespace:  ns,
+		Selector:   "build=myapp-1",
+		Out:        &out,
+		Source: func(ns string, pod string, opts *corev1.PodLogOptions) (io.ReadCloser, error) {
+			lock.Lock()
+			opened = append(opened, pod+"/"+opts.Container)
+			lock.Unlock()
+			return ioutil.NopCloser(strings.NewReader("log of " + opts.Container + "\n")), nil
+		},
+	}
+	err := streamer.Run(nil)
+	require.NoError(t, err)
+
+	assert.Len(t, opened, 3)
+	text := out.String()
+	assert.Contains(t, text, "log of build-step-checkout\n")
+	assert.Contains(t, text, "log of build-step-build\n")
+	assert.Contains(t, text, "log of nop\n")
+
+	out.Reset()
+	opened = []string{}
+	streamer.Container = "build-step-build"
+	streamer.Prefix = true
+	streamer.Follow = true
+	streamer.UntilComplete = true
+	streamer.Selector = "build"
+	streamer.KubeClient.CoreV1().Pods(ns).Delete("myapp-build-2", &metav1.DeleteOptions{})
+	streamer.KubeClient.CoreV1().Pods(ns).Delete("myapp-build-3", &metav1.DeleteOptions{})
+	err = streamer.Run(nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"myapp-build-1/build-step-build"}, opened)
+	assert.Contains(t, out.String(), "myapp-build-1 build-step-build")
+	assert.Contains(t, out.String(), "log of build-step-build")
+}
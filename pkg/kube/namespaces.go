@@ -180,6 +180,16 @@ func EnsureEditEnvironmentSetup(kubeClient kubernetes.Interface, jxClient versio
 
 // Ensure that the namespace exists for the given name
 func EnsureNamespaceCreated(kubeClient kubernetes.Interface, name string, labels map[string]string, annotations map[string]string) error {
+	err := RetryOnAPIError(func() error {
+		return ensureNamespaceCreated(kubeClient, name, labels, annotations)
+	})
+	if err != nil {
+		return fmt.Errorf("Failed to create or label Namespace %s %s", name, err)
+	}
+	return nil
+}
+
+func ensureNamespaceCreated(kubeClient kubernetes.Interface, name string, labels map[string]string, annotations map[string]string) error {
 	n, err := kubeClient.CoreV1().Namespaces().Get(name, metav1.GetOptions{})
 	if err == nil {
 		// lets check if we have the labels setup
@@ -208,9 +218,7 @@ func EnsureNamespaceCreated(kubeClient kubernetes.Interface, name string, labels
 		}
 		if changed {
 			_, err = kubeClient.CoreV1().Namespaces().Update(n)
-			if err != nil {
-				return fmt.Errorf("Failed to label Namespace %s %s", name, err)
-			}
+			return err
 		}
 		return nil
 	}
@@ -223,8 +231,5 @@ func EnsureNamespaceCreated(kubeClient kubernetes.Interface, name string, labels
 		},
 	}
 	_, err = kubeClient.CoreV1().Namespaces().Create(namespace)
-	if err != nil {
-		return fmt.Errorf("Failed to create Namespace %s %s", name, err)
-	}
 	return err
 }
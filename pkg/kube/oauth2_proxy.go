@@ -0,0 +1,156 @@
+package kube
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// OAuth2ProxyProviderGitHub logs users in with their GitHub account
+	OAuth2ProxyProviderGitHub = "github"
+	// OAuth2ProxyProviderGoogle logs users in with their Google account
+	OAuth2ProxyProviderGoogle = "google"
+	// OAuth2ProxyProviderOIDC logs users in via an OpenID Connect issuer such as Dex or Keycloak
+	OAuth2ProxyProviderOIDC = "oidc"
+
+	// the keys of the Secret of the OAuth2 proxy expected by its chart
+	oauth2ProxyClientIDKey     = "client-id"
+	oauth2ProxyClientSecretKey = "client-secret"
+	oauth2ProxyCookieSecretKey = "cookie-secret"
+)
+
+// OAuth2ProxyProviders the login providers supported by the OAuth2 proxy
+var OAuth2ProxyProviders = []string{OAuth2ProxyProviderGitHub, OAuth2ProxyProviderGoogle, OAuth2ProxyProviderOIDC}
+
+// OAuth2ProxySettings the OAuth application and login provider the OAuth2 proxy uses to log users in
+type OAuth2ProxySettings struct {
+	Provider      string
+	ClientID      string
+	ClientSecret  string
+	OIDCIssuerURL string
+	EmailDomain   string
+	GitHubOrg     string
+}
+
+// Validate returns an error if the provider is not supported or is missing its settings
+func (s *OAuth2ProxySettings) Validate() error {
+	if s.Provider == "" {
+		s.Provider = OAuth2ProxyProviderGitHub
+	}
+	if util.StringArrayIndex(OAuth2ProxyProviders, s.Provider) < 0 {
+		return util.InvalidOption("oauth-provider", s.Provider, OAuth2ProxyProviders)
+	}
+	if s.Provider == OAuth2ProxyProviderOIDC && s.OIDCIssuerURL == "" {
+		return util.MissingOption("oidc-issuer-url")
+	}
+	if s.GitHubOrg != "" && s.Provider != OAuth2ProxyProviderGitHub {
+		return util.InvalidOptionf("github-org", s.GitHubOrg, "the organisation can only be used with the %s provider", OAuth2ProxyProviderGitHub)
+	}
+	return nil
+}
+
+// OAuth2ProxyHost returns the host exposecontroller exposes the OAuth2 proxy on
+func OAuth2ProxyHost(ns string, domain string) string {
+	return OAuth2ProxyName + "." + ns + "." + domain
+}
+
+// OAuth2ProxyCallbackURL returns the URL the login provider redirects users back to which must be registered as the
+// callback URL of the OAuth application
+func OAuth2ProxyCallbackURL(ns string, ic IngressConfig) string {
+	scheme := "http://"
+	if ic.TLS {
+		scheme = "https://"
+	}
+	return scheme + OAuth2ProxyHost(ns, ic.Domain) + "/oauth2/callback"
+}
+
+// OAuth2ProxyArgs returns the arguments of the OAuth2 proxy which log users in via the provider and set a cookie which
+// is valid for all the hosts of the domain
+func OAuth2ProxyArgs(settings *OAuth2ProxySettings, ns string, ic IngressConfig) map[string]string {
+	emailDomain := settings.EmailDomain
+	if emailDomain == "" {
+		emailDomain = "*"
+	}
+	args := map[string]string{
+		"provider":         settings.Provider,
+		"email-domain":     emailDomain,
+		"cookie-domain":    "." + ic.Domain,
+		"whitelist-domain": "." + ic.Domain,
+		"redirect-url":     OAuth2ProxyCallbackURL(ns, ic),
+		"cookie-secure":    fmt.Sprintf("%t", ic.TLS),
+	}
+	if settings.Provider == OAuth2ProxyProviderOIDC {
+		args["oidc-issuer-url"] = settings.OIDCIssuerURL
+	}
+	if settings.GitHubOrg != "" {
+		args["github-org"] = settings.GitHubOrg
+	}
+	return args
+}
+
+// EnsureOAuth2ProxySecret creates or updates the Secret of the OAuth2 proxy with the client ID and secret of the
+// OAuth application. Values missing from the settings are kept from the existing Secret and the cookie secret is only
+// generated if it does not exist yet or is rotated
+func EnsureOAuth2ProxySecret(client kubernetes.Interface, ns string, settings *OAuth2ProxySettings, rotateCookieSecret bool) error {
+	secrets := client.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(OAuth2ProxyName, metav1.GetOptions{})
+	create := false
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			return err
+		}
+		create = true
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: OAuth2ProxyName,
+			},
+		}
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	if settings.ClientID != "" {
+		secret.Data[oauth2ProxyClientIDKey] = []byte(settings.ClientID)
+	}
+	if settings.ClientSecret != "" {
+		secret.Data[oauth2ProxyClientSecretKey] = []byte(settings.ClientSecret)
+	}
+	if len(secret.Data[oauth2ProxyClientIDKey]) == 0 || len(secret.Data[oauth2ProxyClientSecretKey]) == 0 {
+		return fmt.Errorf("no client ID and secret of the OAuth application of the OAuth2 proxy in Secret %s in namespace %s", OAuth2ProxyName, ns)
+	}
+	if rotateCookieSecret || len(secret.Data[oauth2ProxyCookieSecretKey]) == 0 {
+		cookieSecret, err := generateCookieSecret()
+		if err != nil {
+			return err
+		}
+		secret.Data[oauth2ProxyCookieSecretKey] = []byte(cookieSecret)
+	}
+	if create {
+		_, err = secrets.Create(secret)
+	} else {
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save Secret %s in namespace %s", OAuth2ProxyName, ns)
+	}
+	return nil
+}
+
+// generateCookieSecret generates the secret the OAuth2 proxy uses to encrypt its cookies. The 32 characters are a
+// valid AES key length
+func generateCookieSecret() (string, error) {
+	data := make([]byte, 16)
+	_, err := rand.Read(data)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
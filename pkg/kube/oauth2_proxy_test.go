@@ -0,0 +1,68 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOAuth2ProxySettingsValidate(t *testing.T) {
+	t.Parallel()
+	settings := &kube.OAuth2ProxySettings{}
+	assert.NoError(t, settings.Validate())
+	assert.Equal(t, kube.OAuth2ProxyProviderGitHub, settings.Provider)
+
+	assert.Error(t, (&kube.OAuth2ProxySettings{Provider: "facebook"}).Validate())
+	assert.Error(t, (&kube.OAuth2ProxySettings{Provider: kube.OAuth2ProxyProviderOIDC}).Validate())
+	assert.NoError(t, (&kube.OAuth2ProxySettings{Provider: kube.OAuth2ProxyProviderOIDC, OIDCIssuerURL: "https://dex.example.com"}).Validate())
+	assert.Error(t, (&kube.OAuth2ProxySettings{Provider: kube.OAuth2ProxyProviderGoogle, GitHubOrg: "myorg"}).Validate())
+}
+
+func TestOAuth2ProxyArgs(t *testing.T) {
+	t.Parallel()
+	ic := kube.IngressConfig{Domain: "example.com", TLS: true}
+	assert.Equal(t, "https://oauth2-proxy.jx.example.com/oauth2/callback", kube.OAuth2ProxyCallbackURL("jx", ic))
+
+	settings := &kube.OAuth2ProxySettings{Provider: kube.OAuth2ProxyProviderOIDC, OIDCIssuerURL: "https://dex.example.com"}
+	args := kube.OAuth2ProxyArgs(settings, "jx", ic)
+	assert.Equal(t, map[string]string{
+		"provider":         "oidc",
+		"email-domain":     "*",
+		"cookie-domain":    ".example.com",
+		"whitelist-domain": ".example.com",
+		"redirect-url":     "https://oauth2-proxy.jx.example.com/oauth2/callback",
+		"cookie-secure":    "true",
+		"oidc-issuer-url":  "https://dex.example.com",
+	}, args)
+}
+
+func TestEnsureOAuth2ProxySecret(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	assert.Error(t, kube.EnsureOAuth2ProxySecret(client, "jx", &kube.OAuth2ProxySettings{}, false), "the client ID and secret are required")
+
+	err := kube.EnsureOAuth2ProxySecret(client, "jx", &kube.OAuth2ProxySettings{ClientID: "123", ClientSecret: "456"}, false)
+	require.NoError(t, err)
+	secret, err := client.CoreV1().Secrets("jx").Get(kube.OAuth2ProxyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "123", string(secret.Data["client-id"]))
+	cookieSecret := string(secret.Data["cookie-secret"])
+	assert.Len(t, cookieSecret, 32)
+
+	err = kube.EnsureOAuth2ProxySecret(client, "jx", &kube.OAuth2ProxySettings{}, false)
+	require.NoError(t, err)
+	secret, err = client.CoreV1().Secrets("jx").Get(kube.OAuth2ProxyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "456", string(secret.Data["client-secret"]))
+	assert.Equal(t, cookieSecret, string(secret.Data["cookie-secret"]), "the cookie secret should be kept")
+
+	err = kube.EnsureOAuth2ProxySecret(client, "jx", &kube.OAuth2ProxySettings{}, true)
+	require.NoError(t, err)
+	secret, err = client.CoreV1().Secrets("jx").Get(kube.OAuth2ProxyName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEqual(t, cookieSecret, string(secret.Data["cookie-secret"]), "the cookie secret should be rotated")
+}
@@ -0,0 +1,132 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ExposerRoute the exposer which exposes services using OpenShift Routes
+	ExposerRoute = "Route"
+
+	// OpenShiftRouteAPIVersion the API version of the OpenShift Route resources
+	OpenShiftRouteAPIVersion = "route.openshift.io/v1"
+
+	openShiftRouteAPIPath = "/apis/" + OpenShiftRouteAPIVersion
+)
+
+// OpenShiftRoute is the subset of an OpenShift Route resource which describes the host a service is exposed on
+type OpenShiftRoute struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              OpenShiftRouteSpec `json:"spec"`
+}
+
+// OpenShiftRouteSpec the host of a Route, the service it routes to and how TLS is terminated
+type OpenShiftRouteSpec struct {
+	Host string                   `json:"host,omitempty"`
+	Path string                   `json:"path,omitempty"`
+	To   OpenShiftRouteTarget     `json:"to"`
+	TLS  *OpenShiftRouteTLSConfig `json:"tls,omitempty"`
+}
+
+// OpenShiftRouteTarget the kind and name of the resource a Route routes to
+type OpenShiftRouteTarget struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+}
+
+// OpenShiftRouteTLSConfig the TLS termination of a Route
+type OpenShiftRouteTLSConfig struct {
+	Termination string `json:"termination"`
+}
+
+type openShiftRouteList struct {
+	Items []OpenShiftRoute `json:"items"`
+}
+
+// GetOpenShiftRoutes returns the Routes in the given namespace. If the cluster is not OpenShift no Routes are returned
+func GetOpenShiftRoutes(client kubernetes.Interface, ns string) ([]OpenShiftRoute, error) {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return nil, nil
+	}
+	data, err := restClient.Get().AbsPath(openShiftRouteAPIPath + "/namespaces/" + ns + "/routes").DoRaw()
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list the Routes in namespace %s: %s", ns, err)
+	}
+	list := openShiftRouteList{}
+	err = json.Unmarshal(data, &list)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Routes in namespace %s: %s", ns, err)
+	}
+	return list.Items, nil
+}
+
+// IsOpenShiftCluster returns true if the cluster serves the OpenShift Route API
+func IsOpenShiftCluster(client kubernetes.Interface) bool {
+	restClient := crdRESTClient(client)
+	if restClient == nil {
+		return false
+	}
+	_, err := restClient.Get().AbsPath(openShiftRouteAPIPath).DoRaw()
+	return err == nil
+}
+
+// OpenShiftRouteURL returns the URL of the Route using https if it terminates TLS or an empty string if the Route
+// has not been assigned a host yet
+func OpenShiftRouteURL(route *OpenShiftRoute) string {
+	if route == nil || route.Spec.Host == "" {
+		return ""
+	}
+	scheme := "http://"
+	if route.Spec.TLS != nil && route.Spec.TLS.Termination != "" {
+		scheme = "https://"
+	}
+	return scheme + route.Spec.Host + route.Spec.Path
+}
+
+// OpenShiftRouteServiceName returns the name of the service the Route routes to or an empty string if it routes to
+// another kind of resource
+func OpenShiftRouteServiceName(route *OpenShiftRoute) string {
+	if route.Spec.To.Kind != "" && route.Spec.To.Kind != "Service" {
+		return ""
+	}
+	return route.Spec.To.Name
+}
+
+// FindOpenShiftRouteServiceURL returns the URL of the Route to the service of the given name or an empty string
+// if the service is not exposed by a Route
+func FindOpenShiftRouteServiceURL(client kubernetes.Interface, ns string, name string) (string, error) {
+	urls, err := FindOpenShiftRouteServiceURLs(client, ns)
+	if err != nil {
+		return "", err
+	}
+	return urls[name], nil
+}
+
+// FindOpenShiftRouteServiceURLs returns the URLs of the services in the namespace exposed by Routes indexed by
+// service name
+func FindOpenShiftRouteServiceURLs(client kubernetes.Interface, ns string) (map[string]string, error) {
+	answer := map[string]string{}
+	routes, err := GetOpenShiftRoutes(client, ns)
+	if err != nil {
+		return answer, err
+	}
+	for i := range routes {
+		route := &routes[i]
+		name := OpenShiftRouteServiceName(route)
+		url := OpenShiftRouteURL(route)
+		if name != "" && url != "" && answer[name] == "" {
+			answer[name] = url
+		}
+	}
+	return answer, nil
+}
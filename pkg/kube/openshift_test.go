@@ -0,0 +1,45 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestOpenShiftRouteURL(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "", kube.OpenShiftRouteURL(nil))
+	assert.Equal(t, "", kube.OpenShiftRouteURL(&kube.OpenShiftRoute{}))
+
+	route := &kube.OpenShiftRoute{}
+	route.Spec.Host = "jenkins-jx.192.168.64.2.nip.io"
+	assert.Equal(t, "http://jenkins-jx.192.168.64.2.nip.io", kube.OpenShiftRouteURL(route))
+
+	route.Spec.Path = "/ui"
+	route.Spec.TLS = &kube.OpenShiftRouteTLSConfig{Termination: "edge"}
+	assert.Equal(t, "https://jenkins-jx.192.168.64.2.nip.io/ui", kube.OpenShiftRouteURL(route))
+}
+
+func TestOpenShiftRouteServiceName(t *testing.T) {
+	t.Parallel()
+	route := &kube.OpenShiftRoute{}
+	route.Spec.To = kube.OpenShiftRouteTarget{Kind: "Service", Name: "jenkins"}
+	assert.Equal(t, "jenkins", kube.OpenShiftRouteServiceName(route))
+
+	route.Spec.To.Kind = ""
+	assert.Equal(t, "jenkins", kube.OpenShiftRouteServiceName(route))
+
+	route.Spec.To.Kind = "DeploymentConfig"
+	assert.Equal(t, "", kube.OpenShiftRouteServiceName(route))
+}
+
+func TestFindOpenShiftRouteServiceURLsWithoutOpenShift(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	urls, err := kube.FindOpenShiftRouteServiceURLs(client, "jx")
+	assert.NoError(t, err)
+	assert.Empty(t, urls)
+	assert.False(t, kube.IsOpenShiftCluster(client))
+}
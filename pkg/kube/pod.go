@@ -104,11 +104,11 @@ func WaitForPodNameToBeReady(client kubernetes.Interface, namespace string, name
 
 func GetReadyPodNames(client kubernetes.Interface, ns string, filter string) ([]string, error) {
 	names := []string{}
-	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{})
+	list, err := ListPods(client, ns, meta_v1.ListOptions{})
 	if err != nil {
 		return names, fmt.Errorf("Failed to load Pods %s", err)
 	}
-	for _, p := range list.Items {
+	for _, p := range list {
 		name := p.Name
 		if filter == "" || strings.Contains(name, filter) && IsPodReady(&p) {
 			names = append(names, name)
@@ -120,11 +120,11 @@ func GetReadyPodNames(client kubernetes.Interface, ns string, filter string) ([]
 
 func GetPodNames(client kubernetes.Interface, ns string, filter string) ([]string, error) {
 	names := []string{}
-	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{})
+	list, err := ListPods(client, ns, meta_v1.ListOptions{})
 	if err != nil {
 		return names, fmt.Errorf("Failed to load Pods %s", err)
 	}
-	for _, d := range list.Items {
+	for _, d := range list {
 		name := d.Name
 		if filter == "" || strings.Contains(name, filter) {
 			names = append(names, name)
@@ -137,11 +137,11 @@ func GetPodNames(client kubernetes.Interface, ns string, filter string) ([]strin
 func GetPods(client kubernetes.Interface, ns string, filter string) ([]string, map[string]*v1.Pod, error) {
 	names := []string{}
 	m := map[string]*v1.Pod{}
-	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{})
+	list, err := ListPods(client, ns, meta_v1.ListOptions{})
 	if err != nil {
 		return names, m, fmt.Errorf("Failed to load Pods %s", err)
 	}
-	for _, d := range list.Items {
+	for _, d := range list {
 		c := d
 		name := d.Name
 		m[name] = &c
@@ -156,13 +156,13 @@ func GetPods(client kubernetes.Interface, ns string, filter string) ([]string, m
 func GetPodsWithLabels(client kubernetes.Interface, ns string, selector string) ([]string, map[string]*v1.Pod, error) {
 	names := []string{}
 	m := map[string]*v1.Pod{}
-	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{
+	list, err := ListPods(client, ns, meta_v1.ListOptions{
 		LabelSelector: selector,
 	})
 	if err != nil {
 		return names, m, fmt.Errorf("Failed to load Pods %s", err)
 	}
-	for _, d := range list.Items {
+	for _, d := range list {
 		c := d
 		name := d.Name
 		m[name] = &c
@@ -0,0 +1,238 @@
+package kube
+
+import (
+	"archive/tar"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+)
+
+// PodExecFunc runs a command in a container of a pod streaming its standard input and output
+type PodExecFunc func(ns string, pod string, container string, command []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error
+
+// CopyStats the number of files and bytes copied
+type CopyStats struct {
+	Files int
+	Bytes int64
+}
+
+// NewPodExec creates a PodExecFunc which execs the commands via the exec API of the cluster
+func NewPodExec(config *rest.Config, client kubernetes.Interface) PodExecFunc {
+	return func(ns string, pod string, container string, command []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+		req := client.CoreV1().RESTClient().Post().
+			Resource("pods").
+			Name(pod).
+			Namespace(ns).
+			SubResource("exec")
+		req.VersionedParams(&v1.PodExecOptions{
+			Container: container,
+			Command:   command,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+		executor, err := remotecommand.NewSPDYExecutor(config, "POST", req.URL())
+		if err != nil {
+			return errors.Wrapf(err, "failed to exec in pod %s", pod)
+		}
+		return executor.Stream(remotecommand.StreamOptions{
+			Stdin:  stdin,
+			Stdout: stdout,
+			Stderr: stderr,
+		})
+	}
+}
+
+// CopyToPod copies the local files and directories matching the glob patterns into the directory of the container.
+// Each file copied is reported to the progress writer if it is not nil
+func CopyToPod(exec PodExecFunc, ns string, pod string, container string, patterns []string, destDir string, progress io.Writer) (*CopyStats, error) {
+	paths := []string{}
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %s", pattern)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %s", pattern)
+		}
+		paths = append(paths, matches...)
+	}
+	reader, writer := io.Pipe()
+	done := make(chan *CopyStats, 1)
+	go func() {
+		stats, err := WriteTar(writer, paths, progress)
+		writer.CloseWithError(err)
+		done <- stats
+	}()
+	var stderr strings.Builder
+	command := []string{"sh", "-c", fmt.Sprintf("mkdir -p %s && tar xf - -C %s", shellQuote(destDir), shellQuote(destDir))}
+	err := exec(ns, pod, container, command, reader, nil, &stderr)
+	// lets unblock the writer if the command exited before reading all of the archive
+	reader.Close()
+	stats := <-done
+	if err != nil {
+		return nil, execError(err, pod, stderr.String())
+	}
+	return stats, nil
+}
+
+// CopyFromPod copies the files and directories of the container matching the path, whose last element may be a glob
+// pattern, into the local directory. Each file copied is reported to the progress writer if it is not nil
+func CopyFromPod(exec PodExecFunc, ns string, pod string, container string, remotePath string, destDir string, progress io.Writer) (*CopyStats, error) {
+	dir, pattern := path.Split(remotePath)
+	if dir == "" {
+		dir = "."
+	}
+	if pattern == "" {
+		pattern = "."
+	}
+	if !strings.ContainsAny(pattern, "*?[") {
+		pattern = shellQuote(pattern)
+	}
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		var stderr strings.Builder
+		command := []string{"sh", "-c", fmt.Sprintf("cd %s && tar cf - %s", shellQuote(dir), pattern)}
+		err := exec(ns, pod, container, command, nil, writer, &stderr)
+		if err != nil {
+			err = execError(err, pod, stderr.String())
+		}
+		writer.CloseWithError(err)
+		done <- err
+	}()
+	stats, err := ExtractTar(reader, destDir, progress)
+	// lets unblock the command if the archive was rejected before it was completely read
+	reader.Close()
+	execErr := <-done
+	if err == nil {
+		err = execErr
+	}
+	return stats, err
+}
+
+// WriteTar writes the files and directories to the tar stream naming each entry relative to the directory of its path
+func WriteTar(w io.Writer, paths []string, progress io.Writer) (*CopyStats, error) {
+	stats := &CopyStats{}
+	tw := tar.NewWriter(w)
+	for _, p := range paths {
+		base := filepath.Dir(filepath.Clean(p))
+		err := filepath.Walk(p, func(fileName string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.Mode().IsRegular() && !info.IsDir() {
+				return nil
+			}
+			name, err := filepath.Rel(base, fileName)
+			if err != nil {
+				return err
+			}
+			header, err := tar.FileInfoHeader(info, "")
+			if err != nil {
+				return err
+			}
+			header.Name = filepath.ToSlash(name)
+			err = tw.WriteHeader(header)
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+			f, err := os.Open(fileName)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			n, err := io.Copy(tw, f)
+			if err != nil {
+				return err
+			}
+			stats.add(progress, header.Name, n)
+			return nil
+		})
+		if err != nil {
+			return stats, err
+		}
+	}
+	return stats, tw.Close()
+}
+
+// ExtractTar extracts the regular files and directories of the tar stream into the directory. Entries which would be
+// extracted outside of the directory are rejected
+func ExtractTar(r io.Reader, destDir string, progress io.Writer) (*CopyStats, error) {
+	stats := &CopyStats{}
+	destDir = filepath.Clean(destDir)
+	tr := tar.NewReader(r)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return stats, nil
+		}
+		if err != nil {
+			return stats, err
+		}
+		fileName := filepath.Join(destDir, filepath.FromSlash(header.Name))
+		if fileName != destDir && !strings.HasPrefix(fileName, destDir+string(os.PathSeparator)) {
+			return stats, fmt.Errorf("the archive entry %s is outside of the directory %s", header.Name, destDir)
+		}
+		switch header.Typeflag {
+		case tar.TypeDir:
+			err = os.MkdirAll(fileName, os.FileMode(header.Mode)|0700)
+		case tar.TypeReg, tar.TypeRegA:
+			err = extractFile(tr, fileName, os.FileMode(header.Mode))
+			if err == nil {
+				stats.add(progress, header.Name, header.Size)
+			}
+		}
+		if err != nil {
+			return stats, err
+		}
+	}
+}
+
+func extractFile(r io.Reader, fileName string, mode os.FileMode) error {
+	err := os.MkdirAll(filepath.Dir(fileName), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(fileName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode|0600)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	_, err = io.Copy(f, r)
+	return err
+}
+
+func (s *CopyStats) add(progress io.Writer, name string, size int64) {
+	s.Files++
+	s.Bytes += size
+	if progress != nil {
+		fmt.Fprintf(progress, "%s (%d bytes)\n", name, size)
+	}
+}
+
+// shellQuote quotes the text so that it is passed to a command by the shell as a single argument
+func shellQuote(text string) string {
+	return "'" + strings.Replace(text, "'", `'\''`, -1) + "'"
+}
+
+func execError(err error, pod string, stderr string) error {
+	stderr = strings.TrimSpace(stderr)
+	if stderr != "" {
+		return fmt.Errorf("failed to copy files in pod %s: %s: %s", pod, err, stderr)
+	}
+	return errors.Wrapf(err, "failed to copy files in pod %s", pod)
+}
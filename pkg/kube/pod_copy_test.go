@@ -0,0 +1,91 @@
+package kube_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePodExec emulates the tar commands run in a pod whose file system is the remote directory
+func fakePodExec(t *testing.T, remoteDir string) kube.PodExecFunc {
+	return func(ns string, pod string, container string, command []string, stdin io.Reader, stdout io.Writer, stderr io.Writer) error {
+		require.Len(t, command, 3)
+		script := command[2]
+		if stdin != nil {
+			require.Contains(t, script, "tar xf - -C '/workspace/src'")
+			_, err := kube.ExtractTar(stdin, filepath.Join(remoteDir, "workspace", "src"), nil)
+			return err
+		}
+		if !strings.HasPrefix(script, "cd '/workspace/src/' && tar cf - ") {
+			return fmt.Errorf("unexpected command %s", script)
+		}
+		matches, err := filepath.Glob(filepath.Join(remoteDir, "workspace", "src", strings.TrimPrefix(script, "cd '/workspace/src/' && tar cf - ")))
+		require.NoError(t, err)
+		_, err = kube.WriteTar(stdout, matches, nil)
+		return err
+	}
+}
+
+func TestCopyToAndFromPod(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-pod-copy-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	localDir := filepath.Join(dir, "local")
+	remoteDir := filepath.Join(dir, "remote")
+	require.NoError(t, os.MkdirAll(filepath.Join(localDir, "charts", "myapp"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(localDir, "a.txt"), []byte("hello"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(localDir, "b.txt"), []byte("world!"), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(localDir, "charts", "myapp", "values.yaml"), []byte("replicaCount: 1\n"), 0644))
+
+	exec := fakePodExec(t, remoteDir)
+	var progress strings.Builder
+	stats, err := kube.CopyToPod(exec, "jx", "myapp-123", "", []string{filepath.Join(localDir, "*.txt"), filepath.Join(localDir, "charts")}, "/workspace/src", &progress)
+	require.NoError(t, err)
+	assert.Equal(t, 3, stats.Files)
+	assert.Equal(t, int64(27), stats.Bytes)
+	assert.Contains(t, progress.String(), "charts/myapp/values.yaml (16 bytes)")
+	assert.FileExists(t, filepath.Join(remoteDir, "workspace", "src", "charts", "myapp", "values.yaml"))
+
+	_, err = kube.CopyToPod(exec, "jx", "myapp-123", "", []string{filepath.Join(localDir, "*.java")}, "/workspace/src", nil)
+	assert.Error(t, err)
+
+	downloadDir := filepath.Join(dir, "download")
+	stats, err = kube.CopyFromPod(exec, "jx", "myapp-123", "", "/workspace/src/*.txt", downloadDir, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 2, stats.Files)
+	data, err := ioutil.ReadFile(filepath.Join(downloadDir, "b.txt"))
+	require.NoError(t, err)
+	assert.Equal(t, "world!", string(data))
+}
+
+func TestExtractTarRejectsEntriesOutsideDir(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-extract-tar-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var buffer bytes.Buffer
+	tw := tar.NewWriter(&buffer)
+	content := []byte("evil")
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: "../evil.sh", Mode: 0755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+
+	_, err = kube.ExtractTar(&buffer, filepath.Join(dir, "out"), nil)
+	assert.Error(t, err)
+	_, err = os.Stat(filepath.Join(dir, "evil.sh"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,340 @@
+package kube
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ChatOpsPromoteCommand the comment an approver adds to a promotion Pull Request to approve the promotion
+	ChatOpsPromoteCommand = "/approve promote"
+
+	// ChatOpsCancelCommand the comment an approver adds to a promotion Pull Request to withdraw their approval
+	ChatOpsCancelCommand = "/approve cancel"
+
+	promotionWindowTimeFormat = "15:04"
+)
+
+var (
+	// PromotionWindowDays the abbreviated week days which can be used in a promotion window
+	PromotionWindowDays = []string{"Sun", "Mon", "Tue", "Wed", "Thu", "Fri", "Sat"}
+
+	promotionWindowRegex = regexp.MustCompile(`^(?:([A-Za-z,\-]+)\s+)?(\d{1,2}:\d{2})-(\d{1,2}:\d{2})(?:\s+(\S+))?$`)
+)
+
+// PromotionGateStatus the result of checking the promotion gates of an environment
+type PromotionGateStatus struct {
+	// Open is true if all of the gates allow the promotion
+	Open bool
+	// Approvers the users who have approved the promotion
+	Approvers []string
+	// Reasons describes why the promotion is blocked
+	Reasons []string
+}
+
+// HasPromotionGates returns true if the environment has any promotion gates configured
+func HasPromotionGates(env *v1.Environment) bool {
+	if env == nil || env.Spec.PromotionGates == nil {
+		return false
+	}
+	gates := env.Spec.PromotionGates
+	return gates.RequiredApprovals > 0 || gates.ChatOps || len(gates.Windows) > 0
+}
+
+// RequiresPromotionApproval returns true if the promotion gates need approvals from a Pull Request
+func RequiresPromotionApproval(gates *v1.PromotionGates) bool {
+	return gates != nil && (gates.RequiredApprovals > 0 || gates.ChatOps)
+}
+
+// CheckPromotionGates checks the promotion gates of the environment against the promotion Pull Request at the given time
+func CheckPromotionGates(env *v1.Environment, pr *gits.GitPullRequest, provider gits.GitProvider, now time.Time) (*PromotionGateStatus, error) {
+	status := &PromotionGateStatus{Open: true}
+	if !HasPromotionGates(env) {
+		return status, nil
+	}
+	gates := env.Spec.PromotionGates
+
+	if len(gates.Windows) > 0 {
+		inWindow, err := IsInPromotionWindow(gates.Windows, now)
+		if err != nil {
+			return status, err
+		}
+		if !inWindow {
+			status.Open = false
+			status.Reasons = append(status.Reasons, fmt.Sprintf("outside of the promotion windows %s", strings.Join(PromotionWindowsToStrings(gates.Windows), ", ")))
+		}
+	}
+
+	if RequiresPromotionApproval(gates) {
+		if pr == nil {
+			status.Open = false
+			status.Reasons = append(status.Reasons, fmt.Sprintf("environment %s requires approval of a promotion Pull Request", env.Name))
+			return status, nil
+		}
+		lister, ok := provider.(gits.PullRequestReviewLister)
+		if !ok {
+			return status, fmt.Errorf("the %s git provider does not support listing Pull Request reviews so the promotion gates of environment %s cannot be checked", provider.Kind(), env.Name)
+		}
+		author := ""
+		if pr.Author != nil {
+			author = pr.Author.Login
+		}
+
+		if gates.RequiredApprovals > 0 {
+			reviews, err := lister.ListPullRequestReviews(pr)
+			if err != nil {
+				return status, fmt.Errorf("failed to list the reviews of Pull Request %s: %s", pr.URL, err)
+			}
+			approvers := ReviewApprovers(reviews, gates.Approvers, author)
+			status.Approvers = approvers
+			if len(approvers) < gates.RequiredApprovals {
+				status.Open = false
+				status.Reasons = append(status.Reasons, fmt.Sprintf("has %d of %d required approvals", len(approvers), gates.RequiredApprovals))
+			}
+		}
+
+		if gates.ChatOps {
+			comments, err := lister.ListPullRequestComments(pr)
+			if err != nil {
+				return status, fmt.Errorf("failed to list the comments of Pull Request %s: %s", pr.URL, err)
+			}
+			approvers := ChatOpsApprovers(comments, gates.Approvers, author)
+			for _, approver := range approvers {
+				if util.StringArrayIndex(status.Approvers, approver) < 0 {
+					status.Approvers = append(status.Approvers, approver)
+				}
+			}
+			if len(approvers) == 0 {
+				status.Open = false
+				status.Reasons = append(status.Reasons, fmt.Sprintf("waiting for an approver to comment '%s'", ChatOpsPromoteCommand))
+			}
+		}
+	}
+	return status, nil
+}
+
+// ReviewApprovers returns the users whose latest review approves the Pull Request. Reviews by the author or by users
+// who are not in the allowed approvers are ignored
+func ReviewApprovers(reviews []*gits.GitPullRequestReview, allowed []string, author string) []string {
+	latest := map[string]*gits.GitPullRequestReview{}
+	users := []string{}
+	for _, review := range reviews {
+		if review.User == nil || !isAllowedApprover(review.User.Login, allowed, author) {
+			continue
+		}
+		state := strings.ToUpper(review.State)
+		if state == "COMMENTED" || state == "PENDING" {
+			// comments do not change the approval of a reviewer
+			continue
+		}
+		login := review.User.Login
+		if latest[login] == nil {
+			users = append(users, login)
+		}
+		latest[login] = review
+	}
+	answer := []string{}
+	for _, login := range users {
+		if latest[login].IsApproved() {
+			answer = append(answer, login)
+		}
+	}
+	return answer
+}
+
+// ChatOpsApprovers returns the users whose latest ChatOps command on the Pull Request approves the promotion.
+// Comments by the author or by users who are not in the allowed approvers are ignored
+func ChatOpsApprovers(comments []*gits.GitPullRequestComment, allowed []string, author string) []string {
+	approved := map[string]bool{}
+	users := []string{}
+	for _, comment := range comments {
+		if comment.User == nil || !isAllowedApprover(comment.User.Login, allowed, author) {
+			continue
+		}
+		login := comment.User.Login
+		for _, line := range strings.Split(comment.Body, "\n") {
+			command := strings.ToLower(strings.Join(strings.Fields(line), " "))
+			switch command {
+			case ChatOpsPromoteCommand:
+				if !approved[login] {
+					users = append(users, login)
+				}
+				approved[login] = true
+			case ChatOpsCancelCommand:
+				approved[login] = false
+			}
+		}
+	}
+	answer := []string{}
+	for _, login := range users {
+		if approved[login] && util.StringArrayIndex(answer, login) < 0 {
+			answer = append(answer, login)
+		}
+	}
+	return answer
+}
+
+func isAllowedApprover(login string, allowed []string, author string) bool {
+	if login == "" || strings.EqualFold(login, author) {
+		return false
+	}
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsInPromotionWindow returns true if the time is inside any of the promotion windows
+func IsInPromotionWindow(windows []v1.PromotionWindow, now time.Time) (bool, error) {
+	for _, w := range windows {
+		inWindow, err := isInWindow(w, now)
+		if err != nil {
+			return false, err
+		}
+		if inWindow {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isInWindow(w v1.PromotionWindow, now time.Time) (bool, error) {
+	loc := time.UTC
+	if w.TimeZone != "" {
+		var err error
+		loc, err = time.LoadLocation(w.TimeZone)
+		if err != nil {
+			return false, fmt.Errorf("invalid time zone %s in promotion window: %s", w.TimeZone, err)
+		}
+	}
+	start, err := time.Parse(promotionWindowTimeFormat, w.Start)
+	if err != nil {
+		return false, fmt.Errorf("invalid start time %s in promotion window: %s", w.Start, err)
+	}
+	end, err := time.Parse(promotionWindowTimeFormat, w.End)
+	if err != nil {
+		return false, fmt.Errorf("invalid end time %s in promotion window: %s", w.End, err)
+	}
+	t := now.In(loc)
+	minutes := t.Hour()*60 + t.Minute()
+	startMinutes := start.Hour()*60 + start.Minute()
+	endMinutes := end.Hour()*60 + end.Minute()
+
+	day := t.Weekday()
+	inTime := false
+	if startMinutes <= endMinutes {
+		inTime = minutes >= startMinutes && minutes < endMinutes
+	} else {
+		// the window spans midnight so the early hours belong to the window which started the previous day
+		if minutes < endMinutes {
+			inTime = true
+			day = (day + 6) % 7
+		} else {
+			inTime = minutes >= startMinutes
+		}
+	}
+	if !inTime {
+		return false, nil
+	}
+	if len(w.Days) == 0 {
+		return true, nil
+	}
+	for _, d := range w.Days {
+		if strings.EqualFold(d, PromotionWindowDays[day]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// ParsePromotionWindow parses a promotion window of the form '[days] HH:MM-HH:MM [timezone]' where days is a comma
+// separated list of week days or ranges such as 'Mon-Fri' or 'Mon,Wed,Fri'
+func ParsePromotionWindow(text string) (*v1.PromotionWindow, error) {
+	m := promotionWindowRegex.FindStringSubmatch(strings.TrimSpace(text))
+	if m == nil {
+		return nil, fmt.Errorf("invalid promotion window '%s' should be of the form '[Mon-Fri] 09:00-17:00 [Europe/London]'", text)
+	}
+	w := &v1.PromotionWindow{
+		Start:    m[2],
+		End:      m[3],
+		TimeZone: m[4],
+	}
+	if m[1] != "" {
+		days, err := parsePromotionDays(m[1])
+		if err != nil {
+			return nil, err
+		}
+		w.Days = days
+	}
+	_, err := isInWindow(*w, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func parsePromotionDays(text string) ([]string, error) {
+	answer := []string{}
+	for _, part := range strings.Split(text, ",") {
+		bounds := strings.Split(part, "-")
+		if len(bounds) > 2 {
+			return nil, fmt.Errorf("invalid day range %s", part)
+		}
+		indexes := []int{}
+		for _, b := range bounds {
+			idx := promotionDayIndex(b)
+			if idx < 0 {
+				return nil, util.InvalidOption("day", b, append([]string{}, PromotionWindowDays...))
+			}
+			indexes = append(indexes, idx)
+		}
+		if len(indexes) == 1 {
+			indexes = append(indexes, indexes[0])
+		}
+		for i := indexes[0]; ; i = (i + 1) % 7 {
+			if util.StringArrayIndex(answer, PromotionWindowDays[i]) < 0 {
+				answer = append(answer, PromotionWindowDays[i])
+			}
+			if i == indexes[1] {
+				break
+			}
+		}
+	}
+	return answer, nil
+}
+
+func promotionDayIndex(day string) int {
+	for i, d := range PromotionWindowDays {
+		if strings.EqualFold(d, strings.TrimSpace(day)) {
+			return i
+		}
+	}
+	return -1
+}
+
+// PromotionWindowsToStrings returns the text form of the promotion windows
+func PromotionWindowsToStrings(windows []v1.PromotionWindow) []string {
+	answer := []string{}
+	for _, w := range windows {
+		text := w.Start + "-" + w.End
+		if len(w.Days) > 0 {
+			text = strings.Join(w.Days, ",") + " " + text
+		}
+		if w.TimeZone != "" {
+			text += " " + w.TimeZone
+		}
+		answer = append(answer, text)
+	}
+	return answer
+}
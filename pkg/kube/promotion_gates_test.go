@@ -0,0 +1,130 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePromotionWindow(t *testing.T) {
+	t.Parallel()
+	w, err := kube.ParsePromotionWindow("Mon-Fri 09:00-17:30 Europe/London")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, w.Days)
+	assert.Equal(t, "09:00", w.Start)
+	assert.Equal(t, "17:30", w.End)
+	assert.Equal(t, "Europe/London", w.TimeZone)
+
+	w, err = kube.ParsePromotionWindow("fri-mon,wed 22:00-06:00")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Fri", "Sat", "Sun", "Mon", "Wed"}, w.Days)
+	assert.Equal(t, []string{"Fri,Sat,Sun,Mon,Wed 22:00-06:00"}, kube.PromotionWindowsToStrings([]v1.PromotionWindow{*w}))
+
+	for _, text := range []string{"9am-5pm", "Funday 09:00-17:00", "09:00-17:00 Nowhere/City"} {
+		_, err = kube.ParsePromotionWindow(text)
+		assert.Error(t, err, "window %s", text)
+	}
+}
+
+func TestIsInPromotionWindow(t *testing.T) {
+	t.Parallel()
+	officeHours := []v1.PromotionWindow{{Days: []string{"Mon", "Tue", "Wed", "Thu", "Fri"}, Start: "09:00", End: "17:00"}}
+	overnight := []v1.PromotionWindow{{Days: []string{"Fri"}, Start: "22:00", End: "02:00"}}
+
+	// 2018-08-03 is a Friday
+	friday := func(hour, minute int) time.Time {
+		return time.Date(2018, 8, 3, hour, minute, 0, 0, time.UTC)
+	}
+	testCases := []struct {
+		windows  []v1.PromotionWindow
+		now      time.Time
+		expected bool
+	}{
+		{officeHours, friday(9, 0), true},
+		{officeHours, friday(16, 59), true},
+		{officeHours, friday(17, 0), false},
+		{officeHours, friday(8, 59), false},
+		{officeHours, friday(12, 0).AddDate(0, 0, 1), false},
+		{overnight, friday(23, 0), true},
+		{overnight, friday(1, 0).AddDate(0, 0, 1), true},
+		{overnight, friday(1, 0), false},
+	}
+	for _, tc := range testCases {
+		actual, err := kube.IsInPromotionWindow(tc.windows, tc.now)
+		require.NoError(t, err)
+		assert.Equal(t, tc.expected, actual, "at %s for windows %v", tc.now, kube.PromotionWindowsToStrings(tc.windows))
+	}
+}
+
+func TestCheckPromotionGates(t *testing.T) {
+	t.Parallel()
+	number := 1
+	pr := &gits.GitPullRequest{
+		URL:    "https://github.com/jstrachan/environment-production/pull/1",
+		Owner:  "jstrachan",
+		Repo:   "environment-production",
+		Number: &number,
+		Author: &gits.GitUser{Login: "jenkins-x-bot"},
+	}
+	fakePR := &gits.FakePullRequest{PullRequest: pr}
+	repo := &gits.FakeRepository{
+		Owner:        "jstrachan",
+		GitRepo:      &gits.GitRepository{Name: "environment-production"},
+		PullRequests: map[int]*gits.FakePullRequest{1: fakePR},
+	}
+	provider := gits.NewFakeProvider(repo)
+
+	env := &v1.Environment{}
+	env.Name = "production"
+	env.Spec.PromotionGates = &v1.PromotionGates{
+		RequiredApprovals: 2,
+		ChatOps:           true,
+		Approvers:         []string{"alice", "bob", "carol"},
+	}
+	now := time.Now()
+
+	status, err := kube.CheckPromotionGates(env, pr, provider, now)
+	require.NoError(t, err)
+	assert.False(t, status.Open)
+	assert.Len(t, status.Reasons, 2)
+
+	user := func(login string) *gits.GitUser {
+		return &gits.GitUser{Login: login}
+	}
+	fakePR.Reviews = []*gits.GitPullRequestReview{
+		{User: user("alice"), State: "APPROVED"},
+		{User: user("jenkins-x-bot"), State: "APPROVED"},
+		{User: user("mallory"), State: "APPROVED"},
+		{User: user("bob"), State: "APPROVED"},
+		{User: user("bob"), State: "CHANGES_REQUESTED"},
+		{User: user("carol"), State: "APPROVED"},
+		{User: user("carol"), State: "COMMENTED"},
+	}
+	fakePR.Comments = []*gits.GitPullRequestComment{
+		{User: user("mallory"), Body: "/approve promote"},
+		{User: user("bob"), Body: "looks good\n/approve   Promote"},
+		{User: user("bob"), Body: "/approve cancel"},
+	}
+	status, err = kube.CheckPromotionGates(env, pr, provider, now)
+	require.NoError(t, err)
+	assert.False(t, status.Open)
+	assert.Equal(t, []string{"alice", "carol"}, status.Approvers)
+	assert.Len(t, status.Reasons, 1)
+
+	fakePR.Comments = append(fakePR.Comments, &gits.GitPullRequestComment{User: user("carol"), Body: "/approve promote"})
+	status, err = kube.CheckPromotionGates(env, pr, provider, now)
+	require.NoError(t, err)
+	assert.True(t, status.Open, "reasons %v", status.Reasons)
+
+	_, err = kube.CheckPromotionGates(env, pr, &gits.GitlabProvider{}, now)
+	assert.Error(t, err, "providers which cannot list reviews should not silently pass the gates")
+
+	status, err = kube.CheckPromotionGates(env, nil, nil, now)
+	require.NoError(t, err)
+	assert.False(t, status.Open, "approvals cannot be given without a Pull Request")
+}
@@ -0,0 +1,169 @@
+package kube
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewPromotion creates a Promotion queued at the given time which leaves the queue once its lease expires unless the
+// lease is renewed via RenewPromotion
+func NewPromotion(spec v1.PromotionSpec, now time.Time, lease time.Duration) *v1.Promotion {
+	version := spec.Version
+	if version == "" {
+		version = "latest"
+	}
+	spec.QueuedTimestamp = &metav1.Time{Time: now}
+	spec.Expires = &metav1.Time{Time: now.Add(lease)}
+	return &v1.Promotion{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: ToValidName(spec.Environment + "-" + spec.Application + "-" + version),
+		},
+		Spec: spec,
+		Status: v1.PromotionStatus{
+			Phase: v1.PromotionPhaseQueued,
+		},
+	}
+}
+
+// EnqueuePromotion adds the promotion to the queue of its environment replacing any previous promotion of the same
+// version which has terminated or expired
+func EnqueuePromotion(jxClient versioned.Interface, ns string, promotion *v1.Promotion, now time.Time) (*v1.Promotion, error) {
+	promotions := jxClient.JenkinsV1().Promotions(ns)
+	existing, err := promotions.Get(promotion.Name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return nil, err
+		}
+		return promotions.Create(promotion)
+	}
+	if !existing.Status.Phase.IsTerminated() && !IsPromotionExpired(existing, now) {
+		return nil, fmt.Errorf("app %s version %s is already queued for promotion to %s as %s", promotion.Spec.Application,
+			promotion.Spec.Version, promotion.Spec.Environment, promotion.Name)
+	}
+	existing.Spec = promotion.Spec
+	existing.Status = promotion.Status
+	return promotions.Update(existing)
+}
+
+// IsPromotionExpired returns true if the lease of the promotion has not been renewed in time such as when the jx
+// process promoting it was killed
+func IsPromotionExpired(promotion *v1.Promotion, now time.Time) bool {
+	expires := promotion.Spec.Expires
+	return expires != nil && !now.Before(expires.Time)
+}
+
+// PromotionQueue returns the promotions to the environment which are promoting or waiting to promote in the order
+// they promote
+func PromotionQueue(promotions []v1.Promotion, environment string, now time.Time) []*v1.Promotion {
+	answer := []*v1.Promotion{}
+	for i := range promotions {
+		promotion := &promotions[i]
+		phase := promotion.Status.Phase
+		if promotion.Spec.Environment != environment || IsPromotionExpired(promotion, now) {
+			continue
+		}
+		if phase == v1.PromotionPhaseQueued || phase == v1.PromotionPhasePromoting {
+			answer = append(answer, promotion)
+		}
+	}
+	sort.SliceStable(answer, func(i, j int) bool {
+		p1 := answer[i]
+		p2 := answer[j]
+		promoting1 := p1.Status.Phase == v1.PromotionPhasePromoting
+		promoting2 := p2.Status.Phase == v1.PromotionPhasePromoting
+		if promoting1 != promoting2 {
+			return promoting1
+		}
+		t1 := queuedTime(p1)
+		t2 := queuedTime(p2)
+		if !t1.Equal(t2) {
+			return t1.Before(t2)
+		}
+		return p1.Name < p2.Name
+	})
+	return answer
+}
+
+func queuedTime(promotion *v1.Promotion) time.Time {
+	if promotion.Spec.QueuedTimestamp != nil {
+		return promotion.Spec.QueuedTimestamp.Time
+	}
+	return promotion.CreationTimestamp.Time
+}
+
+// PromotionQueuePosition returns the zero based position of the named promotion in the queue or -1 if it is not
+// in the queue
+func PromotionQueuePosition(queue []*v1.Promotion, name string) int {
+	for i, promotion := range queue {
+		if promotion.Name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// BatchPromotions returns the queued promotions which can be included in the Pull Request of the promotion at the
+// head of the queue. Only promotions of a specific version of a different app are batched and only the first
+// promotion of each app so that later versions of an app still promote in order
+func BatchPromotions(queue []*v1.Promotion) []*v1.Promotion {
+	answer := []*v1.Promotion{}
+	if len(queue) == 0 {
+		return answer
+	}
+	apps := map[string]bool{
+		queue[0].Spec.Application: true,
+	}
+	for _, promotion := range queue[1:] {
+		app := promotion.Spec.Application
+		if apps[app] {
+			continue
+		}
+		apps[app] = true
+		if promotion.Status.Phase == v1.PromotionPhaseQueued && promotion.Spec.Version != "" {
+			answer = append(answer, promotion)
+		}
+	}
+	return answer
+}
+
+// RenewPromotion extends the lease of the promotion so that it stays in the queue of its environment
+func RenewPromotion(jxClient versioned.Interface, ns string, name string, now time.Time, lease time.Duration) error {
+	promotions := jxClient.JenkinsV1().Promotions(ns)
+	return RetryOnAPIError(func() error {
+		promotion, err := promotions.Get(name, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		promotion.Spec.Expires = &metav1.Time{Time: now.Add(lease)}
+		_, err = promotions.Update(promotion)
+		return err
+	})
+}
+
+// UpdatePromotionPhase updates the phase of the Promotion resource. A conflict caused by the renewal of the lease of
+// the promotion is retried while a conflict with a change of its phase is returned
+func UpdatePromotionPhase(jxClient versioned.Interface, ns string, promotion *v1.Promotion, phase v1.PromotionPhase, message string) (*v1.Promotion, error) {
+	promotions := jxClient.JenkinsV1().Promotions(ns)
+	previousPhase := promotion.Status.Phase
+	status := promotion.Status
+	status.Phase = phase
+	status.Message = message
+	for i := 0; ; i++ {
+		promotion.Status = status
+		answer, err := promotions.Update(promotion)
+		if err == nil || !errors.IsConflict(err) || i >= 3 {
+			return answer, err
+		}
+		latest, getErr := promotions.Get(promotion.Name, metav1.GetOptions{})
+		if getErr != nil || latest.Status.Phase != previousPhase {
+			return answer, err
+		}
+		promotion = latest
+	}
+}
@@ -0,0 +1,126 @@
+package kube_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	jxfake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestPromotionQueue(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	jxClient := jxfake.NewSimpleClientset()
+	now := time.Date(2018, 12, 20, 9, 0, 0, 0, time.UTC)
+
+	queued := []struct {
+		app     string
+		version string
+	}{
+		{"cheese", "1.0.0"},
+		{"wine", "2.0.0"},
+		{"cheese", "1.0.1"},
+		{"bread", ""},
+		{"olives", "0.1.0"},
+	}
+	for i, q := range queued {
+		spec := v1.PromotionSpec{Environment: "production", Application: q.app, Version: q.version}
+		_, err := kube.EnqueuePromotion(jxClient, ns, kube.NewPromotion(spec, now.Add(time.Duration(i)*time.Minute), time.Hour), now)
+		require.NoError(t, err)
+	}
+	staging := kube.NewPromotion(v1.PromotionSpec{Environment: "staging", Application: "wine", Version: "2.0.0"}, now, time.Hour)
+	_, err := kube.EnqueuePromotion(jxClient, ns, staging, now)
+	require.NoError(t, err)
+
+	_, err = kube.EnqueuePromotion(jxClient, ns, staging, now)
+	assert.Error(t, err, "should not queue the same version twice")
+
+	list, err := jxClient.JenkinsV1().Promotions(ns).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	queue := kube.PromotionQueue(list.Items, "production", now)
+	assert.Equal(t, []string{"production-cheese-1-0-0", "production-wine-2-0-0", "production-cheese-1-0-1", "production-bread-latest", "production-olives-0-1-0"}, promotionNames(queue))
+	assert.Equal(t, 1, kube.PromotionQueuePosition(queue, "production-wine-2-0-0"))
+	assert.Equal(t, -1, kube.PromotionQueuePosition(queue, staging.Name))
+
+	batch := kube.BatchPromotions(queue)
+	assert.Equal(t, []string{"production-wine-2-0-0", "production-olives-0-1-0"}, promotionNames(batch))
+
+	_, err = kube.UpdatePromotionPhase(jxClient, ns, queue[1], v1.PromotionPhasePromoting, "")
+	require.NoError(t, err)
+	_, err = kube.UpdatePromotionPhase(jxClient, ns, queue[0], v1.PromotionPhaseSucceeded, "")
+	require.NoError(t, err)
+
+	list, err = jxClient.JenkinsV1().Promotions(ns).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	queue = kube.PromotionQueue(list.Items, "production", now)
+	assert.Equal(t, []string{"production-wine-2-0-0", "production-cheese-1-0-1", "production-bread-latest", "production-olives-0-1-0"}, promotionNames(queue))
+
+	queue = kube.PromotionQueue(list.Items, "production", now.Add(time.Hour+2*time.Minute))
+	assert.Equal(t, []string{"production-bread-latest", "production-olives-0-1-0"}, promotionNames(queue), "expired promotions should leave the queue")
+
+	_, err = kube.EnqueuePromotion(jxClient, ns, kube.NewPromotion(v1.PromotionSpec{Environment: "production", Application: "cheese", Version: "1.0.0"}, now, time.Hour), now)
+	assert.NoError(t, err, "should queue a version again once its previous promotion terminated")
+}
+
+func TestRenewPromotion(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	jxClient := jxfake.NewSimpleClientset()
+	now := time.Date(2018, 12, 20, 9, 0, 0, 0, time.UTC)
+	promotion, err := kube.EnqueuePromotion(jxClient, ns, kube.NewPromotion(v1.PromotionSpec{Environment: "production", Application: "cheese", Version: "1.0.0"}, now, 2*time.Minute), now)
+	require.NoError(t, err)
+
+	later := now.Add(time.Hour)
+	assert.True(t, kube.IsPromotionExpired(promotion, later), "a promotion whose lease is not renewed should expire")
+	err = kube.RenewPromotion(jxClient, ns, promotion.Name, later, 2*time.Minute)
+	require.NoError(t, err)
+
+	list, err := jxClient.JenkinsV1().Promotions(ns).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{promotion.Name}, promotionNames(kube.PromotionQueue(list.Items, "production", later.Add(time.Minute))))
+	assert.Empty(t, kube.PromotionQueue(list.Items, "production", later.Add(2*time.Minute)))
+}
+
+func TestUpdatePromotionPhaseConflicts(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	jxClient := jxfake.NewSimpleClientset()
+	now := time.Date(2018, 12, 20, 9, 0, 0, 0, time.UTC)
+	promotion, err := kube.EnqueuePromotion(jxClient, ns, kube.NewPromotion(v1.PromotionSpec{Environment: "production", Application: "cheese", Version: "1.0.0"}, now, 2*time.Minute), now)
+	require.NoError(t, err)
+
+	conflicts := 0
+	jxClient.PrependReactor("update", "promotions", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts == 0 {
+			conflicts++
+			return true, nil, kerrors.NewConflict(schema.GroupResource{Resource: "promotions"}, promotion.Name, fmt.Errorf("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+	updated, err := kube.UpdatePromotionPhase(jxClient, ns, promotion.DeepCopy(), v1.PromotionPhaseFailed, "timed out")
+	require.NoError(t, err, "a conflict with the renewal of the lease should be retried")
+	assert.Equal(t, v1.PromotionPhaseFailed, updated.Status.Phase)
+
+	conflicts = 0
+	promotion.Status.Phase = v1.PromotionPhaseQueued
+	_, err = kube.UpdatePromotionPhase(jxClient, ns, promotion, v1.PromotionPhasePromoting, "")
+	assert.True(t, kerrors.IsConflict(err), "a conflict with a change of the phase should be returned")
+}
+
+func promotionNames(promotions []*v1.Promotion) []string {
+	answer := []string{}
+	for _, promotion := range promotions {
+		answer = append(answer, promotion.Name)
+	}
+	return answer
+}
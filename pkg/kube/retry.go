@@ -0,0 +1,66 @@
+package kube
+
+import (
+	"time"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// maxSuggestedRetryDelay the longest delay the API server can ask for via a Retry-After header which is honoured
+const maxSuggestedRetryDelay = 10 * time.Second
+
+// DefaultAPIRetryBackoff the backoff used when retrying requests which failed because of a conflicting change or
+// because the API server was throttling requests or temporarily unavailable
+var DefaultAPIRetryBackoff = wait.Backoff{
+	Steps:    7,
+	Duration: 200 * time.Millisecond,
+	Factor:   2.0,
+	Jitter:   0.1,
+}
+
+// IsRetryableAPIError returns true if the request failed because of a conflicting change to the resource, throttling
+// or a transient error of the API server so that it may succeed if it is retried
+func IsRetryableAPIError(err error) bool {
+	return kerrors.IsConflict(err) ||
+		kerrors.IsTooManyRequests(err) ||
+		kerrors.IsServerTimeout(err) ||
+		kerrors.IsTimeout(err) ||
+		kerrors.IsInternalError(err) ||
+		kerrors.IsServiceUnavailable(err) ||
+		kerrors.IsUnexpectedServerError(err)
+}
+
+// RetryOnAPIError invokes the function, retrying with an exponential backoff while it fails with a retryable API
+// error. The function should get the latest version of any resource it modifies so that conflicts can be resolved
+func RetryOnAPIError(fn func() error) error {
+	return RetryOnAPIErrorWithBackoff(DefaultAPIRetryBackoff, fn)
+}
+
+// RetryOnAPIErrorWithBackoff invokes the function, retrying with the given backoff while it fails with a retryable API
+// error. Any delay the API server asks for is honoured. The last error is returned if the retries are exhausted
+func RetryOnAPIErrorWithBackoff(backoff wait.Backoff, fn func() error) error {
+	var lastErr error
+	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
+		err := fn()
+		if err == nil {
+			return true, nil
+		}
+		if !IsRetryableAPIError(err) {
+			return false, err
+		}
+		lastErr = err
+		if seconds, ok := kerrors.SuggestsClientDelay(err); ok {
+			delay := time.Duration(seconds) * time.Second
+			if delay > maxSuggestedRetryDelay {
+				delay = maxSuggestedRetryDelay
+			}
+			time.Sleep(delay)
+		}
+		return false, nil
+	})
+	if err == wait.ErrWaitTimeout {
+		err = lastErr
+	}
+	return err
+}
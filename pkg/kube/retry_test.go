@@ -0,0 +1,119 @@
+package kube_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestRetryOnAPIError(t *testing.T) {
+	t.Parallel()
+	backoff := wait.Backoff{Steps: 3, Duration: time.Millisecond, Factor: 1.0}
+	resource := schema.GroupResource{Resource: "services"}
+
+	calls := 0
+	err := kube.RetryOnAPIErrorWithBackoff(backoff, func() error {
+		calls++
+		if calls == 1 {
+			return kerrors.NewConflict(resource, "jenkins", fmt.Errorf("the object has been modified"))
+		}
+		if calls == 2 {
+			return kerrors.NewTooManyRequests("throttled", 0)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = kube.RetryOnAPIErrorWithBackoff(backoff, func() error {
+		calls++
+		return kerrors.NewInternalError(fmt.Errorf("etcd is unavailable"))
+	})
+	assert.True(t, kerrors.IsInternalError(err), "the last error is returned once the retries are exhausted")
+	assert.Equal(t, 3, calls)
+
+	calls = 0
+	err = kube.RetryOnAPIErrorWithBackoff(backoff, func() error {
+		calls++
+		return kerrors.NewNotFound(resource, "jenkins")
+	})
+	assert.True(t, kerrors.IsNotFound(err))
+	assert.Equal(t, 1, calls, "errors which are not transient are not retried")
+}
+
+func TestAnnotateServicesRetriesConflicts(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	client := fake.NewSimpleClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: ns, Annotations: map[string]string{
+			kube.ExposeAnnotation: "true",
+		}},
+	})
+	conflicts := 0
+	client.PrependReactor("update", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts < 2 {
+			conflicts++
+			return true, nil, kerrors.NewConflict(schema.GroupResource{Resource: "services"}, "jenkins", fmt.Errorf("the object has been modified"))
+		}
+		return false, nil, nil
+	})
+
+	err := kube.AnnotateNamespaceServicesWithCertManager(client, ns, "letsencrypt-prod")
+	require.NoError(t, err)
+	assert.Equal(t, 2, conflicts)
+
+	svc, err := client.CoreV1().Services(ns).Get("jenkins", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, kube.CertManagerAnnotation+": letsencrypt-prod", svc.Annotations[kube.ExposeIngressAnnotation])
+}
+
+func TestCreateServiceLinkRetriedAfterCreated(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	client := fake.NewSimpleClientset()
+	objectReactor := client.ReactionChain[0]
+	failures := 0
+	client.PrependReactor("create", "services", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if failures == 0 {
+			failures++
+			// the service is created but the API server fails before responding
+			_, _, err := objectReactor.React(action)
+			require.NoError(t, err)
+			return true, nil, kerrors.NewInternalError(fmt.Errorf("etcd is unavailable"))
+		}
+		return false, nil, nil
+	})
+
+	err := kube.CreateServiceLink(client, ns, "anchore", "anchore-engine", "http://anchore.jx.example.com")
+	require.NoError(t, err)
+	assert.Equal(t, 1, failures)
+
+	svc, err := client.CoreV1().Services(ns).Get("anchore-engine", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "anchore-engine.anchore.svc.cluster.local", svc.Spec.ExternalName)
+}
+
+func TestCreateServiceLinkAlreadyExists(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	client := fake.NewSimpleClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "anchore-engine", Namespace: ns},
+		Spec:       v1.ServiceSpec{Type: v1.ServiceTypeClusterIP},
+	})
+
+	err := kube.CreateServiceLink(client, ns, "anchore", "anchore-engine", "http://anchore.jx.example.com")
+	assert.True(t, kerrors.IsAlreadyExists(err), "a service which existed before the first attempt is not a link")
+}
@@ -0,0 +1,93 @@
+package kube
+
+import (
+	"fmt"
+
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// EventReasonTriggeredScaleUp the reason of the event the cluster autoscaler raises on a pod when it adds nodes for it
+	EventReasonTriggeredScaleUp = "TriggeredScaleUp"
+
+	// EventReasonNotTriggerScaleUp the reason of the event the cluster autoscaler raises on a pod when no node pool
+	// can be scaled up to fit it
+	EventReasonNotTriggerScaleUp = "NotTriggerScaleUp"
+)
+
+// IsPodUnschedulable returns true and the scheduler message if the pod cannot be scheduled onto any node
+func IsPodUnschedulable(pod *v1.Pod) (bool, string) {
+	if pod.Status.Phase != v1.PodPending {
+		return false, ""
+	}
+	_, condition := GetPodCondition(&pod.Status, v1.PodScheduled)
+	if condition == nil || condition.Status != v1.ConditionFalse || condition.Reason != v1.PodReasonUnschedulable {
+		return false, ""
+	}
+	return true, condition.Message
+}
+
+// GetUnschedulablePods returns the pods matching the label selector in the namespace which cannot be scheduled
+func GetUnschedulablePods(client kubernetes.Interface, ns string, selector string) ([]*v1.Pod, error) {
+	list, err := client.CoreV1().Pods(ns).List(meta_v1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	answer := []*v1.Pod{}
+	for i := range list.Items {
+		pod := &list.Items[i]
+		if unschedulable, _ := IsPodUnschedulable(pod); unschedulable {
+			answer = append(answer, pod)
+		}
+	}
+	return answer, nil
+}
+
+// GetScaleUpEventReason returns the reason of the latest cluster autoscaler scale up event of the pod or an empty
+// string if the cluster autoscaler has not reacted to the pod
+func GetScaleUpEventReason(client kubernetes.Interface, pod *v1.Pod) (string, error) {
+	events, err := client.CoreV1().Events(pod.Namespace).List(meta_v1.ListOptions{
+		FieldSelector: "involvedObject.kind=Pod,involvedObject.name=" + pod.Name,
+	})
+	if err != nil {
+		return "", err
+	}
+	answer := ""
+	var latest meta_v1.Time
+	for _, event := range events.Items {
+		if event.InvolvedObject.Name != pod.Name {
+			continue
+		}
+		if event.Reason != EventReasonTriggeredScaleUp && event.Reason != EventReasonNotTriggerScaleUp {
+			continue
+		}
+		if answer == "" || latest.Before(&event.LastTimestamp) {
+			answer = event.Reason
+			latest = event.LastTimestamp
+		}
+	}
+	return answer, nil
+}
+
+// UnschedulablePodHint returns a message explaining why the pod is waiting for a node and how to add capacity to
+// the cluster or an empty string if the pod can be scheduled
+func UnschedulablePodHint(client kubernetes.Interface, pod *v1.Pod) (string, error) {
+	unschedulable, message := IsPodUnschedulable(pod)
+	if !unschedulable {
+		return "", nil
+	}
+	reason, err := GetScaleUpEventReason(client, pod)
+	if err != nil {
+		return "", err
+	}
+	switch reason {
+	case EventReasonTriggeredScaleUp:
+		return fmt.Sprintf("pod %s cannot be scheduled yet: %s\nthe cluster autoscaler is adding nodes so the pod should start shortly", pod.Name, message), nil
+	case EventReasonNotTriggerScaleUp:
+		return fmt.Sprintf("pod %s cannot be scheduled: %s\nthe cluster autoscaler cannot add a node which fits the pod. Try increasing the maximum size of a node pool or adding a larger node pool via: jx create nodepool", pod.Name, message), nil
+	default:
+		return fmt.Sprintf("pod %s cannot be scheduled: %s\nthe cluster does not appear to be autoscaled. Try adding nodes via: jx scale nodepool", pod.Name, message), nil
+	}
+}
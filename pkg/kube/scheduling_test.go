@@ -0,0 +1,82 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func unschedulablePod(name string) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: meta_v1.ObjectMeta{Name: name, Namespace: "jx", Labels: map[string]string{"build.knative.dev/buildName": name}},
+		Status: v1.PodStatus{
+			Phase: v1.PodPending,
+			Conditions: []v1.PodCondition{
+				{
+					Type:    v1.PodScheduled,
+					Status:  v1.ConditionFalse,
+					Reason:  v1.PodReasonUnschedulable,
+					Message: "0/3 nodes are available: 3 Insufficient cpu.",
+				},
+			},
+		},
+	}
+}
+
+func scaleUpEvent(pod string, reason string) *v1.Event {
+	return &v1.Event{
+		ObjectMeta:     meta_v1.ObjectMeta{Name: pod + "-" + reason, Namespace: "jx"},
+		InvolvedObject: v1.ObjectReference{Kind: "Pod", Name: pod, Namespace: "jx"},
+		Reason:         reason,
+	}
+}
+
+func TestIsPodUnschedulable(t *testing.T) {
+	t.Parallel()
+	unschedulable, message := kube.IsPodUnschedulable(unschedulablePod("build"))
+	assert.True(t, unschedulable)
+	assert.Equal(t, "0/3 nodes are available: 3 Insufficient cpu.", message)
+
+	running := &v1.Pod{Status: v1.PodStatus{Phase: v1.PodRunning}}
+	unschedulable, _ = kube.IsPodUnschedulable(running)
+	assert.False(t, unschedulable)
+}
+
+func TestUnschedulablePodHint(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset(
+		unschedulablePod("scaling"),
+		unschedulablePod("stuck"),
+		unschedulablePod("fixed"),
+		&v1.Pod{ObjectMeta: meta_v1.ObjectMeta{Name: "running", Namespace: "jx", Labels: map[string]string{"build.knative.dev/buildName": "running"}}, Status: v1.PodStatus{Phase: v1.PodRunning}},
+		scaleUpEvent("scaling", kube.EventReasonTriggeredScaleUp),
+		scaleUpEvent("stuck", kube.EventReasonNotTriggerScaleUp),
+	)
+
+	pods, err := kube.GetUnschedulablePods(client, "jx", "build.knative.dev/buildName")
+	require.NoError(t, err)
+	assert.Len(t, pods, 3)
+
+	hints := map[string]string{
+		"scaling": "the cluster autoscaler is adding nodes",
+		"stuck":   "jx create nodepool",
+		"fixed":   "jx scale nodepool",
+	}
+	for _, pod := range pods {
+		hint, err := kube.UnschedulablePodHint(client, pod)
+		require.NoError(t, err)
+		assert.Contains(t, hint, hints[pod.Name], "pod %s", pod.Name)
+		assert.Contains(t, hint, "Insufficient cpu")
+	}
+
+	running, err := client.CoreV1().Pods("jx").Get("running", meta_v1.GetOptions{})
+	require.NoError(t, err)
+	hint, err := kube.UnschedulablePodHint(client, running)
+	require.NoError(t, err)
+	assert.Empty(t, hint)
+}
@@ -0,0 +1,70 @@
+package kube
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// WellKnownServices the names of the services of the well known tools of Jenkins X indexed by the short name users
+// can open them by
+var WellKnownServices = map[string][]string{
+	"jenkins":     {ServiceJenkins},
+	"deck":        {"deck"},
+	"nexus":       {ServiceNexus, "jenkins-x-sonatype-nexus"},
+	"monocular":   {"jenkins-x-monocular-ui", "monocular-ui", "monocular"},
+	"chartmuseum": {ServiceChartMuseum, "chartmuseum"},
+	"dashboard":   {ServiceKubernetesDashboard},
+	"cloudbees":   {ServiceCloudBees},
+}
+
+// ServiceNameCandidates returns the names of the services which the given name refers to, which is either the
+// name of a service or the short name of a well known service
+func ServiceNameCandidates(name string) []string {
+	answer := []string{}
+	if names, ok := WellKnownServices[strings.ToLower(name)]; ok {
+		answer = append(answer, names...)
+	}
+	if util.StringArrayIndex(answer, name) < 0 {
+		answer = append(answer, name)
+	}
+	return answer
+}
+
+// MatchServiceURLs returns the service URLs matching the name. Exact matches of the service name or the short name
+// of a well known service win, then services whose name contains the name and finally services whose name is
+// similar to the name so that typos still find the service
+func MatchServiceURLs(urls []ServiceURL, name string) []ServiceURL {
+	candidates := ServiceNameCandidates(name)
+	exact := []ServiceURL{}
+	for _, u := range urls {
+		if util.StringArrayIndex(candidates, u.Name) >= 0 {
+			exact = append(exact, u)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+
+	lower := strings.ToLower(name)
+	contains := []ServiceURL{}
+	names := []string{}
+	for _, u := range urls {
+		if strings.Contains(strings.ToLower(u.Name), lower) {
+			contains = append(contains, u)
+		}
+		names = append(names, u.Name)
+	}
+	if len(contains) > 0 {
+		return contains
+	}
+
+	similar := []ServiceURL{}
+	suggestions := util.SuggestionsFor(name, names, util.DefaultSuggestionsMinimumDistance)
+	for _, u := range urls {
+		if util.StringArrayIndex(suggestions, u.Name) >= 0 {
+			similar = append(similar, u)
+		}
+	}
+	return similar
+}
@@ -0,0 +1,39 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServiceNameCandidates(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{"nexus", "jenkins-x-sonatype-nexus"}, kube.ServiceNameCandidates("nexus"))
+	assert.Equal(t, []string{"jenkins-x-monocular-ui", "monocular-ui", "monocular", "Monocular"}, kube.ServiceNameCandidates("Monocular"))
+	assert.Equal(t, []string{"myapp"}, kube.ServiceNameCandidates("myapp"))
+}
+
+func TestMatchServiceURLs(t *testing.T) {
+	t.Parallel()
+	urls := []kube.ServiceURL{
+		{Name: "jenkins", URL: "http://jenkins.jx.example.com", Namespace: "jx"},
+		{Name: "jenkins-x-monocular-ui", URL: "http://monocular.jx.example.com", Namespace: "jx"},
+		{Name: "orders", URL: "http://orders.jx-staging.example.com", Namespace: "jx-staging"},
+		{Name: "orders", URL: "http://orders.jx-production.example.com", Namespace: "jx-production"},
+		{Name: "orders-api", URL: "http://orders-api.jx-staging.example.com", Namespace: "jx-staging"},
+		{Name: "payments", URL: "http://payments.jx-staging.example.com", Namespace: "jx-staging"},
+	}
+	names := func(matches []kube.ServiceURL) []string {
+		answer := []string{}
+		for _, m := range matches {
+			answer = append(answer, m.Name+"/"+m.Namespace)
+		}
+		return answer
+	}
+	assert.Equal(t, []string{"jenkins-x-monocular-ui/jx"}, names(kube.MatchServiceURLs(urls, "monocular")))
+	assert.Equal(t, []string{"orders/jx-staging", "orders/jx-production"}, names(kube.MatchServiceURLs(urls, "orders")))
+	assert.Equal(t, []string{"orders/jx-staging", "orders/jx-production", "orders-api/jx-staging"}, names(kube.MatchServiceURLs(urls, "order")))
+	assert.Equal(t, []string{"payments/jx-staging"}, names(kube.MatchServiceURLs(urls, "paymnets")))
+	assert.Empty(t, kube.MatchServiceURLs(urls, "nexus"))
+}
@@ -5,14 +5,18 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	meta_v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
 )
 
 const (
@@ -25,17 +29,23 @@ const (
 )
 
 type ServiceURL struct {
-	Name string
-	URL  string
+	Name      string
+	URL       string
+	Namespace string
 }
 
 func GetServices(client kubernetes.Interface, ns string) (map[string]*v1.Service, error) {
+	return GetServicesWithOptions(client, ns, meta_v1.ListOptions{})
+}
+
+// GetServicesWithOptions returns the services in the namespace matching the selectors of the options indexed by name
+func GetServicesWithOptions(client kubernetes.Interface, ns string, options meta_v1.ListOptions) (map[string]*v1.Service, error) {
 	answer := map[string]*v1.Service{}
-	list, err := client.CoreV1().Services(ns).List(meta_v1.ListOptions{})
+	list, err := ListServices(client, ns, options)
 	if err != nil {
 		return answer, fmt.Errorf("failed to load Services %s", err)
 	}
-	for _, r := range list.Items {
+	for _, r := range list {
 		name := r.Name
 		copy := r
 		answer[name] = &copy
@@ -45,11 +55,11 @@ func GetServices(client kubernetes.Interface, ns string) (map[string]*v1.Service
 
 func GetServiceNames(client kubernetes.Interface, ns string, filter string) ([]string, error) {
 	names := []string{}
-	list, err := client.CoreV1().Services(ns).List(meta_v1.ListOptions{})
+	list, err := ListServices(client, ns, meta_v1.ListOptions{})
 	if err != nil {
 		return names, fmt.Errorf("failed to load Services %s", err)
 	}
-	for _, r := range list.Items {
+	for _, r := range list {
 		name := r.Name
 		if filter == "" || strings.Contains(name, filter) {
 			names = append(names, name)
@@ -66,6 +76,11 @@ func GetServiceURLFromMap(services map[string]*v1.Service, name string) string {
 func FindServiceURL(client kubernetes.Interface, namespace string, name string) (string, error) {
 	svc, err := client.CoreV1().Services(namespace).Get(name, meta_v1.GetOptions{})
 	if err != nil {
+		// apps deployed as Knative Services may not have a Service until their Route is ready
+		knativeURL, knativeErr := FindKnativeServiceURL(client, namespace, name)
+		if knativeErr == nil && knativeURL != "" {
+			return knativeURL, nil
+		}
 		return "", err
 	}
 	answer := GetServiceURL(svc)
@@ -91,7 +106,25 @@ func FindServiceURL(client kubernetes.Interface, namespace string, name string)
 			}
 		}
 	}
-	return "", nil
+
+	// lets try find the service via an OpenShift Route
+	answer, err = FindOpenShiftRouteServiceURL(client, namespace, name)
+	if err == nil && answer != "" {
+		return answer, nil
+	}
+
+	// lets try find the service via an Istio VirtualService
+	answer, err = FindIstioServiceURL(client, namespace, name)
+	if err == nil && answer != "" {
+		return answer, nil
+	}
+
+	// lets try find the Route of a Knative Service
+	answer, err = FindKnativeServiceURL(client, namespace, name)
+	if err != nil {
+		return "", nil
+	}
+	return answer, nil
 }
 
 func FindServiceHostname(client kubernetes.Interface, namespace string, name string) (string, error) {
@@ -113,16 +146,26 @@ func FindServiceHostname(client kubernetes.Interface, namespace string, name str
 			}
 		}
 	}
+
+	// lets try find the service via an OpenShift Route
+	routes, err := GetOpenShiftRoutes(client, namespace)
+	if err == nil {
+		for i := range routes {
+			if OpenShiftRouteServiceName(&routes[i]) == name && routes[i].Spec.Host != "" {
+				return routes[i].Spec.Host, nil
+			}
+		}
+	}
 	return "", nil
 }
 
 // FindService looks up a service by name across all namespaces
 func FindService(client kubernetes.Interface, name string) (*v1.Service, error) {
-	nsl, err := client.CoreV1().Namespaces().List(meta_v1.ListOptions{})
+	nsl, err := ListNamespaces(client, meta_v1.ListOptions{})
 	if err != nil {
 		return nil, err
 	}
-	for _, ns := range nsl.Items {
+	for _, ns := range nsl {
 		svc, err := client.CoreV1().Services(ns.GetName()).Get(name, meta_v1.GetOptions{})
 		if err == nil {
 			return svc, nil
@@ -144,28 +187,141 @@ func GetServiceURLFromName(c kubernetes.Interface, name, ns string) (string, err
 	if err != nil {
 		return "", err
 	}
-	return GetServiceURL(svc), nil
+	answer := GetServiceURL(svc)
+	if answer != "" {
+		return answer, nil
+	}
+	answer, err = FindOpenShiftRouteServiceURL(c, ns, name)
+	if err == nil && answer != "" {
+		return answer, nil
+	}
+	answer, err = FindIstioServiceURL(c, ns, name)
+	if err == nil && answer != "" {
+		return answer, nil
+	}
+	answer, err = FindKnativeServiceURL(c, ns, name)
+	if err != nil {
+		return "", nil
+	}
+	return answer, nil
 }
 
 func FindServiceURLs(client kubernetes.Interface, namespace string) ([]ServiceURL, error) {
-	options := meta_v1.ListOptions{}
+	return FindServiceURLsWithOptions(client, namespace, meta_v1.ListOptions{})
+}
+
+// FindServiceURLsWithOptions returns the URLs of the services in the namespace matching the selectors of the options
+func FindServiceURLsWithOptions(client kubernetes.Interface, namespace string, options meta_v1.ListOptions) ([]ServiceURL, error) {
 	urls := []ServiceURL{}
-	svcs, err := client.CoreV1().Services(namespace).List(options)
+	svcs, err := ListServices(client, namespace, options)
 	if err != nil {
 		return urls, err
 	}
-	for _, svc := range svcs.Items {
-		url := GetServiceURL(&svc)
+	// services routed through an Istio gateway or an OpenShift Route may have no expose annotation so lets find
+	// their VirtualService and Route hosts
+	istioURLs, _ := FindIstioServiceURLs(client, namespace)
+	knativeURLs, _ := FindKnativeServiceURLs(client, namespace)
+	routeURLs, _ := FindOpenShiftRouteServiceURLs(client, namespace)
+	for _, svc := range svcs {
+		url := serviceURL(&svc, routeURLs, istioURLs, knativeURLs)
 		if len(url) > 0 {
 			urls = append(urls, ServiceURL{
-				Name: svc.Name,
-				URL:  url,
+				Name:      svc.Name,
+				URL:       url,
+				Namespace: namespace,
 			})
 		}
 	}
 	return urls, nil
 }
 
+func serviceURL(svc *v1.Service, routeURLs map[string]string, istioURLs map[string]string, knativeURLs map[string]string) string {
+	url := GetServiceURL(svc)
+	if url == "" {
+		url = routeURLs[svc.Name]
+	}
+	if url == "" {
+		url = istioURLs[svc.Name]
+	}
+	if url == "" {
+		url = knativeURLs[svc.Name]
+	}
+	return url
+}
+
+// WatchServiceURLs watches the services in the namespace matching the selectors of the options and invokes the
+// callback with the URLs of all the services, sorted by name, each time a URL is added, changed or removed such as when
+// exposecontroller or cert-manager annotate a service. It blocks until the stop channel is closed
+func WatchServiceURLs(client kubernetes.Interface, namespace string, options meta_v1.ListOptions, stop <-chan struct{}, callback func([]ServiceURL)) {
+	istioURLs, _ := FindIstioServiceURLs(client, namespace)
+	knativeURLs, _ := FindKnativeServiceURLs(client, namespace)
+	routeURLs, _ := FindOpenShiftRouteServiceURLs(client, namespace)
+
+	listWatch := &cache.ListWatch{
+		ListFunc: func(listOptions meta_v1.ListOptions) (runtime.Object, error) {
+			listOptions.LabelSelector = options.LabelSelector
+			listOptions.FieldSelector = options.FieldSelector
+			return client.CoreV1().Services(namespace).List(listOptions)
+		},
+		WatchFunc: func(listOptions meta_v1.ListOptions) (watch.Interface, error) {
+			listOptions.LabelSelector = options.LabelSelector
+			listOptions.FieldSelector = options.FieldSelector
+			return client.CoreV1().Services(namespace).Watch(listOptions)
+		},
+	}
+	urls := map[string]string{}
+	var lock sync.Mutex
+	onChange := func(svc *v1.Service, deleted bool) {
+		lock.Lock()
+		defer lock.Unlock()
+		url := ""
+		if !deleted {
+			url = serviceURL(svc, routeURLs, istioURLs, knativeURLs)
+		}
+		if urls[svc.Name] == url {
+			return
+		}
+		if url == "" {
+			delete(urls, svc.Name)
+		} else {
+			urls[svc.Name] = url
+		}
+		answer := []ServiceURL{}
+		for name, u := range urls {
+			answer = append(answer, ServiceURL{
+				Name:      name,
+				URL:       u,
+				Namespace: namespace,
+			})
+		}
+		sort.Slice(answer, func(i, j int) bool {
+			return answer[i].Name < answer[j].Name
+		})
+		callback(answer)
+	}
+	_, controller := cache.NewInformer(listWatch, &v1.Service{}, time.Minute*10, cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if svc, ok := obj.(*v1.Service); ok {
+				onChange(svc, false)
+			}
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			if svc, ok := newObj.(*v1.Service); ok {
+				onChange(svc, false)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if svc, ok := obj.(*v1.Service); ok {
+				onChange(svc, true)
+			}
+		},
+	})
+	controller.Run(stop)
+}
+
 // waits for the pods of a deployment to become ready
 func WaitForExternalIP(client kubernetes.Interface, name, namespace string, timeout time.Duration) error {
 
@@ -217,12 +373,26 @@ func CreateServiceLink(client kubernetes.Interface, currentNamespace, targetName
 		},
 	}
 
-	_, err := client.CoreV1().Services(currentNamespace).Create(&svc)
-	if err != nil {
-		return err
-	}
+	return createServiceLink(client, currentNamespace, &svc)
+}
 
-	return nil
+// createServiceLink creates the service retrying on transient API errors. If a retried create finds the service
+// already exists an earlier attempt which failed with a transient error may have created it, so the create succeeds
+// if the existing service links to the same service
+func createServiceLink(client kubernetes.Interface, ns string, svc *v1.Service) error {
+	services := client.CoreV1().Services(ns)
+	attempts := 0
+	return RetryOnAPIError(func() error {
+		attempts++
+		_, err := services.Create(svc)
+		if err != nil && attempts > 1 && kerrors.IsAlreadyExists(err) {
+			existing, getErr := services.Get(svc.Name, meta_v1.GetOptions{})
+			if getErr == nil && existing.Spec.Type == svc.Spec.Type && existing.Spec.ExternalName == svc.Spec.ExternalName {
+				return nil
+			}
+		}
+		return err
+	})
 }
 
 func DeleteService(client *kubernetes.Clientset, namespace string, serviceName string) error {
@@ -240,11 +410,7 @@ func GetService(client kubernetes.Interface, currentNamespace, targetNamespace,
 			ExternalName: fmt.Sprintf("%s.%s.svc.cluster.local", serviceName, targetNamespace),
 		},
 	}
-	_, err := client.CoreV1().Services(currentNamespace).Create(&svc)
-	if err != nil {
-		return err
-	}
-	return nil
+	return createServiceLink(client, currentNamespace, &svc)
 }
 
 func IsServicePresent(c kubernetes.Interface, name, ns string) (bool, error) {
@@ -261,8 +427,11 @@ func AnnotateNamespaceServicesWithCertManager(c kubernetes.Interface, ns, issuer
 		return err
 	}
 
-	for _, s := range svcList {
-		if s.Annotations[ExposeAnnotation] == "true" && s.Annotations[JenkinsXSkipTLSAnnotation] != "true" {
+	for name := range svcList {
+		err = UpdateService(c, ns, name, func(s *v1.Service) bool {
+			if s.Annotations[ExposeAnnotation] != "true" || s.Annotations[JenkinsXSkipTLSAnnotation] == "true" {
+				return false
+			}
 			existingAnnotations, _ := s.Annotations[ExposeIngressAnnotation]
 			// if no existing `fabric8.io/ingress.annotations` initialise and add else update with ClusterIssuer
 			if len(existingAnnotations) > 0 {
@@ -270,10 +439,10 @@ func AnnotateNamespaceServicesWithCertManager(c kubernetes.Interface, ns, issuer
 			} else {
 				s.Annotations[ExposeIngressAnnotation] = CertManagerAnnotation + ": " + issuer
 			}
-			_, err = c.CoreV1().Services(ns).Update(s)
-			if err != nil {
-				return fmt.Errorf("failed to annotate and update service %s in namespace %s: %v", s.Name, ns, err)
-			}
+			return true
+		})
+		if err != nil {
+			return fmt.Errorf("failed to annotate and update service %s in namespace %s: %v", name, ns, err)
 		}
 	}
 	return nil
@@ -284,8 +453,11 @@ func CleanServiceAnnotations(c kubernetes.Interface, ns string) error {
 	if err != nil {
 		return err
 	}
-	for _, s := range svcList {
-		if s.Annotations[ExposeAnnotation] == "true" && s.Annotations[JenkinsXSkipTLSAnnotation] != "true" {
+	for name := range svcList {
+		err = UpdateService(c, ns, name, func(s *v1.Service) bool {
+			if s.Annotations[ExposeAnnotation] != "true" || s.Annotations[JenkinsXSkipTLSAnnotation] == "true" {
+				return false
+			}
 			// if no existing `fabric8.io/ingress.annotations` initialise and add else update with ClusterIssuer
 			annotationsForIngress, _ := s.Annotations[ExposeIngressAnnotation]
 			if len(annotationsForIngress) > 0 {
@@ -311,12 +483,30 @@ func CleanServiceAnnotations(c kubernetes.Interface, ns string) error {
 
 			}
 			delete(s.Annotations, ExposeURLAnnotation)
-
-			_, err = c.CoreV1().Services(ns).Update(s)
-			if err != nil {
-				return fmt.Errorf("failed to clean service %s annotations in namespace %s: %v", s.Name, ns, err)
-			}
+			return true
+		})
+		if err != nil {
+			return fmt.Errorf("failed to clean service %s annotations in namespace %s: %v", name, ns, err)
 		}
 	}
 	return nil
 }
+
+// UpdateService gets the latest version of the service, invokes the function to modify it and updates the service
+// if the function returns true. The update is retried on conflicts and transient API server errors
+func UpdateService(c kubernetes.Interface, ns string, name string, modify func(svc *v1.Service) bool) error {
+	return RetryOnAPIError(func() error {
+		svc, err := c.CoreV1().Services(ns).Get(name, meta_v1.GetOptions{})
+		if err != nil {
+			return err
+		}
+		if svc.Annotations == nil {
+			svc.Annotations = map[string]string{}
+		}
+		if !modify(svc) {
+			return nil
+		}
+		_, err = c.CoreV1().Services(ns).Update(svc)
+		return err
+	})
+}
@@ -0,0 +1,60 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestWatchServiceURLs(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	client := fake.NewSimpleClientset(
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "jenkins", Namespace: ns, Annotations: map[string]string{
+			kube.ExposeURLAnnotation: "http://jenkins.jx.example.com",
+		}}},
+		&v1.Service{ObjectMeta: metav1.ObjectMeta{Name: "nexus", Namespace: ns}},
+	)
+	changes := make(chan []kube.ServiceURL, 10)
+	stop := make(chan struct{})
+	defer close(stop)
+	go kube.WatchServiceURLs(client, ns, metav1.ListOptions{}, stop, func(urls []kube.ServiceURL) {
+		changes <- urls
+	})
+
+	nextChange := func() []kube.ServiceURL {
+		select {
+		case urls := <-changes:
+			return urls
+		case <-time.After(10 * time.Second):
+			require.Fail(t, "timed out waiting for the URLs to change")
+			return nil
+		}
+	}
+	assert.Equal(t, []kube.ServiceURL{
+		{Name: "jenkins", URL: "http://jenkins.jx.example.com", Namespace: ns},
+	}, nextChange())
+
+	// exposecontroller annotates the service with its URL
+	svc, err := client.CoreV1().Services(ns).Get("nexus", metav1.GetOptions{})
+	require.NoError(t, err)
+	svc.Annotations = map[string]string{kube.ExposeURLAnnotation: "http://nexus.jx.example.com"}
+	_, err = client.CoreV1().Services(ns).Update(svc)
+	require.NoError(t, err)
+	assert.Equal(t, []kube.ServiceURL{
+		{Name: "jenkins", URL: "http://jenkins.jx.example.com", Namespace: ns},
+		{Name: "nexus", URL: "http://nexus.jx.example.com", Namespace: ns},
+	}, nextChange())
+
+	err = client.CoreV1().Services(ns).Delete("jenkins", &metav1.DeleteOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []kube.ServiceURL{
+		{Name: "nexus", URL: "http://nexus.jx.example.com", Namespace: ns},
+	}, nextChange())
+}
@@ -0,0 +1,72 @@
+package kube
+
+import (
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// the job name is used as a label value by the pods of the job
+const maxJobNameLength = 63
+
+// SmokeTestJob creates the Job which runs the smoke test image against a promoted version. If the command is not
+// empty it is run via the shell rather than the entrypoint of the image. The environment variables are added to
+// the container so that the tests know the version and environment they are testing
+func SmokeTestJob(name string, image string, command string, envVars map[string]string, timeout time.Duration) *batchv1.Job {
+	container := corev1.Container{
+		Name:  "smoke-test",
+		Image: image,
+	}
+	if command != "" {
+		container.Command = []string{"sh", "-c", command}
+	}
+	for _, k := range util.SortedMapKeys(envVars) {
+		container.Env = append(container.Env, corev1.EnvVar{
+			Name:  k,
+			Value: envVars[k],
+		})
+	}
+	name = ToValidName(name)
+	if len(name) > maxJobNameLength {
+		name = strings.TrimRight(name[:maxJobNameLength], "-")
+	}
+	backoffLimit := int32(0)
+	deadline := int64(timeout.Seconds())
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				LabelCreatedBy: ValueCreatedByJX,
+				LabelJobKind:   ValueJobKindSmokeTest,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers:    []corev1.Container{container},
+					RestartPolicy: corev1.RestartPolicyNever,
+				},
+			},
+			BackoffLimit: &backoffLimit,
+		},
+	}
+	if deadline > 0 {
+		job.Spec.ActiveDeadlineSeconds = &deadline
+	}
+	return job
+}
+
+// DeployedAppVersion returns the version of the deployment of the app in the namespace or an empty string if the
+// app is not deployed
+func DeployedAppVersion(client kubernetes.Interface, ns string, app string) (string, error) {
+	d, err := FindAppDeployment(client, ns, app)
+	if err != nil || d == nil {
+		return "", err
+	}
+	return GetVersion(&d.ObjectMeta), nil
+}
@@ -0,0 +1,61 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/apps/v1beta1"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestSmokeTestJob(t *testing.T) {
+	t.Parallel()
+	envVars := map[string]string{
+		"JX_VERSION": "1.2.3",
+		"JX_APP":     "myapp",
+	}
+	job := kube.SmokeTestJob("smoke-test-myapp-1.2.3", "myorg/tests:1.0", "./run.sh", envVars, 5*time.Minute)
+
+	assert.Equal(t, "smoke-test-myapp-1-2-3", job.Name)
+	assert.Equal(t, kube.ValueJobKindSmokeTest, job.Labels[kube.LabelJobKind])
+	assert.Equal(t, int32(0), *job.Spec.BackoffLimit)
+	assert.Equal(t, int64(300), *job.Spec.ActiveDeadlineSeconds)
+	podSpec := job.Spec.Template.Spec
+	assert.Equal(t, v1.RestartPolicyNever, podSpec.RestartPolicy)
+	require.Len(t, podSpec.Containers, 1)
+	container := podSpec.Containers[0]
+	assert.Equal(t, "myorg/tests:1.0", container.Image)
+	assert.Equal(t, []string{"sh", "-c", "./run.sh"}, container.Command)
+	assert.Equal(t, []v1.EnvVar{{Name: "JX_APP", Value: "myapp"}, {Name: "JX_VERSION", Value: "1.2.3"}}, container.Env)
+
+	job = kube.SmokeTestJob("smoke-test-myapp", "myorg/tests:1.0", "", nil, 0)
+	assert.Empty(t, job.Spec.Template.Spec.Containers[0].Command)
+	assert.Nil(t, job.Spec.ActiveDeadlineSeconds)
+}
+
+func TestDeployedAppVersion(t *testing.T) {
+	t.Parallel()
+	ns := "jx-staging"
+	client := fake.NewSimpleClientset(&v1beta1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jx-staging-myapp",
+			Namespace: ns,
+			Labels: map[string]string{
+				"version": "1.0.1",
+			},
+		},
+	})
+
+	version, err := kube.DeployedAppVersion(client, ns, "myapp")
+	require.NoError(t, err)
+	assert.Equal(t, "1.0.1", version)
+
+	version, err = kube.DeployedAppVersion(client, ns, "other")
+	require.NoError(t, err)
+	assert.Equal(t, "", version)
+}
@@ -0,0 +1,95 @@
+package kube
+
+import (
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationPipelinesPaused the annotation of a SourceRepository which is "true" while its pipeline triggers are paused
+	AnnotationPipelinesPaused = "jenkins.io/pipelines-paused"
+	// AnnotationPipelinesPausedBy the annotation containing the user who paused the pipeline triggers
+	AnnotationPipelinesPausedBy = "jenkins.io/pipelines-paused-by"
+	// AnnotationPipelinesPausedReason the annotation containing why the pipeline triggers were paused
+	AnnotationPipelinesPausedReason = "jenkins.io/pipelines-paused-reason"
+	// AnnotationPipelinesPausedAt the annotation containing when the pipeline triggers were paused
+	AnnotationPipelinesPausedAt = "jenkins.io/pipelines-paused-at"
+
+	// AnnotationPipelineLastRerun the annotation containing the pull request or commit of the last manual re-run
+	AnnotationPipelineLastRerun = "jenkins.io/pipeline-last-rerun"
+	// AnnotationPipelineLastRerunBy the annotation containing the user who last re-ran a pipeline manually
+	AnnotationPipelineLastRerunBy = "jenkins.io/pipeline-last-rerun-by"
+	// AnnotationPipelineLastRerunAt the annotation containing when a pipeline was last re-run manually
+	AnnotationPipelineLastRerunAt = "jenkins.io/pipeline-last-rerun-at"
+)
+
+// SourceRepositoryName returns the name of the SourceRepository resource of the git repository
+func SourceRepositoryName(org string, repo string) string {
+	return ToValidName(org + "-" + repo)
+}
+
+// GetOrCreateSourceRepository returns the SourceRepository resource of the git repository creating it if it does not
+// exist yet
+func GetOrCreateSourceRepository(jxClient versioned.Interface, ns string, org string, repo string, provider string) (*v1.SourceRepository, error) {
+	repositories := jxClient.JenkinsV1().SourceRepositories(ns)
+	name := SourceRepositoryName(org, repo)
+	sr, err := repositories.Get(name, metav1.GetOptions{})
+	if err == nil {
+		return sr, nil
+	}
+	if !errors.IsNotFound(err) {
+		return nil, err
+	}
+	return repositories.Create(&v1.SourceRepository{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: v1.SourceRepositorySpec{
+			Provider: provider,
+			Org:      org,
+			Repo:     repo,
+		},
+	})
+}
+
+// IsPipelinePaused returns true if the pipeline triggers of the repository are paused
+func IsPipelinePaused(sr *v1.SourceRepository) bool {
+	return sr.Annotations[AnnotationPipelinesPaused] == "true"
+}
+
+// PausePipelines records on the SourceRepository that its pipeline triggers have been paused by the user
+func PausePipelines(sr *v1.SourceRepository, user string, reason string, now time.Time) {
+	if sr.Annotations == nil {
+		sr.Annotations = map[string]string{}
+	}
+	sr.Annotations[AnnotationPipelinesPaused] = "true"
+	sr.Annotations[AnnotationPipelinesPausedBy] = user
+	sr.Annotations[AnnotationPipelinesPausedAt] = now.UTC().Format(time.RFC3339)
+	if reason != "" {
+		sr.Annotations[AnnotationPipelinesPausedReason] = reason
+	} else {
+		delete(sr.Annotations, AnnotationPipelinesPausedReason)
+	}
+}
+
+// ResumePipelines removes the annotations recording that the pipeline triggers of the SourceRepository are paused
+func ResumePipelines(sr *v1.SourceRepository) {
+	delete(sr.Annotations, AnnotationPipelinesPaused)
+	delete(sr.Annotations, AnnotationPipelinesPausedBy)
+	delete(sr.Annotations, AnnotationPipelinesPausedReason)
+	delete(sr.Annotations, AnnotationPipelinesPausedAt)
+}
+
+// RecordRerun records on the SourceRepository that the pipeline of the pull request or commit was re-run by the user
+func RecordRerun(sr *v1.SourceRepository, ref string, user string, now time.Time) {
+	if sr.Annotations == nil {
+		sr.Annotations = map[string]string{}
+	}
+	sr.Annotations[AnnotationPipelineLastRerun] = ref
+	sr.Annotations[AnnotationPipelineLastRerunBy] = user
+	sr.Annotations[AnnotationPipelineLastRerunAt] = now.UTC().Format(time.RFC3339)
+}
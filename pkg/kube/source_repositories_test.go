@@ -0,0 +1,49 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	jxfake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSourceRepositoryPipelineAnnotations(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	jxClient := jxfake.NewSimpleClientset()
+
+	sr, err := kube.GetOrCreateSourceRepository(jxClient, ns, "jstrachan", "Cheese_App", "https://github.com")
+	require.NoError(t, err)
+	assert.Equal(t, "jstrachan-cheese-app", sr.Name)
+	assert.Equal(t, "Cheese_App", sr.Spec.Repo)
+	assert.False(t, kube.IsPipelinePaused(sr))
+
+	now := time.Date(2018, 10, 1, 12, 30, 0, 0, time.UTC)
+	kube.PausePipelines(sr, "rawlingsj", "incident freeze", now)
+	assert.True(t, kube.IsPipelinePaused(sr))
+	assert.Equal(t, "rawlingsj", sr.Annotations[kube.AnnotationPipelinesPausedBy])
+	assert.Equal(t, "incident freeze", sr.Annotations[kube.AnnotationPipelinesPausedReason])
+	assert.Equal(t, "2018-10-01T12:30:00Z", sr.Annotations[kube.AnnotationPipelinesPausedAt])
+
+	kube.RecordRerun(sr, "PR-12", "rawlingsj", now)
+	_, err = jxClient.JenkinsV1().SourceRepositories(ns).Update(sr)
+	require.NoError(t, err)
+
+	sr, err = kube.GetOrCreateSourceRepository(jxClient, ns, "jstrachan", "Cheese_App", "https://github.com")
+	require.NoError(t, err)
+	assert.True(t, kube.IsPipelinePaused(sr))
+	assert.Equal(t, "PR-12", sr.Annotations[kube.AnnotationPipelineLastRerun])
+
+	kube.ResumePipelines(sr)
+	assert.False(t, kube.IsPipelinePaused(sr))
+	assert.Empty(t, sr.Annotations[kube.AnnotationPipelinesPausedReason])
+	assert.Equal(t, "PR-12", sr.Annotations[kube.AnnotationPipelineLastRerun])
+
+	list, err := jxClient.JenkinsV1().SourceRepositories(ns).List(metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, list.Items, 1)
+}
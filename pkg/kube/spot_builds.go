@@ -0,0 +1,277 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// AnnotationSpotBuilds the annotation on pod templates recording the spot build settings which were applied
+	// so that they can be removed again when the settings change
+	AnnotationSpotBuilds = "jenkins.io/spot-builds"
+
+	// AnnotationBuildRetries the annotation on build pods recording how many times the build has been retried
+	AnnotationBuildRetries = "jenkins.io/build-retries"
+
+	// AnnotationBuildRetryOf the annotation on retried build pods with the name of the pod which was preempted
+	AnnotationBuildRetryOf = "jenkins.io/build-retry-of"
+
+	// DefaultSpotBuildMaxRetries the default number of times a build whose node was preempted is retried
+	DefaultSpotBuildMaxRetries = 2
+)
+
+var (
+	// spotNodeSelectors the labels the cloud providers add to spot or preemptible nodes
+	spotNodeSelectors = map[string]map[string]string{
+		"gke": {"cloud.google.com/gke-preemptible": "true"},
+		"eks": {"eks.amazonaws.com/capacityType": "SPOT"},
+		"aks": {"kubernetes.azure.com/scalesetpriority": "spot"},
+	}
+
+	// spotTaints the taints of spot or preemptible nodes so that only pods which tolerate them run there. EKS does
+	// not taint spot nodes
+	spotTaints = map[string]corev1.Taint{
+		"gke": {Key: "cloud.google.com/gke-preemptible", Value: "true", Effect: corev1.TaintEffectNoSchedule},
+		"aks": {Key: "kubernetes.azure.com/scalesetpriority", Value: "spot", Effect: corev1.TaintEffectNoSchedule},
+	}
+
+	// preemptionReasons the reasons of failed pods whose node was preempted or shut down
+	preemptionReasons = []string{"Preempting", "Shutdown", "NodeShutdown", "Terminated", "NodeLost"}
+)
+
+// SpotNodeTaint returns the taint of the spot or preemptible nodes of the cloud provider or nil if they are not tainted
+func SpotNodeTaint(provider string) *corev1.Taint {
+	taint, ok := spotTaints[provider]
+	if !ok {
+		return nil
+	}
+	return &taint
+}
+
+// DefaultSpotBuilds returns the spot build settings which schedule build pods onto the spot or preemptible nodes of
+// the cloud provider
+func DefaultSpotBuilds(provider string) (*v1.SpotBuilds, error) {
+	selector, ok := spotNodeSelectors[provider]
+	if !ok {
+		return nil, fmt.Errorf("spot or preemptible nodes are not supported for provider %s", provider)
+	}
+	answer := &v1.SpotBuilds{
+		NodeSelector: map[string]string{},
+		MaxRetries:   DefaultSpotBuildMaxRetries,
+	}
+	for k, v := range selector {
+		answer.NodeSelector[k] = v
+	}
+	taint := SpotNodeTaint(provider)
+	if taint != nil {
+		answer.Tolerations = []corev1.Toleration{
+			{
+				Key:      taint.Key,
+				Operator: corev1.TolerationOpEqual,
+				Value:    taint.Value,
+				Effect:   taint.Effect,
+			},
+		}
+	}
+	return answer, nil
+}
+
+// ParseToleration parses a toleration of the form key=value:Effect, key:Effect or key
+func ParseToleration(text string) (corev1.Toleration, error) {
+	answer := corev1.Toleration{Operator: corev1.TolerationOpExists}
+	rest := text
+	if idx := strings.LastIndex(rest, ":"); idx >= 0 {
+		answer.Effect = corev1.TaintEffect(rest[idx+1:])
+		rest = rest[0:idx]
+	}
+	if idx := strings.Index(rest, "="); idx >= 0 {
+		answer.Value = rest[idx+1:]
+		answer.Operator = corev1.TolerationOpEqual
+		rest = rest[0:idx]
+	}
+	answer.Key = rest
+	if answer.Key == "" {
+		return answer, fmt.Errorf("invalid toleration %s: no key specified", text)
+	}
+	switch answer.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return answer, fmt.Errorf("invalid toleration %s: unknown effect %s", text, answer.Effect)
+	}
+	return answer, nil
+}
+
+// ApplySpotBuilds adds the node selector and tolerations of the spot build settings to the pod, removing any
+// previously applied spot build settings. Passing nil settings removes them
+func ApplySpotBuilds(pod *corev1.Pod, settings *v1.SpotBuilds) error {
+	previous := &v1.SpotBuilds{}
+	if text := pod.Annotations[AnnotationSpotBuilds]; text != "" {
+		err := json.Unmarshal([]byte(text), previous)
+		if err != nil {
+			return fmt.Errorf("failed to parse annotation %s on pod %s: %s", AnnotationSpotBuilds, pod.Name, err)
+		}
+	}
+	spec := &pod.Spec
+	for k := range previous.NodeSelector {
+		delete(spec.NodeSelector, k)
+	}
+	tolerations := []corev1.Toleration{}
+	for _, t := range spec.Tolerations {
+		if !containsToleration(previous.Tolerations, t) {
+			tolerations = append(tolerations, t)
+		}
+	}
+	spec.Tolerations = tolerations
+
+	if settings == nil {
+		delete(pod.Annotations, AnnotationSpotBuilds)
+	} else {
+		if len(settings.NodeSelector) > 0 && spec.NodeSelector == nil {
+			spec.NodeSelector = map[string]string{}
+		}
+		for k, v := range settings.NodeSelector {
+			spec.NodeSelector[k] = v
+		}
+		for _, t := range settings.Tolerations {
+			if !containsToleration(spec.Tolerations, t) {
+				spec.Tolerations = append(spec.Tolerations, t)
+			}
+		}
+		data, err := json.Marshal(settings)
+		if err != nil {
+			return err
+		}
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[AnnotationSpotBuilds] = string(data)
+	}
+	if len(spec.NodeSelector) == 0 {
+		spec.NodeSelector = nil
+	}
+	if len(spec.Tolerations) == 0 {
+		spec.Tolerations = nil
+	}
+	return nil
+}
+
+// UpdatePodTemplatesWithSpotBuilds updates all the pod templates used by builds with the spot build settings
+func UpdatePodTemplatesWithSpotBuilds(kubeClient kubernetes.Interface, ns string, settings *v1.SpotBuilds) error {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find ConfigMap %s in namespace %s: %s", ConfigMapJenkinsPodTemplates, ns, err)
+	}
+	names := []string{}
+	for name := range cm.Data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		pod := &corev1.Pod{}
+		err = yaml.Unmarshal([]byte(cm.Data[name]), pod)
+		if err != nil {
+			return fmt.Errorf("failed to parse pod template %s: %s", name, err)
+		}
+		err = ApplySpotBuilds(pod, settings)
+		if err != nil {
+			return err
+		}
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return err
+		}
+		cm.Data[name] = string(data)
+	}
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Update(cm)
+	return err
+}
+
+// IsPodPreempted returns true if the pod failed because its node was preempted or shut down
+func IsPodPreempted(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodFailed {
+		return false
+	}
+	for _, reason := range preemptionReasons {
+		if pod.Status.Reason == reason {
+			return true
+		}
+	}
+	return false
+}
+
+// GetBuildRetries returns the number of times the build of the pod has been retried
+func GetBuildRetries(pod *corev1.Pod) int {
+	retries, err := strconv.Atoi(pod.Annotations[AnnotationBuildRetries])
+	if err != nil {
+		return 0
+	}
+	return retries
+}
+
+// RetryPreemptedBuildPod recreates a build pod which failed because its node was preempted if it has been retried
+// fewer than maxRetries times. Returns the new pod or nil if the pod is not retried
+func RetryPreemptedBuildPod(kubeClient kubernetes.Interface, pod *corev1.Pod, maxRetries int) (*corev1.Pod, error) {
+	if !IsPodPreempted(pod) {
+		return nil, nil
+	}
+	retries := GetBuildRetries(pod)
+	if retries >= maxRetries {
+		return nil, nil
+	}
+	retries++
+	original := pod.Annotations[AnnotationBuildRetryOf]
+	if original == "" {
+		original = pod.Name
+	}
+	name := fmt.Sprintf("%s-retry-%d", original, retries)
+	pods := kubeClient.CoreV1().Pods(pod.Namespace)
+	if _, err := pods.Get(name, metav1.GetOptions{}); err == nil {
+		// already retried
+		return nil, nil
+	}
+
+	annotations := map[string]string{}
+	for k, v := range pod.Annotations {
+		annotations[k] = v
+	}
+	annotations[AnnotationBuildRetries] = strconv.Itoa(retries)
+	annotations[AnnotationBuildRetryOf] = original
+	labels := map[string]string{}
+	for k, v := range pod.Labels {
+		labels[k] = v
+	}
+	retry := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            name,
+			Namespace:       pod.Namespace,
+			Labels:          labels,
+			Annotations:     annotations,
+			OwnerReferences: pod.OwnerReferences,
+		},
+		Spec: *pod.Spec.DeepCopy(),
+	}
+	retry.Spec.NodeName = ""
+	answer, err := pods.Create(retry)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create pod %s to retry preempted build pod %s: %s", name, pod.Name, err)
+	}
+	return answer, nil
+}
+
+func containsToleration(tolerations []corev1.Toleration, t corev1.Toleration) bool {
+	for _, t2 := range tolerations {
+		if t2.Key == t.Key && t2.Operator == t.Operator && t2.Value == t.Value && t2.Effect == t.Effect {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseToleration(t *testing.T) {
+	t.Parallel()
+	toleration, err := kube.ParseToleration("spot=true:NoSchedule")
+	require.NoError(t, err)
+	assert.Equal(t, corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpEqual, Value: "true", Effect: corev1.TaintEffectNoSchedule}, toleration)
+
+	toleration, err = kube.ParseToleration("spot")
+	require.NoError(t, err)
+	assert.Equal(t, corev1.Toleration{Key: "spot", Operator: corev1.TolerationOpExists}, toleration)
+
+	_, err = kube.ParseToleration("=true:NoSchedule")
+	assert.Error(t, err)
+	_, err = kube.ParseToleration("spot=true:Never")
+	assert.Error(t, err)
+}
+
+func TestApplySpotBuilds(t *testing.T) {
+	t.Parallel()
+	userToleration := corev1.Toleration{Key: "dedicated", Operator: corev1.TolerationOpExists}
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{"disktype": "ssd"},
+			Tolerations:  []corev1.Toleration{userToleration},
+		},
+	}
+
+	gke, err := kube.DefaultSpotBuilds("gke")
+	require.NoError(t, err)
+	require.NoError(t, kube.ApplySpotBuilds(pod, gke))
+	require.NoError(t, kube.ApplySpotBuilds(pod, gke))
+	assert.Equal(t, map[string]string{"disktype": "ssd", "cloud.google.com/gke-preemptible": "true"}, pod.Spec.NodeSelector)
+	assert.Len(t, pod.Spec.Tolerations, 2)
+
+	// changing the settings removes the previous ones
+	custom := &v1.SpotBuilds{NodeSelector: map[string]string{"pool": "spot"}}
+	require.NoError(t, kube.ApplySpotBuilds(pod, custom))
+	assert.Equal(t, map[string]string{"disktype": "ssd", "pool": "spot"}, pod.Spec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{userToleration}, pod.Spec.Tolerations)
+
+	require.NoError(t, kube.ApplySpotBuilds(pod, nil))
+	assert.Equal(t, map[string]string{"disktype": "ssd"}, pod.Spec.NodeSelector)
+	assert.Empty(t, pod.Annotations[kube.AnnotationSpotBuilds])
+
+	_, err = kube.DefaultSpotBuilds("minikube")
+	assert.Error(t, err)
+	eks, err := kube.DefaultSpotBuilds("eks")
+	require.NoError(t, err)
+	assert.Empty(t, eks.Tolerations, "EKS does not taint spot nodes")
+}
+
+func TestRetryPreemptedBuildPod(t *testing.T) {
+	t.Parallel()
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "myorg-myrepo-master-1-build",
+			Namespace: "jx",
+			Labels:    map[string]string{"build.knative.dev/buildName": "myorg-myrepo-master-1"},
+		},
+		Spec: corev1.PodSpec{NodeName: "preempted-node"},
+		Status: corev1.PodStatus{
+			Phase:  corev1.PodFailed,
+			Reason: "Terminated",
+		},
+	}
+	client := fake.NewSimpleClientset(pod)
+
+	assert.True(t, kube.IsPodPreempted(pod))
+	retry, err := kube.RetryPreemptedBuildPod(client, pod, 2)
+	require.NoError(t, err)
+	require.NotNil(t, retry)
+	assert.Equal(t, "myorg-myrepo-master-1-build-retry-1", retry.Name)
+	assert.Equal(t, "", retry.Spec.NodeName)
+	assert.Equal(t, pod.Labels, retry.Labels)
+	assert.Equal(t, 1, kube.GetBuildRetries(retry))
+
+	again, err := kube.RetryPreemptedBuildPod(client, pod, 2)
+	require.NoError(t, err)
+	assert.Nil(t, again, "the pod was already retried")
+
+	retry.Status = pod.Status
+	retry2, err := kube.RetryPreemptedBuildPod(client, retry, 2)
+	require.NoError(t, err)
+	require.NotNil(t, retry2)
+	assert.Equal(t, "myorg-myrepo-master-1-build-retry-2", retry2.Name)
+
+	retry2.Status = pod.Status
+	retry3, err := kube.RetryPreemptedBuildPod(client, retry2, 2)
+	require.NoError(t, err)
+	assert.Nil(t, retry3, "the maximum number of retries was reached")
+
+	failed := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}}
+	assert.False(t, kube.IsPodPreempted(failed))
+}
@@ -0,0 +1,203 @@
+package kube
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PVCUsage the disk usage of a PersistentVolumeClaim as reported by the kubelet
+type PVCUsage struct {
+	UsedBytes     int64
+	CapacityBytes int64
+}
+
+// kubeletStatsSummary the subset of the kubelet stats summary we use to find the usage of volumes
+type kubeletStatsSummary struct {
+	Pods []struct {
+		Volume []struct {
+			UsedBytes     *int64 `json:"usedBytes"`
+			CapacityBytes *int64 `json:"capacityBytes"`
+			PVCRef        *struct {
+				Name      string `json:"name"`
+				Namespace string `json:"namespace"`
+			} `json:"pvcRef"`
+		} `json:"volume"`
+	} `json:"pods"`
+}
+
+// IsDefaultStorageClass returns true if the storage class is the default storage class of the cluster
+func IsDefaultStorageClass(sc *storagev1.StorageClass) bool {
+	ann := sc.Annotations
+	return ann != nil && (ann[AnnotationIsDefaultStorageClass] == "true" || ann["storageclass.beta.kubernetes.io/is-default-class"] == "true")
+}
+
+// StorageClassAllowsExpansion returns true if the PersistentVolumeClaims of the storage class can be resized
+func StorageClassAllowsExpansion(sc *storagev1.StorageClass) bool {
+	return sc.AllowVolumeExpansion != nil && *sc.AllowVolumeExpansion
+}
+
+// GetDefaultStorageClass returns the default storage class of the cluster or nil if there is none
+func GetDefaultStorageClass(client kubernetes.Interface) (*storagev1.StorageClass, error) {
+	list, err := client.StorageV1().StorageClasses().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range list.Items {
+		if IsDefaultStorageClass(&list.Items[i]) {
+			return &list.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// GetStorageClassNames returns the sorted names of the storage classes of the cluster
+func GetStorageClassNames(client kubernetes.Interface) ([]string, error) {
+	names := []string{}
+	list, err := client.StorageV1().StorageClasses().List(metav1.ListOptions{})
+	if err != nil {
+		return names, err
+	}
+	for _, sc := range list.Items {
+		names = append(names, sc.Name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// ValidateStorageClass returns an error if the storage class does not exist in the cluster
+func ValidateStorageClass(client kubernetes.Interface, name string) error {
+	names, err := GetStorageClassNames(client)
+	if err != nil {
+		return err
+	}
+	for _, n := range names {
+		if n == name {
+			return nil
+		}
+	}
+	return fmt.Errorf("no storage class called %s exists. Available storage classes: %s", name, strings.Join(names, ", "))
+}
+
+// PVCStorageClassName returns the name of the storage class of the PersistentVolumeClaim taking into account
+// the default storage class when the claim does not specify one
+func PVCStorageClassName(client kubernetes.Interface, pvc *v1.PersistentVolumeClaim) (string, error) {
+	if pvc.Spec.StorageClassName != nil && *pvc.Spec.StorageClassName != "" {
+		return *pvc.Spec.StorageClassName, nil
+	}
+	if pvc.Annotations != nil && pvc.Annotations["volume.beta.kubernetes.io/storage-class"] != "" {
+		return pvc.Annotations["volume.beta.kubernetes.io/storage-class"], nil
+	}
+	sc, err := GetDefaultStorageClass(client)
+	if err != nil || sc == nil {
+		return "", err
+	}
+	return sc.Name, nil
+}
+
+// FindPersistentVolumeClaim finds a PersistentVolumeClaim by name or by the app label of a platform component
+// such as jenkins, nexus or chartmuseum
+func FindPersistentVolumeClaim(client kubernetes.Interface, ns string, name string) (*v1.PersistentVolumeClaim, error) {
+	pvcs := client.CoreV1().PersistentVolumeClaims(ns)
+	pvc, err := pvcs.Get(name, metav1.GetOptions{})
+	if err == nil {
+		return pvc, nil
+	}
+	list, err := pvcs.List(metav1.ListOptions{LabelSelector: "app=" + name})
+	if err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 1 {
+		return &list.Items[0], nil
+	}
+	if len(list.Items) > 1 {
+		names := []string{}
+		for _, item := range list.Items {
+			names = append(names, item.Name)
+		}
+		return nil, fmt.Errorf("found multiple PersistentVolumeClaims for app %s in namespace %s: %s", name, ns, strings.Join(names, ", "))
+	}
+	return nil, fmt.Errorf("no PersistentVolumeClaim called %s found in namespace %s", name, ns)
+}
+
+// ResizePersistentVolumeClaim increases the requested storage of the PersistentVolumeClaim if its storage class
+// supports volume expansion
+func ResizePersistentVolumeClaim(client kubernetes.Interface, pvc *v1.PersistentVolumeClaim, size resource.Quantity) (*v1.PersistentVolumeClaim, error) {
+	scName, err := PVCStorageClassName(client, pvc)
+	if err != nil {
+		return nil, err
+	}
+	if scName == "" {
+		return nil, fmt.Errorf("the PersistentVolumeClaim %s has no storage class so cannot be resized", pvc.Name)
+	}
+	sc, err := client.StorageV1().StorageClasses().Get(scName, metav1.GetOptions{})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find storage class %s", scName)
+	}
+	if !StorageClassAllowsExpansion(sc) {
+		return nil, fmt.Errorf("the storage class %s of PersistentVolumeClaim %s does not allow volume expansion", scName, pvc.Name)
+	}
+	current := pvc.Spec.Resources.Requests[v1.ResourceStorage]
+	if size.Cmp(current) <= 0 {
+		return nil, fmt.Errorf("the new size %s of PersistentVolumeClaim %s must be larger than its current size %s", size.String(), pvc.Name, current.String())
+	}
+	if pvc.Spec.Resources.Requests == nil {
+		pvc.Spec.Resources.Requests = v1.ResourceList{}
+	}
+	pvc.Spec.Resources.Requests[v1.ResourceStorage] = size
+	return client.CoreV1().PersistentVolumeClaims(pvc.Namespace).Update(pvc)
+}
+
+// GetPVCUsage returns the disk usage of the PersistentVolumeClaims in the namespace indexed by claim name using
+// the stats summary of the kubelets running pods which mount them. Claims which are not mounted are not included
+func GetPVCUsage(client kubernetes.Interface, ns string) (map[string]PVCUsage, error) {
+	answer := map[string]PVCUsage{}
+	pods, err := client.CoreV1().Pods(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	nodes := map[string]bool{}
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName == "" {
+			continue
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.PersistentVolumeClaim != nil {
+				nodes[pod.Spec.NodeName] = true
+			}
+		}
+	}
+	for node := range nodes {
+		data, err := client.CoreV1().RESTClient().Get().Resource("nodes").Name(node).SubResource("proxy").Suffix("stats/summary").DoRaw()
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to get the stats summary of node %s", node)
+		}
+		summary := kubeletStatsSummary{}
+		err = json.Unmarshal(data, &summary)
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to unmarshal the stats summary of node %s", node)
+		}
+		for _, pod := range summary.Pods {
+			for _, volume := range pod.Volume {
+				ref := volume.PVCRef
+				if ref == nil || ref.Namespace != ns || volume.UsedBytes == nil {
+					continue
+				}
+				usage := PVCUsage{UsedBytes: *volume.UsedBytes}
+				if volume.CapacityBytes != nil {
+					usage.CapacityBytes = *volume.CapacityBytes
+				}
+				answer[ref.Name] = usage
+			}
+		}
+	}
+	return answer, nil
+}
@@ -0,0 +1,93 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestStorageClasses(t *testing.T) {
+	t.Parallel()
+	expandable := true
+	client := fake.NewSimpleClientset(
+		&storagev1.StorageClass{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "standard",
+				Annotations: map[string]string{kube.AnnotationIsDefaultStorageClass: "true"},
+			},
+		},
+		&storagev1.StorageClass{
+			ObjectMeta:           metav1.ObjectMeta{Name: "ssd"},
+			AllowVolumeExpansion: &expandable,
+		},
+	)
+
+	sc, err := kube.GetDefaultStorageClass(client)
+	require.NoError(t, err)
+	require.NotNil(t, sc)
+	assert.Equal(t, "standard", sc.Name)
+	assert.False(t, kube.StorageClassAllowsExpansion(sc))
+
+	assert.NoError(t, kube.ValidateStorageClass(client, "ssd"))
+	assert.Error(t, kube.ValidateStorageClass(client, "missing"))
+
+	pvc := &v1.PersistentVolumeClaim{}
+	name, err := kube.PVCStorageClassName(client, pvc)
+	require.NoError(t, err)
+	assert.Equal(t, "standard", name)
+}
+
+func TestResizePersistentVolumeClaim(t *testing.T) {
+	t.Parallel()
+	expandable := true
+	ssd := "ssd"
+	standard := "standard"
+	ns := "jx"
+	newPVC := func(name string, app string, storageClass *string) *v1.PersistentVolumeClaim {
+		return &v1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				Labels:    map[string]string{"app": app},
+			},
+			Spec: v1.PersistentVolumeClaimSpec{
+				StorageClassName: storageClass,
+				Resources: v1.ResourceRequirements{
+					Requests: v1.ResourceList{v1.ResourceStorage: resource.MustParse("10Gi")},
+				},
+			},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: standard}},
+		&storagev1.StorageClass{ObjectMeta: metav1.ObjectMeta{Name: ssd}, AllowVolumeExpansion: &expandable},
+		newPVC("jenkins", "jenkins", &ssd),
+		newPVC("jenkins-x-nexus", "nexus", &standard),
+	)
+
+	pvc, err := kube.FindPersistentVolumeClaim(client, ns, "jenkins")
+	require.NoError(t, err)
+	_, err = kube.ResizePersistentVolumeClaim(client, pvc, resource.MustParse("5Gi"))
+	assert.Error(t, err, "volumes cannot be shrunk")
+	updated, err := kube.ResizePersistentVolumeClaim(client, pvc, resource.MustParse("50Gi"))
+	require.NoError(t, err)
+	size := updated.Spec.Resources.Requests[v1.ResourceStorage]
+	assert.Equal(t, "50Gi", size.String())
+
+	// the nexus claim is found via its app label
+	pvc, err = kube.FindPersistentVolumeClaim(client, ns, "nexus")
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-x-nexus", pvc.Name)
+	_, err = kube.ResizePersistentVolumeClaim(client, pvc, resource.MustParse("50Gi"))
+	assert.Error(t, err, "the storage class does not allow expansion")
+
+	_, err = kube.FindPersistentVolumeClaim(client, ns, "chartmuseum")
+	assert.Error(t, err)
+}
@@ -0,0 +1,143 @@
+package kube
+
+import (
+	"reflect"
+
+	"github.com/pkg/errors"
+	rbacv1 "k8s.io/api/rbac/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// TeamRoleAdmin the team role which can manage everything in the team including its members
+	TeamRoleAdmin = "admin"
+
+	// TeamRoleDeveloper the team role which can create, build and promote applications
+	TeamRoleDeveloper = "developer"
+
+	// TeamRoleViewer the team role which can only view the team resources
+	TeamRoleViewer = "viewer"
+
+	// AnnotationDefaultTeamRole the annotation marking the default team Roles created by jx which are kept up to date.
+	// Roles without it have been created or customized by the team admins and are never modified
+	AnnotationDefaultTeamRole = "jenkins.io/default-team-role"
+)
+
+// DefaultTeamRoleNames the names of the default team roles ordered from the most to the least privileged
+var DefaultTeamRoleNames = []string{TeamRoleAdmin, TeamRoleDeveloper, TeamRoleViewer}
+
+var (
+	readVerbs  = []string{"get", "list", "watch"}
+	writeVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete"}
+)
+
+// DefaultTeamRoles returns the default Roles for a team in the given dev namespace
+func DefaultTeamRoles(ns string) []*rbacv1.Role {
+	return []*rbacv1.Role{
+		createTeamRole(ns, TeamRoleAdmin, []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"*"},
+				Resources: []string{"*"},
+				Verbs:     []string{"*"},
+			},
+		}),
+		createTeamRole(ns, TeamRoleDeveloper, []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{""},
+				Resources: []string{"configmaps", "pods", "pods/log", "pods/portforward", "services", "persistentvolumeclaims", "events"},
+				Verbs:     writeVerbs,
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"pods/exec"},
+				Verbs:     []string{"create"},
+			},
+			{
+				APIGroups: []string{"apps", "extensions"},
+				Resources: []string{"deployments", "replicasets", "ingresses"},
+				Verbs:     writeVerbs,
+			},
+			{
+				APIGroups: []string{"batch"},
+				Resources: []string{"jobs"},
+				Verbs:     writeVerbs,
+			},
+			{
+				APIGroups: []string{"jenkins.io"},
+				Resources: []string{"*"},
+				Verbs:     writeVerbs,
+			},
+			{
+				APIGroups: []string{""},
+				Resources: []string{"secrets"},
+				Verbs:     readVerbs,
+			},
+		}),
+		createTeamRole(ns, TeamRoleViewer, []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"", "apps", "extensions", "batch"},
+				Resources: []string{"configmaps", "pods", "pods/log", "services", "persistentvolumeclaims", "events", "deployments", "replicasets", "ingresses", "jobs"},
+				Verbs:     readVerbs,
+			},
+			{
+				APIGroups: []string{"jenkins.io"},
+				Resources: []string{"*"},
+				Verbs:     readVerbs,
+			},
+		}),
+	}
+}
+
+func createTeamRole(ns string, name string, rules []rbacv1.PolicyRule) *rbacv1.Role {
+	return &rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ns,
+			Labels: map[string]string{
+				LabelKind: ValueKindEnvironmentRole,
+			},
+			Annotations: map[string]string{
+				AnnotationDefaultTeamRole: "true",
+			},
+		},
+		Rules: rules,
+	}
+}
+
+// EnsureDefaultTeamRoles lazily creates the default team Roles in the given dev namespace so that they can be
+// associated with users via EnvironmentRoleBindings. Roles created by jx are updated to the current defaults whereas
+// existing Roles of the same name which were created or customized by the team admins are left untouched
+func EnsureDefaultTeamRoles(kubeClient kubernetes.Interface, ns string) error {
+	roleInterface := kubeClient.RbacV1().Roles(ns)
+	for _, role := range DefaultTeamRoles(ns) {
+		current, err := roleInterface.Get(role.Name, metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return errors.Wrapf(err, "failed to get Role %s in namespace %s", role.Name, ns)
+			}
+			_, err = roleInterface.Create(role)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create Role %s in namespace %s", role.Name, ns)
+			}
+			continue
+		}
+		if current.Annotations[AnnotationDefaultTeamRole] != "true" {
+			continue
+		}
+		if current.Labels[LabelKind] == ValueKindEnvironmentRole && reflect.DeepEqual(current.Rules, role.Rules) {
+			continue
+		}
+		if current.Labels == nil {
+			current.Labels = map[string]string{}
+		}
+		current.Labels[LabelKind] = ValueKindEnvironmentRole
+		current.Rules = role.Rules
+		_, err = roleInterface.Update(current)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update Role %s in namespace %s", role.Name, ns)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureDefaultTeamRoles(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	customRules := []rbacv1.PolicyRule{{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get"}}}
+	kubeClient := fake.NewSimpleClientset(&rbacv1.Role{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kube.TeamRoleViewer,
+			Namespace: ns,
+			Labels:    map[string]string{kube.LabelKind: kube.ValueKindEnvironmentRole},
+		},
+		Rules: customRules,
+	})
+
+	err := kube.EnsureDefaultTeamRoles(kubeClient, ns)
+	require.NoError(t, err)
+
+	_, names, err := kube.GetTeamRoles(kubeClient, ns)
+	require.NoError(t, err)
+	assert.Equal(t, []string{kube.TeamRoleAdmin, kube.TeamRoleDeveloper, kube.TeamRoleViewer}, names)
+
+	viewer, err := kubeClient.RbacV1().Roles(ns).Get(kube.TeamRoleViewer, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, customRules, viewer.Rules, "the viewer role customized by the team admins should not be modified")
+
+	developer, err := kubeClient.RbacV1().Roles(ns).Get(kube.TeamRoleDeveloper, metav1.GetOptions{})
+	require.NoError(t, err)
+	developer.Rules = customRules
+	_, err = kubeClient.RbacV1().Roles(ns).Update(developer)
+	require.NoError(t, err)
+	err = kube.EnsureDefaultTeamRoles(kubeClient, ns)
+	require.NoError(t, err)
+	developer, err = kubeClient.RbacV1().Roles(ns).Get(kube.TeamRoleDeveloper, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEqual(t, customRules, developer.Rules, "the default developer role created by jx should be updated")
+
+	err = kube.EnsureDefaultTeamRoles(kubeClient, ns)
+	assert.NoError(t, err, "ensuring the roles again should be a no-op")
+}
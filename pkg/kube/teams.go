@@ -1,15 +1,26 @@
 package kube
 
 import (
+	"reflect"
 	"sort"
 	"strings"
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/client/clientset/versioned"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	networkingv1 "k8s.io/api/networking/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
+const (
+	// NetworkPolicyTeamIsolation the name of the NetworkPolicy which stops the pods of other teams connecting to the
+	// pods in the namespaces of a team
+	NetworkPolicyTeamIsolation = "jx-team-isolation"
+)
+
 // GetAdminNamespace tries to find the namespace which is annotated as the global admin namespace for the cluster
 // or returns the current namespace
 func GetAdminNamespace(kubeClient kubernetes.Interface, ns string) (string, error) {
@@ -64,3 +75,140 @@ func DeleteTeam(jxClient versioned.Interface, ns string, teamName string) error
 	}
 	return err
 }
+
+// TeamDomain returns the ingress domain of the team which defaults to a sub domain of the cluster domain
+func TeamDomain(team *v1.Team, clusterDomain string) string {
+	if team.Spec.Domain != "" {
+		return team.Spec.Domain
+	}
+	if clusterDomain == "" {
+		return ""
+	}
+	return team.Name + "." + clusterDomain
+}
+
+// TeamDockerRegistryOrg returns the docker registry organisation of the team which defaults to the team name
+func TeamDockerRegistryOrg(team *v1.Team) string {
+	if team.Spec.DockerRegistryOrg != "" {
+		return team.Spec.DockerRegistryOrg
+	}
+	return team.Name
+}
+
+// GetTeamNamespaces returns the sorted names of the namespaces of the team, i.e. its development namespace and the
+// namespaces of its environments
+func GetTeamNamespaces(kubeClient kubernetes.Interface, teamName string) ([]string, error) {
+	names := []string{}
+	list, err := kubeClient.CoreV1().Namespaces().List(metav1.ListOptions{
+		LabelSelector: LabelTeam + "=" + teamName,
+	})
+	if err != nil {
+		return names, err
+	}
+	for _, ns := range list.Items {
+		names = append(names, ns.Name)
+	}
+	if util.StringArrayIndex(names, teamName) < 0 {
+		names = append(names, teamName)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// EnsureTeamIsolation creates a NetworkPolicy in each namespace of the team so that the pods of the team can only
+// be reached from the namespaces of the same team or from namespaces which do not belong to a team, such as the
+// namespace of the ingress controller
+func EnsureTeamIsolation(kubeClient kubernetes.Interface, teamName string) error {
+	namespaces, err := GetTeamNamespaces(kubeClient, teamName)
+	if err != nil {
+		return err
+	}
+	for _, ns := range namespaces {
+		policy := TeamIsolationNetworkPolicy(ns, teamName)
+		policies := kubeClient.NetworkingV1().NetworkPolicies(ns)
+		current, err := policies.Get(policy.Name, metav1.GetOptions{})
+		if err != nil {
+			_, err = policies.Create(policy)
+			if err != nil {
+				return errors.Wrapf(err, "failed to create NetworkPolicy %s in namespace %s", policy.Name, ns)
+			}
+			continue
+		}
+		if reflect.DeepEqual(current.Spec, policy.Spec) {
+			continue
+		}
+		current.Spec = policy.Spec
+		_, err = policies.Update(current)
+		if err != nil {
+			return errors.Wrapf(err, "failed to update NetworkPolicy %s in namespace %s", policy.Name, ns)
+		}
+	}
+	return nil
+}
+
+// TeamIsolationNetworkPolicy returns the NetworkPolicy which isolates the namespace of the team from other teams
+func TeamIsolationNetworkPolicy(ns string, teamName string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyTeamIsolation,
+			Namespace: ns,
+			Labels: map[string]string{
+				LabelTeam: teamName,
+			},
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									LabelTeam: teamName,
+								},
+							},
+						},
+						{
+							NamespaceSelector: &metav1.LabelSelector{
+								MatchExpressions: []metav1.LabelSelectorRequirement{
+									{
+										Key:      LabelTeam,
+										Operator: metav1.LabelSelectorOpDoesNotExist,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		},
+	}
+}
+
+// EnsureTeamMembers makes sure the default team Roles exist in the development namespace of the team and that each
+// member without a role is bound to the developer role in all the environments of the team
+func EnsureTeamMembers(kubeClient kubernetes.Interface, jxClient versioned.Interface, ns string, members []string) error {
+	err := EnsureDefaultTeamRoles(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	roles, _, err := GetTeamRoles(kubeClient, ns)
+	if err != nil {
+		return err
+	}
+	for _, member := range members {
+		current, err := GetUserRoles(jxClient, ns, rbacv1.UserKind, member)
+		if err != nil {
+			return err
+		}
+		if len(current) > 0 {
+			continue
+		}
+		err = UpdateUserRoles(kubeClient, jxClient, ns, rbacv1.UserKind, member, []string{TeamRoleDeveloper}, roles)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
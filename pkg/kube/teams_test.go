@@ -0,0 +1,90 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	jxfake "github.com/jenkins-x/jx/pkg/client/clientset/versioned/fake"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestTeamDomainAndDockerRegistryOrg(t *testing.T) {
+	t.Parallel()
+	team := kube.CreateTeam("jx", "cheese", nil)
+	assert.Equal(t, "cheese.acme.com", kube.TeamDomain(team, "acme.com"))
+	assert.Equal(t, "", kube.TeamDomain(team, ""))
+	assert.Equal(t, "cheese", kube.TeamDockerRegistryOrg(team))
+
+	team.Spec.Domain = "cheese.io"
+	team.Spec.DockerRegistryOrg = "acme-cheese"
+	assert.Equal(t, "cheese.io", kube.TeamDomain(team, "acme.com"))
+	assert.Equal(t, "acme-cheese", kube.TeamDockerRegistryOrg(team))
+}
+
+func TestEnsureTeamIsolation(t *testing.T) {
+	t.Parallel()
+	teamNamespace := func(name string, team string) *corev1.Namespace {
+		return &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{kube.LabelTeam: team},
+			},
+		}
+	}
+	client := fake.NewSimpleClientset(
+		teamNamespace("cheese", "cheese"),
+		teamNamespace("cheese-staging", "cheese"),
+		teamNamespace("wine", "wine"),
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "kube-system"}},
+	)
+
+	namespaces, err := kube.GetTeamNamespaces(client, "cheese")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cheese", "cheese-staging"}, namespaces)
+
+	err = kube.EnsureTeamIsolation(client, "cheese")
+	require.NoError(t, err)
+	err = kube.EnsureTeamIsolation(client, "cheese")
+	require.NoError(t, err)
+
+	for _, ns := range namespaces {
+		policy, err := client.NetworkingV1().NetworkPolicies(ns).Get(kube.NetworkPolicyTeamIsolation, metav1.GetOptions{})
+		require.NoError(t, err, "no NetworkPolicy in namespace %s", ns)
+		require.Len(t, policy.Spec.Ingress, 1)
+		peers := policy.Spec.Ingress[0].From
+		require.Len(t, peers, 2)
+		assert.Equal(t, "cheese", peers[0].NamespaceSelector.MatchLabels[kube.LabelTeam])
+		assert.Equal(t, metav1.LabelSelectorOpDoesNotExist, peers[1].NamespaceSelector.MatchExpressions[0].Operator)
+	}
+	_, err = client.NetworkingV1().NetworkPolicies("wine").Get(kube.NetworkPolicyTeamIsolation, metav1.GetOptions{})
+	assert.Error(t, err)
+}
+
+func TestEnsureTeamMembers(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	jxClient := jxfake.NewSimpleClientset(&v1.EnvironmentRoleBinding{
+		ObjectMeta: metav1.ObjectMeta{Name: kube.TeamRoleAdmin, Namespace: "cheese"},
+		Spec: v1.EnvironmentRoleBindingSpec{
+			RoleRef:  rbacv1.RoleRef{Kind: "Role", Name: kube.TeamRoleAdmin},
+			Subjects: []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice", Namespace: "cheese"}},
+		},
+	})
+
+	err := kube.EnsureTeamMembers(client, jxClient, "cheese", []string{"alice", "bob"})
+	require.NoError(t, err)
+
+	roles, err := kube.GetUserRoles(jxClient, "cheese", rbacv1.UserKind, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, []string{kube.TeamRoleAdmin}, roles)
+
+	roles, err = kube.GetUserRoles(jxClient, "cheese", rbacv1.UserKind, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, []string{kube.TeamRoleDeveloper}, roles)
+}
@@ -0,0 +1,121 @@
+package kube
+
+import (
+	"fmt"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ClusterTeardownName the name of the CronJob and Secret which tear down an expiring cluster
+	ClusterTeardownName = "jx-cluster-teardown"
+	// AnnotationClusterExpires the time in RFC3339 format after which the cluster is torn down
+	AnnotationClusterExpires = "jenkins.io/cluster-expires"
+	// DefaultClusterTeardownSchedule how often the teardown job checks if the cluster has expired
+	DefaultClusterTeardownSchedule = "*/10 * * * *"
+)
+
+// ClusterTeardown describes how to delete a cluster and its cloud resources once it has expired
+type ClusterTeardown struct {
+	// Image the container image containing the cloud provider CLI
+	Image string
+	// Command the shell command which deletes the cluster
+	Command string
+	// Expires the time after which the cluster is deleted
+	Expires time.Time
+	// Schedule the cron schedule of checking if the cluster has expired
+	Schedule string
+	// Secrets the environment variables such as cloud credentials which are stored in a Secret
+	Secrets map[string]string
+}
+
+// TeardownScript returns the shell script which runs the command only once the expiry time has passed
+func (t *ClusterTeardown) TeardownScript() string {
+	return fmt.Sprintf(`if [ "$(date +%%s)" -lt "%d" ]; then
+  echo "the cluster expires at %s"
+  exit 0
+fi
+echo "the cluster expired at %s so deleting it"
+%s
+`, t.Expires.Unix(), t.Expires.UTC().Format(time.RFC3339), t.Expires.UTC().Format(time.RFC3339), t.Command)
+}
+
+// CreateClusterTeardown creates or updates the CronJob in the namespace which periodically checks if the cluster
+// has expired and if so deletes it
+func CreateClusterTeardown(client kubernetes.Interface, ns string, teardown *ClusterTeardown) (*batchv1beta1.CronJob, error) {
+	labels := map[string]string{LabelCreatedBy: ValueCreatedByJX}
+	annotations := map[string]string{AnnotationClusterExpires: teardown.Expires.UTC().Format(time.RFC3339)}
+	container := corev1.Container{
+		Name:    "teardown",
+		Image:   teardown.Image,
+		Command: []string{"/bin/sh", "-c", teardown.TeardownScript()},
+	}
+	if len(teardown.Secrets) > 0 {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: ClusterTeardownName, Labels: labels},
+			StringData: teardown.Secrets,
+		}
+		err := RetryOnAPIError(func() error {
+			_, err := client.CoreV1().Secrets(ns).Create(secret)
+			if kerrors.IsAlreadyExists(err) {
+				_, err = client.CoreV1().Secrets(ns).Update(secret)
+			}
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to save Secret %s in namespace %s: %s", ClusterTeardownName, ns, err)
+		}
+		container.EnvFrom = []corev1.EnvFromSource{
+			{SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: ClusterTeardownName}}},
+		}
+	}
+	schedule := teardown.Schedule
+	if schedule == "" {
+		schedule = DefaultClusterTeardownSchedule
+	}
+	backoffLimit := int32(0)
+	cronJob := &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{Name: ClusterTeardownName, Labels: labels, Annotations: annotations},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:          schedule,
+			ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				Spec: batchv1.JobSpec{
+					BackoffLimit: &backoffLimit,
+					Template: corev1.PodTemplateSpec{
+						ObjectMeta: metav1.ObjectMeta{Labels: labels},
+						Spec: corev1.PodSpec{
+							RestartPolicy: corev1.RestartPolicyNever,
+							Containers:    []corev1.Container{container},
+						},
+					},
+				},
+			},
+		},
+	}
+	var answer *batchv1beta1.CronJob
+	err := RetryOnAPIError(func() error {
+		cronJobs := client.BatchV1beta1().CronJobs(ns)
+		existing, err := cronJobs.Get(ClusterTeardownName, metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return err
+			}
+			answer, err = cronJobs.Create(cronJob)
+			return err
+		}
+		cronJob.ResourceVersion = existing.ResourceVersion
+		answer, err = cronJobs.Update(cronJob)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save CronJob %s in namespace %s: %s", ClusterTeardownName, ns, err)
+	}
+	return answer, nil
+}
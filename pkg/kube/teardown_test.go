@@ -0,0 +1,41 @@
+package kube
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestCreateClusterTeardown(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	expires := time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)
+	teardown := &ClusterTeardown{
+		Image:   "google/cloud-sdk:slim",
+		Command: "gcloud container clusters delete demo --quiet",
+		Expires: expires,
+		Secrets: map[string]string{"TOKEN": "secret"},
+	}
+	cronJob, err := CreateClusterTeardown(client, "kube-system", teardown)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultClusterTeardownSchedule, cronJob.Spec.Schedule)
+	assert.Equal(t, "2018-10-01T12:00:00Z", cronJob.Annotations[AnnotationClusterExpires])
+
+	container := cronJob.Spec.JobTemplate.Spec.Template.Spec.Containers[0]
+	assert.Contains(t, container.Command[2], `-lt "1538395200"`)
+	assert.Contains(t, container.Command[2], teardown.Command)
+	require.Len(t, container.EnvFrom, 1)
+	assert.Equal(t, ClusterTeardownName, container.EnvFrom[0].SecretRef.Name)
+
+	_, err = client.CoreV1().Secrets("kube-system").Get(ClusterTeardownName, metav1.GetOptions{})
+	require.NoError(t, err)
+
+	teardown.Expires = expires.Add(time.Hour)
+	cronJob, err = CreateClusterTeardown(client, "kube-system", teardown)
+	require.NoError(t, err)
+	assert.Equal(t, "2018-10-01T13:00:00Z", cronJob.Annotations[AnnotationClusterExpires])
+}
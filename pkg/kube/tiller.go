@@ -0,0 +1,76 @@
+package kube
+
+import (
+	"fmt"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// TillerServiceAccount the name of the ServiceAccount tiller runs as
+	TillerServiceAccount = "tiller"
+	// TillerRoleName the name of the Role which allows a namespaced tiller to manage the resources of a namespace
+	TillerRoleName = "tiller-manager"
+	// TillerRoleBindingName the name of the RoleBinding which binds the tiller Role to the tiller ServiceAccount
+	TillerRoleBindingName = "tiller-binding"
+)
+
+// EnsureTillerRole makes sure the tiller running in the tiller namespace can manage the resources of the namespace
+// using a Role rather than a ClusterRole so that the tiller of a team can only change the namespaces of the team
+func EnsureTillerRole(client kubernetes.Interface, tillerNamespace string, ns string) error {
+	_, err := client.RbacV1().Roles(ns).Get(TillerRoleName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		role := &rbacv1.Role{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TillerRoleName,
+				Namespace: ns,
+			},
+			Rules: []rbacv1.PolicyRule{
+				{
+					APIGroups: []string{"", "extensions", "apps", "batch", "autoscaling", "rbac.authorization.k8s.io"},
+					Resources: []string{"*"},
+					Verbs:     []string{"*"},
+				},
+			},
+		}
+		_, err = client.RbacV1().Roles(ns).Create(role)
+		if err != nil {
+			return fmt.Errorf("failed to create Role %s in namespace %s: %s", TillerRoleName, ns, err)
+		}
+	}
+	_, err = client.RbacV1().RoleBindings(ns).Get(TillerRoleBindingName, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		roleBinding := &rbacv1.RoleBinding{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      TillerRoleBindingName,
+				Namespace: ns,
+			},
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      rbacv1.ServiceAccountKind,
+					Name:      TillerServiceAccount,
+					Namespace: tillerNamespace,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				Kind:     "Role",
+				Name:     TillerRoleName,
+				APIGroup: rbacv1.GroupName,
+			},
+		}
+		_, err = client.RbacV1().RoleBindings(ns).Create(roleBinding)
+		if err != nil {
+			return fmt.Errorf("failed to create RoleBinding %s in namespace %s: %s", TillerRoleBindingName, ns, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestEnsureTillerRole(t *testing.T) {
+	t.Parallel()
+	client := fake.NewSimpleClientset()
+	require.NoError(t, kube.EnsureTillerRole(client, "jx", "jx-staging"))
+	require.NoError(t, kube.EnsureTillerRole(client, "jx", "jx-staging"))
+
+	role, err := client.RbacV1().Roles("jx-staging").Get(kube.TillerRoleName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.NotEmpty(t, role.Rules)
+
+	binding, err := client.RbacV1().RoleBindings("jx-staging").Get(kube.TillerRoleBindingName, metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, binding.Subjects, 1)
+	assert.Equal(t, "jx", binding.Subjects[0].Namespace)
+	assert.Equal(t, kube.TillerServiceAccount, binding.Subjects[0].Name)
+	assert.Equal(t, kube.TillerRoleName, binding.RoleRef.Name)
+}
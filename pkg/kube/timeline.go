@@ -0,0 +1,263 @@
+package kube
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// TimelineKindPodStatus the kind of timeline entries created from the container statuses of pods
+	TimelineKindPodStatus = "PodStatus"
+
+	// TimelineKindHelmRelease the kind of timeline entries created from the helm release history
+	TimelineKindHelmRelease = "HelmRelease"
+
+	// DefaultTillerNamespace the default namespace in which tiller stores the helm releases
+	DefaultTillerNamespace = "kube-system"
+)
+
+// TimelineEntry a Kubernetes event, pod status or helm release of a troubleshooting timeline
+type TimelineEntry struct {
+	Time      time.Time
+	Namespace string
+	Kind      string
+	Name      string
+	// Type is either Normal or Warning
+	Type    string
+	Reason  string
+	Message string
+	Count   int32
+}
+
+// IsWarning returns true if the entry indicates a problem
+func (e *TimelineEntry) IsWarning() bool {
+	return e.Type == v1.EventTypeWarning
+}
+
+// TimelineQuery the application or build to create a timeline for
+type TimelineQuery struct {
+	// Namespaces the namespaces to search
+	Namespaces []string
+	// App the name of the application whose Deployments, Pods and helm releases are included
+	App string
+	// PodSelector an optional label selector of additional pods to include such as the pods of a build
+	PodSelector string
+	// TillerNamespace the namespace in which tiller stores the helm releases
+	TillerNamespace string
+	// Since only entries after this time are included if it is not zero
+	Since time.Time
+}
+
+// GetTimeline correlates the events, pod statuses and helm releases of the application or build of the query and
+// returns them in chronological order
+func GetTimeline(kubeClient kubernetes.Interface, query *TimelineQuery) ([]*TimelineEntry, error) {
+	answer := []*TimelineEntry{}
+	for _, ns := range query.Namespaces {
+		entries, err := namespaceTimeline(kubeClient, ns, query)
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, entries...)
+	}
+	if query.App != "" {
+		entries, err := helmReleaseTimeline(kubeClient, query)
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, entries...)
+	}
+
+	filtered := []*TimelineEntry{}
+	for _, e := range answer {
+		if query.Since.IsZero() || !e.Time.Before(query.Since) {
+			filtered = append(filtered, e)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return filtered[i].Time.Before(filtered[j].Time)
+	})
+	return filtered, nil
+}
+
+func namespaceTimeline(kubeClient kubernetes.Interface, ns string, query *TimelineQuery) ([]*TimelineEntry, error) {
+	answer := []*TimelineEntry{}
+
+	// the names of the objects whose events are included and the name prefixes of the ReplicaSets of deployments
+	names := map[string]bool{}
+	prefixes := []string{}
+	pods := map[string]*v1.Pod{}
+
+	if query.App != "" {
+		deployments, err := GetDeployments(kubeClient, ns)
+		if err != nil {
+			return answer, fmt.Errorf("Failed to load Deployments in namespace %s: %s", ns, err)
+		}
+		for name, d := range deployments {
+			if GetAppName(name, ns) != query.App {
+				continue
+			}
+			names[name] = true
+			prefixes = append(prefixes, name+"-")
+			if d.Spec.Selector != nil && len(d.Spec.Selector.MatchLabels) > 0 {
+				selector := labels.SelectorFromSet(d.Spec.Selector.MatchLabels).String()
+				_, m, err := GetPodsWithLabels(kubeClient, ns, selector)
+				if err != nil {
+					return answer, err
+				}
+				for k, v := range m {
+					pods[k] = v
+				}
+			}
+		}
+	}
+	if query.PodSelector != "" {
+		_, m, err := GetPodsWithLabels(kubeClient, ns, query.PodSelector)
+		if err != nil {
+			return answer, err
+		}
+		for k, v := range m {
+			pods[k] = v
+		}
+	}
+	if len(names) == 0 && len(pods) == 0 {
+		return answer, nil
+	}
+	for name, pod := range pods {
+		names[name] = true
+		answer = append(answer, podStatusTimeline(pod)...)
+	}
+
+	events, err := kubeClient.CoreV1().Events(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return answer, fmt.Errorf("Failed to load Events in namespace %s: %s", ns, err)
+	}
+	for _, event := range events.Items {
+		name := event.InvolvedObject.Name
+		if !names[name] && !hasAnyPrefix(name, prefixes) {
+			continue
+		}
+		t := event.LastTimestamp.Time
+		if t.IsZero() {
+			t = event.FirstTimestamp.Time
+		}
+		if t.IsZero() {
+			t = event.CreationTimestamp.Time
+		}
+		answer = append(answer, &TimelineEntry{
+			Time:      t,
+			Namespace: ns,
+			Kind:      event.InvolvedObject.Kind,
+			Name:      name,
+			Type:      event.Type,
+			Reason:    event.Reason,
+			Message:   strings.TrimSpace(event.Message),
+			Count:     event.Count,
+		})
+	}
+	return answer, nil
+}
+
+// podStatusTimeline returns the current state of the containers of the pod
+func podStatusTimeline(pod *v1.Pod) []*TimelineEntry {
+	answer := []*TimelineEntry{}
+	statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+	statuses = append(statuses, pod.Status.ContainerStatuses...)
+	for _, status := range statuses {
+		entry := &TimelineEntry{
+			Time:      pod.CreationTimestamp.Time,
+			Namespace: pod.Namespace,
+			Kind:      TimelineKindPodStatus,
+			Name:      pod.Name + "/" + status.Name,
+			Type:      v1.EventTypeNormal,
+			Count:     status.RestartCount,
+		}
+		state := status.State
+		switch {
+		case state.Waiting != nil:
+			entry.Type = v1.EventTypeWarning
+			entry.Reason = state.Waiting.Reason
+			entry.Message = state.Waiting.Message
+			if last := status.LastTerminationState.Terminated; last != nil {
+				entry.Time = last.FinishedAt.Time
+			}
+		case state.Terminated != nil:
+			entry.Time = state.Terminated.FinishedAt.Time
+			entry.Reason = state.Terminated.Reason
+			entry.Message = state.Terminated.Message
+			if state.Terminated.ExitCode != 0 {
+				entry.Type = v1.EventTypeWarning
+				entry.Message = strings.TrimSpace(fmt.Sprintf("exit code %d %s", state.Terminated.ExitCode, entry.Message))
+			}
+		case state.Running != nil:
+			entry.Time = state.Running.StartedAt.Time
+			entry.Reason = "Running"
+			if !status.Ready {
+				entry.Type = v1.EventTypeWarning
+				entry.Message = "container is not ready"
+			}
+		}
+		if entry.Time.IsZero() {
+			entry.Time = pod.CreationTimestamp.Time
+		}
+		answer = append(answer, entry)
+	}
+	return answer
+}
+
+// helmReleaseTimeline returns the revisions of the helm releases of the application which tiller stores in ConfigMaps
+func helmReleaseTimeline(kubeClient kubernetes.Interface, query *TimelineQuery) ([]*TimelineEntry, error) {
+	answer := []*TimelineEntry{}
+	tillerNs := query.TillerNamespace
+	if tillerNs == "" {
+		tillerNs = DefaultTillerNamespace
+	}
+	releaseNames := map[string]bool{query.App: true}
+	for _, ns := range query.Namespaces {
+		releaseNames[ns+"-"+query.App] = true
+	}
+	list, err := kubeClient.CoreV1().ConfigMaps(tillerNs).List(metav1.ListOptions{
+		LabelSelector: "OWNER=TILLER",
+	})
+	if err != nil {
+		// lets not fail if we cannot see the tiller namespace
+		return answer, nil
+	}
+	for _, cm := range list.Items {
+		l := cm.Labels
+		name := l["NAME"]
+		if !releaseNames[name] {
+			continue
+		}
+		status := l["STATUS"]
+		entryType := v1.EventTypeNormal
+		if status == "FAILED" {
+			entryType = v1.EventTypeWarning
+		}
+		answer = append(answer, &TimelineEntry{
+			Time:      cm.CreationTimestamp.Time,
+			Namespace: tillerNs,
+			Kind:      TimelineKindHelmRelease,
+			Name:      name,
+			Type:      entryType,
+			Reason:    status,
+			Message:   "revision " + l["VERSION"],
+		})
+	}
+	return answer, nil
+}
+
+func hasAnyPrefix(text string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(text, prefix) {
+			return true
+		}
+	}
+	return false
+}
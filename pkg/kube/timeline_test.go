@@ -0,0 +1,113 @@
+package kube_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestGetTimeline(t *testing.T) {
+	t.Parallel()
+	ns := "jx-staging"
+	start := time.Date(2018, 8, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) metav1.Time {
+		return metav1.NewTime(start.Add(time.Duration(minutes) * time.Minute))
+	}
+	appLabels := map[string]string{"app": "jx-staging-myapp"}
+
+	kubeClient := fake.NewSimpleClientset(
+		&v1beta1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "jx-staging-myapp", Namespace: ns},
+			Spec: v1beta1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: appLabels},
+			},
+		},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "jx-staging-myapp-5d8f-abcde",
+				Namespace:         ns,
+				Labels:            appLabels,
+				CreationTimestamp: at(1),
+			},
+			Status: corev1.PodStatus{
+				ContainerStatuses: []corev1.ContainerStatus{
+					{
+						Name:         "myapp",
+						RestartCount: 3,
+						State: corev1.ContainerState{
+							Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff"},
+						},
+						LastTerminationState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 1, FinishedAt: at(5)},
+						},
+					},
+				},
+			},
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e1", Namespace: ns},
+			InvolvedObject: corev1.ObjectReference{Kind: "ReplicaSet", Name: "jx-staging-myapp-5d8f"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "SuccessfulCreate",
+			LastTimestamp:  at(1),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e2", Namespace: ns},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "jx-staging-myapp-5d8f-abcde"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			LastTimestamp:  at(4),
+		},
+		&corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "e3", Namespace: ns},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "jx-staging-other-1234"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			LastTimestamp:  at(2),
+		},
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "jx-staging-myapp.v2",
+				Namespace:         kube.DefaultTillerNamespace,
+				CreationTimestamp: at(0),
+				Labels: map[string]string{
+					"OWNER":   "TILLER",
+					"NAME":    "jx-staging-myapp",
+					"STATUS":  "DEPLOYED",
+					"VERSION": "2",
+				},
+			},
+		},
+	)
+
+	entries, err := kube.GetTimeline(kubeClient, &kube.TimelineQuery{
+		Namespaces: []string{ns},
+		App:        "myapp",
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, kube.TimelineKindHelmRelease, entries[0].Kind)
+	assert.Equal(t, "revision 2", entries[0].Message)
+	assert.Equal(t, "SuccessfulCreate", entries[1].Reason)
+	assert.Equal(t, "BackOff", entries[2].Reason)
+	assert.True(t, entries[2].IsWarning())
+	assert.Equal(t, kube.TimelineKindPodStatus, entries[3].Kind)
+	assert.Equal(t, "CrashLoopBackOff", entries[3].Reason)
+	assert.Equal(t, int32(3), entries[3].Count)
+
+	entries, err = kube.GetTimeline(kubeClient, &kube.TimelineQuery{
+		Namespaces: []string{ns},
+		App:        "myapp",
+		Since:      start.Add(3 * time.Minute),
+	})
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
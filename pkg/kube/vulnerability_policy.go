@@ -0,0 +1,64 @@
+package kube
+
+import (
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+)
+
+const (
+	// AnnotationVulnerabilityOverride the annotation on an Environment listing the apps which may be promoted to it
+	// even if their images exceed the vulnerability thresholds of the team. The value is a comma separated list of
+	// app names or app:version pairs
+	AnnotationVulnerabilityOverride = "jenkins-x.io/vulnerability-override"
+
+	// DefaultVulnerabilityEnvironment the environment the vulnerability policy applies to by default
+	DefaultVulnerabilityEnvironment = "production"
+)
+
+// DefaultVulnerabilityThresholds the thresholds used if a vulnerability policy does not specify any
+var DefaultVulnerabilityThresholds = map[string]int{"CRITICAL": 0}
+
+// VulnerabilityPolicyApplies returns true if the vulnerability policy blocks promotions to the environment
+func VulnerabilityPolicyApplies(policy *v1.VulnerabilityPolicy, env *v1.Environment) bool {
+	if policy == nil || env == nil {
+		return false
+	}
+	envs := policy.Environments
+	if len(envs) == 0 {
+		envs = []string{DefaultVulnerabilityEnvironment}
+	}
+	for _, e := range envs {
+		if e == "*" || e == env.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// VulnerabilityThresholds returns the thresholds of the policy or the default thresholds
+func VulnerabilityThresholds(policy *v1.VulnerabilityPolicy) map[string]int {
+	if policy == nil || len(policy.Thresholds) == 0 {
+		return DefaultVulnerabilityThresholds
+	}
+	return policy.Thresholds
+}
+
+// IsVulnerabilityOverridden returns true if the environment has been annotated to allow the version of the app to be
+// promoted regardless of its vulnerabilities
+func IsVulnerabilityOverridden(env *v1.Environment, app string, version string) bool {
+	if env == nil || env.Annotations == nil {
+		return false
+	}
+	value := env.Annotations[AnnotationVulnerabilityOverride]
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if entry == app || (version != "" && entry == app+":"+version) {
+			return true
+		}
+	}
+	return false
+}
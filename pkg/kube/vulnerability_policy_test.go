@@ -0,0 +1,48 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestVulnerabilityPolicyApplies(t *testing.T) {
+	t.Parallel()
+	staging := &v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "staging"}}
+	production := &v1.Environment{ObjectMeta: metav1.ObjectMeta{Name: "production"}}
+
+	assert.False(t, kube.VulnerabilityPolicyApplies(nil, production))
+
+	policy := &v1.VulnerabilityPolicy{}
+	assert.True(t, kube.VulnerabilityPolicyApplies(policy, production))
+	assert.False(t, kube.VulnerabilityPolicyApplies(policy, staging))
+	assert.Equal(t, kube.DefaultVulnerabilityThresholds, kube.VulnerabilityThresholds(policy))
+
+	policy = &v1.VulnerabilityPolicy{Environments: []string{"staging"}, Thresholds: map[string]int{"HIGH": 3}}
+	assert.True(t, kube.VulnerabilityPolicyApplies(policy, staging))
+	assert.False(t, kube.VulnerabilityPolicyApplies(policy, production))
+	assert.Equal(t, map[string]int{"HIGH": 3}, kube.VulnerabilityThresholds(policy))
+
+	policy = &v1.VulnerabilityPolicy{Environments: []string{"*"}}
+	assert.True(t, kube.VulnerabilityPolicyApplies(policy, staging))
+}
+
+func TestIsVulnerabilityOverridden(t *testing.T) {
+	t.Parallel()
+	env := &v1.Environment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "production",
+			Annotations: map[string]string{
+				kube.AnnotationVulnerabilityOverride: "myapp:1.2.3, legacy",
+			},
+		},
+	}
+	assert.True(t, kube.IsVulnerabilityOverridden(env, "myapp", "1.2.3"))
+	assert.False(t, kube.IsVulnerabilityOverridden(env, "myapp", "1.2.4"))
+	assert.True(t, kube.IsVulnerabilityOverridden(env, "legacy", "0.0.1"))
+	assert.False(t, kube.IsVulnerabilityOverridden(env, "other", "1.2.3"))
+	assert.False(t, kube.IsVulnerabilityOverridden(&v1.Environment{}, "myapp", "1.2.3"))
+}
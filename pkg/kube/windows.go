@@ -0,0 +1,228 @@
+package kube
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// LabelOS the label of the operating system of a node
+	LabelOS = "kubernetes.io/os"
+	// LabelOSBeta the deprecated label of the operating system of a node used by older clusters
+	LabelOSBeta = "beta.kubernetes.io/os"
+
+	// OSLinux the linux operating system
+	OSLinux = "linux"
+	// OSWindows the windows operating system
+	OSWindows = "windows"
+
+	// WindowsPodTemplateName the name of the build pod template used to build Windows container images
+	WindowsPodTemplateName = "windows"
+
+	// DefaultWindowsBuilderImage the default image of the builder container of the Windows pod template
+	DefaultWindowsBuilderImage = "mcr.microsoft.com/windows/servercore:ltsc2019"
+
+	windowsDockerPipe = `\\.\pipe\docker_engine`
+)
+
+var (
+	// WindowsToleration the toleration of the taint commonly added to Windows nodes so that linux pods are not
+	// scheduled onto them
+	WindowsToleration = corev1.Toleration{
+		Key:      "os",
+		Operator: corev1.TolerationOpEqual,
+		Value:    OSWindows,
+		Effect:   corev1.TaintEffectNoSchedule,
+	}
+
+	windowsBaseImageRegex = regexp.MustCompile(`(?im)^\s*FROM\s+(\S*mcr\.microsoft\.com/(windows|dotnet/framework|powershell:.*nanoserver)\S*|\S*(servercore|nanoserver)\S*)`)
+)
+
+// GetNodeOS returns the operating system of the node defaulting to linux
+func GetNodeOS(node *corev1.Node) string {
+	for _, label := range []string{LabelOS, LabelOSBeta} {
+		if os := node.Labels[label]; os != "" {
+			return os
+		}
+	}
+	return OSLinux
+}
+
+// GetNodeOperatingSystems returns the number of nodes of each operating system in the cluster
+func GetNodeOperatingSystems(client kubernetes.Interface) (map[string]int, error) {
+	nodes, err := client.CoreV1().Nodes().List(metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	answer := map[string]int{}
+	for i := range nodes.Items {
+		answer[GetNodeOS(&nodes.Items[i])]++
+	}
+	return answer, nil
+}
+
+// IsMixedOSCluster returns true if the cluster has both linux and Windows nodes
+func IsMixedOSCluster(client kubernetes.Interface) (bool, error) {
+	counts, err := GetNodeOperatingSystems(client)
+	if err != nil {
+		return false, err
+	}
+	return counts[OSLinux] > 0 && counts[OSWindows] > 0, nil
+}
+
+// hasOSNodeSelector returns true if the node selector already constrains the operating system
+func hasOSNodeSelector(selector map[string]string) bool {
+	return selector[LabelOS] != "" || selector[LabelOSBeta] != ""
+}
+
+// EnsureLinuxNodeSelectors adds a linux node selector to the Deployments and StatefulSets in the namespace which do
+// not already select an operating system so that they are not scheduled onto Windows nodes. Returns the names of the
+// workloads which were updated
+func EnsureLinuxNodeSelectors(client kubernetes.Interface, ns string) ([]string, error) {
+	answer := []string{}
+	deployments, err := client.AppsV1beta1().Deployments(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for i := range deployments.Items {
+		d := &deployments.Items[i]
+		spec := &d.Spec.Template.Spec
+		if hasOSNodeSelector(spec.NodeSelector) {
+			continue
+		}
+		addLinuxNodeSelector(spec)
+		_, err = client.AppsV1beta1().Deployments(ns).Update(d)
+		if err != nil {
+			return answer, fmt.Errorf("failed to update Deployment %s in namespace %s: %s", d.Name, ns, err)
+		}
+		answer = append(answer, d.Name)
+	}
+	statefulSets, err := client.AppsV1beta1().StatefulSets(ns).List(metav1.ListOptions{})
+	if err != nil {
+		return answer, err
+	}
+	for i := range statefulSets.Items {
+		s := &statefulSets.Items[i]
+		spec := &s.Spec.Template.Spec
+		if hasOSNodeSelector(spec.NodeSelector) {
+			continue
+		}
+		addLinuxNodeSelector(spec)
+		_, err = client.AppsV1beta1().StatefulSets(ns).Update(s)
+		if err != nil {
+			return answer, fmt.Errorf("failed to update StatefulSet %s in namespace %s: %s", s.Name, ns, err)
+		}
+		answer = append(answer, s.Name)
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+// NewWindowsPodTemplate creates the build pod template used to build Windows container images using the docker
+// engine of the Windows node
+func NewWindowsPodTemplate() *corev1.Pod {
+	pipeType := corev1.HostPathUnset
+	return &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "v1",
+			Kind:       "Pod",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "jenkins-" + WindowsPodTemplateName,
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector: map[string]string{LabelOS: OSWindows},
+			Tolerations:  []corev1.Toleration{WindowsToleration},
+			Volumes: []corev1.Volume{
+				{
+					Name: "docker-engine",
+					VolumeSource: corev1.VolumeSource{
+						HostPath: &corev1.HostPathVolumeSource{Path: windowsDockerPipe, Type: &pipeType},
+					},
+				},
+			},
+			Containers: []corev1.Container{
+				{
+					Name:    WindowsPodTemplateName,
+					Image:   DefaultWindowsBuilderImage,
+					Command: []string{"powershell"},
+					Args:    []string{"-Command", "Start-Sleep -Seconds 2147483"},
+					TTY:     true,
+					VolumeMounts: []corev1.VolumeMount{
+						{Name: "docker-engine", MountPath: windowsDockerPipe},
+					},
+				},
+			},
+		},
+	}
+}
+
+// UpdatePodTemplatesForMixedOSCluster adds a linux node selector to the build pod templates which do not select an
+// operating system and adds the Windows pod template if it does not exist
+func UpdatePodTemplatesForMixedOSCluster(client kubernetes.Interface, ns string) error {
+	cm, err := client.CoreV1().ConfigMaps(ns).Get(ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to find ConfigMap %s in namespace %s: %s", ConfigMapJenkinsPodTemplates, ns, err)
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	for name, text := range cm.Data {
+		pod := &corev1.Pod{}
+		err = yaml.Unmarshal([]byte(text), pod)
+		if err != nil {
+			return fmt.Errorf("failed to parse pod template %s: %s", name, err)
+		}
+		if hasOSNodeSelector(pod.Spec.NodeSelector) {
+			continue
+		}
+		addLinuxNodeSelector(&pod.Spec)
+		data, err := yaml.Marshal(pod)
+		if err != nil {
+			return err
+		}
+		cm.Data[name] = string(data)
+	}
+	if cm.Data[WindowsPodTemplateName] == "" {
+		data, err := yaml.Marshal(NewWindowsPodTemplate())
+		if err != nil {
+			return err
+		}
+		cm.Data[WindowsPodTemplateName] = string(data)
+	}
+	_, err = client.CoreV1().ConfigMaps(ns).Update(cm)
+	return err
+}
+
+// IsWindowsDockerfile returns true if the Dockerfile builds a Windows container image
+func IsWindowsDockerfile(dockerfile string) bool {
+	return windowsBaseImageRegex.MatchString(dockerfile)
+}
+
+// IsInClusterDockerRegistry returns true if the docker registry is the registry running inside the cluster. Windows
+// nodes cannot pull from it as it is insecure and only reachable via its service IP
+func IsInClusterDockerRegistry(registry string) bool {
+	host := registry
+	if h, _, err := net.SplitHostPort(registry); err == nil {
+		host = h
+	}
+	if net.ParseIP(host) != nil {
+		return true
+	}
+	return strings.HasPrefix(host, "jenkins-x-docker-registry") || strings.HasSuffix(host, ".svc") || strings.Contains(host, ".svc.cluster.local")
+}
+
+func addLinuxNodeSelector(spec *corev1.PodSpec) {
+	if spec.NodeSelector == nil {
+		spec.NodeSelector = map[string]string{}
+	}
+	spec.NodeSelector[LabelOS] = OSLinux
+}
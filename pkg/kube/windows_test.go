@@ -0,0 +1,110 @@
+package kube_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1beta1 "k8s.io/api/apps/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newNode(name string, labels map[string]string) *corev1.Node {
+	return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+}
+
+func TestIsMixedOSCluster(t *testing.T) {
+	t.Parallel()
+	linux := newNode("linux", map[string]string{kube.LabelOS: kube.OSLinux})
+	unlabelled := newNode("unlabelled", nil)
+	windows := newNode("windows", map[string]string{kube.LabelOSBeta: kube.OSWindows})
+	assert.Equal(t, kube.OSLinux, kube.GetNodeOS(unlabelled))
+	assert.Equal(t, kube.OSWindows, kube.GetNodeOS(windows))
+
+	mixed, err := kube.IsMixedOSCluster(fake.NewSimpleClientset(linux, unlabelled))
+	require.NoError(t, err)
+	assert.False(t, mixed)
+
+	client := fake.NewSimpleClientset(linux, unlabelled, windows)
+	mixed, err = kube.IsMixedOSCluster(client)
+	require.NoError(t, err)
+	assert.True(t, mixed)
+	counts, err := kube.GetNodeOperatingSystems(client)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{kube.OSLinux: 2, kube.OSWindows: 1}, counts)
+}
+
+func TestEnsureLinuxNodeSelectors(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	deployment := &appsv1beta1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "chartmuseum", Namespace: ns}}
+	windows := &appsv1beta1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "windows-app", Namespace: ns}}
+	windows.Spec.Template.Spec.NodeSelector = map[string]string{kube.LabelOS: kube.OSWindows}
+	statefulSet := &appsv1beta1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: "mongodb", Namespace: ns}}
+	client := fake.NewSimpleClientset(deployment, windows, statefulSet)
+
+	names, err := kube.EnsureLinuxNodeSelectors(client, ns)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chartmuseum", "mongodb"}, names)
+
+	d, err := client.AppsV1beta1().Deployments(ns).Get("chartmuseum", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, kube.OSLinux, d.Spec.Template.Spec.NodeSelector[kube.LabelOS])
+	d, err = client.AppsV1beta1().Deployments(ns).Get("windows-app", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, kube.OSWindows, d.Spec.Template.Spec.NodeSelector[kube.LabelOS])
+
+	names, err = kube.EnsureLinuxNodeSelectors(client, ns)
+	require.NoError(t, err)
+	assert.Empty(t, names)
+}
+
+func TestUpdatePodTemplatesForMixedOSCluster(t *testing.T) {
+	t.Parallel()
+	ns := "jx"
+	maven, err := yaml.Marshal(&corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "jenkins-maven"},
+		Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "maven", Image: "jenkinsxio/builder-maven"}}},
+	})
+	require.NoError(t, err)
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: kube.ConfigMapJenkinsPodTemplates, Namespace: ns},
+		Data:       map[string]string{"maven": string(maven)},
+	}
+	client := fake.NewSimpleClientset(cm)
+	require.NoError(t, kube.UpdatePodTemplatesForMixedOSCluster(client, ns))
+
+	cm, err = client.CoreV1().ConfigMaps(ns).Get(kube.ConfigMapJenkinsPodTemplates, metav1.GetOptions{})
+	require.NoError(t, err)
+	pod := &corev1.Pod{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data["maven"]), pod))
+	assert.Equal(t, map[string]string{kube.LabelOS: kube.OSLinux}, pod.Spec.NodeSelector)
+
+	pod = &corev1.Pod{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[kube.WindowsPodTemplateName]), pod))
+	assert.Equal(t, map[string]string{kube.LabelOS: kube.OSWindows}, pod.Spec.NodeSelector)
+	assert.Equal(t, []corev1.Toleration{kube.WindowsToleration}, pod.Spec.Tolerations)
+	require.Len(t, pod.Spec.Containers, 1)
+	assert.Equal(t, kube.WindowsPodTemplateName, pod.Spec.Containers[0].Name)
+}
+
+func TestIsWindowsDockerfile(t *testing.T) {
+	t.Parallel()
+	assert.True(t, kube.IsWindowsDockerfile("FROM mcr.microsoft.com/dotnet/framework/aspnet:4.7.2-windowsservercore-ltsc2019\nCOPY . /inetpub/wwwroot\n"))
+	assert.True(t, kube.IsWindowsDockerfile("# escape=`\nFROM mcr.microsoft.com/windows/nanoserver:1809\n"))
+	assert.True(t, kube.IsWindowsDockerfile("from microsoft/aspnet:4.7-windowsservercore AS build\n"))
+	assert.False(t, kube.IsWindowsDockerfile("FROM openjdk:8-jdk-slim\nENV PORT 8080\n"))
+	assert.False(t, kube.IsWindowsDockerfile("FROM mcr.microsoft.com/dotnet/core/aspnet:2.2\n"))
+}
+
+func TestIsInClusterDockerRegistry(t *testing.T) {
+	t.Parallel()
+	assert.True(t, kube.IsInClusterDockerRegistry("10.59.247.14:5000"))
+	assert.True(t, kube.IsInClusterDockerRegistry("jenkins-x-docker-registry.jx.svc.cluster.local:5000"))
+	assert.False(t, kube.IsInClusterDockerRegistry("gcr.io"))
+	assert.False(t, kube.IsInClusterDockerRegistry("myorg.azurecr.io"))
+}
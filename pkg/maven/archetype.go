@@ -9,6 +9,7 @@ import (
 	"strings"
 
 	"fmt"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/util"
 	"gopkg.in/AlecAivazis/survey.v1"
 	"sort"
@@ -148,7 +149,7 @@ func (m *ArchetypeModel) AddArtifact(a *ArtifactData) *ArtifactVersions {
 
 func LoadArchetypes(name string, archetypeCatalogURL string, cacheDir string) (*ArchetypeModel, error) {
 	loader := func() ([]byte, error) {
-		client := http.Client{}
+		client := httpclient.NewBaseClient()
 		req, err := http.NewRequest(http.MethodGet, archetypeCatalogURL, nil)
 		if err != nil {
 			return nil, err
@@ -224,7 +225,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 			Message: "Group ID:",
 			Options: filteredGroups,
 		}
-		err := survey.AskOne(prompt, &form.ArchetypeGroupId, survey.Required)
+		err := util.AskOne(prompt, &form.ArchetypeGroupId, survey.Required)
 		if err != nil {
 			return err
 		}
@@ -258,7 +259,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 			Message: "Artifact ID:",
 			Options: artifactIds,
 		}
-		err := survey.AskOne(prompt, &form.ArchetypeArtifactId, survey.Required)
+		err := util.AskOne(prompt, &form.ArchetypeArtifactId, survey.Required)
 		if err != nil {
 			return err
 		}
@@ -280,7 +281,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 			Message: "Version:",
 			Options: versions,
 		}
-		err := survey.AskOne(prompt, &form.ArchetypeVersion, survey.Required)
+		err := util.AskOne(prompt, &form.ArchetypeVersion, survey.Required)
 		if err != nil {
 			return err
 		}
@@ -294,7 +295,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 			Message: "Project Group ID:",
 			Default: "com.acme",
 		}
-		err := survey.AskOne(q, &form.GroupId, survey.Required)
+		err := util.AskOne(q, &form.GroupId, survey.Required)
 		if err != nil {
 			return err
 		}
@@ -304,7 +305,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 			Message: "Project Artifact ID:",
 			Default: "",
 		}
-		err := survey.AskOne(q, &form.ArtifactId, survey.Required)
+		err := util.AskOne(q, &form.ArtifactId, survey.Required)
 		if err != nil {
 			return err
 		}
@@ -314,7 +315,7 @@ func (model *ArchetypeModel) CreateSurvey(data *ArchetypeFilter, pickVersion boo
 			Message: "Project Version:",
 			Default: "1.0.0-SNAPSHOT",
 		}
-		err := survey.AskOne(q, &form.Version, survey.Required)
+		err := util.AskOne(q, &form.Version, survey.Required)
 		if err != nil {
 			return err
 		}
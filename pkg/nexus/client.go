@@ -0,0 +1,132 @@
+package nexus
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// DefaultUsername the default admin user of Nexus
+	DefaultUsername = "admin"
+
+	scriptAPIPath = "/service/rest/v1/script"
+	// blobStoresAPIPath the read only API of the blob stores and their metrics
+	blobStoresAPIPath = "/service/rest/v1/blobstores"
+)
+
+// Client talks to the Nexus 3 REST API
+type Client struct {
+	BaseURL    string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// script a groovy script stored in Nexus
+type script struct {
+	Name    string `json:"name"`
+	Type    string `json:"type"`
+	Content string `json:"content"`
+}
+
+// scriptResult the result of running a script
+type scriptResult struct {
+	Name   string `json:"name"`
+	Result string `json:"result"`
+}
+
+// NewClient creates a new client for the Nexus server at the given URL
+func NewClient(baseURL string, username string, password string) *Client {
+	if username == "" {
+		username = DefaultUsername
+	}
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Username:   username,
+		Password:   password,
+		HTTPClient: httpclient.NewBaseClient(),
+	}
+}
+
+// RunScript uploads the groovy script with the given name, replacing any previous version, then runs it
+// passing the arguments as JSON and returns the result of the script
+func (c *Client) RunScript(name string, content string, args interface{}) (string, error) {
+	body, err := json.Marshal(&script{Name: name, Type: "groovy", Content: content})
+	if err != nil {
+		return "", err
+	}
+	status, _, err := c.do("GET", scriptAPIPath+"/"+name, "", nil)
+	if err != nil {
+		return "", err
+	}
+	if status == http.StatusNotFound {
+		status, data, err := c.do("POST", scriptAPIPath, "application/json", body)
+		if err != nil {
+			return "", err
+		}
+		if status != http.StatusNoContent && status != http.StatusOK {
+			return "", fmt.Errorf("failed to create Nexus script %s: status %d %s", name, status, string(data))
+		}
+	} else {
+		status, data, err := c.do("PUT", scriptAPIPath+"/"+name, "application/json", body)
+		if err != nil {
+			return "", err
+		}
+		if status != http.StatusNoContent && status != http.StatusOK {
+			return "", fmt.Errorf("failed to update Nexus script %s: status %d %s", name, status, string(data))
+		}
+	}
+
+	argData := []byte{}
+	if args != nil {
+		argData, err = json.Marshal(args)
+		if err != nil {
+			return "", err
+		}
+	}
+	status, data, err := c.do("POST", scriptAPIPath+"/"+name+"/run", "text/plain", argData)
+	if err != nil {
+		return "", err
+	}
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to run Nexus script %s: status %d %s", name, status, string(data))
+	}
+	result := scriptResult{}
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the result of Nexus script %s: %s", name, err)
+	}
+	return result.Result, nil
+}
+
+func (c *Client) do(method string, path string, contentType string, body []byte) (int, []byte, error) {
+	url := c.BaseURL + path
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, nil, err
+	}
+	req.Header.Add("Authorization", "Basic "+util.BasicAuth(c.Username, c.Password))
+	if contentType != "" {
+		req.Header.Add("Content-Type", contentType)
+	}
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return 0, nil, fmt.Errorf("failed to invoke %s %s: %s", method, url, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
+		return resp.StatusCode, data, fmt.Errorf("not authorized to invoke %s on Nexus as user %s", url, c.Username)
+	}
+	return resp.StatusCode, data, nil
+}
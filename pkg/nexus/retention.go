@@ -0,0 +1,175 @@
+package nexus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+const (
+	// DefaultRetentionSchedule the default cron expression of the cleanup tasks; every night at 1am
+	DefaultRetentionSchedule = "0 0 1 * * ?"
+
+	retentionScriptName = "jx-retention-policy"
+
+	retentionScript = `import groovy.json.JsonSlurper
+import org.sonatype.nexus.scheduling.TaskScheduler
+
+def policy = new JsonSlurper().parseText(args)
+def taskScheduler = container.lookup(TaskScheduler.class.getName())
+
+def schedule = { String name, String typeId, Map properties ->
+  taskScheduler.listsTasks().findAll { it.name == name }.each { it.remove() }
+  if (properties == null) {
+    return
+  }
+  def config = taskScheduler.createTaskConfigurationInstance(typeId)
+  config.setName(name)
+  properties.each { k, v -> config.setString(k, v.toString()) }
+  taskScheduler.scheduleTask(config, taskScheduler.scheduleFactory.cron(new Date(), policy.schedule))
+}
+
+if (policy.snapshotRetentionDays > 0) {
+  schedule('jx-remove-snapshots', 'repository.maven.remove-snapshots', [
+    repositoryName: '*',
+    minimumRetained: policy.minimumSnapshots,
+    snapshotRetentionDays: policy.snapshotRetentionDays,
+    removeIfReleased: true,
+    gracePeriodInDays: policy.snapshotRetentionDays
+  ])
+} else {
+  schedule('jx-remove-snapshots', null, null)
+}
+
+if (policy.dockerRetentionDays > 0) {
+  schedule('jx-prune-docker-images', 'script', [
+    language: 'groovy',
+    source: """import org.joda.time.DateTime
+import org.sonatype.nexus.repository.storage.Query
+import org.sonatype.nexus.repository.storage.StorageFacet
+
+def cutoff = DateTime.now().minusDays(${policy.dockerRetentionDays})
+repository.repositoryManager.browse().findAll { it.format.value == 'docker' && it.type.value == 'hosted' }.each { repo ->
+  def tx = repo.facet(StorageFacet).txSupplier().get()
+  try {
+    tx.begin()
+    tx.findComponents(Query.builder().where('last_updated < ').param(cutoff).build(), [repo]).each { tx.deleteComponent(it) }
+    tx.commit()
+  } finally {
+    tx.close()
+  }
+}
+"""
+  ])
+  schedule('jx-docker-gc', 'repository.docker.gc', [repositoryName: '*'])
+} else {
+  schedule('jx-prune-docker-images', null, null)
+  schedule('jx-docker-gc', null, null)
+}
+
+schedule('jx-compact-blobstores', 'blobstore.compact', [blobstoreName: 'default'])
+return 'ok'
+`
+)
+
+// RetentionPolicy the cleanup policies for the artifacts and images stored in Nexus
+type RetentionPolicy struct {
+	// SnapshotRetentionDays the number of days maven snapshots are kept for. 0 disables snapshot cleanup
+	SnapshotRetentionDays int `json:"snapshotRetentionDays"`
+	// MinimumSnapshots the minimum number of snapshots of each artifact to keep
+	MinimumSnapshots int `json:"minimumSnapshots"`
+	// DockerRetentionDays the number of days since docker images were last updated before they are deleted. 0 disables docker image pruning
+	DockerRetentionDays int `json:"dockerRetentionDays"`
+	// Schedule the cron expression of when the cleanup tasks run
+	Schedule string `json:"schedule"`
+}
+
+// ErrStorageNotSupported the error returned when the version of Nexus has no blob store API
+var ErrStorageNotSupported = errors.New("the storage of the Nexus blob stores requires Nexus 3.19 or later")
+
+// blobStore a blob store returned by the blob store API of Nexus
+type blobStore struct {
+	Name                  string `json:"name"`
+	Type                  string `json:"type"`
+	BlobCount             int64  `json:"blobCount"`
+	TotalSizeInBytes      int64  `json:"totalSizeInBytes"`
+	AvailableSpaceInBytes int64  `json:"availableSpaceInBytes"`
+}
+
+// BlobStoreStorage the storage used by a Nexus blob store
+type BlobStoreStorage struct {
+	Name           string `json:"name"`
+	BlobCount      int64  `json:"blobCount"`
+	TotalSize      int64  `json:"totalSize"`
+	AvailableSpace int64  `json:"availableSpace"`
+	Unlimited      bool   `json:"unlimited"`
+}
+
+// UsedPercent returns the percentage of the underlying volume which is used by the blob store
+func (s *BlobStoreStorage) UsedPercent() float64 {
+	total := s.TotalSize + s.AvailableSpace
+	if s.Unlimited || total <= 0 {
+		return 0
+	}
+	return float64(s.TotalSize) * 100 / float64(total)
+}
+
+// Validate validates the retention policy
+func (p *RetentionPolicy) Validate() error {
+	if p.SnapshotRetentionDays < 0 {
+		return fmt.Errorf("the snapshot retention days cannot be negative")
+	}
+	if p.MinimumSnapshots < 0 {
+		return fmt.Errorf("the minimum number of snapshots cannot be negative")
+	}
+	if p.DockerRetentionDays < 0 {
+		return fmt.Errorf("the docker retention days cannot be negative")
+	}
+	return nil
+}
+
+// ConfigureRetention schedules the Nexus tasks which remove old snapshots and docker images then compact the blob store
+func (c *Client) ConfigureRetention(policy *RetentionPolicy) error {
+	err := policy.Validate()
+	if err != nil {
+		return err
+	}
+	if policy.Schedule == "" {
+		policy.Schedule = DefaultRetentionSchedule
+	}
+	_, err = c.RunScript(retentionScriptName, retentionScript, policy)
+	return err
+}
+
+// GetStorage returns the storage used by each blob store in Nexus. It only reads the blob store metrics so it never
+// changes the state of the server. ErrStorageNotSupported is returned by versions of Nexus without the blob store API
+func (c *Client) GetStorage() ([]BlobStoreStorage, error) {
+	status, data, err := c.do("GET", blobStoresAPIPath, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, ErrStorageNotSupported
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to get the Nexus blob stores: status %d %s", status, string(data))
+	}
+	stores := []blobStore{}
+	err = json.Unmarshal(data, &stores)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the Nexus blob stores %s: %s", string(data), err)
+	}
+	answer := []BlobStoreStorage{}
+	for _, s := range stores {
+		answer = append(answer, BlobStoreStorage{
+			Name:           s.Name,
+			BlobCount:      s.BlobCount,
+			TotalSize:      s.TotalSizeInBytes,
+			AvailableSpace: s.AvailableSpaceInBytes,
+			Unlimited:      strings.EqualFold(s.Type, "S3") || s.AvailableSpaceInBytes <= 0,
+		})
+	}
+	return answer, nil
+}
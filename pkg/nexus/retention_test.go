@@ -0,0 +1,81 @@
+package nexus_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/nexus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigureRetentionAndGetStorage(t *testing.T) {
+	t.Parallel()
+
+	scripts := map[string]string{}
+	var retentionArgs map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		if !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/service/rest/v1/blobstores":
+			w.Write([]byte(`[{"name":"default","type":"File","blobCount":10,"totalSizeInBytes":300,"availableSpaceInBytes":100}]`))
+		case r.Method == "GET":
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "POST" && r.URL.Path == "/service/rest/v1/script":
+			s := map[string]string{}
+			json.Unmarshal(body, &s)
+			scripts[s["name"]] = s["content"]
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/service/rest/v1/script/jx-retention-policy/run":
+			json.Unmarshal(body, &retentionArgs)
+			w.Write([]byte(`{"name":"jx-retention-policy","result":"ok"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	client := nexus.NewClient(server.URL+"/", "", "secret")
+	policy := &nexus.RetentionPolicy{
+		SnapshotRetentionDays: 30,
+		MinimumSnapshots:      2,
+		DockerRetentionDays:   14,
+	}
+	err := client.ConfigureRetention(policy)
+	require.NoError(t, err)
+	assert.Contains(t, scripts["jx-retention-policy"], "repository.maven.remove-snapshots")
+	assert.Equal(t, float64(14), retentionArgs["dockerRetentionDays"])
+	assert.Equal(t, nexus.DefaultRetentionSchedule, retentionArgs["schedule"])
+
+	storage, err := client.GetStorage()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(storage))
+	assert.Equal(t, "default", storage[0].Name)
+	assert.Equal(t, float64(75), storage[0].UsedPercent())
+	assert.Len(t, scripts, 1, "getting the storage should not upload a script")
+
+	err = nexus.NewClient(server.URL, "admin", "wrong").ConfigureRetention(policy)
+	assert.Error(t, err)
+
+	err = client.ConfigureRetention(&nexus.RetentionPolicy{DockerRetentionDays: -1})
+	assert.Error(t, err)
+}
+
+func TestGetStorageNotSupported(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := nexus.NewClient(server.URL, "", "secret").GetStorage()
+	assert.Equal(t, nexus.ErrStorageNotSupported, err)
+}
@@ -0,0 +1,186 @@
+package notify
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/kube"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// SecretPrefix the prefix of the names of the Secrets which store the notification channels
+	SecretPrefix = "jx-notify-"
+
+	// TemplatesConfigMapName the name of the ConfigMap containing the customised message templates of a team
+	TemplatesConfigMapName = "jx-notification-templates"
+
+	secretKeyURL      = "url"
+	secretKeyProvider = "provider"
+	secretKeyEvents   = "events"
+)
+
+// Channel a chat channel which is posted notifications via a webhook
+type Channel struct {
+	Name       string
+	Provider   string
+	WebhookURL string
+	// Events the kinds of events posted to the channel
+	Events []string
+}
+
+// Accepts returns true if the channel is posted events of the given kind
+func (c *Channel) Accepts(kind string) bool {
+	return util.StringArrayIndex(c.Events, kind) >= 0
+}
+
+// ValidateChannel returns an error if the channel has an unknown provider or event kind
+func ValidateChannel(channel *Channel) error {
+	if util.StringArrayIndex(Providers, channel.Provider) < 0 {
+		return util.InvalidOption("provider", channel.Provider, Providers)
+	}
+	if channel.WebhookURL == "" {
+		return util.MissingOption("url")
+	}
+	for _, e := range channel.Events {
+		if util.StringArrayIndex(EventKinds, e) < 0 {
+			return util.InvalidOption("events", e, EventKinds)
+		}
+	}
+	return nil
+}
+
+// SaveChannel creates or updates the Secret storing the channel in the given namespace
+func SaveChannel(kubeClient kubernetes.Interface, ns string, channel *Channel) error {
+	err := ValidateChannel(channel)
+	if err != nil {
+		return err
+	}
+	name := SecretPrefix + channel.Name
+	data := map[string][]byte{
+		secretKeyURL:      []byte(channel.WebhookURL),
+		secretKeyProvider: []byte(channel.Provider),
+		secretKeyEvents:   []byte(strings.Join(channel.Events, ",")),
+	}
+	secrets := kubeClient.CoreV1().Secrets(ns)
+	secret, err := secrets.Get(name, metav1.GetOptions{})
+	if err != nil {
+		_, err = secrets.Create(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					kube.LabelKind: kube.ValueKindNotification,
+				},
+			},
+			Data: data,
+		})
+	} else {
+		secret.Data = data
+		_, err = secrets.Update(secret)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save Secret %s", name)
+	}
+	return nil
+}
+
+// GetChannels returns the notification channels of the given namespace sorted by name
+func GetChannels(kubeClient kubernetes.Interface, ns string) ([]*Channel, error) {
+	list, err := kubeClient.CoreV1().Secrets(ns).List(metav1.ListOptions{
+		LabelSelector: kube.LabelKind + "=" + kube.ValueKindNotification,
+	})
+	if err != nil {
+		return nil, err
+	}
+	answer := []*Channel{}
+	for _, secret := range list.Items {
+		answer = append(answer, channelFromSecret(&secret))
+	}
+	sort.Slice(answer, func(i, j int) bool {
+		return answer[i].Name < answer[j].Name
+	})
+	return answer, nil
+}
+
+// DeleteChannel deletes the notification channel with the given name
+func DeleteChannel(kubeClient kubernetes.Interface, ns string, name string) error {
+	err := kubeClient.CoreV1().Secrets(ns).Delete(SecretPrefix+name, &metav1.DeleteOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("no notification channel called %s", name)
+	}
+	return err
+}
+
+// GetTemplates returns the customised message templates of the given namespace indexed by event kind
+func GetTemplates(kubeClient kubernetes.Interface, ns string) (map[string]string, error) {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get(TemplatesConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return map[string]string{}, nil
+		}
+		return nil, err
+	}
+	if cm.Data == nil {
+		return map[string]string{}, nil
+	}
+	return cm.Data, nil
+}
+
+// SaveTemplate sets the message template of the given event kind or removes it if the template is empty so that
+// the default template is used
+func SaveTemplate(kubeClient kubernetes.Interface, ns string, kind string, text string) error {
+	if util.StringArrayIndex(EventKinds, kind) < 0 {
+		return util.InvalidOption("event", kind, EventKinds)
+	}
+	if text != "" {
+		_, err := RenderMessage(&Event{Kind: kind}, text)
+		if err != nil {
+			return err
+		}
+	}
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(TemplatesConfigMapName, metav1.GetOptions{})
+	create := err != nil
+	if create {
+		cm = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: TemplatesConfigMapName,
+			},
+		}
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	if text == "" {
+		delete(cm.Data, kind)
+	} else {
+		cm.Data[kind] = text
+	}
+	if create {
+		_, err = configMaps.Create(cm)
+	} else {
+		_, err = configMaps.Update(cm)
+	}
+	return err
+}
+
+func channelFromSecret(secret *corev1.Secret) *Channel {
+	channel := &Channel{
+		Name:       strings.TrimPrefix(secret.Name, SecretPrefix),
+		Provider:   string(secret.Data[secretKeyProvider]),
+		WebhookURL: string(secret.Data[secretKeyURL]),
+		Events:     []string{},
+	}
+	for _, e := range strings.Split(string(secret.Data[secretKeyEvents]), ",") {
+		if e != "" {
+			channel.Events = append(channel.Events, e)
+		}
+	}
+	return channel
+}
@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ProviderSlack posts messages to a Slack incoming webhook
+	ProviderSlack = "slack"
+
+	// ProviderTeams posts messages to a Microsoft Teams incoming webhook
+	ProviderTeams = "teams"
+
+	// ProviderDiscord posts messages to a Discord webhook
+	ProviderDiscord = "discord"
+
+	// EventPipelineFailed is sent when a pipeline fails
+	EventPipelineFailed = "pipeline-failed"
+
+	// EventPromotionSucceeded is sent when a version is promoted to an environment
+	EventPromotionSucceeded = "promotion-succeeded"
+
+	// EventPreviewCreated is sent when a preview environment is available for a Pull Request
+	EventPreviewCreated = "preview-created"
+
+	// AnnotationNotifiedEvents the annotation on a PipelineActivity containing the comma separated events which have
+	// been notified
+	AnnotationNotifiedEvents = "jenkins.io/notified-events"
+)
+
+var (
+	// Providers the supported chat providers
+	Providers = []string{ProviderSlack, ProviderTeams, ProviderDiscord}
+
+	// EventKinds the kinds of events which can be notified
+	EventKinds = []string{EventPipelineFailed, EventPromotionSucceeded, EventPreviewCreated}
+
+	// DefaultTemplates the default message templates for each kind of event
+	DefaultTemplates = map[string]string{
+		EventPipelineFailed:     `Pipeline {{.Pipeline}} #{{.Build}} {{.Status}}{{if .URL}}: {{.URL}}{{end}}`,
+		EventPromotionSucceeded: `Promoted {{.Repository}} version {{.Version}} to {{.Environment}}{{if .URL}}: {{.URL}}{{end}}`,
+		EventPreviewCreated:     `Preview of {{.Repository}} for {{.PullRequestURL}} is available at {{.URL}}`,
+	}
+
+	httpClient = &http.Client{Transport: httpclient.BaseTransport(), Timeout: 30 * time.Second}
+)
+
+// Event a pipeline or promotion event which can be posted to a chat channel
+type Event struct {
+	// Kind the kind of event, such as pipeline-failed
+	Kind string
+	// Key uniquely identifies the event within its PipelineActivity
+	Key string
+
+	Pipeline       string
+	Build          string
+	Owner          string
+	Repository     string
+	Version        string
+	Status         string
+	Environment    string
+	PullRequestURL string
+	// URL the most relevant link for the event; the build logs, application or preview URL
+	URL string
+}
+
+// ActivityEvents returns the events of the activity which have not been notified yet
+func ActivityEvents(activity *v1.PipelineActivity) []*Event {
+	spec := &activity.Spec
+	notified := NotifiedEvents(activity)
+	newEvent := func(kind string, key string) *Event {
+		return &Event{
+			Kind:       kind,
+			Key:        key,
+			Pipeline:   spec.Pipeline,
+			Build:      spec.Build,
+			Owner:      spec.GitOwner,
+			Repository: activity.RepositoryName(),
+			Version:    spec.Version,
+			Status:     string(spec.Status),
+		}
+	}
+
+	answer := []*Event{}
+	if spec.Status == v1.ActivityStatusTypeFailed || spec.Status == v1.ActivityStatusTypeError {
+		if util.StringArrayIndex(notified, EventPipelineFailed) < 0 {
+			e := newEvent(EventPipelineFailed, EventPipelineFailed)
+			e.URL = spec.BuildLogsURL
+			if e.URL == "" {
+				e.URL = spec.BuildURL
+			}
+			answer = append(answer, e)
+		}
+	}
+	for _, step := range spec.Steps {
+		if promote := step.Promote; promote != nil && promote.Status == v1.ActivityStatusTypeSucceeded && promote.Environment != "" {
+			key := EventPromotionSucceeded + "/" + promote.Environment
+			if util.StringArrayIndex(notified, key) < 0 {
+				e := newEvent(EventPromotionSucceeded, key)
+				e.Environment = promote.Environment
+				e.URL = promote.ApplicationURL
+				answer = append(answer, e)
+			}
+		}
+		if preview := step.Preview; preview != nil && preview.ApplicationURL != "" {
+			if util.StringArrayIndex(notified, EventPreviewCreated) < 0 {
+				e := newEvent(EventPreviewCreated, EventPreviewCreated)
+				e.Environment = preview.Environment
+				e.PullRequestURL = preview.PullRequestURL
+				e.URL = preview.ApplicationURL
+				answer = append(answer, e)
+			}
+		}
+	}
+	return answer
+}
+
+// NotifiedEvents returns the keys of the events of the activity which have been notified
+func NotifiedEvents(activity *v1.PipelineActivity) []string {
+	answer := []string{}
+	for _, key := range strings.Split(activity.Annotations[AnnotationNotifiedEvents], ",") {
+		if key != "" {
+			answer = append(answer, key)
+		}
+	}
+	return answer
+}
+
+// MarkNotified adds the annotation to the activity which records that the given events have been notified
+func MarkNotified(activity *v1.PipelineActivity, events []*Event) {
+	notified := NotifiedEvents(activity)
+	for _, e := range events {
+		if util.StringArrayIndex(notified, e.Key) < 0 {
+			notified = append(notified, e.Key)
+		}
+	}
+	if activity.Annotations == nil {
+		activity.Annotations = map[string]string{}
+	}
+	activity.Annotations[AnnotationNotifiedEvents] = strings.Join(notified, ",")
+}
+
+// RenderMessage renders the message of the event using the given template or the default template of the event kind
+func RenderMessage(event *Event, text string) (string, error) {
+	if text == "" {
+		text = DefaultTemplates[event.Kind]
+	}
+	tmpl, err := template.New(event.Kind).Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse the %s template: %s", event.Kind, err)
+	}
+	var buffer bytes.Buffer
+	err = tmpl.Execute(&buffer, event)
+	if err != nil {
+		return "", fmt.Errorf("failed to render the %s template: %s", event.Kind, err)
+	}
+	return buffer.String(), nil
+}
+
+// Payload returns the JSON body of the webhook request which posts the message to the given provider
+func Payload(provider string, message string) ([]byte, error) {
+	var body map[string]interface{}
+	switch provider {
+	case ProviderSlack:
+		body = map[string]interface{}{"text": message}
+	case ProviderTeams:
+		body = map[string]interface{}{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"text":     message,
+		}
+	case ProviderDiscord:
+		body = map[string]interface{}{"content": message}
+	default:
+		return nil, fmt.Errorf("unsupported notification provider %s", provider)
+	}
+	return json.Marshal(body)
+}
+
+// Send posts the message to the webhook of the channel
+func Send(channel *Channel, message string) error {
+	data, err := Payload(channel.Provider, message)
+	if err != nil {
+		return err
+	}
+	resp, err := httpClient.Post(channel.WebhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("the %s webhook of channel %s returned status %s", channel.Provider, channel.Name, resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package notify_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/notify"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestActivityEventsAreOnlyNotifiedOnce(t *testing.T) {
+	t.Parallel()
+	activity := &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{Name: "myorg-myapp-master-3"},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:      "myorg/myapp/master",
+			Build:         "3",
+			GitOwner:      "myorg",
+			GitRepository: "myapp",
+			Version:       "1.0.3",
+			Status:        v1.ActivityStatusTypeFailed,
+			BuildLogsURL:  "http://jenkins/job/myorg/job/myapp/job/master/3/console",
+			Steps: []v1.PipelineActivityStep{
+				{
+					Kind: v1.ActivityStepKindTypePromote,
+					Promote: &v1.PromoteActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{Status: v1.ActivityStatusTypeSucceeded},
+						Environment:      "staging",
+						ApplicationURL:   "http://myapp.jx-staging.example.com",
+					},
+				},
+			},
+		},
+	}
+
+	events := notify.ActivityEvents(activity)
+	require.Len(t, events, 2)
+	assert.Equal(t, notify.EventPipelineFailed, events[0].Kind)
+	assert.Equal(t, notify.EventPromotionSucceeded, events[1].Kind)
+
+	message, err := notify.RenderMessage(events[0], "")
+	require.NoError(t, err)
+	assert.Equal(t, "Pipeline myorg/myapp/master #3 Failed: http://jenkins/job/myorg/job/myapp/job/master/3/console", message)
+
+	message, err = notify.RenderMessage(events[1], "{{.Repository}} {{.Version}} is in {{.Environment}}")
+	require.NoError(t, err)
+	assert.Equal(t, "myapp 1.0.3 is in staging", message)
+
+	notify.MarkNotified(activity, events)
+	assert.Empty(t, notify.ActivityEvents(activity))
+}
+
+func TestPayload(t *testing.T) {
+	t.Parallel()
+	for provider, key := range map[string]string{
+		notify.ProviderSlack:   "text",
+		notify.ProviderTeams:   "text",
+		notify.ProviderDiscord: "content",
+	} {
+		data, err := notify.Payload(provider, "hello")
+		require.NoError(t, err)
+		body := map[string]interface{}{}
+		require.NoError(t, json.Unmarshal(data, &body))
+		assert.Equal(t, "hello", body[key], "provider %s", provider)
+	}
+
+	_, err := notify.Payload("irc", "hello")
+	assert.Error(t, err)
+}
+
+func TestSaveAndGetChannels(t *testing.T) {
+	t.Parallel()
+	kubeClient := fake.NewSimpleClientset()
+	ns := "jx"
+
+	err := notify.SaveChannel(kubeClient, ns, &notify.Channel{
+		Name:       "builds",
+		Provider:   notify.ProviderSlack,
+		WebhookURL: "https://hooks.slack.com/services/T000/B000/XXXX",
+		Events:     []string{notify.EventPipelineFailed},
+	})
+	require.NoError(t, err)
+
+	err = notify.SaveChannel(kubeClient, ns, &notify.Channel{Name: "bad", Provider: "irc", WebhookURL: "http://irc"})
+	assert.Error(t, err)
+
+	channels, err := notify.GetChannels(kubeClient, ns)
+	require.NoError(t, err)
+	require.Len(t, channels, 1)
+	assert.Equal(t, "builds", channels[0].Name)
+	assert.True(t, channels[0].Accepts(notify.EventPipelineFailed))
+	assert.False(t, channels[0].Accepts(notify.EventPreviewCreated))
+
+	err = notify.SaveTemplate(kubeClient, ns, notify.EventPipelineFailed, "{{.Pipeline}} broke")
+	require.NoError(t, err)
+	err = notify.SaveTemplate(kubeClient, ns, notify.EventPipelineFailed, "{{.Pipeline")
+	assert.Error(t, err)
+	messageTemplates, err := notify.GetTemplates(kubeClient, ns)
+	require.NoError(t, err)
+	assert.Equal(t, "{{.Pipeline}} broke", messageTemplates[notify.EventPipelineFailed])
+
+	require.NoError(t, notify.DeleteChannel(kubeClient, ns, "builds"))
+	assert.Error(t, notify.DeleteChannel(kubeClient, ns, "builds"))
+}
@@ -0,0 +1,117 @@
+package observability
+
+import (
+	"encoding/json"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// MetricPipelineRuns the counter of completed pipelines labelled by owner, repository, branch and status
+	MetricPipelineRuns = "jx_pipeline_runs_total"
+
+	// MetricPipelineDuration the histogram of the durations in seconds of completed pipelines
+	MetricPipelineDuration = "jx_pipeline_duration_seconds"
+
+	// MetricPipelineQueueTime the histogram of the seconds pipelines waited before their first step started
+	MetricPipelineQueueTime = "jx_pipeline_queue_seconds"
+
+	// MetricPromotions the counter of completed promotions labelled by owner, repository, environment and status
+	MetricPromotions = "jx_promotions_total"
+
+	// MetricPromotionDuration the histogram of the durations in seconds of completed promotions
+	MetricPromotionDuration = "jx_promotion_duration_seconds"
+)
+
+// dashboardPanel a graph on a dashboard
+type dashboardPanel struct {
+	title string
+	expr  string
+	unit  string
+}
+
+var pipelinePanels = []dashboardPanel{
+	{title: "Pipelines per hour", expr: `sum(increase(` + MetricPipelineRuns + `[1h])) by (status)`, unit: "short"},
+	{title: "Pipeline success rate", expr: `sum(increase(` + MetricPipelineRuns + `{status="Succeeded"}[1d])) / sum(increase(` + MetricPipelineRuns + `[1d]))`, unit: "percentunit"},
+	{title: "Pipeline duration (95th percentile)", expr: `histogram_quantile(0.95, sum(rate(` + MetricPipelineDuration + `_bucket[1h])) by (le, repository))`, unit: "s"},
+	{title: "Pipeline queue time (95th percentile)", expr: `histogram_quantile(0.95, sum(rate(` + MetricPipelineQueueTime + `_bucket[1h])) by (le))`, unit: "s"},
+}
+
+var promotionPanels = []dashboardPanel{
+	{title: "Deployment frequency per day", expr: `sum(increase(` + MetricPromotions + `{status="Succeeded"}[1d])) by (environment)`, unit: "short"},
+	{title: "Change failure rate", expr: `sum(increase(` + MetricPromotions + `{status="Failed"}[7d])) by (environment) / sum(increase(` + MetricPromotions + `[7d])) by (environment)`, unit: "percentunit"},
+	{title: "Promotion duration (95th percentile)", expr: `histogram_quantile(0.95, sum(rate(` + MetricPromotionDuration + `_bucket[1d])) by (le, environment))`, unit: "s"},
+}
+
+// DashboardConfigMaps returns the ConfigMaps containing the Grafana dashboards for pipeline and promotion metrics
+func DashboardConfigMaps(ns string) ([]*corev1.ConfigMap, error) {
+	dashboards := []struct {
+		name   string
+		title  string
+		panels []dashboardPanel
+	}{
+		{name: "jx-pipelines", title: "Jenkins X Pipelines", panels: pipelinePanels},
+		{name: "jx-promotions", title: "Jenkins X Promotions", panels: promotionPanels},
+	}
+	answer := []*corev1.ConfigMap{}
+	for _, d := range dashboards {
+		data, err := json.MarshalIndent(dashboard(d.name, d.title, d.panels), "", "  ")
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      d.name + "-dashboard",
+				Namespace: ns,
+				Labels: map[string]string{
+					LabelGrafanaDashboard: "1",
+				},
+			},
+			Data: map[string]string{
+				d.name + ".json": string(data),
+			},
+		})
+	}
+	return answer, nil
+}
+
+func dashboard(uid string, title string, panels []dashboardPanel) map[string]interface{} {
+	items := []interface{}{}
+	for i, p := range panels {
+		items = append(items, map[string]interface{}{
+			"id":    i + 1,
+			"type":  "graph",
+			"title": p.title,
+			"gridPos": map[string]interface{}{
+				"x": (i % 2) * 12,
+				"y": (i / 2) * 8,
+				"w": 12,
+				"h": 8,
+			},
+			"targets": []interface{}{
+				map[string]interface{}{
+					"expr":  p.expr,
+					"refId": "A",
+				},
+			},
+			"yaxes": []interface{}{
+				map[string]interface{}{"format": p.unit},
+				map[string]interface{}{"format": "short"},
+			},
+		})
+	}
+	return map[string]interface{}{
+		"uid":           uid,
+		"title":         title,
+		"tags":          []string{"jenkins-x"},
+		"timezone":      "browser",
+		"schemaVersion": 16,
+		"refresh":       "1m",
+		"time": map[string]interface{}{
+			"from": "now-7d",
+			"to":   "now",
+		},
+		"panels": items,
+	}
+}
@@ -0,0 +1,153 @@
+package observability
+
+import (
+	"strconv"
+
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// ExporterName the name of the Deployment, Service and RBAC resources of the pipeline metrics exporter
+	ExporterName = "jx-pipeline-metrics"
+
+	// DefaultExporterImage the default image used to run the pipeline metrics exporter which must contain the jx binary
+	DefaultExporterImage = "jenkinsxio/builder-base:0.0.547"
+
+	// DefaultExporterPort the default port the pipeline metrics exporter serves the metrics on
+	DefaultExporterPort = 9090
+)
+
+// EnsureExporter creates or updates the resources which run the pipeline metrics exporter in the given namespace
+func EnsureExporter(kubeClient kubernetes.Interface, ns string, image string, port int) error {
+	labels := map[string]string{"app": ExporterName}
+	meta := metav1.ObjectMeta{
+		Name:      ExporterName,
+		Namespace: ns,
+		Labels:    labels,
+	}
+
+	serviceAccounts := kubeClient.CoreV1().ServiceAccounts(ns)
+	_, err := serviceAccounts.Get(ExporterName, metav1.GetOptions{})
+	if err != nil {
+		_, err = serviceAccounts.Create(&corev1.ServiceAccount{ObjectMeta: meta})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create ServiceAccount %s", ExporterName)
+		}
+	}
+
+	role := &rbacv1.Role{
+		ObjectMeta: meta,
+		Rules: []rbacv1.PolicyRule{
+			{
+				APIGroups: []string{"jenkins.io"},
+				Resources: []string{"pipelineactivities"},
+				Verbs:     []string{"get", "list", "watch", "update", "patch"},
+			},
+		},
+	}
+	roles := kubeClient.RbacV1().Roles(ns)
+	current, err := roles.Get(ExporterName, metav1.GetOptions{})
+	if err != nil {
+		_, err = roles.Create(role)
+	} else {
+		current.Rules = role.Rules
+		_, err = roles.Update(current)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save Role %s", ExporterName)
+	}
+
+	roleBindings := kubeClient.RbacV1().RoleBindings(ns)
+	_, err = roleBindings.Get(ExporterName, metav1.GetOptions{})
+	if err != nil {
+		_, err = roleBindings.Create(&rbacv1.RoleBinding{
+			ObjectMeta: meta,
+			Subjects: []rbacv1.Subject{
+				{
+					Kind:      "ServiceAccount",
+					Name:      ExporterName,
+					Namespace: ns,
+				},
+			},
+			RoleRef: rbacv1.RoleRef{
+				Kind:     "Role",
+				Name:     ExporterName,
+				APIGroup: "rbac.authorization.k8s.io",
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create RoleBinding %s", ExporterName)
+		}
+	}
+
+	replicas := int32(1)
+	deployment := &appsv1.Deployment{
+		ObjectMeta: meta,
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: labels,
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+				},
+				Spec: corev1.PodSpec{
+					ServiceAccountName: ExporterName,
+					Containers: []corev1.Container{
+						{
+							Name:    ExporterName,
+							Image:   image,
+							Command: []string{"jx", "controller", "metrics", "--namespace", ns, "--port", strconv.Itoa(port)},
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          "http",
+									ContainerPort: int32(port),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	deployments := kubeClient.AppsV1().Deployments(ns)
+	currentDeployment, err := deployments.Get(ExporterName, metav1.GetOptions{})
+	if err != nil {
+		_, err = deployments.Create(deployment)
+	} else {
+		currentDeployment.Spec = deployment.Spec
+		_, err = deployments.Update(currentDeployment)
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to save Deployment %s", ExporterName)
+	}
+
+	services := kubeClient.CoreV1().Services(ns)
+	_, err = services.Get(ExporterName, metav1.GetOptions{})
+	if err != nil {
+		_, err = services.Create(&corev1.Service{
+			ObjectMeta: meta,
+			Spec: corev1.ServiceSpec{
+				Selector: labels,
+				Ports: []corev1.ServicePort{
+					{
+						Name:       "http",
+						Port:       80,
+						TargetPort: intstr.FromInt(port),
+					},
+				},
+			},
+		})
+		if err != nil {
+			return errors.Wrapf(err, "failed to create Service %s", ExporterName)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package observability
+
+import (
+	"github.com/ghodss/yaml"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// ChartRepositoryName the name of the helm repository containing the prometheus charts
+	ChartRepositoryName = "prometheus-community"
+
+	// ChartRepositoryURL the URL of the helm repository containing the prometheus charts
+	ChartRepositoryURL = "https://prometheus-community.github.io/helm-charts"
+
+	// ChartKubePrometheusStack the chart which installs prometheus, alertmanager and grafana
+	ChartKubePrometheusStack = ChartRepositoryName + "/kube-prometheus-stack"
+
+	// LabelGrafanaDashboard the label which the grafana sidecar uses to find the ConfigMaps containing dashboards
+	LabelGrafanaDashboard = "grafana_dashboard"
+
+	// AuthUsernameKey the key of the username in the basic auth secrets used by ServiceMonitors
+	AuthUsernameKey = "username"
+
+	// AuthPasswordKey the key of the password in the basic auth secrets used by ServiceMonitors
+	AuthPasswordKey = "password"
+)
+
+// ServiceMonitorTarget a Jenkins X service which exposes prometheus metrics
+type ServiceMonitorTarget struct {
+	// Name the name of the ServiceMonitor
+	Name string
+	// Service the name of the Service in the team namespace
+	Service string
+	// Path the HTTP path of the metrics
+	Path string
+	// BasicAuth whether the metrics are protected by the admin user and password
+	BasicAuth bool
+}
+
+// DefaultServiceMonitorTargets the services of a team which are scraped if they exist
+var DefaultServiceMonitorTargets = []ServiceMonitorTarget{
+	{Name: "jx-jenkins", Service: "jenkins", Path: "/prometheus"},
+	{Name: "jx-nexus", Service: "nexus", Path: "/service/metrics/prometheus", BasicAuth: true},
+	{Name: "jx-prow-hook", Service: "hook", Path: "/metrics"},
+	{Name: "jx-prow-tide", Service: "tide", Path: "/metrics"},
+	{Name: "jx-prow-deck", Service: "deck", Path: "/metrics"},
+	{Name: ExporterName, Service: ExporterName, Path: "/metrics"},
+}
+
+// ServiceMonitor returns the ServiceMonitor definition, in the format of the 'prometheus.additionalServiceMonitors'
+// value of the kube-prometheus-stack chart, which scrapes the given service
+func ServiceMonitor(target ServiceMonitorTarget, svc *corev1.Service, authSecret string) map[string]interface{} {
+	endpoint := map[string]interface{}{
+		"path": target.Path,
+	}
+	if len(svc.Spec.Ports) > 0 {
+		port := svc.Spec.Ports[0]
+		if port.Name != "" {
+			endpoint["port"] = port.Name
+		} else {
+			targetPort := port.TargetPort
+			if targetPort.Type == intstr.Int && targetPort.IntVal == 0 {
+				targetPort = intstr.FromInt(int(port.Port))
+			}
+			endpoint["targetPort"] = targetPort
+		}
+	}
+	if target.BasicAuth && authSecret != "" {
+		endpoint["basicAuth"] = map[string]interface{}{
+			"username": map[string]interface{}{"name": authSecret, "key": AuthUsernameKey},
+			"password": map[string]interface{}{"name": authSecret, "key": AuthPasswordKey},
+		}
+	}
+	selector := svc.Spec.Selector
+	if len(svc.Labels) > 0 {
+		selector = svc.Labels
+	}
+	return map[string]interface{}{
+		"name": target.Name,
+		"selector": map[string]interface{}{
+			"matchLabels": selector,
+		},
+		"namespaceSelector": map[string]interface{}{
+			"matchNames": []string{svc.Namespace},
+		},
+		"endpoints": []interface{}{endpoint},
+	}
+}
+
+// ChartValues returns the YAML values for the kube-prometheus-stack chart
+func ChartValues(serviceMonitors []map[string]interface{}, grafanaPassword string) ([]byte, error) {
+	monitors := []interface{}{}
+	for _, m := range serviceMonitors {
+		monitors = append(monitors, m)
+	}
+	grafana := map[string]interface{}{
+		"ingress": map[string]interface{}{
+			"enabled": false,
+		},
+		"sidecar": map[string]interface{}{
+			"dashboards": map[string]interface{}{
+				"enabled":         true,
+				"label":           LabelGrafanaDashboard,
+				"searchNamespace": "ALL",
+			},
+		},
+	}
+	if grafanaPassword != "" {
+		grafana["adminPassword"] = grafanaPassword
+	}
+	values := map[string]interface{}{
+		"grafana": grafana,
+		"prometheus": map[string]interface{}{
+			"additionalServiceMonitors": monitors,
+			"prometheusSpec": map[string]interface{}{
+				// lets find the ServiceMonitors created outside of this chart too
+				"serviceMonitorSelectorNilUsesHelmValues": false,
+			},
+		},
+	}
+	return yaml.Marshal(values)
+}
@@ -0,0 +1,84 @@
+package observability_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/observability"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestChartValuesWithServiceMonitors(t *testing.T) {
+	t.Parallel()
+	jenkins := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "jenkins",
+			Namespace: "jx",
+			Labels:    map[string]string{"app": "jenkins"},
+		},
+		Spec: corev1.ServiceSpec{
+			Ports: []corev1.ServicePort{{Name: "http", Port: 8080}},
+		},
+	}
+	nexus := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "nexus",
+			Namespace: "jx",
+		},
+		Spec: corev1.ServiceSpec{
+			Selector: map[string]string{"app": "nexus"},
+			Ports:    []corev1.ServicePort{{Port: 80, TargetPort: intstr.FromInt(8081)}},
+		},
+	}
+	targets := observability.DefaultServiceMonitorTargets
+	monitors := []map[string]interface{}{
+		observability.ServiceMonitor(targets[0], jenkins, "nexus-auth"),
+		observability.ServiceMonitor(targets[1], nexus, "nexus-auth"),
+	}
+
+	data, err := observability.ChartValues(monitors, "s3cret")
+	require.NoError(t, err)
+
+	values := map[string]interface{}{}
+	err = yaml.Unmarshal(data, &values)
+	require.NoError(t, err)
+
+	grafana := values["grafana"].(map[string]interface{})
+	assert.Equal(t, "s3cret", grafana["adminPassword"])
+
+	additional := values["prometheus"].(map[string]interface{})["additionalServiceMonitors"].([]interface{})
+	require.Len(t, additional, 2)
+
+	jenkinsEndpoint := additional[0].(map[string]interface{})["endpoints"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "http", jenkinsEndpoint["port"])
+	assert.Equal(t, "/prometheus", jenkinsEndpoint["path"])
+	assert.Nil(t, jenkinsEndpoint["basicAuth"])
+
+	nexusMonitor := additional[1].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"app": "nexus"}, nexusMonitor["selector"].(map[string]interface{})["matchLabels"])
+	nexusEndpoint := nexusMonitor["endpoints"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, float64(8081), nexusEndpoint["targetPort"])
+	assert.NotNil(t, nexusEndpoint["basicAuth"])
+}
+
+func TestDashboardConfigMaps(t *testing.T) {
+	t.Parallel()
+	configMaps, err := observability.DashboardConfigMaps("monitoring")
+	require.NoError(t, err)
+	require.Len(t, configMaps, 2)
+
+	for _, cm := range configMaps {
+		assert.Equal(t, "1", cm.Labels[observability.LabelGrafanaDashboard])
+		for _, text := range cm.Data {
+			dashboard := map[string]interface{}{}
+			err = json.Unmarshal([]byte(text), &dashboard)
+			require.NoError(t, err, "dashboard %s should be valid JSON", cm.Name)
+			assert.NotEmpty(t, dashboard["panels"])
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package observability
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// AnnotationPipelineDuration the annotation on a PipelineActivity containing the duration of the pipeline in seconds
+	AnnotationPipelineDuration = "jenkins.io/pipeline-duration-seconds"
+
+	// AnnotationPipelineQueueTime the annotation on a PipelineActivity containing the seconds the pipeline was queued for
+	AnnotationPipelineQueueTime = "jenkins.io/pipeline-queue-seconds"
+
+	// AnnotationMetricsRecorded the annotation on a PipelineActivity indicating its pipeline metrics have been exported
+	AnnotationMetricsRecorded = "jenkins.io/metrics-recorded"
+
+	// AnnotationPromotionsRecorded the annotation on a PipelineActivity containing the comma separated environments
+	// whose promotion metrics have been exported
+	AnnotationPromotionsRecorded = "jenkins.io/promotion-metrics-recorded"
+)
+
+// PromotionMetrics the metrics of a completed promotion
+type PromotionMetrics struct {
+	Environment string
+	Status      v1.ActivityStatusType
+	Duration    time.Duration
+}
+
+// ActivityMetrics the metrics of a PipelineActivity which have not been exported yet
+type ActivityMetrics struct {
+	Owner      string
+	Repository string
+	Branch     string
+
+	// Completed whether the pipeline has completed and its metrics have not been recorded yet
+	Completed bool
+	Status    v1.ActivityStatusType
+	Duration  time.Duration
+	QueueTime time.Duration
+
+	Promotions []PromotionMetrics
+}
+
+// IsEmpty returns true if there are no metrics to record
+func (m *ActivityMetrics) IsEmpty() bool {
+	return !m.Completed && len(m.Promotions) == 0
+}
+
+// ComputeActivityMetrics returns the metrics of the activity which have not been recorded yet
+func ComputeActivityMetrics(activity *v1.PipelineActivity) *ActivityMetrics {
+	spec := &activity.Spec
+	answer := &ActivityMetrics{
+		Owner:      spec.GitOwner,
+		Repository: spec.GitRepository,
+		Branch:     pipelineBranch(spec.Pipeline),
+		Status:     spec.Status,
+	}
+	annotations := activity.Annotations
+	if annotations[AnnotationMetricsRecorded] != "true" && spec.Status.IsTerminated() && spec.StartedTimestamp != nil && spec.CompletedTimestamp != nil {
+		answer.Completed = true
+		answer.Duration = elapsed(spec.StartedTimestamp, spec.CompletedTimestamp)
+		started := firstStepStarted(spec.Steps)
+		if started != nil {
+			answer.QueueTime = elapsed(spec.StartedTimestamp, started)
+		}
+	}
+
+	recorded := recordedPromotions(activity)
+	for _, step := range spec.Steps {
+		promote := step.Promote
+		if promote == nil || promote.Environment == "" || util.StringArrayIndex(recorded, promote.Environment) >= 0 {
+			continue
+		}
+		if promote.Status.IsTerminated() && promote.StartedTimestamp != nil && promote.CompletedTimestamp != nil {
+			answer.Promotions = append(answer.Promotions, PromotionMetrics{
+				Environment: promote.Environment,
+				Status:      promote.Status,
+				Duration:    elapsed(promote.StartedTimestamp, promote.CompletedTimestamp),
+			})
+		}
+	}
+	return answer
+}
+
+// AnnotateActivity adds the annotations to the activity which record that the given metrics have been exported
+func AnnotateActivity(activity *v1.PipelineActivity, metrics *ActivityMetrics) {
+	if activity.Annotations == nil {
+		activity.Annotations = map[string]string{}
+	}
+	if metrics.Completed {
+		activity.Annotations[AnnotationMetricsRecorded] = "true"
+		activity.Annotations[AnnotationPipelineDuration] = formatSeconds(metrics.Duration)
+		activity.Annotations[AnnotationPipelineQueueTime] = formatSeconds(metrics.QueueTime)
+	}
+	if len(metrics.Promotions) > 0 {
+		recorded := recordedPromotions(activity)
+		for _, p := range metrics.Promotions {
+			recorded = append(recorded, p.Environment)
+		}
+		activity.Annotations[AnnotationPromotionsRecorded] = strings.Join(recorded, ",")
+	}
+}
+
+// PipelineMetrics the prometheus metrics of pipelines and promotions
+type PipelineMetrics struct {
+	runs              *prometheus.CounterVec
+	duration          *prometheus.HistogramVec
+	queueTime         *prometheus.HistogramVec
+	promotions        *prometheus.CounterVec
+	promotionDuration *prometheus.HistogramVec
+}
+
+// NewPipelineMetrics creates the pipeline metrics and registers them with the given registerer
+func NewPipelineMetrics(registerer prometheus.Registerer) (*PipelineMetrics, error) {
+	pipelineLabels := []string{"owner", "repository", "branch", "status"}
+	promotionLabels := []string{"owner", "repository", "environment", "status"}
+	buckets := prometheus.ExponentialBuckets(15, 2, 10)
+	m := &PipelineMetrics{
+		runs: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricPipelineRuns,
+			Help: "The number of completed pipelines",
+		}, pipelineLabels),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricPipelineDuration,
+			Help:    "The duration of completed pipelines in seconds",
+			Buckets: buckets,
+		}, pipelineLabels),
+		queueTime: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricPipelineQueueTime,
+			Help:    "The number of seconds pipelines waited before their first stage started",
+			Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+		}, []string{"owner", "repository"}),
+		promotions: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: MetricPromotions,
+			Help: "The number of completed promotions",
+		}, promotionLabels),
+		promotionDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    MetricPromotionDuration,
+			Help:    "The duration of completed promotions in seconds",
+			Buckets: buckets,
+		}, promotionLabels),
+	}
+	for _, c := range []prometheus.Collector{m.runs, m.duration, m.queueTime, m.promotions, m.promotionDuration} {
+		err := registerer.Register(c)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return m, nil
+}
+
+// Record records the given metrics
+func (m *PipelineMetrics) Record(metrics *ActivityMetrics) {
+	if metrics.Completed {
+		status := string(metrics.Status)
+		m.runs.WithLabelValues(metrics.Owner, metrics.Repository, metrics.Branch, status).Inc()
+		m.duration.WithLabelValues(metrics.Owner, metrics.Repository, metrics.Branch, status).Observe(metrics.Duration.Seconds())
+		m.queueTime.WithLabelValues(metrics.Owner, metrics.Repository).Observe(metrics.QueueTime.Seconds())
+	}
+	for _, p := range metrics.Promotions {
+		status := string(p.Status)
+		m.promotions.WithLabelValues(metrics.Owner, metrics.Repository, p.Environment, status).Inc()
+		m.promotionDuration.WithLabelValues(metrics.Owner, metrics.Repository, p.Environment, status).Observe(p.Duration.Seconds())
+	}
+}
+
+func recordedPromotions(activity *v1.PipelineActivity) []string {
+	answer := []string{}
+	for _, env := range strings.Split(activity.Annotations[AnnotationPromotionsRecorded], ",") {
+		if env != "" {
+			answer = append(answer, env)
+		}
+	}
+	return answer
+}
+
+// firstStepStarted returns the time the first stage or step of the pipeline started
+func firstStepStarted(steps []v1.PipelineActivityStep) *metav1.Time {
+	var answer *metav1.Time
+	for _, step := range steps {
+		var started *metav1.Time
+		switch {
+		case step.Stage != nil:
+			started = step.Stage.StartedTimestamp
+		case step.Promote != nil:
+			started = step.Promote.StartedTimestamp
+		case step.Preview != nil:
+			started = step.Preview.StartedTimestamp
+		}
+		if started != nil && (answer == nil || started.Before(answer)) {
+			answer = started
+		}
+	}
+	return answer
+}
+
+// pipelineBranch returns the branch name from a pipeline name of the form owner/repository/branch
+func pipelineBranch(pipeline string) string {
+	paths := strings.Split(pipeline, "/")
+	if len(paths) < 3 {
+		return ""
+	}
+	return paths[len(paths)-1]
+}
+
+func elapsed(from *metav1.Time, to *metav1.Time) time.Duration {
+	d := to.Sub(from.Time)
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+func formatSeconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', 0, 64)
+}
@@ -0,0 +1,83 @@
+package observability_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
+	"github.com/jenkins-x/jx/pkg/observability"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestComputeAndAnnotateActivityMetrics(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2018, 7, 1, 10, 0, 0, 0, time.UTC)
+	at := func(seconds int) *metav1.Time {
+		t := metav1.NewTime(start.Add(time.Duration(seconds) * time.Second))
+		return &t
+	}
+	activity := &v1.PipelineActivity{
+		ObjectMeta: metav1.ObjectMeta{Name: "myorg-myapp-master-1"},
+		Spec: v1.PipelineActivitySpec{
+			Pipeline:           "myorg/myapp/master",
+			GitOwner:           "myorg",
+			GitRepository:      "myapp",
+			Status:             v1.ActivityStatusTypeSucceeded,
+			StartedTimestamp:   at(0),
+			CompletedTimestamp: at(300),
+			Steps: []v1.PipelineActivityStep{
+				{
+					Kind: v1.ActivityStepKindTypeStage,
+					Stage: &v1.StageActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{StartedTimestamp: at(20)},
+					},
+				},
+				{
+					Kind: v1.ActivityStepKindTypePromote,
+					Promote: &v1.PromoteActivityStep{
+						CoreActivityStep: v1.CoreActivityStep{
+							Status:             v1.ActivityStatusTypeSucceeded,
+							StartedTimestamp:   at(200),
+							CompletedTimestamp: at(290),
+						},
+						Environment: "staging",
+					},
+				},
+			},
+		},
+	}
+
+	metrics := observability.ComputeActivityMetrics(activity)
+	require.False(t, metrics.IsEmpty())
+	assert.True(t, metrics.Completed)
+	assert.Equal(t, "master", metrics.Branch)
+	assert.Equal(t, 300*time.Second, metrics.Duration)
+	assert.Equal(t, 20*time.Second, metrics.QueueTime)
+	require.Len(t, metrics.Promotions, 1)
+	assert.Equal(t, "staging", metrics.Promotions[0].Environment)
+	assert.Equal(t, 90*time.Second, metrics.Promotions[0].Duration)
+
+	registry := prometheus.NewRegistry()
+	pipelineMetrics, err := observability.NewPipelineMetrics(registry)
+	require.NoError(t, err)
+	pipelineMetrics.Record(metrics)
+
+	families, err := registry.Gather()
+	require.NoError(t, err)
+	names := []string{}
+	for _, f := range families {
+		names = append(names, f.GetName())
+	}
+	assert.Contains(t, names, observability.MetricPipelineRuns)
+	assert.Contains(t, names, observability.MetricPromotionDuration)
+
+	observability.AnnotateActivity(activity, metrics)
+	assert.Equal(t, "300", activity.Annotations[observability.AnnotationPipelineDuration])
+	assert.Equal(t, "20", activity.Annotations[observability.AnnotationPipelineQueueTime])
+	assert.Equal(t, "staging", activity.Annotations[observability.AnnotationPromotionsRecorded])
+
+	assert.True(t, observability.ComputeActivityMetrics(activity).IsEmpty(), "metrics should only be recorded once")
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/jenkins-x/jx/pkg/apis/jenkins.io/v1"
 	"github.com/jenkins-x/jx/pkg/auth"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/log"
 	"github.com/jenkins-x/jx/pkg/util"
 )
@@ -44,7 +45,7 @@ func NewElasticsearchProvider(server *auth.AuthServer, user *auth.UserAuth) (Pip
 	provider := ElasticsearchProvider{
 		BaseURL:   server.URL,
 		BasicAuth: basicAuth,
-		Client:    http.DefaultClient,
+		Client:    httpclient.NewBaseClient(),
 	}
 
 	return &provider, nil
@@ -0,0 +1,111 @@
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// RegoQuery the query evaluated for each resource. Rego policies should be in package 'jx' and add
+	// a message to the 'deny' set for each violation
+	RegoQuery = "data.jx.deny"
+
+	// PolicyRego the name of the policy reported for violations found by rego policies
+	PolicyRego = "rego"
+)
+
+// HasRegoPolicies returns true if the given directory tree contains any rego policy files
+func HasRegoPolicies(dir string) (bool, error) {
+	found := false
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() && info.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if !info.IsDir() && filepath.Ext(path) == ".rego" {
+			found = true
+		}
+		return nil
+	})
+	return found, err
+}
+
+// EvaluateRego evaluates the rego policies in the policy directory against each manifest using the opa binary
+func EvaluateRego(policyDir string, manifests []*Manifest) ([]*Violation, error) {
+	answer := []*Violation{}
+	tmpDir, err := ioutil.TempDir("", "jx-policy-")
+	if err != nil {
+		return answer, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	inputFile := filepath.Join(tmpDir, "input.json")
+	for _, m := range manifests {
+		data, err := json.Marshal(m.Object)
+		if err != nil {
+			return answer, err
+		}
+		err = ioutil.WriteFile(inputFile, data, util.DefaultWritePermissions)
+		if err != nil {
+			return answer, err
+		}
+		cmd := util.Command{
+			Name: "opa",
+			Args: []string{"eval", "--format", "json", "--data", policyDir, "--input", inputFile, RegoQuery},
+		}
+		output, err := cmd.RunWithoutRetry()
+		if err != nil {
+			return answer, errors.Wrapf(err, "failed to evaluate the rego policies in %s", policyDir)
+		}
+		messages, err := parseRegoMessages(output)
+		if err != nil {
+			return answer, err
+		}
+		for _, message := range messages {
+			answer = append(answer, newViolation(PolicyRego, m, "%s", message))
+		}
+	}
+	return answer, nil
+}
+
+type regoResults struct {
+	Result []struct {
+		Expressions []struct {
+			Value interface{} `json:"value"`
+		} `json:"expressions"`
+	} `json:"result"`
+}
+
+// parseRegoMessages parses the deny messages from the JSON output of 'opa eval'
+func parseRegoMessages(output string) ([]string, error) {
+	answer := []string{}
+	results := regoResults{}
+	err := json.Unmarshal([]byte(output), &results)
+	if err != nil {
+		return answer, errors.Wrap(err, "failed to parse the output of opa eval")
+	}
+	for _, result := range results.Result {
+		for _, expression := range result.Expressions {
+			values, ok := expression.Value.([]interface{})
+			if !ok {
+				continue
+			}
+			for _, value := range values {
+				text, ok := value.(string)
+				if !ok {
+					text = fmt.Sprintf("%v", value)
+				}
+				answer = append(answer, text)
+			}
+		}
+	}
+	return answer, nil
+}
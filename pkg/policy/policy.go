@@ -0,0 +1,276 @@
+package policy
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ConfigFileName the name of the file in the root of a policy repository which configures the built in policies
+	ConfigFileName = "jx-policy.yml"
+
+	// PolicyNoLatestTag the built in policy which rejects images using the latest tag or no tag at all
+	PolicyNoLatestTag = "no-latest-tag"
+
+	// PolicyResourceLimits the built in policy which requires containers to specify CPU and memory limits
+	PolicyResourceLimits = "resource-limits"
+
+	// PolicyAllowedRegistries the built in policy which only allows images from the configured registries
+	PolicyAllowedRegistries = "allowed-registries"
+
+	defaultRegistry = "docker.io"
+)
+
+// Config configures the built in policies
+type Config struct {
+	// NoLatestTag rejects images which use the latest tag or no tag
+	NoLatestTag bool `json:"noLatestTag"`
+	// RequireResourceLimits rejects containers which do not specify CPU and memory limits
+	RequireResourceLimits bool `json:"requireResourceLimits"`
+	// AllowedRegistries if specified only images from these registries or registry prefixes are allowed
+	AllowedRegistries []string `json:"allowedRegistries,omitempty"`
+}
+
+// DefaultConfig returns the configuration used if a policy repository does not contain a configuration file
+func DefaultConfig() *Config {
+	return &Config{
+		NoLatestTag:           true,
+		RequireResourceLimits: true,
+	}
+}
+
+// LoadConfig loads the configuration of the built in policies from the given policy directory
+func LoadConfig(dir string) (*Config, error) {
+	fileName := filepath.Join(dir, ConfigFileName)
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return nil, err
+	}
+	config := DefaultConfig()
+	if !exists {
+		return config, nil
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load file %s", fileName)
+	}
+	err = yaml.Unmarshal(data, config)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal YAML file %s", fileName)
+	}
+	return config, nil
+}
+
+// Manifest a kubernetes resource rendered from a chart
+type Manifest struct {
+	File   string
+	Object map[string]interface{}
+}
+
+// Kind returns the kind of the resource
+func (m *Manifest) Kind() string {
+	return stringValue(m.Object, "kind")
+}
+
+// Name returns the name of the resource
+func (m *Manifest) Name() string {
+	metadata, _ := m.Object["metadata"].(map[string]interface{})
+	return stringValue(metadata, "name")
+}
+
+// Violation a failure of a resource to comply with a policy
+type Violation struct {
+	Policy  string
+	Kind    string
+	Name    string
+	File    string
+	Message string
+}
+
+// LoadManifests loads all the kubernetes resources in the YAML files in the given directory tree
+func LoadManifests(dir string) ([]*Manifest, error) {
+	answer := []*Manifest{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		ext := filepath.Ext(path)
+		if ext != ".yaml" && ext != ".yml" {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+		for _, doc := range bytes.Split(data, []byte("\n---")) {
+			if len(bytes.TrimSpace(doc)) == 0 {
+				continue
+			}
+			object := map[string]interface{}{}
+			err = yaml.Unmarshal(doc, &object)
+			if err != nil {
+				return errors.Wrapf(err, "failed to parse YAML in file %s", path)
+			}
+			if len(object) == 0 || stringValue(object, "kind") == "" {
+				continue
+			}
+			answer = append(answer, &Manifest{File: rel, Object: object})
+		}
+		return nil
+	})
+	return answer, err
+}
+
+// Validate validates the manifests against the built in policies
+func Validate(manifests []*Manifest, config *Config) []*Violation {
+	answer := []*Violation{}
+	for _, m := range manifests {
+		for _, container := range podContainers(m.Object) {
+			name := stringValue(container, "name")
+			image := stringValue(container, "image")
+			if config.NoLatestTag && usesLatestTag(image) {
+				answer = append(answer, newViolation(PolicyNoLatestTag, m, "container %s uses image %s which does not have a fixed version tag", name, image))
+			}
+			if len(config.AllowedRegistries) > 0 && !isAllowedRegistry(image, config.AllowedRegistries) {
+				answer = append(answer, newViolation(PolicyAllowedRegistries, m, "container %s uses image %s which is not from one of the allowed registries: %s", name, image, strings.Join(config.AllowedRegistries, ", ")))
+			}
+			if config.RequireResourceLimits {
+				missing := missingLimits(container)
+				if len(missing) > 0 {
+					answer = append(answer, newViolation(PolicyResourceLimits, m, "container %s does not specify resource limits for %s", name, strings.Join(missing, ", ")))
+				}
+			}
+		}
+	}
+	return answer
+}
+
+// SortViolations sorts the violations by file, resource and policy
+func SortViolations(violations []*Violation) {
+	sort.SliceStable(violations, func(i, j int) bool {
+		v1 := violations[i]
+		v2 := violations[j]
+		if v1.File != v2.File {
+			return v1.File < v2.File
+		}
+		if v1.Kind != v2.Kind {
+			return v1.Kind < v2.Kind
+		}
+		if v1.Name != v2.Name {
+			return v1.Name < v2.Name
+		}
+		return v1.Policy < v2.Policy
+	})
+}
+
+func newViolation(policy string, m *Manifest, format string, args ...interface{}) *Violation {
+	return &Violation{
+		Policy:  policy,
+		Kind:    m.Kind(),
+		Name:    m.Name(),
+		File:    m.File,
+		Message: fmt.Sprintf(format, args...),
+	}
+}
+
+// podContainers returns the containers of the pod template of the given resource if it has one
+func podContainers(object map[string]interface{}) []map[string]interface{} {
+	var podSpec map[string]interface{}
+	spec, _ := object["spec"].(map[string]interface{})
+	switch stringValue(object, "kind") {
+	case "Pod":
+		podSpec = spec
+	case "Deployment", "StatefulSet", "DaemonSet", "ReplicaSet", "ReplicationController", "Job":
+		podSpec = nestedMap(spec, "template", "spec")
+	case "CronJob":
+		podSpec = nestedMap(spec, "jobTemplate", "spec", "template", "spec")
+	}
+	answer := []map[string]interface{}{}
+	if podSpec == nil {
+		return answer
+	}
+	for _, key := range []string{"initContainers", "containers"} {
+		list, _ := podSpec[key].([]interface{})
+		for _, item := range list {
+			container, ok := item.(map[string]interface{})
+			if ok {
+				answer = append(answer, container)
+			}
+		}
+	}
+	return answer
+}
+
+func usesLatestTag(image string) bool {
+	if strings.Contains(image, "@") {
+		return false
+	}
+	name := image
+	idx := strings.LastIndex(image, "/")
+	if idx >= 0 {
+		name = image[idx+1:]
+	}
+	idx = strings.LastIndex(name, ":")
+	return idx < 0 || name[idx+1:] == "latest"
+}
+
+func isAllowedRegistry(image string, allowedRegistries []string) bool {
+	normalized := image
+	paths := strings.SplitN(image, "/", 2)
+	if len(paths) < 2 || !(strings.ContainsAny(paths[0], ".:") || paths[0] == "localhost") {
+		normalized = defaultRegistry + "/" + image
+	}
+	for _, allowed := range allowedRegistries {
+		allowed = strings.TrimSuffix(allowed, "/")
+		if normalized == allowed || strings.HasPrefix(normalized, allowed+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func missingLimits(container map[string]interface{}) []string {
+	limits := nestedMap(container, "resources", "limits")
+	answer := []string{}
+	for _, resource := range []string{"cpu", "memory"} {
+		if limits == nil || limits[resource] == nil {
+			answer = append(answer, resource)
+		}
+	}
+	return answer
+}
+
+func nestedMap(object map[string]interface{}, keys ...string) map[string]interface{} {
+	current := object
+	for _, key := range keys {
+		if current == nil {
+			return nil
+		}
+		current, _ = current[key].(map[string]interface{})
+	}
+	return current
+}
+
+func stringValue(object map[string]interface{}, key string) string {
+	if object == nil {
+		return ""
+	}
+	text, _ := object[key].(string)
+	return text
+}
@@ -0,0 +1,67 @@
+package policy_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateDefaultPolicies(t *testing.T) {
+	t.Parallel()
+	manifests, err := policy.LoadManifests(filepath.Join("test_data", "chart"))
+	require.NoError(t, err)
+	require.Len(t, manifests, 3)
+
+	violations := policy.Validate(manifests, policy.DefaultConfig())
+	policy.SortViolations(violations)
+
+	actual := []string{}
+	for _, v := range violations {
+		actual = append(actual, v.Policy+" "+v.Kind+"/"+v.Name)
+	}
+	assert.Equal(t, []string{
+		"no-latest-tag CronJob/cleanup",
+		"resource-limits CronJob/cleanup",
+		"no-latest-tag Deployment/myapp",
+		"resource-limits Deployment/myapp",
+	}, actual)
+	assert.Equal(t, "templates/cronjob.yaml", violations[0].File)
+	assert.Contains(t, violations[1].Message, "cpu")
+	assert.NotContains(t, violations[1].Message, "memory")
+	assert.Contains(t, violations[2].Message, "nginx")
+}
+
+func TestValidateConfiguredPolicies(t *testing.T) {
+	t.Parallel()
+	config, err := policy.LoadConfig("test_data")
+	require.NoError(t, err)
+	assert.False(t, config.RequireResourceLimits)
+	assert.Equal(t, []string{"gcr.io/myproject"}, config.AllowedRegistries)
+
+	manifests, err := policy.LoadManifests(filepath.Join("test_data", "chart"))
+	require.NoError(t, err)
+
+	violations := policy.Validate(manifests, config)
+	policy.SortViolations(violations)
+
+	actual := []string{}
+	for _, v := range violations {
+		actual = append(actual, v.Policy+" "+v.Kind+"/"+v.Name)
+	}
+	assert.Equal(t, []string{
+		"allowed-registries CronJob/cleanup",
+		"no-latest-tag CronJob/cleanup",
+		"allowed-registries Deployment/myapp",
+		"no-latest-tag Deployment/myapp",
+	}, actual)
+}
+
+func TestLoadConfigDefaults(t *testing.T) {
+	t.Parallel()
+	config, err := policy.LoadConfig(filepath.Join("test_data", "chart"))
+	require.NoError(t, err)
+	assert.Equal(t, policy.DefaultConfig(), config)
+}
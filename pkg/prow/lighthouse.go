@@ -0,0 +1,257 @@
+package prow
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/test-infra/prow/config"
+	"k8s.io/test-infra/prow/plugins"
+)
+
+const (
+	DefaultLighthouseReleaseName = "jx-lighthouse"
+	ChartLighthouse              = "jenkins-x/lighthouse"
+	LighthouseVersion            = "0.0.8"
+
+	// LighthouseConfigMapName the name of the ConfigMap which Lighthouse reads its configuration from
+	LighthouseConfigMapName = "lighthouse-config"
+	// LighthouseConfigKey the key of the configuration in the Lighthouse ConfigMap
+	LighthouseConfigKey = "lighthouse.yaml"
+)
+
+// LighthouseConfig the configuration of Lighthouse which, unlike Prow, keeps the ChatOps plugins and merge
+// settings of a repository together
+type LighthouseConfig struct {
+	Repositories map[string]*LighthouseRepository `json:"repositories"`
+}
+
+// LighthouseRepository the configuration of a repository in Lighthouse
+type LighthouseRepository struct {
+	Plugins []string           `json:"plugins,omitempty"`
+	Approve *LighthouseApprove `json:"approve,omitempty"`
+	// Merge whether Lighthouse merges the Pull Requests of the repository once their checks pass
+	Merge bool `json:"merge"`
+	// MergeLabels the labels a Pull Request needs before it is merged
+	MergeLabels []string `json:"mergeLabels,omitempty"`
+	// MissingLabels the labels which stop a Pull Request from being merged
+	MissingLabels []string `json:"missingLabels,omitempty"`
+	// Presubmits the names of the jobs triggered on Pull Requests
+	Presubmits []string `json:"presubmits,omitempty"`
+	// Postsubmits the names of the jobs triggered on merges
+	Postsubmits []string `json:"postsubmits,omitempty"`
+}
+
+// LighthouseApprove the settings of the approve plugin of a repository
+type LighthouseApprove struct {
+	ReviewActsAsApprove bool `json:"reviewActsAsApprove"`
+	LgtmActsAsApprove   bool `json:"lgtmActsAsApprove"`
+}
+
+// ToLighthouseConfig translates the Prow configuration and plugin configuration into the Lighthouse configuration
+func ToLighthouseConfig(prowConfig *config.Config, pluginConfig *plugins.Configuration) *LighthouseConfig {
+	answer := &LighthouseConfig{Repositories: map[string]*LighthouseRepository{}}
+	repository := func(name string) *LighthouseRepository {
+		r := answer.Repositories[name]
+		if r == nil {
+			r = &LighthouseRepository{}
+			answer.Repositories[name] = r
+		}
+		return r
+	}
+	if pluginConfig != nil {
+		for name, pluginNames := range pluginConfig.Plugins {
+			repository(name).Plugins = append([]string{}, pluginNames...)
+		}
+		for _, a := range pluginConfig.Approve {
+			for _, name := range a.Repos {
+				repository(name).Approve = &LighthouseApprove{
+					ReviewActsAsApprove: a.ReviewActsAsApprove,
+					LgtmActsAsApprove:   a.LgtmActsAsApprove,
+				}
+			}
+		}
+	}
+	if prowConfig != nil {
+		for _, q := range prowConfig.Tide.Queries {
+			for _, name := range q.Repos {
+				r := repository(name)
+				r.Merge = true
+				r.MergeLabels = append([]string{}, q.Labels...)
+				r.MissingLabels = append([]string{}, q.MissingLabels...)
+			}
+		}
+		for name, jobs := range prowConfig.Presubmits {
+			r := repository(name)
+			for _, job := range jobs {
+				r.Presubmits = append(r.Presubmits, job.Name)
+			}
+		}
+		for name, jobs := range prowConfig.Postsubmits {
+			r := repository(name)
+			for _, job := range jobs {
+				r.Postsubmits = append(r.Postsubmits, job.Name)
+			}
+		}
+	}
+	return answer
+}
+
+// ToProwPlugins translates the Lighthouse configuration back into the Prow plugin configuration and tide queries
+// so a team can switch from Lighthouse to Prow. The build specs of the jobs are not part of the Lighthouse
+// configuration so the jobs are recreated when the repositories are added to Prow
+func ToProwPlugins(lighthouseConfig *LighthouseConfig) (*plugins.Configuration, config.Tide) {
+	pluginConfig := &plugins.Configuration{
+		Plugins: map[string][]string{},
+		Approve: []plugins.Approve{},
+	}
+	tide := config.Tide{}
+	queries := map[string]int{}
+
+	names := []string{}
+	for name := range lighthouseConfig.Repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		r := lighthouseConfig.Repositories[name]
+		if len(r.Plugins) > 0 {
+			pluginConfig.Plugins[name] = append([]string{}, r.Plugins...)
+		}
+		if r.Approve != nil {
+			pluginConfig.Approve = append(pluginConfig.Approve, plugins.Approve{
+				Repos:               []string{name},
+				ReviewActsAsApprove: r.Approve.ReviewActsAsApprove,
+				LgtmActsAsApprove:   r.Approve.LgtmActsAsApprove,
+			})
+		}
+		if !r.Merge {
+			continue
+		}
+		key := fmt.Sprintf("%v/%v", r.MergeLabels, r.MissingLabels)
+		index, ok := queries[key]
+		if !ok {
+			index = len(tide.Queries)
+			queries[key] = index
+			tide.Queries = append(tide.Queries, config.TideQuery{
+				Labels:        append([]string{}, r.MergeLabels...),
+				MissingLabels: append([]string{}, r.MissingLabels...),
+			})
+		}
+		tide.Queries[index].Repos = append(tide.Queries[index].Repos, name)
+	}
+	return pluginConfig, tide
+}
+
+// SyncLighthouseConfig translates the Prow configuration in the namespace into the Lighthouse configuration so that
+// the repositories added via jx are configured in Lighthouse the same way as in Prow
+func SyncLighthouseConfig(kubeClient kubernetes.Interface, ns string) error {
+	prowConfig := &config.Config{}
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get("config", metav1.GetOptions{})
+	if err == nil {
+		err = yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &prowConfig)
+		if err != nil {
+			return err
+		}
+	}
+	pluginConfig := &plugins.Configuration{}
+	cm, err = kubeClient.CoreV1().ConfigMaps(ns).Get("plugins", metav1.GetOptions{})
+	if err == nil {
+		err = yaml.Unmarshal([]byte(cm.Data["plugins.yaml"]), &pluginConfig)
+		if err != nil {
+			return err
+		}
+	}
+	data, err := yaml.Marshal(ToLighthouseConfig(prowConfig, pluginConfig))
+	if err != nil {
+		return err
+	}
+
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err = configMaps.Get(LighthouseConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		cm = &v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: LighthouseConfigMapName,
+			},
+			Data: map[string]string{LighthouseConfigKey: string(data)},
+		}
+		_, err = configMaps.Create(cm)
+		return err
+	}
+	if cm.Data == nil {
+		cm.Data = map[string]string{}
+	}
+	cm.Data[LighthouseConfigKey] = string(data)
+	_, err = configMaps.Update(cm)
+	return err
+}
+
+// syncLighthouseConfigIfInstalled keeps the Lighthouse configuration in step with the Prow configuration when the
+// team uses Lighthouse
+func syncLighthouseConfigIfInstalled(kubeClient kubernetes.Interface, ns string) error {
+	_, err := kubeClient.CoreV1().ConfigMaps(ns).Get(LighthouseConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	err = SyncLighthouseConfig(kubeClient, ns)
+	if err != nil {
+		return fmt.Errorf("failed to update the Lighthouse configuration in namespace %s: %v", ns, err)
+	}
+	return nil
+}
+
+// ImportLighthouseConfig creates the Prow plugin configuration and tide queries from the Lighthouse configuration
+// when a team which used Lighthouse installs Prow. Any existing Prow configuration is left untouched
+func ImportLighthouseConfig(kubeClient kubernetes.Interface, ns string) error {
+	configMaps := kubeClient.CoreV1().ConfigMaps(ns)
+	cm, err := configMaps.Get(LighthouseConfigMapName, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	lighthouseConfig := &LighthouseConfig{}
+	err = yaml.Unmarshal([]byte(cm.Data[LighthouseConfigKey]), lighthouseConfig)
+	if err != nil {
+		return fmt.Errorf("failed to parse the Lighthouse configuration in namespace %s: %v", ns, err)
+	}
+	pluginConfig, tide := ToProwPlugins(lighthouseConfig)
+
+	_, err = configMaps.Get("plugins", metav1.GetOptions{})
+	if err != nil {
+		pluginConfig.ConfigUpdater.Maps = map[string]plugins.ConfigMapSpec{
+			"prow/config.yaml":  {Name: "config"},
+			"prow/plugins.yaml": {Name: "plugins"},
+		}
+		pluginYAML, err := yaml.Marshal(pluginConfig)
+		if err != nil {
+			return err
+		}
+		_, err = configMaps.Create(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "plugins"},
+			Data:       map[string]string{"plugins.yaml": string(pluginYAML)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	_, err = configMaps.Get("config", metav1.GetOptions{})
+	if err != nil {
+		prowConfig := &config.Config{}
+		prowConfig.Tide = tide
+		configYAML, err := yaml.Marshal(prowConfig)
+		if err != nil {
+			return err
+		}
+		_, err = configMaps.Create(&v1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "config"},
+			Data:       map[string]string{"config.yaml": string(configYAML)},
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
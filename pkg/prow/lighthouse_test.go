@@ -0,0 +1,67 @@
+package prow_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testclient "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/test-infra/prow/config"
+	"k8s.io/test-infra/prow/plugins"
+)
+
+func TestLighthouseConfigRoundTrip(t *testing.T) {
+	t.Parallel()
+	prowConfig := &config.Config{}
+	prowConfig.Tide.Queries = []config.TideQuery{
+		{Repos: []string{"org/app"}, Labels: []string{"approved"}, MissingLabels: []string{"do-not-merge"}},
+		{Repos: []string{"org/env-staging"}, MissingLabels: []string{"do-not-merge"}},
+	}
+	prowConfig.Presubmits = map[string][]config.Presubmit{"org/app": {{Name: "jenkins-engine-ci"}}}
+	pluginConfig := &plugins.Configuration{
+		Plugins: map[string][]string{"org/app": {"approve", "lgtm"}, "org/env-staging": {"approve"}},
+		Approve: []plugins.Approve{{Repos: []string{"org/app"}, ReviewActsAsApprove: true}},
+	}
+
+	lighthouseConfig := prow.ToLighthouseConfig(prowConfig, pluginConfig)
+	app := lighthouseConfig.Repositories["org/app"]
+	require.NotNil(t, app)
+	assert.Equal(t, []string{"approve", "lgtm"}, app.Plugins)
+	assert.True(t, app.Merge)
+	assert.Equal(t, []string{"approved"}, app.MergeLabels)
+	assert.Equal(t, []string{"jenkins-engine-ci"}, app.Presubmits)
+	require.NotNil(t, app.Approve)
+	assert.True(t, app.Approve.ReviewActsAsApprove)
+	assert.False(t, app.Approve.LgtmActsAsApprove)
+
+	plugins, tide := prow.ToProwPlugins(lighthouseConfig)
+	assert.Equal(t, pluginConfig.Plugins, plugins.Plugins)
+	assert.Equal(t, pluginConfig.Approve, plugins.Approve)
+	require.Len(t, tide.Queries, 2)
+	assert.Equal(t, []string{"org/app"}, tide.Queries[0].Repos)
+	assert.Equal(t, []string{"approved"}, tide.Queries[0].Labels)
+	assert.Equal(t, []string{"org/env-staging"}, tide.Queries[1].Repos)
+}
+
+func TestAddApplicationSyncsLighthouseConfig(t *testing.T) {
+	t.Parallel()
+	kubeClient := testclient.NewSimpleClientset(&v1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: prow.LighthouseConfigMapName, Namespace: "jx"},
+	})
+
+	err := prow.AddApplication(kubeClient, []string{"org/app"}, "jx", "maven")
+	require.NoError(t, err)
+
+	cm, err := kubeClient.CoreV1().ConfigMaps("jx").Get(prow.LighthouseConfigMapName, metav1.GetOptions{})
+	require.NoError(t, err)
+	lighthouseConfig := &prow.LighthouseConfig{}
+	require.NoError(t, yaml.Unmarshal([]byte(cm.Data[prow.LighthouseConfigKey]), lighthouseConfig))
+	app := lighthouseConfig.Repositories["org/app"]
+	require.NotNil(t, app)
+	assert.Contains(t, app.Plugins, "trigger")
+	assert.Equal(t, []string{"release"}, app.Postsubmits)
+}
@@ -0,0 +1,20 @@
+package prow
+
+import (
+	"regexp"
+	"strings"
+)
+
+// jenkinsfileAgentRegex matches the label of the pod template used by the agent of a Jenkinsfile such as
+// label "jenkins-maven"
+var jenkinsfileAgentRegex = regexp.MustCompile(`label\s+['"]jenkins-([a-zA-Z0-9_.-]+)['"]`)
+
+// DraftPackFromJenkinsfile returns the draft pack whose build image runs the pipeline of the Jenkinsfile, based on
+// the pod template of its agent, or an empty string if the Jenkinsfile does not use a Jenkins X pod template
+func DraftPackFromJenkinsfile(jenkinsfile string) string {
+	m := jenkinsfileAgentRegex.FindStringSubmatch(jenkinsfile)
+	if len(m) < 2 {
+		return ""
+	}
+	return strings.ToLower(m[1])
+}
@@ -0,0 +1,146 @@
+package prow
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
+	prowjobv1 "k8s.io/test-infra/prow/apis/prowjobs/v1"
+	"k8s.io/test-infra/prow/config"
+	"k8s.io/test-infra/prow/plugins"
+)
+
+const (
+	// TriggerPlugin the prow plugin which starts the pipelines of a repository on pushes and Pull Request comments
+	TriggerPlugin = "trigger"
+
+	prowJobsAPIPath = "/apis/prow.k8s.io/v1"
+)
+
+// SetTriggersPaused pauses or resumes the triggering of the pipelines of the repository by removing or adding the
+// trigger plugin. All other plugins, such as approve and lgtm, keep working while the triggers are paused
+func SetTriggersPaused(kubeClient kubernetes.Interface, repo string, ns string, paused bool) error {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get("plugins", metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to load the prow plugins config in namespace %s: %v", ns, err)
+	}
+	pluginConfig := &plugins.Configuration{}
+	err = yaml.Unmarshal([]byte(cm.Data["plugins.yaml"]), &pluginConfig)
+	if err != nil {
+		return err
+	}
+	repoPlugins, ok := pluginConfig.Plugins[repo]
+	if !ok {
+		return fmt.Errorf("the repository %s is not configured in prow", repo)
+	}
+	answer := []string{}
+	for _, p := range repoPlugins {
+		if p != TriggerPlugin {
+			answer = append(answer, p)
+		}
+	}
+	if !paused {
+		answer = append(answer, TriggerPlugin)
+	}
+	pluginConfig.Plugins[repo] = answer
+	pluginYAML, err := yaml.Marshal(pluginConfig)
+	if err != nil {
+		return err
+	}
+	cm.Data["plugins.yaml"] = string(pluginYAML)
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Update(cm)
+	return err
+}
+
+// PostsubmitJobs returns the ProwJobs which re-run the postsubmit pipelines of the repository for the commit of the
+// branch
+func PostsubmitJobs(kubeClient kubernetes.Interface, ns string, org string, repo string, branch string, sha string) ([]prowjobv1.ProwJob, error) {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get("config", metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the prow config in namespace %s: %v", ns, err)
+	}
+	prowConfig := &config.Config{}
+	err = yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &prowConfig)
+	if err != nil {
+		return nil, err
+	}
+	answer := []prowjobv1.ProwJob{}
+	for _, p := range prowConfig.Postsubmits[org+"/"+repo] {
+		if !runsAgainstBranch(p.Brancher, branch) {
+			continue
+		}
+		answer = append(answer, newProwJob(prowjobv1.ProwJobSpec{
+			Type:      prowjobv1.PostsubmitJob,
+			Agent:     prowjobv1.ProwJobAgent(p.Agent),
+			Cluster:   p.Cluster,
+			Job:       p.Name,
+			Report:    true,
+			PodSpec:   p.Spec,
+			BuildSpec: p.BuildSpec,
+			Refs: &prowjobv1.Refs{
+				Org:     org,
+				Repo:    repo,
+				BaseRef: branch,
+				BaseSHA: sha,
+			},
+		}))
+	}
+	if len(answer) == 0 {
+		return nil, fmt.Errorf("no postsubmit jobs are configured in prow for the branch %s of %s/%s", branch, org, repo)
+	}
+	return answer, nil
+}
+
+// CreateProwJob creates the ProwJob so that prow starts its pipeline
+func CreateProwJob(kubeClient kubernetes.Interface, ns string, job *prowjobv1.ProwJob) error {
+	discovery := kubeClient.Discovery()
+	if discovery == nil || discovery.RESTClient() == nil {
+		return fmt.Errorf("no REST client available to create the ProwJob %s", job.Name)
+	}
+	body, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	err = discovery.RESTClient().Post().AbsPath(prowJobsAPIPath + "/namespaces/" + ns + "/prowjobs").Body(body).Do().Error()
+	if err != nil {
+		return fmt.Errorf("failed to create the ProwJob %s for %s in namespace %s: %v", job.Name, job.Spec.Job, ns, err)
+	}
+	return nil
+}
+
+func newProwJob(spec prowjobv1.ProwJobSpec) prowjobv1.ProwJob {
+	return prowjobv1.ProwJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "prow.k8s.io/v1",
+			Kind:       "ProwJob",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: string(uuid.NewUUID()),
+			Labels: map[string]string{
+				"created-by-prow":  "true",
+				"prow.k8s.io/type": string(spec.Type),
+			},
+			Annotations: map[string]string{
+				"prow.k8s.io/job": spec.Job,
+			},
+		},
+		Spec: spec,
+		Status: prowjobv1.ProwJobStatus{
+			StartTime: metav1.Now(),
+			State:     prowjobv1.TriggeredState,
+		},
+	}
+}
+
+// runsAgainstBranch matches the branches literally as the regular expressions of the brancher are only compiled
+// when prow loads its config
+func runsAgainstBranch(b config.Brancher, branch string) bool {
+	if util.Contains(b.SkipBranches, branch) {
+		return false
+	}
+	return len(b.Branches) == 0 || util.Contains(b.Branches, branch)
+}
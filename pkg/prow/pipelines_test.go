@@ -0,0 +1,72 @@
+package prow_test
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/prow"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	prowjobv1 "k8s.io/test-infra/prow/apis/prowjobs/v1"
+	"k8s.io/test-infra/prow/plugins"
+)
+
+func TestSetTriggersPaused(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prow.Application
+	require.NoError(t, o.AddProwPlugins())
+
+	repoPlugins := func() []string {
+		cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get("plugins", metav1.GetOptions{})
+		require.NoError(t, err)
+		pluginConfig := &plugins.Configuration{}
+		require.NoError(t, yaml.Unmarshal([]byte(cm.Data["plugins.yaml"]), pluginConfig))
+		return pluginConfig.Plugins["test/repo"]
+	}
+	assert.Contains(t, repoPlugins(), prow.TriggerPlugin)
+
+	require.NoError(t, prow.SetTriggersPaused(o.KubeClient, "test/repo", o.NS, true))
+	assert.NotContains(t, repoPlugins(), prow.TriggerPlugin)
+	assert.Contains(t, repoPlugins(), "approve")
+
+	require.NoError(t, prow.SetTriggersPaused(o.KubeClient, "test/repo", o.NS, false))
+	assert.Contains(t, repoPlugins(), prow.TriggerPlugin)
+	require.NoError(t, prow.SetTriggersPaused(o.KubeClient, "test/repo", o.NS, false))
+	count := 0
+	for _, p := range repoPlugins() {
+		if p == prow.TriggerPlugin {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count, "resuming twice should not add the trigger plugin twice")
+
+	assert.Error(t, prow.SetTriggersPaused(o.KubeClient, "test/other", o.NS, true))
+}
+
+func TestPostsubmitJobs(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prow.Application
+	o.DraftPack = "maven"
+	require.NoError(t, o.AddProwConfig())
+
+	jobs, err := prow.PostsubmitJobs(o.KubeClient, o.NS, "test", "repo", "master", "abc123")
+	require.NoError(t, err)
+	require.Len(t, jobs, 1)
+	job := jobs[0]
+	assert.NotEmpty(t, job.Name)
+	assert.Equal(t, "release", job.Spec.Job)
+	assert.Equal(t, prowjobv1.ProwJobType(prowjobv1.PostsubmitJob), job.Spec.Type)
+	assert.Equal(t, prowjobv1.ProwJobAgent(prowjobv1.KnativeBuildAgent), job.Spec.Agent)
+	assert.Equal(t, prowjobv1.TriggeredState, job.Status.State)
+	assert.Equal(t, "master", job.Spec.Refs.BaseRef)
+	assert.Equal(t, "abc123", job.Spec.Refs.BaseSHA)
+	assert.NotNil(t, job.Spec.BuildSpec)
+
+	_, err = prow.PostsubmitJobs(o.KubeClient, o.NS, "test", "repo", "feature", "abc123")
+	assert.Error(t, err)
+}
@@ -2,6 +2,9 @@ package prow
 
 import (
 	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
 
 	"github.com/ghodss/yaml"
 	"github.com/jenkins-x/jx/pkg/log"
@@ -30,6 +33,13 @@ const (
 
 type Kind string
 
+// MonorepoApp is an application which lives in a sub directory of a monorepo
+type MonorepoApp struct {
+	Name      string
+	Dir       string
+	DraftPack string
+}
+
 // Options for prow
 type Options struct {
 	KubeClient           kubernetes.Interface
@@ -38,6 +48,7 @@ type Options struct {
 	Kind                 Kind
 	DraftPack            string
 	EnvironmentNamespace string
+	Apps                 []MonorepoApp
 }
 
 func add(kubeClient kubernetes.Interface, repos []string, ns string, kind Kind, draftPack, environmentNamespace string) error {
@@ -59,7 +70,11 @@ func add(kubeClient kubernetes.Interface, repos []string, ns string, kind Kind,
 		return err
 	}
 
-	return o.AddProwPlugins()
+	err = o.AddProwPlugins()
+	if err != nil {
+		return err
+	}
+	return syncLighthouseConfigIfInstalled(kubeClient, ns)
 }
 
 func AddEnvironment(kubeClient kubernetes.Interface, repos []string, ns, environmentNamespace string) error {
@@ -70,6 +85,31 @@ func AddApplication(kubeClient kubernetes.Interface, repos []string, ns, draftPa
 	return add(kubeClient, repos, ns, Application, draftPack, "")
 }
 
+// AddMonorepoApplications adds the prow configuration for a monorepo with the given applications in sub directories.
+// Each application gets its own presubmit job which only runs when files in its directory change
+// and its own release postsubmit job which runs in its directory
+func AddMonorepoApplications(kubeClient kubernetes.Interface, repo string, ns string, apps []MonorepoApp) error {
+	if len(apps) == 0 {
+		return fmt.Errorf("no monorepo applications defined")
+	}
+	o := Options{
+		KubeClient: kubeClient,
+		Repos:      []string{repo},
+		NS:         ns,
+		Kind:       Application,
+		Apps:       apps,
+	}
+	err := o.AddProwConfig()
+	if err != nil {
+		return err
+	}
+	err = o.AddProwPlugins()
+	if err != nil {
+		return err
+	}
+	return syncLighthouseConfigIfInstalled(kubeClient, ns)
+}
+
 // create git repo?
 // get config and update / overwrite repos?
 // should we get the existing CM and do a diff?
@@ -245,6 +285,44 @@ func (o *Options) createPreSubmitApplication() config.Presubmit {
 	return ps
 }
 
+func (o *Options) createPreSubmitMonorepoApplication(app MonorepoApp) config.Presubmit {
+	appOptions := *o
+	appOptions.DraftPack = app.DraftPack
+	ps := appOptions.createPreSubmitApplication()
+
+	dir := strings.Trim(filepath.ToSlash(app.Dir), "/")
+	ps.Name = app.Name + "-ci"
+	ps.Context = app.Name + "-ci"
+	ps.RunIfChanged = "^" + regexp.QuoteMeta(dir) + "/"
+	ps.RerunCommand = "/test " + app.Name
+	ps.Trigger = "(?m)^/test( all| this| " + regexp.QuoteMeta(app.Name) + "),?(\\s+|$)"
+	setWorkingDir(ps.BuildSpec, dir)
+	return ps
+}
+
+// createPostSubmitMonorepoApplication creates the release job for an application in a monorepo. Note that
+// postsubmit jobs do not support filtering on the changed files so the release pipeline runs on every merge
+func (o *Options) createPostSubmitMonorepoApplication(app MonorepoApp) config.Postsubmit {
+	appOptions := *o
+	appOptions.DraftPack = app.DraftPack
+	ps := appOptions.createPostSubmitApplication()
+
+	dir := strings.Trim(filepath.ToSlash(app.Dir), "/")
+	ps.Name = "release-" + app.Name
+	setWorkingDir(ps.BuildSpec, dir)
+	return ps
+}
+
+func setWorkingDir(spec *build.BuildSpec, dir string) {
+	if spec == nil {
+		return
+	}
+	for i := range spec.Steps {
+		spec.Steps[i].WorkingDir = "/workspace/" + dir
+		spec.Steps[i].Env = append(spec.Steps[i].Env, v1.EnvVar{Name: "APP_DIR", Value: dir})
+	}
+}
+
 func (o *Options) addRepoToTideConfig(t *config.Tide, repo string, kind Kind) error {
 	switch o.Kind {
 	case Application:
@@ -324,16 +402,23 @@ func (o *Options) createTide() config.Tide {
 
 // AddProwConfig adds config to prow
 func (o *Options) AddProwConfig() error {
-	var preSubmit config.Presubmit
-	var postSubmit config.Postsubmit
+	var preSubmits []config.Presubmit
+	var postSubmits []config.Postsubmit
 
 	switch o.Kind {
 	case Application:
-		preSubmit = o.createPreSubmitApplication()
-		postSubmit = o.createPostSubmitApplication()
+		if len(o.Apps) > 0 {
+			for _, app := range o.Apps {
+				preSubmits = append(preSubmits, o.createPreSubmitMonorepoApplication(app))
+				postSubmits = append(postSubmits, o.createPostSubmitMonorepoApplication(app))
+			}
+		} else {
+			preSubmits = []config.Presubmit{o.createPreSubmitApplication()}
+			postSubmits = []config.Postsubmit{o.createPostSubmitApplication()}
+		}
 	case Environment:
-		preSubmit = o.createPreSubmitEnvironment()
-		postSubmit = o.createPostSubmitEnvironment()
+		preSubmits = []config.Presubmit{o.createPreSubmitEnvironment()}
+		postSubmits = []config.Postsubmit{o.createPostSubmitEnvironment()}
 	default:
 		return fmt.Errorf("unknown prow config kind %s", o.Kind)
 	}
@@ -366,8 +451,8 @@ func (o *Options) AddProwConfig() error {
 	}
 
 	for _, r := range o.Repos {
-		prowConfig.Presubmits[r] = []config.Presubmit{preSubmit}
-		prowConfig.Postsubmits[r] = []config.Postsubmit{postSubmit}
+		prowConfig.Presubmits[r] = preSubmits
+		prowConfig.Postsubmits[r] = postSubmits
 	}
 
 	configYAML, err := yaml.Marshal(prowConfig)
@@ -460,3 +545,70 @@ func (o *Options) AddProwPlugins() error {
 
 	return err
 }
+
+// SetPromotionGated configures prow for an environment repository which has promotion gates. Tide does not merge
+// the Pull Requests of a gated repository so that only the promotion code merges them once the gates are open and
+// the approve plugin only treats explicit '/approve' commands as approvals
+func SetPromotionGated(kubeClient kubernetes.Interface, repo string, ns string, gated bool) error {
+	cm, err := kubeClient.CoreV1().ConfigMaps(ns).Get("config", metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to load the prow config in namespace %s: %v", ns, err)
+	}
+	prowConfig := &config.Config{}
+	err = yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &prowConfig)
+	if err != nil {
+		return err
+	}
+	if gated {
+		for index, q := range prowConfig.Tide.Queries {
+			repos := []string{}
+			for _, r := range q.Repos {
+				if r != repo {
+					repos = append(repos, r)
+				}
+			}
+			prowConfig.Tide.Queries[index].Repos = repos
+		}
+	} else {
+		o := &Options{Kind: Environment}
+		err = o.addRepoToTideConfig(&prowConfig.Tide, repo, Environment)
+		if err != nil {
+			return err
+		}
+	}
+	configYAML, err := yaml.Marshal(prowConfig)
+	if err != nil {
+		return err
+	}
+	cm.Data["config.yaml"] = string(configYAML)
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Update(cm)
+	if err != nil {
+		return err
+	}
+
+	cm, err = kubeClient.CoreV1().ConfigMaps(ns).Get("plugins", metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to load the prow plugins config in namespace %s: %v", ns, err)
+	}
+	pluginConfig := &plugins.Configuration{}
+	err = yaml.Unmarshal([]byte(cm.Data["plugins.yaml"]), &pluginConfig)
+	if err != nil {
+		return err
+	}
+	for index, a := range pluginConfig.Approve {
+		if util.Contains(a.Repos, repo) {
+			pluginConfig.Approve[index].ReviewActsAsApprove = !gated
+			pluginConfig.Approve[index].LgtmActsAsApprove = !gated
+		}
+	}
+	pluginYAML, err := yaml.Marshal(pluginConfig)
+	if err != nil {
+		return err
+	}
+	cm.Data["plugins.yaml"] = string(pluginYAML)
+	_, err = kubeClient.CoreV1().ConfigMaps(ns).Update(cm)
+	if err != nil {
+		return err
+	}
+	return syncLighthouseConfigIfInstalled(kubeClient, ns)
+}
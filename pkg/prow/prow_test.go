@@ -268,3 +268,95 @@ func TestReplaceProwConfig(t *testing.T) {
 	assert.Equal(t, 2, len(prowConfig.Tide.Queries[0].Repos))
 	assert.Equal(t, 1, len(prowConfig.Tide.Queries[1].Repos))
 }
+
+func TestAddProwConfigMonorepo(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prow.Application
+	o.Apps = []prow.MonorepoApp{
+		{Name: "frontend", Dir: "frontend", DraftPack: "javascript"},
+		{Name: "backend", Dir: "services/backend", DraftPack: "go"},
+	}
+
+	err := o.AddProwConfig()
+	assert.NoError(t, err)
+
+	cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get("config", metav1.GetOptions{})
+	assert.NoError(t, err)
+
+	prowConfig := &config.Config{}
+	yaml.Unmarshal([]byte(cm.Data["config.yaml"]), &prowConfig)
+
+	presubmits := prowConfig.Presubmits["test/repo"]
+	assert.Equal(t, 2, len(presubmits))
+	assert.Equal(t, "frontend-ci", presubmits[0].Name)
+	assert.Equal(t, "^frontend/", presubmits[0].RunIfChanged)
+	assert.Equal(t, "^services/backend/", presubmits[1].RunIfChanged)
+	assert.Equal(t, "/workspace/services/backend", presubmits[1].BuildSpec.Steps[0].WorkingDir)
+
+	postsubmits := prowConfig.Postsubmits["test/repo"]
+	assert.Equal(t, 2, len(postsubmits))
+	assert.Equal(t, "release-backend", postsubmits[1].Name)
+	assert.Equal(t, "/workspace/services/backend", postsubmits[1].BuildSpec.Steps[0].WorkingDir)
+}
+
+func TestSetPromotionGated(t *testing.T) {
+	t.Parallel()
+	o := TestOptions{}
+	o.Setup()
+	o.Kind = prow.Environment
+	o.EnvironmentNamespace = "jx-production"
+
+	assert.NoError(t, o.AddProwConfig())
+	assert.NoError(t, o.AddProwPlugins())
+
+	loadConfig := func() (*config.Config, *plugins.Configuration) {
+		cm, err := o.KubeClient.CoreV1().ConfigMaps(o.NS).Get("config", metav1.GetOptions{})
+		assert.NoError(t, err)
+		prowConfig := &config.Config{}
+		assert.NoError(t, yaml.Unmarshal([]byte(cm.Data["config.yaml"]), prowConfig))
+		cm, err = o.KubeClient.CoreV1().ConfigMaps(o.NS).Get("plugins", metav1.GetOptions{})
+		assert.NoError(t, err)
+		pluginConfig := &plugins.Configuration{}
+		assert.NoError(t, yaml.Unmarshal([]byte(cm.Data["plugins.yaml"]), pluginConfig))
+		return prowConfig, pluginConfig
+	}
+	tideRepos := func(c *config.Config) []string {
+		answer := []string{}
+		for _, q := range c.Tide.Queries {
+			answer = append(answer, q.Repos...)
+		}
+		return answer
+	}
+
+	prowConfig, _ := loadConfig()
+	assert.Contains(t, tideRepos(prowConfig), "test/repo")
+
+	assert.NoError(t, prow.SetPromotionGated(o.KubeClient, "test/repo", o.NS, true))
+	prowConfig, pluginConfig := loadConfig()
+	assert.NotContains(t, tideRepos(prowConfig), "test/repo", "tide should not merge gated promotions")
+	assert.False(t, pluginConfig.Approve[0].LgtmActsAsApprove)
+	assert.False(t, pluginConfig.Approve[0].ReviewActsAsApprove)
+
+	assert.NoError(t, prow.SetPromotionGated(o.KubeClient, "test/repo", o.NS, false))
+	prowConfig, pluginConfig = loadConfig()
+	assert.Contains(t, tideRepos(prowConfig), "test/repo")
+	assert.True(t, pluginConfig.Approve[0].LgtmActsAsApprove)
+}
+
+func TestDraftPackFromJenkinsfile(t *testing.T) {
+	t.Parallel()
+	tests := map[string]string{
+		`pipeline {
+  agent {
+    label "jenkins-maven"
+  }
+}`: "maven",
+		`pipeline { agent { label 'jenkins-nodejs' } }`: "nodejs",
+		`pipeline { agent any }`:                        "",
+	}
+	for jenkinsfile, pack := range tests {
+		assert.Equal(t, pack, prow.DraftPackFromJenkinsfile(jenkinsfile), "Jenkinsfile %s", jenkinsfile)
+	}
+}
@@ -0,0 +1,177 @@
+package quickstarts
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+const (
+	// CatalogSignatureSuffix the suffix added to the catalog URL to find its detached signature
+	CatalogSignatureSuffix = ".sig"
+)
+
+// QuickstartCatalog is an index of the quickstarts available from a git server
+type QuickstartCatalog struct {
+	Quickstarts []QuickstartCatalogEntry `json:"quickstarts,omitempty"`
+}
+
+// QuickstartCatalogEntry describes a single quickstart in a catalog
+type QuickstartCatalogEntry struct {
+	Owner          string   `json:"owner,omitempty"`
+	Name           string   `json:"name"`
+	Language       string   `json:"language,omitempty"`
+	Framework      string   `json:"framework,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	DownloadZipURL string   `json:"downloadZipUrl,omitempty"`
+}
+
+// ParseCatalog parses the YAML catalog index
+func ParseCatalog(data []byte) (*QuickstartCatalog, error) {
+	catalog := &QuickstartCatalog{}
+	err := yaml.Unmarshal(data, catalog)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse quickstart catalog: %s", err)
+	}
+	return catalog, nil
+}
+
+// ToYAML marshals the catalog into YAML
+func (c *QuickstartCatalog) ToYAML() ([]byte, error) {
+	return yaml.Marshal(c)
+}
+
+// RepositoryCatalog creates a catalog from the repositories of the given owner which match the includes and excludes
+func RepositoryCatalog(provider gits.GitProvider, owner string, includes []string, excludes []string) (*QuickstartCatalog, error) {
+	model := NewQuickstartModel()
+	err := model.LoadGithubQuickstarts(provider, owner, includes, excludes)
+	if err != nil {
+		return nil, err
+	}
+	catalog := &QuickstartCatalog{}
+	for _, q := range model.Quickstarts {
+		catalog.Quickstarts = append(catalog.Quickstarts, QuickstartCatalogEntry{
+			Owner:          q.Owner,
+			Name:           q.Name,
+			Language:       q.Language,
+			Framework:      q.Framework,
+			Tags:           q.Tags,
+			DownloadZipURL: q.DownloadZipURL,
+		})
+	}
+	return catalog, nil
+}
+
+// LoadCatalog adds all the quickstarts in the catalog to the model, downloading them via the given git provider
+func (model *QuickstartModel) LoadCatalog(provider gits.GitProvider, owner string, catalog *QuickstartCatalog) {
+	for _, e := range catalog.Quickstarts {
+		o := e.Owner
+		if o == "" {
+			o = owner
+		}
+		q := GitQuickstart(provider, o, e.Name, e.Language, e.Framework, e.Tags...)
+		if e.DownloadZipURL != "" {
+			q.DownloadZipURL = e.DownloadZipURL
+		}
+		model.Add(q)
+	}
+}
+
+// DownloadCatalogIndex downloads the catalog index at the given URL using the credentials of the git provider.
+// If a public key is specified then the detached signature of the index is downloaded and verified
+func DownloadCatalogIndex(provider gits.GitProvider, catalogURL string, publicKey string) ([]byte, error) {
+	data, err := Download(provider, catalogURL)
+	if err != nil {
+		return nil, err
+	}
+	if publicKey != "" {
+		signature, err := Download(provider, catalogURL+CatalogSignatureSuffix)
+		if err != nil {
+			return nil, fmt.Errorf("failed to download the signature of quickstart catalog %s: %s", catalogURL, err)
+		}
+		err = VerifyCatalogSignature(data, signature, publicKey)
+		if err != nil {
+			return nil, fmt.Errorf("quickstart catalog %s failed verification: %s", catalogURL, err)
+		}
+	}
+	return data, nil
+}
+
+// VerifyCatalogSignature verifies the base64 encoded RSA SHA256 signature of the catalog data using the PEM encoded public key
+func VerifyCatalogSignature(data []byte, signature []byte, publicKey string) error {
+	block, _ := pem.Decode([]byte(publicKey))
+	if block == nil {
+		return fmt.Errorf("no PEM encoded public key found")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return fmt.Errorf("failed to parse public key: %s", err)
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("public key is not an RSA key")
+	}
+	sig, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(signature)))
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %s", err)
+	}
+	hashed := sha256.Sum256(data)
+	return rsa.VerifyPKCS1v15(rsaKey, crypto.SHA256, hashed[:], sig)
+}
+
+// Download downloads the given URL using the credentials of the git provider
+func Download(provider gits.GitProvider, u string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	AddAuthHeaders(req, provider)
+	client := httpclient.NewBaseClient()
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode >= 400 {
+		return nil, fmt.Errorf("status %s when downloading %s", res.Status, u)
+	}
+	return body, nil
+}
+
+// AddAuthHeaders adds the authentication headers of the git provider's user to the request
+func AddAuthHeaders(req *http.Request, provider gits.GitProvider) {
+	if provider == nil {
+		return
+	}
+	userAuth := provider.UserAuth()
+	token := userAuth.ApiToken
+	username := userAuth.Username
+	if token == "" {
+		return
+	}
+	switch provider.Kind() {
+	case gits.KindGitlab:
+		req.Header.Set("PRIVATE-TOKEN", token)
+	case gits.KindGitea:
+		req.Header.Set("Authorization", "token "+token)
+	default:
+		if username != "" {
+			req.SetBasicAuth(username, token)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package quickstarts_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/gits"
+	"github.com/jenkins-x/jx/pkg/quickstarts"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuickstartCatalogSignature(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	publicKeyData, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyData}))
+
+	data := []byte(`quickstarts:
+- name: node-http
+  language: JavaScript
+- name: spring-boot-http-gradle
+  owner: my-quickstarts
+  language: Java
+  tags:
+  - spring
+`)
+	hashed := sha256.Sum256(data)
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+	require.NoError(t, err)
+	signature := []byte(base64.StdEncoding.EncodeToString(sig))
+
+	err = quickstarts.VerifyCatalogSignature(data, signature, publicKey)
+	assert.NoError(t, err)
+
+	tampered := append([]byte{}, data...)
+	tampered[len(tampered)-2] = 'x'
+	err = quickstarts.VerifyCatalogSignature(tampered, signature, publicKey)
+	assert.Error(t, err)
+
+	catalog, err := quickstarts.ParseCatalog(data)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(catalog.Quickstarts))
+
+	model := quickstarts.NewQuickstartModel()
+	model.LoadCatalog(&gits.FakeProvider{}, "jenkins-x-quickstarts", catalog)
+	q := model.Quickstarts["my-quickstarts/spring-boot-http-gradle"]
+	require.NotNil(t, q)
+	assert.Equal(t, "Java", q.Language)
+	assert.Equal(t, []string{"spring"}, q.Tags)
+	assert.NotNil(t, model.Quickstarts["jenkins-x-quickstarts/node-http"])
+}
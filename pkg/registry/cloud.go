@@ -0,0 +1,101 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/jenkins-x/jx/pkg/cloud/amazon"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// ecrRegistry uses the AWS API of the Elastic Container Registry
+type ecrRegistry struct {
+}
+
+func (r *ecrRegistry) ListTags(image string) ([]string, error) {
+	return amazon.ListImageTags(image)
+}
+
+func (r *ecrRegistry) DeleteTag(image string, tag string) error {
+	return amazon.DeleteImageTag(image, tag)
+}
+
+func (r *ecrRegistry) GarbageCollect() (bool, error) {
+	return false, nil
+}
+
+// gcrRegistry uses the gcloud CLI to manage the images of the Google Container Registry
+type gcrRegistry struct {
+	host string
+}
+
+func (r *gcrRegistry) ListTags(image string) ([]string, error) {
+	cmd := util.Command{
+		Name: "gcloud",
+		Args: []string{"container", "images", "list-tags", r.host + "/" + image, "--format=json"},
+	}
+	out, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return nil, err
+	}
+	images := []struct {
+		Tags []string `json:"tags"`
+	}{}
+	err = json.Unmarshal([]byte(out), &images)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the tags of image %s: %s", image, err)
+	}
+	answer := []string{}
+	for _, i := range images {
+		answer = append(answer, i.Tags...)
+	}
+	return answer, nil
+}
+
+func (r *gcrRegistry) DeleteTag(image string, tag string) error {
+	cmd := util.Command{
+		Name: "gcloud",
+		Args: []string{"container", "images", "delete", r.host + "/" + image + ":" + tag, "--quiet", "--force-delete-tags"},
+	}
+	_, err := cmd.RunWithoutRetry()
+	return err
+}
+
+func (r *gcrRegistry) GarbageCollect() (bool, error) {
+	return false, nil
+}
+
+// acrRegistry uses the az CLI to manage the images of the Azure Container Registry
+type acrRegistry struct {
+	name string
+}
+
+func (r *acrRegistry) ListTags(image string) ([]string, error) {
+	cmd := util.Command{
+		Name: "az",
+		Args: []string{"acr", "repository", "show-tags", "--name", r.name, "--repository", image, "--output", "json"},
+	}
+	out, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return nil, err
+	}
+	answer := []string{}
+	err = json.Unmarshal([]byte(out), &answer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the tags of image %s: %s", image, err)
+	}
+	return answer, nil
+}
+
+func (r *acrRegistry) DeleteTag(image string, tag string) error {
+	cmd := util.Command{
+		Name: "az",
+		Args: []string{"acr", "repository", "delete", "--name", r.name, "--image", image + ":" + tag, "--yes"},
+	}
+	_, err := cmd.RunWithoutRetry()
+	return err
+}
+
+func (r *acrRegistry) GarbageCollect() (bool, error) {
+	return false, nil
+}
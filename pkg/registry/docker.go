@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+const manifestV2MediaType = "application/vnd.docker.distribution.manifest.v2+json"
+
+// DockerRegistry uses the Docker Registry HTTP API V2. Deleting images must be enabled in the registry
+type DockerRegistry struct {
+	URL        string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// NewDockerRegistry creates a client for the Docker Registry HTTP API V2 at the given host or URL
+func NewDockerRegistry(host string, username string, password string) *DockerRegistry {
+	return &DockerRegistry{
+		URL:        registryURL(host),
+		Username:   username,
+		Password:   password,
+		HTTPClient: httpclient.NewBaseClient(),
+	}
+}
+
+// ListTags returns the tags of the image
+func (r *DockerRegistry) ListTags(image string) ([]string, error) {
+	resp, err := r.do(http.MethodGet, "/v2/"+image+"/tags/list", "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return []string{}, nil
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list the tags of image %s due to response %d: %s", image, resp.StatusCode, string(data))
+	}
+	tags := struct {
+		Tags []string `json:"tags"`
+	}{}
+	err = json.Unmarshal(data, &tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the tags of image %s: %s", image, err)
+	}
+	return tags.Tags, nil
+}
+
+// DeleteTag deletes the manifest of the image tag
+func (r *DockerRegistry) DeleteTag(image string, tag string) error {
+	resp, err := r.do(http.MethodHead, "/v2/"+image+"/manifests/"+tag, manifestV2MediaType)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if resp.StatusCode != http.StatusOK || digest == "" {
+		return fmt.Errorf("failed to find the digest of image %s:%s due to response %d", image, tag, resp.StatusCode)
+	}
+	resp, err = r.do(http.MethodDelete, "/v2/"+image+"/manifests/"+digest, "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		data, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("failed to delete image %s:%s due to response %d: %s", image, tag, resp.StatusCode, string(data))
+	}
+	return nil
+}
+
+// GarbageCollect returns false as the registry garbage collector has to be run inside the registry container
+func (r *DockerRegistry) GarbageCollect() (bool, error) {
+	return false, nil
+}
+
+func (r *DockerRegistry) do(method string, path string, accept string) (*http.Response, error) {
+	u := r.URL + path
+	req, err := http.NewRequest(method, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if r.Username != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to invoke %s %s: %s", method, u, err)
+	}
+	return resp, nil
+}
+
+// registryURL returns the URL of the registry defaulting to https if the host has no scheme
+func registryURL(host string) string {
+	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
+		host = "https://" + host
+	}
+	return strings.TrimSuffix(host, "/")
+}
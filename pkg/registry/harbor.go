@@ -0,0 +1,102 @@
+package registry
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+// HarborRegistry uses the REST API of Harbor
+type HarborRegistry struct {
+	URL        string
+	Username   string
+	Password   string
+	HTTPClient *http.Client
+}
+
+// NewHarborRegistry creates a client for the Harbor at the given host or URL
+func NewHarborRegistry(host string, username string, password string) *HarborRegistry {
+	return &HarborRegistry{
+		URL:        registryURL(host),
+		Username:   username,
+		Password:   password,
+		HTTPClient: httpclient.NewBaseClient(),
+	}
+}
+
+// ListTags returns the tags of the image
+func (r *HarborRegistry) ListTags(image string) ([]string, error) {
+	data, status, err := r.do(http.MethodGet, "/api/repositories/"+image+"/tags", nil)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return []string{}, nil
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to list the tags of image %s due to response %d: %s", image, status, string(data))
+	}
+	tags := []struct {
+		Name string `json:"name"`
+	}{}
+	err = json.Unmarshal(data, &tags)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the tags of image %s: %s", image, err)
+	}
+	answer := []string{}
+	for _, t := range tags {
+		answer = append(answer, t.Name)
+	}
+	return answer, nil
+}
+
+// DeleteTag deletes the tag of the image
+func (r *HarborRegistry) DeleteTag(image string, tag string) error {
+	data, status, err := r.do(http.MethodDelete, "/api/repositories/"+image+"/tags/"+url.PathEscape(tag), nil)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("failed to delete image %s:%s due to response %d: %s", image, tag, status, string(data))
+	}
+	return nil
+}
+
+// GarbageCollect triggers a manual garbage collection in Harbor
+func (r *HarborRegistry) GarbageCollect() (bool, error) {
+	body := []byte(`{"schedule":{"type":"Manual"}}`)
+	data, status, err := r.do(http.MethodPost, "/api/system/gc/schedule", body)
+	if err != nil {
+		return true, err
+	}
+	if status != http.StatusOK && status != http.StatusCreated {
+		return true, fmt.Errorf("failed to trigger the Harbor garbage collection due to response %d: %s", status, string(data))
+	}
+	return true, nil
+}
+
+func (r *HarborRegistry) do(method string, path string, body []byte) ([]byte, int, error) {
+	u := r.URL + path
+	req, err := http.NewRequest(method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, 0, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if r.Username != "" {
+		req.SetBasicAuth(r.Username, r.Password)
+	}
+	resp, err := r.HTTPClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to invoke %s %s: %s", method, u, err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	return data, resp.StatusCode, err
+}
@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	// KindDocker a registry implementing the Docker Registry HTTP API V2 such as the one installed with Jenkins X
+	KindDocker = "docker"
+	// KindECR the Amazon Elastic Container Registry
+	KindECR = "ecr"
+	// KindGCR the Google Container Registry
+	KindGCR = "gcr"
+	// KindACR the Azure Container Registry
+	KindACR = "acr"
+	// KindHarbor a Harbor registry
+	KindHarbor = "harbor"
+)
+
+var (
+	// Kinds the kinds of registry which are supported
+	Kinds = []string{KindDocker, KindECR, KindGCR, KindACR, KindHarbor}
+
+	previewTagRegex = regexp.MustCompile(`(?i)-PR-(\d+)-`)
+)
+
+// Registry a docker registry from which image tags can be removed
+type Registry interface {
+	// ListTags returns the tags of the image
+	ListTags(image string) ([]string, error)
+
+	// DeleteTag deletes the tag of the image
+	DeleteTag(image string, tag string) error
+
+	// GarbageCollect triggers the registry to free the storage of deleted images. Returns false if the registry
+	// does not support triggering garbage collection, typically as it is performed automatically
+	GarbageCollect() (bool, error)
+}
+
+// KindForHost returns the kind of registry for the registry host name
+func KindForHost(host string) string {
+	switch {
+	case strings.HasSuffix(host, ".amazonaws.com") && strings.Contains(host, ".ecr."):
+		return KindECR
+	case host == "gcr.io" || strings.HasSuffix(host, ".gcr.io"):
+		return KindGCR
+	case strings.HasSuffix(host, ".azurecr.io"):
+		return KindACR
+	default:
+		return KindDocker
+	}
+}
+
+// IsHTTPSOnly returns true if the kind of registry is a cloud registry which can only be accessed via https
+func IsHTTPSOnly(kind string) bool {
+	switch kind {
+	case KindECR, KindGCR, KindACR:
+		return true
+	default:
+		return false
+	}
+}
+
+// NewRegistry creates the Registry of the given kind for the registry host
+func NewRegistry(kind string, host string, username string, password string) (Registry, error) {
+	if kind == "" {
+		kind = KindForHost(host)
+	}
+	switch kind {
+	case KindDocker:
+		return NewDockerRegistry(host, username, password), nil
+	case KindHarbor:
+		return NewHarborRegistry(host, username, password), nil
+	case KindECR:
+		return &ecrRegistry{}, nil
+	case KindGCR:
+		return &gcrRegistry{host: host}, nil
+	case KindACR:
+		return &acrRegistry{name: strings.TrimSuffix(host, ".azurecr.io")}, nil
+	default:
+		return nil, fmt.Errorf("unknown registry kind %s. Supported kinds are: %s", kind, strings.Join(Kinds, ", "))
+	}
+}
+
+// PreviewPullRequestNumber returns the number of the pull request of a preview image tag such as 0.0.0-SNAPSHOT-PR-23-1
+func PreviewPullRequestNumber(tag string) (int, bool) {
+	m := previewTagRegex.FindStringSubmatch(tag + "-")
+	if len(m) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
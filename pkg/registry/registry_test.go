@@ -0,0 +1,80 @@
+package registry_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKindForHost(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, registry.KindECR, registry.KindForHost("123456789012.dkr.ecr.us-west-2.amazonaws.com"))
+	assert.Equal(t, registry.KindGCR, registry.KindForHost("eu.gcr.io"))
+	assert.Equal(t, registry.KindACR, registry.KindForHost("myregistry.azurecr.io"))
+	assert.Equal(t, registry.KindDocker, registry.KindForHost("10.0.0.1:5000"))
+}
+
+func TestIsHTTPSOnly(t *testing.T) {
+	t.Parallel()
+
+	for _, kind := range []string{registry.KindECR, registry.KindGCR, registry.KindACR} {
+		assert.True(t, registry.IsHTTPSOnly(kind), "kind %s", kind)
+	}
+	for _, kind := range []string{registry.KindDocker, registry.KindHarbor} {
+		assert.False(t, registry.IsHTTPSOnly(kind), "kind %s", kind)
+	}
+}
+
+func TestPreviewPullRequestNumber(t *testing.T) {
+	t.Parallel()
+
+	n, ok := registry.PreviewPullRequestNumber("0.0.0-SNAPSHOT-PR-23-1")
+	assert.True(t, ok)
+	assert.Equal(t, 23, n)
+
+	n, ok = registry.PreviewPullRequestNumber("0.0.0-SNAPSHOT-PR-7")
+	assert.True(t, ok)
+	assert.Equal(t, 7, n)
+
+	_, ok = registry.PreviewPullRequestNumber("0.0.12")
+	assert.False(t, ok)
+}
+
+func TestDockerRegistryDeleteTag(t *testing.T) {
+	t.Parallel()
+
+	deleted := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v2/myorg/myapp/tags/list":
+			w.Write([]byte(`{"name":"myorg/myapp","tags":["0.0.1","0.0.0-SNAPSHOT-PR-1-1"]}`))
+		case r.Method == http.MethodHead && r.URL.Path == "/v2/myorg/myapp/manifests/0.0.0-SNAPSHOT-PR-1-1":
+			w.Header().Set("Docker-Content-Digest", "sha256:abc")
+		case r.Method == http.MethodDelete:
+			deleted = r.URL.Path
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	r, err := registry.NewRegistry(registry.KindDocker, server.URL, "", "")
+	require.NoError(t, err)
+
+	tags, err := r.ListTags("myorg/myapp")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"0.0.1", "0.0.0-SNAPSHOT-PR-1-1"}, tags)
+
+	err = r.DeleteTag("myorg/myapp", "0.0.0-SNAPSHOT-PR-1-1")
+	require.NoError(t, err)
+	assert.Equal(t, "/v2/myorg/myapp/manifests/sha256:abc", deleted)
+
+	err = r.DeleteTag("myorg/myapp", "missing")
+	assert.Error(t, err)
+}
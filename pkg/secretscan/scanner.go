@@ -0,0 +1,213 @@
+package secretscan
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"syscall"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ScannerNone disables secret scanning
+	ScannerNone = "none"
+	// ScannerBuiltin the built in regular expression based secret detector
+	ScannerBuiltin = "builtin"
+	// ScannerGitleaks the gitleaks scanner
+	ScannerGitleaks = "gitleaks"
+	// ScannerTrufflehog the trufflehog scanner
+	ScannerTrufflehog = "trufflehog"
+
+	// IgnoreMarker can be added to a line to ignore any secrets detected on that line
+	IgnoreMarker = "jx-secret-scan:ignore"
+
+	maxScannedFileSize = 1024 * 1024
+)
+
+// Finding a secret detected in a file
+type Finding struct {
+	File string
+	Line int
+	Rule string
+}
+
+// String returns a description of the finding
+func (f *Finding) String() string {
+	if f.Line > 0 {
+		return fmt.Sprintf("%s:%d %s", f.File, f.Line, f.Rule)
+	}
+	return fmt.Sprintf("%s %s", f.File, f.Rule)
+}
+
+// Scanner detects secrets committed to the source code in a directory
+type Scanner interface {
+	Scan(dir string) ([]Finding, error)
+}
+
+// Rule a regular expression which detects a kind of secret
+type Rule struct {
+	Name  string
+	Regex *regexp.Regexp
+}
+
+// DefaultRules the rules used by the built in detector
+var DefaultRules = []Rule{
+	{Name: "AWS access key ID", Regex: regexp.MustCompile(`\b(AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{Name: "AWS secret access key", Regex: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}\b`)},
+	{Name: "GitHub token", Regex: regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36}\b`)},
+	{Name: "GitLab personal access token", Regex: regexp.MustCompile(`\bglpat-[A-Za-z0-9_\-]{20}\b`)},
+	{Name: "Slack token", Regex: regexp.MustCompile(`\bxox[abposr]-[0-9A-Za-z\-]{10,}`)},
+	{Name: "Google API key", Regex: regexp.MustCompile(`\bAIza[0-9A-Za-z_\-]{35}\b`)},
+	{Name: "private key", Regex: regexp.MustCompile(`-----BEGIN (RSA |EC |DSA |OPENSSH |PGP |ENCRYPTED )?PRIVATE KEY( BLOCK)?-----`)},
+}
+
+// Detector the built in Scanner which matches files against regular expressions
+type Detector struct {
+	Rules []Rule
+}
+
+// NewDetector creates the built in detector with the default rules and any additional rules
+func NewDetector(rules ...Rule) *Detector {
+	return &Detector{
+		Rules: append(append([]Rule{}, DefaultRules...), rules...),
+	}
+}
+
+// Scan scans all the files in the directory apart from the .git folder
+func (d *Detector) Scan(dir string) ([]Finding, error) {
+	answer := []Finding{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if info.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.Mode().IsRegular() || info.Size() > maxScannedFileSize {
+			return nil
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		answer = append(answer, d.ScanData(filepath.ToSlash(rel), data)...)
+		return nil
+	})
+	return answer, err
+}
+
+// ScanData returns the secrets found in the contents of a file. Binary files are ignored
+func (d *Detector) ScanData(fileName string, data []byte) []Finding {
+	answer := []Finding{}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return answer
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), maxScannedFileSize)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := scanner.Text()
+		if strings.Contains(text, IgnoreMarker) {
+			continue
+		}
+		for _, rule := range d.Rules {
+			if rule.Regex.MatchString(text) {
+				answer = append(answer, Finding{File: fileName, Line: line, Rule: rule.Name})
+			}
+		}
+	}
+	return answer
+}
+
+// CommandScanner runs an external secret scanner such as gitleaks passing the directory as the last argument
+type CommandScanner struct {
+	Name string
+	Args []string
+	// FindingsExitCodes the exit codes of the scanner which mean secrets were found. Any other non zero exit code
+	// means the scanner failed
+	FindingsExitCodes []int
+}
+
+// knownScanners the external scanners which can be configured by name in the team settings
+var knownScanners = map[string]CommandScanner{
+	ScannerGitleaks:   {Name: "gitleaks", Args: []string{"detect", "--no-git", "--redact", "--source"}, FindingsExitCodes: []int{1}},
+	ScannerTrufflehog: {Name: "trufflehog", Args: []string{"filesystem", "--fail", "--no-update"}, FindingsExitCodes: []int{183}},
+}
+
+// ScannerNames returns the names of the scanners which can be configured in the team settings
+func ScannerNames() []string {
+	return []string{ScannerBuiltin, ScannerGitleaks, ScannerTrufflehog, ScannerNone}
+}
+
+// Scan runs the command in the directory. An error is returned if the scanner can not be run or fails rather than
+// reporting secrets
+func (c *CommandScanner) Scan(dir string) ([]Finding, error) {
+	_, err := exec.LookPath(c.Name)
+	if err != nil {
+		return nil, fmt.Errorf("could not find the secret scanner %s on the PATH: %s", c.Name, err)
+	}
+	cmd := util.Command{
+		Name: c.Name,
+		Args: append(append([]string{}, c.Args...), dir),
+		Dir:  dir,
+	}
+	out, err := cmd.RunWithoutRetry()
+	if err == nil {
+		return []Finding{}, nil
+	}
+	exitErr, ok := errors.Cause(err).(*exec.ExitError)
+	if !ok {
+		return nil, fmt.Errorf("failed to run the secret scanner %s: %s", c.Name, err)
+	}
+	status, ok := exitErr.Sys().(syscall.WaitStatus)
+	if !ok || !containsInt(c.FindingsExitCodes, status.ExitStatus()) {
+		return nil, fmt.Errorf("the secret scanner %s failed: %s", c.Name, err)
+	}
+	return []Finding{{File: dir, Rule: fmt.Sprintf("%s reported secrets: %s", c.Name, strings.TrimSpace(out))}}, nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// NewScanner creates the scanner which is either empty or none to disable scanning, builtin for the built in
+// detector or the name of a known external scanner. As the team settings are shared by every developer of the team
+// the command line of any other external scanner, which is run on their machines, is only accepted if allowCommand
+// is true such as when it was passed on the command line
+func NewScanner(scanner string, allowCommand bool) (Scanner, error) {
+	switch scanner {
+	case "", ScannerNone:
+		return nil, nil
+	case ScannerBuiltin:
+		return NewDetector(), nil
+	}
+	if known, ok := knownScanners[scanner]; ok {
+		return &known, nil
+	}
+	if !allowCommand {
+		return nil, fmt.Errorf("unknown secret scanner %s. Supported scanners are: %s", scanner, strings.Join(ScannerNames(), ", "))
+	}
+	fields := strings.Fields(scanner)
+	return &CommandScanner{Name: fields[0], Args: fields[1:], FindingsExitCodes: []int{1}}, nil
+}
@@ -11,6 +11,7 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"github.com/jenkins-x/jx/pkg/util"
 	"github.com/jenkins-x/jx/pkg/version"
 	"gopkg.in/AlecAivazis/survey.v1"
@@ -94,7 +95,7 @@ type SpringBootForm struct {
 
 func LoadSpringBoot(cacheDir string) (*SpringBootModel, error) {
 	loader := func() ([]byte, error) {
-		client := http.Client{}
+		client := httpclient.NewBaseClient()
 		req, err := http.NewRequest(http.MethodGet, startSpringURL, nil)
 		if err != nil {
 			return nil, err
@@ -194,7 +195,7 @@ func (model *SpringBootModel) CreateSurvey(data *SpringBootForm, advanced bool,
 	if emptyArray(data.Dependencies) {
 		qs = append(qs, CreateSpringTreeSelect("Dependencies", "dependencies", &model.Dependencies, data))
 	}
-	return survey.Ask(qs, data)
+	return util.Ask(qs, data)
 }
 
 func (options *SpringOptions) StringArray() []string {
@@ -319,7 +320,7 @@ func (data *SpringBootForm) CreateProject(workDir string) (string, error) {
 	}
 	answer := filepath.Join(workDir, dirName)
 
-	client := http.Client{}
+	client := httpclient.NewBaseClient()
 
 	form := url.Values{}
 	data.AddFormValues(&form)
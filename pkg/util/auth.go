@@ -1,8 +1,65 @@
 package util
 
-import "encoding/base64"
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"runtime"
+	"strings"
+)
+
+const (
+	// HeadlessEnvVar forces the login flows which do not need a browser when set to true such as on CI machines
+	HeadlessEnvVar = "JX_HEADLESS"
+
+	// TokenFromStdin the token file name which reads the token from stdin
+	TokenFromStdin = "-"
+)
 
 func BasicAuth(username, password string) string {
 	auth := username + ":" + password
 	return base64.StdEncoding.EncodeToString([]byte(auth))
 }
+
+// IsHeadlessEnvironment returns true if there is no browser to log in with because $JX_HEADLESS is true or, on
+// linux, there is no display
+func IsHeadlessEnvironment() bool {
+	if os.Getenv(HeadlessEnvVar) == "true" {
+		return true
+	}
+	return runtime.GOOS == "linux" && os.Getenv("DISPLAY") == "" && os.Getenv("WAYLAND_DISPLAY") == ""
+}
+
+// ReadToken reads a token from the file or, if the file name is TokenFromStdin, the first line of stdin so that
+// tokens can be passed to jx without prompting and without appearing in the arguments of the process
+func ReadToken(fileName string, stdin io.Reader) (string, error) {
+	var token string
+	if fileName == TokenFromStdin {
+		line, err := bufio.NewReader(stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", fmt.Errorf("failed to read the token from stdin: %s", err)
+		}
+		token = line
+	} else {
+		data, err := ioutil.ReadFile(fileName)
+		if err != nil {
+			return "", fmt.Errorf("failed to read the token file %s: %s", fileName, err)
+		}
+		token = string(data)
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", InvalidInputError(fmt.Errorf("no token found in %s", tokenSourceName(fileName)))
+	}
+	return token, nil
+}
+
+func tokenSourceName(fileName string) string {
+	if fileName == TokenFromStdin {
+		return "stdin"
+	}
+	return "the token file " + fileName
+}
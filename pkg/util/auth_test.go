@@ -0,0 +1,36 @@
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadToken(t *testing.T) {
+	t.Parallel()
+	token, err := util.ReadToken(util.TokenFromStdin, strings.NewReader("  abc123\nignored\n"))
+	require.NoError(t, err)
+	assert.Equal(t, "abc123", token)
+
+	dir, err := ioutil.TempDir("", "test-read-token-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "token")
+	err = ioutil.WriteFile(fileName, []byte("def456\n"), util.DefaultWritePermissions)
+	require.NoError(t, err)
+	token, err = util.ReadToken(fileName, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "def456", token)
+
+	_, err = util.ReadToken(util.TokenFromStdin, strings.NewReader("\n"))
+	assert.Error(t, err)
+	assert.Equal(t, util.ErrorCategoryInvalidInput, util.ErrorCategoryOf(err))
+	_, err = util.ReadToken(filepath.Join(dir, "missing"), nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,247 @@
+package buckets
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+const (
+	// azureStorageVersion the version of the Azure storage REST API and of the string to sign of SAS tokens
+	azureStorageVersion = "2018-03-28"
+
+	// azureRequestExpiry how long the SAS tokens used for each request are valid
+	azureRequestExpiry = 15 * time.Minute
+)
+
+// azureBucket an Azure Blob Storage container accessed via the REST API using SAS tokens signed with the account key
+type azureBucket struct {
+	container  string
+	account    string
+	key        []byte
+	endpoint   string
+	httpClient *http.Client
+}
+
+func newAzureBucket(container string, creds *Credentials) (*azureBucket, error) {
+	if creds.AzureAccount == "" || creds.AzureKey == "" {
+		return nil, fmt.Errorf("the Azure storage account and key are required for container %s", container)
+	}
+	key, err := base64.StdEncoding.DecodeString(creds.AzureKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Azure storage key: %s", err)
+	}
+	endpoint := creds.AzureEndpoint
+	if endpoint == "" {
+		endpoint = "https://" + creds.AzureAccount + ".blob.core.windows.net"
+	}
+	return &azureBucket{
+		container:  container,
+		account:    creds.AzureAccount,
+		key:        key,
+		endpoint:   strings.TrimSuffix(endpoint, "/"),
+		httpClient: &http.Client{Transport: httpclient.BaseTransport(), Timeout: 5 * time.Minute},
+	}, nil
+}
+
+func (b *azureBucket) Name() string {
+	return b.container
+}
+
+func (b *azureBucket) URL() string {
+	return ProviderAzure + "://" + b.container
+}
+
+func (b *azureBucket) Exists() (bool, error) {
+	resp, err := b.do("GET", "", url.Values{"restype": {"container"}}, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	err = checkAzureResponse(resp)
+	return err == nil, err
+}
+
+func (b *azureBucket) Create(region string) error {
+	// the region of a container is the region of its storage account
+	resp, err := b.do("PUT", "", url.Values{"restype": {"container"}}, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return checkAzureResponse(resp)
+}
+
+func (b *azureBucket) Upload(key string, body io.Reader, contentType string) error {
+	data, err := ioutil.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	headers := map[string]string{"x-ms-blob-type": "BlockBlob"}
+	if contentType != "" {
+		headers["Content-Type"] = contentType
+	}
+	resp, err := b.do("PUT", key, url.Values{}, headers, data)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return checkAzureResponse(resp)
+}
+
+func (b *azureBucket) Download(key string) (io.ReadCloser, error) {
+	resp, err := b.do("GET", key, url.Values{}, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = checkAzureResponse(resp)
+	if err != nil {
+		resp.Body.Close()
+		return nil, err
+	}
+	return resp.Body, nil
+}
+
+func (b *azureBucket) Delete(key string) error {
+	resp, err := b.do("DELETE", key, url.Values{}, nil, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return checkAzureResponse(resp)
+}
+
+type azureBlobList struct {
+	Blobs []struct {
+		Name string `xml:"Name"`
+	} `xml:"Blobs>Blob"`
+	NextMarker string `xml:"NextMarker"`
+}
+
+func (b *azureBucket) List(prefix string) ([]string, error) {
+	answer := []string{}
+	marker := ""
+	for {
+		query := url.Values{"restype": {"container"}, "comp": {"list"}}
+		if prefix != "" {
+			query.Set("prefix", prefix)
+		}
+		if marker != "" {
+			query.Set("marker", marker)
+		}
+		resp, err := b.do("GET", "", query, nil, nil)
+		if err != nil {
+			return answer, err
+		}
+		err = checkAzureResponse(resp)
+		if err != nil {
+			resp.Body.Close()
+			return answer, err
+		}
+		list := azureBlobList{}
+		err = xml.NewDecoder(resp.Body).Decode(&list)
+		resp.Body.Close()
+		if err != nil {
+			return answer, err
+		}
+		for _, blob := range list.Blobs {
+			answer = append(answer, blob.Name)
+		}
+		marker = list.NextMarker
+		if marker == "" {
+			break
+		}
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+func (b *azureBucket) SignedURL(key string, method string, expires time.Duration) (string, error) {
+	permissions := ""
+	switch strings.ToUpper(method) {
+	case "GET":
+		permissions = "r"
+	case "PUT":
+		permissions = "cw"
+	default:
+		return "", fmt.Errorf("unsupported method %s for a signed URL", method)
+	}
+	expiry := time.Now().UTC().Add(expires).Format(time.RFC3339)
+	resource := "/blob/" + b.account + "/" + b.container + "/" + key
+	stringToSign := strings.Join([]string{permissions, "", expiry, resource, "", "", "https", azureStorageVersion, "", "", "", "", ""}, "\n")
+	query := url.Values{
+		"sv":  {azureStorageVersion},
+		"sr":  {"b"},
+		"sp":  {permissions},
+		"se":  {expiry},
+		"spr": {"https"},
+		"sig": {b.sign(stringToSign)},
+	}
+	return b.blobURL(key) + "?" + query.Encode(), nil
+}
+
+// accountSAS returns the query parameters of an account SAS token which allows all blob operations
+func (b *azureBucket) accountSAS() url.Values {
+	expiry := time.Now().UTC().Add(azureRequestExpiry).Format(time.RFC3339)
+	permissions := "rwdlac"
+	stringToSign := strings.Join([]string{b.account, permissions, "b", "sco", "", expiry, "", "", azureStorageVersion, ""}, "\n")
+	return url.Values{
+		"sv":  {azureStorageVersion},
+		"ss":  {"b"},
+		"srt": {"sco"},
+		"sp":  {permissions},
+		"se":  {expiry},
+		"sig": {b.sign(stringToSign)},
+	}
+}
+
+func (b *azureBucket) sign(stringToSign string) string {
+	mac := hmac.New(sha256.New, b.key)
+	mac.Write([]byte(stringToSign))
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (b *azureBucket) blobURL(key string) string {
+	u := b.endpoint + "/" + b.container
+	if key != "" {
+		u += "/" + (&url.URL{Path: key}).EscapedPath()
+	}
+	return u
+}
+
+func (b *azureBucket) do(method string, key string, query url.Values, headers map[string]string, body []byte) (*http.Response, error) {
+	for k, v := range b.accountSAS() {
+		query[k] = v
+	}
+	req, err := http.NewRequest(method, b.blobURL(key)+"?"+query.Encode(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-ms-version", azureStorageVersion)
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return b.httpClient.Do(req)
+}
+
+func checkAzureResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	return fmt.Errorf("Azure storage request %s %s failed with status %s", resp.Request.Method, resp.Request.URL.Path, resp.Status)
+}
@@ -0,0 +1,101 @@
+package buckets
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// ProviderGCS the URL scheme of Google Cloud Storage buckets such as gs://mybucket
+	ProviderGCS = "gs"
+
+	// ProviderS3 the URL scheme of Amazon S3 buckets such as s3://mybucket
+	ProviderS3 = "s3"
+
+	// ProviderAzure the URL scheme of Azure Blob Storage containers such as azblob://mycontainer
+	ProviderAzure = "azblob"
+
+	// ProviderFile the URL scheme of a local directory used as a bucket such as file:///tmp/mybucket
+	ProviderFile = "file"
+)
+
+// Providers the supported bucket providers
+var Providers = []string{ProviderGCS, ProviderS3, ProviderAzure, ProviderFile}
+
+// Bucket a client for a cloud storage bucket
+type Bucket interface {
+	// Name returns the name of the bucket
+	Name() string
+
+	// URL returns the URL of the bucket such as gs://mybucket
+	URL() string
+
+	// Exists returns true if the bucket exists
+	Exists() (bool, error)
+
+	// Create creates the bucket in the given region or the default region of the provider if it is blank
+	Create(region string) error
+
+	// Upload writes the body to the object with the given key
+	Upload(key string, body io.Reader, contentType string) error
+
+	// Download returns the contents of the object with the given key which must be closed by the caller
+	Download(key string) (io.ReadCloser, error)
+
+	// Delete removes the object with the given key
+	Delete(key string) error
+
+	// List returns the keys of the objects with the given prefix sorted by key
+	List(prefix string) ([]string, error)
+
+	// SignedURL returns a URL which can be used without credentials to perform the HTTP method, GET or PUT, on the
+	// object with the given key until it expires
+	SignedURL(key string, method string, expires time.Duration) (string, error)
+}
+
+// ParseBucketURL returns the provider and bucket name of the given bucket URL
+func ParseBucketURL(bucketURL string) (string, string, error) {
+	u, err := url.Parse(bucketURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid bucket URL %s: %s", bucketURL, err)
+	}
+	provider := strings.ToLower(u.Scheme)
+	name := u.Host
+	if provider == ProviderFile {
+		name = u.Path
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("no bucket name in URL %s", bucketURL)
+	}
+	for _, p := range Providers {
+		if p == provider {
+			return provider, name, nil
+		}
+	}
+	return "", "", fmt.Errorf("unsupported bucket URL %s. Supported schemes are: %s", bucketURL, strings.Join(Providers, ", "))
+}
+
+// NewBucket creates a client for the bucket with the given URL using the credentials. Any blank credentials are
+// resolved by the provider from its default locations such as the environment or the instance metadata
+func NewBucket(bucketURL string, credentials *Credentials) (Bucket, error) {
+	provider, name, err := ParseBucketURL(bucketURL)
+	if err != nil {
+		return nil, err
+	}
+	if credentials == nil {
+		credentials = &Credentials{}
+	}
+	switch provider {
+	case ProviderGCS:
+		return newGCSBucket(name, credentials), nil
+	case ProviderS3:
+		return newS3Bucket(name, credentials)
+	case ProviderAzure:
+		return newAzureBucket(name, credentials)
+	default:
+		return newFileBucket(name), nil
+	}
+}
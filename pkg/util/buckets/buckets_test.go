@@ -0,0 +1,230 @@
+package buckets_test
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util/buckets"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestParseBucketURL(t *testing.T) {
+	t.Parallel()
+	for u, expected := range map[string][]string{
+		"gs://my-bucket":          {buckets.ProviderGCS, "my-bucket"},
+		"s3://my-bucket/ignored":  {buckets.ProviderS3, "my-bucket"},
+		"azblob://my-container":   {buckets.ProviderAzure, "my-container"},
+		"file:///tmp/my-bucket":   {buckets.ProviderFile, "/tmp/my-bucket"},
+		"S3://upper-case-scheme":  {buckets.ProviderS3, "upper-case-scheme"},
+		"gs://another.bucket.com": {buckets.ProviderGCS, "another.bucket.com"},
+	} {
+		provider, name, err := buckets.ParseBucketURL(u)
+		require.NoError(t, err, "parsing %s", u)
+		assert.Equal(t, expected, []string{provider, name}, "parsing %s", u)
+	}
+	for _, u := range []string{"ftp://my-bucket", "gs://", "my-bucket"} {
+		_, _, err := buckets.ParseBucketURL(u)
+		assert.Error(t, err, "parsing %s", u)
+	}
+}
+
+func TestFileBucket(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-file-bucket")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	bucket, err := buckets.NewBucket("file://"+filepath.ToSlash(filepath.Join(dir, "bucket")), nil)
+	require.NoError(t, err)
+	exists, err := bucket.Exists()
+	require.NoError(t, err)
+	assert.False(t, exists)
+	require.NoError(t, bucket.Create(""))
+
+	require.NoError(t, bucket.Upload("logs/myapp/1.log", strings.NewReader("build 1"), "text/plain"))
+	require.NoError(t, bucket.Upload("logs/myapp/2.log", strings.NewReader("build 2"), "text/plain"))
+	require.NoError(t, bucket.Upload("backup/env.yaml", strings.NewReader("env"), ""))
+
+	keys, err := bucket.List("logs/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs/myapp/1.log", "logs/myapp/2.log"}, keys)
+
+	reader, err := bucket.Download("logs/myapp/2.log")
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "build 2", string(data))
+
+	require.NoError(t, bucket.Delete("logs/myapp/1.log"))
+	keys, err = bucket.List("logs/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs/myapp/2.log"}, keys)
+}
+
+func TestAzureBucket(t *testing.T) {
+	t.Parallel()
+	var lock sync.Mutex
+	blobs := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("sig") == "" || query.Get("sv") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		key := strings.TrimPrefix(r.URL.Path, "/my-container/")
+		switch {
+		case query.Get("comp") == "list":
+			w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?><EnumerationResults><Blobs>`))
+			for k := range blobs {
+				if strings.HasPrefix(k, query.Get("prefix")) {
+					w.Write([]byte("<Blob><Name>" + k + "</Name></Blob>"))
+				}
+			}
+			w.Write([]byte(`</Blobs><NextMarker /></EnumerationResults>`))
+		case r.Method == "PUT":
+			assert.Equal(t, "BlockBlob", r.Header.Get("x-ms-blob-type"))
+			data, _ := ioutil.ReadAll(r.Body)
+			blobs[key] = string(data)
+			w.WriteHeader(http.StatusCreated)
+		case r.Method == "GET":
+			data, ok := blobs[key]
+			if !ok {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(data))
+		case r.Method == "DELETE":
+			delete(blobs, key)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	bucket, err := buckets.NewBucket("azblob://my-container", &buckets.Credentials{
+		AzureAccount:  "myaccount",
+		AzureKey:      base64.StdEncoding.EncodeToString([]byte("not-a-real-key")),
+		AzureEndpoint: server.URL,
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, bucket.Upload("logs/1.log", strings.NewReader("build 1"), "text/plain"))
+	require.NoError(t, bucket.Upload("other/2.log", strings.NewReader("build 2"), "text/plain"))
+	keys, err := bucket.List("logs/")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"logs/1.log"}, keys)
+
+	reader, err := bucket.Download("logs/1.log")
+	require.NoError(t, err)
+	data, err := ioutil.ReadAll(reader)
+	reader.Close()
+	require.NoError(t, err)
+	assert.Equal(t, "build 1", string(data))
+
+	require.NoError(t, bucket.Delete("logs/1.log"))
+	_, err = bucket.Download("logs/1.log")
+	assert.Error(t, err)
+
+	signed, err := bucket.SignedURL("logs/1.log", "GET", time.Hour)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/my-container/logs/1.log", u.Path)
+	assert.Equal(t, "r", u.Query().Get("sp"))
+	assert.Equal(t, "b", u.Query().Get("sr"))
+	assert.NotEmpty(t, u.Query().Get("sig"))
+}
+
+func TestS3SignedURL(t *testing.T) {
+	t.Parallel()
+	bucket, err := buckets.NewBucket("s3://my-bucket", &buckets.Credentials{
+		AWSAccessKeyID:     "AKIDEXAMPLE",
+		AWSSecretAccessKey: "secret",
+		AWSRegion:          "eu-west-1",
+	})
+	require.NoError(t, err)
+
+	signed, err := bucket.SignedURL("logs/1.log", "PUT", 15*time.Minute)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Contains(t, u.Host, "my-bucket")
+	assert.Equal(t, "/logs/1.log", u.Path)
+	assert.Equal(t, "900", u.Query().Get("X-Amz-Expires"))
+	assert.NotEmpty(t, u.Query().Get("X-Amz-Signature"))
+
+	_, err = bucket.SignedURL("logs/1.log", "DELETE", time.Minute)
+	assert.Error(t, err)
+}
+
+func TestGCSSignedURL(t *testing.T) {
+	t.Parallel()
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	require.NoError(t, err)
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	serviceAccount, err := json.Marshal(map[string]string{
+		"type":         "service_account",
+		"project_id":   "my-project",
+		"client_email": "jx@my-project.iam.gserviceaccount.com",
+		"private_key":  string(privateKey),
+		"token_uri":    "https://oauth2.googleapis.com/token",
+	})
+	require.NoError(t, err)
+
+	bucket, err := buckets.NewBucket("gs://my-bucket", &buckets.Credentials{GoogleCredentialsJSON: serviceAccount})
+	require.NoError(t, err)
+	signed, err := bucket.SignedURL("logs/1.log", "GET", time.Hour)
+	require.NoError(t, err)
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	assert.Equal(t, "/my-bucket/logs/1.log", u.Path)
+	assert.Equal(t, "jx@my-project.iam.gserviceaccount.com", u.Query().Get("GoogleAccessId"))
+	assert.NotEmpty(t, u.Query().Get("Signature"))
+
+	bucket, err = buckets.NewBucket("gs://my-bucket", nil)
+	require.NoError(t, err)
+	_, err = bucket.SignedURL("logs/1.log", "GET", time.Hour)
+	assert.Error(t, err)
+}
+
+func TestResolveCredentialsFromSecret(t *testing.T) {
+	t.Parallel()
+	kubeClient := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buckets.CredentialsSecretName,
+			Namespace: "jx",
+		},
+		Data: map[string][]byte{
+			buckets.SecretKeyAWSAccessKeyID:     []byte("AKIDEXAMPLE"),
+			buckets.SecretKeyAWSSecretAccessKey: []byte("secret"),
+			buckets.SecretKeyS3Endpoint:         []byte("http://minio:9000"),
+			buckets.SecretKeyAzureAccount:       []byte("myaccount"),
+		},
+	})
+	creds, err := buckets.ResolveCredentials(kubeClient, "jx")
+	require.NoError(t, err)
+	assert.Equal(t, "AKIDEXAMPLE", creds.AWSAccessKeyID)
+	assert.Equal(t, "secret", creds.AWSSecretAccessKey)
+	assert.Equal(t, "http://minio:9000", creds.S3Endpoint)
+	assert.Equal(t, "myaccount", creds.AzureAccount)
+}
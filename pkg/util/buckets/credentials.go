@@ -0,0 +1,120 @@
+package buckets
+
+import (
+	"io/ioutil"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// CredentialsSecretName the name of the Secret in the development namespace containing the bucket credentials
+	CredentialsSecretName = "jx-bucket-credentials"
+
+	// SecretKeyGoogleCredentials the key of the Google service account JSON key in the credentials Secret
+	SecretKeyGoogleCredentials = "google-credentials.json"
+
+	// SecretKeyAWSAccessKeyID the key of the AWS access key ID in the credentials Secret
+	SecretKeyAWSAccessKeyID = "aws-access-key-id"
+
+	// SecretKeyAWSSecretAccessKey the key of the AWS secret access key in the credentials Secret
+	SecretKeyAWSSecretAccessKey = "aws-secret-access-key"
+
+	// SecretKeyAWSRegion the key of the AWS region in the credentials Secret
+	SecretKeyAWSRegion = "aws-region"
+
+	// SecretKeyS3Endpoint the key of a custom S3 compatible endpoint in the credentials Secret
+	SecretKeyS3Endpoint = "s3-endpoint"
+
+	// SecretKeyAzureAccount the key of the Azure storage account name in the credentials Secret
+	SecretKeyAzureAccount = "azure-storage-account"
+
+	// SecretKeyAzureKey the key of the Azure storage account key in the credentials Secret
+	SecretKeyAzureKey = "azure-storage-key"
+
+	// SecretKeyAzureEndpoint the key of a custom Azure blob endpoint in the credentials Secret
+	SecretKeyAzureEndpoint = "azure-endpoint"
+)
+
+// Credentials the credentials of the bucket providers. Blank values are resolved by the SDK of each provider
+type Credentials struct {
+	// GoogleCredentialsJSON the JSON key of a Google service account which is required to sign GCS URLs
+	GoogleCredentialsJSON []byte
+	// GoogleProjectID the project in which GCS buckets are created
+	GoogleProjectID string
+
+	AWSAccessKeyID     string
+	AWSSecretAccessKey string
+	AWSSessionToken    string
+	AWSRegion          string
+	// S3Endpoint an optional S3 compatible endpoint such as a minio server
+	S3Endpoint string
+
+	AzureAccount string
+	AzureKey     string
+	// AzureEndpoint an optional blob endpoint which defaults to https://<account>.blob.core.windows.net
+	AzureEndpoint string
+}
+
+// CredentialsFromEnvironment returns the credentials defined by the standard environment variables of each provider
+func CredentialsFromEnvironment() (*Credentials, error) {
+	answer := &Credentials{
+		GoogleProjectID:    os.Getenv("GOOGLE_PROJECT_ID"),
+		AWSAccessKeyID:     os.Getenv("AWS_ACCESS_KEY_ID"),
+		AWSSecretAccessKey: os.Getenv("AWS_SECRET_ACCESS_KEY"),
+		AWSSessionToken:    os.Getenv("AWS_SESSION_TOKEN"),
+		AWSRegion:          os.Getenv("AWS_REGION"),
+		AzureAccount:       os.Getenv("AZURE_STORAGE_ACCOUNT"),
+		AzureKey:           os.Getenv("AZURE_STORAGE_KEY"),
+	}
+	if answer.AWSRegion == "" {
+		answer.AWSRegion = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	file := os.Getenv("GOOGLE_APPLICATION_CREDENTIALS")
+	if file != "" {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return answer, err
+		}
+		answer.GoogleCredentialsJSON = data
+	}
+	return answer, nil
+}
+
+// ResolveCredentials returns the credentials from the bucket credentials Secret of the given namespace, if it exists,
+// falling back to the environment for any values not in the Secret
+func ResolveCredentials(kubeClient kubernetes.Interface, ns string) (*Credentials, error) {
+	answer, err := CredentialsFromEnvironment()
+	if err != nil {
+		return answer, err
+	}
+	if kubeClient == nil {
+		return answer, nil
+	}
+	secret, err := kubeClient.CoreV1().Secrets(ns).Get(CredentialsSecretName, metav1.GetOptions{})
+	if err != nil {
+		return answer, nil
+	}
+	data := secret.Data
+	override := func(value *string, key string) {
+		if v := string(data[key]); v != "" {
+			*value = v
+		}
+	}
+	if v := data[SecretKeyGoogleCredentials]; len(v) > 0 {
+		answer.GoogleCredentialsJSON = v
+	}
+	if answer.AWSSessionToken != "" && len(data[SecretKeyAWSAccessKeyID]) > 0 {
+		// a session token from the environment is only valid with the access key it was issued for
+		answer.AWSSessionToken = ""
+	}
+	override(&answer.AWSAccessKeyID, SecretKeyAWSAccessKeyID)
+	override(&answer.AWSSecretAccessKey, SecretKeyAWSSecretAccessKey)
+	override(&answer.AWSRegion, SecretKeyAWSRegion)
+	override(&answer.S3Endpoint, SecretKeyS3Endpoint)
+	override(&answer.AzureAccount, SecretKeyAzureAccount)
+	override(&answer.AzureKey, SecretKeyAzureKey)
+	override(&answer.AzureEndpoint, SecretKeyAzureEndpoint)
+	return answer, nil
+}
@@ -0,0 +1,99 @@
+package buckets
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// fileBucket a local directory used as a bucket for testing and local development
+type fileBucket struct {
+	dir string
+}
+
+func newFileBucket(dir string) *fileBucket {
+	return &fileBucket{dir: dir}
+}
+
+func (b *fileBucket) Name() string {
+	return b.dir
+}
+
+func (b *fileBucket) URL() string {
+	return ProviderFile + "://" + filepath.ToSlash(b.dir)
+}
+
+func (b *fileBucket) Exists() (bool, error) {
+	info, err := os.Stat(b.dir)
+	if os.IsNotExist(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return info.IsDir(), nil
+}
+
+func (b *fileBucket) Create(region string) error {
+	return os.MkdirAll(b.dir, 0755)
+}
+
+func (b *fileBucket) Upload(key string, body io.Reader, contentType string) error {
+	path := b.path(key)
+	err := os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return err
+	}
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, body)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func (b *fileBucket) Download(key string) (io.ReadCloser, error) {
+	return os.Open(b.path(key))
+}
+
+func (b *fileBucket) Delete(key string) error {
+	return os.Remove(b.path(key))
+}
+
+func (b *fileBucket) List(prefix string) ([]string, error) {
+	answer := []string{}
+	err := filepath.Walk(b.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(b.dir, path)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+		if strings.HasPrefix(key, prefix) {
+			answer = append(answer, key)
+		}
+		return nil
+	})
+	sort.Strings(answer)
+	return answer, err
+}
+
+func (b *fileBucket) SignedURL(key string, method string, expires time.Duration) (string, error) {
+	return ProviderFile + "://" + filepath.ToSlash(b.path(key)), nil
+}
+
+func (b *fileBucket) path(key string) string {
+	return filepath.Join(b.dir, filepath.FromSlash(key))
+}
@@ -0,0 +1,169 @@
+package buckets
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/storage"
+	"golang.org/x/oauth2/google"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+)
+
+// gcsBucket a Google Cloud Storage bucket
+type gcsBucket struct {
+	name        string
+	credentials *Credentials
+	client      *storage.Client
+}
+
+func newGCSBucket(name string, credentials *Credentials) *gcsBucket {
+	return &gcsBucket{name: name, credentials: credentials}
+}
+
+func (b *gcsBucket) Name() string {
+	return b.name
+}
+
+func (b *gcsBucket) URL() string {
+	return ProviderGCS + "://" + b.name
+}
+
+// bucket lazily creates the client so that URLs can be signed without connecting to GCS
+func (b *gcsBucket) bucket(ctx context.Context) (*storage.BucketHandle, error) {
+	if b.client == nil {
+		opts := []option.ClientOption{}
+		if len(b.credentials.GoogleCredentialsJSON) > 0 {
+			creds, err := google.CredentialsFromJSON(ctx, b.credentials.GoogleCredentialsJSON, storage.ScopeFullControl)
+			if err != nil {
+				return nil, fmt.Errorf("invalid Google credentials: %s", err)
+			}
+			opts = append(opts, option.WithCredentials(creds))
+		}
+		client, err := storage.NewClient(ctx, opts...)
+		if err != nil {
+			return nil, err
+		}
+		b.client = client
+	}
+	return b.client.Bucket(b.name), nil
+}
+
+func (b *gcsBucket) Exists() (bool, error) {
+	ctx := context.Background()
+	bucket, err := b.bucket(ctx)
+	if err != nil {
+		return false, err
+	}
+	_, err = bucket.Attrs(ctx)
+	if err == storage.ErrBucketNotExist {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (b *gcsBucket) Create(region string) error {
+	ctx := context.Background()
+	projectID, err := b.projectID(ctx)
+	if err != nil {
+		return err
+	}
+	bucket, err := b.bucket(ctx)
+	if err != nil {
+		return err
+	}
+	return bucket.Create(ctx, projectID, &storage.BucketAttrs{Location: region})
+}
+
+func (b *gcsBucket) Upload(key string, body io.Reader, contentType string) error {
+	ctx := context.Background()
+	bucket, err := b.bucket(ctx)
+	if err != nil {
+		return err
+	}
+	w := bucket.Object(key).NewWriter(ctx)
+	w.ContentType = contentType
+	_, err = io.Copy(w, body)
+	if err != nil {
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+func (b *gcsBucket) Download(key string) (io.ReadCloser, error) {
+	ctx := context.Background()
+	bucket, err := b.bucket(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return bucket.Object(key).NewReader(ctx)
+}
+
+func (b *gcsBucket) Delete(key string) error {
+	ctx := context.Background()
+	bucket, err := b.bucket(ctx)
+	if err != nil {
+		return err
+	}
+	return bucket.Object(key).Delete(ctx)
+}
+
+func (b *gcsBucket) List(prefix string) ([]string, error) {
+	answer := []string{}
+	ctx := context.Background()
+	bucket, err := b.bucket(ctx)
+	if err != nil {
+		return answer, err
+	}
+	it := bucket.Objects(ctx, &storage.Query{Prefix: prefix})
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, attrs.Name)
+	}
+	sort.Strings(answer)
+	return answer, nil
+}
+
+func (b *gcsBucket) SignedURL(key string, method string, expires time.Duration) (string, error) {
+	if len(b.credentials.GoogleCredentialsJSON) == 0 {
+		return "", fmt.Errorf("signing GCS URLs requires the JSON key of a service account")
+	}
+	conf, err := google.JWTConfigFromJSON(b.credentials.GoogleCredentialsJSON)
+	if err != nil {
+		return "", fmt.Errorf("invalid Google credentials: %s", err)
+	}
+	return storage.SignedURL(b.name, key, &storage.SignedURLOptions{
+		GoogleAccessID: conf.Email,
+		PrivateKey:     conf.PrivateKey,
+		Method:         strings.ToUpper(method),
+		Expires:        time.Now().Add(expires),
+	})
+}
+
+func (b *gcsBucket) projectID(ctx context.Context) (string, error) {
+	if b.credentials.GoogleProjectID != "" {
+		return b.credentials.GoogleProjectID, nil
+	}
+	if len(b.credentials.GoogleCredentialsJSON) > 0 {
+		creds, err := google.CredentialsFromJSON(ctx, b.credentials.GoogleCredentialsJSON)
+		if err == nil && creds.ProjectID != "" {
+			return creds.ProjectID, nil
+		}
+	}
+	creds, err := google.FindDefaultCredentials(ctx)
+	if err == nil && creds.ProjectID != "" {
+		return creds.ProjectID, nil
+	}
+	return "", fmt.Errorf("no Google project to create bucket %s in. Please set the GOOGLE_PROJECT_ID environment variable", b.name)
+}
@@ -0,0 +1,164 @@
+package buckets
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+)
+
+// defaultAWSRegion the region used if none is configured which matches the other AWS commands
+const defaultAWSRegion = "us-west-2"
+
+// s3Bucket an Amazon S3 bucket or a bucket of an S3 compatible server
+type s3Bucket struct {
+	name   string
+	region string
+	svc    *s3.S3
+}
+
+func newS3Bucket(name string, creds *Credentials) (*s3Bucket, error) {
+	region := creds.AWSRegion
+	if region == "" {
+		region = defaultAWSRegion
+	}
+	config := &aws.Config{
+		Region: aws.String(region),
+	}
+	if creds.AWSAccessKeyID != "" && creds.AWSSecretAccessKey != "" {
+		config.Credentials = credentials.NewStaticCredentials(creds.AWSAccessKeyID, creds.AWSSecretAccessKey, creds.AWSSessionToken)
+	}
+	if creds.S3Endpoint != "" {
+		config.Endpoint = aws.String(creds.S3Endpoint)
+		config.S3ForcePathStyle = aws.Bool(true)
+	}
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, err
+	}
+	return &s3Bucket{
+		name:   name,
+		region: region,
+		svc:    s3.New(sess),
+	}, nil
+}
+
+func (b *s3Bucket) Name() string {
+	return b.name
+}
+
+func (b *s3Bucket) URL() string {
+	return ProviderS3 + "://" + b.name
+}
+
+func (b *s3Bucket) Exists() (bool, error) {
+	_, err := b.svc.HeadBucket(&s3.HeadBucketInput{Bucket: aws.String(b.name)})
+	if err != nil {
+		if aerr, ok := err.(awserr.Error); ok && (aerr.Code() == "NotFound" || aerr.Code() == s3.ErrCodeNoSuchBucket) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (b *s3Bucket) Create(region string) error {
+	if region == "" {
+		region = b.region
+	}
+	input := &s3.CreateBucketInput{
+		Bucket: aws.String(b.name),
+	}
+	// us-east-1 is the default location and must not be specified
+	if region != "us-east-1" {
+		input.CreateBucketConfiguration = &s3.CreateBucketConfiguration{
+			LocationConstraint: aws.String(region),
+		}
+	}
+	_, err := b.svc.CreateBucket(input)
+	return err
+}
+
+func (b *s3Bucket) Upload(key string, body io.Reader, contentType string) error {
+	seeker, ok := body.(io.ReadSeeker)
+	if !ok {
+		data, err := ioutil.ReadAll(body)
+		if err != nil {
+			return err
+		}
+		seeker = bytes.NewReader(data)
+	}
+	input := &s3.PutObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(key),
+		Body:   seeker,
+	}
+	if contentType != "" {
+		input.ContentType = aws.String(contentType)
+	}
+	_, err := b.svc.PutObject(input)
+	return err
+}
+
+func (b *s3Bucket) Download(key string) (io.ReadCloser, error) {
+	output, err := b.svc.GetObject(&s3.GetObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return output.Body, nil
+}
+
+func (b *s3Bucket) Delete(key string) error {
+	_, err := b.svc.DeleteObject(&s3.DeleteObjectInput{
+		Bucket: aws.String(b.name),
+		Key:    aws.String(key),
+	})
+	return err
+}
+
+func (b *s3Bucket) List(prefix string) ([]string, error) {
+	answer := []string{}
+	err := b.svc.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(b.name),
+		Prefix: aws.String(prefix),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			answer = append(answer, aws.StringValue(object.Key))
+		}
+		return true
+	})
+	sort.Strings(answer)
+	return answer, err
+}
+
+func (b *s3Bucket) SignedURL(key string, method string, expires time.Duration) (string, error) {
+	var req *request.Request
+	switch strings.ToUpper(method) {
+	case "GET":
+		req, _ = b.svc.GetObjectRequest(&s3.GetObjectInput{
+			Bucket: aws.String(b.name),
+			Key:    aws.String(key),
+		})
+	case "PUT":
+		req, _ = b.svc.PutObjectRequest(&s3.PutObjectInput{
+			Bucket: aws.String(b.name),
+			Key:    aws.String(key),
+		})
+	default:
+		return "", fmt.Errorf("unsupported method %s for a signed URL", method)
+	}
+	return req.Presign(expires)
+}
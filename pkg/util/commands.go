@@ -1,6 +1,9 @@
 package util
 
 import (
+	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"os"
 	"os/exec"
@@ -23,6 +26,14 @@ type Command struct {
 	Out                io.Writer
 	Err                io.Writer
 	Env                map[string]string
+	// ExecTimeout kills a single execution of the command if it does not complete in time, whereas Timeout limits
+	// the total time spent retrying the command
+	ExecTimeout time.Duration
+	// OutputLimit the maximum number of bytes of the combined output. If the output is larger the command fails and
+	// only the last OutputLimit bytes are kept for the error message. The output is unlimited if zero
+	OutputLimit int
+	// OnOutput is called with each line of the combined output as it is written
+	OnOutput func(line string)
 }
 
 // SetName Setter method for Name to enable use of interface instead of Command struct
@@ -123,7 +134,13 @@ func (c *Command) RunWithoutRetry() (string, error) {
 }
 
 func (c *Command) run() (string, error) {
-	e := exec.Command(c.Name, c.Args...)
+	ctx := context.Background()
+	if c.ExecTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.ExecTimeout)
+		defer cancel()
+	}
+	e := exec.CommandContext(ctx, c.Name, c.Args...)
 	if c.Dir != "" {
 		e.Dir = c.Dir
 	}
@@ -160,22 +177,94 @@ func (c *Command) run() (string, error) {
 	if c.Out != nil {
 		err := e.Run()
 		if err != nil {
-			return text, errors.Wrapf(err, "failed to run '%s %s' command in directory '%s', output: '%s'",
-				c.Name, strings.Join(c.Args, " "), c.Dir, text)
+			return text, c.runError(ctx, err, text)
 		}
 	} else {
-		data, err := e.CombinedOutput()
-		output := string(data)
-		text = strings.TrimSpace(output)
+		output := &truncatingBuffer{limit: c.OutputLimit}
+		var w io.Writer = output
+		var lines *lineWriter
+		if c.OnOutput != nil {
+			lines = &lineWriter{onLine: c.OnOutput}
+			w = io.MultiWriter(output, lines)
+		}
+		e.Stdout = w
+		e.Stderr = w
+		err := e.Run()
+		if lines != nil {
+			lines.Flush()
+		}
+		text = strings.TrimSpace(output.String())
+		if err == nil && output.truncated > 0 {
+			err = errors.Errorf("the output exceeded the limit of %d bytes", c.OutputLimit)
+		}
 		if err != nil {
-			return text, errors.Wrapf(err, "failed to run '%s %s' command in directory '%s', output: '%s'",
-				c.Name, strings.Join(c.Args, " "), c.Dir, text)
+			return text, c.runError(ctx, err, text)
 		}
 	}
 
 	return text, err
 }
 
+func (c *Command) runError(ctx context.Context, err error, text string) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		err = errors.Errorf("timed out after %s", c.ExecTimeout)
+	}
+	return errors.Wrapf(err, "failed to run '%s %s' command in directory '%s', output: '%s'",
+		c.Name, strings.Join(c.Args, " "), c.Dir, text)
+}
+
+// truncatingBuffer keeps the last limit bytes written to it, so that the errors commands report at the end of their
+// output are kept, and counts the bytes which were dropped. A limit of zero keeps everything
+type truncatingBuffer struct {
+	buffer    []byte
+	limit     int
+	truncated int
+}
+
+func (b *truncatingBuffer) Write(p []byte) (int, error) {
+	b.buffer = append(b.buffer, p...)
+	if b.limit > 0 && len(b.buffer) > b.limit {
+		drop := len(b.buffer) - b.limit
+		b.truncated += drop
+		b.buffer = append([]byte{}, b.buffer[drop:]...)
+	}
+	return len(p), nil
+}
+
+func (b *truncatingBuffer) String() string {
+	if b.truncated > 0 {
+		return fmt.Sprintf("... %d earlier bytes truncated\n%s", b.truncated, string(b.buffer))
+	}
+	return string(b.buffer)
+}
+
+// lineWriter calls onLine with each complete line written to it
+type lineWriter struct {
+	partial []byte
+	onLine  func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.partial = append(w.partial, p...)
+	for {
+		i := bytes.IndexByte(w.partial, '\n')
+		if i < 0 {
+			break
+		}
+		w.onLine(strings.TrimSuffix(string(w.partial[:i]), "\r"))
+		w.partial = w.partial[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush calls onLine with the last line if it did not end with a new line
+func (w *lineWriter) Flush() {
+	if len(w.partial) > 0 {
+		w.onLine(string(w.partial))
+		w.partial = nil
+	}
+}
+
 // PathWithBinary Sets the $PATH variable. Accepts an optional slice of strings containing paths to add to $PATH
 func PathWithBinary(paths ...string) string {
 	path := os.Getenv("PATH")
@@ -243,3 +243,48 @@ func TestRunQuiet(t *testing.T) {
 	os.Remove(exPath + "/" + tmpFileName)
 
 }
+
+func TestRunWithExecTimeout(t *testing.T) {
+	t.Parallel()
+
+	cmd := util.Command{
+		Name:        "sleep",
+		Args:        []string{"5"},
+		ExecTimeout: 100 * time.Millisecond,
+	}
+
+	start := time.Now()
+	_, err := cmd.RunWithoutRetry()
+
+	assert.Error(t, err, "Run should be killed")
+	assert.Contains(t, err.Error(), "timed out after 100ms")
+	assert.True(t, time.Since(start) < 4*time.Second, "Run should not wait for the command to complete")
+}
+
+func TestRunWithEnvOutputLimitAndCallback(t *testing.T) {
+	t.Parallel()
+
+	lines := []string{}
+	cmd := util.Command{
+		Name:        "sh",
+		Args:        []string{"-c", "echo $GREETING; echo second line; printf partial"},
+		Env:         map[string]string{"GREETING": "hello"},
+		OutputLimit: 10,
+		OnOutput: func(line string) {
+			lines = append(lines, line)
+		},
+	}
+
+	res, err := cmd.RunWithoutRetry()
+
+	assert.Error(t, err, "Run should fail as the output exceeds the limit")
+	assert.Contains(t, err.Error(), "the output exceeded the limit of 10 bytes")
+	assert.Equal(t, "... 15 earlier bytes truncated\nne\npartial", res)
+	assert.Equal(t, []string{"hello", "second line", "partial"}, lines)
+
+	cmd.OutputLimit = 0
+	res, err = cmd.RunWithoutRetry()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello\nsecond line\npartial", res)
+}
@@ -1,8 +1,10 @@
 package util
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 )
 
@@ -109,34 +111,102 @@ func LogsDir() (string, error) {
 	return path, nil
 }
 
+// jxBinDir the bin directory configured via SetJXBinLocation
+var jxBinDir string
+
+// SetJXBinLocation configures the directory jx installs the binaries it depends on into rather than the bin
+// directory inside the JX config directory. An empty directory restores the default
+func SetJXBinLocation(dir string) {
+	jxBinDir = dir
+}
+
+// IsJXBinLocationConfigured returns true if a bin directory has been configured via SetJXBinLocation
+func IsJXBinLocationConfigured() bool {
+	return jxBinDir != ""
+}
+
 // JXBinLocation finds the JX config directory and creates a bin directory inside it if it does not already exist. Returns the JX bin path
 func JXBinLocation() (string, error) {
-	h, err := ConfigDir()
-	if err != nil {
-		return "", err
+	path := jxBinDir
+	if path == "" {
+		h, err := ConfigDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(h, "bin")
 	}
-	path := filepath.Join(h, "bin")
-	err = os.MkdirAll(path, DefaultWritePermissions)
+	err := os.MkdirAll(path, DefaultWritePermissions)
 	if err != nil {
 		return "", err
 	}
 	return path, nil
 }
 
-// JXBinaryLocation Returns the path to the currently installed JX binary.
+// JXBinaryLocation Returns the directory of the currently installed JX binary.
 func JXBinaryLocation(commandInterface Commander) (string, error) {
 	jxBinaryFromEnv, found := os.LookupEnv("JX_BINARY")
 	if found {
-		return strings.TrimSuffix(jxBinaryFromEnv, "/jx"), nil
+		return trimJXBinaryName(jxBinaryFromEnv), nil
+	}
+	if runtime.GOOS == "windows" {
+		commandInterface.SetName("where")
+	} else {
+		commandInterface.SetName("which")
 	}
-	commandInterface.SetName("which")
 	commandInterface.SetArgs([]string{"jx"})
 	out, err := commandInterface.RunWithoutRetry()
 	if err != nil {
 		return out, err
 	}
-	path := strings.TrimSuffix(out, "/jx")
-	return path, nil
+	// where lists every match on the PATH and the first one is run
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	return trimJXBinaryName(strings.TrimSpace(lines[0])), nil
+}
+
+// trimJXBinaryName returns the directory of the path if it is the path of the jx binary
+func trimJXBinaryName(path string) string {
+	for _, name := range []string{"/jx", "\\jx.exe", "/jx.exe"} {
+		if strings.HasSuffix(path, name) {
+			return strings.TrimSuffix(path, name)
+		}
+	}
+	return path
+}
+
+// IsDirOnPath returns true if the directory is one of the directories of the PATH
+func IsDirOnPath(dir string, path string) bool {
+	dir = filepath.Clean(dir)
+	for _, d := range filepath.SplitList(path) {
+		if d != "" && filepath.Clean(d) == dir {
+			return true
+		}
+	}
+	return false
+}
+
+// PathInstructions returns the commands which add the directory to the PATH of the shell on the operating system
+func PathInstructions(dir string, goos string, shell string) []string {
+	if goos == "windows" {
+		return []string{
+			fmt.Sprintf(`setx PATH "%%PATH%%;%s"`, dir),
+		}
+	}
+	switch filepath.Base(shell) {
+	case "fish":
+		return []string{
+			fmt.Sprintf("set -U fish_user_paths %s $fish_user_paths", dir),
+		}
+	case "zsh":
+		return []string{
+			fmt.Sprintf("echo 'export PATH=\"$PATH:%s\"' >> ~/.zshrc", dir),
+			"source ~/.zshrc",
+		}
+	default:
+		return []string{
+			fmt.Sprintf("echo 'export PATH=\"$PATH:%s\"' >> ~/.bashrc", dir),
+			"source ~/.bashrc",
+		}
+	}
 }
 
 func MavenBinaryLocation() (string, error) {
@@ -3,6 +3,7 @@ package util_test
 import (
 	"errors"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/jenkins-x/jx/pkg/util"
@@ -46,3 +47,33 @@ func TestJXBinaryLocationFromEnvWithPrefix(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, "/usr/bin", res)
 }
+
+func TestJXBinaryLocationUsesFirstMatch(t *testing.T) {
+	t.Parallel()
+	commandInterface := mocks.NewMockCommander()
+	When(commandInterface.RunWithoutRetry()).ThenReturn("/home/jx/bin/jx\n/usr/local/bin/jx\n", nil)
+
+	res, err := util.JXBinaryLocation(commandInterface)
+	assert.NoError(t, err)
+	assert.Equal(t, "/home/jx/bin", res)
+}
+
+func TestIsDirOnPath(t *testing.T) {
+	t.Parallel()
+	path := strings.Join([]string{"/usr/bin", "/home/jx/.jx/bin/", ""}, string(os.PathListSeparator))
+	assert.True(t, util.IsDirOnPath("/usr/bin", path))
+	assert.True(t, util.IsDirOnPath("/home/jx/.jx/bin", path))
+	assert.False(t, util.IsDirOnPath("/opt/jx/bin", path))
+}
+
+func TestPathInstructions(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, []string{`echo 'export PATH="$PATH:/opt/jx/bin"' >> ~/.bashrc`, "source ~/.bashrc"},
+		util.PathInstructions("/opt/jx/bin", "linux", "/bin/bash"))
+	assert.Equal(t, []string{`echo 'export PATH="$PATH:/opt/jx/bin"' >> ~/.zshrc`, "source ~/.zshrc"},
+		util.PathInstructions("/opt/jx/bin", "darwin", "/usr/local/bin/zsh"))
+	assert.Equal(t, []string{"set -U fish_user_paths /opt/jx/bin $fish_user_paths"},
+		util.PathInstructions("/opt/jx/bin", "linux", "/usr/bin/fish"))
+	assert.Equal(t, []string{`setx PATH "%PATH%;C:\jx\bin"`},
+		util.PathInstructions(`C:\jx\bin`, "windows", ""))
+}
@@ -6,7 +6,6 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"net/http"
 	"os"
 	"path"
 	"path/filepath"
@@ -14,6 +13,7 @@ import (
 
 	"github.com/blang/semver"
 	"github.com/google/go-github/github"
+	"github.com/jenkins-x/jx/pkg/httpclient"
 	"golang.org/x/oauth2"
 )
 
@@ -29,7 +29,7 @@ func DownloadFile(filepath string, url string) (err error) {
 	defer out.Close()
 
 	// Get the data
-	resp, err := http.Get(url)
+	resp, err := httpclient.NewClient(nil).Get(url)
 	if err != nil {
 		return err
 	}
@@ -60,17 +60,41 @@ func GetLatestVersionFromGitHub(githubOwner, githubRepo string) (semver.Version,
 	return semver.Make(text)
 }
 
+// GetLatestVersionStringFromGitHub returns the version of the latest release of the repository. Releases are looked up
+// on the GitHub Enterprise server whose API is at $GITHUB_API_URL if it is set so that mirrored releases can be used.
+// If $JX_VERSION_MANIFEST is set the version is read from the manifest instead so GitHub is not contacted at all
 func GetLatestVersionStringFromGitHub(githubOwner, githubRepo string) (string, error) {
+	manifest, err := configuredVersionManifest()
+	if err != nil {
+		return "", err
+	}
+	if manifest != nil {
+		return manifest.Version(githubOwner, githubRepo)
+	}
+	return GetLatestReleaseFromGitHubAPI(githubOwner, githubRepo)
+}
+
+// GetLatestReleaseFromGitHubAPI returns the version of the latest release of the repository using the GitHub API
+func GetLatestReleaseFromGitHubAPI(githubOwner, githubRepo string) (string, error) {
 	if githubClient == nil {
 		token := os.Getenv("GH_TOKEN")
-		var tc *http.Client
+		tc := httpclient.NewClient(nil)
 		if len(token) > 0 {
 			ts := oauth2.StaticTokenSource(
 				&oauth2.Token{AccessToken: token},
 			)
-			tc = oauth2.NewClient(oauth2.NoContext, ts)
+			tc = oauth2.NewClient(context.WithValue(context.Background(), oauth2.HTTPClient, tc), ts)
+		}
+		apiURL := os.Getenv("GITHUB_API_URL")
+		if apiURL != "" && apiURL != "https://api.github.com" {
+			client, err := github.NewEnterpriseClient(apiURL, apiURL, tc)
+			if err != nil {
+				return "", fmt.Errorf("invalid $GITHUB_API_URL %s: %s", apiURL, err)
+			}
+			githubClient = client
+		} else {
+			githubClient = github.NewClient(tc)
 		}
-		githubClient = github.NewClient(tc)
 	}
 	client := githubClient
 	var (
@@ -80,14 +104,14 @@ func GetLatestVersionStringFromGitHub(githubOwner, githubRepo string) (string, e
 	)
 	release, resp, err = client.Repositories.GetLatestRelease(context.Background(), githubOwner, githubRepo)
 	if err != nil {
-		return "", fmt.Errorf("Unable to get latest version for github.com/%s/%s %v", githubOwner, githubRepo, err)
+		return "", fmt.Errorf("Unable to get latest version for %s/%s/%s %v", client.BaseURL.Host, githubOwner, githubRepo, err)
 	}
 	defer resp.Body.Close()
 	latestVersionString := release.TagName
 	if latestVersionString != nil {
 		return strings.TrimPrefix(*latestVersionString, "v"), nil
 	}
-	return "", fmt.Errorf("Unable to find the latest version for github.com/%s/%s", githubOwner, githubRepo)
+	return "", fmt.Errorf("Unable to find the latest version for %s/%s/%s", client.BaseURL.Host, githubOwner, githubRepo)
 }
 
 // untargz a tarball to a target, from
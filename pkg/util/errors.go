@@ -0,0 +1,132 @@
+package util
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"os"
+	"os/exec"
+)
+
+// ErrorCategory the category of an error which scripts can use to tell failures apart via the exit code of jx
+type ErrorCategory string
+
+const (
+	// ErrorCategoryUnknown the error could not be categorised
+	ErrorCategoryUnknown ErrorCategory = "Unknown"
+	// ErrorCategoryInvalidInput an argument, option or input file is invalid or missing
+	ErrorCategoryInvalidInput ErrorCategory = "InvalidInput"
+	// ErrorCategoryMissingDependency a binary or other dependency jx needs is not installed
+	ErrorCategoryMissingDependency ErrorCategory = "MissingDependency"
+	// ErrorCategoryPermissionDenied the user is not authenticated or not allowed to perform the operation
+	ErrorCategoryPermissionDenied ErrorCategory = "PermissionDenied"
+	// ErrorCategoryNotFound a resource or file could not be found
+	ErrorCategoryNotFound ErrorCategory = "NotFound"
+	// ErrorCategoryNetwork a server could not be reached
+	ErrorCategoryNetwork ErrorCategory = "Network"
+	// ErrorCategoryTimeout an operation did not complete in time
+	ErrorCategoryTimeout ErrorCategory = "Timeout"
+)
+
+var errorCategoryExitCodes = map[ErrorCategory]int{
+	ErrorCategoryUnknown:           1,
+	ErrorCategoryInvalidInput:      2,
+	ErrorCategoryMissingDependency: 3,
+	ErrorCategoryPermissionDenied:  4,
+	ErrorCategoryNotFound:          5,
+	ErrorCategoryNetwork:           6,
+	ErrorCategoryTimeout:           7,
+}
+
+// ExitCode returns the process exit code of the category
+func (c ErrorCategory) ExitCode() int {
+	code, ok := errorCategoryExitCodes[c]
+	if !ok {
+		return 1
+	}
+	return code
+}
+
+// CategorizedError an error along with its category
+type CategorizedError struct {
+	Category ErrorCategory
+	Err      error
+}
+
+// Error returns the message of the underlying error
+func (e *CategorizedError) Error() string {
+	return e.Err.Error()
+}
+
+// Cause returns the underlying error
+func (e *CategorizedError) Cause() error {
+	return e.Err
+}
+
+// NewCategorizedError returns the error with the given category
+func NewCategorizedError(category ErrorCategory, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &CategorizedError{Category: category, Err: err}
+}
+
+// InvalidInputError returns the error categorised as invalid input
+func InvalidInputError(err error) error {
+	return NewCategorizedError(ErrorCategoryInvalidInput, err)
+}
+
+// MissingDependencyError returns the error categorised as a missing dependency
+func MissingDependencyError(err error) error {
+	return NewCategorizedError(ErrorCategoryMissingDependency, err)
+}
+
+// PermissionDeniedError returns the error categorised as permission denied
+func PermissionDeniedError(err error) error {
+	return NewCategorizedError(ErrorCategoryPermissionDenied, err)
+}
+
+// ErrorCategoryOf returns the category of the error by looking for a CategorizedError or a well known error in the
+// chain of errors it wraps
+func ErrorCategoryOf(err error) ErrorCategory {
+	for err != nil {
+		if category := wellKnownErrorCategory(err); category != "" {
+			return category
+		}
+		cause, ok := err.(interface{ Cause() error })
+		if !ok {
+			break
+		}
+		err = cause.Cause()
+	}
+	return ErrorCategoryUnknown
+}
+
+func wellKnownErrorCategory(err error) ErrorCategory {
+	switch t := err.(type) {
+	case *CategorizedError:
+		return t.Category
+	case *exec.Error:
+		if t.Err == exec.ErrNotFound {
+			return ErrorCategoryMissingDependency
+		}
+	case *url.Error:
+		if t.Timeout() {
+			return ErrorCategoryTimeout
+		}
+		return ErrorCategoryNetwork
+	case *net.OpError, *net.DNSError:
+		return ErrorCategoryNetwork
+	}
+	switch {
+	case err == exec.ErrNotFound:
+		return ErrorCategoryMissingDependency
+	case err == context.DeadlineExceeded:
+		return ErrorCategoryTimeout
+	case os.IsPermission(err):
+		return ErrorCategoryPermissionDenied
+	case os.IsNotExist(err):
+		return ErrorCategoryNotFound
+	}
+	return ""
+}
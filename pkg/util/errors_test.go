@@ -0,0 +1,32 @@
+package util_test
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCategoryOf(t *testing.T) {
+	t.Parallel()
+	tests := map[error]util.ErrorCategory{
+		fmt.Errorf("boom"):                                                              util.ErrorCategoryUnknown,
+		util.MissingOption("name"):                                                      util.ErrorCategoryInvalidInput,
+		errors.Wrap(util.InvalidArgf("foo", "bad"), "failed"):                           util.ErrorCategoryInvalidInput,
+		errors.Wrap(&exec.Error{Err: exec.ErrNotFound}, "failed"):                       util.ErrorCategoryMissingDependency,
+		&url.Error{Op: "Get", URL: "http://foo", Err: fmt.Errorf("connection refused")}: util.ErrorCategoryNetwork,
+		&os.PathError{Op: "open", Path: "foo", Err: os.ErrNotExist}:                     util.ErrorCategoryNotFound,
+		util.PermissionDeniedError(fmt.Errorf("no access")):                             util.ErrorCategoryPermissionDenied,
+	}
+	for err, expected := range tests {
+		assert.Equal(t, expected, util.ErrorCategoryOf(err), "for error %s", err)
+	}
+	assert.Equal(t, 1, util.ErrorCategoryUnknown.ExitCode())
+	assert.Equal(t, 2, util.ErrorCategoryInvalidInput.ExitCode())
+	assert.Equal(t, 3, util.ErrorCategoryMissingDependency.ExitCode())
+}
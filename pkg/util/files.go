@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
 
 	"github.com/pkg/errors"
@@ -93,6 +94,42 @@ func RenameFile(src string, dst string) (err error) {
 	return nil
 }
 
+// ReplaceBinary replaces the executable at dst with the executable at src even if dst is currently running
+func ReplaceBinary(src string, dst string) error {
+	return replaceBinary(src, dst, runtime.GOOS)
+}
+
+func replaceBinary(src string, dst string, goos string) error {
+	// remove the binary moved aside by an earlier replacement now that it is no longer running
+	old := dst + ".old"
+	os.Remove(old)
+
+	exists, err := FileExists(dst)
+	if err != nil {
+		return err
+	}
+	if exists {
+		if goos == "windows" {
+			// a running executable can not be overwritten or removed on Windows but it can be renamed
+			err = os.Rename(dst, old)
+		} else {
+			// unlinking keeps a running executable intact whereas overwriting it fails
+			err = os.Remove(dst)
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to move aside the existing binary %s", dst)
+		}
+	}
+	err = os.Rename(src, dst)
+	if err != nil {
+		err = RenameFile(src, dst)
+		if err != nil {
+			return err
+		}
+	}
+	return os.Chmod(dst, 0755)
+}
+
 // credit https://gist.github.com/r0l1/92462b38df26839a3ca324697c8cba04
 func CopyDir(src string, dst string, force bool) (err error) {
 	src = filepath.Clean(src)
@@ -0,0 +1,43 @@
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplaceBinary(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-replace-binary-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dst := filepath.Join(dir, "jx")
+	src := filepath.Join(dir, "jx.new")
+	require.NoError(t, ioutil.WriteFile(dst, []byte("old"), 0755))
+	require.NoError(t, ioutil.WriteFile(dst+".old", []byte("older"), 0755))
+	require.NoError(t, ioutil.WriteFile(src, []byte("new"), 0644))
+
+	err = util.ReplaceBinary(src, dst)
+	require.NoError(t, err)
+
+	data, err := ioutil.ReadFile(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+	info, err := os.Stat(dst)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0755), info.Mode().Perm())
+	assert.False(t, fileExists(t, src), "the new binary should have been moved")
+	assert.False(t, fileExists(t, dst+".old"), "the binary moved aside by an earlier replacement should have been removed")
+}
+
+func fileExists(t *testing.T, path string) bool {
+	exists, err := util.FileExists(path)
+	require.NoError(t, err)
+	return exists
+}
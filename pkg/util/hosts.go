@@ -0,0 +1,91 @@
+package util
+
+import (
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+const (
+	// HostsBeginMarker the comment which starts the entries of a hosts file which are managed by jx
+	HostsBeginMarker = "# BEGIN jx managed entries"
+	// HostsEndMarker the comment which ends the entries of a hosts file which are managed by jx
+	HostsEndMarker = "# END jx managed entries"
+
+	// legacyHostsMarker the comment older versions of jx added before the line of the service entries
+	legacyHostsMarker = "# jx added service entries"
+)
+
+// wildcardDNSSuffixes the wildcard DNS services whose host names already resolve to the IP address they contain
+var wildcardDNSSuffixes = []string{".nip.io", ".xip.io", ".sslip.io"}
+
+// HostsFromURLs returns the sorted unique host names of the URLs which need a hosts file entry to resolve, ignoring
+// IP addresses and the host names of wildcard DNS services
+func HostsFromURLs(urls []string) []string {
+	answer := []string{}
+	for _, text := range urls {
+		u, err := url.Parse(text)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		host := u.Hostname()
+		if net.ParseIP(host) != nil || StringArrayIndex(answer, host) >= 0 {
+			continue
+		}
+		wildcard := false
+		for _, suffix := range wildcardDNSSuffixes {
+			if strings.HasSuffix(host, suffix) {
+				wildcard = true
+			}
+		}
+		if !wildcard {
+			answer = append(answer, host)
+		}
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// UpdateHostsEntries returns the text of a hosts file with the entries managed by jx replaced by the host names
+// resolving to the given IP address. Entries added by the user outside of the managed section are left untouched
+func UpdateHostsEntries(text string, ip string, hosts []string) string {
+	text = RemoveHostsEntries(text)
+	if len(hosts) == 0 {
+		return text
+	}
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	lines := []string{HostsBeginMarker}
+	for _, host := range hosts {
+		lines = append(lines, ip+" "+host)
+	}
+	lines = append(lines, HostsEndMarker)
+	return text + strings.Join(lines, "\n") + "\n"
+}
+
+// RemoveHostsEntries returns the text of a hosts file without the entries managed by jx
+func RemoveHostsEntries(text string) string {
+	lines := strings.Split(text, "\n")
+	answer := []string{}
+	managed := false
+	legacy := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case trimmed == HostsBeginMarker:
+			managed = true
+		case trimmed == HostsEndMarker:
+			managed = false
+		case trimmed == legacyHostsMarker:
+			legacy = true
+		case legacy:
+			// the line of service entries follows the legacy marker
+			legacy = false
+		case !managed:
+			answer = append(answer, line)
+		}
+	}
+	return strings.Join(answer, "\n")
+}
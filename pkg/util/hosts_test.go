@@ -0,0 +1,40 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHostsFromURLs(t *testing.T) {
+	t.Parallel()
+	hosts := util.HostsFromURLs([]string{
+		"http://jenkins.jx.example.local",
+		"https://myapp.jx-staging.example.local:8443/path",
+		"http://jenkins.jx.example.local/blue",
+		"http://10.0.0.1:8080",
+		"http://jenkins.jx.192.168.99.100.nip.io",
+		"::invalid",
+	})
+	assert.Equal(t, []string{"jenkins.jx.example.local", "myapp.jx-staging.example.local"}, hosts)
+}
+
+func TestUpdateHostsEntries(t *testing.T) {
+	t.Parallel()
+	original := "127.0.0.1 localhost\n"
+	text := util.UpdateHostsEntries(original, "192.168.99.100", []string{"a.example.local", "b.example.local"})
+	assert.Equal(t, "127.0.0.1 localhost\n"+util.HostsBeginMarker+"\n192.168.99.100 a.example.local\n192.168.99.100 b.example.local\n"+util.HostsEndMarker+"\n", text)
+
+	updated := util.UpdateHostsEntries(text+"10.0.0.1 mine\n", "192.168.99.101", []string{"a.example.local"})
+	assert.Equal(t, "127.0.0.1 localhost\n10.0.0.1 mine\n"+util.HostsBeginMarker+"\n192.168.99.101 a.example.local\n"+util.HostsEndMarker+"\n", updated)
+
+	assert.Equal(t, original, util.RemoveHostsEntries(text))
+	assert.Equal(t, original, util.UpdateHostsEntries(text, "192.168.99.100", nil))
+}
+
+func TestRemoveLegacyHostsEntries(t *testing.T) {
+	t.Parallel()
+	text := "127.0.0.1 localhost\n\n# jx added service entries\n192.168.99.100 jenkins.jx.example.local\n"
+	assert.Equal(t, "127.0.0.1 localhost\n\n", util.RemoveHostsEntries(text))
+}
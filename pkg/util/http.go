@@ -0,0 +1,47 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+// NewHTTPClientWithCAFile returns an HTTP client which verifies TLS certificates against the certificate authorities
+// in the PEM file as well as the system roots and the CA bundles trusted by all HTTP clients. A client using the
+// base transport of jx is returned if the file is blank
+func NewHTTPClientWithCAFile(caFile string) (*http.Client, error) {
+	if caFile == "" {
+		return httpclient.NewBaseClient(), nil
+	}
+	data, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CA file %s: %s", caFile, err)
+	}
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	pool.AppendCertsFromPEM(httpclient.CABundle())
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in CA file %s", caFile)
+	}
+	return &http.Client{
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   30 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			TLSClientConfig: &tls.Config{
+				RootCAs: pool,
+			},
+		},
+	}, nil
+}
@@ -19,7 +19,7 @@ func PickValue(message string, defaultValue string, required bool) (string, erro
 	if !required {
 		validator = nil
 	}
-	err := survey.AskOne(prompt, &answer, validator)
+	err := AskOne(prompt, &answer, validator)
 	if err != nil {
 		return "", err
 	}
@@ -32,7 +32,7 @@ func PickPassword(message string) (string, error) {
 		Message: message,
 	}
 	validator := survey.Required
-	err := survey.AskOne(prompt, &answer, validator)
+	err := AskOne(prompt, &answer, validator)
 	if err != nil {
 		return "", err
 	}
@@ -51,7 +51,7 @@ func PickNameWithDefault(names []string, message string, defaultValue string) (s
 			Options: names,
 			Default: defaultValue,
 		}
-		err := survey.AskOne(prompt, &name, nil)
+		err := AskOne(prompt, &name, nil)
 		if err != nil {
 			return "", err
 		}
@@ -71,7 +71,7 @@ func PickRequiredNameWithDefault(names []string, message string, defaultValue st
 			Options: names,
 			Default: defaultValue,
 		}
-		err := survey.AskOne(prompt, &name, survey.Required)
+		err := AskOne(prompt, &name, survey.Required)
 		if err != nil {
 			return "", err
 		}
@@ -94,7 +94,7 @@ func PickNames(names []string, message string) ([]string, error) {
 			Message: message,
 			Options: names,
 		}
-		err := survey.AskOne(prompt, &picked, nil)
+		err := AskOne(prompt, &picked, nil)
 		if err != nil {
 			return picked, err
 		}
@@ -131,7 +131,7 @@ func SelectNames(names []string, message string, selectAll bool) ([]string, erro
 	if selectAll {
 		prompt.Default = names
 	}
-	err := survey.AskOne(prompt, &answer, nil)
+	err := AskOne(prompt, &answer, nil)
 	return answer, err
 }
 
@@ -143,7 +143,7 @@ func Confirm(message string, defaultValue bool, help string) bool {
 		Default: defaultValue,
 		Help:    help,
 	}
-	survey.AskOne(prompt, &answer, nil)
+	AskOne(prompt, &answer, nil)
 	log.Blank()
 	return answer
 }
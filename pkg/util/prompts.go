@@ -0,0 +1,347 @@
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/ghodss/yaml"
+	"gopkg.in/AlecAivazis/survey.v1"
+	"gopkg.in/AlecAivazis/survey.v1/core"
+)
+
+const (
+	// NoInputEnvVar disables all prompts when set to true
+	NoInputEnvVar = "JX_NO_INPUT"
+	// NoColorEnvVar disables colors in prompts and output when set to any value
+	NoColorEnvVar = "NO_COLOR"
+	// AccessibleEnvVar uses plain line based prompts which work with screen readers when set to true
+	AccessibleEnvVar = "JX_ACCESSIBLE"
+	// AnswersFileEnvVar the YAML file of the answers to prompts indexed by the message of the prompt
+	AnswersFileEnvVar = "JX_ANSWERS_FILE"
+)
+
+// PromptSettings how prompts behave
+type PromptSettings struct {
+	// NoInput never prompts. The answers file or the default of a prompt is used instead or an error is returned
+	NoInput bool
+	// NoColor disables colors in prompts and output
+	NoColor bool
+	// Accessible uses plain line based prompts without colors or cursor movement which work with screen readers
+	Accessible bool
+	// Answers the answers to prompts indexed by the message of the prompt which are used rather than prompting
+	Answers map[string]string
+
+	In  io.Reader
+	Out io.Writer
+}
+
+var (
+	promptSettings = PromptSettings{}
+	promptReader   *bufio.Reader
+
+	defaultPromptIcons = []string{core.ErrorIcon, core.HelpIcon, core.MarkedOptionIcon, core.UnmarkedOptionIcon, core.SelectFocusIcon}
+)
+
+// ConfigurePrompts configures how all prompts behave
+func ConfigurePrompts(settings PromptSettings) {
+	if settings.In == nil {
+		settings.In = os.Stdin
+	}
+	if settings.Out == nil {
+		settings.Out = os.Stderr
+	}
+	promptSettings = settings
+	promptReader = bufio.NewReader(settings.In)
+
+	if settings.NoColor || settings.Accessible {
+		core.DisableColor = true
+		color.NoColor = true
+	}
+	icons := defaultPromptIcons
+	if settings.Accessible {
+		icons = []string{"X", "?", "[x]", "[ ]", ">"}
+	}
+	core.ErrorIcon, core.HelpIcon, core.MarkedOptionIcon, core.UnmarkedOptionIcon, core.SelectFocusIcon = icons[0], icons[1], icons[2], icons[3], icons[4]
+}
+
+// PromptSettingsFromEnv returns the prompt settings of the environment variables
+func PromptSettingsFromEnv() (PromptSettings, error) {
+	settings := PromptSettings{
+		NoInput:    os.Getenv(NoInputEnvVar) == "true",
+		NoColor:    os.Getenv(NoColorEnvVar) != "",
+		Accessible: os.Getenv(AccessibleEnvVar) == "true",
+	}
+	fileName := os.Getenv(AnswersFileEnvVar)
+	if fileName != "" {
+		answers, err := LoadPromptAnswers(fileName)
+		if err != nil {
+			return settings, err
+		}
+		settings.Answers = answers
+	}
+	return settings, nil
+}
+
+// LoadPromptAnswers loads the YAML file of the answers to prompts indexed by the message of the prompt
+func LoadPromptAnswers(fileName string) (map[string]string, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the answers file %s: %s", fileName, err)
+	}
+	answers := map[string]string{}
+	err = yaml.Unmarshal(data, &answers)
+	if err != nil {
+		return nil, InvalidInputError(fmt.Errorf("failed to parse the answers file %s: %s", fileName, err))
+	}
+	return answers, nil
+}
+
+// AskOne asks the prompt honouring the prompt settings. It takes the same arguments as survey.AskOne so that all the
+// prompts of jx, however deeply nested, behave the same way
+func AskOne(p survey.Prompt, response interface{}, v survey.Validator, opts ...survey.AskOpt) error {
+	message := promptMessage(p)
+	answer, ok := promptSettings.Answers[message]
+	if ok {
+		return writePromptAnswer(p, response, v, answer)
+	}
+	if promptSettings.NoInput {
+		return writePromptDefault(p, response, v, message)
+	}
+	if promptSettings.Accessible {
+		return askAccessible(p, response, v)
+	}
+	return survey.AskOne(p, response, v, opts...)
+}
+
+// Ask asks the questions honouring the prompt settings. It takes the same arguments as survey.Ask
+func Ask(qs []*survey.Question, response interface{}, opts ...survey.AskOpt) error {
+	if !promptSettings.NoInput && !promptSettings.Accessible && len(promptSettings.Answers) == 0 {
+		return survey.Ask(qs, response, opts...)
+	}
+	for _, q := range qs {
+		var answer interface{}
+		err := AskOne(q.Prompt, &answer, q.Validate, opts...)
+		if err != nil {
+			return err
+		}
+		if q.Transform != nil {
+			answer = q.Transform(answer)
+		}
+		err = core.WriteAnswer(response, q.Name, answer)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func promptMessage(p survey.Prompt) string {
+	switch t := p.(type) {
+	case *survey.Input:
+		return t.Message
+	case *survey.Password:
+		return t.Message
+	case *survey.Confirm:
+		return t.Message
+	case *survey.Select:
+		return t.Message
+	case *survey.MultiSelect:
+		return t.Message
+	case *survey.Editor:
+		return t.Message
+	default:
+		return ""
+	}
+}
+
+// writePromptAnswer converts the text of the answer to the type of answer of the prompt and writes it to the response
+func writePromptAnswer(p survey.Prompt, response interface{}, v survey.Validator, text string) error {
+	var answer interface{} = text
+	switch p.(type) {
+	case *survey.Confirm:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return InvalidInputError(fmt.Errorf("the answer %q to %q is not true or false", text, promptMessage(p)))
+		}
+		answer = b
+	case *survey.MultiSelect:
+		values := []string{}
+		for _, value := range strings.Split(text, ",") {
+			value = strings.TrimSpace(value)
+			if value != "" {
+				values = append(values, value)
+			}
+		}
+		answer = values
+	}
+	if v != nil {
+		err := v(answer)
+		if err != nil {
+			return InvalidInputError(fmt.Errorf("invalid answer %q to %q: %s", text, promptMessage(p), err))
+		}
+	}
+	return core.WriteAnswer(response, "", answer)
+}
+
+// writePromptDefault writes the default of the prompt to the response when input is disabled
+func writePromptDefault(p survey.Prompt, response interface{}, v survey.Validator, message string) error {
+	var answer interface{}
+	switch t := p.(type) {
+	case *survey.Input:
+		answer = t.Default
+	case *survey.Confirm:
+		answer = t.Default
+	case *survey.Select:
+		if t.Default != "" {
+			answer = t.Default
+		}
+	case *survey.MultiSelect:
+		answer = append([]string{}, t.Default...)
+	case *survey.Editor:
+		answer = t.Default
+	}
+	if answer != nil && v != nil && v(answer) != nil {
+		answer = nil
+	}
+	if answer == nil {
+		return InvalidInputError(fmt.Errorf("cannot prompt for %q as input is disabled. Add an answer to the file $%s or pass the value as an option",
+			message, AnswersFileEnvVar))
+	}
+	return core.WriteAnswer(response, "", answer)
+}
+
+// askAccessible asks the prompt with plain lines of text and no cursor movement so that it works with screen readers
+func askAccessible(p survey.Prompt, response interface{}, v survey.Validator) error {
+	out := promptSettings.Out
+	for {
+		var answer interface{}
+		switch t := p.(type) {
+		case *survey.Input:
+			text, err := readAccessibleLine(withDefault(t.Message, t.Default))
+			if err != nil {
+				return err
+			}
+			if text == "" {
+				text = t.Default
+			}
+			answer = text
+		case *survey.Confirm:
+			defaultValue := "n"
+			if t.Default {
+				defaultValue = "y"
+			}
+			text, err := readAccessibleLine(withDefault(t.Message+" (y/n)", defaultValue))
+			if err != nil {
+				return err
+			}
+			if text == "" {
+				text = defaultValue
+			}
+			switch strings.ToLower(text) {
+			case "y", "yes":
+				answer = true
+			case "n", "no":
+				answer = false
+			default:
+				fmt.Fprintln(out, "Please answer y or n")
+				continue
+			}
+		case *survey.Select:
+			index, err := readAccessibleChoice(t.Message, t.Options, t.Default)
+			if err != nil {
+				return err
+			}
+			if index < 0 {
+				continue
+			}
+			answer = t.Options[index]
+		case *survey.MultiSelect:
+			for i, option := range t.Options {
+				fmt.Fprintf(out, "%d) %s\n", i+1, option)
+			}
+			text, err := readAccessibleLine(withDefault(t.Message+" (numbers separated by commas)", strings.Join(t.Default, ",")))
+			if err != nil {
+				return err
+			}
+			values, ok := accessibleChoices(text, t.Options, t.Default)
+			if !ok {
+				fmt.Fprintln(out, "Please enter the numbers of the options separated by commas")
+				continue
+			}
+			answer = values
+		default:
+			// passwords and editors are not read back to the user so the normal prompts are used
+			return survey.AskOne(p, response, v)
+		}
+		if v != nil {
+			err := v(answer)
+			if err != nil {
+				fmt.Fprintf(out, "Sorry, your reply was invalid: %s\n", err)
+				continue
+			}
+		}
+		return core.WriteAnswer(response, "", answer)
+	}
+}
+
+func withDefault(message string, defaultValue string) string {
+	if defaultValue == "" {
+		return message
+	}
+	return fmt.Sprintf("%s [%s]", message, defaultValue)
+}
+
+func readAccessibleLine(message string) (string, error) {
+	fmt.Fprintf(promptSettings.Out, "%s: ", message)
+	line, err := promptReader.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimSpace(line), nil
+}
+
+// readAccessibleChoice returns the index of the chosen option or -1 if the choice was invalid
+func readAccessibleChoice(message string, options []string, defaultValue string) (int, error) {
+	for i, option := range options {
+		fmt.Fprintf(promptSettings.Out, "%d) %s\n", i+1, option)
+	}
+	defaultIndex := StringArrayIndex(options, defaultValue)
+	defaultText := ""
+	if defaultIndex >= 0 {
+		defaultText = strconv.Itoa(defaultIndex + 1)
+	}
+	text, err := readAccessibleLine(withDefault(message+" (enter a number)", defaultText))
+	if err != nil {
+		return -1, err
+	}
+	if text == "" {
+		return defaultIndex, nil
+	}
+	n, err := strconv.Atoi(text)
+	if err != nil || n < 1 || n > len(options) {
+		fmt.Fprintf(promptSettings.Out, "Please enter a number between 1 and %d\n", len(options))
+		return -1, nil
+	}
+	return n - 1, nil
+}
+
+func accessibleChoices(text string, options []string, defaults []string) ([]string, bool) {
+	if text == "" {
+		return append([]string{}, defaults...), true
+	}
+	answer := []string{}
+	for _, field := range strings.Split(text, ",") {
+		n, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || n < 1 || n > len(options) {
+			return nil, false
+		}
+		answer = append(answer, options[n-1])
+	}
+	return answer, true
+}
@@ -0,0 +1,75 @@
+package util_test
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/AlecAivazis/survey.v1"
+)
+
+func TestAskOneNoInput(t *testing.T) {
+	defer util.ConfigurePrompts(util.PromptSettings{})
+	util.ConfigurePrompts(util.PromptSettings{
+		NoInput: true,
+		Answers: map[string]string{"Git user name:": "jenkins-x-bot"},
+	})
+
+	name := ""
+	err := util.AskOne(&survey.Input{Message: "Git user name:"}, &name, survey.Required)
+	require.NoError(t, err)
+	assert.Equal(t, "jenkins-x-bot", name, "the answers file should be used")
+
+	confirm := false
+	err = util.AskOne(&survey.Confirm{Message: "Create the repository?", Default: true}, &confirm, nil)
+	require.NoError(t, err)
+	assert.True(t, confirm, "the default should be used")
+
+	token := ""
+	err = util.AskOne(&survey.Password{Message: "API token:"}, &token, survey.Required)
+	require.Error(t, err)
+	assert.Equal(t, util.ErrorCategoryInvalidInput, util.ErrorCategoryOf(err))
+	assert.Contains(t, err.Error(), "API token:")
+}
+
+func TestAskOneAccessible(t *testing.T) {
+	defer util.ConfigurePrompts(util.PromptSettings{})
+	out := &bytes.Buffer{}
+	util.ConfigurePrompts(util.PromptSettings{
+		Accessible: true,
+		In:         strings.NewReader("maybe\ny\n5\n2\n"),
+		Out:        out,
+	})
+
+	confirm := false
+	err := util.AskOne(&survey.Confirm{Message: "Continue?"}, &confirm, nil)
+	require.NoError(t, err)
+	assert.True(t, confirm)
+
+	provider := ""
+	err = util.AskOne(&survey.Select{Message: "Provider", Options: []string{"aks", "gke", "eks"}}, &provider, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "gke", provider)
+
+	assert.Contains(t, out.String(), "Please answer y or n")
+	assert.Contains(t, out.String(), "2) gke")
+	assert.Contains(t, out.String(), "Please enter a number between 1 and 3")
+}
+
+func TestLoadPromptAnswers(t *testing.T) {
+	t.Parallel()
+	file, err := ioutil.TempFile("", "test-answers-")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	err = ioutil.WriteFile(file.Name(), []byte("\"Git user name:\": jenkins-x-bot\n"), util.DefaultWritePermissions)
+	require.NoError(t, err)
+
+	answers, err := util.LoadPromptAnswers(file.Name())
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"Git user name:": "jenkins-x-bot"}, answers)
+}
@@ -0,0 +1,155 @@
+package util
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	// ShellInitBegin the line which starts the block added to a shell profile file by jx shell init
+	ShellInitBegin = "# >>> jx shell init >>>"
+	// ShellInitEnd the line which ends the block added to a shell profile file by jx shell init
+	ShellInitEnd = "# <<< jx shell init <<<"
+)
+
+// Shells the shells whose environment can be initialised to use jx
+var Shells = []string{"bash", "zsh", "fish", "powershell"}
+
+// ShellAlias an alias defined in the shell for a command
+type ShellAlias struct {
+	Name    string
+	Command string
+}
+
+// DefaultShellAliases the aliases defined by default for the commands used most often with jx
+var DefaultShellAliases = []ShellAlias{
+	{Name: "k", Command: "kubectl"},
+	{Name: "jxc", Command: "jx context"},
+	{Name: "jxe", Command: "jx environment"},
+	{Name: "jxg", Command: "jx get"},
+	{Name: "jxn", Command: "jx namespace"},
+}
+
+// ShellInit the environment a shell is initialised with to use jx
+type ShellInit struct {
+	// BinDir the directory jx installs binaries into which is added to the PATH
+	BinDir string
+	// KubeConfig the kube config file to use or empty to use the default one
+	KubeConfig string
+	// Aliases the aliases to define
+	Aliases []ShellAlias
+}
+
+// DetectShell returns the name of the shell of the user from the path of the shell or the operating system if the
+// shell is unknown
+func DetectShell(shellPath string, goos string) string {
+	name := strings.TrimSuffix(filepath.Base(shellPath), ".exe")
+	switch name {
+	case "pwsh":
+		return "powershell"
+	case "bash", "zsh", "fish", "powershell":
+		return name
+	}
+	if goos == "windows" {
+		return "powershell"
+	}
+	return "bash"
+}
+
+// Script returns the snippet which initialises the environment of the shell. Sourcing it more than once does not
+// add the bin directory to the PATH again
+func (s *ShellInit) Script(shell string) (string, error) {
+	lines := []string{}
+	switch shell {
+	case "bash", "zsh":
+		if s.BinDir != "" {
+			lines = append(lines, fmt.Sprintf(`case ":$PATH:" in *:%s:*) ;; *) export PATH="$PATH":%s ;; esac`, shQuote(s.BinDir), shQuote(s.BinDir)))
+		}
+		if s.KubeConfig != "" {
+			lines = append(lines, fmt.Sprintf("export KUBECONFIG=%s", shQuote(s.KubeConfig)))
+		}
+		for _, alias := range s.Aliases {
+			lines = append(lines, fmt.Sprintf("alias %s=%s", alias.Name, shQuote(alias.Command)))
+		}
+	case "fish":
+		if s.BinDir != "" {
+			lines = append(lines, fmt.Sprintf("contains %s $PATH; or set -gx PATH $PATH %s", fishQuote(s.BinDir), fishQuote(s.BinDir)))
+		}
+		if s.KubeConfig != "" {
+			lines = append(lines, fmt.Sprintf("set -gx KUBECONFIG %s", fishQuote(s.KubeConfig)))
+		}
+		for _, alias := range s.Aliases {
+			lines = append(lines, fmt.Sprintf("alias %s %s", alias.Name, fishQuote(alias.Command)))
+		}
+	case "powershell":
+		if s.BinDir != "" {
+			lines = append(lines, fmt.Sprintf("if (($env:PATH -split [IO.Path]::PathSeparator) -notcontains %s) { $env:PATH += [IO.Path]::PathSeparator + %s }",
+				psQuote(s.BinDir), psQuote(s.BinDir)))
+		}
+		if s.KubeConfig != "" {
+			lines = append(lines, fmt.Sprintf("$env:KUBECONFIG = %s", psQuote(s.KubeConfig)))
+		}
+		for _, alias := range s.Aliases {
+			// aliases can not pass arguments in PowerShell so functions are used instead
+			lines = append(lines, fmt.Sprintf("function %s { %s @args }", alias.Name, alias.Command))
+		}
+	default:
+		return "", InvalidArg(shell, Shells)
+	}
+	return strings.Join(lines, "\n") + "\n", nil
+}
+
+// ShellProfileFile returns the profile file the shell runs on startup relative to the home directory
+func ShellProfileFile(shell string, home string, goos string) (string, error) {
+	switch shell {
+	case "bash":
+		if goos == "darwin" {
+			// terminals on MacOS start login shells which do not read .bashrc
+			return filepath.Join(home, ".bash_profile"), nil
+		}
+		return filepath.Join(home, ".bashrc"), nil
+	case "zsh":
+		return filepath.Join(home, ".zshrc"), nil
+	case "fish":
+		return filepath.Join(home, ".config", "fish", "config.fish"), nil
+	case "powershell":
+		if goos == "windows" {
+			return filepath.Join(home, "Documents", "WindowsPowerShell", "Microsoft.PowerShell_profile.ps1"), nil
+		}
+		return filepath.Join(home, ".config", "powershell", "Microsoft.PowerShell_profile.ps1"), nil
+	}
+	return "", InvalidArg(shell, Shells)
+}
+
+// ReplaceShellInitBlock returns the text of a profile file with the block between ShellInitBegin and ShellInitEnd
+// replaced by the script, or the block appended if there is none yet, so that installing the script again does not
+// add it twice
+func ReplaceShellInitBlock(text string, script string) string {
+	block := ShellInitBegin + "\n" + strings.TrimSuffix(script, "\n") + "\n" + ShellInitEnd + "\n"
+	start := strings.Index(text, ShellInitBegin)
+	if start >= 0 {
+		end := strings.Index(text[start:], ShellInitEnd)
+		if end >= 0 {
+			rest := strings.TrimPrefix(text[start+end+len(ShellInitEnd):], "\n")
+			return text[:start] + block + rest
+		}
+	}
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	return text + block
+}
+
+func shQuote(text string) string {
+	return "'" + strings.Replace(text, "'", `'\''`, -1) + "'"
+}
+
+func fishQuote(text string) string {
+	text = strings.Replace(text, `\`, `\\`, -1)
+	return "'" + strings.Replace(text, "'", `\'`, -1) + "'"
+}
+
+func psQuote(text string) string {
+	return "'" + strings.Replace(text, "'", "''", -1) + "'"
+}
@@ -0,0 +1,81 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShellInitScript(t *testing.T) {
+	t.Parallel()
+	shellInit := &util.ShellInit{
+		BinDir:     "/home/jx/.jx/bin",
+		KubeConfig: "/home/jx/.kube/dev",
+		Aliases:    []util.ShellAlias{{Name: "k", Command: "kubectl"}, {Name: "jxg", Command: "jx get"}},
+	}
+
+	script, err := shellInit.Script("bash")
+	require.NoError(t, err)
+	assert.Equal(t, `case ":$PATH:" in *:'/home/jx/.jx/bin':*) ;; *) export PATH="$PATH":'/home/jx/.jx/bin' ;; esac
+export KUBECONFIG='/home/jx/.kube/dev'
+alias k='kubectl'
+alias jxg='jx get'
+`, script)
+
+	script, err = shellInit.Script("fish")
+	require.NoError(t, err)
+	assert.Equal(t, `contains '/home/jx/.jx/bin' $PATH; or set -gx PATH $PATH '/home/jx/.jx/bin'
+set -gx KUBECONFIG '/home/jx/.kube/dev'
+alias k 'kubectl'
+alias jxg 'jx get'
+`, script)
+
+	shellInit = &util.ShellInit{BinDir: `C:\Users\O'Brien\.jx\bin`, Aliases: []util.ShellAlias{{Name: "jxg", Command: "jx get"}}}
+	script, err = shellInit.Script("powershell")
+	require.NoError(t, err)
+	assert.Equal(t, `if (($env:PATH -split [IO.Path]::PathSeparator) -notcontains 'C:\Users\O''Brien\.jx\bin') { $env:PATH += [IO.Path]::PathSeparator + 'C:\Users\O''Brien\.jx\bin' }
+function jxg { jx get @args }
+`, script)
+
+	_, err = shellInit.Script("tcsh")
+	assert.Error(t, err)
+}
+
+func TestDetectShell(t *testing.T) {
+	t.Parallel()
+	assert.Equal(t, "zsh", util.DetectShell("/bin/zsh", "darwin"))
+	assert.Equal(t, "fish", util.DetectShell("/usr/local/bin/fish", "linux"))
+	assert.Equal(t, "powershell", util.DetectShell("/usr/bin/pwsh", "linux"))
+	assert.Equal(t, "bash", util.DetectShell("", "linux"))
+	assert.Equal(t, "powershell", util.DetectShell("", "windows"))
+}
+
+func TestShellProfileFile(t *testing.T) {
+	t.Parallel()
+	file, err := util.ShellProfileFile("bash", "/home/jx", "linux")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/jx/.bashrc", file)
+	file, err = util.ShellProfileFile("bash", "/Users/jx", "darwin")
+	require.NoError(t, err)
+	assert.Equal(t, "/Users/jx/.bash_profile", file)
+	file, err = util.ShellProfileFile("fish", "/home/jx", "linux")
+	require.NoError(t, err)
+	assert.Equal(t, "/home/jx/.config/fish/config.fish", file)
+	_, err = util.ShellProfileFile("tcsh", "/home/jx", "linux")
+	assert.Error(t, err)
+}
+
+func TestReplaceShellInitBlock(t *testing.T) {
+	t.Parallel()
+	text := util.ReplaceShellInitBlock("export EDITOR=vi", "alias k='kubectl'\n")
+	expected := "export EDITOR=vi\n" + util.ShellInitBegin + "\nalias k='kubectl'\n" + util.ShellInitEnd + "\n"
+	assert.Equal(t, expected, text)
+
+	text = util.ReplaceShellInitBlock(text+"export PAGER=less\n", "alias k='kubectl'\nalias jxg='jx get'\n")
+	expected = "export EDITOR=vi\n" + util.ShellInitBegin + "\nalias k='kubectl'\nalias jxg='jx get'\n" + util.ShellInitEnd + "\nexport PAGER=less\n"
+	assert.Equal(t, expected, text)
+
+	assert.Equal(t, text, util.ReplaceShellInitBlock(text, "alias k='kubectl'\nalias jxg='jx get'\n"), "installing the same script again changes nothing")
+}
@@ -16,11 +16,11 @@ func InvalidOptionError(option string, value string, err error) error {
 
 func InvalidOptionf(option string, value string, message string, a ...interface{}) error {
 	text := fmt.Sprintf(message, a...)
-	return fmt.Errorf("Invalid option: --%s %s\n%s", option, value, text)
+	return InvalidInputError(fmt.Errorf("Invalid option: --%s %s\n%s", option, value, text))
 }
 
 func MissingOption(name string) error {
-	return fmt.Errorf("Missing option: --%s", name)
+	return InvalidInputError(fmt.Errorf("Missing option: --%s", name))
 }
 
 func InvalidOption(name string, value string, values []string) error {
@@ -53,7 +53,7 @@ func InvalidArgError(value string, err error) error {
 
 func InvalidArgf(value string, message string, a ...interface{}) error {
 	text := fmt.Sprintf(message, a...)
-	return fmt.Errorf("Invalid argument: %s\n%s", value, text)
+	return InvalidInputError(fmt.Errorf("Invalid argument: %s\n%s", value, text))
 }
 
 func SuggestionsFor(typedName string, values []string, suggestionsMinimumDistance int, explicitSuggestions ...string) []string {
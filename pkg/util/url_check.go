@@ -0,0 +1,156 @@
+package util
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+// maxCheckedBodySize the maximum number of bytes of a response body which are checked
+const maxCheckedBodySize = 1024 * 1024
+
+// URLCheck checks that a URL returns an expected response
+type URLCheck struct {
+	URL    string
+	Method string
+	// Headers the request headers
+	Headers map[string]string
+	// StatusCodes the expected status codes. Defaults to any 2xx status code
+	StatusCodes []int
+	// BodyContains text which must be contained in the response body
+	BodyContains []string
+	// BodyRegex an optional regular expression which must match the response body
+	BodyRegex *regexp.Regexp
+	// Insecure disables the verification of the TLS certificate chain
+	Insecure bool
+	// RootCAs the certificate authorities used to verify the TLS certificate chain. Defaults to the system roots
+	// and the CA bundles trusted by all HTTP clients
+	RootCAs *x509.CertPool
+	// MinCertValidity the minimum time the TLS certificate of the server must be valid for
+	MinCertValidity time.Duration
+	// RequestTimeout the timeout of each request
+	RequestTimeout time.Duration
+
+	client *http.Client
+}
+
+// URLCheckResult the response of a successful check
+type URLCheckResult struct {
+	StatusCode int
+	// CertExpiry the time the TLS certificate of the server expires if the URL uses TLS
+	CertExpiry *time.Time
+}
+
+// LoadCertPool loads a pool of certificate authorities from a PEM file
+func LoadCertPool(fileName string) (*x509.CertPool, error) {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CA file %s: %s", fileName, err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, fmt.Errorf("no PEM certificates found in CA file %s", fileName)
+	}
+	return pool, nil
+}
+
+// Check requests the URL once and returns an error if the response is not the expected one
+func (c *URLCheck) Check() (*URLCheckResult, error) {
+	method := c.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+	req, err := http.NewRequest(method, c.URL, nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.Headers {
+		req.Header.Set(k, v)
+	}
+	res, err := c.httpClient().Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	result := &URLCheckResult{
+		StatusCode: res.StatusCode,
+	}
+	if res.TLS != nil && len(res.TLS.PeerCertificates) > 0 {
+		expiry := res.TLS.PeerCertificates[0].NotAfter
+		for _, cert := range res.TLS.PeerCertificates {
+			if cert.NotAfter.Before(expiry) {
+				expiry = cert.NotAfter
+			}
+		}
+		result.CertExpiry = &expiry
+		if c.MinCertValidity > 0 && time.Until(expiry) < c.MinCertValidity {
+			return result, fmt.Errorf("the TLS certificate of %s expires at %s which is within %s", c.URL, expiry.Format(time.RFC3339), c.MinCertValidity)
+		}
+	}
+
+	if !c.isExpectedStatus(res.StatusCode) {
+		return result, fmt.Errorf("got status %d from %s", res.StatusCode, c.URL)
+	}
+	if len(c.BodyContains) == 0 && c.BodyRegex == nil {
+		return result, nil
+	}
+	data, err := ioutil.ReadAll(io.LimitReader(res.Body, maxCheckedBodySize))
+	if err != nil {
+		return result, fmt.Errorf("failed to read the response body from %s: %s", c.URL, err)
+	}
+	body := string(data)
+	for _, text := range c.BodyContains {
+		if !strings.Contains(body, text) {
+			return result, fmt.Errorf("the response body from %s does not contain '%s'", c.URL, text)
+		}
+	}
+	if c.BodyRegex != nil && !c.BodyRegex.MatchString(body) {
+		return result, fmt.Errorf("the response body from %s does not match the regular expression '%s'", c.URL, c.BodyRegex.String())
+	}
+	return result, nil
+}
+
+func (c *URLCheck) isExpectedStatus(code int) bool {
+	if len(c.StatusCodes) == 0 {
+		return code >= 200 && code < 300
+	}
+	for _, s := range c.StatusCodes {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *URLCheck) httpClient() *http.Client {
+	if c.client == nil {
+		timeout := c.RequestTimeout
+		if timeout == 0 {
+			timeout = 30 * time.Second
+		}
+		rootCAs := c.RootCAs
+		if rootCAs == nil {
+			rootCAs = httpclient.RootCAs()
+		}
+		c.client = &http.Client{
+			Timeout: timeout,
+			Transport: &http.Transport{
+				Proxy: http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{
+					InsecureSkipVerify: c.Insecure,
+					RootCAs:            rootCAs,
+				},
+			},
+		}
+	}
+	return c.client
+}
@@ -0,0 +1,90 @@
+package util_test
+
+import (
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURLCheck(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			fmt.Fprint(w, `{"status": "UP", "version": "1.2.3"}`)
+		case "/secure":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	check := &util.URLCheck{
+		URL:          server.URL + "/health",
+		BodyContains: []string{"UP"},
+		BodyRegex:    regexp.MustCompile(`"version":\s*"1\.2\.\d+"`),
+	}
+	result, err := check.Check()
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Nil(t, result.CertExpiry)
+
+	check.BodyContains = []string{"DOWN"}
+	_, err = check.Check()
+	assert.Error(t, err)
+
+	check = &util.URLCheck{URL: server.URL + "/missing"}
+	_, err = check.Check()
+	assert.Error(t, err)
+
+	check.StatusCodes = []int{http.StatusNotFound}
+	_, err = check.Check()
+	assert.NoError(t, err)
+
+	check = &util.URLCheck{URL: server.URL + "/secure"}
+	_, err = check.Check()
+	assert.Error(t, err)
+	check.Headers = map[string]string{"Authorization": "Bearer token"}
+	_, err = check.Check()
+	assert.NoError(t, err)
+}
+
+func TestURLCheckTLS(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "OK")
+	}))
+	defer server.Close()
+
+	// the test server certificate is not signed by a trusted authority
+	check := &util.URLCheck{URL: server.URL}
+	_, err := check.Check()
+	assert.Error(t, err)
+
+	check = &util.URLCheck{URL: server.URL, Insecure: true}
+	_, err = check.Check()
+	assert.NoError(t, err)
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	check = &util.URLCheck{URL: server.URL, RootCAs: pool}
+	result, err := check.Check()
+	require.NoError(t, err)
+	require.NotNil(t, result.CertExpiry)
+	assert.Equal(t, server.Certificate().NotAfter, *result.CertExpiry)
+
+	check = &util.URLCheck{URL: server.URL, RootCAs: pool, MinCertValidity: time.Until(server.Certificate().NotAfter) + time.Hour}
+	_, err = check.Check()
+	assert.Error(t, err)
+}
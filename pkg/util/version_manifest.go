@@ -0,0 +1,128 @@
+package util
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/httpclient"
+)
+
+// VersionManifestEnvVar the environment variable of the file or URL of the version manifest which is consulted
+// instead of the GitHub API to find the latest releases when installing offline
+const VersionManifestEnvVar = "JX_VERSION_MANIFEST"
+
+var versionManifest *VersionManifest
+
+// VersionManifest the latest release versions of GitHub repositories exported on a connected machine via
+// 'jx admin export versions' so that air-gapped installs do not need to reach the GitHub API
+type VersionManifest struct {
+	Generated time.Time `json:"generated"`
+	// Releases the version of the latest release of each repository keyed by 'owner/repo'
+	Releases map[string]string `json:"releases"`
+}
+
+// LoadVersionManifest loads the version manifest from a file or an http(s) URL
+func LoadVersionManifest(location string) (*VersionManifest, error) {
+	var data []byte
+	var err error
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		data, err = downloadVersionManifest(location)
+	} else {
+		data, err = ioutil.ReadFile(location)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the version manifest %s: %s", location, err)
+	}
+	manifest := &VersionManifest{}
+	err = json.Unmarshal(data, manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the version manifest %s: %s", location, err)
+	}
+	if manifest.Releases == nil {
+		manifest.Releases = map[string]string{}
+	}
+	return manifest, nil
+}
+
+func downloadVersionManifest(u string) ([]byte, error) {
+	client := httpclient.NewClient(nil)
+	client.Timeout = 30 * time.Second
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status %s", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
+// SaveVersionManifest saves the version manifest as JSON to the file
+func (m *VersionManifest) SaveVersionManifest(fileName string) error {
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, DefaultWritePermissions)
+}
+
+// SetVersion records the latest release version of the repository
+func (m *VersionManifest) SetVersion(owner string, repo string, version string) {
+	if m.Releases == nil {
+		m.Releases = map[string]string{}
+	}
+	m.Releases[owner+"/"+repo] = strings.TrimPrefix(version, "v")
+}
+
+// Version returns the latest release version of the repository
+func (m *VersionManifest) Version(owner string, repo string) (string, error) {
+	version := m.Releases[owner+"/"+repo]
+	if version == "" {
+		return "", fmt.Errorf("the version manifest generated at %s has no release of %s/%s. Please regenerate it via: jx admin export versions --repo %s/%s",
+			m.Generated.Format(time.RFC3339), owner, repo, owner, repo)
+	}
+	return version, nil
+}
+
+// Repositories returns the sorted 'owner/repo' names of the repositories in the manifest
+func (m *VersionManifest) Repositories() []string {
+	answer := []string{}
+	for name := range m.Releases {
+		answer = append(answer, name)
+	}
+	sort.Strings(answer)
+	return answer
+}
+
+// configuredVersionManifest returns the version manifest referenced by $JX_VERSION_MANIFEST or nil if it is not set
+func configuredVersionManifest() (*VersionManifest, error) {
+	location := os.Getenv(VersionManifestEnvVar)
+	if location == "" {
+		return nil, nil
+	}
+	if versionManifest == nil {
+		manifest, err := LoadVersionManifest(location)
+		if err != nil {
+			return nil, err
+		}
+		versionManifest = manifest
+	}
+	return versionManifest, nil
+}
+
+// PinnedVersion returns the version of the repository in the version manifest referenced by $JX_VERSION_MANIFEST or
+// an empty string if no manifest is configured so that the latest version can be used
+func PinnedVersion(owner string, repo string) (string, error) {
+	manifest, err := configuredVersionManifest()
+	if err != nil || manifest == nil {
+		return "", err
+	}
+	return manifest.Version(owner, repo)
+}
@@ -0,0 +1,65 @@
+package util_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionManifest(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-version-manifest-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	manifest := &util.VersionManifest{Generated: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)}
+	manifest.SetVersion("kubernetes", "helm", "v2.11.0")
+	manifest.SetVersion("jenkins-x", "jx", "1.3.400")
+	fileName := filepath.Join(dir, "jx-versions.json")
+	require.NoError(t, manifest.SaveVersionManifest(fileName))
+
+	loaded, err := util.LoadVersionManifest(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"jenkins-x/jx", "kubernetes/helm"}, loaded.Repositories())
+	version, err := loaded.Version("kubernetes", "helm")
+	require.NoError(t, err)
+	assert.Equal(t, "2.11.0", version)
+
+	_, err = loaded.Version("hashicorp", "terraform")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "jx admin export versions --repo hashicorp/terraform")
+
+	_, err = util.LoadVersionManifest(filepath.Join(dir, "missing.json"))
+	assert.Error(t, err)
+}
+
+func TestPinnedVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "test-pinned-version-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	manifest := &util.VersionManifest{Generated: time.Date(2018, 10, 1, 12, 0, 0, 0, time.UTC)}
+	manifest.SetVersion("jenkins-x", "jx", "v1.3.400")
+	fileName := filepath.Join(dir, "jx-versions.json")
+	require.NoError(t, manifest.SaveVersionManifest(fileName))
+
+	defer os.Unsetenv(util.VersionManifestEnvVar)
+	os.Unsetenv(util.VersionManifestEnvVar)
+	version, err := util.PinnedVersion("jenkins-x", "jx")
+	require.NoError(t, err)
+	assert.Equal(t, "", version, "the latest version is used when there is no version manifest")
+
+	os.Setenv(util.VersionManifestEnvVar, fileName)
+	version, err = util.PinnedVersion("jenkins-x", "jx")
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.400", version)
+
+	_, err = util.PinnedVersion("hashicorp", "terraform")
+	assert.Error(t, err, "a repository missing from the version manifest can not be pinned")
+}
@@ -0,0 +1,107 @@
+package util
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+const (
+	// WSLMountRoot the directory under which WSL mounts the drives of Windows
+	WSLMountRoot = "/mnt/"
+)
+
+var (
+	// wslWindowsBinaries the binaries which have to run on the Windows host when using WSL as they drive the
+	// Windows hypervisors
+	wslWindowsBinaries = []string{"minikube"}
+
+	windowsPathRegex = regexp.MustCompile(`^([A-Za-z]):(?:[\\/](.*))?$`)
+	wslPathRegex     = regexp.MustCompile(`^` + WSLMountRoot + `([A-Za-z])(?:/(.*))?$`)
+)
+
+// IsWSL returns true if jx is running inside the Windows Subsystem for Linux
+func IsWSL() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+	if os.Getenv("WSL_DISTRO_NAME") != "" {
+		return true
+	}
+	data, err := ioutil.ReadFile("/proc/sys/kernel/osrelease")
+	if err != nil {
+		return false
+	}
+	return IsWSLKernelRelease(string(data))
+}
+
+// IsWSLKernelRelease returns true if the kernel release is the one of WSL 1 or WSL 2
+func IsWSLKernelRelease(release string) bool {
+	release = strings.ToLower(release)
+	return strings.Contains(release, "microsoft") || strings.Contains(release, "wsl")
+}
+
+// BinaryGOOS returns the operating system whose binary should be installed for the named tool. Inside WSL tools
+// which drive the Windows hypervisors have to be Windows binaries, all the other tools are Linux binaries
+func BinaryGOOS(name string) string {
+	if IsWSL() && StringArrayIndex(wslWindowsBinaries, name) >= 0 {
+		return "windows"
+	}
+	return runtime.GOOS
+}
+
+// WindowsToWSLPath translates a path on a Windows drive such as C:\Users\jx into the path WSL mounts it at such as
+// /mnt/c/Users/jx
+func WindowsToWSLPath(path string) (string, error) {
+	m := windowsPathRegex.FindStringSubmatch(path)
+	if m == nil {
+		return "", fmt.Errorf("%s is not a path on a Windows drive", path)
+	}
+	answer := WSLMountRoot + strings.ToLower(m[1])
+	rest := strings.Trim(strings.Replace(m[2], "\\", "/", -1), "/")
+	if rest != "" {
+		answer += "/" + rest
+	}
+	return answer, nil
+}
+
+// WSLToWindowsPath translates a path under a Windows drive mounted by WSL such as /mnt/c/Users/jx into the Windows
+// path such as C:\Users\jx
+func WSLToWindowsPath(path string) (string, error) {
+	m := wslPathRegex.FindStringSubmatch(path)
+	if m == nil {
+		return "", fmt.Errorf("%s is not on a Windows drive mounted under %s", path, WSLMountRoot)
+	}
+	return strings.ToUpper(m[1]) + ":\\" + strings.Replace(strings.Trim(m[2], "/"), "/", "\\", -1), nil
+}
+
+// WindowsUserProfile returns the WSL path of the profile directory of the Windows user running WSL
+func WindowsUserProfile() (string, error) {
+	cmd := Command{
+		Name: "cmd.exe",
+		Args: []string{"/c", "echo", "%USERPROFILE%"},
+	}
+	out, err := cmd.RunWithoutRetry()
+	if err != nil {
+		return "", err
+	}
+	return WindowsToWSLPath(strings.TrimSpace(out))
+}
+
+// LinkWindowsBinary links the name of a tool in the directory to its Windows executable as WSL only finds Windows
+// executables on the PATH when their .exe extension is given
+func LinkWindowsBinary(dir string, name string, exe string) error {
+	link := filepath.Join(dir, name)
+	if _, err := os.Lstat(link); err == nil {
+		return nil
+	}
+	err := os.MkdirAll(dir, DefaultWritePermissions)
+	if err != nil {
+		return err
+	}
+	return os.Symlink(exe, link)
+}
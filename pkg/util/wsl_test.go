@@ -0,0 +1,56 @@
+package util_test
+
+import (
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsWSLKernelRelease(t *testing.T) {
+	t.Parallel()
+	assert.True(t, util.IsWSLKernelRelease("4.4.0-17763-Microsoft\n"))
+	assert.True(t, util.IsWSLKernelRelease("4.19.104-microsoft-standard"))
+	assert.True(t, util.IsWSLKernelRelease("5.10.16.3-microsoft-standard-WSL2"))
+	assert.False(t, util.IsWSLKernelRelease("4.15.0-1044-gcp"))
+}
+
+func TestWindowsToWSLPath(t *testing.T) {
+	t.Parallel()
+	testData := map[string]string{
+		`C:\Users\jx\.kube\config`: "/mnt/c/Users/jx/.kube/config",
+		`d:/minikube/ca.crt`:       "/mnt/d/minikube/ca.crt",
+		`C:\`:                      "/mnt/c",
+		`C:`:                       "/mnt/c",
+	}
+	for path, expected := range testData {
+		actual, err := util.WindowsToWSLPath(path)
+		require.NoError(t, err, "translating %s", path)
+		assert.Equal(t, expected, actual, "translating %s", path)
+	}
+
+	_, err := util.WindowsToWSLPath("/home/jx/.kube/config")
+	assert.Error(t, err)
+	_, err = util.WindowsToWSLPath(`\\server\share\file`)
+	assert.Error(t, err)
+}
+
+func TestWSLToWindowsPath(t *testing.T) {
+	t.Parallel()
+	testData := map[string]string{
+		"/mnt/c/Users/jx/.kube/config": `C:\Users\jx\.kube\config`,
+		"/mnt/d/":                      `D:\`,
+		"/mnt/c":                       `C:\`,
+	}
+	for path, expected := range testData {
+		actual, err := util.WSLToWindowsPath(path)
+		require.NoError(t, err, "translating %s", path)
+		assert.Equal(t, expected, actual, "translating %s", path)
+	}
+
+	_, err := util.WSLToWindowsPath("/home/jx/.kube/config")
+	assert.Error(t, err)
+	_, err = util.WSLToWindowsPath("/mnt/wsl/docker")
+	assert.Error(t, err)
+}
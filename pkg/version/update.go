@@ -0,0 +1,108 @@
+package version
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/ghodss/yaml"
+)
+
+const (
+	// ChannelStable the release channel of released versions
+	ChannelStable = "stable"
+	// ChannelPrerelease the release channel of release candidates and other pre-release versions
+	ChannelPrerelease = "prerelease"
+	// ChannelDev the release channel of development builds which are never asked to upgrade
+	ChannelDev = "dev"
+
+	releaseURLFormat = "https://github.com/jenkins-x/jx/releases/tag/v%s"
+)
+
+// UpdateCheck the result of the last check for a new jx version which is cached so that the check is rate limited.
+// The time of a failed check is cached as well, keeping the latest version found by an earlier check
+type UpdateCheck struct {
+	CheckedAt     time.Time `json:"checkedAt"`
+	LatestVersion string    `json:"latestVersion"`
+}
+
+// UpdateStatus describes whether a new jx version is available
+type UpdateStatus struct {
+	Current         string `json:"current"`
+	Latest          string `json:"latest"`
+	Channel         string `json:"channel"`
+	UpdateAvailable bool   `json:"updateAvailable"`
+	ChangelogURL    string `json:"changelogURL,omitempty"`
+}
+
+// Channel returns the release channel of the version
+func Channel(v semver.Version) string {
+	for _, pre := range v.Pre {
+		if pre.VersionStr == "dev" {
+			return ChannelDev
+		}
+	}
+	if len(v.Pre) > 0 {
+		return ChannelPrerelease
+	}
+	return ChannelStable
+}
+
+// ChangelogURL returns the URL of the release notes of the version
+func ChangelogURL(v semver.Version) string {
+	return fmt.Sprintf(releaseURLFormat, v.String())
+}
+
+// NewUpdateStatus compares the current version with the latest released version. Development builds never have an
+// update available
+func NewUpdateStatus(current semver.Version, latest semver.Version) UpdateStatus {
+	status := UpdateStatus{
+		Current: current.String(),
+		Latest:  latest.String(),
+		Channel: Channel(current),
+	}
+	if status.Channel != ChannelDev && latest.GT(current) {
+		status.UpdateAvailable = true
+		status.ChangelogURL = ChangelogURL(latest)
+	}
+	return status
+}
+
+// Hint returns the one line upgrade hint of the status
+func (s UpdateStatus) Hint() string {
+	return fmt.Sprintf("A new jx version %s is available (you have %s), upgrade via 'jx upgrade cli' - changes: %s",
+		s.Latest, s.Current, s.ChangelogURL)
+}
+
+// IsStale returns true if the last check, whether it succeeded or not, is older than the interval
+func (c *UpdateCheck) IsStale(now time.Time, interval time.Duration) bool {
+	return !now.Before(c.CheckedAt.Add(interval))
+}
+
+// LoadUpdateCheck loads the cached update check which is empty if the file does not exist
+func LoadUpdateCheck(fileName string) (*UpdateCheck, error) {
+	answer := &UpdateCheck{}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return answer, nil
+		}
+		return answer, err
+	}
+	err = yaml.Unmarshal(data, answer)
+	if err != nil {
+		return &UpdateCheck{}, fmt.Errorf("failed to parse the update check file %s: %s", fileName, err)
+	}
+	return answer, nil
+}
+
+// SaveUpdateCheck saves the update check so that it is cached
+func SaveUpdateCheck(fileName string, check *UpdateCheck) error {
+	data, err := yaml.Marshal(check)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(fileName, data, 0644)
+}
@@ -0,0 +1,66 @@
+package version_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/version"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUpdateStatus(t *testing.T) {
+	t.Parallel()
+	latest := semver.MustParse("1.3.153")
+
+	status := version.NewUpdateStatus(semver.MustParse("1.3.100"), latest)
+	assert.Equal(t, version.UpdateStatus{
+		Current:         "1.3.100",
+		Latest:          "1.3.153",
+		Channel:         version.ChannelStable,
+		UpdateAvailable: true,
+		ChangelogURL:    "https://github.com/jenkins-x/jx/releases/tag/v1.3.153",
+	}, status)
+	assert.Equal(t, "A new jx version 1.3.153 is available (you have 1.3.100), upgrade via 'jx upgrade cli' - changes: https://github.com/jenkins-x/jx/releases/tag/v1.3.153", status.Hint())
+
+	status = version.NewUpdateStatus(semver.MustParse("1.3.153"), latest)
+	assert.False(t, status.UpdateAvailable)
+	assert.Equal(t, "", status.ChangelogURL)
+
+	status = version.NewUpdateStatus(semver.MustParse("1.3.153-rc.1"), latest)
+	assert.Equal(t, version.ChannelPrerelease, status.Channel)
+	assert.True(t, status.UpdateAvailable)
+
+	status = version.NewUpdateStatus(semver.MustParse("1.3.100-dev+7a8285f4"), latest)
+	assert.Equal(t, version.ChannelDev, status.Channel)
+	assert.False(t, status.UpdateAvailable, "development builds are never asked to upgrade")
+}
+
+func TestUpdateCheckCache(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-update-check-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	fileName := filepath.Join(dir, "update-check.yml")
+	now := time.Date(2019, 3, 1, 9, 0, 0, 0, time.UTC)
+
+	check, err := version.LoadUpdateCheck(fileName)
+	require.NoError(t, err)
+	assert.True(t, check.IsStale(now, 24*time.Hour), "a missing cache is stale")
+
+	err = version.SaveUpdateCheck(fileName, &version.UpdateCheck{CheckedAt: now, LatestVersion: "1.3.153"})
+	require.NoError(t, err)
+	check, err = version.LoadUpdateCheck(fileName)
+	require.NoError(t, err)
+	assert.Equal(t, "1.3.153", check.LatestVersion)
+	assert.False(t, check.IsStale(now.Add(23*time.Hour), 24*time.Hour))
+	assert.True(t, check.IsStale(now.Add(24*time.Hour), 24*time.Hour))
+
+	failed := &version.UpdateCheck{CheckedAt: now}
+	assert.False(t, failed.IsStale(now.Add(time.Hour), 24*time.Hour), "a failed check is not retried until the interval passes")
+	assert.True(t, failed.IsStale(now.Add(24*time.Hour), 24*time.Hour))
+}
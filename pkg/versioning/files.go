@@ -0,0 +1,125 @@
+package versioning
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	pomXML      = "pom.xml"
+	makefile    = "Makefile"
+	packageJSON = "package.json"
+	chartYAML   = "Chart.yaml"
+	versionFile = "VERSION"
+)
+
+var (
+	makefileVersionRegex    = regexp.MustCompile(`(?m)^(VERSION\s*[:?]?=\s*)\S*`)
+	chartVersionRegex       = regexp.MustCompile(`(?m)^(version:\s*)\S*`)
+	packageJSONVersionRegex = regexp.MustCompile(`("version"\s*:\s*")[^"]*(")`)
+	pomVersionRegex         = regexp.MustCompile(`(<version>)[^<]*(</version>)`)
+	pomParentRegex          = regexp.MustCompile(`(?s)<parent>.*?</parent>`)
+	pomSectionRegex         = regexp.MustCompile(`<(dependencies|dependencyManagement|build|profiles|modules)>`)
+)
+
+// DetectVersionFiles returns the files in the project directory which contain the project version
+func DetectVersionFiles(dir string) ([]string, error) {
+	answer := []string{}
+	for _, name := range []string{pomXML, makefile, packageJSON} {
+		exists, err := util.FileExists(filepath.Join(dir, name))
+		if err != nil {
+			return answer, err
+		}
+		if exists {
+			answer = append(answer, name)
+		}
+	}
+	charts, err := filepath.Glob(filepath.Join(dir, "charts", "*", chartYAML))
+	if err != nil {
+		return answer, err
+	}
+	sort.Strings(charts)
+	for _, chart := range charts {
+		rel, err := filepath.Rel(dir, chart)
+		if err != nil {
+			return answer, err
+		}
+		answer = append(answer, rel)
+	}
+	return answer, nil
+}
+
+// UpdateVersionFiles updates the version in each of the files relative to the project directory
+func UpdateVersionFiles(dir string, files []string, version string) error {
+	for _, file := range files {
+		err := UpdateVersionFile(filepath.Join(dir, file), version)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UpdateVersionFile updates the version in a pom.xml, Makefile, package.json, Chart.yaml or VERSION file
+func UpdateVersionFile(fileName string, version string) error {
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return err
+	}
+	text, err := SetVersion(filepath.Base(fileName), string(data), version)
+	if err != nil {
+		return fmt.Errorf("failed to update the version of %s: %s", fileName, err)
+	}
+	return ioutil.WriteFile(fileName, []byte(text), util.DefaultWritePermissions)
+}
+
+// SetVersion returns the contents of the file of the given name with the version replaced
+func SetVersion(name string, text string, version string) (string, error) {
+	switch name {
+	case makefile:
+		return replaceFirst(makefileVersionRegex, text, "${1}"+version)
+	case chartYAML:
+		return replaceFirst(chartVersionRegex, text, "${1}"+version)
+	case packageJSON:
+		return replaceFirst(packageJSONVersionRegex, text, "${1}"+version+"${2}")
+	case pomXML:
+		return setPomVersion(text, version)
+	case versionFile:
+		return version, nil
+	default:
+		return text, fmt.Errorf("unsupported version file %s, supported files are %s", name, strings.Join([]string{pomXML, makefile, packageJSON, chartYAML, versionFile}, ", "))
+	}
+}
+
+func replaceFirst(regex *regexp.Regexp, text string, replacement string) (string, error) {
+	loc := regex.FindStringSubmatchIndex(text)
+	if loc == nil {
+		return text, fmt.Errorf("no version found")
+	}
+	result := []byte{}
+	result = regex.ExpandString(result, replacement, text, loc)
+	return text[:loc[0]] + string(result) + text[loc[1]:], nil
+}
+
+// setPomVersion replaces the version of the project rather than the version of its parent or dependencies
+func setPomVersion(text string, version string) (string, error) {
+	start := 0
+	if loc := pomParentRegex.FindStringIndex(text); loc != nil {
+		start = loc[1]
+	}
+	end := len(text)
+	if loc := pomSectionRegex.FindStringIndex(text[start:]); loc != nil {
+		end = start + loc[0]
+	}
+	updated, err := replaceFirst(pomVersionRegex, text[start:end], "${1}"+version+"${2}")
+	if err != nil {
+		return text, err
+	}
+	return text[:start] + updated + text[end:], nil
+}
@@ -0,0 +1,131 @@
+package versioning
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/blang/semver"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+	"gopkg.in/src-d/go-git.v4/plumbing/storer"
+)
+
+// TagPrefix the prefix of the git tags of released versions
+const TagPrefix = "v"
+
+// VersionTag a git tag of a released version
+type VersionTag struct {
+	Name    string
+	Version semver.Version
+	Commit  *object.Commit
+}
+
+// LatestVersionTag returns the tag of the highest released version in the git repository containing the directory
+// or nil if no version has been tagged
+func LatestVersionTag(dir string) (*VersionTag, error) {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	return latestVersionTag(repo)
+}
+
+func latestVersionTag(repo *git.Repository) (*VersionTag, error) {
+	tags, err := repo.Tags()
+	if err != nil {
+		return nil, err
+	}
+	var answer *VersionTag
+	err = tags.ForEach(func(ref *plumbing.Reference) error {
+		name := ref.Name().Short()
+		v, err := semver.Parse(strings.TrimPrefix(name, TagPrefix))
+		if err != nil {
+			// ignore tags which are not versions
+			return nil
+		}
+		if answer != nil && !v.GT(answer.Version) {
+			return nil
+		}
+		commit, err := tagCommit(repo, ref)
+		if err != nil {
+			return fmt.Errorf("failed to resolve the commit of tag %s: %s", name, err)
+		}
+		answer = &VersionTag{
+			Name:    name,
+			Version: v,
+			Commit:  commit,
+		}
+		return nil
+	})
+	return answer, err
+}
+
+// tagCommit returns the commit of a lightweight or annotated tag
+func tagCommit(repo *git.Repository, ref *plumbing.Reference) (*object.Commit, error) {
+	tag, err := repo.TagObject(ref.Hash())
+	if err == nil {
+		return tag.Commit()
+	}
+	if err != plumbing.ErrObjectNotFound {
+		return nil, err
+	}
+	return repo.CommitObject(ref.Hash())
+}
+
+// CommitMessagesSince returns the messages of the commits in the git repository containing the directory which are
+// reachable from HEAD but not from the tag. If the tag is nil the messages of all the commits are returned
+func CommitMessagesSince(dir string, tag *VersionTag) ([]string, error) {
+	repo, err := openRepository(dir)
+	if err != nil {
+		return nil, err
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return nil, fmt.Errorf("failed to find HEAD of the git repository at %s: %s", dir, err)
+	}
+
+	released := map[plumbing.Hash]bool{}
+	if tag != nil {
+		iter, err := repo.Log(&git.LogOptions{From: tag.Commit.Hash})
+		if err != nil {
+			return nil, err
+		}
+		err = iter.ForEach(func(c *object.Commit) error {
+			released[c.Hash] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	answer := []string{}
+	iter, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	err = iter.ForEach(func(c *object.Commit) error {
+		if released[c.Hash] {
+			if len(answer) == 0 && c.Hash == head.Hash() {
+				// HEAD has already been released
+				return storer.ErrStop
+			}
+			return nil
+		}
+		answer = append(answer, c.Message)
+		return nil
+	})
+	return answer, err
+}
+
+func openRepository(dir string) (*git.Repository, error) {
+	if dir == "" {
+		dir = "."
+	}
+	repo, err := git.PlainOpenWithOptions(dir, &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the git repository at %s: %s", dir, err)
+	}
+	return repo, nil
+}
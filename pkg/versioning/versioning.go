@@ -0,0 +1,128 @@
+package versioning
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+// Bump the part of a semantic version which is incremented by a change
+type Bump int
+
+const (
+	// BumpNone the change does not require a new version
+	BumpNone Bump = iota
+	// BumpPatch the change fixes a bug
+	BumpPatch
+	// BumpMinor the change adds a feature
+	BumpMinor
+	// BumpMajor the change breaks backwards compatibility
+	BumpMajor
+)
+
+var (
+	// DefaultMinorTypes the conventional commit types which bump the minor version by default
+	DefaultMinorTypes = []string{"feat"}
+	// DefaultPatchTypes the conventional commit types which bump the patch version by default
+	DefaultPatchTypes = []string{"fix", "perf"}
+
+	commitHeaderRegex   = regexp.MustCompile(`^(\w+)(?:\([^)]*\))?(!)?:\s`)
+	breakingFooterRegex = regexp.MustCompile(`(?m)^BREAKING[ -]CHANGE:`)
+)
+
+// String returns the name of the bump
+func (b Bump) String() string {
+	switch b {
+	case BumpMajor:
+		return "major"
+	case BumpMinor:
+		return "minor"
+	case BumpPatch:
+		return "patch"
+	default:
+		return "none"
+	}
+}
+
+// CommitBump returns the bump required by a conventional commit message
+// see: https://conventionalcommits.org/
+func CommitBump(message string, cfg *config.VersioningConfig) Bump {
+	if breakingFooterRegex.MatchString(message) {
+		return BumpMajor
+	}
+	m := commitHeaderRegex.FindStringSubmatch(strings.TrimSpace(message))
+	if m == nil {
+		return BumpNone
+	}
+	if m[2] == "!" {
+		return BumpMajor
+	}
+	kind := strings.ToLower(m[1])
+	minorTypes := DefaultMinorTypes
+	patchTypes := DefaultPatchTypes
+	if cfg != nil {
+		if len(cfg.MinorTypes) > 0 {
+			minorTypes = cfg.MinorTypes
+		}
+		if len(cfg.PatchTypes) > 0 {
+			patchTypes = cfg.PatchTypes
+		}
+	}
+	if util.StringArrayIndex(minorTypes, kind) >= 0 {
+		return BumpMinor
+	}
+	if util.StringArrayIndex(patchTypes, kind) >= 0 {
+		return BumpPatch
+	}
+	return BumpNone
+}
+
+// CommitsBump returns the largest bump required by the commit messages
+func CommitsBump(messages []string, cfg *config.VersioningConfig) Bump {
+	answer := BumpNone
+	for _, message := range messages {
+		b := CommitBump(message, cfg)
+		if b > answer {
+			answer = b
+		}
+	}
+	return answer
+}
+
+// NextVersion returns the next version after the current version for the commit messages since the current version
+// was released along with the bump which was applied. If no commit requires a new version then the patch version is
+// bumped unless the configuration requires releasable commits in which case the current version is returned
+func NextVersion(current semver.Version, messages []string, cfg *config.VersioningConfig) (semver.Version, Bump) {
+	bump := CommitsBump(messages, cfg)
+	if bump == BumpNone {
+		if cfg != nil && cfg.RequireReleasableCommits {
+			return current, BumpNone
+		}
+		bump = BumpPatch
+	}
+	return Apply(current, bump), bump
+}
+
+// Apply returns the version incremented by the bump
+func Apply(current semver.Version, bump Bump) semver.Version {
+	answer := semver.Version{
+		Major: current.Major,
+		Minor: current.Minor,
+		Patch: current.Patch,
+	}
+	switch bump {
+	case BumpMajor:
+		answer.Major++
+		answer.Minor = 0
+		answer.Patch = 0
+	case BumpMinor:
+		answer.Minor++
+		answer.Patch = 0
+	case BumpPatch:
+		answer.Patch++
+	}
+	return answer
+}
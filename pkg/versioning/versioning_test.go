@@ -0,0 +1,163 @@
+package versioning_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/jenkins-x/jx/pkg/config"
+	"github.com/jenkins-x/jx/pkg/versioning"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/src-d/go-git.v4"
+	"gopkg.in/src-d/go-git.v4/plumbing"
+	"gopkg.in/src-d/go-git.v4/plumbing/object"
+)
+
+func TestCommitBump(t *testing.T) {
+	t.Parallel()
+	testCases := map[string]versioning.Bump{
+		"fix: handle nil pointer":                          versioning.BumpPatch,
+		"perf(cache): avoid copying":                       versioning.BumpPatch,
+		"feat: add a new command":                          versioning.BumpMinor,
+		"Feat(cli): add a flag":                            versioning.BumpMinor,
+		"feat!: remove the old flags":                      versioning.BumpMajor,
+		"refactor(api)!: rename the types":                 versioning.BumpMajor,
+		"fix: change the config\n\nBREAKING CHANGE: moved": versioning.BumpMajor,
+		"docs: update the readme":                          versioning.BumpNone,
+		"Merge pull request #12 from foo/bar":              versioning.BumpNone,
+		"fixed the build":                                  versioning.BumpNone,
+	}
+	for message, expected := range testCases {
+		assert.Equal(t, expected, versioning.CommitBump(message, nil), "message %s", message)
+	}
+
+	cfg := &config.VersioningConfig{MinorTypes: []string{"feature"}, PatchTypes: []string{"chore"}}
+	assert.Equal(t, versioning.BumpMinor, versioning.CommitBump("feature: something", cfg))
+	assert.Equal(t, versioning.BumpPatch, versioning.CommitBump("chore: deps", cfg))
+	assert.Equal(t, versioning.BumpNone, versioning.CommitBump("fix: bug", cfg))
+}
+
+func TestNextVersion(t *testing.T) {
+	t.Parallel()
+	current := semver.MustParse("1.2.3")
+
+	next, bump := versioning.NextVersion(current, []string{"fix: a", "feat: b", "docs: c"}, nil)
+	assert.Equal(t, "1.3.0", next.String())
+	assert.Equal(t, versioning.BumpMinor, bump)
+
+	next, _ = versioning.NextVersion(current, []string{"fix: a", "feat!: b"}, nil)
+	assert.Equal(t, "2.0.0", next.String())
+
+	next, bump = versioning.NextVersion(current, []string{"docs: c"}, nil)
+	assert.Equal(t, "1.2.4", next.String(), "the patch version should be bumped by default")
+	assert.Equal(t, versioning.BumpPatch, bump)
+
+	next, bump = versioning.NextVersion(current, []string{"docs: c"}, &config.VersioningConfig{RequireReleasableCommits: true})
+	assert.Equal(t, "1.2.3", next.String())
+	assert.Equal(t, versioning.BumpNone, bump)
+}
+
+func TestSetVersion(t *testing.T) {
+	t.Parallel()
+	pom := `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <version>2.0.4.RELEASE</version>
+  </parent>
+  <artifactId>demo</artifactId>
+  <version>0.0.1-SNAPSHOT</version>
+  <dependencies>
+    <dependency>
+      <version>1.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`
+	testCases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"Makefile", "NAME := app\nVERSION := 0.0.1-SNAPSHOT\nbuild:\n", "NAME := app\nVERSION := 1.2.0\nbuild:\n"},
+		{"Makefile", "VERSION=1.0\n", "VERSION=1.2.0\n"},
+		{"Chart.yaml", "name: app\nversion: 0.1.0-SNAPSHOT\nappVersion: 1\n", "name: app\nversion: 1.2.0\nappVersion: 1\n"},
+		{"package.json", "{\n  \"name\": \"app\",\n  \"version\": \"0.0.1\",\n  \"dependencies\": {}\n}", "{\n  \"name\": \"app\",\n  \"version\": \"1.2.0\",\n  \"dependencies\": {}\n}"},
+		{"VERSION", "0.0.1\n", "1.2.0"},
+		{"pom.xml", pom, `<project>
+  <parent>
+    <groupId>org.springframework.boot</groupId>
+    <version>2.0.4.RELEASE</version>
+  </parent>
+  <artifactId>demo</artifactId>
+  <version>1.2.0</version>
+  <dependencies>
+    <dependency>
+      <version>1.0.0</version>
+    </dependency>
+  </dependencies>
+</project>
+`},
+	}
+	for _, tc := range testCases {
+		actual, err := versioning.SetVersion(tc.name, tc.input, "1.2.0")
+		require.NoError(t, err, "file %s", tc.name)
+		assert.Equal(t, tc.expected, actual, "file %s", tc.name)
+	}
+
+	_, err := versioning.SetVersion("build.gradle", "version = '1.0'", "1.2.0")
+	assert.Error(t, err)
+	_, err = versioning.SetVersion("Chart.yaml", "name: app\n", "1.2.0")
+	assert.Error(t, err)
+}
+
+func TestCommitMessagesSinceLatestTag(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-versioning")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	repo, err := git.PlainInit(dir, false)
+	require.NoError(t, err)
+	wt, err := repo.Worktree()
+	require.NoError(t, err)
+	commit := func(message string) plumbing.Hash {
+		err := ioutil.WriteFile(filepath.Join(dir, "file.txt"), []byte(message), 0644)
+		require.NoError(t, err)
+		_, err = wt.Add("file.txt")
+		require.NoError(t, err)
+		hash, err := wt.Commit(message, &git.CommitOptions{
+			Author: &object.Signature{Name: "test", Email: "test@example.com", When: time.Now()},
+		})
+		require.NoError(t, err)
+		return hash
+	}
+	tag := func(name string, hash plumbing.Hash) {
+		err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName("refs/tags/"+name), hash))
+		require.NoError(t, err)
+	}
+
+	tag("v0.1.0", commit("feat: initial"))
+	tag("v1.0.0", commit("feat!: first stable release"))
+	tag("not-a-version", commit("fix: a bug"))
+	commit("docs: update the readme")
+
+	latest, err := versioning.LatestVersionTag(dir)
+	require.NoError(t, err)
+	require.NotNil(t, latest)
+	assert.Equal(t, "v1.0.0", latest.Name)
+
+	messages, err := versioning.CommitMessagesSince(dir, latest)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"docs: update the readme", "fix: a bug"}, messages)
+
+	next, _ := versioning.NextVersion(latest.Version, messages, nil)
+	assert.Equal(t, "1.0.1", next.String())
+
+	all, err := versioning.CommitMessagesSince(dir, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 4)
+}
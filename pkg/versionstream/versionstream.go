@@ -0,0 +1,270 @@
+package versionstream
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/ghodss/yaml"
+	"github.com/jenkins-x/jx/pkg/util"
+)
+
+const (
+	// ReleasesDir the directory of the versions repository containing a file per platform release
+	ReleasesDir = "releases"
+
+	// ReleaseFileExtension the extension of the platform release files
+	ReleaseFileExtension = ".yml"
+)
+
+// Release the chart and image versions pinned by a release of the platform
+type Release struct {
+	Version string            `json:"version"`
+	Charts  []ChartVersion    `json:"charts,omitempty"`
+	Images  map[string]string `json:"images,omitempty"`
+}
+
+// ChartVersion a chart installed by a platform release
+type ChartVersion struct {
+	// Name the helm release name of the chart
+	Name      string `json:"name"`
+	Chart     string `json:"chart"`
+	Version   string `json:"version"`
+	Namespace string `json:"namespace,omitempty"`
+	// DependsOn the names of the charts which must be upgraded before this chart
+	DependsOn   []string `json:"dependsOn,omitempty"`
+	PreUpgrade  []Hook   `json:"preUpgrade,omitempty"`
+	PostUpgrade []Hook   `json:"postUpgrade,omitempty"`
+}
+
+// Hook a command run before or after a chart is upgraded
+type Hook struct {
+	Name    string   `json:"name,omitempty"`
+	Command string   `json:"command"`
+	Args    []string `json:"args,omitempty"`
+}
+
+// ChartChange a chart whose version changes between two platform releases
+type ChartChange struct {
+	ChartVersion
+	From string
+}
+
+// ImageChange an image whose version changes between two platform releases
+type ImageChange struct {
+	Image string
+	From  string
+	To    string
+}
+
+// ReleaseDiff the changes required to upgrade the platform from one release to another
+type ReleaseDiff struct {
+	From   string
+	To     string
+	Charts []ChartChange
+	Images []ImageChange
+}
+
+// IsEmpty returns true if there are no changes
+func (d *ReleaseDiff) IsEmpty() bool {
+	return len(d.Charts) == 0 && len(d.Images) == 0
+}
+
+// String returns the command line text of the hook
+func (h *Hook) String() string {
+	return strings.TrimSpace(h.Command + " " + strings.Join(h.Args, " "))
+}
+
+// ReleaseFileName returns the file name of the given platform release in the versions repository
+func ReleaseFileName(dir string, version string) string {
+	return filepath.Join(dir, ReleasesDir, version+ReleaseFileExtension)
+}
+
+// LoadRelease loads and validates the platform release file
+func LoadRelease(fileName string) (*Release, error) {
+	exists, err := util.FileExists(fileName)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("platform release file %s does not exist", fileName)
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load file %s due to %s", fileName, err)
+	}
+	release, err := ParseRelease(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid platform release file %s: %s", fileName, err)
+	}
+	return release, nil
+}
+
+// ParseRelease parses and validates the YAML of a platform release
+func ParseRelease(data []byte) (*Release, error) {
+	release := &Release{}
+	err := yaml.Unmarshal(data, release)
+	if err != nil {
+		return nil, err
+	}
+	err = release.Validate()
+	if err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// Validate validates the charts of the release are complete, unique and only depend on charts in the release
+func (r *Release) Validate() error {
+	names := map[string]bool{}
+	for _, chart := range r.Charts {
+		if chart.Name == "" || chart.Chart == "" || chart.Version == "" {
+			return fmt.Errorf("chart %s requires a name, chart and version", chart.Name)
+		}
+		if names[chart.Name] {
+			return fmt.Errorf("duplicate chart %s", chart.Name)
+		}
+		names[chart.Name] = true
+	}
+	for _, chart := range r.Charts {
+		for _, dep := range chart.DependsOn {
+			if !names[dep] {
+				return fmt.Errorf("chart %s depends on unknown chart %s", chart.Name, dep)
+			}
+		}
+	}
+	_, err := OrderCharts(r.Charts)
+	return err
+}
+
+// String returns the release as YAML
+func (r *Release) String() (string, error) {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the platform release: %s", err)
+	}
+	return string(data), nil
+}
+
+// FindChart returns the chart with the given release name or nil if there is none
+func (r *Release) FindChart(name string) *ChartVersion {
+	for i := range r.Charts {
+		if r.Charts[i].Name == name {
+			return &r.Charts[i]
+		}
+	}
+	return nil
+}
+
+// LatestReleaseVersion returns the highest semantic version of the platform releases in the versions repository
+func LatestReleaseVersion(dir string) (string, error) {
+	releasesDir := filepath.Join(dir, ReleasesDir)
+	files, err := ioutil.ReadDir(releasesDir)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the platform releases in %s: %s", releasesDir, err)
+	}
+	latest := ""
+	var latestVersion semver.Version
+	for _, f := range files {
+		name := f.Name()
+		if f.IsDir() || !strings.HasSuffix(name, ReleaseFileExtension) {
+			continue
+		}
+		text := strings.TrimSuffix(name, ReleaseFileExtension)
+		v, err := semver.ParseTolerant(text)
+		if err != nil {
+			continue
+		}
+		if latest == "" || v.GT(latestVersion) {
+			latest = text
+			latestVersion = v
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no platform releases found in %s", releasesDir)
+	}
+	return latest, nil
+}
+
+// OrderCharts sorts the charts so that every chart comes after the charts it depends on, otherwise keeping
+// the order in which the charts are declared
+func OrderCharts(charts []ChartVersion) ([]ChartVersion, error) {
+	answer := []ChartVersion{}
+	done := map[string]bool{}
+	visiting := map[string]bool{}
+	byName := map[string]ChartVersion{}
+	for _, chart := range charts {
+		byName[chart.Name] = chart
+	}
+	var visit func(chart ChartVersion) error
+	visit = func(chart ChartVersion) error {
+		if done[chart.Name] {
+			return nil
+		}
+		if visiting[chart.Name] {
+			return fmt.Errorf("circular dependency on chart %s", chart.Name)
+		}
+		visiting[chart.Name] = true
+		for _, dep := range chart.DependsOn {
+			if d, ok := byName[dep]; ok {
+				err := visit(d)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		visiting[chart.Name] = false
+		done[chart.Name] = true
+		answer = append(answer, chart)
+		return nil
+	}
+	for _, chart := range charts {
+		err := visit(chart)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return answer, nil
+}
+
+// Diff returns the charts to upgrade, in dependency order, and the images which change when upgrading from
+// the installed release to the target release. The installed release may be nil if nothing is installed
+func Diff(installed *Release, target *Release) (*ReleaseDiff, error) {
+	if installed == nil {
+		installed = &Release{}
+	}
+	diff := &ReleaseDiff{
+		From: installed.Version,
+		To:   target.Version,
+	}
+	charts, err := OrderCharts(target.Charts)
+	if err != nil {
+		return nil, err
+	}
+	for _, chart := range charts {
+		from := ""
+		current := installed.FindChart(chart.Name)
+		if current != nil {
+			from = current.Version
+		}
+		if from != chart.Version {
+			diff.Charts = append(diff.Charts, ChartChange{ChartVersion: chart, From: from})
+		}
+	}
+	images := []string{}
+	for image := range target.Images {
+		images = append(images, image)
+	}
+	sort.Strings(images)
+	for _, image := range images {
+		to := target.Images[image]
+		from := installed.Images[image]
+		if from != to {
+			diff.Images = append(diff.Images, ImageChange{Image: image, From: from, To: to})
+		}
+	}
+	return diff, nil
+}
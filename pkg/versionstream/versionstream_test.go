@@ -0,0 +1,118 @@
+package versionstream_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/jenkins-x/jx/pkg/versionstream"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const releaseYaml = `version: 1.2.0
+charts:
+- name: jenkins-x
+  chart: jenkins-x/jenkins-x-platform
+  version: 0.0.3300
+  dependsOn:
+  - cert-manager
+  postUpgrade:
+  - name: verify
+    command: jx
+    args: [step, verify]
+- name: cert-manager
+  chart: stable/cert-manager
+  version: v0.5.2
+  namespace: cert-manager
+- name: prow
+  chart: jenkins-x/prow
+  version: 0.0.400
+  dependsOn:
+  - jenkins-x
+images:
+  jenkinsx/builder-go: 0.1.200
+  jenkinsx/builder-maven: 0.1.100
+`
+
+func TestLatestReleaseAndLoad(t *testing.T) {
+	t.Parallel()
+	dir, err := ioutil.TempDir("", "test-versionstream-")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	releasesDir := filepath.Join(dir, versionstream.ReleasesDir)
+	require.NoError(t, os.MkdirAll(releasesDir, 0755))
+	for _, version := range []string{"1.0.0", "1.2.0", "1.10.0-rc1", "1.9.3"} {
+		require.NoError(t, ioutil.WriteFile(versionstream.ReleaseFileName(dir, version), []byte(releaseYaml), 0644))
+	}
+	require.NoError(t, ioutil.WriteFile(filepath.Join(releasesDir, "README.md"), []byte("releases"), 0644))
+
+	latest, err := versionstream.LatestReleaseVersion(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "1.10.0-rc1", latest)
+
+	release, err := versionstream.LoadRelease(versionstream.ReleaseFileName(dir, "1.2.0"))
+	require.NoError(t, err)
+	assert.Len(t, release.Charts, 3)
+	assert.Equal(t, "jx step verify", release.FindChart("jenkins-x").PostUpgrade[0].String())
+
+	_, err = versionstream.LoadRelease(versionstream.ReleaseFileName(dir, "2.0.0"))
+	assert.Error(t, err)
+}
+
+func TestReleaseValidation(t *testing.T) {
+	t.Parallel()
+	invalid := []string{
+		"charts:\n- name: a\n  chart: x/a\n",
+		"charts:\n- name: a\n  chart: x/a\n  version: v1\n- name: a\n  chart: x/b\n  version: v1\n",
+		"charts:\n- name: a\n  chart: x/a\n  version: v1\n  dependsOn: [b]\n",
+		"charts:\n- name: a\n  chart: x/a\n  version: v1\n  dependsOn: [b]\n- name: b\n  chart: x/b\n  version: v1\n  dependsOn: [a]\n",
+	}
+	for _, text := range invalid {
+		_, err := versionstream.ParseRelease([]byte(text))
+		assert.Error(t, err, "for release %s", text)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+	target, err := versionstream.ParseRelease([]byte(releaseYaml))
+	require.NoError(t, err)
+
+	installed := &versionstream.Release{
+		Version: "1.1.0",
+		Charts: []versionstream.ChartVersion{
+			{Name: "jenkins-x", Chart: "jenkins-x/jenkins-x-platform", Version: "0.0.3200"},
+			{Name: "prow", Chart: "jenkins-x/prow", Version: "0.0.400"},
+		},
+		Images: map[string]string{
+			"jenkinsx/builder-go":    "0.1.200",
+			"jenkinsx/builder-maven": "0.1.90",
+		},
+	}
+	diff, err := versionstream.Diff(installed, target)
+	require.NoError(t, err)
+	assert.Equal(t, "1.1.0", diff.From)
+	assert.Equal(t, "1.2.0", diff.To)
+	require.Len(t, diff.Charts, 2)
+	assert.Equal(t, "cert-manager", diff.Charts[0].Name)
+	assert.Equal(t, "", diff.Charts[0].From)
+	assert.Equal(t, "jenkins-x", diff.Charts[1].Name)
+	assert.Equal(t, "0.0.3200", diff.Charts[1].From)
+	assert.Equal(t, "0.0.3300", diff.Charts[1].Version)
+	assert.Equal(t, []versionstream.ImageChange{{Image: "jenkinsx/builder-maven", From: "0.1.90", To: "0.1.100"}}, diff.Images)
+
+	diff, err = versionstream.Diff(target, target)
+	require.NoError(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	diff, err = versionstream.Diff(nil, target)
+	require.NoError(t, err)
+	names := []string{}
+	for _, c := range diff.Charts {
+		names = append(names, c.Name)
+	}
+	assert.Equal(t, []string{"cert-manager", "jenkins-x", "prow"}, names)
+}